@@ -0,0 +1,124 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package client is a Go client of the gRPC API exposed by arduino-cli when
+// running in daemon mode. It wraps the generated gRPC client with methods
+// that take a context, fill the instance in the requests and consume the
+// streaming responses, reporting the progress through callbacks.
+//
+//	c, err := client.Dial(ctx, "localhost:50051")
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//	inst, err := c.CreateInstance(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	if _, err := inst.Init(ctx, nil); err != nil {
+//		return err
+//	}
+//	res, err := inst.Compile(ctx, &rpc.CompileRequest{Fqbn: "arduino:avr:uno", SketchPath: "Blink"},
+//		client.WithOutput(os.Stdout, os.Stderr))
+//
+// The methods not wrapped by this package are still available through the
+// generated client returned by Client.Service.
+//
+// A client created with NewEmbedded runs the commands in the calling
+// process, for the tools that link arduino-cli as a library instead of
+// running the daemon.
+//
+// The API of this package and of the gRPC messages follows semantic
+// versioning: it doesn't change in incompatible ways between minor
+// releases. The other Go packages of arduino-cli, including commands, are
+// implementation details and may change at any time.
+package client
+
+import (
+	"context"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client is a client of the arduino-cli daemon.
+type Client struct {
+	conn    *grpc.ClientConn
+	service rpc.ArduinoCoreServiceClient
+	server  *grpc.Server
+}
+
+// Dial connects to the arduino-cli daemon listening at the given address,
+// for example "localhost:50051". The connection is not encrypted unless a
+// different transport is given in the options.
+func Dial(ctx context.Context, address string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, service: rpc.NewArduinoCoreServiceClient(conn)}, nil
+}
+
+// New returns a Client using an already established connection. Closing
+// the Client doesn't close the connection.
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{service: rpc.NewArduinoCoreServiceClient(conn)}
+}
+
+// Close closes the connection opened by Dial, or stops the commands of an
+// embedded client.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	if c.server != nil {
+		c.server.Stop()
+	}
+	return err
+}
+
+// Service returns the generated gRPC client, to call the methods that are
+// not wrapped by this package.
+func (c *Client) Service() rpc.ArduinoCoreServiceClient {
+	return c.service
+}
+
+// Version returns the version of the arduino-cli daemon.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	res, err := c.service.Version(ctx, &rpc.VersionRequest{})
+	if err != nil {
+		return "", err
+	}
+	return res.GetVersion(), nil
+}
+
+// CreateInstance creates a new instance of the daemon. The instance must be
+// initialized with Instance.Init before use.
+func (c *Client) CreateInstance(ctx context.Context) (*Instance, error) {
+	res, err := c.service.Create(ctx, &rpc.CreateRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &Instance{client: c, instance: res.GetInstance()}, nil
+}
+
+// Instance returns an Instance wrapping an instance already created on the
+// daemon.
+func (c *Client) Instance(instance *rpc.Instance) *Instance {
+	return &Instance{client: c, instance: instance}
+}
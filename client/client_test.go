@@ -0,0 +1,178 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeServer struct {
+	rpc.UnimplementedArduinoCoreServiceServer
+}
+
+func (s *fakeServer) Version(context.Context, *rpc.VersionRequest) (*rpc.VersionResponse, error) {
+	return &rpc.VersionResponse{Version: "1.0.0"}, nil
+}
+
+func (s *fakeServer) Create(context.Context, *rpc.CreateRequest) (*rpc.CreateResponse, error) {
+	return &rpc.CreateResponse{Instance: &rpc.Instance{Id: 7}}, nil
+}
+
+func (s *fakeServer) Init(req *rpc.InitRequest, stream rpc.ArduinoCoreService_InitServer) error {
+	_ = stream.Send(&rpc.InitResponse{Message: &rpc.InitResponse_InitProgress{InitProgress: &rpc.InitResponse_Progress{
+		TaskProgress: &rpc.TaskProgress{Name: "Loading", Completed: true},
+	}}})
+	_ = stream.Send(&rpc.InitResponse{Message: &rpc.InitResponse_Error{Error: &status.Status{
+		Code: int32(codes.FailedPrecondition), Message: "index not found",
+	}}})
+	return nil
+}
+
+func (s *fakeServer) Compile(req *rpc.CompileRequest, stream rpc.ArduinoCoreService_CompileServer) error {
+	if req.GetInstance().GetId() != 7 {
+		return io.ErrUnexpectedEOF
+	}
+	_ = stream.Send(&rpc.CompileResponse{Message: &rpc.CompileResponse_Progress{Progress: &rpc.TaskProgress{Percent: 50}}})
+	_ = stream.Send(&rpc.CompileResponse{Message: &rpc.CompileResponse_OutStream{OutStream: []byte("compiling ")}})
+	_ = stream.Send(&rpc.CompileResponse{Message: &rpc.CompileResponse_ErrStream{ErrStream: []byte("warning")}})
+	_ = stream.Send(&rpc.CompileResponse{Message: &rpc.CompileResponse_OutStream{OutStream: []byte(req.GetSketchPath())}})
+	_ = stream.Send(&rpc.CompileResponse{Message: &rpc.CompileResponse_Result{Result: &rpc.BuilderResult{BuildPath: "/tmp/build"}}})
+	return nil
+}
+
+func (s *fakeServer) Monitor(stream rpc.ArduinoCoreService_MonitorServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	_ = stream.Send(&rpc.MonitorResponse{Success: true})
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		if req.GetClose() {
+			return nil
+		}
+		// Echo the data back
+		_ = stream.Send(&rpc.MonitorResponse{RxData: req.GetTxData()})
+	}
+}
+
+func newTestClient(t *testing.T) *Client {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	rpc.RegisterArduinoCoreServiceServer(server, &fakeServer{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return listener.Dial() }
+	c, err := Dial(context.Background(), "bufnet", grpc.WithContextDialer(dialer))
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	version, err := c.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", version)
+
+	inst, err := c.CreateInstance(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(7), inst.RPC().GetId())
+
+	// The loading errors are collected without failing the initialization
+	tasks := []*rpc.TaskProgress{}
+	initRes, err := inst.Init(ctx, nil, WithTaskProgress(func(p *rpc.TaskProgress) { tasks = append(tasks, p) }))
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Len(t, initRes.Errors, 1)
+	require.Contains(t, initRes.Errors[0].Error(), "index not found")
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	tasks = tasks[:0]
+	res, err := inst.Compile(ctx, &rpc.CompileRequest{SketchPath: "Blink"},
+		WithOutput(stdout, stderr),
+		WithTaskProgress(func(p *rpc.TaskProgress) { tasks = append(tasks, p) }))
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/build", res.GetBuildPath())
+	require.Equal(t, "compiling Blink", stdout.String())
+	require.Equal(t, "warning", stderr.String())
+	require.Len(t, tasks, 1)
+
+	// The methods not implemented by the server report the gRPC error
+	_, err = inst.BoardList(ctx, nil)
+	require.Error(t, err)
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+	inst := newTestClient(t).Instance(&rpc.Instance{Id: 1})
+
+	m, err := inst.OpenMonitor(ctx, &rpc.MonitorPortOpenRequest{Port: &rpc.Port{Address: "/dev/ttyACM0", Protocol: "serial"}})
+	require.NoError(t, err)
+
+	_, err = m.Write([]byte("hello"))
+	require.NoError(t, err)
+	buff := make([]byte, 3)
+	n, err := m.Read(buff)
+	require.NoError(t, err)
+	require.Equal(t, "hel", string(buff[:n]))
+	n, err = m.Read(buff)
+	require.NoError(t, err)
+	require.Equal(t, "lo", string(buff[:n]))
+
+	require.NoError(t, m.Close())
+}
+
+func TestEmbedded(t *testing.T) {
+	dataDir := t.TempDir()
+	configuration.Settings = configuration.Init("")
+	configuration.Settings.Set("directories.data", dataDir)
+	configuration.Settings.Set("directories.downloads", filepath.Join(dataDir, "staging"))
+	configuration.Settings.Set("directories.user", filepath.Join(dataDir, "user"))
+	defer func() { configuration.Settings = nil }()
+
+	ctx := context.Background()
+	c, err := NewEmbedded("")
+	require.NoError(t, err)
+	defer c.Close()
+
+	version, err := c.Version(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, version)
+
+	inst, err := c.CreateInstance(ctx)
+	require.NoError(t, err)
+	require.NotZero(t, inst.RPC().GetId())
+	require.NoError(t, inst.Destroy(ctx))
+}
@@ -0,0 +1,59 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package client
+
+import (
+	"context"
+	"net"
+
+	"github.com/arduino/arduino-cli/commands/daemon"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/arduino-cli/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// embeddedBufferSize is the size of the in-memory connection between an
+// embedded client and its server.
+const embeddedBufferSize = 1024 * 1024
+
+// NewEmbedded returns a Client running the commands in the calling process,
+// without a daemon. The configuration is read from the given file, or from
+// the default location if empty, the first time an embedded client is
+// created; it is shared by all the embedded clients and can be changed with
+// the Settings methods of the service.
+func NewEmbedded(configFile string) (*Client, error) {
+	if configuration.Settings == nil {
+		configuration.Settings = configuration.Init(configFile)
+	}
+
+	listener := bufconn.Listen(embeddedBufferSize)
+	server := grpc.NewServer()
+	rpc.RegisterArduinoCoreServiceServer(server, &daemon.ArduinoCoreServerImpl{
+		VersionString: version.VersionInfo.VersionString,
+	})
+	go server.Serve(listener)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return listener.Dial() }
+	c, err := Dial(context.Background(), "embedded", grpc.WithContextDialer(dialer))
+	if err != nil {
+		server.Stop()
+		return nil, err
+	}
+	c.server = server
+	return c, nil
+}
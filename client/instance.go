@@ -0,0 +1,237 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package client
+
+import (
+	"context"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"google.golang.org/grpc/status"
+)
+
+// Instance is an instance of the daemon, holding the installed platforms
+// and libraries. The methods taking a request set its instance, so the
+// caller can leave it empty.
+type Instance struct {
+	client   *Client
+	instance *rpc.Instance
+}
+
+// InitResult is the result of the initialization of an Instance.
+type InitResult struct {
+	// Profile is the sketch profile loaded, if requested.
+	Profile *rpc.SketchProfile
+	// Errors are the failures to load an index, a platform or a library.
+	// They don't prevent the use of the instance.
+	Errors []error
+}
+
+// RPC returns the instance to be used in the requests made with the
+// generated gRPC client.
+func (i *Instance) RPC() *rpc.Instance {
+	return i.instance
+}
+
+// Init loads the installed platforms and libraries, and the ones of the
+// sketch profile if given in the request. A nil request is allowed.
+func (i *Instance) Init(ctx context.Context, req *rpc.InitRequest, opts ...CallOption) (*InitResult, error) {
+	if req == nil {
+		req = &rpc.InitRequest{}
+	}
+	req.Instance = i.instance
+	o := newCallOptions(opts)
+	stream, err := i.client.service.Init(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := &InitResult{}
+	err = receiveAll(stream, func(msg *rpc.InitResponse) error {
+		if st := msg.GetError(); st != nil {
+			res.Errors = append(res.Errors, status.ErrorProto(st))
+		}
+		if progress := msg.GetInitProgress(); progress != nil {
+			o.download(progress.GetDownloadProgress())
+			o.task(progress.GetTaskProgress())
+		}
+		if profile := msg.GetProfile(); profile != nil {
+			res.Profile = profile
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Destroy deletes the instance from the daemon.
+func (i *Instance) Destroy(ctx context.Context) error {
+	_, err := i.client.service.Destroy(ctx, &rpc.DestroyRequest{Instance: i.instance})
+	return err
+}
+
+// UpdateIndex downloads the latest version of the package indexes. A nil
+// request is allowed.
+func (i *Instance) UpdateIndex(ctx context.Context, req *rpc.UpdateIndexRequest, opts ...CallOption) (*rpc.UpdateIndexResponse_Result, error) {
+	if req == nil {
+		req = &rpc.UpdateIndexRequest{}
+	}
+	req.Instance = i.instance
+	o := newCallOptions(opts)
+	stream, err := i.client.service.UpdateIndex(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var res *rpc.UpdateIndexResponse_Result
+	err = receiveAll(stream, func(msg *rpc.UpdateIndexResponse) error {
+		o.download(msg.GetDownloadProgress())
+		if msg.GetResult() != nil {
+			res = msg.GetResult()
+		}
+		return nil
+	})
+	return res, err
+}
+
+// UpdateLibrariesIndex downloads the latest version of the libraries index.
+// A nil request is allowed.
+func (i *Instance) UpdateLibrariesIndex(ctx context.Context, req *rpc.UpdateLibrariesIndexRequest, opts ...CallOption) (*rpc.UpdateLibrariesIndexResponse_Result, error) {
+	if req == nil {
+		req = &rpc.UpdateLibrariesIndexRequest{}
+	}
+	req.Instance = i.instance
+	o := newCallOptions(opts)
+	stream, err := i.client.service.UpdateLibrariesIndex(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var res *rpc.UpdateLibrariesIndexResponse_Result
+	err = receiveAll(stream, func(msg *rpc.UpdateLibrariesIndexResponse) error {
+		o.download(msg.GetDownloadProgress())
+		if msg.GetResult() != nil {
+			res = msg.GetResult()
+		}
+		return nil
+	})
+	return res, err
+}
+
+// PlatformSearch searches the platforms in the package indexes.
+func (i *Instance) PlatformSearch(ctx context.Context, req *rpc.PlatformSearchRequest) ([]*rpc.PlatformSummary, error) {
+	req.Instance = i.instance
+	res, err := i.client.service.PlatformSearch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetSearchOutput(), nil
+}
+
+// PlatformInstall installs a platform and its tools. The instance must be
+// initialized again to use the installed platform.
+func (i *Instance) PlatformInstall(ctx context.Context, req *rpc.PlatformInstallRequest, opts ...CallOption) error {
+	req.Instance = i.instance
+	o := newCallOptions(opts)
+	stream, err := i.client.service.PlatformInstall(ctx, req)
+	if err != nil {
+		return err
+	}
+	return receiveAll(stream, func(msg *rpc.PlatformInstallResponse) error {
+		o.download(msg.GetProgress())
+		o.task(msg.GetTaskProgress())
+		return nil
+	})
+}
+
+// LibrarySearch searches the libraries in the libraries index.
+func (i *Instance) LibrarySearch(ctx context.Context, req *rpc.LibrarySearchRequest) ([]*rpc.SearchedLibrary, error) {
+	req.Instance = i.instance
+	res, err := i.client.service.LibrarySearch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetLibraries(), nil
+}
+
+// LibraryInstall installs a library and, unless disabled in the request,
+// its dependencies. The instance must be initialized again to use the
+// installed library.
+func (i *Instance) LibraryInstall(ctx context.Context, req *rpc.LibraryInstallRequest, opts ...CallOption) error {
+	req.Instance = i.instance
+	o := newCallOptions(opts)
+	stream, err := i.client.service.LibraryInstall(ctx, req)
+	if err != nil {
+		return err
+	}
+	return receiveAll(stream, func(msg *rpc.LibraryInstallResponse) error {
+		o.download(msg.GetProgress())
+		o.task(msg.GetTaskProgress())
+		return nil
+	})
+}
+
+// BoardList returns the boards connected to the computer. A nil request is
+// allowed.
+func (i *Instance) BoardList(ctx context.Context, req *rpc.BoardListRequest) ([]*rpc.DetectedPort, error) {
+	if req == nil {
+		req = &rpc.BoardListRequest{}
+	}
+	req.Instance = i.instance
+	res, err := i.client.service.BoardList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetPorts(), nil
+}
+
+// Compile compiles a sketch. The output of the build tools is written to
+// the writers given with WithOutput.
+func (i *Instance) Compile(ctx context.Context, req *rpc.CompileRequest, opts ...CallOption) (*rpc.BuilderResult, error) {
+	req.Instance = i.instance
+	o := newCallOptions(opts)
+	stream, err := i.client.service.Compile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var res *rpc.BuilderResult
+	err = receiveAll(stream, func(msg *rpc.CompileResponse) error {
+		o.task(msg.GetProgress())
+		if msg.GetResult() != nil {
+			res = msg.GetResult()
+		}
+		return o.output(msg.GetOutStream(), msg.GetErrStream())
+	})
+	return res, err
+}
+
+// Upload uploads a compiled sketch to a board. The output of the upload
+// tools is written to the writers given with WithOutput.
+func (i *Instance) Upload(ctx context.Context, req *rpc.UploadRequest, opts ...CallOption) (*rpc.UploadResult, error) {
+	req.Instance = i.instance
+	o := newCallOptions(opts)
+	stream, err := i.client.service.Upload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var res *rpc.UploadResult
+	err = receiveAll(stream, func(msg *rpc.UploadResponse) error {
+		o.task(msg.GetProgress())
+		if msg.GetResult() != nil {
+			res = msg.GetResult()
+		}
+		return o.output(msg.GetOutStream(), msg.GetErrStream())
+	})
+	return res, err
+}
@@ -0,0 +1,118 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// Monitor is an open connection to the monitor of a port. It implements
+// io.ReadWriteCloser: the data read is received from the port and the data
+// written is sent to the port.
+type Monitor struct {
+	stream        rpc.ArduinoCoreService_MonitorClient
+	buffer        []byte
+	stateChangeCB func(*rpc.MonitorPortStateChange)
+}
+
+// OpenMonitor opens the monitor of the port given in the request, and
+// returns when the port is ready.
+func (i *Instance) OpenMonitor(ctx context.Context, req *rpc.MonitorPortOpenRequest) (*Monitor, error) {
+	req.Instance = i.instance
+	stream, err := i.client.service.Monitor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(&rpc.MonitorRequest{Message: &rpc.MonitorRequest_OpenRequest{OpenRequest: req}}); err != nil {
+		return nil, err
+	}
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if res.GetError() != "" {
+			return nil, errors.New(res.GetError())
+		}
+		if res.GetSuccess() {
+			return &Monitor{stream: stream}, nil
+		}
+	}
+}
+
+// OnStateChange sets the callback called when the port is suspended, for
+// example during an upload on the same port, and when it is resumed.
+func (m *Monitor) OnStateChange(cb func(*rpc.MonitorPortStateChange)) {
+	m.stateChangeCB = cb
+}
+
+// Read reads the data received from the port.
+func (m *Monitor) Read(p []byte) (int, error) {
+	for len(m.buffer) == 0 {
+		res, err := m.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if res.GetError() != "" {
+			return 0, fmt.Errorf("monitor error: %s", res.GetError())
+		}
+		if change := res.GetPortStateChange(); change != nil && m.stateChangeCB != nil {
+			m.stateChangeCB(change)
+		}
+		m.buffer = res.GetRxData()
+	}
+	n := copy(p, m.buffer)
+	m.buffer = m.buffer[n:]
+	return n, nil
+}
+
+// Write sends the data to the port.
+func (m *Monitor) Write(p []byte) (int, error) {
+	if err := m.stream.Send(&rpc.MonitorRequest{Message: &rpc.MonitorRequest_TxData{TxData: p}}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Configure changes the settings of the port.
+func (m *Monitor) Configure(settings ...*rpc.MonitorPortSetting) error {
+	return m.stream.Send(&rpc.MonitorRequest{Message: &rpc.MonitorRequest_UpdatedConfiguration{
+		UpdatedConfiguration: &rpc.MonitorPortConfiguration{Settings: settings},
+	}})
+}
+
+// Close closes the port and waits for the daemon to release it.
+func (m *Monitor) Close() error {
+	if err := m.stream.Send(&rpc.MonitorRequest{Message: &rpc.MonitorRequest_Close{Close: true}}); err != nil {
+		return err
+	}
+	if err := m.stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		if _, err := m.stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package client
+
+import (
+	"errors"
+	"io"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// CallOption sets the callbacks receiving the progress and the output of a
+// streaming call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	downloadCB rpc.DownloadProgressCB
+	taskCB     rpc.TaskProgressCB
+	stdout     io.Writer
+	stderr     io.Writer
+}
+
+// WithDownloadProgress sets the callback receiving the progress of the
+// downloads.
+func WithDownloadProgress(cb rpc.DownloadProgressCB) CallOption {
+	return func(o *callOptions) { o.downloadCB = cb }
+}
+
+// WithTaskProgress sets the callback receiving the progress of the tasks,
+// like the installation of a platform or the compilation of a sketch.
+func WithTaskProgress(cb rpc.TaskProgressCB) CallOption {
+	return func(o *callOptions) { o.taskCB = cb }
+}
+
+// WithOutput sets the writers receiving the output of the tools run by the
+// compilation or the upload. A nil writer discards the output.
+func WithOutput(stdout, stderr io.Writer) CallOption {
+	return func(o *callOptions) { o.stdout, o.stderr = stdout, stderr }
+}
+
+func newCallOptions(opts []CallOption) *callOptions {
+	o := &callOptions{stdout: io.Discard, stderr: io.Discard}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.stdout == nil {
+		o.stdout = io.Discard
+	}
+	if o.stderr == nil {
+		o.stderr = io.Discard
+	}
+	return o
+}
+
+func (o *callOptions) download(progress *rpc.DownloadProgress) {
+	if progress != nil && o.downloadCB != nil {
+		o.downloadCB(progress)
+	}
+}
+
+func (o *callOptions) task(progress *rpc.TaskProgress) {
+	if progress != nil && o.taskCB != nil {
+		o.taskCB(progress)
+	}
+}
+
+func (o *callOptions) output(out, err []byte) error {
+	if len(out) > 0 {
+		if _, werr := o.stdout.Write(out); werr != nil {
+			return werr
+		}
+	}
+	if len(err) > 0 {
+		if _, werr := o.stderr.Write(err); werr != nil {
+			return werr
+		}
+	}
+	return nil
+}
+
+// receiver is a stream of responses of a server streaming call.
+type receiver[T any] interface {
+	Recv() (T, error)
+}
+
+// receiveAll calls handle with each response of the stream until the end of
+// the stream or the first error.
+func receiveAll[T any](stream receiver[T], handle func(T) error) error {
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := handle(res); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// boardCapabilities computes the capabilities of an installed board from the
+// properties of the board and of its platform.
+func boardCapabilities(board *cores.Board) *rpc.BoardCapabilities {
+	hasProperty := func(key string) bool {
+		if board.Properties.ContainsKey(key) {
+			return true
+		}
+		return board.PlatformRelease != nil && board.PlatformRelease.Properties.ContainsKey(key)
+	}
+	return &rpc.BoardCapabilities{
+		Debug: hasProperty("debug.tool") || hasProperty("debug.executable"),
+		// The legacy network_pattern of the upload tools is converted into
+		// the upload.tool.network property while loading the platform.
+		NetworkUpload: board.Properties.ContainsKey("upload.tool.network"),
+		CryptoChip:    board.Properties.ContainsKey("provision.loader"),
+	}
+}
+
+// boardFilter selects the boards matching a rpc.BoardFilter.
+type boardFilter struct {
+	filter    *rpc.BoardFilter
+	connected map[string]bool
+}
+
+// newBoardFilter returns a boardFilter, when the filter is restricted to the
+// connected boards the attached ports are discovered through the List
+// command, so it must be called without holding the package manager.
+func newBoardFilter(instance *rpc.Instance, filter *rpc.BoardFilter) (*boardFilter, error) {
+	f := &boardFilter{filter: filter}
+	if !filter.GetConnectedOnly() {
+		return f, nil
+	}
+	ports, _, err := List(&rpc.BoardListRequest{
+		Instance: instance,
+		Timeout:  filter.GetDiscoveryTimeout(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	f.connected = map[string]bool{}
+	for _, port := range ports {
+		for _, board := range port.GetMatchingBoards() {
+			f.connected[board.GetFqbn()] = true
+		}
+	}
+	return f, nil
+}
+
+// matchPlatform returns true if the boards of the platform may match the filter.
+func (f *boardFilter) matchPlatform(platform *cores.Platform) bool {
+	if arch := f.filter.GetArchitecture(); arch != "" && !strings.EqualFold(platform.Architecture, arch) {
+		return false
+	}
+	if vendor := f.filter.GetVendor(); vendor != "" &&
+		!strings.EqualFold(platform.Package.Name, vendor) &&
+		!strings.EqualFold(platform.Package.Maintainer, vendor) {
+		return false
+	}
+	return true
+}
+
+// requiresInstalledBoards returns true if the filter can be satisfied only by
+// the boards of installed platforms.
+func (f *boardFilter) requiresInstalledBoards() bool {
+	return f.filter.GetHasDebug() || f.filter.GetHasNetworkUpload() || f.filter.GetHasCryptoChip() || f.filter.GetConnectedOnly()
+}
+
+// matchBoard returns true if an installed board matches the filter.
+func (f *boardFilter) matchBoard(fqbn string, capabilities *rpc.BoardCapabilities) bool {
+	if f.filter.GetHasDebug() && !capabilities.GetDebug() {
+		return false
+	}
+	if f.filter.GetHasNetworkUpload() && !capabilities.GetNetworkUpload() {
+		return false
+	}
+	if f.filter.GetHasCryptoChip() && !capabilities.GetCryptoChip() {
+		return false
+	}
+	if f.filter.GetConnectedOnly() && !f.connected[fqbn] {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardCapabilities(t *testing.T) {
+	platformRelease := &cores.PlatformRelease{Properties: properties.NewMap()}
+	newBoard := func(props map[string]string) *cores.Board {
+		return &cores.Board{Properties: properties.NewFromHashmap(props), PlatformRelease: platformRelease}
+	}
+
+	require.Equal(t, &rpc.BoardCapabilities{}, boardCapabilities(newBoard(nil)))
+	require.Equal(t,
+		&rpc.BoardCapabilities{Debug: true, NetworkUpload: true, CryptoChip: true},
+		boardCapabilities(newBoard(map[string]string{
+			"debug.executable":    "{build.path}/{build.project_name}.elf",
+			"upload.tool.network": "arduino_ota",
+			"provision.loader":    "provision.bin",
+		})))
+
+	// The debug tool may be defined in the platform
+	platformRelease.Properties.Set("debug.tool", "gdb")
+	require.Equal(t, &rpc.BoardCapabilities{Debug: true}, boardCapabilities(newBoard(nil)))
+}
+
+func TestBoardFilter(t *testing.T) {
+	pkg := &cores.Package{Name: "adafruit", Maintainer: "Adafruit"}
+	samd := &cores.Platform{Architecture: "samd", Package: pkg}
+	nrf := &cores.Platform{Architecture: "nrf52", Package: pkg}
+
+	f := &boardFilter{filter: &rpc.BoardFilter{Architecture: "samd", Vendor: "Adafruit"}}
+	require.True(t, f.matchPlatform(samd))
+	require.False(t, f.matchPlatform(nrf))
+	require.False(t, f.requiresInstalledBoards())
+	require.True(t, f.matchBoard("adafruit:samd:feather", &rpc.BoardCapabilities{}))
+
+	f = &boardFilter{filter: &rpc.BoardFilter{Vendor: "arduino"}}
+	require.False(t, f.matchPlatform(samd))
+
+	f = &boardFilter{filter: &rpc.BoardFilter{HasDebug: true, HasCryptoChip: true}}
+	require.True(t, f.requiresInstalledBoards())
+	require.True(t, f.matchBoard("adafruit:samd:feather", &rpc.BoardCapabilities{Debug: true, CryptoChip: true}))
+	require.False(t, f.matchBoard("adafruit:samd:feather", &rpc.BoardCapabilities{Debug: true}))
+	require.False(t, f.matchBoard("adafruit:samd:feather", nil))
+
+	f = &boardFilter{
+		filter:    &rpc.BoardFilter{ConnectedOnly: true},
+		connected: map[string]bool{"adafruit:samd:feather": true},
+	}
+	require.True(t, f.matchBoard("adafruit:samd:feather", &rpc.BoardCapabilities{}))
+	require.False(t, f.matchBoard("adafruit:samd:metro", &rpc.BoardCapabilities{}))
+}
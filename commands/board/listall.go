@@ -29,6 +29,11 @@ import (
 
 // ListAll FIXMEDOC
 func ListAll(ctx context.Context, req *rpc.BoardListAllRequest) (*rpc.BoardListAllResponse, error) {
+	filter, err := newBoardFilter(req.GetInstance(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
 	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
 	if err != nil {
 		return nil, err
@@ -45,6 +50,9 @@ func ListAll(ctx context.Context, req *rpc.BoardListAllRequest) (*rpc.BoardListA
 			if installedPlatformRelease == nil {
 				continue
 			}
+			if !filter.matchPlatform(platform) {
+				continue
+			}
 
 			rpcPlatform := &rpc.Platform{
 				Metadata: commands.PlatformToRPCPlatformMetadata(platform),
@@ -69,12 +77,17 @@ func ListAll(ctx context.Context, req *rpc.BoardListAllRequest) (*rpc.BoardListA
 				if !utils.MatchAny(searchArgs, toTest) {
 					continue
 				}
+				capabilities := boardCapabilities(board)
+				if !filter.matchBoard(board.FQBN(), capabilities) {
+					continue
+				}
 
 				list.Boards = append(list.GetBoards(), &rpc.BoardListItem{
-					Name:     board.Name(),
-					Fqbn:     board.FQBN(),
-					IsHidden: board.IsHidden(),
-					Platform: rpcPlatform,
+					Name:         board.Name(),
+					Fqbn:         board.FQBN(),
+					IsHidden:     board.IsHidden(),
+					Platform:     rpcPlatform,
+					Capabilities: capabilities,
 				})
 			}
 		}
@@ -31,6 +31,11 @@ import (
 // installed. Note that platforms that are not installed don't include boards' FQBNs.
 // If no search argument is used all boards are returned.
 func Search(ctx context.Context, req *rpc.BoardSearchRequest) (*rpc.BoardSearchResponse, error) {
+	filter, err := newBoardFilter(req.GetInstance(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
 	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
 	if err != nil {
 		return nil, err
@@ -46,6 +51,9 @@ func Search(ctx context.Context, req *rpc.BoardSearchRequest) (*rpc.BoardSearchR
 			if latestPlatformRelease == nil && installedPlatformRelease == nil {
 				continue
 			}
+			if !filter.matchPlatform(platform) {
+				continue
+			}
 
 			// Platforms that are not installed don't have a list of boards
 			// generated from their boards.txt file so we need two different
@@ -62,6 +70,10 @@ func Search(ctx context.Context, req *rpc.BoardSearchRequest) (*rpc.BoardSearchR
 					if !utils.MatchAny(req.GetSearchArgs(), toTest) {
 						continue
 					}
+					capabilities := boardCapabilities(board)
+					if !filter.matchBoard(board.FQBN(), capabilities) {
+						continue
+					}
 
 					foundBoards = append(foundBoards, &rpc.BoardListItem{
 						Name:     board.Name(),
@@ -71,9 +83,10 @@ func Search(ctx context.Context, req *rpc.BoardSearchRequest) (*rpc.BoardSearchR
 							Metadata: commands.PlatformToRPCPlatformMetadata(platform),
 							Release:  commands.PlatformReleaseToRPC(installedPlatformRelease),
 						},
+						Capabilities: capabilities,
 					})
 				}
-			} else if latestPlatformRelease != nil {
+			} else if latestPlatformRelease != nil && !filter.requiresInstalledBoards() {
 				for _, board := range latestPlatformRelease.BoardsManifest {
 					toTest := append(strings.Split(board.Name, " "), board.Name)
 					if !utils.MatchAny(req.GetSearchArgs(), toTest) {
@@ -0,0 +1,163 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+// SetupPermissions checks if the current user has the permissions needed to
+// access the given port. The detected issues are returned together with the
+// commands needed to fix them, if requested the fixes are applied.
+func SetupPermissions(ctx context.Context, req *rpc.BoardSetupPermissionsRequest) (*rpc.BoardSetupPermissionsResponse, error) {
+	port := req.GetPort()
+	if port.GetAddress() == "" {
+		return nil, &cmderrors.MissingPortAddressError{}
+	}
+	if port.GetProtocol() != "serial" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Permissions can be checked only on serial ports")}
+	}
+
+	res := &rpc.BoardSetupPermissionsResponse{}
+	for _, issue := range checkPortPermissions(port) {
+		if req.GetApplyFixes() {
+			issue.applyFix(ctx)
+		}
+		res.Issues = append(res.Issues, issue.PortPermissionIssue)
+	}
+	return res, nil
+}
+
+// permissionIssue is a detected issue together with the steps that fix it.
+type permissionIssue struct {
+	*rpc.PortPermissionIssue
+	fixes []*fixStep
+}
+
+func newPermissionIssue(id, description string, fixes ...*fixStep) *permissionIssue {
+	issue := &permissionIssue{
+		PortPermissionIssue: &rpc.PortPermissionIssue{Id: id, Description: description},
+		fixes:               fixes,
+	}
+	for _, fix := range fixes {
+		issue.FixCommands = append(issue.FixCommands, fix.command)
+	}
+	return issue
+}
+
+func (issue *permissionIssue) applyFix(ctx context.Context) {
+	if len(issue.fixes) == 0 {
+		return
+	}
+	for _, fix := range issue.fixes {
+		logrus.WithField("issue", issue.GetId()).Infof("Running: %s", fix.command)
+		if err := fix.run(ctx); err != nil {
+			issue.FixError = err.Error()
+			return
+		}
+	}
+	issue.Fixed = true
+}
+
+// fixStep is a step of the fix of an issue: command is the equivalent shell
+// command shown to the user, run applies the step without going through a
+// shell, so that the values coming from the port can't inject commands.
+type fixStep struct {
+	command string
+	run     func(ctx context.Context) error
+}
+
+// commandStep returns a step running the given command line. The commands
+// run through sudo never ask for a password, since there may be no terminal
+// to type it (for example when the fix is requested through the daemon).
+func commandStep(args ...string) *fixStep {
+	command := shellJoin(args)
+	return &fixStep{
+		command: command,
+		run: func(ctx context.Context) error {
+			runArgs := args
+			if len(args) > 0 && args[0] == "sudo" {
+				if !canSudoWithoutPassword(ctx) {
+					return errors.New(tr("The fix requires administrative privileges, run it in a terminal: %s", command))
+				}
+				runArgs = append([]string{"sudo", "-n"}, args[1:]...)
+			}
+			cmd, err := paths.NewProcess(nil, runArgs...)
+			if err != nil {
+				return err
+			}
+			if _, stderr, err := cmd.RunAndCaptureOutput(ctx); err != nil {
+				if msg := strings.TrimSpace(string(stderr)); msg != "" {
+					return errors.New(msg)
+				}
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// sudo returns the given command line prefixed with sudo, if the current
+// user is not already root.
+func sudo(args ...string) []string {
+	if os.Geteuid() == 0 {
+		return args
+	}
+	return append([]string{"sudo"}, args...)
+}
+
+// canSudoWithoutPassword returns true if the current user can run commands
+// with sudo without typing a password.
+var canSudoWithoutPassword = func(ctx context.Context) bool {
+	cmd, err := paths.NewProcess(nil, "sudo", "-n", "true")
+	if err != nil {
+		return false
+	}
+	_, _, err = cmd.RunAndCaptureOutput(ctx)
+	return err == nil
+}
+
+var shellSafeRegexp = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes the argument, if needed, to be pasted in a POSIX shell.
+func shellQuote(arg string) string {
+	if shellSafeRegexp.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func driverMissingIssue(address string) *permissionIssue {
+	return newPermissionIssue("driver_missing",
+		tr("Port %s not found: the board may be disconnected or the driver for the USB-serial converter may be missing.", address))
+}
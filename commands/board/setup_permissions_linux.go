@@ -0,0 +1,200 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	accessRead  = 0x4
+	accessWrite = 0x2
+)
+
+var udevRulesDirs = paths.NewPathList("/etc/udev/rules.d", "/lib/udev/rules.d", "/usr/lib/udev/rules.d")
+
+// udevRulesDir is the folder where the missing udev rules are installed
+var udevRulesDir = paths.New("/etc/udev/rules.d")
+
+// usbIDRegexp matches a normalized USB VID or PID, the values that don't
+// match are never written in a udev rule.
+var usbIDRegexp = regexp.MustCompile(`^[0-9a-f]{4}$`)
+
+// groupNameRegexp matches the group names that can be safely written in a
+// udev rule.
+var groupNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+func checkPortPermissions(port *rpc.Port) []*permissionIssue {
+	address := port.GetAddress()
+	info, err := os.Stat(address)
+	if err != nil {
+		return []*permissionIssue{driverMissingIssue(address)}
+	}
+	if syscall.Access(address, accessRead|accessWrite) == nil {
+		return nil
+	}
+
+	issues := []*permissionIssue{}
+	group := portGroup(info)
+	if issue := checkGroupMembership(group); issue != nil {
+		issues = append(issues, issue)
+	}
+	vid := normalizeUSBID(port.GetProperties()["vid"])
+	pid := normalizeUSBID(port.GetProperties()["pid"])
+	if vid != "" && pid != "" && !hasUdevRuleForVID(vid) {
+		groupName := ""
+		if group != nil {
+			groupName = group.Name
+		}
+		if issue, err := udevRuleIssue(vid, pid, groupName); err != nil {
+			logrus.WithError(err).Warn("Skipping udev rule check")
+		} else {
+			issues = append(issues, issue)
+		}
+	}
+	if len(issues) == 0 && info.Mode()&os.ModeCharDevice != 0 {
+		// Only the access to a device can be granted, never to a generic file
+		if currentUser, err := user.Current(); err == nil {
+			issues = append(issues, newPermissionIssue("access_denied",
+				tr("The current user has no read/write access to %s.", address),
+				commandStep(sudo("setfacl", "-m", "u:"+currentUser.Username+":rw", "--", address)...)))
+		}
+	}
+	return issues
+}
+
+// udevRuleIssue returns the issue of the missing udev rule for the USB device,
+// fixed by a rule giving access to the users of the given group and to the
+// user logged in at the seat.
+func udevRuleIssue(vid, pid, group string) (*permissionIssue, error) {
+	if !usbIDRegexp.MatchString(vid) || !usbIDRegexp.MatchString(pid) {
+		return nil, errors.New(tr("invalid USB ID %[1]s:%[2]s", vid, pid))
+	}
+	rule := fmt.Sprintf(`SUBSYSTEMS=="usb", ATTRS{idVendor}=="%s", ATTRS{idProduct}=="%s", `, vid, pid)
+	if groupNameRegexp.MatchString(group) {
+		rule += fmt.Sprintf(`GROUP="%s", MODE="0660", `, group)
+	}
+	rule += `TAG+="uaccess"`
+	ruleFile := udevRulesDir.Join(fmt.Sprintf("60-arduino-cli-%s-%s.rules", vid, pid))
+	return newPermissionIssue("udev_rule",
+		tr("No udev rule found for the USB device %[1]s:%[2]s.", vid, pid),
+		installFileStep(ruleFile, rule+"\n"),
+		commandStep(sudo("udevadm", "control", "--reload-rules")...),
+		commandStep(sudo("udevadm", "trigger")...),
+	), nil
+}
+
+// installFileStep returns a step writing the file with the given content. If
+// the current user is not root the content is written in a temporary file
+// that is then copied in place with sudo.
+func installFileStep(file *paths.Path, content string) *fixStep {
+	return &fixStep{
+		command: shellJoin([]string{"printf", "%s", content}) + " | " + shellJoin(sudo("tee", file.String())) + " > /dev/null",
+		run: func(ctx context.Context) error {
+			if os.Geteuid() == 0 {
+				return file.WriteFile([]byte(content))
+			}
+			tmp, err := paths.WriteToTempFile([]byte(content), nil, "arduino-cli-")
+			if err != nil {
+				return err
+			}
+			defer tmp.Remove()
+			return commandStep("sudo", "install", "-m", "0644", tmp.String(), file.String()).run(ctx)
+		},
+	}
+}
+
+// portGroup returns the group owning the port, if the members of the group
+// can read and write the port.
+func portGroup(info os.FileInfo) *user.Group {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || info.Mode().Perm()&0060 != 0060 {
+		return nil
+	}
+	group, err := user.LookupGroupId(strconv.FormatUint(uint64(stat.Gid), 10))
+	if err != nil {
+		return nil
+	}
+	return group
+}
+
+// checkGroupMembership returns an issue if the port is accessible by the
+// given owner group and the current user is not a member of that group.
+func checkGroupMembership(group *user.Group) *permissionIssue {
+	if group == nil {
+		return nil
+	}
+	currentUser, err := user.Current()
+	if err != nil {
+		return nil
+	}
+	userGroups, err := currentUser.GroupIds()
+	if err != nil {
+		return nil
+	}
+	if slices.Contains(userGroups, group.Gid) {
+		// The user is a member of the group but the current session has been
+		// started before being added to the group.
+		return newPermissionIssue("relogin_required",
+			tr("User %[1]s is a member of the group %[2]s, log out and log in again to apply the group membership.", currentUser.Username, group.Name))
+	}
+	return newPermissionIssue("group_membership",
+		tr("User %[1]s is not a member of the group %[2]s that owns the port. After adding the user to the group a new login is required.", currentUser.Username, group.Name),
+		commandStep(sudo("usermod", "-a", "-G", group.Name, "--", currentUser.Username)...))
+}
+
+// normalizeUSBID converts an USB VID or PID as reported by the serial
+// discovery (e.g. "0x2341") into the format used in udev rules (e.g. "2341").
+func normalizeUSBID(id string) string {
+	return strings.TrimPrefix(strings.ToLower(id), "0x")
+}
+
+// hasUdevRuleForVID returns true if a udev rule installed in the system
+// refers to the given USB VID.
+func hasUdevRuleForVID(vid string) bool {
+	for _, dir := range udevRulesDirs {
+		files, err := dir.ReadDir()
+		if err != nil {
+			continue
+		}
+		files.FilterSuffix(".rules")
+		for _, file := range files {
+			data, err := file.ReadFile()
+			if err != nil {
+				continue
+			}
+			for _, line := range strings.Split(strings.ToLower(string(data)), "\n") {
+				if strings.Contains(line, "idvendor") && strings.Contains(line, `"`+vid+`"`) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUdevRuleIssue(t *testing.T) {
+	udevRulesDir = paths.New(t.TempDir())
+	defer func() { udevRulesDir = paths.New("/etc/udev/rules.d") }()
+
+	issue, err := udevRuleIssue("2341", "0043", "dialout")
+	require.NoError(t, err)
+	require.Equal(t, "udev_rule", issue.GetId())
+	require.Len(t, issue.GetFixCommands(), 3)
+	ruleFile := udevRulesDir.Join("60-arduino-cli-2341-0043.rules")
+	rule := `SUBSYSTEMS=="usb", ATTRS{idVendor}=="2341", ATTRS{idProduct}=="0043", GROUP="dialout", MODE="0660", TAG+="uaccess"`
+	require.Contains(t, issue.GetFixCommands()[0], "'"+rule+"\n'")
+	require.NotContains(t, issue.GetFixCommands()[0], "0666")
+
+	if os.Geteuid() == 0 {
+		// Without root the rule is installed with sudo
+		require.NoError(t, issue.fixes[0].run(context.Background()))
+		data, err := ruleFile.ReadFile()
+		require.NoError(t, err)
+		require.Equal(t, rule+"\n", string(data))
+	}
+
+	issue, err = udevRuleIssue("2341", "0043", `bad"group`)
+	require.NoError(t, err)
+	require.Contains(t, issue.GetFixCommands()[0], `ATTRS{idProduct}=="0043", TAG+="uaccess"`)
+
+	for _, id := range []string{"", "234", "23411", "'; rm -rf / #", "23;1", "ABCD"} {
+		_, err := udevRuleIssue(id, "0043", "dialout")
+		require.Error(t, err, id)
+		_, err = udevRuleIssue("2341", id, "dialout")
+		require.Error(t, err, id)
+	}
+	require.Equal(t, "2341", normalizeUSBID("0x2341"))
+	require.Equal(t, "abcd", normalizeUSBID("0xABCD"))
+}
+
+func TestCheckPortPermissionsOnFiles(t *testing.T) {
+	// A regular file is never made accessible
+	file := paths.New(t.TempDir()).Join("file")
+	require.NoError(t, file.WriteFile(nil))
+	require.NoError(t, file.Chmod(0))
+	for _, issue := range checkPortPermissions(&rpc.Port{Address: file.String()}) {
+		require.Empty(t, issue.fixes, issue.GetId())
+	}
+}
+
+func TestApplyFix(t *testing.T) {
+	issue := newPermissionIssue("test", "", commandStep("true"), commandStep("sh", "-c", "echo failed >&2; exit 1"), commandStep("true"))
+	require.Equal(t, []string{"true", "sh -c 'echo failed >&2; exit 1'", "true"}, issue.GetFixCommands())
+	issue.applyFix(context.Background())
+	require.False(t, issue.GetFixed())
+	require.Equal(t, "failed", issue.GetFixError())
+
+	issue = newPermissionIssue("test", "", commandStep("true"))
+	issue.applyFix(context.Background())
+	require.True(t, issue.GetFixed())
+
+	// Without a terminal sudo can't ask the password, the fix must not be tried
+	defer func(f func(context.Context) bool) { canSudoWithoutPassword = f }(canSudoWithoutPassword)
+	canSudoWithoutPassword = func(context.Context) bool { return false }
+	issue = newPermissionIssue("test", "", commandStep("sudo", "udevadm", "trigger"))
+	issue.applyFix(context.Background())
+	require.False(t, issue.GetFixed())
+	require.Equal(t, "The fix requires administrative privileges, run it in a terminal: sudo udevadm trigger", issue.GetFixError())
+}
@@ -0,0 +1,38 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+//go:build !linux
+
+package board
+
+import (
+	"os"
+	"strings"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+func checkPortPermissions(port *rpc.Port) []*permissionIssue {
+	// On Windows and macOS serial ports are accessible by any user, the only
+	// issue that may arise is a missing driver that prevents the port from
+	// being created.
+	address := port.GetAddress()
+	if strings.HasPrefix(address, "/dev/") {
+		if _, err := os.Stat(address); err != nil {
+			return []*permissionIssue{driverMissingIssue(address)}
+		}
+	}
+	return nil
+}
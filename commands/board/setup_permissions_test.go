@@ -0,0 +1,46 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellJoin(t *testing.T) {
+	require.Equal(t, "usermod -a -G dialout -- user", shellJoin([]string{"usermod", "-a", "-G", "dialout", "--", "user"}))
+	require.Equal(t, `printf %s 'a "b" $c' ''`, shellJoin([]string{"printf", "%s", `a "b" $c`, ""}))
+	require.Equal(t, `echo 'it'\''s; rm -rf /'`, shellJoin([]string{"echo", "it's; rm -rf /"}))
+}
+
+func TestSetupPermissions(t *testing.T) {
+	_, err := SetupPermissions(context.Background(), &rpc.BoardSetupPermissionsRequest{Port: &rpc.Port{Protocol: "serial"}})
+	require.Error(t, err)
+	_, err = SetupPermissions(context.Background(), &rpc.BoardSetupPermissionsRequest{Port: &rpc.Port{Address: "1.2.3.4", Protocol: "network"}})
+	require.Error(t, err)
+
+	res, err := SetupPermissions(context.Background(), &rpc.BoardSetupPermissionsRequest{
+		Port:       &rpc.Port{Address: "/dev/ttyNonExistent", Protocol: "serial"},
+		ApplyFixes: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.GetIssues(), 1)
+	require.Equal(t, "driver_missing", res.GetIssues()[0].GetId())
+	require.False(t, res.GetIssues()[0].GetFixed())
+}
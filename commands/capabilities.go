@@ -0,0 +1,87 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"context"
+	"sort"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// APIVersion is the version of the gRPC API implemented by the daemon.
+const APIVersion = "v1"
+
+// capability is a feature that may be queried by the clients to adapt
+// their behaviour to the daemon in use.
+type capability struct {
+	name    string
+	version int32
+	enabled func() bool
+}
+
+// capabilities lists the features supported by the daemon. The version of a
+// capability must be increased every time its behaviour changes, so clients
+// may gracefully degrade when talking to an older daemon.
+var capabilities = []capability{
+	{name: "sketch_profiles", version: 1},
+	{name: "memory_read_write", version: 1},
+	{name: "memory_regions", version: 1},
+	{name: "board_recover", version: 1},
+	{name: "board_certificates", version: 1},
+	{name: "board_provision", version: 1},
+	{name: "build_secrets", version: 1},
+	{name: "instance_events", version: 1},
+	{name: "monitor_tcp", version: 1},
+	{name: "rescan_libraries", version: 1},
+	{name: "upload_progress", version: 1},
+	{name: "watch_directories", version: 1, enabled: func() bool {
+		return configuration.Settings.GetBool("daemon.watch_directories")
+	}},
+	{name: "ble", version: 1, enabled: func() bool {
+		return configuration.Settings.GetBool("ble.enabled")
+	}},
+	{name: "loopback", version: 1, enabled: func() bool {
+		return configuration.Settings.GetBool("loopback.enabled")
+	}},
+}
+
+// GetCapabilities returns the API version and the features supported by the daemon.
+func GetCapabilities(ctx context.Context, req *rpc.GetCapabilitiesRequest, version string) (*rpc.GetCapabilitiesResponse, error) {
+	methods := []string{}
+	for _, m := range rpc.ArduinoCoreService_ServiceDesc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range rpc.ArduinoCoreService_ServiceDesc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+	sort.Strings(methods)
+
+	res := &rpc.GetCapabilitiesResponse{
+		Version:    version,
+		ApiVersion: APIVersion,
+		Methods:    methods,
+	}
+	for _, c := range capabilities {
+		res.Capabilities = append(res.Capabilities, &rpc.Capability{
+			Name:    c.name,
+			Version: c.version,
+			Enabled: c.enabled == nil || c.enabled(),
+		})
+	}
+	return res, nil
+}
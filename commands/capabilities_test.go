@@ -0,0 +1,44 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	configuration.Settings = configuration.Init("")
+	res, err := GetCapabilities(context.Background(), &rpc.GetCapabilitiesRequest{}, "1.2.3")
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", res.GetVersion())
+	require.Equal(t, "v1", res.GetApiVersion())
+	require.Contains(t, res.GetMethods(), "GetCapabilities")
+	require.Contains(t, res.GetMethods(), "Compile")
+	require.IsIncreasing(t, res.GetMethods())
+
+	names := map[string]bool{}
+	for _, c := range res.GetCapabilities() {
+		require.NotZero(t, c.GetVersion())
+		names[c.GetName()] = true
+	}
+	require.True(t, names["board_recover"])
+	require.True(t, names["loopback"])
+}
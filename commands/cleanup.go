@@ -0,0 +1,139 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/datalock"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/utils"
+	"github.com/arduino/arduino-cli/internal/buildcache"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+)
+
+// Cleanup removes the unused files from the data directory: the platform
+// releases superseded by another installed release, the orphaned tools, the
+// stale archives in the downloads directory and the expired build caches.
+func Cleanup(ctx context.Context, req *rpc.CleanupRequest) (*rpc.CleanupResponse, error) {
+	dryRun := req.GetDryRun()
+	noopCB := func(*rpc.TaskProgress) {}
+
+	cleanup := func() (*rpc.CleanupResponse, error) {
+		unlock, err := datalock.Acquire(tr("cleaning up the data directory"), nil)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
+		pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+		if err != nil {
+			return nil, &cmderrors.InvalidInstanceError{}
+		}
+		defer release()
+
+		// The stale archives must be collected before removing anything, since
+		// they depend on the platforms and tools in use
+		staleDownloads := pme.StaleDownloads()
+
+		platforms := &rpc.CleanupCategory{Type: rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_PLATFORMS, Items: []string{}}
+		for _, platformRelease := range pme.UnusedPlatformReleases() {
+			platforms.Items = append(platforms.Items, platformRelease.String())
+			platforms.Size += utils.DirSize(platformRelease.InstallDir)
+			if dryRun {
+				continue
+			}
+			if err := pme.UninstallPlatform(platformRelease, noopCB, false); err != nil {
+				return nil, err
+			}
+		}
+
+		tools := &rpc.CleanupCategory{Type: rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_TOOLS, Items: []string{}}
+		removedTools, freed, err := pme.CleanTools(dryRun, noopCB)
+		if err != nil {
+			return nil, &cmderrors.FailedUninstallError{Message: tr("Error removing unused tools"), Cause: err}
+		}
+		for _, tool := range removedTools {
+			tools.Items = append(tools.Items, tool.String())
+		}
+		tools.Size = freed
+
+		downloads := removePaths(rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_DOWNLOADS, staleDownloads, dryRun)
+		return &rpc.CleanupResponse{
+			Categories: []*rpc.CleanupCategory{platforms, tools, downloads},
+		}, nil
+	}
+
+	res, err := cleanup()
+	if err != nil {
+		return nil, err
+	}
+	platforms, tools := res.GetCategories()[0], res.GetCategories()[1]
+
+	cacheTTL := configuration.Settings.GetDuration("build_cache.ttl").Abs()
+	expiredCaches := paths.PathList{}
+	for _, dir := range buildCacheDirs() {
+		expiredCaches.AddAll(buildcache.New(dir).Expired(cacheTTL))
+	}
+	res.Categories = append(res.Categories, removePaths(rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_BUILD_CACHE, expiredCaches, dryRun))
+
+	if !dryRun && len(platforms.GetItems())+len(tools.GetItems()) > 0 {
+		if err := Init(&rpc.InitRequest{Instance: req.GetInstance()}, nil); err != nil {
+			return nil, err
+		}
+		if len(platforms.GetItems()) > 0 {
+			instances.NotifyEvent(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PLATFORMS_CHANGED, "")
+		}
+	}
+	return res, nil
+}
+
+// buildCacheDirs returns the directories of the default build caches of the
+// sketches, the cores and the libraries.
+func buildCacheDirs() paths.PathList {
+	cacheDir := paths.TempDir().Join("arduino")
+	dirs := paths.PathList{cacheDir.Join("cores"), cacheDir.Join("libraries"), cacheDir.Join("sketches")}
+	if sketchesCacheDir := configuration.SketchesBuildCacheDir(configuration.Settings); sketchesCacheDir != nil && !dirs.Contains(sketchesCacheDir) {
+		dirs.Add(sketchesCacheDir)
+	}
+	return dirs
+}
+
+// removePaths removes the given files or directories, unless dryRun is true,
+// and returns them as a cleanup category.
+func removePaths(categoryType rpc.CleanupCategoryType, list paths.PathList, dryRun bool) *rpc.CleanupCategory {
+	res := &rpc.CleanupCategory{Type: categoryType, Items: []string{}}
+	for _, path := range list {
+		var size int64
+		if path.IsDir() {
+			size = utils.DirSize(path)
+		} else if info, err := path.Stat(); err == nil {
+			size = info.Size()
+		}
+		if !dryRun {
+			if err := path.RemoveAll(); err != nil {
+				// Files in use can't be removed on some OSes, skip them
+				continue
+			}
+		}
+		res.Items = append(res.Items, path.String())
+		res.Size += size
+	}
+	return res
+}
@@ -16,6 +16,7 @@
 package cmderrors
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -517,6 +518,19 @@ func (e *CantCreateSketchError) Unwrap() error {
 	return e.Cause
 }
 
+// CantCreateLibraryError is returned when the library cannot be created
+type CantCreateLibraryError struct {
+	Cause error
+}
+
+func (e *CantCreateLibraryError) Error() string {
+	return composeErrorMsg(tr("Can't create library"), e.Cause)
+}
+
+func (e *CantCreateLibraryError) Unwrap() error {
+	return e.Cause
+}
+
 // CantUpdateSketchError is returned when the sketch cannot be updated
 type CantUpdateSketchError struct {
 	Cause error
@@ -567,6 +581,24 @@ func (e *FailedInstallError) ToRPCStatus() *status.Status {
 	return status.New(codes.Internal, e.Error())
 }
 
+// PostInstallStepRequiresAdminError is returned when a post-install step
+// requires administrative privileges and they are not available
+type PostInstallStepRequiresAdminError struct {
+	Step string
+}
+
+func (e *PostInstallStepRequiresAdminError) Error() string {
+	return tr("Post-install step %s requires administrative privileges", e.Step)
+}
+
+// ToRPCStatus converts the error into a *status.Status
+func (e *PostInstallStepRequiresAdminError) ToRPCStatus() *status.Status {
+	st, _ := status.
+		New(codes.PermissionDenied, e.Error()).
+		WithDetails(&rpc.PostInstallStepRequiresAdminError{})
+	return st
+}
+
 // FailedLibraryInstallError is returned if a library install operation fails
 type FailedLibraryInstallError struct {
 	Cause error
@@ -627,10 +659,18 @@ func (e *FailedDownloadError) ToRPCStatus() *status.Status {
 type FailedUploadError struct {
 	Message string
 	Cause   error
+	// Kind is the cause of the failure detected in the output of the upload tool
+	Kind rpc.UploadErrorKind
+	// ToolOutput is the line of the tool output that reported the failure
+	ToolOutput string
 }
 
 func (e *FailedUploadError) Error() string {
-	return composeErrorMsg(e.Message, e.Cause)
+	msg := e.Message
+	if hint := uploadErrorKindHint(e.Kind); hint != "" {
+		msg = composeErrorMsg(msg, errors.New(hint))
+	}
+	return composeErrorMsg(msg, e.Cause)
 }
 
 func (e *FailedUploadError) Unwrap() error {
@@ -639,7 +679,104 @@ func (e *FailedUploadError) Unwrap() error {
 
 // ToRPCStatus converts the error into a *status.Status
 func (e *FailedUploadError) ToRPCStatus() *status.Status {
-	return status.New(codes.Internal, e.Error())
+	st := status.New(codes.Internal, e.Error())
+	if e.Kind == rpc.UploadErrorKind_UPLOAD_ERROR_KIND_UNSPECIFIED {
+		return st
+	}
+	if withDetails, err := st.WithDetails(&rpc.FailedUploadError{Kind: e.Kind, ToolOutput: e.ToolOutput}); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+func uploadErrorKindHint(kind rpc.UploadErrorKind) string {
+	switch kind {
+	case rpc.UploadErrorKind_UPLOAD_ERROR_KIND_WRONG_SIGNATURE:
+		return tr("the microcontroller doesn't match the selected board")
+	case rpc.UploadErrorKind_UPLOAD_ERROR_KIND_SYNC_FAILURE:
+		return tr("the board doesn't respond, check the selected port and board, or press the reset button before the upload")
+	case rpc.UploadErrorKind_UPLOAD_ERROR_KIND_ACCESS_DENIED:
+		return tr("access to the port denied, check that it's not used by another program and the permissions of the user")
+	case rpc.UploadErrorKind_UPLOAD_ERROR_KIND_PORT_NOT_FOUND:
+		return tr("the port doesn't exist, check that the board is connected")
+	case rpc.UploadErrorKind_UPLOAD_ERROR_KIND_DEVICE_NOT_FOUND:
+		return tr("the device can't be found, check that the board or the programmer is connected")
+	case rpc.UploadErrorKind_UPLOAD_ERROR_KIND_VERIFICATION_FAILED:
+		return tr("the content of the memory doesn't match the uploaded binary")
+	}
+	return ""
+}
+
+// BuildManifestMismatchError is returned when the build artifacts to upload
+// don't match the build manifest produced by the compilation
+type BuildManifestMismatchError struct {
+	Message string
+	Cause   error
+}
+
+func (e *BuildManifestMismatchError) Error() string {
+	return composeErrorMsg(e.Message, e.Cause)
+}
+
+func (e *BuildManifestMismatchError) Unwrap() error {
+	return e.Cause
+}
+
+// ToRPCStatus converts the error into a *status.Status
+func (e *BuildManifestMismatchError) ToRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// IrreversibleOperationError is returned when an operation that can't be
+// undone is requested without forcing it
+type IrreversibleOperationError struct {
+	Message string
+}
+
+func (e *IrreversibleOperationError) Error() string {
+	return tr("%s: the operation can't be undone, it must be forced", e.Message)
+}
+
+// ToRPCStatus converts the error into a *status.Status
+func (e *IrreversibleOperationError) ToRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// DataDirectoryLockedError is returned when the data directory is locked by
+// another operation for longer than the wait timeout
+type DataDirectoryLockedError struct {
+	Holder string
+}
+
+func (e *DataDirectoryLockedError) Error() string {
+	return tr("The data directory is locked by %s", e.Holder)
+}
+
+// ToRPCStatus converts the error into a *status.Status
+func (e *DataDirectoryLockedError) ToRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
+// IncompatibleToolError is returned when a tool is built for an operating
+// system or an architecture that can't run on the current one
+type IncompatibleToolError struct {
+	Tool   string
+	Arch   string
+	Host   string
+	Advice string
+}
+
+func (e *IncompatibleToolError) Error() string {
+	msg := tr("The tool %[1]s is built for %[2]s and can't run on %[3]s", e.Tool, e.Arch, e.Host)
+	if e.Advice != "" {
+		msg += ". " + e.Advice
+	}
+	return msg
+}
+
+// ToRPCStatus converts the error into a *status.Status
+func (e *IncompatibleToolError) ToRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
 }
 
 // FailedDebugError is returned when the debug fails
@@ -698,6 +835,47 @@ func (e *CompileFailedError) ToRPCStatus() *status.Status {
 	return status.New(codes.Internal, e.Error())
 }
 
+// MissingIncludeError is returned when the compile fails because an included
+// header file can't be found in the platform or in any installed library
+type MissingIncludeError struct {
+	Header     string
+	SourceFile string
+	Line       int
+	// Candidates are the names of the libraries in the library index that
+	// provide the missing header
+	Candidates []string
+	Cause      error
+}
+
+func (e *MissingIncludeError) Error() string {
+	location := e.SourceFile
+	if e.Line > 0 {
+		location += fmt.Sprintf(":%d", e.Line)
+	}
+	msg := tr("Missing header file %[1]s, included by %[2]s", e.Header, location)
+	if len(e.Candidates) > 0 {
+		msg += "\n" + tr("The header is provided by the following libraries: %s", strings.Join(e.Candidates, ", "))
+	}
+	return msg
+}
+
+func (e *MissingIncludeError) Unwrap() error {
+	return e.Cause
+}
+
+// ToRPCStatus converts the error into a *status.Status
+func (e *MissingIncludeError) ToRPCStatus() *status.Status {
+	st, _ := status.
+		New(codes.NotFound, e.Error()).
+		WithDetails(&rpc.MissingIncludeError{
+			Header:             e.Header,
+			SourceFile:         e.SourceFile,
+			Line:               int64(e.Line),
+			CandidateLibraries: e.Candidates,
+		})
+	return st
+}
+
 // InvalidArgumentError is returned when an invalid argument is passed to the command
 type InvalidArgumentError struct {
 	Message string
@@ -0,0 +1,116 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/builder"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+)
+
+// buildLog accumulates the full verbose output of a build, it's saved in the
+// build path when the build is completed.
+type buildLog struct {
+	mux sync.Mutex
+	buf bytes.Buffer
+}
+
+func (l *buildLog) Write(data []byte) (int, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.buf.Write(data)
+}
+
+// teeWriter returns a writer copying the data written to w in the log
+func (l *buildLog) teeWriter(w io.Writer) io.Writer {
+	return io.MultiWriter(w, l)
+}
+
+// save writes the log in the logs directory of the build path, and removes
+// the oldest logs to keep only the given number of logs.
+func (l *buildLog) save(buildPath *paths.Path, keep int) error {
+	logsDir := buildPath.Join(builder.BuildLogsDirName)
+	if err := logsDir.MkdirAll(); err != nil {
+		return err
+	}
+	l.mux.Lock()
+	data := bytes.Clone(l.buf.Bytes())
+	l.mux.Unlock()
+	name := fmt.Sprintf("build-%s.log", time.Now().Format("20060102-150405.000"))
+	if err := logsDir.Join(name).WriteFile(data); err != nil {
+		return err
+	}
+
+	logs, err := buildLogs(buildPath)
+	if err != nil {
+		return err
+	}
+	for len(logs) > keep {
+		if err := logs[0].Remove(); err != nil {
+			return err
+		}
+		logs = logs[1:]
+	}
+	return nil
+}
+
+// buildLogs returns the logs saved in the build path, from the oldest to the
+// newest.
+func buildLogs(buildPath *paths.Path) (paths.PathList, error) {
+	logsDir := buildPath.Join(builder.BuildLogsDirName)
+	if !logsDir.IsDir() {
+		return paths.PathList{}, nil
+	}
+	logs, err := logsDir.ReadDir()
+	if err != nil {
+		return nil, err
+	}
+	logs.FilterSuffix(".log")
+	logs.Sort()
+	return logs, nil
+}
+
+// lastBuildLog returns the log of the last build saved in the build path
+func lastBuildLog(buildPath *paths.Path) (*rpc.BuildLog, error) {
+	logs, err := buildLogs(buildPath)
+	if err != nil {
+		return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot read the build logs"), Cause: err}
+	}
+	if len(logs) == 0 {
+		return nil, &cmderrors.NotFoundError{Message: tr("No build log found in %s", buildPath)}
+	}
+	last := logs[len(logs)-1]
+	content, err := last.ReadFile()
+	if err != nil {
+		return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot read the build logs"), Cause: err}
+	}
+	return &rpc.BuildLog{Path: last.String(), Content: string(content)}, nil
+}
+
+// exportBuildLog saves a copy of the log in the given directory
+func exportBuildLog(log *rpc.BuildLog, exportDir *paths.Path) error {
+	if err := exportDir.MkdirAll(); err != nil {
+		return err
+	}
+	return exportDir.Join(paths.New(log.GetPath()).Base()).WriteFile([]byte(log.GetContent()))
+}
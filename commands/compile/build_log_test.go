@@ -0,0 +1,52 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLog(t *testing.T) {
+	buildPath := paths.New(t.TempDir())
+	_, err := lastBuildLog(buildPath)
+	require.ErrorAs(t, err, new(*cmderrors.NotFoundError))
+
+	for _, content := range []string{"first", "second", "third"} {
+		log := &buildLog{}
+		log.Write([]byte(content))
+		require.NoError(t, log.save(buildPath, 2))
+		time.Sleep(2 * time.Millisecond)
+	}
+	logs, err := buildLogs(buildPath)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+
+	last, err := lastBuildLog(buildPath)
+	require.NoError(t, err)
+	require.Equal(t, "third", last.GetContent())
+	require.Equal(t, logs[1].String(), last.GetPath())
+
+	exportDir := paths.New(t.TempDir(), "export")
+	require.NoError(t, exportBuildLog(last, exportDir))
+	exported, err := exportDir.Join(logs[1].Base()).ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "third", string(exported))
+}
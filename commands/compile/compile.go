@@ -20,13 +20,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/arduino/arduino-cli/commands"
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/lib"
+	"github.com/arduino/arduino-cli/commands/upload"
 	"github.com/arduino/arduino-cli/internal/arduino/builder"
+	"github.com/arduino/arduino-cli/internal/arduino/buildmanifest"
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/httpclient"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesmanager"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
 	"github.com/arduino/arduino-cli/internal/arduino/utils"
@@ -36,13 +42,39 @@ import (
 	"github.com/arduino/arduino-cli/internal/inventory"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	paths "github.com/arduino/go-paths-helper"
+	"github.com/gofrs/uuid/v5"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
 )
 
 var tr = i18n.Tr
 
 // Compile FIXMEDOC
-func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream io.Writer, progressCB rpc.TaskProgressCB) (r *rpc.BuilderResult, e error) {
+func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream io.Writer, progressCB rpc.TaskProgressCB) (*rpc.BuilderResult, error) {
+	autoInstalled := map[string]bool{}
+	for {
+		r, err := compile(ctx, req, outStream, errStream, progressCB)
+		if !req.GetAutoInstallLibraries() {
+			return r, err
+		}
+		var missingInclude *cmderrors.MissingIncludeError
+		if !errors.As(err, &missingInclude) || len(missingInclude.Candidates) != 1 {
+			return r, err
+		}
+		library := missingInclude.Candidates[0]
+		if autoInstalled[library] {
+			return r, err
+		}
+		autoInstalled[library] = true
+		if installed, installErr := autoInstallLibrary(ctx, req, library, outStream); installErr != nil {
+			return r, installErr
+		} else if !installed {
+			return r, err
+		}
+	}
+}
+
+func compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream io.Writer, progressCB rpc.TaskProgressCB) (r *rpc.BuilderResult, e error) {
 	exportBinaries := configuration.Settings.GetBool("sketch.always_export_binaries")
 	if e := req.ExportBinaries; e != nil {
 		exportBinaries = *e
@@ -73,6 +105,30 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 		return nil, &cmderrors.CantOpenSketchError{Cause: err}
 	}
 
+	for name, value := range req.GetSecrets() {
+		if err := builder.ValidateSecret(name, value); err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid secret"), Cause: err}
+		}
+	}
+	if len(req.GetSecrets()) > 0 && req.GetPreprocess() {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Secrets can not be used when preprocessing the sketch")}
+	}
+	if len(req.GetSecrets()) > 0 && req.GetCompilerExplorerSession() {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Secrets can not be used in a Compiler Explorer session")}
+	}
+
+	var buildTarget builder.BuildTarget
+	if target := req.GetTarget(); target != nil {
+		buildTarget = builder.BuildTarget{
+			Core:    target.GetCore(),
+			Library: target.GetLibrary(),
+			Sketch:  target.GetSketch(),
+		}
+		if file := target.GetFile(); file != "" {
+			buildTarget.File = paths.New(file)
+		}
+	}
+
 	fqbnIn := req.GetFqbn()
 	if fqbnIn == "" && sk != nil {
 		if pme.GetProfile() != nil {
@@ -130,14 +186,34 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 	var buildPath *paths.Path
 	if buildPathArg := req.GetBuildPath(); buildPathArg != "" {
 		buildPath = paths.New(req.GetBuildPath()).Canonical()
-		if in, _ := buildPath.IsInsideDir(sk.FullPath); in && buildPath.IsDir() {
-			if sk.AdditionalFiles, err = removeBuildFromSketchFiles(sk.AdditionalFiles, buildPath); err != nil {
-				return nil, err
-			}
+	} else {
+		template, err := configuration.SketchBuildPathTemplate(configuration.Settings)
+		if err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid build path"), Cause: err}
+		}
+		buildPath, err = sk.BuildPath(template, fqbn.StringWithoutConfig())
+		if err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid build path"), Cause: err}
+		}
+	}
+	if in, _ := buildPath.IsInsideDir(sk.FullPath); in && buildPath.IsDir() {
+		if sk.AdditionalFiles, err = removeBuildFromSketchFiles(sk.AdditionalFiles, buildPath); err != nil {
+			return nil, err
 		}
 	}
-	if buildPath == nil {
-		buildPath = sk.DefaultBuildPath()
+	if req.GetLastLog() {
+		// Just report the log of the last build and exit
+		lastLog, err := lastBuildLog(buildPath)
+		if err != nil {
+			return nil, err
+		}
+		if exportDir := req.GetExportDir(); exportDir != "" {
+			if err := exportBuildLog(lastLog, paths.New(exportDir)); err != nil {
+				return nil, &cmderrors.PermissionDeniedError{Message: tr("Error copying output file %s", lastLog.GetPath()), Cause: err}
+			}
+		}
+		r.LastLog = lastLog
+		return r, nil
 	}
 	if err = buildPath.MkdirAll(); err != nil {
 		return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot create build directory"), Cause: err}
@@ -146,9 +222,10 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 	// cache is purged after compilation to not remove entries that might be required
 	defer maybePurgeBuildCache()
 
-	var coreBuildCachePath *paths.Path
+	var coreBuildCachePath, librariesBuildCachePath *paths.Path
 	if req.GetBuildCachePath() == "" {
 		coreBuildCachePath = paths.TempDir().Join("arduino", "cores")
+		librariesBuildCachePath = paths.TempDir().Join("arduino", "libraries")
 	} else {
 		buildCachePath, err := paths.New(req.GetBuildCachePath()).Abs()
 		if err != nil {
@@ -158,6 +235,10 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 			return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot create build cache directory"), Cause: err}
 		}
 		coreBuildCachePath = buildCachePath.Join("core")
+		librariesBuildCachePath = buildCachePath.Join("libraries")
+	}
+	if !configuration.Settings.GetBool("build_cache.libraries") {
+		librariesBuildCachePath = nil
 	}
 
 	if _, err := pme.FindToolsRequiredForBuild(targetPlatform, buildPlatform); err != nil {
@@ -165,12 +246,71 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 	}
 
 	actualPlatform := buildPlatform
-	otherLibrariesDirs := paths.NewPathList(req.GetLibraries()...)
-	otherLibrariesDirs.Add(configuration.LibrariesDir(configuration.Settings))
+	otherLibrariesDirs := librariesDirsOf(req.GetLibraries())
+	builtInLibrariesDir := configuration.IDEBuiltinLibrariesDir(configuration.Settings)
+	libraryDirs := paths.NewPathList(req.GetLibrary()...)
 
 	var libsManager *librariesmanager.LibrariesManager
+	var loadedLibraries *builder.LoadedLibraries
 	if pme.GetProfile() != nil {
 		libsManager = lm
+	} else if !req.GetSkipLibrariesDiscovery() {
+		loadedLibraries, err = loadLibraries(req.GetInstance(), fqbn, targetPlatform, actualPlatform,
+			builtInLibrariesDir, libraryDirs, otherLibrariesDirs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	warningsLevel := req.GetWarnings()
+	var warningsAsErrors *builder.WarningsAsErrors
+	if req.GetWarningsAsErrors() {
+		warningsAsErrors = &builder.WarningsAsErrors{
+			ExemptLibraries: append(
+				configuration.Settings.GetStringSlice("sketch.warnings_as_errors_exempt_libraries"),
+				req.GetWarningsAsErrorsExemptLibraries()...),
+		}
+		// The compiler doesn't report any warning with the "none" level
+		if warningsLevel == "" || warningsLevel == "none" {
+			warningsLevel = "default"
+		}
+	}
+
+	var keepGoing *builder.KeepGoing
+	if req.GetKeepGoing() {
+		keepGoing = &builder.KeepGoing{MaxErrors: int(req.GetMaxErrors())}
+	}
+
+	var strip *builder.StripOptions
+	if req.GetStripPaths() || req.GetStripDebugInfo() || req.GetStripSymbols() {
+		strip = &builder.StripOptions{
+			Paths:     req.GetStripPaths(),
+			DebugInfo: req.GetStripDebugInfo(),
+			Symbols:   req.GetStripSymbols(),
+		}
+	}
+
+	var symbolsArchive *builder.SymbolsArchive
+	if req.GetExportSymbols() {
+		buildUUID, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+		symbolsArchive = &builder.SymbolsArchive{
+			Dir:       configuration.SymbolsDir(configuration.Settings),
+			BuildUUID: buildUUID.String(),
+		}
+	}
+
+	// The full verbose log of the build is saved in the build path, even if
+	// the verbose output is disabled
+	var fullLog *buildLog
+	var verboseLog io.Writer
+	logsToKeep := configuration.Settings.GetInt("sketch.build_logs_to_keep")
+	if logsToKeep > 0 {
+		fullLog = &buildLog{}
+		verboseLog = fullLog
+		outStream, errStream = fullLog.teeWriter(outStream), fullLog.teeWriter(errStream)
 	}
 
 	sketchBuilder, err := builder.NewBuilder(
@@ -179,20 +319,30 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 		buildPath,
 		req.GetOptimizeForDebug(),
 		coreBuildCachePath,
+		librariesBuildCachePath,
 		int(req.GetJobs()),
 		req.GetBuildProperties(),
 		configuration.HardwareDirectories(configuration.Settings),
 		otherLibrariesDirs,
-		configuration.IDEBuiltinLibrariesDir(configuration.Settings),
+		builtInLibrariesDir,
 		fqbn,
 		req.GetClean(),
 		req.GetSourceOverride(),
+		req.GetSecrets(),
 		req.GetCreateCompilationDatabaseOnly(),
+		buildTarget,
 		targetPlatform, actualPlatform,
 		req.GetSkipLibrariesDiscovery(),
 		libsManager,
-		paths.NewPathList(req.GetLibrary()...),
-		outStream, errStream, req.GetVerbose(), req.GetWarnings(),
+		loadedLibraries,
+		libraryDirs,
+		outStream, errStream, req.GetVerbose(), warningsLevel,
+		verboseLog,
+		warningsAsErrors,
+		keepGoing,
+		req.GetApplyFixits(),
+		strip,
+		symbolsArchive,
 		progressCB,
 		pme.GetEnvVarsForSpawnedProcess(),
 	)
@@ -242,13 +392,90 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 		// Just output preprocessed source code and exit
 		preprocessedSketch, err := sketchBuilder.Preprocess()
 		if err != nil {
-			err = &cmderrors.CompileFailedError{Message: err.Error()}
-			return r, err
+			return r, compileFailedError(req.GetInstance(), err)
 		}
 		_, err = outStream.Write(preprocessedSketch)
 		return r, err
 	}
 
+	if req.GetCompilerExplorerSession() {
+		// Just report the Compiler Explorer session and exit
+		session, err := sketchBuilder.CompilerExplorerSession(req.GetCompilerExplorerCompiler())
+		if err != nil {
+			return r, compileFailedError(req.GetInstance(), err)
+		}
+		httpClient, err := httpclient.New()
+		if err != nil {
+			return r, err
+		}
+		link, err := session.Link(ctx, httpClient)
+		if err != nil {
+			return r, &cmderrors.UnavailableError{Message: tr("Error uploading the session to Compiler Explorer"), Cause: err}
+		}
+		r.CompilerExplorerSession = &rpc.CompilerExplorerSession{
+			Compiler: session.Compiler,
+			Flags:    session.Flags,
+			Source:   session.Source,
+			Link:     link,
+		}
+		return r, nil
+	}
+
+	if req.GetReportConditionalBranches() {
+		// Just report the conditional compilation branches and exit
+		branches, err := sketchBuilder.ConditionalBranches()
+		if err != nil {
+			return r, compileFailedError(req.GetInstance(), err)
+		}
+		for _, branch := range branches {
+			r.ConditionalBranches = append(r.ConditionalBranches, &rpc.ConditionalBranch{
+				File:      branch.File.String(),
+				Line:      int64(branch.Line),
+				EndLine:   int64(branch.EndLine),
+				Directive: branch.Directive,
+				Condition: branch.Condition,
+				Active:    branch.Active,
+			})
+		}
+		return r, nil
+	}
+
+	if req.GetReportHeaderDeclarations() {
+		// Just report the names declared by the headers and exit
+		declarations, err := sketchBuilder.HeaderDeclarations()
+		if err != nil {
+			return r, compileFailedError(req.GetInstance(), err)
+		}
+		r.HeaderDeclarations = &rpc.HeaderDeclarations{
+			Names:          declarations.Names,
+			MissingHeaders: declarations.MissingHeaders,
+		}
+		return r, nil
+	}
+
+	if req.GetAnalyzeIncludes() {
+		// Just report the issues of the #include directives and exit
+		issues, err := sketchBuilder.IncludesAnalysis()
+		if err != nil {
+			return r, compileFailedError(req.GetInstance(), err)
+		}
+		for _, issue := range issues {
+			rpcIssue := &rpc.IncludeIssue{
+				Kind:       rpc.IncludeIssue_KIND_UNUSED,
+				File:       issue.File.String(),
+				Line:       int64(issue.Line),
+				Header:     issue.Header,
+				Symbols:    issue.Symbols,
+				IncludedBy: issue.IncludedBy,
+			}
+			if issue.Kind == builder.IncludeMissing {
+				rpcIssue.Kind = rpc.IncludeIssue_KIND_MISSING
+			}
+			r.IncludeIssues = append(r.IncludeIssues, rpcIssue)
+		}
+		return r, nil
+	}
+
 	defer func() {
 		importedLibs := []*rpc.Library{}
 		for _, lib := range sketchBuilder.ImportedLibraries() {
@@ -264,7 +491,19 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 
 	// if it's a regular build, go on...
 
+	if fullLog != nil {
+		defer func() {
+			if err := fullLog.save(buildPath, logsToKeep); err != nil {
+				logrus.WithError(err).Warn("Error saving the build log")
+			}
+		}()
+	}
+
+	verboseOut := verboseLog
 	if req.GetVerbose() {
+		verboseOut = outStream
+	}
+	if verboseOut != nil {
 		core := sketchBuilder.GetBuildProperties().Get("build.core")
 		if core == "" {
 			core = "arduino"
@@ -272,14 +511,14 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 		// select the core name in case of "package:core" format
 		normalizedFQBN, err := pme.NormalizeFQBN(fqbn)
 		if err != nil {
-			outStream.Write([]byte(fmt.Sprintf("Could not normalize FQBN: %s\n", err)))
+			verboseOut.Write([]byte(fmt.Sprintf("Could not normalize FQBN: %s\n", err)))
 			normalizedFQBN = fqbn
 		}
-		outStream.Write([]byte(fmt.Sprintf("FQBN: %s\n", normalizedFQBN)))
+		verboseOut.Write([]byte(fmt.Sprintf("FQBN: %s\n", normalizedFQBN)))
 		core = core[strings.Index(core, ":")+1:]
-		outStream.Write([]byte(tr("Using board '%[1]s' from platform in folder: %[2]s", targetBoard.BoardID, targetPlatform.InstallDir) + "\n"))
-		outStream.Write([]byte(tr("Using core '%[1]s' from platform in folder: %[2]s", core, buildPlatform.InstallDir) + "\n"))
-		outStream.Write([]byte("\n"))
+		verboseOut.Write([]byte(tr("Using board '%[1]s' from platform in folder: %[2]s", targetBoard.BoardID, targetPlatform.InstallDir) + "\n"))
+		verboseOut.Write([]byte(tr("Using core '%[1]s' from platform in folder: %[2]s", core, buildPlatform.InstallDir) + "\n"))
+		verboseOut.Write([]byte("\n"))
 	}
 	if !targetBoard.Properties.ContainsKey("build.board") {
 		outStream.Write([]byte(
@@ -287,16 +526,44 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 				targetBoard.String(), "'build.board'", sketchBuilder.GetBuildProperties().Get("build.board")) + "\n"))
 	}
 
+	if req.GetApplyFixits() {
+		// The fix-it hints are applied even if the build fails, since most of
+		// them are suggested to fix the compile errors
+		defer func() {
+			applied, err := sketchBuilder.ApplyFixIts()
+			if err != nil {
+				errStream.Write([]byte(tr("Error applying the fix-it hints: %v", err) + "\n"))
+			}
+			r.AppliedFixits = applied.ToRPC()
+		}()
+	}
+
 	if err := sketchBuilder.Build(); err != nil {
-		return r, &cmderrors.CompileFailedError{Message: err.Error()}
+		return r, compileFailedError(req.GetInstance(), err)
+	}
+
+	// Describe the build artifacts, so they can be verified before the upload
+	if !req.GetCreateCompilationDatabaseOnly() && !buildTarget.IsPartial() {
+		projectName := sketchBuilder.GetBuildProperties().Get("build.project_name")
+		manifest, err := buildmanifest.New(sketchBuilder.GetBuildPath(), projectName, targetBoard.CompleteFQBN(fqbn), targetPlatform.String())
+		if err == nil {
+			if symbolsArchive != nil {
+				manifest.BuildUUID = symbolsArchive.BuildUUID
+			}
+			err = manifest.Save(sketchBuilder.GetBuildPath())
+		}
+		if err != nil {
+			return r, &cmderrors.PermissionDeniedError{Message: tr("Error writing the build manifest"), Cause: err}
+		}
 	}
 
 	// If the export directory is set we assume you want to export the binaries
-	if req.GetExportDir() != "" {
+	if req.GetExportDir() != "" || req.GetSaveAssemblyListings() {
 		exportBinaries = true
 	}
-	// If CreateCompilationDatabaseOnly is set, we do not need to export anything
-	if req.GetCreateCompilationDatabaseOnly() {
+	// If CreateCompilationDatabaseOnly is set, or only a part of the sketch is
+	// built, we do not need to export anything
+	if req.GetCreateCompilationDatabaseOnly() || buildTarget.IsPartial() {
 		exportBinaries = false
 	}
 	if exportBinaries {
@@ -328,6 +595,9 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 				return r, &cmderrors.PermissionDeniedError{Message: tr("Error reading build directory"), Cause: err}
 			}
 			buildFiles.FilterPrefix(baseName)
+			if manifest := sketchBuilder.GetBuildPath().Join(buildmanifest.FileName); manifest.Exist() {
+				buildFiles.Add(manifest)
+			}
 			for _, buildFile := range buildFiles {
 				exportedFile := exportPath.Join(buildFile.Base())
 				logrus.WithField("src", buildFile).WithField("dest", exportedFile).Trace("Copying artifact.")
@@ -337,6 +607,20 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 			}
 		}
 
+		if err := upload.GenerateFlashScripts(pme, fqbn, exportPath, sketchBuilder.GetBuildProperties().Get("build.project_name")); err != nil {
+			msg := tr("Could not create the flash script") + ": " + err.Error() + "\n"
+			errStream.Write([]byte(msg))
+		}
+
+		if req.GetSaveAssemblyListings() {
+			if err := exportPath.MkdirAll(); err != nil {
+				return r, &cmderrors.PermissionDeniedError{Message: tr("Error creating output dir"), Cause: err}
+			}
+			if err := sketchBuilder.SaveAssemblyListings(exportPath); err != nil {
+				return r, err
+			}
+		}
+
 		err = sketchBuilder.RunRecipe("recipe.hooks.savehex.postsavehex", ".pattern", false)
 		if err != nil {
 			return r, err
@@ -345,6 +629,36 @@ func Compile(ctx context.Context, req *rpc.CompileRequest, outStream, errStream
 
 	r.ExecutableSectionsSize = sketchBuilder.ExecutableSectionsSize().ToRPCExecutableSectionSizeArray()
 
+	if req.GetSuggestBoards() && !buildTarget.IsPartial() {
+		// Suggest the other boards of the same architecture
+		boards := []*cores.Board{}
+		for _, board := range pme.InstalledBoards() {
+			if board != targetBoard && !board.IsHidden() && board.PlatformRelease.Platform.Architecture == targetPlatform.Platform.Architecture {
+				boards = append(boards, board)
+			}
+		}
+		for _, suggestion := range sketchBuilder.ExecutableSectionsSize().SuggestBoards(boards, int(req.GetSuggestBoardsHeadroom())) {
+			r.BoardSuggestions = append(r.BoardSuggestions, &rpc.BoardSuggestion{
+				Fqbn:     suggestion.FQBN,
+				Name:     suggestion.Name,
+				MaxFlash: int64(suggestion.MaxFlash),
+				MaxRam:   int64(suggestion.MaxRAM),
+			})
+		}
+	}
+
+	if req.GetMemoryMapReport() && !req.GetCreateCompilationDatabaseOnly() && !buildTarget.IsPartial() {
+		if report, err := sketchBuilder.MemoryMapReport(); err != nil {
+			msg := tr("Could not create the memory map report") + ": " + err.Error() + "\n"
+			errStream.Write([]byte(msg))
+		} else {
+			r.MemoryMapReport = report.ToRPC()
+		}
+	}
+
+	if symbolsArchive != nil {
+		r.BuildUuid = symbolsArchive.BuildUUID
+	}
 	logrus.Tracef("Compile %s for %s successful", sk.Name, fqbnIn)
 
 	return r, nil
@@ -368,7 +682,13 @@ func maybePurgeBuildCache() {
 	inventory.Store.Set("build_cache.compilation_count_since_last_purge", 0)
 	cacheTTL := configuration.Settings.GetDuration("build_cache.ttl").Abs()
 	buildcache.New(paths.TempDir().Join("arduino", "cores")).Purge(cacheTTL)
+	buildcache.New(paths.TempDir().Join("arduino", "libraries")).Purge(cacheTTL)
 	buildcache.New(paths.TempDir().Join("arduino", "sketches")).Purge(cacheTTL)
+	if sketchesCacheDir := configuration.SketchesBuildCacheDir(configuration.Settings); sketchesCacheDir != nil {
+		if !sketchesCacheDir.EquivalentTo(paths.TempDir().Join("arduino", "sketches")) {
+			buildcache.New(sketchesCacheDir).Purge(cacheTTL)
+		}
+	}
 }
 
 // removeBuildFromSketchFiles removes the files contained in the build directory from
@@ -389,3 +709,105 @@ func removeBuildFromSketchFiles(files paths.PathList, build *paths.Path) (paths.
 	}
 	return res, nil
 }
+
+// compileFailedError converts the error returned by the builder in the
+// corresponding command error. If a header file is missing, the libraries
+// of the index that provide the header are suggested.
+func compileFailedError(instance *rpc.Instance, err error) error {
+	var missingInclude *builder.MissingIncludeError
+	if !errors.As(err, &missingInclude) {
+		return &cmderrors.CompileFailedError{Message: err.Error()}
+	}
+	res := &cmderrors.MissingIncludeError{
+		Header:     missingInclude.Header,
+		SourceFile: missingInclude.SourceFile.String(),
+		Line:       missingInclude.Line,
+		Cause:      err,
+	}
+	if li, err := instances.GetLibrariesIndex(instance); err == nil && li != nil {
+		for _, lib := range li.FindLibrariesProvidingInclude(missingInclude.Header) {
+			res.Candidates = append(res.Candidates, lib.Name)
+		}
+	}
+	return res
+}
+
+// autoInstallLibrary installs the latest release of the given library from the libraries
+// index. If a profile is in use the library is added to the profile in the sketch project
+// file, and the instance is initialized again to install it. It returns false if the
+// library is already installed.
+func autoInstallLibrary(ctx context.Context, req *rpc.CompileRequest, library string, outStream io.Writer) (bool, error) {
+	li, err := instances.GetLibrariesIndex(req.GetInstance())
+	if err != nil {
+		return false, err
+	}
+	indexLib, ok := li.Libraries[library]
+	if !ok || indexLib.Latest == nil {
+		return false, &cmderrors.LibraryNotFoundError{Library: library}
+	}
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return false, err
+	}
+	profile := pme.GetProfile()
+	release()
+
+	taskCB := func(msg *rpc.TaskProgress) {
+		if msg.GetName() != "" {
+			fmt.Fprintln(outStream, msg.GetName())
+		}
+		if msg.GetMessage() != "" {
+			fmt.Fprintln(outStream, msg.GetMessage())
+		}
+	}
+	downloadCB := func(*rpc.DownloadProgress) {}
+
+	if profile == nil {
+		lm, err := instances.GetLibraryManager(req.GetInstance())
+		if err != nil {
+			return false, err
+		}
+		lme, release := lm.NewExplorer()
+		alreadyInstalled := slices.Contains(lme.Names(), library)
+		release()
+		if alreadyInstalled {
+			return false, nil
+		}
+		fmt.Fprintln(outStream, tr("Installing missing library %s", indexLib.Latest))
+		return true, lib.LibraryInstall(ctx, &rpc.LibraryInstallRequest{
+			Instance:    req.GetInstance(),
+			Name:        indexLib.Latest.GetName(),
+			Version:     indexLib.Latest.GetVersion().String(),
+			NoOverwrite: true,
+		}, downloadCB, taskCB)
+	}
+
+	for _, libRef := range profile.Libraries {
+		if libRef.Library == indexLib.Latest.GetName() {
+			return false, nil
+		}
+	}
+	sk, err := sketch.New(paths.New(req.GetSketchPath()))
+	if err != nil {
+		return false, &cmderrors.CantOpenSketchError{Cause: err}
+	}
+	libRef := &sketch.ProfileLibraryReference{Library: indexLib.Latest.GetName(), Version: indexLib.Latest.GetVersion()}
+	fmt.Fprintln(outStream, tr("Adding missing library %[1]s to profile %[2]s", indexLib.Latest, profile.Name))
+	if err := sk.AddProfileLibrary(profile.Name, libRef); err != nil {
+		return false, &cmderrors.PermissionDeniedError{Message: tr("Cannot update sketch project file"), Cause: err}
+	}
+	err = commands.Init(&rpc.InitRequest{
+		Instance:   req.GetInstance(),
+		SketchPath: req.GetSketchPath(),
+		Profile:    profile.Name,
+	}, func(res *rpc.InitResponse) {
+		if st := res.GetError(); st != nil {
+			logrus.WithError(status.FromProto(st).Err()).Warn("Error initializing instance")
+		}
+		if progress := res.GetInitProgress(); progress != nil && progress.GetTaskProgress() != nil {
+			taskCB(progress.GetTaskProgress())
+		}
+	})
+	return true, err
+}
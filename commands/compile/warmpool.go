@@ -0,0 +1,192 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/builder"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+// warmPoolSize is the maximum number of build states kept between the
+// compilations, the least recently used one is dropped when the pool is full.
+const warmPoolSize = 8
+
+// warmState is the state of the builds for a board kept between the
+// compilations, so that the following builds for the same board skip the
+// loading of the libraries.
+type warmState struct {
+	instanceID     int32
+	fqbn           string
+	targetPlatform *cores.PlatformRelease
+	actualPlatform *cores.PlatformRelease
+	libraries      *builder.LoadedLibraries
+	lastUsed       time.Time
+}
+
+var warmPool = map[string]*warmState{}
+var warmPoolMux sync.Mutex
+
+// loadLibraries returns the libraries available to the builds for the board,
+// reusing the ones kept in the warm pool if the platforms and the libraries
+// directories didn't change since they have been loaded.
+func loadLibraries(
+	inst *rpc.Instance, fqbn *cores.FQBN,
+	targetPlatform, actualPlatform *cores.PlatformRelease,
+	builtInLibrariesDir *paths.Path, libraryDirs, otherLibrariesDirs paths.PathList,
+) (*builder.LoadedLibraries, error) {
+	if builtInLibrariesDir != nil {
+		if err := builtInLibrariesDir.ToAbs(); err != nil {
+			return nil, err
+		}
+	}
+	if err := otherLibrariesDirs.ToAbs(); err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%d|%s|%s|%s|%s", inst.GetId(), fqbn, builtInLibrariesDir,
+		strings.Join(libraryDirs.AsStrings(), ","), strings.Join(otherLibrariesDirs.AsStrings(), ","))
+
+	warmPoolMux.Lock()
+	state := warmPool[key]
+	warmPoolMux.Unlock()
+	// The platforms are replaced when the instance is initialized again
+	if state != nil && state.targetPlatform == targetPlatform && state.actualPlatform == actualPlatform && state.libraries.IsUpToDate() {
+		warmPoolMux.Lock()
+		state.lastUsed = time.Now()
+		warmPoolMux.Unlock()
+		return state.libraries, nil
+	}
+
+	libs, err := builder.LoadLibraries(builtInLibrariesDir, libraryDirs, otherLibrariesDirs, actualPlatform, targetPlatform)
+	if err != nil {
+		return nil, err
+	}
+
+	warmPoolMux.Lock()
+	defer warmPoolMux.Unlock()
+	if _, has := warmPool[key]; !has && len(warmPool) >= warmPoolSize {
+		var oldestKey string
+		var oldest *warmState
+		for k, s := range warmPool {
+			if oldest == nil || s.lastUsed.Before(oldest.lastUsed) {
+				oldestKey, oldest = k, s
+			}
+		}
+		logrus.Tracef("Dropping build state of %s from the warm pool", oldest.fqbn)
+		delete(warmPool, oldestKey)
+	}
+	warmPool[key] = &warmState{
+		instanceID:     inst.GetId(),
+		fqbn:           fqbn.String(),
+		targetPlatform: targetPlatform,
+		actualPlatform: actualPlatform,
+		libraries:      libs,
+		lastUsed:       time.Now(),
+	}
+	return libs, nil
+}
+
+// librariesDirsOf returns the directories of the libraries given with a
+// compile request, followed by the user libraries directory.
+func librariesDirsOf(requestLibraries []string) paths.PathList {
+	dirs := paths.NewPathList(requestLibraries...)
+	dirs.Add(configuration.LibrariesDir(configuration.Settings))
+	return dirs
+}
+
+// CompileWarmUp prepares the state of the builds for a board, so that the
+// following compilations for the same board skip the loading of the libraries.
+func CompileWarmUp(ctx context.Context, req *rpc.CompileWarmUpRequest) (*rpc.CompileWarmUpResponse, error) {
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if pme.Dirty() {
+		return nil, &cmderrors.InstanceNeedsReinitialization{}
+	}
+	if req.GetFqbn() == "" {
+		return nil, &cmderrors.MissingFQBNError{}
+	}
+	fqbn, err := cores.ParseFQBN(req.GetFqbn())
+	if err != nil {
+		return nil, &cmderrors.InvalidFQBNError{Cause: err}
+	}
+	_, targetPlatform, _, _, buildPlatform, err := pme.ResolveFQBN(fqbn)
+	if err != nil {
+		if targetPlatform == nil {
+			return nil, &cmderrors.PlatformNotFoundError{
+				Platform: fmt.Sprintf("%s:%s", fqbn.Package, fqbn.PlatformArch),
+				Cause:    fmt.Errorf(tr("platform not installed")),
+			}
+		}
+		return nil, &cmderrors.InvalidFQBNError{Cause: err}
+	}
+
+	// The libraries of the profiles are already loaded by the instance
+	if pme.GetProfile() != nil {
+		return &rpc.CompileWarmUpResponse{}, nil
+	}
+
+	if _, err := loadLibraries(req.GetInstance(), fqbn, targetPlatform, buildPlatform,
+		configuration.IDEBuiltinLibrariesDir(configuration.Settings),
+		paths.NewPathList(req.GetLibrary()...),
+		librariesDirsOf(req.GetLibraries()),
+	); err != nil {
+		return nil, err
+	}
+	return &rpc.CompileWarmUpResponse{}, nil
+}
+
+// CompileDropWarmState drops the state of the builds for a board, or for all
+// the boards if the FQBN is empty, kept for the given instance.
+func CompileDropWarmState(ctx context.Context, req *rpc.CompileDropWarmStateRequest) (*rpc.CompileDropWarmStateResponse, error) {
+	fqbn := req.GetFqbn()
+	if fqbn != "" {
+		parsed, err := cores.ParseFQBN(fqbn)
+		if err != nil {
+			return nil, &cmderrors.InvalidFQBNError{Cause: err}
+		}
+		fqbn = parsed.String()
+	}
+
+	warmPoolMux.Lock()
+	defer warmPoolMux.Unlock()
+	dropped := int32(0)
+	for key, state := range warmPool {
+		if state.instanceID != req.GetInstance().GetId() {
+			continue
+		}
+		if fqbn != "" && state.fqbn != fqbn {
+			continue
+		}
+		delete(warmPool, key)
+		dropped++
+	}
+	return &rpc.CompileDropWarmStateResponse{Dropped: dropped}, nil
+}
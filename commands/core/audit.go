@@ -0,0 +1,86 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// PlatformAudit compares the tools installed by the package manager with the
+// records of their installation and with the package index.
+func PlatformAudit(ctx context.Context, req *rpc.PlatformAuditRequest) (*rpc.PlatformAuditResponse, error) {
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	res := &rpc.PlatformAuditResponse{Tools: []*rpc.ToolAudit{}}
+	for _, tool := range pme.GetAllInstalledToolsReleases() {
+		if !pme.IsManagedToolRelease(tool) {
+			// Tools bundled with the IDE or loaded from the hardware directories
+			// are not downloaded by the package manager
+			continue
+		}
+		audit := &rpc.ToolAudit{
+			Packager: tool.Tool.Package.Name,
+			Name:     tool.Tool.Name,
+			Version:  tool.Version.String(),
+			Issues:   []rpc.ToolAuditIssue{},
+		}
+		if resource := tool.GetCompatibleFlavour(); resource != nil {
+			audit.IndexUrl = resource.URL
+			audit.IndexChecksum = resource.Checksum
+		} else {
+			audit.Issues = append(audit.Issues, rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_NOT_IN_INDEX)
+		}
+		record, err := pme.GetToolInstallRecord(tool)
+		if err != nil {
+			return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot read the tools database"), Cause: err}
+		}
+		if record != nil {
+			audit.InstalledUrl = record.URL
+			audit.InstalledChecksum = record.Checksum
+			if audit.IndexChecksum != "" && !strings.EqualFold(record.Checksum, audit.IndexChecksum) {
+				audit.Issues = append(audit.Issues, rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_CHECKSUM_CHANGED)
+			}
+		} else {
+			audit.Issues = append(audit.Issues, rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_UNKNOWN_ORIGIN)
+		}
+		if packagemanager.IsInsecureURL(audit.GetInstalledUrl()) || packagemanager.IsInsecureURL(audit.GetIndexUrl()) {
+			audit.Issues = append(audit.Issues, rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_INSECURE_URL)
+		}
+		res.Tools = append(res.Tools, audit)
+	}
+	sort.Slice(res.Tools, func(i, j int) bool {
+		a, b := res.Tools[i], res.Tools[j]
+		if a.GetPackager() != b.GetPackager() {
+			return a.GetPackager() < b.GetPackager()
+		}
+		if a.GetName() != b.GetName() {
+			return a.GetName() < b.GetName()
+		}
+		return a.GetVersion() < b.GetVersion()
+	})
+	return res, nil
+}
@@ -0,0 +1,62 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+
+	"github.com/arduino/arduino-cli/commands"
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/datalock"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// PlatformCleanTools removes the installed tools that are not required by
+// any installed platform.
+func PlatformCleanTools(ctx context.Context, req *rpc.PlatformCleanToolsRequest) (*rpc.PlatformCleanToolsResponse, error) {
+	unlock, err := datalock.Acquire(tr("removing unused tools"), nil)
+	if err != nil {
+		return nil, err
+	}
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		unlock()
+		return nil, &cmderrors.InvalidInstanceError{}
+	}
+	removed, freed, err := pme.CleanTools(req.GetDryRun(), func(*rpc.TaskProgress) {})
+	release()
+	unlock()
+	if err != nil {
+		return nil, &cmderrors.FailedUninstallError{Message: tr("Error removing unused tools"), Cause: err}
+	}
+
+	if !req.GetDryRun() && len(removed) > 0 {
+		if err := commands.Init(&rpc.InitRequest{Instance: req.GetInstance()}, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &rpc.PlatformCleanToolsResponse{RemovedTools: []*rpc.CleanedTool{}, FreedBytes: freed}
+	for _, tool := range removed {
+		res.RemovedTools = append(res.RemovedTools, &rpc.CleanedTool{
+			Packager: tool.Tool.Package.Name,
+			Name:     tool.Tool.Name,
+			Version:  tool.Version.String(),
+		})
+	}
+	return res, nil
+}
@@ -21,23 +21,31 @@ import (
 
 	"github.com/arduino/arduino-cli/commands"
 	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/datalock"
 	"github.com/arduino/arduino-cli/commands/internal/instances"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	semver "go.bug.st/relaxed-semver"
 )
 
 // PlatformInstall FIXMEDOC
 func PlatformInstall(ctx context.Context, req *rpc.PlatformInstallRequest, downloadCB rpc.DownloadProgressCB, taskCB rpc.TaskProgressCB) (*rpc.PlatformInstallResponse, error) {
-	install := func() error {
+	install := func() (*rpc.ComponentChange, error) {
+		unlock, err := datalock.Acquire(tr("installing platform %s", req.GetPlatformPackage()+":"+req.GetArchitecture()), taskCB)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
 		pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
 		if err != nil {
-			return err
+			return nil, err
 		}
 		defer release()
 
 		version, err := commands.ParseVersion(req.GetVersion())
 		if err != nil {
-			return &cmderrors.InvalidVersionError{Cause: err}
+			return nil, &cmderrors.InvalidVersionError{Cause: err}
 		}
 
 		ref := &packagemanager.PlatformReference{
@@ -47,35 +55,46 @@ func PlatformInstall(ctx context.Context, req *rpc.PlatformInstallRequest, downl
 		}
 		platformRelease, tools, err := pme.FindPlatformReleaseDependencies(ref)
 		if err != nil {
-			return &cmderrors.PlatformNotFoundError{Platform: ref.String(), Cause: err}
+			return nil, &cmderrors.PlatformNotFoundError{Platform: ref.String(), Cause: err}
 		}
 
 		// Prerequisite checks before install
 		if platformRelease.IsInstalled() {
 			taskCB(&rpc.TaskProgress{Name: tr("Platform %s already installed", platformRelease), Completed: true})
-			return nil
+			return nil, nil
 		}
 
+		installed := pme.GetInstalledPlatformRelease(platformRelease.Platform)
 		if req.GetNoOverwrite() {
-			if installed := pme.GetInstalledPlatformRelease(platformRelease.Platform); installed != nil {
-				return fmt.Errorf("%s: %s",
+			if installed != nil {
+				return nil, fmt.Errorf("%s: %s",
 					tr("Platform %s already installed", installed),
 					tr("could not overwrite"))
 			}
 		}
 
-		if err := pme.DownloadAndInstallPlatformAndTools(platformRelease, tools, downloadCB, taskCB, req.GetSkipPostInstall(), req.GetSkipPreUninstall()); err != nil {
-			return err
+		if err := pme.DownloadAndInstallPlatformAndTools(platformRelease, tools, downloadCB, taskCB, req.GetSkipPostInstall(), req.GetSkipPreUninstall(), req.GetKeepPrevious()); err != nil {
+			return nil, err
 		}
 
-		return nil
+		var previousVersion *semver.Version
+		if installed != nil {
+			previousVersion = installed.Version
+		}
+		return instances.ComponentChange(platformRelease.Platform.String(), previousVersion, platformRelease.Version), nil
 	}
 
-	if err := install(); err != nil {
+	change, err := install()
+	if err != nil {
 		return nil, err
 	}
 	if err := commands.Init(&rpc.InitRequest{Instance: req.GetInstance()}, nil); err != nil {
 		return nil, err
 	}
+	if change != nil {
+		instances.NotifyComponentChange(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PLATFORMS_CHANGED, change)
+	} else {
+		instances.NotifyEvent(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PLATFORMS_CHANGED, req.GetPlatformPackage()+":"+req.GetArchitecture())
+	}
 	return &rpc.PlatformInstallResponse{}, nil
 }
@@ -0,0 +1,137 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// PlatformPostInstallSteps returns the post-install setup steps declared by an installed platform.
+func PlatformPostInstallSteps(ctx context.Context, req *rpc.PlatformPostInstallStepsRequest) (*rpc.PlatformPostInstallStepsResponse, error) {
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	platformRelease, err := findInstalledPlatformRelease(pme, req.GetPlatformPackage(), req.GetArchitecture())
+	if err != nil {
+		return nil, err
+	}
+
+	props := postInstallStepProperties(platformRelease)
+	res := &rpc.PlatformPostInstallStepsResponse{Steps: []*rpc.PlatformPostInstallStep{}}
+	for _, step := range platformRelease.GetPostInstallSteps() {
+		res.Steps = append(res.Steps, &rpc.PlatformPostInstallStep{
+			Id:            step.ID,
+			Description:   step.Description,
+			Command:       props.ExpandPropsInString(step.Pattern),
+			RequiresAdmin: step.RequiresAdmin,
+		})
+	}
+	return res, nil
+}
+
+// PlatformRunPostInstallStep runs a post-install setup step of an installed platform.
+// The step executes a command declared by the platform, so the request must be
+// confirmed by the user after reviewing the command.
+func PlatformRunPostInstallStep(ctx context.Context, req *rpc.PlatformRunPostInstallStepRequest) (*rpc.PlatformRunPostInstallStepResponse, error) {
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	platformRelease, err := findInstalledPlatformRelease(pme, req.GetPlatformPackage(), req.GetArchitecture())
+	if err != nil {
+		return nil, err
+	}
+	step := platformRelease.GetPostInstallStep(req.GetStepId())
+	if step == nil {
+		return nil, &cmderrors.NotFoundError{Message: tr("Post-install step %s not found in platform %s", req.GetStepId(), platformRelease)}
+	}
+	// The step runs a command supplied by the platform
+	if !req.GetConfirmed() {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Post-install step %s must be confirmed by the user before running it", step.ID)}
+	}
+	if step.RequiresAdmin && !hasAdminPrivileges() {
+		return nil, &cmderrors.PostInstallStepRequiresAdminError{Step: step.ID}
+	}
+
+	if step.ID == cores.PostInstallScriptStepID {
+		stdout, stderr, err := pme.RunPreOrPostScript(platformRelease.InstallDir, "post_install")
+		res := &rpc.PlatformRunPostInstallStepResponse{OutStream: stdout, ErrStream: stderr}
+		if err != nil {
+			return res, &cmderrors.FailedInstallError{Message: tr("Error running post-install step %s", step.ID), Cause: err}
+		}
+		return res, nil
+	}
+
+	cmdLine := postInstallStepProperties(platformRelease).ExpandPropsInString(step.Pattern)
+	cmdArgs, err := properties.SplitQuotedString(cmdLine, `"'`, false)
+	if err != nil {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid post-install step %s in platform.txt", step.ID), Cause: err}
+	}
+	cmd, err := toolenv.NewProcess(pme.GetEnvVarsForSpawnedProcess(), cmdArgs...)
+	if err != nil {
+		return nil, &cmderrors.FailedInstallError{Message: tr("Error running post-install step %s", step.ID), Cause: err}
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.RedirectStdoutTo(stdout)
+	cmd.RedirectStderrTo(stderr)
+	cmd.SetDirFromPath(platformRelease.InstallDir)
+	err = cmd.RunWithinContext(ctx)
+	res := &rpc.PlatformRunPostInstallStepResponse{OutStream: stdout.Bytes(), ErrStream: stderr.Bytes()}
+	if err != nil {
+		return res, &cmderrors.FailedInstallError{Message: tr("Error running post-install step %s", step.ID), Cause: err}
+	}
+	return res, nil
+}
+
+// hasAdminPrivileges returns true if the process is running with
+// administrative privileges
+var hasAdminPrivileges = processHasAdminPrivileges
+
+func findInstalledPlatformRelease(pme *packagemanager.Explorer, platformPackage, architecture string) (*cores.PlatformRelease, error) {
+	ref := &packagemanager.PlatformReference{
+		Package:              platformPackage,
+		PlatformArchitecture: architecture,
+	}
+	platform := pme.FindPlatform(ref)
+	if platform == nil {
+		return nil, &cmderrors.PlatformNotFoundError{Platform: ref.String()}
+	}
+	platformRelease := pme.GetInstalledPlatformRelease(platform)
+	if platformRelease == nil {
+		return nil, &cmderrors.PlatformNotFoundError{Platform: ref.String()}
+	}
+	return platformRelease, nil
+}
+
+func postInstallStepProperties(platformRelease *cores.PlatformRelease) *properties.Map {
+	props := platformRelease.Properties.Clone()
+	props.Merge(platformRelease.RuntimeProperties())
+	return props
+}
@@ -0,0 +1,91 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlatformRunPostInstallStep(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	dataDir := tmp.Join("data_dir")
+	downloadDir := tmp.Join("staging")
+	t.Setenv("ARDUINO_DATA_DIR", dataDir.String())
+	t.Setenv("ARDUINO_DOWNLOADS_DIR", downloadDir.String())
+	require.NoError(t, downloadDir.MkdirAll())
+	platformDir := dataDir.Join("packages", "test", "hardware", "avr", "1.0.0")
+	require.NoError(t, platformDir.MkdirAll())
+	require.NoError(t, paths.New("testdata", "package_index.json").CopyTo(dataDir.Join("package_index.json")))
+	require.NoError(t, platformDir.Join("boards.txt").WriteFile([]byte("uno.name=Test Uno\n")))
+	// The steps run the test binary itself, that is available on every OS
+	testBinary := fmt.Sprintf(`"%s" -test.run=^$`, os.Args[0])
+	require.NoError(t, platformDir.Join("platform.txt").WriteFile([]byte(
+		"name=Test\nversion=1.0.0\n"+
+			"post_install.steps.user.description=A user step\n"+
+			"post_install.steps.user.pattern="+testBinary+"\n"+
+			"post_install.steps.admin.description=An admin step\n"+
+			"post_install.steps.admin.pattern="+testBinary+"\n"+
+			"post_install.steps.admin.requires_admin=true\n")))
+
+	configuration.Settings = configuration.Init(tmp.Join("arduino-cli.yaml").String())
+	inst := instance.CreateAndInit()
+	require.NotNil(t, inst)
+
+	steps, err := PlatformPostInstallSteps(context.Background(), &rpc.PlatformPostInstallStepsRequest{
+		Instance:        inst,
+		PlatformPackage: "test",
+		Architecture:    "avr",
+	})
+	require.NoError(t, err)
+	require.Len(t, steps.GetSteps(), 2)
+
+	run := func(stepID string, confirmed bool) error {
+		_, err := PlatformRunPostInstallStep(context.Background(), &rpc.PlatformRunPostInstallStepRequest{
+			Instance:        inst,
+			PlatformPackage: "test",
+			Architecture:    "avr",
+			StepId:          stepID,
+			Confirmed:       confirmed,
+		})
+		return err
+	}
+
+	// The steps must be confirmed
+	err = run("user", false)
+	var invalidArgErr *cmderrors.InvalidArgumentError
+	require.ErrorAs(t, err, &invalidArgErr)
+	require.NoError(t, run("user", true))
+
+	defer func(f func() bool) { hasAdminPrivileges = f }(hasAdminPrivileges)
+	hasAdminPrivileges = func() bool { return false }
+	err = run("admin", true)
+	var adminErr *cmderrors.PostInstallStepRequiresAdminError
+	require.ErrorAs(t, err, &adminErr)
+	require.Equal(t, "admin", adminErr.Step)
+
+	hasAdminPrivileges = func() bool { return true }
+	require.NoError(t, run("admin", true))
+}
@@ -20,27 +20,39 @@ import (
 
 	"github.com/arduino/arduino-cli/commands"
 	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/datalock"
 	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 )
 
 // PlatformUninstall FIXMEDOC
 func PlatformUninstall(ctx context.Context, req *rpc.PlatformUninstallRequest, taskCB rpc.TaskProgressCB) (*rpc.PlatformUninstallResponse, error) {
-	if err := platformUninstall(ctx, req, taskCB); err != nil {
+	platformRelease, err := platformUninstall(ctx, req, taskCB)
+	if err != nil {
 		return nil, err
 	}
 	if err := commands.Init(&rpc.InitRequest{Instance: req.GetInstance()}, nil); err != nil {
 		return nil, err
 	}
+	change := instances.ComponentChange(platformRelease.Platform.String(), platformRelease.Version, nil)
+	instances.NotifyComponentChange(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PLATFORMS_CHANGED, change)
 	return &rpc.PlatformUninstallResponse{}, nil
 }
 
-// platformUninstall is the implementation of platform unistaller
-func platformUninstall(ctx context.Context, req *rpc.PlatformUninstallRequest, taskCB rpc.TaskProgressCB) error {
+// platformUninstall is the implementation of platform unistaller, it returns
+// the uninstalled platform release
+func platformUninstall(ctx context.Context, req *rpc.PlatformUninstallRequest, taskCB rpc.TaskProgressCB) (*cores.PlatformRelease, error) {
+	unlock, err := datalock.Acquire(tr("uninstalling platform %s", req.GetPlatformPackage()+":"+req.GetArchitecture()), taskCB)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
 	if err != nil {
-		return &cmderrors.InvalidInstanceError{}
+		return nil, &cmderrors.InvalidInstanceError{}
 	}
 	defer release()
 
@@ -51,22 +63,22 @@ func platformUninstall(ctx context.Context, req *rpc.PlatformUninstallRequest, t
 	if ref.PlatformVersion == nil {
 		platform := pme.FindPlatform(ref)
 		if platform == nil {
-			return &cmderrors.PlatformNotFoundError{Platform: ref.String()}
+			return nil, &cmderrors.PlatformNotFoundError{Platform: ref.String()}
 		}
 		platformRelease := pme.GetInstalledPlatformRelease(platform)
 		if platformRelease == nil {
-			return &cmderrors.PlatformNotFoundError{Platform: ref.String()}
+			return nil, &cmderrors.PlatformNotFoundError{Platform: ref.String()}
 		}
 		ref.PlatformVersion = platformRelease.Version
 	}
 
 	platform, tools, err := pme.FindPlatformReleaseDependencies(ref)
 	if err != nil {
-		return &cmderrors.NotFoundError{Message: tr("Can't find dependencies for platform %s", ref), Cause: err}
+		return nil, &cmderrors.NotFoundError{Message: tr("Can't find dependencies for platform %s", ref), Cause: err}
 	}
 
 	if err := pme.UninstallPlatform(platform, taskCB, req.GetSkipPreUninstall()); err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, tool := range tools {
@@ -76,5 +88,5 @@ func platformUninstall(ctx context.Context, req *rpc.PlatformUninstallRequest, t
 		}
 	}
 
-	return nil
+	return platform, nil
 }
@@ -19,15 +19,24 @@ import (
 	"context"
 
 	"github.com/arduino/arduino-cli/commands"
+	"github.com/arduino/arduino-cli/commands/internal/datalock"
 	"github.com/arduino/arduino-cli/commands/internal/instances"
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	semver "go.bug.st/relaxed-semver"
 )
 
 // PlatformUpgrade FIXMEDOC
 func PlatformUpgrade(ctx context.Context, req *rpc.PlatformUpgradeRequest, downloadCB rpc.DownloadProgressCB, taskCB rpc.TaskProgressCB) (*rpc.PlatformUpgradeResponse, error) {
+	var previousVersion *semver.Version
 	upgrade := func() (*cores.PlatformRelease, error) {
+		unlock, err := datalock.Acquire(tr("upgrading platform %s", req.GetPlatformPackage()+":"+req.GetArchitecture()), taskCB)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
 		pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
 		if err != nil {
 			return nil, err
@@ -39,7 +48,12 @@ func PlatformUpgrade(ctx context.Context, req *rpc.PlatformUpgradeRequest, downl
 			Package:              req.GetPlatformPackage(),
 			PlatformArchitecture: req.GetArchitecture(),
 		}
-		platform, err := pme.DownloadAndInstallPlatformUpgrades(ref, downloadCB, taskCB, req.GetSkipPostInstall(), req.GetSkipPreUninstall())
+		if platform := pme.FindPlatform(ref); platform != nil {
+			if installed := pme.GetInstalledPlatformRelease(platform); installed != nil {
+				previousVersion = installed.Version
+			}
+		}
+		platform, err := pme.DownloadAndInstallPlatformUpgrades(ref, downloadCB, taskCB, req.GetSkipPostInstall(), req.GetSkipPreUninstall(), req.GetKeepPrevious())
 		if err != nil {
 			return platform, err
 		}
@@ -61,6 +75,8 @@ func PlatformUpgrade(ctx context.Context, req *rpc.PlatformUpgradeRequest, downl
 	if err := commands.Init(&rpc.InitRequest{Instance: req.GetInstance()}, nil); err != nil {
 		return nil, err
 	}
+	change := instances.ComponentChange(platformRelease.Platform.String(), previousVersion, platformRelease.Version)
+	instances.NotifyComponentChange(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PLATFORMS_CHANGED, change)
 
 	return &rpc.PlatformUpgradeResponse{Platform: rpcPlatform}, nil
 }
@@ -0,0 +1,162 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+)
+
+// PlatformUpgradePreview returns the changes that a platform upgrade would
+// make, without downloading or installing anything. If no platform is given
+// all the installed platforms are considered.
+func PlatformUpgradePreview(ctx context.Context, req *rpc.PlatformUpgradePreviewRequest) (*rpc.PlatformUpgradePreviewResponse, error) {
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Find the platforms to upgrade
+	var platforms []*cores.Platform
+	if req.GetPlatformPackage() == "" {
+		seen := map[*cores.Platform]bool{}
+		for _, installed := range pme.InstalledPlatformReleases() {
+			if !seen[installed.Platform] {
+				seen[installed.Platform] = true
+				platforms = append(platforms, installed.Platform)
+			}
+		}
+		sort.Slice(platforms, func(i, j int) bool {
+			return platforms[i].String() < platforms[j].String()
+		})
+	} else {
+		ref := &packagemanager.PlatformReference{
+			Package:              req.GetPlatformPackage(),
+			PlatformArchitecture: req.GetArchitecture(),
+		}
+		platform := pme.FindPlatform(ref)
+		if platform == nil || pme.GetInstalledPlatformRelease(platform) == nil {
+			return nil, &cmderrors.PlatformNotFoundError{Platform: ref.String()}
+		}
+		platforms = append(platforms, platform)
+	}
+
+	// upgrades maps each platform to the release that would be installed
+	upgrades := map[*cores.Platform]*cores.PlatformRelease{}
+	for _, platform := range platforms {
+		installed := pme.GetInstalledPlatformRelease(platform)
+		latest := platform.GetLatestCompatibleRelease()
+		if latest != nil && latest.Version.GreaterThan(installed.Version) {
+			upgrades[platform] = latest
+		}
+	}
+
+	res := &rpc.PlatformUpgradePreviewResponse{Platforms: []*rpc.PlatformUpgradePreview{}}
+	newTools := map[*cores.ToolRelease]bool{}
+	removedTools := map[*cores.ToolRelease]bool{}
+	for _, platform := range platforms {
+		latest, ok := upgrades[platform]
+		if !ok {
+			continue
+		}
+		installed := pme.GetInstalledPlatformRelease(platform)
+		preview := &rpc.PlatformUpgradePreview{
+			Id:               platform.String(),
+			InstalledVersion: installed.Version.String(),
+			NewVersion:       latest.Version.String(),
+			NewTools:         []*rpc.ToolInstallPreview{},
+			RemovedTools:     []string{},
+		}
+		preview.DownloadSize, preview.Cached = downloadPreview(latest.Resource, pme.DownloadDir)
+		if !preview.GetCached() {
+			res.DownloadSize += preview.GetDownloadSize()
+		}
+
+		_, requiredTools, err := pme.FindPlatformReleaseDependencies(platformReferenceOf(latest))
+		if err != nil {
+			return nil, &cmderrors.NotFoundError{Message: tr("Can't find dependencies for platform %s", latest), Cause: err}
+		}
+		for _, tool := range requiredTools {
+			if tool.IsInstalled() || newTools[tool] {
+				continue
+			}
+			newTools[tool] = true
+			toolPreview := &rpc.ToolInstallPreview{Id: tool.String()}
+			toolPreview.DownloadSize, toolPreview.Cached = downloadPreview(tool.GetCompatibleFlavour(), pme.DownloadDir)
+			if !toolPreview.GetCached() {
+				res.DownloadSize += toolPreview.GetDownloadSize()
+			}
+			preview.NewTools = append(preview.NewTools, toolPreview)
+		}
+
+		if !req.GetKeepPrevious() {
+			_, installedTools, err := pme.FindPlatformReleaseDependencies(platformReferenceOf(installed))
+			if err != nil {
+				return nil, &cmderrors.NotFoundError{Message: tr("Can't find dependencies for platform %s", installed), Cause: err}
+			}
+			for _, tool := range installedTools {
+				if !removedTools[tool] && !isToolRequiredAfterUpgrade(pme, tool, upgrades) {
+					removedTools[tool] = true
+					preview.RemovedTools = append(preview.RemovedTools, tool.String())
+				}
+			}
+		}
+		res.Platforms = append(res.Platforms, preview)
+	}
+	return res, nil
+}
+
+func platformReferenceOf(release *cores.PlatformRelease) *packagemanager.PlatformReference {
+	return &packagemanager.PlatformReference{
+		Package:              release.Platform.Package.Name,
+		PlatformArchitecture: release.Platform.Architecture,
+		PlatformVersion:      release.Version,
+	}
+}
+
+// isToolRequiredAfterUpgrade returns true if the tool is required by an
+// installed platform, once the given upgrades have been applied.
+func isToolRequiredAfterUpgrade(pme *packagemanager.Explorer, tool *cores.ToolRelease, upgrades map[*cores.Platform]*cores.PlatformRelease) bool {
+	for _, installed := range pme.InstalledPlatformReleases() {
+		release := installed
+		if upgrade, ok := upgrades[installed.Platform]; ok && installed == pme.GetInstalledPlatformRelease(installed.Platform) {
+			release = upgrade
+		}
+		if release.RequiresToolRelease(tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// downloadPreview returns the size of the archive and whether it's already
+// in the downloads directory
+func downloadPreview(resource *resources.DownloadResource, downloadDir *paths.Path) (int64, bool) {
+	if resource == nil {
+		return 0, false
+	}
+	cached, err := resource.IsCached(downloadDir)
+	return resource.Size, err == nil && cached
+}
@@ -39,7 +39,9 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
-// ArduinoCoreServerImpl FIXMEDOC
+// ArduinoCoreServerImpl is the implementation of the ArduinoCoreService,
+// running the commands in the current process. It is served by the daemon
+// and by the embedded clients of the client package.
 type ArduinoCoreServerImpl struct {
 	// Force compile error for unimplemented methods
 	rpc.UnsafeArduinoCoreServiceServer
@@ -114,6 +116,12 @@ func (s *ArduinoCoreServerImpl) BoardListWatch(req *rpc.BoardListWatchRequest, s
 	return nil
 }
 
+// BoardSetupPermissions checks and fixes the permissions needed to access a port
+func (s *ArduinoCoreServerImpl) BoardSetupPermissions(ctx context.Context, req *rpc.BoardSetupPermissionsRequest) (*rpc.BoardSetupPermissionsResponse, error) {
+	resp, err := board.SetupPermissions(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // Destroy FIXMEDOC
 func (s *ArduinoCoreServerImpl) Destroy(ctx context.Context, req *rpc.DestroyRequest) (*rpc.DestroyResponse, error) {
 	resp, err := commands.Destroy(ctx, req)
@@ -176,11 +184,33 @@ func (s *ArduinoCoreServerImpl) Init(req *rpc.InitRequest, stream rpc.ArduinoCor
 	return convertErrorToRPCStatus(err)
 }
 
+// InstanceEvents streams the changes to the state shared by the instances
+func (s *ArduinoCoreServerImpl) InstanceEvents(req *rpc.InstanceEventsRequest, stream rpc.ArduinoCoreService_InstanceEventsServer) error {
+	eventsChan, err := commands.InstanceEvents(stream.Context(), req)
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+
+	syncSend := NewSynchronizedSend(stream.Send)
+	for event := range eventsChan {
+		if err := syncSend.Send(event); err != nil {
+			logrus.Infof("sending instance event: %v", err)
+		}
+	}
+	return nil
+}
+
 // Version FIXMEDOC
 func (s *ArduinoCoreServerImpl) Version(ctx context.Context, req *rpc.VersionRequest) (*rpc.VersionResponse, error) {
 	return &rpc.VersionResponse{Version: s.VersionString}, nil
 }
 
+// GetCapabilities returns the API version and the features supported by the daemon.
+func (s *ArduinoCoreServerImpl) GetCapabilities(ctx context.Context, req *rpc.GetCapabilitiesRequest) (*rpc.GetCapabilitiesResponse, error) {
+	resp, err := commands.GetCapabilities(ctx, req, s.VersionString)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // NewSketch FIXMEDOC
 func (s *ArduinoCoreServerImpl) NewSketch(ctx context.Context, req *rpc.NewSketchRequest) (*rpc.NewSketchResponse, error) {
 	resp, err := sketch.NewSketch(ctx, req)
@@ -199,6 +229,18 @@ func (s *ArduinoCoreServerImpl) SetSketchDefaults(ctx context.Context, req *rpc.
 	return resp, convertErrorToRPCStatus(err)
 }
 
+// SketchbookList returns the sketches contained in the sketchbook
+func (s *ArduinoCoreServerImpl) SketchbookList(ctx context.Context, req *rpc.SketchbookListRequest) (*rpc.SketchbookListResponse, error) {
+	resp, err := sketch.SketchbookList(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// Symbolize resolves the addresses reported by a firmware
+func (s *ArduinoCoreServerImpl) Symbolize(ctx context.Context, req *rpc.SymbolizeRequest) (*rpc.SymbolizeResponse, error) {
+	resp, err := commands.Symbolize(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // Compile FIXMEDOC
 func (s *ArduinoCoreServerImpl) Compile(req *rpc.CompileRequest, stream rpc.ArduinoCoreService_CompileServer) error {
 	syncSend := NewSynchronizedSend(stream.Send)
@@ -234,6 +276,18 @@ func (s *ArduinoCoreServerImpl) Compile(req *rpc.CompileRequest, stream rpc.Ardu
 	return compileRespSendErr
 }
 
+// CompileWarmUp prepares the state of the builds for a board
+func (s *ArduinoCoreServerImpl) CompileWarmUp(ctx context.Context, req *rpc.CompileWarmUpRequest) (*rpc.CompileWarmUpResponse, error) {
+	resp, err := compile.CompileWarmUp(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// CompileDropWarmState drops the state of the builds kept between the compilations
+func (s *ArduinoCoreServerImpl) CompileDropWarmState(ctx context.Context, req *rpc.CompileDropWarmStateRequest) (*rpc.CompileDropWarmStateResponse, error) {
+	resp, err := compile.CompileDropWarmState(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // PlatformInstall FIXMEDOC
 func (s *ArduinoCoreServerImpl) PlatformInstall(req *rpc.PlatformInstallRequest, stream rpc.ArduinoCoreService_PlatformInstallServer) error {
 	syncSend := NewSynchronizedSend(stream.Send)
@@ -288,6 +342,36 @@ func (s *ArduinoCoreServerImpl) PlatformUpgrade(req *rpc.PlatformUpgradeRequest,
 	return convertErrorToRPCStatus(err)
 }
 
+// PlatformUpgradePreview previews the changes of a platform upgrade
+func (s *ArduinoCoreServerImpl) PlatformUpgradePreview(ctx context.Context, req *rpc.PlatformUpgradePreviewRequest) (*rpc.PlatformUpgradePreviewResponse, error) {
+	resp, err := core.PlatformUpgradePreview(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// PlatformPostInstallSteps lists the post-install setup steps of a platform
+func (s *ArduinoCoreServerImpl) PlatformPostInstallSteps(ctx context.Context, req *rpc.PlatformPostInstallStepsRequest) (*rpc.PlatformPostInstallStepsResponse, error) {
+	resp, err := core.PlatformPostInstallSteps(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// PlatformRunPostInstallStep runs a post-install setup step of a platform
+func (s *ArduinoCoreServerImpl) PlatformRunPostInstallStep(ctx context.Context, req *rpc.PlatformRunPostInstallStepRequest) (*rpc.PlatformRunPostInstallStepResponse, error) {
+	resp, err := core.PlatformRunPostInstallStep(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// PlatformAudit audits the tools installed by the package manager
+func (s *ArduinoCoreServerImpl) PlatformAudit(ctx context.Context, req *rpc.PlatformAuditRequest) (*rpc.PlatformAuditResponse, error) {
+	resp, err := core.PlatformAudit(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// PlatformCleanTools removes the installed tools not required by any platform
+func (s *ArduinoCoreServerImpl) PlatformCleanTools(ctx context.Context, req *rpc.PlatformCleanToolsRequest) (*rpc.PlatformCleanToolsResponse, error) {
+	resp, err := core.PlatformCleanTools(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // PlatformSearch FIXMEDOC
 func (s *ArduinoCoreServerImpl) PlatformSearch(ctx context.Context, req *rpc.PlatformSearchRequest) (*rpc.PlatformSearchResponse, error) {
 	resp, err := core.PlatformSearch(req)
@@ -307,7 +391,12 @@ func (s *ArduinoCoreServerImpl) Upload(req *rpc.UploadRequest, stream rpc.Arduin
 			Message: &rpc.UploadResponse_ErrStream{ErrStream: data},
 		})
 	})
-	res, err := upload.Upload(stream.Context(), req, outStream, errStream)
+	progressStream := func(p *rpc.TaskProgress) {
+		syncSend.Send(&rpc.UploadResponse{
+			Message: &rpc.UploadResponse_Progress{Progress: p},
+		})
+	}
+	res, err := upload.Upload(stream.Context(), req, outStream, errStream, progressStream)
 	outStream.Close()
 	errStream.Close()
 	if res != nil {
@@ -337,7 +426,12 @@ func (s *ArduinoCoreServerImpl) UploadUsingProgrammer(req *rpc.UploadUsingProgra
 			},
 		})
 	})
-	err := upload.UsingProgrammer(stream.Context(), req, outStream, errStream)
+	progressStream := func(p *rpc.TaskProgress) {
+		syncSend.Send(&rpc.UploadUsingProgrammerResponse{
+			Message: &rpc.UploadUsingProgrammerResponse_Progress{Progress: p},
+		})
+	}
+	err := upload.UsingProgrammer(stream.Context(), req, outStream, errStream, progressStream)
 	outStream.Close()
 	errStream.Close()
 	if err != nil {
@@ -378,12 +472,288 @@ func (s *ArduinoCoreServerImpl) BurnBootloader(req *rpc.BurnBootloaderRequest, s
 	return syncSend.Send(resp)
 }
 
+// ReadMemory reads a memory range of a board and saves it to a file.
+func (s *ArduinoCoreServerImpl) ReadMemory(req *rpc.ReadMemoryRequest, stream rpc.ArduinoCoreService_ReadMemoryServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.ReadMemoryResponse{
+			Message: &rpc.ReadMemoryResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.ReadMemoryResponse{
+			Message: &rpc.ReadMemoryResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	resp, err := upload.ReadMemory(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(resp)
+}
+
+// WriteMemory writes the content of a file to a memory range of a board.
+func (s *ArduinoCoreServerImpl) WriteMemory(req *rpc.WriteMemoryRequest, stream rpc.ArduinoCoreService_WriteMemoryServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.WriteMemoryResponse{
+			Message: &rpc.WriteMemoryResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.WriteMemoryResponse{
+			Message: &rpc.WriteMemoryResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	resp, err := upload.WriteMemory(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(resp)
+}
+
+// EraseChip erases the whole memory of the microcontroller of a board.
+func (s *ArduinoCoreServerImpl) EraseChip(req *rpc.EraseChipRequest, stream rpc.ArduinoCoreService_EraseChipServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.EraseChipResponse{
+			Message: &rpc.EraseChipResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.EraseChipResponse{
+			Message: &rpc.EraseChipResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	resp, err := upload.EraseChip(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(resp)
+}
+
+// FlashProtection queries or changes the flash readout protection of a board.
+func (s *ArduinoCoreServerImpl) FlashProtection(req *rpc.FlashProtectionRequest, stream rpc.ArduinoCoreService_FlashProtectionServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.FlashProtectionResponse{
+			Message: &rpc.FlashProtectionResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.FlashProtectionResponse{
+			Message: &rpc.FlashProtectionResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	res, err := upload.FlashProtection(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(&rpc.FlashProtectionResponse{
+		Message: &rpc.FlashProtectionResponse_Result{
+			Result: res,
+		},
+	})
+}
+
+// BoardRecover tries to recover a board that does not respond anymore.
+func (s *ArduinoCoreServerImpl) BoardRecover(req *rpc.BoardRecoverRequest, stream rpc.ArduinoCoreService_BoardRecoverServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardRecoverResponse{
+			Message: &rpc.BoardRecoverResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardRecoverResponse{
+			Message: &rpc.BoardRecoverResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	res, err := upload.BoardRecover(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(&rpc.BoardRecoverResponse{
+		Message: &rpc.BoardRecoverResponse_Result{
+			Result: res,
+		},
+	})
+}
+
+// BoardReset resets a board using the reset method of its platform.
+func (s *ArduinoCoreServerImpl) BoardReset(req *rpc.BoardResetRequest, stream rpc.ArduinoCoreService_BoardResetServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardResetResponse{
+			Message: &rpc.BoardResetResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardResetResponse{
+			Message: &rpc.BoardResetResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	res, err := upload.BoardReset(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(&rpc.BoardResetResponse{
+		Message: &rpc.BoardResetResponse_Result{
+			Result: res,
+		},
+	})
+}
+
+// BoardPower streams the power samples of a board measured by a debug probe.
+func (s *ArduinoCoreServerImpl) BoardPower(req *rpc.BoardPowerRequest, stream rpc.ArduinoCoreService_BoardPowerServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardPowerResponse{
+			Message: &rpc.BoardPowerResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardPowerResponse{
+			Message: &rpc.BoardPowerResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	err := upload.BoardPower(stream.Context(), req, outStream, errStream, func(sample *rpc.PowerSample) {
+		syncSend.Send(&rpc.BoardPowerResponse{
+			Message: &rpc.BoardPowerResponse_Sample{
+				Sample: sample,
+			},
+		})
+	})
+	outStream.Close()
+	errStream.Close()
+	return convertErrorToRPCStatus(err)
+}
+
+// BoardCertificates flashes root certificates, and optionally a firmware, to
+// the network module of a board.
+func (s *ArduinoCoreServerImpl) BoardCertificates(req *rpc.BoardCertificatesRequest, stream rpc.ArduinoCoreService_BoardCertificatesServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardCertificatesResponse{
+			Message: &rpc.BoardCertificatesResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardCertificatesResponse{
+			Message: &rpc.BoardCertificatesResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	res, err := upload.BoardCertificates(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(&rpc.BoardCertificatesResponse{
+		Message: &rpc.BoardCertificatesResponse_Result{
+			Result: res,
+		},
+	})
+}
+
+// BoardProvision provisions the secure element of a board.
+func (s *ArduinoCoreServerImpl) BoardProvision(req *rpc.BoardProvisionRequest, stream rpc.ArduinoCoreService_BoardProvisionServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	outStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardProvisionResponse{
+			Message: &rpc.BoardProvisionResponse_OutStream{
+				OutStream: data,
+			},
+		})
+	})
+	errStream := feedStreamTo(func(data []byte) {
+		syncSend.Send(&rpc.BoardProvisionResponse{
+			Message: &rpc.BoardProvisionResponse_ErrStream{
+				ErrStream: data,
+			},
+		})
+	})
+	res, err := upload.BoardProvision(stream.Context(), req, outStream, errStream)
+	outStream.Close()
+	errStream.Close()
+	if err != nil {
+		return convertErrorToRPCStatus(err)
+	}
+	return syncSend.Send(&rpc.BoardProvisionResponse{
+		Message: &rpc.BoardProvisionResponse_Result{
+			Result: res,
+		},
+	})
+}
+
+// DeploymentsList lists the last sketch uploaded to each board
+func (s *ArduinoCoreServerImpl) DeploymentsList(ctx context.Context, req *rpc.DeploymentsListRequest) (*rpc.DeploymentsListResponse, error) {
+	resp, err := upload.DeploymentsList(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// DeploymentsShow shows the history of the sketches uploaded to a board
+func (s *ArduinoCoreServerImpl) DeploymentsShow(ctx context.Context, req *rpc.DeploymentsShowRequest) (*rpc.DeploymentsShowResponse, error) {
+	resp, err := upload.DeploymentsShow(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // ListProgrammersAvailableForUpload FIXMEDOC
 func (s *ArduinoCoreServerImpl) ListProgrammersAvailableForUpload(ctx context.Context, req *rpc.ListProgrammersAvailableForUploadRequest) (*rpc.ListProgrammersAvailableForUploadResponse, error) {
 	resp, err := upload.ListProgrammersAvailableForUpload(ctx, req)
 	return resp, convertErrorToRPCStatus(err)
 }
 
+// ProgrammerDetails returns the operations supported by a programmer
+func (s *ArduinoCoreServerImpl) ProgrammerDetails(ctx context.Context, req *rpc.ProgrammerDetailsRequest) (*rpc.ProgrammerDetailsResponse, error) {
+	resp, err := upload.ProgrammerDetails(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // LibraryDownload FIXMEDOC
 func (s *ArduinoCoreServerImpl) LibraryDownload(req *rpc.LibraryDownloadRequest, stream rpc.ArduinoCoreService_LibraryDownloadServer) error {
 	syncSend := NewSynchronizedSend(stream.Send)
@@ -397,6 +767,30 @@ func (s *ArduinoCoreServerImpl) LibraryDownload(req *rpc.LibraryDownloadRequest,
 	return syncSend.Send(resp)
 }
 
+// LibraryNew creates the skeleton of a new library.
+func (s *ArduinoCoreServerImpl) LibraryNew(ctx context.Context, req *rpc.LibraryNewRequest) (*rpc.LibraryNewResponse, error) {
+	resp, err := lib.LibraryNew(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// LibraryDiff compares the API of two versions of a library.
+func (s *ArduinoCoreServerImpl) LibraryDiff(req *rpc.LibraryDiffRequest, stream rpc.ArduinoCoreService_LibraryDiffServer) error {
+	syncSend := NewSynchronizedSend(stream.Send)
+	res, err := lib.LibraryDiff(stream.Context(), req,
+		func(p *rpc.DownloadProgress) {
+			syncSend.Send(&rpc.LibraryDiffResponse{
+				Message: &rpc.LibraryDiffResponse_DownloadProgress{DownloadProgress: p},
+			})
+		},
+	)
+	if res != nil {
+		syncSend.Send(&rpc.LibraryDiffResponse{
+			Message: &rpc.LibraryDiffResponse_Result_{Result: res},
+		})
+	}
+	return convertErrorToRPCStatus(err)
+}
+
 // LibraryInstall FIXMEDOC
 func (s *ArduinoCoreServerImpl) LibraryInstall(req *rpc.LibraryInstallRequest, stream rpc.ArduinoCoreService_LibraryInstallServer) error {
 	syncSend := NewSynchronizedSend(stream.Send)
@@ -438,6 +832,12 @@ func (s *ArduinoCoreServerImpl) LibraryUpgradeAll(req *rpc.LibraryUpgradeAllRequ
 	return convertErrorToRPCStatus(err)
 }
 
+// LibraryUpgradePreview previews the changes of a library upgrade
+func (s *ArduinoCoreServerImpl) LibraryUpgradePreview(ctx context.Context, req *rpc.LibraryUpgradePreviewRequest) (*rpc.LibraryUpgradePreviewResponse, error) {
+	resp, err := lib.LibraryUpgradePreview(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // LibraryResolveDependencies FIXMEDOC
 func (s *ArduinoCoreServerImpl) LibraryResolveDependencies(ctx context.Context, req *rpc.LibraryResolveDependenciesRequest) (*rpc.LibraryResolveDependenciesResponse, error) {
 	resp, err := lib.LibraryResolveDependencies(ctx, req)
@@ -456,12 +856,24 @@ func (s *ArduinoCoreServerImpl) LibraryList(ctx context.Context, req *rpc.Librar
 	return resp, convertErrorToRPCStatus(err)
 }
 
+// RescanLibraries reloads the libraries contained in the given directories
+func (s *ArduinoCoreServerImpl) RescanLibraries(ctx context.Context, req *rpc.RescanLibrariesRequest) (*rpc.RescanLibrariesResponse, error) {
+	resp, err := lib.RescanLibraries(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // ArchiveSketch FIXMEDOC
 func (s *ArduinoCoreServerImpl) ArchiveSketch(ctx context.Context, req *rpc.ArchiveSketchRequest) (*rpc.ArchiveSketchResponse, error) {
 	resp, err := sketch.ArchiveSketch(ctx, req)
 	return resp, convertErrorToRPCStatus(err)
 }
 
+// FlattenSketch merges the files of a sketch in a single source file
+func (s *ArduinoCoreServerImpl) FlattenSketch(ctx context.Context, req *rpc.FlattenSketchRequest) (*rpc.FlattenSketchResponse, error) {
+	resp, err := sketch.FlattenSketch(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
 // ZipLibraryInstall FIXMEDOC
 func (s *ArduinoCoreServerImpl) ZipLibraryInstall(req *rpc.ZipLibraryInstallRequest, stream rpc.ArduinoCoreService_ZipLibraryInstallServer) error {
 	syncSend := NewSynchronizedSend(stream.Send)
@@ -510,6 +922,15 @@ func (s *ArduinoCoreServerImpl) Monitor(stream rpc.ArduinoCoreService_MonitorSer
 	// Send a message with Success set to true to notify the caller of the port being now active
 	_ = syncSend.Send(&rpc.MonitorResponse{Success: true})
 
+	// Notify the caller when the port is suspended because of an upload running on the same port
+	portProxy.SetStateChangedCallback(func(suspended bool, err error) {
+		stateChange := &rpc.MonitorPortStateChange{Suspended: suspended}
+		if err != nil {
+			stateChange.Error = err.Error()
+		}
+		syncSend.Send(&rpc.MonitorResponse{PortStateChange: stateChange})
+	})
+
 	cancelCtx, cancel := context.WithCancel(stream.Context())
 	gracefulCloseInitiated := &atomic.Bool{}
 	gracefuleCloseCtx, gracefulCloseCancel := context.WithCancel(context.Background())
@@ -595,3 +1016,15 @@ func (s *ArduinoCoreServerImpl) CleanDownloadCacheDirectory(ctx context.Context,
 	resp, err := cache.CleanDownloadCacheDirectory(ctx, req)
 	return resp, convertErrorToRPCStatus(err)
 }
+
+// Cleanup removes the unused files from the data directory
+func (s *ArduinoCoreServerImpl) Cleanup(ctx context.Context, req *rpc.CleanupRequest) (*rpc.CleanupResponse, error) {
+	resp, err := commands.Cleanup(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
+
+// DiskUsage reports the disk usage of the data directory
+func (s *ArduinoCoreServerImpl) DiskUsage(ctx context.Context, req *rpc.DiskUsageRequest) (*rpc.DiskUsageResponse, error) {
+	resp, err := commands.DiskUsage(ctx, req)
+	return resp, convertErrorToRPCStatus(err)
+}
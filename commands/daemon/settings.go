@@ -75,6 +75,11 @@ func (s *ArduinoCoreServerImpl) SettingsMerge(ctx context.Context, req *rpc.Sett
 	}
 
 	mapped := mapper(toMerge)
+	for k := range mapped {
+		if err := configuration.CheckSettingChangeAllowed(configuration.Settings, k); err != nil {
+			return nil, convertErrorToRPCStatus(err)
+		}
+	}
 
 	// Set each value individually.
 	// This is done because Viper ignores empty strings or maps when
@@ -125,6 +130,9 @@ func (s *ArduinoCoreServerImpl) SettingsSetValue(ctx context.Context, val *rpc.S
 	key := val.GetKey()
 	var value interface{}
 
+	if err := configuration.CheckSettingChangeAllowed(configuration.Settings, key); err != nil {
+		return nil, convertErrorToRPCStatus(err)
+	}
 	err := json.Unmarshal([]byte(val.GetJsonData()), &value)
 	if err == nil {
 		configuration.Settings.Set(key, value)
@@ -158,6 +166,9 @@ func (s *ArduinoCoreServerImpl) SettingsDelete(ctx context.Context, req *rpc.Set
 			keys = append(keys, k)
 			continue
 		}
+		if err := configuration.CheckSettingChangeAllowed(configuration.Settings, k); err != nil {
+			return nil, convertErrorToRPCStatus(err)
+		}
 		keyExists = true
 	}
 
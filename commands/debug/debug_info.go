@@ -29,6 +29,7 @@ import (
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/arduino/go-paths-helper"
 	"github.com/arduino/go-properties-orderedmap"
@@ -94,6 +95,7 @@ func getDebugProperties(req *rpc.GetDebugConfigRequest, pme *packagemanager.Expl
 		sketchName             string
 		sketchDefaultFQBN      string
 		sketchDefaultBuildPath *paths.Path
+		sk                     *sketch.Sketch
 	)
 	if !skipSketchChecks {
 		// TODO: make a generic function to extract sketch from request
@@ -102,13 +104,13 @@ func getDebugProperties(req *rpc.GetDebugConfigRequest, pme *packagemanager.Expl
 			return nil, &cmderrors.MissingSketchPathError{}
 		}
 		sketchPath := paths.New(req.GetSketchPath())
-		sk, err := sketch.New(sketchPath)
+		var err error
+		sk, err = sketch.New(sketchPath)
 		if err != nil {
 			return nil, &cmderrors.CantOpenSketchError{Cause: err}
 		}
 		sketchName = sk.Name
 		sketchDefaultFQBN = sk.GetDefaultFQBN()
-		sketchDefaultBuildPath = sk.DefaultBuildPath()
 	} else {
 		// Use placeholder sketch data
 		sketchName = "Sketch"
@@ -128,6 +130,16 @@ func getDebugProperties(req *rpc.GetDebugConfigRequest, pme *packagemanager.Expl
 	if err != nil {
 		return nil, &cmderrors.InvalidFQBNError{Cause: err}
 	}
+	if sk != nil {
+		template, err := configuration.SketchBuildPathTemplate(configuration.Settings)
+		if err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid build path"), Cause: err}
+		}
+		sketchDefaultBuildPath, err = sk.BuildPath(template, fqbn.StringWithoutConfig())
+		if err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid build path"), Cause: err}
+		}
+	}
 
 	// Find target board and board properties
 	_, platformRelease, _, boardProperties, referencedPlatformRelease, err := pme.ResolveFQBN(fqbn)
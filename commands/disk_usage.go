@@ -0,0 +1,123 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/utils"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// DiskUsage reports the disk usage of the installed platforms and tools, of
+// the downloaded archives and of the build caches.
+func DiskUsage(ctx context.Context, req *rpc.DiskUsageRequest) (*rpc.DiskUsageResponse, error) {
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, &cmderrors.InvalidInstanceError{}
+	}
+	defer release()
+
+	res := &rpc.DiskUsageResponse{Entries: []*rpc.DiskUsageEntry{}}
+	for _, platformRelease := range pme.InstalledPlatformReleases() {
+		if !pme.IsManagedPlatformRelease(platformRelease) {
+			continue
+		}
+		res.Entries = append(res.Entries, &rpc.DiskUsageEntry{
+			Category: rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_PLATFORM,
+			Name:     platformRelease.String(),
+			Path:     platformRelease.InstallDir.String(),
+			Size:     utils.DirSize(platformRelease.InstallDir),
+		})
+	}
+
+	// The tools sharing the same copy are counted once in the total
+	countedDirs := map[string]bool{}
+	for _, tool := range pme.GetAllInstalledToolsReleases() {
+		if !pme.IsManagedToolRelease(tool) {
+			continue
+		}
+		storageDir, shared := pme.ToolStorageDir(tool)
+		entry := &rpc.DiskUsageEntry{
+			Category: rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_TOOL,
+			Name:     tool.String(),
+			Path:     tool.InstallDir.String(),
+			Size:     utils.DirSize(storageDir),
+			Shared:   shared,
+		}
+		res.Entries = append(res.Entries, entry)
+		if !countedDirs[storageDir.String()] {
+			countedDirs[storageDir.String()] = true
+			res.TotalSize += entry.GetSize()
+		}
+	}
+
+	filepath.WalkDir(pme.DownloadDir.String(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(pme.DownloadDir.String(), path)
+		if err != nil {
+			name = d.Name()
+		}
+		res.Entries = append(res.Entries, &rpc.DiskUsageEntry{
+			Category: rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_DOWNLOAD,
+			Name:     filepath.ToSlash(name),
+			Path:     path,
+			Size:     info.Size(),
+		})
+		return nil
+	})
+
+	for _, cacheDir := range buildCacheDirs() {
+		dirs, err := cacheDir.ReadDir()
+		if err != nil {
+			continue
+		}
+		dirs.FilterDirs()
+		for _, dir := range dirs {
+			res.Entries = append(res.Entries, &rpc.DiskUsageEntry{
+				Category: rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_BUILD_CACHE,
+				Name:     cacheDir.Base() + "/" + dir.Base(),
+				Path:     dir.String(),
+				Size:     utils.DirSize(dir),
+			})
+		}
+	}
+
+	for _, entry := range res.GetEntries() {
+		if entry.GetCategory() != rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_TOOL {
+			res.TotalSize += entry.GetSize()
+		}
+	}
+	sort.SliceStable(res.Entries, func(i, j int) bool {
+		a, b := res.Entries[i], res.Entries[j]
+		if a.GetCategory() != b.GetCategory() {
+			return a.GetCategory() < b.GetCategory()
+		}
+		return a.GetName() < b.GetName()
+	})
+	return res, nil
+}
@@ -25,14 +25,18 @@ import (
 	"time"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/datalock"
 	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/ble"
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packageindex"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
 	"github.com/arduino/arduino-cli/internal/arduino/globals"
+	"github.com/arduino/arduino-cli/internal/arduino/httpclient"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesindex"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesmanager"
+	"github.com/arduino/arduino-cli/internal/arduino/loopback"
 	"github.com/arduino/arduino-cli/internal/arduino/resources"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
 	"github.com/arduino/arduino-cli/internal/arduino/utils"
@@ -48,6 +52,12 @@ import (
 var tr = i18n.Tr
 
 func installTool(pm *packagemanager.PackageManager, tool *cores.ToolRelease, downloadCB rpc.DownloadProgressCB, taskCB rpc.TaskProgressCB) error {
+	unlock, err := datalock.Acquire(tr("installing tool %s", tool), taskCB)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	pme, release := pm.NewExplorer()
 	defer release()
 	taskCB(&rpc.TaskProgress{Name: tr("Downloading missing tool %s", tool)})
@@ -167,6 +177,15 @@ func Init(req *rpc.InitRequest, responseCallback func(r *rpc.InitResponse)) erro
 				responseError(e.ToRPCStatus())
 				continue
 			}
+			if err := configuration.CheckAdditionalURLAllowed(configuration.Settings, u); err != nil {
+				e := &cmderrors.InitFailedError{
+					Code:   codes.PermissionDenied,
+					Cause:  err,
+					Reason: rpc.FailedInstanceInitReason_FAILED_INSTANCE_INIT_REASON_INVALID_INDEX_URL,
+				}
+				responseError(e.ToRPCStatus())
+				continue
+			}
 			allPackageIndexUrls = append(allPackageIndexUrls, URL)
 		}
 	}
@@ -228,10 +247,16 @@ func Init(req *rpc.InitRequest, responseCallback func(r *rpc.InitResponse)) erro
 				responseError(s.ToRPCStatus())
 			}
 		} else {
-			// Load platforms from profile
-			errs := pmb.LoadHardwareForProfile(
-				profile, true, downloadCallback, taskCallback,
-			)
+			// Load platforms from profile, the missing ones are installed
+			var errs []error
+			if unlock, err := datalock.Acquire(tr("installing platforms of profile %s", profile.Name), taskCallback); err != nil {
+				errs = []error{err}
+			} else {
+				errs = pmb.LoadHardwareForProfile(
+					profile, true, downloadCallback, taskCallback,
+				)
+				unlock()
+			}
 			for _, err := range errs {
 				s := &cmderrors.PlatformLoadingError{Cause: err}
 				responseError(s.ToRPCStatus())
@@ -294,6 +319,20 @@ func Init(req *rpc.InitRequest, responseCallback func(r *rpc.InitResponse)) erro
 		s := &cmderrors.PlatformLoadingError{Cause: err}
 		responseError(s.ToRPCStatus())
 	}
+	if configuration.Settings.GetBool("loopback.enabled") {
+		if cmdLine, err := loopback.DiscoveryCommandLine(); err != nil {
+			responseError(status.New(codes.Internal, err.Error()))
+		} else {
+			pme.DiscoveryManager().Add("builtin:loopback-discovery", cmdLine...)
+		}
+	}
+	if configuration.Settings.GetBool("ble.enabled") {
+		if cmdLine, err := ble.DiscoveryCommandLine(); err != nil {
+			responseError(status.New(codes.Internal, err.Error()))
+		} else {
+			pme.DiscoveryManager().Add("builtin:ble-discovery", cmdLine...)
+		}
+	}
 
 	// Create library manager and add libraries directories
 	lmb := librariesmanager.NewBuilder()
@@ -348,7 +387,14 @@ func Init(req *rpc.InitRequest, responseCallback func(r *rpc.InitResponse)) erro
 			libRoot := configuration.ProfilesCacheDir(configuration.Settings).Join(uid)
 			libDir := libRoot.Join(libraryRef.Library)
 
-			if !libDir.IsDir() {
+			if !libDir.IsDir() && libraryRef.URL != nil {
+				if err := installProfileLibraryFromURL(pme.DownloadDir, libraryRef, libRoot, libDir, downloadCallback, taskCallback); err != nil {
+					taskCallback(&rpc.TaskProgress{Name: tr("Error installing library %s", libraryRef)})
+					e := &cmderrors.FailedLibraryInstallError{Cause: err}
+					responseError(e.ToRPCStatus())
+					continue
+				}
+			} else if !libDir.IsDir() {
 				// Download library
 				taskCallback(&rpc.TaskProgress{Name: tr("Downloading library %s", libraryRef)})
 				libRelease, err := li.FindRelease(libraryRef.Library, libraryRef.Version)
@@ -407,10 +453,100 @@ func Destroy(ctx context.Context, req *rpc.DestroyRequest) (*rpc.DestroyResponse
 	return &rpc.DestroyResponse{}, nil
 }
 
+// InstanceEvents returns a channel that receives the changes to the state
+// shared by the instances. The channel is closed when the context is canceled.
+func InstanceEvents(ctx context.Context, req *rpc.InstanceEventsRequest) (<-chan *rpc.InstanceEventsResponse, error) {
+	if !instances.IsValid(req.GetInstance()) {
+		return nil, &cmderrors.InvalidInstanceError{}
+	}
+
+	events, unsubscribe := instances.SubscribeEvents()
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	return events, nil
+}
+
+// installProfileLibraryFromURL installs in libDir a library of a profile that
+// is provided by a zip archive or a git repository instead of the libraries
+// index. The archives are kept in the downloads directory and are verified
+// against the checksum of the profile.
+func installProfileLibraryFromURL(downloadDir *paths.Path, libraryRef *sketch.ProfileLibraryReference, libRoot, libDir *paths.Path, downloadCB rpc.DownloadProgressCB, taskCB rpc.TaskProgressCB) error {
+	if libraryRef.IsGit() {
+		taskCB(&rpc.TaskProgress{Name: tr("Cloning library %s", libraryRef)})
+		if err := libRoot.MkdirAll(); err != nil {
+			return err
+		}
+		tmpDir, err := libRoot.MkTempDir("git-")
+		if err != nil {
+			return err
+		}
+		defer tmpDir.RemoveAll()
+		clonedDir := tmpDir.Join(libraryRef.Library)
+		if err := librariesmanager.CloneGitLibrary(libraryRef.URL.String(), clonedDir, nil); err != nil {
+			return err
+		}
+		if err := clonedDir.Rename(libDir); err != nil {
+			return err
+		}
+		taskCB(&rpc.TaskProgress{Completed: true})
+		return nil
+	}
+
+	archiveExt := filepath.Ext(libraryRef.URL.Path)
+	if archiveExt == "" {
+		archiveExt = ".zip"
+	}
+	resource := &resources.DownloadResource{
+		URL:             libraryRef.URL.String(),
+		ArchiveFileName: libraryRef.InternalUniqueIdentifier() + archiveExt,
+		Checksum:        libraryRef.Checksum,
+		CachePath:       "libraries",
+	}
+	archive, err := resource.ArchivePath(downloadDir)
+	if err != nil {
+		return err
+	}
+	if !archive.Exist() {
+		taskCB(&rpc.TaskProgress{Name: tr("Downloading library %s", libraryRef)})
+		if err := httpclient.DownloadFile(archive, resource.URL, "", libraryRef.String(), downloadCB, nil); err != nil {
+			_ = archive.Remove()
+			return err
+		}
+		taskCB(&rpc.TaskProgress{Completed: true})
+	}
+	// The size of the archive is not known in advance, the checksum is
+	// enough to verify it.
+	if info, err := archive.Stat(); err != nil {
+		return err
+	} else {
+		resource.Size = info.Size()
+	}
+	if ok, err := resource.TestLocalArchiveChecksum(downloadDir); err != nil || !ok {
+		// Remove the archive so that it's downloaded again on the next run
+		_ = archive.Remove()
+		return fmt.Errorf(tr("the checksum of the archive of library %[1]s doesn't match the one in the profile: %[2]v", libraryRef.Library, err))
+	}
+
+	taskCB(&rpc.TaskProgress{Name: tr("Installing library %s", libraryRef)})
+	if err := resource.Install(downloadDir, libRoot, libDir); err != nil {
+		return err
+	}
+	taskCB(&rpc.TaskProgress{Completed: true})
+	return nil
+}
+
 // UpdateLibrariesIndex updates the library_index.json
 func UpdateLibrariesIndex(ctx context.Context, req *rpc.UpdateLibrariesIndexRequest, downloadCB rpc.DownloadProgressCB) (*rpc.UpdateLibrariesIndexResponse_Result, error) {
 	logrus.Info("Updating libraries index")
 
+	unlock, err := datalock.Acquire(tr("updating libraries index"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
 	if err != nil {
 		return nil, err
@@ -446,6 +582,7 @@ func UpdateLibrariesIndex(ctx context.Context, req *rpc.UpdateLibrariesIndexRequ
 	if err := globals.LibrariesIndexResource.Download(indexDir, downloadCB); err != nil {
 		return nil, err
 	}
+	instances.NotifyEvent(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARY_INDEX_UPDATED, "")
 
 	return result(rpc.IndexUpdateReport_STATUS_UPDATED), nil
 }
@@ -456,6 +593,12 @@ func UpdateIndex(ctx context.Context, req *rpc.UpdateIndexRequest, downloadCB rp
 		return nil, &cmderrors.InvalidInstanceError{}
 	}
 
+	unlock, err := datalock.Acquire(tr("updating package index"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	report := func(indexURL *url.URL, status rpc.IndexUpdateReport_Status) *rpc.IndexUpdateReport {
 		return &rpc.IndexUpdateReport{
 			IndexUrl: indexURL.String(),
@@ -471,6 +614,7 @@ func UpdateIndex(ctx context.Context, req *rpc.UpdateIndexRequest, downloadCB rp
 	}
 
 	failed := false
+	updated := false
 	result := &rpc.UpdateIndexResponse_Result{}
 	for _, u := range urls {
 		URL, err := url.Parse(u)
@@ -483,6 +627,15 @@ func UpdateIndex(ctx context.Context, req *rpc.UpdateIndexRequest, downloadCB rp
 			result.UpdatedIndexes = append(result.GetUpdatedIndexes(), report(URL, rpc.IndexUpdateReport_STATUS_FAILED))
 			continue
 		}
+		if u != globals.DefaultIndexURL {
+			if err := configuration.CheckAdditionalURLAllowed(configuration.Settings, u); err != nil {
+				downloadCB.Start(u, tr("Downloading index: %s", u))
+				downloadCB.End(false, err.Error())
+				failed = true
+				result.UpdatedIndexes = append(result.GetUpdatedIndexes(), report(URL, rpc.IndexUpdateReport_STATUS_FAILED))
+				continue
+			}
+		}
 
 		logrus.WithField("url", URL).Print("Updating index")
 
@@ -532,10 +685,15 @@ func UpdateIndex(ctx context.Context, req *rpc.UpdateIndexRequest, downloadCB rp
 			failed = true
 			result.UpdatedIndexes = append(result.GetUpdatedIndexes(), report(URL, rpc.IndexUpdateReport_STATUS_FAILED))
 		} else {
+			updated = true
 			result.UpdatedIndexes = append(result.GetUpdatedIndexes(), report(URL, rpc.IndexUpdateReport_STATUS_UPDATED))
 		}
 	}
 
+	if updated {
+		instances.NotifyEvent(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PACKAGE_INDEX_UPDATED, "")
+	}
+
 	if failed {
 		return result, &cmderrors.FailedDownloadError{Message: tr("Some indexes could not be updated.")}
 	}
@@ -0,0 +1,93 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallProfileLibraryFromURL(t *testing.T) {
+	configuration.Settings = configuration.Init("")
+	downloadCB := rpc.DownloadProgressCB(func(*rpc.DownloadProgress) {})
+	taskCB := rpc.TaskProgressCB(func(*rpc.TaskProgress) {})
+
+	archive := &bytes.Buffer{}
+	w := zip.NewWriter(archive)
+	f, err := w.Create("MyLib-1.0.0/src/MyLib.h")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("void f();\n"))
+	require.NoError(t, err)
+	_, err = w.Create("MyLib-1.0.0/library.properties")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	sum := sha256.Sum256(archive.Bytes())
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive.Bytes())
+	}))
+	defer ts.Close()
+
+	downloadDir := paths.New(t.TempDir())
+	libRoot := paths.New(t.TempDir())
+	archiveURL, err := url.Parse(ts.URL + "/MyLib-1.0.0.zip")
+	require.NoError(t, err)
+
+	// Checksum mismatch
+	ref := &sketch.ProfileLibraryReference{Library: "MyLib", URL: archiveURL, Checksum: "SHA-256:" + hex.EncodeToString(make([]byte, 32))}
+	err = installProfileLibraryFromURL(downloadDir, ref, libRoot, libRoot.Join("MyLib"), downloadCB, taskCB)
+	require.ErrorContains(t, err, "checksum")
+	require.NoDirExists(t, libRoot.Join("MyLib").String())
+
+	ref.Checksum = "SHA-256:" + hex.EncodeToString(sum[:])
+	require.NoError(t, installProfileLibraryFromURL(downloadDir, ref, libRoot, libRoot.Join("MyLib"), downloadCB, taskCB))
+	require.FileExists(t, libRoot.Join("MyLib", "src", "MyLib.h").String())
+	require.FileExists(t, downloadDir.Join("libraries", ref.InternalUniqueIdentifier()+".zip").String())
+
+	// Git repository
+	repoDir := paths.New(t.TempDir()).Join("MyDriver.git")
+	repo, err := git.PlainInit(repoDir.String(), false)
+	require.NoError(t, err)
+	require.NoError(t, repoDir.Join("MyDriver.h").WriteFile([]byte("void g();\n")))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add("MyDriver.h")
+	require.NoError(t, err)
+	hash, err := wt.Commit("first", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}})
+	require.NoError(t, err)
+
+	gitURL, err := url.Parse(repoDir.String() + "#" + hash.String())
+	require.NoError(t, err)
+	ref = &sketch.ProfileLibraryReference{Library: "MyDriver", URL: gitURL}
+	require.True(t, ref.IsGit())
+	require.NoError(t, installProfileLibraryFromURL(downloadDir, ref, libRoot, libRoot.Join("MyDriver"), downloadCB, taskCB))
+	require.FileExists(t, libRoot.Join("MyDriver", "MyDriver.h").String())
+	require.NoDirExists(t, libRoot.Join("MyDriver", ".git").String())
+}
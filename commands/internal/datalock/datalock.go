@@ -0,0 +1,142 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalock
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+var tr = i18n.Tr
+
+// lockFileName is the name of the lock file inside the data directory. The
+// file is never removed: removing it would let two processes lock different
+// files with the same name.
+const lockFileName = ".lock"
+
+// pollInterval is the time between two attempts to acquire a busy lock
+var pollInterval = 100 * time.Millisecond
+
+// errLocked is returned by tryLock if the lock is held by another process
+var errLocked = errors.New("locked")
+
+// Holder describes the process holding the lock on the data directory.
+type Holder struct {
+	PID       int       `json:"pid"`
+	Hostname  string    `json:"hostname"`
+	Operation string    `json:"operation"`
+	Since     time.Time `json:"since"`
+}
+
+func (h *Holder) String() string {
+	if h == nil {
+		return tr("an unknown process")
+	}
+	return tr("process %[1]d on %[2]s (%[3]s, since %[4]s)", h.PID, h.Hostname, h.Operation, h.Since.Format(time.RFC3339))
+}
+
+// Acquire obtains an exclusive advisory lock on the data directory, shared
+// by all the CLI and daemon processes using it, before performing the given
+// operation. If the lock is held by another operation, taskCB is notified and
+// Acquire waits up to the timeout set in the `locking.wait_timeout` setting.
+// The returned function must be called to release the lock.
+func Acquire(operation string, taskCB rpc.TaskProgressCB) (release func(), err error) {
+	dataDir := configuration.DataDir(configuration.Settings)
+	timeout := configuration.Settings.GetDuration("locking.wait_timeout")
+	return AcquireDir(dataDir, operation, timeout, taskCB)
+}
+
+// AcquireDir obtains an exclusive advisory lock on the given directory, see
+// Acquire.
+func AcquireDir(dir *paths.Path, operation string, timeout time.Duration, taskCB rpc.TaskProgressCB) (func(), error) {
+	if err := dir.MkdirAll(); err != nil {
+		return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot create data directory"), Cause: err}
+	}
+	lockFile := dir.Join(lockFileName)
+	f, err := os.OpenFile(lockFile.String(), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot open the lock file %s", lockFile), Cause: err}
+	}
+
+	deadline := time.Now().Add(timeout)
+	notified := false
+	for {
+		err := tryLock(f)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errLocked) {
+			f.Close()
+			return nil, &cmderrors.PermissionDeniedError{Message: tr("Cannot lock the data directory"), Cause: err}
+		}
+		holder := readHolder(lockFile)
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, &cmderrors.DataDirectoryLockedError{Holder: holder.String()}
+		}
+		if !notified {
+			notified = true
+			logrus.WithField("holder", holder).Info("Waiting for the data directory lock")
+			if taskCB != nil {
+				taskCB(&rpc.TaskProgress{Message: tr("Waiting for %s to release the data directory lock...", holder)})
+			}
+		}
+		time.Sleep(pollInterval)
+	}
+
+	// Record the holder of the lock, so the processes waiting for it can
+	// report who is blocking them
+	hostname, _ := os.Hostname()
+	holder := &Holder{PID: os.Getpid(), Hostname: hostname, Operation: operation, Since: time.Now()}
+	if data, err := json.Marshal(holder); err == nil {
+		if err := f.Truncate(0); err == nil {
+			f.WriteAt(data, 0)
+		}
+	}
+	logrus.WithField("operation", operation).Debug("Acquired data directory lock")
+
+	return func() {
+		f.Truncate(0)
+		if err := unlock(f); err != nil {
+			logrus.WithError(err).Warn("Error releasing the data directory lock")
+		}
+		f.Close()
+		logrus.WithField("operation", operation).Debug("Released data directory lock")
+	}, nil
+}
+
+// readHolder returns the holder recorded in the lock file, or nil if it's
+// unknown.
+func readHolder(lockFile *paths.Path) *Holder {
+	data, err := lockFile.ReadFile()
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	var holder Holder
+	if err := json.Unmarshal(data, &holder); err != nil {
+		return nil
+	}
+	return &holder
+}
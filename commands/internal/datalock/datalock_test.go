@@ -0,0 +1,60 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalock
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireDir(t *testing.T) {
+	dir := paths.New(t.TempDir())
+	unlock, err := AcquireDir(dir, "core install arduino:avr", time.Second, nil)
+	require.NoError(t, err)
+
+	holder := readHolder(dir.Join(lockFileName))
+	require.NotNil(t, holder)
+	require.Equal(t, os.Getpid(), holder.PID)
+	require.Equal(t, "core install arduino:avr", holder.Operation)
+
+	// A concurrent operation reports the holder of the lock on timeout
+	messages := []string{}
+	taskCB := func(msg *rpc.TaskProgress) { messages = append(messages, msg.GetMessage()) }
+	_, err = AcquireDir(dir, "core uninstall arduino:avr", 200*time.Millisecond, taskCB)
+	var lockedErr *cmderrors.DataDirectoryLockedError
+	require.True(t, errors.As(err, &lockedErr))
+	require.Contains(t, lockedErr.Holder, "core install arduino:avr")
+	require.Len(t, messages, 1)
+	require.Contains(t, messages[0], "core install arduino:avr")
+
+	// ...or acquires it once released
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		unlock()
+	}()
+	unlock, err = AcquireDir(dir, "core uninstall arduino:avr", 5*time.Second, nil)
+	require.NoError(t, err)
+	require.Equal(t, "core uninstall arduino:avr", readHolder(dir.Join(lockFileName)).Operation)
+	unlock()
+	require.Nil(t, readHolder(dir.Join(lockFileName)))
+}
@@ -0,0 +1,115 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package instances
+
+import (
+	"sync"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	semver "go.bug.st/relaxed-semver"
+)
+
+// eventsQueueSize is the number of events buffered for each subscriber,
+// events are dropped if a subscriber is too slow to consume them.
+const eventsQueueSize = 32
+
+var subscribers = map[chan *rpc.InstanceEventsResponse]bool{}
+var subscribersMux sync.Mutex
+
+// SubscribeEvents returns a channel that receives all the events notified
+// with NotifyEvent. The returned function must be called to unsubscribe,
+// after that the channel is closed.
+func SubscribeEvents() (<-chan *rpc.InstanceEventsResponse, func()) {
+	ch := make(chan *rpc.InstanceEventsResponse, eventsQueueSize)
+	subscribersMux.Lock()
+	subscribers[ch] = true
+	subscribersMux.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			subscribersMux.Lock()
+			delete(subscribers, ch)
+			subscribersMux.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// NotifyEvent sends an event to all the subscribers. The source instance
+// may be nil if the event is not caused by a request to an instance.
+func NotifyEvent(source *rpc.Instance, eventType rpc.InstanceEventType, details string) {
+	notify(&rpc.InstanceEventsResponse{
+		Type:             eventType,
+		SourceInstanceId: source.GetId(),
+		Details:          details,
+	})
+}
+
+// NotifyComponentChange sends an event describing the install, upgrade or
+// uninstall of a platform or library to all the subscribers and to the
+// webhooks set in the configuration.
+func NotifyComponentChange(source *rpc.Instance, eventType rpc.InstanceEventType, change *rpc.ComponentChange) {
+	event := &rpc.InstanceEventsResponse{
+		Type:             eventType,
+		SourceInstanceId: source.GetId(),
+		Details:          change.GetId(),
+		ComponentChange:  change,
+	}
+	notify(event)
+	postWebhooks(event)
+}
+
+// ComponentChange returns the change of the component with the given id from
+// the previous to the current version. The previous version is nil if the
+// component has been installed and the current one is nil if the component
+// has been uninstalled.
+func ComponentChange(id string, previous, current *semver.Version) *rpc.ComponentChange {
+	change := &rpc.ComponentChange{Id: id}
+	if previous != nil {
+		change.PreviousVersion = previous.String()
+	}
+	if current != nil {
+		change.Version = current.String()
+	}
+	switch {
+	case previous == nil:
+		change.Action = rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_INSTALLED
+	case current == nil:
+		change.Action = rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_UNINSTALLED
+	case current.GreaterThan(previous):
+		change.Action = rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_UPGRADED
+	case current.Equal(previous):
+		change.Action = rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_REINSTALLED
+	default:
+		change.Action = rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_DOWNGRADED
+	}
+	return change
+}
+
+func notify(event *rpc.InstanceEventsResponse) {
+	subscribersMux.Lock()
+	defer subscribersMux.Unlock()
+	for ch := range subscribers {
+		select {
+		case ch <- event:
+		default:
+			logrus.Warnf("Dropped instance event %s: subscriber queue full", event.GetType())
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package instances
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+	semver "go.bug.st/relaxed-semver"
+)
+
+func TestEvents(t *testing.T) {
+	ch1, unsubscribe1 := SubscribeEvents()
+	ch2, unsubscribe2 := SubscribeEvents()
+	defer unsubscribe2()
+
+	NotifyEvent(&rpc.Instance{Id: 5}, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PLATFORMS_CHANGED, "arduino:avr")
+	for _, ch := range []<-chan *rpc.InstanceEventsResponse{ch1, ch2} {
+		ev := <-ch
+		require.Equal(t, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_PLATFORMS_CHANGED, ev.GetType())
+		require.Equal(t, int32(5), ev.GetSourceInstanceId())
+		require.Equal(t, "arduino:avr", ev.GetDetails())
+	}
+
+	unsubscribe1()
+	unsubscribe1()
+	_, ok := <-ch1
+	require.False(t, ok)
+
+	// A slow subscriber must not block the notifier
+	for i := 0; i < eventsQueueSize*2; i++ {
+		NotifyEvent(nil, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_SKETCHBOOK_CHANGED, "")
+	}
+	require.Len(t, ch2, eventsQueueSize)
+	require.Zero(t, (<-ch2).GetSourceInstanceId())
+}
+
+func TestComponentChange(t *testing.T) {
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	tests := []struct {
+		previous, current *semver.Version
+		action            rpc.ComponentChangeAction
+	}{
+		{nil, v1, rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_INSTALLED},
+		{v1, v2, rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_UPGRADED},
+		{v2, v1, rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_DOWNGRADED},
+		{v1, v1, rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_REINSTALLED},
+		{v1, nil, rpc.ComponentChangeAction_COMPONENT_CHANGE_ACTION_UNINSTALLED},
+	}
+	for _, test := range tests {
+		change := ComponentChange("arduino:avr", test.previous, test.current)
+		require.Equal(t, test.action, change.GetAction())
+		require.Equal(t, "arduino:avr", change.GetId())
+		if test.current != nil {
+			require.Equal(t, test.current.String(), change.GetVersion())
+		}
+		if test.previous != nil {
+			require.Equal(t, test.previous.String(), change.GetPreviousVersion())
+		}
+	}
+}
+
+func TestNotifyComponentChange(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		payload := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		received <- payload
+	}))
+	defer server.Close()
+
+	configuration.Settings = configuration.Init("")
+	configuration.Settings.Set("notifications.webhooks", []string{server.URL})
+	defer func() { configuration.Settings = nil }()
+
+	ch, unsubscribe := SubscribeEvents()
+	defer unsubscribe()
+
+	change := ComponentChange("Servo", semver.MustParse("1.1.0"), semver.MustParse("1.2.0"))
+	NotifyComponentChange(&rpc.Instance{Id: 1}, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, change)
+
+	ev := <-ch
+	require.Equal(t, "Servo", ev.GetDetails())
+	require.Equal(t, "1.2.0", ev.GetComponentChange().GetVersion())
+
+	payload := <-received
+	require.Equal(t, "INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED", payload["type"])
+	componentChange := payload["component_change"].(map[string]interface{})
+	require.Equal(t, "COMPONENT_CHANGE_ACTION_UPGRADED", componentChange["action"])
+	require.Equal(t, "1.1.0", componentChange["previous_version"])
+}
+
+func TestNotifyComponentChangeDoesNotWaitWebhooks(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	configuration.Settings = configuration.Init("")
+	configuration.Settings.Set("notifications.webhooks", []string{server.URL})
+	defer func() { configuration.Settings = nil }()
+
+	// A webhook that doesn't answer must not stall the notifier
+	done := make(chan struct{})
+	go func() {
+		change := ComponentChange("Servo", nil, semver.MustParse("1.2.0"))
+		NotifyComponentChange(&rpc.Instance{Id: 1}, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, change)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(webhookTimeout / 2):
+		require.FailNow(t, "NotifyComponentChange waited for the webhook")
+	}
+}
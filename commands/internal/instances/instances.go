@@ -160,6 +160,17 @@ func Create(dataDir, packagesDir, downloadsDir *paths.Path, extraUserAgent ...st
 	return &rpc.Instance{Id: id}, nil
 }
 
+// All returns all the existing instances.
+func All() []*rpc.Instance {
+	instancesMux.Lock()
+	defer instancesMux.Unlock()
+	res := []*rpc.Instance{}
+	for id := range instances {
+		res = append(res, &rpc.Instance{Id: id})
+	}
+	return res
+}
+
 // IsValid returns true if the given instance is valid.
 func IsValid(inst *rpc.Instance) bool {
 	instancesMux.Lock()
@@ -0,0 +1,83 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package instances
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/arduino/httpclient"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// webhookTimeout is the maximum time spent delivering an event to a webhook.
+const webhookTimeout = 10 * time.Second
+
+// postWebhooks sends the event, encoded in JSON, to the URLs set in the
+// `notifications.webhooks` setting. The event is delivered in the background
+// and the failures are only logged: a slow or broken webhook must not delay
+// or make fail the operation that caused the event.
+func postWebhooks(event *rpc.InstanceEventsResponse) {
+	if configuration.Settings == nil {
+		return
+	}
+	urls := configuration.Settings.GetStringSlice("notifications.webhooks")
+	if len(urls) == 0 {
+		return
+	}
+	payload, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(event)
+	if err != nil {
+		logrus.WithError(err).Error("Error encoding webhook payload")
+		return
+	}
+	client, err := httpclient.New()
+	if err != nil {
+		logrus.WithError(err).Error("Error creating webhook client")
+		return
+	}
+	for _, url := range urls {
+		go func(url string) {
+			if err := postWebhook(client, url, payload); err != nil {
+				logrus.WithError(err).WithField("url", url).Warn("Error sending event to webhook")
+			}
+		}(url)
+	}
+}
+
+func postWebhook(client *http.Client, url string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package portlock
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Suspendable is a user of a port that can temporarily give up the port
+// to let another operation (for example an upload) use it.
+type Suspendable interface {
+	// Suspend releases the port, it may be called only on a running session.
+	Suspend() error
+	// Resume reacquires the port after a Suspend.
+	Resume() error
+}
+
+type portKey struct {
+	protocol string
+	address  string
+}
+
+type registration struct {
+	session Suspendable
+}
+
+var sessions = map[portKey]map[*registration]bool{}
+var uploadLocks = map[portKey]*sync.Mutex{}
+var mux sync.Mutex
+
+// Register adds a session that is using the port identified by the given
+// protocol and address. The session will be suspended while an exclusive
+// lock on the same port is held. The returned function must be called
+// to unregister the session once the port is closed.
+func Register(protocol, address string, session Suspendable) (unregister func()) {
+	key := portKey{protocol: protocol, address: address}
+	reg := &registration{session: session}
+
+	mux.Lock()
+	if sessions[key] == nil {
+		sessions[key] = map[*registration]bool{}
+	}
+	sessions[key][reg] = true
+	mux.Unlock()
+
+	return func() {
+		mux.Lock()
+		delete(sessions[key], reg)
+		if len(sessions[key]) == 0 {
+			delete(sessions, key)
+		}
+		mux.Unlock()
+	}
+}
+
+// Acquire obtains an exclusive lock on the port identified by the given
+// protocol and address. All the sessions registered on the same port are
+// suspended until the returned release function is called. Concurrent calls
+// to Acquire on the same port are serialized.
+func Acquire(protocol, address string) (release func()) {
+	key := portKey{protocol: protocol, address: address}
+
+	mux.Lock()
+	lock, ok := uploadLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		uploadLocks[key] = lock
+	}
+	mux.Unlock()
+	lock.Lock()
+
+	mux.Lock()
+	toSuspend := []*registration{}
+	for reg := range sessions[key] {
+		toSuspend = append(toSuspend, reg)
+	}
+	mux.Unlock()
+
+	suspended := []*registration{}
+	for _, reg := range toSuspend {
+		if err := reg.session.Suspend(); err != nil {
+			logrus.WithError(err).WithField("port", key).Warn("Could not suspend port session")
+			continue
+		}
+		suspended = append(suspended, reg)
+	}
+
+	return func() {
+		for _, reg := range suspended {
+			if err := reg.session.Resume(); err != nil {
+				logrus.WithError(err).WithField("port", key).Warn("Could not resume port session")
+			}
+		}
+		lock.Unlock()
+	}
+}
@@ -0,0 +1,54 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package portlock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	events []string
+}
+
+func (s *fakeSession) Suspend() error {
+	s.events = append(s.events, "suspend")
+	return nil
+}
+
+func (s *fakeSession) Resume() error {
+	s.events = append(s.events, "resume")
+	return nil
+}
+
+func TestAcquireSuspendsSessionsOnSamePort(t *testing.T) {
+	onPort := &fakeSession{}
+	onOtherPort := &fakeSession{}
+	unregister := Register("serial", "/dev/ttyACM0", onPort)
+	defer Register("serial", "/dev/ttyACM1", onOtherPort)()
+
+	release := Acquire("serial", "/dev/ttyACM0")
+	require.Equal(t, []string{"suspend"}, onPort.events)
+	release()
+	require.Equal(t, []string{"suspend", "resume"}, onPort.events)
+	require.Empty(t, onOtherPort.events)
+
+	// Unregistered sessions are no more suspended
+	unregister()
+	Acquire("serial", "/dev/ttyACM0")()
+	require.Equal(t, []string{"suspend", "resume"}, onPort.events)
+}
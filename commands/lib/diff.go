@@ -0,0 +1,119 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+
+	"github.com/arduino/arduino-cli/commands"
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesapi"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesindex"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+// LibraryDiff downloads two versions of a library and compares the public
+// API declared in their headers.
+// A DownloadProgressCB callback function must be passed to monitor download progress.
+func LibraryDiff(ctx context.Context, req *rpc.LibraryDiffRequest, downloadCB rpc.DownloadProgressCB) (*rpc.LibraryDiffResponse_Result, error) {
+	logrus.Info("Executing `arduino-cli lib diff`")
+
+	if req.GetFromVersion() == "" || req.GetToVersion() == "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Both the versions to compare must be specified")}
+	}
+
+	var downloadsDir *paths.Path
+	if pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance()); err != nil {
+		return nil, err
+	} else {
+		downloadsDir = pme.DownloadDir
+		release()
+	}
+
+	li, err := instances.GetLibrariesIndex(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+
+	releases := []*librariesindex.Release{}
+	for _, v := range []string{req.GetFromVersion(), req.GetToVersion()} {
+		version, err := commands.ParseVersion(v)
+		if err != nil {
+			return nil, err
+		}
+		release, err := li.FindRelease(req.GetName(), version)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+
+	tmpDir, err := paths.MkTempDir("", "lib-diff-")
+	if err != nil {
+		return nil, &cmderrors.TempDirCreationFailedError{Cause: err}
+	}
+	defer tmpDir.RemoveAll()
+
+	apis := [][]*librariesapi.Declaration{}
+	for _, release := range releases {
+		if err := downloadLibrary(downloadsDir, release, downloadCB, func(*rpc.TaskProgress) {}, "download"); err != nil {
+			return nil, err
+		}
+		libDir := tmpDir.Join(release.GetVersion().String())
+		if err := release.Resource.Install(downloadsDir, tmpDir.Join("extract"), libDir); err != nil {
+			return nil, &cmderrors.FailedInstallError{Message: tr("Error extracting %s", release), Cause: err}
+		}
+		library, err := libraries.Load(libDir, libraries.User)
+		if err != nil {
+			return nil, &cmderrors.InvalidLibraryError{Cause: err}
+		}
+		api, err := librariesapi.ParseLibrary(library)
+		if err != nil {
+			return nil, &cmderrors.InvalidLibraryError{Cause: err}
+		}
+		apis = append(apis, api)
+	}
+
+	res := &rpc.LibraryDiffResponse_Result{Changes: []*rpc.LibraryAPIChange{}}
+	for _, change := range librariesapi.Diff(apis[0], apis[1]) {
+		decl := change.Declaration()
+		rpcChange := &rpc.LibraryAPIChange{
+			Header:     decl.Header,
+			Symbol:     decl.Name,
+			SymbolKind: string(decl.Kind),
+		}
+		switch change.Kind {
+		case librariesapi.Added:
+			rpcChange.Kind = rpc.LibraryAPIChange_KIND_ADDED
+		case librariesapi.Removed:
+			rpcChange.Kind = rpc.LibraryAPIChange_KIND_REMOVED
+		case librariesapi.Changed:
+			rpcChange.Kind = rpc.LibraryAPIChange_KIND_CHANGED
+		}
+		if change.Old != nil {
+			rpcChange.OldDeclaration = change.Old.Signature
+		}
+		if change.New != nil {
+			rpcChange.NewDeclaration = change.New.Signature
+		}
+		res.Changes = append(res.Changes, rpcChange)
+	}
+	return res, nil
+}
@@ -26,9 +26,11 @@ import (
 	"github.com/arduino/arduino-cli/internal/arduino/libraries"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesindex"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesmanager"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/arduino/go-paths-helper"
 	"github.com/sirupsen/logrus"
+	semver "go.bug.st/relaxed-semver"
 )
 
 // LibraryInstall resolves the library dependencies, then downloads and installs the libraries into the install location.
@@ -117,6 +119,7 @@ func LibraryInstall(ctx context.Context, req *rpc.LibraryInstallRequest, downloa
 		libReleasesToInstall[libRelease] = installTask
 	}
 
+	// Download all the libraries before installing any of them
 	for libRelease, installTask := range libReleasesToInstall {
 		// Checks if libRelease is the requested library and not a dependency
 		downloadReason := "depends"
@@ -132,38 +135,96 @@ func LibraryInstall(ctx context.Context, req *rpc.LibraryInstallRequest, downloa
 		if err := downloadLibrary(downloadsDir, libRelease, downloadCB, taskCB, downloadReason); err != nil {
 			return err
 		}
-		if err := installLibrary(lmi, downloadsDir, libRelease, installTask, taskCB); err != nil {
+	}
+
+	// Install the libraries, if any of them fails the ones already installed are
+	// removed and the replaced ones are restored
+	installed := []*installedLibrary{}
+	for libRelease, installTask := range libReleasesToInstall {
+		lib, err := installLibrary(downloadsDir, libRelease, installTask, taskCB)
+		if err != nil {
+			for _, lib := range installed {
+				lib.rollback(taskCB)
+			}
 			return err
 		}
+		installed = append(installed, lib)
+	}
+	for _, lib := range installed {
+		lib.commit()
 	}
 
 	if err := commands.Init(&rpc.InitRequest{Instance: req.GetInstance()}, nil); err != nil {
 		return err
 	}
+	for _, lib := range installed {
+		instances.NotifyComponentChange(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, lib.change)
+	}
 
 	return nil
 }
 
-func installLibrary(lmi *librariesmanager.Installer, downloadsDir *paths.Path, libRelease *librariesindex.Release, installTask *librariesmanager.LibraryInstallPlan, taskCB rpc.TaskProgressCB) error {
+// installedLibrary is a library installed by LibraryInstall, the library
+// it replaced is kept aside until the whole installation is completed.
+type installedLibrary struct {
+	release     *librariesindex.Release
+	installPath *paths.Path
+	replaced    *resources.DirBackup
+	change      *rpc.ComponentChange
+}
+
+// rollback removes the installed library and restores the replaced one.
+func (lib *installedLibrary) rollback(taskCB rpc.TaskProgressCB) {
+	taskCB(&rpc.TaskProgress{Message: tr("Rolling back installation of %s", lib.release)})
+	if err := lib.installPath.RemoveAll(); err != nil {
+		logrus.WithError(err).WithField("library", lib.release).Error("Error rolling-back changes")
+	}
+	if lib.replaced != nil {
+		if err := lib.replaced.Restore(); err != nil {
+			logrus.WithError(err).WithField("library", lib.release).Error("Error rolling-back changes")
+			taskCB(&rpc.TaskProgress{Message: tr("Error rolling-back changes: %s", err)})
+		}
+	}
+}
+
+// commit removes the replaced library.
+func (lib *installedLibrary) commit() {
+	if lib.replaced != nil {
+		if err := lib.replaced.Discard(); err != nil {
+			logrus.WithError(err).WithField("library", lib.release).Warn("Error removing replaced library")
+		}
+	}
+}
+
+func installLibrary(downloadsDir *paths.Path, libRelease *librariesindex.Release, installTask *librariesmanager.LibraryInstallPlan, taskCB rpc.TaskProgressCB) (*installedLibrary, error) {
 	taskCB(&rpc.TaskProgress{Name: tr("Installing %s", libRelease)})
 	logrus.WithField("library", libRelease).Info("Installing library")
 
+	installPath := installTask.TargetPath
+	lib := &installedLibrary{
+		release:     libRelease,
+		installPath: installPath,
+		change:      instances.ComponentChange(libRelease.Library.Name, nil, libRelease.Version),
+	}
 	if libReplaced := installTask.ReplacedLib; libReplaced != nil {
+		lib.change = instances.ComponentChange(libRelease.Library.Name, libReplaced.Version, libRelease.Version)
 		taskCB(&rpc.TaskProgress{Message: tr("Replacing %[1]s with %[2]s", libReplaced, libRelease)})
-		if err := lmi.Uninstall(libReplaced); err != nil {
-			return &cmderrors.FailedLibraryInstallError{
+		backup, err := resources.BackupDir(libReplaced.InstallDir)
+		if err != nil {
+			return nil, &cmderrors.FailedLibraryInstallError{
 				Cause: fmt.Errorf("%s: %s", tr("could not remove old library"), err)}
 		}
+		lib.replaced = backup
 	}
 
-	installPath := installTask.TargetPath
 	tmpDirPath := installPath.Parent()
 	if err := libRelease.Resource.Install(downloadsDir, tmpDirPath, installPath); err != nil {
-		return &cmderrors.FailedLibraryInstallError{Cause: err}
+		lib.rollback(taskCB)
+		return nil, &cmderrors.FailedLibraryInstallError{Cause: err}
 	}
 
 	taskCB(&rpc.TaskProgress{Message: tr("Installed %s", libRelease), Completed: true})
-	return nil
+	return lib, nil
 }
 
 // ZipLibraryInstall FIXMEDOC
@@ -174,9 +235,11 @@ func ZipLibraryInstall(ctx context.Context, req *rpc.ZipLibraryInstallRequest, t
 	}
 	lmi, release := lm.NewInstaller()
 	defer release()
-	if err := lmi.InstallZipLib(ctx, paths.New(req.GetPath()), req.GetOverwrite()); err != nil {
+	installPlan, err := lmi.InstallZipLib(ctx, paths.New(req.GetPath()), req.GetOverwrite())
+	if err != nil {
 		return &cmderrors.FailedLibraryInstallError{Cause: err}
 	}
+	instances.NotifyComponentChange(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, importedLibraryChange(installPlan))
 	taskCB(&rpc.TaskProgress{Message: tr("Library installed"), Completed: true})
 	return nil
 }
@@ -189,9 +252,29 @@ func GitLibraryInstall(ctx context.Context, req *rpc.GitLibraryInstallRequest, t
 	}
 	lmi, release := lm.NewInstaller()
 	defer release()
-	if err := lmi.InstallGitLib(req.GetUrl(), req.GetOverwrite()); err != nil {
+	installPlan, err := lmi.InstallGitLib(req.GetUrl(), req.GetOverwrite())
+	if err != nil {
 		return &cmderrors.FailedLibraryInstallError{Cause: err}
 	}
+	instances.NotifyComponentChange(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, importedLibraryChange(installPlan))
 	taskCB(&rpc.TaskProgress{Message: tr("Library installed"), Completed: true})
 	return nil
 }
+
+// importedLibraryChange returns the change made by the import of a library
+// from a zip file or a git repository. The libraries without a valid version
+// are reported with an empty version.
+func importedLibraryChange(installPlan *librariesmanager.LibraryInstallPlan) *rpc.ComponentChange {
+	current := installPlan.Version
+	if current == nil {
+		current = semver.MustParse("")
+	}
+	var previous *semver.Version
+	if installPlan.ReplacedLib != nil {
+		previous = installPlan.ReplacedLib.Version
+		if previous == nil {
+			previous = semver.MustParse("")
+		}
+	}
+	return instances.ComponentChange(installPlan.Name, previous, current)
+}
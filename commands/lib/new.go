@@ -0,0 +1,279 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+// libraryNameMaxLength could be part of the regex, but it's intentionally left out for clearer error reporting
+var libraryNameMaxLength = 63
+var libraryNameValidationRegex = regexp.MustCompile(`^[0-9a-zA-Z][0-9a-zA-Z _.-]*$`)
+var notIdentifierRegex = regexp.MustCompile(`[^0-9a-zA-Z_]`)
+
+// The templates of the files of a new library: {name} is the name of the
+// library, {class} the name of its class and {header} the name of its header.
+// {src} is the folder containing the sources, relative to the library folder.
+const libraryPropertiesTemplate = `name={name}
+version=0.1.0
+author={author}
+maintainer={author}
+sentence={name} library for Arduino.
+paragraph=
+category=Uncategorized
+url=
+architectures=*
+includes={header}
+`
+
+const keywordsTemplate = "{class}\tKEYWORD1\nbegin\tKEYWORD2\nincrement\tKEYWORD2\ncounter\tKEYWORD2\n"
+
+const headerTemplate = `#ifndef {guard}
+#define {guard}
+
+#ifdef ARDUINO
+#include <Arduino.h>
+#endif
+
+class {class} {
+public:
+  void begin();
+  void increment();
+  int counter() const;
+
+private:
+  int _counter = 0;
+};
+
+#endif
+`
+
+const sourceTemplate = `#include "{header}"
+
+void {class}::begin() {
+  _counter = 0;
+}
+
+void {class}::increment() {
+  _counter++;
+}
+
+int {class}::counter() const {
+  return _counter;
+}
+`
+
+const headerOnlyTemplate = `#ifndef {guard}
+#define {guard}
+
+#ifdef ARDUINO
+#include <Arduino.h>
+#endif
+
+class {class} {
+public:
+  void begin() { _counter = 0; }
+  void increment() { _counter++; }
+  int counter() const { return _counter; }
+
+private:
+  int _counter = 0;
+};
+
+#endif
+`
+
+const exampleTemplate = `#include <{header}>
+
+{class} counter;
+
+void setup() {
+  Serial.begin(9600);
+  counter.begin();
+}
+
+void loop() {
+  counter.increment();
+  Serial.println(counter.counter());
+  delay(1000);
+}
+`
+
+const testTemplate = `// Unit tests of the library, compiled and run on the host by the CI workflow:
+//   g++ -std=c++11 -I {src} extras/test/test.cpp {sources}-o test && ./test
+#include <cassert>
+#include <cstdio>
+
+#include "{header}"
+
+int main() {
+  {class} counter;
+  counter.begin();
+  assert(counter.counter() == 0);
+  counter.increment();
+  assert(counter.counter() == 1);
+  std::puts("All tests passed");
+  return 0;
+}
+`
+
+const workflowTemplate = `name: CI
+
+on:
+  push:
+  pull_request:
+
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: arduino/arduino-lint-action@v1
+        with:
+          library-manager: submit
+          compliance: strict
+
+  compile-examples:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        fqbn:
+          - arduino:avr:uno
+          - arduino:samd:mkrzero
+          - arduino:mbed_rp2040:pico
+    steps:
+      - uses: actions/checkout@v4
+      - uses: arduino/compile-sketches@v1
+        with:
+          fqbn: ${{ matrix.fqbn }}
+          libraries: |
+            - source-path: ./
+
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: g++ -std=c++11 -I {src} extras/test/test.cpp {sources}-o test && ./test
+`
+
+type libraryFile struct {
+	path     *paths.Path
+	template string
+}
+
+// LibraryNew creates the skeleton of a new library
+func LibraryNew(ctx context.Context, req *rpc.LibraryNewRequest) (*rpc.LibraryNewResponse, error) {
+	logrus.Info("Executing `arduino-cli lib new`")
+
+	if err := validateLibraryName(req.GetName()); err != nil {
+		return nil, err
+	}
+
+	librariesDir := paths.New(req.GetDir())
+	inSketchbook := librariesDir == nil
+	if inSketchbook {
+		librariesDir = configuration.LibrariesDir(configuration.Settings)
+	}
+	name := req.GetName()
+	libraryDir := librariesDir.Join(strings.ReplaceAll(name, " ", "_"))
+	if !req.GetOverwrite() && libraryDir.Join("library.properties").Exist() {
+		return nil, &cmderrors.CantCreateLibraryError{Cause: errors.New(tr("library.properties already exists"))}
+	}
+
+	class := notIdentifierRegex.ReplaceAllString(name, "_")
+	if class[0] >= '0' && class[0] <= '9' {
+		class = "_" + class
+	}
+	header := libraryDir.Base() + ".h"
+	author := req.GetAuthor()
+	if author == "" {
+		author = "Unknown"
+	}
+	src := "src"
+	if req.GetFlatLayout() {
+		src = "."
+	}
+	sources := ""
+	if !req.GetHeaderOnly() {
+		sources = src + "/" + libraryDir.Base() + ".cpp "
+	}
+	replacer := strings.NewReplacer(
+		"{name}", name,
+		"{class}", class,
+		"{header}", header,
+		"{guard}", strings.ToUpper(class)+"_H",
+		"{author}", author,
+		"{src}", src,
+		"{sources}", sources,
+	)
+
+	files := []*libraryFile{
+		{paths.New("library.properties"), libraryPropertiesTemplate},
+		{paths.New("keywords.txt"), keywordsTemplate},
+		{paths.New("examples", "Counter", "Counter.ino"), exampleTemplate},
+		{paths.New("extras", "test", "test.cpp"), testTemplate},
+		{paths.New(".github", "workflows", "ci.yml"), workflowTemplate},
+	}
+	if req.GetHeaderOnly() {
+		files = append(files, &libraryFile{paths.New(src, header), headerOnlyTemplate})
+	} else {
+		files = append(files,
+			&libraryFile{paths.New(src, header), headerTemplate},
+			&libraryFile{paths.New(src, libraryDir.Base()+".cpp"), sourceTemplate})
+	}
+
+	res := &rpc.LibraryNewResponse{LibraryDir: libraryDir.String()}
+	for _, file := range files {
+		path := libraryDir.JoinPath(file.path)
+		if err := path.Parent().MkdirAll(); err != nil {
+			return nil, &cmderrors.CantCreateLibraryError{Cause: err}
+		}
+		if err := path.WriteFile([]byte(replacer.Replace(file.template))); err != nil {
+			return nil, &cmderrors.CantCreateLibraryError{Cause: err}
+		}
+		res.Files = append(res.Files, file.path.Clean().String())
+	}
+	if inSketchbook {
+		instances.NotifyEvent(nil, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, libraryDir.Base())
+	}
+	return res, nil
+}
+
+func validateLibraryName(name string) error {
+	if name == "" {
+		return &cmderrors.CantCreateLibraryError{Cause: errors.New(tr("library name cannot be empty"))}
+	}
+	if len(name) > libraryNameMaxLength {
+		return &cmderrors.CantCreateLibraryError{Cause: errors.New(tr("library name too long (%[1]d characters). Maximum allowed length is %[2]d",
+			len(name),
+			libraryNameMaxLength))}
+	}
+	if !libraryNameValidationRegex.MatchString(name) {
+		return &cmderrors.CantCreateLibraryError{Cause: errors.New(tr(`invalid library name "%[1]s": the first character must be alphanumeric, the following ones can also contain spaces, "_", "-" and ".".`,
+			name))}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryNew(t *testing.T) {
+	dir := paths.New(t.TempDir())
+
+	res, err := LibraryNew(context.Background(), &rpc.LibraryNewRequest{Name: "My Lib", Dir: dir.String(), Author: "Jane"})
+	require.NoError(t, err)
+	require.Equal(t, dir.Join("My_Lib").String(), res.GetLibraryDir())
+	require.Contains(t, res.GetFiles(), paths.New("src", "My_Lib.h").String())
+	require.Contains(t, res.GetFiles(), paths.New("src", "My_Lib.cpp").String())
+
+	library, err := libraries.Load(dir.Join("My_Lib"), libraries.User)
+	require.NoError(t, err)
+	require.Equal(t, "My Lib", library.Name)
+	require.Equal(t, "Jane", library.Author)
+	require.Equal(t, libraries.RecursiveLayout, library.Layout)
+	require.Equal(t, []string{"My_Lib.h"}, library.DeclaredHeaders())
+	require.Len(t, library.Examples, 1)
+	header, err := dir.Join("My_Lib", "src", "My_Lib.h").ReadFile()
+	require.NoError(t, err)
+	require.Contains(t, string(header), "class My_Lib {")
+
+	_, err = LibraryNew(context.Background(), &rpc.LibraryNewRequest{Name: "My Lib", Dir: dir.String()})
+	require.ErrorContains(t, err, "library.properties already exists")
+	_, err = LibraryNew(context.Background(), &rpc.LibraryNewRequest{Name: "My Lib", Dir: dir.String(), Overwrite: true})
+	require.NoError(t, err)
+
+	res, err = LibraryNew(context.Background(), &rpc.LibraryNewRequest{
+		Name:       "2fast",
+		Dir:        dir.String(),
+		FlatLayout: true,
+		HeaderOnly: true,
+	})
+	require.NoError(t, err)
+	require.Contains(t, res.GetFiles(), "2fast.h")
+	require.False(t, dir.Join("2fast", "2fast.cpp").Exist())
+	header, err = dir.Join("2fast", "2fast.h").ReadFile()
+	require.NoError(t, err)
+	require.Contains(t, string(header), "class _2fast {")
+	library, err = libraries.Load(dir.Join("2fast"), libraries.User)
+	require.NoError(t, err)
+	require.Equal(t, libraries.FlatLayout, library.Layout)
+}
+
+func TestLibraryNewInvalidName(t *testing.T) {
+	dir := paths.New(t.TempDir()).String()
+	for _, name := range []string{"", "_Lib", "My/Lib", strings.Repeat("a", 64)} {
+		_, err := LibraryNew(context.Background(), &rpc.LibraryNewRequest{Name: name, Dir: dir})
+		require.Error(t, err, name)
+	}
+}
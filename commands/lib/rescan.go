@@ -0,0 +1,64 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+// RescanLibraries reloads the libraries contained in the given directories
+func RescanLibraries(ctx context.Context, req *rpc.RescanLibrariesRequest) (*rpc.RescanLibrariesResponse, error) {
+	lm, err := instances.GetLibraryManager(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	lmi, release := lm.NewInstaller()
+	defer release()
+
+	res := &rpc.RescanLibrariesResponse{}
+	for _, p := range req.GetPaths() {
+		path := paths.New(p)
+		if abs, err := path.Abs(); err == nil {
+			path = abs
+		}
+
+		rescanned, statuses := lmi.RescanLibrariesDir(path)
+		for _, s := range statuses {
+			logrus.WithError(s.Err()).Warn("Error loading library")
+		}
+		if !rescanned {
+			rescanned, err = lmi.RescanLibrary(path)
+			if err != nil {
+				logrus.WithError(err).Warn("Error loading library")
+			}
+		}
+		if rescanned {
+			res.RescannedPaths = append(res.RescannedPaths, p)
+		} else {
+			res.IgnoredPaths = append(res.IgnoredPaths, p)
+		}
+	}
+
+	if len(res.GetRescannedPaths()) > 0 {
+		instances.NotifyEvent(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, "")
+	}
+	return res, nil
+}
@@ -49,6 +49,8 @@ func LibraryUninstall(ctx context.Context, req *rpc.LibraryUninstallRequest, tas
 	if len(libs) == 1 {
 		taskCB(&rpc.TaskProgress{Name: tr("Uninstalling %s", libs)})
 		lmi.Uninstall(libs[0])
+		change := instances.ComponentChange(libs[0].Name, libs[0].Version, nil)
+		instances.NotifyComponentChange(req.GetInstance(), rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, change)
 		taskCB(&rpc.TaskProgress{Completed: true})
 		return nil
 	}
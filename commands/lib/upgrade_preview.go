@@ -0,0 +1,158 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+	"sort"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesindex"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+)
+
+// LibraryUpgradePreview returns the changes that a library upgrade would
+// make, without downloading or installing anything. If no library is given
+// all the installed libraries are considered.
+func LibraryUpgradePreview(ctx context.Context, req *rpc.LibraryUpgradePreviewRequest) (*rpc.LibraryUpgradePreviewResponse, error) {
+	pme, pmeRelease, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	downloadDir := pme.DownloadDir
+	pmeRelease()
+
+	li, err := instances.GetLibrariesIndex(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	lme, release, err := instances.GetLibraryManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return libraryUpgradePreview(li, listLibraries(lme, li, false, false), lme.FindAllInstalled(), req.GetNames(), downloadDir)
+}
+
+// libraryUpgradePreview computes the upgrade preview of the given libraries,
+// installedLibs are the libraries that can be upgraded and allLibs are all the
+// installed libraries, whose dependencies are checked against the new versions.
+func libraryUpgradePreview(li *librariesindex.Index, installedLibs []*installedLib, allLibs libraries.List, names []string, downloadDir *paths.Path) (*rpc.LibraryUpgradePreviewResponse, error) {
+	var targets []*installedLib
+	if len(names) == 0 {
+		for _, lib := range installedLibs {
+			if lib.Available != nil {
+				targets = append(targets, lib)
+			}
+		}
+	} else {
+		for _, name := range names {
+			lib := filterByName(installedLibs, name)
+			if lib == nil {
+				return nil, &cmderrors.LibraryNotFoundError{Library: name}
+			}
+			if lib.Available != nil {
+				targets = append(targets, lib)
+			}
+		}
+	}
+
+	// Collect the releases that would be installed, the upgraded libraries
+	// and their dependencies, as done by LibraryInstall
+	changes := map[string]*librariesindex.Release{}
+	isDependency := map[string]bool{}
+	for _, target := range targets {
+		changes[target.Library.Name] = target.Available
+		isDependency[target.Library.Name] = false
+	}
+	for _, target := range targets {
+		deps := li.ResolveDependencies(target.Available, nil)
+		if len(deps) == 0 {
+			return nil, &cmderrors.LibraryDependenciesResolutionFailedError{}
+		}
+		for _, dep := range deps {
+			if _, ok := changes[dep.GetName()]; ok {
+				continue
+			}
+			if installed := filterByName(installedLibs, dep.GetName()); installed != nil && installed.Library.Version != nil && installed.Library.Version.Equal(dep.GetVersion()) {
+				continue
+			}
+			changes[dep.GetName()] = dep
+			isDependency[dep.GetName()] = true
+		}
+	}
+
+	res := &rpc.LibraryUpgradePreviewResponse{
+		Libraries:         []*rpc.LibraryUpgradePreview{},
+		BrokenConstraints: []*rpc.LibraryBrokenConstraint{},
+	}
+	for name, newRelease := range changes {
+		preview := &rpc.LibraryUpgradePreview{
+			Name:       name,
+			NewVersion: newRelease.GetVersion().String(),
+			Dependency: isDependency[name],
+		}
+		if installed := filterByName(installedLibs, name); installed != nil {
+			preview.InstalledVersion = installed.Library.Version.String()
+		}
+		if newRelease.Resource != nil {
+			preview.DownloadSize = newRelease.Resource.Size
+			cached, err := newRelease.Resource.IsCached(downloadDir)
+			preview.Cached = err == nil && cached
+		}
+		if !preview.GetCached() {
+			res.DownloadSize += preview.GetDownloadSize()
+		}
+		res.Libraries = append(res.Libraries, preview)
+	}
+	sort.Slice(res.Libraries, func(i, j int) bool {
+		return res.Libraries[i].GetName() < res.Libraries[j].GetName()
+	})
+
+	// Check the dependencies of the installed libraries against the new
+	// versions, using the dependencies declared by the release that would be
+	// installed after the upgrade
+	for _, lib := range allLibs {
+		libRelease, ok := changes[lib.Name]
+		if !ok {
+			var err error
+			if libRelease, err = li.FindRelease(lib.Name, lib.Version); err != nil {
+				// Not in the index, the dependencies are unknown
+				continue
+			}
+		}
+		for _, dep := range libRelease.GetDependencies() {
+			newRelease, ok := changes[dep.GetName()]
+			if !ok || dep.GetConstraint() == nil || dep.GetConstraint().Match(newRelease.GetVersion()) {
+				continue
+			}
+			res.BrokenConstraints = append(res.BrokenConstraints, &rpc.LibraryBrokenConstraint{
+				Library:    libRelease.String(),
+				Dependency: dep.GetName(),
+				Constraint: dep.GetConstraint().String(),
+				NewVersion: newRelease.GetVersion().String(),
+			})
+		}
+	}
+	sort.SliceStable(res.BrokenConstraints, func(i, j int) bool {
+		return res.BrokenConstraints[i].GetLibrary() < res.BrokenConstraints[j].GetLibrary()
+	})
+	return res, nil
+}
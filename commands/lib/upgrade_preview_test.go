@@ -0,0 +1,72 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesindex"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+	semver "go.bug.st/relaxed-semver"
+)
+
+func TestLibraryUpgradePreview(t *testing.T) {
+	li, err := librariesindex.LoadIndex(fullIndexPath)
+	require.NoError(t, err)
+
+	installed := func(name, version string) *installedLib {
+		lib := &libraries.Library{Name: name, Version: semver.MustParse(version), Location: libraries.User}
+		return &installedLib{Library: lib, Available: li.FindLibraryUpdate(lib)}
+	}
+	arduinoJSON := installed("ArduinoJson", "6.15.2")
+	algoduino := installed("Algoduino", "0.2.2")
+	installedLibs := []*installedLib{arduinoJSON, algoduino}
+	allLibs := libraries.List{arduinoJSON.Library, algoduino.Library}
+	downloadDir := paths.New(t.TempDir())
+
+	// Upgrading ArduinoJson breaks the exact version required by Algoduino
+	res, err := libraryUpgradePreview(li, installedLibs, allLibs, []string{"ArduinoJson"}, downloadDir)
+	require.NoError(t, err)
+	require.Len(t, res.GetLibraries(), 1)
+	require.Equal(t, "ArduinoJson", res.GetLibraries()[0].GetName())
+	require.Equal(t, "6.15.2", res.GetLibraries()[0].GetInstalledVersion())
+	require.Equal(t, arduinoJSON.Available.GetVersion().String(), res.GetLibraries()[0].GetNewVersion())
+	require.False(t, res.GetLibraries()[0].GetDependency())
+	require.Equal(t, res.GetLibraries()[0].GetDownloadSize(), res.GetDownloadSize())
+	require.Len(t, res.GetBrokenConstraints(), 1)
+	require.Equal(t, "Algoduino@0.2.2", res.GetBrokenConstraints()[0].GetLibrary())
+	require.Equal(t, "ArduinoJson", res.GetBrokenConstraints()[0].GetDependency())
+	require.Equal(t, "=6.15.2", res.GetBrokenConstraints()[0].GetConstraint())
+
+	// The dependencies of the upgraded libraries are installed too
+	algoduino = installed("Algoduino", "0.2.1")
+	res, err = libraryUpgradePreview(li, []*installedLib{algoduino}, libraries.List{algoduino.Library}, nil, downloadDir)
+	require.NoError(t, err)
+	require.Empty(t, res.GetBrokenConstraints())
+	require.Len(t, res.GetLibraries(), 2)
+	require.Equal(t, "Algoduino", res.GetLibraries()[0].GetName())
+	require.Equal(t, "0.2.3", res.GetLibraries()[0].GetNewVersion())
+	require.False(t, res.GetLibraries()[0].GetDependency())
+	require.Equal(t, "ArduinoJson", res.GetLibraries()[1].GetName())
+	require.Equal(t, "6.15.2", res.GetLibraries()[1].GetNewVersion())
+	require.Empty(t, res.GetLibraries()[1].GetInstalledVersion())
+	require.True(t, res.GetLibraries()[1].GetDependency())
+
+	_, err = libraryUpgradePreview(li, installedLibs, allLibs, []string{"NotInstalled"}, downloadDir)
+	require.Error(t, err)
+}
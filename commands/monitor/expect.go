@@ -0,0 +1,96 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// Expect opens the monitor port, sends the given data, if any, and waits until
+// the data received from the board matches the expect regular expression.
+// The port is opened again until the timeout expires, since it may disappear
+// for a while when the board is reset. The received data is copied to out.
+func Expect(ctx context.Context, req *rpc.MonitorPortOpenRequest, send []byte, expect *regexp.Regexp, timeout time.Duration, out io.Writer) error {
+	deadline := time.Now().Add(timeout)
+	var portProxy *PortProxy
+	for {
+		var err error
+		portProxy, _, err = Monitor(ctx, req)
+		if err == nil {
+			break
+		}
+		if time.Now().Add(250 * time.Millisecond).After(deadline) {
+			return err
+		}
+		logrus.WithError(err).Info("Could not open the monitor port, retrying")
+		time.Sleep(250 * time.Millisecond)
+	}
+	defer portProxy.Close()
+
+	if len(send) > 0 {
+		if _, err := portProxy.Write(send); err != nil {
+			return err
+		}
+	}
+
+	var mux sync.Mutex
+	received := &bytes.Buffer{}
+	done := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := portProxy.Read(buf)
+			if n > 0 {
+				out.Write(buf[:n])
+				mux.Lock()
+				received.Write(buf[:n])
+				matched := expect.Match(received.Bytes())
+				mux.Unlock()
+				if matched {
+					done <- nil
+					return
+				}
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("%s: %w", tr("error reading from the board"), err)
+		}
+		return nil
+	case <-time.After(time.Until(deadline)):
+		mux.Lock()
+		defer mux.Unlock()
+		return fmt.Errorf(tr("timeout waiting for %[1]q, received %[2]q"), expect.String(), received.String())
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
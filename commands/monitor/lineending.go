@@ -0,0 +1,29 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+// LineEndings maps the names of the line endings that can be sent by the
+// monitor, in place of the ones typed by the user, to their characters.
+var LineEndings = map[string]string{
+	"none": "",
+	"nl":   "\n",
+	"cr":   "\r",
+	"nlcr": "\r\n",
+}
+
+// LineEndingNames are the names of the line endings, in the order they are
+// cycled through in the monitor.
+var LineEndingNames = []string{"none", "nl", "cr", "nlcr"}
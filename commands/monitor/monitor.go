@@ -19,12 +19,19 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	"github.com/arduino/arduino-cli/internal/arduino/ble"
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	"github.com/arduino/arduino-cli/internal/arduino/loopback"
 	pluggableMonitor "github.com/arduino/arduino-cli/internal/arduino/monitor"
+	"github.com/arduino/arduino-cli/internal/arduino/tcpmonitor"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/arduino/go-properties-orderedmap"
@@ -33,19 +40,83 @@ import (
 
 var tr = i18n.Tr
 
+// resumeRetryDelay is the time waited between the attempts to reopen a
+// port after an upload
+var resumeRetryDelay = time.Second
+
 // PortProxy is an io.ReadWriteCloser that maps into the monitor port of the board
 type PortProxy struct {
 	rw               io.ReadWriter
 	changeSettingsCB func(setting, value string) error
 	closeCB          func() error
+	suspendCB        func() error
+	resumeCB         func() (io.ReadWriter, error)
+	stateChangedCB   func(suspended bool, err error)
+	unregister       func()
+
+	stateMux  sync.Mutex
+	stateCond *sync.Cond
+	suspended bool
+	closed    bool
+	resumeErr error
+
+	closeOnce sync.Once
+	closeErr  error
 }
 
 func (p *PortProxy) Read(buff []byte) (int, error) {
-	return p.rw.Read(buff)
+	for {
+		rw, err := p.waitActivePort()
+		if err != nil {
+			return 0, err
+		}
+		n, err := rw.Read(buff)
+		if err != nil && p.portChanged(rw) {
+			// The port has been suspended while reading, wait for it to come back
+			continue
+		}
+		return n, err
+	}
 }
 
 func (p *PortProxy) Write(buff []byte) (int, error) {
-	return p.rw.Write(buff)
+	for {
+		rw, err := p.waitActivePort()
+		if err != nil {
+			return 0, err
+		}
+		n, err := rw.Write(buff)
+		if err != nil && p.portChanged(rw) {
+			continue
+		}
+		return n, err
+	}
+}
+
+// waitActivePort blocks while the port is suspended and returns the
+// current port stream. If the port has been closed io.EOF is returned, or
+// the error that prevented the port from being resumed.
+func (p *PortProxy) waitActivePort() (io.ReadWriter, error) {
+	p.stateMux.Lock()
+	defer p.stateMux.Unlock()
+	for p.suspended && !p.closed {
+		p.stateCond.Wait()
+	}
+	if p.resumeErr != nil {
+		return nil, p.resumeErr
+	}
+	if p.closed {
+		return nil, io.EOF
+	}
+	return p.rw, nil
+}
+
+// portChanged returns true if the given port stream is no longer the active
+// one, because the port has been suspended or reopened in the meantime.
+func (p *PortProxy) portChanged(rw io.ReadWriter) bool {
+	p.stateMux.Lock()
+	defer p.stateMux.Unlock()
+	return !p.closed && (p.suspended || p.rw != rw)
 }
 
 // Config sets the port configuration setting to the specified value
@@ -53,9 +124,95 @@ func (p *PortProxy) Config(setting, value string) error {
 	return p.changeSettingsCB(setting, value)
 }
 
-// Close the port
+// Suspend temporarily closes the port, to allow another process (for
+// example an uploader) to use it. Reads and writes are blocked until the
+// port is resumed.
+func (p *PortProxy) Suspend() error {
+	p.stateMux.Lock()
+	if p.closed || p.suspended {
+		p.stateMux.Unlock()
+		return nil
+	}
+	p.suspended = true
+	p.stateMux.Unlock()
+
+	err := p.suspendCB()
+	p.notifyStateChanged(true, err)
+	return err
+}
+
+// Resume reopens a port previously suspended.
+func (p *PortProxy) Resume() error {
+	p.stateMux.Lock()
+	if p.closed || !p.suspended {
+		p.stateMux.Unlock()
+		return nil
+	}
+	p.stateMux.Unlock()
+
+	var rw io.ReadWriter
+	var err error
+	for attempt := 0; attempt < 5; attempt++ {
+		// The port may take a while to reappear after an upload
+		if rw, err = p.resumeCB(); err == nil {
+			break
+		}
+		time.Sleep(resumeRetryDelay)
+	}
+
+	if err != nil {
+		// The monitor is quit and the error is returned to the readers and
+		// writers of the port, that would be blocked forever otherwise
+		p.stateMux.Lock()
+		p.resumeErr = &cmderrors.FailedMonitorError{Cause: err}
+		p.stateMux.Unlock()
+		if closeErr := p.Close(); closeErr != nil {
+			logrus.WithError(closeErr).Debug("Error closing monitor")
+		}
+		p.notifyStateChanged(false, err)
+		return err
+	}
+
+	p.stateMux.Lock()
+	p.rw = rw
+	p.suspended = false
+	p.stateCond.Broadcast()
+	p.stateMux.Unlock()
+
+	p.notifyStateChanged(false, nil)
+	return nil
+}
+
+// SetStateChangedCallback sets a callback that is called each time the port
+// is suspended or resumed. If resuming the port fails the error is passed
+// to the callback and the port is closed, the following reads and writes
+// return the error.
+func (p *PortProxy) SetStateChangedCallback(cb func(suspended bool, err error)) {
+	p.stateMux.Lock()
+	p.stateChangedCB = cb
+	p.stateMux.Unlock()
+}
+
+func (p *PortProxy) notifyStateChanged(suspended bool, err error) {
+	p.stateMux.Lock()
+	cb := p.stateChangedCB
+	p.stateMux.Unlock()
+	if cb != nil {
+		cb(suspended, err)
+	}
+}
+
+// Close the port and quit the monitor, it may be called more than once
 func (p *PortProxy) Close() error {
-	return p.closeCB()
+	p.stateMux.Lock()
+	p.closed = true
+	p.stateCond.Broadcast()
+	p.stateMux.Unlock()
+	p.closeOnce.Do(func() {
+		p.unregister()
+		p.closeErr = p.closeCB()
+	})
+	return p.closeErr
 }
 
 // Monitor opens a communication port. It returns a PortProxy to communicate with the port and a PortDescriptor
@@ -103,14 +260,21 @@ func Monitor(ctx context.Context, req *rpc.MonitorPortOpenRequest) (*PortProxy,
 	}
 
 	logrus.Infof("Port %s successfully opened", req.GetPort().GetAddress())
-	return &PortProxy{
+	portProxy := &PortProxy{
 		rw:               monIO,
 		changeSettingsCB: m.Configure,
 		closeCB: func() error {
 			m.Close()
 			return m.Quit()
 		},
-	}, descriptor, nil
+		suspendCB: m.Close,
+		resumeCB: func() (io.ReadWriter, error) {
+			return m.Open(req.GetPort().GetAddress(), req.GetPort().GetProtocol())
+		},
+	}
+	portProxy.stateCond = sync.NewCond(&portProxy.stateMux)
+	portProxy.unregister = portlock.Register(req.GetPort().GetProtocol(), req.GetPort().GetAddress(), portProxy)
+	return portProxy, descriptor, nil
 }
 
 func findMonitorAndSettingsForProtocolAndBoard(pme *packagemanager.Explorer, protocol, fqbn string) (*pluggableMonitor.PluggableMonitor, *properties.Map, error) {
@@ -159,6 +323,30 @@ func findMonitorAndSettingsForProtocolAndBoard(pme *packagemanager.Explorer, pro
 		}
 	}
 
+	if monitorDepOrRecipe == nil && protocol == loopback.Protocol && configuration.Settings.GetBool("loopback.enabled") {
+		cmdLine, err := loopback.MonitorCommandLine()
+		if err != nil {
+			return nil, nil, &cmderrors.MonitorNotFoundError{Monitor: "loopback-monitor", Cause: err}
+		}
+		return pluggableMonitor.New("loopback-monitor", cmdLine...), boardSettings, nil
+	}
+
+	if monitorDepOrRecipe == nil && protocol == ble.Protocol && configuration.Settings.GetBool("ble.enabled") {
+		cmdLine, err := ble.MonitorCommandLine()
+		if err != nil {
+			return nil, nil, &cmderrors.MonitorNotFoundError{Monitor: "ble-monitor", Cause: err}
+		}
+		return pluggableMonitor.New("ble-monitor", cmdLine...), boardSettings, nil
+	}
+
+	if monitorDepOrRecipe == nil && protocol == tcpmonitor.Protocol {
+		cmdLine, err := tcpmonitor.MonitorCommandLine()
+		if err != nil {
+			return nil, nil, &cmderrors.MonitorNotFoundError{Monitor: "tcp-monitor", Cause: err}
+		}
+		return pluggableMonitor.New("tcp-monitor", cmdLine...), boardSettings, nil
+	}
+
 	if monitorDepOrRecipe == nil {
 		return nil, nil, &cmderrors.NoMonitorAvailableForProtocolError{Protocol: protocol}
 	}
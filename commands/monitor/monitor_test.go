@@ -0,0 +1,65 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortProxyResumeFailure(t *testing.T) {
+	resumeRetryDelay = 0
+	defer func() { resumeRetryDelay = time.Second }()
+
+	closed := 0
+	unregistered := 0
+	p := &PortProxy{
+		rw:         &bytes.Buffer{},
+		closeCB:    func() error { closed++; return nil },
+		suspendCB:  func() error { return nil },
+		resumeCB:   func() (io.ReadWriter, error) { return nil, errors.New("port not found") },
+		unregister: func() { unregistered++ },
+	}
+	p.stateCond = sync.NewCond(&p.stateMux)
+	var stateErr error
+	p.SetStateChangedCallback(func(suspended bool, err error) {
+		if !suspended {
+			stateErr = err
+		}
+	})
+
+	require.NoError(t, p.Suspend())
+	require.Error(t, p.Resume())
+	require.EqualError(t, stateErr, "port not found")
+
+	// The monitor is quit and the error is returned to the port users
+	require.Equal(t, 1, closed)
+	require.Equal(t, 1, unregistered)
+	_, err := p.Read(make([]byte, 10))
+	require.ErrorContains(t, err, "port not found")
+	_, err = p.Write([]byte("hello"))
+	require.ErrorContains(t, err, "port not found")
+
+	// Closing again doesn't quit the monitor twice
+	require.NoError(t, p.Close())
+	require.Equal(t, 1, closed)
+}
@@ -0,0 +1,151 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/compile"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/cpp"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+)
+
+var (
+	lineDirective    = regexp.MustCompile(`^\s*#\s*line\s+(\d+)\s*(.*?)\s*$`)
+	includeDirective = regexp.MustCompile(`^\s*#\s*include\s*"([^"]+)"`)
+	pragmaOnce       = regexp.MustCompile(`^\s*#\s*pragma\s+once\b`)
+)
+
+// FlattenSketch merges the files of a sketch, together with the prototypes
+// generated by the preprocessor, in a single source file that can be shared
+// to reproduce an issue.
+func FlattenSketch(ctx context.Context, req *rpc.FlattenSketchRequest) (*rpc.FlattenSketchResponse, error) {
+	if req.GetSketchPath() == "" {
+		return nil, &cmderrors.MissingSketchPathError{}
+	}
+	sk, err := sketch.New(paths.New(req.GetSketchPath()))
+	if err != nil {
+		return nil, &cmderrors.CantOpenSketchError{Cause: err}
+	}
+
+	preprocessed := &bytes.Buffer{}
+	compileReq := &rpc.CompileRequest{
+		Instance:   req.GetInstance(),
+		SketchPath: sk.FullPath.String(),
+		Fqbn:       req.GetFqbn(),
+		Preprocess: true,
+	}
+	if _, err := compile.Compile(ctx, compileReq, preprocessed, io.Discard, func(*rpc.TaskProgress) {}); err != nil {
+		return nil, err
+	}
+
+	source := preprocessed.String()
+	if req.GetInlineHeaders() {
+		source, err = inlineSketchHeaders(sk, source)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !req.GetKeepLineDirectives() {
+		source = removeLineDirectives(source)
+	}
+	return &rpc.FlattenSketchResponse{Source: source}, nil
+}
+
+// inlineSketchHeaders replaces the inclusions of the headers of the sketch
+// with their content. The origin of each line is tracked through the `#line`
+// directives, so the headers are looked up relative to the file including
+// them. Each header is inlined only once, as if it had an include guard.
+func inlineSketchHeaders(sk *sketch.Sketch, source string) (string, error) {
+	inlined := map[string]bool{}
+
+	var inline func(source string, file *paths.Path) (string, error)
+	inline = func(source string, file *paths.Path) (string, error) {
+		res := strings.Builder{}
+		line := 0
+		for _, row := range strings.SplitAfter(source, "\n") {
+			line++
+			if match := lineDirective.FindStringSubmatch(row); match != nil {
+				if n, err := strconv.Atoi(match[1]); err == nil {
+					line = n - 1
+				}
+				if name, _, ok := cpp.ParseString(match[2]); ok {
+					file = paths.New(name)
+				}
+				res.WriteString(row)
+				continue
+			}
+			match := includeDirective.FindStringSubmatch(row)
+			if match == nil || file == nil {
+				res.WriteString(row)
+				continue
+			}
+			header := file.Parent().Join(match[1]).Clean()
+			if inSketch, _ := header.IsInsideDir(sk.FullPath); !inSketch || !header.IsNotDir() {
+				res.WriteString(row)
+				continue
+			}
+			if inlined[header.String()] {
+				res.WriteString("// " + strings.TrimSpace(row) + " (already inlined)\n")
+				continue
+			}
+			inlined[header.String()] = true
+
+			content, err := header.ReadFile()
+			if err != nil {
+				return "", &cmderrors.PermissionDeniedError{Message: tr("Error reading sketch files"), Cause: err}
+			}
+			headerSource, err := inline(string(content), header)
+			if err != nil {
+				return "", err
+			}
+			res.WriteString("#line 1 " + cpp.QuoteString(header.String()) + "\n")
+			for _, headerRow := range strings.SplitAfter(headerSource, "\n") {
+				if pragmaOnce.MatchString(headerRow) {
+					headerRow = "\n"
+				}
+				res.WriteString(headerRow)
+			}
+			if !strings.HasSuffix(headerSource, "\n") {
+				res.WriteString("\n")
+			}
+			res.WriteString(fmt.Sprintf("#line %d %s\n", line+1, cpp.QuoteString(file.String())))
+		}
+		return res.String(), nil
+	}
+	return inline(source, nil)
+}
+
+// removeLineDirectives removes the `#line` directives from the source, they
+// point to the original files of the sketch that are not shared.
+func removeLineDirectives(source string) string {
+	res := strings.Builder{}
+	for _, row := range strings.SplitAfter(source, "\n") {
+		if !lineDirective.MatchString(row) {
+			res.WriteString(row)
+		}
+	}
+	return res.String()
+}
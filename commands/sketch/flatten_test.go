@@ -0,0 +1,63 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketch
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/cpp"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineSketchHeaders(t *testing.T) {
+	sketchPath, err := paths.New("testdata", "sketch_with_headers").Abs()
+	require.NoError(t, err)
+	sk, err := sketch.New(sketchPath)
+	require.NoError(t, err)
+
+	mainFile := cpp.QuoteString(sk.MainFile.String())
+	source := "#include <Arduino.h>\n" +
+		"#line 1 " + mainFile + "\n" +
+		"#include \"config.h\"\n" +
+		"#include \"config.h\"\n" +
+		"\n" +
+		"void setup() {}\n"
+
+	flattened, err := inlineSketchHeaders(sk, source)
+	require.NoError(t, err)
+	require.Equal(t, "#include <Arduino.h>\n"+
+		"#line 1 "+mainFile+"\n"+
+		"#line 1 "+cpp.QuoteString(sketchPath.Join("config.h").String())+"\n"+
+		"\n"+
+		"#line 1 "+cpp.QuoteString(sketchPath.Join("src", "util.h").String())+"\n"+
+		"int twice(int x);\n"+
+		"#line 3 "+cpp.QuoteString(sketchPath.Join("config.h").String())+"\n"+
+		"#define LED 13\n"+
+		"#line 2 "+mainFile+"\n"+
+		"// #include \"config.h\" (already inlined)\n"+
+		"\n"+
+		"void setup() {}\n", flattened)
+
+	require.Equal(t, "#include <Arduino.h>\n"+
+		"\n"+
+		"int twice(int x);\n"+
+		"#define LED 13\n"+
+		"// #include \"config.h\" (already inlined)\n"+
+		"\n"+
+		"void setup() {}\n", removeLineDirectives(flattened))
+}
@@ -21,6 +21,7 @@ import (
 	"regexp"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
 	"github.com/arduino/arduino-cli/internal/arduino/globals"
 	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
@@ -45,7 +46,8 @@ var invalidNames = []string{"CON", "PRN", "AUX", "NUL", "COM0", "COM1", "COM2",
 // NewSketch creates a new sketch via gRPC
 func NewSketch(ctx context.Context, req *rpc.NewSketchRequest) (*rpc.NewSketchResponse, error) {
 	var sketchesDir string
-	if len(req.GetSketchDir()) > 0 {
+	inSketchbook := len(req.GetSketchDir()) == 0
+	if !inSketchbook {
 		sketchesDir = req.GetSketchDir()
 	} else {
 		sketchesDir = configuration.Settings.GetString("directories.User")
@@ -69,6 +71,9 @@ func NewSketch(ctx context.Context, req *rpc.NewSketchRequest) (*rpc.NewSketchRe
 	if err := sketchMainFilePath.WriteFile(emptySketch); err != nil {
 		return nil, &cmderrors.CantCreateSketchError{Cause: err}
 	}
+	if inSketchbook {
+		instances.NotifyEvent(nil, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_SKETCHBOOK_CHANGED, sketchName)
+	}
 
 	return &rpc.NewSketchResponse{MainFile: sketchMainFilePath.String()}, nil
 }
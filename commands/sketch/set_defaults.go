@@ -17,15 +17,18 @@ package sketch
 
 import (
 	"context"
+	"strings"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/monitor"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	paths "github.com/arduino/go-paths-helper"
 )
 
 // SetSketchDefaults updates the sketch project file (sketch.yaml) with the given defaults
-// for the values `default_fqbn`, `default_port`, and `default_protocol`.
+// for the values `default_fqbn`, `default_port`, `default_protocol`, `default_programmer`,
+// `default_monitor_config`, `default_monitor_line_ending` and `default_monitor_echo`.
 func SetSketchDefaults(ctx context.Context, req *rpc.SetSketchDefaultsRequest) (*rpc.SetSketchDefaultsResponse, error) {
 	sk, err := sketch.New(paths.New(req.GetSketchPath()))
 	if err != nil {
@@ -34,10 +37,13 @@ func SetSketchDefaults(ctx context.Context, req *rpc.SetSketchDefaultsRequest) (
 
 	oldAddress, oldProtocol := sk.GetDefaultPortAddressAndProtocol()
 	res := &rpc.SetSketchDefaultsResponse{
-		DefaultFqbn:         sk.GetDefaultFQBN(),
-		DefaultProgrammer:   sk.GetDefaultProgrammer(),
-		DefaultPortAddress:  oldAddress,
-		DefaultPortProtocol: oldProtocol,
+		DefaultFqbn:              sk.GetDefaultFQBN(),
+		DefaultProgrammer:        sk.GetDefaultProgrammer(),
+		DefaultPortAddress:       oldAddress,
+		DefaultPortProtocol:      oldProtocol,
+		DefaultMonitorConfig:     sk.GetDefaultMonitorConfig(),
+		DefaultMonitorLineEnding: sk.GetDefaultMonitorLineEnding(),
+		DefaultMonitorEcho:       sk.GetDefaultMonitorEcho(),
 	}
 
 	if fqbn := req.GetDefaultFqbn(); fqbn != "" {
@@ -59,6 +65,27 @@ func SetSketchDefaults(ctx context.Context, req *rpc.SetSketchDefaultsRequest) (
 		res.DefaultPortAddress = newAddress
 		res.DefaultPortProtocol = newProtocol
 	}
+	if config := req.GetDefaultMonitorConfig(); len(config) > 0 {
+		if err := sk.SetDefaultMonitorConfig(config); err != nil {
+			return nil, &cmderrors.CantUpdateSketchError{Cause: err}
+		}
+		res.DefaultMonitorConfig = config
+	}
+	if lineEnding := req.GetDefaultMonitorLineEnding(); lineEnding != "" {
+		if _, ok := monitor.LineEndings[lineEnding]; !ok {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid line ending %[1]s, must be one of: %[2]s", lineEnding, strings.Join(monitor.LineEndingNames, ", "))}
+		}
+		if err := sk.SetDefaultMonitorLineEnding(lineEnding); err != nil {
+			return nil, &cmderrors.CantUpdateSketchError{Cause: err}
+		}
+		res.DefaultMonitorLineEnding = lineEnding
+	}
+	if req.DefaultMonitorEcho != nil {
+		if err := sk.SetDefaultMonitorEcho(req.GetDefaultMonitorEcho()); err != nil {
+			return nil, &cmderrors.CantUpdateSketchError{Cause: err}
+		}
+		res.DefaultMonitorEcho = req.GetDefaultMonitorEcho()
+	}
 
 	return res, nil
 }
@@ -0,0 +1,129 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/globals"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/arduino/utils"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+)
+
+// sketchbookMaxDepth limits the depth of the sketchbook scan, it also
+// prevents endless loops caused by symlinks.
+const sketchbookMaxDepth = 10
+
+// SketchbookList returns the sketches contained in the sketchbook
+func SketchbookList(ctx context.Context, req *rpc.SketchbookListRequest) (*rpc.SketchbookListResponse, error) {
+	sketchbook := paths.New(req.GetSketchbookPath())
+	if sketchbook == nil {
+		sketchbook = paths.New(configuration.Settings.GetString("directories.User"))
+	}
+	if !sketchbook.IsDir() {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Sketchbook folder not found: %s", sketchbook)}
+	}
+
+	res := &rpc.SketchbookListResponse{}
+	searchTerms := utils.SearchTermsFromQueryString(req.GetSearchArgs())
+	for _, dir := range findSketchFolders(sketchbook) {
+		sk, err := sketch.New(dir)
+		if err != nil {
+			res.InvalidSketches = append(res.InvalidSketches, &rpc.SketchbookInvalidSketch{
+				Path:  dir.String(),
+				Error: err.Error(),
+			})
+			continue
+		}
+		if !utils.Match(sk.Name, searchTerms) {
+			continue
+		}
+		if fqbn := req.GetFqbn(); fqbn != "" && !sketchUsesBoard(sk, fqbn) {
+			continue
+		}
+		res.Sketches = append(res.Sketches, sk.ToRpc())
+	}
+	return res, nil
+}
+
+// findSketchFolders returns the folders containing a sketch, sorted by path.
+// The libraries and hardware folders of the sketchbook are skipped.
+func findSketchFolders(sketchbook *paths.Path) paths.PathList {
+	var res paths.PathList
+	var scan func(dir *paths.Path, depth int)
+	scan = func(dir *paths.Path, depth int) {
+		if depth > sketchbookMaxDepth {
+			return
+		}
+		files, err := dir.ReadDir()
+		if err != nil {
+			return
+		}
+		files.FilterOutHiddenFiles()
+		files.Sort()
+		for _, f := range files {
+			if f.IsNotDir() {
+				continue
+			}
+			if depth == 0 && (f.Base() == "libraries" || f.Base() == "hardware") {
+				continue
+			}
+			if isSketchFolder(f) {
+				res.Add(f)
+				continue
+			}
+			scan(f, depth+1)
+		}
+	}
+	scan(sketchbook, 0)
+	return res
+}
+
+// isSketchFolder returns true if the folder contains a main sketch file
+// named as the folder itself.
+func isSketchFolder(dir *paths.Path) bool {
+	for ext := range globals.MainFileValidExtensions {
+		if dir.Join(dir.Base() + ext).IsNotDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// sketchUsesBoard returns true if the given FQBN, or partial FQBN, matches
+// the default FQBN of the sketch or the FQBN of one of its profiles.
+func sketchUsesBoard(sk *sketch.Sketch, fqbn string) bool {
+	matches := func(boardFqbn string) bool {
+		return boardFqbn == fqbn || strings.HasPrefix(boardFqbn, fqbn+":")
+	}
+	if sk.Project == nil {
+		return false
+	}
+	if matches(sk.Project.DefaultFqbn) {
+		return true
+	}
+	for _, profile := range sk.Project.Profiles {
+		if matches(profile.FQBN) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketch
+
+import (
+	"context"
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSketchbookList(t *testing.T) {
+	sketchbook := paths.New(t.TempDir())
+	addSketch := func(rel string, project string) {
+		dir := sketchbook.Join(rel)
+		require.NoError(t, dir.MkdirAll())
+		require.NoError(t, dir.Join(dir.Base()+".ino").WriteFile([]byte("void setup(){}\nvoid loop(){}\n")))
+		if project != "" {
+			require.NoError(t, dir.Join("sketch.yaml").WriteFile([]byte(project)))
+		}
+	}
+	addSketch("Blink", "default_fqbn: arduino:avr:uno\n")
+	addSketch("Projects/WeatherStation", "profiles:\n  esp:\n    fqbn: esp32:esp32:esp32\n    platforms:\n      - platform: esp32:esp32 (2.0.0)\n")
+	addSketch("Projects/Blink2", "")
+	addSketch("libraries/NotASketch", "")
+	addSketch("Broken", "default_fqbn: [\n")
+	require.NoError(t, sketchbook.Join("Empty").MkdirAll())
+
+	names := func(res *rpc.SketchbookListResponse) []string {
+		res2 := []string{}
+		for _, sk := range res.GetSketches() {
+			res2 = append(res2, paths.New(sk.GetLocationPath()).Base())
+		}
+		return res2
+	}
+
+	res, err := SketchbookList(context.Background(), &rpc.SketchbookListRequest{SketchbookPath: sketchbook.String()})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Blink", "Blink2", "WeatherStation"}, names(res))
+	require.Len(t, res.GetInvalidSketches(), 1)
+	require.Equal(t, sketchbook.Join("Broken").String(), res.GetInvalidSketches()[0].GetPath())
+
+	res, err = SketchbookList(context.Background(), &rpc.SketchbookListRequest{SketchbookPath: sketchbook.String(), SearchArgs: "blink"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Blink", "Blink2"}, names(res))
+
+	res, err = SketchbookList(context.Background(), &rpc.SketchbookListRequest{SketchbookPath: sketchbook.String(), Fqbn: "arduino:avr"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Blink"}, names(res))
+
+	res, err = SketchbookList(context.Background(), &rpc.SketchbookListRequest{SketchbookPath: sketchbook.String(), Fqbn: "esp32:esp32:esp32"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"WeatherStation"}, names(res))
+
+	_, err = SketchbookList(context.Background(), &rpc.SketchbookListRequest{SketchbookPath: sketchbook.Join("missing").String()})
+	require.Error(t, err)
+}
@@ -0,0 +1,3 @@
+#pragma once
+#include "src/util.h"
+#define LED 13
@@ -0,0 +1,6 @@
+#include "config.h"
+#include "config.h"
+
+void setup() {}
+
+void loop() {}
@@ -0,0 +1,108 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/builder"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Symbolize resolves the addresses reported by a firmware using the
+// executable saved in the symbols archive when the firmware was compiled.
+func Symbolize(ctx context.Context, req *rpc.SymbolizeRequest) (*rpc.SymbolizeResponse, error) {
+	buildUUID, err := uuid.FromString(req.GetBuildUuid())
+	if err != nil {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid build UUID"), Cause: err}
+	}
+	if len(req.GetAddresses()) == 0 {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("No address to resolve")}
+	}
+	addresses := []string{}
+	for _, address := range req.GetAddresses() {
+		value, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(address), "0x"), 16, 64)
+		if err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid address %s", address), Cause: err}
+		}
+		addresses = append(addresses, fmt.Sprintf("0x%x", value))
+	}
+
+	archiveDir := configuration.SymbolsDir(configuration.Settings).Join(buildUUID.String())
+	data, err := archiveDir.Join(builder.SymbolsArchiveIndexFile).ReadFile()
+	if err != nil {
+		return nil, &cmderrors.NotFoundError{Message: tr("Build %s not found in the symbols archive", buildUUID), Cause: err}
+	}
+	var index builder.SymbolsArchiveIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid symbols archive of build %s", buildUUID), Cause: err}
+	}
+	if index.Addr2Line == "" || index.Executable == "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid symbols archive of build %s", buildUUID)}
+	}
+
+	args := append([]string{index.Addr2Line, "-e", archiveDir.Join(index.Executable).String(), "-a", "-f", "-C", "-i"}, addresses...)
+	proc, err := toolenv.NewProcess(nil, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tr("Cannot execute %s", index.Addr2Line), err)
+	}
+	stdout, stderr, err := proc.RunAndCaptureOutput(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", tr("Cannot execute %s", index.Addr2Line), err, strings.TrimSpace(string(stderr)))
+	}
+	return &rpc.SymbolizeResponse{Addresses: parseAddr2LineOutput(string(stdout))}, nil
+}
+
+// parseAddr2LineOutput parses the output of `addr2line -a -f -i`: each
+// address is followed by pairs of lines with the function name and the
+// source location, one pair for each inlined function.
+func parseAddr2LineOutput(output string) []*rpc.SymbolizedAddress {
+	res := []*rpc.SymbolizedAddress{}
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "0x") {
+			res = append(res, &rpc.SymbolizedAddress{Address: line, Locations: []*rpc.SourceLocation{}})
+			continue
+		}
+		if line == "" || len(res) == 0 || i+1 >= len(lines) {
+			continue
+		}
+		location := &rpc.SourceLocation{Function: line}
+		if location.Function == "??" {
+			location.Function = ""
+		}
+		i++
+		fileAndLine, _, _ := strings.Cut(strings.TrimSpace(lines[i]), " (discriminator")
+		if sep := strings.LastIndex(fileAndLine, ":"); sep != -1 {
+			if file := fileAndLine[:sep]; file != "??" {
+				location.File = file
+			}
+			location.Line, _ = strconv.ParseInt(fileAndLine[sep+1:], 10, 64)
+		}
+		current := res[len(res)-1]
+		current.Locations = append(current.Locations, location)
+	}
+	return res
+}
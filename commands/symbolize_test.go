@@ -0,0 +1,47 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddr2LineOutput(t *testing.T) {
+	output := "0x000001f4\n" +
+		"digitalWrite\n" +
+		"/home/user/Arduino/Blink/Blink.ino:12 (discriminator 1)\n" +
+		"loop\n" +
+		"/home/user/Arduino/Blink/Blink.ino:20\n" +
+		"0x00ffffff\n" +
+		"??\n" +
+		"??:0\n"
+	res := parseAddr2LineOutput(output)
+	require.Len(t, res, 2)
+
+	require.Equal(t, "0x000001f4", res[0].GetAddress())
+	require.Equal(t, []*rpc.SourceLocation{
+		{Function: "digitalWrite", File: "/home/user/Arduino/Blink/Blink.ino", Line: 12},
+		{Function: "loop", File: "/home/user/Arduino/Blink/Blink.ino", Line: 20},
+	}, res[0].GetLocations())
+
+	require.Equal(t, "0x00ffffff", res[1].GetAddress())
+	require.Len(t, res[1].GetLocations(), 1)
+	require.Empty(t, res[1].GetLocations()[0].GetFunction())
+	require.Empty(t, res[1].GetLocations()[0].GetFile())
+}
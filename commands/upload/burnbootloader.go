@@ -48,9 +48,11 @@ func BurnBootloader(ctx context.Context, req *rpc.BurnBootloaderRequest, outStre
 		req.GetProgrammer(),
 		req.GetVerbose(),
 		req.GetVerify(),
-		true, // burnBootloader
+		true,  // burnBootloader
+		false, // preflight
 		outStream,
 		errStream,
+		nil, // progressCB
 		req.GetDryRun(),
 		map[string]string{}, // User fields
 	); err != nil {
@@ -0,0 +1,283 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// BoardCertificates flashes root certificates, and optionally a new firmware,
+// to the network module of a board. If the platform declares a loader sketch
+// with the `certificates.loader` property, the loader is uploaded to the board
+// before running the `firmware.write.pattern` and `certificates.write.pattern`
+// recipes of the tool selected with `certificates.tool`.
+func BoardCertificates(ctx context.Context, req *rpc.BoardCertificatesRequest, outStream io.Writer, errStream io.Writer) (*rpc.BoardCertificatesResult, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("urls", req.GetUrls()).
+		Trace("BoardCertificates started")
+
+	if len(req.GetUrls()) == 0 && len(req.GetCertificateFiles()) == 0 && req.GetFirmwareFile() == "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("No certificates or firmware to flash")}
+	}
+
+	res := &rpc.BoardCertificatesResult{}
+	var certs []*x509.Certificate
+	addCertificate := func(cert *x509.Certificate, source string) {
+		certs = append(certs, cert)
+		res.Certificates = append(res.Certificates, &rpc.BoardCertificate{
+			Subject:  cert.Subject.String(),
+			Source:   source,
+			NotAfter: cert.NotAfter.Format(time.RFC3339),
+		})
+	}
+	for _, u := range req.GetUrls() {
+		cert, err := fetchRootCertificate(ctx, u)
+		if err != nil {
+			return nil, &cmderrors.FailedDownloadError{Message: tr("Error getting the root certificate of %s", u), Cause: err}
+		}
+		addCertificate(cert, u)
+	}
+	for _, file := range req.GetCertificateFiles() {
+		fileCerts, err := loadCertificates(paths.New(file))
+		if err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Error reading certificates from %s", file), Cause: err}
+		}
+		for _, cert := range fileCerts {
+			addCertificate(cert, file)
+		}
+	}
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	fqbn, err := cores.ParseFQBN(req.GetFqbn())
+	if err != nil {
+		return nil, &cmderrors.InvalidFQBNError{Cause: err}
+	}
+	_, boardPlatform, _, boardProperties, _, err := pme.ResolveFQBN(fqbn)
+	if boardPlatform == nil {
+		return nil, &cmderrors.PlatformNotFoundError{
+			Platform: fmt.Sprintf("%s:%s", fqbn.Package, fqbn.PlatformArch),
+			Cause:    err,
+		}
+	} else if err != nil {
+		return nil, &cmderrors.UnknownFQBNError{Cause: err}
+	}
+
+	// Upload the loader sketch that exposes the network module to the tool
+	userPort, err := uploadLoaderSketch(pme, boardPlatform, boardProperties, "certificates.loader",
+		req.GetFqbn(), req.GetPort(), req.GetVerbose(), req.GetDryRun(), outStream, errStream)
+	if err != nil {
+		return nil, err
+	}
+
+	port := rpc.DiscoveryPortFromRPCPort(userPort)
+	if port == nil || (port.Address == "" && port.Protocol == "") {
+		port = &discovery.Port{Protocol: "default"}
+	}
+	props, err := loadUploadToolProperties(pme, boardPlatform, boardProperties, nil, "certificates", port.Protocol)
+	var missingTool *cmderrors.MissingPlatformPropertyError
+	if errors.As(err, &missingTool) {
+		props, err = loadUploadToolProperties(pme, boardPlatform, boardProperties, nil, "upload", port.Protocol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	setPortProperties(props, port)
+	if req.GetVerbose() {
+		props.Set("certificates.verbose", props.Get("certificates.params.verbose"))
+	} else {
+		props.Set("certificates.verbose", props.Get("certificates.params.quiet"))
+	}
+
+	if !req.GetDryRun() && port.Address != "" {
+		releasePort := portlock.Acquire(port.Protocol, port.Address)
+		defer releasePort()
+	}
+	toolEnv := pme.GetEnvVarsForSpawnedProcess()
+
+	// The firmware is flashed first since it may erase the certificates
+	if firmware := req.GetFirmwareFile(); firmware != "" {
+		if !props.ContainsKey("firmware.write.pattern") {
+			return nil, &cmderrors.MissingPlatformPropertyError{Property: "firmware.write.pattern"}
+		}
+		props.Set("firmware.file", firmware)
+		if err := runTool("firmware.write.pattern", props, outStream, errStream, req.GetVerbose(), req.GetDryRun(), toolEnv); err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed flashing the firmware"), Cause: err}
+		}
+		res.FirmwareFlashed = true
+	}
+
+	if len(certs) > 0 {
+		if !props.ContainsKey("certificates.write.pattern") {
+			return nil, &cmderrors.MissingPlatformPropertyError{Property: "certificates.write.pattern"}
+		}
+		tmpDir, err := paths.MkTempDir("", "arduino-certificates")
+		if err != nil {
+			return nil, &cmderrors.TempDirCreationFailedError{Cause: err}
+		}
+		defer tmpDir.RemoveAll()
+		bundle := tmpDir.Join("certificates.pem")
+		if err := bundle.WriteFile(encodeCertificates(certs)); err != nil {
+			return nil, &cmderrors.PermissionDeniedError{Message: tr("Error writing certificates"), Cause: err}
+		}
+		props.Set("certificates.file", bundle.String())
+		props.Set("certificates.count", fmt.Sprint(len(certs)))
+		if err := runTool("certificates.write.pattern", props, outStream, errStream, req.GetVerbose(), req.GetDryRun(), toolEnv); err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed flashing the certificates"), Cause: err}
+		}
+	}
+	return res, nil
+}
+
+// uploadLoaderSketch uploads the precompiled sketch contained in the folder,
+// relative to the platform folder, declared by the given board property. The
+// port to use after the upload is returned. If the property is not defined
+// nothing is uploaded.
+func uploadLoaderSketch(pme *packagemanager.Explorer, boardPlatform *cores.PlatformRelease, boardProperties *properties.Map,
+	property string, fqbn string, port *rpc.Port, verbose, dryRun bool, outStream, errStream io.Writer,
+) (*rpc.Port, error) {
+	loader := boardProperties.Get(property)
+	if loader == "" {
+		return port, nil
+	}
+	loaderDir := boardPlatform.InstallDir.Join(loader)
+	if !loaderDir.IsDir() {
+		return nil, &cmderrors.InvalidPlatformPropertyError{Property: property, Value: loader}
+	}
+	updatedPort, err := runProgramAction(
+		pme,
+		nil, // sketch
+		"",  // importFile
+		loaderDir.String(),
+		fqbn,
+		port,
+		"", // programmer
+		verbose,
+		false, // verify
+		false, // burnBootloader
+		false, // preflight
+		outStream,
+		errStream,
+		nil, // progressCB
+		dryRun,
+		map[string]string{}, // User fields
+	)
+	if err != nil {
+		return nil, &cmderrors.FailedUploadError{Message: tr("Failed uploading the loader sketch"), Cause: err}
+	}
+	if updatedPort != nil {
+		return updatedPort, nil
+	}
+	return port, nil
+}
+
+// fetchRootCertificate connects to the given server and returns the root
+// certificate of the verified chain. The address may be a URL or a
+// `host[:port]` pair, if the port is missing 443 is used.
+func fetchRootCertificate(ctx context.Context, address string) (*x509.Certificate, error) {
+	host := address
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, err
+		}
+		host = u.Host
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "443")
+	}
+
+	dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: 30 * time.Second}}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	chains := conn.(*tls.Conn).ConnectionState().VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil, errors.New(tr("no verified certificate chain"))
+	}
+	chain := chains[0]
+	return chain[len(chain)-1], nil
+}
+
+// loadCertificates reads the certificates contained in a PEM or DER file
+func loadCertificates(file *paths.Path) ([]*x509.Certificate, error) {
+	data, err := file.ReadFile()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		return x509.ParseCertificates(data)
+	}
+	var res []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cert)
+	}
+	if len(res) == 0 {
+		return nil, errors.New(tr("no certificates found"))
+	}
+	return res, nil
+}
+
+// encodeCertificates returns the PEM encoding of the given certificates
+func encodeCertificates(certs []*x509.Certificate) []byte {
+	var res bytes.Buffer
+	for _, cert := range certs {
+		pem.Encode(&res, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return res.Bytes()
+}
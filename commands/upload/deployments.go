@@ -0,0 +1,103 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"context"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/buildmanifest"
+	"github.com/arduino/arduino-cli/internal/arduino/deployments"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+)
+
+// DeploymentsList returns the last sketch uploaded to each board
+func DeploymentsList(ctx context.Context, req *rpc.DeploymentsListRequest) (*rpc.DeploymentsListResponse, error) {
+	registry, err := deployments.Load(configuration.DeploymentsFile(configuration.Settings))
+	if err != nil {
+		return nil, &cmderrors.PermissionDeniedError{Message: tr("Error reading the deployments registry"), Cause: err}
+	}
+	return &rpc.DeploymentsListResponse{Deployments: deploymentsToRPC(registry.Latest())}, nil
+}
+
+// DeploymentsShow returns the history of the sketches uploaded to a board
+func DeploymentsShow(ctx context.Context, req *rpc.DeploymentsShowRequest) (*rpc.DeploymentsShowResponse, error) {
+	if req.GetBoard() == "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Missing serial number or port address of the board")}
+	}
+	registry, err := deployments.Load(configuration.DeploymentsFile(configuration.Settings))
+	if err != nil {
+		return nil, &cmderrors.PermissionDeniedError{Message: tr("Error reading the deployments registry"), Cause: err}
+	}
+	history := registry.History(req.GetBoard())
+	if len(history) == 0 {
+		return nil, &cmderrors.NotFoundError{Message: tr("No sketch has been uploaded to %s", req.GetBoard())}
+	}
+	return &rpc.DeploymentsShowResponse{Deployments: deploymentsToRPC(history)}, nil
+}
+
+func deploymentsToRPC(list []*deployments.Deployment) []*rpc.Deployment {
+	res := []*rpc.Deployment{}
+	for _, d := range list {
+		res = append(res, &rpc.Deployment{
+			SerialNumber: d.SerialNumber,
+			PortAddress:  d.PortAddress,
+			PortProtocol: d.PortProtocol,
+			Fqbn:         d.FQBN,
+			Sketch:       d.Sketch,
+			BuildUuid:    d.BuildUUID,
+			Timestamp:    d.Timestamp.Format(time.RFC3339),
+		})
+	}
+	return res
+}
+
+// recordDeployment adds a successful upload to the deployments registry. The
+// serial number of the board is taken from the upload port or, if missing,
+// from the port where the board reconnected after the upload.
+func recordDeployment(registryFile *paths.Path, sk *sketch.Sketch, buildPath *paths.Path, projectName, fqbn string, port, updatedPort *discovery.Port) error {
+	d := &deployments.Deployment{
+		PortAddress:  port.Address,
+		PortProtocol: port.Protocol,
+		FQBN:         fqbn,
+		Sketch:       projectName,
+		Timestamp:    time.Now(),
+	}
+	if port.Properties != nil {
+		d.SerialNumber = port.Properties.Get("serialNumber")
+	}
+	if d.SerialNumber == "" && updatedPort != nil && updatedPort.Properties != nil {
+		d.SerialNumber = updatedPort.Properties.Get("serialNumber")
+	}
+	if sk != nil {
+		d.Sketch = sk.FullPath.String()
+	}
+	if manifest, err := buildmanifest.Load(buildPath); err == nil && manifest != nil {
+		d.BuildUUID = manifest.BuildUUID
+	}
+
+	registry, err := deployments.Load(registryFile)
+	if err != nil {
+		return err
+	}
+	registry.Add(d)
+	return registry.Save(registryFile)
+}
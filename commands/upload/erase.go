@@ -0,0 +1,90 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"context"
+	"io"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// EraseChip erases the whole memory of the microcontroller of the board using
+// the `erase.pattern` recipe of the tool selected with `erase.tool.<protocol>`.
+// If the property is missing the tool used to burn the bootloader is selected
+// when a programmer is used, otherwise the tool used for upload.
+func EraseChip(ctx context.Context, req *rpc.EraseChipRequest, outStream io.Writer, errStream io.Writer) (*rpc.EraseChipResponse, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("programmer", req.GetProgrammer()).
+		Trace("EraseChip started")
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if req.GetFqbn() == "" {
+		return nil, &cmderrors.MissingFQBNError{}
+	}
+	port := rpc.DiscoveryPortFromRPCPort(req.GetPort())
+	if port == nil || (port.Address == "" && port.Protocol == "") {
+		port = &discovery.Port{Protocol: "default"}
+	}
+
+	props, err := loadBoardActionTool(pme, req.GetFqbn(), req.GetProgrammer(), "erase", port.Protocol, "bootloader", "upload")
+	if err != nil {
+		return nil, err
+	}
+	if !props.ContainsKey("erase.pattern") {
+		return nil, &cmderrors.MissingPlatformPropertyError{Property: "erase.pattern"}
+	}
+	if !req.GetForce() && !req.GetDryRun() {
+		return nil, &cmderrors.IrreversibleOperationError{Message: tr("Erasing the chip deletes the sketch, the bootloader and all the data stored in the board")}
+	}
+
+	setActionVerbosity(props, "erase", req.GetVerbose())
+	props.Set("erase.verify", props.Get("erase.params.noverify"))
+	setPortProperties(props, port)
+
+	if !req.GetDryRun() && port.Address != "" {
+		releasePort := portlock.Acquire(port.Protocol, port.Address)
+		defer releasePort()
+	}
+
+	if err := runTool("erase.pattern", props, outStream, errStream, req.GetVerbose(), req.GetDryRun(), pme.GetEnvVarsForSpawnedProcess()); err != nil {
+		return nil, &cmderrors.FailedUploadError{Message: tr("Failed chip erase"), Cause: err}
+	}
+	return &rpc.EraseChipResponse{}, nil
+}
+
+// setActionVerbosity sets the `<action>.verbose` property to the value of
+// `<action>.params.verbose` or `<action>.params.quiet`.
+func setActionVerbosity(props *properties.Map, action string, verbose bool) {
+	if verbose {
+		props.Set(action+".verbose", props.Get(action+".params.verbose"))
+	} else {
+		props.Set(action+".verbose", props.Get(action+".params.quiet"))
+	}
+}
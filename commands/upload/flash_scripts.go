@@ -0,0 +1,306 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// The variables used in the flash scripts. The placeholders are put in the
+// upload recipe in place of the values known only when the script is run.
+const (
+	flashScriptBuildDirVar = "BUILD_DIR"
+	flashScriptPortVar     = "PORT"
+	flashScriptPlaceholder = "\x00"
+)
+
+var flashScriptInvalidVarChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// FlashScriptMetadata describes how to flash the binaries exported by a
+// compilation, it's saved as flash.json together with the flash script.
+type FlashScriptMetadata struct {
+	FQBN        string `json:"fqbn"`
+	ProjectName string `json:"project_name"`
+	Tool        string `json:"tool"`
+	// Tools are the tools used by the command, the scripts look for them in
+	// the installation folder of this machine unless the corresponding
+	// variable is set in the environment.
+	Tools []*FlashScriptTool `json:"tools"`
+	// Files are the exported binaries
+	Files []string `json:"files"`
+	// Command is the flashing command line, the ${VARIABLE} placeholders are
+	// replaced when the script is run.
+	Command           []string `json:"command"`
+	RequiresPort      bool     `json:"requires_port"`
+	Use1200bpsTouch   bool     `json:"use_1200bps_touch"`
+	WaitForUploadPort bool     `json:"wait_for_upload_port"`
+
+	// commandParts are the arguments of the command, each one split in
+	// literal strings, at even indexes, and names of variables, at odd indexes.
+	commandParts [][]string
+}
+
+// FlashScriptTool is a tool used by the flashing command
+type FlashScriptTool struct {
+	Packager string `json:"packager"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Variable string `json:"variable"`
+}
+
+// GenerateFlashScripts writes in exportDir a script that flashes the exported
+// binaries of the given project using the upload tool of the board, and the
+// flash.json file with the metadata of the flashing command. The script is
+// `flash.sh` (or `flash.bat` on Windows) and takes the port as argument.
+func GenerateFlashScripts(pme *packagemanager.Explorer, fqbn *cores.FQBN, exportDir *paths.Path, projectName string) error {
+	_, boardPlatform, _, boardProperties, _, err := pme.ResolveFQBN(fqbn)
+	if boardPlatform == nil {
+		return &cmderrors.PlatformNotFoundError{
+			Platform: fmt.Sprintf("%s:%s", fqbn.Package, fqbn.PlatformArch),
+			Cause:    err,
+		}
+	} else if err != nil {
+		return &cmderrors.UnknownFQBNError{Cause: err}
+	}
+	uploadProperties, err := loadUploadToolProperties(pme, boardPlatform, boardProperties, nil, "upload", "serial")
+	if err != nil {
+		return err
+	}
+	if !uploadProperties.ContainsKey("upload.protocol") {
+		return &cmderrors.ProgrammerRequiredForUploadError{}
+	}
+	uploadProperties.Set("upload.verbose", uploadProperties.Get("upload.params.quiet"))
+	uploadProperties.Set("upload.verify", uploadProperties.Get("upload.params.noverify"))
+	uploadProperties.Set("build.project_name", projectName)
+
+	files, err := exportDir.ReadDir()
+	if err != nil {
+		return err
+	}
+	files.FilterPrefix(projectName)
+	files.FilterOutDirs()
+	files.Sort()
+
+	tools := map[*paths.Path]*FlashScriptTool{}
+	for _, tool := range pme.GetAllInstalledToolsReleases() {
+		tools[tool.InstallDir] = &FlashScriptTool{
+			Packager: tool.Tool.Package.Name,
+			Name:     tool.Tool.Name,
+			Version:  tool.Version.String(),
+			Variable: "TOOL_" + flashScriptInvalidVarChars.ReplaceAllString(strings.ToUpper(tool.Tool.Name), "_"),
+		}
+	}
+	uploadToolID, _ := getToolID(uploadProperties, "upload", "serial")
+	metadata, toolsPaths, err := flashScriptCommand(uploadProperties, tools)
+	if err != nil {
+		return err
+	}
+	metadata.FQBN = fqbn.String()
+	metadata.ProjectName = projectName
+	metadata.Tool = uploadToolID
+	for _, file := range files {
+		metadata.Files = append(metadata.Files, file.Base())
+	}
+
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := exportDir.Join("flash.json").WriteFile(append(data, '\n')); err != nil {
+		return &cmderrors.PermissionDeniedError{Message: tr("Error writing flash script"), Cause: err}
+	}
+	if runtime.GOOS == "windows" {
+		script := flashScriptBat(metadata, toolsPaths)
+		if err := exportDir.Join("flash.bat").WriteFile([]byte(script)); err != nil {
+			return &cmderrors.PermissionDeniedError{Message: tr("Error writing flash script"), Cause: err}
+		}
+		return nil
+	}
+	script := exportDir.Join("flash.sh")
+	if err := script.WriteFile([]byte(flashScriptSh(metadata, toolsPaths))); err != nil {
+		return &cmderrors.PermissionDeniedError{Message: tr("Error writing flash script"), Cause: err}
+	}
+	return script.Chmod(0755)
+}
+
+// flashScriptCommand expands the upload recipe using placeholders for the
+// build folder, the port and the installation folder of the tools. It returns
+// the metadata with the command and the installation folder of the tools used.
+func flashScriptCommand(uploadProperties *properties.Map, tools map[*paths.Path]*FlashScriptTool) (*FlashScriptMetadata, map[string]string, error) {
+	placeholder := func(variable string) string {
+		return flashScriptPlaceholder + variable + flashScriptPlaceholder
+	}
+	props := uploadProperties.Clone()
+	props.Set("build.path", placeholder(flashScriptBuildDirVar))
+	props.Set("serial.port", placeholder(flashScriptPortVar))
+	props.Set("serial.port.file", placeholder(flashScriptPortVar))
+	props.Set("upload.port.address", placeholder(flashScriptPortVar))
+	props.Set("upload.port.label", placeholder(flashScriptPortVar))
+	props.Set("upload.port.protocol", "serial")
+
+	recipe, ok := props.GetOk("upload.pattern")
+	if !ok {
+		return nil, nil, fmt.Errorf(tr("recipe not found '%s'"), "upload.pattern")
+	}
+	cmdLine := props.ExpandPropsInString(recipe)
+
+	// Replace the installation folder of the tools, the longest paths first
+	// to correctly handle nested folders.
+	installDirs := []*paths.Path{}
+	for dir := range tools {
+		installDirs = append(installDirs, dir)
+	}
+	sort.Slice(installDirs, func(i, j int) bool { return len(installDirs[i].String()) > len(installDirs[j].String()) })
+	metadata := &FlashScriptMetadata{
+		Tools:             []*FlashScriptTool{},
+		Use1200bpsTouch:   props.GetBoolean("upload.use_1200bps_touch"),
+		WaitForUploadPort: props.GetBoolean("upload.wait_for_upload_port"),
+	}
+	toolsPaths := map[string]string{}
+	for _, dir := range installDirs {
+		if !strings.Contains(cmdLine, dir.String()) {
+			continue
+		}
+		tool := tools[dir]
+		if _, exists := toolsPaths[tool.Variable]; exists {
+			// Another version of the same tool is used
+			tool.Variable += "_" + flashScriptInvalidVarChars.ReplaceAllString(strings.ToUpper(tool.Version), "_")
+		}
+		cmdLine = strings.ReplaceAll(cmdLine, dir.String(), placeholder(tool.Variable))
+		metadata.Tools = append(metadata.Tools, tool)
+		toolsPaths[tool.Variable] = dir.String()
+	}
+	sort.Slice(metadata.Tools, func(i, j int) bool { return metadata.Tools[i].Variable < metadata.Tools[j].Variable })
+
+	args, err := properties.SplitQuotedString(cmdLine, `"'`, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf(tr("invalid recipe '%[1]s': %[2]s"), recipe, err)
+	}
+	for _, arg := range args {
+		parts := strings.Split(arg, flashScriptPlaceholder)
+		command := ""
+		for i, part := range parts {
+			if i%2 == 0 {
+				command += part
+				continue
+			}
+			if part == flashScriptPortVar {
+				metadata.RequiresPort = true
+			}
+			command += "${" + part + "}"
+		}
+		metadata.Command = append(metadata.Command, command)
+		metadata.commandParts = append(metadata.commandParts, parts)
+	}
+	return metadata, toolsPaths, nil
+}
+
+// flashScriptSh returns the POSIX shell flash script
+func flashScriptSh(metadata *FlashScriptMetadata, toolsPaths map[string]string) string {
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&script, "# Flash %s on %s, generated by arduino-cli.\n", metadata.ProjectName, metadata.FQBN)
+	script.WriteString("# The tools are searched in the folders where they are installed on the machine that\n")
+	script.WriteString("# compiled the sketch, set the TOOL_* variables to use a different location.\n")
+	script.WriteString("set -e\n")
+	fmt.Fprintf(&script, "%s=\"$(cd \"$(dirname \"$0\")\" && pwd)\"\n", flashScriptBuildDirVar)
+	if metadata.RequiresPort {
+		fmt.Fprintf(&script, "%s=\"$1\"\n", flashScriptPortVar)
+		fmt.Fprintf(&script, "if [ -z \"$%s\" ]; then\n\techo \"Usage: $0 PORT\" >&2\n\texit 1\nfi\n", flashScriptPortVar)
+	}
+	for _, tool := range metadata.Tools {
+		fmt.Fprintf(&script, "%s=\"${%s:-%s}\"\n", tool.Variable, tool.Variable, strings.ReplaceAll(toolsPaths[tool.Variable], `"`, `\"`))
+	}
+	if metadata.RequiresPort && metadata.Use1200bpsTouch {
+		script.WriteString("# Reset the board in bootloader mode\n")
+		fmt.Fprintf(&script, "stty -F \"$%[1]s\" 1200 2>/dev/null || stty -f \"$%[1]s\" 1200\n", flashScriptPortVar)
+		script.WriteString("sleep 2\n")
+	}
+
+	args := []string{}
+	for _, parts := range metadata.commandParts {
+		res := ""
+		for i, part := range parts {
+			if i%2 == 1 {
+				res += `"${` + part + `}"`
+			} else if part != "" {
+				res += quote(part)
+			}
+		}
+		if res == "" {
+			res = "''"
+		}
+		args = append(args, res)
+	}
+	script.WriteString("exec " + strings.Join(args, " ") + "\n")
+	return script.String()
+}
+
+// flashScriptBat returns the Windows batch flash script
+func flashScriptBat(metadata *FlashScriptMetadata, toolsPaths map[string]string) string {
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "%", "%%")
+	}
+	var script strings.Builder
+	script.WriteString("@echo off\r\n")
+	fmt.Fprintf(&script, "rem Flash %s on %s, generated by arduino-cli.\r\n", metadata.ProjectName, metadata.FQBN)
+	script.WriteString("rem The tools are searched in the folders where they are installed on the machine that\r\n")
+	script.WriteString("rem compiled the sketch, set the TOOL_* variables to use a different location.\r\n")
+	script.WriteString("setlocal\r\n")
+	fmt.Fprintf(&script, "set \"%s=%%~dp0.\"\r\n", flashScriptBuildDirVar)
+	if metadata.RequiresPort {
+		fmt.Fprintf(&script, "set \"%s=%%~1\"\r\n", flashScriptPortVar)
+		fmt.Fprintf(&script, "if \"%%%s%%\"==\"\" (\r\n\techo Usage: %%~nx0 PORT 1>&2\r\n\texit /b 1\r\n)\r\n", flashScriptPortVar)
+	}
+	for _, tool := range metadata.Tools {
+		fmt.Fprintf(&script, "if not defined %[1]s set \"%[1]s=%[2]s\"\r\n", tool.Variable, escape(toolsPaths[tool.Variable]))
+	}
+	if metadata.RequiresPort && metadata.Use1200bpsTouch {
+		script.WriteString("rem Reset the board in bootloader mode\r\n")
+		fmt.Fprintf(&script, "mode %%%s%%: baud=1200 > nul\r\n", flashScriptPortVar)
+		script.WriteString("timeout /t 2 /nobreak > nul\r\n")
+	}
+
+	args := []string{}
+	for _, parts := range metadata.commandParts {
+		res := ""
+		for i, part := range parts {
+			if i%2 == 1 {
+				res += "%" + part + "%"
+			} else {
+				res += escape(part)
+			}
+		}
+		args = append(args, `"`+res+`"`)
+	}
+	script.WriteString(strings.Join(args, " ") + "\r\n")
+	script.WriteString("exit /b %ERRORLEVEL%\r\n")
+	return script.String()
+}
@@ -0,0 +1,70 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlashScriptCommand(t *testing.T) {
+	toolDir := paths.New("/opt", "tools", "avrdude", "6.3.0")
+	tools := map[*paths.Path]*FlashScriptTool{
+		toolDir:                              {Name: "avrdude", Version: "6.3.0", Variable: "TOOL_AVRDUDE"},
+		paths.New("/opt", "tools", "bossac"): {Name: "bossac", Version: "1.9.1", Variable: "TOOL_BOSSAC"},
+	}
+	props := properties.NewMap()
+	props.Set("path", toolDir.String())
+	props.Set("build.project_name", "Blink.ino")
+	props.Set("upload.verbose", "-q")
+	props.Set("upload.use_1200bps_touch", "true")
+	props.Set("upload.pattern", `"{path}/bin/avrdude" "-C{path}/etc/avrdude.conf" {upload.verbose} "-P{serial.port}" "-Uflash:w:{build.path}/{build.project_name}.hex:i" '50%'`)
+
+	metadata, toolsPaths, err := flashScriptCommand(props, tools)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"${TOOL_AVRDUDE}/bin/avrdude",
+		"-C${TOOL_AVRDUDE}/etc/avrdude.conf",
+		"-q",
+		"-P${PORT}",
+		"-Uflash:w:${BUILD_DIR}/Blink.ino.hex:i",
+		"50%",
+	}, metadata.Command)
+	require.Len(t, metadata.Tools, 1)
+	require.Equal(t, "avrdude", metadata.Tools[0].Name)
+	require.Equal(t, map[string]string{"TOOL_AVRDUDE": toolDir.String()}, toolsPaths)
+	require.True(t, metadata.RequiresPort)
+	require.True(t, metadata.Use1200bpsTouch)
+
+	sh := flashScriptSh(metadata, toolsPaths)
+	require.Contains(t, sh, `TOOL_AVRDUDE="${TOOL_AVRDUDE:-`+toolDir.String()+`}"`)
+	require.Contains(t, sh, `exec "${TOOL_AVRDUDE}"'/bin/avrdude' '-C'"${TOOL_AVRDUDE}"'/etc/avrdude.conf' '-q' '-P'"${PORT}" '-Uflash:w:'"${BUILD_DIR}"'/Blink.ino.hex:i' '50%'`+"\n")
+
+	bat := strings.ReplaceAll(flashScriptBat(metadata, toolsPaths), "\r\n", "\n")
+	require.Contains(t, bat, `if not defined TOOL_AVRDUDE set "TOOL_AVRDUDE=`+toolDir.String()+`"`)
+	require.Contains(t, bat, `"%TOOL_AVRDUDE%/bin/avrdude" "-C%TOOL_AVRDUDE%/etc/avrdude.conf" "-q" "-P%PORT%" "-Uflash:w:%BUILD_DIR%/Blink.ino.hex:i" "50%%"`+"\n")
+
+	// Recipes without the port
+	props.Set("upload.pattern", `"{path}/bin/avrdude" "{build.path}/{build.project_name}.hex"`)
+	metadata, _, err = flashScriptCommand(props, tools)
+	require.NoError(t, err)
+	require.False(t, metadata.RequiresPort)
+	require.NotContains(t, flashScriptSh(metadata, toolsPaths), "PORT")
+}
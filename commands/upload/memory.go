@@ -0,0 +1,271 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ReadMemory reads a memory range of the board using the tools declared by
+// the platform and saves it to the requested file.
+func ReadMemory(ctx context.Context, req *rpc.ReadMemoryRequest, outStream io.Writer, errStream io.Writer) (*rpc.ReadMemoryResponse, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("memory", req.GetMemoryType()).
+		Trace("ReadMemory started")
+
+	if req.GetOutputFile() == "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Missing output file")}
+	}
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := runMemoryAction(pme, "memory.read.pattern", &memoryAction{
+		fqbn:         req.GetFqbn(),
+		port:         req.GetPort(),
+		programmerID: req.GetProgrammer(),
+		memoryType:   req.GetMemoryType(),
+		address:      req.GetAddress(),
+		size:         req.GetSize(),
+		file:         req.GetOutputFile(),
+		verbose:      req.GetVerbose(),
+		dryRun:       req.GetDryRun(),
+	}, outStream, errStream); err != nil {
+		return nil, &cmderrors.FailedUploadError{Message: tr("Failed reading memory"), Cause: err}
+	}
+	return &rpc.ReadMemoryResponse{}, nil
+}
+
+// WriteMemory writes the content of a file in a memory range of the board
+// using the tools declared by the platform.
+func WriteMemory(ctx context.Context, req *rpc.WriteMemoryRequest, outStream io.Writer, errStream io.Writer) (*rpc.WriteMemoryResponse, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("memory", req.GetMemoryType()).
+		Trace("WriteMemory started")
+
+	if req.GetInputFile() == "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Missing input file")}
+	}
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := runMemoryAction(pme, "memory.write.pattern", &memoryAction{
+		fqbn:         req.GetFqbn(),
+		port:         req.GetPort(),
+		programmerID: req.GetProgrammer(),
+		memoryType:   req.GetMemoryType(),
+		address:      req.GetAddress(),
+		file:         req.GetInputFile(),
+		verbose:      req.GetVerbose(),
+		verify:       req.GetVerify(),
+		dryRun:       req.GetDryRun(),
+	}, outStream, errStream); err != nil {
+		return nil, &cmderrors.FailedUploadError{Message: tr("Failed writing memory"), Cause: err}
+	}
+	return &rpc.WriteMemoryResponse{}, nil
+}
+
+type memoryAction struct {
+	fqbn            string
+	port            *rpc.Port
+	programmerID    string
+	memoryType      string
+	address, size   uint64
+	file            string
+	verbose, verify bool
+	dryRun          bool
+}
+
+// runMemoryAction runs the given memory recipe of the upload tool selected for
+// the board. The tool is selected with the `memory.tool.<protocol>` property,
+// if missing the tool used for upload (or for programming, if a programmer is
+// used) is selected instead.
+func runMemoryAction(pme *packagemanager.Explorer, recipeID string, m *memoryAction, outStream, errStream io.Writer) error {
+	if m.memoryType == "" {
+		m.memoryType = "flash"
+	}
+
+	port := rpc.DiscoveryPortFromRPCPort(m.port)
+	if port == nil || (port.Address == "" && port.Protocol == "") {
+		port = &discovery.Port{Protocol: "default"}
+	}
+
+	props, err := loadBoardActionTool(pme, m.fqbn, m.programmerID, "memory", port.Protocol, "program", "upload")
+	if err != nil {
+		return err
+	}
+	if !props.ContainsKey(recipeID) {
+		return &cmderrors.MissingPlatformPropertyError{Property: recipeID}
+	}
+
+	if err := resolveMemoryRegion(props, m); err != nil {
+		return err
+	}
+	setMemoryActionProperties(props, m)
+
+	setPortProperties(props, port)
+
+	if !m.dryRun && port.Address != "" {
+		releasePort := portlock.Acquire(port.Protocol, port.Address)
+		defer releasePort()
+	}
+
+	return runTool(recipeID, props, outStream, errStream, m.verbose, m.dryRun, pme.GetEnvVarsForSpawnedProcess())
+}
+
+// loadBoardActionTool returns the properties of the tool that performs the
+// given action on the board. The tool is selected with the
+// `<action>.tool.<protocol>` property, if missing the tool of the fallback
+// action is selected instead: programmerFallback if a programmer is used,
+// otherwise bootloaderFallback.
+func loadBoardActionTool(pme *packagemanager.Explorer, fqbnIn, programmerID, action, protocol, programmerFallback, bootloaderFallback string) (*properties.Map, error) {
+	fqbn, err := cores.ParseFQBN(fqbnIn)
+	if err != nil {
+		return nil, &cmderrors.InvalidFQBNError{Cause: err}
+	}
+	_, boardPlatform, _, boardProperties, buildPlatform, err := pme.ResolveFQBN(fqbn)
+	if boardPlatform == nil {
+		return nil, &cmderrors.PlatformNotFoundError{
+			Platform: fmt.Sprintf("%s:%s", fqbn.Package, fqbn.PlatformArch),
+			Cause:    err,
+		}
+	} else if err != nil {
+		return nil, &cmderrors.UnknownFQBNError{Cause: err}
+	}
+
+	programmer, err := findProgrammer(boardPlatform, buildPlatform, programmerID)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := loadUploadToolProperties(pme, boardPlatform, boardProperties, programmer, action, protocol)
+	var missingTool *cmderrors.MissingPlatformPropertyError
+	if errors.As(err, &missingTool) {
+		fallbackAction := bootloaderFallback
+		if programmer != nil {
+			fallbackAction = programmerFallback
+		}
+		props, err = loadUploadToolProperties(pme, boardPlatform, boardProperties, programmer, fallbackAction, protocol)
+	}
+	return props, err
+}
+
+// setPortProperties sets the `serial.port` and `upload.port.*` properties
+// for the given port.
+func setPortProperties(props *properties.Map, port *discovery.Port) {
+	if port.Address != "" {
+		props.Set("serial.port", port.Address)
+		if port.Protocol == "serial" || port.Protocol == "default" {
+			props.Set("serial.port.file", strings.TrimPrefix(port.Address, "/dev/"))
+		}
+	}
+	props.Set("upload.port.address", port.Address)
+	props.Set("upload.port.label", port.AddressLabel)
+	props.Set("upload.port.protocol", port.Protocol)
+	props.Set("upload.port.protocolLabel", port.ProtocolLabel)
+	if port.Properties != nil {
+		for prop, value := range port.Properties.AsMap() {
+			props.Set(fmt.Sprintf("upload.port.properties.%s", prop), value)
+		}
+	}
+}
+
+// setMemoryActionProperties sets the `memory.*` properties used by the
+// memory read and write recipes.
+func setMemoryActionProperties(props *properties.Map, m *memoryAction) {
+	props.Set("memory.type", m.memoryType)
+	props.Set("memory.address", fmt.Sprint(m.address))
+	props.Set("memory.address.hex", fmt.Sprintf("0x%X", m.address))
+	props.Set("memory.size", fmt.Sprint(m.size))
+	props.Set("memory.size.hex", fmt.Sprintf("0x%X", m.size))
+	props.Set("memory.file", m.file)
+
+	setActionVerbosity(props, "memory", m.verbose)
+	if m.verify {
+		props.Set("memory.verify", props.Get("memory.params.verify"))
+	} else {
+		props.Set("memory.verify", props.Get("memory.params.noverify"))
+	}
+}
+
+// resolveMemoryRegion translates a memory region declared by the platform into
+// the underlying memory, address and size. For example a board that emulates
+// the EEPROM in flash may declare:
+//
+//	memory.region.eeprom.type=flash
+//	memory.region.eeprom.address=0x3F000
+//	memory.region.eeprom.size=4096
+//
+// the requested address is considered relative to the start of the region.
+func resolveMemoryRegion(props *properties.Map, m *memoryAction) error {
+	region := props.SubTree("memory.region." + m.memoryType)
+	if region.Size() == 0 {
+		return nil
+	}
+	parse := func(key string) (uint64, error) {
+		v, err := strconv.ParseUint(region.Get(key), 0, 64)
+		if err != nil {
+			return 0, &cmderrors.InvalidPlatformPropertyError{
+				Property: fmt.Sprintf("memory.region.%s.%s", m.memoryType, key),
+				Value:    region.Get(key),
+			}
+		}
+		return v, nil
+	}
+	start, err := parse("address")
+	if err != nil {
+		return err
+	}
+	size, err := parse("size")
+	if err != nil {
+		return err
+	}
+	if m.address >= size || m.address+m.size > size {
+		return &cmderrors.InvalidArgumentError{Message: tr("The requested range exceeds the %[1]s region (size %[2]d)", m.memoryType, size)}
+	}
+	if m.size == 0 {
+		m.size = size - m.address
+	}
+	m.address += start
+	m.memoryType = region.Get("type")
+	return nil
+}
@@ -0,0 +1,93 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryActions(t *testing.T) {
+	pme := loadTestHardware(t)
+
+	run := func(recipeID string, m *memoryAction) (string, error) {
+		outStream := &bytes.Buffer{}
+		err := runMemoryAction(pme, recipeID, m, outStream, &bytes.Buffer{})
+		return strings.ReplaceAll(outStream.String(), "\r", ""), err
+	}
+
+	out, err := run("memory.read.pattern", &memoryAction{
+		fqbn:       "alice:avr:board1",
+		port:       &rpc.Port{Address: "port", Protocol: "serial"},
+		memoryType: "eeprom",
+		address:    16,
+		size:       64,
+		file:       "dump.bin",
+	})
+	require.NoError(t, err)
+	require.Contains(t, out, "READ conf-board1 quiet port eeprom 0x10 64 dump.bin\n")
+
+	out, err = run("memory.write.pattern", &memoryAction{
+		fqbn:    "alice:avr:board1",
+		port:    &rpc.Port{Address: "port", Protocol: "serial"},
+		file:    "data.bin",
+		verbose: true,
+		verify:  true,
+	})
+	require.NoError(t, err)
+	require.Contains(t, out, "WRITE conf-board1 verbose verify port flash 0 data.bin\n")
+
+	// board3 emulates the EEPROM in a flash region
+	out, err = run("memory.read.pattern", &memoryAction{
+		fqbn:       "alice:avr:board3",
+		port:       &rpc.Port{Address: "port", Protocol: "serial"},
+		memoryType: "eeprom",
+		file:       "dump.bin",
+	})
+	require.NoError(t, err)
+	require.Contains(t, out, "READ conf-board3 quiet port flash 0x3F000 4096 dump.bin\n")
+
+	out, err = run("memory.read.pattern", &memoryAction{
+		fqbn:       "alice:avr:board3",
+		port:       &rpc.Port{Address: "port", Protocol: "serial"},
+		memoryType: "eeprom",
+		address:    256,
+		file:       "dump.bin",
+	})
+	require.NoError(t, err)
+	require.Contains(t, out, "READ conf-board3 quiet port flash 0x3F100 3840 dump.bin\n")
+
+	_, err = run("memory.read.pattern", &memoryAction{
+		fqbn:       "alice:avr:board3",
+		port:       &rpc.Port{Address: "port", Protocol: "serial"},
+		memoryType: "eeprom",
+		address:    4000,
+		size:       200,
+		file:       "dump.bin",
+	})
+	require.Error(t, err)
+
+	// The tool of board2 does not support memory actions
+	_, err = run("memory.read.pattern", &memoryAction{
+		fqbn: "alice:avr:board2",
+		file: "dump.bin",
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,183 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// BoardPower measures the current drawn by a board, and the target voltage,
+// running the `power.pattern` recipe of the tool selected for the board. The
+// tool must print a line for each sample, the samples are passed to sampleCB.
+// Canceling the context stops the measurement.
+func BoardPower(ctx context.Context, req *rpc.BoardPowerRequest, outStream io.Writer, errStream io.Writer, sampleCB func(*rpc.PowerSample)) error {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("programmer", req.GetProgrammer()).
+		Trace("BoardPower started")
+
+	if req.GetInterval() < 0 || req.GetDuration() < 0 {
+		return &cmderrors.InvalidArgumentError{Message: tr("The interval and the duration of the measurement can't be negative")}
+	}
+
+	port := rpc.DiscoveryPortFromRPCPort(req.GetPort())
+	if port == nil || (port.Address == "" && port.Protocol == "") {
+		port = &discovery.Port{Protocol: "default"}
+	}
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return err
+	}
+	props, err := loadBoardActionTool(pme, req.GetFqbn(), req.GetProgrammer(), "power", port.Protocol, "program", "upload")
+	toolEnv := pme.GetEnvVarsForSpawnedProcess()
+	// The measurement may last indefinitely, don't keep the package manager
+	// locked in the meantime
+	release()
+	if err != nil {
+		return err
+	}
+	if !props.ContainsKey("power.pattern") {
+		return &cmderrors.MissingPlatformPropertyError{Property: "power.pattern"}
+	}
+
+	setActionVerbosity(props, "power", req.GetVerbose())
+	props.Set("power.interval", fmt.Sprint(req.GetInterval()))
+	props.Set("power.duration", fmt.Sprint(req.GetDuration()))
+	setPortProperties(props, port)
+
+	if !req.GetDryRun() && port.Address != "" {
+		releasePort := portlock.Acquire(port.Protocol, port.Address)
+		defer releasePort()
+	}
+
+	toolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if req.GetDuration() > 0 {
+		toolCtx, cancel = context.WithTimeout(toolCtx, time.Duration(req.GetDuration())*time.Millisecond)
+		defer cancel()
+	}
+
+	received := false
+	toolOut := &powerSampleWriter{
+		verbose: req.GetVerbose(),
+		out:     outStream,
+		sampleCB: func(sample *rpc.PowerSample) {
+			if received && !req.GetWatch() {
+				return
+			}
+			received = true
+			sampleCB(sample)
+			if !req.GetWatch() {
+				// A single sample has been requested
+				cancel()
+			}
+		},
+	}
+	err = runToolWithContext(toolCtx, "power.pattern", props, toolOut, errStream, req.GetVerbose(), req.GetDryRun(), toolEnv)
+	toolOut.Flush()
+	if err != nil && toolCtx.Err() == nil {
+		return &cmderrors.FailedUploadError{Message: tr("Failed measuring board power"), Cause: err}
+	}
+	if !received && !req.GetDryRun() && ctx.Err() == nil {
+		return &cmderrors.FailedUploadError{Message: tr("Failed measuring board power"), Cause: fmt.Errorf(tr("the tool didn't report any sample"))}
+	}
+	return nil
+}
+
+// powerSampleWriter parses the output of the power measurement tool: each
+// line in the format `<time>,<current>[,<voltage>]` is a sample, the other
+// lines are forwarded to out if verbose is set.
+type powerSampleWriter struct {
+	verbose  bool
+	out      io.Writer
+	sampleCB func(*rpc.PowerSample)
+	buf      bytes.Buffer
+	mux      sync.Mutex
+}
+
+func (w *powerSampleWriter) Write(data []byte) (int, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.buf.Write(data)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// Keep the incomplete line for the next write
+			w.buf.WriteString(line)
+			break
+		}
+		w.processLine(line)
+	}
+	return len(data), nil
+}
+
+// Flush processes the last line, if not terminated by a newline.
+func (w *powerSampleWriter) Flush() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.buf.Len() > 0 {
+		w.processLine(w.buf.String())
+		w.buf.Reset()
+	}
+}
+
+func (w *powerSampleWriter) processLine(line string) {
+	if sample, ok := parsePowerSample(line); ok {
+		w.sampleCB(sample)
+	} else if w.verbose {
+		w.out.Write([]byte(line))
+	}
+}
+
+// parsePowerSample parses a sample printed by the power measurement tool:
+// the time in seconds, the current in amperes and, optionally, the voltage
+// in volts, separated by commas.
+func parsePowerSample(line string) (*rpc.PowerSample, bool) {
+	fields := strings.Split(strings.TrimSpace(line), ",")
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, false
+	}
+	values := make([]float64, 3)
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, false
+		}
+		values[i] = v
+	}
+	return &rpc.PowerSample{
+		Time:    values[0],
+		Current: values[1],
+		Voltage: values[2],
+		Power:   values[1] * values[2],
+	}, true
+}
@@ -0,0 +1,72 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPowerTool(t *testing.T) {
+	pme := loadTestHardware(t)
+
+	props, err := loadBoardActionTool(pme, "alice:avr:board1", "", "power", "default", "program", "upload")
+	require.NoError(t, err)
+	require.Contains(t, props.Get("power.pattern"), "POWER")
+}
+
+func TestParsePowerSample(t *testing.T) {
+	sample, ok := parsePowerSample("0.5, 0.012, 3.3\n")
+	require.True(t, ok)
+	require.Equal(t, 0.5, sample.GetTime())
+	require.Equal(t, 0.012, sample.GetCurrent())
+	require.Equal(t, 3.3, sample.GetVoltage())
+	require.InDelta(t, 0.0396, sample.GetPower(), 1e-9)
+
+	// The voltage is optional
+	sample, ok = parsePowerSample("1.5,0.02")
+	require.True(t, ok)
+	require.Equal(t, 0.02, sample.GetCurrent())
+	require.Zero(t, sample.GetVoltage())
+	require.Zero(t, sample.GetPower())
+
+	for _, line := range []string{"", "Connecting to J-Link...", "1", "1,2,3,4", "1,2mA"} {
+		_, ok := parsePowerSample(line)
+		require.False(t, ok, line)
+	}
+}
+
+func TestPowerSampleWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	samples := []*rpc.PowerSample{}
+	w := &powerSampleWriter{
+		verbose:  true,
+		out:      out,
+		sampleCB: func(s *rpc.PowerSample) { samples = append(samples, s) },
+	}
+	w.Write([]byte("Probe connected\n0.0,0.01"))
+	w.Write([]byte("0,3.3\n0.1,0.02,3.3\n0.2,"))
+	require.Len(t, samples, 2)
+	w.Write([]byte("0.03,3.3"))
+	w.Flush()
+	require.Len(t, samples, 3)
+	require.Equal(t, 0.010, samples[0].GetCurrent())
+	require.Equal(t, 0.03, samples[2].GetCurrent())
+	require.Equal(t, "Probe connected\n", out.String())
+}
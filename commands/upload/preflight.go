@@ -0,0 +1,146 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// runPreflightCheck runs the `<action>.preflight.pattern` recipe of the upload
+// tool to verify that the target is connected and is the expected one before
+// writing. If the tool doesn't support the preflight check a warning is printed
+// and the check is skipped.
+func runPreflightCheck(action string, props *properties.Map, outStream, errStream io.Writer, verbose, dryRun bool, toolEnv []string) error {
+	recipeID := action + ".preflight.pattern"
+	if !props.ContainsKey(recipeID) {
+		fmt.Fprintln(errStream, tr("The upload tool doesn't support the preflight check, skipping it."))
+		return nil
+	}
+
+	// The output of the recipe is parsed to read the target signature and
+	// voltage, it's shown to the user only in verbose mode
+	toolOutput := &bytes.Buffer{}
+	toolOutStream, toolErrStream := io.Writer(toolOutput), io.Writer(toolOutput)
+	if verbose {
+		toolOutStream = io.MultiWriter(toolOutput, outStream)
+		toolErrStream = io.MultiWriter(toolOutput, errStream)
+	}
+	if err := runTool(recipeID, props, toolOutStream, toolErrStream, verbose, dryRun, toolEnv); err != nil {
+		return &cmderrors.FailedUploadError{
+			Message: tr("Preflight check failed: the target doesn't respond, check the connections and that the board is powered"),
+			Cause:   err,
+		}
+	}
+	if dryRun {
+		return nil
+	}
+	if err := checkPreflightOutput(action, props, toolOutput.String()); err != nil {
+		return &cmderrors.FailedUploadError{Message: tr("Preflight check failed"), Cause: err}
+	}
+	if verbose {
+		fmt.Fprintln(outStream, tr("Preflight check passed."))
+	}
+	return nil
+}
+
+// checkPreflightOutput verifies the output of the preflight recipe:
+//   - the target signature, captured by `<action>.preflight.signature.regex`,
+//     must match the `preflight.signature` property of the board
+//   - the target voltage, captured by `<action>.preflight.voltage.regex`,
+//     must be in the range given by the `preflight.voltage.min` and
+//     `preflight.voltage.max` properties of the board
+//
+// Each check is performed only if the needed properties are defined.
+func checkPreflightOutput(action string, props *properties.Map, output string) error {
+	capture := func(regexProperty string) (string, bool, error) {
+		regex, ok := props.GetOk(regexProperty)
+		if !ok {
+			return "", false, nil
+		}
+		re, err := regexp.Compile(regex)
+		if err != nil || re.NumSubexp() < 1 {
+			return "", false, &cmderrors.InvalidPlatformPropertyError{Property: regexProperty, Value: regex}
+		}
+		match := re.FindStringSubmatch(output)
+		if match == nil {
+			return "", false, nil
+		}
+		return strings.TrimSpace(match[1]), true, nil
+	}
+
+	if expected, ok := props.GetOk("preflight.signature"); ok {
+		signature, found, err := capture(action + ".preflight.signature.regex")
+		if err != nil {
+			return err
+		}
+		if !found && props.ContainsKey(action+".preflight.signature.regex") {
+			return errors.New(tr("the signature of the target can't be read, check the connections"))
+		}
+		if found && !strings.EqualFold(signature, expected) {
+			return errors.New(tr("the target signature %[1]s doesn't match the expected %[2]s, check that the selected board is the connected one", signature, expected))
+		}
+	}
+
+	minVoltage, hasMin := props.GetOk("preflight.voltage.min")
+	maxVoltage, hasMax := props.GetOk("preflight.voltage.max")
+	if !hasMin && !hasMax {
+		return nil
+	}
+	voltage, found, err := capture(action + ".preflight.voltage.regex")
+	if err != nil || !found {
+		// Not all the tools can measure the voltage
+		return err
+	}
+	value, err := strconv.ParseFloat(voltage, 64)
+	if err != nil {
+		return errors.New(tr("invalid target voltage: %s", voltage))
+	}
+	parseLimit := func(property, limit string) (float64, error) {
+		v, err := strconv.ParseFloat(limit, 64)
+		if err != nil {
+			return 0, &cmderrors.InvalidPlatformPropertyError{Property: property, Value: limit}
+		}
+		return v, nil
+	}
+	if hasMin {
+		limit, err := parseLimit("preflight.voltage.min", minVoltage)
+		if err != nil {
+			return err
+		}
+		if value < limit {
+			return errors.New(tr("the target voltage %[1]sV is lower than the minimum %[2]sV, check the power supply of the board", voltage, minVoltage))
+		}
+	}
+	if hasMax {
+		limit, err := parseLimit("preflight.voltage.max", maxVoltage)
+		if err != nil {
+			return err
+		}
+		if value > limit {
+			return errors.New(tr("the target voltage %[1]sV is higher than the maximum %[2]sV, check that the board is powered with the right voltage", voltage, maxVoltage))
+		}
+	}
+	return nil
+}
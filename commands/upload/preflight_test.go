@@ -0,0 +1,73 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightCheck(t *testing.T) {
+	props := properties.NewFromHashmap(map[string]string{
+		"program.preflight.pattern":         "echo PREFLIGHT {preflight.output}",
+		"program.preflight.signature.regex": `Device signature = (0x[0-9a-fA-F]+)`,
+		"program.preflight.voltage.regex":   `Vtarget\s*:\s*([0-9.]+)\s*V`,
+		"preflight.signature":               "0x1e950f",
+		"preflight.voltage.min":             "4.5",
+		"preflight.voltage.max":             "5.5",
+	})
+	run := func(output string) (string, string, error) {
+		props.Set("preflight.output", output)
+		outStream, errStream := &bytes.Buffer{}, &bytes.Buffer{}
+		err := runPreflightCheck("program", props, outStream, errStream, false, false, nil)
+		return outStream.String(), errStream.String(), err
+	}
+
+	out, _, err := run(`"Device signature = 0x1E950F Vtarget : 5.0 V"`)
+	require.NoError(t, err)
+	require.Empty(t, out, "the output of the tool is shown only in verbose mode")
+
+	_, _, err = run(`"Device signature = 0x1e9587 Vtarget : 5.0 V"`)
+	require.ErrorContains(t, err, "the target signature 0x1e9587 doesn't match the expected 0x1e950f")
+
+	_, _, err = run(`"Device signature = 0x1e950f Vtarget : 3.3 V"`)
+	require.ErrorContains(t, err, "the target voltage 3.3V is lower than the minimum 4.5V")
+
+	_, _, err = run(`"Device signature = 0x1e950f Vtarget : 12 V"`)
+	require.ErrorContains(t, err, "the target voltage 12V is higher than the maximum 5.5V")
+
+	_, _, err = run(`"No answer"`)
+	require.ErrorContains(t, err, "the signature of the target can't be read")
+
+	// The voltage is not measured by all the tools
+	_, _, err = run(`"Device signature = 0x1e950f"`)
+	require.NoError(t, err)
+
+	props.Set("program.preflight.voltage.regex", "Vtarget")
+	_, _, err = run(`"Device signature = 0x1e950f Vtarget : 5.0 V"`)
+	var invalidProperty *cmderrors.InvalidPlatformPropertyError
+	require.ErrorAs(t, err, &invalidProperty)
+
+	// The check is skipped if the tool doesn't support it
+	props.Remove("program.preflight.pattern")
+	_, errOut, err := run("")
+	require.NoError(t, err)
+	require.Contains(t, errOut, "doesn't support the preflight check")
+}
@@ -0,0 +1,182 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+var fusesPropertyRef = regexp.MustCompile(`\{([^{}]*fuse[^{}]*)\}`)
+
+// ProgrammerDetails returns the operations that the programmer supports with
+// the given board, as defined by the tools selected in the platform.
+func ProgrammerDetails(ctx context.Context, req *rpc.ProgrammerDetailsRequest) (*rpc.ProgrammerDetailsResponse, error) {
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if req.GetFqbn() == "" {
+		return nil, &cmderrors.MissingFQBNError{}
+	}
+	if req.GetProgrammer() == "" {
+		return nil, &cmderrors.MissingProgrammerError{}
+	}
+	fqbn, err := cores.ParseFQBN(req.GetFqbn())
+	if err != nil {
+		return nil, &cmderrors.InvalidFQBNError{Cause: err}
+	}
+	return programmerDetails(pme, fqbn, req.GetProgrammer())
+}
+
+func programmerDetails(pme *packagemanager.Explorer, fqbn *cores.FQBN, programmerID string) (*rpc.ProgrammerDetailsResponse, error) {
+	_, boardPlatform, _, boardProperties, buildPlatform, err := pme.ResolveFQBN(fqbn)
+	if boardPlatform == nil {
+		return nil, &cmderrors.PlatformNotFoundError{
+			Platform: fmt.Sprintf("%s:%s", fqbn.Package, fqbn.PlatformArch),
+			Cause:    err,
+		}
+	} else if err != nil {
+		return nil, &cmderrors.UnknownFQBNError{Cause: err}
+	}
+	programmer, err := findProgrammer(boardPlatform, buildPlatform, programmerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// loadTool returns the properties of the tool that performs the action,
+	// or nil if the action is not supported
+	loadTool := func(action string) (*properties.Map, error) {
+		props, err := loadUploadToolProperties(pme, boardPlatform, boardProperties, programmer, action, "default")
+		var missingTool *cmderrors.MissingPlatformPropertyError
+		if errors.As(err, &missingTool) {
+			return nil, nil
+		}
+		return props, err
+	}
+	hasRecipe := func(props *properties.Map, recipeID string) bool {
+		return props != nil && strings.TrimSpace(props.Get(recipeID)) != ""
+	}
+
+	res := &rpc.ProgrammerDetailsResponse{
+		Programmer: &rpc.Programmer{
+			Platform: programmer.PlatformRelease.String(),
+			Id:       programmerID,
+			Name:     programmer.Name,
+		},
+		Communication: programmer.Properties.Get("communication"),
+		Wiring:        programmer.Properties.Get("wiring"),
+		Operations:    &rpc.ProgrammerOperations{},
+	}
+
+	programProps, err := loadTool("program")
+	if err != nil {
+		return nil, err
+	}
+	if programProps != nil {
+		res.Tool, _ = getToolID(programProps, "program", "default")
+		res.Protocol = programProps.Get("program.protocol")
+		if res.Protocol == "" {
+			res.Protocol = programProps.Get("protocol")
+		}
+		res.Operations.Upload = hasRecipe(programProps, "program.pattern")
+		res.RequiresPort = res.Operations.Upload && recipeRequiresPort(programProps, "program.pattern")
+	}
+
+	bootloaderProps, err := loadTool("bootloader")
+	if err != nil {
+		return nil, err
+	}
+	res.Operations.BurnBootloader = hasRecipe(bootloaderProps, "bootloader.pattern")
+	if res.Operations.BurnBootloader {
+		res.Operations.Fuses = recipeWritesFuses(bootloaderProps, "erase.pattern") || recipeWritesFuses(bootloaderProps, "bootloader.pattern")
+	}
+
+	memoryProps, err := loadTool("memory")
+	if err != nil {
+		return nil, err
+	}
+	if memoryProps == nil {
+		// The memory action falls back to the program tool
+		memoryProps = programProps
+	}
+	res.Operations.MemoryRead = hasRecipe(memoryProps, "memory.read.pattern")
+	res.Operations.MemoryWrite = hasRecipe(memoryProps, "memory.write.pattern")
+
+	eraseProps, err := loadTool("erase")
+	if err != nil {
+		return nil, err
+	}
+	if eraseProps == nil {
+		// The erase action falls back to the bootloader tool
+		eraseProps = bootloaderProps
+	}
+	res.Operations.Erase = hasRecipe(eraseProps, "erase.pattern")
+
+	protectionProps, err := loadTool("protection")
+	if err != nil {
+		return nil, err
+	}
+	if protectionProps == nil {
+		// The protection action falls back to the program tool
+		protectionProps = programProps
+	}
+	res.Operations.FlashProtection = hasRecipe(protectionProps, "protection.status.pattern")
+
+	powerProps, err := loadTool("power")
+	if err != nil {
+		return nil, err
+	}
+	if powerProps == nil {
+		// The power action falls back to the program tool
+		powerProps = programProps
+	}
+	res.Operations.PowerMeasurement = hasRecipe(powerProps, "power.pattern")
+	return res, nil
+}
+
+// recipeRequiresPort returns true if the recipe uses the port selected by the user
+func recipeRequiresPort(props *properties.Map, recipeID string) bool {
+	recipe := props.Get(recipeID)
+	for _, portProperty := range []string{"serial.port", "serial.port.file", "upload.port.address"} {
+		if props.IsPropertyMissingInExpandPropsInString(portProperty, recipe) {
+			return true
+		}
+	}
+	return false
+}
+
+// recipeWritesFuses returns true if the recipe uses the fuses defined by the board
+func recipeWritesFuses(props *properties.Map, recipeID string) bool {
+	for _, match := range fusesPropertyRef.FindAllStringSubmatch(props.Get(recipeID), -1) {
+		if props.Get(match[1]) != "" {
+			return true
+		}
+	}
+	return false
+}
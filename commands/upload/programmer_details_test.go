@@ -0,0 +1,62 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgrammerDetails(t *testing.T) {
+	pme := loadTestHardware(t)
+
+	res, err := programmerDetails(pme, cores.MustParseFQBN("alice:avr:board1"), "progr1")
+	require.NoError(t, err)
+	require.Equal(t, "Programmer 1", res.GetProgrammer().GetName())
+	require.Equal(t, "one", res.GetTool())
+	require.Equal(t, "progprotocol", res.GetProtocol())
+	require.True(t, res.GetRequiresPort())
+	require.Equal(t, &rpc.ProgrammerOperations{
+		Upload:           true,
+		Erase:            true,
+		BurnBootloader:   true,
+		MemoryRead:       true,
+		MemoryWrite:      true,
+		Fuses:            true,
+		FlashProtection:  true,
+		PowerMeasurement: true,
+	}, res.GetOperations())
+
+	// The tool doesn't use the port nor supports the memory actions, the
+	// fuses used by the recipe are not defined by the board
+	res, err = programmerDetails(pme, cores.MustParseFQBN("alice:avr:board2"), "progr2")
+	require.NoError(t, err)
+	require.Equal(t, "one-noport", res.GetTool())
+	require.False(t, res.GetRequiresPort())
+	require.Equal(t, &rpc.ProgrammerOperations{
+		Upload:         true,
+		Erase:          true,
+		BurnBootloader: true,
+	}, res.GetOperations())
+
+	_, err = programmerDetails(pme, cores.MustParseFQBN("alice:avr:board1"), "missing")
+	var notFound *cmderrors.ProgrammerNotFoundError
+	require.ErrorAs(t, err, &notFound)
+}
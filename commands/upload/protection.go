@@ -0,0 +1,159 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// FlashProtection queries, enables or disables the flash readout protection of
+// the board using the `protection.*` recipes of the tool selected with
+// `protection.tool.<protocol>`. If the property is missing the tool used for
+// upload (or for programming, if a programmer is used) is selected instead.
+func FlashProtection(ctx context.Context, req *rpc.FlashProtectionRequest, outStream io.Writer, errStream io.Writer) (*rpc.FlashProtectionResult, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("programmer", req.GetProgrammer()).
+		WithField("action", req.GetAction()).
+		Trace("FlashProtection started")
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if req.GetFqbn() == "" {
+		return nil, &cmderrors.MissingFQBNError{}
+	}
+	port := rpc.DiscoveryPortFromRPCPort(req.GetPort())
+	if port == nil || (port.Address == "" && port.Protocol == "") {
+		port = &discovery.Port{Protocol: "default"}
+	}
+
+	props, err := loadBoardActionTool(pme, req.GetFqbn(), req.GetProgrammer(), "protection", port.Protocol, "program", "upload")
+	if err != nil {
+		return nil, err
+	}
+	res := &rpc.FlashProtectionResult{Permanent: props.GetBoolean("protection.permanent")}
+
+	var recipeID string
+	switch req.GetAction() {
+	case rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_STATUS:
+		recipeID = "protection.status.pattern"
+	case rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_ENABLE:
+		recipeID = "protection.enable.pattern"
+		res.Enabled = true
+	case rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_DISABLE:
+		recipeID = "protection.disable.pattern"
+	default:
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid flash protection action: %s", req.GetAction())}
+	}
+	if !props.ContainsKey(recipeID) {
+		return nil, &cmderrors.MissingPlatformPropertyError{Property: recipeID}
+	}
+	if recipeID == "protection.status.pattern" && !props.ContainsKey("protection.status.regex") {
+		return nil, &cmderrors.MissingPlatformPropertyError{Property: "protection.status.regex"}
+	}
+	if err := checkFlashProtectionForced(req, res.GetPermanent()); err != nil {
+		return nil, err
+	}
+
+	setActionVerbosity(props, "protection", req.GetVerbose())
+	setPortProperties(props, port)
+
+	if !req.GetDryRun() && port.Address != "" {
+		releasePort := portlock.Acquire(port.Protocol, port.Address)
+		defer releasePort()
+	}
+
+	toolEnv := pme.GetEnvVarsForSpawnedProcess()
+	if recipeID != "protection.status.pattern" {
+		if err := runTool(recipeID, props, outStream, errStream, req.GetVerbose(), req.GetDryRun(), toolEnv); err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed to change the flash protection"), Cause: err}
+		}
+		return res, nil
+	}
+
+	// The output of the status recipe is parsed to detect the protection,
+	// it's shown to the user only in verbose mode
+	toolOutput := &bytes.Buffer{}
+	toolOutStream, toolErrStream := io.Writer(toolOutput), io.Writer(toolOutput)
+	if req.GetVerbose() {
+		toolOutStream = io.MultiWriter(toolOutput, outStream)
+		toolErrStream = io.MultiWriter(toolOutput, errStream)
+	}
+	if err := runTool(recipeID, props, toolOutStream, toolErrStream, req.GetVerbose(), req.GetDryRun(), toolEnv); err != nil {
+		return nil, &cmderrors.FailedUploadError{Message: tr("Failed to read the flash protection"), Cause: err}
+	}
+	res.Enabled, res.Level, err = parseFlashProtectionStatus(props, toolOutput.String())
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// checkFlashProtectionForced returns an error if the requested change of the
+// flash protection can't be undone and it hasn't been forced.
+func checkFlashProtectionForced(req *rpc.FlashProtectionRequest, permanent bool) error {
+	if req.GetForce() || req.GetDryRun() {
+		return nil
+	}
+	switch req.GetAction() {
+	case rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_ENABLE:
+		if permanent {
+			return &cmderrors.IrreversibleOperationError{Message: tr("On this board the flash protection can't be disabled anymore once enabled, the board won't be programmable again")}
+		}
+		return &cmderrors.IrreversibleOperationError{Message: tr("Once the flash protection is enabled the firmware can't be read back and disabling the protection erases the whole memory")}
+	case rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_DISABLE:
+		return &cmderrors.IrreversibleOperationError{Message: tr("Disabling the flash protection erases the sketch, the bootloader and all the data stored in the board")}
+	}
+	return nil
+}
+
+// parseFlashProtectionStatus matches the `protection.status.regex` regular
+// expression against the output of the status recipe: if it matches the
+// protection is enabled and the first capture group, if any, is the
+// protection level.
+func parseFlashProtectionStatus(props *properties.Map, output string) (bool, string, error) {
+	statusRegex, err := regexp.Compile(props.Get("protection.status.regex"))
+	if err != nil {
+		return false, "", &cmderrors.InvalidPlatformPropertyError{
+			Property: "protection.status.regex",
+			Value:    props.Get("protection.status.regex"),
+		}
+	}
+	match := statusRegex.FindStringSubmatch(output)
+	if match == nil {
+		return false, "", nil
+	}
+	if len(match) > 1 {
+		return true, match[1], nil
+	}
+	return true, "", nil
+}
@@ -0,0 +1,75 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEraseAndProtectionTools(t *testing.T) {
+	pme := loadTestHardware(t)
+
+	// With a programmer the erase falls back to the bootloader tool
+	props, err := loadBoardActionTool(pme, "alice:avr:board2", "progr2", "erase", "default", "bootloader", "upload")
+	require.NoError(t, err)
+	require.Contains(t, props.Get("erase.pattern"), "ERASE")
+
+	// Without a programmer the upload tool is used, that doesn't support erase
+	props, err = loadBoardActionTool(pme, "alice:avr:board2", "", "erase", "default", "bootloader", "upload")
+	require.NoError(t, err)
+	require.False(t, props.ContainsKey("erase.pattern"))
+
+	props, err = loadBoardActionTool(pme, "alice:avr:board1", "", "protection", "default", "program", "upload")
+	require.NoError(t, err)
+	require.Contains(t, props.Get("protection.status.pattern"), "RDP")
+
+	enabled, level, err := parseFlashProtectionStatus(props, "Option bytes:\nRDP level 1\n")
+	require.NoError(t, err)
+	require.True(t, enabled)
+	require.Equal(t, "1", level)
+	enabled, level, err = parseFlashProtectionStatus(props, "Option bytes:\nRDP level 0\n")
+	require.NoError(t, err)
+	require.False(t, enabled)
+	require.Empty(t, level)
+
+	invalid := properties.NewFromHashmap(map[string]string{"protection.status.regex": "RDP ("})
+	_, _, err = parseFlashProtectionStatus(invalid, "")
+	var invalidProperty *cmderrors.InvalidPlatformPropertyError
+	require.ErrorAs(t, err, &invalidProperty)
+}
+
+func TestCheckFlashProtectionForced(t *testing.T) {
+	var irreversible *cmderrors.IrreversibleOperationError
+	status := &rpc.FlashProtectionRequest{Action: rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_STATUS}
+	require.NoError(t, checkFlashProtectionForced(status, true))
+
+	enable := &rpc.FlashProtectionRequest{Action: rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_ENABLE}
+	require.ErrorAs(t, checkFlashProtectionForced(enable, false), &irreversible)
+	require.ErrorAs(t, checkFlashProtectionForced(enable, true), &irreversible)
+	require.Contains(t, irreversible.Message, "won't be programmable again")
+	enable.Force = true
+	require.NoError(t, checkFlashProtectionForced(enable, true))
+
+	disable := &rpc.FlashProtectionRequest{Action: rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_DISABLE}
+	require.ErrorAs(t, checkFlashProtectionForced(disable, false), &irreversible)
+	disable.DryRun = true
+	require.NoError(t, checkFlashProtectionForced(disable, false))
+}
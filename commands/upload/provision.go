@@ -0,0 +1,305 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bufio"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"go.bug.st/serial"
+)
+
+// provisionProtocolVersion is the version of the provisioning protocol
+// spoken with the provisioning sketch.
+const provisionProtocolVersion = "1"
+
+// BoardProvision drives the provisioning of the secure element of a board.
+// The provisioning sketch declared by the platform with the `provision.loader`
+// property is uploaded to the board, then a private key and a certificate
+// signing request are generated or, if a certificate is given, the
+// certificate signed by the CA is written back to the secure element.
+func BoardProvision(ctx context.Context, req *rpc.BoardProvisionRequest, outStream io.Writer, errStream io.Writer) (*rpc.BoardProvisionResult, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("slot", req.GetSlot()).
+		Trace("BoardProvision started")
+
+	if req.GetCertificate() != "" {
+		block, _ := pem.Decode([]byte(req.GetCertificate()))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid certificate: PEM certificate expected")}
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid certificate"), Cause: err}
+		}
+	} else if req.GetCommonName() == "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Missing common name for the certificate signing request")}
+	}
+	if strings.ContainsAny(req.GetCommonName(), " \r\n") {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("The common name must not contain spaces")}
+	}
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	fqbn, err := cores.ParseFQBN(req.GetFqbn())
+	if err != nil {
+		return nil, &cmderrors.InvalidFQBNError{Cause: err}
+	}
+	_, boardPlatform, _, boardProperties, _, err := pme.ResolveFQBN(fqbn)
+	if boardPlatform == nil {
+		return nil, &cmderrors.PlatformNotFoundError{
+			Platform: fmt.Sprintf("%s:%s", fqbn.Package, fqbn.PlatformArch),
+			Cause:    err,
+		}
+	} else if err != nil {
+		return nil, &cmderrors.UnknownFQBNError{Cause: err}
+	}
+
+	port, err := uploadLoaderSketch(pme, boardPlatform, boardProperties, "provision.loader",
+		req.GetFqbn(), req.GetPort(), req.GetVerbose(), req.GetDryRun(), outStream, errStream)
+	if err != nil {
+		return nil, err
+	}
+	address := port.GetAddress()
+	if address == "" {
+		return nil, &cmderrors.MissingPortAddressError{}
+	}
+	speed := 115200
+	if s := boardProperties.Get("provision.serial.speed"); s != "" {
+		if speed, err = strconv.Atoi(s); err != nil {
+			return nil, &cmderrors.InvalidPlatformPropertyError{Property: "provision.serial.speed", Value: s}
+		}
+	}
+
+	var conn io.ReadWriter
+	if req.GetDryRun() {
+		conn = &dryRunProvisionConn{out: outStream}
+	} else {
+		releasePort := portlock.Acquire(port.GetProtocol(), address)
+		defer releasePort()
+		serialPort, err := openProvisionPort(ctx, address, speed)
+		if err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Error opening port %s", address), Cause: err}
+		}
+		defer serialPort.Close()
+		conn = &timeoutReader{serialPort}
+	}
+
+	p := newProvisioner(conn, outStream, req.GetVerbose())
+	if err := p.handshake(); err != nil {
+		return nil, &cmderrors.FailedUploadError{Message: tr("The provisioning sketch is not responding"), Cause: err}
+	}
+
+	res := &rpc.BoardProvisionResult{}
+	slot := fmt.Sprint(req.GetSlot())
+	if cert := req.GetCertificate(); cert != "" {
+		if _, err := p.command("CERT "+slot, strings.Split(strings.TrimSpace(cert), "\n")...); err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed writing the certificate"), Cause: err}
+		}
+		res.CertificateWritten = true
+	} else {
+		if !req.GetReuseKey() {
+			if _, err := p.command("GENKEY " + slot); err != nil {
+				return nil, &cmderrors.FailedUploadError{Message: tr("Failed generating the private key"), Cause: err}
+			}
+		}
+		lines, err := p.command("CSR " + slot + " " + req.GetCommonName())
+		if err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed generating the certificate signing request"), Cause: err}
+		}
+		res.Csr = extractPEM(lines, "CERTIFICATE REQUEST")
+		if res.Csr == "" && !req.GetDryRun() {
+			return nil, &cmderrors.FailedUploadError{Message: tr("The board did not send a valid certificate signing request")}
+		}
+	}
+	if req.GetLock() {
+		if _, err := p.command("LOCK"); err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed locking the secure element"), Cause: err}
+		}
+		res.Locked = true
+	}
+	return res, nil
+}
+
+// openProvisionPort opens the serial port of the board, retrying for a while
+// since the port may disappear for some time after the loader upload.
+func openProvisionPort(ctx context.Context, address string, speed int) (serial.Port, error) {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		serialPort, err := serial.Open(address, &serial.Mode{BaudRate: speed})
+		if err == nil {
+			if err := serialPort.SetReadTimeout(30 * time.Second); err != nil {
+				serialPort.Close()
+				return nil, err
+			}
+			return serialPort, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// provisioner speaks the line based provisioning protocol: each command is
+// a single line, optionally followed by payload lines and an empty line. The
+// board answers with any number of lines terminated by `OK` or `ERROR <msg>`.
+type provisioner struct {
+	r       *bufio.Reader
+	w       io.Writer
+	log     io.Writer
+	verbose bool
+}
+
+func newProvisioner(conn io.ReadWriter, log io.Writer, verbose bool) *provisioner {
+	return &provisioner{r: bufio.NewReader(conn), w: conn, log: log, verbose: verbose}
+}
+
+// handshake checks that the provisioning sketch is running and speaks a
+// supported version of the protocol. Any output printed by the sketch before
+// the answer is ignored.
+func (p *provisioner) handshake() error {
+	lines, err := p.command("VERSION")
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if version, ok := strings.CutPrefix(line, "ARDUINO-PROVISION "); ok {
+			if version != provisionProtocolVersion {
+				return errors.New(tr("unsupported provisioning protocol version %s", version))
+			}
+			return nil
+		}
+	}
+	return errors.New(tr("invalid answer from the provisioning sketch"))
+}
+
+// command sends a command with the given payload and returns the lines sent
+// by the board before the final `OK`.
+func (p *provisioner) command(cmd string, payload ...string) ([]string, error) {
+	if p.verbose {
+		fmt.Fprintln(p.log, "> "+cmd)
+	}
+	msg := cmd + "\n"
+	if len(payload) > 0 {
+		msg += strings.Join(payload, "\n") + "\n\n"
+	}
+	if _, err := io.WriteString(p.w, msg); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for {
+		line, err := p.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if p.verbose {
+			fmt.Fprintln(p.log, "< "+line)
+		}
+		if line == "OK" {
+			return lines, nil
+		}
+		if msg, ok := strings.CutPrefix(line, "ERROR"); ok {
+			return nil, errors.New(strings.TrimSpace(msg))
+		}
+		lines = append(lines, line)
+	}
+}
+
+// extractPEM returns the PEM block of the given type contained in lines
+func extractPEM(lines []string, blockType string) string {
+	begin, end := "-----BEGIN "+blockType+"-----", "-----END "+blockType+"-----"
+	var res []string
+	for _, line := range lines {
+		if line == begin {
+			res = []string{}
+		}
+		if res != nil {
+			res = append(res, line)
+		}
+		if line == end && res != nil {
+			return strings.Join(res, "\n") + "\n"
+		}
+	}
+	return ""
+}
+
+// errProvisionTimeout is returned when the board does not answer in time
+var errProvisionTimeout = errors.New(tr("timeout waiting for the board"))
+
+// timeoutReader turns the empty reads of a serial port, returned when the
+// read timeout expires, into an error.
+type timeoutReader struct {
+	serial.Port
+}
+
+func (r *timeoutReader) Read(p []byte) (int, error) {
+	n, err := r.Port.Read(p)
+	if n == 0 && err == nil {
+		return 0, errProvisionTimeout
+	}
+	return n, err
+}
+
+// dryRunProvisionConn prints the commands sent to the board and answers `OK`
+// to each of them, it is used for debugging purposes.
+type dryRunProvisionConn struct {
+	out     io.Writer
+	pending []byte
+}
+
+func (c *dryRunProvisionConn) Write(p []byte) (int, error) {
+	fmt.Fprint(c.out, string(p))
+	if strings.HasPrefix(string(p), "VERSION") {
+		c.pending = append(c.pending, "ARDUINO-PROVISION "+provisionProtocolVersion+"\n"...)
+	}
+	c.pending = append(c.pending, "OK\n"...)
+	return len(p), nil
+}
+
+func (c *dryRunProvisionConn) Read(p []byte) (int, error) {
+	if len(c.pending) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
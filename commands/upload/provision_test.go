@@ -0,0 +1,117 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvisioningBoard emulates the provisioning sketch on the given
+// connection, the certificates written are saved in the certs map.
+func fakeProvisioningBoard(conn net.Conn, certs map[string]string) {
+	// Answers are sent asynchronously, as done by a real serial port, to
+	// avoid deadlocks on the synchronous pipe.
+	answers := make(chan string, 16)
+	defer close(answers)
+	go func() {
+		for a := range answers {
+			conn.Write([]byte(a))
+		}
+		conn.Close()
+	}()
+	send := func(a string) { answers <- a }
+
+	r := bufio.NewReader(conn)
+	keys := map[string]bool{}
+	send("Provisioning sketch started\r\n")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		args := strings.Fields(line)
+		switch args[0] {
+		case "VERSION":
+			send("ARDUINO-PROVISION 1\r\nOK\r\n")
+		case "GENKEY":
+			keys[args[1]] = true
+			send("OK\r\n")
+		case "CSR":
+			if !keys[args[1]] {
+				send("ERROR no key in slot " + args[1] + "\r\n")
+				continue
+			}
+			send("-----BEGIN CERTIFICATE REQUEST-----\r\n" + args[2] + "\r\n-----END CERTIFICATE REQUEST-----\r\nOK\r\n")
+		case "CERT":
+			var payload []string
+			for {
+				l, _ := r.ReadString('\n')
+				if l = strings.TrimSpace(l); l == "" {
+					break
+				}
+				payload = append(payload, l)
+			}
+			certs[args[1]] = strings.Join(payload, "\n")
+			send("OK\r\n")
+		default:
+			send("ERROR unknown command\r\n")
+		}
+	}
+}
+
+func TestProvisioner(t *testing.T) {
+	host, board := net.Pipe()
+	defer host.Close()
+	certs := map[string]string{}
+	go fakeProvisioningBoard(board, certs)
+
+	log := &bytes.Buffer{}
+	p := newProvisioner(host, log, true)
+	require.NoError(t, p.handshake())
+
+	// CSR without a key
+	_, err := p.command("CSR 0 device-1")
+	require.EqualError(t, err, "no key in slot 0")
+
+	_, err = p.command("GENKEY 0")
+	require.NoError(t, err)
+	lines, err := p.command("CSR 0 device-1")
+	require.NoError(t, err)
+	require.Equal(t, "-----BEGIN CERTIFICATE REQUEST-----\ndevice-1\n-----END CERTIFICATE REQUEST-----\n",
+		extractPEM(lines, "CERTIFICATE REQUEST"))
+
+	_, err = p.command("CERT 0", "-----BEGIN CERTIFICATE-----", "AAAA", "-----END CERTIFICATE-----")
+	require.NoError(t, err)
+	require.Equal(t, "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----", certs["0"])
+
+	require.Contains(t, log.String(), "> GENKEY 0\n")
+	require.Contains(t, log.String(), "< Provisioning sketch started\n")
+}
+
+func TestProvisionerDryRun(t *testing.T) {
+	out := &bytes.Buffer{}
+	p := newProvisioner(&dryRunProvisionConn{out: out}, out, false)
+	require.NoError(t, p.handshake())
+	_, err := p.command("GENKEY 1")
+	require.NoError(t, err)
+	require.Equal(t, "VERSION\nGENKEY 1\n", out.String())
+}
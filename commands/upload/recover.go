@@ -0,0 +1,192 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+	serialutils "github.com/arduino/go-serial-utils"
+	"github.com/sirupsen/logrus"
+	"go.bug.st/serial"
+)
+
+// BoardRecover tries to recover a board that does not respond anymore. If a
+// programmer is given the bootloader is burned again, otherwise the board is
+// forced in bootloader mode using a 1200-bps touch or a DTR reset pulse.
+func BoardRecover(ctx context.Context, req *rpc.BoardRecoverRequest, outStream io.Writer, errStream io.Writer) (*rpc.BoardRecoverResult, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("programmer", req.GetProgrammer()).
+		Trace("BoardRecover started")
+
+	pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	fqbn, err := cores.ParseFQBN(req.GetFqbn())
+	if err != nil {
+		return nil, &cmderrors.InvalidFQBNError{Cause: err}
+	}
+	_, boardPlatform, board, boardProperties, _, err := pme.ResolveFQBN(fqbn)
+	if boardPlatform == nil {
+		return nil, &cmderrors.PlatformNotFoundError{
+			Platform: fmt.Sprintf("%s:%s", fqbn.Package, fqbn.PlatformArch),
+			Cause:    err,
+		}
+	} else if err != nil {
+		return nil, &cmderrors.UnknownFQBNError{Cause: err}
+	}
+
+	res := &rpc.BoardRecoverResult{}
+	addStep := func(description string, success bool, message string) {
+		res.Steps = append(res.Steps, &rpc.BoardRecoverStep{Description: description, Success: success, Message: message})
+		if req.GetVerbose() {
+			fmt.Fprintln(outStream, description+": "+message)
+		}
+	}
+
+	// With a programmer the bootloader can be burned again
+	if req.GetProgrammer() != "" {
+		step := tr("Burn bootloader using programmer %s", req.GetProgrammer())
+		if _, err := runProgramAction(
+			pme,
+			nil, // sketch
+			"",  // importFile
+			"",  // importDir
+			req.GetFqbn(),
+			req.GetPort(),
+			req.GetProgrammer(),
+			req.GetVerbose(),
+			true,  // verify
+			true,  // burnBootloader
+			false, // preflight
+			outStream,
+			errStream,
+			nil, // progressCB
+			req.GetDryRun(),
+			map[string]string{}, // User fields
+		); err != nil {
+			addStep(step, false, err.Error())
+			res.Suggestions = append(res.Suggestions,
+				tr("Check the connections between the programmer and the board, and that the board is powered."))
+			return res, nil
+		}
+		addStep(step, true, tr("Bootloader burned"))
+		res.Recovered = true
+		return res, nil
+	}
+
+	address := req.GetPort().GetAddress()
+	if address == "" {
+		return nil, &cmderrors.MissingPortAddressError{}
+	}
+
+	// Check if the board is connected and how it identifies itself
+	step := tr("Detect board on port %s", address)
+	if !req.GetDryRun() {
+		ports, err := serialutils.DefaultPortMapper()
+		if err != nil {
+			addStep(step, false, err.Error())
+		} else if !ports[address] {
+			addStep(step, false, tr("Port not found"))
+			res.Suggestions = append(res.Suggestions,
+				tr("Connect the board and check the cable, some cables are power-only."),
+				tr("Press the reset button twice quickly to start the bootloader, then run the command again with the new port."))
+			return res, nil
+		} else if portProps := req.GetPort().GetProperties(); len(portProps) > 0 &&
+			!board.IsBoardMatchingIDProperties(properties.NewFromHashmap(portProps)) {
+			addStep(step, true, tr("The port does not identify as %s, the board may be running only the bootloader", board.Name()))
+		} else {
+			addStep(step, true, tr("Port found"))
+		}
+	}
+
+	// Suspend the monitor sessions opened on the port during the recovery
+	if !req.GetDryRun() {
+		releasePort := portlock.Acquire(req.GetPort().GetProtocol(), address)
+		defer releasePort()
+	}
+
+	if boardProperties.GetBoolean("upload.use_1200bps_touch") {
+		step := tr("Force bootloader mode with a 1200-bps touch")
+		cb := &serialutils.ResetProgressCallbacks{
+			Debug: func(msg string) { logrus.WithField("phase", "board recover").Debug(msg) },
+		}
+		newPort, err := serialutils.Reset(address, true, req.GetDryRun(), nil, cb)
+		if err != nil {
+			addStep(step, false, err.Error())
+		} else if newPort != "" {
+			addStep(step, true, tr("Bootloader found on port %s", newPort))
+			res.Recovered = true
+			res.BootloaderPortAddress = newPort
+		} else {
+			addStep(step, false, tr("No bootloader port detected"))
+		}
+	} else {
+		step := tr("Reset the board with a DTR pulse")
+		if req.GetDryRun() {
+			addStep(step, true, tr("Skipped (dry run)"))
+		} else if err := pulseSerialLine(address, "dtr"); err != nil {
+			addStep(step, false, err.Error())
+		} else {
+			addStep(step, true, tr("The bootloader is running for a short time on port %s", address))
+			res.Recovered = true
+			res.BootloaderPortAddress = address
+		}
+	}
+
+	if res.GetRecovered() {
+		res.Suggestions = append(res.Suggestions,
+			tr("Upload a working sketch to the port %s now.", res.GetBootloaderPortAddress()))
+	} else {
+		res.Suggestions = append(res.Suggestions,
+			tr("Press the reset button twice quickly to start the bootloader, then upload a working sketch."),
+			tr("If the bootloader is damaged, use an external programmer with the --programmer flag to burn it again."))
+	}
+	return res, nil
+}
+
+// pulseSerialLine resets the board connected to the given serial port by
+// toggling the DTR or the RTS line, as done by the auto-reset circuit of many
+// boards.
+func pulseSerialLine(address, line string) error {
+	p, err := serial.Open(address, &serial.Mode{BaudRate: 9600})
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	set := p.SetDTR
+	if line == "rts" {
+		set = p.SetRTS
+	}
+	if err := set(false); err != nil {
+		return err
+	}
+	time.Sleep(250 * time.Millisecond)
+	return set(true)
+}
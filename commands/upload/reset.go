@@ -0,0 +1,128 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"context"
+	"io"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	serialutils "github.com/arduino/go-serial-utils"
+	"github.com/sirupsen/logrus"
+)
+
+// BoardReset resets a board. The reset method is selected from the board
+// platform, unless specified in the request: the `reset.pattern` recipe of the
+// upload tool if defined, a 1200-bps touch if the board uses it to start the
+// bootloader, otherwise a DTR pulse as done by the auto-reset circuit.
+func BoardReset(ctx context.Context, req *rpc.BoardResetRequest, outStream io.Writer, errStream io.Writer) (*rpc.BoardResetResult, error) {
+	logrus.
+		WithField("fqbn", req.GetFqbn()).
+		WithField("port", req.GetPort()).
+		WithField("method", req.GetMethod()).
+		Trace("BoardReset started")
+
+	port := rpc.DiscoveryPortFromRPCPort(req.GetPort())
+	if port == nil || port.Address == "" {
+		return nil, &cmderrors.MissingPortAddressError{}
+	}
+
+	method := req.GetMethod()
+	switch method {
+	case "", "tool", "1200bps", "dtr", "rts":
+	default:
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("Invalid reset method %s, must be one of: tool, 1200bps, dtr, rts", method)}
+	}
+
+	if req.GetFqbn() == "" {
+		if method == "" {
+			method = "dtr"
+		} else if method == "tool" {
+			return nil, &cmderrors.MissingFQBNError{}
+		}
+	}
+	res := &rpc.BoardResetResult{PortAddress: port.Address}
+	if method == "" || method == "tool" {
+		pme, release, err := instances.GetPackageManagerExplorer(req.GetInstance())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		props, err := loadBoardActionTool(pme, req.GetFqbn(), "", "reset", port.Protocol, "upload", "upload")
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case props.ContainsKey("reset.pattern"):
+			method = "tool"
+		case method == "tool":
+			return nil, &cmderrors.MissingPlatformPropertyError{Property: "reset.pattern"}
+		case props.GetBoolean("upload.use_1200bps_touch"):
+			method = "1200bps"
+		default:
+			method = "dtr"
+		}
+
+		if method == "tool" {
+			setActionVerbosity(props, "reset", req.GetVerbose())
+			setPortProperties(props, port)
+			res.Method = method
+			if !req.GetDryRun() {
+				releasePort := portlock.Acquire(port.Protocol, port.Address)
+				defer releasePort()
+			}
+			if err := runTool("reset.pattern", props, outStream, errStream, req.GetVerbose(), req.GetDryRun(), pme.GetEnvVarsForSpawnedProcess()); err != nil {
+				return nil, &cmderrors.FailedUploadError{Message: tr("Failed board reset"), Cause: err}
+			}
+			return res, nil
+		}
+	}
+
+	res.Method = method
+	if port.Protocol != "serial" && port.Protocol != "default" && port.Protocol != "" {
+		return nil, &cmderrors.InvalidArgumentError{Message: tr("The reset method %[1]s requires a serial port, the port %[2]s uses the %[3]s protocol", method, port.Address, port.Protocol)}
+	}
+	if req.GetDryRun() {
+		return res, nil
+	}
+
+	// Suspend the monitor sessions opened on the port during the reset
+	releasePort := portlock.Acquire(port.Protocol, port.Address)
+	defer releasePort()
+
+	switch method {
+	case "1200bps":
+		cb := &serialutils.ResetProgressCallbacks{
+			Debug: func(msg string) { logrus.WithField("phase", "board reset").Debug(msg) },
+		}
+		newPort, err := serialutils.Reset(port.Address, true, false, nil, cb)
+		if err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed board reset"), Cause: err}
+		}
+		if newPort != "" {
+			res.PortAddress = newPort
+		}
+	case "dtr", "rts":
+		if err := pulseSerialLine(port.Address, method); err != nil {
+			return nil, &cmderrors.FailedUploadError{Message: tr("Failed board reset"), Cause: err}
+		}
+	}
+	return res, nil
+}
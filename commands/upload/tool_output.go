@@ -0,0 +1,295 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// toolOutputRules describes how to parse the output of an upload tool
+type toolOutputRules struct {
+	// phases are the lines that start a new phase of the upload
+	phases []*phaseRule
+	// progress are the lines that report the progress of the current phase
+	progress []*progressRule
+	// errors are the lines that report the cause of a failure, the first
+	// matching line determines the kind of error
+	errors []*errorRule
+}
+
+type phaseRule struct {
+	re *regexp.Regexp
+	// phase is the name of the phase, if empty the `phase` group of the
+	// regexp is used
+	phase string
+}
+
+// progressRule matches a progress line. The progress is taken from the
+// `percent` group of the regexp or, for textual progress bars, from the length
+// of the `bar` group multiplied by barScale. If the regexp has none of them
+// the fixed percent is reported. The `phase` group, if present, sets the
+// current phase.
+type progressRule struct {
+	re       *regexp.Regexp
+	barScale float32
+	percent  float32
+}
+
+type errorRule struct {
+	re   *regexp.Regexp
+	kind rpc.UploadErrorKind
+}
+
+const (
+	kindWrongSignature     = rpc.UploadErrorKind_UPLOAD_ERROR_KIND_WRONG_SIGNATURE
+	kindSyncFailure        = rpc.UploadErrorKind_UPLOAD_ERROR_KIND_SYNC_FAILURE
+	kindAccessDenied       = rpc.UploadErrorKind_UPLOAD_ERROR_KIND_ACCESS_DENIED
+	kindPortNotFound       = rpc.UploadErrorKind_UPLOAD_ERROR_KIND_PORT_NOT_FOUND
+	kindDeviceNotFound     = rpc.UploadErrorKind_UPLOAD_ERROR_KIND_DEVICE_NOT_FOUND
+	kindVerificationFailed = rpc.UploadErrorKind_UPLOAD_ERROR_KIND_VERIFICATION_FAILED
+)
+
+// toolOutputParsers are the known upload tools output formats
+var toolOutputParsers = map[string]*toolOutputRules{
+	"avrdude": {
+		progress: []*progressRule{
+			{re: regexp.MustCompile(`^(?P<phase>Reading|Writing) \| (?P<bar>#*)`), barScale: 2},
+		},
+		errors: []*errorRule{
+			{re: regexp.MustCompile(`Expected signature for .* is|Invalid device signature`), kind: kindWrongSignature},
+			{re: regexp.MustCompile(`not in sync|programmer is not responding|getsync\(\)|initialization failed, rc=-1`), kind: kindSyncFailure},
+			{re: regexp.MustCompile(`(?i)permission denied|access is denied`), kind: kindAccessDenied},
+			{re: regexp.MustCompile(`ser_open\(\): can't open device`), kind: kindPortNotFound},
+			{re: regexp.MustCompile(`did not find any USB device|could not find USB device`), kind: kindDeviceNotFound},
+			{re: regexp.MustCompile(`verification error|verification mismatch`), kind: kindVerificationFailed},
+		},
+	},
+	"bossac": {
+		phases: []*phaseRule{
+			{re: regexp.MustCompile(`^(?P<phase>Erase|Write|Read|Verify)\b`)},
+		},
+		progress: []*progressRule{
+			{re: regexp.MustCompile(`\[[=\s]*\]\s*(?P<percent>\d+)%`)},
+		},
+		errors: []*errorRule{
+			{re: regexp.MustCompile(`Device unsupported`), kind: kindWrongSignature},
+			{re: regexp.MustCompile(`SAM-BA operation failed`), kind: kindSyncFailure},
+			{re: regexp.MustCompile(`(?i)permission denied|access is denied`), kind: kindAccessDenied},
+			{re: regexp.MustCompile(`No device found on`), kind: kindDeviceNotFound},
+			{re: regexp.MustCompile(`(?i)verify failed`), kind: kindVerificationFailed},
+		},
+	},
+	"esptool": {
+		phases: []*phaseRule{
+			{re: regexp.MustCompile(`^Erasing flash`), phase: "Erasing"},
+		},
+		progress: []*progressRule{
+			{re: regexp.MustCompile(`^(?P<phase>Writing) at 0x[0-9a-fA-F]+\.*\s*\((?P<percent>\d+)\s?%\)`)},
+		},
+		errors: []*errorRule{
+			{re: regexp.MustCompile(`Wrong --chip argument|Unexpected chip id|This chip is .* not`), kind: kindWrongSignature},
+			{re: regexp.MustCompile(`Failed to connect to`), kind: kindSyncFailure},
+			{re: regexp.MustCompile(`(?i)permission denied|PermissionError|access is denied`), kind: kindAccessDenied},
+			{re: regexp.MustCompile(`could not open port|FileNotFoundError`), kind: kindPortNotFound},
+			{re: regexp.MustCompile(`MD5 of file does not match`), kind: kindVerificationFailed},
+		},
+	},
+	"dfu-util": {
+		progress: []*progressRule{
+			{re: regexp.MustCompile(`^(?P<phase>Download|Upload|Erase)\s*\[[=\s]*\]\s*(?P<percent>\d+)%`)},
+		},
+		errors: []*errorRule{
+			{re: regexp.MustCompile(`LIBUSB_ERROR_ACCESS|Cannot open DFU device`), kind: kindAccessDenied},
+			{re: regexp.MustCompile(`No DFU capable USB device available`), kind: kindDeviceNotFound},
+			{re: regexp.MustCompile(`(?i)verify failed`), kind: kindVerificationFailed},
+		},
+	},
+	"openocd": {
+		progress: []*progressRule{
+			{re: regexp.MustCompile(`\*\* (?P<phase>Programming) Started \*\*`), percent: 0},
+			{re: regexp.MustCompile(`\*\* (?P<phase>Programming) Finished \*\*`), percent: 100},
+			{re: regexp.MustCompile(`\*\* (?P<phase>Verify) Started \*\*`), percent: 0},
+			{re: regexp.MustCompile(`\*\* (?P<phase>Verif)ied OK \*\*`), percent: 100},
+		},
+		errors: []*errorRule{
+			{re: regexp.MustCompile(`UNEXPECTED.*idcode|JTAG tap: .* UNEXPECTED`), kind: kindWrongSignature},
+			{re: regexp.MustCompile(`Error connecting DP|init mode failed|[Ee]xamination failed|Target not examined`), kind: kindSyncFailure},
+			{re: regexp.MustCompile(`LIBUSB_ERROR_ACCESS`), kind: kindAccessDenied},
+			{re: regexp.MustCompile(`unable to find a matching CMSIS-DAP device|No J-Link device found|open failed`), kind: kindDeviceNotFound},
+			{re: regexp.MustCompile(`\*\* Verify Failed \*\*|checksum mismatch`), kind: kindVerificationFailed},
+		},
+	},
+}
+
+// findToolOutputRules returns the rules to parse the output of the given tool.
+// The parser is selected with the `<action>.output_parser` property of the
+// tool, if missing the tool name is matched against the known parsers (e.g.
+// the parser "esptool" is used for the tool "esptool_py").
+func findToolOutputRules(props *properties.Map, action, toolID string) *toolOutputRules {
+	if parser, ok := props.GetOk(action + ".output_parser"); ok {
+		return toolOutputParsers[parser]
+	}
+	for name, rules := range toolOutputParsers {
+		if strings.HasPrefix(toolID, name) {
+			return rules
+		}
+	}
+	return nil
+}
+
+// toolOutputParser parses the output of an upload tool to report the progress
+// of the upload and the cause of a failure
+type toolOutputParser struct {
+	rules      *toolOutputRules
+	progressCB rpc.TaskProgressCB
+
+	mux          sync.Mutex
+	phase        string
+	lastProgress *rpc.TaskProgress
+	errorKind    rpc.UploadErrorKind
+	errorLine    string
+}
+
+// newToolOutputParser returns a parser for the output of the tool that performs
+// the given action, or nil if the output of the tool can't be parsed.
+func newToolOutputParser(props *properties.Map, action, protocol string, progressCB rpc.TaskProgressCB) *toolOutputParser {
+	toolID, _ := getToolID(props, action, protocol)
+	rules := findToolOutputRules(props, action, toolID)
+	if rules == nil {
+		return nil
+	}
+	return &toolOutputParser{rules: rules, progressCB: progressCB}
+}
+
+// wrap returns a writer that sends the output to w and parses it
+func (p *toolOutputParser) wrap(w io.Writer) io.Writer {
+	if p == nil {
+		return w
+	}
+	return &toolOutputWriter{parser: p, out: w}
+}
+
+// failedUploadError returns a FailedUploadError with the cause of the failure
+// detected in the tool output
+func (p *toolOutputParser) failedUploadError(message string, cause error) error {
+	res := &cmderrors.FailedUploadError{Message: message, Cause: cause}
+	if p != nil {
+		p.mux.Lock()
+		res.Kind, res.ToolOutput = p.errorKind, p.errorLine
+		p.mux.Unlock()
+	}
+	return res
+}
+
+// parseLine parses a line of output. Partial lines are checked only for
+// progress, since the textual progress bars are printed a piece at a time.
+func (p *toolOutputParser) parseLine(line string, partial bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	if !partial {
+		for _, rule := range p.rules.phases {
+			if match := rule.re.FindStringSubmatch(line); match != nil {
+				p.phase = rule.phase
+				if i := rule.re.SubexpIndex("phase"); i != -1 {
+					p.phase = match[i]
+				}
+				break
+			}
+		}
+		if p.errorKind == rpc.UploadErrorKind_UPLOAD_ERROR_KIND_UNSPECIFIED {
+			for _, rule := range p.rules.errors {
+				if rule.re.MatchString(line) {
+					p.errorKind, p.errorLine = rule.kind, line
+					break
+				}
+			}
+		}
+	}
+	for _, rule := range p.rules.progress {
+		match := rule.re.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if i := rule.re.SubexpIndex("phase"); i != -1 {
+			p.phase = match[i]
+		}
+		percent := rule.percent
+		if i := rule.re.SubexpIndex("percent"); i != -1 {
+			v, _ := strconv.ParseFloat(match[i], 32)
+			percent = float32(v)
+		} else if i := rule.re.SubexpIndex("bar"); i != -1 {
+			percent = float32(len(match[i])) * rule.barScale
+		}
+		p.reportProgress(min(percent, 100))
+		break
+	}
+}
+
+func (p *toolOutputParser) reportProgress(percent float32) {
+	progress := &rpc.TaskProgress{Name: p.phase, Percent: percent, Completed: percent >= 100}
+	if p.lastProgress != nil &&
+		p.lastProgress.GetName() == progress.GetName() &&
+		p.lastProgress.GetPercent() == progress.GetPercent() {
+		return
+	}
+	p.lastProgress = progress
+	if p.progressCB != nil {
+		p.progressCB(progress)
+	}
+}
+
+// toolOutputWriter sends the tool output to the underlying writer, unchanged,
+// and to the parser split in lines
+type toolOutputWriter struct {
+	parser *toolOutputParser
+	out    io.Writer
+	line   []byte
+}
+
+// maxToolOutputLine is the maximum length of a line kept for parsing
+const maxToolOutputLine = 4096
+
+func (w *toolOutputWriter) Write(data []byte) (int, error) {
+	w.line = append(w.line, data...)
+	for {
+		i := bytes.IndexAny(w.line, "\r\n")
+		if i == -1 {
+			break
+		}
+		w.parser.parseLine(string(w.line[:i]), false)
+		w.line = w.line[i+1:]
+	}
+	if len(w.line) > maxToolOutputLine {
+		w.line = w.line[len(w.line)-maxToolOutputLine:]
+	}
+	if len(w.line) > 0 {
+		w.parser.parseLine(string(w.line), true)
+	}
+	return w.out.Write(data)
+}
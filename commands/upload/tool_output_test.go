@@ -0,0 +1,119 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolOutputParser(t *testing.T) {
+	parse := func(toolID string, chunks ...string) ([]string, *cmderrors.FailedUploadError, string) {
+		props := properties.NewFromHashmap(map[string]string{"upload.tool.default": toolID})
+		progress := []string{}
+		parser := newToolOutputParser(props, "upload", "serial", func(p *rpc.TaskProgress) {
+			progress = append(progress, fmt.Sprintf("%s %.0f%% %v", p.GetName(), p.GetPercent(), p.GetCompleted()))
+		})
+		require.NotNil(t, parser)
+		out := &bytes.Buffer{}
+		w := parser.wrap(out)
+		for _, chunk := range chunks {
+			_, err := io.WriteString(w, chunk)
+			require.NoError(t, err)
+		}
+		var failed *cmderrors.FailedUploadError
+		require.ErrorAs(t, parser.failedUploadError("Failed uploading", errors.New("exit status 1")), &failed)
+		return progress, failed, out.String()
+	}
+
+	// avrdude prints the progress bar a piece at a time
+	progress, failed, out := parse("avrdude",
+		"avrdude: writing flash (924 bytes):\n\nWriting | ", "#########################", "#########################", " | 100% 0.16s\n",
+		"avrdude: verifying ...\nReading | ################################################## | 100% 0.12s\n")
+	require.Equal(t, []string{"Writing 50% false", "Writing 100% true", "Reading 100% true"}, progress)
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_UNSPECIFIED, failed.Kind)
+	require.Contains(t, out, "Writing | ##################################################", "the output is passed through unchanged")
+
+	_, failed, _ = parse("avrdude",
+		"avrdude: stk500_recv(): programmer is not responding\n",
+		"avrdude: stk500_getsync() attempt 1 of 10: not in sync: resp=0x00\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_SYNC_FAILURE, failed.Kind)
+	require.Equal(t, "avrdude: stk500_recv(): programmer is not responding", failed.ToolOutput)
+	require.Contains(t, failed.Error(), "the board doesn't respond")
+	require.Contains(t, failed.Error(), "exit status 1")
+
+	_, failed, _ = parse("avrdude", "avrdude: Device signature = 0x1e9587\n",
+		"avrdude: Expected signature for ATmega328P is 1E 95 0F\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_WRONG_SIGNATURE, failed.Kind)
+
+	_, failed, _ = parse("avrdude", "avrdude: ser_open(): can't open device \"/dev/ttyACM0\": Permission denied\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_ACCESS_DENIED, failed.Kind)
+
+	_, failed, _ = parse("avrdude", "avrdude: ser_open(): can't open device \"/dev/ttyACM9\": No such file or directory\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_PORT_NOT_FOUND, failed.Kind)
+
+	// bossac updates the progress bar on the same line
+	progress, _, _ = parse("bossac",
+		"Erase flash\ndone in 0.8 seconds\n\nWrite 11536 bytes to flash (181 pages)\n",
+		"[=====                         ] 17% (32/181 pages)\r[==============================] 100% (181/181 pages)\n")
+	require.Equal(t, []string{"Write 17% false", "Write 100% true"}, progress)
+
+	_, failed, _ = parse("bossac", "No device found on /dev/ttyACM0\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_DEVICE_NOT_FOUND, failed.Kind)
+
+	progress, _, _ = parse("esptool_py",
+		"Erasing flash (this may take a while)...\n",
+		"Writing at 0x00010000... (50 %)\nWriting at 0x00014000... (100 %)\n")
+	require.Equal(t, []string{"Writing 50% false", "Writing 100% true"}, progress)
+
+	_, failed, _ = parse("esptool_py", "A fatal error occurred: Failed to connect to ESP32: No serial data received.\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_SYNC_FAILURE, failed.Kind)
+
+	progress, _, _ = parse("dfu-util", "Download\t[=========                ]  36%        45056 bytes\n")
+	require.Equal(t, []string{"Download 36% false"}, progress)
+
+	_, failed, _ = parse("dfu-util", "dfu-util: No DFU capable USB device available\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_DEVICE_NOT_FOUND, failed.Kind)
+
+	progress, _, _ = parse("openocd",
+		"** Programming Started **\n** Programming Finished **\n** Verify Started **\n** Verified OK **\n")
+	require.Equal(t, []string{"Programming 0% false", "Programming 100% true", "Verify 0% false", "Verif 100% true"}, progress)
+
+	_, failed, _ = parse("openocd", "** Verify Failed **\n")
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_VERIFICATION_FAILED, failed.Kind)
+	require.Equal(t, rpc.UploadErrorKind_UPLOAD_ERROR_KIND_VERIFICATION_FAILED,
+		failed.ToRPCStatus().Details()[0].(*rpc.FailedUploadError).GetKind())
+}
+
+func TestFindToolOutputRules(t *testing.T) {
+	props := properties.NewMap()
+	require.Equal(t, toolOutputParsers["esptool"], findToolOutputRules(props, "upload", "esptool_py"))
+	require.Nil(t, findToolOutputRules(props, "upload", "rp2040tools"))
+
+	// The parser may be selected by the platform
+	props.Set("upload.output_parser", "openocd")
+	require.Equal(t, toolOutputParsers["openocd"], findToolOutputRules(props, "upload", "rp2040tools"))
+	props.Set("upload.output_parser", "none")
+	require.Nil(t, findToolOutputRules(props, "upload", "avrdude"))
+}
@@ -26,11 +26,15 @@ import (
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/commands/internal/portlock"
 	f "github.com/arduino/arduino-cli/internal/algorithms"
+	"github.com/arduino/arduino-cli/internal/arduino/buildmanifest"
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
 	"github.com/arduino/arduino-cli/internal/arduino/globals"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	paths "github.com/arduino/go-paths-helper"
@@ -124,7 +128,7 @@ func getUserFields(toolID string, platformRelease *cores.PlatformRelease) []*rpc
 }
 
 // Upload FIXMEDOC
-func Upload(ctx context.Context, req *rpc.UploadRequest, outStream io.Writer, errStream io.Writer) (*rpc.UploadResult, error) {
+func Upload(ctx context.Context, req *rpc.UploadRequest, outStream io.Writer, errStream io.Writer, progressCB rpc.TaskProgressCB) (*rpc.UploadResult, error) {
 	logrus.Tracef("Upload %s on %s started", req.GetSketchPath(), req.GetFqbn())
 
 	// TODO: make a generic function to extract sketch from request
@@ -165,8 +169,10 @@ func Upload(ctx context.Context, req *rpc.UploadRequest, outStream io.Writer, er
 		req.GetVerbose(),
 		req.GetVerify(),
 		false, // burnBootloader
+		req.GetPreflight(),
 		outStream,
 		errStream,
+		progressCB,
 		req.GetDryRun(),
 		req.GetUserFields(),
 	)
@@ -180,7 +186,7 @@ func Upload(ctx context.Context, req *rpc.UploadRequest, outStream io.Writer, er
 }
 
 // UsingProgrammer FIXMEDOC
-func UsingProgrammer(ctx context.Context, req *rpc.UploadUsingProgrammerRequest, outStream io.Writer, errStream io.Writer) error {
+func UsingProgrammer(ctx context.Context, req *rpc.UploadUsingProgrammerRequest, outStream io.Writer, errStream io.Writer, progressCB rpc.TaskProgressCB) error {
 	logrus.Tracef("Upload using programmer %s on %s started", req.GetSketchPath(), req.GetFqbn())
 
 	if req.GetProgrammer() == "" {
@@ -197,7 +203,8 @@ func UsingProgrammer(ctx context.Context, req *rpc.UploadUsingProgrammerRequest,
 		Verbose:    req.GetVerbose(),
 		Verify:     req.GetVerify(),
 		UserFields: req.GetUserFields(),
-	}, outStream, errStream)
+		Preflight:  req.GetPreflight(),
+	}, outStream, errStream, progressCB)
 	return err
 }
 
@@ -205,8 +212,8 @@ func runProgramAction(pme *packagemanager.Explorer,
 	sk *sketch.Sketch,
 	importFile, importDir, fqbnIn string, userPort *rpc.Port,
 	programmerID string,
-	verbose, verify, burnBootloader bool,
-	outStream, errStream io.Writer,
+	verbose, verify, burnBootloader, preflight bool,
+	outStream, errStream io.Writer, progressCB rpc.TaskProgressCB,
 	dryRun bool, userFields map[string]string,
 ) (*rpc.Port, error) {
 	port := rpc.DiscoveryPortFromRPCPort(userPort)
@@ -243,82 +250,22 @@ func runProgramAction(pme *packagemanager.Explorer,
 		Tracef("Upload data")
 
 	// Extract programmer properties (when specified)
-	var programmer *cores.Programmer
-	if programmerID != "" {
-		programmer = boardPlatform.Programmers[programmerID]
-		if programmer == nil {
-			// Try to find the programmer in the referenced build platform
-			programmer = buildPlatform.Programmers[programmerID]
-		}
-		if programmer == nil {
-			return nil, &cmderrors.ProgrammerNotFoundError{Programmer: programmerID}
-		}
+	programmer, err := findProgrammer(boardPlatform, buildPlatform, programmerID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Determine upload tool
-	// create a temporary configuration only for the selection of upload tool
-	props := properties.NewMap()
-	props.Merge(boardPlatform.Properties)
-	props.Merge(boardPlatform.RuntimeProperties())
-	props.Merge(boardProperties)
-	if programmer != nil {
-		props.Merge(programmer.Properties)
-	}
 	action := "upload"
 	if burnBootloader {
 		action = "bootloader"
 	} else if programmer != nil {
 		action = "program"
 	}
-	uploadToolID, err := getToolID(props, action, port.Protocol)
+	uploadProperties, err := loadUploadToolProperties(pme, boardPlatform, boardProperties, programmer, action, port.Protocol)
 	if err != nil {
 		return nil, err
 	}
 
-	var uploadToolPlatform *cores.PlatformRelease
-	if programmer != nil {
-		uploadToolPlatform = programmer.PlatformRelease
-	} else {
-		uploadToolPlatform = boardPlatform
-	}
-	logrus.
-		WithField("uploadToolID", uploadToolID).
-		WithField("uploadToolPlatform", uploadToolPlatform).
-		Trace("Upload tool")
-
-	if split := strings.Split(uploadToolID, ":"); len(split) > 2 {
-		return nil, &cmderrors.InvalidPlatformPropertyError{
-			Property: fmt.Sprintf("%s.tool.%s", action, port.Protocol), // TODO: Can be done better, maybe inline getToolID(...)
-			Value:    uploadToolID}
-	} else if len(split) == 2 {
-		p := pme.FindPlatform(&packagemanager.PlatformReference{
-			Package:              split[0],
-			PlatformArchitecture: boardPlatform.Platform.Architecture,
-		})
-		if p == nil {
-			return nil, &cmderrors.PlatformNotFoundError{Platform: split[0] + ":" + boardPlatform.Platform.Architecture}
-		}
-		uploadToolID = split[1]
-		uploadToolPlatform = pme.GetInstalledPlatformRelease(p)
-		if uploadToolPlatform == nil {
-			return nil, &cmderrors.PlatformNotFoundError{Platform: split[0] + ":" + boardPlatform.Platform.Architecture}
-		}
-	}
-
-	// Build configuration for upload
-	uploadProperties := properties.NewMap()
-	if uploadToolPlatform != nil {
-		uploadProperties.Merge(uploadToolPlatform.Properties)
-	}
-	uploadProperties.Set("runtime.os", properties.GetOSSuffix())
-	uploadProperties.Merge(boardPlatform.Properties)
-	uploadProperties.Merge(boardPlatform.RuntimeProperties())
-	uploadProperties.Merge(overrideProtocolProperties(action, port.Protocol, boardProperties))
-	uploadProperties.Merge(uploadProperties.SubTree("tools." + uploadToolID))
-	if programmer != nil {
-		uploadProperties.Merge(programmer.Properties)
-	}
-
 	// Certain tools require the user to provide custom fields at run time,
 	// if they've been provided set them
 	// For more info:
@@ -373,8 +320,10 @@ func runProgramAction(pme *packagemanager.Explorer,
 		uploadProperties.Set("bootloader.verify", uploadProperties.Get("bootloader.params.noverify"))
 	}
 
+	var importPath *paths.Path
+	var sketchName string
 	if !burnBootloader {
-		importPath, sketchName, err := determineBuildPathAndSketchName(importFile, importDir, sk, fqbn)
+		importPath, sketchName, err = determineBuildPathAndSketchName(importFile, importDir, sk, fqbn)
 		if err != nil {
 			return nil, &cmderrors.NotFoundError{Message: tr("Error finding build artifacts"), Cause: err}
 		}
@@ -384,6 +333,9 @@ func runProgramAction(pme *packagemanager.Explorer,
 		if !importPath.IsDir() {
 			return nil, &cmderrors.NotFoundError{Message: tr("Expected compiled sketch in directory %s, but is a file instead", importPath)}
 		}
+		if err := checkBuildManifest(importPath, sketchName, board.CompleteFQBN(fqbn)); err != nil {
+			return nil, err
+		}
 		uploadProperties.SetPath("build.path", importPath)
 		uploadProperties.Set("build.project_name", sketchName)
 	}
@@ -419,6 +371,13 @@ func runProgramAction(pme *packagemanager.Explorer,
 		(!uploadProperties.ContainsKey("upload.tool.serial") ||
 			uploadProperties.Get("upload.tool.serial") == uploadProperties.Get("upload.tool.default"))
 
+	// Suspend the monitor sessions opened on the same port (if any), they
+	// are resumed when the upload is completed.
+	if !dryRun && port.Address != "" {
+		releasePort := portlock.Acquire(port.Protocol, port.Address)
+		defer releasePort()
+	}
+
 	// If not using programmer perform some action required
 	// to set the board in bootloader mode
 	actualPort := port.Clone()
@@ -503,20 +462,29 @@ func runProgramAction(pme *packagemanager.Explorer,
 
 	// Run recipes for upload
 	toolEnv := pme.GetEnvVarsForSpawnedProcess()
+	if preflight && !burnBootloader {
+		if err := runPreflightCheck(action, uploadProperties, outStream, errStream, verbose, dryRun, toolEnv); err != nil {
+			return nil, err
+		}
+	}
+	// The output of the tool is parsed, if possible, to report the progress
+	// and the cause of a failure
+	outputParser := newToolOutputParser(uploadProperties, action, port.Protocol, progressCB)
+	toolOutStream, toolErrStream := outputParser.wrap(outStream), outputParser.wrap(errStream)
 	if burnBootloader {
-		if err := runTool("erase.pattern", uploadProperties, outStream, errStream, verbose, dryRun, toolEnv); err != nil {
-			return nil, &cmderrors.FailedUploadError{Message: tr("Failed chip erase"), Cause: err}
+		if err := runTool("erase.pattern", uploadProperties, toolOutStream, toolErrStream, verbose, dryRun, toolEnv); err != nil {
+			return nil, outputParser.failedUploadError(tr("Failed chip erase"), err)
 		}
-		if err := runTool("bootloader.pattern", uploadProperties, outStream, errStream, verbose, dryRun, toolEnv); err != nil {
-			return nil, &cmderrors.FailedUploadError{Message: tr("Failed to burn bootloader"), Cause: err}
+		if err := runTool("bootloader.pattern", uploadProperties, toolOutStream, toolErrStream, verbose, dryRun, toolEnv); err != nil {
+			return nil, outputParser.failedUploadError(tr("Failed to burn bootloader"), err)
 		}
 	} else if programmer != nil {
-		if err := runTool("program.pattern", uploadProperties, outStream, errStream, verbose, dryRun, toolEnv); err != nil {
-			return nil, &cmderrors.FailedUploadError{Message: tr("Failed programming"), Cause: err}
+		if err := runTool("program.pattern", uploadProperties, toolOutStream, toolErrStream, verbose, dryRun, toolEnv); err != nil {
+			return nil, outputParser.failedUploadError(tr("Failed programming"), err)
 		}
 	} else {
-		if err := runTool("upload.pattern", uploadProperties, outStream, errStream, verbose, dryRun, toolEnv); err != nil {
-			return nil, &cmderrors.FailedUploadError{Message: tr("Failed uploading"), Cause: err}
+		if err := runTool("upload.pattern", uploadProperties, toolOutStream, toolErrStream, verbose, dryRun, toolEnv); err != nil {
+			return nil, outputParser.failedUploadError(tr("Failed uploading"), err)
 		}
 	}
 
@@ -524,6 +492,15 @@ func runProgramAction(pme *packagemanager.Explorer,
 	logrus.Tracef("Upload successful")
 
 	updatedPort := updatedUploadPort.Await()
+
+	// Keep track of the sketch running on the board
+	if !burnBootloader && !dryRun && configuration.Settings != nil {
+		registryFile := configuration.DeploymentsFile(configuration.Settings)
+		if err := recordDeployment(registryFile, sk, importPath, sketchName, board.CompleteFQBN(fqbn).String(), port, updatedPort); err != nil {
+			logrus.WithError(err).Warn("Error recording the deployment")
+		}
+	}
+
 	if updatedPort == nil {
 		// If the algorithms can not detect the new port, fallback to the user-provided port.
 		return userPort, nil
@@ -531,6 +508,89 @@ func runProgramAction(pme *packagemanager.Explorer,
 	return rpc.DiscoveryPortToRPC(updatedPort), nil
 }
 
+// findProgrammer returns the programmer with the given ID searching in the board
+// platform and in the referenced build platform, or nil if programmerID is empty.
+func findProgrammer(boardPlatform, buildPlatform *cores.PlatformRelease, programmerID string) (*cores.Programmer, error) {
+	if programmerID == "" {
+		return nil, nil
+	}
+	programmer := boardPlatform.Programmers[programmerID]
+	if programmer == nil {
+		// Try to find the programmer in the referenced build platform
+		programmer = buildPlatform.Programmers[programmerID]
+	}
+	if programmer == nil {
+		return nil, &cmderrors.ProgrammerNotFoundError{Programmer: programmerID}
+	}
+	return programmer, nil
+}
+
+// loadUploadToolProperties determines the tool that performs the given action
+// with the given protocol and returns the configuration needed to run it.
+func loadUploadToolProperties(pme *packagemanager.Explorer,
+	boardPlatform *cores.PlatformRelease, boardProperties *properties.Map,
+	programmer *cores.Programmer, action, protocol string,
+) (*properties.Map, error) {
+	// Determine upload tool
+	// create a temporary configuration only for the selection of upload tool
+	props := properties.NewMap()
+	props.Merge(boardPlatform.Properties)
+	props.Merge(boardPlatform.RuntimeProperties())
+	props.Merge(boardProperties)
+	if programmer != nil {
+		props.Merge(programmer.Properties)
+	}
+	uploadToolID, err := getToolID(props, action, protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	var uploadToolPlatform *cores.PlatformRelease
+	if programmer != nil {
+		uploadToolPlatform = programmer.PlatformRelease
+	} else {
+		uploadToolPlatform = boardPlatform
+	}
+	logrus.
+		WithField("uploadToolID", uploadToolID).
+		WithField("uploadToolPlatform", uploadToolPlatform).
+		Trace("Upload tool")
+
+	if split := strings.Split(uploadToolID, ":"); len(split) > 2 {
+		return nil, &cmderrors.InvalidPlatformPropertyError{
+			Property: fmt.Sprintf("%s.tool.%s", action, protocol), // TODO: Can be done better, maybe inline getToolID(...)
+			Value:    uploadToolID}
+	} else if len(split) == 2 {
+		p := pme.FindPlatform(&packagemanager.PlatformReference{
+			Package:              split[0],
+			PlatformArchitecture: boardPlatform.Platform.Architecture,
+		})
+		if p == nil {
+			return nil, &cmderrors.PlatformNotFoundError{Platform: split[0] + ":" + boardPlatform.Platform.Architecture}
+		}
+		uploadToolID = split[1]
+		uploadToolPlatform = pme.GetInstalledPlatformRelease(p)
+		if uploadToolPlatform == nil {
+			return nil, &cmderrors.PlatformNotFoundError{Platform: split[0] + ":" + boardPlatform.Platform.Architecture}
+		}
+	}
+
+	// Build configuration for upload
+	uploadProperties := properties.NewMap()
+	if uploadToolPlatform != nil {
+		uploadProperties.Merge(uploadToolPlatform.Properties)
+	}
+	uploadProperties.Set("runtime.os", properties.GetOSSuffix())
+	uploadProperties.Merge(boardPlatform.Properties)
+	uploadProperties.Merge(boardPlatform.RuntimeProperties())
+	uploadProperties.Merge(overrideProtocolProperties(action, protocol, boardProperties))
+	uploadProperties.Merge(uploadProperties.SubTree("tools." + uploadToolID))
+	if programmer != nil {
+		uploadProperties.Merge(programmer.Properties)
+	}
+	return uploadProperties, nil
+}
+
 func detectUploadPort(
 	uploadCtx context.Context,
 	uploadPort *discovery.Port, watch <-chan *discovery.Event,
@@ -634,6 +694,12 @@ func detectUploadPort(
 }
 
 func runTool(recipeID string, props *properties.Map, outStream, errStream io.Writer, verbose bool, dryRun bool, toolEnv []string) error {
+	return runToolWithContext(context.Background(), recipeID, props, outStream, errStream, verbose, dryRun, toolEnv)
+}
+
+// runToolWithContext runs the recipe as runTool does, the tool is killed if
+// the context is canceled before it terminates.
+func runToolWithContext(ctx context.Context, recipeID string, props *properties.Map, outStream, errStream io.Writer, verbose bool, dryRun bool, toolEnv []string) error {
 	recipe, ok := props.GetOk(recipeID)
 	if !ok {
 		return fmt.Errorf(tr("recipe not found '%s'"), recipeID)
@@ -658,9 +724,10 @@ func runTool(recipeID string, props *properties.Map, outStream, errStream io.Wri
 	if dryRun {
 		return nil
 	}
-	cmd, err := paths.NewProcess(toolEnv, cmdArgs...)
+	toolEnv = append(append([]string{}, toolEnv...), toolenv.PropertiesEnv(props)...)
+	cmd, err := toolenv.NewProcess(toolEnv, cmdArgs...)
 	if err != nil {
-		return fmt.Errorf(tr("cannot execute upload tool: %s"), err)
+		return fmt.Errorf(tr("cannot execute upload tool: %w"), err)
 	}
 
 	cmd.RedirectStdoutTo(outStream)
@@ -670,6 +737,16 @@ func runTool(recipeID string, props *properties.Map, outStream, errStream io.Wri
 		return fmt.Errorf(tr("cannot execute upload tool: %s"), err)
 	}
 
+	completed := make(chan struct{})
+	defer close(completed)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cmd.Kill()
+		case <-completed:
+		}
+	}()
+
 	if err := cmd.Wait(); err != nil {
 		return fmt.Errorf(tr("uploading error: %s"), err)
 	}
@@ -716,6 +793,11 @@ func determineBuildPathAndSketchName(importFile, importDir string, sk *sketch.Sk
 		// - "build.path" as importDir
 		// - "build.project_name" after trying to autodetect it from the build folder.
 		buildPath := paths.New(importDir)
+		if manifest, err := buildmanifest.Load(buildPath); err != nil {
+			return nil, "", err
+		} else if manifest != nil {
+			return buildPath, manifest.ProjectName, nil
+		}
 		sketchName, err := detectSketchNameFromBuildPath(buildPath)
 		if err != nil {
 			return nil, "", fmt.Errorf("%s: %w", tr("looking for build artifacts"), err)
@@ -730,7 +812,44 @@ func determineBuildPathAndSketchName(importFile, importDir string, sk *sketch.Sk
 
 	// Case 4: only sketch specified. In this case we use the generated build path
 	// and the given sketch name.
-	return sk.DefaultBuildPath(), sk.Name + sk.MainFile.Ext(), nil
+	template, err := configuration.SketchBuildPathTemplate(configuration.Settings)
+	if err != nil {
+		return nil, "", err
+	}
+	fqbnString := ""
+	if fqbn != nil {
+		fqbnString = fqbn.StringWithoutConfig()
+	}
+	buildPath, err := sk.BuildPath(template, fqbnString)
+	if err != nil {
+		return nil, "", err
+	}
+	return buildPath, sk.Name + sk.MainFile.Ext(), nil
+}
+
+// checkBuildManifest verifies the build artifacts in buildPath against the
+// build manifest saved by the compilation, if present: the artifacts must have
+// been compiled for the given board and must not have been modified.
+func checkBuildManifest(buildPath *paths.Path, sketchName string, fqbn *cores.FQBN) error {
+	manifest, err := buildmanifest.Load(buildPath)
+	if err != nil {
+		return &cmderrors.BuildManifestMismatchError{Message: tr("Error reading the build manifest"), Cause: err}
+	}
+	if manifest == nil {
+		return nil
+	}
+	if manifest.ProjectName != sketchName {
+		return &cmderrors.BuildManifestMismatchError{
+			Message: tr("The build manifest in %[1]s describes %[2]s, not %[3]s", buildPath, manifest.ProjectName, sketchName),
+		}
+	}
+	if err := manifest.CheckFQBN(fqbn); err != nil {
+		return &cmderrors.BuildManifestMismatchError{Message: tr("Build artifacts don't match the board"), Cause: err}
+	}
+	if err := manifest.VerifyFiles(buildPath); err != nil {
+		return &cmderrors.BuildManifestMismatchError{Message: tr("Build artifacts don't match the build manifest"), Cause: err}
+	}
+	return nil
 }
 
 func detectSketchNameFromBuildPath(buildPath *paths.Path) (string, error) {
@@ -126,10 +126,19 @@ func TestDetermineBuildPathAndSketchName(t *testing.T) {
 	}
 }
 
-func TestUploadPropertiesComposition(t *testing.T) {
+// loadTestHardware returns an explorer of a package manager loaded with the
+// platforms in testdata/hardware, the explorer is released at the end of the test.
+func loadTestHardware(t *testing.T) *packagemanager.Explorer {
 	pmb := packagemanager.NewBuilder(nil, nil, nil, nil, "test")
 	errs := pmb.LoadHardwareFromDirectory(paths.New("testdata", "hardware"))
 	require.Len(t, errs, 0)
+	pme, release := pmb.Build().NewExplorer()
+	t.Cleanup(release)
+	return pme
+}
+
+func TestUploadPropertiesComposition(t *testing.T) {
+	pme := loadTestHardware(t)
 	buildPath1 := paths.New("testdata", "build_path_1")
 	logrus.SetLevel(logrus.TraceLevel)
 	type test struct {
@@ -177,10 +186,6 @@ func TestUploadPropertiesComposition(t *testing.T) {
 			"BURN conf-board1 conf-two-general conf-two-bootloader $$VERBOSE-VERIFY$$ prog4protocol-bootloader port -bspeed -F0xFF " + cwd + "/testdata/hardware/alice/avr/bootloaders/niceboot/niceboot.hex\n"},
 	}
 
-	pm := pmb.Build()
-	pme, release := pm.NewExplorer()
-	defer release()
-
 	testRunner := func(t *testing.T, test test, verboseVerify bool) {
 		outStream := &bytes.Buffer{}
 		errStream := &bytes.Buffer{}
@@ -195,8 +200,10 @@ func TestUploadPropertiesComposition(t *testing.T) {
 			verboseVerify,       // verbose
 			verboseVerify,       // verify
 			test.burnBootloader, // burnBootloader
+			false,               // preflight
 			outStream,
 			errStream,
+			nil, // progressCB
 			false,
 			map[string]string{},
 		)
@@ -0,0 +1,135 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// watcherDebounce is the time to wait after the last filesystem event before
+// processing the changes, this allows to handle a library as a whole even
+// if it's copied file by file.
+var watcherDebounce = 500 * time.Millisecond
+
+// WatchDirectories watches the sketchbook and the user libraries directories
+// for additions and removals. The libraries added or removed are reloaded in
+// all the instances, without a full rescan, and the changes are notified
+// through InstanceEvents. The function blocks until the context is canceled.
+func WatchDirectories(ctx context.Context) error {
+	sketchbookDir := paths.New(configuration.Settings.GetString("directories.User"))
+	librariesDir := configuration.LibrariesDir(configuration.Settings)
+	return watchDirectories(ctx, sketchbookDir, librariesDir)
+}
+
+func watchDirectories(ctx context.Context, sketchbookDir, librariesDir *paths.Path) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(sketchbookDir.String()); err != nil {
+		return err
+	}
+	if librariesDir.IsDir() {
+		if err := watcher.Add(librariesDir.String()); err != nil {
+			return err
+		}
+	}
+	logrus.WithField("sketchbook", sketchbookDir).WithField("libraries", librariesDir).Info("Watching directories")
+
+	changed := paths.NewPathList()
+	timer := time.NewTimer(watcherDebounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			path := paths.New(event.Name)
+			if path.EquivalentTo(librariesDir) && event.Has(fsnotify.Create) {
+				if err := watcher.Add(librariesDir.String()); err != nil {
+					logrus.WithError(err).Warn("Watching libraries directory")
+				}
+			}
+			changed.AddIfMissing(path)
+			timer.Reset(watcherDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logrus.WithError(err).Warn("Watching directories")
+		case <-timer.C:
+			processDirectoriesChanges(changed, sketchbookDir, librariesDir)
+			changed = paths.NewPathList()
+		}
+	}
+}
+
+func processDirectoriesChanges(changed paths.PathList, sketchbookDir, librariesDir *paths.Path) {
+	var libDirs paths.PathList
+	sketchbookChanged := false
+	for _, path := range changed {
+		if path.EquivalentTo(librariesDir) {
+			// The whole libraries directory has been created or removed
+			if dirs, err := librariesDir.ReadDir(); err == nil {
+				dirs.FilterDirs()
+				libDirs.AddAllMissing(dirs)
+			}
+		} else if path.Parent().EquivalentTo(librariesDir) {
+			libDirs.AddIfMissing(path)
+		} else if path.Parent().EquivalentTo(sketchbookDir) {
+			sketchbookChanged = true
+		}
+	}
+
+	for _, libDir := range libDirs {
+		updated := false
+		for _, inst := range instances.All() {
+			lm, err := instances.GetLibraryManager(inst)
+			if err != nil {
+				continue
+			}
+			lmi, release := lm.NewInstaller()
+			ok, err := lmi.RescanLibrary(libDir)
+			release()
+			if err != nil {
+				logrus.WithError(err).Warn("Reloading library")
+			}
+			updated = updated || ok
+		}
+		if updated {
+			instances.NotifyEvent(nil, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, libDir.Base())
+		}
+	}
+	if sketchbookChanged {
+		instances.NotifyEvent(nil, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_SKETCHBOOK_CHANGED, "")
+	}
+}
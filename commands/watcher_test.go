@@ -0,0 +1,83 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/internal/instances"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesmanager"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchDirectories(t *testing.T) {
+	watcherDebounce = 50 * time.Millisecond
+	tmp := paths.New(t.TempDir())
+	sketchbookDir := tmp.Join("Arduino")
+	librariesDir := sketchbookDir.Join("libraries")
+	require.NoError(t, librariesDir.MkdirAll())
+
+	inst, err := instances.Create(tmp, tmp, tmp)
+	require.NoError(t, err)
+	defer instances.Delete(inst)
+	lmb := librariesmanager.NewBuilder()
+	lmb.AddLibrariesDir(librariesmanager.LibrariesDir{Path: librariesDir, Location: libraries.User})
+	lm, _ := lmb.Build()
+	require.True(t, instances.SetLibraryManager(inst, lm))
+
+	events, unsubscribe := instances.SubscribeEvents()
+	defer unsubscribe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchDirectories(ctx, sketchbookDir, librariesDir)
+	time.Sleep(100 * time.Millisecond)
+
+	nextEvent := func() *rpc.InstanceEventsResponse {
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout waiting for event")
+			return nil
+		}
+	}
+
+	libDir := librariesDir.Join("MyLib")
+	require.NoError(t, libDir.MkdirAll())
+	require.NoError(t, libDir.Join("library.properties").WriteFile([]byte("name=MyLib\nversion=1.0.0\n")))
+	ev := nextEvent()
+	require.Equal(t, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, ev.GetType())
+	require.Equal(t, "MyLib", ev.GetDetails())
+	lme, release := lm.NewExplorer()
+	require.Len(t, lme.FindAllInstalled(), 1)
+	release()
+
+	require.NoError(t, sketchbookDir.Join("Blink").MkdirAll())
+	ev = nextEvent()
+	require.Equal(t, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_SKETCHBOOK_CHANGED, ev.GetType())
+
+	require.NoError(t, libDir.RemoveAll())
+	ev = nextEvent()
+	require.Equal(t, rpc.InstanceEventType_INSTANCE_EVENT_TYPE_LIBRARIES_CHANGED, ev.GetType())
+	lme, release = lm.NewExplorer()
+	require.Empty(t, lme.FindAllInstalled())
+	release()
+}
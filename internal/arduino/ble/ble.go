@@ -0,0 +1,338 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package ble implements a pluggable discovery and a pluggable monitor for
+// the boards exposing a Bluetooth Low Energy UART, using the Nordic UART
+// Service (NUS). The native Bluetooth stack of the operating system is used:
+// BlueZ on Linux, CoreBluetooth on macOS and WinRT on Windows.
+package ble
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/arduino/go-properties-orderedmap"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	monitor "github.com/arduino/pluggable-monitor-protocol-handler"
+)
+
+// Protocol is the protocol of the BLE ports
+const Protocol = "ble"
+
+// deviceTimeout is the time after which a device that is no longer
+// advertising is reported as removed.
+var deviceTimeout = 30 * time.Second
+
+// Device is a BLE device advertising the Nordic UART Service
+type Device struct {
+	Address string
+	Name    string
+	RSSI    int16
+}
+
+// Port returns the discovery port of the device
+func (d *Device) Port() *discovery.Port {
+	label := d.Address
+	if d.Name != "" {
+		label = fmt.Sprintf("%s (%s)", d.Name, d.Address)
+	}
+	props := properties.NewMap()
+	props.Set("name", d.Name)
+	return &discovery.Port{
+		Address:       d.Address,
+		AddressLabel:  label,
+		Protocol:      Protocol,
+		ProtocolLabel: "Bluetooth LE",
+		HardwareID:    d.Address,
+		Properties:    props,
+	}
+}
+
+// adapter is the Bluetooth stack used to scan and connect to the devices
+type adapter interface {
+	// scan calls found for each advertisement of a device exposing the
+	// Nordic UART Service, it blocks until stopScan is called.
+	scan(found func(*Device)) error
+	stopScan() error
+	// connect connects to the UART of the device with the given address,
+	// received is called with the data notified by the device.
+	connect(address string, received func([]byte)) (uartConnection, error)
+}
+
+// uartConnection is a connection to the UART of a device
+type uartConnection interface {
+	// write sends the data to the device, the data must fit in a single packet
+	write(data []byte) error
+	// mtu returns the maximum size of the data that can be written at once
+	mtu() int
+	close() error
+}
+
+// Discovery is a pluggable discovery that reports the BLE UART devices
+type Discovery struct {
+	adapter adapter
+
+	mux      sync.Mutex
+	devices  map[string]*seenDevice
+	stopSync chan struct{}
+}
+
+type seenDevice struct {
+	port     *discovery.Port
+	lastSeen time.Time
+}
+
+// NewDiscovery creates a new BLE Discovery using the native Bluetooth stack
+func NewDiscovery() *Discovery {
+	return &Discovery{adapter: newNativeAdapter()}
+}
+
+// Hello does nothing, it's here to implement the discovery.Discovery interface
+func (d *Discovery) Hello(userAgent string, protocolVersion int) error {
+	return nil
+}
+
+// StartSync starts scanning for devices. A device is reported as removed when
+// it is no longer advertising for some time, usually because another central
+// connected to it.
+func (d *Discovery) StartSync(eventCB discovery.EventCallback, errorCB discovery.ErrorCallback) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if d.stopSync != nil {
+		return errors.New("already syncing")
+	}
+	stop := make(chan struct{})
+	d.stopSync = stop
+	d.devices = map[string]*seenDevice{}
+
+	go func() {
+		err := d.adapter.scan(func(dev *Device) {
+			d.mux.Lock()
+			defer d.mux.Unlock()
+			if d.stopSync != stop {
+				return
+			}
+			if seen, ok := d.devices[dev.Address]; ok {
+				seen.lastSeen = time.Now()
+				return
+			}
+			port := dev.Port()
+			d.devices[dev.Address] = &seenDevice{port: port, lastSeen: time.Now()}
+			eventCB("add", port)
+		})
+		if err != nil {
+			errorCB(err.Error())
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(deviceTimeout / 6)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			d.mux.Lock()
+			for address, seen := range d.devices {
+				if time.Since(seen.lastSeen) > deviceTimeout {
+					delete(d.devices, address)
+					eventCB("remove", seen.port)
+				}
+			}
+			d.mux.Unlock()
+		}
+	}()
+	return nil
+}
+
+// Stop stops scanning for devices
+func (d *Discovery) Stop() error {
+	d.mux.Lock()
+	if d.stopSync == nil {
+		d.mux.Unlock()
+		return nil
+	}
+	close(d.stopSync)
+	d.stopSync = nil
+	d.devices = nil
+	// The lock must be released before stopping the scan, since the stack
+	// may wait for a pending scan callback to complete.
+	d.mux.Unlock()
+	return d.adapter.stopScan()
+}
+
+// Quit does nothing, it's here to implement the discovery.Discovery interface
+func (d *Discovery) Quit() {}
+
+// Monitor is a pluggable monitor that connects to the UART of a BLE device
+type Monitor struct {
+	adapter adapter
+
+	mux    sync.Mutex
+	opened *uartPort
+}
+
+// NewMonitor creates a new BLE Monitor using the native Bluetooth stack
+func NewMonitor() *Monitor {
+	return &Monitor{adapter: newNativeAdapter()}
+}
+
+// Hello does nothing, it's here to implement the monitor.Monitor interface
+func (m *Monitor) Hello(userAgent string, protocolVersion int) error {
+	return nil
+}
+
+// Describe returns the settings of the BLE port, there are no settings since
+// the speed and framing are negotiated by the Bluetooth stack.
+func (m *Monitor) Describe() (*monitor.PortDescriptor, error) {
+	return &monitor.PortDescriptor{
+		Protocol:               Protocol,
+		ConfigurationParameter: map[string]*monitor.PortParameterDescriptor{},
+	}, nil
+}
+
+// Configure always fails since the BLE port has no settings
+func (m *Monitor) Configure(parameterName string, value string) error {
+	return fmt.Errorf("could not find parameter named %s", parameterName)
+}
+
+// Open connects to the UART of the device with the given address
+func (m *Monitor) Open(boardPort string) (io.ReadWriter, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.opened != nil {
+		return nil, fmt.Errorf("port already opened: %s", boardPort)
+	}
+	p := newUARTPort()
+	conn, err := m.adapter.connect(boardPort, p.received)
+	if err != nil {
+		return nil, err
+	}
+	p.conn = conn
+	m.opened = p
+	return p, nil
+}
+
+// Close disconnects from the device
+func (m *Monitor) Close() error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.opened == nil {
+		return errors.New("port already closed")
+	}
+	err := m.opened.Close()
+	m.opened = nil
+	return err
+}
+
+// Quit does nothing, it's here to implement the monitor.Monitor interface
+func (m *Monitor) Quit() {}
+
+// uartPort is an io.ReadWriter that buffers the data notified by the device
+// and splits the data written in packets fitting the MTU.
+type uartPort struct {
+	conn uartConnection
+
+	mux    sync.Mutex
+	cond   *sync.Cond
+	buffer []byte
+	closed bool
+}
+
+func newUARTPort() *uartPort {
+	p := &uartPort{}
+	p.cond = sync.NewCond(&p.mux)
+	return p
+}
+
+func (p *uartPort) received(data []byte) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if p.closed {
+		return
+	}
+	p.buffer = append(p.buffer, data...)
+	p.cond.Broadcast()
+}
+
+func (p *uartPort) Read(buf []byte) (int, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	for len(p.buffer) == 0 && !p.closed {
+		p.cond.Wait()
+	}
+	if len(p.buffer) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(buf, p.buffer)
+	p.buffer = p.buffer[n:]
+	return n, nil
+}
+
+func (p *uartPort) Write(buf []byte) (int, error) {
+	p.mux.Lock()
+	closed := p.closed
+	p.mux.Unlock()
+	if closed {
+		return 0, io.ErrClosedPipe
+	}
+	mtu := p.conn.mtu()
+	written := 0
+	for written < len(buf) {
+		end := written + mtu
+		if end > len(buf) {
+			end = len(buf)
+		}
+		if err := p.conn.write(buf[written:end]); err != nil {
+			return written, err
+		}
+		written = end
+	}
+	return written, nil
+}
+
+func (p *uartPort) Close() error {
+	p.mux.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mux.Unlock()
+	return p.conn.close()
+}
+
+// DiscoveryCommandLine returns the command line to run the BLE discovery, it
+// is served by the hidden `ble discovery` command of the running executable.
+func DiscoveryCommandLine() ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return []string{exe, "ble", "discovery"}, nil
+}
+
+// MonitorCommandLine returns the command line to run the BLE monitor, it is
+// served by the hidden `ble monitor` command of the running executable.
+func MonitorCommandLine() ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return []string{exe, "ble", "monitor"}, nil
+}
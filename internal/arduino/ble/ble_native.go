@@ -0,0 +1,166 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+//go:build linux || windows || (darwin && cgo)
+
+package ble
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// connectScanTimeout is the maximum time spent looking for the device to
+// connect to.
+var connectScanTimeout = 10 * time.Second
+
+// nativeAdapter uses the Bluetooth stack of the operating system
+type nativeAdapter struct {
+	adapter   *bluetooth.Adapter
+	enableErr error
+	enabled   sync.Once
+}
+
+func newNativeAdapter() adapter {
+	return &nativeAdapter{adapter: bluetooth.DefaultAdapter}
+}
+
+func (a *nativeAdapter) enable() error {
+	a.enabled.Do(func() {
+		if err := a.adapter.Enable(); err != nil {
+			a.enableErr = fmt.Errorf("could not enable the Bluetooth adapter: %w", err)
+		}
+	})
+	return a.enableErr
+}
+
+func (a *nativeAdapter) scan(found func(*Device)) error {
+	if err := a.enable(); err != nil {
+		return err
+	}
+	return a.adapter.Scan(func(_ *bluetooth.Adapter, res bluetooth.ScanResult) {
+		if !res.HasServiceUUID(bluetooth.ServiceUUIDNordicUART) {
+			return
+		}
+		found(&Device{
+			Address: res.Address.String(),
+			Name:    res.LocalName(),
+			RSSI:    res.RSSI,
+		})
+	})
+}
+
+func (a *nativeAdapter) stopScan() error {
+	return a.adapter.StopScan()
+}
+
+func (a *nativeAdapter) connect(address string, received func([]byte)) (uartConnection, error) {
+	if err := a.enable(); err != nil {
+		return nil, err
+	}
+
+	// The device must be found with a scan first, since on some platforms
+	// (macOS) the address is assigned by the Bluetooth stack.
+	var target *bluetooth.Address
+	timeout := time.AfterFunc(connectScanTimeout, func() { a.adapter.StopScan() })
+	err := a.adapter.Scan(func(adapter *bluetooth.Adapter, res bluetooth.ScanResult) {
+		if strings.EqualFold(res.Address.String(), address) {
+			addr := res.Address
+			target = &addr
+			adapter.StopScan()
+		}
+	})
+	timeout.Stop()
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("device not found: %s", address)
+	}
+
+	device, err := a.adapter.Connect(*target, bluetooth.ConnectionParams{})
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", address, err)
+	}
+	conn, err := openUART(device, received)
+	if err != nil {
+		device.Disconnect()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// nativeUART is a connection to the Nordic UART Service of a device
+type nativeUART struct {
+	device bluetooth.Device
+	rx     bluetooth.DeviceCharacteristic
+}
+
+func openUART(device bluetooth.Device, received func([]byte)) (*nativeUART, error) {
+	services, err := device.DiscoverServices([]bluetooth.UUID{bluetooth.ServiceUUIDNordicUART})
+	if err != nil || len(services) == 0 {
+		return nil, fmt.Errorf("the device does not expose the Nordic UART Service: %v", err)
+	}
+	chars, err := services[0].DiscoverCharacteristics([]bluetooth.UUID{
+		bluetooth.CharacteristicUUIDUARTRX,
+		bluetooth.CharacteristicUUIDUARTTX,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find the UART characteristics: %w", err)
+	}
+
+	uart := &nativeUART{device: device}
+	foundRX, foundTX := false, false
+	for _, c := range chars {
+		switch c.UUID() {
+		case bluetooth.CharacteristicUUIDUARTRX:
+			uart.rx, foundRX = c, true
+		case bluetooth.CharacteristicUUIDUARTTX:
+			// Copy the data since the buffer may be reused by the stack
+			err := c.EnableNotifications(func(buf []byte) {
+				received(append([]byte{}, buf...))
+			})
+			if err != nil {
+				return nil, fmt.Errorf("could not enable the UART notifications: %w", err)
+			}
+			foundTX = true
+		}
+	}
+	if !foundRX || !foundTX {
+		return nil, fmt.Errorf("could not find the UART characteristics")
+	}
+	return uart, nil
+}
+
+func (u *nativeUART) write(data []byte) error {
+	_, err := u.rx.WriteWithoutResponse(data)
+	return err
+}
+
+func (u *nativeUART) mtu() int {
+	// The ATT header takes 3 bytes of the MTU
+	if mtu, err := u.rx.GetMTU(); err == nil && mtu > 23 {
+		return int(mtu) - 3
+	}
+	return 20
+}
+
+func (u *nativeUART) close() error {
+	return u.device.Disconnect()
+}
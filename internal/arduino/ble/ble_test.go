@@ -0,0 +1,133 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package ble
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAdapter advertises the given devices and echoes back the data written
+// to the UART.
+type fakeAdapter struct {
+	devices []*Device
+	stop    chan struct{}
+	written [][]byte
+}
+
+func (a *fakeAdapter) scan(found func(*Device)) error {
+	a.stop = make(chan struct{})
+	for _, d := range a.devices {
+		found(d)
+	}
+	<-a.stop
+	return nil
+}
+
+func (a *fakeAdapter) stopScan() error {
+	close(a.stop)
+	return nil
+}
+
+func (a *fakeAdapter) connect(address string, received func([]byte)) (uartConnection, error) {
+	for _, d := range a.devices {
+		if d.Address == address {
+			return &fakeUART{adapter: a, received: received}, nil
+		}
+	}
+	return nil, errors.New("device not found")
+}
+
+type fakeUART struct {
+	adapter  *fakeAdapter
+	received func([]byte)
+}
+
+func (u *fakeUART) write(data []byte) error {
+	u.adapter.written = append(u.adapter.written, data)
+	u.received(data)
+	return nil
+}
+
+func (u *fakeUART) mtu() int { return 4 }
+
+func (u *fakeUART) close() error { return nil }
+
+func TestBLEDiscovery(t *testing.T) {
+	deviceTimeout = 300 * time.Millisecond
+	defer func() { deviceTimeout = 30 * time.Second }()
+
+	d := &Discovery{adapter: &fakeAdapter{devices: []*Device{
+		{Address: "AA:BB:CC:DD:EE:FF", Name: "Nano33BLE"},
+	}}}
+	var mux sync.Mutex
+	events := []string{}
+	eventCB := func(event string, port *discovery.Port) {
+		mux.Lock()
+		defer mux.Unlock()
+		events = append(events, event+" "+port.AddressLabel+" "+port.Properties.Get("name"))
+	}
+	require.NoError(t, d.StartSync(eventCB, func(string) {}))
+	require.Error(t, d.StartSync(eventCB, func(string) {}))
+
+	// The device is removed when it is no longer advertising
+	require.Eventually(t, func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(events) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{
+		"add Nano33BLE (AA:BB:CC:DD:EE:FF) Nano33BLE",
+		"remove Nano33BLE (AA:BB:CC:DD:EE:FF) Nano33BLE",
+	}, events)
+	require.NoError(t, d.Stop())
+	require.NoError(t, d.Stop())
+}
+
+func TestBLEMonitor(t *testing.T) {
+	adapter := &fakeAdapter{devices: []*Device{{Address: "AA:BB:CC:DD:EE:FF"}}}
+	m := &Monitor{adapter: adapter}
+	require.Error(t, m.Configure("baudrate", "9600"))
+
+	_, err := m.Open("11:22:33:44:55:66")
+	require.Error(t, err)
+	port, err := m.Open("AA:BB:CC:DD:EE:FF")
+	require.NoError(t, err)
+	_, err = m.Open("AA:BB:CC:DD:EE:FF")
+	require.Error(t, err)
+
+	// The data is split in packets fitting the MTU
+	n, err := port.Write([]byte("hello world"))
+	require.NoError(t, err)
+	require.Equal(t, 11, n)
+	require.Equal(t, [][]byte{[]byte("hell"), []byte("o wo"), []byte("rld")}, adapter.written)
+
+	buf := make([]byte, 20)
+	n, err = port.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(buf[:n]))
+
+	require.NoError(t, m.Close())
+	_, err = port.Read(buf)
+	require.ErrorIs(t, err, io.EOF)
+	require.Error(t, m.Close())
+}
@@ -0,0 +1,41 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+//go:build !(linux || windows || (darwin && cgo))
+
+package ble
+
+import "errors"
+
+var errUnsupported = errors.New("the Bluetooth stack of this platform is not supported")
+
+// unsupportedAdapter is used on the platforms without a supported Bluetooth stack
+type unsupportedAdapter struct{}
+
+func newNativeAdapter() adapter {
+	return &unsupportedAdapter{}
+}
+
+func (a *unsupportedAdapter) scan(found func(*Device)) error {
+	return errUnsupported
+}
+
+func (a *unsupportedAdapter) stopScan() error {
+	return nil
+}
+
+func (a *unsupportedAdapter) connect(address string, received func([]byte)) (uartConnection, error) {
+	return nil, errUnsupported
+}
@@ -16,27 +16,23 @@
 package builder
 
 import (
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
 	"github.com/arduino/go-paths-helper"
 )
 
 // ArchiveCompiledFiles fixdoc
 func (b *Builder) archiveCompiledFiles(archiveFilePath *paths.Path, objectFilesToArchive paths.PathList) (*paths.Path, error) {
 	if b.onlyUpdateCompilationDatabase {
-		if b.logger.Verbose() {
-			b.logger.Info(tr("Skipping archive creation of: %[1]s", archiveFilePath))
-		}
+		b.logger.VerboseInfo(tr("Skipping archive creation of: %[1]s", archiveFilePath))
 		return archiveFilePath, nil
 	}
 
-	if archiveFileStat, err := archiveFilePath.Stat(); err == nil {
-		rebuildArchive := false
-		for _, objectFile := range objectFilesToArchive {
-			objectFileStat, err := objectFile.Stat()
-			if err != nil || objectFileStat.ModTime().After(archiveFileStat.ModTime()) {
-				// need to rebuild the archive
-				rebuildArchive = true
-				break
-			}
+	sumsFile := utils.SumsFilePath(archiveFilePath)
+	if archiveFilePath.Exist() {
+		// rebuild the archive if the archived objects are changed
+		rebuildArchive := true
+		if sums, err := utils.ReadFileSums(sumsFile); err == nil {
+			rebuildArchive = !sums.Match(append(objectFilesToArchive.Clone(), archiveFilePath))
 		}
 
 		// something changed, rebuild the core archive
@@ -45,9 +41,7 @@ func (b *Builder) archiveCompiledFiles(archiveFilePath *paths.Path, objectFilesT
 				return nil, err
 			}
 		} else {
-			if b.logger.Verbose() {
-				b.logger.Info(tr("Using previously compiled file: %[1]s", archiveFilePath))
-			}
+			b.logger.VerboseInfo(tr("Using previously compiled file: %[1]s", archiveFilePath))
 			return archiveFilePath, nil
 		}
 	}
@@ -63,10 +57,21 @@ func (b *Builder) archiveCompiledFiles(archiveFilePath *paths.Path, objectFilesT
 			return nil, err
 		}
 
-		if err := b.execCommand(command); err != nil {
+		if err := b.execCommand(command, "archive "+archiveFilePath.Base()); err != nil {
 			return nil, err
 		}
 	}
 
+	if !archiveFilePath.Exist() {
+		// nothing has been archived
+		return archiveFilePath, nil
+	}
+	sums, err := utils.SumFiles(append(objectFilesToArchive.Clone(), archiveFilePath))
+	if err != nil {
+		return nil, err
+	}
+	if err := sums.WriteFile(sumsFile); err != nil {
+		return nil, err
+	}
 	return archiveFilePath, nil
 }
@@ -99,19 +99,47 @@ func (b *Builder) createBuildOptionsJSON() error {
 	if err != nil {
 		return err
 	}
-	return b.buildOptions.buildPath.Join("build.options.json").WriteFile(buildOptionsJSON)
+	if err := b.buildOptions.buildPath.Join("build.options.json").WriteFile(buildOptionsJSON); err != nil {
+		return err
+	}
+	return utils.WriteDirContentSums(b.buildOptions.coreFolders(), b.buildOptionsSumsFile(), ".txt")
+}
+
+// buildOptionsSumsFile returns the file with the fingerprints of the
+// configuration files of the core folders used in the previous build
+func (b *Builder) buildOptionsSumsFile() *paths.Path {
+	return utils.SumsFilePath(b.buildOptions.buildPath.Join("build.options.json"))
+}
+
+// coreFolders returns the folders of the platforms used in the build
+func (o *buildOptions) coreFolders() paths.PathList {
+	realCoreFolder := o.buildCorePath.Parent().Parent()
+	folders := paths.NewPathList(realCoreFolder.String())
+	if o.runtimePlatformPath != nil && !realCoreFolder.EqualsTo(o.runtimePlatformPath) {
+		folders.Add(o.runtimePlatformPath)
+	}
+	return folders
 }
 
 func (b *Builder) wipeBuildPath() error {
 	// FIXME: this should go outside legacy and behind a `logrus` call so users can
 	// control when this should be printed.
 	// logger.Println(constants.LOG_LEVEL_INFO, constants.MSG_BUILD_OPTIONS_CHANGED + constants.MSG_REBUILD_ALL)
-	if err := b.buildOptions.buildPath.RemoveAll(); err != nil {
+	if err := b.buildOptions.buildPath.MkdirAll(); err != nil {
 		return fmt.Errorf("%s: %w", tr("cleaning build path"), err)
 	}
-	if err := b.buildOptions.buildPath.MkdirAll(); err != nil {
+	files, err := b.buildOptions.buildPath.ReadDir()
+	if err != nil {
 		return fmt.Errorf("%s: %w", tr("cleaning build path"), err)
 	}
+	for _, file := range files {
+		if file.Base() == BuildLogsDirName {
+			continue
+		}
+		if err := file.RemoveAll(); err != nil {
+			return fmt.Errorf("%s: %w", tr("cleaning build path"), err)
+		}
+	}
 	return nil
 }
 
@@ -153,14 +181,7 @@ func (b *Builder) wipeBuildPathIfBuildOptionsChanged() error {
 		// check if any of the files contained in the core folders has changed
 		// since the json was generated - like platform.txt or similar
 		// if so, trigger a "safety" wipe
-		targetCoreFolder := b.buildOptions.runtimePlatformPath
-		coreFolder := b.buildOptions.buildCorePath
-		realCoreFolder := coreFolder.Parent().Parent()
-		jsonPath := b.buildOptions.buildPath.Join("build.options.json")
-		coreUnchanged, _ := utils.DirContentIsOlderThan(realCoreFolder, jsonPath, ".txt")
-		if coreUnchanged && targetCoreFolder != nil && !realCoreFolder.EqualsTo(targetCoreFolder) {
-			coreUnchanged, _ = utils.DirContentIsOlderThan(targetCoreFolder, jsonPath, ".txt")
-		}
+		coreUnchanged := utils.DirContentMatches(b.buildOptions.coreFolders(), b.buildOptionsSumsFile(), ".txt")
 		if coreUnchanged {
 			return nil
 		}
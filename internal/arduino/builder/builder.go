@@ -21,7 +21,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/compilation"
 	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/detector"
@@ -32,15 +34,26 @@ import (
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesmanager"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesresolver"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/arduino/go-paths-helper"
 	"github.com/arduino/go-properties-orderedmap"
 )
 
+// BuildLogsDirName is the directory, inside the build path, containing the logs
+// of the last builds. It's kept when the build path is cleaned.
+const BuildLogsDirName = "logs"
+
 // ErrSketchCannotBeLocatedInBuildPath fixdoc
 var ErrSketchCannotBeLocatedInBuildPath = errors.New("sketch cannot be located in build path")
 
+// MissingIncludeError is returned when a header file included by the sketch
+// or by a library can't be found in the platform or in any of the installed
+// libraries.
+type MissingIncludeError = detector.MissingIncludeError
+
 // Builder is a Sketch builder.
 type Builder struct {
 	sketch          *sketch.Sketch
@@ -59,6 +72,8 @@ type Builder struct {
 
 	// core related
 	coreBuildCachePath *paths.Path
+	// libraries objects shared between sketches
+	librariesBuildCachePath *paths.Path
 
 	logger *logger.BuilderLogger
 	clean  bool
@@ -68,8 +83,14 @@ type Builder struct {
 	// The keys of the map are paths relative to sketch folder.
 	sourceOverrides map[string]string
 
+	// Secrets injected at build time (name -> value map), they are never
+	// written in the sketch folder.
+	secrets map[string]string
+
 	// Set to true to skip build and produce only Compilation Database
 	onlyUpdateCompilationDatabase bool
+	// Part of the sketch to build
+	target BuildTarget
 	// Compilation Database to build/update
 	compilationDatabase *compilation.Database
 
@@ -94,6 +115,27 @@ type Builder struct {
 	toolEnv []string
 
 	diagnosticStore *diagnostics.Store
+
+	// Policy to fail the build on compiler warnings, nil if disabled
+	warningsAsErrors *WarningsAsErrors
+
+	// Policy to continue the build after a file fails to compile, nil if disabled
+	keepGoing   *KeepGoing
+	failedFiles int
+	failedMux   sync.Mutex
+
+	// Asks the compiler the fix-it hints of the diagnostics
+	parseableFixIts bool
+
+	// Information stripped from the build artifacts, nil if disabled
+	strip        *StripOptions
+	strippedDirs []*strippedDir
+
+	// Archive where the executable is saved with the debug information, nil if disabled
+	symbolsArchive *SymbolsArchive
+
+	// True if the variant of the board is overridden by the sketch project file
+	variantOverridden bool
 }
 
 // buildArtifacts contains the result of various build
@@ -109,13 +151,15 @@ type buildArtifacts struct {
 	sketchObjectFiles paths.PathList
 }
 
-// NewBuilder creates a sketch Builder.
+// NewBuilder creates a sketch Builder. The libraries are loaded from the
+// libraries directories, unless already loaded with LoadLibraries.
 func NewBuilder(
 	sk *sketch.Sketch,
 	boardBuildProperties *properties.Map,
 	buildPath *paths.Path,
 	optimizeForDebug bool,
 	coreBuildCachePath *paths.Path,
+	librariesBuildCachePath *paths.Path,
 	jobs int,
 	requestBuildProperties []string,
 	hardwareDirs, otherLibrariesDirs paths.PathList,
@@ -123,12 +167,21 @@ func NewBuilder(
 	fqbn *cores.FQBN,
 	clean bool,
 	sourceOverrides map[string]string,
+	secrets map[string]string,
 	onlyUpdateCompilationDatabase bool,
+	target BuildTarget,
 	targetPlatform, actualPlatform *cores.PlatformRelease,
 	useCachedLibrariesResolution bool,
 	librariesManager *librariesmanager.LibrariesManager,
+	loadedLibraries *LoadedLibraries,
 	libraryDirs paths.PathList,
 	stdout, stderr io.Writer, verbose bool, warningsLevel string,
+	verboseLog io.Writer,
+	warningsAsErrors *WarningsAsErrors,
+	keepGoing *KeepGoing,
+	parseableFixIts bool,
+	strip *StripOptions,
+	symbolsArchive *SymbolsArchive,
 	progresCB rpc.TaskProgressCB,
 	toolEnv []string,
 ) (*Builder, error) {
@@ -163,6 +216,51 @@ func NewBuilder(
 	}
 	buildProperties.Merge(customBuildProperties)
 	customBuildPropertiesArgs := append(requestBuildProperties, "build.warn_data_percentage=75")
+	if warningsAsErrors != nil {
+		customBuildPropertiesArgs = append(customBuildPropertiesArgs, warningsAsErrors.buildOption())
+	}
+	if symbolsArchive != nil {
+		customBuildPropertiesArgs = append(customBuildPropertiesArgs, symbolsArchive.buildOption())
+		applyBuildUUIDFlags(buildProperties)
+	}
+	var stripped []*strippedDir
+	if strip != nil {
+		customBuildPropertiesArgs = append(customBuildPropertiesArgs, strip.buildOption())
+		libraryDirsToStrip := paths.NewPathList()
+		libraryDirsToStrip.AddAll(libraryDirs)
+		libraryDirsToStrip.AddAll(otherLibrariesDirs)
+		if builtInLibrariesDirs != nil {
+			libraryDirsToStrip.Add(builtInLibrariesDirs)
+		}
+		stripped = strippedDirs(sk, buildPath, hardwareDirs, libraryDirsToStrip, coreBuildCachePath, librariesBuildCachePath)
+		if strip.Paths {
+			applyStripPathsFlags(buildProperties, stripped)
+		}
+	}
+	if sk != nil && sk.Project != nil && sk.GetBuildVariant() != "" {
+		variantPath, err := overrideBuildVariant(buildProperties, sk)
+		if err != nil {
+			return nil, err
+		}
+		customBuildPropertiesArgs = append(customBuildPropertiesArgs, "build_variant="+variantPath.String())
+	}
+	if sk != nil && sk.Project != nil {
+		if err := applyCustomLinkerScripts(buildProperties, sk); err != nil {
+			return nil, err
+		}
+	}
+	// Changing the flags of a library triggers a rebuild
+	if sk != nil && sk.Project != nil {
+		libraryNames := make([]string, 0, len(sk.Project.LibrariesBuildProperties))
+		for name := range sk.Project.LibrariesBuildProperties {
+			libraryNames = append(libraryNames, name)
+		}
+		sort.Strings(libraryNames)
+		for _, name := range libraryNames {
+			customBuildPropertiesArgs = append(customBuildPropertiesArgs,
+				fmt.Sprintf("libraries_build_properties.%s=%s", name, sk.Project.LibrariesBuildProperties[name]))
+		}
+	}
 
 	sketchBuildPath, err := buildPath.Join("sketch").Abs()
 	if err != nil {
@@ -182,17 +280,23 @@ func NewBuilder(
 	}
 
 	logger := logger.New(stdout, stderr, verbose, warningsLevel)
-	libsManager, libsResolver, verboseOut, err := detector.LibrariesLoader(
-		useCachedLibrariesResolution, librariesManager,
-		builtInLibrariesDirs, libraryDirs, otherLibrariesDirs,
-		actualPlatform, targetPlatform,
-	)
-	if err != nil {
-		return nil, err
-	}
-	if logger.Verbose() {
-		logger.Warn(string(verboseOut))
+	logger.SetVerboseLog(verboseLog)
+	var libsManager *librariesmanager.LibrariesManager
+	var libsResolver *librariesresolver.Cpp
+	var verboseOut []byte
+	if loadedLibraries != nil && !useCachedLibrariesResolution && librariesManager == nil {
+		libsManager, libsResolver, verboseOut = loadedLibraries.manager, loadedLibraries.resolver, loadedLibraries.warnings
+	} else {
+		libsManager, libsResolver, verboseOut, err = detector.LibrariesLoader(
+			useCachedLibrariesResolution, librariesManager,
+			builtInLibrariesDirs, libraryDirs, otherLibrariesDirs,
+			actualPlatform, targetPlatform,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
+	logger.VerboseWarn(string(verboseOut))
 
 	diagnosticStore := diagnostics.NewStore()
 	b := &Builder{
@@ -205,10 +309,13 @@ func NewBuilder(
 		jobs:                          jobs,
 		customBuildProperties:         customBuildPropertiesArgs,
 		coreBuildCachePath:            coreBuildCachePath,
+		librariesBuildCachePath:       librariesBuildCachePath,
 		logger:                        logger,
 		clean:                         clean,
 		sourceOverrides:               sourceOverrides,
+		secrets:                       secrets,
 		onlyUpdateCompilationDatabase: onlyUpdateCompilationDatabase,
+		target:                        target,
 		compilationDatabase:           compilation.NewDatabase(buildPath.Join("compile_commands.json")),
 		Progress:                      progress.New(progresCB),
 		executableSectionsSize:        []ExecutableSectionSize{},
@@ -216,6 +323,13 @@ func NewBuilder(
 		targetPlatform:                targetPlatform,
 		actualPlatform:                actualPlatform,
 		toolEnv:                       toolEnv,
+		warningsAsErrors:              warningsAsErrors,
+		keepGoing:                     keepGoing,
+		parseableFixIts:               parseableFixIts,
+		strip:                         strip,
+		strippedDirs:                  stripped,
+		symbolsArchive:                symbolsArchive,
+		variantOverridden:             sk != nil && sk.Project != nil && sk.GetBuildVariant() != "",
 		buildOptions: newBuildOptions(
 			hardwareDirs, otherLibrariesDirs,
 			builtInLibrariesDirs, buildPath,
@@ -268,6 +382,7 @@ func (b *Builder) CompilerDiagnostics() diagnostics.Diagnostics {
 func (b *Builder) Preprocess() ([]byte, error) {
 	b.Progress.AddSubSteps(6)
 	defer b.Progress.RemoveSubSteps()
+	defer b.removeSecrets()
 
 	if err := b.preprocess(); err != nil {
 		return nil, err
@@ -278,6 +393,8 @@ func (b *Builder) Preprocess() ([]byte, error) {
 	return preprocessedSketch, err
 }
 
+// preprocess prepares the sketch build folder and detects the used libraries,
+// the callers must remove the generated secrets with removeSecrets once done.
 func (b *Builder) preprocess() error {
 	if err := b.buildPath.MkdirAll(); err != nil {
 		return err
@@ -299,6 +416,9 @@ func (b *Builder) preprocess() error {
 	if err := b.prepareSketchBuildPath(); err != nil {
 		return err
 	}
+	if err := b.generateSecretsHeader(); err != nil {
+		return err
+	}
 	b.Progress.CompleteStep()
 
 	b.logIfVerbose(false, tr("Detecting libraries used..."))
@@ -330,26 +450,29 @@ func (b *Builder) preprocess() error {
 }
 
 func (b *Builder) logIfVerbose(warn bool, msg string) {
-	if !b.logger.Verbose() {
-		return
-	}
 	if warn {
-		b.logger.Warn(msg)
+		b.logger.VerboseWarn(msg)
 		return
 	}
-	b.logger.Info(msg)
+	b.logger.VerboseInfo(msg)
 }
 
 // Build fixdoc
 func (b *Builder) Build() error {
-	b.Progress.AddSubSteps(6 /** preprocess **/ + 21 /** build **/)
+	b.Progress.AddSubSteps(6 /** preprocess **/ + 23 /** build **/)
 	defer b.Progress.RemoveSubSteps()
+	defer b.removeSecrets()
 
 	if err := b.preprocess(); err != nil {
 		return err
 	}
 
-	buildErr := b.build()
+	var buildErr error
+	if b.target.IsPartial() {
+		buildErr = b.buildTarget()
+	} else {
+		buildErr = b.build()
+	}
 
 	b.libsDetector.PrintUsedAndNotUsedLibraries(buildErr != nil)
 	b.Progress.CompleteStep()
@@ -362,6 +485,10 @@ func (b *Builder) Build() error {
 	}
 	b.Progress.CompleteStep()
 
+	// A partial build has no executable to measure
+	if b.target.IsPartial() {
+		return nil
+	}
 	if err := b.size(); err != nil {
 		return err
 	}
@@ -378,13 +505,18 @@ func (b *Builder) build() error {
 	}
 	b.Progress.CompleteStep()
 
-	if err := b.buildSketch(b.libsDetector.IncludeFolders()); err != nil {
-		return err
+	// With the KeepGoing policy the libraries and the core are compiled even
+	// if the sketch fails to compile, and the build stops before linking
+	sketchErr := b.buildSketch(b.libsDetector.IncludeFolders())
+	if sketchErr != nil && !b.keepGoingAfterErrors() {
+		return b.compileFailed(sketchErr)
 	}
 	b.Progress.CompleteStep()
 
-	if err := b.RunRecipe("recipe.hooks.sketch.postbuild", ".pattern", true); err != nil {
-		return err
+	if sketchErr == nil {
+		if err := b.RunRecipe("recipe.hooks.sketch.postbuild", ".pattern", true); err != nil {
+			return err
+		}
 	}
 	b.Progress.CompleteStep()
 
@@ -399,13 +531,16 @@ func (b *Builder) build() error {
 	}
 	b.Progress.CompleteStep()
 
-	if err := b.buildLibraries(b.libsDetector.IncludeFolders(), b.libsDetector.ImportedLibraries()); err != nil {
-		return err
+	librariesErr := b.buildLibraries(b.libsDetector.IncludeFolders(), b.libsDetector.ImportedLibraries())
+	if librariesErr != nil && !b.keepGoingAfterErrors() {
+		return b.compileFailed(librariesErr)
 	}
 	b.Progress.CompleteStep()
 
-	if err := b.RunRecipe("recipe.hooks.libraries.postbuild", ".pattern", true); err != nil {
-		return err
+	if librariesErr == nil {
+		if err := b.RunRecipe("recipe.hooks.libraries.postbuild", ".pattern", true); err != nil {
+			return err
+		}
 	}
 	b.Progress.CompleteStep()
 
@@ -415,16 +550,23 @@ func (b *Builder) build() error {
 	}
 	b.Progress.CompleteStep()
 
-	if err := b.buildCore(); err != nil {
-		return err
+	coreErr := b.buildCore()
+	if coreErr != nil && !b.keepGoingAfterErrors() {
+		return b.compileFailed(coreErr)
 	}
 	b.Progress.CompleteStep()
 
-	if err := b.RunRecipe("recipe.hooks.core.postbuild", ".pattern", true); err != nil {
-		return err
+	if coreErr == nil {
+		if err := b.RunRecipe("recipe.hooks.core.postbuild", ".pattern", true); err != nil {
+			return err
+		}
 	}
 	b.Progress.CompleteStep()
 
+	if err := b.compileFailed(sketchErr, librariesErr, coreErr); err != nil {
+		return err
+	}
+
 	b.logIfVerbose(false, tr("Linking everything together..."))
 	if err := b.RunRecipe("recipe.hooks.linking.prelink", ".pattern", false); err != nil {
 		return err
@@ -441,6 +583,14 @@ func (b *Builder) build() error {
 	}
 	b.Progress.CompleteStep()
 
+	if err := b.archiveSymbols(); err != nil {
+		return err
+	}
+
+	if err := b.stripExecutable(); err != nil {
+		return err
+	}
+
 	if err := b.RunRecipe("recipe.hooks.objcopy.preobjcopy", ".pattern", false); err != nil {
 		return err
 	}
@@ -461,11 +611,25 @@ func (b *Builder) build() error {
 	}
 	b.Progress.CompleteStep()
 
+	if err := b.mergeImages(); err != nil {
+		return err
+	}
+	b.Progress.CompleteStep()
+
+	if err := b.runSecretsRecipe(); err != nil {
+		return err
+	}
+	b.Progress.CompleteStep()
+
 	if err := b.RunRecipe("recipe.hooks.postbuild", ".pattern", true); err != nil {
 		return err
 	}
 	b.Progress.CompleteStep()
 
+	if err := b.checkStrippedPaths(); err != nil {
+		return err
+	}
+
 	if b.compilationDatabase != nil {
 		b.compilationDatabase.SaveToFile()
 	}
@@ -473,9 +637,20 @@ func (b *Builder) build() error {
 }
 
 func (b *Builder) prepareCommandForRecipe(buildProperties *properties.Map, recipe string, removeUnsetProperties bool) (*paths.Process, error) {
+	parts, dir, err := b.prepareCommandLineForRecipe(buildProperties, recipe, removeUnsetProperties)
+	if err != nil {
+		return nil, err
+	}
+	return b.newRecipeCommand(buildProperties, parts, dir, true)
+}
+
+// prepareCommandLineForRecipe returns the arguments of the command of the
+// recipe and the directory where it must be run, without the rewrites needed
+// only to run it (extended-length paths and response files).
+func (b *Builder) prepareCommandLineForRecipe(buildProperties *properties.Map, recipe string, removeUnsetProperties bool) ([]string, string, error) {
 	pattern := buildProperties.Get(recipe)
 	if pattern == "" {
-		return nil, fmt.Errorf(tr("%[1]s pattern is missing"), recipe)
+		return nil, "", fmt.Errorf(tr("%[1]s pattern is missing"), recipe)
 	}
 
 	commandLine := buildProperties.ExpandPropsInString(pattern)
@@ -485,7 +660,7 @@ func (b *Builder) prepareCommandForRecipe(buildProperties *properties.Map, recip
 
 	parts, err := properties.SplitQuotedString(commandLine, `"'`, false)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// if the overall commandline is too long for the platform
@@ -504,24 +679,48 @@ func (b *Builder) prepareCommandForRecipe(buildProperties *properties.Map, recip
 			}
 		}
 	}
+	return parts, relativePath, nil
+}
 
-	command, err := paths.NewProcess(b.toolEnv, parts...)
+// newRecipeCommand creates the process running the given command line in dir.
+// If toExecute is true the paths are converted to extended-length paths and,
+// if enabled, the arguments are moved to a response file; the command lines
+// stored for other tools (like the compilation database) must not be
+// rewritten, since the response files are temporary.
+func (b *Builder) newRecipeCommand(buildProperties *properties.Map, parts []string, dir string, toExecute bool) (*paths.Process, error) {
+	if toExecute {
+		parts = utils.ExtendedLengthArgs(parts)
+
+		// if the commandline is still too long use a response file
+		if utils.ResponseFilesEnabled(buildProperties) {
+			var err error
+			parts, err = utils.UseResponseFile(parts, utils.ResponseFilesDir(buildProperties))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	toolEnv := append(append([]string{}, b.toolEnv...), toolenv.PropertiesEnv(buildProperties)...)
+	command, err := toolenv.NewProcess(toolEnv, parts...)
 	if err != nil {
 		return nil, err
 	}
-	if relativePath != "" {
-		command.SetDir(relativePath)
+	if dir != "" {
+		command.SetDir(dir)
 	}
 
 	return command, nil
 }
 
-func (b *Builder) execCommand(command *paths.Process) error {
-	if b.logger.Verbose() {
-		b.logger.Info(utils.PrintableCommand(command.GetArgs()))
-		command.RedirectStdoutTo(b.logger.Stdout())
-	}
-	command.RedirectStderrTo(b.logger.Stderr())
+// execCommand runs the command of the given phase of the build, its output is
+// attributed to the tool and the phase as described in logger.ToolOutput.
+func (b *Builder) execCommand(command *paths.Process, phase string) error {
+	stdout, stderr, flush := b.logger.ToolWriters(toolenv.ToolName(command.GetArgs()[0]), phase)
+	defer flush()
+	b.logger.VerboseInfo(utils.PrintableCommand(command.GetArgs()))
+	command.RedirectStdoutTo(stdout)
+	command.RedirectStderrTo(stderr)
 
 	if err := command.Start(); err != nil {
 		return err
@@ -0,0 +1,349 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/cpp"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/detector"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/diagnostics"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/logger"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareCommandForRecipePaths(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	sourceFile := tmp.Join("Bob's sketches", "Schön Sketch", "Schön Sketch.ino.cpp")
+	objectFile := tmp.Join("build dir", "sketch", "Schön Sketch.ino.cpp.o")
+	includeDir := tmp.Join("libraries", "My Library (fork)", "src")
+	longFile := tmp.Join(strings.Repeat("a", 200), strings.Repeat("b", 100), "file.cpp")
+
+	props := properties.NewMap()
+	props.Set("recipe.cpp.o.pattern", `"{compiler.path}g++" -c {includes} "{source_file}" -o "{object_file}" "{long_file}"`)
+	props.Set("compiler.path", "/opt/tool chain/bin/")
+	props.Set("includes", cpp.WrapWithHyphenI(includeDir.String()))
+	props.SetPath("source_file", sourceFile)
+	props.SetPath("object_file", objectFile)
+	props.SetPath("long_file", longFile)
+
+	b := &Builder{}
+	command, err := b.prepareCommandForRecipe(props, "recipe.cpp.o.pattern", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/opt/tool chain/bin/g++",
+		"-c",
+		"-I" + includeDir.String(),
+		sourceFile.String(),
+		"-o",
+		objectFile.String(),
+		longFile.String(),
+	}, command.GetArgs())
+}
+
+func TestNewRecipeCommandForCompilationDatabase(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	props := properties.NewMap()
+	props.SetPath("build.path", tmp)
+	parts := []string{"g++", "-c"}
+	for i := 0; i < 2000; i++ {
+		parts = append(parts, "-I"+tmp.Join("libraries", fmt.Sprintf("Library%d_%s", i, strings.Repeat("x", 50)), "src").String())
+	}
+
+	b := &Builder{}
+	// The command line stored in the compilation database is not rewritten
+	dbCommand, err := b.newRecipeCommand(props, parts, "", false)
+	require.NoError(t, err)
+	require.Equal(t, parts, dbCommand.GetArgs())
+	require.True(t, tmp.Join("rsp").NotExist())
+
+	// while the executed one uses a response file
+	command, err := b.newRecipeCommand(props, parts, "", true)
+	require.NoError(t, err)
+	require.Len(t, command.GetArgs(), 2)
+	require.True(t, strings.HasPrefix(command.GetArgs()[1], "@"))
+}
+
+func TestObjdumpBuildProperties(t *testing.T) {
+	props := properties.NewMap()
+	props.Set("compiler.path", "/opt/avr/bin/")
+	props.Set("compiler.c.cmd", "avr-gcc")
+	res, err := objdumpBuildProperties(props)
+	require.NoError(t, err)
+	res.Set("object_file", "/build/sketch/Blink.ino.cpp.o")
+	require.Equal(t, `"/opt/avr/bin/avr-objdump" -d -S -C "/build/sketch/Blink.ino.cpp.o"`, res.ExpandPropsInString(res.Get("recipe.objdump.pattern")))
+	require.False(t, props.ContainsKey("recipe.objdump.pattern"))
+
+	props.Set("compiler.objdump.flags", "-d")
+	props.Set("compiler.objdump.cmd", "llvm-objdump")
+	res, err = objdumpBuildProperties(props)
+	require.NoError(t, err)
+	require.Equal(t, `"{compiler.path}{compiler.objdump.cmd}" {compiler.objdump.flags} "{object_file}"`, res.Get("recipe.objdump.pattern"))
+	require.Equal(t, "llvm-objdump", res.Get("compiler.objdump.cmd"))
+	require.Equal(t, "-d", res.Get("compiler.objdump.flags"))
+
+	props.Set("recipe.objdump.pattern", `"{compiler.path}dump" "{object_file}"`)
+	res, err = objdumpBuildProperties(props)
+	require.NoError(t, err)
+	require.Equal(t, `"{compiler.path}dump" "{object_file}"`, res.Get("recipe.objdump.pattern"))
+
+	_, err = objdumpBuildProperties(properties.NewMap())
+	require.Error(t, err)
+}
+
+func TestCheckWarningsAsErrors(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	b := &Builder{
+		sketch:             &sketch.Sketch{FullPath: tmp.Join("Sketch")},
+		sketchBuildPath:    tmp.Join("build", "sketch"),
+		librariesBuildPath: tmp.Join("build", "libraries"),
+		libsDetector:       detector.NewSketchLibrariesDetector(nil, nil, false, false, nil, nil),
+	}
+	sketchWarning := &diagnostics.Diagnostic{
+		Severity: diagnostics.SeverityWarning,
+		Message:  "unused variable 'x'\n    2 | int x;",
+		File:     tmp.Join("Sketch", "Sketch.ino").String(),
+		Line:     2,
+	}
+	coreWarning := &diagnostics.Diagnostic{
+		Severity: diagnostics.SeverityWarning,
+		Message:  "unused variable 'y'",
+		File:     tmp.Join("hardware", "core", "main.cpp").String(),
+		Line:     3,
+	}
+
+	// Disabled
+	require.NoError(t, b.checkWarningsAsErrors(diagnostics.Diagnostics{sketchWarning}))
+
+	b.warningsAsErrors = &WarningsAsErrors{}
+	require.NoError(t, b.checkWarningsAsErrors(diagnostics.Diagnostics{coreWarning}))
+	err := b.checkWarningsAsErrors(diagnostics.Diagnostics{coreWarning, sketchWarning})
+	require.EqualError(t, err, sketchWarning.File+":2: warning treated as error: unused variable 'x'")
+}
+
+func TestWarningsAsErrorsExemptLibraries(t *testing.T) {
+	w := &WarningsAsErrors{ExemptLibraries: []string{"Adafruit GFX Library", "fastled"}}
+	require.True(t, w.isExempt(&libraries.Library{Name: "Adafruit GFX Library", DirName: "Adafruit_GFX_Library"}))
+	require.True(t, w.isExempt(&libraries.Library{Name: "FastLED", DirName: "FastLED"}))
+	require.False(t, w.isExempt(&libraries.Library{Name: "MyLib", DirName: "MyLib"}))
+	require.Equal(t, "build.warnings_as_errors=Adafruit GFX Library,fastled", w.buildOption())
+}
+
+func TestOverrideBuildVariant(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	sketchPath := tmp.Join("Sketch")
+	platformPath := tmp.Join("platform")
+	require.NoError(t, sketchPath.Join("pinout").MkdirAll())
+	require.NoError(t, platformPath.Join("variants", "standard").MkdirAll())
+	require.NoError(t, platformPath.Join("variants", "mega").MkdirAll())
+
+	newProps := func() *properties.Map {
+		props := properties.NewMap()
+		props.SetPath("runtime.platform.path", platformPath)
+		props.Set("build.variant", "standard")
+		props.SetPath("build.variant.path", platformPath.Join("variants", "standard"))
+		return props
+	}
+	sk := &sketch.Sketch{FullPath: sketchPath, Project: &sketch.Project{}}
+
+	// Variant folder inside the sketch
+	sk.Project.BuildVariant = "pinout"
+	props := newProps()
+	variantPath, err := overrideBuildVariant(props, sk)
+	require.NoError(t, err)
+	require.Equal(t, sketchPath.Join("pinout").String(), variantPath.String())
+	require.Equal(t, "pinout", props.Get("build.variant"))
+	require.Equal(t, sketchPath.Join("pinout").String(), props.Get("build.variant.path"))
+
+	// Another variant of the platform
+	sk.Project.BuildVariant = "mega"
+	props = newProps()
+	_, err = overrideBuildVariant(props, sk)
+	require.NoError(t, err)
+	require.Equal(t, "mega", props.Get("build.variant"))
+	require.Equal(t, platformPath.Join("variants", "mega").String(), props.Get("build.variant.path"))
+
+	sk.Project.BuildVariant = "missing"
+	_, err = overrideBuildVariant(newProps(), sk)
+	require.Error(t, err)
+}
+
+func TestApplyCustomLinkerScripts(t *testing.T) {
+	sketchPath := paths.New(t.TempDir())
+	require.NoError(t, sketchPath.Join("memory.ld").WriteFile([]byte{}))
+	require.NoError(t, sketchPath.Join("bootloader.ld").WriteFile([]byte{}))
+	sk := &sketch.Sketch{FullPath: sketchPath, Project: &sketch.Project{}}
+
+	newProps := func(recipe string) *properties.Map {
+		props := properties.NewMap()
+		props.Set("recipe.c.combine.pattern", recipe)
+		props.Set("compiler.c.elf.extra_flags", "-Wl,--relax")
+		return props
+	}
+
+	// Platform exposing {build.ldscript.path}
+	sk.Project.LinkerScript = "memory.ld"
+	props := newProps(`gcc "-T{build.ldscript.path}" -o "{build.path}/{build.project_name}.elf"`)
+	require.NoError(t, applyCustomLinkerScripts(props, sk))
+	require.Equal(t, sketchPath.Join("memory.ld").String(), props.Get("build.ldscript.path"))
+
+	// Platform using the linker script of the variant
+	props = newProps(`gcc "-T{build.variant.path}/{build.ldscript}" -o "{build.path}/{build.project_name}.elf"`)
+	require.NoError(t, applyCustomLinkerScripts(props, sk))
+	require.Equal(t, `gcc "-T{build.ldscript.path}" -o "{build.path}/{build.project_name}.elf"`, props.Get("recipe.c.combine.pattern"))
+	require.Equal(t, sketchPath.Join("memory.ld").String(), props.Get("build.ldscript.path"))
+
+	// Platform without the hook
+	props = newProps(`gcc -o "{build.path}/{build.project_name}.elf"`)
+	require.Error(t, applyCustomLinkerScripts(props, sk))
+
+	// Missing linker script
+	sk.Project.LinkerScript = "missing.ld"
+	props = newProps(`gcc "-T{build.ldscript.path}"`)
+	require.Error(t, applyCustomLinkerScripts(props, sk))
+
+	// Linker script fragments
+	sk.Project.LinkerScript = ""
+	sk.Project.LinkerScriptFragments = []string{"bootloader.ld"}
+	props = newProps(`gcc {compiler.c.elf.extra_flags} -o "{build.path}/{build.project_name}.elf"`)
+	require.NoError(t, applyCustomLinkerScripts(props, sk))
+	require.Equal(t, `-Wl,--relax "`+sketchPath.Join("bootloader.ld").String()+`"`, props.Get("compiler.c.elf.extra_flags"))
+
+	props = newProps(`gcc -o "{build.path}/{build.project_name}.elf"`)
+	require.Error(t, applyCustomLinkerScripts(props, sk))
+}
+
+func TestMergeImages(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	require.NoError(t, tmp.Join("boot.bin").WriteFile([]byte{1, 2}))
+	require.NoError(t, tmp.Join("app.bin").WriteFile([]byte{3, 4, 5}))
+	// A single data record with 2 bytes at 0x0008
+	require.NoError(t, tmp.Join("table.hex").WriteFile([]byte(":020008000607E9\n:00000001FF\n")))
+
+	props := properties.NewMap()
+	props.SetPath("build.path", tmp)
+	props.Set("build.merge.image.0.file", "{build.path}/app.bin")
+	props.Set("build.merge.image.0.offset", "0x10")
+	props.Set("build.merge.image.1.file", "{build.path}/boot.bin")
+	props.Set("build.merge.image.1.offset", "4")
+	props.Set("build.merge.image.2.file", "{build.path}/table.hex")
+	images, err := parseMergeImages(props)
+	require.NoError(t, err)
+	require.Len(t, images, 3)
+	require.Equal(t, tmp.Join("table.hex").String(), images[0].file.String())
+	require.Equal(t, tmp.Join("boot.bin").String(), images[1].file.String())
+	require.Equal(t, uint32(0x10), images[2].offset)
+
+	segments, err := loadMergeImagesSegments(images)
+	require.NoError(t, err)
+	data, err := mergeImagesSegments(segments, 4)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 0xFF, 0xFF, 6, 7, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 3, 4, 5}, data)
+
+	// Data before the base address
+	_, err = mergeImagesSegments(segments, 5)
+	require.Error(t, err)
+
+	// Overlapping images
+	props.Set("build.merge.image.0.offset", "0x5")
+	images, err = parseMergeImages(props)
+	require.NoError(t, err)
+	segments, err = loadMergeImagesSegments(images)
+	require.NoError(t, err)
+	_, err = mergeImagesSegments(segments, 0)
+	require.Error(t, err)
+
+	// Missing offset or file
+	props.Remove("build.merge.image.0.offset")
+	_, err = parseMergeImages(props)
+	require.Error(t, err)
+	props.Set("build.merge.image.0.offset", "0x10")
+	props.Set("build.merge.image.0.file", "{build.path}/missing.bin")
+	_, err = parseMergeImages(props)
+	require.Error(t, err)
+}
+
+func TestKeepGoing(t *testing.T) {
+	compileErr := errors.New("exit status 1")
+	b := &Builder{}
+	b.fileFailed()
+	require.False(t, b.keepGoingAfterErrors())
+	require.Equal(t, compileErr, b.compileFailed(nil, compileErr))
+	require.NoError(t, b.compileFailed(nil, nil))
+
+	b = &Builder{keepGoing: &KeepGoing{}}
+	require.False(t, b.keepGoingAfterErrors())
+	require.Equal(t, compileErr, b.compileFailed(compileErr))
+	b.fileFailed()
+	b.fileFailed()
+	require.True(t, b.keepGoingAfterErrors())
+	require.EqualError(t, b.compileFailed(nil, compileErr), "2 files failed to compile: exit status 1")
+
+	b.keepGoing.MaxErrors = 2
+	require.False(t, b.keepGoingAfterErrors())
+}
+
+func TestApplyFixIts(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	sketchFile := tmp.Join("Sketch", "Sketch.ino")
+	localLibFile := tmp.Join("MyLib", "MyLib.cpp")
+	installedLibFile := tmp.Join("libraries", "Servo", "Servo.cpp")
+	for _, file := range []*paths.Path{sketchFile, localLibFile, installedLibFile} {
+		require.NoError(t, file.Parent().MkdirAll())
+		require.NoError(t, file.WriteFile([]byte("dalay(1000)\n")))
+	}
+	stderr := &bytes.Buffer{}
+	b := &Builder{
+		sketch:          &sketch.Sketch{FullPath: tmp.Join("Sketch")},
+		buildPath:       tmp.Join("build"),
+		libsDetector:    detector.NewSketchLibrariesDetector(nil, nil, false, false, nil, nil),
+		diagnosticStore: diagnostics.NewStore(),
+		logger:          logger.New(io.Discard, stderr, false, ""),
+	}
+	b.libsDetector.AppendImportedLibraries(&libraries.Library{Name: "MyLib", InstallDir: tmp.Join("MyLib"), Location: libraries.Unmanaged})
+	b.libsDetector.AppendImportedLibraries(&libraries.Library{Name: "Servo", InstallDir: tmp.Join("libraries", "Servo"), Location: libraries.User})
+
+	output := ""
+	for _, file := range []*paths.Path{sketchFile, localLibFile, installedLibFile} {
+		output += fmt.Sprintf("%[1]s:1:1: error: 'dalay' was not declared in this scope\n"+
+			"fix-it:\"%[1]s\":{1:1-1:6}:\"delay\"\n"+
+			"%[1]s:1:12: error: expected ';' at end of input\n"+
+			"fix-it:\"%[1]s\":{1:12-1:12}:\";\"\n", file)
+	}
+	b.diagnosticStore.Parse([]string{"avr-g++"}, []byte(output))
+
+	applied, err := b.ApplyFixIts()
+	require.NoError(t, err)
+	require.Len(t, applied, 4)
+	for _, file := range []*paths.Path{sketchFile, localLibFile} {
+		data, err := file.ReadFile()
+		require.NoError(t, err)
+		require.Equal(t, "delay(1000);\n", string(data))
+	}
+	data, err := installedLibFile.ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "dalay(1000)\n", string(data))
+	require.Contains(t, stderr.String(), installedLibFile.String())
+}
@@ -22,9 +22,12 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/diagnostics"
 	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
 	"github.com/arduino/arduino-cli/internal/arduino/globals"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
 )
 
 func (b *Builder) compileFiles(
@@ -32,6 +35,7 @@ func (b *Builder) compileFiles(
 	buildPath *paths.Path,
 	recurse bool,
 	includes []string,
+	buildProperties *properties.Map,
 ) (paths.PathList, error) {
 	validExtensions := []string{}
 	for ext := range globals.SourceFilesValidExtensions {
@@ -56,12 +60,9 @@ func (b *Builder) compileFiles(
 
 	queue := make(chan *paths.Path)
 	job := func(source *paths.Path) {
-		recipe := fmt.Sprintf("recipe%s.o.pattern", source.Ext())
-		if !b.buildProperties.ContainsKey(recipe) {
-			recipe = fmt.Sprintf("recipe%s.o.pattern", globals.SourceFilesValidExtensions[source.Ext()])
-		}
-		objectFile, err := b.compileFileWithRecipe(sourceDir, source, buildPath, includes, recipe)
+		objectFile, err := b.compileFileWithRecipe(sourceDir, source, buildPath, includes, buildProperties, b.compileRecipe(source))
 		if err != nil {
+			b.fileFailed()
 			errorsMux.Lock()
 			errorsList = append(errorsList, err)
 			errorsMux.Unlock()
@@ -92,7 +93,7 @@ func (b *Builder) compileFiles(
 		errorsMux.Lock()
 		gotError := len(errorsList) > 0
 		errorsMux.Unlock()
-		if gotError {
+		if gotError && !b.keepGoingAfterErrors() {
 			break
 		}
 		queue <- source
@@ -109,16 +110,29 @@ func (b *Builder) compileFiles(
 	return objectFiles, nil
 }
 
+// compileRecipe returns the recipe used to compile the given source file
+func (b *Builder) compileRecipe(source *paths.Path) string {
+	recipe := fmt.Sprintf("recipe%s.o.pattern", source.Ext())
+	if !b.buildProperties.ContainsKey(recipe) {
+		recipe = fmt.Sprintf("recipe%s.o.pattern", globals.SourceFilesValidExtensions[source.Ext()])
+	}
+	return recipe
+}
+
 // CompileFilesRecursive fixdoc
 func (b *Builder) compileFileWithRecipe(
 	sourcePath *paths.Path,
 	source *paths.Path,
 	buildPath *paths.Path,
 	includes []string,
+	buildProperties *properties.Map,
 	recipe string,
 ) (*paths.Path, error) {
-	properties := b.buildProperties.Clone()
+	properties := buildProperties.Clone()
 	properties.Set("compiler.warning_flags", properties.Get("compiler.warning_flags."+b.logger.WarningsLevel()))
+	if b.parseableFixIts {
+		properties.Set("compiler.warning_flags", strings.TrimSpace(properties.Get("compiler.warning_flags")+" -fdiagnostics-parseable-fixits"))
+	}
 	properties.Set("includes", strings.Join(includes, " "))
 	properties.SetPath("source_file", source)
 	relativeSource, err := sourcePath.RelTo(source)
@@ -139,48 +153,62 @@ func (b *Builder) compileFileWithRecipe(
 		return nil, err
 	}
 
-	command, err := b.prepareCommandForRecipe(properties, recipe, false)
+	commandLine, commandDir, err := b.prepareCommandLineForRecipe(properties, recipe, false)
 	if err != nil {
 		return nil, err
 	}
 	if b.compilationDatabase != nil {
-		b.compilationDatabase.Add(source, command)
+		// The database stores the original command line, that can be used by
+		// other tools, instead of the one rewritten to be executed
+		dbCommand, err := b.newRecipeCommand(properties, commandLine, commandDir, false)
+		if err != nil {
+			return nil, err
+		}
+		b.compilationDatabase.Add(source, dbCommand)
 	}
 	if !objIsUpToDate && !b.onlyUpdateCompilationDatabase {
+		command, err := b.newRecipeCommand(properties, commandLine, commandDir, true)
+		if err != nil {
+			return nil, err
+		}
 		commandStdout, commandStderr := &bytes.Buffer{}, &bytes.Buffer{}
 		command.RedirectStdoutTo(commandStdout)
 		command.RedirectStderrTo(commandStderr)
 
-		if b.logger.Verbose() {
-			b.logger.Info(utils.PrintableCommand(command.GetArgs()))
-		}
+		b.logger.VerboseInfo(utils.PrintableCommand(command.GetArgs()))
 		// Since this compile could be multithreaded, we first capture the command output
 		if err := command.Start(); err != nil {
 			return nil, err
 		}
-		err := command.Wait()
+		err = command.Wait()
 		// and transfer all at once at the end...
-		if b.logger.Verbose() {
-			b.logger.WriteStdout(commandStdout.Bytes())
-		}
-		b.logger.WriteStderr(commandStderr.Bytes())
+		b.logger.ToolOutput(toolenv.ToolName(command.GetArgs()[0]), "compile "+relativeSource.String(), commandStdout.Bytes(), commandStderr.Bytes())
 
 		// Parse the output of the compiler to gather errors and warnings...
+		var diags diagnostics.Diagnostics
 		if b.diagnosticStore != nil {
-			b.diagnosticStore.Parse(command.GetArgs(), commandStdout.Bytes())
-			b.diagnosticStore.Parse(command.GetArgs(), commandStderr.Bytes())
+			diags = append(diags, b.diagnosticStore.Parse(command.GetArgs(), commandStdout.Bytes())...)
+			diags = append(diags, b.diagnosticStore.Parse(command.GetArgs(), commandStderr.Bytes())...)
 		}
 
 		// ...and then return the error
 		if err != nil {
 			return nil, err
 		}
-	} else if b.logger.Verbose() {
-		if objIsUpToDate {
-			b.logger.Info(tr("Using previously compiled file: %[1]s", objectFile))
-		} else {
-			b.logger.Info(tr("Skipping compile of: %[1]s", objectFile))
+		if err := b.checkWarningsAsErrors(diags); err != nil {
+			// Remove the object file, so the source file is compiled again
+			// (and the warnings reported again) by the next build
+			objectFile.Remove()
+			return nil, err
+		}
+
+		if err := utils.WriteObjFileSums(source, objectFile, depsFile); err != nil {
+			return nil, err
 		}
+	} else if objIsUpToDate {
+		b.logger.VerboseInfo(tr("Using previously compiled file: %[1]s", objectFile))
+	} else {
+		b.logger.VerboseInfo(tr("Skipping compile of: %[1]s", objectFile))
 	}
 
 	return objectFile, nil
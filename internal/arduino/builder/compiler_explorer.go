@@ -0,0 +1,212 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/preprocessor"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// compilerExplorerShortenerURL is the Compiler Explorer API that stores a
+// session and returns its short link: the translation unit of a sketch is
+// too big to be encoded in the link itself.
+var compilerExplorerShortenerURL = "https://godbolt.org/api/shortener"
+
+// compilerExplorerCompilers maps the C++ compilers of the toolchains to the
+// Compiler Explorer compilers, the platforms may set a different compiler
+// with the `compiler_explorer.compiler` build property.
+var compilerExplorerCompilers = map[string]string{
+	"avr-g++": "avrg1230",
+}
+
+// gccLineMarker matches the line markers added by the gcc preprocessor
+var gccLineMarker = regexp.MustCompile(`^#\s+\d+\s+"`)
+
+// CompilerExplorerSession is a Compiler Explorer session reproducing the
+// compilation of the sketch
+type CompilerExplorerSession struct {
+	Compiler string
+	Flags    []string
+	Source   string
+}
+
+// CompilerExplorerSession preprocesses the sketch and returns a Compiler
+// Explorer session with the resulting translation unit and the flags used
+// to compile it. If compiler is empty the Compiler Explorer compiler is
+// guessed from the toolchain of the platform.
+func (b *Builder) CompilerExplorerSession(compiler string) (*CompilerExplorerSession, error) {
+	if len(b.secrets) > 0 {
+		// The session is uploaded to Compiler Explorer
+		return nil, errors.New(tr("a Compiler Explorer session can not be created when secrets are used"))
+	}
+
+	b.Progress.AddSubSteps(6)
+	defer b.Progress.RemoveSubSteps()
+	defer b.removeSecrets()
+
+	if compiler == "" {
+		compiler = b.buildProperties.Get("compiler_explorer.compiler")
+	}
+	if compiler == "" {
+		compiler = compilerExplorerCompilers[b.buildProperties.Get("compiler.cpp.cmd")]
+	}
+	if compiler == "" {
+		return nil, errors.New(tr("the Compiler Explorer compiler for %s is unknown, it must be set explicitly", b.buildProperties.Get("compiler.cpp.cmd")))
+	}
+
+	if err := b.preprocess(); err != nil {
+		return nil, err
+	}
+
+	flags, err := compilerExplorerFlags(b.buildProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceFile := b.sketchBuildPath.Join(b.sketch.MainFile.Base() + ".cpp")
+	targetFile := b.buildPath.Join("preproc", "compiler_explorer.ii")
+	if err := targetFile.Parent().MkdirAll(); err != nil {
+		return nil, err
+	}
+	result, err := preprocessor.GCC(sourceFile, targetFile, b.libsDetector.IncludeFolders(), b.buildProperties)
+	b.logger.VerboseStdout(result.Stdout())
+	if err != nil {
+		b.logger.WriteStderr(result.Stderr())
+		return nil, err
+	}
+	preprocessed, err := targetFile.ReadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	source := strings.Builder{}
+	for _, line := range strings.SplitAfter(string(preprocessed), "\n") {
+		if !gccLineMarker.MatchString(line) {
+			source.WriteString(line)
+		}
+	}
+	return &CompilerExplorerSession{
+		Compiler: compiler,
+		Flags:    flags,
+		Source:   source.String(),
+	}, nil
+}
+
+// compilerExplorerFlags returns the flags of the C++ compile recipe, without
+// the arguments that are meaningful only on the local machine: the source and
+// the object files, the include paths and the response files.
+func compilerExplorerFlags(buildProperties *properties.Map) ([]string, error) {
+	const sourceFile, objectFile = "{compiler_explorer.source_file}", "{compiler_explorer.object_file}"
+	props := buildProperties.Clone()
+	props.Set("includes", "")
+	props.Set("source_file", sourceFile)
+	props.Set("object_file", objectFile)
+
+	pattern := props.Get("recipe.cpp.o.pattern")
+	if pattern == "" {
+		return nil, errors.New(tr("%s pattern is missing", "recipe.cpp.o.pattern"))
+	}
+	commandLine := props.ExpandPropsInString(pattern)
+	commandLine = strings.ReplaceAll(commandLine, sourceFile, "")
+	commandLine = strings.ReplaceAll(commandLine, objectFile, "")
+	commandLine = properties.DeleteUnexpandedPropsFromString(commandLine)
+	args, err := properties.SplitQuotedString(commandLine, `"'`, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New(tr("%s pattern is missing", "recipe.cpp.o.pattern"))
+	}
+
+	flags := []string{}
+	for i := 1; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "" || arg == "-c" || arg == "-MMD":
+		case arg == "-o" || arg == "-include" || arg == "-iprefix":
+			i++
+		case strings.HasPrefix(arg, "-I"), strings.HasPrefix(arg, "-iwithprefix"), strings.HasPrefix(arg, "@"):
+		default:
+			flags = append(flags, arg)
+		}
+	}
+	return flags, nil
+}
+
+// ClientState returns the Compiler Explorer client state of the session
+func (s *CompilerExplorerSession) ClientState() ([]byte, error) {
+	type compiler struct {
+		ID      string `json:"id"`
+		Options string `json:"options"`
+	}
+	type session struct {
+		ID        int        `json:"id"`
+		Language  string     `json:"language"`
+		Source    string     `json:"source"`
+		Compilers []compiler `json:"compilers"`
+	}
+	state := struct {
+		Sessions []session `json:"sessions"`
+	}{
+		Sessions: []session{{
+			ID:        1,
+			Language:  "c++",
+			Source:    s.Source,
+			Compilers: []compiler{{ID: s.Compiler, Options: strings.Join(s.Flags, " ")}},
+		}},
+	}
+	return json.Marshal(state)
+}
+
+// Link uploads the session to Compiler Explorer and returns the short link
+// that opens it.
+func (s *CompilerExplorerSession) Link(ctx context.Context, client *http.Client) (string, error) {
+	state, err := s.ClientState()
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, compilerExplorerShortenerURL, bytes.NewReader(state))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(tr("Compiler Explorer replied with status %s", resp.Status))
+	}
+	var res struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", err
+	}
+	if res.URL == "" {
+		return "", errors.New(tr("Compiler Explorer replied without the link to the session"))
+	}
+	return res.URL, nil
+}
@@ -0,0 +1,82 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilerExplorerFlags(t *testing.T) {
+	props := properties.NewMap()
+	props.Set("compiler.path", "/opt/avr/bin/")
+	props.Set("compiler.cpp.cmd", "avr-g++")
+	props.Set("compiler.cpp.flags", "-c -g -Os -std=gnu++11 -MMD")
+	props.Set("build.mcu", "atmega328p")
+	props.Set("build.f_cpu", "16000000L")
+	props.Set("recipe.cpp.o.pattern", `"{compiler.path}{compiler.cpp.cmd}" {compiler.cpp.flags} -mmcu={build.mcu} -DF_CPU={build.f_cpu} {compiler.cpp.extra_flags} {includes} "{source_file}" -o "{object_file}"`)
+
+	flags, err := compilerExplorerFlags(props)
+	require.NoError(t, err)
+	require.Equal(t, []string{"-g", "-Os", "-std=gnu++11", "-mmcu=atmega328p", "-DF_CPU=16000000L"}, flags)
+
+	props.Remove("recipe.cpp.o.pattern")
+	_, err = compilerExplorerFlags(props)
+	require.Error(t, err)
+}
+
+func TestCompilerExplorerSessionLink(t *testing.T) {
+	session := &CompilerExplorerSession{
+		Compiler: "avrg1230",
+		Flags:    []string{"-Os", "-mmcu=atmega328p"},
+		Source:   "int main() {}\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		state, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if !strings.Contains(string(state), "main") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		require.JSONEq(t, `{"sessions":[{"id":1,"language":"c++","source":"int main() {}\n","compilers":[{"id":"avrg1230","options":"-Os -mmcu=atmega328p"}]}]}`, string(state))
+		w.Write([]byte(`{"url":"https://godbolt.org/z/abc123"}`))
+	}))
+	defer server.Close()
+	defer func(url string) { compilerExplorerShortenerURL = url }(compilerExplorerShortenerURL)
+	compilerExplorerShortenerURL = server.URL
+
+	link, err := session.Link(context.Background(), server.Client())
+	require.NoError(t, err)
+	require.Equal(t, "https://godbolt.org/z/abc123", link)
+
+	session.Source = ""
+	_, err = session.Link(context.Background(), server.Client())
+	require.ErrorContains(t, err, "400")
+}
+
+func TestCompilerExplorerSessionWithSecrets(t *testing.T) {
+	b := &Builder{secrets: map[string]string{"PASS": "x"}}
+	_, err := b.CompilerExplorerSession("avrg1230")
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/preprocessor"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+)
+
+// ConditionalBranch is a branch of a conditional compilation directive of the sketch
+type ConditionalBranch = preprocessor.ConditionalBranch
+
+// ConditionalBranches preprocesses the sketch and reports the branches of the conditional
+// compilation directives (#if, #ifdef...) of the sketch source files, marking the branches
+// that are compiled for the selected board.
+func (b *Builder) ConditionalBranches() ([]*ConditionalBranch, error) {
+	b.Progress.AddSubSteps(6)
+	defer b.Progress.RemoveSubSteps()
+	defer b.removeSecrets()
+
+	if err := b.preprocess(); err != nil {
+		return nil, err
+	}
+
+	sources, err := utils.FindFilesInFolder(b.sketchBuildPath, false, ".c", ".cpp", ".S")
+	if err != nil {
+		return nil, err
+	}
+	if sketchSrcPath := b.sketchBuildPath.Join("src"); sketchSrcPath.IsDir() {
+		srcSources, err := utils.FindFilesInFolder(sketchSrcPath, true, ".c", ".cpp", ".S")
+		if err != nil {
+			return nil, err
+		}
+		sources.AddAll(srcSources)
+	}
+
+	includes := b.libsDetector.IncludeFolders()
+	res := []*ConditionalBranch{}
+	for _, source := range sources {
+		// Report the branches in the files of the sketch folder, the #line
+		// directives of the merged sketch already point to the original files.
+		originalFile := source
+		if rel, err := source.RelFrom(b.sketchBuildPath); err == nil {
+			originalFile = b.sketch.FullPath.JoinPath(rel)
+		}
+		branches, result, err := preprocessor.ConditionalBranches(source, originalFile, includes, b.buildProperties)
+		b.logger.VerboseStdout(result.Stdout())
+		if err != nil {
+			b.logger.WriteStderr(result.Stderr())
+			b.diagnosticStore.Parse(result.Args(), result.Stderr())
+			return nil, err
+		}
+		res = append(res, branches...)
+	}
+	return res, nil
+}
@@ -36,6 +36,12 @@ func (b *Builder) buildCore() error {
 		return err
 	}
 
+	if b.coreBuildCachePath != nil && b.variantOverridden {
+		// The core compiled with a different variant can't be shared with
+		// the other sketches using the same board
+		b.logIfVerbose(false, tr("The variant of the board is overridden by the sketch project file, the core will not be cached"))
+		b.coreBuildCachePath = nil
+	}
 	if b.coreBuildCachePath != nil {
 		if _, err := b.coreBuildCachePath.RelTo(b.buildPath); err != nil {
 			b.logger.Info(tr("Couldn't deeply cache core build: %[1]s", err))
@@ -61,27 +67,11 @@ func (b *Builder) compileCore() (*paths.Path, paths.PathList, error) {
 	coreFolder := b.buildProperties.GetPath("build.core.path")
 	variantFolder := b.buildProperties.GetPath("build.variant.path")
 	targetCoreFolder := b.buildProperties.GetPath("runtime.platform.path")
+	includes := b.coreIncludes()
 
-	includes := []string{coreFolder.String()}
-	if variantFolder != nil && variantFolder.IsDir() {
-		includes = append(includes, variantFolder.String())
-	}
-	includes = f.Map(includes, cpp.WrapWithHyphenI)
-
-	var err error
-	variantObjectFiles := paths.NewPathList()
-	if variantFolder != nil && variantFolder.IsDir() {
-		variantObjectFiles, err = b.compileFiles(
-			variantFolder, b.coreBuildPath,
-			true, /** recursive **/
-			includes,
-		)
-		if err != nil {
-			return nil, nil, err
-		}
-	}
-
-	var targetArchivedCore *paths.Path
+	var targetArchivedCore, targetArchivedCoreSums, cachedObjectsDir *paths.Path
+	var coreFolders paths.PathList
+	canUseArchivedCore := false
 	if b.coreBuildCachePath != nil {
 		realCoreFolder := coreFolder.Parent().Parent()
 		archivedCoreName := getCachedCoreArchiveDirName(
@@ -95,34 +85,56 @@ func (b *Builder) compileCore() (*paths.Path, paths.PathList, error) {
 			return nil, nil, fmt.Errorf(tr("creating core cache folder: %s", err))
 		}
 
-		var canUseArchivedCore bool
-		if b.onlyUpdateCompilationDatabase || b.clean {
-			canUseArchivedCore = false
-		} else if isOlder, err := utils.DirContentIsOlderThan(realCoreFolder, targetArchivedCore); err != nil || !isOlder {
-			// Recreate the archive if ANY of the core files (including platform.txt) has changed
-			canUseArchivedCore = false
-		} else if targetCoreFolder == nil || realCoreFolder.EquivalentTo(targetCoreFolder) {
-			canUseArchivedCore = true
-		} else if isOlder, err := utils.DirContentIsOlderThan(targetCoreFolder, targetArchivedCore); err != nil || !isOlder {
-			// Recreate the archive if ANY of the build core files (including platform.txt) has changed
-			canUseArchivedCore = false
-		} else {
-			canUseArchivedCore = true
+		// The cached archive can be used only if ALL of the core files
+		// (including platform.txt) are unchanged
+		coreFolders = paths.NewPathList(realCoreFolder.String())
+		if targetCoreFolder != nil && !realCoreFolder.EquivalentTo(targetCoreFolder) {
+			coreFolders.Add(targetCoreFolder)
 		}
-
-		if canUseArchivedCore {
-			// use archived core
-			if b.logger.Verbose() {
-				b.logger.Info(tr("Using precompiled core: %[1]s", targetArchivedCore))
+		targetArchivedCoreSums = utils.SumsFilePath(targetArchivedCore)
+		canUseArchivedCore = !b.onlyUpdateCompilationDatabase && !b.clean &&
+			targetArchivedCore.Exist() &&
+			utils.DirContentMatches(coreFolders, targetArchivedCoreSums)
+
+		// The objects of the core and of the variant are cached too, together
+		// with their dependency files: when the core files change only the
+		// objects depending on the changed files are compiled again
+		if !b.onlyUpdateCompilationDatabase {
+			cachedObjectsDir = b.coreBuildCachePath.Join(archivedCoreName, "objects")
+			if !b.clean && cachedObjectsDir.IsDir() {
+				if err := b.restoreCachedObjects(cachedObjectsDir, b.coreBuildPath); err != nil {
+					b.logIfVerbose(true, tr("Couldn't use the cached objects of the core: %[1]s", err))
+				}
 			}
-			return targetArchivedCore, variantObjectFiles, nil
 		}
 	}
 
+	var err error
+	variantObjectFiles := paths.NewPathList()
+	if variantFolder != nil && variantFolder.IsDir() {
+		variantObjectFiles, err = b.compileFiles(
+			variantFolder, b.coreBuildPath,
+			true, /** recursive **/
+			includes,
+			b.buildProperties,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if canUseArchivedCore {
+		// use archived core
+		b.logger.VerboseInfo(tr("Using precompiled core: %[1]s", targetArchivedCore))
+		b.storeCoreObjects(cachedObjectsDir)
+		return targetArchivedCore, variantObjectFiles, nil
+	}
+
 	coreObjectFiles, err := b.compileFiles(
 		coreFolder, b.coreBuildPath,
 		true, /** recursive **/
 		includes,
+		b.buildProperties,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -136,22 +148,44 @@ func (b *Builder) compileCore() (*paths.Path, paths.PathList, error) {
 	// archive core.a
 	if targetArchivedCore != nil && !b.onlyUpdateCompilationDatabase {
 		err := archiveFile.CopyTo(targetArchivedCore)
-		if b.logger.Verbose() {
-			if err == nil {
-				b.logger.Info(tr("Archiving built core (caching) in: %[1]s", targetArchivedCore))
-			} else if os.IsNotExist(err) {
-				b.logger.Info(tr("Unable to cache built core, please tell %[1]s maintainers to follow %[2]s",
-					b.actualPlatform,
-					"https://arduino.github.io/arduino-cli/latest/platform-specification/#recipes-to-build-the-corea-archive-file"))
-			} else {
-				b.logger.Info(tr("Error archiving built core (caching) in %[1]s: %[2]s", targetArchivedCore, err))
-			}
+		if err == nil {
+			err = utils.WriteDirContentSums(coreFolders, targetArchivedCoreSums)
+		}
+		if err == nil {
+			b.logger.VerboseInfo(tr("Archiving built core (caching) in: %[1]s", targetArchivedCore))
+		} else if os.IsNotExist(err) {
+			b.logger.VerboseInfo(tr("Unable to cache built core, please tell %[1]s maintainers to follow %[2]s",
+				b.actualPlatform,
+				"https://arduino.github.io/arduino-cli/latest/platform-specification/#recipes-to-build-the-corea-archive-file"))
+		} else {
+			b.logger.VerboseInfo(tr("Error archiving built core (caching) in %[1]s: %[2]s", targetArchivedCore, err))
 		}
 	}
+	b.storeCoreObjects(cachedObjectsDir)
 
 	return archiveFile, variantObjectFiles, nil
 }
 
+// storeCoreObjects copies the objects of the core and of the variant to the
+// core build cache, if cachedObjectsDir is not nil.
+func (b *Builder) storeCoreObjects(cachedObjectsDir *paths.Path) {
+	if cachedObjectsDir == nil {
+		return
+	}
+	if err := b.storeCachedObjects(b.coreBuildPath, cachedObjectsDir); err != nil {
+		b.logIfVerbose(true, tr("Couldn't cache the objects of the core: %[1]s", err))
+	}
+}
+
+// coreIncludes returns the include flags used to compile the core and the variant
+func (b *Builder) coreIncludes() []string {
+	includes := []string{b.buildProperties.GetPath("build.core.path").String()}
+	if variantFolder := b.buildProperties.GetPath("build.variant.path"); variantFolder != nil && variantFolder.IsDir() {
+		includes = append(includes, variantFolder.String())
+	}
+	return f.Map(includes, cpp.WrapWithHyphenI)
+}
+
 // getCachedCoreArchiveDirName returns the directory name to be used to store
 // the global cached core.a.
 func getCachedCoreArchiveDirName(fqbn string, optimizationFlags string, coreFolder *paths.Path) string {
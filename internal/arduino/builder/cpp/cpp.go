@@ -18,7 +18,6 @@ package cpp
 import (
 	"strconv"
 	"strings"
-	"unicode/utf8"
 
 	"github.com/arduino/go-paths-helper"
 )
@@ -65,7 +64,9 @@ func ParseLineMarker(line string) *paths.Path {
 
 // ParseString parse a string as emitted by the preprocessor. This
 // is a string contained in double quotes, with any backslashes or
-// quotes escaped with a backslash. If a valid string was present at the
+// quotes escaped with a backslash. The non-printable characters, like
+// the bytes of the non-ASCII characters, may be escaped as octal
+// sequences. If a valid string was present at the
 // start of the given line, returns the unquoted string contents, the
 // remainder of the line (everything after the closing "), and true.
 // Otherwise, returns the empty string, the entire line and false.
@@ -73,40 +74,52 @@ func ParseString(line string) (string, string, bool) {
 	// For details about how these strings are output by gcc, see:
 	// https://github.com/gcc-mirror/gcc/blob/a588355ab948cf551bc9d2b89f18e5ae5140f52c/libcpp/macro.c#L491-L511
 	// Note that the documentation suggests all non-printable
-	// characters are also escaped, but the implementation does not
+	// characters are also escaped, but older implementations do not
 	// actually do this. See https://gcc.gnu.org/bugzilla/show_bug.cgi?id=51259
 	if len(line) < 1 || line[0] != '"' {
 		return "", line, false
 	}
 
 	i := 1
-	res := ""
+	res := []byte{}
 	for {
 		if i >= len(line) {
 			return "", line, false
 		}
 
-		c, width := utf8.DecodeRuneInString(line[i:])
-
-		switch c {
+		switch c := line[i]; c {
 		case '\\':
-			// Backslash, next character is used unmodified
-			i += width
+			// Backslash, next character is used unmodified unless it's
+			// an octal escape sequence
+			i++
 			if i >= len(line) {
 				return "", line, false
 			}
-			res += string(line[i])
+			if isOctalDigit(line[i]) {
+				value := 0
+				for n := 0; n < 3 && i < len(line) && isOctalDigit(line[i]); n++ {
+					value = value*8 + int(line[i]-'0')
+					i++
+				}
+				res = append(res, byte(value))
+				continue
+			}
+			res = append(res, line[i])
 		case '"':
 			// Quote, end of string
-			return res, line[i+width:], true
+			return string(res), line[i+1:], true
 		default:
-			res += string(c)
+			res = append(res, c)
 		}
 
-		i += width
+		i++
 	}
 }
 
+func isOctalDigit(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
 // WrapWithHyphenI fixdoc
 func WrapWithHyphenI(value string) string {
 	return "\"-I" + value + "\""
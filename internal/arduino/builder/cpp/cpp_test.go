@@ -58,6 +58,17 @@ func TestParseString(t *testing.T) {
 	require.Equal(t, true, ok)
 	require.Equal(t, `/home/ççç/ /$sdsdd\`, str)
 	require.Equal(t, ``, rest)
+
+	// Non-ASCII characters escaped as octal sequences
+	str, rest, ok = cpp.ParseString(`"/home/\303\247\303\247/My Sketch\\\\è.ino" 2`)
+	require.Equal(t, true, ok)
+	require.Equal(t, `/home/çç/My Sketch\\è.ino`, str)
+	require.Equal(t, ` 2`, rest)
+
+	str, rest, ok = cpp.ParseString(`"\0\12x"`)
+	require.Equal(t, true, ok)
+	require.Equal(t, "\x00\nx", str)
+	require.Equal(t, ``, rest)
 }
 
 func TestQuoteString(t *testing.T) {
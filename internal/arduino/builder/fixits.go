@@ -0,0 +1,85 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/diagnostics"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
+)
+
+// ApplyFixIts applies in place the fix-it hints suggested by the compiler to
+// the files of the sketch and of the libraries given with the --library flag.
+// The files of the other libraries, of the core and of the toolchain are never
+// modified. The applied fix-it hints are returned.
+func (b *Builder) ApplyFixIts() (diagnostics.FixIts, error) {
+	files := paths.PathList{}
+	fixitsByFile := map[string]diagnostics.FixIts{}
+	skipped := map[string]bool{}
+	for _, diag := range b.diagnosticStore.Diagnostics() {
+		for _, fixit := range diag.FixIts {
+			file := paths.New(fixit.File)
+			if skipped[file.String()] {
+				continue
+			}
+			if _, ok := fixitsByFile[file.String()]; !ok {
+				if !b.canApplyFixIts(file) {
+					b.logger.Warn(tr("The fix-it hints for %s are not applied: only the files of the sketch and of the libraries given with --library can be modified", file))
+					skipped[file.String()] = true
+					continue
+				}
+				files.Add(file)
+			}
+			fixitsByFile[file.String()] = append(fixitsByFile[file.String()], fixit)
+		}
+	}
+
+	var res diagnostics.FixIts
+	for _, file := range files {
+		data, err := file.ReadFile()
+		if err != nil {
+			return res, err
+		}
+		data, applied := fixitsByFile[file.String()].Apply(data)
+		if len(applied) == 0 {
+			continue
+		}
+		if err := file.WriteFile(data); err != nil {
+			return res, err
+		}
+		res = append(res, applied...)
+	}
+	return res, nil
+}
+
+// canApplyFixIts returns true if the given file is part of the sketch or of
+// one of the libraries given with the --library flag.
+func (b *Builder) canApplyFixIts(file *paths.Path) bool {
+	if isInside(file, b.buildPath) {
+		// The copies of the sketch files in the build path are overwritten
+		// by the next build
+		return false
+	}
+	if b.sketch != nil && isInside(file, b.sketch.FullPath) {
+		return true
+	}
+	for _, library := range b.libsDetector.ImportedLibraries() {
+		if library.Location == libraries.Unmanaged && isInside(file, library.InstallDir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/detector"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/preprocessor"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesapi"
+	"github.com/arduino/go-paths-helper"
+)
+
+// HeaderDeclarations are the names declared by the headers included by the
+// sketch and by the core of the board
+type HeaderDeclarations struct {
+	Names []string
+	// MissingHeaders are the headers included by the sketch that can't be
+	// found for the board
+	MissingHeaders []string
+}
+
+// HeaderDeclarations preprocesses only the preprocessor directives of the
+// sketch, so that the code of the sketch doesn't need to compile for the
+// board, and returns the names declared by the headers included. The headers
+// that can't be found are dropped from the sketch and reported as missing.
+func (b *Builder) HeaderDeclarations() (*HeaderDeclarations, error) {
+	b.Progress.AddSubSteps(6)
+	defer b.Progress.RemoveSubSteps()
+	defer b.removeSecrets()
+
+	files := paths.PathList{b.sketch.MainFile}
+	files.AddAll(b.sketch.OtherSketchFiles)
+	files.AddAll(b.sketch.AdditionalFiles)
+	overrides := map[string]string{}
+	for _, file := range files {
+		source, err := b.sketchSource(file)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := b.sketch.FullPath.RelTo(file)
+		if err != nil {
+			return nil, err
+		}
+		overrides[rel.String()] = directivesOnly(source)
+	}
+
+	sourceOverrides := b.sourceOverrides
+	b.sourceOverrides = overrides
+	defer func() { b.sourceOverrides = sourceOverrides }()
+
+	res := &HeaderDeclarations{MissingHeaders: []string{}}
+	for {
+		err := b.preprocess()
+		var missingInclude *detector.MissingIncludeError
+		if !errors.As(err, &missingInclude) {
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+		if !removeInclude(overrides, missingInclude.Header) {
+			// The header is included by a library
+			return nil, err
+		}
+		res.MissingHeaders = append(res.MissingHeaders, missingInclude.Header)
+		b.libsDetector.Reset()
+	}
+
+	// Keep the macros in the preprocessed output
+	buildProperties := b.buildProperties.Clone()
+	buildProperties.Set("preproc.macros.flags", buildProperties.Get("preproc.macros.flags")+" -dD")
+	sources := paths.PathList{b.sketchBuildPath.Join(b.sketch.MainFile.Base() + ".cpp")}
+	for _, file := range b.sketch.AdditionalFiles {
+		if ext := file.Ext(); ext != ".c" && ext != ".cpp" {
+			continue
+		}
+		if rel, err := b.sketch.FullPath.RelTo(file); err == nil {
+			sources.Add(b.sketchBuildPath.JoinPath(rel))
+		}
+	}
+	if err := b.buildPath.Join("preproc").MkdirAll(); err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for i, source := range sources {
+		targetFile := b.buildPath.Join("preproc", fmt.Sprintf("declarations_%d.ii", i))
+		result, err := preprocessor.GCC(source, targetFile, b.libsDetector.IncludeFolders(), buildProperties)
+		b.logger.VerboseStdout(result.Stdout())
+		if err != nil {
+			b.logger.WriteStderr(result.Stderr())
+			return nil, err
+		}
+		preprocessed, err := targetFile.ReadFile()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range librariesapi.DeclaredNames(string(preprocessed)) {
+			names[name] = true
+		}
+	}
+	for name := range names {
+		res.Names = append(res.Names, name)
+	}
+	sort.Strings(res.Names)
+	return res, nil
+}
+
+// directivesOnly blanks the lines of the source that are not preprocessor
+// directives, keeping the line numbers unchanged.
+func directivesOnly(source string) string {
+	lines := strings.Split(source, "\n")
+	continued := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !continued && !strings.HasPrefix(trimmed, "#") {
+			lines[i] = ""
+			continue
+		}
+		continued = strings.HasSuffix(trimmed, "\\")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// removeInclude removes the #include directives of the header from the
+// sources, it returns false if none is found.
+func removeInclude(sources map[string]string, header string) bool {
+	directive := regexp.MustCompile(`(?m)^[ \t]*#[ \t]*include[ \t]*[<"]` + regexp.QuoteMeta(header) + `[>"].*$`)
+	found := false
+	for file, source := range sources {
+		if directive.MatchString(source) {
+			sources[file] = directive.ReplaceAllString(source, "")
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,47 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectivesOnly(t *testing.T) {
+	source := "#include <Wire.h>\n" +
+		"#if defined(ARDUINO_ARCH_AVR)\n" +
+		"  #include <avr/io.h>\n" +
+		"#endif\n" +
+		"#define LONG_MACRO(x) \\\n" +
+		"  ((x) * 2)\n" +
+		"void setup() {\n" +
+		"  Wire.begin();\n" +
+		"}\n"
+	require.Equal(t, "#include <Wire.h>\n"+
+		"#if defined(ARDUINO_ARCH_AVR)\n"+
+		"  #include <avr/io.h>\n"+
+		"#endif\n"+
+		"#define LONG_MACRO(x) \\\n"+
+		"  ((x) * 2)\n"+
+		"\n\n\n", directivesOnly(source))
+
+	sources := map[string]string{"Sketch.ino": directivesOnly(source), "config.h": "# include \"avr/io.h\" // registers\n"}
+	require.True(t, removeInclude(sources, "avr/io.h"))
+	require.Equal(t, "#include <Wire.h>\n#if defined(ARDUINO_ARCH_AVR)\n\n#endif\n#define LONG_MACRO(x) \\\n  ((x) * 2)\n\n\n\n", sources["Sketch.ino"])
+	require.Equal(t, "\n", sources["config.h"])
+	require.False(t, removeInclude(sources, "SPI.h"))
+}
@@ -0,0 +1,175 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/compilation"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/includes"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
+	"github.com/arduino/go-paths-helper"
+)
+
+// IncludeIssue is a problem found in the #include directives of the sketch
+type IncludeIssue = includes.Issue
+
+// The kinds of IncludeIssue
+const (
+	IncludeUnused  = includes.Unused
+	IncludeMissing = includes.Missing
+)
+
+// IncludesAnalysis reports the headers included by the sketch that are never
+// used, and the headers the sketch relies on without including them directly,
+// because they're included by a header of another library or of the core.
+// The headers included by each file of the sketch are listed by running the
+// commands of the compilation database with the -H flag of gcc.
+func (b *Builder) IncludesAnalysis() ([]*IncludeIssue, error) {
+	b.Progress.AddSubSteps(6)
+	defer b.Progress.RemoveSubSteps()
+	defer b.removeSecrets()
+
+	if err := b.preprocess(); err != nil {
+		return nil, err
+	}
+
+	// Add the commands compiling the sketch to the compilation database,
+	// without running them
+	onlyUpdateCompilationDatabase := b.onlyUpdateCompilationDatabase
+	b.onlyUpdateCompilationDatabase = true
+	err := b.buildSketch(b.libsDetector.IncludeFolders())
+	b.onlyUpdateCompilationDatabase = onlyUpdateCompilationDatabase
+	if err != nil {
+		return nil, err
+	}
+
+	mergedSketch := b.sketchBuildPath.Join(b.sketch.MainFile.Base() + ".cpp")
+	analyzer := includes.NewAnalyzer(b.includeComponent)
+	res := []*IncludeIssue{}
+	for _, command := range b.compilationDatabase.Commands() {
+		source := paths.New(command.File)
+		if !isInside(source, b.sketchBuildPath) {
+			continue
+		}
+
+		// Analyze the files of the sketch, instead of their copies in the
+		// build path
+		file := b.sketch.FullPath
+		originals := paths.PathList{}
+		if source.EquivalentTo(mergedSketch) {
+			file = b.sketch.MainFile
+			originals.Add(b.sketch.MainFile)
+			originals.AddAll(b.sketch.OtherSketchFiles)
+		} else if rel, err := source.RelFrom(b.sketchBuildPath); err == nil {
+			file = b.sketch.FullPath.JoinPath(rel)
+			originals.Add(file)
+		}
+		sources := map[*paths.Path]string{}
+		for _, original := range originals {
+			content, err := b.sketchSource(original)
+			if err != nil {
+				return nil, err
+			}
+			sources[original] = content
+		}
+
+		tree, err := b.includesTree(command)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, analyzer.Analyze(file, sources, tree)...)
+	}
+	return res, nil
+}
+
+// includesTree runs the compile command with the -H flag of gcc and returns
+// the tree of the headers included.
+func (b *Builder) includesTree(command compilation.Command) ([]*includes.TreeEntry, error) {
+	args := []string{}
+	for i := 0; i < len(command.Arguments); i++ {
+		switch arg := command.Arguments[i]; arg {
+		case "-c", "-MMD", "-MD":
+		case "-o":
+			i++
+		default:
+			args = append(args, arg)
+		}
+	}
+	args = append(args, "-E", "-H", "-w", "-o", os.DevNull)
+
+	toolEnv := append(append([]string{}, b.toolEnv...), toolenv.PropertiesEnv(b.buildProperties)...)
+	proc, err := toolenv.NewProcess(toolEnv, args...)
+	if err != nil {
+		return nil, err
+	}
+	proc.SetDir(command.Directory)
+	b.logger.VerboseInfo(utils.PrintableCommand(args))
+	_, stderr, err := proc.RunAndCaptureOutput(context.Background())
+	if err != nil {
+		b.logger.WriteStderr(stderr)
+		b.diagnosticStore.Parse(args, stderr)
+		return nil, err
+	}
+
+	tree := includes.ParseTree(stderr)
+	for _, entry := range tree {
+		if !entry.Path.IsAbs() {
+			entry.Path = paths.New(command.Directory).JoinPath(entry.Path)
+		}
+		entry.Path = entry.Path.Clean()
+	}
+	return tree, nil
+}
+
+// includeComponent returns the component containing the header, and the
+// header to include to use it: the headers of the core are used through
+// Arduino.h. The headers of the toolchain are not part of any component.
+func (b *Builder) includeComponent(header *paths.Path) (string, string) {
+	for _, dir := range []*paths.Path{b.buildProperties.GetPath("build.core.path"), b.buildProperties.GetPath("build.variant.path")} {
+		if isInside(header, dir) {
+			return "core", "<Arduino.h>"
+		}
+	}
+	for _, library := range b.libsDetector.ImportedLibraries() {
+		if isInside(header, library.SourceDir) {
+			if rel, err := header.RelFrom(library.SourceDir); err == nil {
+				return library.Name, "<" + filepath.ToSlash(rel.String()) + ">"
+			}
+		}
+	}
+	if isInside(header, b.sketchBuildPath) {
+		if rel, err := header.RelFrom(b.sketchBuildPath); err == nil {
+			return "sketch", `"` + filepath.ToSlash(rel.String()) + `"`
+		}
+	}
+	return "", ""
+}
+
+// sketchSource returns the content of a file of the sketch, taking into
+// account the source overrides.
+func (b *Builder) sketchSource(file *paths.Path) (string, error) {
+	if rel, err := b.sketch.FullPath.RelTo(file); err == nil {
+		if override, ok := b.sourceOverrides[rel.String()]; ok {
+			return override, nil
+		}
+	}
+	data, err := file.ReadFile()
+	return string(data), err
+}
@@ -96,3 +96,10 @@ func (db *Database) Add(target *paths.Path, command *paths.Process) {
 	db.contents = append(db.contents, entry)
 	db.lock.Unlock()
 }
+
+// Commands returns the entries of the CompilationDatabase
+func (db *Database) Commands() []Command {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	return append([]Command{}, db.contents...)
+}
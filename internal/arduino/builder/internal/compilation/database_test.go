@@ -43,4 +43,5 @@ func TestCompilationDatabase(t *testing.T) {
 	cwd, err := paths.Getwd()
 	require.NoError(t, err)
 	require.Equal(t, db2.contents[0].Directory, cwd.String())
+	require.Equal(t, db2.contents, db2.Commands())
 }
@@ -23,6 +23,7 @@ import (
 	"os/exec"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -88,11 +89,9 @@ func (l *SketchLibrariesDetector) resolveLibrary(header, platformArch string) *l
 	importedLibraries := l.importedLibraries
 	candidates := l.librariesResolver.AlternativesFor(header)
 
-	if l.logger.Verbose() {
-		l.logger.Info(tr("Alternatives for %[1]s: %[2]s", header, candidates))
-		l.logger.Info(fmt.Sprintf("ResolveLibrary(%s)", header))
-		l.logger.Info(fmt.Sprintf("  -> %s: %s", tr("candidates"), candidates))
-	}
+	l.logger.VerboseInfo(tr("Alternatives for %[1]s: %[2]s", header, candidates))
+	l.logger.VerboseInfo(fmt.Sprintf("ResolveLibrary(%s)", header))
+	l.logger.VerboseInfo(fmt.Sprintf("  -> %s: %s", tr("candidates"), candidates))
 
 	if len(candidates) == 0 {
 		return nil
@@ -127,6 +126,14 @@ func (l *SketchLibrariesDetector) resolveLibrary(header, platformArch string) *l
 	return selected
 }
 
+// Reset forgets the libraries and the include folders detected, so that
+// FindIncludes can be run again from scratch.
+func (l *SketchLibrariesDetector) Reset() {
+	l.importedLibraries = libraries.List{}
+	l.librariesResolutionResults = map[string]libraryResolutionResult{}
+	l.includeFolders = paths.PathList{}
+}
+
 // ImportedLibraries todo
 func (l *SketchLibrariesDetector) ImportedLibraries() libraries.List {
 	// TODO understand if we have to do a deepcopy
@@ -145,7 +152,7 @@ func (l *SketchLibrariesDetector) PrintUsedAndNotUsedLibraries(sketchError bool)
 	// - as warning, when the sketch didn't compile
 	// - as info, when verbose is on
 	// - otherwise, output nothing
-	if !sketchError && !l.logger.Verbose() {
+	if !sketchError && !l.logger.VerboseOutput() {
 		return
 	}
 
@@ -164,7 +171,7 @@ func (l *SketchLibrariesDetector) PrintUsedAndNotUsedLibraries(sketchError bool)
 	if sketchError {
 		l.logger.Warn(res)
 	} else {
-		l.logger.Info(res)
+		l.logger.VerboseInfo(res)
 	}
 	// todo why?? should we remove this?
 	time.Sleep(100 * time.Millisecond)
@@ -238,9 +245,7 @@ func (l *SketchLibrariesDetector) findIncludes(
 		if err := json.Unmarshal(d, &l.includeFolders); err != nil {
 			return err
 		}
-		if l.logger.Verbose() {
-			l.logger.Info("Using cached library discovery: " + librariesResolutionCache.String())
-		}
+		l.logger.VerboseInfo("Using cached library discovery: " + librariesResolutionCache.String())
 		return nil
 	}
 
@@ -346,14 +351,12 @@ func (l *SketchLibrariesDetector) findIncludesUntilDone(
 		var missingIncludeH string
 		if unchanged && cache.valid {
 			missingIncludeH = cache.Next().Include
-			if first && l.logger.Verbose() {
-				l.logger.Info(tr("Using cached library dependencies for file: %[1]s", sourcePath))
+			if first {
+				l.logger.VerboseInfo(tr("Using cached library dependencies for file: %[1]s", sourcePath))
 			}
 		} else {
 			preprocFirstResult, preprocErr = preprocessor.GCC(sourcePath, targetFilePath, includeFolders, buildProperties)
-			if l.logger.Verbose() {
-				l.logger.WriteStdout(preprocFirstResult.Stdout())
-			}
+			l.logger.VerboseStdout(preprocFirstResult.Stdout())
 			// Unwrap error and see if it is an ExitError.
 			var exitErr *exec.ExitError
 			if preprocErr == nil {
@@ -364,8 +367,8 @@ func (l *SketchLibrariesDetector) findIncludesUntilDone(
 				return preprocErr
 			} else {
 				missingIncludeH = IncludesFinderWithRegExp(string(preprocFirstResult.Stderr()))
-				if missingIncludeH == "" && l.logger.Verbose() {
-					l.logger.Info(tr("Error while detecting libraries included by %[1]s", sourcePath))
+				if missingIncludeH == "" {
+					l.logger.VerboseInfo(tr("Error while detecting libraries included by %[1]s", sourcePath))
 				}
 			}
 		}
@@ -382,9 +385,7 @@ func (l *SketchLibrariesDetector) findIncludesUntilDone(
 			if preprocErr == nil || preprocFirstResult.Stderr() == nil {
 				// Filename came from cache, so run preprocessor to obtain error to show
 				result, err := preprocessor.GCC(sourcePath, targetFilePath, includeFolders, buildProperties)
-				if l.logger.Verbose() {
-					l.logger.WriteStdout(result.Stdout())
-				}
+				l.logger.VerboseStdout(result.Stdout())
 				if err == nil {
 					// If there is a missing #include in the cache, but running
 					// gcc does not reproduce that, there is something wrong.
@@ -394,11 +395,11 @@ func (l *SketchLibrariesDetector) findIncludesUntilDone(
 				}
 				l.diagnosticStore.Parse(result.Args(), result.Stderr())
 				l.logger.WriteStderr(result.Stderr())
-				return err
+				return newMissingIncludeError(missingIncludeH, sourcePath, result.Stderr(), err)
 			}
 			l.diagnosticStore.Parse(preprocFirstResult.Args(), preprocFirstResult.Stderr())
 			l.logger.WriteStderr(preprocFirstResult.Stderr())
-			return preprocErr
+			return newMissingIncludeError(missingIncludeH, sourcePath, preprocFirstResult.Stderr(), preprocErr)
 		}
 
 		// Add this library to the list of libraries, the
@@ -409,9 +410,7 @@ func (l *SketchLibrariesDetector) findIncludesUntilDone(
 
 		if library.Precompiled && library.PrecompiledWithSources {
 			// Fully precompiled libraries should have no dependencies to avoid ABI breakage
-			if l.logger.Verbose() {
-				l.logger.Info(tr("Skipping dependencies detection for precompiled library %[1]s", library.Name))
-			}
+			l.logger.VerboseInfo(tr("Skipping dependencies detection for precompiled library %[1]s", library.Name))
 		} else {
 			for _, sourceDir := range library.SourceDirs() {
 				l.queueSourceFilesFromFolder(sourceFileQueue, sourceDir.Dir, sourceDir.Recurse,
@@ -476,6 +475,45 @@ func (l *SketchLibrariesDetector) failIfImportedLibraryIsWrong() error {
 	return nil
 }
 
+// MissingIncludeError is returned when a header file included by a source
+// file can't be found in the platform or in any of the installed libraries.
+type MissingIncludeError struct {
+	Header string
+	// SourceFile is the file containing the include directive and Line is
+	// the line of the directive, or 0 if unknown
+	SourceFile *paths.Path
+	Line       int
+	Cause      error
+}
+
+func (e *MissingIncludeError) Error() string {
+	location := e.SourceFile.String()
+	if e.Line > 0 {
+		location += ":" + strconv.Itoa(e.Line)
+	}
+	return tr("missing header file %[1]s, included by %[2]s", e.Header, location)
+}
+
+func (e *MissingIncludeError) Unwrap() error {
+	return e.Cause
+}
+
+// fatalErrorLocationRegexp matches the location of the fatal error printed
+// by gcc, like "/path/to/file.cpp:12:10: fatal error: ..."
+var fatalErrorLocationRegexp = regexp.MustCompile(`(?m)^(.+?):(\d+):(?:\d+:)? fatal error: `)
+
+// newMissingIncludeError creates a MissingIncludeError for the given header
+// included by sourceFile, the precise location of the include directive is
+// taken from the preprocessor output, if available.
+func newMissingIncludeError(header string, sourceFile *paths.Path, stderr []byte, cause error) *MissingIncludeError {
+	res := &MissingIncludeError{Header: header, SourceFile: sourceFile, Cause: cause}
+	if match := fatalErrorLocationRegexp.FindSubmatch(stderr); match != nil {
+		res.SourceFile = paths.New(string(match[1]))
+		res.Line, _ = strconv.Atoi(string(match[2]))
+	}
+	return res
+}
+
 // includeRegexp fixdoc
 var includeRegexp = regexp.MustCompile("(?ms)^\\s*#[ \t]*include\\s*[<\"](\\S+)[\">]")
 
@@ -0,0 +1,46 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package detector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingIncludeError(t *testing.T) {
+	cause := errors.New("exit status 1")
+	sourceFile := paths.New("/build/sketch/My Sketch.ino.cpp")
+	stderr := "In file included from /build/sketch/My Sketch.ino.cpp:1:\n" +
+		"C:\\My Sketch\\header.h:12:10: fatal error: SPI.h: No such file or directory\n" +
+		"   12 | #include <SPI.h>\n" +
+		"compilation terminated.\n"
+
+	err := newMissingIncludeError("SPI.h", sourceFile, []byte(stderr), cause)
+	require.Equal(t, "SPI.h", err.Header)
+	require.Equal(t, `C:\My Sketch\header.h`, err.SourceFile.String())
+	require.Equal(t, 12, err.Line)
+	require.ErrorIs(t, err, cause)
+	require.Equal(t, `missing header file SPI.h, included by C:\My Sketch\header.h:12`, err.Error())
+
+	// Without the preprocessor output the compiled file is reported
+	err = newMissingIncludeError("SPI.h", sourceFile, nil, cause)
+	require.Equal(t, sourceFile, err.SourceFile)
+	require.Equal(t, 0, err.Line)
+	require.Equal(t, "missing header file SPI.h, included by /build/sketch/My Sketch.ino.cpp", err.Error())
+}
@@ -0,0 +1,81 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package diagnostics
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+)
+
+// Apply applies the fix-it hints, all referring to the same file, to the
+// given content of the file. The duplicated fix-it hints, reported by each
+// file including the same header, are applied once, the fix-it hints
+// overlapping another one or outside the content are skipped. The new content
+// and the applied fix-it hints are returned.
+func (f FixIts) Apply(data []byte) ([]byte, FixIts) {
+	lineStarts := []int{0}
+	for i, c := range data {
+		if c == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	offset := func(line, col int) (int, bool) {
+		if line < 1 || line > len(lineStarts) || col < 1 {
+			return 0, false
+		}
+		lineEnd := len(data)
+		if line < len(lineStarts) {
+			lineEnd = lineStarts[line] - 1
+		}
+		res := lineStarts[line-1] + col - 1
+		return res, res <= lineEnd
+	}
+
+	type edit struct {
+		fixit      *FixIt
+		start, end int
+	}
+	edits := []*edit{}
+	for _, fixit := range f {
+		if slices.ContainsFunc(edits, func(e *edit) bool { return *e.fixit == *fixit }) {
+			continue
+		}
+		start, startOk := offset(fixit.StartLine, fixit.StartColumn)
+		end, endOk := offset(fixit.EndLine, fixit.EndColumn)
+		if !startOk || !endOk || end < start {
+			continue
+		}
+		edits = append(edits, &edit{fixit: fixit, start: start, end: end})
+	}
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var res bytes.Buffer
+	var applied FixIts
+	last := 0
+	for _, e := range edits {
+		if e.start < last {
+			// Overlaps the previous fix-it hint
+			continue
+		}
+		res.Write(data[last:e.start])
+		res.WriteString(e.fixit.Replacement)
+		last = e.end
+		applied = append(applied, e.fixit)
+	}
+	res.Write(data[last:])
+	return res.Bytes(), applied
+}
@@ -0,0 +1,41 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixItsApply(t *testing.T) {
+	data := []byte("void loop() {\n  digitalWrite(13, HIGH)\n  dalay(1000);\n}\n")
+	insert := &FixIt{File: "Blink.ino", StartLine: 2, StartColumn: 25, EndLine: 2, EndColumn: 25, Replacement: ";"}
+	replace := &FixIt{File: "Blink.ino", StartLine: 3, StartColumn: 3, EndLine: 3, EndColumn: 8, Replacement: "delay"}
+	duplicate := *replace
+	overlapping := &FixIt{File: "Blink.ino", StartLine: 3, StartColumn: 5, EndLine: 3, EndColumn: 10, Replacement: "x"}
+	outOfRange := &FixIt{File: "Blink.ino", StartLine: 7, StartColumn: 1, EndLine: 7, EndColumn: 1, Replacement: "x"}
+	pastEndOfLine := &FixIt{File: "Blink.ino", StartLine: 1, StartColumn: 20, EndLine: 1, EndColumn: 20, Replacement: "x"}
+
+	res, applied := FixIts{replace, overlapping, insert, &duplicate, outOfRange, pastEndOfLine}.Apply(data)
+	require.Equal(t, "void loop() {\n  digitalWrite(13, HIGH);\n  delay(1000);\n}\n", string(res))
+	require.Equal(t, FixIts{insert, replace}, applied)
+
+	// Insertion at the end of a file without a final newline
+	res, applied = FixIts{{StartLine: 1, StartColumn: 4, EndLine: 1, EndColumn: 4, Replacement: ";"}}.Apply([]byte("x++"))
+	require.Equal(t, "x++;", string(res))
+	require.Len(t, applied, 1)
+}
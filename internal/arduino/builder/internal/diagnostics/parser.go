@@ -38,6 +38,7 @@ type Diagnostic struct {
 	Column      int         `json:"col,omitempty"`
 	Context     FullContext `json:"context,omitempty"`
 	Suggestions Notes       `json:"suggestions,omitempty"`
+	FixIts      FixIts      `json:"fixits,omitempty"`
 }
 
 // Severity is a diagnostic severity
@@ -65,6 +66,21 @@ type Note struct {
 	Column  int    `json:"col,omitempty"`
 }
 
+// FixIts represents a list of FixIt
+type FixIts []*FixIt
+
+// FixIt represents a change of the source code suggested by the compiler to
+// fix a diagnostic: the text in the range from the start line and column to
+// the end line and column (excluded) is replaced with the Replacement.
+type FixIt struct {
+	File        string `json:"file"`
+	StartLine   int    `json:"start_line"`
+	StartColumn int    `json:"start_col"`
+	EndLine     int    `json:"end_line"`
+	EndColumn   int    `json:"end_col"`
+	Replacement string `json:"replacement"`
+}
+
 // FullContext represents a list of Context
 type FullContext []*Context
 
@@ -125,6 +141,7 @@ func (d *Diagnostic) ToRPC() *rpc.CompileDiagnostic {
 		Column:   int64(d.Column),
 		Context:  d.Context.ToRPC(),
 		Notes:    d.Suggestions.ToRPC(),
+		Fixits:   d.FixIts.ToRPC(),
 	}
 }
 
@@ -150,6 +167,30 @@ func (s *Note) ToRPC() *rpc.CompileDiagnosticNote {
 	}
 }
 
+// ToRPC converts a FixIts to a slice of rpc.CompileDiagnosticFixIt
+func (f FixIts) ToRPC() []*rpc.CompileDiagnosticFixIt {
+	var res []*rpc.CompileDiagnosticFixIt
+	for _, fixit := range f {
+		res = append(res, fixit.ToRPC())
+	}
+	return res
+}
+
+// ToRPC converts a FixIt to a rpc.CompileDiagnosticFixIt
+func (f *FixIt) ToRPC() *rpc.CompileDiagnosticFixIt {
+	if f == nil {
+		return nil
+	}
+	return &rpc.CompileDiagnosticFixIt{
+		File:        f.File,
+		StartLine:   int64(f.StartLine),
+		StartColumn: int64(f.StartColumn),
+		EndLine:     int64(f.EndLine),
+		EndColumn:   int64(f.EndColumn),
+		Replacement: f.Replacement,
+	}
+}
+
 // ToRPC converts a FullContext to a slice of rpc.CompileDiagnosticContext
 func (t FullContext) ToRPC() []*rpc.CompileDiagnosticContext {
 	var res []*rpc.CompileDiagnosticContext
@@ -16,6 +16,7 @@
 package diagnostics
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -53,6 +54,12 @@ func parseGccOutput(output []string) ([]*Diagnostic, error) {
 	//
 	//   ·void enableInterrupts()  { NVIC_EnableIRQ(isrId); };
 	//   ···········································^~~~~
+	//
+	// 6. fix-it hints, printed if the -fdiagnostics-parseable-fixits flag is
+	//    given to the compiler, they refer to the last diagnostic even if
+	//    they follow a note:
+	//
+	//   fix-it:"/home/megabug/Arduino/Blink/Blink.ino":{4:1-4:4}:"rand"
 
 	var fullContext FullContext
 	var fullContextRefersTo string
@@ -62,6 +69,15 @@ func parseGccOutput(output []string) ([]*Diagnostic, error) {
 	var res []*Diagnostic
 
 	for _, in := range output {
+		if strings.HasPrefix(in, "fix-it:") {
+			// 6. fix-it hints
+			if fixit := parseGccFixIt(in); fixit != nil && currentDiagnostic != nil {
+				currentDiagnostic.FixIts = append(currentDiagnostic.FixIts, fixit)
+			}
+			currentMessage = nil
+			continue
+		}
+
 		isTrace := false
 		if strings.HasPrefix(in, "In file included from ") {
 			in = strings.TrimPrefix(in, "In file included from ")
@@ -167,6 +183,69 @@ func parseGccOutput(output []string) ([]*Diagnostic, error) {
 	return res, nil
 }
 
+// parseGccFixIt parses a fix-it hint in the format:
+//
+//	fix-it:"FILE":{START_LINE:START_COL-END_LINE:END_COL}:"REPLACEMENT"
+//
+// or returns nil if the line is malformed.
+func parseGccFixIt(in string) *FixIt {
+	file, rest, ok := unquoteGccString(strings.TrimPrefix(in, "fix-it:"))
+	if !ok || !strings.HasPrefix(rest, ":{") {
+		return nil
+	}
+	rng, rest, ok := strings.Cut(rest[2:], "}:")
+	if !ok {
+		return nil
+	}
+	replacement, rest, ok := unquoteGccString(rest)
+	if !ok || rest != "" {
+		return nil
+	}
+	var startLine, startCol, endLine, endCol int
+	if n, err := fmt.Sscanf(rng, "%d:%d-%d:%d", &startLine, &startCol, &endLine, &endCol); err != nil || n != 4 {
+		return nil
+	}
+	return &FixIt{
+		File:        file,
+		StartLine:   startLine,
+		StartColumn: startCol,
+		EndLine:     endLine,
+		EndColumn:   endCol,
+		Replacement: replacement,
+	}
+}
+
+// unquoteGccString unquotes the string at the start of in, escaped by gcc
+// with backslashes and octal escape sequences, and returns the remainder of
+// in.
+func unquoteGccString(in string) (string, string, bool) {
+	if !strings.HasPrefix(in, `"`) {
+		return "", "", false
+	}
+	var res strings.Builder
+	for i := 1; i < len(in); i++ {
+		switch c := in[i]; c {
+		case '"':
+			return res.String(), in[i+1:], true
+		case '\\':
+			if i+3 < len(in) && isOctalDigit(in[i+1]) && isOctalDigit(in[i+2]) && isOctalDigit(in[i+3]) {
+				res.WriteByte((in[i+1]-'0')<<6 | (in[i+2]-'0')<<3 | (in[i+3] - '0'))
+				i += 3
+			} else if i+1 < len(in) {
+				res.WriteByte(in[i+1])
+				i++
+			}
+		default:
+			res.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+func isOctalDigit(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
 func extractFileLineAndColumn(file string) (string, int, int) {
 	split := strings.Split(file, ":")
 	file = split[0]
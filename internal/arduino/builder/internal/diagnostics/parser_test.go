@@ -30,6 +30,7 @@ func TestParser(t *testing.T) {
 	t.Run("Generic002", func(t *testing.T) { runParserTest(t, "test002.txt") })
 	t.Run("Generic003", func(t *testing.T) { runParserTest(t, "test003.txt") })
 	t.Run("Generic004", func(t *testing.T) { runParserTest(t, "test004.txt") })
+	t.Run("FixIts", func(t *testing.T) { runParserTest(t, "test005.txt") })
 }
 
 func runParserTest(t *testing.T, testFile string) {
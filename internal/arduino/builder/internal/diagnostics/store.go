@@ -28,22 +28,24 @@ func NewStore() *Store {
 }
 
 // Parse parses the output coming from a compiler. It then stores the parsed
-// diagnostics in a slice of Diagnostic.
-func (m *Store) Parse(cmdline []string, out []byte) {
+// diagnostics in a slice of Diagnostic. The diagnostics parsed from the given
+// output are returned.
+func (m *Store) Parse(cmdline []string, out []byte) Diagnostics {
 	compiler := DetectCompilerFromCommandLine(
 		cmdline,
 		false, // at the moment compiler-probing is not required
 	)
 	if compiler == nil {
 		logrus.Warnf("Could not detect compiler from: %s", cmdline)
-		return
+		return nil
 	}
 	diags, err := ParseCompilerOutput(compiler, out)
 	if err != nil {
 		logrus.Warnf("Error parsing compiler output: %s", err)
-		return
+		return nil
 	}
 	m.results = append(m.results, diags...)
+	return diags
 }
 
 func (m *Store) Diagnostics() Diagnostics {
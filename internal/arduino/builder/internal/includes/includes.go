@@ -0,0 +1,336 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package includes analyzes the #include directives of the sketch, in the
+// style of include-what-you-use: it reports the headers included and never
+// used, and the headers the sketch relies on without including them, because
+// they're included by another header. The analysis matches the names used by
+// the sketch with the names declared by the headers, as found by the
+// librariesapi parser, so it's an approximation and not a full C++ analysis.
+package includes
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesapi"
+	"github.com/arduino/go-paths-helper"
+)
+
+// IssueKind is the kind of an include issue.
+type IssueKind string
+
+const (
+	// Unused is a header included by the sketch that declares nothing used
+	// by the sketch.
+	Unused IssueKind = "unused"
+	// Missing is a header declaring names used by the sketch, that the
+	// sketch doesn't include directly.
+	Missing IssueKind = "missing"
+)
+
+// Issue is a problem found in the #include directives of a sketch file.
+type Issue struct {
+	Kind IssueKind
+	// File is the file of the sketch with the issue
+	File *paths.Path
+	// Line is the line of the unused #include directive
+	Line int
+	// Header is the header to remove or to include, as written in the
+	// #include directive, e.g. "<Wire.h>"
+	Header string
+	// Symbols are the names declared by the missing header used by the file
+	Symbols []string
+	// IncludedBy is the header, included by the file, that includes the
+	// missing header
+	IncludedBy string
+}
+
+// TreeEntry is a header in the tree of the headers included by a translation
+// unit, as printed by gcc with the -H flag.
+type TreeEntry struct {
+	Depth int
+	Path  *paths.Path
+}
+
+var treeEntryRegexp = regexp.MustCompile(`^(\.+) (.+)$`)
+
+// ParseTree parses the tree of the included headers printed by gcc with the
+// -H flag, the other lines of the output are ignored.
+func ParseTree(out []byte) []*TreeEntry {
+	res := []*TreeEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if m := treeEntryRegexp.FindStringSubmatch(strings.TrimRight(scanner.Text(), "\r")); m != nil {
+			res = append(res, &TreeEntry{Depth: len(m[1]), Path: paths.New(m[2])})
+		}
+	}
+	return res
+}
+
+// Directive is an #include directive.
+type Directive struct {
+	File *paths.Path
+	Line int
+	// Name is the included header, without the delimiters
+	Name string
+	// Text is the included header with the delimiters, e.g. "<Wire.h>"
+	Text string
+}
+
+var directiveRegexp = regexp.MustCompile(`^\s*#\s*include\s*([<"]([^>"]+)[>"])`)
+
+// Directives returns the #include directives of the source.
+func Directives(file *paths.Path, source string) []*Directive {
+	res := []*Directive{}
+	for i, line := range strings.Split(source, "\n") {
+		if m := directiveRegexp.FindStringSubmatch(line); m != nil {
+			res = append(res, &Directive{File: file, Line: i + 1, Name: m[2], Text: m[1]})
+		}
+	}
+	return res
+}
+
+// matches returns true if the directive includes the given header.
+func (d *Directive) matches(header *paths.Path) bool {
+	return headerMatches(header, d.Name)
+}
+
+func headerMatches(header *paths.Path, name string) bool {
+	h := filepath.ToSlash(header.String())
+	name = strings.TrimPrefix(filepath.ToSlash(name), "./")
+	return h == name || strings.HasSuffix(h, "/"+name)
+}
+
+// Component returns the component containing a header: the name of a
+// library, "core" or "sketch", and the header to use in the #include
+// directive of the sketch, with the delimiters, e.g. "<Wire.h>".
+// An empty component is returned for the headers of the toolchain, that are
+// not analyzed.
+type Component func(header *paths.Path) (component, includeName string)
+
+// Analyzer analyzes the includes of the translation units of a sketch, the
+// headers are parsed once and shared by all the translation units.
+type Analyzer struct {
+	component Component
+	headers   map[string]*header
+}
+
+type header struct {
+	path        *paths.Path
+	component   string
+	includeName string
+	names       map[string]bool
+	includes    []string
+}
+
+// NewAnalyzer creates an Analyzer, the component function tells the
+// component of each header.
+func NewAnalyzer(component Component) *Analyzer {
+	return &Analyzer{component: component, headers: map[string]*header{}}
+}
+
+func (a *Analyzer) header(path *paths.Path) *header {
+	if h, ok := a.headers[path.String()]; ok {
+		return h
+	}
+	h := &header{path: path, names: map[string]bool{}}
+	h.component, h.includeName = a.component(path)
+	if h.component != "" {
+		if source, err := path.ReadFile(); err == nil {
+			for _, name := range librariesapi.DeclaredNames(string(source)) {
+				h.names[name] = true
+			}
+			for _, directive := range Directives(path, string(source)) {
+				h.includes = append(h.includes, directive.Name)
+			}
+		}
+	}
+	a.headers[path.String()] = h
+	return h
+}
+
+// Analyze analyzes a translation unit: sources are the files of the sketch
+// compiled in the translation unit, with their content, and tree is the tree
+// of the headers included. The missing headers are reported for the given
+// file.
+func (a *Analyzer) Analyze(file *paths.Path, sources map[*paths.Path]string, tree []*TreeEntry) []*Issue {
+	// The names used by the sources and not declared by the sources
+	used := map[string]bool{}
+	declared := map[string]bool{}
+	directives := []*Directive{}
+	sourcePaths := paths.PathList{}
+	for path := range sources {
+		sourcePaths.Add(path)
+	}
+	sourcePaths.Sort()
+	for _, path := range sourcePaths {
+		for _, name := range librariesapi.UsedNames(sources[path]) {
+			used[name] = true
+		}
+		for _, name := range librariesapi.DeclaredNames(sources[path]) {
+			declared[name] = true
+		}
+		directives = append(directives, Directives(path, sources[path])...)
+	}
+	usedNames := []string{}
+	for name := range used {
+		if !declared[name] {
+			usedNames = append(usedNames, name)
+		}
+	}
+	sort.Strings(usedNames)
+
+	// The graph of the headers, the edges are taken both from the tree and
+	// from the #include directives of the headers, because the tree lists
+	// only the first inclusion of each header.
+	headers := []*header{}
+	direct := []*header{}
+	edges := map[*header][]*header{}
+	parents := []*header{}
+	for _, entry := range tree {
+		h := a.header(entry.Path)
+		if !slices.Contains(headers, h) {
+			headers = append(headers, h)
+		}
+		if entry.Depth > len(parents)+1 {
+			continue
+		}
+		parents = append(parents[:entry.Depth-1], h)
+		if entry.Depth == 1 {
+			if !slices.Contains(direct, h) {
+				direct = append(direct, h)
+			}
+		} else {
+			edges[parents[entry.Depth-2]] = append(edges[parents[entry.Depth-2]], h)
+		}
+	}
+	// The headers already included by another header are listed in the tree
+	// only once, the #include directives of the sources tell if they're
+	// also included directly
+	for _, directive := range directives {
+		for _, h := range headers {
+			if directive.matches(h.path) && !slices.Contains(direct, h) {
+				direct = append(direct, h)
+			}
+		}
+	}
+	for _, h := range headers {
+		for _, name := range h.includes {
+			for _, included := range headers {
+				if included != h && headerMatches(included.path, name) {
+					edges[h] = append(edges[h], included)
+				}
+			}
+		}
+	}
+	reachable := map[*header]map[*header]bool{}
+	reach := func(from *header) map[*header]bool {
+		if res, ok := reachable[from]; ok {
+			return res
+		}
+		res := map[*header]bool{from: true}
+		queue := []*header{from}
+		for len(queue) > 0 {
+			h := queue[0]
+			queue = queue[1:]
+			for _, next := range edges[h] {
+				if !res[next] {
+					res[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		reachable[from] = res
+		return res
+	}
+
+	issues := []*Issue{}
+
+	// The direct includes declaring nothing used, the headers included
+	// automatically, without a directive, are not reported
+	for _, d := range direct {
+		if d.component == "" {
+			continue
+		}
+		idx := slices.IndexFunc(directives, func(directive *Directive) bool { return directive.matches(d.path) })
+		if idx == -1 {
+			continue
+		}
+		isUsed := false
+		for h := range reach(d) {
+			for _, name := range usedNames {
+				if h.names[name] {
+					isUsed = true
+					break
+				}
+			}
+		}
+		if !isUsed {
+			directive := directives[idx]
+			issues = append(issues, &Issue{Kind: Unused, File: directive.File, Line: directive.Line, Header: directive.Text})
+		}
+	}
+
+	// The names declared only by headers included through a header of
+	// another component
+	missing := map[*header]*Issue{}
+	missingHeaders := []*header{}
+	for _, name := range usedNames {
+		var provider, through *header
+		covered := false
+		for _, h := range headers {
+			if h.component == "" || !h.names[name] {
+				continue
+			}
+			for _, d := range direct {
+				if !reach(d)[h] {
+					continue
+				}
+				if d == h || d.component == h.component {
+					covered = true
+				} else if provider == nil {
+					provider, through = h, d
+				}
+			}
+		}
+		if covered || provider == nil {
+			continue
+		}
+		issue, ok := missing[provider]
+		if !ok {
+			includedBy := through.includeName
+			if idx := slices.IndexFunc(directives, func(directive *Directive) bool { return directive.matches(through.path) }); idx != -1 {
+				includedBy = directives[idx].Text
+			}
+			issue = &Issue{Kind: Missing, File: file, Header: provider.includeName, IncludedBy: includedBy}
+			missing[provider] = issue
+			missingHeaders = append(missingHeaders, provider)
+		}
+		issue.Symbols = append(issue.Symbols, name)
+	}
+	sort.SliceStable(missingHeaders, func(i, j int) bool {
+		return missingHeaders[i].includeName < missingHeaders[j].includeName
+	})
+	for _, h := range missingHeaders {
+		issues = append(issues, missing[h])
+	}
+	return issues
+}
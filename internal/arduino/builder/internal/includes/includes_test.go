@@ -0,0 +1,88 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package includes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTree(t *testing.T) {
+	out := []byte(". /core/Arduino.h\n" +
+		".. /toolchain/include/stdint.h\r\n" +
+		". /libraries/Wire/src/Wire.h\n" +
+		"Multiple include guards may be useful for:\n" +
+		"/toolchain/include/stdint.h\n")
+	require.Equal(t, []*TreeEntry{
+		{Depth: 1, Path: paths.New("/core/Arduino.h")},
+		{Depth: 2, Path: paths.New("/toolchain/include/stdint.h")},
+		{Depth: 1, Path: paths.New("/libraries/Wire/src/Wire.h")},
+	}, ParseTree(out))
+}
+
+func TestAnalyze(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	files := map[string]string{
+		"core/Arduino.h":        "#include <stdint.h>\n#include \"HardwareSerial.h\"\nvoid digitalWrite(uint8_t pin, uint8_t val);\n",
+		"core/HardwareSerial.h": "class HardwareSerial {};\nextern HardwareSerial Serial;\n",
+		"toolchain/stdint.h":    "typedef unsigned char uint8_t;\n",
+		"libraries/GFX/GFX.h":   "#include <Wire.h>\nclass Adafruit_GFX {};\n",
+		"libraries/Wire/Wire.h": "class TwoWire {};\nextern TwoWire Wire;\n",
+		"libraries/SPI/SPI.h":   "class SPIClass {};\nextern SPIClass SPI;\n",
+	}
+	for name, content := range files {
+		file := tmp.Join(name)
+		require.NoError(t, file.Parent().MkdirAll())
+		require.NoError(t, file.WriteFile([]byte(content)))
+	}
+	component := func(header *paths.Path) (string, string) {
+		switch header.Parent().String() {
+		case tmp.Join("core").String():
+			return "core", "<Arduino.h>"
+		case tmp.Join("toolchain").String():
+			return "", ""
+		}
+		return header.Parent().Base(), "<" + header.Base() + ">"
+	}
+
+	sketch := tmp.Join("Sketch", "Sketch.ino")
+	sources := map[*paths.Path]string{sketch: "#include <GFX.h>\n#include <SPI.h>\n\n" +
+		"Adafruit_GFX gfx;\n" +
+		"void setup() {\n  Serial.begin(9600);\n  Wire.begin();\n  digitalWrite(13, 1);\n}\n"}
+	tree := []*TreeEntry{
+		{Depth: 1, Path: tmp.Join("core", "Arduino.h")},
+		{Depth: 2, Path: tmp.Join("toolchain", "stdint.h")},
+		{Depth: 2, Path: tmp.Join("core", "HardwareSerial.h")},
+		{Depth: 1, Path: tmp.Join("libraries", "GFX", "GFX.h")},
+		{Depth: 2, Path: tmp.Join("libraries", "Wire", "Wire.h")},
+		{Depth: 1, Path: tmp.Join("libraries", "SPI", "SPI.h")},
+	}
+
+	issues := NewAnalyzer(component).Analyze(sketch, sources, tree)
+	require.Equal(t, []*Issue{
+		{Kind: Unused, File: sketch, Line: 2, Header: "<SPI.h>"},
+		{Kind: Missing, File: sketch, Header: "<Wire.h>", Symbols: []string{"Wire"}, IncludedBy: "<GFX.h>"},
+	}, issues)
+
+	// Including the missing header fixes the issue, even if the header is
+	// listed in the tree only where it's first included
+	sources[sketch] = strings.Replace(sources[sketch], "<SPI.h>", "<Wire.h>", 1)
+	tree = tree[:5]
+	require.Empty(t, NewAnalyzer(component).Analyze(sketch, sources, tree))
+}
@@ -16,10 +16,13 @@
 package logger
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"sync"
+
+	"github.com/sirupsen/logrus"
 )
 
 // BuilderLogger fixdoc
@@ -30,6 +33,9 @@ type BuilderLogger struct {
 
 	verbose       bool
 	warningsLevel string
+
+	// verboseLog receives the verbose output when not in verbose mode
+	verboseLog io.Writer
 }
 
 // New fixdoc
@@ -79,11 +85,63 @@ func (l *BuilderLogger) WriteStderr(data []byte) (int, error) {
 	return l.stderr.Write(data)
 }
 
+// SetVerboseLog sets the writer receiving the output that is printed only in
+// verbose mode, when the verbose mode is disabled. It allows to keep a full
+// verbose log of the build, the output printed in any mode must be copied to
+// the log by the caller.
+func (l *BuilderLogger) SetVerboseLog(w io.Writer) {
+	l.stdLock.Lock()
+	defer l.stdLock.Unlock()
+	l.verboseLog = w
+}
+
+// VerboseInfo prints the message only in verbose mode, otherwise it's written
+// to the verbose log if set.
+func (l *BuilderLogger) VerboseInfo(msg string) {
+	l.VerboseStdout([]byte(msg + "\n"))
+}
+
+// VerboseWarn prints the message on stderr only in verbose mode, otherwise
+// it's written to the verbose log if set.
+func (l *BuilderLogger) VerboseWarn(msg string) {
+	if l.verbose {
+		l.Warn(msg)
+		return
+	}
+	l.writeVerboseLog([]byte(msg + "\n"))
+}
+
+// VerboseStdout writes the data on stdout only in verbose mode, otherwise
+// it's written to the verbose log if set.
+func (l *BuilderLogger) VerboseStdout(data []byte) {
+	if l.verbose {
+		l.WriteStdout(data)
+		return
+	}
+	l.writeVerboseLog(data)
+}
+
+func (l *BuilderLogger) writeVerboseLog(data []byte) {
+	l.stdLock.Lock()
+	defer l.stdLock.Unlock()
+	if l.verboseLog != nil {
+		l.verboseLog.Write(data)
+	}
+}
+
 // Verbose fixdoc
 func (l *BuilderLogger) Verbose() bool {
 	return l.verbose
 }
 
+// VerboseOutput returns true if the verbose output is printed or written to
+// the verbose log.
+func (l *BuilderLogger) VerboseOutput() bool {
+	l.stdLock.Lock()
+	defer l.stdLock.Unlock()
+	return l.verbose || l.verboseLog != nil
+}
+
 // WarningsLevel fixdoc
 func (l *BuilderLogger) WarningsLevel() string {
 	return l.warningsLevel
@@ -98,3 +156,83 @@ func (l *BuilderLogger) Stdout() io.Writer {
 func (l *BuilderLogger) Stderr() io.Writer {
 	return l.stderr
 }
+
+// ToolOutput writes the output of an external tool, the standard output is
+// written only in verbose mode (or to the verbose log). In verbose mode each line is prefixed with
+// the name of the tool and the phase of the build, so that the output of the
+// tools running in parallel can be told apart. The lines are also logged
+// with the tool and the phase as fields.
+func (l *BuilderLogger) ToolOutput(tool, phase string, stdout, stderr []byte) {
+	l.VerboseStdout(toolOutputLines(tool, phase, "stdout", stdout, true))
+	l.WriteStderr(toolOutputLines(tool, phase, "stderr", stderr, l.verbose))
+}
+
+// ToolWriters returns the writers for the output of an external tool, they
+// behave as ToolOutput but write the lines as soon as they are completed.
+// The returned function must be called when the tool terminates, to write the
+// last line if it's not terminated by a newline.
+func (l *BuilderLogger) ToolWriters(tool, phase string) (io.Writer, io.Writer, func()) {
+	stdout := &toolWriter{write: func(line []byte) {
+		l.VerboseStdout(toolOutputLines(tool, phase, "stdout", line, true))
+	}}
+	stderr := &toolWriter{write: func(line []byte) {
+		l.WriteStderr(toolOutputLines(tool, phase, "stderr", line, l.verbose))
+	}}
+	return stdout, stderr, func() {
+		stdout.flush()
+		stderr.flush()
+	}
+}
+
+// ToolPrefix returns the prefix of the lines printed by an external tool
+func ToolPrefix(tool, phase string) string {
+	if phase == "" {
+		return "[" + tool + "] "
+	}
+	return "[" + tool + " " + phase + "] "
+}
+
+func toolOutputLines(tool, phase, stream string, data []byte, prefixed bool) []byte {
+	if len(data) == 0 {
+		return data
+	}
+	prefix := []byte(ToolPrefix(tool, phase))
+	res := &bytes.Buffer{}
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		logrus.WithField("tool", tool).WithField("phase", phase).WithField("stream", stream).Debug(string(bytes.TrimRight(line, "\r\n")))
+		if prefixed {
+			res.Write(prefix)
+		}
+		res.Write(line)
+	}
+	return res.Bytes()
+}
+
+// toolWriter splits the output of a tool in lines
+type toolWriter struct {
+	mux   sync.Mutex
+	buf   bytes.Buffer
+	write func(line []byte)
+}
+
+func (w *toolWriter) Write(data []byte) (int, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.buf.Write(data)
+	if i := bytes.LastIndexByte(w.buf.Bytes(), '\n'); i >= 0 {
+		w.write(w.buf.Next(i + 1))
+	}
+	return len(data), nil
+}
+
+func (w *toolWriter) flush() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.buf.Len() > 0 {
+		w.write(append(w.buf.Bytes(), '\n'))
+		w.buf.Reset()
+	}
+}
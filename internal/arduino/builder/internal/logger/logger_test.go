@@ -0,0 +1,73 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolOutput(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	l := New(stdout, stderr, false, "none")
+	l.ToolOutput("avr-gcc", "compile Blink.ino.cpp", []byte("out\n"), []byte("warning\r\nnote"))
+	require.Empty(t, stdout.String())
+	require.Equal(t, "warning\r\nnote", stderr.String())
+
+	stdout.Reset()
+	stderr.Reset()
+	l = New(stdout, stderr, true, "none")
+	l.ToolOutput("avr-gcc", "compile Blink.ino.cpp", []byte("out\n"), []byte("warning\r\nnote"))
+	require.Equal(t, "[avr-gcc compile Blink.ino.cpp] out\n", stdout.String())
+	require.Equal(t, "[avr-gcc compile Blink.ino.cpp] warning\r\n[avr-gcc compile Blink.ino.cpp] note", stderr.String())
+}
+
+func TestToolWriters(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	l := New(stdout, stderr, true, "none")
+	out, errOut, flush := l.ToolWriters("avr-ar", "")
+	out.Write([]byte("first li"))
+	require.Empty(t, stdout.String())
+	out.Write([]byte("ne\nsecond line\nthird"))
+	require.Equal(t, "[avr-ar] first line\n[avr-ar] second line\n", stdout.String())
+	errOut.Write([]byte("error"))
+	flush()
+	require.Equal(t, "[avr-ar] first line\n[avr-ar] second line\n[avr-ar] third\n", stdout.String())
+	require.Equal(t, "[avr-ar] error\n", stderr.String())
+}
+
+func TestVerboseLog(t *testing.T) {
+	stdout, stderr, verboseLog := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+	l := New(stdout, stderr, false, "none")
+	l.SetVerboseLog(verboseLog)
+	require.True(t, l.VerboseOutput())
+	l.Info("always")
+	l.VerboseInfo("command line")
+	l.ToolOutput("avr-gcc", "link", []byte("out\n"), []byte("error\n"))
+	require.Equal(t, "always\n", stdout.String())
+	require.Equal(t, "error\n", stderr.String())
+	require.Equal(t, "command line\n[avr-gcc link] out\n", verboseLog.String())
+
+	stdout.Reset()
+	verboseLog.Reset()
+	l = New(stdout, stderr, true, "none")
+	l.SetVerboseLog(verboseLog)
+	l.VerboseInfo("command line")
+	require.Equal(t, "command line\n", stdout.String())
+	require.Empty(t, verboseLog.String())
+}
@@ -0,0 +1,301 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package mapfile
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// MaxContributors is the number of largest contributors reported for each memory region
+const MaxContributors = 10
+
+// Report is the memory usage of an executable, split by memory region
+type Report struct {
+	Regions []*Region
+}
+
+// Region is a memory region of the linker script (for example flash or RAM)
+type Region struct {
+	Name   string
+	Origin uint64
+	Length uint64
+	Used   uint64
+	// Sections are the output sections placed in the region
+	Sections []*Section
+	// Contributors are the input files using most of the region, sorted by size
+	Contributors []*Contributor
+}
+
+// Section is an output section of the executable
+type Section struct {
+	Name    string
+	Address uint64
+	Size    uint64
+}
+
+// Contributor is an input file (object file or archive member) of the executable
+type Contributor struct {
+	Name string
+	Size uint64
+}
+
+var (
+	memoryConfigurationRegexp = regexp.MustCompile(`^(\S+)\s+0x([0-9a-fA-F]+)\s+0x([0-9a-fA-F]+)`)
+	outputSectionRegexp       = regexp.MustCompile(`^(\S+)?\s+0x([0-9a-fA-F]+)\s+0x([0-9a-fA-F]+)(?:\s+load address 0x([0-9a-fA-F]+))?\s*$`)
+	inputSectionRegexp        = regexp.MustCompile(`^ (\S+)?\s+0x([0-9a-fA-F]+)\s+0x([0-9a-fA-F]+)(?:\s+(\S.*?))?\s*$`)
+)
+
+// nonAllocatedSectionPrefixes are the prefixes of the sections not loaded in memory
+var nonAllocatedSectionPrefixes = []string{".debug", ".stab", ".comment", ".note", ".ARM.attributes", ".gnu.attributes", ".xt.", ".xtensa.info"}
+
+// Parse parses a map file generated by the GNU linker
+func Parse(data []byte) *Report {
+	var regions []*Region
+	type inputSection struct {
+		size uint64
+		file string
+	}
+	type outputSection struct {
+		Section
+		loadAddress *uint64
+		inputs      []inputSection
+	}
+	var sections []*outputSection
+	var currentSection *outputSection
+
+	const (
+		header = iota
+		memoryConfiguration
+		memoryMap
+	)
+	state := header
+	pendingName := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case line == "Memory Configuration":
+			state = memoryConfiguration
+			continue
+		case line == "Linker script and memory map":
+			state = memoryMap
+			continue
+		}
+
+		switch state {
+		case memoryConfiguration:
+			match := memoryConfigurationRegexp.FindStringSubmatch(line)
+			if match == nil || match[1] == "*default*" {
+				continue
+			}
+			regions = append(regions, &Region{
+				Name:   match[1],
+				Origin: parseHex(match[2]),
+				Length: parseHex(match[3]),
+			})
+
+		case memoryMap:
+			if line == "" {
+				pendingName = ""
+				continue
+			}
+			// Long output and input section names are printed on their own
+			// line, followed by the address and the size on the next line.
+			if pendingName != "" && strings.HasPrefix(line, "  ") {
+				line = pendingName + line
+			}
+			pendingName = ""
+			if name := strings.TrimSpace(line); !strings.Contains(name, " ") && !strings.HasPrefix(line, "  ") {
+				pendingName = line
+				if !strings.HasPrefix(line, " ") {
+					currentSection = nil
+				}
+				continue
+			}
+
+			if !strings.HasPrefix(line, " ") {
+				match := outputSectionRegexp.FindStringSubmatch(line)
+				currentSection = nil
+				if match == nil || !isAllocatedSection(match[1]) {
+					continue
+				}
+				currentSection = &outputSection{Section: Section{
+					Name:    match[1],
+					Address: parseHex(match[2]),
+					Size:    parseHex(match[3]),
+				}}
+				if match[4] != "" {
+					loadAddress := parseHex(match[4])
+					currentSection.loadAddress = &loadAddress
+				}
+				sections = append(sections, currentSection)
+				continue
+			}
+			if currentSection == nil {
+				continue
+			}
+			match := inputSectionRegexp.FindStringSubmatch(line)
+			if match == nil || strings.HasPrefix(match[1], "*(") {
+				continue
+			}
+			size := parseHex(match[3])
+			file := match[4]
+			if match[1] == "*fill*" || file == "" {
+				file = "*fill*"
+			}
+			if size == 0 {
+				continue
+			}
+			currentSection.inputs = append(currentSection.inputs, inputSection{size: size, file: contributorName(file)})
+		}
+	}
+
+	// If the linker script doesn't define memory regions put everything in a
+	// single region covering the whole address space.
+	if len(regions) == 0 {
+		regions = append(regions, &Region{Name: "*default*"})
+	}
+	findRegion := func(address uint64) *Region {
+		for _, region := range regions {
+			if region.Length == 0 || (address >= region.Origin && address-region.Origin < region.Length) {
+				return region
+			}
+		}
+		return nil
+	}
+
+	contributors := map[*Region]map[string]uint64{}
+	addToRegion := func(region *Region, name string, address uint64, section *outputSection) {
+		region.Used += section.Size
+		region.Sections = append(region.Sections, &Section{Name: name, Address: address, Size: section.Size})
+		if contributors[region] == nil {
+			contributors[region] = map[string]uint64{}
+		}
+		for _, input := range section.inputs {
+			contributors[region][input.file] += input.size
+		}
+	}
+	for _, section := range sections {
+		if section.Size == 0 {
+			continue
+		}
+		region := findRegion(section.Address)
+		if region != nil {
+			addToRegion(region, section.Name, section.Address, section)
+		}
+		// The initialized data is also stored at the load address
+		if section.loadAddress != nil && occupiesLoadMemory(section.Name) {
+			if loadRegion := findRegion(*section.loadAddress); loadRegion != nil && loadRegion != region {
+				addToRegion(loadRegion, section.Name, *section.loadAddress, section)
+			}
+		}
+	}
+
+	for region, sizes := range contributors {
+		for name, size := range sizes {
+			region.Contributors = append(region.Contributors, &Contributor{Name: name, Size: size})
+		}
+		sort.Slice(region.Contributors, func(i, j int) bool {
+			a, b := region.Contributors[i], region.Contributors[j]
+			if a.Size != b.Size {
+				return a.Size > b.Size
+			}
+			return a.Name < b.Name
+		})
+		if len(region.Contributors) > MaxContributors {
+			region.Contributors = region.Contributors[:MaxContributors]
+		}
+	}
+	return &Report{Regions: regions}
+}
+
+// ToRPC converts the report to its gRPC representation
+func (r *Report) ToRPC() *rpc.MemoryMapReport {
+	if r == nil {
+		return nil
+	}
+	res := &rpc.MemoryMapReport{}
+	for _, region := range r.Regions {
+		rpcRegion := &rpc.MemoryRegionUsage{
+			Name:   region.Name,
+			Origin: region.Origin,
+			Length: region.Length,
+			Used:   region.Used,
+		}
+		for _, section := range region.Sections {
+			rpcRegion.Sections = append(rpcRegion.Sections, &rpc.MemorySectionUsage{
+				Name:    section.Name,
+				Address: section.Address,
+				Size:    section.Size,
+			})
+		}
+		for _, contributor := range region.Contributors {
+			rpcRegion.LargestContributors = append(rpcRegion.LargestContributors, &rpc.MemoryContributor{
+				Name: contributor.Name,
+				Size: contributor.Size,
+			})
+		}
+		res.Regions = append(res.Regions, rpcRegion)
+	}
+	return res
+}
+
+func isAllocatedSection(name string) bool {
+	if name == "" || name == "/DISCARD/" {
+		return false
+	}
+	for _, prefix := range nonAllocatedSectionPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// occupiesLoadMemory returns false for the sections that are only allocated at
+// runtime, even if the linker reports a load address for them.
+func occupiesLoadMemory(name string) bool {
+	return !strings.HasPrefix(name, ".bss") && !strings.HasPrefix(name, ".noinit") && !strings.HasPrefix(name, ".tbss")
+}
+
+// contributorName returns the base name of the given input file, keeping the
+// archive member if present: "/path/core.a(wiring.c.o)" becomes "core.a(wiring.c.o)".
+func contributorName(file string) string {
+	if file == "*fill*" {
+		return file
+	}
+	member := ""
+	if strings.HasSuffix(file, ")") {
+		if i := strings.LastIndex(file, "("); i > 0 {
+			file, member = file[:i], file[i:]
+		}
+	}
+	return filepath.Base(filepath.FromSlash(file)) + member
+}
+
+func parseHex(s string) uint64 {
+	v, _ := strconv.ParseUint(s, 16, 64)
+	return v
+}
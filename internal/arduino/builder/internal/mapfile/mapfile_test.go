@@ -0,0 +1,87 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package mapfile
+
+import (
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	data, err := paths.New("testdata", "sketch.map").ReadFile()
+	require.NoError(t, err)
+	report := Parse(data)
+	require.Len(t, report.Regions, 3)
+
+	text := report.Regions[0]
+	require.Equal(t, "text", text.Name)
+	require.Equal(t, uint64(0), text.Origin)
+	require.Equal(t, uint64(0x8000), text.Length)
+	// .text plus the initial values of .data, .bss is not loaded
+	require.Equal(t, uint64(0xa7+0x2c), text.Used)
+	require.Equal(t, []*Section{
+		{Name: ".text", Address: 0, Size: 0xa7},
+		{Name: ".data", Address: 0xa7, Size: 0x2c},
+	}, text.Sections)
+	require.Equal(t, []*Contributor{
+		{Name: "S1.ino.cpp.o", Size: 0x10 + 0x1b + 0x27 + 0x28},
+		{Name: "core.a(wiring.c.o)", Size: 0x1e + 0x27 + 0x4},
+		{Name: "*fill*", Size: 0x10},
+	}, text.Contributors)
+
+	ram := report.Regions[1]
+	require.Equal(t, "data", ram.Name)
+	require.Equal(t, uint64(0x800100), ram.Origin)
+	require.Equal(t, uint64(0x2c+0x60), ram.Used)
+	require.Equal(t, []*Section{
+		{Name: ".data", Address: 0x800100, Size: 0x2c},
+		{Name: ".bss", Address: 0x800140, Size: 0x60},
+	}, ram.Sections)
+	require.Equal(t, []*Contributor{
+		{Name: "core.a(wiring.c.o)", Size: 0x4 + 0x40},
+		{Name: "S1.ino.cpp.o", Size: 0x28 + 0x4},
+		{Name: "*fill*", Size: 0x1c},
+	}, ram.Contributors)
+
+	eeprom := report.Regions[2]
+	require.Equal(t, "eeprom", eeprom.Name)
+	require.Equal(t, uint64(0), eeprom.Used)
+	require.Empty(t, eeprom.Sections)
+	require.Empty(t, eeprom.Contributors)
+}
+
+func TestParseWithoutMemoryRegions(t *testing.T) {
+	data := []byte(`Memory Configuration
+
+Name             Origin             Length             Attributes
+*default*        0x0000000000000000 0xffffffffffffffff
+
+Linker script and memory map
+
+.text           0x0000000000001000       0x20
+ .text.a        0x0000000000001000       0x20 /tmp/build/sketch/a.cpp.o
+
+.comment        0x0000000000000000       0x10
+ .comment       0x0000000000000000       0x10 /tmp/build/sketch/a.cpp.o
+`)
+	report := Parse(data)
+	require.Len(t, report.Regions, 1)
+	require.Equal(t, "*default*", report.Regions[0].Name)
+	require.Equal(t, uint64(0x20), report.Regions[0].Used)
+	require.Equal(t, []*Contributor{{Name: "a.cpp.o", Size: 0x20}}, report.Regions[0].Contributors)
+}
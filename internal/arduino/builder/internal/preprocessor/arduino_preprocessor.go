@@ -24,6 +24,7 @@ import (
 
 	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	"github.com/arduino/go-paths-helper"
 	"github.com/arduino/go-properties-orderedmap"
 )
@@ -68,7 +69,7 @@ func PreprocessSketchWithArduinoPreprocessor(
 		return nil, err
 	}
 
-	command, err := paths.NewProcess(nil, parts...)
+	command, err := toolenv.NewProcess(nil, parts...)
 	if err != nil {
 		return nil, err
 	}
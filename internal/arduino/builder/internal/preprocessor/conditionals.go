@@ -0,0 +1,206 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package preprocessor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// ConditionalBranch is a branch of a conditional compilation directive
+// (#if, #ifdef, #ifndef, #elif or #else) of a source file.
+type ConditionalBranch struct {
+	File *paths.Path
+	// Line is the line of the directive opening the branch
+	Line int
+	// EndLine is the line of the directive closing the branch, or 0 if the
+	// branch is not closed in the source file
+	EndLine   int
+	Directive string
+	Condition string
+	// Active is true if the code of the branch is compiled
+	Active bool
+}
+
+var conditionalBranchMarkerRegexp = regexp.MustCompile(`__arduino_conditional_branch_(\d+)__`)
+
+var conditionalDirectiveRegexp = regexp.MustCompile(`^\s*#\s*(if|ifdef|ifndef|elif|elifdef|elifndef|else|endif|line)\b\s*(.*?)\s*$`)
+
+// ConditionalBranches runs the gcc preprocessor on the given source file and reports the
+// branches of the conditional compilation directives taken by the preprocessor. The file
+// is reported as originalFile, unless a #line directive says otherwise.
+func ConditionalBranches(
+	sourceFile, originalFile *paths.Path,
+	includes paths.PathList, buildProperties *properties.Map,
+) ([]*ConditionalBranch, Result, error) {
+	source, err := sourceFile.ReadFile()
+	if err != nil {
+		return nil, Result{}, err
+	}
+	instrumented, branches := instrumentConditionals(string(source), originalFile)
+
+	// The instrumented source is placed next to the original one, so the
+	// relative includes are resolved in the same way
+	ext := sourceFile.Ext()
+	instrumentedFile := sourceFile.Parent().Join(strings.TrimSuffix(sourceFile.Base(), ext) + ".conditionals" + ext)
+	if err := instrumentedFile.WriteFile([]byte(instrumented)); err != nil {
+		return nil, Result{}, err
+	}
+	defer instrumentedFile.Remove()
+
+	tmpDir, err := paths.MkTempDir("", "")
+	if err != nil {
+		return nil, Result{}, err
+	}
+	defer tmpDir.RemoveAll()
+	targetFile := tmpDir.Join("conditionals.cpp")
+
+	result, err := GCC(instrumentedFile, targetFile, includes, buildProperties)
+	if err != nil {
+		return nil, result, err
+	}
+	preprocessed, err := targetFile.ReadFile()
+	if err != nil {
+		return nil, result, err
+	}
+	for _, match := range conditionalBranchMarkerRegexp.FindAllSubmatch(preprocessed, -1) {
+		if i, err := strconv.Atoi(string(match[1])); err == nil && i < len(branches) {
+			branches[i].Active = true
+		}
+	}
+	return branches, result, nil
+}
+
+// instrumentConditionals adds a marker at the beginning of each branch of the conditional
+// compilation directives of the given source. The markers of the active branches are kept
+// in the output of the preprocessor.
+func instrumentConditionals(source string, file *paths.Path) (string, []*ConditionalBranch) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	lines := strings.Split(source, "\n")
+
+	branches := []*ConditionalBranch{}
+	openBranches := []*ConditionalBranch{}
+	out := &strings.Builder{}
+	inComment := false
+	lineNumber := 1
+	for i := 0; i < len(lines); i++ {
+		// Join the continuation lines
+		startLine := lineNumber
+		logicalLine := lines[i]
+		out.WriteString(lines[i])
+		for strings.HasSuffix(logicalLine, `\`) && i+1 < len(lines) {
+			i++
+			lineNumber++
+			logicalLine = strings.TrimSuffix(logicalLine, `\`) + lines[i]
+			out.WriteString("\n" + lines[i])
+		}
+		lineNumber++
+		if i+1 < len(lines) {
+			out.WriteString("\n")
+		}
+
+		var code string
+		code, inComment = stripComments(logicalLine, inComment)
+		match := conditionalDirectiveRegexp.FindStringSubmatch(code)
+		if match == nil {
+			continue
+		}
+		directive, argument := match[1], match[2]
+		switch directive {
+		case "line":
+			// #line <number> ["<file>"]
+			number, name, _ := strings.Cut(argument, " ")
+			if n, err := strconv.Atoi(number); err == nil {
+				lineNumber = n
+			}
+			if name = strings.TrimSpace(name); len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+				file = paths.New(strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(name[1 : len(name)-1]))
+			}
+			continue
+		case "endif":
+			if n := len(openBranches); n > 0 {
+				openBranches[n-1].EndLine = startLine
+				openBranches = openBranches[:n-1]
+			}
+			continue
+		case "elif", "elifdef", "elifndef", "else":
+			if n := len(openBranches); n > 0 {
+				openBranches[n-1].EndLine = startLine
+				openBranches = openBranches[:n-1]
+			}
+		}
+
+		branch := &ConditionalBranch{
+			File:      file,
+			Line:      startLine,
+			Directive: directive,
+			Condition: strings.Join(strings.Fields(argument), " "),
+		}
+		openBranches = append(openBranches, branch)
+		fmt.Fprintf(out, "__arduino_conditional_branch_%d__\n", len(branches))
+		fmt.Fprintf(out, "#line %d %s\n", lineNumber, cStringLiteral(file.String()))
+		branches = append(branches, branch)
+	}
+	return out.String(), branches
+}
+
+// stripComments removes the comments from the given line. inComment is true if the
+// line starts inside a block comment, the returned bool is true if the line ends
+// inside a block comment.
+func stripComments(line string, inComment bool) (string, bool) {
+	res := &strings.Builder{}
+	inString := byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case inComment:
+			if c == '*' && i+1 < len(line) && line[i+1] == '/' {
+				inComment = false
+				i++
+				res.WriteByte(' ')
+			}
+		case inString != 0:
+			res.WriteByte(c)
+			if c == '\\' && i+1 < len(line) {
+				i++
+				res.WriteByte(line[i])
+			} else if c == inString {
+				inString = 0
+			}
+		case c == '/' && i+1 < len(line) && line[i+1] == '/':
+			return res.String(), false
+		case c == '/' && i+1 < len(line) && line[i+1] == '*':
+			inComment = true
+			i++
+		default:
+			if c == '"' || c == '\'' {
+				inString = c
+			}
+			res.WriteByte(c)
+		}
+	}
+	return res.String(), inComment
+}
+
+// cStringLiteral returns the given string as a C string literal.
+func cStringLiteral(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
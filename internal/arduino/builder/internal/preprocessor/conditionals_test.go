@@ -0,0 +1,84 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package preprocessor
+
+import (
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentConditionals(t *testing.T) {
+	source := "#include <Arduino.h>\n" +
+		"#line 1 \"/sketch/Blink.ino\"\n" +
+		"#if defined(ARDUINO_AVR_UNO) \\\n" +
+		"    || defined(ARDUINO_AVR_NANO)\n" +
+		"int led = 13;\n" +
+		"#elif defined(ESP32) // comment\n" +
+		"int led = 2;\n" +
+		"#else\n" +
+		"/*\n" +
+		"#ifdef NOT_A_DIRECTIVE\n" +
+		"*/\n" +
+		"  #  ifndef LED\n" +
+		"int led = 0;\n" +
+		"  #endif\n" +
+		"#endif\n"
+	instrumented, branches := instrumentConditionals(source, paths.New("/build/Blink.ino.cpp"))
+
+	require.Len(t, branches, 4)
+	expected := []struct {
+		line, endLine        int
+		directive, condition string
+	}{
+		{1, 4, "if", "defined(ARDUINO_AVR_UNO) || defined(ARDUINO_AVR_NANO)"},
+		{4, 6, "elif", "defined(ESP32)"},
+		{6, 13, "else", ""},
+		{10, 12, "ifndef", "LED"},
+	}
+	for i, e := range expected {
+		require.Equal(t, "/sketch/Blink.ino", branches[i].File.String())
+		require.Equal(t, e.line, branches[i].Line, "branch %d", i)
+		require.Equal(t, e.endLine, branches[i].EndLine, "branch %d", i)
+		require.Equal(t, e.directive, branches[i].Directive, "branch %d", i)
+		require.Equal(t, e.condition, branches[i].Condition, "branch %d", i)
+	}
+
+	require.Equal(t, "#include <Arduino.h>\n"+
+		"#line 1 \"/sketch/Blink.ino\"\n"+
+		"#if defined(ARDUINO_AVR_UNO) \\\n"+
+		"    || defined(ARDUINO_AVR_NANO)\n"+
+		"__arduino_conditional_branch_0__\n"+
+		"#line 3 \"/sketch/Blink.ino\"\n"+
+		"int led = 13;\n"+
+		"#elif defined(ESP32) // comment\n"+
+		"__arduino_conditional_branch_1__\n"+
+		"#line 5 \"/sketch/Blink.ino\"\n"+
+		"int led = 2;\n"+
+		"#else\n"+
+		"__arduino_conditional_branch_2__\n"+
+		"#line 7 \"/sketch/Blink.ino\"\n"+
+		"/*\n"+
+		"#ifdef NOT_A_DIRECTIVE\n"+
+		"*/\n"+
+		"  #  ifndef LED\n"+
+		"__arduino_conditional_branch_3__\n"+
+		"#line 11 \"/sketch/Blink.ino\"\n"+
+		"int led = 0;\n"+
+		"  #endif\n"+
+		"#endif\n", instrumented)
+}
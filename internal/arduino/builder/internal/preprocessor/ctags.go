@@ -28,6 +28,7 @@ import (
 	"github.com/arduino/arduino-cli/internal/arduino/builder/cpp"
 	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/preprocessor/internal/ctags"
 	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	"github.com/arduino/go-paths-helper"
 	"github.com/arduino/go-properties-orderedmap"
@@ -198,7 +199,7 @@ func RunCTags(sourceFile *paths.Path, buildProperties *properties.Map) ([]byte,
 	if err != nil {
 		return nil, nil, err
 	}
-	proc, err := paths.NewProcess(nil, parts...)
+	proc, err := toolenv.NewProcess(nil, parts...)
 	if err != nil {
 		return nil, nil, err
 	}
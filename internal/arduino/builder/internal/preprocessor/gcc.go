@@ -23,6 +23,8 @@ import (
 
 	f "github.com/arduino/arduino-cli/internal/algorithms"
 	"github.com/arduino/arduino-cli/internal/arduino/builder/cpp"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	"github.com/arduino/go-paths-helper"
 	"github.com/arduino/go-properties-orderedmap"
 )
@@ -70,8 +72,15 @@ func GCC(
 	// Remove -MMD argument if present. Leaving it will make gcc try
 	// to create a /dev/null.d dependency file, which won't work.
 	args = f.Filter(args, f.NotEquals("-MMD"))
+	args = utils.ExtendedLengthArgs(args)
+	if utils.ResponseFilesEnabled(gccBuildProperties) {
+		args, err = utils.UseResponseFile(args, utils.ResponseFilesDir(gccBuildProperties))
+		if err != nil {
+			return Result{}, err
+		}
+	}
 
-	proc, err := paths.NewProcess(nil, args...)
+	proc, err := toolenv.NewProcess(nil, args...)
 	if err != nil {
 		return Result{}, err
 	}
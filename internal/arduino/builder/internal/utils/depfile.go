@@ -0,0 +1,142 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package utils
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/arduino/go-paths-helper"
+)
+
+// DepFile is a dependency file in the make format, as generated by the
+// compiler with the -MMD flag.
+type DepFile struct {
+	// Targets are the targets of the first rule, usually the object file
+	Targets []string
+	// Prerequisites are the prerequisites of the first rule: the source file
+	// followed by the headers included while compiling it
+	Prerequisites []string
+}
+
+// ReadDepFile reads and parses the given dependency file
+func ReadDepFile(file *paths.Path) (*DepFile, error) {
+	data, err := file.ReadFile()
+	if err != nil {
+		return nil, err
+	}
+	return ParseDepFile(string(data))
+}
+
+// ParseDepFile parses a dependency file in the make format. Only the first
+// rule is considered, the empty rules added by the -MP flag for each header
+// are ignored. The escaped spaces, tabs and hashes and the `$$` sequences in
+// the file names are unescaped, the other backslashes are kept as they are
+// since they are the path separators on Windows.
+func ParseDepFile(data string) (*DepFile, error) {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	// Join the continuation lines
+	data = strings.ReplaceAll(data, "\\\n", " ")
+
+	var rule string
+	for _, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) != "" {
+			rule = line
+			break
+		}
+	}
+	if rule == "" {
+		return &DepFile{}, nil
+	}
+
+	res := &DepFile{}
+	targetsDone := false
+	for _, word := range splitDepFileWords(rule) {
+		if targetsDone {
+			res.Prerequisites = append(res.Prerequisites, word)
+			continue
+		}
+		if word == ":" {
+			targetsDone = true
+			continue
+		}
+		if strings.HasSuffix(word, ":") {
+			res.Targets = append(res.Targets, strings.TrimSuffix(word, ":"))
+			targetsDone = true
+			continue
+		}
+		res.Targets = append(res.Targets, word)
+	}
+	if !targetsDone || len(res.Targets) == 0 {
+		return nil, errors.New("missing target in dependency file")
+	}
+	return res, nil
+}
+
+// splitDepFileWords splits the line in words separated by blanks, taking
+// care of the escaped blanks.
+func splitDepFileWords(line string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line) && (line[i+1] == ' ' || line[i+1] == '\t' || line[i+1] == '#'):
+			word.WriteByte(line[i+1])
+			i++
+		case c == '$' && i+1 < len(line) && line[i+1] == '$':
+			word.WriteByte('$')
+			i++
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			word.WriteByte(c)
+		}
+	}
+	flush()
+	return words
+}
+
+// String returns the dependency file in the make format
+func (d *DepFile) String() string {
+	var res strings.Builder
+	for i, target := range d.Targets {
+		if i > 0 {
+			res.WriteString(" ")
+		}
+		res.WriteString(escapeDepFileName(target))
+	}
+	res.WriteString(":")
+	for _, prerequisite := range d.Prerequisites {
+		res.WriteString(" \\\n ")
+		res.WriteString(escapeDepFileName(prerequisite))
+	}
+	res.WriteString("\n")
+	return res.String()
+}
+
+func escapeDepFileName(name string) string {
+	name = strings.ReplaceAll(name, "$", "$$")
+	name = strings.ReplaceAll(name, " ", "\\ ")
+	name = strings.ReplaceAll(name, "\t", "\\\t")
+	return strings.ReplaceAll(name, "#", "\\#")
+}
@@ -0,0 +1,253 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/cespare/xxhash/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// FileSum is the fingerprint of the content of a file.
+type FileSum struct {
+	Size int64
+	// ModTime is used only as a hint to avoid hashing again the files that
+	// have not been touched, it's never compared to the time of other files.
+	ModTime int64
+	Hash    uint64
+	// racy is set when the file was modified too close to the time the
+	// fingerprint was stored, a later change with the same size could have
+	// left the same modification time: the file must always be hashed.
+	racy bool
+}
+
+// racyGranularity is the coarsest timestamp granularity of the supported
+// filesystems (FAT stores the modification times with a 2 seconds resolution,
+// some network filesystems are even coarser).
+const racyGranularity = 3 * time.Second
+
+// FileSums is a set of file fingerprints, indexed by file path. The
+// fingerprints are used in place of the modification times to decide if a
+// build artifact must be rebuilt: comparing modification times is unreliable
+// when the files are copied around, checked out by a VCS, shared by a network
+// filesystem or mounted inside a container.
+type FileSums map[string]FileSum
+
+// SumFile computes the fingerprint of the given file
+func SumFile(file *paths.Path) (FileSum, error) {
+	f, err := file.Open()
+	if err != nil {
+		return FileSum{}, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return FileSum{}, err
+	}
+	h := xxhash.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return FileSum{}, err
+	}
+	return FileSum{Size: stat.Size(), ModTime: stat.ModTime().UnixNano(), Hash: h.Sum64()}, nil
+}
+
+// SumFiles computes the fingerprints of the given files. The fingerprints of
+// the files modified within the timestamp granularity of the current time are
+// marked as racy, as ReadFileSums does for the stored fingerprints.
+func SumFiles(files paths.PathList) (FileSums, error) {
+	racyLimit := time.Now().Add(-racyGranularity).UnixNano()
+	sums := FileSums{}
+	for _, file := range files {
+		sum, err := SumFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sum.racy = sum.ModTime >= racyLimit
+		sums[file.Clean().String()] = sum
+	}
+	return sums, nil
+}
+
+// Match returns true if the fingerprints refer exactly to the given files and
+// the content of the files is unchanged. A file with a different size is
+// changed, a file with the same size and modification time is unchanged
+// (unless the fingerprint is racy, see ReadFileSums), in all the other cases
+// the content of the file is hashed and compared.
+func (sums FileSums) Match(files paths.PathList) bool {
+	seen := map[string]bool{}
+	for _, file := range files {
+		key := file.Clean().String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		sum, ok := sums[key]
+		if !ok {
+			logrus.Debugf("No previous fingerprint for: %v", file)
+			return false
+		}
+		stat, err := file.Stat()
+		if err != nil {
+			logrus.Debugf("Not found: %v", file)
+			return false
+		}
+		if stat.Size() != sum.Size {
+			logrus.Debugf("%v size changed", file)
+			return false
+		}
+		if !sum.racy && stat.ModTime().UnixNano() == sum.ModTime {
+			continue
+		}
+		current, err := SumFile(file)
+		if err != nil || current.Hash != sum.Hash {
+			logrus.Debugf("%v content changed", file)
+			return false
+		}
+	}
+	return len(seen) == len(sums)
+}
+
+// SumsFilePath returns the path of the file storing the fingerprints for the
+// given build artifact, the extension of the artifact is replaced by ".sum".
+func SumsFilePath(artifact *paths.Path) *paths.Path {
+	return artifact.Parent().Join(strings.TrimSuffix(artifact.Base(), artifact.Ext()) + ".sum")
+}
+
+// ReadFileSums reads the fingerprints from the given file. As git does for
+// its index, the fingerprints of the files modified within the timestamp
+// granularity of the write of the sums file are marked as racy: their
+// content is always hashed by Match.
+func ReadFileSums(file *paths.Path) (FileSums, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	racyLimit := stat.ModTime().Add(-racyGranularity).UnixNano()
+
+	sums := FileSums{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Each line has the format: <hash> <size> <modtime> <path>
+		fields := strings.SplitN(scanner.Text(), " ", 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid line in %s: %s", file, scanner.Text())
+		}
+		hash, err1 := strconv.ParseUint(fields[0], 16, 64)
+		size, err2 := strconv.ParseInt(fields[1], 10, 64)
+		modTime, err3 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("invalid line in %s: %s", file, scanner.Text())
+		}
+		sums[fields[3]] = FileSum{Size: size, ModTime: modTime, Hash: hash, racy: modTime >= racyLimit}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// String returns the fingerprints in the format used by the sums files
+func (sums FileSums) String() string {
+	keys := make([]string, 0, len(sums))
+	for key := range sums {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var res strings.Builder
+	for _, key := range keys {
+		sum := sums[key]
+		fmt.Fprintf(&res, "%016x %d %d %s\n", sum.Hash, sum.Size, sum.ModTime, key)
+	}
+	return res.String()
+}
+
+// ContentHash returns an hash of the paths, the sizes and the content hashes
+// of the fingerprinted files, the modification times are ignored.
+func (sums FileSums) ContentHash() string {
+	keys := make([]string, 0, len(sums))
+	for key := range sums {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := xxhash.New()
+	for _, key := range keys {
+		sum := sums[key]
+		fmt.Fprintf(h, "%016x %d %s\n", sum.Hash, sum.Size, key)
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// WriteFile writes the fingerprints to the given file
+func (sums FileSums) WriteFile(file *paths.Path) error {
+	return file.WriteFile([]byte(sums.String()))
+}
+
+// DirContentMatches returns true if the files in the given directories (with
+// the given extensions, if any) are the same files fingerprinted in sumsFile,
+// with the same content.
+func DirContentMatches(dirs paths.PathList, sumsFile *paths.Path, extensions ...string) bool {
+	sums, err := ReadFileSums(sumsFile)
+	if err != nil {
+		return false
+	}
+	files, err := dirsContent(dirs, extensions...)
+	if err != nil {
+		return false
+	}
+	return sums.Match(files)
+}
+
+// WriteDirContentSums fingerprints the files in the given directories (with
+// the given extensions, if any) and writes the result in sumsFile.
+func WriteDirContentSums(dirs paths.PathList, sumsFile *paths.Path, extensions ...string) error {
+	files, err := dirsContent(dirs, extensions...)
+	if err != nil {
+		return err
+	}
+	sums, err := SumFiles(files)
+	if err != nil {
+		return err
+	}
+	return sums.WriteFile(sumsFile)
+}
+
+func dirsContent(dirs paths.PathList, extensions ...string) (paths.PathList, error) {
+	files := paths.NewPathList()
+	for _, dir := range dirs {
+		dirFiles, err := FindFilesInFolder(dir, true, extensions...)
+		if err != nil {
+			return nil, err
+		}
+		files.AddAll(dirFiles)
+	}
+	return files, nil
+}
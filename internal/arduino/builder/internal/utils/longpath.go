@@ -0,0 +1,109 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package utils
+
+import (
+	"runtime"
+	"strings"
+)
+
+// windowsMaxPath is the maximum length of a path that can be used on Windows
+// without the extended-length prefix (MAX_PATH, including the terminating NUL)
+const windowsMaxPath = 260
+
+// pathArgPrefixes are the prefixes of the arguments of the GCC toolchains
+// that are immediately followed by a path, the longest prefixes come first.
+var pathArgPrefixes = []string{"-Wl,-Map,", "-Wl,-Map=", "-idirafter", "-isystem", "-include", "-iquote", "-MF", "-I", "-L", "-o", "@"}
+
+// ExtendedLengthArgs returns the given command line with the arguments that
+// are too long Windows paths, or that are made of a flag immediately followed
+// by a too long path (e.g. -I<path>, -o<path> or @<file>), converted to their
+// extended-length form, since the tools may fail to open them otherwise. The
+// command line is returned unchanged on the other operating systems.
+func ExtendedLengthArgs(commandLine []string) []string {
+	if runtime.GOOS != "windows" {
+		return commandLine
+	}
+	return extendedLengthArgs(commandLine)
+}
+
+func extendedLengthArgs(commandLine []string) []string {
+	res := make([]string, len(commandLine))
+	copy(res, commandLine)
+	for i := 1; i < len(res); i++ {
+		res[i] = extendedLengthArg(res[i])
+	}
+	return res
+}
+
+func extendedLengthArg(arg string) string {
+	if converted := ExtendedLengthPath(arg); converted != arg {
+		return converted
+	}
+	for _, prefix := range pathArgPrefixes {
+		if path, ok := strings.CutPrefix(arg, prefix); ok {
+			return prefix + ExtendedLengthPath(path)
+		}
+	}
+	return arg
+}
+
+// ExtendedLengthPath returns the extended-length form of the given Windows
+// absolute path (the path prefixed with `\\?\`) if it's too long to be used
+// as is, otherwise the path is returned unchanged. The extended-length form
+// allows the tools to open files with paths up to 32767 characters.
+func ExtendedLengthPath(path string) string {
+	// The directories paths are limited to MAX_PATH-12 characters, leaving
+	// room for a 8.3 file name
+	if len(path) < windowsMaxPath-12 || strings.HasPrefix(path, `\\?\`) {
+		return path
+	}
+	// The extended-length paths are not normalized by Windows, so only
+	// backslashes must be used as separators and the "." and ".." elements
+	// must be resolved
+	converted := strings.ReplaceAll(path, "/", `\`)
+	if len(converted) >= 3 && isASCIILetter(converted[0]) && converted[1] == ':' && converted[2] == '\\' {
+		// C:\path\to\file
+		return `\\?\` + converted[:2] + cleanWindowsPath(converted[2:])
+	}
+	if strings.HasPrefix(converted, `\\`) && !strings.HasPrefix(converted, `\\.\`) {
+		// \\server\share\path\to\file
+		return `\\?\UNC` + cleanWindowsPath(converted[1:])
+	}
+	return path
+}
+
+// cleanWindowsPath resolves the "." and ".." elements of the given rooted
+// path, that must start with a backslash
+func cleanWindowsPath(path string) string {
+	res := []string{}
+	for _, elem := range strings.Split(path[1:], `\`) {
+		switch elem {
+		case "", ".":
+		case "..":
+			if len(res) > 0 {
+				res = res[:len(res)-1]
+			}
+		default:
+			res = append(res, elem)
+		}
+	}
+	return `\` + strings.Join(res, `\`)
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,113 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2023 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/cespare/xxhash/v2"
+)
+
+// maxCommandLineLength is the maximum length of a command line that can be
+// safely run without a response file: the limit on Windows is 32767
+// characters, on the other operating systems each argument is limited to
+// 128KiB and the whole command line to a few hundreds KiB.
+var maxCommandLineLength = 128 * 1024
+
+func init() {
+	if runtime.GOOS == "windows" {
+		maxCommandLineLength = 30000
+	}
+}
+
+// ResponseFilesEnabled returns true if the tools of the platform support
+// the response files, this is the default unless the platform property
+// "compiler.response_files" is set to false.
+func ResponseFilesEnabled(buildProperties *properties.Map) bool {
+	return buildProperties.Get("compiler.response_files") != "false"
+}
+
+// ResponseFilesDir returns the directory where the response files of the
+// build are saved.
+func ResponseFilesDir(buildProperties *properties.Map) *paths.Path {
+	if buildPath := buildProperties.GetPath("build.path"); buildPath != nil {
+		return buildPath.Join("rsp")
+	}
+	return paths.TempDir().Join("arduino", "rsp")
+}
+
+// UseResponseFile moves the arguments of the given command line to a response
+// file in the given directory, if the command line is too long to be run.
+// The returned command line refers to the response file with the @file
+// syntax, supported by the GCC toolchains. The name of the response file is
+// derived from its content, so the same file is reused by identical command
+// lines.
+func UseResponseFile(commandLine []string, dir *paths.Path) ([]string, error) {
+	length := 0
+	for _, arg := range commandLine {
+		length += len(arg) + 3 // separator and quotes
+	}
+	if length <= maxCommandLineLength || len(commandLine) < 2 {
+		return commandLine, nil
+	}
+
+	var content strings.Builder
+	for _, arg := range commandLine[1:] {
+		content.WriteString(escapeResponseFileArg(arg))
+		content.WriteString("\n")
+	}
+	data := []byte(content.String())
+
+	responseFile := dir.Join(fmt.Sprintf("%016x.rsp", xxhash.Sum64(data)))
+	if !responseFile.Exist() {
+		if err := dir.MkdirAll(); err != nil {
+			return nil, err
+		}
+		// The file is written atomically, since it may be used by concurrent
+		// compiles
+		tmp, err := paths.WriteToTempFile(data, dir, ".tmp-")
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Rename(tmp.String(), responseFile.String()); err != nil {
+			tmp.Remove()
+			return nil, err
+		}
+	}
+	return []string{commandLine[0], "@" + responseFile.String()}, nil
+}
+
+// escapeResponseFileArg escapes the characters that have a special meaning
+// in a response file: whitespaces, quotes and backslashes.
+func escapeResponseFileArg(arg string) string {
+	if arg == "" {
+		return `""`
+	}
+	var res strings.Builder
+	for _, c := range arg {
+		switch c {
+		case ' ', '\t', '\n', '\r', '\v', '\f', '\'', '"', '\\':
+			res.WriteRune('\\')
+		}
+		res.WriteRune(c)
+	}
+	return res.String()
+}
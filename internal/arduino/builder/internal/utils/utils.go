@@ -28,7 +28,9 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
-// ObjFileIsUpToDate fixdoc
+// ObjFileIsUpToDate returns true if the object file has been compiled from
+// the current content of the source file and of the headers listed in the
+// dependency file.
 func ObjFileIsUpToDate(sourceFile, objectFile, dependencyFile *paths.Path) (bool, error) {
 	logrus.Debugf("Checking previous results for %v (result = %v, dep = %v)", sourceFile, objectFile, dependencyFile)
 	if objectFile == nil || dependencyFile == nil {
@@ -37,14 +39,12 @@ func ObjFileIsUpToDate(sourceFile, objectFile, dependencyFile *paths.Path) (bool
 	}
 
 	sourceFile = sourceFile.Clean()
-	sourceFileStat, err := sourceFile.Stat()
-	if err != nil {
+	if _, err := sourceFile.Stat(); err != nil {
 		return false, err
 	}
 
 	objectFile = objectFile.Clean()
-	objectFileStat, err := objectFile.Stat()
-	if err != nil {
+	if _, err := objectFile.Stat(); err != nil {
 		if os.IsNotExist(err) {
 			logrus.Debugf("Not found: %v", objectFile)
 			return false, nil
@@ -53,8 +53,7 @@ func ObjFileIsUpToDate(sourceFile, objectFile, dependencyFile *paths.Path) (bool
 	}
 
 	dependencyFile = dependencyFile.Clean()
-	dependencyFileStat, err := dependencyFile.Stat()
-	if err != nil {
+	if _, err := dependencyFile.Stat(); err != nil {
 		if os.IsNotExist(err) {
 			logrus.Debugf("Not found: %v", dependencyFile)
 			return false, nil
@@ -62,83 +61,64 @@ func ObjFileIsUpToDate(sourceFile, objectFile, dependencyFile *paths.Path) (bool
 		return false, err
 	}
 
-	if sourceFileStat.ModTime().After(objectFileStat.ModTime()) {
-		logrus.Debugf("%v newer than %v", sourceFile, objectFile)
+	prerequisites, ok := objFilePrerequisites(sourceFile, objectFile, dependencyFile)
+	if !ok {
 		return false, nil
 	}
-	if sourceFileStat.ModTime().After(dependencyFileStat.ModTime()) {
-		logrus.Debugf("%v newer than %v", sourceFile, dependencyFile)
+	sums, err := ReadFileSums(SumsFilePath(objectFile))
+	if err != nil {
+		logrus.WithError(err).Debugf("Failed to read fingerprints of: %v", objectFile)
 		return false, nil
 	}
+	return sums.Match(append(prerequisites, objectFile)), nil
+}
 
-	rows, err := dependencyFile.ReadFileAsLines()
+// WriteObjFileSums fingerprints the object file together with the source file
+// and the headers listed in the dependency file, the fingerprints are used by
+// ObjFileIsUpToDate to check if the object file must be compiled again.
+func WriteObjFileSums(sourceFile, objectFile, dependencyFile *paths.Path) error {
+	sourceFile = sourceFile.Clean()
+	objectFile = objectFile.Clean()
+	sumsFile := SumsFilePath(objectFile)
+	prerequisites, ok := objFilePrerequisites(sourceFile, objectFile, dependencyFile.Clean())
+	if !ok {
+		// The object file will be compiled again in the next build
+		return sumsFile.RemoveAll()
+	}
+	sums, err := SumFiles(append(prerequisites, objectFile))
 	if err != nil {
-		return false, err
+		return err
 	}
+	return sums.WriteFile(sumsFile)
+}
 
-	rows = f.Map(rows, removeEndingBackSlash)
-	rows = f.Map(rows, strings.TrimSpace)
-	rows = f.Map(rows, unescapeDep)
-	rows = f.Filter(rows, f.NotEquals(""))
-
-	if len(rows) == 0 {
-		return true, nil
+// objFilePrerequisites returns the files used to compile the object file, as
+// listed in the dependency file: the source file and the included headers.
+func objFilePrerequisites(sourceFile, objectFile, dependencyFile *paths.Path) (paths.PathList, bool) {
+	depFile, err := ReadDepFile(dependencyFile)
+	if err != nil {
+		// The dependency file is probably truncated or corrupted, trigger a
+		// rebuild of the object file
+		logrus.WithError(err).Debugf("Failed to parse: %v", dependencyFile)
+		return nil, false
 	}
-
-	firstRow := rows[0]
-	if !strings.HasSuffix(firstRow, ":") {
-		logrus.Debugf("No colon in first line of depfile")
-		return false, nil
+	if len(depFile.Targets) == 0 {
+		return paths.NewPathList(sourceFile.String()), true
 	}
-	objFileInDepFile := firstRow[:len(firstRow)-1]
-	if objFileInDepFile != objectFile.String() {
-		logrus.Debugf("Depfile is about different file: %v", objFileInDepFile)
-		return false, nil
+	if !objectFile.EquivalentTo(paths.New(depFile.Targets[0])) {
+		logrus.Debugf("Depfile is about different file: %v", depFile.Targets[0])
+		return nil, false
 	}
 
-	// The first line of the depfile contains the path to the object file to generate.
-	// The second line of the depfile contains the path to the source file.
-	// All subsequent lines contain the header files necessary to compile the object file.
+	// The first prerequisite is the source file, the others are the header
+	// files necessary to compile the object file.
 
 	// If we don't do this check it might happen that trying to compile a source file
 	// that has the same name but a different path wouldn't recreate the object file.
-	if sourceFile.String() != strings.Trim(rows[1], " ") {
-		return false, nil
+	if len(depFile.Prerequisites) == 0 || !sourceFile.EquivalentTo(paths.New(depFile.Prerequisites[0])) {
+		return nil, false
 	}
-
-	rows = rows[1:]
-	for _, row := range rows {
-		depStat, err := os.Stat(row)
-		if err != nil && !os.IsNotExist(err) {
-			// There is probably a parsing error of the dep file
-			// Ignore the error and trigger a full rebuild anyway
-			logrus.WithError(err).Debugf("Failed to read: %v", row)
-			return false, nil
-		}
-		if os.IsNotExist(err) {
-			logrus.Debugf("Not found: %v", row)
-			return false, nil
-		}
-		if depStat.ModTime().After(objectFileStat.ModTime()) {
-			logrus.Debugf("%v newer than %v", row, objectFile)
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
-func removeEndingBackSlash(s string) string {
-	return strings.TrimSuffix(s, "\\")
-}
-
-func unescapeDep(s string) string {
-	s = strings.ReplaceAll(s, "\\ ", " ")
-	s = strings.ReplaceAll(s, "\\\t", "\t")
-	s = strings.ReplaceAll(s, "\\#", "#")
-	s = strings.ReplaceAll(s, "$$", "$")
-	s = strings.ReplaceAll(s, "\\\\", "\\")
-	return s
+	return paths.NewPathList(depFile.Prerequisites...), true
 }
 
 // NormalizeUTF8 byte slice
@@ -198,29 +178,3 @@ func printableArgument(arg string) string {
 func PrintableCommand(parts []string) string {
 	return strings.Join(f.Map(parts, printableArgument), " ")
 }
-
-// DirContentIsOlderThan returns true if the content of the given directory is
-// older than target file. If extensions are given, only the files with these
-// extensions are tested.
-func DirContentIsOlderThan(dir *paths.Path, target *paths.Path, extensions ...string) (bool, error) {
-	targetStat, err := target.Stat()
-	if err != nil {
-		return false, err
-	}
-	targetModTime := targetStat.ModTime()
-
-	files, err := FindFilesInFolder(dir, true, extensions...)
-	if err != nil {
-		return false, err
-	}
-	for _, file := range files {
-		file, err := file.Stat()
-		if err != nil {
-			return false, err
-		}
-		if file.ModTime().After(targetModTime) {
-			return false, nil
-		}
-	}
-	return true, nil
-}
@@ -17,10 +17,12 @@ package utils
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -97,6 +99,8 @@ func TestObjFileIsUpToDateObjNewer(t *testing.T) {
 	defer objFile.RemoveAll()
 	depFile := tempFile(t, "dep")
 	defer depFile.RemoveAll()
+	require.NoError(t, WriteObjFileSums(sourceFile, objFile, depFile))
+	defer SumsFilePath(objFile).RemoveAll()
 
 	upToDate, err := ObjFileIsUpToDate(sourceFile, objFile, depFile)
 	require.NoError(t, err)
@@ -121,6 +125,9 @@ func TestObjFileIsUpToDateDepIsNewer(t *testing.T) {
 
 	data := objFile.String() + ": \\\n\t" + sourceFile.String() + " \\\n\t" + headerFile.String()
 	depFile.WriteFile([]byte(data))
+	require.NoError(t, WriteObjFileSums(sourceFile, objFile, depFile))
+	defer SumsFilePath(objFile).RemoveAll()
+	require.NoError(t, headerFile.WriteFile([]byte("changed")))
 
 	upToDate, err := ObjFileIsUpToDate(sourceFile, objFile, depFile)
 	require.NoError(t, err)
@@ -143,6 +150,8 @@ func TestObjFileIsUpToDateDepIsOlder(t *testing.T) {
 
 	res := objFile.String() + ": \\\n\t" + sourceFile.String() + " \\\n\t" + headerFile.String()
 	depFile.WriteFile([]byte(res))
+	require.NoError(t, WriteObjFileSums(sourceFile, objFile, depFile))
+	defer SumsFilePath(objFile).RemoveAll()
 
 	upToDate, err := ObjFileIsUpToDate(sourceFile, objFile, depFile)
 	require.NoError(t, err)
@@ -172,3 +181,255 @@ func TestObjFileIsUpToDateDepIsWrong(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, upToDate)
 }
+
+func TestObjFileIsUpToDateDepFileWithPhonyTargets(t *testing.T) {
+	sourceFile := tempFile(t, "source")
+	defer sourceFile.RemoveAll()
+	headerFile := tempFile(t, "header")
+	defer headerFile.RemoveAll()
+
+	time.Sleep(time.Second)
+
+	objFile := tempFile(t, "obj")
+	defer objFile.RemoveAll()
+	depFile := tempFile(t, "dep")
+	defer depFile.RemoveAll()
+
+	// Multiple prerequisites on the same line and the empty rules added by -MP
+	res := objFile.String() + ": " + sourceFile.String() + " " + headerFile.String() + "\n\n" + headerFile.String() + ":\n"
+	depFile.WriteFile([]byte(res))
+	require.NoError(t, WriteObjFileSums(sourceFile, objFile, depFile))
+	defer SumsFilePath(objFile).RemoveAll()
+
+	upToDate, err := ObjFileIsUpToDate(sourceFile, objFile, depFile)
+	require.NoError(t, err)
+	require.True(t, upToDate)
+
+	time.Sleep(time.Second)
+	require.NoError(t, headerFile.WriteFile([]byte("changed")))
+	upToDate, err = ObjFileIsUpToDate(sourceFile, objFile, depFile)
+	require.NoError(t, err)
+	require.False(t, upToDate)
+}
+
+func TestObjFileIsUpToDateContentChanges(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	sourceFile := tmp.Join("source.cpp")
+	headerFile := tmp.Join("header.h")
+	objFile := tmp.Join("source.cpp.o")
+	depFile := tmp.Join("source.cpp.d")
+	require.NoError(t, sourceFile.WriteFile([]byte("#include \"header.h\"")))
+	require.NoError(t, headerFile.WriteFile([]byte("int a;")))
+	require.NoError(t, objFile.WriteFile([]byte("object")))
+	dep := &DepFile{Targets: []string{objFile.String()}, Prerequisites: []string{sourceFile.String(), headerFile.String()}}
+	require.NoError(t, depFile.WriteFile([]byte(dep.String())))
+
+	// Without fingerprints the object must be compiled
+	upToDate, err := ObjFileIsUpToDate(sourceFile, objFile, depFile)
+	require.NoError(t, err)
+	require.False(t, upToDate)
+
+	require.NoError(t, WriteObjFileSums(sourceFile, objFile, depFile))
+	require.True(t, SumsFilePath(objFile).EqualsTo(tmp.Join("source.cpp.sum")))
+	upToDate, err = ObjFileIsUpToDate(sourceFile, objFile, depFile)
+	require.NoError(t, err)
+	require.True(t, upToDate)
+
+	// A source file touched, but not changed, doesn't trigger a rebuild
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(sourceFile.String(), future, future))
+	upToDate, err = ObjFileIsUpToDate(sourceFile, objFile, depFile)
+	require.NoError(t, err)
+	require.True(t, upToDate)
+
+	// A header changed, with the same size and an older modification time,
+	// triggers a rebuild
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, headerFile.WriteFile([]byte("int b;")))
+	require.NoError(t, os.Chtimes(headerFile.String(), past, past))
+	upToDate, err = ObjFileIsUpToDate(sourceFile, objFile, depFile)
+	require.NoError(t, err)
+	require.False(t, upToDate)
+
+	// A changed object file triggers a rebuild
+	require.NoError(t, WriteObjFileSums(sourceFile, objFile, depFile))
+	require.NoError(t, objFile.WriteFile([]byte("truncated")))
+	upToDate, err = ObjFileIsUpToDate(sourceFile, objFile, depFile)
+	require.NoError(t, err)
+	require.False(t, upToDate)
+}
+
+func TestFileSums(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	a := tmp.Join("a with spaces.txt")
+	b := tmp.Join("b.txt")
+	require.NoError(t, a.WriteFile([]byte("a")))
+	require.NoError(t, b.WriteFile([]byte("b")))
+
+	sums, err := SumFiles(paths.NewPathList(a.String(), b.String()))
+	require.NoError(t, err)
+	sumsFile := tmp.Join("files.sum")
+	require.NoError(t, sums.WriteFile(sumsFile))
+	read, err := ReadFileSums(sumsFile)
+	require.NoError(t, err)
+	require.Equal(t, sums.String(), read.String())
+
+	require.True(t, read.Match(paths.NewPathList(b.String(), a.String())))
+	// The set of files must be the same
+	require.False(t, read.Match(paths.NewPathList(a.String())))
+	require.False(t, read.Match(paths.NewPathList(a.String(), b.String(), sumsFile.String())))
+
+	require.True(t, DirContentMatches(paths.NewPathList(tmp.String()), sumsFile, ".txt"))
+	require.NoError(t, tmp.Join("c.txt").WriteFile([]byte("c")))
+	require.False(t, DirContentMatches(paths.NewPathList(tmp.String()), sumsFile, ".txt"))
+
+	require.NoError(t, sumsFile.WriteFile([]byte("invalid\n")))
+	_, err = ReadFileSums(sumsFile)
+	require.Error(t, err)
+}
+
+func TestFileSumsRacyModTime(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	file := tmp.Join("file.txt")
+	sumsFile := tmp.Join("file.sum")
+
+	// The content changes without changing the size and the modification
+	// time, as it happens on filesystems with a coarse timestamp granularity
+	require.NoError(t, file.WriteFile([]byte("aaa")))
+	stat, err := file.Stat()
+	require.NoError(t, err)
+	sums, err := SumFiles(paths.NewPathList(file.String()))
+	require.NoError(t, err)
+	require.NoError(t, sums.WriteFile(sumsFile))
+	require.NoError(t, file.WriteFile([]byte("bbb")))
+	require.NoError(t, os.Chtimes(file.String(), stat.ModTime(), stat.ModTime()))
+	read, err := ReadFileSums(sumsFile)
+	require.NoError(t, err)
+	require.False(t, read.Match(paths.NewPathList(file.String())))
+
+	// Same content, the racy fingerprint still matches
+	require.NoError(t, file.WriteFile([]byte("aaa")))
+	require.NoError(t, os.Chtimes(file.String(), stat.ModTime(), stat.ModTime()))
+	require.True(t, read.Match(paths.NewPathList(file.String())))
+
+	// A file modified long before the sums file is not hashed again
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(file.String(), old, old))
+	sums, err = SumFiles(paths.NewPathList(file.String()))
+	require.NoError(t, err)
+	require.NoError(t, sums.WriteFile(sumsFile))
+	read, err = ReadFileSums(sumsFile)
+	require.NoError(t, err)
+	require.False(t, read[file.String()].racy)
+	require.True(t, read.Match(paths.NewPathList(file.String())))
+}
+
+func TestExtendedLengthPath(t *testing.T) {
+	long := strings.Repeat("a", 150) + `\` + strings.Repeat("b", 150)
+	require.Equal(t, `C:\short\path`, ExtendedLengthPath(`C:\short\path`))
+	require.Equal(t, `\\?\C:\dir\`+long+`\file.cpp`, ExtendedLengthPath(`C:\dir\`+long+`\file.cpp`))
+	require.Equal(t, `\\?\C:\dir\`+long+`\file.cpp`, ExtendedLengthPath(`C:/dir/./other/../`+strings.ReplaceAll(long, `\`, "/")+`/file.cpp`))
+	require.Equal(t, `\\?\UNC\server\share\`+long, ExtendedLengthPath(`\\server\share\`+long))
+	require.Equal(t, `\\?\C:\`+long, ExtendedLengthPath(`\\?\C:\`+long))
+	// Not absolute paths or not paths at all are unchanged
+	require.Equal(t, long, ExtendedLengthPath(long))
+	require.Equal(t, "-DLONG="+long, ExtendedLengthPath("-DLONG="+long))
+}
+
+func TestExtendedLengthArgs(t *testing.T) {
+	long := `C:\` + strings.Repeat("a", 150) + `\` + strings.Repeat("b", 150)
+	commandLine := []string{
+		`C:\` + strings.Repeat("t", 250) + `\g++.exe`,
+		"-c", "-Os", "-DLONG=" + long,
+		"-I" + long + `\include`,
+		"-isystem" + long + `\system`,
+		"-o" + long + `\file.o`,
+		"-o", long + `\file.o`,
+		"@" + long + `\file.rsp`,
+		"-Wl,-Map," + long + `\file.map`,
+		`-IC:\short`,
+	}
+	original := append([]string{}, commandLine...)
+	require.Equal(t, []string{
+		commandLine[0],
+		"-c", "-Os", "-DLONG=" + long,
+		`-I\\?\` + long + `\include`,
+		`-isystem\\?\` + long + `\system`,
+		`-o\\?\` + long + `\file.o`,
+		"-o", `\\?\` + long + `\file.o`,
+		`@\\?\` + long + `\file.rsp`,
+		`-Wl,-Map,\\?\` + long + `\file.map`,
+		`-IC:\short`,
+	}, extendedLengthArgs(commandLine))
+	// The given command line is not modified
+	require.Equal(t, original, commandLine)
+}
+
+func TestUseResponseFile(t *testing.T) {
+	dir := paths.New(t.TempDir()).Join("rsp")
+	commandLine := []string{"/opt/tool chain/gcc", "-c", `-I/My Libraries/Bob's "lib"`, `C:\sketch\sketch.cpp`, "-DEMPTY="}
+
+	// Short command lines are unchanged
+	res, err := UseResponseFile(commandLine, dir)
+	require.NoError(t, err)
+	require.Equal(t, commandLine, res)
+	require.False(t, dir.Exist())
+
+	defer func(max int) { maxCommandLineLength = max }(maxCommandLineLength)
+	maxCommandLineLength = 10
+	res, err = UseResponseFile(commandLine, dir)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, "/opt/tool chain/gcc", res[0])
+	require.True(t, strings.HasPrefix(res[1], "@"+dir.String()))
+	data, err := paths.New(res[1][1:]).ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "-c\n"+
+		`-I/My\ Libraries/Bob\'s\ \"lib\"`+"\n"+
+		`C:\\sketch\\sketch.cpp`+"\n"+
+		"-DEMPTY=\n", string(data))
+
+	// The same command line reuses the same response file
+	res2, err := UseResponseFile(commandLine, dir)
+	require.NoError(t, err)
+	require.Equal(t, res, res2)
+	files, err := dir.ReadDir()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	props := properties.NewMap()
+	require.True(t, ResponseFilesEnabled(props))
+	props.Set("compiler.response_files", "false")
+	require.False(t, ResponseFilesEnabled(props))
+}
+
+func TestParseDepFile(t *testing.T) {
+	dep, err := ParseDepFile("/build/sketch/My\\ Sketch.ino.cpp.o: \\\r\n /build/sketch/My\\ Sketch.ino.cpp /lib/a.h \\\r\n /lib/b$$.h /lib/\\#c.h\r\n\r\n/lib/a.h:\r\n/lib/b$$.h:\r\n")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/build/sketch/My Sketch.ino.cpp.o"}, dep.Targets)
+	require.Equal(t, []string{"/build/sketch/My Sketch.ino.cpp", "/lib/a.h", "/lib/b$.h", "/lib/#c.h"}, dep.Prerequisites)
+
+	// Windows paths, the backslashes are not escapes
+	dep, err = ParseDepFile("C:\\build\\core\\main.cpp.o: C:\\core\\main.cpp \\\n C:\\core\\Arduino.h\n")
+	require.NoError(t, err)
+	require.Equal(t, []string{"C:\\build\\core\\main.cpp.o"}, dep.Targets)
+	require.Equal(t, []string{"C:\\core\\main.cpp", "C:\\core\\Arduino.h"}, dep.Prerequisites)
+
+	dep, err = ParseDepFile("a.o b.o : a.c\n")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.o", "b.o"}, dep.Targets)
+	require.Equal(t, []string{"a.c"}, dep.Prerequisites)
+
+	dep, err = ParseDepFile("\n")
+	require.NoError(t, err)
+	require.Empty(t, dep.Targets)
+
+	_, err = ParseDepFile("a.o a.c\n")
+	require.Error(t, err)
+
+	dep = &DepFile{Targets: []string{"/build/My Sketch.o"}, Prerequisites: []string{"/src/My Sketch.cpp", "/lib/#b$.h"}}
+	require.Equal(t, "/build/My\\ Sketch.o: \\\n /src/My\\ Sketch.cpp \\\n /lib/\\#b$$.h\n", dep.String())
+	parsed, err := ParseDepFile(dep.String())
+	require.NoError(t, err)
+	require.Equal(t, dep, parsed)
+}
@@ -0,0 +1,70 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"fmt"
+)
+
+// KeepGoing is the policy used to continue the build after a file fails to
+// compile: the other files of the sketch, of the libraries and of the core
+// are compiled anyway, to report all the errors at once, and the build stops
+// before linking.
+type KeepGoing struct {
+	// MaxErrors is the number of files failing to compile after which the
+	// build stops, 0 means no limit
+	MaxErrors int
+}
+
+// fileFailed records a file that failed to compile
+func (b *Builder) fileFailed() {
+	b.failedMux.Lock()
+	defer b.failedMux.Unlock()
+	b.failedFiles++
+}
+
+// keepGoingAfterErrors returns true if the build must continue after some
+// files failed to compile.
+func (b *Builder) keepGoingAfterErrors() bool {
+	if b.keepGoing == nil {
+		return false
+	}
+	b.failedMux.Lock()
+	defer b.failedMux.Unlock()
+	if b.failedFiles == 0 {
+		// The error is not caused by a compilation
+		return false
+	}
+	return b.keepGoing.MaxErrors <= 0 || b.failedFiles < b.keepGoing.MaxErrors
+}
+
+// compileFailed returns the first of the given errors, with the number of
+// files that failed to compile if the KeepGoing policy is used, or nil if all
+// the errors are nil.
+func (b *Builder) compileFailed(errs ...error) error {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		b.failedMux.Lock()
+		defer b.failedMux.Unlock()
+		if b.keepGoing == nil || b.failedFiles == 0 {
+			return err
+		}
+		return fmt.Errorf(tr("%[1]d files failed to compile: %[2]w"), b.failedFiles, err)
+	}
+	return nil
+}
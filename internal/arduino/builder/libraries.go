@@ -114,23 +114,46 @@ func (b *Builder) compileLibraries(libraries libraries.List, includes []string)
 	defer b.Progress.RemoveSubSteps()
 
 	objectFiles := paths.NewPathList()
+	var compileErr error
 	for _, library := range libraries {
+		libraryBuildPath := b.librariesBuildPath.Join(library.DirName)
+		cacheDir := b.libraryCacheDir(library, includes)
+		if cacheDir != nil {
+			if err := b.restoreCachedObjects(cacheDir, libraryBuildPath); err != nil {
+				b.logIfVerbose(true, tr("Couldn't use the cached objects of library %[1]s: %[2]s", library.Name, err))
+			}
+		}
+
 		libraryObjectFiles, err := b.compileLibrary(library, includes)
 		if err != nil {
-			return nil, err
+			if !b.keepGoingAfterErrors() {
+				return nil, err
+			}
+			if compileErr == nil {
+				compileErr = err
+			}
+			b.Progress.CompleteStep()
+			continue
 		}
 		objectFiles.AddAll(libraryObjectFiles)
 
+		if cacheDir != nil {
+			if err := b.storeCachedObjects(libraryBuildPath, cacheDir); err != nil {
+				b.logIfVerbose(true, tr("Couldn't cache the objects of library %[1]s: %[2]s", library.Name, err))
+			}
+		}
+
 		b.Progress.CompleteStep()
 	}
 
+	if compileErr != nil {
+		return nil, compileErr
+	}
 	return objectFiles, nil
 }
 
 func (b *Builder) compileLibrary(library *libraries.Library, includes []string) (paths.PathList, error) {
-	if b.logger.Verbose() {
-		b.logger.Info(tr(`Compiling library "%[1]s"`, library.Name))
-	}
+	b.logger.VerboseInfo(tr(`Compiling library "%[1]s"`, library.Name))
 	libraryBuildPath := b.librariesBuildPath.Join(library.DirName)
 
 	if err := libraryBuildPath.MkdirAll(); err != nil {
@@ -138,6 +161,7 @@ func (b *Builder) compileLibrary(library *libraries.Library, includes []string)
 	}
 
 	objectFiles := paths.NewPathList()
+	buildProperties := b.libraryBuildProperties(library)
 
 	if library.Precompiled {
 		coreSupportPrecompiled := b.buildProperties.ContainsKey("compiler.libraries.ldflags")
@@ -191,6 +215,7 @@ func (b *Builder) compileLibrary(library *libraries.Library, includes []string)
 			library.SourceDir, libraryBuildPath,
 			true, /** recursive **/
 			includes,
+			buildProperties,
 		)
 		if err != nil {
 			return nil, err
@@ -212,6 +237,7 @@ func (b *Builder) compileLibrary(library *libraries.Library, includes []string)
 			library.SourceDir, libraryBuildPath,
 			false, /** recursive **/
 			includes,
+			buildProperties,
 		)
 		if err != nil {
 			return nil, err
@@ -224,6 +250,7 @@ func (b *Builder) compileLibrary(library *libraries.Library, includes []string)
 				library.UtilityDir, utilityBuildPath,
 				false, /** recursive **/
 				includes,
+				buildProperties,
 			)
 			if err != nil {
 				return nil, err
@@ -235,6 +262,34 @@ func (b *Builder) compileLibrary(library *libraries.Library, includes []string)
 	return objectFiles, nil
 }
 
+// libraryBuildProperties returns the build properties used to compile the
+// sources of the given library: the extra flags set for the library in the
+// sketch project file are added to the compiler flags.
+func (b *Builder) libraryBuildProperties(library *libraries.Library) *properties.Map {
+	flags := b.libraryExtraFlags(library)
+	if flags == "" {
+		return b.buildProperties
+	}
+	buildProperties := b.buildProperties.Clone()
+	for _, key := range []string{"compiler.c.extra_flags", "compiler.cpp.extra_flags", "compiler.S.extra_flags"} {
+		buildProperties.Set(key, strings.TrimSpace(buildProperties.Get(key)+" "+flags))
+	}
+	return buildProperties
+}
+
+// libraryExtraFlags returns the extra compiler flags set for the given
+// library in the sketch project file, the library may be referenced by name
+// or by directory name.
+func (b *Builder) libraryExtraFlags(library *libraries.Library) string {
+	if b.sketch == nil || b.sketch.Project == nil {
+		return ""
+	}
+	if flags := b.sketch.GetLibraryBuildProperties(library.Name); flags != "" {
+		return flags
+	}
+	return b.sketch.GetLibraryBuildProperties(library.DirName)
+}
+
 // removeUnusedCompiledLibraries fixdoc
 func (b *Builder) removeUnusedCompiledLibraries(importedLibraries libraries.List) error {
 	if b.librariesBuildPath.NotExist() {
@@ -291,7 +346,7 @@ func (b *Builder) warnAboutArchIncompatibleLibraries(importedLibraries libraries
 // TODO here we can completly remove this part as it's duplicated in what we can
 // read in the gRPC response
 func (b *Builder) printUsedLibraries(importedLibraries libraries.List) {
-	if !b.logger.Verbose() || len(importedLibraries) == 0 {
+	if !b.logger.VerboseOutput() || len(importedLibraries) == 0 {
 		return
 	}
 
@@ -301,13 +356,13 @@ func (b *Builder) printUsedLibraries(importedLibraries libraries.List) {
 			legacy = tr("(legacy)")
 		}
 		if library.Version.String() == "" {
-			b.logger.Info(
+			b.logger.VerboseInfo(
 				tr("Using library %[1]s in folder: %[2]s %[3]s",
 					library.Name,
 					library.InstallDir,
 					legacy))
 		} else {
-			b.logger.Info(
+			b.logger.VerboseInfo(
 				tr("Using library %[1]s at version %[2]s in folder: %[3]s %[4]s",
 					library.Name,
 					library.Version,
@@ -0,0 +1,175 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/buildcache"
+	"github.com/arduino/go-paths-helper"
+)
+
+// libraryCacheDir returns the directory of the libraries build cache where
+// the objects of the library are saved, or nil if the objects can't be
+// cached. The directory depends on the library path and version, on the
+// include paths resolved for the sketch and on the compile recipes, so the
+// objects are shared by the sketches using the same library, with the same
+// dependencies, board and flags. The content of the unmanaged libraries is
+// hashed too, since they may be changed without changing their version. The
+// cache is not used when warnings are treated as errors, since the warnings
+// of the cached objects are lost.
+func (b *Builder) libraryCacheDir(library *libraries.Library, includes []string) *paths.Path {
+	if b.librariesBuildCachePath == nil || b.clean || b.onlyUpdateCompilationDatabase || library.Precompiled {
+		return nil
+	}
+	if b.warningsAsErrors != nil {
+		return nil
+	}
+
+	// The properties specific of the sketch are removed, to share the
+	// objects between different sketches
+	props := b.libraryBuildProperties(library).Clone()
+	props.Set("compiler.warning_flags", props.Get("compiler.warning_flags."+b.logger.WarningsLevel()))
+	for _, key := range []string{"build.path", "build.source.path", "build.project_name", "sketch_path", "includes", "source_file", "object_file"} {
+		props.Set(key, "")
+	}
+	hash := md5.New()
+	hash.Write([]byte(library.InstallDir.String() + "\n" + library.Version.String() + "\n"))
+	if library.Location == libraries.Unmanaged {
+		files, err := utils.FindFilesInFolder(library.InstallDir, true)
+		if err != nil {
+			return nil
+		}
+		sums, err := utils.SumFiles(files)
+		if err != nil {
+			return nil
+		}
+		hash.Write([]byte(sums.ContentHash() + "\n"))
+	}
+	for _, include := range includes {
+		hash.Write([]byte(include + "\n"))
+	}
+	for _, recipe := range []string{"recipe.c.o.pattern", "recipe.cpp.o.pattern", "recipe.S.o.pattern"} {
+		hash.Write([]byte(props.ExpandPropsInString(props.Get(recipe)) + "\n"))
+	}
+	key := library.DirName + "_" + hex.EncodeToString(hash.Sum(nil))
+
+	dir, err := buildcache.New(b.librariesBuildCachePath).GetOrCreate(key)
+	if err != nil {
+		b.logIfVerbose(true, tr("Couldn't cache library %[1]s: %[2]s", library.Name, err))
+		return nil
+	}
+	return dir
+}
+
+// restoreCachedObjects copies the objects cached in cacheDir to the build
+// path, the objects already in the build path are kept. The dependency files
+// are updated to refer to the copied objects, so the objects whose source
+// file or headers changed are compiled again.
+func (b *Builder) restoreCachedObjects(cacheDir, buildPath *paths.Path) error {
+	return copyObjectFiles(cacheDir, buildPath, func(src, dst *paths.Path) bool {
+		return dst.NotExist()
+	})
+}
+
+// storeCachedObjects copies the objects from the build path to the cache,
+// the objects already cached are replaced if different.
+func (b *Builder) storeCachedObjects(buildPath, cacheDir *paths.Path) error {
+	return copyObjectFiles(buildPath, cacheDir, func(src, dst *paths.Path) bool {
+		srcSum, err := utils.SumFile(src)
+		if err != nil {
+			return false
+		}
+		dstSum, err := utils.SumFile(dst)
+		return err != nil || srcSum.Size != dstSum.Size || srcSum.Hash != dstSum.Hash
+	})
+}
+
+// copyObjectFiles copies the object files, together with their dependency
+// and fingerprints files, from srcDir to dstDir if shouldCopy returns true.
+// The files are written atomically since the cache may be used by concurrent
+// builds.
+func copyObjectFiles(srcDir, dstDir *paths.Path, shouldCopy func(src, dst *paths.Path) bool) error {
+	objects, err := srcDir.ReadDirRecursiveFiltered(nil, paths.FilterSuffixes(".o"))
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		depFile := object.Parent().Join(object.Base()[:len(object.Base())-2] + ".d")
+		rel, err := object.RelFrom(srcDir)
+		if err != nil {
+			return err
+		}
+		dstObject := dstDir.JoinPath(rel)
+		dstDepFile := dstObject.Parent().Join(dstObject.Base()[:len(dstObject.Base())-2] + ".d")
+		if depFile.NotExist() || !shouldCopy(object, dstObject) {
+			continue
+		}
+
+		dep, err := utils.ReadDepFile(depFile)
+		if err != nil || len(dep.Targets) == 0 {
+			continue
+		}
+		dep.Targets = []string{dstObject.String()}
+
+		// The fingerprints are needed to reuse the object
+		sums, err := utils.ReadFileSums(utils.SumsFilePath(object))
+		if err != nil {
+			continue
+		}
+		objectSum, ok := sums[object.Clean().String()]
+		if !ok {
+			continue
+		}
+		delete(sums, object.Clean().String())
+		sums[dstObject.Clean().String()] = objectSum
+
+		if err := dstObject.Parent().MkdirAll(); err != nil {
+			return err
+		}
+		data, err := object.ReadFile()
+		if err != nil {
+			return err
+		}
+		if err := atomicWrite(dstObject, data); err != nil {
+			return err
+		}
+		if err := atomicWrite(dstDepFile, []byte(dep.String())); err != nil {
+			return err
+		}
+		if err := atomicWrite(utils.SumsFilePath(dstObject), []byte(sums.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// atomicWrite writes the data to a temporary file and renames it to dst
+func atomicWrite(dst *paths.Path, data []byte) error {
+	tmp, err := paths.WriteToTempFile(data, dst.Parent(), ".tmp-"+dst.Base())
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.String(), dst.String()); err != nil {
+		tmp.Remove()
+		return err
+	}
+	return nil
+}
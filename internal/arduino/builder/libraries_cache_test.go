@@ -0,0 +1,128 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/logger"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+	semver "go.bug.st/relaxed-semver"
+)
+
+func TestLibraryCacheDir(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	library := &libraries.Library{
+		Name:       "My Library",
+		DirName:    "My_Library",
+		InstallDir: tmp.Join("libraries", "My_Library"),
+		Version:    semver.MustParse("1.2.3"),
+	}
+	newBuilder := func(buildPath, flags string) *Builder {
+		props := properties.NewMap()
+		props.Set("build.path", buildPath)
+		props.Set("compiler.cpp.flags", flags)
+		props.Set("recipe.cpp.o.pattern", `g++ {compiler.cpp.flags} -I{build.path}/sketch {includes} "{source_file}" -o "{object_file}"`)
+		return &Builder{
+			buildProperties:         props,
+			librariesBuildCachePath: tmp.Join("cache"),
+			logger:                  logger.New(io.Discard, io.Discard, false, "none"),
+		}
+	}
+
+	includes := []string{"-I/core", "-I/libraries/Dependency/src"}
+
+	// The objects are shared between sketches
+	dir := newBuilder("/build/sketch1", "-Os").libraryCacheDir(library, includes)
+	require.NotNil(t, dir)
+	require.True(t, dir.IsDir())
+	require.Equal(t, dir, newBuilder("/build/sketch2", "-Os").libraryCacheDir(library, includes))
+	// but not between different flags, dependencies or versions
+	require.NotEqual(t, dir, newBuilder("/build/sketch1", "-O2").libraryCacheDir(library, includes))
+	require.NotEqual(t, dir, newBuilder("/build/sketch1", "-Os").libraryCacheDir(library, []string{"-I/core", "-I/libraries/Dependency_2.0/src"}))
+	library.Version = semver.MustParse("1.2.4")
+	require.NotEqual(t, dir, newBuilder("/build/sketch1", "-Os").libraryCacheDir(library, includes))
+
+	// The content of the unmanaged libraries is part of the key
+	library.Location = libraries.Unmanaged
+	require.NoError(t, library.InstallDir.MkdirAll())
+	source := library.InstallDir.Join("lib.cpp")
+	require.NoError(t, source.WriteFile([]byte("int a;")))
+	dir = newBuilder("/build/sketch1", "-Os").libraryCacheDir(library, includes)
+	require.Equal(t, dir, newBuilder("/build/sketch1", "-Os").libraryCacheDir(library, includes))
+	require.NoError(t, source.WriteFile([]byte("int b;")))
+	require.NotEqual(t, dir, newBuilder("/build/sketch1", "-Os").libraryCacheDir(library, includes))
+
+	library.Precompiled = true
+	require.Nil(t, newBuilder("/build/sketch1", "-Os").libraryCacheDir(library, includes))
+}
+
+func TestLibraryObjectsCache(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	source := tmp.Join("src", "lib.cpp")
+	header := tmp.Join("src", "lib.h")
+	require.NoError(t, source.Parent().MkdirAll())
+	require.NoError(t, source.WriteFile([]byte("#include \"lib.h\"")))
+	require.NoError(t, header.WriteFile([]byte("")))
+	time.Sleep(time.Second)
+
+	// Object compiled by a sketch
+	buildPath1 := tmp.Join("build1", "libraries", "lib")
+	object1 := buildPath1.Join("utility", "lib.cpp.o")
+	require.NoError(t, object1.Parent().MkdirAll())
+	require.NoError(t, object1.WriteFile([]byte("object")))
+	dep := &utils.DepFile{Targets: []string{object1.String()}, Prerequisites: []string{source.String(), header.String()}}
+	require.NoError(t, buildPath1.Join("utility", "lib.cpp.d").WriteFile([]byte(dep.String())))
+	require.NoError(t, utils.WriteObjFileSums(source, object1, buildPath1.Join("utility", "lib.cpp.d")))
+
+	b := &Builder{}
+	cacheDir := tmp.Join("cache", "lib")
+	require.NoError(t, b.storeCachedObjects(buildPath1, cacheDir))
+	cached, err := utils.ReadDepFile(cacheDir.Join("utility", "lib.cpp.d"))
+	require.NoError(t, err)
+	require.Equal(t, []string{cacheDir.Join("utility", "lib.cpp.o").String()}, cached.Targets)
+
+	// The object is reused by another sketch
+	buildPath2 := tmp.Join("build2", "libraries", "lib")
+	require.NoError(t, b.restoreCachedObjects(cacheDir, buildPath2))
+	object2 := buildPath2.Join("utility", "lib.cpp.o")
+	data, err := object2.ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "object", string(data))
+	upToDate, err := utils.ObjFileIsUpToDate(source, object2, buildPath2.Join("utility", "lib.cpp.d"))
+	require.NoError(t, err)
+	require.True(t, upToDate)
+
+	// A changed header triggers the compile of the restored object
+	time.Sleep(time.Second)
+	require.NoError(t, header.WriteFile([]byte("// changed")))
+	upToDate, err = utils.ObjFileIsUpToDate(source, object2, buildPath2.Join("utility", "lib.cpp.d"))
+	require.NoError(t, err)
+	require.False(t, upToDate)
+
+	// The objects already in the build path are not overwritten
+	require.NoError(t, object2.WriteFile([]byte("recompiled")))
+	require.NoError(t, b.restoreCachedObjects(cacheDir, buildPath2))
+	data, err = object2.ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "recompiled", string(data))
+}
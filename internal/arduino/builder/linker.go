@@ -19,15 +19,14 @@ import (
 	"strings"
 
 	f "github.com/arduino/arduino-cli/internal/algorithms"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
 	"github.com/arduino/go-paths-helper"
 )
 
 // link fixdoc
 func (b *Builder) link() error {
 	if b.onlyUpdateCompilationDatabase {
-		if b.logger.Verbose() {
-			b.logger.Info(tr("Skip linking of final executable."))
-		}
+		b.logger.VerboseInfo(tr("Skip linking of final executable."))
 		return nil
 	}
 
@@ -44,9 +43,9 @@ func (b *Builder) link() error {
 	wrapWithDoubleQuotes := func(value string) string { return "\"" + value + "\"" }
 	objectFileList := strings.Join(f.Map(objectFiles.AsStrings(), wrapWithDoubleQuotes), " ")
 
-	// If command line length is too big (> 30000 chars), try to collect the object files into archives
-	// and use that archives to complete the build.
-	if len(objectFileList) > 30000 {
+	// If command line length is too big (> 30000 chars), and the platform doesn't support the response
+	// files, try to collect the object files into archives and use that archives to complete the build.
+	if len(objectFileList) > 30000 && !utils.ResponseFilesEnabled(b.buildProperties) {
 
 		// We must create an object file for each visited directory: this is required because gcc-ar checks
 		// if an object file is already in the archive by looking ONLY at the filename WITHOUT the path, so
@@ -93,5 +92,5 @@ func (b *Builder) link() error {
 		return err
 	}
 
-	return b.execCommand(command)
+	return b.execCommand(command, "link")
 }
@@ -0,0 +1,81 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// applyCustomLinkerScripts sets the linker script and the linker script
+// fragments of the sketch project file in the link recipe. The recipe of the
+// platform must expose the hooks used to pass them to the linker:
+//
+//   - the linker script replaces {build.ldscript.path} (or the commonly used
+//     {build.variant.path}/{build.ldscript}).
+//   - the fragments are added to {compiler.c.elf.extra_flags}, the linker
+//     uses them to augment the main linker script.
+func applyCustomLinkerScripts(buildProperties *properties.Map, sk *sketch.Sketch) error {
+	recipe := buildProperties.Get("recipe.c.combine.pattern")
+	if script := sk.Project.LinkerScript; script != "" {
+		scriptPath, err := sketchLinkerScript(sk, script)
+		if err != nil {
+			return err
+		}
+		switch {
+		case strings.Contains(recipe, "{build.ldscript.path}"):
+		case strings.Contains(recipe, "{build.variant.path}/{build.ldscript}"):
+			recipe = strings.ReplaceAll(recipe, "{build.variant.path}/{build.ldscript}", "{build.ldscript.path}")
+			buildProperties.Set("recipe.c.combine.pattern", recipe)
+		default:
+			return errors.New(tr("The platform doesn't support custom linker scripts: %[1]s doesn't use %[2]s", "recipe.c.combine.pattern", "{build.ldscript.path}"))
+		}
+		buildProperties.SetPath("build.ldscript.path", scriptPath)
+	}
+
+	if len(sk.Project.LinkerScriptFragments) > 0 {
+		if !strings.Contains(recipe, "{compiler.c.elf.extra_flags}") {
+			return errors.New(tr("The platform doesn't support linker script fragments: %[1]s doesn't use %[2]s", "recipe.c.combine.pattern", "{compiler.c.elf.extra_flags}"))
+		}
+		flags := buildProperties.Get("compiler.c.elf.extra_flags")
+		for _, fragment := range sk.Project.LinkerScriptFragments {
+			fragmentPath, err := sketchLinkerScript(sk, fragment)
+			if err != nil {
+				return err
+			}
+			flags += ` "` + fragmentPath.String() + `"`
+		}
+		buildProperties.Set("compiler.c.elf.extra_flags", strings.TrimSpace(flags))
+	}
+	return nil
+}
+
+// sketchLinkerScript returns the absolute path of the given linker script of the sketch
+func sketchLinkerScript(sk *sketch.Sketch, script string) (*paths.Path, error) {
+	scriptPath, err := sk.FullPath.Join(script).Abs()
+	if err != nil {
+		return nil, err
+	}
+	if !scriptPath.Exist() {
+		return nil, fmt.Errorf(tr("linker script %s not found", scriptPath))
+	}
+	return scriptPath, nil
+}
@@ -0,0 +1,115 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// SaveAssemblyListings writes into the given directory the assembly listings, interleaved
+// with the source code, of the compiled object files and of the final executable. The
+// listings of the object files are saved in the "listings" subfolder.
+func (b *Builder) SaveAssemblyListings(dir *paths.Path) error {
+	if b.onlyUpdateCompilationDatabase {
+		return nil
+	}
+	buildProperties, err := objdumpBuildProperties(b.buildProperties)
+	if err != nil {
+		return err
+	}
+
+	objectFiles := paths.NewPathList()
+	objectFiles.AddAll(b.buildArtifacts.sketchObjectFiles)
+	objectFiles.AddAll(b.buildArtifacts.librariesObjectFiles)
+	objectFiles.AddAll(b.buildArtifacts.coreObjectsFiles)
+	for _, objectFile := range objectFiles {
+		rel, err := objectFile.RelFrom(b.buildPath)
+		if err != nil {
+			rel = paths.New(objectFile.Base())
+		}
+		listingFile := dir.Join("listings").JoinPath(rel)
+		listingFile = listingFile.Parent().Join(strings.TrimSuffix(listingFile.Base(), ".o") + ".lst")
+		if err := b.saveAssemblyListing(buildProperties, objectFile, listingFile); err != nil {
+			return err
+		}
+	}
+
+	projectName := buildProperties.Get("build.project_name")
+	if executable := b.buildPath.Join(projectName + ".elf"); executable.Exist() {
+		if err := b.saveAssemblyListing(buildProperties, executable, dir.Join(projectName+".lst")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objdumpBuildProperties returns the build properties with the recipe.objdump.pattern
+// recipe. If the platform doesn't define the recipe, it's generated using the objdump
+// of the toolchain used to compile.
+func objdumpBuildProperties(buildProperties *properties.Map) (*properties.Map, error) {
+	res := buildProperties.Clone()
+	if res.ContainsKey("recipe.objdump.pattern") {
+		return res, nil
+	}
+	if !res.ContainsKey("compiler.objdump.cmd") {
+		gccCmd := res.Get("compiler.c.cmd")
+		if !strings.HasSuffix(gccCmd, "gcc") {
+			return nil, errors.New(tr("%s pattern is missing", "recipe.objdump.pattern"))
+		}
+		res.Set("compiler.objdump.cmd", strings.TrimSuffix(gccCmd, "gcc")+"objdump")
+	}
+	if !res.ContainsKey("compiler.objdump.flags") {
+		res.Set("compiler.objdump.flags", "-d -S -C")
+	}
+	res.Set("recipe.objdump.pattern", `"{compiler.path}{compiler.objdump.cmd}" {compiler.objdump.flags} "{object_file}"`)
+	return res, nil
+}
+
+// saveAssemblyListing runs the recipe.objdump.pattern recipe on the given object file,
+// and writes the output into the listing file.
+func (b *Builder) saveAssemblyListing(buildProperties *properties.Map, objectFile, listingFile *paths.Path) error {
+	properties := buildProperties.Clone()
+	properties.SetPath("object_file", objectFile)
+	command, err := b.prepareCommandForRecipe(properties, "recipe.objdump.pattern", true)
+	if err != nil {
+		return err
+	}
+	if err := listingFile.Parent().MkdirAll(); err != nil {
+		return err
+	}
+	out, err := listingFile.Create()
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	b.logger.VerboseInfo(utils.PrintableCommand(command.GetArgs()))
+	_, stderr, flush := b.logger.ToolWriters(toolenv.ToolName(command.GetArgs()[0]), "listing")
+	defer flush()
+	command.RedirectStdoutTo(out)
+	command.RedirectStderrTo(stderr)
+	if err := command.Run(); err != nil {
+		return fmt.Errorf(tr("Error creating the assembly listing of %[1]s: %[2]s"), objectFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/detector"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesmanager"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesresolver"
+	"github.com/arduino/go-paths-helper"
+)
+
+// LoadedLibraries are the libraries available to the builds for a pair of
+// target and actual platforms, loaded from the libraries directories. They
+// can be passed to NewBuilder to skip the loading of the libraries, as long
+// as the directories don't change.
+type LoadedLibraries struct {
+	manager  *librariesmanager.LibrariesManager
+	resolver *librariesresolver.Cpp
+	warnings []byte
+
+	// The content of the libraries directories and the fingerprints of the
+	// libraries metadata, to detect the libraries added, removed or changed
+	librariesDirs    paths.PathList
+	librariesFolders map[string]string
+	metadataFiles    paths.PathList
+	metadataSums     utils.FileSums
+}
+
+// LoadLibraries loads the libraries available to the builds for the given
+// platforms, from the same directories used by NewBuilder.
+func LoadLibraries(
+	builtInLibrariesDirs *paths.Path, libraryDirs, otherLibrariesDirs paths.PathList,
+	actualPlatform, targetPlatform *cores.PlatformRelease,
+) (*LoadedLibraries, error) {
+	lm, resolver, warnings, err := detector.LibrariesLoader(false, nil,
+		builtInLibrariesDirs, libraryDirs, otherLibrariesDirs,
+		actualPlatform, targetPlatform)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := paths.NewPathList()
+	for _, dir := range []*paths.Path{actualPlatform.GetLibrariesDir(), targetPlatform.GetLibrariesDir(), builtInLibrariesDirs} {
+		if dir != nil {
+			dirs.Add(dir)
+		}
+	}
+	dirs.AddAll(libraryDirs)
+	dirs.AddAll(otherLibrariesDirs)
+	folders := map[string]string{}
+	for _, dir := range dirs {
+		folders[dir.String()] = librariesFolders(dir)
+	}
+
+	metadataFiles := paths.NewPathList()
+	for _, lib := range lm.FindAllInstalled() {
+		if file := lib.InstallDir.Join("library.properties"); file.Exist() {
+			metadataFiles.Add(file)
+		}
+	}
+	metadataSums, err := utils.SumFiles(metadataFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoadedLibraries{
+		manager:          lm,
+		resolver:         resolver,
+		warnings:         warnings,
+		librariesDirs:    dirs,
+		librariesFolders: folders,
+		metadataFiles:    metadataFiles,
+		metadataSums:     metadataSums,
+	}, nil
+}
+
+// IsUpToDate returns true if none of the libraries has been added, removed
+// or changed since they have been loaded. The content of the libraries
+// metadata is compared, as done for the build artifacts, instead of the
+// modification times.
+func (l *LoadedLibraries) IsUpToDate() bool {
+	for _, dir := range l.librariesDirs {
+		if librariesFolders(dir) != l.librariesFolders[dir.String()] {
+			return false
+		}
+	}
+	return l.metadataSums.Match(l.metadataFiles)
+}
+
+// librariesFolders returns the sorted list of the folders in the libraries
+// directory, or an empty string if the directory doesn't exist.
+func librariesFolders(dir *paths.Path) string {
+	folders, err := dir.ReadDir(paths.FilterDirectories())
+	if err != nil {
+		return ""
+	}
+	folders.Sort()
+	names := make([]string, len(folders))
+	for i, folder := range folders {
+		names[i] = folder.Base()
+	}
+	return strings.Join(names, "\n")
+}
@@ -0,0 +1,69 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadedLibraries(t *testing.T) {
+	librariesDir := paths.New(t.TempDir())
+	createLibrary := func(name string) *paths.Path {
+		dir := librariesDir.Join(name)
+		require.NoError(t, dir.MkdirAll())
+		require.NoError(t, dir.Join(name+".h").WriteFile([]byte("")))
+		require.NoError(t, dir.Join("library.properties").WriteFile([]byte("name="+name+"\nversion=1.0.0\n")))
+		return dir
+	}
+	lib := createLibrary("MyLib")
+
+	platform := &cores.PlatformRelease{InstallDir: paths.New(t.TempDir())}
+	libs, err := LoadLibraries(nil, nil, paths.PathList{librariesDir}, platform, platform)
+	require.NoError(t, err)
+	require.Len(t, libs.resolver.AlternativesFor("MyLib.h"), 1)
+	require.True(t, libs.IsUpToDate())
+
+	// A library added to the libraries directory
+	createLibrary("OtherLib")
+	require.False(t, libs.IsUpToDate())
+
+	libs, err = LoadLibraries(nil, nil, paths.PathList{librariesDir}, platform, platform)
+	require.NoError(t, err)
+	require.True(t, libs.IsUpToDate())
+
+	// The modification time of the metadata changed but not the content
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(lib.Join("library.properties").String(), past, past))
+	require.True(t, libs.IsUpToDate())
+
+	// The metadata of a library changed, without changing the size and the
+	// modification time
+	require.NoError(t, lib.Join("library.properties").WriteFile([]byte("name=MyLib\nversion=1.0.1\n")))
+	require.NoError(t, os.Chtimes(lib.Join("library.properties").String(), past, past))
+	require.False(t, libs.IsUpToDate())
+
+	// A library removed from the libraries directory
+	libs, err = LoadLibraries(nil, nil, paths.PathList{librariesDir}, platform, platform)
+	require.NoError(t, err)
+	require.NoError(t, librariesDir.Join("OtherLib").RemoveAll())
+	require.False(t, libs.IsUpToDate())
+}
@@ -0,0 +1,46 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/mapfile"
+	"github.com/arduino/go-paths-helper"
+)
+
+// MemoryMapReport is the memory usage of the executable computed from the linker map file
+type MemoryMapReport = mapfile.Report
+
+// MemoryMapReport parses the linker map file produced by the build and reports the
+// usage of each memory region. The map file is expected in
+// {build.path}/{build.project_name}.map, unless the platform sets a different path
+// with the compiler.map.file property.
+func (b *Builder) MemoryMapReport() (*MemoryMapReport, error) {
+	mapFile := b.buildPath.Join(b.buildProperties.Get("build.project_name") + ".map")
+	if f, ok := b.buildProperties.GetOk("compiler.map.file"); ok {
+		mapFile = paths.New(b.buildProperties.ExpandPropsInString(f))
+	}
+	if !mapFile.Exist() {
+		return nil, errors.New(tr("linker map file not found: %s", mapFile))
+	}
+	data, err := mapFile.ReadFile()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tr("reading linker map file"), err)
+	}
+	return mapfile.Parse(data), nil
+}
@@ -0,0 +1,170 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/marcinbor85/gohex"
+)
+
+// maxMergedImageSize is the maximum size of the merged image, it prevents
+// the creation of huge files when the offsets of the images are far apart.
+const maxMergedImageSize = 64 * 1024 * 1024
+
+// mergeImage is an image to be placed in the merged image
+type mergeImage struct {
+	file   *paths.Path
+	offset uint32
+}
+
+// mergeImages combines the images declared by the platform with the
+// `build.merge.image.N.file` and `build.merge.image.N.offset` properties in a
+// single flashable image, {build.path}/{build.project_name}.merged.bin.
+// If the platform defines the `recipe.merge.pattern` recipe (for example to
+// run esptool merge_bin or srec_cat) the recipe is run to produce the image,
+// otherwise the images are merged by the builder.
+func (b *Builder) mergeImages() error {
+	if b.onlyUpdateCompilationDatabase {
+		return nil
+	}
+	images, err := parseMergeImages(b.buildProperties)
+	if err != nil || len(images) == 0 {
+		return err
+	}
+
+	mergedImagePath := b.buildPath.Join(b.buildProperties.Get("build.project_name") + ".merged.bin")
+	b.buildProperties.SetPath("build.merge.output", mergedImagePath)
+	var imagesArgs []string
+	for _, image := range images {
+		imagesArgs = append(imagesArgs, fmt.Sprintf(`0x%x "%s"`, image.offset, image.file))
+	}
+	b.buildProperties.Set("build.merge.images", strings.Join(imagesArgs, " "))
+
+	b.logIfVerbose(false, tr("Merging images..."))
+	if b.buildProperties.ContainsKey("recipe.merge.pattern") {
+		return b.RunRecipe("recipe.merge.", ".pattern", true)
+	}
+
+	segments, err := loadMergeImagesSegments(images)
+	if err != nil {
+		return err
+	}
+	var baseAddress uint32 = math.MaxUint32
+	for _, segment := range segments {
+		baseAddress = min(baseAddress, segment.Address)
+	}
+	if base, ok := b.buildProperties.GetOk("build.merge.base_address"); ok {
+		address, err := strconv.ParseUint(base, 0, 32)
+		if err != nil {
+			return errors.New(tr("invalid value for build.merge.base_address: %s", base))
+		}
+		baseAddress = uint32(address)
+	}
+	data, err := mergeImagesSegments(segments, baseAddress)
+	if err != nil {
+		return err
+	}
+	return mergedImagePath.WriteFile(data)
+}
+
+// parseMergeImages returns the images to merge declared in the build
+// properties, sorted by offset. The files of the images must exist.
+func parseMergeImages(buildProperties *properties.Map) ([]*mergeImage, error) {
+	images := []*mergeImage{}
+	for _, props := range buildProperties.ExtractSubIndexSets("build.merge.image") {
+		file := buildProperties.ExpandPropsInString(props.Get("file"))
+		if file == "" {
+			return nil, errors.New(tr("missing file of image to merge"))
+		}
+		imagePath := paths.New(file)
+		if imagePath.NotExist() {
+			return nil, errors.New(tr("image to merge %s not found", imagePath))
+		}
+		image := &mergeImage{file: imagePath}
+		if offset, ok := props.GetOk("offset"); ok {
+			address, err := strconv.ParseUint(buildProperties.ExpandPropsInString(offset), 0, 32)
+			if err != nil {
+				return nil, errors.New(tr("invalid offset %[1]s of image %[2]s", offset, imagePath))
+			}
+			image.offset = uint32(address)
+		} else if imagePath.Ext() != ".hex" {
+			return nil, errors.New(tr("missing offset of image %s", imagePath))
+		}
+		images = append(images, image)
+	}
+	sort.SliceStable(images, func(i, j int) bool { return images[i].offset < images[j].offset })
+	return images, nil
+}
+
+// loadMergeImagesSegments reads the data of the images. Raw binary images are
+// placed at their offset, while Intel HEX images use the addresses in the
+// file, shifted by the offset of the image.
+func loadMergeImagesSegments(images []*mergeImage) ([]gohex.DataSegment, error) {
+	segments := []gohex.DataSegment{}
+	for _, image := range images {
+		content, err := image.file.ReadFile()
+		if err != nil {
+			return nil, err
+		}
+		if image.file.Ext() != ".hex" {
+			segments = append(segments, gohex.DataSegment{Address: image.offset, Data: content})
+			continue
+		}
+		hex := gohex.NewMemory()
+		if err := hex.ParseIntelHex(bytes.NewReader(content)); err != nil {
+			return nil, fmt.Errorf("%s: %w", image.file, err)
+		}
+		for _, segment := range hex.GetDataSegments() {
+			if uint64(segment.Address)+uint64(image.offset) > math.MaxUint32 {
+				return nil, errors.New(tr("invalid offset %[1]s of image %[2]s", fmt.Sprintf("0x%x", image.offset), image.file))
+			}
+			segments = append(segments, gohex.DataSegment{Address: segment.Address + image.offset, Data: segment.Data})
+		}
+	}
+	return segments, nil
+}
+
+// mergeImagesSegments places the segments in a single binary starting at the
+// given base address, the gaps between the segments are filled with 0xFF.
+func mergeImagesSegments(segments []gohex.DataSegment, baseAddress uint32) ([]byte, error) {
+	merged := gohex.NewMemory()
+	lastAddress := uint64(baseAddress)
+	base := fmt.Sprintf("0x%x", baseAddress)
+	for _, segment := range segments {
+		address := fmt.Sprintf("0x%x", segment.Address)
+		end := uint64(segment.Address) + uint64(len(segment.Data))
+		if segment.Address < baseAddress {
+			return nil, errors.New(tr("image data at %[1]s is before the base address %[2]s", address, base))
+		}
+		if end > math.MaxUint32 || end-uint64(baseAddress) > maxMergedImageSize {
+			return nil, errors.New(tr("image data at %[1]s is too far from the base address %[2]s", address, base))
+		}
+		if err := merged.AddBinary(segment.Address, segment.Data); err != nil {
+			return nil, errors.New(tr("image data at %s overlaps with another image", address))
+		}
+		lastAddress = max(lastAddress, end)
+	}
+	return merged.ToBinary(baseAddress, uint32(lastAddress-uint64(baseAddress)), 0xFF), nil
+}
@@ -28,9 +28,7 @@ func (b *Builder) preprocessSketch(includes paths.PathList) error {
 		b.buildProperties, b.onlyUpdateCompilationDatabase, b.logger.Verbose(),
 	)
 	if result != nil {
-		if b.logger.Verbose() {
-			b.logger.WriteStdout(result.Stdout())
-		}
+		b.logger.VerboseStdout(result.Stdout())
 		b.logger.WriteStdout(result.Stderr())
 		b.diagnosticStore.Parse(result.Args(), result.Stderr())
 	}
@@ -43,13 +43,12 @@ func (b *Builder) RunRecipe(prefix, suffix string, skipIfOnlyUpdatingCompilation
 		}
 
 		if b.onlyUpdateCompilationDatabase && skipIfOnlyUpdatingCompilationDatabase {
-			if b.logger.Verbose() {
-				b.logger.Info(tr("Skipping: %[1]s", strings.Join(command.GetArgs(), " ")))
-			}
+			b.logger.VerboseInfo(tr("Skipping: %[1]s", strings.Join(command.GetArgs(), " ")))
 			return nil
 		}
 
-		if err := b.execCommand(command); err != nil {
+		phase := strings.TrimSuffix(strings.TrimPrefix(recipe, "recipe."), ".pattern")
+		if err := b.execCommand(command, phase); err != nil {
 			return err
 		}
 	}
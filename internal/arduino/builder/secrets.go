@@ -0,0 +1,130 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/cpp"
+)
+
+// SecretsHeaderFileName is the name of the header generated in the sketch
+// build folder by the `header` secrets storage.
+const SecretsHeaderFileName = "arduino_secrets.h"
+
+var validSecretName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateSecret returns an error if the given secret name can not be used as
+// a C identifier or if the value spans multiple lines.
+func ValidateSecret(name, value string) error {
+	if !validSecretName.MatchString(name) {
+		return errors.New(tr("invalid secret name %s: only letters, digits and underscores are allowed", name))
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return errors.New(tr("invalid value for secret %s: new lines are not allowed", name))
+	}
+	return nil
+}
+
+// secretsStorage returns the storage used for the secrets, selected by the
+// platform with the `build.secrets.storage` property:
+//   - `header` (default) generates the arduino_secrets.h header in the sketch
+//     build folder, each secret is defined as a SECRET_<NAME> macro;
+//   - `recipe` saves the secrets in a temporary file, passed as
+//     {build.secrets.file} to the `recipe.secrets.pattern` recipe, run after
+//     the objcopy recipes. The platform may use it to write the secrets in a
+//     reserved flash region of the binary.
+func (b *Builder) secretsStorage() (string, error) {
+	storage := b.buildProperties.Get("build.secrets.storage")
+	switch storage {
+	case "", "header":
+		return "header", nil
+	case "recipe":
+		return storage, nil
+	default:
+		return "", errors.New(tr("invalid value for build.secrets.storage: %s", storage))
+	}
+}
+
+// generateSecretsHeader writes the secrets header in the sketch build folder,
+// overriding the arduino_secrets.h provided by the sketch, if any.
+func (b *Builder) generateSecretsHeader() error {
+	if len(b.secrets) == 0 {
+		return nil
+	}
+	if storage, err := b.secretsStorage(); err != nil || storage != "header" {
+		return err
+	}
+	var header strings.Builder
+	header.WriteString("// Generated by arduino-cli from the secrets passed at build time, do not edit.\n")
+	header.WriteString("#pragma once\n")
+	for _, name := range sortedSecretNames(b.secrets) {
+		fmt.Fprintf(&header, "#define SECRET_%s %s\n", strings.ToUpper(name), cpp.QuoteString(b.secrets[name]))
+	}
+	return b.sketchBuildPath.Join(SecretsHeaderFileName).WriteFile([]byte(header.String()))
+}
+
+// runSecretsRecipe runs the `recipe.secrets.pattern` recipe with the secrets
+// saved in a temporary file, that is removed right after.
+func (b *Builder) runSecretsRecipe() error {
+	if len(b.secrets) == 0 {
+		return nil
+	}
+	if storage, err := b.secretsStorage(); err != nil || storage != "recipe" {
+		return err
+	}
+	if !b.buildProperties.ContainsKey("recipe.secrets.pattern") {
+		return errors.New(tr("missing recipe.secrets.pattern required by the recipe secrets storage"))
+	}
+
+	secretsFile := b.buildPath.Join("secrets.txt")
+	var data strings.Builder
+	for _, name := range sortedSecretNames(b.secrets) {
+		fmt.Fprintf(&data, "%s=%s\n", name, b.secrets[name])
+	}
+	if err := secretsFile.WriteFile([]byte(data.String())); err != nil {
+		return err
+	}
+	defer secretsFile.Remove()
+
+	b.buildProperties.SetPath("build.secrets.file", secretsFile)
+	defer b.buildProperties.Remove("build.secrets.file")
+	return b.RunRecipe("recipe.secrets.", ".pattern", true)
+}
+
+// removeSecrets removes the secrets header from the build folder, so the
+// secrets are not left on disk after the build.
+func (b *Builder) removeSecrets() {
+	if len(b.secrets) == 0 {
+		return
+	}
+	if header := b.sketchBuildPath.Join(SecretsHeaderFileName); header.Exist() {
+		header.Remove()
+	}
+}
+
+func sortedSecretNames(secrets map[string]string) []string {
+	res := []string{}
+	for name := range secrets {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,60 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSecret(t *testing.T) {
+	require.NoError(t, ValidateSecret("SSID", "my network"))
+	require.NoError(t, ValidateSecret("_pass2", ""))
+	require.Error(t, ValidateSecret("2PASS", "x"))
+	require.Error(t, ValidateSecret("MY-PASS", "x"))
+	require.Error(t, ValidateSecret("PASS", "line1\nline2"))
+}
+
+func TestSecretsHeader(t *testing.T) {
+	sketchBuildPath := paths.New(t.TempDir())
+	b := &Builder{
+		sketchBuildPath: sketchBuildPath,
+		buildProperties: properties.NewMap(),
+		secrets:         map[string]string{"ssid": `my "home"`, "PASS": `a\b`},
+	}
+	require.NoError(t, b.generateSecretsHeader())
+	header, err := sketchBuildPath.Join(SecretsHeaderFileName).ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "// Generated by arduino-cli from the secrets passed at build time, do not edit.\n"+
+		"#pragma once\n"+
+		"#define SECRET_PASS \"a\\\\b\"\n"+
+		"#define SECRET_SSID \"my \\\"home\\\"\"\n", string(header))
+
+	b.removeSecrets()
+	require.False(t, sketchBuildPath.Join(SecretsHeaderFileName).Exist())
+
+	// With the recipe storage no header is generated
+	b.buildProperties.Set("build.secrets.storage", "recipe")
+	require.NoError(t, b.generateSecretsHeader())
+	require.False(t, sketchBuildPath.Join(SecretsHeaderFileName).Exist())
+	require.Error(t, b.runSecretsRecipe(), "missing recipe.secrets.pattern")
+
+	b.buildProperties.Set("build.secrets.storage", "eeprom")
+	require.Error(t, b.generateSecretsHeader())
+}
@@ -21,9 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 
 	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/utils"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/arduino/go-properties-orderedmap"
 )
@@ -77,12 +80,12 @@ func (b *Builder) checkSizeAdvanced() (ExecutablesFileSections, error) {
 	if err != nil {
 		return nil, errors.New(tr("Error while determining sketch size: %s", err))
 	}
-	if b.logger.Verbose() {
-		b.logger.Info(utils.PrintableCommand(command.GetArgs()))
-	}
+	b.logger.VerboseInfo(utils.PrintableCommand(command.GetArgs()))
 	out := &bytes.Buffer{}
+	_, stderr, flush := b.logger.ToolWriters(toolenv.ToolName(command.GetArgs()[0]), "advanced_size")
+	defer flush()
 	command.RedirectStdoutTo(out)
-	command.RedirectStderrTo(b.logger.Stderr())
+	command.RedirectStderrTo(stderr)
 	if err := command.Start(); err != nil {
 		return nil, errors.New(tr("Error while determining sketch size: %s", err))
 	}
@@ -214,12 +217,12 @@ func (b *Builder) execSizeRecipe(properties *properties.Map) (textSize int, data
 		resErr = fmt.Errorf(tr("Error while determining sketch size: %s"), err)
 		return
 	}
-	if b.logger.Verbose() {
-		b.logger.Info(utils.PrintableCommand(command.GetArgs()))
-	}
+	b.logger.VerboseInfo(utils.PrintableCommand(command.GetArgs()))
 	commandStdout := &bytes.Buffer{}
+	_, stderr, flush := b.logger.ToolWriters(toolenv.ToolName(command.GetArgs()[0]), "size")
+	defer flush()
 	command.RedirectStdoutTo(commandStdout)
-	command.RedirectStderrTo(b.logger.Stderr())
+	command.RedirectStderrTo(stderr)
 	if err := command.Start(); err != nil {
 		resErr = fmt.Errorf(tr("Error while determining sketch size: %s"), err)
 		return
@@ -278,3 +281,71 @@ func computeSize(re string, output []byte) (int, error) {
 	}
 	return size, nil
 }
+
+// BoardSuggestion is a board where the executable fits
+type BoardSuggestion struct {
+	FQBN     string
+	Name     string
+	MaxFlash int
+	// MaxRAM is -1 if the board doesn't declare the size of the RAM
+	MaxRAM int
+}
+
+// SuggestBoards returns the boards where the executable fits leaving free at
+// least headroom percent of the flash and of the RAM, sorted by the size of
+// the flash and of the RAM. The memory sizes are taken from the
+// upload.maximum_size and upload.maximum_data_size properties of the default
+// configuration of the boards, the boards not declaring them are skipped.
+func (s ExecutablesFileSections) SuggestBoards(boards []*cores.Board, headroom int) []*BoardSuggestion {
+	flash, ram := -1, -1
+	for _, section := range s {
+		switch section.Name {
+		case "text":
+			flash = section.Size
+		case "data":
+			ram = section.Size
+		}
+	}
+	if flash < 0 {
+		return nil
+	}
+
+	fits := func(size, maxSize int) bool {
+		return maxSize > 0 && size*(100+headroom) <= maxSize*100
+	}
+	res := []*BoardSuggestion{}
+	for _, board := range boards {
+		fqbn, err := cores.ParseFQBN(board.FQBN())
+		if err != nil {
+			continue
+		}
+		boardProperties, err := board.GetBuildProperties(fqbn)
+		if err != nil {
+			continue
+		}
+		maxFlash, err := strconv.Atoi(boardProperties.Get("upload.maximum_size"))
+		if err != nil || !fits(flash, maxFlash) {
+			continue
+		}
+		maxRAM := -1
+		if maxRAMString := boardProperties.Get("upload.maximum_data_size"); maxRAMString != "" {
+			if maxRAM, err = strconv.Atoi(maxRAMString); err != nil {
+				continue
+			}
+		}
+		if ram >= 0 && !fits(ram, maxRAM) {
+			continue
+		}
+		res = append(res, &BoardSuggestion{FQBN: board.FQBN(), Name: board.Name(), MaxFlash: maxFlash, MaxRAM: maxRAM})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].MaxFlash != res[j].MaxFlash {
+			return res[i].MaxFlash < res[j].MaxFlash
+		}
+		if res[i].MaxRAM != res[j].MaxRAM {
+			return res[i].MaxRAM < res[j].MaxRAM
+		}
+		return res[i].FQBN < res[j].FQBN
+	})
+	return res
+}
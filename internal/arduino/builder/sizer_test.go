@@ -18,6 +18,8 @@ package builder
 import (
 	"testing"
 
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/go-properties-orderedmap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -86,3 +88,37 @@ func TestSizerWithInvalidRegexp(t *testing.T) {
 	_, err := computeSize(`[xx`, []byte(`xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx`))
 	require.Error(t, err)
 }
+
+func TestSuggestBoards(t *testing.T) {
+	platformRelease := &cores.PlatformRelease{
+		Platform: &cores.Platform{Architecture: "avr", Package: &cores.Package{Name: "arduino"}},
+	}
+	newBoard := func(id string, props map[string]string) *cores.Board {
+		return &cores.Board{BoardID: id, Properties: properties.NewFromHashmap(props), PlatformRelease: platformRelease}
+	}
+	boards := []*cores.Board{
+		newBoard("mega", map[string]string{"name": "Mega", "upload.maximum_size": "253952", "upload.maximum_data_size": "8192"}),
+		newBoard("uno", map[string]string{"name": "Uno", "upload.maximum_size": "32256", "upload.maximum_data_size": "2048"}),
+		newBoard("nano", map[string]string{"name": "Nano", "upload.maximum_size": "30720", "upload.maximum_data_size": "2048"}),
+		newBoard("tiny", map[string]string{"name": "Tiny", "upload.maximum_size": "8192", "upload.maximum_data_size": "512"}),
+		newBoard("gemma", map[string]string{"name": "Gemma", "upload.maximum_size": "5310"}),
+		newBoard("custom", map[string]string{"name": "Custom"}),
+	}
+
+	sections := ExecutablesFileSections{{Name: "text", Size: 7000, MaxSize: 253952}, {Name: "data", Size: 400, MaxSize: 8192}}
+	require.Equal(t, []*BoardSuggestion{
+		{FQBN: "arduino:avr:nano", Name: "Nano", MaxFlash: 30720, MaxRAM: 2048},
+		{FQBN: "arduino:avr:uno", Name: "Uno", MaxFlash: 32256, MaxRAM: 2048},
+		{FQBN: "arduino:avr:mega", Name: "Mega", MaxFlash: 253952, MaxRAM: 8192},
+	}, sections.SuggestBoards(boards, 20))
+
+	// Without headroom the sketch fits in the Tiny, the RAM of the Gemma is
+	// unknown
+	require.Equal(t, "arduino:avr:tiny", sections.SuggestBoards(boards, 0)[0].FQBN)
+
+	// If the RAM used is unknown only the flash is checked
+	sections = ExecutablesFileSections{{Name: "text", Size: 4000, MaxSize: 253952}}
+	require.Equal(t, &BoardSuggestion{FQBN: "arduino:avr:gemma", Name: "Gemma", MaxFlash: 5310, MaxRAM: -1}, sections.SuggestBoards(boards, 20)[0])
+
+	require.Nil(t, ExecutablesFileSections{}.SuggestBoards(boards, 20))
+}
@@ -58,6 +58,10 @@ func (b *Builder) prepareSketchBuildPath() error {
 		return err
 	}
 
+	if err := b.writeBuildUUIDSource(); err != nil {
+		return err
+	}
+
 	b.lineOffset = offset
 
 	return nil
@@ -184,10 +188,12 @@ func (b *Builder) buildSketch(includesFolders paths.PathList) error {
 		b.sketchBuildPath, b.sketchBuildPath,
 		false, /** recursive **/
 		includes,
+		b.buildProperties,
 	)
-	if err != nil {
+	if err != nil && !b.keepGoingAfterErrors() {
 		return err
 	}
+	compileErr := err
 
 	// The "src/" subdirectory of a sketch is compiled recursively
 	sketchSrcPath := b.sketchBuildPath.Join("src")
@@ -196,12 +202,16 @@ func (b *Builder) buildSketch(includesFolders paths.PathList) error {
 			sketchSrcPath, sketchSrcPath,
 			true, /** recursive **/
 			includes,
+			b.buildProperties,
 		)
 		if err != nil {
 			return err
 		}
 		sketchObjectFiles.AddAll(srcObjectFiles)
 	}
+	if compileErr != nil {
+		return compileErr
+	}
 
 	b.buildArtifacts.sketchObjectFiles = sketchObjectFiles
 	return nil
@@ -240,9 +250,7 @@ func (b *Builder) mergeSketchWithBootloader() error {
 
 	bootloaderPath := b.buildProperties.GetPath("runtime.platform.path").Join("bootloaders", bootloader)
 	if bootloaderPath.NotExist() {
-		if b.logger.Verbose() {
-			b.logger.Warn(tr("Bootloader file specified but missing: %[1]s", bootloaderPath))
-		}
+		b.logger.VerboseWarn(tr("Bootloader file specified but missing: %[1]s", bootloaderPath))
 		return nil
 	}
 
@@ -255,8 +263,8 @@ func (b *Builder) mergeSketchWithBootloader() error {
 		maximumBinSize *= 2
 	}
 	err := merge(builtSketchPath, bootloaderPath, mergedSketchPath, maximumBinSize)
-	if err != nil && b.logger.Verbose() {
-		b.logger.Info(err.Error())
+	if err != nil {
+		b.logger.VerboseInfo(err.Error())
 	}
 
 	return nil
@@ -0,0 +1,233 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/marcinbor85/gohex"
+)
+
+// StripOptions are the information removed from the executable of the
+// sketch, and from the linker map file, when building the firmware for
+// distribution.
+type StripOptions struct {
+	// Paths replaces the absolute paths of the sources with relative names
+	Paths bool
+	// DebugInfo removes the debug information from the executable
+	DebugInfo bool
+	// Symbols removes the symbol table from the executable and the linker
+	// map file
+	Symbols bool
+}
+
+// buildOption returns the string added to the build options, so that changing
+// the stripped information triggers a rebuild of the sketch.
+func (s *StripOptions) buildOption() string {
+	return fmt.Sprintf("build.strip=paths:%t,debug_info:%t,symbols:%t", s.Paths, s.DebugInfo, s.Symbols)
+}
+
+// strippedDir is a directory whose absolute path is replaced by a name
+type strippedDir struct {
+	path *paths.Path
+	name string
+}
+
+// strippedDirs returns the directories that may appear in the build artifacts,
+// sorted so that the longest paths come last: the compiler uses the last
+// matching prefix map.
+func strippedDirs(sk *sketch.Sketch, buildPath *paths.Path, hardwareDirs, libraryDirs paths.PathList, cacheDirs ...*paths.Path) []*strippedDir {
+	res := []*strippedDir{}
+	add := func(dir *paths.Path, name string) {
+		if dir == nil {
+			return
+		}
+		if abs, err := dir.Abs(); err == nil {
+			dir = abs
+		}
+		for _, d := range res {
+			if d.path.EqualsTo(dir) {
+				return
+			}
+		}
+		res = append(res, &strippedDir{path: dir, name: name})
+	}
+	if sk != nil {
+		add(sk.FullPath, "sketch")
+	}
+	add(buildPath, "build")
+	for _, dir := range hardwareDirs {
+		add(dir, "hardware")
+	}
+	for _, dir := range libraryDirs {
+		add(dir, "libraries")
+	}
+	for _, dir := range cacheDirs {
+		add(dir, "cache")
+	}
+	sort.SliceStable(res, func(i, j int) bool { return len(res[i].path.String()) < len(res[j].path.String()) })
+	return res
+}
+
+// applyStripPathsFlags adds to the compiler flags the prefix maps replacing
+// the absolute paths of the stripped directories. The flag is generated with
+// the `compiler.file_prefix_map.pattern` property, the platforms using a
+// toolchain based on GCC 8 or later may set it to `-ffile-prefix-map={old}={new}`
+// to strip the paths expanded by the __FILE__ macro too.
+func applyStripPathsFlags(buildProperties *properties.Map, dirs []*strippedDir) {
+	pattern := buildProperties.Get("compiler.file_prefix_map.pattern")
+	if pattern == "" {
+		pattern = "-fdebug-prefix-map={old}={new}"
+	}
+	flags := []string{}
+	for _, dir := range dirs {
+		flag := strings.NewReplacer("{old}", dir.path.String(), "{new}", dir.name).Replace(pattern)
+		flags = append(flags, `"`+flag+`"`)
+	}
+	for _, key := range []string{"compiler.c.extra_flags", "compiler.cpp.extra_flags", "compiler.S.extra_flags"} {
+		buildProperties.Set(key, strings.TrimSpace(buildProperties.Get(key)+" "+strings.Join(flags, " ")))
+	}
+}
+
+// stripExecutable removes the debug information and the symbols from the
+// executable, and the paths or the whole linker map file, as requested by
+// the strip options.
+func (b *Builder) stripExecutable() error {
+	if b.strip == nil || b.onlyUpdateCompilationDatabase {
+		return nil
+	}
+	mapFile := b.buildPath.Join(b.buildProperties.Get("build.project_name") + ".map")
+	if f, ok := b.buildProperties.GetOk("compiler.map.file"); ok {
+		mapFile = paths.New(b.buildProperties.ExpandPropsInString(f))
+	}
+	if mapFile.Exist() {
+		if b.strip.Symbols {
+			if err := mapFile.Remove(); err != nil {
+				return err
+			}
+		} else if b.strip.Paths {
+			content, err := mapFile.ReadFile()
+			if err != nil {
+				return err
+			}
+			if err := mapFile.WriteFile(replaceStrippedDirs(content, b.strippedDirs)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !b.strip.DebugInfo && !b.strip.Symbols {
+		return nil
+	}
+	buildProperties, err := stripBuildProperties(b.buildProperties)
+	if err != nil {
+		return err
+	}
+	if b.strip.Symbols {
+		buildProperties.Set("compiler.strip.flags", "--strip-all")
+	} else {
+		buildProperties.Set("compiler.strip.flags", "--strip-debug")
+	}
+	command, err := b.prepareCommandForRecipe(buildProperties, "recipe.strip.pattern", true)
+	if err != nil {
+		return err
+	}
+	if err := b.execCommand(command, "strip"); err != nil {
+		return fmt.Errorf(tr("Error stripping the executable: %s"), err)
+	}
+	return nil
+}
+
+// stripBuildProperties returns the build properties with the recipe.strip.pattern
+// recipe. If the platform doesn't define the recipe, it's generated using the objcopy
+// of the toolchain used to compile.
+func stripBuildProperties(buildProperties *properties.Map) (*properties.Map, error) {
+	res := buildProperties.Clone()
+	if res.ContainsKey("recipe.strip.pattern") {
+		return res, nil
+	}
+	if !res.ContainsKey("compiler.objcopy.cmd") {
+		gccCmd := res.Get("compiler.c.cmd")
+		if !strings.HasSuffix(gccCmd, "gcc") {
+			return nil, errors.New(tr("%s pattern is missing", "recipe.strip.pattern"))
+		}
+		res.Set("compiler.objcopy.cmd", strings.TrimSuffix(gccCmd, "gcc")+"objcopy")
+	}
+	res.Set("recipe.strip.pattern", `"{compiler.path}{compiler.objcopy.cmd}" {compiler.strip.flags} "{build.path}/{build.project_name}.elf"`)
+	return res, nil
+}
+
+// replaceStrippedDirs replaces the absolute paths of the stripped directories
+// with their names, starting from the longest paths.
+func replaceStrippedDirs(content []byte, dirs []*strippedDir) []byte {
+	for i := len(dirs) - 1; i >= 0; i-- {
+		content = bytes.ReplaceAll(content, []byte(dirs[i].path.String()), []byte(dirs[i].name))
+	}
+	return content
+}
+
+// checkStrippedPaths verifies that the absolute paths of the stripped
+// directories are not embedded in the build artifacts of the sketch.
+func (b *Builder) checkStrippedPaths() error {
+	if b.strip == nil || !b.strip.Paths || b.onlyUpdateCompilationDatabase {
+		return nil
+	}
+	projectName := b.buildProperties.Get("build.project_name")
+	artifacts, err := b.buildPath.ReadDir()
+	if err != nil {
+		return err
+	}
+	artifacts.FilterOutDirs()
+	artifacts.FilterPrefix(projectName + ".")
+	for _, artifact := range artifacts {
+		content, err := artifact.ReadFile()
+		if err != nil {
+			return err
+		}
+		if artifact.Ext() == ".hex" {
+			hex := gohex.NewMemory()
+			if err := hex.ParseIntelHex(bytes.NewReader(content)); err == nil {
+				content = []byte{}
+				for _, segment := range hex.GetDataSegments() {
+					content = append(content, segment.Data...)
+				}
+			}
+		}
+		if dir := findStrippedDir(content, b.strippedDirs); dir != nil {
+			return errors.New(tr("the path %[1]s is still embedded in %[2]s, the toolchain may need a different %[3]s build property",
+				dir.path, artifact.Base(), "compiler.file_prefix_map.pattern"))
+		}
+	}
+	return nil
+}
+
+// findStrippedDir returns the first stripped directory whose absolute path
+// is contained in content, or nil if none is found.
+func findStrippedDir(content []byte, dirs []*strippedDir) *strippedDir {
+	for _, dir := range dirs {
+		if bytes.Contains(content, []byte(dir.path.String())) {
+			return dir
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripPaths(t *testing.T) {
+	sk := &sketch.Sketch{FullPath: paths.New("/home/user/Arduino/Blink")}
+	dirs := strippedDirs(sk, paths.New("/home/user/Arduino/Blink/build"),
+		paths.NewPathList("/home/user/.arduino15/packages"),
+		paths.NewPathList("/home/user/Arduino/libraries", "/home/user/Arduino/libraries"),
+		nil)
+	names := []string{}
+	for _, dir := range dirs {
+		names = append(names, dir.name)
+	}
+	require.Equal(t, []string{"sketch", "libraries", "build", "hardware"}, names)
+
+	props := properties.NewMap()
+	props.Set("compiler.cpp.extra_flags", "-DFOO")
+	applyStripPathsFlags(props, []*strippedDir{dirs[0], dirs[2]})
+	require.Equal(t, `-DFOO "-fdebug-prefix-map=/home/user/Arduino/Blink=sketch" "-fdebug-prefix-map=/home/user/Arduino/Blink/build=build"`, props.Get("compiler.cpp.extra_flags"))
+	require.Equal(t, `"-fdebug-prefix-map=/home/user/Arduino/Blink=sketch" "-fdebug-prefix-map=/home/user/Arduino/Blink/build=build"`, props.Get("compiler.c.extra_flags"))
+
+	props = properties.NewMap()
+	props.Set("compiler.file_prefix_map.pattern", "-ffile-prefix-map={old}={new}")
+	applyStripPathsFlags(props, dirs[:1])
+	require.Equal(t, `"-ffile-prefix-map=/home/user/Arduino/Blink=sketch"`, props.Get("compiler.S.extra_flags"))
+
+	mapFile := []byte("/home/user/Arduino/Blink/build/sketch/Blink.ino.cpp.o\n/home/user/Arduino/Blink/Blink.ino\n")
+	stripped := replaceStrippedDirs(mapFile, dirs)
+	require.Equal(t, "build/sketch/Blink.ino.cpp.o\nsketch/Blink.ino\n", string(stripped))
+	require.Nil(t, findStrippedDir(stripped, dirs))
+	require.Equal(t, "sketch", findStrippedDir(mapFile, dirs).name)
+}
+
+func TestStripBuildProperties(t *testing.T) {
+	props := properties.NewMap()
+	props.Set("compiler.c.cmd", "avr-gcc")
+	res, err := stripBuildProperties(props)
+	require.NoError(t, err)
+	require.Equal(t, "avr-objcopy", res.Get("compiler.objcopy.cmd"))
+	require.NotEmpty(t, res.Get("recipe.strip.pattern"))
+
+	props.Set("compiler.c.cmd", "clang")
+	_, err = stripBuildProperties(props)
+	require.Error(t, err)
+
+	props.Set("recipe.strip.pattern", "strip {build.path}")
+	res, err = stripBuildProperties(props)
+	require.NoError(t, err)
+	require.Equal(t, "strip {build.path}", res.Get("recipe.strip.pattern"))
+}
@@ -0,0 +1,133 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// buildUUIDSymbol is the symbol of the build UUID embedded in the executable
+const buildUUIDSymbol = "arduino_build_uuid"
+
+// buildUUIDSourceFile is the source file, generated in the sketch build path,
+// defining the build UUID
+const buildUUIDSourceFile = "arduino_build_uuid.c"
+
+// SymbolsArchiveIndexFile is the name of the file, saved together with the
+// executable in the symbols archive, describing the build
+const SymbolsArchiveIndexFile = "build.json"
+
+// SymbolsArchive is the archive where the executables, with the full debug
+// information, are saved to resolve the addresses reported by the firmware
+// running in the field. Each build is identified by an UUID embedded in the
+// executable and saved in the Dir/<BuildUUID> directory.
+type SymbolsArchive struct {
+	Dir       *paths.Path
+	BuildUUID string
+}
+
+// SymbolsArchiveIndex describes a build saved in the symbols archive
+type SymbolsArchiveIndex struct {
+	BuildUUID  string    `json:"build_uuid"`
+	FQBN       string    `json:"fqbn"`
+	Sketch     string    `json:"sketch"`
+	Executable string    `json:"executable"`
+	Addr2Line  string    `json:"addr2line"`
+	Created    time.Time `json:"created"`
+}
+
+// buildOption returns the string added to the build options, so that enabling
+// or disabling the symbols export triggers a rebuild of the sketch. The UUID
+// is not part of the options, only the generated source file is rebuilt.
+func (s *SymbolsArchive) buildOption() string {
+	return "build.export_symbols=true"
+}
+
+// applyBuildUUIDFlags forces the linker to keep the build UUID, even if it's
+// not referenced by the sketch.
+func applyBuildUUIDFlags(buildProperties *properties.Map) {
+	flags := buildProperties.Get("compiler.c.elf.extra_flags") + " -Wl,--undefined=" + buildUUIDSymbol
+	buildProperties.Set("compiler.c.elf.extra_flags", strings.TrimSpace(flags))
+}
+
+// writeBuildUUIDSource generates the source file defining the build UUID in
+// the sketch build path, or removes it if the symbols are not exported.
+func (b *Builder) writeBuildUUIDSource() error {
+	sourceFile := b.sketchBuildPath.Join(buildUUIDSourceFile)
+	if b.symbolsArchive == nil {
+		if sourceFile.Exist() {
+			return sourceFile.Remove()
+		}
+		return nil
+	}
+	source := "/* Generated by arduino-cli: the UUID identifying the build in the symbols archive */\n" +
+		fmt.Sprintf("const char %s[] __attribute__((used)) = \"ARDUINO_BUILD_UUID=%s\";\n", buildUUIDSymbol, b.symbolsArchive.BuildUUID)
+	return writeIfDifferent([]byte(source), sourceFile)
+}
+
+// archiveSymbols saves the executable, before it's stripped, in the symbols
+// archive together with the index describing the build.
+func (b *Builder) archiveSymbols() error {
+	if b.symbolsArchive == nil || b.onlyUpdateCompilationDatabase {
+		return nil
+	}
+	projectName := b.buildProperties.Get("build.project_name")
+	executable := b.buildPath.Join(projectName + ".elf")
+	if !executable.Exist() {
+		return errors.New(tr("executable %s not found, the symbols can't be exported", executable))
+	}
+
+	archiveDir := b.symbolsArchive.Dir.Join(b.symbolsArchive.BuildUUID)
+	if err := archiveDir.MkdirAll(); err != nil {
+		return err
+	}
+	if err := executable.CopyTo(archiveDir.Join(executable.Base())); err != nil {
+		return fmt.Errorf("%s: %w", tr("saving the executable in the symbols archive"), err)
+	}
+
+	index := &SymbolsArchiveIndex{
+		BuildUUID:  b.symbolsArchive.BuildUUID,
+		FQBN:       b.buildProperties.Get("build.fqbn"),
+		Executable: executable.Base(),
+		Addr2Line:  addr2lineCommand(b.buildProperties),
+		Created:    time.Now(),
+	}
+	if b.sketch != nil {
+		index.Sketch = b.sketch.FullPath.String()
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return archiveDir.Join(SymbolsArchiveIndexFile).WriteFile(data)
+}
+
+// addr2lineCommand returns the addr2line of the toolchain used to compile,
+// set with the compiler.addr2line.cmd property or guessed from the C compiler.
+func addr2lineCommand(buildProperties *properties.Map) string {
+	cmd := buildProperties.Get("compiler.addr2line.cmd")
+	if cmd == "" {
+		cmd = strings.TrimSuffix(buildProperties.Get("compiler.c.cmd"), "gcc") + "addr2line"
+	}
+	return buildProperties.ExpandPropsInString(buildProperties.Get("compiler.path") + cmd)
+}
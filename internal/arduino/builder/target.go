@@ -0,0 +1,185 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"fmt"
+
+	f "github.com/arduino/arduino-cli/internal/algorithms"
+	"github.com/arduino/arduino-cli/internal/arduino/builder/cpp"
+	"github.com/arduino/arduino-cli/internal/arduino/globals"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
+)
+
+// BuildTarget selects the part of the sketch to build. For a partial build the
+// linking and the following steps are skipped. The zero value selects the
+// whole sketch.
+type BuildTarget struct {
+	// Core builds only the core and the variant
+	Core bool
+	// Library builds only the library with the given name
+	Library string
+	// Sketch builds only the sketch objects
+	Sketch bool
+	// File builds only the given source file, a file of the sketch, of a
+	// library used by the sketch or of the core.
+	File *paths.Path
+}
+
+// IsPartial returns true if the target selects only a part of the sketch
+func (t BuildTarget) IsPartial() bool {
+	return t.Core || t.Library != "" || t.Sketch || t.File != nil
+}
+
+// buildTarget builds the part of the sketch selected by the target, the
+// hooks of the selected part are run before and after the build.
+func (b *Builder) buildTarget() error {
+	includesFolders := b.libsDetector.IncludeFolders()
+	switch {
+	case b.target.Core:
+		b.logIfVerbose(false, tr("Compiling core..."))
+		return b.runWithHooks("core", b.buildCore)
+	case b.target.Sketch:
+		b.logIfVerbose(false, tr("Compiling sketch..."))
+		return b.runWithHooks("sketch", func() error {
+			return b.buildSketch(includesFolders)
+		})
+	case b.target.Library != "":
+		library := b.findImportedLibrary(b.target.Library)
+		if library == nil {
+			return fmt.Errorf(tr("library %s is not used by the sketch"), b.target.Library)
+		}
+		b.logIfVerbose(false, tr("Compiling libraries..."))
+		return b.runWithHooks("libraries", func() error {
+			return b.buildLibraries(includesFolders, libraries.List{library})
+		})
+	case b.target.File != nil:
+		return b.compileSingleFile(b.target.File, includesFolders)
+	}
+	return nil
+}
+
+// runWithHooks runs the build function between the prebuild and postbuild
+// hooks of the given part of the sketch.
+func (b *Builder) runWithHooks(part string, build func() error) error {
+	if err := b.RunRecipe("recipe.hooks."+part+".prebuild", ".pattern", false); err != nil {
+		return err
+	}
+	if err := build(); err != nil {
+		return err
+	}
+	return b.RunRecipe("recipe.hooks."+part+".postbuild", ".pattern", true)
+}
+
+// findImportedLibrary returns the library used by the sketch with the given
+// name or directory name, or nil if not found.
+func (b *Builder) findImportedLibrary(name string) *libraries.Library {
+	for _, library := range b.libsDetector.ImportedLibraries() {
+		if library.Name == name || library.DirName == name {
+			return library
+		}
+	}
+	return nil
+}
+
+// compileSingleFile compiles the given source file with the same flags used
+// for the part of the sketch it belongs to.
+func (b *Builder) compileSingleFile(file *paths.Path, includesFolders paths.PathList) error {
+	file, err := file.Abs()
+	if err != nil {
+		return err
+	}
+	includes := f.Map(includesFolders.AsStrings(), cpp.WrapWithHyphenI)
+
+	source, sourceDir, buildPath, includes := b.singleFileBuildPaths(file, includes)
+	if source == nil {
+		return fmt.Errorf(tr("%s is not a source file of the sketch, of the libraries used by the sketch or of the core"), file)
+	}
+	if _, ok := globals.SourceFilesValidExtensions[source.Ext()]; !ok {
+		return fmt.Errorf(tr("%s is not a source file"), file)
+	}
+
+	buildProperties := b.buildProperties
+	if library := b.libraryContaining(file); library != nil {
+		buildProperties = b.libraryBuildProperties(library)
+	}
+
+	b.logIfVerbose(false, tr("Compiling %s...", file))
+	objectFile, err := b.compileFileWithRecipe(sourceDir, source, buildPath, includes, buildProperties, b.compileRecipe(source))
+	if err != nil {
+		return err
+	}
+	b.logIfVerbose(false, tr("Object file: %s", objectFile))
+	return nil
+}
+
+// singleFileBuildPaths returns the source file to compile, the directory of
+// the source files it's relative to, the build path of its objects and the
+// include flags. The .ino files of the sketch are compiled from the merged
+// sketch source. A nil source is returned if the file doesn't belong to the
+// sketch, to the libraries used by the sketch or to the core.
+func (b *Builder) singleFileBuildPaths(file *paths.Path, includes []string) (*paths.Path, *paths.Path, *paths.Path, []string) {
+	if b.sketch.MainFile.EquivalentTo(file) || b.sketch.OtherSketchFiles.ContainsEquivalentTo(file) {
+		return b.sketchBuildPath.Join(b.sketch.MainFile.Base() + ".cpp"), b.sketchBuildPath, b.sketchBuildPath, includes
+	}
+	if b.sketch.AdditionalFiles.ContainsEquivalentTo(file) {
+		rel, err := file.RelFrom(b.sketch.FullPath)
+		if err != nil {
+			return nil, nil, nil, nil
+		}
+		return b.sketchBuildPath.JoinPath(rel), b.sketchBuildPath, b.sketchBuildPath, includes
+	}
+	if library := b.libraryContaining(file); library != nil {
+		libraryBuildPath := b.librariesBuildPath.Join(library.DirName)
+		if library.UtilityDir != nil && library.Layout != libraries.RecursiveLayout {
+			includes = append(includes, cpp.WrapWithHyphenI(library.UtilityDir.String()))
+			if inside, _ := file.IsInsideDir(library.UtilityDir); inside {
+				return file, library.UtilityDir, libraryBuildPath.Join("utility"), includes
+			}
+		}
+		return file, library.SourceDir, libraryBuildPath, includes
+	}
+	for _, dir := range []*paths.Path{
+		b.buildProperties.GetPath("build.variant.path"),
+		b.buildProperties.GetPath("build.core.path"),
+	} {
+		if dir == nil {
+			continue
+		}
+		if inside, _ := file.IsInsideDir(dir); inside {
+			return file, dir, b.coreBuildPath, b.coreIncludes()
+		}
+	}
+	return nil, nil, nil, nil
+}
+
+// libraryContaining returns the library used by the sketch containing the
+// given source file, or nil if not found.
+func (b *Builder) libraryContaining(file *paths.Path) *libraries.Library {
+	for _, library := range b.libsDetector.ImportedLibraries() {
+		dirs := []*paths.Path{library.SourceDir}
+		if library.UtilityDir != nil {
+			dirs = append(dirs, library.UtilityDir)
+		}
+		for _, dir := range dirs {
+			if inside, _ := file.IsInsideDir(dir); inside {
+				return library
+			}
+		}
+	}
+	return nil
+}
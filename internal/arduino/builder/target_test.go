@@ -0,0 +1,83 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/detector"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingleFileBuildPaths(t *testing.T) {
+	sk, err := sketch.New(paths.New("testdata", "TestLoadSketchFolder"))
+	require.NoError(t, err)
+
+	tmp := paths.New(t.TempDir())
+	buildPath := tmp.Join("build")
+	corePath := tmp.Join("core")
+	library := &libraries.Library{
+		Name:      "My Library",
+		DirName:   "My_Library",
+		SourceDir: tmp.Join("libraries", "My_Library", "src"),
+		Layout:    libraries.RecursiveLayout,
+	}
+	props := properties.NewMap()
+	props.SetPath("build.core.path", corePath)
+	b := &Builder{
+		sketch:             sk,
+		buildProperties:    props,
+		sketchBuildPath:    buildPath.Join("sketch"),
+		coreBuildPath:      buildPath.Join("core"),
+		librariesBuildPath: buildPath.Join("libraries"),
+		libsDetector:       detector.NewSketchLibrariesDetector(nil, nil, false, false, nil, nil),
+	}
+	b.libsDetector.AppendImportedLibraries(library)
+	includes := []string{"-Iinclude"}
+
+	// The .ino files are compiled from the merged sketch
+	source, sourceDir, objPath, incs := b.singleFileBuildPaths(sk.FullPath.Join("other.ino"), includes)
+	require.Equal(t, buildPath.Join("sketch", "TestLoadSketchFolder.ino.cpp").String(), source.String())
+	require.Equal(t, buildPath.Join("sketch").String(), sourceDir.String())
+	require.Equal(t, buildPath.Join("sketch").String(), objPath.String())
+	require.Equal(t, includes, incs)
+
+	source, _, _, _ = b.singleFileBuildPaths(sk.FullPath.Join("s_file.S"), includes)
+	require.Equal(t, buildPath.Join("sketch", "s_file.S").String(), source.String())
+
+	file := library.SourceDir.Join("utils", "lib.cpp")
+	source, sourceDir, objPath, _ = b.singleFileBuildPaths(file, includes)
+	require.Equal(t, file, source)
+	require.Equal(t, library.SourceDir, sourceDir)
+	require.Equal(t, buildPath.Join("libraries", "My_Library").String(), objPath.String())
+	require.Equal(t, library, b.findImportedLibrary("My Library"))
+	require.Equal(t, library, b.findImportedLibrary("My_Library"))
+	require.Nil(t, b.findImportedLibrary("Other"))
+
+	file = corePath.Join("main.cpp")
+	source, sourceDir, objPath, incs = b.singleFileBuildPaths(file, includes)
+	require.Equal(t, file, source)
+	require.Equal(t, corePath, sourceDir)
+	require.Equal(t, buildPath.Join("core").String(), objPath.String())
+	require.Equal(t, []string{`"-I` + corePath.String() + `"`}, incs)
+
+	source, _, _, _ = b.singleFileBuildPaths(tmp.Join("other.cpp"), includes)
+	require.Nil(t, source)
+}
@@ -0,0 +1,49 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"fmt"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// overrideBuildVariant replaces the variant of the board with the one set in
+// the sketch project file. The variant may be a folder of the sketch or the
+// name of another variant of the platform used by the board.
+func overrideBuildVariant(buildProperties *properties.Map, sk *sketch.Sketch) (*paths.Path, error) {
+	variant := sk.GetBuildVariant()
+	variantPath := sk.FullPath.Join(variant)
+	if !variantPath.IsDir() {
+		variantsDir := buildProperties.GetPath("runtime.platform.path").Join("variants")
+		if boardVariantPath := buildProperties.GetPath("build.variant.path"); boardVariantPath != nil {
+			variantsDir = boardVariantPath.Parent()
+		}
+		variantPath = variantsDir.Join(variant)
+	}
+	if !variantPath.IsDir() {
+		return nil, fmt.Errorf(tr("variant %[1]s not found in the sketch folder or in %[2]s", variant, variantPath.Parent()))
+	}
+	variantPath, err := variantPath.Abs()
+	if err != nil {
+		return nil, err
+	}
+	buildProperties.Set("build.variant", variantPath.Base())
+	buildProperties.SetPath("build.variant.path", variantPath)
+	return variantPath, nil
+}
@@ -0,0 +1,99 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package builder
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/builder/internal/diagnostics"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
+)
+
+// WarningsAsErrors is the policy used to fail the build on compiler warnings.
+// The warnings of the sketch and of the libraries make the build fail, except
+// for the warnings of the exempted libraries. The warnings of the core and of
+// the toolchain are ignored.
+type WarningsAsErrors struct {
+	// ExemptLibraries are the names of the libraries whose warnings are ignored
+	ExemptLibraries []string
+}
+
+// isExempt returns true if the warnings of the given library are ignored
+func (w *WarningsAsErrors) isExempt(library *libraries.Library) bool {
+	for _, name := range w.ExemptLibraries {
+		if strings.EqualFold(name, library.Name) || strings.EqualFold(name, library.DirName) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildOption returns the string added to the build options, so that enabling,
+// disabling or changing the policy triggers a rebuild of the sketch.
+func (w *WarningsAsErrors) buildOption() string {
+	return "build.warnings_as_errors=" + strings.Join(w.ExemptLibraries, ",")
+}
+
+// checkWarningsAsErrors returns an error if one of the given diagnostics is a
+// warning that must be treated as an error.
+func (b *Builder) checkWarningsAsErrors(diags diagnostics.Diagnostics) error {
+	if b.warningsAsErrors == nil {
+		return nil
+	}
+	for _, diag := range diags {
+		if diag.Severity != diagnostics.SeverityWarning || diag.File == "" {
+			continue
+		}
+		// The message may be followed by the source code context
+		message, _, _ := strings.Cut(diag.Message, "\n")
+		isSketch, library := b.diagnosticComponent(paths.New(diag.File))
+		switch {
+		case isSketch:
+			return errors.New(tr("%[1]s:%[2]d: warning treated as error: %[3]s", diag.File, diag.Line, message))
+		case library != nil && !b.warningsAsErrors.isExempt(library):
+			return errors.New(tr("%[1]s:%[2]d: warning treated as error: %[3]s (in library %[4]s)", diag.File, diag.Line, message, library.Name))
+		}
+	}
+	return nil
+}
+
+// diagnosticComponent returns the component containing the given file: the
+// sketch or one of the libraries used by the sketch. If the file belongs to
+// neither (for example it's part of the core) false and nil are returned.
+func (b *Builder) diagnosticComponent(file *paths.Path) (bool, *libraries.Library) {
+	if b.sketch != nil && isInside(file, b.sketch.FullPath) {
+		return true, nil
+	}
+	if isInside(file, b.sketchBuildPath) {
+		return true, nil
+	}
+	for _, library := range b.libsDetector.ImportedLibraries() {
+		if isInside(file, library.InstallDir) || isInside(file, b.librariesBuildPath.Join(library.DirName)) {
+			return false, library
+		}
+	}
+	return false, nil
+}
+
+func isInside(file, dir *paths.Path) bool {
+	if dir == nil {
+		return false
+	}
+	inside, err := file.IsInsideDir(dir)
+	return err == nil && inside
+}
@@ -0,0 +1,166 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package buildmanifest handles the build-manifest.json file, saved together
+// with the build artifacts, that describes for which board the artifacts have
+// been compiled and the checksums of the artifacts.
+package buildmanifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	"github.com/arduino/go-paths-helper"
+)
+
+var tr = i18n.Tr
+
+// FileName is the name of the build manifest file
+const FileName = "build-manifest.json"
+
+// formatVersion is the version of the format of the build manifest
+const formatVersion = 1
+
+// Manifest describes the artifacts of a compilation
+type Manifest struct {
+	Version int `json:"version"`
+	// FQBN is the FQBN of the board, including all the configuration options
+	FQBN string `json:"fqbn"`
+	// ProjectName is the name shared by the artifacts, e.g. "Blink.ino"
+	ProjectName string `json:"project_name"`
+	// Platform is the platform used to compile, e.g. "arduino:avr@1.8.6"
+	Platform string `json:"platform,omitempty"`
+	// BuildUUID is the UUID of the build in the symbols archive, if the
+	// symbols have been exported
+	BuildUUID string  `json:"build_uuid,omitempty"`
+	Files     []*File `json:"files"`
+}
+
+// File is a build artifact
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// New creates the manifest of the artifacts of the given project found in dir
+func New(dir *paths.Path, projectName string, fqbn *cores.FQBN, platform string) (*Manifest, error) {
+	files, err := dir.ReadDir()
+	if err != nil {
+		return nil, err
+	}
+	files.FilterPrefix(projectName + ".")
+	files.FilterOutDirs()
+	files.Sort()
+
+	m := &Manifest{
+		Version:     formatVersion,
+		FQBN:        fqbn.String(),
+		ProjectName: projectName,
+		Platform:    platform,
+		Files:       []*File{},
+	}
+	for _, file := range files {
+		size, checksum, err := fileChecksum(file)
+		if err != nil {
+			return nil, err
+		}
+		m.Files = append(m.Files, &File{Name: file.Base(), Size: size, SHA256: checksum})
+	}
+	return m, nil
+}
+
+// Load reads the build manifest in the given folder, it returns nil if the
+// folder doesn't contain a build manifest.
+func Load(dir *paths.Path) (*Manifest, error) {
+	file := dir.Join(FileName)
+	if file.NotExist() {
+		return nil, nil
+	}
+	data, err := file.ReadFile()
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf(tr("invalid build manifest %[1]s: %[2]s"), file, err)
+	}
+	if m.Version > formatVersion {
+		return nil, errors.New(tr("unsupported version %[1]d of build manifest %[2]s", m.Version, file))
+	}
+	return &m, nil
+}
+
+// Save writes the build manifest in the given folder
+func (m *Manifest) Save(dir *paths.Path) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return dir.Join(FileName).WriteFile(append(data, '\n'))
+}
+
+// CheckFQBN returns an error if the artifacts have been compiled for a board
+// different from the given one. The given FQBN must contain all the
+// configuration options of the board.
+func (m *Manifest) CheckFQBN(fqbn *cores.FQBN) error {
+	compiledFQBN, err := cores.ParseFQBN(m.FQBN)
+	if err != nil {
+		return fmt.Errorf(tr("invalid FQBN in build manifest: %s"), err)
+	}
+	if !compiledFQBN.Match(fqbn) || !fqbn.Match(compiledFQBN) {
+		return errors.New(tr("the sketch has been compiled for %[1]s but the upload is for %[2]s", compiledFQBN, fqbn))
+	}
+	return nil
+}
+
+// VerifyFiles checks that the artifacts in the given folder have the size and
+// checksum recorded in the manifest.
+func (m *Manifest) VerifyFiles(dir *paths.Path) error {
+	for _, f := range m.Files {
+		file := dir.Join(f.Name)
+		if file.NotExist() {
+			return errors.New(tr("build artifact %s not found", file))
+		}
+		size, checksum, err := fileChecksum(file)
+		if err != nil {
+			return err
+		}
+		if size != f.Size || checksum != f.SHA256 {
+			return errors.New(tr("build artifact %[1]s has been modified: expected SHA-256 %[2]s, found %[3]s", file, f.SHA256, checksum))
+		}
+	}
+	return nil
+}
+
+func fileChecksum(file *paths.Path) (int64, string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,62 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package buildmanifest
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildManifest(t *testing.T) {
+	dir := paths.New(t.TempDir())
+	require.NoError(t, dir.Join("Blink.ino.hex").WriteFile([]byte("hex")))
+	require.NoError(t, dir.Join("Blink.ino.elf").WriteFile([]byte("elf")))
+	require.NoError(t, dir.Join("Other.ino.hex").WriteFile([]byte("other")))
+
+	m, err := Load(dir)
+	require.NoError(t, err)
+	require.Nil(t, m)
+
+	fqbn := cores.MustParseFQBN("arduino:avr:nano:cpu=atmega328")
+	m, err = New(dir, "Blink.ino", fqbn, "arduino:avr@1.8.6")
+	require.NoError(t, err)
+	require.NoError(t, m.Save(dir))
+
+	m, err = Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, "Blink.ino", m.ProjectName)
+	require.Equal(t, "arduino:avr:nano:cpu=atmega328", m.FQBN)
+	require.Len(t, m.Files, 2)
+	require.Equal(t, "Blink.ino.elf", m.Files[0].Name)
+	require.Equal(t, int64(3), m.Files[0].Size)
+	require.NoError(t, m.VerifyFiles(dir))
+
+	require.NoError(t, m.CheckFQBN(cores.MustParseFQBN("arduino:avr:nano:cpu=atmega328")))
+	require.Error(t, m.CheckFQBN(cores.MustParseFQBN("arduino:avr:nano:cpu=atmega328old")))
+	require.Error(t, m.CheckFQBN(cores.MustParseFQBN("arduino:avr:uno")))
+
+	require.NoError(t, dir.Join("Blink.ino.hex").WriteFile([]byte("HEX")))
+	require.ErrorContains(t, m.VerifyFiles(dir), "has been modified")
+	require.NoError(t, dir.Join("Blink.ino.hex").Remove())
+	require.ErrorContains(t, m.VerifyFiles(dir), "not found")
+
+	require.NoError(t, dir.Join(FileName).WriteFile([]byte(`{"version": 2}`)))
+	_, err = Load(dir)
+	require.Error(t, err)
+}
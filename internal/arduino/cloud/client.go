@@ -0,0 +1,163 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package cloud implements a client for the Arduino Cloud sketches API and
+// the logic to synchronize a local sketch with its copy in the cloud.
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+)
+
+var tr = i18n.Tr
+
+// Client is a client for the Arduino Cloud sketches API
+type Client struct {
+	baseURL    *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+// Sketch is a sketch stored in the cloud
+type Sketch struct {
+	ID         string  `json:"id,omitempty"`
+	Name       string  `json:"name"`
+	ModifiedAt string  `json:"modified_at,omitempty"`
+	Files      []*File `json:"files,omitempty"`
+}
+
+// File is a file of a Sketch, the content is base64 encoded
+type File struct {
+	Path string `json:"path"`
+	Data string `json:"data"`
+}
+
+// NewClient creates a new Client for the API at the given base URL, using
+// the given token for authentication.
+func NewClient(baseURL, token string, httpClient *http.Client) (*Client, error) {
+	if token == "" {
+		return nil, errors.New(tr("missing Arduino Cloud token"))
+	}
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tr("invalid Arduino Cloud API URL"), err)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: u, token: token, httpClient: httpClient}, nil
+}
+
+// ListSketches returns the sketches of the user, without the files
+func (c *Client) ListSketches(ctx context.Context) ([]*Sketch, error) {
+	var res struct {
+		Sketches []*Sketch `json:"sketches"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/sketches", nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Sketches, nil
+}
+
+// GetSketch returns the sketch with the given ID, including its files
+func (c *Client) GetSketch(ctx context.Context, id string) (*Sketch, error) {
+	var res Sketch
+	if err := c.do(ctx, http.MethodGet, "/sketches/"+url.PathEscape(id), nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FindSketch returns the sketch with the given name, including its files,
+// or nil if the sketch doesn't exist.
+func (c *Client) FindSketch(ctx context.Context, name string) (*Sketch, error) {
+	sketches, err := c.ListSketches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sk := range sketches {
+		if sk.Name == name {
+			return c.GetSketch(ctx, sk.ID)
+		}
+	}
+	return nil, nil
+}
+
+// SaveSketch creates the given sketch, if it has no ID, or replaces the
+// existing one. The sketch returned by the server is returned.
+func (c *Client) SaveSketch(ctx context.Context, sk *Sketch) (*Sketch, error) {
+	method, path := http.MethodPost, "/sketches"
+	if sk.ID != "" {
+		method, path = http.MethodPut, "/sketches/"+url.PathEscape(sk.ID)
+	}
+	var res Sketch
+	if err := c.do(ctx, method, path, sk, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, res any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Detail string `json:"detail"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Detail != "" {
+			return errors.New(tr("Arduino Cloud responded with %[1]s: %[2]s", resp.Status, apiErr.Detail))
+		}
+		return errors.New(tr("Arduino Cloud responded with %s", resp.Status))
+	}
+	if res == nil {
+		return nil
+	}
+	return json.Unmarshal(data, res)
+}
@@ -0,0 +1,264 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cloud
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// SyncStateFileName is the name of the file, in the sketch folder, that
+// keeps track of the last synchronization with the cloud.
+const SyncStateFileName = ".arduino-cloud.json"
+
+// SyncState is the state of the last synchronization of a sketch
+type SyncState struct {
+	SketchID         string            `json:"sketch_id"`
+	RemoteModifiedAt string            `json:"remote_modified_at"`
+	Files            map[string]string `json:"files"`
+}
+
+// LoadSyncState loads the synchronization state of the given sketch
+// folder. If the sketch has never been synchronized nil is returned.
+func LoadSyncState(sketchDir *paths.Path) (*SyncState, error) {
+	data, err := sketchDir.Join(SyncStateFileName).ReadFile()
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state SyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("%s: %w", tr("reading cloud sync state"), err)
+	}
+	return &state, nil
+}
+
+// SaveSyncState saves the synchronization state of the given sketch folder
+func SaveSyncState(sketchDir *paths.Path, remote *Sketch, files map[string][]byte) error {
+	state := &SyncState{
+		SketchID:         remote.ID,
+		RemoteModifiedAt: remote.ModifiedAt,
+		Files:            map[string]string{},
+	}
+	for path, data := range files {
+		state.Files[path] = hashFile(data)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return sketchDir.Join(SyncStateFileName).WriteFile(data)
+}
+
+// LocalFiles returns the files of the sketch in the given folder, indexed
+// by their slash separated path relative to the sketch folder.
+func LocalFiles(sketchDir *paths.Path) (map[string][]byte, error) {
+	sk, err := sketch.New(sketchDir)
+	if err != nil {
+		return nil, err
+	}
+	files := paths.PathList{sk.MainFile}
+	files.AddAll(sk.OtherSketchFiles)
+	files.AddAll(sk.AdditionalFiles)
+	if projectFile := sk.GetProjectPath(); projectFile.Exist() {
+		files.Add(projectFile)
+	}
+
+	res := map[string][]byte{}
+	for _, f := range files {
+		rel, err := f.RelFrom(sk.FullPath)
+		if err != nil {
+			return nil, err
+		}
+		data, err := f.ReadFile()
+		if err != nil {
+			return nil, err
+		}
+		res[filepath.ToSlash(rel.String())] = data
+	}
+	return res, nil
+}
+
+// RemoteFiles returns the decoded files of the given cloud sketch
+func RemoteFiles(remote *Sketch) (map[string][]byte, error) {
+	res := map[string][]byte{}
+	if remote == nil {
+		return res, nil
+	}
+	for _, f := range remote.Files {
+		// Do not allow files outside the sketch folder
+		if clean := filepath.Clean(filepath.FromSlash(f.Path)); filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return nil, errors.New(tr("invalid file path in cloud sketch: %s", f.Path))
+		}
+		data, err := base64.StdEncoding.DecodeString(f.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tr("decoding file %s", f.Path), err)
+		}
+		res[f.Path] = data
+	}
+	return res, nil
+}
+
+// EncodeFiles converts the given files into cloud sketch files
+func EncodeFiles(files map[string][]byte) []*File {
+	res := []*File{}
+	for _, path := range sortedKeys(files) {
+		res = append(res, &File{Path: path, Data: base64.StdEncoding.EncodeToString(files[path])})
+	}
+	return res
+}
+
+// ChangeType is the type of a Change
+type ChangeType string
+
+const (
+	// FileAdded is a file that doesn't exist in the destination
+	FileAdded ChangeType = "added"
+	// FileModified is a file that has a different content in the destination
+	FileModified ChangeType = "modified"
+	// FileRemoved is a file that exists only in the destination
+	FileRemoved ChangeType = "removed"
+)
+
+// Change is a change to apply to a file of a sketch
+type Change struct {
+	Path string     `json:"path"`
+	Type ChangeType `json:"type"`
+	Old  []byte     `json:"-"`
+	New  []byte     `json:"-"`
+}
+
+// Diff returns the changes to apply to the dst files to make them equal to
+// the src files, sorted by path.
+func Diff(src, dst map[string][]byte) []*Change {
+	res := []*Change{}
+	for _, path := range sortedKeys(src, dst) {
+		srcData, inSrc := src[path]
+		dstData, inDst := dst[path]
+		switch {
+		case inSrc && !inDst:
+			res = append(res, &Change{Path: path, Type: FileAdded, New: srcData})
+		case !inSrc && inDst:
+			res = append(res, &Change{Path: path, Type: FileRemoved, Old: dstData})
+		case !bytes.Equal(srcData, dstData):
+			res = append(res, &Change{Path: path, Type: FileModified, Old: dstData, New: srcData})
+		}
+	}
+	return res
+}
+
+// UnifiedDiff returns a line based diff of the change, where removed lines
+// are prefixed with "-" and added lines with "+".
+func (c *Change) UnifiedDiff() string {
+	dmp := diffmatchpatch.New()
+	oldChars, newChars, lines := dmp.DiffLinesToChars(string(c.Old), string(c.New))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(oldChars, newChars, false), lines)
+
+	var res strings.Builder
+	fmt.Fprintf(&res, "--- %s\n+++ %s\n", c.Path, c.Path)
+	for _, d := range diffs {
+		prefix := " "
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		}
+		for _, line := range strings.SplitAfter(d.Text, "\n") {
+			if line == "" {
+				continue
+			}
+			res.WriteString(prefix + strings.TrimSuffix(line, "\n") + "\n")
+		}
+	}
+	return res.String()
+}
+
+// FindConflicts returns the files that would be overwritten by replacing the
+// dst files with the src files, and that have been modified in dst since the
+// last synchronization. If the sketch was never synchronized (state is nil)
+// all the dst files that differ from src are conflicts.
+func FindConflicts(state *SyncState, src, dst map[string][]byte) []string {
+	res := []string{}
+	for _, change := range Diff(src, dst) {
+		if change.Type == FileAdded {
+			// The file doesn't exist in dst: it may have been removed after the
+			// last synchronization, but there are no changes to be lost.
+			continue
+		}
+		if state != nil && hashFile(change.Old) == state.Files[change.Path] {
+			// dst file not modified since the last synchronization
+			continue
+		}
+		res = append(res, change.Path)
+	}
+	return res
+}
+
+// ApplyChanges applies the given changes to the sketch folder
+func ApplyChanges(sketchDir *paths.Path, changes []*Change) error {
+	for _, c := range changes {
+		target := sketchDir.Join(c.Path)
+		if c.Type == FileRemoved {
+			if err := target.Remove(); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := target.Parent().MkdirAll(); err != nil {
+			return err
+		}
+		if err := target.WriteFile(c.New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hashFile(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func sortedKeys(maps ...map[string][]byte) []string {
+	keys := map[string]bool{}
+	for _, m := range maps {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	res := []string{}
+	for k := range keys {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
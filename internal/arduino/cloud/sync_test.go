@@ -0,0 +1,161 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffAndConflicts(t *testing.T) {
+	local := map[string][]byte{
+		"Sketch.ino": []byte("void setup() {}\nvoid loop() {}\n"),
+		"other.h":    []byte("#define A 1\n"),
+	}
+	remote := map[string][]byte{
+		"Sketch.ino": []byte("void setup() {}\n"),
+		"old.h":      []byte("#define B 2\n"),
+	}
+
+	changes := Diff(local, remote)
+	require.Len(t, changes, 3)
+	require.Equal(t, "Sketch.ino", changes[0].Path)
+	require.Equal(t, FileModified, changes[0].Type)
+	require.Equal(t, "old.h", changes[1].Path)
+	require.Equal(t, FileRemoved, changes[1].Type)
+	require.Equal(t, "other.h", changes[2].Path)
+	require.Equal(t, FileAdded, changes[2].Type)
+	require.Equal(t, "--- Sketch.ino\n+++ Sketch.ino\n void setup() {}\n+void loop() {}\n", changes[0].UnifiedDiff())
+
+	// Never synchronized: every remote file that would be lost is a conflict
+	require.Equal(t, []string{"Sketch.ino", "old.h"}, FindConflicts(nil, local, remote))
+
+	// Remote files unchanged since the last synchronization
+	state := &SyncState{Files: map[string]string{
+		"Sketch.ino": hashFile(remote["Sketch.ino"]),
+		"old.h":      hashFile(remote["old.h"]),
+	}}
+	require.Empty(t, FindConflicts(state, local, remote))
+
+	// Remote file modified after the last synchronization
+	state.Files["Sketch.ino"] = hashFile([]byte("// old\n"))
+	require.Equal(t, []string{"Sketch.ino"}, FindConflicts(state, local, remote))
+}
+
+func TestSyncState(t *testing.T) {
+	dir := paths.New(t.TempDir())
+	state, err := LoadSyncState(dir)
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	files := map[string][]byte{"Sketch.ino": []byte("void setup() {}\n")}
+	require.NoError(t, SaveSyncState(dir, &Sketch{ID: "123", ModifiedAt: "2024-01-01T00:00:00Z"}, files))
+	state, err = LoadSyncState(dir)
+	require.NoError(t, err)
+	require.Equal(t, "123", state.SketchID)
+	require.Equal(t, "2024-01-01T00:00:00Z", state.RemoteModifiedAt)
+	require.Equal(t, hashFile(files["Sketch.ino"]), state.Files["Sketch.ino"])
+}
+
+func TestLocalAndRemoteFiles(t *testing.T) {
+	dir := paths.New(t.TempDir()).Join("Sketch")
+	require.NoError(t, dir.Join("src").MkdirAll())
+	require.NoError(t, dir.Join("Sketch.ino").WriteFile([]byte("void setup() {}\n")))
+	require.NoError(t, dir.Join("src", "util.h").WriteFile([]byte("#pragma once\n")))
+	require.NoError(t, dir.Join(SyncStateFileName).WriteFile([]byte("{}")))
+
+	files, err := LocalFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Contains(t, files, "Sketch.ino")
+	require.Contains(t, files, "src/util.h")
+
+	// Files round-trip through the cloud encoding
+	remote, err := RemoteFiles(&Sketch{Files: EncodeFiles(files)})
+	require.NoError(t, err)
+	require.Equal(t, files, remote)
+
+	// Files outside the sketch folder are rejected
+	_, err = RemoteFiles(&Sketch{Files: []*File{{Path: "../evil.ino"}}})
+	require.Error(t, err)
+
+	// Apply the changes to a new folder
+	dest := paths.New(t.TempDir()).Join("Sketch")
+	require.NoError(t, ApplyChanges(dest, Diff(remote, map[string][]byte{})))
+	data, err := dest.Join("src", "util.h").ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "#pragma once\n", string(data))
+}
+
+func TestClient(t *testing.T) {
+	_, err := NewClient("http://localhost", "", nil)
+	require.Error(t, err)
+
+	stored := map[string]*Sketch{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"detail":"invalid token"}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sketches":
+			list := []*Sketch{}
+			for _, sk := range stored {
+				list = append(list, &Sketch{ID: sk.ID, Name: sk.Name})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"sketches": list})
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(stored[r.URL.Path[len("/sketches/"):]])
+		case r.Method == http.MethodPost:
+			var sk Sketch
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sk))
+			sk.ID = "1"
+			stored[sk.ID] = &sk
+			json.NewEncoder(w).Encode(&sk)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	unauthorized, err := NewClient(srv.URL, "wrong", nil)
+	require.NoError(t, err)
+	_, err = unauthorized.ListSketches(ctx)
+	require.ErrorContains(t, err, "invalid token")
+
+	client, err := NewClient(srv.URL+"/", "secret", nil)
+	require.NoError(t, err)
+	sk, err := client.FindSketch(ctx, "Blink")
+	require.NoError(t, err)
+	require.Nil(t, sk)
+
+	files := map[string][]byte{"Blink.ino": []byte("void setup() {}\n")}
+	saved, err := client.SaveSketch(ctx, &Sketch{Name: "Blink", Files: EncodeFiles(files)})
+	require.NoError(t, err)
+	require.Equal(t, "1", saved.ID)
+
+	sk, err = client.FindSketch(ctx, "Blink")
+	require.NoError(t, err)
+	remote, err := RemoteFiles(sk)
+	require.NoError(t, err)
+	require.Equal(t, files, remote)
+}
@@ -121,6 +121,16 @@ func (b *Board) GetConfigOptionValues(option string) *properties.Map {
 	return b.configOptionValues[option]
 }
 
+// CompleteFQBN returns a copy of the given FQBN of the board with all the
+// configuration options set: the missing ones are set to the default value.
+func (b *Board) CompleteFQBN(fqbn *FQBN) *FQBN {
+	b.buildConfigOptionsStructures()
+	res := fqbn.Clone()
+	res.Configs = b.defaultConfig.Clone()
+	res.Configs.Merge(fqbn.Configs)
+	return res
+}
+
 // GetBuildProperties returns the build properties and the build
 // platform for the Board with the configuration passed as parameter.
 func (b *Board) GetBuildProperties(fqbn *FQBN) (*properties.Map, error) {
@@ -0,0 +1,77 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package packagemanager
+
+import (
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/go-paths-helper"
+)
+
+// UnusedPlatformReleases returns the installed platform releases managed by
+// the package manager that are not used, because another release of the same
+// platform takes precedence.
+func (pme *Explorer) UnusedPlatformReleases() []*cores.PlatformRelease {
+	res := []*cores.PlatformRelease{}
+	for _, targetPackage := range pme.packages {
+		for _, platform := range targetPackage.Platforms {
+			used := pme.GetInstalledPlatformRelease(platform)
+			for _, release := range platform.GetAllInstalled() {
+				if release != used && pme.IsManagedPlatformRelease(release) {
+					res = append(res, release)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// StaleDownloads returns the archives in the downloads directory that are not
+// needed to reinstall the platforms and the tools in use. The archives of the
+// unused platform releases and of the tools not required by any platform are
+// considered stale.
+func (pme *Explorer) StaleDownloads() paths.PathList {
+	res := paths.PathList{}
+	downloadsDir := pme.DownloadDir.Join("packages")
+	archives, err := downloadsDir.ReadDir()
+	if err != nil {
+		return res
+	}
+
+	used := map[string]bool{}
+	for _, targetPackage := range pme.packages {
+		for _, platform := range targetPackage.Platforms {
+			if release := pme.GetInstalledPlatformRelease(platform); release != nil && release.Resource != nil {
+				used[release.Resource.ArchiveFileName] = true
+			}
+		}
+	}
+	for _, tool := range pme.GetAllInstalledToolsReleases() {
+		if tool.Tool.Package.Name != "builtin" && !pme.IsToolRequired(tool) {
+			continue
+		}
+		if resource := tool.GetCompatibleFlavour(); resource != nil {
+			used[resource.ArchiveFileName] = true
+		}
+	}
+
+	for _, archive := range archives {
+		if archive.IsDir() || used[archive.Base()] {
+			continue
+		}
+		res.Add(archive)
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package packagemanager
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+	semver "go.bug.st/relaxed-semver"
+)
+
+func TestCleanupCandidates(t *testing.T) {
+	dataDir := paths.New(t.TempDir())
+	packagesDir := dataDir.Join("packages")
+	downloadDir := dataDir.Join("staging")
+	require.NoError(t, downloadDir.Join("packages").MkdirAll())
+
+	pmb := NewBuilder(dataDir, packagesDir, downloadDir, dataDir.Join("tmp"), "test")
+	platform := pmb.GetOrCreatePackage("test").GetOrCreatePlatform("avr")
+	releases := []*cores.PlatformRelease{}
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		release := platform.GetOrCreateRelease(semver.MustParse(version))
+		release.InstallDir = packagesDir.Join("test", "hardware", "avr", version)
+		require.NoError(t, release.InstallDir.MkdirAll())
+		release.Resource = &resources.DownloadResource{ArchiveFileName: "avr-" + version + ".tar.bz2", CachePath: "packages"}
+		require.NoError(t, downloadDir.Join("packages", release.Resource.ArchiveFileName).WriteFile([]byte{}))
+		releases = append(releases, release)
+	}
+	require.NoError(t, downloadDir.Join("packages", "unknown.zip").WriteFile([]byte{}))
+	pme, release := pmb.Build().NewExplorer()
+	defer release()
+
+	// The latest release is used, the older one can be removed
+	require.Equal(t, []*cores.PlatformRelease{releases[0]}, pme.UnusedPlatformReleases())
+
+	stale := pme.StaleDownloads()
+	stale.Sort()
+	require.Equal(t, paths.PathList{
+		downloadDir.Join("packages", "avr-1.0.0.tar.bz2"),
+		downloadDir.Join("packages", "unknown.zip"),
+	}, stale)
+}
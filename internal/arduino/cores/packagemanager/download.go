@@ -127,6 +127,9 @@ func (pme *Explorer) DownloadToolRelease(tool *cores.ToolRelease, config *downlo
 			Message: tr("Error downloading tool %s", tool),
 			Cause:   errors.New(tr("no versions available for the current OS, try contacting %s", tool.Tool.Package.Email))}
 	}
+	if err := checkToolDownloadPolicy(tool, resource); err != nil {
+		return err
+	}
 	return resource.Download(pme.DownloadDir, config, tool.String(), progressCB, "")
 }
 
@@ -25,7 +25,10 @@ import (
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	"github.com/arduino/arduino-cli/internal/arduino/cores"
 	"github.com/arduino/arduino-cli/internal/arduino/cores/packageindex"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/arduino-cli/version"
 	"github.com/arduino/go-paths-helper"
 )
 
@@ -38,6 +41,7 @@ func (pme *Explorer) DownloadAndInstallPlatformUpgrades(
 	taskCB rpc.TaskProgressCB,
 	skipPostInstall bool,
 	skipPreUninstall bool,
+	keepPrevious bool,
 ) (*cores.PlatformRelease, error) {
 	if platformRef.PlatformVersion != nil {
 		return nil, &cmderrors.InvalidArgumentError{Message: tr("Upgrade doesn't accept parameters with version")}
@@ -62,7 +66,7 @@ func (pme *Explorer) DownloadAndInstallPlatformUpgrades(
 	if err != nil {
 		return nil, &cmderrors.PlatformNotFoundError{Platform: platformRef.String()}
 	}
-	if err := pme.DownloadAndInstallPlatformAndTools(platformRelease, tools, downloadCB, taskCB, skipPostInstall, skipPreUninstall); err != nil {
+	if err := pme.DownloadAndInstallPlatformAndTools(platformRelease, tools, downloadCB, taskCB, skipPostInstall, skipPreUninstall, keepPrevious); err != nil {
 		return nil, err
 	}
 
@@ -72,10 +76,13 @@ func (pme *Explorer) DownloadAndInstallPlatformUpgrades(
 // DownloadAndInstallPlatformAndTools runs a full installation process for the given platform and tools.
 // This method takes care of downloading missing archives, installing/upgrading platforms and tools, and
 // removing the previously installed platform/tools that are no longer needed after the upgrade.
+// The previously installed platform is removed only after the new one has been installed
+// successfully, if the installation fails the tools installed in the process are removed.
+// If keepPrevious is true the previously installed platform and tools are left installed.
 func (pme *Explorer) DownloadAndInstallPlatformAndTools(
 	platformRelease *cores.PlatformRelease, requiredTools []*cores.ToolRelease,
 	downloadCB rpc.DownloadProgressCB, taskCB rpc.TaskProgressCB,
-	skipPostInstall bool, skipPreUninstall bool) error {
+	skipPostInstall bool, skipPreUninstall bool, keepPrevious bool) error {
 	log := pme.log.WithField("platform", platformRelease)
 
 	// Prerequisite checks before install
@@ -101,11 +108,23 @@ func (pme *Explorer) DownloadAndInstallPlatformAndTools(
 	}
 	taskCB(&rpc.TaskProgress{Completed: true})
 
-	// Install tools first
+	// Install tools first, keeping track of them to roll back the installation on failure
+	newlyInstalledTools := []*cores.ToolRelease{}
+	rollbackTools := func() {
+		for _, tool := range newlyInstalledTools {
+			taskCB(&rpc.TaskProgress{Name: tr("Rolling back installation of %s", tool)})
+			if err := pme.UninstallTool(tool, taskCB, true); err != nil {
+				log.WithError(err).Error("Error rolling-back changes.")
+				taskCB(&rpc.TaskProgress{Message: tr("Error rolling-back changes: %s", err)})
+			}
+		}
+	}
 	for _, tool := range toolsToInstall {
 		if err := pme.InstallTool(tool, taskCB, skipPostInstall); err != nil {
+			rollbackTools()
 			return err
 		}
+		newlyInstalledTools = append(newlyInstalledTools, tool)
 	}
 
 	installed := pme.GetInstalledPlatformRelease(platformRelease.Platform)
@@ -130,6 +149,7 @@ func (pme *Explorer) DownloadAndInstallPlatformAndTools(
 		var err error
 		_, installedTools, err = pme.FindPlatformReleaseDependencies(platformRef)
 		if err != nil {
+			rollbackTools()
 			return &cmderrors.NotFoundError{Message: tr("Can't find dependencies for platform %s", platformRef), Cause: err}
 		}
 	}
@@ -137,11 +157,14 @@ func (pme *Explorer) DownloadAndInstallPlatformAndTools(
 	// Install
 	if err := pme.InstallPlatform(platformRelease); err != nil {
 		log.WithError(err).Error("Cannot install platform")
+		rollbackTools()
 		return &cmderrors.FailedInstallError{Message: tr("Cannot install platform"), Cause: err}
 	}
 
-	// If upgrading remove previous release
-	if installed != nil {
+	// If upgrading remove previous release, unless it should be kept
+	if installed != nil && keepPrevious {
+		taskCB(&rpc.TaskProgress{Message: tr("Keeping previous platform %s installed", installed), Completed: true})
+	} else if installed != nil {
 		uninstallErr := pme.UninstallPlatform(installed, taskCB, skipPreUninstall)
 
 		// In case of error try to rollback
@@ -154,6 +177,7 @@ func (pme *Explorer) DownloadAndInstallPlatformAndTools(
 				log.WithError(err).Error("Error rolling-back changes.")
 				taskCB(&rpc.TaskProgress{Message: tr("Error rolling-back changes: %s", err)})
 			}
+			rollbackTools()
 
 			return &cmderrors.FailedInstallError{Message: tr("Cannot upgrade platform"), Cause: uninstallErr}
 		}
@@ -168,23 +192,12 @@ func (pme *Explorer) DownloadAndInstallPlatformAndTools(
 
 	}
 
-	// Perform post install
-	if !skipPostInstall {
-		log.Info("Running post_install script")
-		taskCB(&rpc.TaskProgress{Message: tr("Configuring platform.")})
-		if !platformRelease.IsInstalled() {
-			return errors.New(tr("platform not installed"))
-		}
-		stdout, stderr, err := pme.RunPreOrPostScript(platformRelease.InstallDir, "post_install")
-		skipEmptyMessageTaskProgressCB(taskCB)(&rpc.TaskProgress{Message: string(stdout), Completed: true})
-		skipEmptyMessageTaskProgressCB(taskCB)(&rpc.TaskProgress{Message: string(stderr), Completed: true})
-		if err != nil {
-			taskCB(&rpc.TaskProgress{Message: tr("WARNING cannot configure platform: %s", err), Completed: true})
-		}
-
-	} else {
-		log.Info("Skipping platform configuration.")
-		taskCB(&rpc.TaskProgress{Message: tr("Skipping platform configuration.")})
+	// The post-install script and the additional setup steps declared by the
+	// platform are never run automatically: the user must review and confirm
+	// them with the `core post-install` command
+	if len(platformRelease.GetPostInstallSteps()) > 0 {
+		taskCB(&rpc.TaskProgress{Message: tr("Platform %[1]s requires additional setup, run `%[2]s core post-install %[3]s` to review and apply it.",
+			platformRelease, version.VersionInfo.Application, platformRelease.Platform), Completed: true})
 	}
 
 	log.Info("Platform installed")
@@ -238,7 +251,7 @@ func (pme *Explorer) RunPreOrPostScript(installDir *paths.Path, prefix string) (
 	}
 	script := installDir.Join(scriptFilename)
 	if script.Exist() && script.IsNotDir() {
-		cmd, err := paths.NewProcessFromPath(pme.GetEnvVarsForSpawnedProcess(), script)
+		cmd, err := toolenv.NewProcess(pme.GetEnvVarsForSpawnedProcess(), script.String())
 		if err != nil {
 			return []byte{}, []byte{}, err
 		}
@@ -303,11 +316,17 @@ func (pme *Explorer) UninstallPlatform(platformRelease *cores.PlatformRelease, t
 		taskCB(&rpc.TaskProgress{Message: tr("Skipping pre_uninstall script.")})
 	}
 
-	if err := platformRelease.InstallDir.RemoveAll(); err != nil {
+	// Move the platform aside before removing it, so that a failed removal
+	// doesn't leave an half-deleted platform in place
+	backup, err := resources.BackupDir(platformRelease.InstallDir)
+	if err != nil {
 		err = fmt.Errorf(tr("removing platform files: %s"), err)
 		log.WithError(err).Error("Error uninstalling")
 		return &cmderrors.FailedUninstallError{Message: err.Error()}
 	}
+	if err := backup.Discard(); err != nil {
+		log.WithError(err).Warn("Error removing platform files")
+	}
 
 	platformRelease.InstallDir = nil
 
@@ -334,12 +353,26 @@ func (pme *Explorer) InstallTool(toolRelease *cores.ToolRelease, taskCB rpc.Task
 		return fmt.Errorf(tr("no compatible version of %[1]s tools found for the current os, try contacting %[2]s"),
 			toolRelease.Tool.Name, toolRelease.Tool.Package.Email)
 	}
+	// An archive that doesn't match the checksum of the index is moved away,
+	// it may have been tampered with
+	var checksumMismatch *resources.ChecksumMismatchError
+	if _, err := toolResource.TestLocalArchiveChecksum(pme.DownloadDir); errors.As(err, &checksumMismatch) {
+		cause := errors.New(tr("the archive doesn't match the checksum in the package index"))
+		if quarantined, err := pme.quarantineArchive(toolResource); err == nil {
+			cause = errors.New(tr("the archive doesn't match the checksum in the package index, it has been moved to %s", quarantined))
+		}
+		log.WithError(cause).Warn("Cannot install tool")
+		return &cmderrors.FailedInstallError{Message: tr("Cannot install tool %s", toolRelease), Cause: cause}
+	}
 	destDir := pme.PackagesDir.Join(
 		toolRelease.Tool.Package.Name,
 		"tools",
 		toolRelease.Tool.Name,
 		toolRelease.Version.String())
-	err := toolResource.Install(pme.DownloadDir, pme.tempDir, destDir)
+	shared, err := pme.installSharedTool(toolResource, destDir)
+	if err == nil && !shared {
+		err = toolResource.Install(pme.DownloadDir, pme.tempDir, destDir)
+	}
 	if err != nil {
 		log.WithError(err).Warn("Cannot install tool")
 		return &cmderrors.FailedInstallError{Message: tr("Cannot install tool %s", toolRelease), Cause: err}
@@ -349,6 +382,9 @@ func (pme *Explorer) InstallTool(toolRelease *cores.ToolRelease, taskCB rpc.Task
 	} else {
 		return err
 	}
+	if err := pme.recordToolInstall(toolRelease, toolResource); err != nil {
+		log.WithError(err).Warn("Cannot record the tool in the tools database")
+	}
 	// Perform post install
 	if !skipPostInstall {
 		log.Info("Running tool post_install script")
@@ -402,7 +438,17 @@ func (pme *Explorer) UninstallTool(toolRelease *cores.ToolRelease, taskCB rpc.Ta
 		return err
 	}
 
-	if !skipPreUninstall {
+	// The shared copy of the tool is kept if other installed tools link to it
+	sharedDir := pme.sharedToolTarget(toolRelease.InstallDir)
+	keepSharedDir := false
+	if sharedDir != nil {
+		refs, err := pme.sharedToolReferences(sharedDir)
+		keepSharedDir = err != nil || len(refs) > 1
+	}
+
+	if keepSharedDir {
+		log.Info("Skipping pre_uninstall script, the tool is shared with other packages")
+	} else if !skipPreUninstall {
 		log.Info("Running pre_uninstall script")
 		taskCB(&rpc.TaskProgress{Message: tr("Running pre_uninstall script.")})
 		stdout, stderr, err := pme.RunPreOrPostScript(toolRelease.InstallDir, "pre_uninstall")
@@ -421,8 +467,18 @@ func (pme *Explorer) UninstallTool(toolRelease *cores.ToolRelease, taskCB rpc.Ta
 		log.WithError(err).Error("Error uninstalling")
 		return err
 	}
+	if sharedDir != nil && !keepSharedDir {
+		if err := sharedDir.RemoveAll(); err != nil {
+			err = &cmderrors.FailedUninstallError{Message: err.Error()}
+			log.WithError(err).Error("Error uninstalling")
+			return err
+		}
+	}
 
 	toolRelease.InstallDir = nil
+	if err := pme.removeToolRecord(toolRelease); err != nil {
+		log.WithError(err).Warn("Cannot remove the tool from the tools database")
+	}
 
 	log.Info("Tool uninstalled")
 	taskCB(&rpc.TaskProgress{Message: tr("Tool %s uninstalled", toolRelease), Completed: true})
@@ -171,6 +171,9 @@ func (pmb *Builder) installMissingProfileTool(toolRelease *cores.ToolRelease, de
 	if toolResource == nil {
 		return &cmderrors.InvalidVersionError{Cause: fmt.Errorf(tr("version %s not available for this operating system", toolRelease))}
 	}
+	if err := checkToolDownloadPolicy(toolRelease, toolResource); err != nil {
+		return err
+	}
 	taskCB(&rpc.TaskProgress{Name: tr("Downloading tool %s", toolRelease)})
 	if err := toolResource.Download(pmb.DownloadDir, nil, toolRelease.String(), downloadCB, ""); err != nil {
 		taskCB(&rpc.TaskProgress{Name: tr("Error downloading tool %s", toolRelease)})
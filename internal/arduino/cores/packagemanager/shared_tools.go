@@ -0,0 +1,226 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package packagemanager
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
+	"github.com/arduino/arduino-cli/internal/arduino/utils"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+)
+
+// The tools are extracted once in the shared tools directory, inside the
+// packages directory, using the checksum of the archive as name:
+//
+//	packages/.shared-tools/sha-256-0123.../
+//
+// and each installed tool is a symlink to its shared copy:
+//
+//	packages/PACKAGER/tools/TOOL/VERSION -> ../../../.shared-tools/sha-256-0123...
+//
+// so the same archive required by platforms of different packagers is stored
+// once. A shared copy is removed when no installed tool links to it anymore.
+const sharedToolsDirName = ".shared-tools"
+
+var sharedToolKeyRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// sharedToolDir returns the directory of the shared copy of the tool archive,
+// or nil if the archive can't be shared because its checksum is unknown.
+func (pme *Explorer) sharedToolDir(resource *resources.DownloadResource) *paths.Path {
+	if pme.PackagesDir == nil || resource.Checksum == "" {
+		return nil
+	}
+	key := sharedToolKeyRegexp.ReplaceAllString(strings.ToLower(resource.Checksum), "-")
+	return pme.PackagesDir.Join(sharedToolsDirName, key)
+}
+
+// installSharedTool installs the tool archive in destDir as a link to its
+// shared copy, extracting the archive only if the shared copy doesn't exist
+// yet. It returns false if the tool can't be shared, for example because the
+// filesystem doesn't support symlinks: in this case the caller must install
+// the tool directly in destDir.
+func (pme *Explorer) installSharedTool(resource *resources.DownloadResource, destDir *paths.Path) (bool, error) {
+	sharedDir := pme.sharedToolDir(resource)
+	if sharedDir == nil {
+		return false, nil
+	}
+	if !sharedDir.IsDir() {
+		if err := resource.Install(pme.DownloadDir, pme.tempDir, sharedDir); err != nil {
+			return false, err
+		}
+	} else {
+		pme.log.WithField("dir", sharedDir).Info("Reusing the shared copy of the tool")
+	}
+
+	if err := destDir.Parent().MkdirAll(); err != nil {
+		return false, err
+	}
+	if _, err := destDir.Lstat(); err == nil {
+		if err := destDir.RemoveAll(); err != nil {
+			return false, err
+		}
+	}
+	// The link is relative so the data directory can be moved
+	target, err := filepath.Rel(destDir.Parent().String(), sharedDir.String())
+	if err != nil {
+		target = sharedDir.String()
+	}
+	if err := os.Symlink(target, destDir.String()); err != nil {
+		pme.log.WithError(err).Warn("Cannot link the shared copy of the tool, installing a private copy")
+		if refs, err := pme.sharedToolReferences(sharedDir); err == nil && len(refs) == 0 {
+			sharedDir.RemoveAll()
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// sharedToolTarget returns the shared copy linked by the tool install
+// directory, or nil if the tool has a private copy.
+func (pme *Explorer) sharedToolTarget(installDir *paths.Path) *paths.Path {
+	if pme.PackagesDir == nil {
+		return nil
+	}
+	info, err := installDir.Lstat()
+	if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+		return nil
+	}
+	target, err := os.Readlink(installDir.String())
+	if err != nil {
+		return nil
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(installDir.Parent().String(), target)
+	}
+	targetDir := paths.New(target).Clean()
+	if targetDir.Parent().EquivalentTo(pme.PackagesDir.Join(sharedToolsDirName)) {
+		return targetDir
+	}
+	return nil
+}
+
+// ToolStorageDir returns the directory where the files of the installed tool
+// are stored and true if it's a copy shared with the same tool of other
+// packages.
+func (pme *Explorer) ToolStorageDir(toolRelease *cores.ToolRelease) (*paths.Path, bool) {
+	if target := pme.sharedToolTarget(toolRelease.InstallDir); target != nil {
+		return target, true
+	}
+	return toolRelease.InstallDir, false
+}
+
+// sharedToolReferences returns the installed tools linking to the given
+// shared copy.
+func (pme *Explorer) sharedToolReferences(sharedDir *paths.Path) (paths.PathList, error) {
+	refs := paths.PathList{}
+	packagers, err := pme.PackagesDir.ReadDir()
+	if err != nil {
+		return nil, err
+	}
+	packagers.FilterOutHiddenFiles()
+	for _, packager := range packagers {
+		tools, err := packager.Join("tools").ReadDir()
+		if err != nil {
+			continue
+		}
+		for _, tool := range tools {
+			versions, err := tool.ReadDir()
+			if err != nil {
+				continue
+			}
+			for _, version := range versions {
+				if target := pme.sharedToolTarget(version); target != nil && target.EquivalentTo(sharedDir) {
+					refs.Add(version)
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// CleanTools removes the installed tools that are not required by any
+// installed platform and the shared copies of the tools that are not linked
+// anymore. The tools of the `builtin` package are kept, since they are used
+// by the CLI itself. It returns the removed tools and the disk space freed, if
+// dryRun is true nothing is removed.
+func (pme *Explorer) CleanTools(dryRun bool, taskCB rpc.TaskProgressCB) ([]*cores.ToolRelease, int64, error) {
+	orphans := []*cores.ToolRelease{}
+	for _, tool := range pme.GetAllInstalledToolsReleases() {
+		if tool.Tool.Package.Name == "builtin" || !pme.IsManagedToolRelease(tool) || pme.IsToolRequired(tool) {
+			continue
+		}
+		orphans = append(orphans, tool)
+	}
+
+	// Count the links to the shared copies that will remain
+	sharedDirs := paths.PathList{}
+	if dirs, err := pme.PackagesDir.Join(sharedToolsDirName).ReadDir(); err == nil {
+		sharedDirs = dirs
+	}
+	remainingRefs := make([]int, len(sharedDirs))
+	for i, sharedDir := range sharedDirs {
+		refs, err := pme.sharedToolReferences(sharedDir)
+		if err != nil {
+			return nil, 0, err
+		}
+		remainingRefs[i] = len(refs)
+	}
+
+	var freed int64
+	for _, tool := range orphans {
+		target := pme.sharedToolTarget(tool.InstallDir)
+		if target == nil {
+			freed += utils.DirSize(tool.InstallDir)
+			continue
+		}
+		for i, sharedDir := range sharedDirs {
+			if sharedDir.EquivalentTo(target) {
+				remainingRefs[i]--
+			}
+		}
+	}
+	unusedSharedDirs := paths.PathList{}
+	for i, sharedDir := range sharedDirs {
+		if remainingRefs[i] <= 0 {
+			unusedSharedDirs.Add(sharedDir)
+			freed += utils.DirSize(sharedDir)
+		}
+	}
+	if dryRun {
+		return orphans, freed, nil
+	}
+
+	for _, tool := range orphans {
+		if err := pme.UninstallTool(tool, taskCB, false); err != nil {
+			return nil, 0, err
+		}
+	}
+	for _, sharedDir := range unusedSharedDirs {
+		// The shared copies linked by the removed tools have already been
+		// removed by UninstallTool
+		if err := sharedDir.RemoveAll(); err != nil {
+			return nil, 0, err
+		}
+	}
+	return orphans, freed, nil
+}
@@ -0,0 +1,83 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package packagemanager
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+	semver "go.bug.st/relaxed-semver"
+)
+
+func TestSharedTools(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks may not be available")
+	}
+	dataDir := paths.New(t.TempDir())
+	packagesDir := dataDir.Join("packages")
+	downloadDir := dataDir.Join("staging")
+	require.NoError(t, downloadDir.MkdirAll())
+	resource := createToolArchive(t, downloadDir, "gcc-7.3.0.zip")
+
+	// The same archive is provided by two packagers
+	pmb := NewBuilder(dataDir, packagesDir, downloadDir, dataDir.Join("tmp"), "test")
+	tools := []*cores.ToolRelease{}
+	for _, packager := range []string{"alice", "bob"} {
+		tool := pmb.GetOrCreatePackage(packager).GetOrCreateTool("gcc").GetOrCreateRelease(semver.ParseRelaxed("7.3.0"))
+		tool.Flavors = []*cores.Flavor{{OS: "all", Resource: resource}}
+		tools = append(tools, tool)
+	}
+	pme, release := pmb.Build().NewExplorer()
+	defer release()
+	taskCB := func(*rpc.TaskProgress) {}
+
+	for _, tool := range tools {
+		require.NoError(t, pme.InstallTool(tool, taskCB, true))
+		require.FileExists(t, tool.InstallDir.Join("tool.sh").String())
+		require.True(t, pme.IsManagedToolRelease(tool))
+	}
+	sharedDir := pme.sharedToolDir(resource)
+	require.DirExists(t, sharedDir.String())
+	refs, err := pme.sharedToolReferences(sharedDir)
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	storageDir, shared := pme.ToolStorageDir(tools[0])
+	require.True(t, shared)
+	require.True(t, storageDir.EquivalentTo(sharedDir))
+
+	// The shared copy is kept until the last tool is uninstalled
+	require.NoError(t, pme.UninstallTool(tools[0], taskCB, true))
+	require.DirExists(t, sharedDir.String())
+	require.FileExists(t, tools[1].InstallDir.Join("tool.sh").String())
+
+	// The tools not required by any platform are removed
+	removed, freed, err := pme.CleanTools(true, taskCB)
+	require.NoError(t, err)
+	require.Equal(t, []*cores.ToolRelease{tools[1]}, removed)
+	require.Equal(t, int64(len("echo tool")), freed)
+	require.DirExists(t, sharedDir.String(), "nothing is removed in dry-run mode")
+
+	removed, freed, err = pme.CleanTools(false, taskCB)
+	require.NoError(t, err)
+	require.Equal(t, []*cores.ToolRelease{tools[1]}, removed)
+	require.Equal(t, int64(len("echo tool")), freed)
+	require.NoDirExists(t, sharedDir.String())
+	require.Nil(t, tools[1].InstallDir)
+}
@@ -0,0 +1,170 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package packagemanager
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/go-paths-helper"
+)
+
+// ToolInstallRecord is the record of the archive a tool has been installed from
+type ToolInstallRecord struct {
+	Packager        string    `json:"packager"`
+	Name            string    `json:"name"`
+	Version         string    `json:"version"`
+	URL             string    `json:"url"`
+	ArchiveFileName string    `json:"archive_file_name"`
+	Checksum        string    `json:"checksum"`
+	Size            int64     `json:"size"`
+	InstalledAt     time.Time `json:"installed_at"`
+}
+
+// toolsDatabaseFileName is the name of the file, in the packages directory,
+// that keeps the records of the installed tools
+const toolsDatabaseFileName = "tools_database.json"
+
+func toolRecordKey(tool *cores.ToolRelease) string {
+	return tool.Tool.Package.Name + ":" + tool.Tool.Name + "@" + tool.Version.String()
+}
+
+func (pme *Explorer) toolsDatabasePath() *paths.Path {
+	return pme.PackagesDir.Join(toolsDatabaseFileName)
+}
+
+// LoadToolsDatabase returns the records of the tools installed by the package
+// manager, indexed by `PACKAGER:TOOL@VERSION`. The tools installed by previous
+// versions of the CLI have no record.
+func (pme *Explorer) LoadToolsDatabase() (map[string]*ToolInstallRecord, error) {
+	records := map[string]*ToolInstallRecord{}
+	if pme.PackagesDir == nil {
+		return records, nil
+	}
+	if !pme.toolsDatabasePath().Exist() {
+		return records, nil
+	}
+	data, err := pme.toolsDatabasePath().ReadFile()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.New(tr("reading %[1]s: %[2]s", pme.toolsDatabasePath(), err))
+	}
+	return records, nil
+}
+
+// GetToolInstallRecord returns the record of the installed tool, or nil if the
+// tool has no record.
+func (pme *Explorer) GetToolInstallRecord(tool *cores.ToolRelease) (*ToolInstallRecord, error) {
+	records, err := pme.LoadToolsDatabase()
+	if err != nil {
+		return nil, err
+	}
+	return records[toolRecordKey(tool)], nil
+}
+
+func (pme *Explorer) updateToolsDatabase(update func(records map[string]*ToolInstallRecord)) error {
+	records, err := pme.LoadToolsDatabase()
+	if err != nil {
+		// A corrupted database is replaced, the records are used only for auditing
+		pme.log.WithError(err).Warn("Replacing the tools database")
+		records = map[string]*ToolInstallRecord{}
+	}
+	update(records)
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := pme.PackagesDir.MkdirAll(); err != nil {
+		return err
+	}
+	return pme.toolsDatabasePath().WriteFile(data)
+}
+
+func (pme *Explorer) recordToolInstall(tool *cores.ToolRelease, resource *resources.DownloadResource) error {
+	return pme.updateToolsDatabase(func(records map[string]*ToolInstallRecord) {
+		records[toolRecordKey(tool)] = &ToolInstallRecord{
+			Packager:        tool.Tool.Package.Name,
+			Name:            tool.Tool.Name,
+			Version:         tool.Version.String(),
+			URL:             resource.URL,
+			ArchiveFileName: resource.ArchiveFileName,
+			Checksum:        resource.Checksum,
+			Size:            resource.Size,
+			InstalledAt:     time.Now().UTC().Truncate(time.Second),
+		}
+	})
+}
+
+func (pme *Explorer) removeToolRecord(tool *cores.ToolRelease) error {
+	return pme.updateToolsDatabase(func(records map[string]*ToolInstallRecord) {
+		delete(records, toolRecordKey(tool))
+	})
+}
+
+// IsInsecureURL returns true if the URL is not downloaded over an encrypted
+// transport. Local files are considered secure.
+func IsInsecureURL(u string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return true
+	}
+	return strings.EqualFold(parsed.Scheme, "http") || strings.EqualFold(parsed.Scheme, "ftp")
+}
+
+// checkToolDownloadPolicy returns an error if the tool archive can't be
+// downloaded because of the `board_manager.require_secure_tool_downloads`
+// setting.
+func checkToolDownloadPolicy(tool *cores.ToolRelease, resource *resources.DownloadResource) error {
+	if configuration.Settings == nil || !configuration.Settings.GetBool("board_manager.require_secure_tool_downloads") {
+		return nil
+	}
+	if IsInsecureURL(resource.URL) {
+		return &cmderrors.FailedDownloadError{
+			Message: tr("Error downloading tool %s", tool),
+			Cause:   errors.New(tr("the tool is served over an insecure transport (%s) and insecure tool downloads are forbidden by the board_manager.require_secure_tool_downloads setting", resource.URL)),
+		}
+	}
+	return nil
+}
+
+// quarantineArchive moves a downloaded archive that doesn't match the expected
+// checksum to the `quarantine` subdirectory of the downloads directory, so it
+// can be inspected but it's not used again. It returns the new path of the
+// archive.
+func (pme *Explorer) quarantineArchive(resource *resources.DownloadResource) (*paths.Path, error) {
+	archivePath, err := resource.ArchivePath(pme.DownloadDir)
+	if err != nil {
+		return nil, err
+	}
+	quarantineDir := pme.DownloadDir.Join("quarantine")
+	if err := quarantineDir.MkdirAll(); err != nil {
+		return nil, err
+	}
+	dest := quarantineDir.Join(archivePath.Base())
+	if err := archivePath.Rename(dest); err != nil {
+		return nil, err
+	}
+	return dest, nil
+}
@@ -0,0 +1,98 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package packagemanager
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+	semver "go.bug.st/relaxed-semver"
+)
+
+func TestToolsDatabase(t *testing.T) {
+	dataDir := paths.New(t.TempDir())
+	downloadDir := dataDir.Join("staging")
+	require.NoError(t, downloadDir.MkdirAll())
+
+	pmb := NewBuilder(dataDir, dataDir.Join("packages"), downloadDir, dataDir.Join("tmp"), "test")
+	toolRelease := pmb.GetOrCreatePackage("test").GetOrCreateTool("tool").GetOrCreateRelease(semver.ParseRelaxed("1.0.0"))
+	resource := createToolArchive(t, downloadDir, "tool-1.0.0.zip")
+	resource.URL = "http://example.com/tool-1.0.0.zip"
+	toolRelease.Flavors = []*cores.Flavor{{OS: "all", Resource: resource}}
+	pme, release := pmb.Build().NewExplorer()
+	defer release()
+	taskCB := func(*rpc.TaskProgress) {}
+
+	// Insecure downloads may be forbidden
+	configuration.Settings = configuration.Init("")
+	configuration.Settings.Set("board_manager.require_secure_tool_downloads", true)
+	defer func() { configuration.Settings = configuration.Init("") }()
+	var downloadErr *cmderrors.FailedDownloadError
+	require.ErrorAs(t, pme.DownloadToolRelease(toolRelease, nil, nil), &downloadErr)
+	require.ErrorContains(t, downloadErr, "insecure transport")
+
+	// The installed tools are recorded
+	require.NoError(t, pme.InstallTool(toolRelease, taskCB, true))
+	record, err := pme.GetToolInstallRecord(toolRelease)
+	require.NoError(t, err)
+	require.Equal(t, "http://example.com/tool-1.0.0.zip", record.URL)
+	require.Equal(t, resource.Checksum, record.Checksum)
+	require.True(t, IsInsecureURL(record.URL))
+
+	require.NoError(t, pme.UninstallTool(toolRelease, taskCB, true))
+	record, err = pme.GetToolInstallRecord(toolRelease)
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	// An archive that doesn't match the checksum is quarantined
+	resource.Checksum = "SHA-256:" + hex.EncodeToString(make([]byte, 32))
+	var installErr *cmderrors.FailedInstallError
+	require.ErrorAs(t, pme.InstallTool(toolRelease, taskCB, true), &installErr)
+	require.ErrorContains(t, installErr, "doesn't match the checksum")
+	require.False(t, downloadDir.Join("tool-1.0.0.zip").Exist())
+	require.True(t, downloadDir.Join("quarantine", "tool-1.0.0.zip").Exist())
+	require.Nil(t, toolRelease.InstallDir)
+}
+
+// createToolArchive creates a tool archive in the downloads directory, as if
+// it has been already downloaded, and returns its resource
+func createToolArchive(t *testing.T, downloadDir *paths.Path, name string) *resources.DownloadResource {
+	archive := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(archive)
+	f, err := zipWriter.Create("tool/tool.sh")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("echo tool"))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, downloadDir.Join(name).WriteFile(archive.Bytes()))
+	sum := sha256.Sum256(archive.Bytes())
+	return &resources.DownloadResource{
+		URL:             "https://example.com/" + name,
+		ArchiveFileName: name,
+		Checksum:        "SHA-256:" + hex.EncodeToString(sum[:]),
+		Size:            int64(archive.Len()),
+	}
+}
@@ -0,0 +1,99 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cores
+
+import (
+	"runtime"
+	"sort"
+
+	"github.com/arduino/go-paths-helper"
+)
+
+// PostInstallScriptStepID is the ID of the post-install step that runs the
+// legacy post_install.sh (or post_install.bat) script of a platform.
+const PostInstallScriptStepID = "post_install_script"
+
+// PostInstallStep is a setup step (for example the installation of udev
+// rules or drivers) declared by a platform, that should be run after the
+// platform installation.
+type PostInstallStep struct {
+	ID            string
+	Description   string
+	Pattern       string
+	RequiresAdmin bool
+}
+
+// GetPostInstallSteps returns the post-install steps declared in the
+// platform.txt with the post_install.steps.STEP_ID.* properties. If the
+// platform contains a legacy post_install script it is returned as a step
+// with the PostInstallScriptStepID ID.
+func (release *PlatformRelease) GetPostInstallSteps() []*PostInstallStep {
+	res := []*PostInstallStep{}
+	if script := release.GetPostInstallScript(); script != nil {
+		res = append(res, &PostInstallStep{
+			ID:          PostInstallScriptStepID,
+			Description: tr("Run the platform post-install script %s", script.Base()),
+			Pattern:     `"` + script.String() + `"`,
+		})
+	}
+
+	stepsProps := release.Properties.SubTree("post_install.steps")
+	ids := stepsProps.FirstLevelKeys()
+	sort.Strings(ids)
+	for _, id := range ids {
+		stepProps := stepsProps.SubTree(id)
+		pattern := stepProps.Get("pattern")
+		if pattern == "" {
+			// Not available for the current OS
+			continue
+		}
+		res = append(res, &PostInstallStep{
+			ID:            id,
+			Description:   stepProps.Get("description"),
+			Pattern:       pattern,
+			RequiresAdmin: stepProps.GetBoolean("requires_admin"),
+		})
+	}
+	return res
+}
+
+// GetPostInstallStep returns the post-install step with the given ID, or nil
+// if the step is not declared by the platform.
+func (release *PlatformRelease) GetPostInstallStep(id string) *PostInstallStep {
+	for _, step := range release.GetPostInstallSteps() {
+		if step.ID == id {
+			return step
+		}
+	}
+	return nil
+}
+
+// GetPostInstallScript returns the path to the legacy post_install script of
+// the platform, or nil if the platform has no post_install script.
+func (release *PlatformRelease) GetPostInstallScript() *paths.Path {
+	if release.InstallDir == nil {
+		return nil
+	}
+	scriptFilename := "post_install.sh"
+	if runtime.GOOS == "windows" {
+		scriptFilename = "post_install.bat"
+	}
+	script := release.InstallDir.Join(scriptFilename)
+	if script.Exist() && script.IsNotDir() {
+		return script
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cores
+
+import (
+	"testing"
+
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPostInstallSteps(t *testing.T) {
+	props, err := properties.LoadFromBytes([]byte(`
+post_install.steps.udev_rules.description=Install udev rules
+post_install.steps.udev_rules.pattern=sudo cp "{runtime.platform.path}/99-board.rules" /etc/udev/rules.d/
+post_install.steps.udev_rules.requires_admin=true
+post_install.steps.driver.description=Install driver
+post_install.steps.driver.pattern=
+`))
+	require.NoError(t, err)
+	release := &PlatformRelease{Properties: props}
+
+	steps := release.GetPostInstallSteps()
+	require.Len(t, steps, 1)
+	require.Equal(t, "udev_rules", steps[0].ID)
+	require.Equal(t, "Install udev rules", steps[0].Description)
+	require.True(t, steps[0].RequiresAdmin)
+
+	require.NotNil(t, release.GetPostInstallStep("udev_rules"))
+	require.Nil(t, release.GetPostInstallStep("driver"))
+}
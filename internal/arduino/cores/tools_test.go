@@ -29,6 +29,7 @@ func TestFlavorCompatibility(t *testing.T) {
 	}
 	windows32 := &os{"windows", "386"}
 	windows64 := &os{"windows", "amd64"}
+	windowsArm64 := &os{"windows", "arm64"}
 	linux32 := &os{"linux", "386"}
 	linux64 := &os{"linux", "amd64"}
 	linuxArm := &os{"linux", "arm"}
@@ -42,6 +43,7 @@ func TestFlavorCompatibility(t *testing.T) {
 	oses := []*os{
 		windows32,
 		windows64,
+		windowsArm64,
 		linux32,
 		linux64,
 		linuxArm,
@@ -60,8 +62,9 @@ func TestFlavorCompatibility(t *testing.T) {
 		ExactMatch  []*os
 	}
 	tests := []*test{
-		{&Flavor{OS: "i686-mingw32"}, []*os{windows32, windows64}, []*os{windows32}},
-		{&Flavor{OS: "x86_64-mingw32"}, []*os{windows64}, []*os{windows64}},
+		{&Flavor{OS: "i686-mingw32"}, []*os{windows32, windows64, windowsArm64}, []*os{windows32}},
+		{&Flavor{OS: "x86_64-mingw32"}, []*os{windows64, windowsArm64}, []*os{windows64}},
+		{&Flavor{OS: "aarch64-w64-mingw32"}, []*os{windowsArm64}, []*os{windowsArm64}},
 		{&Flavor{OS: "i386-apple-darwin11"}, []*os{darwin32, darwin64, darwinArm64}, []*os{darwin32}},
 		{&Flavor{OS: "x86_64-apple-darwin"}, []*os{darwin64, darwinArm64}, []*os{darwin64}},
 		{&Flavor{OS: "arm64-apple-darwin"}, []*os{darwinArm64}, []*os{darwinArm64}},
@@ -170,4 +173,22 @@ func TestFlavorPrioritySelection(t *testing.T) {
 	}).GetFlavourCompatibleWith("windows", "amd64")
 	require.NotNil(t, res)
 	require.Equal(t, "2", res.ArchiveFileName)
+
+	res = (&ToolRelease{
+		Flavors: []*Flavor{
+			{OS: "i686-mingw32", Resource: &resources.DownloadResource{ArchiveFileName: "1"}},
+			{OS: "x86_64-mingw32", Resource: &resources.DownloadResource{ArchiveFileName: "2"}},
+		},
+	}).GetFlavourCompatibleWith("windows", "arm64")
+	require.NotNil(t, res)
+	require.Equal(t, "2", res.ArchiveFileName)
+
+	res = (&ToolRelease{
+		Flavors: []*Flavor{
+			{OS: "x86_64-mingw32", Resource: &resources.DownloadResource{ArchiveFileName: "2"}},
+			{OS: "aarch64-w64-mingw32", Resource: &resources.DownloadResource{ArchiveFileName: "3"}},
+		},
+	}).GetFlavourCompatibleWith("windows", "arm64")
+	require.NotNil(t, res)
+	require.Equal(t, "3", res.ArchiveFileName)
 }
@@ -0,0 +1,137 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package datalog parses the lines printed by a sketch into records and
+// forwards them to the sinks, like an MQTT broker, an InfluxDB server or a
+// SQLite database.
+package datalog
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+)
+
+var tr = i18n.Tr
+
+// Field is a named value of a record, the value is a float64 for the
+// numbers and a string otherwise.
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+// Record is the data parsed from a line
+type Record struct {
+	Time   time.Time
+	Fields []Field
+}
+
+// String returns the fields of the record in the form name=value
+func (r *Record) String() string {
+	res := []string{}
+	for _, f := range r.Fields {
+		res = append(res, fmt.Sprintf("%s=%v", f.Name, f.Value))
+	}
+	return strings.Join(res, " ")
+}
+
+// Parser parses the lines printed by a sketch. It accepts the format of the
+// Arduino Serial Plotter: the values are separated by commas, spaces or tabs
+// and are optionally labeled as label:value (or label=value). The names of
+// the values without a label are given to the parser or taken from a header
+// line, the first line made only of names.
+type Parser struct {
+	names   []string
+	csv     bool
+	started bool
+}
+
+// NewParser returns a parser giving the names to the values without a label,
+// in order. If csv is true the values are separated only by commas and can
+// be quoted.
+func NewParser(names []string, csv bool) *Parser {
+	return &Parser{names: names, csv: csv}
+}
+
+// Parse returns the record parsed from the line, or nil if the line is empty
+// or is the header.
+func (p *Parser) Parse(line string, t time.Time) *Record {
+	values, err := p.split(strings.TrimSpace(line))
+	if err != nil || len(values) == 0 {
+		return nil
+	}
+
+	header := p.names == nil && !p.started
+	for _, value := range values {
+		if _, err := strconv.ParseFloat(value, 64); err == nil || p.label(value) > 0 {
+			header = false
+		}
+	}
+	if header {
+		p.names = values
+		return nil
+	}
+
+	p.started = true
+	record := &Record{Time: t}
+	for i, value := range values {
+		name := ""
+		if sep := p.label(value); sep > 0 {
+			name, value = value[:sep], value[sep+1:]
+		} else if i < len(p.names) {
+			name = p.names[i]
+		} else {
+			name = fmt.Sprintf("value%d", i+1)
+		}
+		record.Fields = append(record.Fields, Field{Name: name, Value: parseValue(value)})
+	}
+	return record
+}
+
+// label returns the position of the separator of the label in the value, or
+// -1 if the value has no label.
+func (p *Parser) label(value string) int {
+	if p.csv {
+		return -1
+	}
+	return strings.IndexAny(value, ":=")
+}
+
+func (p *Parser) split(line string) ([]string, error) {
+	if line == "" {
+		return nil, nil
+	}
+	if p.csv {
+		r := csv.NewReader(strings.NewReader(line))
+		r.TrimLeadingSpace = true
+		return r.Read()
+	}
+	return strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}), nil
+}
+
+// parseValue returns the value as a float64 if it's a number
+func parseValue(value string) interface{} {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
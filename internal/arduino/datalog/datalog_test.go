@@ -0,0 +1,120 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalog
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+var testTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParser(t *testing.T) {
+	parse := func(p *Parser, line string) string {
+		record := p.Parse(line, testTime)
+		if record == nil {
+			return "<nil>"
+		}
+		return record.String()
+	}
+
+	// Labeled values, as printed for the Serial Plotter
+	p := NewParser(nil, false)
+	require.Equal(t, "temp=21.5 hum=40", parse(p, "temp:21.5,hum:40\r"))
+	require.Equal(t, "temp=21.5 value2=40 state=ok", parse(p, "temp=21.5 40\tstate=ok"))
+	require.Equal(t, "<nil>", parse(p, ""))
+	require.Equal(t, "value1=Booting...", parse(p, "Booting..."))
+
+	// Header line
+	p = NewParser(nil, false)
+	require.Equal(t, "<nil>", parse(p, "temp hum"))
+	require.Equal(t, "temp=21.5 hum=40 value3=1", parse(p, "21.5 40 1"))
+
+	// Names given
+	p = NewParser([]string{"a", "b"}, false)
+	require.Equal(t, "a=x b=2", parse(p, "x,2"))
+
+	// CSV
+	p = NewParser(nil, true)
+	require.Equal(t, "<nil>", parse(p, "time,message"))
+	require.Equal(t, "time=12:30:00 message=hello, world", parse(p, `12:30:00, "hello, world"`))
+}
+
+func TestLineProtocol(t *testing.T) {
+	record := &Record{Time: testTime, Fields: []Field{
+		{Name: "temp", Value: 21.5},
+		{Name: "room name", Value: `kitchen "1"`},
+	}}
+	require.Equal(t,
+		`arduino,port=/dev/ttyACM0 temp=21.5,room\ name="kitchen \"1\"" 1714564800000000000`+"\n",
+		lineProtocol("arduino", map[string]string{"port": "/dev/ttyACM0"}, record))
+	require.Equal(t, "", lineProtocol("arduino", nil, &Record{Time: testTime}))
+}
+
+func TestInfluxSink(t *testing.T) {
+	var query, auth, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, auth = r.URL.RawQuery, r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := OpenSink("influx://"+strings.TrimPrefix(server.URL, "http://")+"/sensors?measurement=room&token=secret", "COM3")
+	require.NoError(t, err)
+	defer sink.Close()
+	require.NoError(t, sink.Write(&Record{Time: testTime, Fields: []Field{{Name: "temp", Value: 21.5}}}))
+	require.Equal(t, "db=sensors&precision=ns", query)
+	require.Equal(t, "Token secret", auth)
+	require.Equal(t, "room,port=COM3 temp=21.5 1714564800000000000\n", body)
+
+	_, err = OpenSink("influx://localhost", "COM3")
+	require.ErrorContains(t, err, "the database is missing")
+	_, err = OpenSink("ftp://localhost", "COM3")
+	require.ErrorContains(t, err, "the scheme must be one of")
+}
+
+func TestSQLiteSink(t *testing.T) {
+	dbFile := paths.New(t.TempDir()).Join("log.db")
+	sink, err := OpenSink("sqlite:"+dbFile.String()+"?table=sensors", "COM3")
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(&Record{Time: testTime, Fields: []Field{{Name: "temp", Value: 21.5}}}))
+	require.NoError(t, sink.Write(&Record{Time: testTime, Fields: []Field{{Name: "temp", Value: 22.0}, {Name: "state", Value: "ok"}}}))
+	s := sink.(*sqliteSink)
+	var count int
+	var temp float64
+	require.NoError(t, s.db.QueryRow(`SELECT COUNT(*), MAX(temp) FROM sensors WHERE port = 'COM3'`).Scan(&count, &temp))
+	require.Equal(t, 2, count)
+	require.Equal(t, 22.0, temp)
+	require.NoError(t, sink.Close())
+
+	// The columns are kept when the database is opened again
+	sink, err = OpenSink("sqlite:"+dbFile.String()+"?table=sensors", "COM4")
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(&Record{Time: testTime, Fields: []Field{{Name: "state", Value: "ko"}}}))
+	require.NoError(t, sink.Close())
+
+	_, err = OpenSink("sqlite:"+dbFile.String()+"?table=my-table", "COM3")
+	require.ErrorContains(t, err, "invalid table name")
+}
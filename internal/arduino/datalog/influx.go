@@ -0,0 +1,150 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/httpclient"
+)
+
+// influxSink writes the records to an InfluxDB server, with the HTTP API
+// of the version 1, that is also supported by the version 2.
+type influxSink struct {
+	client      *http.Client
+	writeURL    string
+	token       string
+	measurement string
+	port        string
+}
+
+func openInfluxSink(u *url.URL, port string) (*influxSink, error) {
+	database := strings.TrimPrefix(u.Path, "/")
+	if database == "" {
+		return nil, errors.New(tr("invalid sink %s: the database is missing", u.Redacted()))
+	}
+	query := u.Query()
+	measurement := query.Get("measurement")
+	if measurement == "" {
+		measurement = "arduino"
+	}
+
+	writeURL := &url.URL{Scheme: "http", Host: u.Host, Path: "/write"}
+	if u.Scheme == "influxs" {
+		writeURL.Scheme = "https"
+	}
+	if u.Port() == "" {
+		writeURL.Host += ":8086"
+	}
+	params := url.Values{"db": {database}, "precision": {"ns"}}
+	if u.User != nil {
+		params.Set("u", u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			params.Set("p", password)
+		}
+	}
+	writeURL.RawQuery = params.Encode()
+
+	client, err := httpclient.New()
+	if err != nil {
+		return nil, err
+	}
+	return &influxSink{
+		client:      client,
+		writeURL:    writeURL.String(),
+		token:       query.Get("token"),
+		measurement: measurement,
+		port:        port,
+	}, nil
+}
+
+func (s *influxSink) Write(record *Record) error {
+	line := lineProtocol(s.measurement, map[string]string{"port": s.port}, record)
+	if line == "" {
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodPost, s.writeURL, strings.NewReader(line))
+	if err != nil {
+		return err
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Token "+s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.New(tr("error writing to InfluxDB: %[1]s %[2]s", resp.Status, bytes.TrimSpace(body)))
+	}
+	return nil
+}
+
+func (s *influxSink) Close() error {
+	return nil
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	stringEscaper      = strings.NewReplacer(`"`, `\"`, `\`, `\\`)
+)
+
+// lineProtocol returns the record in the InfluxDB line protocol, or an empty
+// string if the record has no fields.
+func lineProtocol(measurement string, tags map[string]string, record *Record) string {
+	if len(record.Fields) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(measurement))
+	tagKeys := make([]string, 0, len(tags))
+	for k := range tags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		if tags[k] != "" {
+			fmt.Fprintf(&b, ",%s=%s", keyEscaper.Replace(k), keyEscaper.Replace(tags[k]))
+		}
+	}
+	for i, f := range record.Fields {
+		if i == 0 {
+			b.WriteString(" ")
+		} else {
+			b.WriteString(",")
+		}
+		b.WriteString(keyEscaper.Replace(f.Name) + "=")
+		switch v := f.Value.(type) {
+		case float64:
+			b.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		default:
+			b.WriteString(`"` + stringEscaper.Replace(fmt.Sprint(v)) + `"`)
+		}
+	}
+	fmt.Fprintf(&b, " %d\n", record.Time.UnixNano())
+	return b.String()
+}
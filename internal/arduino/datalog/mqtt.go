@@ -0,0 +1,107 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalog
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// mqttTimeout is the max time to wait for the broker
+var mqttTimeout = 10 * time.Second
+
+// mqttSink publishes each record as a JSON object
+type mqttSink struct {
+	client mqtt.Client
+	topic  string
+	port   string
+}
+
+func openMQTTSink(u *url.URL, port string) (*mqttSink, error) {
+	topic := strings.TrimPrefix(u.Path, "/")
+	if topic == "" {
+		return nil, errors.New(tr("invalid sink %s: the topic is missing", u.Redacted()))
+	}
+	broker := &url.URL{Scheme: "tcp", Host: u.Host}
+	if u.Port() == "" {
+		broker.Host += ":1883"
+	}
+	opts := mqtt.NewClientOptions().
+		SetClientID(fmt.Sprintf("arduino-cli-%d", time.Now().UnixNano())).
+		SetConnectTimeout(mqttTimeout).
+		SetAutoReconnect(true)
+	if u.Scheme == "mqtts" {
+		broker.Scheme = "ssl"
+		if u.Port() == "" {
+			broker.Host = u.Host + ":8883"
+		}
+		opts.SetTLSConfig(&tls.Config{})
+	}
+	opts.AddBroker(broker.String())
+	if u.User != nil {
+		opts.SetUsername(u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			opts.SetPassword(password)
+		}
+	}
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(mqttTimeout) {
+		return nil, errors.New(tr("error connecting to %s: timeout", u.Host))
+	}
+	if err := token.Error(); err != nil {
+		return nil, errors.New(tr("error connecting to %[1]s: %[2]v", u.Host, err))
+	}
+	return &mqttSink{client: client, topic: topic, port: port}, nil
+}
+
+func (s *mqttSink) Write(record *Record) error {
+	payload, err := json.Marshal(recordJSON(record, s.port))
+	if err != nil {
+		return err
+	}
+	token := s.client.Publish(s.topic, 0, false, payload)
+	if !token.WaitTimeout(mqttTimeout) {
+		return errors.New(tr("timeout publishing to %s", s.topic))
+	}
+	return token.Error()
+}
+
+func (s *mqttSink) Close() error {
+	s.client.Disconnect(250)
+	return nil
+}
+
+// recordJSON returns the record as a JSON object with the time, the port and
+// the fields
+func recordJSON(record *Record, port string) map[string]interface{} {
+	res := map[string]interface{}{
+		"time": record.Time.Format(time.RFC3339Nano),
+		"port": port,
+	}
+	for _, f := range record.Fields {
+		res[f.Name] = f.Value
+	}
+	return res
+}
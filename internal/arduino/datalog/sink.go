@@ -0,0 +1,54 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalog
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Sink receives the records
+type Sink interface {
+	// Write forwards the record to the sink
+	Write(record *Record) error
+	// Close releases the resources used by the sink
+	Close() error
+}
+
+// SinkSchemes are the schemes of the URLs of the supported sinks
+var SinkSchemes = []string{"mqtt", "mqtts", "influx", "influxs", "sqlite"}
+
+// OpenSink opens the sink at the given URL, the records are tagged with the
+// address of the port they come from. The URLs are in the form:
+//   - mqtt://[user:password@]host[:port]/topic (mqtts:// for TLS)
+//   - influx://[user:password@]host[:port]/database[?measurement=name&token=token] (influxs:// for HTTPS)
+//   - sqlite:path/to/file.db[?table=name]
+func OpenSink(rawURL, port string) (Sink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.New(tr("invalid sink %[1]s: %[2]v", rawURL, err))
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "mqtt", "mqtts":
+		return openMQTTSink(u, port)
+	case "influx", "influxs":
+		return openInfluxSink(u, port)
+	case "sqlite":
+		return openSQLiteSink(u, port)
+	}
+	return nil, errors.New(tr("invalid sink %[1]s: the scheme must be one of %[2]s", rawURL, strings.Join(SinkSchemes, ", ")))
+}
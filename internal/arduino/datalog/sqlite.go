@@ -0,0 +1,110 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalog
+
+import (
+	"database/sql"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	// The pure Go SQLite driver, it works in the builds without cgo
+	_ "modernc.org/sqlite"
+)
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// sqliteSink inserts the records in a table of a SQLite database, a column
+// is added to the table for each new field.
+type sqliteSink struct {
+	db      *sql.DB
+	table   string
+	port    string
+	columns map[string]bool
+}
+
+func openSQLiteSink(u *url.URL, port string) (*sqliteSink, error) {
+	path := u.Opaque
+	if path == "" {
+		path = u.Path
+	}
+	if path == "" {
+		return nil, errors.New(tr("invalid sink %s: the database file is missing", u.String()))
+	}
+	table := u.Query().Get("table")
+	if table == "" {
+		table = "records"
+	}
+	if !sqlIdentifier.MatchString(table) {
+		return nil, errors.New(tr("invalid sink %[1]s: invalid table name %[2]s", u.String(), table))
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS ` + table + ` ("time" TEXT, "port" TEXT)`); err != nil {
+		db.Close()
+		return nil, errors.New(tr("error creating the table %[1]s in %[2]s: %[3]v", table, path, err))
+	}
+	columns := map[string]bool{}
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			db.Close()
+			return nil, err
+		}
+		columns[strings.ToLower(name)] = true
+	}
+	return &sqliteSink{db: db, table: table, port: port, columns: columns}, rows.Err()
+}
+
+func (s *sqliteSink) Write(record *Record) error {
+	names := []string{`"time"`, `"port"`}
+	placeholders := []string{"?", "?"}
+	values := []interface{}{record.Time.Format(time.RFC3339Nano), s.port}
+	for _, f := range record.Fields {
+		column := quoteIdentifier(f.Name)
+		if !s.columns[strings.ToLower(f.Name)] {
+			if _, err := s.db.Exec(`ALTER TABLE ` + s.table + ` ADD COLUMN ` + column); err != nil {
+				return err
+			}
+			s.columns[strings.ToLower(f.Name)] = true
+		}
+		names = append(names, column)
+		placeholders = append(placeholders, "?")
+		values = append(values, f.Value)
+	}
+	_, err := s.db.Exec(`INSERT INTO `+s.table+` (`+strings.Join(names, ", ")+`) VALUES (`+strings.Join(placeholders, ", ")+`)`, values...)
+	return err
+}
+
+func (s *sqliteSink) Close() error {
+	return s.db.Close()
+}
+
+// quoteIdentifier quotes a column name
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
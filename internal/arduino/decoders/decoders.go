@@ -0,0 +1,181 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package decoders turns the data received from a monitor port into frames of
+// a known protocol, that are printed in a human-readable form.
+//
+// The decoders are pluggable: a decoder is registered with a name using
+// Register and selected by the user with that name. Besides the built-in
+// decoders, external programs can be registered as decoders, see External.
+package decoders
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+)
+
+var tr = i18n.Tr
+
+// Decoder decodes the frames of a protocol.
+type Decoder interface {
+	// Decode reads the data received from r and calls emit for each frame,
+	// until r returns an error. The data that can't be decoded is reported
+	// with a frame having an Error.
+	Decode(r io.Reader, emit func(*Frame)) error
+}
+
+// Checksum is the result of the validation of the checksum of a frame
+type Checksum string
+
+const (
+	// ChecksumNone is used for the frames without a checksum, or whose
+	// checksum can't be verified.
+	ChecksumNone Checksum = ""
+	// ChecksumValid is used for the frames with a valid checksum
+	ChecksumValid Checksum = "valid"
+	// ChecksumInvalid is used for the frames with a wrong checksum
+	ChecksumInvalid Checksum = "invalid"
+)
+
+// Field is a named value of a frame
+type Field struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Frame is a message decoded from the data received.
+type Frame struct {
+	Protocol string   `json:"protocol"`
+	Type     string   `json:"type"`
+	Fields   []Field  `json:"fields,omitempty"`
+	Checksum Checksum `json:"checksum,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// String returns the frame on a single line, the fields with an empty value
+// are omitted.
+func (f *Frame) String() string {
+	res := "[" + f.Protocol + "]"
+	if f.Type != "" {
+		res += " " + f.Type
+	}
+	for _, field := range f.Fields {
+		if field.Value != "" {
+			res += " " + field.Name + "=" + field.Value
+		}
+	}
+	switch f.Checksum {
+	case ChecksumValid:
+		res += " (" + tr("checksum OK") + ")"
+	case ChecksumInvalid:
+		res += " (" + tr("CHECKSUM ERROR") + ")"
+	}
+	if f.Error != "" {
+		res += " " + tr("error: %s", f.Error)
+	}
+	return res
+}
+
+// invalidData returns the frame reporting data that can't be decoded
+func invalidData(protocol string, data []byte) *Frame {
+	return &Frame{Protocol: protocol, Error: tr("invalid data: %q", data)}
+}
+
+type decoderFactory struct {
+	description string
+	new         func() (Decoder, error)
+}
+
+var (
+	decodersMux sync.Mutex
+	decoders    = map[string]*decoderFactory{}
+)
+
+func init() {
+	Register("nmea", tr("NMEA 0183 sentences of GPS receivers"), func() (Decoder, error) { return &nmeaDecoder{}, nil })
+	Register("modbus", tr("MODBUS RTU frames"), func() (Decoder, error) { return &modbusDecoder{}, nil })
+	Register("mavlink", tr("MAVLink v1 and v2 messages"), func() (Decoder, error) { return &mavlinkDecoder{}, nil })
+}
+
+// Register makes a decoder available with the given name, replacing the
+// decoder previously registered with the same name. newDecoder is called to
+// create a decoder for each session.
+func Register(name, description string, newDecoder func() (Decoder, error)) {
+	decodersMux.Lock()
+	defer decodersMux.Unlock()
+	decoders[strings.ToLower(name)] = &decoderFactory{description: description, new: newDecoder}
+}
+
+// New returns a new decoder of the type registered with the given name.
+func New(name string) (Decoder, error) {
+	decodersMux.Lock()
+	factory, ok := decoders[strings.ToLower(name)]
+	decodersMux.Unlock()
+	if !ok {
+		return nil, errors.New(tr("unknown decoder: %[1]s, must be one of: %[2]s", name, strings.Join(Names(), ", ")))
+	}
+	return factory.new()
+}
+
+// Names returns the sorted names of the registered decoders.
+func Names() []string {
+	decodersMux.Lock()
+	defer decodersMux.Unlock()
+	names := make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Description returns the description of the decoder registered with the
+// given name.
+func Description(name string) string {
+	decodersMux.Lock()
+	defer decodersMux.Unlock()
+	if factory, ok := decoders[strings.ToLower(name)]; ok {
+		return factory.description
+	}
+	return ""
+}
+
+// decodeChunks reads r and calls decode with each chunk of data received,
+// the frames returned are passed to emit.
+func decodeChunks(r io.Reader, emit func(*Frame), decode func(data []byte) []*Frame) error {
+	buf := make([]byte, 1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			for _, frame := range decode(buf[:n]) {
+				emit(frame)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// hexBytes returns the data as an hex string
+func hexBytes(data []byte) string {
+	return strings.ToUpper(hex.EncodeToString(data))
+}
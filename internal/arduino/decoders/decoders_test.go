@@ -0,0 +1,123 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package decoders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// decodeAll decodes the data written in chunks of the given size
+func decodeAll(t *testing.T, name string, data []byte, chunk int) []string {
+	decoder, err := New(name)
+	require.NoError(t, err)
+	r, w := io.Pipe()
+	go func() {
+		for len(data) > 0 {
+			n := min(chunk, len(data))
+			w.Write(data[:n])
+			data = data[n:]
+		}
+		w.Close()
+	}()
+	res := []string{}
+	err = decoder.Decode(r, func(f *Frame) { res = append(res, f.String()) })
+	require.ErrorIs(t, err, io.EOF)
+	return res
+}
+
+func TestChecksums(t *testing.T) {
+	require.Equal(t, uint16(0x4B37), modbusCRC([]byte("123456789")))
+	require.Equal(t, uint16(0x6F91), mavlinkCRC([]byte("123456789")))
+}
+
+func TestNMEA(t *testing.T) {
+	data := "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n" +
+		"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48\r\n" +
+		"$PMTK001,604,3\r\n" +
+		"Booting...\r\n"
+	require.Equal(t, []string{
+		"[NMEA] GPGGA time=123519 lat=4807.038 ns=N lon=01131.000 ew=E quality=1 satellites=08 hdop=0.9 altitude=545.4 altitude_unit=M separation=46.9 separation_unit=M (checksum OK)",
+		"[NMEA] GPGGA time=123519 lat=4807.038 ns=N lon=01131.000 ew=E quality=1 satellites=08 hdop=0.9 altitude=545.4 altitude_unit=M separation=46.9 separation_unit=M (CHECKSUM ERROR)",
+		"[NMEA] PMTK001 1=604 2=3",
+		`[NMEA] error: invalid data: "Booting..."`,
+	}, decodeAll(t, "nmea", []byte(data), 7))
+}
+
+func TestModbus(t *testing.T) {
+	data := []byte{
+		0x01, 0x03, 0x00, 0x00, 0x00, 0x0A, 0xC5, 0xCD, // Read 10 registers from 0
+		0xFF, 0xFF, // Noise
+	}
+	response := []byte{0x01, 0x03, 0x04, 0x00, 0x2A, 0x01, 0x00}
+	response = binary.LittleEndian.AppendUint16(response, modbusCRC(response))
+	exception := []byte{0x01, 0x83, 0x02}
+	exception = binary.LittleEndian.AppendUint16(exception, modbusCRC(exception))
+	data = append(append(data, response...), exception...)
+	require.Equal(t, []string{
+		"[MODBUS] READ_HOLDING_REGISTERS address=1 start=0 count=10 (checksum OK)",
+		`[MODBUS] error: invalid data: "\xff\xff"`,
+		"[MODBUS] READ_HOLDING_REGISTERS address=1 registers=42,256 (checksum OK)",
+		"[MODBUS] READ_HOLDING_REGISTERS_EXCEPTION address=1 exception=ILLEGAL_DATA_ADDRESS (checksum OK)",
+	}, decodeAll(t, "modbus", data, 3))
+}
+
+func TestMAVLink(t *testing.T) {
+	frame := func(v2 bool, id uint32, payload []byte, crcExtra byte) []byte {
+		var header []byte
+		if v2 {
+			header = []byte{mavlinkV2Start, byte(len(payload)), 0, 0, 7, 1, 1, byte(id), byte(id >> 8), byte(id >> 16)}
+		} else {
+			header = []byte{mavlinkV1Start, byte(len(payload)), 7, 1, 1, byte(id)}
+		}
+		res := append(header, payload...)
+		crc := mavlinkCRC(append(append([]byte{}, res[1:]...), crcExtra))
+		return binary.LittleEndian.AppendUint16(res, crc)
+	}
+	heartbeat := []byte{0x00, 0x00, 0x00, 0x00, 2, 3, 0x51, 4, 3}
+	data := frame(false, 0, heartbeat, 50)
+	data = append(data, 'x')
+	data = append(data, frame(true, 253, append([]byte{6}, "Armed"...), 83)...)
+	data = append(data, frame(true, 0, heartbeat, 51)...)
+	data = append(data, frame(true, 1000, []byte{1, 2}, 0)...)
+	require.Equal(t, []string{
+		"[MAVLink] HEARTBEAT version=1 seq=7 system=1 component=1 custom_mode=0 type=2 autopilot=3 base_mode=0x51 system_status=4 mavlink_version=3 (checksum OK)",
+		`[MAVLink] error: invalid data: "x"`,
+		`[MAVLink] STATUSTEXT version=2 seq=7 system=1 component=1 severity=6 text="Armed" (checksum OK)`,
+		"[MAVLink] HEARTBEAT version=2 seq=7 system=1 component=1 custom_mode=0 type=2 autopilot=3 base_mode=0x51 system_status=4 mavlink_version=3 (CHECKSUM ERROR)",
+		"[MAVLink] MSG_1000 version=2 seq=7 system=1 component=1 payload=0102",
+	}, decodeAll(t, "mavlink", data, 5))
+}
+
+func TestExternal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test decoder is a shell script")
+	}
+	Register("test", "", External("test", `sh -c 'while read l; do echo "{\"type\":\"$l\"}"; done; echo bye'`))
+	require.Contains(t, Names(), "test")
+	data := bytes.NewBufferString("A\nB\n").Bytes()
+	require.Equal(t, []string{"[test] A", "[test] B", "[test] bye"}, decodeAll(t, "test", data, 1))
+
+	_, err := New("unknown")
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "unknown decoder: unknown"))
+}
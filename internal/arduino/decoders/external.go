@@ -0,0 +1,104 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package decoders
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// External returns a function that creates the decoders running the given
+// command line. The data received is written to the standard input of the
+// command, that prints a frame on each line of its standard output, either
+// as a JSON object with the fields of Frame or as plain text.
+func External(name, commandLine string) func() (Decoder, error) {
+	return func() (Decoder, error) {
+		args, err := properties.SplitQuotedString(commandLine, `"'`, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 {
+			return nil, errors.New(tr("the command of the decoder %s is empty", name))
+		}
+		return &externalDecoder{name: name, args: args}, nil
+	}
+}
+
+type externalDecoder struct {
+	name string
+	args []string
+}
+
+func (d *externalDecoder) Decode(r io.Reader, emit func(*Frame)) error {
+	proc, err := toolenv.NewProcess(nil, d.args...)
+	if err != nil {
+		return err
+	}
+	stdin, err := proc.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := proc.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := proc.Start(); err != nil {
+		return err
+	}
+
+	// The error is sent before closing stdin, so that it's available when
+	// the command terminates at the end of its input
+	copyErr := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(stdin, r)
+		copyErr <- err
+		stdin.Close()
+	}()
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		emit(d.parseFrame(scanner.Text()))
+	}
+	if err := proc.Wait(); err != nil {
+		return errors.New(tr("decoder %[1]s terminated: %[2]v", d.name, err))
+	}
+	select {
+	case err := <-copyErr:
+		if err == nil {
+			return io.EOF
+		}
+		return err
+	default:
+		return errors.New(tr("decoder %s terminated", d.name))
+	}
+}
+
+// parseFrame returns the frame printed by the command on a line
+func (d *externalDecoder) parseFrame(line string) *Frame {
+	frame := &Frame{}
+	if strings.HasPrefix(line, "{") && json.Unmarshal([]byte(line), frame) == nil {
+		if frame.Protocol == "" {
+			frame.Protocol = d.name
+		}
+		return frame
+	}
+	return &Frame{Protocol: d.name, Type: line}
+}
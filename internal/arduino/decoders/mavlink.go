@@ -0,0 +1,205 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package decoders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	mavlinkV1Start = 0xFE
+	mavlinkV2Start = 0xFD
+	// mavlinkSignatureLength is the length of the signature of the v2
+	// messages with the signed flag
+	mavlinkSignatureLength = 13
+)
+
+// mavlinkMessage describes a message of the common MAVLink dialect
+type mavlinkMessage struct {
+	name string
+	// crcExtra is the seed added to the CRC, derived from the definition
+	// of the message
+	crcExtra byte
+	// fields decodes the payload, padded with zeros to 255 bytes since the
+	// v2 messages are sent without the trailing zeros.
+	fields func(payload []byte) []Field
+}
+
+var mavlinkMessages = map[uint32]*mavlinkMessage{
+	0:   {name: "HEARTBEAT", crcExtra: 50, fields: mavlinkHeartbeat},
+	1:   {name: "SYS_STATUS", crcExtra: 124},
+	2:   {name: "SYSTEM_TIME", crcExtra: 137},
+	4:   {name: "PING", crcExtra: 237},
+	22:  {name: "PARAM_VALUE", crcExtra: 220},
+	24:  {name: "GPS_RAW_INT", crcExtra: 24},
+	27:  {name: "RAW_IMU", crcExtra: 144},
+	30:  {name: "ATTITUDE", crcExtra: 39, fields: mavlinkAttitude},
+	33:  {name: "GLOBAL_POSITION_INT", crcExtra: 104},
+	74:  {name: "VFR_HUD", crcExtra: 20},
+	76:  {name: "COMMAND_LONG", crcExtra: 152},
+	77:  {name: "COMMAND_ACK", crcExtra: 143},
+	253: {name: "STATUSTEXT", crcExtra: 83, fields: mavlinkStatusText},
+}
+
+// mavlinkDecoder decodes the MAVLink v1 and v2 messages. The checksum is
+// verified only for the messages of the common dialect known to the
+// decoder, since it depends on the definition of the message.
+type mavlinkDecoder struct {
+	buf     []byte
+	invalid []byte
+}
+
+func (d *mavlinkDecoder) Decode(r io.Reader, emit func(*Frame)) error {
+	return decodeChunks(r, emit, d.decodeChunk)
+}
+
+func (d *mavlinkDecoder) decodeChunk(data []byte) []*Frame {
+	res := []*Frame{}
+	d.buf = append(d.buf, data...)
+	for len(d.buf) > 0 {
+		if d.buf[0] != mavlinkV1Start && d.buf[0] != mavlinkV2Start {
+			d.invalid = append(d.invalid, d.buf[0])
+			d.buf = d.buf[1:]
+			continue
+		}
+		if len(d.invalid) > 0 {
+			res = append(res, invalidData("MAVLink", d.invalid))
+			d.invalid = nil
+		}
+		n := mavlinkFrameLength(d.buf)
+		if n == 0 || len(d.buf) < n {
+			// Wait for the rest of the frame
+			break
+		}
+		res = append(res, decodeMAVLinkFrame(d.buf[:n]))
+		d.buf = d.buf[n:]
+	}
+	return res
+}
+
+// mavlinkFrameLength returns the length of the frame at the start of data, or
+// 0 if the header is not complete.
+func mavlinkFrameLength(data []byte) int {
+	if data[0] == mavlinkV1Start {
+		if len(data) < 2 {
+			return 0
+		}
+		return 6 + int(data[1]) + 2
+	}
+	if len(data) < 3 {
+		return 0
+	}
+	n := 10 + int(data[1]) + 2
+	if data[2]&0x01 != 0 {
+		n += mavlinkSignatureLength
+	}
+	return n
+}
+
+func decodeMAVLinkFrame(frame []byte) *Frame {
+	var seq, system, component byte
+	var id uint32
+	var header, payload []byte
+	version := "1"
+	if frame[0] == mavlinkV1Start {
+		header, payload = frame[1:6], frame[6:6+int(frame[1])]
+		seq, system, component, id = frame[2], frame[3], frame[4], uint32(frame[5])
+	} else {
+		version = "2"
+		header, payload = frame[1:10], frame[10:10+int(frame[1])]
+		seq, system, component = frame[4], frame[5], frame[6]
+		id = uint32(frame[7]) | uint32(frame[8])<<8 | uint32(frame[9])<<16
+	}
+
+	res := &Frame{
+		Protocol: "MAVLink",
+		Type:     fmt.Sprintf("MSG_%d", id),
+		Fields: []Field{
+			{Name: "version", Value: version},
+			{Name: "seq", Value: fmt.Sprint(seq)},
+			{Name: "system", Value: fmt.Sprint(system)},
+			{Name: "component", Value: fmt.Sprint(component)},
+		},
+	}
+	msg, ok := mavlinkMessages[id]
+	if !ok {
+		res.Fields = append(res.Fields, Field{Name: "payload", Value: hexBytes(payload)})
+		return res
+	}
+
+	res.Type = msg.name
+	crc := mavlinkCRC(append(append(append([]byte{}, header...), payload...), msg.crcExtra))
+	crcOffset := len(header) + 1 + len(payload)
+	if crc == binary.LittleEndian.Uint16(frame[crcOffset:]) {
+		res.Checksum = ChecksumValid
+	} else {
+		res.Checksum = ChecksumInvalid
+	}
+	if msg.fields != nil {
+		padded := make([]byte, 255)
+		copy(padded, payload)
+		res.Fields = append(res.Fields, msg.fields(padded)...)
+	} else {
+		res.Fields = append(res.Fields, Field{Name: "payload", Value: hexBytes(payload)})
+	}
+	return res
+}
+
+func mavlinkHeartbeat(payload []byte) []Field {
+	return []Field{
+		{Name: "custom_mode", Value: fmt.Sprint(binary.LittleEndian.Uint32(payload))},
+		{Name: "type", Value: fmt.Sprint(payload[4])},
+		{Name: "autopilot", Value: fmt.Sprint(payload[5])},
+		{Name: "base_mode", Value: fmt.Sprintf("0x%02X", payload[6])},
+		{Name: "system_status", Value: fmt.Sprint(payload[7])},
+		{Name: "mavlink_version", Value: fmt.Sprint(payload[8])},
+	}
+}
+
+func mavlinkAttitude(payload []byte) []Field {
+	res := []Field{{Name: "time_boot_ms", Value: fmt.Sprint(binary.LittleEndian.Uint32(payload))}}
+	for i, name := range []string{"roll", "pitch", "yaw", "rollspeed", "pitchspeed", "yawspeed"} {
+		value := math.Float32frombits(binary.LittleEndian.Uint32(payload[4+4*i:]))
+		res = append(res, Field{Name: name, Value: fmt.Sprintf("%.4f", value)})
+	}
+	return res
+}
+
+func mavlinkStatusText(payload []byte) []Field {
+	text := payload[1:51]
+	if end := bytes.IndexByte(text, 0); end != -1 {
+		text = text[:end]
+	}
+	return []Field{
+		{Name: "severity", Value: fmt.Sprint(payload[0])},
+		{Name: "text", Value: fmt.Sprintf("%q", text)},
+	}
+}
+
+// mavlinkCRC returns the CRC-16/MCRF4XX (X.25) of the data
+func mavlinkCRC(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		tmp := b ^ byte(crc)
+		tmp ^= tmp << 4
+		crc = crc>>8 ^ uint16(tmp)<<8 ^ uint16(tmp)<<3 ^ uint16(tmp)>>4
+	}
+	return crc
+}
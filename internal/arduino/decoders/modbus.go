@@ -0,0 +1,179 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package decoders
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var modbusFunctions = map[byte]string{
+	0x01: "READ_COILS",
+	0x02: "READ_DISCRETE_INPUTS",
+	0x03: "READ_HOLDING_REGISTERS",
+	0x04: "READ_INPUT_REGISTERS",
+	0x05: "WRITE_SINGLE_COIL",
+	0x06: "WRITE_SINGLE_REGISTER",
+	0x0F: "WRITE_MULTIPLE_COILS",
+	0x10: "WRITE_MULTIPLE_REGISTERS",
+	0x17: "READ_WRITE_MULTIPLE_REGISTERS",
+}
+
+var modbusExceptions = map[byte]string{
+	0x01: "ILLEGAL_FUNCTION",
+	0x02: "ILLEGAL_DATA_ADDRESS",
+	0x03: "ILLEGAL_DATA_VALUE",
+	0x04: "SERVER_DEVICE_FAILURE",
+	0x05: "ACKNOWLEDGE",
+	0x06: "SERVER_DEVICE_BUSY",
+	0x08: "MEMORY_PARITY_ERROR",
+	0x0A: "GATEWAY_PATH_UNAVAILABLE",
+	0x0B: "GATEWAY_TARGET_FAILED",
+}
+
+const (
+	// minModbusFrame is the length of the shortest RTU frame: address,
+	// function code, exception code and CRC.
+	minModbusFrame = 5
+	// maxModbusFrame is the length of the longest RTU frame
+	maxModbusFrame = 256
+)
+
+// modbusDecoder decodes the MODBUS RTU frames. The frames have no start
+// marker and the silence between them isn't visible through the port, so
+// the frames are found by looking for a valid CRC.
+type modbusDecoder struct {
+	buf []byte
+}
+
+func (d *modbusDecoder) Decode(r io.Reader, emit func(*Frame)) error {
+	return decodeChunks(r, emit, d.decodeChunk)
+}
+
+func (d *modbusDecoder) decodeChunk(data []byte) []*Frame {
+	res := []*Frame{}
+	d.buf = append(d.buf, data...)
+	for {
+		start, n := findModbusFrame(d.buf)
+		if n == 0 {
+			break
+		}
+		if start > 0 {
+			res = append(res, invalidData("MODBUS", d.buf[:start]))
+		}
+		res = append(res, decodeModbusFrame(d.buf[start:start+n]))
+		d.buf = d.buf[start+n:]
+	}
+	if len(d.buf) > 2*maxModbusFrame {
+		// The frames can't be longer, the data kept isn't valid
+		skip := len(d.buf) - maxModbusFrame
+		res = append(res, invalidData("MODBUS", d.buf[:skip]))
+		d.buf = d.buf[skip:]
+	}
+	return res
+}
+
+// findModbusFrame returns the start and the length of the first frame in
+// data, the length is 0 if there is none.
+func findModbusFrame(data []byte) (int, int) {
+	for start := 0; start+minModbusFrame <= len(data); start++ {
+		if n := modbusFrameLength(data[start:]); n > 0 {
+			return start, n
+		}
+	}
+	return 0, 0
+}
+
+// modbusFrameLength returns the length of the shortest frame at the start of
+// data having a valid CRC, or 0 if there is none.
+func modbusFrameLength(data []byte) int {
+	if _, ok := modbusFunctions[data[1]&0x7F]; !ok {
+		return 0
+	}
+	crc := modbusCRC(data[:minModbusFrame-2])
+	for n := minModbusFrame; n <= len(data) && n <= maxModbusFrame; n++ {
+		if crc == binary.LittleEndian.Uint16(data[n-2:n]) {
+			return n
+		}
+		crc = modbusCRCUpdate(crc, data[n-2])
+	}
+	return 0
+}
+
+// decodeModbusFrame decodes a frame with a valid CRC. Requests and responses
+// are told apart by their length, when possible.
+func decodeModbusFrame(frame []byte) *Frame {
+	res := &Frame{Protocol: "MODBUS", Checksum: ChecksumValid}
+	address, function, data := frame[0], frame[1], frame[2:len(frame)-2]
+	res.Fields = append(res.Fields, Field{Name: "address", Value: fmt.Sprint(address)})
+	res.Type = modbusFunctions[function&0x7F]
+	if function&0x80 != 0 {
+		res.Type += "_EXCEPTION"
+		exception, ok := modbusExceptions[data[0]]
+		if !ok {
+			exception = fmt.Sprintf("0x%02X", data[0])
+		}
+		res.Fields = append(res.Fields, Field{Name: "exception", Value: exception})
+		return res
+	}
+
+	switch {
+	case function <= 0x04 && len(data) == 4:
+		// Read request: address and quantity
+		res.Fields = append(res.Fields,
+			Field{Name: "start", Value: fmt.Sprint(binary.BigEndian.Uint16(data))},
+			Field{Name: "count", Value: fmt.Sprint(binary.BigEndian.Uint16(data[2:]))})
+	case (function == 0x03 || function == 0x04) && int(data[0]) == len(data)-1 && len(data)%2 == 1:
+		// Read registers response: the values of the registers
+		values := []string{}
+		for i := 1; i+1 < len(data); i += 2 {
+			values = append(values, fmt.Sprint(binary.BigEndian.Uint16(data[i:])))
+		}
+		res.Fields = append(res.Fields, Field{Name: "registers", Value: strings.Join(values, ",")})
+	case (function == 0x05 || function == 0x06) && len(data) == 4:
+		// Write single request and response: address and value
+		res.Fields = append(res.Fields,
+			Field{Name: "register", Value: fmt.Sprint(binary.BigEndian.Uint16(data))},
+			Field{Name: "value", Value: fmt.Sprint(binary.BigEndian.Uint16(data[2:]))})
+	default:
+		res.Fields = append(res.Fields, Field{Name: "data", Value: hexBytes(data)})
+	}
+	return res
+}
+
+// modbusCRC returns the CRC-16/MODBUS of the data
+func modbusCRC(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc = modbusCRCUpdate(crc, b)
+	}
+	return crc
+}
+
+// modbusCRCUpdate adds a byte to the CRC
+func modbusCRCUpdate(crc uint16, b byte) uint16 {
+	crc ^= uint16(b)
+	for i := 0; i < 8; i++ {
+		if crc&1 != 0 {
+			crc = crc>>1 ^ 0xA001
+		} else {
+			crc >>= 1
+		}
+	}
+	return crc
+}
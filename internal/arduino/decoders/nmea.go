@@ -0,0 +1,115 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package decoders
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// nmeaFields are the names of the fields of the most common NMEA sentences,
+// by sentence type without the talker ID.
+var nmeaFields = map[string][]string{
+	"GGA": {"time", "lat", "ns", "lon", "ew", "quality", "satellites", "hdop", "altitude", "altitude_unit", "separation", "separation_unit", "dgps_age", "dgps_station"},
+	"RMC": {"time", "status", "lat", "ns", "lon", "ew", "speed_knots", "course", "date", "variation", "variation_ew", "mode"},
+	"GLL": {"lat", "ns", "lon", "ew", "time", "status", "mode"},
+	"VTG": {"course_true", "true", "course_magnetic", "magnetic", "speed_knots", "knots", "speed_kmh", "kmh", "mode"},
+	"GSA": {"mode", "fix", "sv1", "sv2", "sv3", "sv4", "sv5", "sv6", "sv7", "sv8", "sv9", "sv10", "sv11", "sv12", "pdop", "hdop", "vdop"},
+	"GSV": {"messages", "message", "satellites"},
+	"ZDA": {"time", "day", "month", "year", "zone_hours", "zone_minutes"},
+}
+
+// maxNMEALength is the maximum length of a sentence, the standard allows 82
+// characters but many receivers send longer proprietary sentences.
+const maxNMEALength = 1024
+
+// nmeaDecoder decodes the NMEA 0183 sentences, one for each line received.
+type nmeaDecoder struct {
+	line []byte
+}
+
+func (d *nmeaDecoder) Decode(r io.Reader, emit func(*Frame)) error {
+	return decodeChunks(r, emit, d.decodeChunk)
+}
+
+func (d *nmeaDecoder) decodeChunk(data []byte) []*Frame {
+	res := []*Frame{}
+	for _, c := range data {
+		if c != '\n' {
+			d.line = append(d.line, c)
+			if len(d.line) > maxNMEALength {
+				res = append(res, invalidData("NMEA", d.line))
+				d.line = nil
+			}
+			continue
+		}
+		line := bytes.TrimRight(d.line, "\r")
+		d.line = nil
+		if len(line) > 0 {
+			res = append(res, decodeNMEASentence(string(line)))
+		}
+	}
+	return res
+}
+
+// decodeNMEASentence decodes a sentence like $GPGGA,...*hh
+func decodeNMEASentence(sentence string) *Frame {
+	if sentence[0] != '$' && sentence[0] != '!' {
+		return invalidData("NMEA", []byte(sentence))
+	}
+	frame := &Frame{Protocol: "NMEA"}
+	body := sentence[1:]
+	if star := strings.LastIndexByte(body, '*'); star != -1 {
+		checksum := body[star+1:]
+		body = body[:star]
+		expected, err := strconv.ParseUint(checksum, 16, 8)
+		if err != nil || len(checksum) != 2 {
+			frame.Checksum = ChecksumInvalid
+		} else if nmeaChecksum(body) == byte(expected) {
+			frame.Checksum = ChecksumValid
+		} else {
+			frame.Checksum = ChecksumInvalid
+		}
+	}
+
+	values := strings.Split(body, ",")
+	frame.Type = values[0]
+	var names []string
+	if len(frame.Type) == 5 {
+		// The first 2 characters are the talker ID (GP, GN, GL...)
+		names = nmeaFields[frame.Type[2:]]
+	}
+	for i, value := range values[1:] {
+		name := fmt.Sprint(i + 1)
+		if i < len(names) {
+			name = names[i]
+		}
+		frame.Fields = append(frame.Fields, Field{Name: name, Value: value})
+	}
+	return frame
+}
+
+// nmeaChecksum returns the XOR of the characters of the sentence
+func nmeaChecksum(body string) byte {
+	var res byte
+	for i := 0; i < len(body); i++ {
+		res ^= body[i]
+	}
+	return res
+}
@@ -0,0 +1,146 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package deployments handles the registry of the sketches uploaded to the
+// boards, saved in the data directory, that answers the question "what is
+// running on this board?".
+package deployments
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+	"github.com/arduino/go-paths-helper"
+)
+
+var tr = i18n.Tr
+
+// formatVersion is the version of the format of the registry file
+const formatVersion = 1
+
+// Deployment is a successful upload of a sketch to a board
+type Deployment struct {
+	// SerialNumber is the serial number of the board, if reported by the
+	// discovery of the upload port
+	SerialNumber string `json:"serial_number,omitempty"`
+	// PortAddress and PortProtocol identify the upload port
+	PortAddress  string `json:"port_address,omitempty"`
+	PortProtocol string `json:"port_protocol,omitempty"`
+	// FQBN is the FQBN of the board, including all the configuration options
+	FQBN string `json:"fqbn"`
+	// Sketch is the path of the sketch, or the name of the uploaded project
+	// if the build artifacts have been uploaded without the sketch
+	Sketch string `json:"sketch"`
+	// BuildUUID is the UUID of the build in the symbols archive, if the
+	// sketch has been compiled exporting the symbols
+	BuildUUID string    `json:"build_uuid,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Board returns the key identifying the board of the deployment: the serial
+// number if available, the port address otherwise.
+func (d *Deployment) Board() string {
+	if d.SerialNumber != "" {
+		return d.SerialNumber
+	}
+	return d.PortProtocol + "://" + d.PortAddress
+}
+
+// Registry is the list of the deployments, sorted from the oldest to the
+// newest
+type Registry struct {
+	Version     int           `json:"version"`
+	Deployments []*Deployment `json:"deployments"`
+}
+
+// Load reads the registry from the given file, an empty registry is returned
+// if the file doesn't exist.
+func Load(file *paths.Path) (*Registry, error) {
+	r := &Registry{Version: formatVersion, Deployments: []*Deployment{}}
+	if file.NotExist() {
+		return r, nil
+	}
+	data, err := file.ReadFile()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf(tr("invalid deployments registry %[1]s: %[2]s"), file, err)
+	}
+	if r.Version > formatVersion {
+		return nil, errors.New(tr("unsupported version %[1]d of deployments registry %[2]s", r.Version, file))
+	}
+	sort.SliceStable(r.Deployments, func(i, j int) bool {
+		return r.Deployments[i].Timestamp.Before(r.Deployments[j].Timestamp)
+	})
+	return r, nil
+}
+
+// Save writes the registry to the given file
+func (r *Registry) Save(file *paths.Path) error {
+	r.Version = formatVersion
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := file.Parent().MkdirAll(); err != nil {
+		return err
+	}
+	return file.WriteFile(append(data, '\n'))
+}
+
+// Add records a new deployment in the registry
+func (r *Registry) Add(d *Deployment) {
+	r.Deployments = append(r.Deployments, d)
+}
+
+// Latest returns the last deployment of each board, sorted from the newest
+// to the oldest.
+func (r *Registry) Latest() []*Deployment {
+	res := []*Deployment{}
+	seen := map[string]bool{}
+	for i := len(r.Deployments) - 1; i >= 0; i-- {
+		d := r.Deployments[i]
+		if seen[d.Board()] {
+			continue
+		}
+		seen[d.Board()] = true
+		res = append(res, d)
+	}
+	return res
+}
+
+// History returns the deployments of the board with the given serial number,
+// or connected to the given port address, sorted from the newest to the
+// oldest. The serial number is matched case insensitively.
+func (r *Registry) History(board string) []*Deployment {
+	res := []*Deployment{}
+	for i := len(r.Deployments) - 1; i >= 0; i-- {
+		d := r.Deployments[i]
+		if d.SerialNumber != "" {
+			if strings.EqualFold(d.SerialNumber, board) {
+				res = append(res, d)
+			}
+		} else if d.PortAddress == board || d.Board() == board {
+			res = append(res, d)
+		}
+	}
+	return res
+}
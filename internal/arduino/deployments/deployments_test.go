@@ -0,0 +1,60 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package deployments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	file := paths.New(t.TempDir()).Join("deployments", "deployments.json")
+
+	r, err := Load(file)
+	require.NoError(t, err)
+	require.Empty(t, r.Latest())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	r.Add(&Deployment{SerialNumber: "95037323535351F0E1C1", PortAddress: "/dev/ttyACM0", PortProtocol: "serial", FQBN: "arduino:avr:uno", Sketch: "/home/user/Blink", Timestamp: now.Add(-2 * time.Hour)})
+	r.Add(&Deployment{PortAddress: "/dev/ttyUSB0", PortProtocol: "serial", FQBN: "arduino:avr:nano:cpu=atmega328old", Sketch: "/home/user/Fade", Timestamp: now.Add(-time.Hour)})
+	r.Add(&Deployment{SerialNumber: "95037323535351F0E1C1", PortAddress: "/dev/ttyACM1", PortProtocol: "serial", FQBN: "arduino:avr:uno", Sketch: "/home/user/Button", BuildUUID: "7d1c3b5e-6a4f-4c7e-9f3b-2a1d8e6c5b4a", Timestamp: now})
+	require.NoError(t, r.Save(file))
+
+	r, err = Load(file)
+	require.NoError(t, err)
+	require.Len(t, r.Deployments, 3)
+
+	latest := r.Latest()
+	require.Len(t, latest, 2)
+	require.Equal(t, "/home/user/Button", latest[0].Sketch)
+	require.Equal(t, "7d1c3b5e-6a4f-4c7e-9f3b-2a1d8e6c5b4a", latest[0].BuildUUID)
+	require.Equal(t, "/home/user/Fade", latest[1].Sketch)
+	require.Equal(t, "serial:///dev/ttyUSB0", latest[1].Board())
+
+	history := r.History("95037323535351f0e1c1")
+	require.Len(t, history, 2)
+	require.Equal(t, "/home/user/Button", history[0].Sketch)
+	require.Equal(t, "/home/user/Blink", history[1].Sketch)
+	require.True(t, history[1].Timestamp.Equal(now.Add(-2*time.Hour)))
+
+	// Boards without a serial number are found by port address
+	require.Len(t, r.History("/dev/ttyUSB0"), 1)
+	require.Empty(t, r.History("/dev/ttyACM0"))
+	require.Empty(t, r.History("unknown"))
+}
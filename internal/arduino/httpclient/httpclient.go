@@ -18,6 +18,8 @@ package httpclient
 import (
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
@@ -81,16 +83,45 @@ func DownloadFile(path *paths.Path, URL string, queryParameter string, label str
 type Config struct {
 	UserAgent string
 	Proxy     *url.URL
+	// ExtraHeaders are the headers added to the requests, indexed by host name
+	ExtraHeaders map[string]http.Header
+	// Retries is the number of times a failed request is retried
+	Retries int
+	// RetryBackoff is the delay before the first retry, it's doubled at each
+	// following retry
+	RetryBackoff time.Duration
+	// RateLimit is the maximum download speed in bytes per second, 0 means no limit
+	RateLimit int64
 }
 
 // New returns a default http client for use in the arduino-cli
 func New() (*http.Client, error) {
-	userAgent := configuration.UserAgent(configuration.Settings)
-	proxy, err := configuration.NetworkProxy(configuration.Settings)
+	settings := configuration.Settings
+	userAgent := configuration.UserAgent(settings)
+	proxy, err := configuration.NetworkProxy(settings)
 	if err != nil {
 		return nil, err
 	}
-	return NewWithConfig(&Config{UserAgent: userAgent, Proxy: proxy}), nil
+	extraHeaders, err := configuration.NetworkExtraHeaders(settings)
+	if err != nil {
+		return nil, err
+	}
+	retries, retryBackoff, err := configuration.NetworkRetryPolicy(settings)
+	if err != nil {
+		return nil, err
+	}
+	rateLimit, err := configuration.NetworkDownloadRateLimit(settings)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithConfig(&Config{
+		UserAgent:    userAgent,
+		Proxy:        proxy,
+		ExtraHeaders: extraHeaders,
+		Retries:      retries,
+		RetryBackoff: retryBackoff,
+		RateLimit:    rateLimit,
+	}), nil
 }
 
 // NewWithConfig creates a http client for use in the arduino-cli, with a given configuration
@@ -100,7 +131,11 @@ func NewWithConfig(config *Config) *http.Client {
 			transport: &http.Transport{
 				Proxy: http.ProxyURL(config.Proxy),
 			},
-			userAgent: config.UserAgent,
+			userAgent:    config.UserAgent,
+			extraHeaders: config.ExtraHeaders,
+			retries:      config.Retries,
+			retryBackoff: config.RetryBackoff,
+			rateLimiter:  newRateLimiter(config.RateLimit),
 		},
 	}
 }
@@ -117,11 +152,66 @@ func GetDownloaderConfig() (*downloader.Config, error) {
 }
 
 type httpClientRoundTripper struct {
-	transport http.RoundTripper
-	userAgent string
+	transport    http.RoundTripper
+	userAgent    string
+	extraHeaders map[string]http.Header
+	retries      int
+	retryBackoff time.Duration
+	rateLimiter  *rateLimiter
 }
 
 func (h *httpClientRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Add("User-Agent", h.userAgent)
-	return h.transport.RoundTrip(req)
+	// The extra headers are added to a copy of the request, so they are not
+	// forwarded to a different host if the request is redirected
+	if headers := h.extraHeaders[strings.ToLower(req.URL.Hostname())]; len(headers) > 0 {
+		req = req.Clone(req.Context())
+		for name, values := range headers {
+			req.Header[name] = values
+		}
+	}
+
+	resp, err := h.transport.RoundTrip(req)
+	for attempt := 0; attempt < h.retries && shouldRetry(req, resp, err); attempt++ {
+		delay := h.retryBackoff << attempt
+		if resp != nil {
+			if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && retryAfter > 0 {
+				delay = time.Duration(retryAfter) * time.Second
+			}
+			resp.Body.Close()
+		}
+		logrus.WithField("url", req.URL.String()).WithField("delay", delay).Info("Retrying request")
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		resp, err = h.transport.RoundTrip(req)
+	}
+	if err == nil && h.rateLimiter != nil {
+		resp.Body = h.rateLimiter.wrap(req.Context(), resp.Body)
+	}
+	return resp, err
+}
+
+// shouldRetry returns true if the request failed for a temporary error and
+// can be sent again
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
 }
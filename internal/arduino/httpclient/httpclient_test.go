@@ -21,7 +21,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -68,3 +70,79 @@ func TestProxy(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, http.StatusNoContent, response.StatusCode)
 }
+
+func TestExtraHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer ts.Close()
+
+	get := func(client *http.Client) string {
+		response, err := client.Get(ts.URL)
+		require.NoError(t, err)
+		b, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	client := NewWithConfig(&Config{
+		ExtraHeaders: map[string]http.Header{"127.0.0.1": {"Authorization": []string{"Bearer secret"}}},
+	})
+	require.Equal(t, "Bearer secret", get(client))
+
+	// The headers are sent only to the given host
+	client = NewWithConfig(&Config{
+		ExtraHeaders: map[string]http.Header{"mirror.example.com": {"Authorization": []string{"Bearer secret"}}},
+	})
+	require.Empty(t, get(client))
+}
+
+func TestRetries(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewWithConfig(&Config{Retries: 1, RetryBackoff: time.Millisecond})
+	response, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	require.Equal(t, 2, requests)
+
+	requests = 0
+	client = NewWithConfig(&Config{Retries: 3, RetryBackoff: time.Millisecond})
+	response, err = client.Get(ts.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, response.StatusCode)
+	require.Equal(t, 3, requests)
+
+	// Requests with a body that can't be replayed are not retried
+	requests = 0
+	response, err = client.Post(ts.URL, "text/plain", io.NopCloser(strings.NewReader("data")))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	require.Equal(t, 1, requests)
+}
+
+func TestRateLimit(t *testing.T) {
+	data := strings.Repeat("x", 2000)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, data)
+	}))
+	defer ts.Close()
+
+	client := NewWithConfig(&Config{RateLimit: 4000})
+	start := time.Now()
+	response, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	b, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, data, string(b))
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
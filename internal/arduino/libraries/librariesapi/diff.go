@@ -0,0 +1,159 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package librariesapi
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/arduino/arduino-cli/internal/arduino/globals"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
+)
+
+// ChangeKind is the kind of a change of the API.
+type ChangeKind string
+
+const (
+	// Added is a declaration available only in the new version.
+	Added ChangeKind = "added"
+	// Removed is a declaration available only in the old version.
+	Removed ChangeKind = "removed"
+	// Changed is a declaration available in both versions with a
+	// different signature.
+	Changed ChangeKind = "changed"
+)
+
+// Change is a difference between the APIs of two versions of a library.
+// Old is nil for the added declarations and New is nil for the removed ones.
+type Change struct {
+	Kind ChangeKind
+	Old  *Declaration
+	New  *Declaration
+}
+
+// Declaration returns the most recent of the declarations of the change.
+func (c *Change) Declaration() *Declaration {
+	if c.New != nil {
+		return c.New
+	}
+	return c.Old
+}
+
+// ParseLibrary returns the public declarations of the headers in the source
+// directory of the library.
+func ParseLibrary(library *libraries.Library) ([]*Declaration, error) {
+	var files paths.PathList
+	var err error
+	if library.Layout == libraries.RecursiveLayout {
+		files, err = library.SourceDir.ReadDirRecursive()
+	} else {
+		files, err = library.SourceDir.ReadDir()
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := []*Declaration{}
+	for _, file := range files {
+		if file.IsDir() || !globals.HeaderFilesValidExtensions[file.Ext()] {
+			continue
+		}
+		source, err := file.ReadFile()
+		if err != nil {
+			return nil, err
+		}
+		header, err := file.RelFrom(library.SourceDir)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ParseHeader(filepath.ToSlash(header.String()), string(source))...)
+	}
+	return res, nil
+}
+
+// Diff compares the declarations of two versions of a library. The
+// functions are matched by name and parameters, so when a function with a
+// single overload changes its parameters it's reported as changed, in the
+// other cases the overloads are reported as removed and added.
+func Diff(oldDecls, newDecls []*Declaration) []*Change {
+	oldByKey := indexByKey(oldDecls)
+	newByKey := indexByKey(newDecls)
+
+	res := []*Change{}
+	removed := map[string][]*Declaration{}
+	added := map[string][]*Declaration{}
+	for key, o := range oldByKey {
+		n, ok := newByKey[key]
+		if !ok {
+			removed[o.nameKey()] = append(removed[o.nameKey()], o)
+		} else if o.Signature != n.Signature {
+			res = append(res, &Change{Kind: Changed, Old: o, New: n})
+		}
+	}
+	for key, n := range newByKey {
+		if _, ok := oldByKey[key]; !ok {
+			added[n.nameKey()] = append(added[n.nameKey()], n)
+		}
+	}
+	for name, olds := range removed {
+		news := added[name]
+		if len(olds) == 1 && len(news) == 1 {
+			res = append(res, &Change{Kind: Changed, Old: olds[0], New: news[0]})
+			delete(added, name)
+			continue
+		}
+		for _, o := range olds {
+			res = append(res, &Change{Kind: Removed, Old: o})
+		}
+	}
+	for _, news := range added {
+		for _, n := range news {
+			res = append(res, &Change{Kind: Added, New: n})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i].Declaration(), res[j].Declaration()
+		if a.Header != b.Header {
+			return a.Header < b.Header
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Signature != b.Signature {
+			return a.Signature < b.Signature
+		}
+		return res[i].Kind < res[j].Kind
+	})
+	return res
+}
+
+// indexByKey returns the declarations by key. If a declaration is repeated
+// the first one is kept, usually the prototype of a function is followed
+// by its definition.
+func indexByKey(decls []*Declaration) map[string]*Declaration {
+	res := map[string]*Declaration{}
+	for _, d := range decls {
+		if _, ok := res[d.key]; !ok {
+			res[d.key] = d
+		}
+	}
+	return res
+}
+
+func (d *Declaration) nameKey() string {
+	return string(d.Kind) + " " + d.Name
+}
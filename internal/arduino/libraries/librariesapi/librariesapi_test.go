@@ -0,0 +1,201 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package librariesapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const servoV1 = `
+#ifndef Servo_h
+#define Servo_h
+
+#include <inttypes.h>
+
+#define MAX_SERVOS \
+  12
+
+/* The servo
+   class */
+typedef struct {
+  uint8_t nbr;
+} ServoPin_t;
+
+extern "C" {
+void servoInit(void);
+}
+
+enum ServoMode { SERVO_NORMAL, SERVO_INVERTED };
+
+class Servo : public Base
+{
+public:
+  Servo();
+  uint8_t attach(int pin);           // attach the given pin
+  uint8_t attach(int pin, int min, int max);
+  void write(int value);
+  int read() { return value; }
+  bool attached() const;
+  Servo &operator=(const Servo &other);
+private:
+  void update(int value);
+  int value;
+};
+
+namespace servo {
+  void detachAll();
+  namespace detail {
+    int counter(const char *name = "{");
+  }
+}
+
+#endif
+`
+
+const servoV2 = `
+#pragma once
+
+#include <inttypes.h>
+
+extern "C" void servoInit(void);
+
+enum class ServoMode : uint8_t { SERVO_NORMAL, SERVO_INVERTED, SERVO_CONTINUOUS };
+
+template <typename T>
+class Servo final : public Base {
+  int value;
+
+public:
+  Servo() : value(0) {}
+  uint8_t attach(int pin, int min = 544, int max = 2400);
+  void write(int value, bool wait = false);
+  int read() { return value; }
+  bool attached() const;
+  Servo &operator=(const Servo &other);
+  bool operator<(const Servo &other) const;
+
+protected:
+  void update(int value);
+};
+
+namespace servo {
+  void detachAll();
+  int count();
+}
+`
+
+func names(decls []*Declaration) []string {
+	res := []string{}
+	for _, d := range decls {
+		res = append(res, string(d.Kind)+" "+d.Signature)
+	}
+	return res
+}
+
+func TestParseHeader(t *testing.T) {
+	decls := ParseHeader("Servo.h", servoV1)
+	require.Equal(t, []string{
+		"function void servoInit(void)",
+		"enum enum ServoMode {SERVO_NORMAL, SERVO_INVERTED}",
+		"class class Servo : public Base",
+		"function Servo()",
+		"function uint8_t attach(int pin)",
+		"function uint8_t attach(int pin, int min, int max)",
+		"function void write(int value)",
+		"function int read()",
+		"function bool attached() const",
+		"function Servo &operator=(const Servo &other)",
+		"function void detachAll()",
+		"function int counter(const char *name = \"{\")",
+	}, names(decls))
+	require.Equal(t, "Servo::attach", decls[4].Name)
+	require.Equal(t, "Servo::operator=", decls[9].Name)
+	require.Equal(t, "servo::detail::counter", decls[11].Name)
+	for _, d := range decls {
+		require.Equal(t, "Servo.h", d.Header)
+	}
+
+	decls = ParseHeader("Servo.h", servoV2)
+	require.Equal(t, []string{
+		"function extern \"C\" void servoInit(void)",
+		"enum enum class ServoMode : uint8_t {SERVO_NORMAL, SERVO_INVERTED, SERVO_CONTINUOUS}",
+		"class template <typename T> class Servo final : public Base",
+		"function Servo()",
+		"function uint8_t attach(int pin, int min = 544, int max = 2400)",
+		"function void write(int value, bool wait = false)",
+		"function int read()",
+		"function bool attached() const",
+		"function Servo &operator=(const Servo &other)",
+		"function bool operator<(const Servo &other) const",
+		"function void detachAll()",
+		"function int count()",
+	}, names(decls))
+}
+
+func TestDiff(t *testing.T) {
+	changes := Diff(ParseHeader("Servo.h", servoV1), ParseHeader("Servo.h", servoV2))
+	res := []string{}
+	for _, c := range changes {
+		res = append(res, string(c.Kind)+" "+c.Declaration().Name)
+	}
+	require.Equal(t, []string{
+		"changed Servo",
+		"removed Servo::attach",
+		"added Servo::attach",
+		"removed Servo::attach",
+		"added Servo::operator<",
+		"changed Servo::write",
+		"changed ServoMode",
+		"added servo::count",
+		"removed servo::detail::counter",
+		"changed servoInit",
+	}, res)
+	require.Equal(t, "void write(int value)", changes[5].Old.Signature)
+	require.Equal(t, "void write(int value, bool wait = false)", changes[5].New.Signature)
+
+	require.Empty(t, Diff(ParseHeader("Servo.h", servoV1), ParseHeader("Servo.h", servoV1)))
+}
+
+func TestDeclaredNames(t *testing.T) {
+	require.Equal(t, []string{
+		"MAX_SERVOS", "SERVO_INVERTED", "SERVO_NORMAL", "Servo", "ServoMode", "ServoPin_t", "Servo_h", "servo", "servoInit",
+	}, DeclaredNames(servoV1))
+
+	source := `
+extern HardwareSerial Serial;
+extern "C" const char *names[];
+static const uint8_t SS = 10;
+constexpr int LED = 13;
+typedef uint8_t pin_t;
+using callback_t = void (*)(int);
+`
+	require.Equal(t, []string{"LED", "SS", "Serial", "callback_t", "names", "pin_t"}, DeclaredNames(source))
+}
+
+func TestUsedNames(t *testing.T) {
+	source := `#include <Servo.h>
+// Servo comment
+Servo servo;
+void setup() {
+  Serial.begin(9600);
+  servo.attach(LED, servo::detail::counter("Wire"));
+  buffer->write('W');
+}
+`
+	require.Equal(t, []string{"LED", "Serial", "Servo", "buffer", "servo", "setup", "void"}, UsedNames(source))
+}
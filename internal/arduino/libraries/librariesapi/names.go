@@ -0,0 +1,175 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package librariesapi
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	defineRegexp     = regexp.MustCompile(`(?m)^[ \t]*#[ \t]*define[ \t]+(\w+)`)
+	externRegexp     = regexp.MustCompile(`\bextern\s+(?:"C"\s+)?[\w:<>,\s*&]+?\b(\w+)\s*(?:\[[^\]]*\])?\s*;`)
+	constRegexp      = regexp.MustCompile(`\b(?:const|constexpr)\s+[\w:<>,\s*&]+?\b(\w+)\s*(?:\[[^\]]*\])?\s*=`)
+	typedefRegexp    = regexp.MustCompile(`\btypedef\b[^;{}]*?\b(\w+)\s*(?:\[[^\]]*\])?\s*;`)
+	typedefEndRegexp = regexp.MustCompile(`}\s*(\w+)\s*;`)
+	usingRegexp      = regexp.MustCompile(`\busing\s+(\w+)\s*=`)
+	enumBodyRegexp   = regexp.MustCompile(`\{(.*)\}`)
+	identifierRegexp = regexp.MustCompile(`(\.|->|::)?\s*\b([A-Za-z_]\w*)`)
+)
+
+// DeclaredNames returns the names declared at the top level by the header
+// source: the functions, classes, enums and their values, the macros, the
+// type aliases and the extern or constant variables. The members of the
+// classes and of the namespaces are represented by the name of the class or
+// of the namespace.
+func DeclaredNames(source string) []string {
+	names := map[string]bool{}
+	stripped := stripComments(source)
+	for _, m := range defineRegexp.FindAllStringSubmatch(stripped, -1) {
+		names[m[1]] = true
+	}
+	code := topLevel(stripPreprocessor(stripped))
+	for _, re := range []*regexp.Regexp{externRegexp, constRegexp, typedefRegexp, typedefEndRegexp, usingRegexp} {
+		for _, m := range re.FindAllStringSubmatch(code, -1) {
+			names[m[1]] = true
+		}
+	}
+	for _, decl := range ParseHeader("", source) {
+		name, _, _ := strings.Cut(decl.Name, "::")
+		names[name] = true
+		if decl.Kind != Enum {
+			continue
+		}
+		if body := enumBodyRegexp.FindStringSubmatch(decl.Signature); body != nil {
+			for _, value := range strings.Split(body[1], ",") {
+				value, _, _ = strings.Cut(value, "=")
+				if value = strings.TrimSpace(value); value != "" {
+					names[value] = true
+				}
+			}
+		}
+	}
+	return sortedNames(names)
+}
+
+// UsedNames returns the names referenced by the source, ignoring the
+// comments, the string literals, the preprocessor directives and the names
+// of the members accessed with ".", "->" or "::".
+func UsedNames(source string) []string {
+	code := stripPreprocessor(stripStrings(stripComments(source)))
+	names := map[string]bool{}
+	for _, m := range identifierRegexp.FindAllStringSubmatch(code, -1) {
+		if m[1] == "" {
+			names[m[2]] = true
+		}
+	}
+	return sortedNames(names)
+}
+
+// topLevel removes the content of the parentheses and of the blocks, except
+// the extern "C" blocks, so that only the top level declarations are left.
+// The string literals at the top level are kept.
+func topLevel(src string) string {
+	res := strings.Builder{}
+	stmtStart := 0
+	transparent := []bool{}
+	skipped := 0
+	parens := 0
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(src) && src[end] != c && src[end] != '\n' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(src))
+			if skipped == 0 && parens == 0 {
+				res.WriteString(src[i:end])
+			}
+			i = end - 1
+			continue
+		case c == '{':
+			keep := skipped == 0 && parens == 0 && externCRegexp.MatchString(normalize(src[stmtStart:i]))
+			transparent = append(transparent, keep)
+			if !keep {
+				skipped++
+			}
+			if skipped <= 1 {
+				res.WriteByte(c)
+			}
+			stmtStart = i + 1
+			continue
+		case c == '}':
+			if len(transparent) > 0 {
+				if !transparent[len(transparent)-1] {
+					skipped--
+				}
+				transparent = transparent[:len(transparent)-1]
+			}
+			stmtStart = i + 1
+		case c == ';':
+			stmtStart = i + 1
+		case c == '(' && skipped == 0:
+			parens++
+			if parens == 1 {
+				res.WriteByte(c)
+			}
+			continue
+		case c == ')' && skipped == 0 && parens > 0:
+			parens--
+		}
+		if skipped == 0 && parens == 0 {
+			res.WriteByte(c)
+		}
+	}
+	return res.String()
+}
+
+// stripStrings removes the content of the string and char literals.
+func stripStrings(src string) string {
+	res := strings.Builder{}
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		res.WriteByte(c)
+		if c != '"' && c != '\'' {
+			continue
+		}
+		for i++; i < len(src) && src[i] != c && src[i] != '\n'; i++ {
+			if src[i] == '\\' {
+				i++
+			}
+		}
+		if i < len(src) {
+			res.WriteByte(src[i])
+		}
+	}
+	return res.String()
+}
+
+func sortedNames(names map[string]bool) []string {
+	res := []string{}
+	for name := range names {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
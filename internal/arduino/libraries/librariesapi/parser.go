@@ -0,0 +1,374 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package librariesapi extracts the public API of a library from its
+// headers, to compare two versions of the library. The parser is not a full
+// C++ parser: it recognizes the declarations of functions, classes, structs
+// and enums, and it's meant to spot the changes that may break the sketches.
+package librariesapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kind is the kind of a declaration.
+type Kind string
+
+const (
+	// Function is a free function or a method of a class.
+	Function Kind = "function"
+	// Class is a class, struct or union.
+	Class Kind = "class"
+	// Enum is an enumeration.
+	Enum Kind = "enum"
+)
+
+// Declaration is a public declaration found in a header.
+type Declaration struct {
+	Header    string
+	Kind      Kind
+	Name      string
+	Signature string
+	key       string
+}
+
+var (
+	classRegexp    = regexp.MustCompile(`^(?:template\s*<.*>\s*)?(class|struct|union)\s+(?:\w+\s+)*?(\w+)\s*(?:final\s*)?(:.*)?$`)
+	enumRegexp     = regexp.MustCompile(`^(?:typedef\s+)?enum\s+(?:class\s+|struct\s+)?(\w+)\s*(:.*)?$`)
+	namespaceRegex = regexp.MustCompile(`^(?:inline\s+)?namespace\s*(\w*)$`)
+	externCRegexp  = regexp.MustCompile(`^extern\s+"C"$`)
+	accessRegexp   = regexp.MustCompile(`^(public|private|protected)$`)
+	identRegexp    = regexp.MustCompile(`(~?\w+|operator\s*[^\s\w(]+|operator\s*\(\s*\))\s*$`)
+	operatorRegexp = regexp.MustCompile(`operator\s*[^\s\w(]+`)
+	spacesRegexp   = regexp.MustCompile(`\s+`)
+	skippedRegexp  = regexp.MustCompile(`^(typedef|using|friend|static_assert|return|template\s*<.*>\s*friend)\b`)
+)
+
+type scope struct {
+	name    string
+	class   bool
+	visible bool
+}
+
+// ParseHeader returns the public declarations of the header source.
+func ParseHeader(header, source string) []*Declaration {
+	p := &parser{header: header, src: stripPreprocessor(stripComments(source))}
+	p.parse()
+	return p.decls
+}
+
+type parser struct {
+	header string
+	src    string
+	pos    int
+	scopes []*scope
+	decls  []*Declaration
+}
+
+func (p *parser) parse() {
+	stmt := strings.Builder{}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch c {
+		case '"', '\'':
+			stmt.WriteByte(c)
+			stmt.WriteString(p.skipLiteral(c))
+		case ';':
+			p.statement(stmt.String())
+			stmt.Reset()
+		case '{':
+			p.block(normalize(stmt.String()))
+			stmt.Reset()
+		case '}':
+			if len(p.scopes) > 0 {
+				p.scopes = p.scopes[:len(p.scopes)-1]
+			}
+			stmt.Reset()
+		case ':':
+			if p.pos < len(p.src) && p.src[p.pos] == ':' {
+				stmt.WriteString("::")
+				p.pos++
+				continue
+			}
+			if access := accessRegexp.FindStringSubmatch(normalize(stmt.String())); access != nil && p.inClass() {
+				p.scopes[len(p.scopes)-1].visible = access[1] == "public"
+				stmt.Reset()
+				continue
+			}
+			stmt.WriteByte(c)
+		default:
+			stmt.WriteByte(c)
+		}
+	}
+}
+
+// block handles the opening of a block, stmt is the text preceding it.
+func (p *parser) block(stmt string) {
+	if m := namespaceRegex.FindStringSubmatch(stmt); m != nil {
+		p.scopes = append(p.scopes, &scope{name: m[1], visible: true})
+		return
+	}
+	if externCRegexp.MatchString(stmt) {
+		p.scopes = append(p.scopes, &scope{visible: true})
+		return
+	}
+	if m := enumRegexp.FindStringSubmatch(stmt); m != nil {
+		body := normalize(p.skipBlock())
+		p.add(Enum, m[1], strings.TrimSpace(stmt+" {"+body+"}"), "")
+		return
+	}
+	if m := classRegexp.FindStringSubmatch(stmt); m != nil && !strings.Contains(stmt, "(") {
+		p.add(Class, m[2], stmt, "")
+		p.scopes = append(p.scopes, &scope{name: m[2], class: true, visible: m[1] != "class"})
+		return
+	}
+	p.skipBlock()
+	if open := parenIndex(stmt); open >= 0 && !isInitializer(stmt[:open]) {
+		// Function defined inline
+		p.function(stmt)
+	}
+}
+
+// statement handles a statement terminated by a semicolon.
+func (p *parser) statement(stmt string) {
+	stmt = normalize(stmt)
+	if stmt == "" || skippedRegexp.MatchString(stmt) || !strings.Contains(stmt, "(") {
+		return
+	}
+	p.function(stmt)
+}
+
+func (p *parser) function(stmt string) {
+	open := parenIndex(stmt)
+	if open < 0 {
+		return
+	}
+	before := stmt[:open]
+	if isInitializer(before) {
+		// A variable initialized by a function call
+		return
+	}
+	m := identRegexp.FindStringSubmatch(before)
+	if m == nil {
+		return
+	}
+	name := spacesRegexp.ReplaceAllString(m[1], "")
+	if strings.HasPrefix(strings.TrimSpace(stmt[open+1:]), "*") {
+		// A pointer to function
+		return
+	}
+	for _, keyword := range []string{"if", "while", "for", "switch", "return", "sizeof", "decltype"} {
+		if name == keyword {
+			return
+		}
+	}
+	// Remove the initializers of the constructors
+	signature := stmt
+	if close := matchingParen(stmt, open); close > 0 {
+		if i := strings.Index(stmt[close:], ":"); i >= 0 && !strings.HasPrefix(stmt[close+i:], "::") {
+			signature = strings.TrimSpace(stmt[:close+i])
+		}
+		p.add(Function, name, signature, stmt[open:close+1])
+		return
+	}
+	p.add(Function, name, signature, stmt[open:])
+}
+
+func (p *parser) add(kind Kind, name, signature, params string) {
+	qualified := []string{}
+	for _, s := range p.scopes {
+		if !s.visible {
+			return
+		}
+		if s.name != "" {
+			qualified = append(qualified, s.name)
+		}
+	}
+	qualified = append(qualified, name)
+	d := &Declaration{
+		Header:    p.header,
+		Kind:      kind,
+		Name:      strings.Join(qualified, "::"),
+		Signature: signature,
+	}
+	d.key = string(kind) + " " + d.Name + params
+	p.decls = append(p.decls, d)
+}
+
+func (p *parser) inClass() bool {
+	return len(p.scopes) > 0 && p.scopes[len(p.scopes)-1].class
+}
+
+// skipBlock skips the content of a block up to the matching closing brace,
+// and returns the content.
+func (p *parser) skipBlock() string {
+	start := p.pos
+	depth := 1
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch c {
+		case '"', '\'':
+			p.skipLiteral(c)
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return p.src[start : p.pos-1]
+			}
+		}
+	}
+	return p.src[start:]
+}
+
+// skipLiteral skips a string or char literal, and returns its content.
+func (p *parser) skipLiteral(quote byte) string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		if c == '\\' {
+			p.pos++
+		} else if c == quote || c == '\n' {
+			break
+		}
+	}
+	return p.src[start:min(p.pos, len(p.src))]
+}
+
+// parenIndex returns the index of the opening parenthesis of the parameters,
+// ignoring the ones in the template arguments and the attributes.
+func parenIndex(stmt string) int {
+	// The names of the operators, like operator<<, are masked so they're not
+	// confused with the template arguments.
+	stmt = operatorRegexp.ReplaceAllStringFunc(stmt, func(op string) string {
+		return strings.Repeat(" ", len(op))
+	})
+	depth := 0
+	for i := 0; i < len(stmt); i++ {
+		switch stmt[i] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case '(':
+			if depth > 0 {
+				continue
+			}
+			if strings.HasSuffix(strings.TrimSpace(stmt[:i]), "__attribute__") {
+				i = matchingParen(stmt, i)
+				if i < 0 {
+					return -1
+				}
+				continue
+			}
+			if strings.HasSuffix(strings.TrimSpace(stmt[:i]), "operator") {
+				// operator()
+				if close := matchingParen(stmt, i); close > 0 {
+					i = close
+				}
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// isInitializer returns true if the text preceding the parameters contains
+// an assignment, that is not part of the name of an operator.
+func isInitializer(before string) bool {
+	return strings.Contains(operatorRegexp.ReplaceAllString(before, ""), "=")
+}
+
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// normalize collapses the white spaces of a declaration.
+func normalize(s string) string {
+	s = strings.TrimSpace(spacesRegexp.ReplaceAllString(s, " "))
+	s = strings.NewReplacer("( ", "(", " )", ")", " ,", ",", " ;", ";").Replace(s)
+	return s
+}
+
+// stripComments removes the comments, leaving the string literals intact.
+func stripComments(src string) string {
+	res := strings.Builder{}
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			end := min(j+1, len(src))
+			res.WriteString(src[i:end])
+			i = end - 1
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			res.WriteByte('\n')
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return res.String()
+			}
+			i += end + 3
+			res.WriteByte(' ')
+		default:
+			res.WriteByte(c)
+		}
+	}
+	return res.String()
+}
+
+// stripPreprocessor removes the preprocessor directives, including their
+// continuation lines. The code in all the conditional branches is kept.
+func stripPreprocessor(src string) string {
+	lines := strings.Split(src, "\n")
+	res := []string{}
+	continued := false
+	for _, line := range lines {
+		directive := continued || strings.HasPrefix(strings.TrimSpace(line), "#")
+		continued = directive && strings.HasSuffix(strings.TrimRight(line, " \t\r"), "\\")
+		if directive {
+			res = append(res, "")
+			continue
+		}
+		res = append(res, line)
+	}
+	return strings.Join(res, "\n")
+}
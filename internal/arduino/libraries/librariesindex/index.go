@@ -16,7 +16,10 @@
 package librariesindex
 
 import (
+	"path"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries"
@@ -134,6 +137,36 @@ func (idx *Index) FindIndexedLibrary(lib *libraries.Library) *Library {
 	return idx.Libraries[lib.Name]
 }
 
+// FindLibrariesProvidingInclude returns the indexed libraries that provide
+// the given header file: the libraries declaring the header in the
+// "includes" field of the latest release, and the libraries named after the
+// header. The libraries named after the header are returned first, the
+// others are sorted by name.
+func (idx *Index) FindLibrariesProvidingInclude(header string) []*Library {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.ReplaceAll(name, " ", "_"))
+	}
+	headerName := normalize(strings.TrimSuffix(path.Base(header), path.Ext(header)))
+
+	res := []*Library{}
+	namedAfterHeader := map[*Library]bool{}
+	for _, lib := range idx.Libraries {
+		if normalize(lib.Name) == headerName {
+			namedAfterHeader[lib] = true
+			res = append(res, lib)
+		} else if lib.Latest != nil && slices.Contains(lib.Latest.ProvidesIncludes, header) {
+			res = append(res, lib)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if namedAfterHeader[res[i]] != namedAfterHeader[res[j]] {
+			return namedAfterHeader[res[i]]
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
 // FindLibraryUpdate check if an installed library may be updated using
 // one of the indexed libraries. This function returns the Release to install
 // to update the library if found, otherwise nil is returned.
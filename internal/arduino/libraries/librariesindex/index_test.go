@@ -138,3 +138,20 @@ func BenchmarkIndexParsingEasyJSON(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+func TestFindLibrariesProvidingInclude(t *testing.T) {
+	index, err := LoadIndex(paths.New("testdata/library_index.json"))
+	require.NoError(t, err)
+
+	names := func(libs []*Library) []string {
+		res := []string{}
+		for _, lib := range libs {
+			res = append(res, lib.Name)
+		}
+		return res
+	}
+	require.Equal(t, []string{"Servo", "InkyBoard", "SlowMotionServo", "XMC_Servo"}, names(index.FindLibrariesProvidingInclude("Servo.h")))
+	require.Equal(t, []string{"WiFiNINA"}, names(index.FindLibrariesProvidingInclude("WiFiNINA.h")))
+	require.Equal(t, []string{"Arduino Low Power"}, names(index.FindLibrariesProvidingInclude("Arduino_Low_Power.h")))
+	require.Empty(t, index.FindLibrariesProvidingInclude("NotExistent.h"))
+}
@@ -18,6 +18,7 @@ package librariesmanager
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	"github.com/arduino/arduino-cli/internal/arduino/globals"
 	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/resources"
 	"github.com/arduino/arduino-cli/internal/arduino/utils"
 	paths "github.com/arduino/go-paths-helper"
 	"github.com/codeclysm/extract/v3"
@@ -103,42 +105,42 @@ func (lmi *Installer) InstallPrerequisiteCheck(name string, version *semver.Vers
 }
 
 // importLibraryFromDirectory installs a library by copying it from the given directory.
-func (lmi *Installer) importLibraryFromDirectory(libPath *paths.Path, overwrite bool) error {
+func (lmi *Installer) importLibraryFromDirectory(libPath *paths.Path, overwrite bool) (*LibraryInstallPlan, error) {
 	// Check if the library is valid and load metatada
 	if err := validateLibrary(libPath); err != nil {
-		return err
+		return nil, err
 	}
 	library, err := libraries.Load(libPath, libraries.User)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check if the library is already installed and determine install path
 	installPlan, err := lmi.InstallPrerequisiteCheck(library.Name, library.Version, libraries.User)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if installPlan.UpToDate {
 		if !overwrite {
-			return fmt.Errorf(tr("library %s already installed"), installPlan.Name)
+			return nil, fmt.Errorf(tr("library %s already installed"), installPlan.Name)
 		}
 	}
 	if installPlan.ReplacedLib != nil {
 		if !overwrite {
-			return fmt.Errorf(tr("Library %[1]s is already installed, but with a different version: %[2]s", installPlan.Name, installPlan.ReplacedLib))
+			return nil, fmt.Errorf(tr("Library %[1]s is already installed, but with a different version: %[2]s", installPlan.Name, installPlan.ReplacedLib))
 		}
 		if err := lmi.Uninstall(installPlan.ReplacedLib); err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if installPlan.TargetPath.Exist() {
-		return fmt.Errorf("%s: %s", tr("destination directory already exists"), installPlan.TargetPath)
+		return nil, fmt.Errorf("%s: %s", tr("destination directory already exists"), installPlan.TargetPath)
 	}
 	if err := libPath.CopyDirTo(installPlan.TargetPath); err != nil {
-		return fmt.Errorf("%s: %w", tr("copying library to destination directory:"), err)
+		return nil, fmt.Errorf("%s: %w", tr("copying library to destination directory:"), err)
 	}
-	return nil
+	return installPlan, nil
 }
 
 // Uninstall removes a Library
@@ -156,71 +158,99 @@ func (lmi *Installer) Uninstall(lib *libraries.Library) error {
 	return nil
 }
 
-// InstallZipLib installs a Zip library on the specified path.
-func (lmi *Installer) InstallZipLib(ctx context.Context, archivePath *paths.Path, overwrite bool) error {
+// InstallZipLib installs a Zip library on the specified path. The returned
+// plan describes the installed library and the library it replaced, if any.
+func (lmi *Installer) InstallZipLib(ctx context.Context, archivePath *paths.Path, overwrite bool) (*LibraryInstallPlan, error) {
 	// Clone library in a temporary directory
 	tmpDir, err := paths.MkTempDir("", "")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tmpDir.RemoveAll()
 
 	file, err := archivePath.Open()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer file.Close()
 
 	// Extract to a temporary directory so we can check if the zip is structured correctly.
 	// We also use the top level folder from the archive to infer the library name.
-	if err := extract.Archive(ctx, file, tmpDir.String(), nil); err != nil {
-		return fmt.Errorf(tr("extracting archive: %w"), err)
+	if err := extract.Archive(ctx, file, tmpDir.String(), resources.NormalizeArchiveEntryName); err != nil {
+		return nil, fmt.Errorf(tr("extracting archive: %w"), err)
 	}
 
 	libRootFiles, err := tmpDir.ReadDir()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	libRootFiles.FilterOutPrefix("__MACOSX") // Ignores metadata from Mac OS X
 	if len(libRootFiles) > 1 {
-		return fmt.Errorf(tr("archive is not valid: multiple files found in zip file top level"))
+		return nil, fmt.Errorf(tr("archive is not valid: multiple files found in zip file top level"))
 	}
 	if len(libRootFiles) == 0 {
-		return fmt.Errorf(tr("archive is not valid: no files found in zip file top level"))
+		return nil, fmt.Errorf(tr("archive is not valid: no files found in zip file top level"))
 	}
 	tmpInstallPath := libRootFiles[0]
 
 	// Install extracted library in the destination directory
-	if err := lmi.importLibraryFromDirectory(tmpInstallPath, overwrite); err != nil {
-		return fmt.Errorf(tr("moving extracted archive to destination dir: %s"), err)
+	installPlan, err := lmi.importLibraryFromDirectory(tmpInstallPath, overwrite)
+	if err != nil {
+		return nil, fmt.Errorf(tr("moving extracted archive to destination dir: %s"), err)
 	}
 
-	return nil
+	return installPlan, nil
 }
 
 // InstallGitLib installs a library hosted on a git repository on the specified path.
-func (lmi *Installer) InstallGitLib(gitURL string, overwrite bool) error {
+// The returned plan describes the installed library and the library it replaced, if any.
+func (lmi *Installer) InstallGitLib(gitURL string, overwrite bool) (*LibraryInstallPlan, error) {
 	gitLibraryName, ref, err := parseGitURL(gitURL)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Clone library in a temporary directory
 	tmp, err := paths.MkTempDir("", "")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tmp.RemoveAll()
 	tmpInstallPath := tmp.Join(gitLibraryName)
+	if err := cloneGitLibrary(gitURL, ref, tmpInstallPath, os.Stdout); err != nil {
+		return nil, err
+	}
+
+	// Install extracted library in the destination directory
+	installPlan, err := lmi.importLibraryFromDirectory(tmpInstallPath, overwrite)
+	if err != nil {
+		return nil, fmt.Errorf(tr("moving extracted archive to destination dir: %s"), err)
+	}
 
+	return installPlan, nil
+}
+
+// CloneGitLibrary clones the library hosted on a git repository in the
+// destination directory, checking out the revision specified in the fragment
+// of the URL, if any. The progress of the clone is written to progress, that
+// may be nil.
+func CloneGitLibrary(gitURL string, dest *paths.Path, progress io.Writer) error {
+	_, ref, err := parseGitURL(gitURL)
+	if err != nil {
+		return err
+	}
+	return cloneGitLibrary(gitURL, ref, dest, progress)
+}
+
+func cloneGitLibrary(gitURL string, ref plumbing.Revision, dest *paths.Path, progress io.Writer) error {
 	depth := 1
 	if ref != "" {
 		depth = 0
 	}
-	repo, err := git.PlainClone(tmpInstallPath.String(), false, &git.CloneOptions{
+	repo, err := git.PlainClone(dest.String(), false, &git.CloneOptions{
 		URL:      gitURL,
 		Depth:    depth,
-		Progress: os.Stdout,
+		Progress: progress,
 	})
 	if err != nil {
 		return err
@@ -237,14 +267,7 @@ func (lmi *Installer) InstallGitLib(gitURL string, overwrite bool) error {
 	}
 
 	// We don't want the installed library to be a git repository thus we delete this folder
-	tmpInstallPath.Join(".git").RemoveAll()
-
-	// Install extracted library in the destination directory
-	if err := lmi.importLibraryFromDirectory(tmpInstallPath, overwrite); err != nil {
-		return fmt.Errorf(tr("moving extracted archive to destination dir: %s"), err)
-	}
-
-	return nil
+	return dest.Join(".git").RemoveAll()
 }
 
 // parseGitURL tries to recover a library name from a git URL.
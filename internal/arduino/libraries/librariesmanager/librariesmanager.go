@@ -131,7 +131,7 @@ func (lmb *Builder) Build() (*LibrariesManager, []*status.Status) {
 	res := &LibrariesManager{}
 	for _, dir := range lmb.librariesDir {
 		if !dir.scanned {
-			if errs := lmb.loadLibrariesFromDir(dir); len(errs) > 0 {
+			if errs := lmb.loadLibrariesFromDir(dir, false); len(errs) > 0 {
 				statuses = append(statuses, errs...)
 			}
 		}
@@ -173,13 +173,89 @@ func (lmi *Installer) RescanLibraries() []*status.Status {
 	lmi.libraries = map[string]libraries.List{}
 	statuses := []*status.Status{}
 	for _, dir := range lmi.librariesDir {
-		if errs := lmi.loadLibrariesFromDir(dir); len(errs) > 0 {
+		if errs := lmi.loadLibrariesFromDir(dir, false); len(errs) > 0 {
 			statuses = append(statuses, errs...)
 		}
 	}
 	return statuses
 }
 
+// RescanLibrary updates the library installed in the given directory: the
+// library is removed if the directory doesn't exist anymore, otherwise it's
+// (re)loaded. Only the directories contained in one of the libraries dirs
+// are considered, the method returns false if libDir is not one of them.
+func (lmi *Installer) RescanLibrary(libDir *paths.Path) (bool, error) {
+	var librariesDir *LibrariesDir
+	for _, dir := range lmi.librariesDir {
+		if !dir.IsSingleLibrary && dir.Path.EquivalentTo(libDir.Parent()) {
+			librariesDir = dir
+			break
+		}
+	}
+	if librariesDir == nil {
+		return false, nil
+	}
+
+	// Remove the previously loaded library, if any
+	lmi.removeLibraries(func(lib *libraries.Library) bool {
+		return lib.InstallDir.EquivalentTo(libDir)
+	})
+
+	if !libDir.IsDir() || strings.HasPrefix(libDir.Base(), ".") {
+		return true, nil
+	}
+	library, err := loadLibrary(libDir, librariesDir.Location, true)
+	if err != nil {
+		return true, fmt.Errorf(tr("loading library from %[1]s: %[2]s"), libDir, err)
+	}
+	library.ContainerPlatform = librariesDir.PlatformRelease
+	alternatives := lmi.libraries[library.Name]
+	alternatives.Add(library)
+	lmi.libraries[library.Name] = alternatives
+	return true, nil
+}
+
+// RescanLibrariesDir reloads all the libraries contained in the given
+// libraries dir. The method returns false if dir is not one of the libraries
+// dirs of the LibrariesManager.
+func (lmi *Installer) RescanLibrariesDir(dir *paths.Path) (bool, []*status.Status) {
+	for _, librariesDir := range lmi.librariesDir {
+		if !librariesDir.Path.EquivalentTo(dir) {
+			continue
+		}
+		lmi.removeLibraries(func(lib *libraries.Library) bool {
+			if librariesDir.IsSingleLibrary {
+				return lib.InstallDir.EquivalentTo(dir)
+			}
+			return lib.InstallDir.Parent().EquivalentTo(dir)
+		})
+		return true, lmi.loadLibrariesFromDir(librariesDir, true)
+	}
+	return false, nil
+}
+
+// removeLibraries removes the libraries matching the given function. The lists
+// may be shared with a cloned LibrariesManager, so they are rebuilt instead
+// of being altered.
+func (lmi *Installer) removeLibraries(match func(lib *libraries.Library) bool) {
+	for name, alternatives := range lmi.libraries {
+		var kept libraries.List
+		for _, lib := range alternatives {
+			if lib.InstallDir == nil || !match(lib) {
+				kept = append(kept, lib)
+			}
+		}
+		if len(kept) == len(alternatives) {
+			continue
+		}
+		if len(kept) == 0 {
+			delete(lmi.libraries, name)
+		} else {
+			lmi.libraries[name] = kept
+		}
+	}
+}
+
 func (lm *LibrariesManager) getLibrariesDir(installLocation libraries.LibraryLocation) (*paths.Path, error) {
 	for _, dir := range lm.librariesDir {
 		if dir.Location == installLocation {
@@ -197,8 +273,9 @@ func (lm *LibrariesManager) getLibrariesDir(installLocation libraries.LibraryLoc
 }
 
 // loadLibrariesFromDir loads all libraries in the given directory. Returns
-// nil if the directory doesn't exists.
-func (lm *LibrariesManager) loadLibrariesFromDir(librariesDir *LibrariesDir) []*status.Status {
+// nil if the directory doesn't exists. The libraries that didn't change since
+// the last scan are taken from the scan cache, unless force is true.
+func (lm *LibrariesManager) loadLibrariesFromDir(librariesDir *LibrariesDir, force bool) []*status.Status {
 	statuses := []*status.Status{}
 
 	librariesDir.scanned = true
@@ -221,7 +298,7 @@ func (lm *LibrariesManager) loadLibrariesFromDir(librariesDir *LibrariesDir) []*
 	}
 
 	for _, libDir := range libDirs {
-		library, err := libraries.Load(libDir, librariesDir.Location)
+		library, err := loadLibrary(libDir, librariesDir.Location, force)
 		if err != nil {
 			s := status.Newf(codes.Internal, tr("loading library from %[1]s: %[2]s"), libDir, err)
 			statuses = append(statuses, s)
@@ -15,9 +15,12 @@
 package librariesmanager
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,3 +46,89 @@ func TestLibrariesBuilderScanCloneRescan(t *testing.T) {
 	require.Len(t, lm.libraries, 2) // Ensure deep-coping worked as expected...
 	require.Len(t, lm2.libraries, 0)
 }
+
+func TestRescanLibrary(t *testing.T) {
+	userDir := paths.New(t.TempDir())
+	lmb := NewBuilder()
+	lmb.AddLibrariesDir(LibrariesDir{Path: userDir, Location: libraries.User})
+	lm, warns := lmb.Build()
+	require.Empty(t, warns)
+	lmi, release := lm.NewInstaller()
+	defer release()
+	require.Empty(t, lmi.FindAllInstalled())
+
+	// Directories outside the libraries dirs are ignored
+	updated, err := lmi.RescanLibrary(paths.New(t.TempDir()).Join("Other"))
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	libDir := userDir.Join("TestLib")
+	require.NoError(t, libDir.MkdirAll())
+	require.NoError(t, libDir.Join("library.properties").WriteFile([]byte("name=Test Lib\nversion=1.0.0\n")))
+	updated, err = lmi.RescanLibrary(libDir)
+	require.NoError(t, err)
+	require.True(t, updated)
+	require.Len(t, lmi.FindByReference("Test Lib", nil, libraries.User), 1)
+
+	// Reloading the same library must not duplicate it
+	require.NoError(t, libDir.Join("library.properties").WriteFile([]byte("name=Test Lib\nversion=1.1.0\n")))
+	_, err = lmi.RescanLibrary(libDir)
+	require.NoError(t, err)
+	libs := lmi.FindByReference("Test Lib", nil, libraries.User)
+	require.Len(t, libs, 1)
+	require.Equal(t, "1.1.0", libs[0].Version.String())
+
+	require.NoError(t, libDir.RemoveAll())
+	updated, err = lmi.RescanLibrary(libDir)
+	require.NoError(t, err)
+	require.True(t, updated)
+	require.Empty(t, lmi.FindAllInstalled())
+}
+
+func TestRescanLibrariesDirAndScanCache(t *testing.T) {
+	userDir := paths.New(t.TempDir())
+	writeLib := func(name, version string) *paths.Path {
+		libDir := userDir.Join(name)
+		require.NoError(t, libDir.MkdirAll())
+		props := libDir.Join("library.properties")
+		require.NoError(t, props.WriteFile([]byte("name="+name+"\nversion="+version+"\n")))
+		// Ensure the modification time changes even on filesystems with a coarse resolution
+		modTime := time.Now().Add(time.Duration(len(version)) * time.Hour)
+		require.NoError(t, os.Chtimes(props.String(), modTime, modTime))
+		return libDir
+	}
+	writeLib("LibA", "1.0.0")
+
+	lmb := NewBuilder()
+	lmb.AddLibrariesDir(LibrariesDir{Path: userDir, Location: libraries.User})
+	lm, warns := lmb.Build()
+	require.Empty(t, warns)
+
+	lmi, release := lm.NewInstaller()
+	defer release()
+	require.Len(t, lmi.FindAllInstalled(), 1)
+
+	// An unchanged library is taken from the cache...
+	lib1, err := loadLibrary(userDir.Join("LibA"), libraries.User, false)
+	require.NoError(t, err)
+	lib2, err := loadLibrary(userDir.Join("LibA"), libraries.User, false)
+	require.NoError(t, err)
+	require.NotSame(t, lib1, lib2)
+	require.Same(t, lib1.Properties, lib2.Properties)
+
+	// ...while a modified one is loaded again
+	writeLib("LibA", "1.0.10")
+	lib3, err := loadLibrary(userDir.Join("LibA"), libraries.User, false)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.10", lib3.Version.String())
+
+	writeLib("LibB", "1.0.0")
+	rescanned, statuses := lmi.RescanLibrariesDir(userDir)
+	require.True(t, rescanned)
+	require.Empty(t, statuses)
+	require.Len(t, lmi.FindAllInstalled(), 2)
+	require.Equal(t, "1.0.10", lmi.FindByReference("LibA", nil, libraries.User)[0].Version.String())
+
+	rescanned, _ = lmi.RescanLibrariesDir(paths.New(t.TempDir()))
+	require.False(t, rescanned)
+}
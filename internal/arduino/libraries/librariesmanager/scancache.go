@@ -0,0 +1,86 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package librariesmanager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
+)
+
+// scanCache keeps the libraries loaded by any LibrariesManager of the
+// process, so the libraries that didn't change on disk are not parsed
+// again at every rescan (for example when an instance is re-initialized).
+var scanCache = map[scanCacheKey]*scanCacheEntry{}
+var scanCacheMux sync.Mutex
+
+type scanCacheKey struct {
+	dir      string
+	location libraries.LibraryLocation
+}
+
+type scanCacheEntry struct {
+	modTime time.Time
+	library *libraries.Library
+}
+
+// libraryModTime returns the most recent modification time among the files
+// and directories that are relevant for loading the library in libDir.
+func libraryModTime(libDir *paths.Path) (time.Time, error) {
+	info, err := libDir.Stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+	modTime := info.ModTime()
+	for _, f := range []string{"library.properties", "src", "utility", "examples"} {
+		if info, err := libDir.Join(f).Stat(); err == nil && info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+// loadLibrary loads the library in libDir, reusing the cached one if the
+// library didn't change since it was loaded. If force is true the library
+// is always loaded from disk.
+func loadLibrary(libDir *paths.Path, location libraries.LibraryLocation, force bool) (*libraries.Library, error) {
+	key := scanCacheKey{dir: libDir.String(), location: location}
+	modTime, err := libraryModTime(libDir)
+	if err != nil {
+		return nil, err
+	}
+
+	scanCacheMux.Lock()
+	entry := scanCache[key]
+	scanCacheMux.Unlock()
+	if !force && entry != nil && entry.modTime.Equal(modTime) {
+		// Return a copy so each LibrariesManager can set its own container platform
+		library := *entry.library
+		return &library, nil
+	}
+
+	library, err := libraries.Load(libDir, location)
+	if err != nil {
+		return nil, err
+	}
+	cached := *library
+	scanCacheMux.Lock()
+	scanCache[key] = &scanCacheEntry{modTime: modTime, library: &cached}
+	scanCacheMux.Unlock()
+	return library, nil
+}
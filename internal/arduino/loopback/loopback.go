@@ -0,0 +1,223 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package loopback implements a virtual port, together with its pluggable
+// discovery and pluggable monitor, that echoes back all the data written to
+// it. It allows to exercise the board list, monitor and upload flows without
+// physical hardware.
+package loopback
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	monitor "github.com/arduino/pluggable-monitor-protocol-handler"
+)
+
+// Protocol is the protocol of the loopback port
+const Protocol = "loopback"
+
+// Address is the address of the loopback port
+const Address = "loopback"
+
+// Port returns the loopback port as reported by the discovery
+func Port() *discovery.Port {
+	return &discovery.Port{
+		Address:       Address,
+		AddressLabel:  "Loopback port",
+		Protocol:      Protocol,
+		ProtocolLabel: "Loopback",
+		HardwareID:    "loopback",
+	}
+}
+
+// Discovery is a pluggable discovery that reports the loopback port
+type Discovery struct{}
+
+// Hello does nothing, it's here to implement the discovery.Discovery interface
+func (d *Discovery) Hello(userAgent string, protocolVersion int) error {
+	return nil
+}
+
+// StartSync reports the loopback port. The event is sent shortly after
+// the START_SYNC command is acknowledged, as required by the protocol.
+func (d *Discovery) StartSync(eventCB discovery.EventCallback, errorCB discovery.ErrorCallback) error {
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		eventCB("add", Port())
+	}()
+	return nil
+}
+
+// Stop does nothing, it's here to implement the discovery.Discovery interface
+func (d *Discovery) Stop() error {
+	return nil
+}
+
+// Quit does nothing, it's here to implement the discovery.Discovery interface
+func (d *Discovery) Quit() {}
+
+// Monitor is a pluggable monitor that connects to the loopback port
+type Monitor struct {
+	mux      sync.Mutex
+	settings *monitor.PortDescriptor
+	opened   *echoPort
+}
+
+// NewMonitor creates a new loopback Monitor
+func NewMonitor() *Monitor {
+	return &Monitor{
+		settings: &monitor.PortDescriptor{
+			Protocol: Protocol,
+			ConfigurationParameter: map[string]*monitor.PortParameterDescriptor{
+				"baudrate": {
+					Label:    "Baudrate",
+					Type:     "enum",
+					Values:   []string{"9600", "19200", "38400", "57600", "115200"},
+					Selected: "9600",
+				},
+			},
+		},
+	}
+}
+
+// Hello does nothing, it's here to implement the monitor.Monitor interface
+func (m *Monitor) Hello(userAgent string, protocolVersion int) error {
+	return nil
+}
+
+// Describe returns the settings of the loopback port
+func (m *Monitor) Describe() (*monitor.PortDescriptor, error) {
+	return m.settings, nil
+}
+
+// Configure sets a setting of the loopback port, the settings do not have
+// any effect on the data transmitted.
+func (m *Monitor) Configure(parameterName string, value string) error {
+	param := m.settings.ConfigurationParameter[parameterName]
+	if param == nil {
+		return fmt.Errorf("could not find parameter named %s", parameterName)
+	}
+	for _, v := range param.Values {
+		if v == value {
+			param.Selected = value
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value for parameter %s: %s", parameterName, value)
+}
+
+// Open opens the loopback port
+func (m *Monitor) Open(boardPort string) (io.ReadWriter, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if boardPort != Address {
+		return nil, fmt.Errorf("invalid port: %s", boardPort)
+	}
+	if m.opened != nil {
+		return nil, fmt.Errorf("port already opened: %s", boardPort)
+	}
+	m.opened = newEchoPort()
+	return m.opened, nil
+}
+
+// Close closes the loopback port
+func (m *Monitor) Close() error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.opened == nil {
+		return errors.New("port already closed")
+	}
+	m.opened.Close()
+	m.opened = nil
+	return nil
+}
+
+// Quit does nothing, it's here to implement the monitor.Monitor interface
+func (m *Monitor) Quit() {}
+
+// echoPort is an io.ReadWriter that returns on Read all the data
+// previously written with Write.
+type echoPort struct {
+	mux    sync.Mutex
+	cond   *sync.Cond
+	buffer []byte
+	closed bool
+}
+
+func newEchoPort() *echoPort {
+	p := &echoPort{}
+	p.cond = sync.NewCond(&p.mux)
+	return p
+}
+
+func (p *echoPort) Read(buf []byte) (int, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	for len(p.buffer) == 0 && !p.closed {
+		p.cond.Wait()
+	}
+	if len(p.buffer) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(buf, p.buffer)
+	p.buffer = p.buffer[n:]
+	return n, nil
+}
+
+func (p *echoPort) Write(buf []byte) (int, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if p.closed {
+		return 0, io.ErrClosedPipe
+	}
+	p.buffer = append(p.buffer, buf...)
+	p.cond.Broadcast()
+	return len(buf), nil
+}
+
+func (p *echoPort) Close() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.closed = true
+	p.cond.Broadcast()
+}
+
+// DiscoveryCommandLine returns the command line to run the loopback discovery,
+// it is served by the hidden `loopback discovery` command of the running
+// executable.
+func DiscoveryCommandLine() ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return []string{exe, "loopback", "discovery"}, nil
+}
+
+// MonitorCommandLine returns the command line to run the loopback monitor,
+// it is served by the hidden `loopback monitor` command of the running
+// executable.
+func MonitorCommandLine() ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return []string{exe, "loopback", "monitor"}, nil
+}
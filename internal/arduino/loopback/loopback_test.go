@@ -0,0 +1,51 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package loopback
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoopbackMonitor(t *testing.T) {
+	m := NewMonitor()
+	require.NoError(t, m.Configure("baudrate", "115200"))
+	require.Error(t, m.Configure("baudrate", "42"))
+	require.Error(t, m.Configure("parity", "none"))
+
+	_, err := m.Open("/dev/ttyACM0")
+	require.Error(t, err)
+
+	port, err := m.Open(Address)
+	require.NoError(t, err)
+	_, err = m.Open(Address)
+	require.Error(t, err)
+
+	n, err := port.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	buf := make([]byte, 10)
+	n, err = port.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buf[:n]))
+
+	require.NoError(t, m.Close())
+	_, err = port.Read(buf)
+	require.ErrorIs(t, err, io.EOF)
+	require.Error(t, m.Close())
+}
@@ -26,6 +26,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/arduino/arduino-cli/internal/arduino/toolenv"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	"github.com/arduino/arduino-cli/version"
 	"github.com/arduino/go-paths-helper"
@@ -172,7 +173,7 @@ func (mon *PluggableMonitor) sendCommand(command string) error {
 
 func (mon *PluggableMonitor) runProcess() error {
 	mon.log.Infof("Starting monitor process")
-	proc, err := paths.NewProcess(nil, mon.processArgs...)
+	proc, err := toolenv.NewProcess(nil, mon.processArgs...)
 	if err != nil {
 		return err
 	}
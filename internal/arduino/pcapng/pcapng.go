@@ -0,0 +1,296 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package pcapng reads and writes the traffic of a monitor port in the pcapng
+// format, so it can be analyzed with Wireshark. Each chunk of data is saved
+// in a packet of the user defined link type DLT_USER0, the direction of the
+// data is saved in the packet flags.
+package pcapng
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// LinkTypeUser0 is the DLT_USER0 link type used for the captured packets
+const LinkTypeUser0 = 147
+
+const (
+	blockSectionHeader       = 0x0A0D0D0A
+	blockInterfaceDescriptor = 0x00000001
+	blockEnhancedPacket      = 0x00000006
+	byteOrderMagic           = 0x1A2B3C4D
+
+	optionEnd         = 0
+	optionShbUserAppl = 4
+	optionIfName      = 2
+	optionIfTsresol   = 9
+	optionEpbFlags    = 2
+)
+
+// Direction is the direction of the captured data
+type Direction int
+
+const (
+	// Inbound is the data received from the board
+	Inbound Direction = 1
+	// Outbound is the data sent to the board
+	Outbound Direction = 2
+)
+
+// Packet is a chunk of data sent or received at a given time
+type Packet struct {
+	Timestamp time.Time
+	Direction Direction
+	Data      []byte
+}
+
+// Writer writes the packets to a pcapng file, it is safe for concurrent use
+type Writer struct {
+	mux sync.Mutex
+	w   io.Writer
+}
+
+// NewWriter writes the pcapng headers to w and returns a Writer for the
+// packets of the given port.
+func NewWriter(w io.Writer, port, application string) (*Writer, error) {
+	shb := &bytes.Buffer{}
+	binary.Write(shb, binary.LittleEndian, uint32(byteOrderMagic))
+	binary.Write(shb, binary.LittleEndian, uint16(1)) // Major version
+	binary.Write(shb, binary.LittleEndian, uint16(0)) // Minor version
+	binary.Write(shb, binary.LittleEndian, int64(-1)) // Section length not specified
+	writeOption(shb, optionShbUserAppl, []byte(application))
+	writeOption(shb, optionEnd, nil)
+	if err := writeBlock(w, blockSectionHeader, shb.Bytes()); err != nil {
+		return nil, err
+	}
+
+	idb := &bytes.Buffer{}
+	binary.Write(idb, binary.LittleEndian, uint16(LinkTypeUser0))
+	binary.Write(idb, binary.LittleEndian, uint16(0)) // Reserved
+	binary.Write(idb, binary.LittleEndian, uint32(0)) // No snap length
+	writeOption(idb, optionIfName, []byte(port))
+	writeOption(idb, optionIfTsresol, []byte{6}) // Microseconds
+	writeOption(idb, optionEnd, nil)
+	if err := writeBlock(w, blockInterfaceDescriptor, idb.Bytes()); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w}, nil
+}
+
+// WritePacket writes a packet with the given data
+func (w *Writer) WritePacket(p *Packet) error {
+	ts := uint64(p.Timestamp.UnixMicro())
+	epb := &bytes.Buffer{}
+	binary.Write(epb, binary.LittleEndian, uint32(0)) // Interface ID
+	binary.Write(epb, binary.LittleEndian, uint32(ts>>32))
+	binary.Write(epb, binary.LittleEndian, uint32(ts))
+	binary.Write(epb, binary.LittleEndian, uint32(len(p.Data))) // Captured length
+	binary.Write(epb, binary.LittleEndian, uint32(len(p.Data))) // Original length
+	epb.Write(p.Data)
+	epb.Write(make([]byte, padding(len(p.Data))))
+	flags := make([]byte, 4)
+	binary.LittleEndian.PutUint32(flags, uint32(p.Direction))
+	writeOption(epb, optionEpbFlags, flags)
+	writeOption(epb, optionEnd, nil)
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return writeBlock(w.w, blockEnhancedPacket, epb.Bytes())
+}
+
+func padding(n int) int {
+	return (4 - n%4) % 4
+}
+
+func writeOption(buf *bytes.Buffer, code uint16, value []byte) {
+	binary.Write(buf, binary.LittleEndian, code)
+	binary.Write(buf, binary.LittleEndian, uint16(len(value)))
+	buf.Write(value)
+	buf.Write(make([]byte, padding(len(value))))
+}
+
+func writeBlock(w io.Writer, blockType uint32, body []byte) error {
+	length := uint32(len(body) + 12)
+	block := make([]byte, 0, length)
+	block = binary.LittleEndian.AppendUint32(block, blockType)
+	block = binary.LittleEndian.AppendUint32(block, length)
+	block = append(block, body...)
+	block = binary.LittleEndian.AppendUint32(block, length)
+	_, err := w.Write(block)
+	return err
+}
+
+// Reader reads the packets from a pcapng file. Only the packets captured
+// with the DLT_USER0 link type are returned.
+type Reader struct {
+	r          io.Reader
+	order      binary.ByteOrder
+	interfaces []*interfaceDescription
+}
+
+type interfaceDescription struct {
+	linkType uint16
+	// units of the timestamps per second
+	resolution uint64
+}
+
+// NewReader checks the pcapng header of r and returns a Reader
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{r: r}
+	blockType, _, err := reader.readBlock()
+	if err != nil {
+		return nil, err
+	}
+	if blockType != blockSectionHeader {
+		return nil, errors.New("not a pcapng file")
+	}
+	return reader, nil
+}
+
+// Next returns the next packet, or io.EOF if there are no more packets
+func (r *Reader) Next() (*Packet, error) {
+	for {
+		blockType, body, err := r.readBlock()
+		if err != nil {
+			return nil, err
+		}
+		switch blockType {
+		case blockSectionHeader:
+			// A new section resets the interfaces
+			r.interfaces = nil
+		case blockInterfaceDescriptor:
+			if len(body) < 8 {
+				return nil, errors.New("invalid interface description block")
+			}
+			iface := &interfaceDescription{linkType: r.order.Uint16(body), resolution: 1000000}
+			r.readOptions(body[8:], func(code uint16, value []byte) {
+				if code == optionIfTsresol && len(value) == 1 {
+					iface.resolution = tsResolution(value[0])
+				}
+			})
+			r.interfaces = append(r.interfaces, iface)
+		case blockEnhancedPacket:
+			if len(body) < 20 {
+				return nil, errors.New("invalid packet block")
+			}
+			id := r.order.Uint32(body)
+			if int(id) >= len(r.interfaces) {
+				return nil, fmt.Errorf("invalid interface id: %d", id)
+			}
+			iface := r.interfaces[id]
+			if iface.linkType != LinkTypeUser0 {
+				continue
+			}
+			ts := uint64(r.order.Uint32(body[4:]))<<32 | uint64(r.order.Uint32(body[8:]))
+			capLen := int(r.order.Uint32(body[12:]))
+			if 20+capLen > len(body) {
+				return nil, errors.New("invalid packet length")
+			}
+			p := &Packet{
+				Timestamp: timestamp(ts, iface.resolution),
+				Direction: Inbound,
+				Data:      body[20 : 20+capLen],
+			}
+			r.readOptions(body[20+capLen+padding(capLen):], func(code uint16, value []byte) {
+				if code == optionEpbFlags && len(value) == 4 {
+					p.Direction = Direction(r.order.Uint32(value) & 3)
+				}
+			})
+			return p, nil
+		}
+	}
+}
+
+func tsResolution(v byte) uint64 {
+	exp := uint64(v & 0x7F)
+	base := uint64(10)
+	if v&0x80 != 0 {
+		base = 2
+	}
+	res := uint64(1)
+	for i := uint64(0); i < exp && res < math.MaxUint64/base; i++ {
+		res *= base
+	}
+	return res
+}
+
+func timestamp(ts, resolution uint64) time.Time {
+	sec := ts / resolution
+	frac := ts % resolution
+	return time.Unix(int64(sec), int64(frac*uint64(time.Second)/resolution))
+}
+
+// readBlock reads a block and returns its type and body
+func (r *Reader) readBlock() (uint32, []byte, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return 0, nil, err
+	}
+	if binary.LittleEndian.Uint32(header) == blockSectionHeader {
+		// The byte order is set by each section header
+		magic := make([]byte, 4)
+		if _, err := io.ReadFull(r.r, magic); err != nil {
+			return 0, nil, io.ErrUnexpectedEOF
+		}
+		switch {
+		case binary.LittleEndian.Uint32(magic) == byteOrderMagic:
+			r.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(magic) == byteOrderMagic:
+			r.order = binary.BigEndian
+		default:
+			return 0, nil, errors.New("invalid byte order magic")
+		}
+		body, err := r.readBody(r.order.Uint32(header[4:]), 4)
+		return blockSectionHeader, append(magic, body...), err
+	}
+	if r.order == nil {
+		return 0, nil, errors.New("not a pcapng file")
+	}
+	body, err := r.readBody(r.order.Uint32(header[4:]), 0)
+	return r.order.Uint32(header), body, err
+}
+
+// readBody reads the remaining part of a block of the given total length,
+// alreadyRead bytes of the body have already been read.
+func (r *Reader) readBody(length uint32, alreadyRead int) ([]byte, error) {
+	if length < uint32(12+alreadyRead) || length%4 != 0 || length > 64*1024*1024 {
+		return nil, errors.New("invalid block length")
+	}
+	data := make([]byte, int(length)-8-alreadyRead)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	// Remove the trailing block length
+	return data[:len(data)-4], nil
+}
+
+func (r *Reader) readOptions(data []byte, cb func(code uint16, value []byte)) {
+	for len(data) >= 4 {
+		code := r.order.Uint16(data)
+		length := int(r.order.Uint16(data[2:]))
+		if code == optionEnd || 4+length > len(data) {
+			return
+		}
+		cb(code, data[4:4+length])
+		data = data[4+length+padding(length):]
+	}
+}
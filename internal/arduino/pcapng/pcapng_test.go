@@ -0,0 +1,69 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package pcapng
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, "/dev/ttyACM0", "arduino-cli")
+	require.NoError(t, err)
+	require.Zero(t, buf.Len()%4)
+
+	start := time.Date(2024, 3, 1, 10, 0, 0, 123456000, time.UTC)
+	packets := []*Packet{
+		{Timestamp: start, Direction: Outbound, Data: []byte("ping\n")},
+		{Timestamp: start.Add(1500 * time.Microsecond), Direction: Inbound, Data: []byte("pong\r\n")},
+		{Timestamp: start.Add(time.Second), Direction: Inbound, Data: []byte{0, 1, 2, 3}},
+	}
+	for _, p := range packets {
+		require.NoError(t, w.WritePacket(p))
+	}
+	require.Zero(t, buf.Len()%4)
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	for _, expected := range packets {
+		p, err := r.Next()
+		require.NoError(t, err)
+		require.Equal(t, expected.Direction, p.Direction)
+		require.Equal(t, expected.Data, p.Data)
+		require.True(t, expected.Timestamp.Equal(p.Timestamp), "%s != %s", expected.Timestamp, p.Timestamp)
+	}
+	_, err = r.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadInvalidFile(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("not a capture file at all")))
+	require.Error(t, err)
+	_, err = NewReader(bytes.NewReader(nil))
+	require.Error(t, err)
+}
+
+func TestTimestampResolution(t *testing.T) {
+	require.Equal(t, uint64(1000000), tsResolution(6))
+	require.Equal(t, uint64(1000000000), tsResolution(9))
+	require.Equal(t, uint64(1024), tsResolution(0x80|10))
+	require.Equal(t, time.Unix(10, 500000000), timestamp(10*1024+512, 1024))
+}
@@ -0,0 +1,252 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package pipeline loads the provisioning pipelines: a sequence of steps
+// (compile, burn bootloader, upload, serial checks, EEPROM write) executed
+// on each board connected to a manufacturing or test station.
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+	"github.com/arduino/go-paths-helper"
+	"gopkg.in/yaml.v3"
+)
+
+var tr = i18n.Tr
+
+// DefaultExpectTimeout is the timeout of the expect steps not specifying one
+const DefaultExpectTimeout = 10 * time.Second
+
+// Pipeline is a sequence of steps to run on each board
+type Pipeline struct {
+	// Boards selects the boards the pipeline runs on, if empty the pipeline
+	// runs on all the detected boards.
+	Boards []*Board `yaml:"boards"`
+	Steps  []*Step  `yaml:"steps"`
+}
+
+// Board selects the boards matching the FQBN. If the port is set, the board
+// connected to the port is used even if not identified by the discovery. If
+// the serial number is set, only the board with that USB serial number is
+// selected, wherever it's connected.
+type Board struct {
+	FQBN         string `yaml:"fqbn"`
+	Port         string `yaml:"port"`
+	SerialNumber string `yaml:"serial-number"`
+	// Sketch is used by the compile and upload steps not specifying one, so
+	// a different sketch can be run on each board.
+	Sketch string `yaml:"sketch"`
+	// Profile is the profile of the sketch used to compile it, the FQBN of
+	// the profile is used if the board has no FQBN.
+	Profile string `yaml:"profile"`
+}
+
+// Step is a single operation of the pipeline, exactly one of the operations
+// must be set.
+type Step struct {
+	Name           string              `yaml:"name"`
+	Compile        *CompileStep        `yaml:"compile"`
+	BurnBootloader *BurnBootloaderStep `yaml:"burn-bootloader"`
+	Upload         *UploadStep         `yaml:"upload"`
+	Expect         *ExpectStep         `yaml:"expect"`
+	EEPROM         *EEPROMStep         `yaml:"eeprom"`
+	// Extra collects the unknown keys, to report them as errors
+	Extra map[string]yaml.Node `yaml:",inline"`
+}
+
+// CompileStep compiles a sketch for the board
+type CompileStep struct {
+	Sketch          string   `yaml:"sketch"`
+	BuildProperties []string `yaml:"build-properties"`
+}
+
+// BurnBootloaderStep burns the bootloader of the board using a programmer
+type BurnBootloaderStep struct {
+	Programmer string `yaml:"programmer"`
+	Verify     bool   `yaml:"verify"`
+}
+
+// UploadStep uploads a sketch, previously compiled, to the board
+type UploadStep struct {
+	Sketch     string `yaml:"sketch"`
+	Programmer string `yaml:"programmer"`
+	Verify     bool   `yaml:"verify"`
+}
+
+// ExpectStep opens the monitor port of the board, optionally sends some data,
+// and waits for an answer matching a regular expression.
+type ExpectStep struct {
+	Config  map[string]string `yaml:"config"`
+	Send    string            `yaml:"send"`
+	Expect  string            `yaml:"expect"`
+	Timeout time.Duration     `yaml:"timeout"`
+
+	regexp *regexp.Regexp
+}
+
+// Regexp returns the compiled regular expression of the expected answer
+func (s *ExpectStep) Regexp() *regexp.Regexp {
+	return s.regexp
+}
+
+// EEPROMStep writes the content of a file in the EEPROM of the board
+type EEPROMStep struct {
+	File       string `yaml:"file"`
+	Programmer string `yaml:"programmer"`
+	Verify     bool   `yaml:"verify"`
+}
+
+// Kind returns the operation performed by the step
+func (s *Step) Kind() string {
+	switch {
+	case s.Compile != nil:
+		return "compile"
+	case s.BurnBootloader != nil:
+		return "burn-bootloader"
+	case s.Upload != nil:
+		return "upload"
+	case s.Expect != nil:
+		return "expect"
+	case s.EEPROM != nil:
+		return "eeprom"
+	}
+	return ""
+}
+
+// String returns the name of the step, or its kind if not named
+func (s *Step) String() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Kind()
+}
+
+// Load reads a pipeline from the given file. The paths of the sketches and
+// of the EEPROM files are resolved relative to the pipeline file.
+func Load(file *paths.Path) (*Pipeline, error) {
+	data, err := file.ReadFile()
+	if err != nil {
+		return nil, err
+	}
+	var p Pipeline
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", tr("error parsing pipeline"), err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	base, err := file.Parent().Abs()
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(path string) string {
+		if path == "" || paths.New(path).IsAbs() {
+			return path
+		}
+		return base.Join(path).String()
+	}
+	for _, board := range p.Boards {
+		board.Sketch = resolve(board.Sketch)
+	}
+	for _, step := range p.Steps {
+		switch {
+		case step.Compile != nil:
+			step.Compile.Sketch = resolve(step.Compile.Sketch)
+		case step.Upload != nil:
+			step.Upload.Sketch = resolve(step.Upload.Sketch)
+		case step.EEPROM != nil:
+			step.EEPROM.File = resolve(step.EEPROM.File)
+		}
+	}
+	return &p, nil
+}
+
+func (p *Pipeline) validate() error {
+	boardsHaveSketch := len(p.Boards) > 0
+	for _, board := range p.Boards {
+		if board.FQBN == "" && board.Profile == "" {
+			return errors.New(tr("missing fqbn in pipeline board"))
+		}
+		if board.Profile != "" && board.Sketch == "" {
+			return errors.New(tr("missing sketch for profile %s", board.Profile))
+		}
+		if board.Sketch == "" {
+			boardsHaveSketch = false
+		}
+	}
+	if len(p.Steps) == 0 {
+		return errors.New(tr("the pipeline has no steps"))
+	}
+	for i, step := range p.Steps {
+		if err := step.validate(boardsHaveSketch); err != nil {
+			return fmt.Errorf("%s: %w", tr("invalid step %d", i+1), err)
+		}
+	}
+	return nil
+}
+
+// validate checks the step, the sketch of the compile and upload steps may
+// be omitted if all the boards specify one.
+func (s *Step) validate(boardsHaveSketch bool) error {
+	for key := range s.Extra {
+		return errors.New(tr("unknown operation: %s", key))
+	}
+	count := 0
+	for _, set := range []bool{s.Compile != nil, s.BurnBootloader != nil, s.Upload != nil, s.Expect != nil, s.EEPROM != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return errors.New(tr("exactly one operation must be set"))
+	}
+	switch {
+	case s.Compile != nil:
+		if s.Compile.Sketch == "" && !boardsHaveSketch {
+			return errors.New(tr("missing sketch"))
+		}
+	case s.Upload != nil:
+		if s.Upload.Sketch == "" && !boardsHaveSketch {
+			return errors.New(tr("missing sketch"))
+		}
+	case s.BurnBootloader != nil:
+		if s.BurnBootloader.Programmer == "" {
+			return errors.New(tr("missing programmer"))
+		}
+	case s.EEPROM != nil:
+		if s.EEPROM.File == "" {
+			return errors.New(tr("missing file"))
+		}
+	case s.Expect != nil:
+		if s.Expect.Expect == "" {
+			return errors.New(tr("missing expected answer"))
+		}
+		re, err := regexp.Compile(s.Expect.Expect)
+		if err != nil {
+			return fmt.Errorf("%s: %w", tr("invalid expected answer"), err)
+		}
+		s.Expect.regexp = re
+		if s.Expect.Timeout == 0 {
+			s.Expect.Timeout = DefaultExpectTimeout
+		}
+	}
+	return nil
+}
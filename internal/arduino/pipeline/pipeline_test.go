@@ -0,0 +1,92 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPipeline(t *testing.T) {
+	p, err := Load(paths.New("testdata", "station.yaml"))
+	require.NoError(t, err)
+	testdata, err := paths.New("testdata").Abs()
+	require.NoError(t, err)
+
+	require.Equal(t, []*Board{
+		{FQBN: "arduino:avr:uno"},
+		{FQBN: "arduino:avr:nano", Port: "/dev/ttyUSB0"},
+	}, p.Boards)
+	require.Len(t, p.Steps, 5)
+
+	require.Equal(t, "Build the test firmware", p.Steps[0].String())
+	require.Equal(t, testdata.Join("firmware").String(), p.Steps[0].Compile.Sketch)
+	require.Equal(t, []string{"build.extra_flags=-DSTATION=1"}, p.Steps[0].Compile.BuildProperties)
+
+	require.Equal(t, "burn-bootloader", p.Steps[1].String())
+	require.True(t, p.Steps[1].BurnBootloader.Verify)
+
+	require.Equal(t, testdata.Join("firmware").String(), p.Steps[2].Upload.Sketch)
+
+	expect := p.Steps[3].Expect
+	require.Equal(t, "expect", p.Steps[3].Kind())
+	require.Equal(t, map[string]string{"baudrate": "115200"}, expect.Config)
+	require.Equal(t, "TEST\n", expect.Send)
+	require.Equal(t, 5*time.Second, expect.Timeout)
+	require.True(t, expect.Regexp().MatchString("self test: OK"))
+
+	require.Equal(t, "/tmp/calibration.bin", p.Steps[4].EEPROM.File)
+}
+
+func TestInvalidPipelines(t *testing.T) {
+	tmp := paths.New(t.TempDir()).Join("pipeline.yaml")
+	for _, test := range []struct {
+		yaml string
+		err  string
+	}{
+		{"steps: []", "the pipeline has no steps"},
+		{"boards: [{port: COM3}]\nsteps: [{upload: {sketch: s}}]", "missing fqbn in pipeline board"},
+		{"steps: [{name: empty}]", "invalid step 1: exactly one operation must be set"},
+		{"steps: [{upload: {sketch: s}, compile: {sketch: s}}]", "invalid step 1: exactly one operation must be set"},
+		{"steps: [{upload: {sketch: s}}, {flash: {}}]", "invalid step 2: unknown operation: flash"},
+		{"steps: [{expect: {expect: '('}}]", "invalid step 1: invalid expected answer: error parsing regexp: missing closing ): `(`"},
+		{"steps: [{burn-bootloader: {}}]", "invalid step 1: missing programmer"},
+		{"steps: [{compile: {}}]", "invalid step 1: missing sketch"},
+		{"boards: [{fqbn: a:b:c, sketch: s}, {fqbn: a:b:c}]\nsteps: [{upload: {}}]", "invalid step 1: missing sketch"},
+		{"boards: [{profile: uno}]\nsteps: [{upload: {}}]", "missing sketch for profile uno"},
+	} {
+		require.NoError(t, tmp.WriteFile([]byte(test.yaml)))
+		_, err := Load(tmp)
+		require.EqualError(t, err, test.err, test.yaml)
+	}
+
+	// The sketch of the steps is provided by the boards
+	require.NoError(t, tmp.WriteFile([]byte("boards: [{serial-number: '1234', profile: uno, sketch: blink}]\nsteps: [{compile: {}}, {upload: {}}]")))
+	p, err := Load(tmp)
+	require.NoError(t, err)
+	require.Equal(t, "1234", p.Boards[0].SerialNumber)
+	require.Equal(t, tmp.Parent().Join("blink").String(), p.Boards[0].Sketch)
+	require.Empty(t, p.Steps[0].Compile.Sketch)
+
+	// Default timeout
+	require.NoError(t, tmp.WriteFile([]byte("steps: [{expect: {expect: OK}}]")))
+	p, err = Load(tmp)
+	require.NoError(t, err)
+	require.Equal(t, DefaultExpectTimeout, p.Steps[0].Expect.Timeout)
+}
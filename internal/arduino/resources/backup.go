@@ -0,0 +1,106 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package resources
+
+import (
+	"fmt"
+
+	paths "github.com/arduino/go-paths-helper"
+)
+
+// DirBackup is a directory moved aside while it's being replaced, so that
+// it can be restored if the replacement fails.
+type DirBackup struct {
+	dir       *paths.Path
+	backupDir *paths.Path
+}
+
+// BackupDir moves dir in a hidden sibling directory, on the same filesystem,
+// freeing its path for a replacement. The hidden directories are ignored by
+// the platforms and libraries loaders.
+func BackupDir(dir *paths.Path) (*DirBackup, error) {
+	backupDir, err := dir.Parent().MkTempDir("." + dir.Base() + ".previous-")
+	if err != nil {
+		return nil, fmt.Errorf(tr("creating backup of %[1]s: %[2]s", dir, err))
+	}
+	if err := dir.Rename(backupDir.Join(dir.Base())); err != nil {
+		backupDir.RemoveAll()
+		return nil, fmt.Errorf(tr("creating backup of %[1]s: %[2]s", dir, err))
+	}
+	return &DirBackup{dir: dir, backupDir: backupDir}, nil
+}
+
+// Restore moves the backup back to its original path, removing anything
+// that has been installed there in the meantime.
+func (b *DirBackup) Restore() error {
+	if err := b.dir.RemoveAll(); err != nil {
+		return fmt.Errorf(tr("restoring backup of %[1]s: %[2]s", b.dir, err))
+	}
+	if err := b.backupDir.Join(b.dir.Base()).Rename(b.dir); err != nil {
+		return fmt.Errorf(tr("restoring backup of %[1]s: %[2]s", b.dir, err))
+	}
+	return b.backupDir.RemoveAll()
+}
+
+// Discard removes the backup.
+func (b *DirBackup) Discard() error {
+	return b.backupDir.RemoveAll()
+}
+
+// replaceDir moves src to dst. If dst already exists it's kept aside until
+// src is in place, and restored if the move fails, so that dst is never left
+// missing or half written.
+func replaceDir(src, dst *paths.Path) error {
+	var backup *DirBackup
+	if dst.Exist() {
+		b, err := BackupDir(dst)
+		if err != nil {
+			return err
+		}
+		backup = b
+	}
+	if err := moveDir(src, dst); err != nil {
+		if backup != nil {
+			if restoreErr := backup.Restore(); restoreErr != nil {
+				return fmt.Errorf("%s, %s", err, restoreErr)
+			}
+		}
+		return err
+	}
+	if backup != nil {
+		backup.Discard()
+	}
+	return nil
+}
+
+// moveDir renames src as dst. When the rename is not possible, for example
+// because src is on a different filesystem, src is copied in a hidden
+// sibling of dst that is then renamed, so dst appears only when complete.
+func moveDir(src, dst *paths.Path) error {
+	if err := src.Rename(dst); err == nil {
+		return nil
+	}
+	stagingDir, err := dst.Parent().MkTempDir("." + dst.Base() + ".staging-")
+	if err != nil {
+		return err
+	}
+	defer stagingDir.RemoveAll()
+	staged := stagingDir.Join(dst.Base())
+	if err := src.CopyDirTo(staged); err != nil {
+		return err
+	}
+	return staged.Rename(dst)
+}
@@ -34,6 +34,14 @@ import (
 
 var tr = i18n.Tr
 
+// ChecksumMismatchError is returned when the checksum of a local archive
+// differs from the one of the DownloadResource
+type ChecksumMismatchError struct{}
+
+func (e *ChecksumMismatchError) Error() string {
+	return tr("archive hash differs from hash in index")
+}
+
 // TestLocalArchiveChecksum test if the checksum of the local archive match the checksum of the DownloadResource
 func (r *DownloadResource) TestLocalArchiveChecksum(downloadDir *paths.Path) (bool, error) {
 	if r.Checksum == "" {
@@ -76,7 +84,7 @@ func (r *DownloadResource) TestLocalArchiveChecksum(downloadDir *paths.Path) (bo
 	}
 
 	if !bytes.Equal(algo.Sum(nil), digest) {
-		return false, fmt.Errorf(tr("archive hash differs from hash in index"))
+		return false, &ChecksumMismatchError{}
 	}
 
 	return true, nil
@@ -19,16 +19,20 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"unicode/utf8"
 
 	paths "github.com/arduino/go-paths-helper"
 	"github.com/codeclysm/extract/v3"
 	"go.bug.st/cleanup"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Install installs the resource in three steps:
 // - the archive is unpacked in a temporary subdir of tempPath
 // - there should be only one root dir in the unpacked content
 // - the only root dir is moved/renamed to/as the destination directory
+// An already existing destination directory is replaced only if the move succeeds.
 // Note that tempPath and destDir must be on the same filesystem partition
 // otherwise the last step will fail.
 func (release *DownloadResource) Install(downloadDir, tempPath, destDir *paths.Path) error {
@@ -39,7 +43,12 @@ func (release *DownloadResource) Install(downloadDir, tempPath, destDir *paths.P
 		return fmt.Errorf(tr("checking local archive integrity"))
 	}
 
-	// Create a temporary dir to extract package
+	// Create a temporary dir to extract package, the path must be absolute
+	// to allow the extraction of very long paths on Windows
+	tempPath, err := tempPath.Abs()
+	if err != nil {
+		return fmt.Errorf(tr("creating temp dir for extraction: %s", err))
+	}
 	if err := tempPath.MkdirAll(); err != nil {
 		return fmt.Errorf(tr("creating temp dir for extraction: %s", err))
 	}
@@ -63,7 +72,7 @@ func (release *DownloadResource) Install(downloadDir, tempPath, destDir *paths.P
 	// Extract into temp directory
 	ctx, cancel := cleanup.InterruptableContext(context.Background())
 	defer cancel()
-	if err := extract.Archive(ctx, file, tempDir.String(), nil); err != nil {
+	if err := extract.Archive(ctx, file, tempDir.String(), NormalizeArchiveEntryName); err != nil {
 		return fmt.Errorf(tr("extracting archive: %s", err))
 	}
 
@@ -84,20 +93,27 @@ func (release *DownloadResource) Install(downloadDir, tempPath, destDir *paths.P
 		}
 	}()
 
-	// If the destination dir already exists remove it
-	if destDir.IsDir() {
-		destDir.RemoveAll()
+	// Move/rename the extracted root directory in the destination directory,
+	// the previous content is kept until the new one is in place
+	if err := replaceDir(root, destDir); err != nil {
+		return fmt.Errorf(tr("moving extracted archive to destination dir: %s", err))
 	}
 
-	// Move/rename the extracted root directory in the destination directory
-	if err := root.Rename(destDir); err != nil {
-		// Copy the extracted root directory to the destination directory, if move failed
-		if err := root.CopyDirTo(destDir); err != nil {
-			return fmt.Errorf(tr("moving extracted archive to destination dir: %s", err))
+	return nil
+}
+
+// NormalizeArchiveEntryName converts the name of a file contained in an
+// archive to an UTF-8 string in the NFC normal form. The names that are not
+// valid UTF-8 strings are decoded from the IBM Code Page 437 charset, the
+// default for the zip archives made by old compressors. This is meant to be
+// used as an extract.Renamer.
+func NormalizeArchiveEntryName(name string) string {
+	if !utf8.ValidString(name) {
+		if decoded, err := charmap.CodePage437.NewDecoder().String(name); err == nil {
+			name = decoded
 		}
 	}
-
-	return nil
+	return norm.NFC.String(name)
 }
 
 // IsDirEmpty returns true if the directory specified by path is empty.
@@ -16,12 +16,16 @@
 package resources
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
 	"os"
 	"path"
 	"path/filepath"
 	"testing"
 
 	"github.com/arduino/go-paths-helper"
+	"github.com/codeclysm/extract/v3"
 
 	"github.com/stretchr/testify/require"
 )
@@ -88,3 +92,71 @@ func TestInstallPlatform(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeArchiveEntryName(t *testing.T) {
+	require.Equal(t, "My Library/src/Schön.h", NormalizeArchiveEntryName("My Library/src/Schön.h"))
+	// Code Page 437
+	require.Equal(t, "lib/café.h", NormalizeArchiveEntryName("lib/caf\x82.h"))
+	// Decomposed form
+	require.Equal(t, "lib/caf\u00e9.h", NormalizeArchiveEntryName("lib/cafe\u0301.h"))
+
+	// Extract a zip archive made by an old compressor
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	f, err := w.CreateHeader(&zip.FileHeader{Name: "My Library/caf\x82.h", NonUTF8: true})
+	require.NoError(t, err)
+	_, err = f.Write([]byte("content"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	tmp := paths.New(t.TempDir())
+	require.NoError(t, extract.Archive(context.Background(), buf, tmp.String(), NormalizeArchiveEntryName))
+	require.True(t, tmp.Join("My Library", "café.h").Exist())
+}
+
+func TestReplaceDir(t *testing.T) {
+	parent := paths.New(t.TempDir())
+	dst := parent.Join("dst")
+	require.NoError(t, dst.MkdirAll())
+	require.NoError(t, dst.Join("old.txt").WriteFile([]byte("old")))
+
+	t.Run("failed move restores the previous content", func(t *testing.T) {
+		err := replaceDir(parent.Join("missing"), dst)
+		require.Error(t, err)
+		require.True(t, dst.Join("old.txt").Exist())
+		files, err := parent.ReadDir()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+	})
+
+	t.Run("successful move replaces the previous content", func(t *testing.T) {
+		src := parent.Join("src")
+		require.NoError(t, src.MkdirAll())
+		require.NoError(t, src.Join("new.txt").WriteFile([]byte("new")))
+		require.NoError(t, replaceDir(src, dst))
+		require.True(t, dst.Join("new.txt").Exist())
+		require.False(t, dst.Join("old.txt").Exist())
+		files, err := parent.ReadDir()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+	})
+}
+
+func TestDirBackup(t *testing.T) {
+	dir := paths.New(t.TempDir()).Join("lib")
+	require.NoError(t, dir.MkdirAll())
+	require.NoError(t, dir.Join("old.txt").WriteFile([]byte("old")))
+
+	backup, err := BackupDir(dir)
+	require.NoError(t, err)
+	require.False(t, dir.Exist())
+
+	require.NoError(t, dir.MkdirAll())
+	require.NoError(t, dir.Join("new.txt").WriteFile([]byte("new")))
+	require.NoError(t, backup.Restore())
+	require.True(t, dir.Join("old.txt").Exist())
+	require.False(t, dir.Join("new.txt").Exist())
+	files, err := dir.Parent().ReadDir()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
@@ -38,16 +38,48 @@ type projectRaw struct {
 	DefaultPort       string    `yaml:"default_port,omitempty"`
 	DefaultProtocol   string    `yaml:"default_protocol,omitempty"`
 	DefaultProgrammer string    `yaml:"default_programmer,omitempty"`
+	// DefaultMonitorConfig is a map to let the unknown settings be preserved
+	DefaultMonitorConfig map[string]string `yaml:"default_monitor_config,omitempty"`
+	// DefaultMonitorLineEnding and DefaultMonitorEcho are the defaults of the
+	// line ending and of the local echo of the data sent by the monitor
+	DefaultMonitorLineEnding string `yaml:"default_monitor_line_ending,omitempty"`
+	DefaultMonitorEcho       bool   `yaml:"default_monitor_echo,omitempty"`
+	// MonitorMacros maps the keys to the data sent by the monitor when pressed
+	MonitorMacros map[string]string `yaml:"monitor_macros,omitempty"`
+	// LibrariesBuildProperties maps the library names to the extra compiler flags
+	LibrariesBuildProperties map[string]string `yaml:"libraries_build_properties,omitempty"`
+	BuildVariant             string            `yaml:"build_variant,omitempty"`
+	LinkerScript             string            `yaml:"linker_script,omitempty"`
+	LinkerScriptFragments    []string          `yaml:"linker_script_fragments,omitempty"`
 }
 
 // Project represents the sketch project file
 type Project struct {
-	Profiles          []*Profile
-	DefaultProfile    string
-	DefaultFqbn       string
-	DefaultPort       string
-	DefaultProtocol   string
-	DefaultProgrammer string
+	Profiles             []*Profile
+	DefaultProfile       string
+	DefaultFqbn          string
+	DefaultPort          string
+	DefaultProtocol      string
+	DefaultProgrammer    string
+	DefaultMonitorConfig map[string]string
+	// DefaultMonitorLineEnding and DefaultMonitorEcho are the defaults of the
+	// line ending and of the local echo of the data sent by the monitor
+	DefaultMonitorLineEnding string
+	DefaultMonitorEcho       bool
+	// MonitorMacros maps the keys to the data sent by the monitor when pressed
+	MonitorMacros map[string]string
+	// LibrariesBuildProperties are the extra compiler flags used to compile
+	// the sources of the library with the given name
+	LibrariesBuildProperties map[string]string
+	// BuildVariant overrides the variant of the board: it's the name of a
+	// variant of the platform or the path of a variant folder in the sketch
+	BuildVariant string
+	// LinkerScript is the path of the linker script, relative to the sketch
+	// folder, used instead of the linker script of the platform
+	LinkerScript string
+	// LinkerScriptFragments are the paths of the linker scripts, relative to
+	// the sketch folder, used together with the linker script of the platform
+	LinkerScriptFragments []string
 }
 
 // AsYaml outputs the sketch project file as YAML
@@ -74,6 +106,39 @@ func (p *Project) AsYaml() string {
 	if p.DefaultProgrammer != "" {
 		res += fmt.Sprintf("default_programmer: %s\n", p.DefaultProgrammer)
 	}
+	if len(p.DefaultMonitorConfig) > 0 {
+		if config, err := marshalFlowMap(p.DefaultMonitorConfig); err == nil {
+			res += fmt.Sprintf("default_monitor_config: %s\n", config)
+		}
+	}
+	if p.DefaultMonitorLineEnding != "" {
+		res += fmt.Sprintf("default_monitor_line_ending: %s\n", p.DefaultMonitorLineEnding)
+	}
+	if p.DefaultMonitorEcho {
+		res += "default_monitor_echo: true\n"
+	}
+	if len(p.MonitorMacros) > 0 {
+		if macros, err := marshalFlowMap(p.MonitorMacros); err == nil {
+			res += fmt.Sprintf("monitor_macros: %s\n", macros)
+		}
+	}
+	if len(p.LibrariesBuildProperties) > 0 {
+		if flags, err := marshalFlowMap(p.LibrariesBuildProperties); err == nil {
+			res += fmt.Sprintf("libraries_build_properties: %s\n", flags)
+		}
+	}
+	if p.BuildVariant != "" {
+		res += fmt.Sprintf("build_variant: %s\n", p.BuildVariant)
+	}
+	if p.LinkerScript != "" {
+		res += fmt.Sprintf("linker_script: %s\n", p.LinkerScript)
+	}
+	if len(p.LinkerScriptFragments) > 0 {
+		res += "linker_script_fragments:\n"
+		for _, fragment := range p.LinkerScriptFragments {
+			res += fmt.Sprintf("  - %s\n", fragment)
+		}
+	}
 	return res
 }
 
@@ -232,13 +297,24 @@ func (p *ProfilePlatformReference) UnmarshalYAML(unmarshal func(interface{}) err
 type ProfileLibraryReference struct {
 	Library string
 	Version *semver.Version
+	// URL is the URL of a zip archive or of a git repository providing the
+	// library, used instead of the libraries index. Version is nil in this
+	// case.
+	URL *url.URL
+	// Checksum is the checksum of the zip archive at URL, in the same format
+	// used in the indexes, e.g. "SHA-256:a1b2c3...".
+	Checksum string
 }
 
 // UnmarshalYAML decodes a ProfileLibraryReference from YAML source.
 func (l *ProfileLibraryReference) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var data string
 	if err := unmarshal(&data); err != nil {
-		return err
+		var fields map[string]string
+		if err := unmarshal(&fields); err != nil {
+			return err
+		}
+		return l.unmarshalURLReference(fields)
 	}
 	if libName, libVersion, ok := parseNameAndVersion(data); !ok {
 		return fmt.Errorf("%s %s", tr("invalid library directive:"), data)
@@ -251,18 +327,76 @@ func (l *ProfileLibraryReference) UnmarshalYAML(unmarshal func(interface{}) erro
 	return nil
 }
 
+// unmarshalURLReference decodes a library provided by an archive or a git
+// repository, the archives must have a checksum and the git URLs must
+// specify the revision to checkout, so that the builds are reproducible.
+func (l *ProfileLibraryReference) unmarshalURLReference(fields map[string]string) error {
+	l.Library = fields["library"]
+	if l.Library == "" {
+		return fmt.Errorf(tr("missing '%s' directive", "library"))
+	}
+	rawURL, ok := fields["url"]
+	if !ok {
+		return fmt.Errorf(tr("missing '%s' directive", "url"))
+	}
+	libURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%s: %w", tr("invalid library URL:"), err)
+	}
+	l.URL = libURL
+	l.Checksum = fields["checksum"]
+	if l.IsGit() {
+		if libURL.Fragment == "" {
+			return fmt.Errorf(tr("the git URL of library %s must specify the revision to checkout, e.g. %s", l.Library, rawURL+"#v1.0.0"))
+		}
+		return nil
+	}
+	if l.Checksum == "" {
+		return fmt.Errorf(tr("missing '%[1]s' directive for library %[2]s", "checksum", l.Library))
+	}
+	if split := strings.SplitN(l.Checksum, ":", 2); len(split) != 2 || split[1] == "" {
+		return fmt.Errorf(tr("invalid checksum for library %[1]s: %[2]s", l.Library, l.Checksum))
+	}
+	return nil
+}
+
+// IsGit returns true if the library is provided by a git repository.
+func (l *ProfileLibraryReference) IsGit() bool {
+	if l.URL == nil {
+		return false
+	}
+	return l.URL.Scheme == "git" || l.URL.Scheme == "ssh" || strings.HasSuffix(l.URL.Path, ".git")
+}
+
 // AsYaml outputs the required library as Yaml
 func (l *ProfileLibraryReference) AsYaml() string {
+	if l.URL != nil {
+		res := fmt.Sprintf("      - library: %s\n", l.Library)
+		res += fmt.Sprintf("        url: %s\n", l.URL)
+		if l.Checksum != "" {
+			res += fmt.Sprintf("        checksum: %s\n", l.Checksum)
+		}
+		return res
+	}
 	res := fmt.Sprintf("      - %s (%s)\n", l.Library, l.Version)
 	return res
 }
 
 func (l *ProfileLibraryReference) String() string {
+	if l.URL != nil {
+		return fmt.Sprintf("%s (%s)", l.Library, l.URL)
+	}
 	return fmt.Sprintf("%s@%s", l.Library, l.Version)
 }
 
 // InternalUniqueIdentifier returns the unique identifier for this object
 func (l *ProfileLibraryReference) InternalUniqueIdentifier() string {
+	if l.URL != nil {
+		// The checksum is part of the identifier so that the library is
+		// downloaded again when the archive changes.
+		h := sha256.Sum256([]byte(l.URL.String() + "|" + l.Checksum))
+		return utils.SanitizeName(fmt.Sprintf("%s_url_%s", l.Library, hex.EncodeToString(h[:])[:16]))
+	}
 	id := l.String()
 	h := sha256.Sum256([]byte(id))
 	res := fmt.Sprintf("%s_%s", id, hex.EncodeToString(h[:])[:16])
@@ -285,11 +419,19 @@ func LoadProjectFile(file *paths.Path) (*Project, error) {
 		return nil, err
 	}
 	return &Project{
-		Profiles:          profiles,
-		DefaultProfile:    raw.DefaultProfile,
-		DefaultFqbn:       raw.DefaultFqbn,
-		DefaultPort:       raw.DefaultPort,
-		DefaultProtocol:   raw.DefaultProtocol,
-		DefaultProgrammer: raw.DefaultProgrammer,
+		Profiles:                 profiles,
+		DefaultProfile:           raw.DefaultProfile,
+		DefaultFqbn:              raw.DefaultFqbn,
+		DefaultPort:              raw.DefaultPort,
+		DefaultProtocol:          raw.DefaultProtocol,
+		DefaultProgrammer:        raw.DefaultProgrammer,
+		DefaultMonitorConfig:     raw.DefaultMonitorConfig,
+		DefaultMonitorLineEnding: raw.DefaultMonitorLineEnding,
+		DefaultMonitorEcho:       raw.DefaultMonitorEcho,
+		MonitorMacros:            raw.MonitorMacros,
+		LibrariesBuildProperties: raw.LibrariesBuildProperties,
+		BuildVariant:             raw.BuildVariant,
+		LinkerScript:             raw.LinkerScript,
+		LinkerScriptFragments:    raw.LinkerScriptFragments,
 	}, nil
 }
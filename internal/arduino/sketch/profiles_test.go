@@ -21,6 +21,7 @@ import (
 
 	"github.com/arduino/go-paths-helper"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestProjectFileLoading(t *testing.T) {
@@ -42,4 +43,47 @@ func TestProjectFileLoading(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, proj.AsYaml(), string(golden))
 	}
+	{
+		sketchProj := paths.New("testdata", "SketchWithLibrariesBuildProperties", "sketch.yml")
+		proj, err := LoadProjectFile(sketchProj)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"ArduinoJson": "-DARDUINOJSON_ENABLE_NAN=1",
+			"FastLED":     "-DFASTLED_INTERNAL -Wno-register",
+		}, proj.LibrariesBuildProperties)
+		golden, err := sketchProj.ReadFile()
+		require.NoError(t, err)
+		require.Equal(t, proj.AsYaml(), string(golden))
+	}
+}
+
+func TestProjectFileLibraryURLs(t *testing.T) {
+	sketchProj := paths.New("testdata", "SketchWithLibraryURLs", "sketch.yml")
+	proj, err := LoadProjectFile(sketchProj)
+	require.NoError(t, err)
+	golden, err := sketchProj.ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, string(golden), proj.AsYaml())
+
+	libs := proj.Profiles[0].Libraries
+	require.Len(t, libs, 3)
+	require.Nil(t, libs[0].URL)
+	require.Equal(t, "PatchedServo", libs[1].Library)
+	require.Nil(t, libs[1].Version)
+	require.False(t, libs[1].IsGit())
+	require.Equal(t, "SHA-256:4e4d9e4b4e0c3c5fa6c2d1a7e3f1b2c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3", libs[1].Checksum)
+	require.True(t, libs[2].IsGit())
+	require.Equal(t, "4f2c1e9", libs[2].URL.Fragment)
+	require.NotEqual(t, libs[1].InternalUniqueIdentifier(), (&ProfileLibraryReference{Library: "PatchedServo", URL: libs[1].URL, Checksum: "SHA-256:00"}).InternalUniqueIdentifier())
+
+	for _, invalid := range []string{
+		"library: Servo",
+		"url: https://example.com/Servo.zip\nchecksum: SHA-256:00",
+		"library: Servo\nurl: https://example.com/Servo.zip",
+		"library: Servo\nurl: https://example.com/Servo.zip\nchecksum: 1234",
+		"library: Servo\nurl: https://github.com/user/Servo.git",
+	} {
+		var lib ProfileLibraryReference
+		require.Error(t, yaml.Unmarshal([]byte(invalid), &lib), invalid)
+	}
 }
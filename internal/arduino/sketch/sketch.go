@@ -20,6 +20,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -252,6 +254,37 @@ func (s *Sketch) GetDefaultProgrammer() string {
 	return s.Project.DefaultProgrammer
 }
 
+// GetDefaultMonitorConfig returns the default monitor port settings for the sketch (from the
+// sketch.yaml project file), or nil if not set.
+func (s *Sketch) GetDefaultMonitorConfig() map[string]string {
+	return s.Project.DefaultMonitorConfig
+}
+
+// GetDefaultMonitorLineEnding returns the default line ending of the data sent by the monitor
+// (from the sketch.yaml project file), or the empty string if not set.
+func (s *Sketch) GetDefaultMonitorLineEnding() string {
+	return s.Project.DefaultMonitorLineEnding
+}
+
+// GetDefaultMonitorEcho returns true if the local echo of the monitor is enabled by default
+// (from the sketch.yaml project file).
+func (s *Sketch) GetDefaultMonitorEcho() bool {
+	return s.Project.DefaultMonitorEcho
+}
+
+// GetLibraryBuildProperties returns the extra compiler flags used to compile the sources of
+// the library with the given name (from the sketch.yaml project file), or the empty string if
+// not set.
+func (s *Sketch) GetLibraryBuildProperties(libraryName string) string {
+	return s.Project.LibrariesBuildProperties[libraryName]
+}
+
+// GetBuildVariant returns the variant used instead of the variant of the board (from the
+// sketch.yaml project file), or the empty string if not set.
+func (s *Sketch) GetBuildVariant() string {
+	return s.Project.BuildVariant
+}
+
 // SetDefaultFQBN sets the default FQBN for the sketch and saves it in the sketch.yaml project file.
 func (s *Sketch) SetDefaultFQBN(fqbn string) error {
 	s.Project.DefaultFqbn = fqbn
@@ -275,6 +308,44 @@ func (s *Sketch) SetDefaultProgrammer(programmer string) error {
 	return updateOrAddYamlRootEntry(s.GetProjectPath(), "default_programmer", programmer)
 }
 
+// SetDefaultMonitorConfig sets the default monitor port settings for the sketch and saves them
+// in the sketch.yaml project file. If config is empty the settings are removed.
+func (s *Sketch) SetDefaultMonitorConfig(config map[string]string) error {
+	s.Project.DefaultMonitorConfig = config
+	return updateOrAddYamlRootMap(s.GetProjectPath(), "default_monitor_config", config)
+}
+
+// SetDefaultMonitorLineEnding sets the default line ending of the data sent by the monitor and
+// saves it in the sketch.yaml project file. If lineEnding is empty the default is removed.
+func (s *Sketch) SetDefaultMonitorLineEnding(lineEnding string) error {
+	s.Project.DefaultMonitorLineEnding = lineEnding
+	return updateOrAddYamlRootEntry(s.GetProjectPath(), "default_monitor_line_ending", lineEnding)
+}
+
+// SetDefaultMonitorEcho enables or disables by default the local echo of the monitor and saves
+// it in the sketch.yaml project file.
+func (s *Sketch) SetDefaultMonitorEcho(echo bool) error {
+	s.Project.DefaultMonitorEcho = echo
+	if !echo {
+		return updateYamlRootLine(s.GetProjectPath(), "default_monitor_echo", "", nil)
+	}
+	return updateYamlRootLine(s.GetProjectPath(), "default_monitor_echo", "true", true)
+}
+
+// AddProfileLibrary adds the given library to the libraries required by the profile
+// and saves it in the sketch.yaml project file.
+func (s *Sketch) AddProfileLibrary(profileName string, library *ProfileLibraryReference) error {
+	profile, err := s.GetProfile(profileName)
+	if err != nil {
+		return err
+	}
+	if err := addYamlProfileLibrary(s.GetProjectPath(), profileName, library); err != nil {
+		return err
+	}
+	profile.Libraries = append(profile.Libraries, library)
+	return nil
+}
+
 // InvalidSketchFolderNameError is returned when the sketch directory doesn't match the sketch name
 type InvalidSketchFolderNameError struct {
 	SketchFolder *paths.Path
@@ -291,6 +362,76 @@ func (s *Sketch) DefaultBuildPath() *paths.Path {
 	return paths.TempDir().Join("arduino", "sketches", s.Hash())
 }
 
+// BuildPath returns the build directory of the sketch for the board with the
+// given FQBN, expanding the placeholders of the build path template:
+//   - {sketch_path}: the directory of the sketch
+//   - {sketch} or {sketch_name}: the name of the sketch
+//   - {hash}: an hash of the sketch path, unique for each sketch
+//   - {fqbn}: the FQBN without the config options, with ':' replaced by '.'
+//   - {temp}: the temporary directory of the system
+//   - {cache}: the cache directory of the user
+//
+// A relative path is relative to the sketch directory. If the template is
+// empty the DefaultBuildPath is returned.
+func (s *Sketch) BuildPath(template string, fqbn string) (*paths.Path, error) {
+	if template == "" {
+		return s.DefaultBuildPath(), nil
+	}
+	var expandErr error
+	replacer := func(placeholder string) string {
+		switch placeholder {
+		case "{sketch_path}":
+			return s.FullPath.String()
+		case "{sketch}", "{sketch_name}":
+			return s.Name
+		case "{hash}":
+			return s.Hash()
+		case "{fqbn}":
+			if fqbn == "" {
+				expandErr = errors.New(tr("the build path template %s requires a FQBN", template))
+			}
+			return strings.ReplaceAll(fqbn, ":", ".")
+		case "{temp}":
+			return paths.TempDir().String()
+		case "{cache}":
+			cacheDir, err := os.UserCacheDir()
+			if err != nil {
+				expandErr = fmt.Errorf(tr("getting the user cache directory: %s"), err)
+			}
+			return cacheDir
+		}
+		expandErr = errors.New(tr("unknown placeholder %[1]s in build path template %[2]s", placeholder, template))
+		return placeholder
+	}
+	expanded := buildPathPlaceholder.ReplaceAllStringFunc(template, replacer)
+	if expandErr != nil {
+		return nil, expandErr
+	}
+	buildPath := paths.New(expanded)
+	if !buildPath.IsAbs() {
+		buildPath = s.FullPath.JoinPath(buildPath)
+	}
+	return buildPath.Clean(), nil
+}
+
+var buildPathPlaceholder = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// VariantBuildPath returns the build directory of the sketch for the board
+// with the given FQBN and build properties. The directory is derived from the
+// build path template, as in BuildPath, with a suffix unique for each board
+// and set of build properties: the compilations of the same sketch for
+// different boards don't overwrite each other and stay incremental.
+func (s *Sketch) VariantBuildPath(template string, fqbn string, buildProperties []string) (*paths.Path, error) {
+	buildPath, err := s.BuildPath(template, fqbn)
+	if err != nil {
+		return nil, err
+	}
+	key := fqbn + "|" + strings.Join(buildProperties, "|")
+	md5SumBytes := md5.Sum([]byte(key))
+	variant := strings.ToUpper(hex.EncodeToString(md5SumBytes[:4]))
+	return buildPath.Parent().Join(buildPath.Base() + "-" + variant), nil
+}
+
 // Hash generate a unique hash for the given sketch.
 func (s *Sketch) Hash() string {
 	path := s.FullPath.String()
@@ -302,16 +443,20 @@ func (s *Sketch) Hash() string {
 func (s *Sketch) ToRpc() *rpc.Sketch {
 	defaultPort, defaultProtocol := s.GetDefaultPortAddressAndProtocol()
 	res := &rpc.Sketch{
-		MainFile:          s.MainFile.String(),
-		LocationPath:      s.FullPath.String(),
-		OtherSketchFiles:  s.OtherSketchFiles.AsStrings(),
-		AdditionalFiles:   s.AdditionalFiles.AsStrings(),
-		RootFolderFiles:   s.RootFolderFiles.AsStrings(),
-		DefaultFqbn:       s.GetDefaultFQBN(),
-		DefaultPort:       defaultPort,
-		DefaultProtocol:   defaultProtocol,
-		DefaultProgrammer: s.GetDefaultProgrammer(),
-		Profiles:          f.Map(s.Project.Profiles, (*Profile).ToRpc),
+		MainFile:                 s.MainFile.String(),
+		LocationPath:             s.FullPath.String(),
+		OtherSketchFiles:         s.OtherSketchFiles.AsStrings(),
+		AdditionalFiles:          s.AdditionalFiles.AsStrings(),
+		RootFolderFiles:          s.RootFolderFiles.AsStrings(),
+		DefaultFqbn:              s.GetDefaultFQBN(),
+		DefaultPort:              defaultPort,
+		DefaultProtocol:          defaultProtocol,
+		DefaultProgrammer:        s.GetDefaultProgrammer(),
+		DefaultMonitorConfig:     s.GetDefaultMonitorConfig(),
+		MonitorMacros:            s.Project.MonitorMacros,
+		DefaultMonitorLineEnding: s.GetDefaultMonitorLineEnding(),
+		DefaultMonitorEcho:       s.GetDefaultMonitorEcho(),
+		Profiles:                 f.Map(s.Project.Profiles, (*Profile).ToRpc),
 	}
 	if defaultProfile, err := s.GetProfile(s.Project.DefaultProfile); err == nil {
 		res.DefaultProfile = defaultProfile.ToRpc()
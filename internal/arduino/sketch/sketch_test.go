@@ -18,6 +18,7 @@ package sketch
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -291,6 +292,63 @@ func TestGenBuildPath(t *testing.T) {
 	assert.Equal(t, "ACBD18DB4CC2F85CEDEF654FCCC4A4D8", (&Sketch{FullPath: paths.New("foo")}).Hash())
 }
 
+func TestBuildPath(t *testing.T) {
+	sketchPath, _ := paths.New("testdata", "SketchSimple").Abs()
+	sk := &Sketch{Name: "SketchSimple", FullPath: sketchPath}
+
+	buildPath, err := sk.BuildPath("", "arduino:avr:uno")
+	require.NoError(t, err)
+	require.Equal(t, sk.DefaultBuildPath(), buildPath)
+
+	buildPath, err = sk.BuildPath("{sketch_path}/build/{fqbn}", "arduino:avr:uno")
+	require.NoError(t, err)
+	require.Equal(t, sketchPath.Join("build", "arduino.avr.uno").String(), buildPath.String())
+
+	buildPath, err = sk.BuildPath("out/{sketch}-{hash}", "")
+	require.NoError(t, err)
+	require.Equal(t, sketchPath.Join("out", "SketchSimple-"+sk.Hash()).String(), buildPath.String())
+
+	buildPath, err = sk.BuildPath("{temp}/{sketch_name}", "")
+	require.NoError(t, err)
+	require.Equal(t, paths.TempDir().Join("SketchSimple").String(), buildPath.String())
+
+	_, err = sk.BuildPath("{temp}/{fqbn}", "")
+	require.Error(t, err)
+
+	_, err = sk.BuildPath("{temp}/{unknown}", "arduino:avr:uno")
+	require.Error(t, err)
+}
+
+func TestVariantBuildPath(t *testing.T) {
+	sketchPath, _ := paths.New("testdata", "SketchSimple").Abs()
+	sk := &Sketch{Name: "SketchSimple", FullPath: sketchPath}
+
+	uno, err := sk.VariantBuildPath("", "arduino:avr:uno", nil)
+	require.NoError(t, err)
+	require.Equal(t, sk.DefaultBuildPath().Parent().String(), uno.Parent().String())
+	require.True(t, strings.HasPrefix(uno.Base(), sk.Hash()+"-"))
+
+	again, err := sk.VariantBuildPath("", "arduino:avr:uno", nil)
+	require.NoError(t, err)
+	require.Equal(t, uno.String(), again.String())
+
+	mega, err := sk.VariantBuildPath("", "arduino:avr:mega", nil)
+	require.NoError(t, err)
+	require.NotEqual(t, uno.String(), mega.String())
+
+	withProps, err := sk.VariantBuildPath("", "arduino:avr:uno", []string{"build.extra_flags=-DDEBUG"})
+	require.NoError(t, err)
+	require.NotEqual(t, uno.String(), withProps.String())
+
+	inSketch, err := sk.VariantBuildPath("{sketch_path}/build/{fqbn}", "arduino:avr:uno", nil)
+	require.NoError(t, err)
+	require.Equal(t, sketchPath.Join("build").String(), inSketch.Parent().String())
+	require.True(t, strings.HasPrefix(inSketch.Base(), "arduino.avr.uno-"))
+
+	_, err = sk.VariantBuildPath("{temp}/{unknown}", "arduino:avr:uno", nil)
+	require.Error(t, err)
+}
+
 func TestNewSketchWithSymlink(t *testing.T) {
 	sketchPath, _ := paths.New("testdata", "SketchWithSymlink").Abs()
 	mainFilePath := sketchPath.Join("SketchWithSymlink.ino")
@@ -17,6 +17,8 @@ package sketch
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/arduino/go-paths-helper"
@@ -26,6 +28,58 @@ import (
 // updateOrAddYamlRootEntry updates or adds a new entry to the root of the yaml file.
 // If the value is empty the entry is removed.
 func updateOrAddYamlRootEntry(path *paths.Path, key, newValue string) error {
+	v, err := yaml.Marshal(newValue)
+	if err != nil {
+		return err
+	}
+	var expected interface{}
+	if newValue != "" {
+		expected = newValue
+	}
+	return updateYamlRootLine(path, key, strings.TrimSpace(string(v)), expected)
+}
+
+// updateOrAddYamlRootMap updates or adds a new map entry to the root of the yaml file,
+// the map is written in flow style to fit in a single line. If the map is empty the
+// entry is removed.
+func updateOrAddYamlRootMap(path *paths.Path, key string, newValue map[string]string) error {
+	if len(newValue) == 0 {
+		return updateYamlRootLine(path, key, "", nil)
+	}
+	v, err := marshalFlowMap(newValue)
+	if err != nil {
+		return err
+	}
+	expected := map[string]interface{}{}
+	for k, v := range newValue {
+		expected[k] = v
+	}
+	return updateYamlRootLine(path, key, v, expected)
+}
+
+// marshalFlowMap returns the given map as a single line yaml flow mapping, sorted by key.
+func marshalFlowMap(m map[string]string) (string, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	node := &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+	for _, k := range keys {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: m[k]})
+	}
+	v, err := yaml.Marshal(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(v)), nil
+}
+
+// updateYamlRootLine replaces the line of the given root key with "key: value", or
+// removes it if expected is nil. The written value is checked against expected.
+func updateYamlRootLine(path *paths.Path, key, value string, expected interface{}) error {
 	var srcYaml []string
 	if path.Exist() {
 		src, err := path.ReadFileAsLines()
@@ -40,18 +94,12 @@ func updateOrAddYamlRootEntry(path *paths.Path, key, newValue string) error {
 		}
 	}
 
-	// Generate the new yaml key/value pair
-	v, err := yaml.Marshal(newValue)
-	if err != nil {
-		return err
-	}
-	updatedLine := key + ": " + strings.TrimSpace(string(v))
-
 	// Update or add the key/value pair into the original yaml
-	addMissing := (newValue != "")
+	updatedLine := key + ": " + value
+	addMissing := (expected != nil)
 	for i, line := range srcYaml {
 		if strings.HasPrefix(line, key+": ") {
-			if newValue == "" {
+			if expected == nil {
 				// Remove the key/value pair
 				srcYaml = append(srcYaml[:i], srcYaml[i+1:]...)
 			} else {
@@ -82,10 +130,190 @@ func updateOrAddYamlRootEntry(path *paths.Path, key, newValue string) error {
 		return fmt.Errorf(tr("could not update sketch project file"))
 	}
 	writtenValue, notRemoved := dstMap[key]
-	if (newValue == "" && notRemoved) || (newValue != "" && newValue != writtenValue) {
+	if (expected == nil && notRemoved) || (expected != nil && !reflect.DeepEqual(expected, writtenValue)) {
 		return fmt.Errorf(tr("could not update sketch project file"))
 	}
 
 	// Write back the updated YAML
 	return path.WriteFile(dstYaml)
 }
+
+// addYamlProfileLibrary appends the given library to the libraries of the given profile
+// in the yaml file. The file is edited line by line to preserve the original formatting.
+func addYamlProfileLibrary(path *paths.Path, profileName string, library *ProfileLibraryReference) error {
+	srcYaml, err := path.ReadFileAsLines()
+	if err != nil {
+		return err
+	}
+	if last := len(srcYaml) - 1; last > 0 && srcYaml[last] == "" {
+		srcYaml = srcYaml[:last]
+	}
+	fail := fmt.Errorf(tr("could not update sketch project file"))
+
+	// Find the profile block
+	profilesStart := -1
+	for i, line := range srcYaml {
+		if key, value, isKey := yamlKeyLine(line); isKey && yamlIndent(line) == 0 && key == "profiles" && value == "" {
+			profilesStart = i
+			break
+		}
+	}
+	if profilesStart == -1 {
+		return fail
+	}
+	profileStart, profileEnd := yamlBlock(srcYaml, profilesStart, func(line string) bool {
+		key, _, isKey := yamlKeyLine(line)
+		return isKey && key == profileName
+	})
+	if profileStart == -1 {
+		return fail
+	}
+
+	// Find the libraries of the profile and add the new entry
+	childIndent := -1
+	for _, line := range srcYaml[profileStart+1 : profileEnd] {
+		if !yamlIsBlankOrComment(line) {
+			childIndent = yamlIndent(line)
+			break
+		}
+	}
+	if childIndent == -1 {
+		childIndent = yamlIndent(srcYaml[profileStart]) + 2
+	}
+	entry := fmt.Sprintf("- %s (%s)", library.Library, library.Version)
+	var newLines []string
+	insertAt := -1
+	for i := profileStart + 1; i < profileEnd; i++ {
+		key, value, isKey := yamlKeyLine(srcYaml[i])
+		if !isKey || key != "libraries" || yamlIndent(srcYaml[i]) != childIndent {
+			continue
+		}
+		if value == "[]" {
+			srcYaml[i] = strings.Repeat(" ", childIndent) + "libraries:"
+		} else if value != "" {
+			return fail
+		}
+		insertAt = i + 1
+		itemIndent := childIndent + 2
+		for j := i + 1; j < profileEnd; j++ {
+			line := srcYaml[j]
+			if yamlIsBlankOrComment(line) {
+				continue
+			}
+			indent := yamlIndent(line)
+			isItem := strings.HasPrefix(strings.TrimSpace(line), "- ")
+			if indent < childIndent || (indent == childIndent && !isItem) {
+				break
+			}
+			if isItem {
+				itemIndent = indent
+			}
+			insertAt = j + 1
+		}
+		newLines = []string{strings.Repeat(" ", itemIndent) + entry}
+		break
+	}
+	if insertAt == -1 {
+		insertAt = profileStart + 1
+		for i := profileStart + 1; i < profileEnd; i++ {
+			if !yamlIsBlankOrComment(srcYaml[i]) {
+				insertAt = i + 1
+			}
+		}
+		newLines = []string{
+			strings.Repeat(" ", childIndent) + "libraries:",
+			strings.Repeat(" ", childIndent+2) + entry,
+		}
+	}
+	srcYaml = append(srcYaml[:insertAt], append(newLines, srcYaml[insertAt:]...)...)
+
+	// Validate the new yaml
+	dstYaml := []byte(strings.Join(srcYaml, fmt.Sprintln()) + fmt.Sprintln())
+	raw := &projectRaw{}
+	if err := yaml.Unmarshal(dstYaml, &raw); err != nil {
+		return fmt.Errorf("%s: %w", tr("could not update sketch project file"), err)
+	}
+	profiles, err := raw.getProfiles()
+	if err != nil {
+		return fmt.Errorf("%s: %w", tr("could not update sketch project file"), err)
+	}
+	found := false
+	for _, profile := range profiles {
+		if profile.Name != profileName {
+			continue
+		}
+		for _, lib := range profile.Libraries {
+			if lib.Library == library.Library && lib.Version != nil && lib.Version.Equal(library.Version) {
+				found = true
+			}
+		}
+	}
+	if !found {
+		return fail
+	}
+
+	// Write back the updated YAML
+	return path.WriteFile(dstYaml)
+}
+
+// yamlBlock returns the start and end lines of the first child of the block starting at
+// the given line that matches the given filter. The start line is -1 if no child matches.
+func yamlBlock(lines []string, parent int, filter func(line string) bool) (int, int) {
+	parentIndent := yamlIndent(lines[parent])
+	start := -1
+	childIndent := -1
+	for i := parent + 1; i < len(lines); i++ {
+		line := lines[i]
+		if yamlIsBlankOrComment(line) {
+			continue
+		}
+		indent := yamlIndent(line)
+		if indent <= parentIndent {
+			if start != -1 {
+				return start, i
+			}
+			break
+		}
+		if childIndent == -1 {
+			childIndent = indent
+		}
+		if indent != childIndent {
+			continue
+		}
+		if start != -1 {
+			return start, i
+		}
+		if filter(line) {
+			start = i
+		}
+	}
+	if start != -1 {
+		return start, len(lines)
+	}
+	return -1, -1
+}
+
+// yamlKeyLine splits a "key: value" yaml line, trailing comments are removed from the value.
+func yamlKeyLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	key, value, ok = strings.Cut(line, ":")
+	if !ok || (value != "" && value[0] != ' ') {
+		return "", "", false
+	}
+	if comment := strings.Index(value, " #"); comment != -1 {
+		value = value[:comment]
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+func yamlIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func yamlIsBlankOrComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "" || strings.HasPrefix(line, "#")
+}
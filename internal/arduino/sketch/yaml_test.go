@@ -22,6 +22,7 @@ import (
 
 	"github.com/arduino/go-paths-helper"
 	"github.com/stretchr/testify/require"
+	semver "go.bug.st/relaxed-semver"
 )
 
 func TestYamlUpdate(t *testing.T) {
@@ -76,3 +77,81 @@ func TestYamlUpdate(t *testing.T) {
 		require.Equal(t, expected, string(updated))
 	}
 }
+
+func TestYamlUpdateMap(t *testing.T) {
+	tmp, err := paths.WriteToTempFile([]byte("default_fqbn: arduino:avr:uno\n"), nil, "")
+	require.NoError(t, err)
+	defer tmp.Remove()
+
+	err = updateOrAddYamlRootMap(tmp, "default_monitor_config", map[string]string{"baudrate": "115200", "parity": "none"})
+	require.NoError(t, err)
+	updated, err := tmp.ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "default_fqbn: arduino:avr:uno\ndefault_monitor_config: {baudrate: \"115200\", parity: none}\n", string(updated))
+
+	err = updateOrAddYamlRootMap(tmp, "default_monitor_config", map[string]string{"baudrate": "9600"})
+	require.NoError(t, err)
+	updated, err = tmp.ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "default_fqbn: arduino:avr:uno\ndefault_monitor_config: {baudrate: \"9600\"}\n", string(updated))
+
+	err = updateOrAddYamlRootMap(tmp, "default_monitor_config", nil)
+	require.NoError(t, err)
+	updated, err = tmp.ReadFile()
+	require.NoError(t, err)
+	require.Equal(t, "default_fqbn: arduino:avr:uno\n", string(updated))
+}
+
+func TestYamlAddProfileLibrary(t *testing.T) {
+	lib := &ProfileLibraryReference{Library: "Servo", Version: semver.MustParse("1.2.1")}
+	{
+		sample, err := paths.New("testdata", "SketchWithProfiles", "sketch.yml").ReadFile()
+		require.NoError(t, err)
+		tmp, err := paths.WriteToTempFile(sample, nil, "")
+		require.NoError(t, err)
+		defer tmp.Remove()
+
+		require.NoError(t, addYamlProfileLibrary(tmp, "tiny", lib))
+		updated, err := tmp.ReadFile()
+		require.NoError(t, err)
+		expected := strings.Replace(string(sample),
+			"      - TinyDHT sensor library (1.1.0)\n\n  feather:",
+			"      - TinyDHT sensor library (1.1.0)\n      - Servo (1.2.1)\n\n  feather:", 1)
+		require.Equal(t, expected, string(updated))
+
+		require.Error(t, addYamlProfileLibrary(tmp, "missing", lib))
+	}
+	{
+		sample := "profiles:\n" +
+			"  uno:\n" +
+			"    fqbn: arduino:avr:uno\n" +
+			"    platforms:\n" +
+			"      - platform: arduino:avr (1.8.6)\n" +
+			"\n" +
+			"  nano:\n" +
+			"    fqbn: arduino:avr:nano\n" +
+			"    libraries: []\n" +
+			"default_profile: uno\n"
+		tmp, err := paths.WriteToTempFile([]byte(sample), nil, "")
+		require.NoError(t, err)
+		defer tmp.Remove()
+
+		require.NoError(t, addYamlProfileLibrary(tmp, "uno", lib))
+		require.NoError(t, addYamlProfileLibrary(tmp, "nano", lib))
+		updated, err := tmp.ReadFile()
+		require.NoError(t, err)
+		require.Equal(t, "profiles:\n"+
+			"  uno:\n"+
+			"    fqbn: arduino:avr:uno\n"+
+			"    platforms:\n"+
+			"      - platform: arduino:avr (1.8.6)\n"+
+			"    libraries:\n"+
+			"      - Servo (1.2.1)\n"+
+			"\n"+
+			"  nano:\n"+
+			"    fqbn: arduino:avr:nano\n"+
+			"    libraries:\n"+
+			"      - Servo (1.2.1)\n"+
+			"default_profile: uno\n", string(updated))
+	}
+}
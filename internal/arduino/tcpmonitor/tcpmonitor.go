@@ -0,0 +1,358 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package tcpmonitor implements a pluggable monitor that connects to boards
+// exposing a raw TCP or telnet console, like the ESP32 remote debug or the
+// Yún console. The connection is automatically re-established if the board
+// drops it, for example while rebooting.
+package tcpmonitor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	monitor "github.com/arduino/pluggable-monitor-protocol-handler"
+)
+
+// Protocol is the protocol handled by the TCP monitor
+const Protocol = "tcp"
+
+// DefaultPort is the TCP port used when the address does not specify one
+const DefaultPort = "23"
+
+// dialTimeout is the timeout of each connection attempt
+var dialTimeout = 5 * time.Second
+
+// maxReconnectDelay is the maximum delay between two reconnection attempts
+var maxReconnectDelay = 5 * time.Second
+
+// Monitor is a pluggable monitor that connects to a TCP port
+type Monitor struct {
+	mux      sync.Mutex
+	settings *monitor.PortDescriptor
+	opened   *tcpPort
+}
+
+// NewMonitor creates a new TCP Monitor
+func NewMonitor() *Monitor {
+	return &Monitor{
+		settings: &monitor.PortDescriptor{
+			Protocol: Protocol,
+			ConfigurationParameter: map[string]*monitor.PortParameterDescriptor{
+				"reconnect": {
+					Label:    "Reconnect when the connection is lost",
+					Type:     "enum",
+					Values:   []string{"on", "off"},
+					Selected: "on",
+				},
+				"telnet": {
+					Label:    "Telnet protocol",
+					Type:     "enum",
+					Values:   []string{"auto", "on", "off"},
+					Selected: "auto",
+				},
+			},
+		},
+	}
+}
+
+// Hello does nothing, it's here to implement the monitor.Monitor interface
+func (m *Monitor) Hello(userAgent string, protocolVersion int) error {
+	return nil
+}
+
+// Describe returns the settings of the TCP port
+func (m *Monitor) Describe() (*monitor.PortDescriptor, error) {
+	return m.settings, nil
+}
+
+// Configure sets a setting of the TCP port. The `reconnect` setting is
+// applied immediately, the `telnet` setting on the next Open.
+func (m *Monitor) Configure(parameterName string, value string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	param := m.settings.ConfigurationParameter[parameterName]
+	if param == nil {
+		return fmt.Errorf("could not find parameter named %s", parameterName)
+	}
+	for _, v := range param.Values {
+		if v == value {
+			param.Selected = value
+			if m.opened != nil && parameterName == "reconnect" {
+				m.opened.setReconnect(value == "on")
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value for parameter %s: %s", parameterName, value)
+}
+
+// Open connects to the given address, in the form `host[:port]`. If the port
+// is omitted the telnet port is used.
+func (m *Monitor) Open(boardPort string) (io.ReadWriter, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.opened != nil {
+		return nil, fmt.Errorf("port already opened: %s", boardPort)
+	}
+	address, err := NormalizeAddress(boardPort)
+	if err != nil {
+		return nil, err
+	}
+	_, port, _ := net.SplitHostPort(address)
+	telnet := false
+	switch m.settings.ConfigurationParameter["telnet"].Selected {
+	case "auto":
+		telnet = port == DefaultPort
+	case "on":
+		telnet = true
+	}
+	reconnect := m.settings.ConfigurationParameter["reconnect"].Selected == "on"
+
+	p := newTCPPort(address, reconnect, telnet)
+	// The first connection must succeed, otherwise the address is likely wrong
+	if _, err := p.connection(false); err != nil {
+		return nil, err
+	}
+	m.opened = p
+	return p, nil
+}
+
+// Close closes the connection to the TCP port
+func (m *Monitor) Close() error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.opened == nil {
+		return errors.New("port already closed")
+	}
+	m.opened.Close()
+	m.opened = nil
+	return nil
+}
+
+// Quit does nothing, it's here to implement the monitor.Monitor interface
+func (m *Monitor) Quit() {}
+
+// NormalizeAddress returns the given address in the `host:port` form, adding
+// the default port if missing.
+func NormalizeAddress(address string) (string, error) {
+	if address == "" {
+		return "", errors.New("missing address")
+	}
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address, nil
+	}
+	// The port is missing, IPv6 addresses may be enclosed in brackets
+	host := address
+	if len(host) > 1 && host[0] == '[' && host[len(host)-1] == ']' {
+		host = host[1 : len(host)-1]
+	}
+	address = net.JoinHostPort(host, DefaultPort)
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return "", fmt.Errorf("invalid address: %s", address)
+	}
+	return address, nil
+}
+
+// tcpPort is an io.ReadWriter connected to a TCP port that, if reconnect is
+// enabled, transparently re-establishes the connection when it is lost.
+type tcpPort struct {
+	address string
+	telnet  bool
+
+	mux       sync.Mutex
+	conn      net.Conn
+	reconnect bool
+	filter    *telnetFilter
+	closed    chan struct{}
+
+	// dialMux serializes the connection attempts of readers and writers
+	dialMux sync.Mutex
+}
+
+func newTCPPort(address string, reconnect, telnet bool) *tcpPort {
+	return &tcpPort{
+		address:   address,
+		telnet:    telnet,
+		reconnect: reconnect,
+		closed:    make(chan struct{}),
+	}
+}
+
+func (p *tcpPort) setReconnect(reconnect bool) {
+	p.mux.Lock()
+	p.reconnect = reconnect
+	p.mux.Unlock()
+}
+
+func (p *tcpPort) isClosed() bool {
+	select {
+	case <-p.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// connection returns the current connection. If the connection has been lost
+// a new one is established, if retry is true the connection attempts are
+// repeated, with an increasing delay, until successful or the port is closed.
+func (p *tcpPort) connection(retry bool) (net.Conn, error) {
+	p.dialMux.Lock()
+	defer p.dialMux.Unlock()
+
+	delay := 100 * time.Millisecond
+	for {
+		p.mux.Lock()
+		conn := p.conn
+		p.mux.Unlock()
+		if p.isClosed() {
+			return nil, io.EOF
+		}
+		if conn != nil {
+			return conn, nil
+		}
+
+		conn, err := net.DialTimeout("tcp", p.address, dialTimeout)
+		if err == nil {
+			p.mux.Lock()
+			p.conn = conn
+			if p.telnet {
+				p.filter = &telnetFilter{}
+			}
+			p.mux.Unlock()
+			// The port may have been closed while dialing
+			if p.isClosed() {
+				conn.Close()
+				return nil, io.EOF
+			}
+			return conn, nil
+		}
+		if !retry {
+			return nil, err
+		}
+
+		select {
+		case <-p.closed:
+			return nil, io.EOF
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// drop closes the given connection after an error. It returns the error to
+// be reported to the caller, or nil if the connection should be re-established.
+func (p *tcpPort) drop(conn net.Conn, err error) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	conn.Close()
+	if p.conn == conn {
+		p.conn = nil
+	}
+	if !p.reconnect {
+		return err
+	}
+	return nil
+}
+
+func (p *tcpPort) Read(buf []byte) (int, error) {
+	for {
+		conn, err := p.connection(true)
+		if err != nil {
+			return 0, err
+		}
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if n = p.filterTelnet(conn, buf[:n]); n > 0 {
+				return n, nil
+			}
+		}
+		if err != nil {
+			if p.isClosed() {
+				return 0, io.EOF
+			}
+			if err := p.drop(conn, err); err != nil {
+				return 0, err
+			}
+		}
+	}
+}
+
+// filterTelnet removes the telnet commands from the data received, answering
+// to the option negotiations. It returns the length of the remaining data.
+func (p *tcpPort) filterTelnet(conn net.Conn, data []byte) int {
+	p.mux.Lock()
+	filter := p.filter
+	p.mux.Unlock()
+	if filter == nil {
+		return len(data)
+	}
+	n, reply := filter.process(data)
+	if len(reply) > 0 {
+		conn.Write(reply)
+	}
+	return n
+}
+
+func (p *tcpPort) Write(buf []byte) (int, error) {
+	data := buf
+	if p.telnet {
+		data = escapeTelnet(buf)
+	}
+	for {
+		conn, err := p.connection(true)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := conn.Write(data); err != nil {
+			if err := p.drop(conn, err); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		return len(buf), nil
+	}
+}
+
+func (p *tcpPort) Close() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if p.isClosed() {
+		return
+	}
+	close(p.closed)
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+}
+
+// MonitorCommandLine returns the command line to run the TCP monitor, it is
+// served by the hidden `tcp-monitor` command of the running executable.
+func MonitorCommandLine() ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return []string{exe, "tcp-monitor"}, nil
+}
@@ -0,0 +1,141 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tcpmonitor
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	for _, test := range [][2]string{
+		{"192.168.1.42", "192.168.1.42:23"},
+		{"192.168.1.42:2323", "192.168.1.42:2323"},
+		{"esp32.local", "esp32.local:23"},
+		{"[fe80::1]", "[fe80::1]:23"},
+		{"[fe80::1]:8888", "[fe80::1]:8888"},
+	} {
+		res, err := NormalizeAddress(test[0])
+		require.NoError(t, err)
+		require.Equal(t, test[1], res)
+	}
+	_, err := NormalizeAddress("")
+	require.Error(t, err)
+}
+
+func TestTCPMonitorReconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	// The board sends a greeting and drops the first connection, on the
+	// second connection it sends a greeting and echoes back the data received.
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("boot"))
+		conn.Close()
+
+		conn, err = l.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("ready"))
+		buf := make([]byte, 10)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			conn.Write(buf[:n])
+		}
+	}()
+
+	m := NewMonitor()
+	require.Error(t, m.Configure("reconnect", "maybe"))
+	require.Error(t, m.Configure("parity", "none"))
+	port, err := m.Open(l.Addr().String())
+	require.NoError(t, err)
+	_, err = m.Open(l.Addr().String())
+	require.Error(t, err)
+
+	buf := make([]byte, 10)
+	n, err := port.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "boot", string(buf[:n]))
+	// The next read happens on a new connection
+	n, err = port.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "ready", string(buf[:n]))
+
+	_, err = port.Write([]byte("hello"))
+	require.NoError(t, err)
+	read := ""
+	for len(read) < 5 {
+		n, err = port.Read(buf)
+		require.NoError(t, err)
+		read += string(buf[:n])
+	}
+	require.Equal(t, "hello", read)
+
+	require.NoError(t, m.Close())
+	_, err = port.Read(buf)
+	require.ErrorIs(t, err, io.EOF)
+	require.Error(t, m.Close())
+}
+
+func TestTCPMonitorNoReconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		conn.Close()
+		l.Close()
+	}()
+
+	m := NewMonitor()
+	require.NoError(t, m.Configure("reconnect", "off"))
+	port, err := m.Open(l.Addr().String())
+	require.NoError(t, err)
+	_, err = port.Read(make([]byte, 10))
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, m.Close())
+}
+
+func TestTelnetFilter(t *testing.T) {
+	f := &telnetFilter{}
+	data := []byte{'a', telnetIAC, telnetDO, 1, 'b', telnetIAC, telnetIAC, telnetIAC}
+	n, reply := f.process(data)
+	require.Equal(t, []byte{'a', 'b', telnetIAC}, data[:n])
+	require.Equal(t, []byte{telnetIAC, telnetWONT, 1}, reply)
+
+	// The command started in the previous chunk is completed here
+	data = []byte{telnetWILL, 3, telnetIAC, telnetSB, 31, 0, 80, telnetIAC, telnetSE, 'c'}
+	n, reply = f.process(data)
+	require.Equal(t, []byte{'c'}, data[:n])
+	require.Equal(t, []byte{telnetIAC, telnetDONT, 3}, reply)
+
+	require.Equal(t, []byte("abc"), escapeTelnet([]byte("abc")))
+	require.Equal(t, []byte{'a', telnetIAC, telnetIAC}, escapeTelnet([]byte{'a', telnetIAC}))
+}
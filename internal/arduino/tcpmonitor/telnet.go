@@ -0,0 +1,110 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tcpmonitor
+
+import "bytes"
+
+// Telnet commands, see RFC 854
+const (
+	telnetSE   = 240
+	telnetSB   = 250
+	telnetWILL = 251
+	telnetWONT = 252
+	telnetDO   = 253
+	telnetDONT = 254
+	telnetIAC  = 255
+)
+
+type telnetState int
+
+const (
+	telnetData telnetState = iota
+	telnetCommand
+	telnetOption
+	telnetSubnegotiation
+	telnetSubnegotiationCommand
+)
+
+// telnetFilter strips the telnet commands from the received data. All the
+// options requested by the server are refused, so the connection behaves
+// like a raw TCP stream. The state is kept between calls since a command
+// may be split across multiple reads.
+type telnetFilter struct {
+	state   telnetState
+	command byte
+}
+
+// process removes the telnet commands from data, in place, and returns the
+// length of the remaining data and the answers to send to the server.
+func (f *telnetFilter) process(data []byte) (int, []byte) {
+	n := 0
+	var reply []byte
+	for _, b := range data {
+		switch f.state {
+		case telnetData:
+			if b == telnetIAC {
+				f.state = telnetCommand
+				continue
+			}
+			data[n] = b
+			n++
+		case telnetCommand:
+			switch b {
+			case telnetIAC:
+				// Escaped 0xFF data byte
+				data[n] = b
+				n++
+				f.state = telnetData
+			case telnetWILL, telnetWONT, telnetDO, telnetDONT:
+				f.command = b
+				f.state = telnetOption
+			case telnetSB:
+				f.state = telnetSubnegotiation
+			default:
+				// Commands without options (NOP, GA, ...) are ignored
+				f.state = telnetData
+			}
+		case telnetOption:
+			switch f.command {
+			case telnetWILL:
+				reply = append(reply, telnetIAC, telnetDONT, b)
+			case telnetDO:
+				reply = append(reply, telnetIAC, telnetWONT, b)
+			}
+			f.state = telnetData
+		case telnetSubnegotiation:
+			if b == telnetIAC {
+				f.state = telnetSubnegotiationCommand
+			}
+		case telnetSubnegotiationCommand:
+			if b == telnetSE {
+				f.state = telnetData
+			} else {
+				f.state = telnetSubnegotiation
+			}
+		}
+	}
+	return n, reply
+}
+
+// escapeTelnet doubles the IAC bytes in data, as required to send them as
+// plain data to a telnet server.
+func escapeTelnet(data []byte) []byte {
+	if bytes.IndexByte(data, telnetIAC) == -1 {
+		return data
+	}
+	return bytes.ReplaceAll(data, []byte{telnetIAC}, []byte{telnetIAC, telnetIAC})
+}
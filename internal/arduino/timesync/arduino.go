@@ -0,0 +1,105 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package timesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// arduinoProtocol is a line based protocol. The host sends
+//
+//	@TIME <unix seconds> <zone offset in seconds>\n
+//
+// and the board answers with
+//
+//	@TIME OK <unix seconds>\n
+//
+// or with "@TIME ERR <message>\n" if it can't set the time. The request is
+// sent again every second until the board answers, since the board may be
+// resetting when the port is opened.
+type arduinoProtocol struct{}
+
+var arduinoAnswerRegexp = regexp.MustCompile(`@TIME (OK (\d+)|ERR ?([^\r\n]*))\r?\n`)
+
+// arduinoResendInterval is the time waited for an answer before sending the
+// time again.
+var arduinoResendInterval = time.Second
+
+// Description implements Protocol
+func (p *arduinoProtocol) Description() string {
+	return tr("Sends \"@TIME <unix time> <zone offset>\" and waits for \"@TIME OK <unix time>\" from the board.")
+}
+
+// SetTime implements Protocol
+func (p *arduinoProtocol) SetTime(ctx context.Context, port io.ReadWriter, t time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	data := readFrom(ctx, port)
+
+	start := time.Now()
+	_, offset := t.Zone()
+	var sent int64
+	send := func() error {
+		sent = t.Add(time.Since(start)).Unix()
+		_, err := fmt.Fprintf(port, "@TIME %d %d\n", sent, offset)
+		return err
+	}
+	if err := send(); err != nil {
+		return err
+	}
+	resend := time.NewTicker(arduinoResendInterval)
+	defer resend.Stop()
+
+	received := &bytes.Buffer{}
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf(tr("the board did not confirm the time, received %q"), received.String())
+		case <-resend.C:
+			if err := send(); err != nil {
+				return err
+			}
+		case d, ok := <-data:
+			if !ok {
+				return errors.New(tr("the port has been closed before the board confirmed the time"))
+			}
+			received.Write(d)
+			answer := arduinoAnswerRegexp.FindSubmatch(received.Bytes())
+			if answer == nil {
+				continue
+			}
+			if answer[2] == nil {
+				return fmt.Errorf(tr("the board could not set the time: %s"), answer[3])
+			}
+			confirmed, err := strconv.ParseInt(string(answer[2]), 10, 64)
+			if err != nil {
+				return err
+			}
+			// Allow the answer to a previous request to be received
+			if delta := sent - confirmed; delta < 0 || delta > int64(time.Since(start)/time.Second)+1 {
+				return fmt.Errorf(tr("the board confirmed a different time: sent %[1]d, received %[2]d"), sent, confirmed)
+			}
+			return nil
+		}
+	}
+}
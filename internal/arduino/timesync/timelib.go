@@ -0,0 +1,63 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package timesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// timeLibProtocol is the protocol of the TimeSerial example of the Time
+// library: the board requests the time sending the BEL character and the host
+// answers with "T" followed by the seconds since 1970 in local time.
+type timeLibProtocol struct{}
+
+const timeLibRequest = 0x07
+
+// Description implements Protocol
+func (p *timeLibProtocol) Description() string {
+	return tr("Waits for the time request (BEL) of the TimeSerial example of the Time library and answers with \"T<local time>\".")
+}
+
+// SetTime implements Protocol
+func (p *timeLibProtocol) SetTime(ctx context.Context, port io.ReadWriter, t time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	data := readFrom(ctx, port)
+
+	start := time.Now()
+	received := &bytes.Buffer{}
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf(tr("the board did not request the time, received %q"), received.String())
+		case d, ok := <-data:
+			if !ok {
+				return errors.New(tr("the port has been closed before the board requested the time"))
+			}
+			received.Write(d)
+			if bytes.IndexByte(d, timeLibRequest) == -1 {
+				continue
+			}
+			_, err := fmt.Fprintf(port, "T%d", wallClock(t.Add(time.Since(start))))
+			return err
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package timesync sends the time of the host to a board connected through a
+// monitor port, for example to set the RTC of a data logger.
+//
+// The protocols used to send the time are pluggable: a Protocol is registered
+// with a name using Register and selected by the user with that name.
+package timesync
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+)
+
+var tr = i18n.Tr
+
+// Protocol sends the time to a board.
+type Protocol interface {
+	// Description returns a short description of the protocol.
+	Description() string
+	// SetTime sends t to the board connected to port and waits for the board
+	// to confirm it, if the protocol has a confirmation, until ctx is done.
+	SetTime(ctx context.Context, port io.ReadWriter, t time.Time) error
+}
+
+var (
+	protocolsMux sync.Mutex
+	protocols    = map[string]Protocol{}
+)
+
+// DefaultProtocol is the name of the protocol used if none is specified
+const DefaultProtocol = "arduino"
+
+func init() {
+	Register(DefaultProtocol, &arduinoProtocol{})
+	Register("timelib", &timeLibProtocol{})
+}
+
+// Register makes a Protocol available with the given name, replacing the
+// protocol previously registered with the same name.
+func Register(name string, protocol Protocol) {
+	protocolsMux.Lock()
+	defer protocolsMux.Unlock()
+	protocols[name] = protocol
+}
+
+// Find returns the Protocol registered with the given name.
+func Find(name string) (Protocol, error) {
+	protocolsMux.Lock()
+	defer protocolsMux.Unlock()
+	if protocol, ok := protocols[name]; ok {
+		return protocol, nil
+	}
+	return nil, errors.New(tr("unknown time synchronization protocol: %s", name))
+}
+
+// Names returns the sorted names of the registered protocols.
+func Names() []string {
+	protocolsMux.Lock()
+	defer protocolsMux.Unlock()
+	names := make([]string, 0, len(protocols))
+	for name := range protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// wallClock returns the seconds elapsed since 1970-01-01 00:00:00 in the
+// location of t, that is the Unix time shifted by the zone offset.
+func wallClock(t time.Time) int64 {
+	_, offset := t.Zone()
+	return t.Unix() + int64(offset)
+}
+
+// readFrom reads the port in background and sends the data on the returned
+// channel, that is closed when the port returns an error or ctx is done.
+func readFrom(ctx context.Context, port io.Reader) <-chan []byte {
+	data := make(chan []byte)
+	go func() {
+		defer close(data)
+		buf := make([]byte, 256)
+		for {
+			n, err := port.Read(buf)
+			if n > 0 {
+				select {
+				case data <- append([]byte(nil), buf[:n]...):
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return data
+}
@@ -0,0 +1,114 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package timesync
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	require.Equal(t, []string{"arduino", "timelib"}, Names())
+	_, err := Find(DefaultProtocol)
+	require.NoError(t, err)
+	_, err = Find("unknown")
+	require.Error(t, err)
+}
+
+func TestArduinoProtocol(t *testing.T) {
+	host, board := net.Pipe()
+	defer host.Close()
+	defer board.Close()
+
+	now := time.Unix(1700000000, 0).In(time.FixedZone("CET", 3600))
+	requests := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(board).ReadString('\n')
+		requests <- line
+		var secs, offset int64
+		fmt.Sscanf(line, "@TIME %d %d\n", &secs, &offset)
+		fmt.Fprintf(board, "booting\r\n@TIME OK %d\r\n", secs)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	protocol, err := Find("arduino")
+	require.NoError(t, err)
+	require.NoError(t, protocol.SetTime(ctx, host, now))
+	require.Regexp(t, `^@TIME 170000000\d 3600\n$`, <-requests)
+}
+
+func TestArduinoProtocolError(t *testing.T) {
+	host, board := net.Pipe()
+	defer host.Close()
+	defer board.Close()
+
+	go func() {
+		bufio.NewReader(board).ReadString('\n')
+		fmt.Fprintf(board, "@TIME ERR RTC not found\n")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := (&arduinoProtocol{}).SetTime(ctx, host, time.Now())
+	require.EqualError(t, err, "the board could not set the time: RTC not found")
+}
+
+func TestArduinoProtocolTimeout(t *testing.T) {
+	host, board := net.Pipe()
+	defer host.Close()
+	defer board.Close()
+
+	go func() {
+		r := bufio.NewReader(board)
+		for {
+			if _, err := r.ReadString('\n'); err != nil {
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := (&arduinoProtocol{}).SetTime(ctx, host, time.Now())
+	require.EqualError(t, err, `the board did not confirm the time, received ""`)
+}
+
+func TestTimeLibProtocol(t *testing.T) {
+	host, board := net.Pipe()
+	defer host.Close()
+	defer board.Close()
+
+	now := time.Unix(1700000000, 0).In(time.FixedZone("CET", 3600))
+	answers := make(chan string, 1)
+	go func() {
+		board.Write([]byte("Waiting for sync message\r\n\x07"))
+		buf := make([]byte, 32)
+		n, _ := board.Read(buf)
+		answers <- string(buf[:n])
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, (&timeLibProtocol{}).SetTime(ctx, host, now))
+	require.Regexp(t, `^T170000360\d$`, <-answers)
+}
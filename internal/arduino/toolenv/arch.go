@@ -0,0 +1,185 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package toolenv
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/i18n"
+)
+
+var tr = i18n.Tr
+
+// binaryFormat is the operating system and the architectures, in the GOOS
+// and GOARCH notation, of an executable. A macOS universal binary has more
+// than one architecture.
+type binaryFormat struct {
+	os    string
+	archs []string
+}
+
+func (f *binaryFormat) String() string {
+	return f.os + "/" + strings.Join(f.archs, "+")
+}
+
+var elfArchs = map[elf.Machine]string{
+	elf.EM_X86_64:  "amd64",
+	elf.EM_386:     "386",
+	elf.EM_AARCH64: "arm64",
+	elf.EM_ARM:     "arm",
+	elf.EM_RISCV:   "riscv64",
+}
+
+var machoArchs = map[macho.Cpu]string{
+	macho.CpuAmd64: "amd64",
+	macho.Cpu386:   "386",
+	macho.CpuArm64: "arm64",
+	macho.CpuArm:   "arm",
+}
+
+var peArchs = map[uint16]string{
+	pe.IMAGE_FILE_MACHINE_AMD64: "amd64",
+	pe.IMAGE_FILE_MACHINE_I386:  "386",
+	pe.IMAGE_FILE_MACHINE_ARM64: "arm64",
+	pe.IMAGE_FILE_MACHINE_ARMNT: "arm",
+}
+
+// readBinaryFormat returns the format of the executable, or nil if it's not
+// a known binary format (for example a script).
+func readBinaryFormat(path string) *binaryFormat {
+	if f, err := elf.Open(path); err == nil {
+		defer f.Close()
+		osName := "linux"
+		if f.OSABI == elf.ELFOSABI_FREEBSD {
+			osName = "freebsd"
+		}
+		return &binaryFormat{os: osName, archs: []string{archName(elfArchs[f.Machine], f.Machine)}}
+	}
+	if f, err := macho.Open(path); err == nil {
+		defer f.Close()
+		return &binaryFormat{os: "darwin", archs: []string{archName(machoArchs[f.Cpu], f.Cpu)}}
+	}
+	if f, err := macho.OpenFat(path); err == nil {
+		defer f.Close()
+		res := &binaryFormat{os: "darwin"}
+		for _, a := range f.Arches {
+			res.archs = append(res.archs, archName(machoArchs[a.Cpu], a.Cpu))
+		}
+		return res
+	}
+	if f, err := pe.Open(path); err == nil {
+		defer f.Close()
+		return &binaryFormat{os: "windows", archs: []string{archName(peArchs[f.Machine], f.Machine)}}
+	}
+	return nil
+}
+
+func archName(name string, machine interface{}) string {
+	if name == "" {
+		return fmt.Sprint(machine)
+	}
+	return name
+}
+
+// emulatedArchs are the architectures that can run on a host through an
+// emulation layer of the operating system
+var emulatedArchs = map[string][]string{
+	"darwin/arm64":  {"amd64"},
+	"darwin/amd64":  {"386"},
+	"windows/arm64": {"amd64", "386"},
+	"windows/amd64": {"386"},
+	"linux/amd64":   {"386"},
+	"linux/arm64":   {"arm"},
+}
+
+// rosettaInstalled returns true if Rosetta, needed to run the Intel binaries
+// on Apple silicon, is installed.
+var rosettaInstalled = sync.OnceValue(func() bool {
+	_, err := os.Stat("/Library/Apple/usr/share/rosetta/rosetta")
+	return err == nil
+})
+
+// checkCompatibility checks if a binary with the given format can run on
+// the host, the returned error advises how to run it if possible.
+func checkCompatibility(tool string, format *binaryFormat, hostOS, hostArch string) error {
+	if format == nil {
+		return nil
+	}
+	incompatible := &cmderrors.IncompatibleToolError{Tool: tool, Arch: format.String(), Host: hostOS + "/" + hostArch}
+	if format.os != hostOS {
+		incompatible.Advice = tr("Install the version of the platform for your operating system")
+		return incompatible
+	}
+	for _, arch := range format.archs {
+		if arch == hostArch {
+			return nil
+		}
+	}
+	for _, arch := range format.archs {
+		for _, emulated := range emulatedArchs[hostOS+"/"+hostArch] {
+			if arch != emulated {
+				continue
+			}
+			if hostOS == "darwin" && hostArch == "arm64" && !rosettaInstalled() {
+				incompatible.Advice = tr("Install Rosetta to run it, with the command: %s", "softwareupdate --install-rosetta --agree-to-license")
+				return incompatible
+			}
+			return nil
+		}
+	}
+	incompatible.Advice = tr("Contact the maintainer of the platform to get a version for your architecture")
+	return incompatible
+}
+
+type binaryCheck struct {
+	size    int64
+	modTime int64
+	err     error
+}
+
+var binaryChecks sync.Map
+
+// CheckExecutable checks if the executable, looked up in the PATH if needed,
+// can run on the current operating system and architecture. The executables
+// that can't be found or are not binaries are not checked, their execution
+// reports the error.
+func CheckExecutable(executable string) error {
+	path, err := exec.LookPath(executable)
+	if err != nil {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if c, ok := binaryChecks.Load(path); ok {
+		if check := c.(*binaryCheck); check.size == info.Size() && check.modTime == info.ModTime().UnixNano() {
+			return check.err
+		}
+	}
+	err = checkCompatibility(path, readBinaryFormat(path), runtime.GOOS, runtime.GOARCH)
+	binaryChecks.Store(path, &binaryCheck{size: info.Size(), modTime: info.ModTime().UnixNano(), err: err})
+	return err
+}
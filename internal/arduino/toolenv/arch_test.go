@@ -0,0 +1,65 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package toolenv
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBinaryFormat(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	format := readBinaryFormat(executable)
+	require.NotNil(t, format)
+	require.Equal(t, runtime.GOOS, format.os)
+	require.Equal(t, []string{runtime.GOARCH}, format.archs)
+	require.NoError(t, CheckExecutable(executable))
+
+	// Scripts are not checked
+	require.Nil(t, readBinaryFormat("testdata/script.sh"))
+	require.NoError(t, CheckExecutable("testdata/script.sh"))
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	var incompatible *cmderrors.IncompatibleToolError
+	linuxArm64 := &binaryFormat{os: "linux", archs: []string{"arm64"}}
+	require.NoError(t, checkCompatibility("avrdude", linuxArm64, "linux", "arm64"))
+	require.ErrorAs(t, checkCompatibility("avrdude", linuxArm64, "linux", "amd64"), &incompatible)
+	require.Equal(t, "linux/arm64", incompatible.Arch)
+	require.Equal(t, "linux/amd64", incompatible.Host)
+	require.ErrorAs(t, checkCompatibility("avrdude", linuxArm64, "darwin", "arm64"), &incompatible)
+
+	// The 32 bit binaries run through the emulation of the operating system
+	require.NoError(t, checkCompatibility("avrdude", &binaryFormat{os: "windows", archs: []string{"386"}}, "windows", "arm64"))
+	require.NoError(t, checkCompatibility("avrdude", &binaryFormat{os: "linux", archs: []string{"arm"}}, "linux", "arm64"))
+
+	// Universal binaries run if any of the architectures is supported
+	require.NoError(t, checkCompatibility("avrdude", &binaryFormat{os: "darwin", archs: []string{"amd64", "arm64"}}, "darwin", "arm64"))
+
+	// The Intel binaries need Rosetta on Apple silicon
+	defer func(f func() bool) { rosettaInstalled = f }(rosettaInstalled)
+	intel := &binaryFormat{os: "darwin", archs: []string{"amd64"}}
+	rosettaInstalled = func() bool { return true }
+	require.NoError(t, checkCompatibility("avrdude", intel, "darwin", "arm64"))
+	rosettaInstalled = func() bool { return false }
+	require.ErrorAs(t, checkCompatibility("avrdude", intel, "darwin", "arm64"), &incompatible)
+	require.Contains(t, incompatible.Error(), "softwareupdate --install-rosetta")
+}
@@ -0,0 +1,251 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package toolenv builds the environment of the external tools (compilers,
+// uploaders, monitors, debuggers...) run by the CLI, so that the recipes of
+// the platforms behave the same regardless of the environment of the user.
+package toolenv
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/go-paths-helper"
+	"github.com/arduino/go-properties-orderedmap"
+)
+
+// Config is the configuration of the environment of the tools
+type Config struct {
+	// Clean starts from an environment containing only the variables needed
+	// by the tools to run, instead of the environment of the CLI
+	Clean bool
+	// Path are the directories added in front of the PATH
+	Path []string
+	// Vars are the variables, in the `KEY=value` format, set for all the tools
+	Vars []string
+	// Overrides are the variables set for specific tools, indexed by the name
+	// of the executable (without extension)
+	Overrides map[string][]string
+	// Properties are the build properties exported to the tools, see
+	// PropertiesEnv
+	Properties []string
+	// NoNetwork asks the tools not to access the network
+	NoNetwork bool
+}
+
+// DefaultProperties are the build properties always exported to the tools
+var DefaultProperties = []string{
+	"build.fqbn",
+	"build.arch",
+	"build.mcu",
+	"build.path",
+	"build.project_name",
+	"runtime.platform.path",
+	"runtime.ide.version",
+}
+
+// cleanEnvVars are the variables kept in a clean environment
+var cleanEnvVars = []string{
+	"HOME", "USER", "USERNAME", "LOGNAME", "LANG", "LC_ALL", "LC_CTYPE", "TERM",
+	"TMPDIR", "TEMP", "TMP", "XDG_RUNTIME_DIR",
+	"SYSTEMROOT", "SYSTEMDRIVE", "WINDIR", "COMSPEC", "PATHEXT", "USERPROFILE", "APPDATA", "LOCALAPPDATA", "PROGRAMDATA",
+}
+
+// noNetworkVars are set to route the connections of the tools to an
+// unreachable proxy, the tools may check ARDUINO_NO_NETWORK directly
+var noNetworkVars = []string{
+	"ARDUINO_NO_NETWORK=1",
+	"http_proxy=http://127.0.0.1:9",
+	"https_proxy=http://127.0.0.1:9",
+	"all_proxy=http://127.0.0.1:9",
+	"HTTP_PROXY=http://127.0.0.1:9",
+	"HTTPS_PROXY=http://127.0.0.1:9",
+	"ALL_PROXY=http://127.0.0.1:9",
+	"no_proxy=",
+	"NO_PROXY=",
+}
+
+// FromSettings returns the configuration set in the `tools.env` settings.
+func FromSettings() (*Config, error) {
+	settings := configuration.Settings
+	if settings == nil {
+		return &Config{}, nil
+	}
+	vars, err := configuration.ToolsEnvVars(settings)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := configuration.ToolsEnvOverrides(settings)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{
+		Clean:      settings.GetBool("tools.env.clean"),
+		Path:       settings.GetStringSlice("tools.env.path"),
+		Vars:       vars,
+		Overrides:  overrides,
+		Properties: settings.GetStringSlice("tools.env.properties"),
+		NoNetwork:  settings.GetBool("tools.env.no_network"),
+	}, nil
+}
+
+// NewProcess creates the process of an external tool with the environment
+// configured in the settings. The args[0] is the executable, extraEnv are
+// the variables set by the caller, they are overridden by the settings.
+func NewProcess(extraEnv []string, args ...string) (*paths.Process, error) {
+	config, err := FromSettings()
+	if err != nil {
+		return nil, err
+	}
+	return config.NewProcess(extraEnv, args...)
+}
+
+// NewProcess creates the process of an external tool with the environment
+// built by Environ. The executable is checked with CheckExecutable.
+func (c *Config) NewProcess(extraEnv []string, args ...string) (*paths.Process, error) {
+	proc, err := paths.NewProcess(nil, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckExecutable(args[0]); err != nil {
+		return nil, err
+	}
+	proc.SetEnvironment(c.Environ(os.Environ(), ToolName(args[0]), extraEnv))
+	return proc, nil
+}
+
+// Environ returns the environment of the given tool: the base environment,
+// filtered if Clean is set, followed by extraEnv, the variables set for all
+// the tools and the ones set for the tool. The PATH is changed accordingly.
+func (c *Config) Environ(base []string, tool string, extraEnv []string) []string {
+	env := []string{}
+	pathValue := ""
+	for _, v := range base {
+		name, value, _ := strings.Cut(v, "=")
+		if isPathVar(name) {
+			pathValue = value
+			continue
+		}
+		if c.Clean && !isCleanVar(name) {
+			continue
+		}
+		env = append(env, v)
+	}
+	if c.Clean {
+		pathValue = systemPath()
+	}
+	pathList := append([]string{}, c.Path...)
+	if pathValue != "" {
+		pathList = append(pathList, pathValue)
+	}
+	if len(pathList) > 0 {
+		env = append(env, "PATH="+strings.Join(pathList, string(os.PathListSeparator)))
+	}
+	env = append(env, extraEnv...)
+	if c.NoNetwork {
+		env = append(env, noNetworkVars...)
+	}
+	env = append(env, c.Vars...)
+	env = append(env, c.Overrides[tool]...)
+	return dedupEnv(env)
+}
+
+// PropertiesEnv returns the build properties exported to the tools: each
+// property `a.b_c` is exported as `ARDUINO_A_B_C`. The DefaultProperties and
+// the ones in the configuration are exported, if defined.
+func (c *Config) PropertiesEnv(props *properties.Map) []string {
+	env := []string{}
+	for _, key := range append(append([]string{}, DefaultProperties...), c.Properties...) {
+		if value, ok := props.GetOk(key); ok && value != "" {
+			env = append(env, PropertyEnvName(key)+"="+value)
+		}
+	}
+	return env
+}
+
+// PropertiesEnv returns the build properties exported to the tools by the
+// configuration in the settings.
+func PropertiesEnv(props *properties.Map) []string {
+	config, err := FromSettings()
+	if err != nil {
+		config = &Config{}
+	}
+	return config.PropertiesEnv(props)
+}
+
+// PropertyEnvName returns the name of the variable used to export a property
+func PropertyEnvName(key string) string {
+	return "ARDUINO_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// ToolName returns the name of the tool run by the given executable, used to
+// select the overrides of the configuration.
+func ToolName(executable string) string {
+	name := filepath.Base(executable)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	}
+	return name
+}
+
+func isPathVar(name string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(name, "PATH")
+	}
+	return name == "PATH"
+}
+
+func isCleanVar(name string) bool {
+	for _, v := range cleanEnvVars {
+		if name == v || (runtime.GOOS == "windows" && strings.EqualFold(name, v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// systemPath returns the directories of the system executables
+func systemPath() string {
+	if runtime.GOOS == "windows" {
+		root := os.Getenv("SystemRoot")
+		if root == "" {
+			root = `C:\Windows`
+		}
+		return strings.Join([]string{filepath.Join(root, "System32"), root}, string(os.PathListSeparator))
+	}
+	return "/usr/local/bin:/usr/bin:/bin:/usr/sbin:/sbin"
+}
+
+// dedupEnv removes the duplicated variables, the last value wins
+func dedupEnv(env []string) []string {
+	index := map[string]int{}
+	res := []string{}
+	for _, v := range env {
+		name, _, _ := strings.Cut(v, "=")
+		if runtime.GOOS == "windows" {
+			name = strings.ToUpper(name)
+		}
+		if i, ok := index[name]; ok {
+			res[i] = v
+			continue
+		}
+		index[name] = len(res)
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,78 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package toolenv
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnviron(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	base := []string{"HOME=/home/user", "PATH=/usr/bin" + sep + "/opt/bin", "GCC_EXEC_PREFIX=/opt/gcc", "LANG=C"}
+
+	// The default configuration keeps the environment of the CLI
+	env := (&Config{}).Environ(base, "avrdude", []string{"ARDUINO_USER_AGENT=test"})
+	require.Equal(t, []string{"HOME=/home/user", "GCC_EXEC_PREFIX=/opt/gcc", "LANG=C", "PATH=/usr/bin" + sep + "/opt/bin", "ARDUINO_USER_AGENT=test"}, env)
+
+	config := &Config{
+		Path:      []string{"/tools/bin"},
+		Vars:      []string{"LANG=en_US.UTF-8", "TZ=UTC"},
+		Overrides: map[string][]string{"avrdude": {"TZ=Europe/Rome"}},
+	}
+	env = config.Environ(base, "avrdude", nil)
+	require.Contains(t, env, "PATH=/tools/bin"+sep+"/usr/bin"+sep+"/opt/bin")
+	require.Contains(t, env, "LANG=en_US.UTF-8")
+	require.Contains(t, env, "TZ=Europe/Rome")
+	require.NotContains(t, env, "LANG=C")
+	require.NotContains(t, env, "TZ=UTC")
+	require.Contains(t, config.Environ(base, "bossac", nil), "TZ=UTC")
+
+	// A clean environment keeps only the variables needed to run the tools
+	config = &Config{Clean: true, NoNetwork: true}
+	env = config.Environ(base, "avrdude", []string{"ARDUINO_USER_AGENT=test"})
+	require.Contains(t, env, "HOME=/home/user")
+	require.Contains(t, env, "LANG=C")
+	require.Contains(t, env, "ARDUINO_USER_AGENT=test")
+	require.Contains(t, env, "ARDUINO_NO_NETWORK=1")
+	require.Contains(t, env, "PATH="+systemPath())
+	for _, v := range env {
+		require.False(t, strings.HasPrefix(v, "GCC_EXEC_PREFIX="), v)
+	}
+}
+
+func TestPropertiesEnv(t *testing.T) {
+	props := properties.NewFromHashmap(map[string]string{
+		"build.fqbn":  "arduino:avr:uno",
+		"build.mcu":   "atmega328p",
+		"build.f_cpu": "16000000L",
+		"build.path":  "",
+	})
+	require.Equal(t, []string{"ARDUINO_BUILD_FQBN=arduino:avr:uno", "ARDUINO_BUILD_MCU=atmega328p"}, (&Config{}).PropertiesEnv(props))
+	require.Contains(t, (&Config{Properties: []string{"build.f_cpu"}}).PropertiesEnv(props), "ARDUINO_BUILD_F_CPU=16000000L")
+}
+
+func TestToolName(t *testing.T) {
+	require.Equal(t, "arm-none-eabi-g++", ToolName("/opt/tools/bin/arm-none-eabi-g++"))
+	if runtime.GOOS == "windows" {
+		require.Equal(t, "avrdude", ToolName(`C:\tools\avrdude.exe`))
+	}
+}
@@ -79,15 +79,28 @@ func (bc *BuildCache) GetOrCreate(key string) (*paths.Path, error) {
 // To know how long ago a directory has been last used
 // it checks into the .last-used file.
 func (bc *BuildCache) Purge(ttl time.Duration) {
+	for _, dir := range bc.Expired(ttl) {
+		logrus.Tracef(`Purging cache directory "%s"`, dir)
+		if err := dir.RemoveAll(); err != nil {
+			logrus.Tracef(`Error while pruning cache directory "%s": %s`, dir, err)
+		}
+	}
+}
+
+// Expired returns the cache directories within baseDir that have not been
+// used for longer than ttl.
+func (bc *BuildCache) Expired(ttl time.Duration) paths.PathList {
+	res := paths.PathList{}
 	files, err := bc.baseDir.ReadDir()
 	if err != nil {
-		return
+		return res
 	}
 	for _, file := range files {
-		if file.IsDir() {
-			removeIfExpired(file, ttl)
+		if file.IsDir() && isExpired(file, ttl) {
+			res.Add(file)
 		}
 	}
+	return res
 }
 
 // New instantiates a build cache
@@ -95,18 +108,15 @@ func New(baseDir *paths.Path) *BuildCache {
 	return &BuildCache{baseDir}
 }
 
-func removeIfExpired(dir *paths.Path, ttl time.Duration) {
+func isExpired(dir *paths.Path, ttl time.Duration) bool {
 	fileInfo, err := dir.Join(lastUsedFileName).Stat()
 	if err != nil {
-		return
+		return false
 	}
 	lifeExpectancy := ttl - time.Since(fileInfo.ModTime())
 	if lifeExpectancy > 0 {
-		return
-	}
-	logrus.Tracef(`Purging cache directory "%s". Expired by %s`, dir, lifeExpectancy.Abs())
-	err = dir.RemoveAll()
-	if err != nil {
-		logrus.Tracef(`Error while pruning cache directory "%s": %s`, dir, err)
+		return false
 	}
+	logrus.Tracef(`Cache directory "%s" expired by %s`, dir, lifeExpectancy.Abs())
+	return true
 }
@@ -71,6 +71,9 @@ func TestPurge(t *testing.T) {
 		require.NoError(t, infoFilePath.Chtimes(accesstime, lastUsedTime))
 	}
 
+	require.Equal(t, paths.PathList{dirToPurge.Join("old")}, New(dirToPurge).Expired(ttl))
+	require.True(t, dirToPurge.Join("old").Exist(), "Expired must not remove anything")
+
 	New(dirToPurge).Purge(ttl)
 
 	require.False(t, dirToPurge.Join("old").Exist())
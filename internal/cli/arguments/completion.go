@@ -17,11 +17,13 @@ package arguments
 
 import (
 	"context"
+	"sort"
 
 	"github.com/arduino/arduino-cli/commands/board"
 	"github.com/arduino/arduino-cli/commands/core"
 	"github.com/arduino/arduino-cli/commands/lib"
 	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/cli/instance"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 )
@@ -45,6 +47,17 @@ func GetInstalledBoards() []string {
 	return res
 }
 
+// GetBoardAliases is an helper function useful to autocomplete.
+// It returns a list of the board aliases defined in the configuration
+func GetBoardAliases() []string {
+	var res []string
+	for name, alias := range configuration.BoardAliases(configuration.Settings) {
+		res = append(res, name+"\t"+alias.FQBN)
+	}
+	sort.Strings(res)
+	return res
+}
+
 // GetInstalledProgrammers is an helper function useful to autocomplete.
 // It returns a list of programmers available based on the installed boards
 func GetInstalledProgrammers() []string {
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/spf13/cobra"
@@ -34,23 +35,46 @@ type Fqbn struct {
 
 // AddToCommand adds the flags used to set fqbn to the specified Command
 func (f *Fqbn) AddToCommand(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&f.fqbn, "fqbn", "b", "", tr("Fully Qualified Board Name, e.g.: arduino:avr:uno"))
+	cmd.Flags().StringVarP(&f.fqbn, "fqbn", "b", "", tr("Fully Qualified Board Name or board alias, e.g.: arduino:avr:uno"))
 	cmd.RegisterFlagCompletionFunc("fqbn", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return GetInstalledBoards(), cobra.ShellCompDirectiveDefault
+		return append(GetInstalledBoards(), GetBoardAliases()...), cobra.ShellCompDirectiveDefault
 	})
 	cmd.Flags().StringSliceVar(&f.boardOptions, "board-options", []string{},
 		tr("List of board options separated by commas. Or can be used multiple times for multiple options."))
 }
 
-// String returns the fqbn with the board options if there are any
+// String returns the fqbn with the board options if there are any.
+// If the fqbn is a board alias the fqbn of the alias is returned.
 func (f *Fqbn) String() string {
+	fqbn := f.fqbn
+	if alias := f.Alias(); alias != nil {
+		fqbn = alias.FQBN
+	}
 	// If boardOptions are passed with the "--board-options" flags then add them along with the fqbn
 	// This way it's possible to use either the legacy way (appending board options directly to the fqbn),
 	// or the new and more elegant way (using "--board-options"), even using multiple "--board-options" works.
-	if f.fqbn != "" && len(f.boardOptions) != 0 {
-		return f.fqbn + ":" + strings.Join(f.boardOptions, ",")
+	if fqbn != "" && len(f.boardOptions) != 0 {
+		return fqbn + ":" + strings.Join(f.boardOptions, ",")
+	}
+	return fqbn
+}
+
+// Alias returns the board alias given in place of the fqbn, or nil if the
+// fqbn is not an alias.
+func (f *Fqbn) Alias() *configuration.BoardAlias {
+	if strings.Contains(f.fqbn, ":") {
+		return nil
 	}
-	return f.fqbn
+	return configuration.GetBoardAlias(configuration.Settings, f.fqbn)
+}
+
+// Programmer returns the programmer of the board alias given in place of the
+// fqbn, if any.
+func (f *Fqbn) Programmer() string {
+	if alias := f.Alias(); alias != nil {
+		return alias.Programmer
+	}
+	return ""
 }
 
 // Set sets the fqbn
@@ -58,6 +82,16 @@ func (f *Fqbn) Set(fqbn string) {
 	f.fqbn = fqbn
 }
 
+// DefaultPort returns the board alias given in place of the fqbn, if it has a
+// serial number, to be used as default port address: the alias is resolved
+// to the port the board is connected to.
+func (f *Fqbn) DefaultPort() string {
+	if alias := f.Alias(); alias != nil && alias.SerialNumber != "" {
+		return f.fqbn
+	}
+	return ""
+}
+
 // CalculateFQBNAndPort calculate the FQBN and Port metadata based on
 // parameters provided by the user.
 // This determine the FQBN based on:
@@ -65,6 +99,8 @@ func (f *Fqbn) Set(fqbn string) {
 // - the default FQBN value in sketch.yaml (`default_fqbn` key) if available, otherwise
 // - it tries to autodetect the board connected to the given port flags
 // If all above methods fails, it returns the empty string.
+// If the FQBN flag is a board alias and no port flag is given, the board with
+// the serial number of the alias is used in place of the default port.
 // The Port metadata are always returned except if:
 //   - the port is not found, in this case nil is returned
 //   - the FQBN autodetection fail, in this case the function prints an error and
@@ -85,6 +121,9 @@ func CalculateFQBNAndPort(portArgs *Port, fqbnArg *Fqbn, instance *rpc.Instance,
 		return fqbn, port
 	}
 
+	if aliasPort := fqbnArg.DefaultPort(); aliasPort != "" {
+		defaultAddress, defaultProtocol = aliasPort, ""
+	}
 	port, err := portArgs.GetPort(instance, defaultAddress, defaultProtocol)
 	if err != nil {
 		feedback.Fatal(tr("Error getting port metadata: %v", err), feedback.ErrGeneric)
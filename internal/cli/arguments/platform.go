@@ -0,0 +1,129 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package arguments
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/core"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/arduino-cli/version"
+	"github.com/sirupsen/logrus"
+)
+
+// knownPlatformIndexURLs maps the packager of some well known third party
+// platforms to the URL of the package index providing them.
+var knownPlatformIndexURLs = map[string]string{
+	"adafruit":           "https://adafruit.github.io/arduino-board-index/package_adafruit_index.json",
+	"ATTinyCore":         "http://drazzy.com/package_drazzy.com_index.json",
+	"attiny":             "https://raw.githubusercontent.com/damellis/attiny/ide-1.6.x-boards-manager/package_damellis_attiny_index.json",
+	"esp32":              "https://espressif.github.io/arduino-esp32/package_esp32_index.json",
+	"esp8266":            "https://arduino.esp8266.com/stable/package_esp8266com_index.json",
+	"MiniCore":           "https://mcudude.github.io/MiniCore/package_MCUdude_MiniCore_index.json",
+	"rp2040":             "https://github.com/earlephilhower/arduino-pico/releases/download/global/package_rp2040_index.json",
+	"Seeeduino":          "https://files.seeedstudio.com/arduino/package_seeeduino_boards_index.json",
+	"SparkFun":           "https://raw.githubusercontent.com/sparkfun/Arduino_Boards/main/IDE_Board_Manager/package_sparkfun_index.json",
+	"STMicroelectronics": "https://github.com/stm32duino/BoardManagerFiles/raw/main/package_stmicroelectronics_index.json",
+}
+
+// InstallMissingPlatform checks if the platform of the given FQBN is installed.
+// If the platform is not installed, but it's available in the package index, the
+// user is asked to install it. If assumeYes is true the platform is installed
+// without asking for confirmation. After the installation the instance is
+// initialized again.
+func InstallMissingPlatform(inst *rpc.Instance, fqbn string, assumeYes bool) {
+	parsedFQBN, err := cores.ParseFQBN(fqbn)
+	if err != nil {
+		return
+	}
+	platformID := parsedFQBN.Package + ":" + parsedFQBN.PlatformArch
+	platform := findPlatform(inst, platformID)
+	if platform == nil || platform.GetInstalledVersion() != "" || platform.GetLatestVersion() == "" {
+		return
+	}
+
+	if !assumeYes {
+		if !feedback.IsInteractive() {
+			logrus.Infof("Not running from console, will not install the missing platform %s", platformID)
+			return
+		}
+		answer, err := feedback.InputUserField(tr("Platform %s is not installed. Install it now? [y/N]", platformID), false)
+		if err != nil {
+			return
+		}
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			return
+		}
+	}
+
+	scriptFlags := PrePostScriptsFlags{}
+	_, err = core.PlatformInstall(context.Background(), &rpc.PlatformInstallRequest{
+		Instance:        inst,
+		PlatformPackage: parsedFQBN.Package,
+		Architecture:    parsedFQBN.PlatformArch,
+		SkipPostInstall: scriptFlags.DetectSkipPostInstallValue(),
+	}, feedback.ProgressBar(), feedback.TaskProgress())
+	if err != nil {
+		feedback.Fatal(tr("Error during install: %v", err), feedback.ErrGeneric)
+	}
+	instance.Init(inst)
+}
+
+// PlatformNotFoundHint returns a suggestion on how to install the given platform.
+func PlatformNotFoundHint(inst *rpc.Instance, platformID string) string {
+	platform, err := core.PlatformSearch(&rpc.PlatformSearchRequest{
+		Instance:   inst,
+		SearchArgs: platformID,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	if len(platform.GetSearchOutput()) > 0 {
+		return tr("Try running %s", fmt.Sprintf("`%s core install %s`", version.VersionInfo.Application, platformID))
+	}
+	hint := tr("Platform %s is not found in any known index\nMaybe you need to add a 3rd party URL?", platformID)
+	packager, _, _ := strings.Cut(platformID, ":")
+	if indexURL, ok := knownPlatformIndexURLs[packager]; ok {
+		hint += "\n" + tr("The platform is provided by the package index %[1]s, try running %[2]s and %[3]s",
+			indexURL,
+			fmt.Sprintf("`%s config add board_manager.additional_urls %s`", version.VersionInfo.Application, indexURL),
+			fmt.Sprintf("`%s core install %s`", version.VersionInfo.Application, platformID))
+	}
+	return hint
+}
+
+// findPlatform returns the platform with the given ID from the package index, or
+// nil if not found.
+func findPlatform(inst *rpc.Instance, platformID string) *rpc.PlatformSummary {
+	res, err := core.PlatformSearch(&rpc.PlatformSearchRequest{
+		Instance:   inst,
+		SearchArgs: platformID,
+	})
+	if err != nil {
+		return nil
+	}
+	for _, platform := range res.GetSearchOutput() {
+		if strings.EqualFold(platform.GetMetadata().GetId(), platformID) {
+			return platform
+		}
+	}
+	return nil
+}
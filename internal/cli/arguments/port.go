@@ -18,11 +18,13 @@ package arguments
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/arduino/arduino-cli/commands/board"
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	f "github.com/arduino/arduino-cli/internal/algorithms"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/sirupsen/logrus"
@@ -40,7 +42,7 @@ type Port struct {
 
 // AddToCommand adds the flags used to set port and protocol to the specified Command
 func (p *Port) AddToCommand(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&p.address, "port", "p", "", tr("Upload port address, e.g.: COM3 or /dev/ttyACM2"))
+	cmd.Flags().StringVarP(&p.address, "port", "p", "", tr("Upload port address, board serial number or board alias, e.g.: COM3 or /dev/ttyACM2"))
 	cmd.RegisterFlagCompletionFunc("port", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return f.Map(GetAvailablePorts(), (*rpc.Port).GetAddress), cobra.ShellCompDirectiveDefault
 	})
@@ -55,9 +57,10 @@ func (p *Port) AddToCommand(cmd *cobra.Command) {
 // without any other port metadata obtained from the discoveries.
 // This method allows will bypass the discoveries if:
 // - a nil instance is passed: in this case the plain port and protocol arguments are returned (even if empty)
-// - a protocol is specified: in this case the discoveries are not needed to autodetect the protocol.
+// - a protocol is specified: in this case the discoveries are not needed to autodetect the protocol,
+// unless the port is a board alias that must be resolved to the current port.
 func (p *Port) GetPortAddressAndProtocol(instance *rpc.Instance, defaultAddress, defaultProtocol string) (string, string, error) {
-	if p.protocol != "" || instance == nil {
+	if instance == nil || (p.protocol != "" && configuration.GetBoardAlias(configuration.Settings, p.address) == nil) {
 		return p.address, p.protocol, nil
 	}
 
@@ -70,6 +73,8 @@ func (p *Port) GetPortAddressAndProtocol(instance *rpc.Instance, defaultAddress,
 
 // GetPort returns the Port obtained by parsing command line arguments.
 // The extra metadata for the ports is obtained using the pluggable discoveries.
+// The address may also be the USB serial number of the board, or a board
+// alias, in this case it's resolved to the port the board is connected to.
 func (p *Port) GetPort(instance *rpc.Instance, defaultAddress, defaultProtocol string) (*rpc.Port, error) {
 
 	address := p.address
@@ -104,12 +109,15 @@ func (p *Port) GetPort(instance *rpc.Instance, defaultAddress, defaultProtocol s
 				continue
 			}
 			port := portEvent.GetPort().GetPort()
-			if (protocol == "" || protocol == port.GetProtocol()) && address == port.GetAddress() {
+			if portMatches(port, address, protocol) {
 				return port, nil
 			}
 
 		case <-deadline:
 			// No matching port found
+			if alias := configuration.GetBoardAlias(configuration.Settings, address); alias != nil {
+				return nil, fmt.Errorf(tr("board %[1]s not found, no port with serial number %[2]s"), address, alias.SerialNumber)
+			}
 			if protocol == "" {
 				return &rpc.Port{
 					Address:  address,
@@ -139,10 +147,7 @@ func (p *Port) DetectFQBN(inst *rpc.Instance) (string, *rpc.Port) {
 	}
 	for _, detectedPort := range detectedPorts {
 		port := detectedPort.GetPort()
-		if p.address != port.GetAddress() {
-			continue
-		}
-		if p.protocol != "" && p.protocol != port.GetProtocol() {
+		if !portMatches(port, p.address, p.protocol) {
 			continue
 		}
 		if len(detectedPort.GetMatchingBoards()) > 1 {
@@ -156,6 +161,26 @@ func (p *Port) DetectFQBN(inst *rpc.Instance) (string, *rpc.Port) {
 	return "", nil
 }
 
+// portMatches returns true if the port is selected by the given address and
+// protocol. The address matches the port address, or the USB serial number of
+// the board connected to the port, either directly or through a board alias.
+func portMatches(port *rpc.Port, address, protocol string) bool {
+	if protocol != "" && protocol != port.GetProtocol() {
+		return false
+	}
+	if address == port.GetAddress() {
+		return true
+	}
+	serialNumber := port.GetProperties()["serialNumber"]
+	if serialNumber == "" {
+		return false
+	}
+	if alias := configuration.GetBoardAlias(configuration.Settings, address); alias != nil {
+		return strings.EqualFold(alias.SerialNumber, serialNumber)
+	}
+	return strings.EqualFold(address, serialNumber)
+}
+
 // IsPortFlagSet returns true if the port address is provided
 func (p *Port) IsPortFlagSet() bool {
 	return p.address != ""
@@ -0,0 +1,92 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package arguments
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortMatches(t *testing.T) {
+	settings := viper.New()
+	settings.Set("board_aliases", map[string]interface{}{
+		"bench-uno": map[string]interface{}{"serial_number": "95037323535351F0E1C1"},
+	})
+	defer func(s *viper.Viper) { configuration.Settings = s }(configuration.Settings)
+	configuration.Settings = settings
+
+	port := &rpc.Port{
+		Address:    "/dev/ttyACM3",
+		Protocol:   "serial",
+		Properties: map[string]string{"serialNumber": "95037323535351F0E1C1"},
+	}
+	require.True(t, portMatches(port, "/dev/ttyACM3", ""))
+	require.True(t, portMatches(port, "/dev/ttyACM3", "serial"))
+	require.False(t, portMatches(port, "/dev/ttyACM3", "network"))
+	require.False(t, portMatches(port, "/dev/ttyACM0", ""))
+	require.True(t, portMatches(port, "95037323535351f0e1c1", ""))
+	require.True(t, portMatches(port, "bench-uno", ""))
+	require.True(t, portMatches(port, "Bench-UNO", "serial"))
+	require.False(t, portMatches(port, "bench-nano", ""))
+
+	// Ports without serial number are matched only by address
+	require.False(t, portMatches(&rpc.Port{Address: "COM3"}, "", ""))
+	require.True(t, portMatches(&rpc.Port{Address: "COM3"}, "COM3", ""))
+
+	require.Nil(t, configuration.GetBoardAlias(settings, "bench-nano"))
+	require.Equal(t, "95037323535351F0E1C1", configuration.GetBoardAlias(settings, "BENCH-UNO").SerialNumber)
+}
+
+func TestBoardAliasDefaults(t *testing.T) {
+	settings := viper.New()
+	settings.Set("board_aliases", map[string]interface{}{
+		"mydevice": map[string]interface{}{
+			"fqbn":           "arduino:avr:uno",
+			"serial_number":  "1234",
+			"programmer":     "usbasp",
+			"monitor_config": map[string]interface{}{"baudrate": "115200"},
+		},
+		"nano": map[string]interface{}{"fqbn": "arduino:avr:nano"},
+	})
+	defer func(s *viper.Viper) { configuration.Settings = s }(configuration.Settings)
+	configuration.Settings = settings
+
+	fqbn := &Fqbn{fqbn: "MyDevice", boardOptions: []string{"cpu=atmega328old"}}
+	require.Equal(t, "arduino:avr:uno:cpu=atmega328old", fqbn.String())
+	require.Equal(t, "MyDevice", fqbn.DefaultPort())
+	require.Equal(t, []string{"baudrate=115200"}, fqbn.Alias().MonitorSettings())
+
+	programmer := &Programmer{}
+	programmer.SetDefaultFromBoardAlias(fqbn)
+	require.Equal(t, "usbasp", programmer.GetProgrammer())
+	programmer = &Programmer{programmer: "atmel_ice"}
+	programmer.SetDefaultFromBoardAlias(fqbn)
+	require.Equal(t, "atmel_ice", programmer.GetProgrammer())
+
+	// Aliases without serial number don't provide a port
+	fqbn = &Fqbn{fqbn: "nano"}
+	require.Equal(t, "arduino:avr:nano", fqbn.String())
+	require.Empty(t, fqbn.DefaultPort())
+
+	fqbn = &Fqbn{fqbn: "arduino:avr:uno"}
+	require.Nil(t, fqbn.Alias())
+	require.Equal(t, "arduino:avr:uno", fqbn.String())
+	require.Empty(t, fqbn.DefaultPort())
+}
@@ -57,6 +57,14 @@ func (p *Programmer) String(inst *commands.Instance, fqbn string) string {
 	return details.GetDefaultProgrammerId()
 }
 
+// SetDefaultFromBoardAlias uses the programmer of the board alias given in
+// place of the fqbn, if the programmer is not specified by the user.
+func (p *Programmer) SetDefaultFromBoardAlias(fqbn *Fqbn) {
+	if p.programmer == "" {
+		p.programmer = fqbn.Programmer()
+	}
+}
+
 // GetProgrammer returns the programmer specified by the user
 func (p *Programmer) GetProgrammer() string {
 	return p.programmer
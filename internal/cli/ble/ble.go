@@ -0,0 +1,66 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package ble
+
+import (
+	"os"
+
+	"github.com/arduino/arduino-cli/internal/arduino/ble"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	monitor "github.com/arduino/pluggable-monitor-protocol-handler"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `ble` command. The command is hidden, it runs
+// the BLE pluggable discovery and monitor that are started by the CLI
+// itself when the `ble.enabled` setting is true.
+func NewCommand() *cobra.Command {
+	bleCommand := &cobra.Command{
+		Use:    "ble",
+		Short:  tr("Runs the BLE pluggable discovery or monitor."),
+		Long:   tr("Runs the BLE pluggable discovery or monitor."),
+		Hidden: true,
+	}
+
+	bleCommand.AddCommand(&cobra.Command{
+		Use:   "discovery",
+		Short: tr("Runs the BLE pluggable discovery."),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			server := discovery.NewServer(ble.NewDiscovery())
+			if err := server.Run(os.Stdin, os.Stdout); err != nil {
+				feedback.FatalError(err, feedback.ErrGeneric)
+			}
+		},
+	})
+	bleCommand.AddCommand(&cobra.Command{
+		Use:   "monitor",
+		Short: tr("Runs the BLE pluggable monitor."),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			server := monitor.NewServer(ble.NewMonitor())
+			if err := server.Run(os.Stdin, os.Stdout); err != nil {
+				feedback.FatalError(err, feedback.ErrGeneric)
+			}
+		},
+	})
+
+	return bleCommand
+}
@@ -0,0 +1,206 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initAliasCommand() *cobra.Command {
+	aliasCommand := &cobra.Command{
+		Use:   "alias",
+		Short: tr("Manages the board aliases."),
+		Long: tr("Manages the board aliases. An alias can be used in place of the FQBN with the --fqbn flag, the FQBN, the port, " +
+			"the programmer and the monitor settings of the alias are used unless specified with the other flags."),
+		Example: "  " + os.Args[0] + " board alias add mydevice --fqbn arduino:avr:uno --port-serial 95037323535351F0E1C1\n" +
+			"  " + os.Args[0] + " upload -b mydevice",
+	}
+	aliasCommand.AddCommand(initAliasAddCommand())
+	aliasCommand.AddCommand(initAliasListCommand())
+	aliasCommand.AddCommand(initAliasRemoveCommand())
+	return aliasCommand
+}
+
+func initAliasAddCommand() *cobra.Command {
+	var (
+		fqbn          string
+		serialNumber  string
+		programmer    string
+		monitorConfig []string
+	)
+	addCommand := &cobra.Command{
+		Use:   fmt.Sprintf("add <%s>", tr("ALIAS")),
+		Short: tr("Adds a board alias."),
+		Long:  tr("Adds a board alias, or replaces it if already defined. The alias is saved in the configuration file."),
+		Example: "  " + os.Args[0] + " board alias add mydevice --fqbn arduino:avr:uno --port-serial 95037323535351F0E1C1 --monitor-config baudrate=115200\n" +
+			"  " + os.Args[0] + " board alias add programmed-nano --fqbn arduino:avr:nano -P usbasp",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runAliasAddCommand(args[0], fqbn, serialNumber, programmer, monitorConfig)
+		},
+	}
+	addCommand.Flags().StringVarP(&fqbn, "fqbn", "b", "", tr("Fully Qualified Board Name, e.g.: arduino:avr:uno"))
+	addCommand.RegisterFlagCompletionFunc("fqbn", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return arguments.GetInstalledBoards(), cobra.ShellCompDirectiveDefault
+	})
+	addCommand.Flags().StringVar(&serialNumber, "port-serial", "", tr("USB serial number of the board, used to find the port the board is connected to."))
+	addCommand.Flags().StringVarP(&programmer, "programmer", "P", "", tr("Programmer to use, e.g: atmel_ice"))
+	addCommand.Flags().StringSliceVar(&monitorConfig, "monitor-config", nil, tr("Monitor port settings, in the form setting=value, can be used multiple times."))
+	return addCommand
+}
+
+func runAliasAddCommand(name, fqbn, serialNumber, programmer string, monitorConfig []string) {
+	logrus.Info("Executing `arduino-cli board alias add`")
+
+	if name == "" || strings.ContainsAny(name, ":. \t") {
+		feedback.Fatal(tr("Invalid alias name: %s", name), feedback.ErrBadArgument)
+	}
+	if fqbn != "" {
+		if _, err := cores.ParseFQBN(fqbn); err != nil {
+			feedback.Fatal(tr("Invalid FQBN: %v", err), feedback.ErrBadArgument)
+		}
+	}
+	alias := &configuration.BoardAlias{
+		FQBN:          fqbn,
+		SerialNumber:  serialNumber,
+		Programmer:    programmer,
+		MonitorConfig: parseMonitorSettings(monitorConfig),
+	}
+	if alias.FQBN == "" && alias.SerialNumber == "" {
+		feedback.Fatal(tr("At least one of --fqbn and --port-serial must be specified."), feedback.ErrBadArgument)
+	}
+
+	aliases := configuration.BoardAliases(configuration.Settings)
+	aliases[strings.ToLower(name)] = alias
+	if err := configuration.WriteBoardAliases(configuration.Settings, aliases); err != nil {
+		feedback.Fatal(tr("Writing config file: %v", err), feedback.ErrGeneric)
+	}
+	feedback.PrintResult(&boardAliasesResult{Aliases: []*boardAliasResult{newBoardAliasResult(strings.ToLower(name), alias)}})
+}
+
+func initAliasListCommand() *cobra.Command {
+	listCommand := &cobra.Command{
+		Use:     "list",
+		Short:   tr("Lists the board aliases."),
+		Long:    tr("Lists the board aliases defined in the configuration file."),
+		Example: "  " + os.Args[0] + " board alias list",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			logrus.Info("Executing `arduino-cli board alias list`")
+			res := &boardAliasesResult{Aliases: []*boardAliasResult{}}
+			for name, alias := range configuration.BoardAliases(configuration.Settings) {
+				res.Aliases = append(res.Aliases, newBoardAliasResult(name, alias))
+			}
+			sort.Slice(res.Aliases, func(i, j int) bool { return res.Aliases[i].Name < res.Aliases[j].Name })
+			feedback.PrintResult(res)
+		},
+	}
+	return listCommand
+}
+
+func initAliasRemoveCommand() *cobra.Command {
+	removeCommand := &cobra.Command{
+		Use:     fmt.Sprintf("remove <%s>", tr("ALIAS")),
+		Short:   tr("Removes a board alias."),
+		Long:    tr("Removes a board alias from the configuration file."),
+		Example: "  " + os.Args[0] + " board alias remove mydevice",
+		Args:    cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return arguments.GetBoardAliases(), cobra.ShellCompDirectiveDefault
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			logrus.Info("Executing `arduino-cli board alias remove`")
+			name := strings.ToLower(args[0])
+			aliases := configuration.BoardAliases(configuration.Settings)
+			if _, ok := aliases[name]; !ok {
+				feedback.Fatal(tr("Board alias not found: %s", args[0]), feedback.ErrBadArgument)
+			}
+			delete(aliases, name)
+			if err := configuration.WriteBoardAliases(configuration.Settings, aliases); err != nil {
+				feedback.Fatal(tr("Writing config file: %v", err), feedback.ErrGeneric)
+			}
+		},
+	}
+	return removeCommand
+}
+
+type boardAliasResult struct {
+	Name          string            `json:"name"`
+	FQBN          string            `json:"fqbn,omitempty"`
+	SerialNumber  string            `json:"serial_number,omitempty"`
+	Programmer    string            `json:"programmer,omitempty"`
+	MonitorConfig map[string]string `json:"monitor_config,omitempty"`
+	monitorConfig []string
+}
+
+func newBoardAliasResult(name string, alias *configuration.BoardAlias) *boardAliasResult {
+	return &boardAliasResult{
+		Name:          name,
+		FQBN:          alias.FQBN,
+		SerialNumber:  alias.SerialNumber,
+		Programmer:    alias.Programmer,
+		MonitorConfig: alias.MonitorConfig,
+		monitorConfig: alias.MonitorSettings(),
+	}
+}
+
+type boardAliasesResult struct {
+	Aliases []*boardAliasResult `json:"aliases"`
+}
+
+func (r *boardAliasesResult) Data() interface{} {
+	return r
+}
+
+func (r *boardAliasesResult) String() string {
+	if len(r.Aliases) == 0 {
+		return tr("No board aliases defined.")
+	}
+	t := table.New()
+	t.SetHeader(tr("Alias"), tr("FQBN"), tr("Serial number"), tr("Programmer"), tr("Monitor settings"))
+	for _, alias := range r.Aliases {
+		t.AddRow(alias.Name, alias.FQBN, alias.SerialNumber, alias.Programmer, strings.Join(alias.monitorConfig, " "))
+	}
+	return t.Render()
+}
+
+// parseMonitorSettings parses the monitor settings given in the form
+// `setting=value`, it returns nil if there are no settings.
+func parseMonitorSettings(settings []string) map[string]string {
+	var res map[string]string
+	for _, config := range settings {
+		setting, value, ok := strings.Cut(config, "=")
+		if !ok || setting == "" {
+			feedback.Fatal(tr("Invalid monitor setting, expected setting=value: %s", config), feedback.ErrBadArgument)
+		}
+		if res == nil {
+			res = map[string]string{}
+		}
+		res[setting] = value
+	}
+	return res
+}
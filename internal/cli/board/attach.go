@@ -19,11 +19,18 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/arduino/arduino-cli/commands/board"
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/monitor"
 	"github.com/arduino/arduino-cli/commands/sketch"
 	"github.com/arduino/arduino-cli/internal/cli/arguments"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -31,48 +38,82 @@ func initAttachCommand() *cobra.Command {
 	var port arguments.Port
 	var fqbn arguments.Fqbn
 	var programmer arguments.Programmer
+	var monitorConfig []string
+	var monitorLineEnding string
+	var monitorEcho bool
+	var detect bool
 	attachCommand := &cobra.Command{
-		Use:   fmt.Sprintf("attach [-p <%s>] [-b <%s>] [-P <%s>] [%s]", tr("port"), tr("FQBN"), tr("programmer"), tr("sketchPath")),
+		Use:   fmt.Sprintf("attach [-p <%s>] [-b <%s>] [-P <%s>] [--monitor-config <%s>] [--detect] [%s]", tr("port"), tr("FQBN"), tr("programmer"), tr("setting=value"), tr("sketchPath")),
 		Short: tr("Attaches a sketch to a board."),
-		Long:  tr("Sets the default values for port and FQBN. If no port, FQBN or programmer are specified, the current default port, FQBN and programmer are displayed."),
+		Long: tr("Sets the default values for port, FQBN, programmer and monitor settings in the sketch project file (sketch.yaml), they are used by the commands run in the sketch folder. If no port, FQBN, programmer or monitor setting are specified, the current defaults are displayed.") + "\n\n" +
+			tr("The monitor settings include the line ending of the data sent and the local echo, used by the monitor unless given with the --line-ending and --echo flags.") + "\n\n" +
+			tr("With --detect the port and the FQBN of the connected board are recorded, if more than one board is connected the port must be selected with --port."),
 		Example: "  " + os.Args[0] + " board attach -p /dev/ttyACM0\n" +
 			"  " + os.Args[0] + " board attach -p /dev/ttyACM0 HelloWorld\n" +
-			"  " + os.Args[0] + " board attach -b arduino:samd:mkr1000" +
-			"  " + os.Args[0] + " board attach -P atmel_ice",
+			"  " + os.Args[0] + " board attach -b arduino:samd:mkr1000\n" +
+			"  " + os.Args[0] + " board attach -P atmel_ice\n" +
+			"  " + os.Args[0] + " board attach --monitor-config baudrate=115200\n" +
+			"  " + os.Args[0] + " board attach --monitor-line-ending nlcr --monitor-echo\n" +
+			"  " + os.Args[0] + " board attach --detect",
 		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			sketchPath := ""
 			if len(args) > 0 {
 				sketchPath = args[0]
 			}
-			runAttachCommand(sketchPath, &port, fqbn.String(), &programmer)
+			var echo *bool
+			if cmd.Flags().Changed("monitor-echo") {
+				echo = &monitorEcho
+			}
+			runAttachCommand(sketchPath, &port, fqbn.String(), &programmer, monitorConfig, monitorLineEnding, echo, detect)
 		},
 	}
 	fqbn.AddToCommand(attachCommand)
 	port.AddToCommand(attachCommand)
 	programmer.AddToCommand(attachCommand)
+	attachCommand.Flags().StringSliceVar(&monitorConfig, "monitor-config", nil, tr("Monitor port settings, in the form setting=value, can be used multiple times."))
+	attachCommand.Flags().StringVar(&monitorLineEnding, "monitor-line-ending", "", tr("Line ending of the data sent by the monitor: %s.", strings.Join(monitor.LineEndingNames, ", ")))
+	attachCommand.RegisterFlagCompletionFunc("monitor-line-ending", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return monitor.LineEndingNames, cobra.ShellCompDirectiveNoFileComp
+	})
+	attachCommand.Flags().BoolVar(&monitorEcho, "monitor-echo", false, tr("Enable the local echo of the data sent by the monitor, use --monitor-echo=false to disable it."))
+	attachCommand.Flags().BoolVar(&detect, "detect", false, tr("Detect the port and the FQBN of the connected board."))
 
 	return attachCommand
 }
 
-func runAttachCommand(path string, port *arguments.Port, fqbn string, programmer *arguments.Programmer) {
+func runAttachCommand(path string, port *arguments.Port, fqbn string, programmer *arguments.Programmer, monitorConfig []string, monitorLineEnding string, monitorEcho *bool, detect bool) {
+	logrus.Info("Executing `arduino-cli board attach`")
 	sketchPath := arguments.InitSketchPath(path)
 
 	portAddress, portProtocol, _ := port.GetPortAddressAndProtocol(nil, "", "")
+	if detect {
+		detectedFqbn, detectedPort := detectAttachedBoard(port)
+		if fqbn == "" {
+			fqbn = detectedFqbn
+		}
+		portAddress, portProtocol = detectedPort.GetAddress(), detectedPort.GetProtocol()
+	}
 	newDefaults, err := sketch.SetSketchDefaults(context.Background(), &rpc.SetSketchDefaultsRequest{
-		SketchPath:          sketchPath.String(),
-		DefaultFqbn:         fqbn,
-		DefaultProgrammer:   programmer.GetProgrammer(),
-		DefaultPortAddress:  portAddress,
-		DefaultPortProtocol: portProtocol,
+		SketchPath:               sketchPath.String(),
+		DefaultFqbn:              fqbn,
+		DefaultProgrammer:        programmer.GetProgrammer(),
+		DefaultPortAddress:       portAddress,
+		DefaultPortProtocol:      portProtocol,
+		DefaultMonitorConfig:     parseMonitorSettings(monitorConfig),
+		DefaultMonitorLineEnding: monitorLineEnding,
+		DefaultMonitorEcho:       monitorEcho,
 	})
 	if err != nil {
 		feedback.FatalError(err, feedback.ErrGeneric)
 	}
 
 	res := &boardAttachResult{
-		Fqbn:       newDefaults.GetDefaultFqbn(),
-		Programmer: newDefaults.GetDefaultProgrammer(),
+		Fqbn:              newDefaults.GetDefaultFqbn(),
+		Programmer:        newDefaults.GetDefaultProgrammer(),
+		MonitorConfig:     newDefaults.GetDefaultMonitorConfig(),
+		MonitorLineEnding: newDefaults.GetDefaultMonitorLineEnding(),
+		MonitorEcho:       newDefaults.GetDefaultMonitorEcho(),
 	}
 	if newDefaults.GetDefaultPortAddress() != "" {
 		res.Port = &boardAttachPortResult{
@@ -83,6 +124,50 @@ func runAttachCommand(path string, port *arguments.Port, fqbn string, programmer
 	feedback.PrintResult(res)
 }
 
+// detectAttachedBoard returns the FQBN and the port of the connected board.
+// If the port is given the board connected to it is used, otherwise exactly
+// one identified board must be connected.
+func detectAttachedBoard(port *arguments.Port) (string, *rpc.Port) {
+	inst := instance.CreateAndInit()
+	if port.IsPortFlagSet() {
+		fqbn, detectedPort := port.DetectFQBN(inst)
+		if detectedPort == nil {
+			address, _, _ := port.GetPortAddressAndProtocol(nil, "", "")
+			feedback.Fatal(tr("No board found on port %s", address), feedback.ErrBadArgument)
+		}
+		return fqbn, detectedPort
+	}
+
+	detectedPorts, _, err := board.List(&rpc.BoardListRequest{
+		Instance: inst,
+		Timeout:  port.GetSearchTimeout().Milliseconds(),
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error detecting boards: %v", err), feedback.ErrGeneric)
+	}
+	var identified []*rpc.DetectedPort
+	for _, detectedPort := range detectedPorts {
+		if len(detectedPort.GetMatchingBoards()) > 0 {
+			identified = append(identified, detectedPort)
+		}
+	}
+	switch len(identified) {
+	case 0:
+		feedback.Fatal(tr("No boards detected, connect the board or specify the port with --port."), feedback.ErrBadArgument)
+	case 1:
+	default:
+		addresses := []string{}
+		for _, detectedPort := range identified {
+			addresses = append(addresses, detectedPort.GetPort().GetAddress())
+		}
+		feedback.Fatal(tr("Multiple boards detected on ports %s, select one with --port.", strings.Join(addresses, ", ")), feedback.ErrBadArgument)
+	}
+	if len(identified[0].GetMatchingBoards()) > 1 {
+		feedback.FatalError(&cmderrors.MultipleBoardsDetectedError{Port: identified[0].GetPort()}, feedback.ErrBadArgument)
+	}
+	return identified[0].GetMatchingBoards()[0].GetFqbn(), identified[0].GetPort()
+}
+
 type boardAttachPortResult struct {
 	Address  string `json:"address,omitempty"`
 	Protocol string `json:"protocol,omitempty"`
@@ -97,9 +182,12 @@ func (b *boardAttachPortResult) String() string {
 }
 
 type boardAttachResult struct {
-	Fqbn       string                 `json:"fqbn,omitempty"`
-	Programmer string                 `json:"programmer,omitempty"`
-	Port       *boardAttachPortResult `json:"port,omitempty"`
+	Fqbn              string                 `json:"fqbn,omitempty"`
+	Programmer        string                 `json:"programmer,omitempty"`
+	Port              *boardAttachPortResult `json:"port,omitempty"`
+	MonitorConfig     map[string]string      `json:"monitor_config,omitempty"`
+	MonitorLineEnding string                 `json:"monitor_line_ending,omitempty"`
+	MonitorEcho       bool                   `json:"monitor_echo,omitempty"`
 }
 
 func (b *boardAttachResult) Data() interface{} {
@@ -107,11 +195,25 @@ func (b *boardAttachResult) Data() interface{} {
 }
 
 func (b *boardAttachResult) String() string {
-	if b.Port == nil && b.Fqbn == "" && b.Programmer == "" {
+	if b.Port == nil && b.Fqbn == "" && b.Programmer == "" && len(b.MonitorConfig) == 0 && b.MonitorLineEnding == "" && !b.MonitorEcho {
 		return tr("No default port, FQBN or programmer set")
 	}
 	res := fmt.Sprintf("%s: %s\n", tr("Default port set to"), b.Port)
 	res += fmt.Sprintf("%s: %s\n", tr("Default FQBN set to"), b.Fqbn)
 	res += fmt.Sprintf("%s: %s\n", tr("Default programmer set to"), b.Programmer)
+	if len(b.MonitorConfig) > 0 {
+		settings := []string{}
+		for setting, value := range b.MonitorConfig {
+			settings = append(settings, setting+"="+value)
+		}
+		sort.Strings(settings)
+		res += fmt.Sprintf("%s: %s\n", tr("Default monitor settings set to"), strings.Join(settings, " "))
+	}
+	if b.MonitorLineEnding != "" {
+		res += fmt.Sprintf("%s: %s\n", tr("Default monitor line ending set to"), b.MonitorLineEnding)
+	}
+	if b.MonitorEcho {
+		res += fmt.Sprintln(tr("Monitor local echo enabled by default"))
+	}
 	return res
 }
@@ -34,11 +34,23 @@ func NewCommand() *cobra.Command {
 			"  " + os.Args[0] + " board list",
 	}
 
+	boardCommand.AddCommand(initAliasCommand())
 	boardCommand.AddCommand(initAttachCommand())
+	boardCommand.AddCommand(initCertificatesCommand())
 	boardCommand.AddCommand(initDetailsCommand())
+	boardCommand.AddCommand(initEraseCommand())
 	boardCommand.AddCommand(initListCommand())
 	boardCommand.AddCommand(initListAllCommand())
+	boardCommand.AddCommand(initPowerCommand())
+	boardCommand.AddCommand(initProtectionCommand())
+	boardCommand.AddCommand(initProvisionCommand())
+	boardCommand.AddCommand(initReadMemCommand())
+	boardCommand.AddCommand(initRecoverCommand())
+	boardCommand.AddCommand(initResetCommand())
 	boardCommand.AddCommand(initSearchCommand())
+	boardCommand.AddCommand(initSetTimeCommand())
+	boardCommand.AddCommand(initWriteMemCommand())
+	boardCommand.AddCommand(initSetupPermissionsCommand())
 
 	return boardCommand
 }
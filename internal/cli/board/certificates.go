@@ -0,0 +1,149 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initCertificatesCommand() *cobra.Command {
+	var (
+		fqbn     arguments.Fqbn
+		port     arguments.Port
+		urls     []string
+		files    []string
+		firmware string
+		verbose  bool
+		dryRun   bool
+	)
+	certificatesCommand := &cobra.Command{
+		Use:   "certificates",
+		Short: tr("Flashes root certificates and WiFi firmware to a board."),
+		Long: tr("Flashes the root certificates of the given servers, and optionally a new firmware, to the network module of a board. " +
+			"The loader sketch and the tools provided by the board platform are used automatically."),
+		Example: "" +
+			"  " + os.Args[0] + " board certificates -b arduino:samd:mkrwifi1010 -p /dev/ttyACM0 -u arduino.cc -u example.com:8443\n" +
+			"  " + os.Args[0] + " board certificates -b arduino:renesas_uno:unor4wifi -p /dev/ttyACM0 -f ca.pem --firmware fw.bin",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board certificates`")
+
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			certificateFiles := []string{}
+			for _, file := range files {
+				certificateFiles = append(certificateFiles, absPathOrDie(file))
+			}
+			firmwareFile := ""
+			if firmware != "" {
+				firmwareFile = absPathOrDie(firmware)
+			}
+
+			stdOut, stdErr, _ := feedback.OutputStreams()
+			res, err := upload.BoardCertificates(context.Background(), &rpc.BoardCertificatesRequest{
+				Instance:         inst,
+				Fqbn:             fqbn.String(),
+				Port:             discoveryPort,
+				Urls:             urls,
+				CertificateFiles: certificateFiles,
+				FirmwareFile:     firmwareFile,
+				Verbose:          verbose,
+				DryRun:           dryRun,
+			}, stdOut, stdErr)
+			if err != nil {
+				feedback.Fatal(tr("Error flashing certificates: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(newBoardCertificatesResult(res))
+		},
+	}
+	fqbn.AddToCommand(certificatesCommand)
+	port.AddToCommand(certificatesCommand)
+	certificatesCommand.Flags().StringSliceVarP(&urls, "url", "u", nil, tr("Server (host or host:port) whose root certificate is flashed, can be used multiple times."))
+	certificatesCommand.Flags().StringSliceVarP(&files, "file", "f", nil, tr("Certificate file (PEM or DER) to flash, can be used multiple times."))
+	certificatesCommand.Flags().StringVar(&firmware, "firmware", "", tr("Firmware file to flash to the network module before the certificates."))
+	certificatesCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	certificatesCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual flashing, just log out actions"))
+	certificatesCommand.Flags().MarkHidden("dry-run")
+	return certificatesCommand
+}
+
+func absPathOrDie(file string) string {
+	abs, err := paths.New(file).Abs()
+	if err != nil {
+		feedback.Fatal(tr("Invalid file %[1]s: %[2]v", file, err), feedback.ErrBadArgument)
+	}
+	return abs.String()
+}
+
+type boardCertificate struct {
+	Subject  string `json:"subject"`
+	Source   string `json:"source"`
+	NotAfter string `json:"not_after"`
+}
+
+type boardCertificatesResult struct {
+	Certificates    []*boardCertificate `json:"certificates"`
+	FirmwareFlashed bool                `json:"firmware_flashed"`
+}
+
+func newBoardCertificatesResult(res *rpc.BoardCertificatesResult) *boardCertificatesResult {
+	out := &boardCertificatesResult{
+		Certificates:    []*boardCertificate{},
+		FirmwareFlashed: res.GetFirmwareFlashed(),
+	}
+	for _, cert := range res.GetCertificates() {
+		out.Certificates = append(out.Certificates, &boardCertificate{
+			Subject:  cert.GetSubject(),
+			Source:   cert.GetSource(),
+			NotAfter: cert.GetNotAfter(),
+		})
+	}
+	return out
+}
+
+func (r *boardCertificatesResult) Data() interface{} {
+	return r
+}
+
+func (r *boardCertificatesResult) String() string {
+	res := ""
+	if r.FirmwareFlashed {
+		res += tr("Firmware flashed.") + "\n"
+	}
+	if len(r.Certificates) > 0 {
+		t := table.New()
+		t.SetHeader(tr("Source"), tr("Subject"), tr("Expires"))
+		for _, cert := range r.Certificates {
+			t.AddRow(cert.Source, cert.Subject, cert.NotAfter)
+		}
+		res += t.Render() + "\n" + tr("%d certificates flashed.", len(r.Certificates))
+	}
+	return res
+}
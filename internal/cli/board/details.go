@@ -27,7 +27,6 @@ import (
 	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
 	"github.com/arduino/arduino-cli/internal/cli/instance"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
-	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -143,7 +142,7 @@ func (dr detailsResult) String() string {
 	if details.Official {
 		t.AddRow() // get some space from above
 		t.AddRow(tr("Official Arduino board:"),
-			table.NewCell("✔", color.New(color.FgGreen)))
+			table.NewCell("✔", feedback.GetTheme().Success))
 	}
 
 	for _, idp := range details.IdentificationProperties {
@@ -194,7 +193,7 @@ func (dr detailsResult) String() string {
 		}
 	}
 
-	green := color.New(color.FgGreen)
+	green := feedback.GetTheme().Success
 	tab.AddRow() // get some space from above
 	for _, option := range details.ConfigOptions {
 		tab.AddRow(tr("Option:"), option.OptionLabel, "", option.Option)
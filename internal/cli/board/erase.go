@@ -0,0 +1,102 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initEraseCommand() *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		verbose    bool
+		force      bool
+		dryRun     bool
+	)
+	eraseCommand := &cobra.Command{
+		Use:   "erase",
+		Short: tr("Erases the whole memory of a board."),
+		Long: tr("Erases the whole memory of the microcontroller of a board, including the bootloader, using the bootloader or, if specified, a programmer. " +
+			"The erase can't be undone and must be confirmed with --force."),
+		Example: "" +
+			"  " + os.Args[0] + " board erase -b arduino:avr:uno -P atmel_ice --force",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board erase`")
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+
+			if force && !dryRun {
+				warnIrreversible(tr("Erasing the chip: the sketch, the bootloader and all the data stored in the board will be lost."))
+			}
+			stdOut, stdErr, res := feedback.OutputStreams()
+			if _, err := upload.EraseChip(context.Background(), &rpc.EraseChipRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.GetProgrammer(),
+				Verbose:    verbose,
+				Force:      force,
+				DryRun:     dryRun,
+			}, stdOut, stdErr); err != nil {
+				fatalIfNotForced(err)
+				feedback.Fatal(tr("Error erasing the chip: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(res())
+		},
+	}
+	fqbn.AddToCommand(eraseCommand)
+	port.AddToCommand(eraseCommand)
+	programmer.AddToCommand(eraseCommand)
+	eraseCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	eraseCommand.Flags().BoolVar(&force, "force", false, tr("Confirm the erase of the whole memory."))
+	eraseCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual erase, just log out actions"))
+	eraseCommand.Flags().MarkHidden("dry-run")
+	return eraseCommand
+}
+
+// warnIrreversible prints a warning about an operation that can't be undone
+func warnIrreversible(msg string) {
+	feedback.Warning(feedback.GetTheme().Error.Sprint(tr("WARNING:")) + " " + msg)
+}
+
+// fatalIfNotForced exits with an explanation if err has been returned because
+// an operation that can't be undone has not been forced.
+func fatalIfNotForced(err error) {
+	var irreversible *cmderrors.IrreversibleOperationError
+	if errors.As(err, &irreversible) {
+		warnIrreversible(irreversible.Message + ".")
+		feedback.Fatal(tr("Run the command again with --force to proceed."), feedback.ErrBadArgument)
+	}
+}
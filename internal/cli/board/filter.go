@@ -0,0 +1,58 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/spf13/cobra"
+)
+
+// boardFilterFlags contains the flags used to filter the boards by
+// architecture, vendor and capability.
+type boardFilterFlags struct {
+	architecture     string
+	vendor           string
+	hasDebug         bool
+	hasNetworkUpload bool
+	hasCryptoChip    bool
+	connectedOnly    bool
+	discoveryTimeout arguments.DiscoveryTimeout
+}
+
+// AddToCommand adds the filter flags to the specified Command
+func (f *boardFilterFlags) AddToCommand(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.architecture, "arch", "", tr("Show only the boards of the given architecture, e.g.: samd"))
+	cmd.Flags().StringVar(&f.vendor, "vendor", "", tr("Show only the boards of the given vendor, e.g.: adafruit"))
+	cmd.Flags().BoolVar(&f.hasDebug, "has-debug", false, tr("Show only the boards that can be debugged"))
+	cmd.Flags().BoolVar(&f.hasNetworkUpload, "has-network-upload", false, tr("Show only the boards that can be uploaded over the network"))
+	cmd.Flags().BoolVar(&f.hasCryptoChip, "has-crypto-chip", false, tr("Show only the boards with a crypto chip"))
+	cmd.Flags().BoolVar(&f.connectedOnly, "connected-only", false, tr("Show only the boards currently connected"))
+	f.discoveryTimeout.AddToCommand(cmd)
+}
+
+// ToRPC returns the filter to send in the board list requests
+func (f *boardFilterFlags) ToRPC() *rpc.BoardFilter {
+	return &rpc.BoardFilter{
+		Architecture:     f.architecture,
+		Vendor:           f.vendor,
+		HasDebug:         f.hasDebug,
+		HasNetworkUpload: f.hasNetworkUpload,
+		HasCryptoChip:    f.hasCryptoChip,
+		ConnectedOnly:    f.connectedOnly,
+		DiscoveryTimeout: f.discoveryTimeout.Get().Milliseconds(),
+	}
+}
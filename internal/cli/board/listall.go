@@ -34,6 +34,7 @@ import (
 var showHiddenBoard bool
 
 func initListAllCommand() *cobra.Command {
+	var filter boardFilterFlags
 	var listAllCommand = &cobra.Command{
 		Use:   fmt.Sprintf("listall [%s]", tr("boardname")),
 		Short: tr("List all known boards and their corresponding FQBN."),
@@ -41,16 +42,21 @@ func initListAllCommand() *cobra.Command {
 for a specific board if you specify the board name`),
 		Example: "" +
 			"  " + os.Args[0] + " board listall\n" +
-			"  " + os.Args[0] + " board listall zero",
+			"  " + os.Args[0] + " board listall zero\n" +
+			"  " + os.Args[0] + " board listall --arch samd --has-debug\n" +
+			"  " + os.Args[0] + " board listall --connected-only",
 		Args: cobra.ArbitraryArgs,
-		Run:  runListAllCommand,
+		Run: func(cmd *cobra.Command, args []string) {
+			runListAllCommand(args, filter.ToRPC())
+		},
 	}
 	listAllCommand.Flags().BoolVarP(&showHiddenBoard, "show-hidden", "a", false, tr("Show also boards marked as 'hidden' in the platform"))
+	filter.AddToCommand(listAllCommand)
 	return listAllCommand
 }
 
 // runListAllCommand list all installed boards
-func runListAllCommand(cmd *cobra.Command, args []string) {
+func runListAllCommand(args []string, filter *rpc.BoardFilter) {
 	inst := instance.CreateAndInit()
 
 	logrus.Info("Executing `arduino-cli board listall`")
@@ -59,6 +65,7 @@ func runListAllCommand(cmd *cobra.Command, args []string) {
 		Instance:            inst,
 		SearchArgs:          args,
 		IncludeHiddenBoards: showHiddenBoard,
+		Filter:              filter,
 	})
 	if err != nil {
 		feedback.Fatal(tr("Error listing boards: %v", err), feedback.ErrGeneric)
@@ -0,0 +1,175 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initReadMemCommand() *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		memoryType string
+		address    string
+		size       string
+		outputFile string
+		verbose    bool
+		dryRun     bool
+	)
+	readMemCommand := &cobra.Command{
+		Use:   "read-mem",
+		Short: tr("Reads a memory range of a board."),
+		Long:  tr("Reads a memory range of a board and saves it to a file. The memory is read using the bootloader or, if specified, a programmer, if supported by the board platform."),
+		Example: "" +
+			"  " + os.Args[0] + " board read-mem -b arduino:avr:uno -p /dev/ttyACM0 -o firmware.hex\n" +
+			"  " + os.Args[0] + " board read-mem -b arduino:avr:uno -P atmel_ice --memory eeprom --address 0x10 --size 64 -o eeprom.bin",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board read-mem`")
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			output, err := paths.New(outputFile).Abs()
+			if err != nil {
+				feedback.Fatal(tr("Invalid output file: %v", err), feedback.ErrBadArgument)
+			}
+
+			stdOut, stdErr, res := feedback.OutputStreams()
+			if _, err := upload.ReadMemory(context.Background(), &rpc.ReadMemoryRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.GetProgrammer(),
+				MemoryType: memoryType,
+				Address:    parseMemoryValue("address", address),
+				Size:       parseMemoryValue("size", size),
+				OutputFile: output.String(),
+				Verbose:    verbose,
+				DryRun:     dryRun,
+			}, stdOut, stdErr); err != nil {
+				feedback.Fatal(tr("Error reading memory: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(res())
+		},
+	}
+	fqbn.AddToCommand(readMemCommand)
+	port.AddToCommand(readMemCommand)
+	programmer.AddToCommand(readMemCommand)
+	readMemCommand.Flags().StringVar(&memoryType, "memory", "flash", tr("The memory to read, e.g. flash or eeprom."))
+	readMemCommand.Flags().StringVar(&address, "address", "0", tr("Start address of the range, decimal or hexadecimal (0x...)."))
+	readMemCommand.Flags().StringVar(&size, "size", "0", tr("Size of the range, decimal or hexadecimal (0x...). 0 means the whole memory."))
+	readMemCommand.Flags().StringVarP(&outputFile, "output-file", "o", "", tr("The file where the memory content is saved."))
+	readMemCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	readMemCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual read, just log out actions"))
+	readMemCommand.Flags().MarkHidden("dry-run")
+	readMemCommand.MarkFlagRequired("output-file")
+	return readMemCommand
+}
+
+func initWriteMemCommand() *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		memoryType string
+		address    string
+		inputFile  string
+		verbose    bool
+		verify     bool
+		dryRun     bool
+	)
+	writeMemCommand := &cobra.Command{
+		Use:   "write-mem",
+		Short: tr("Writes a file to a memory range of a board."),
+		Long:  tr("Writes the content of a file to a memory range of a board. The memory is written using the bootloader or, if specified, a programmer, if supported by the board platform."),
+		Example: "" +
+			"  " + os.Args[0] + " board write-mem -b arduino:avr:uno -P atmel_ice --memory eeprom -i eeprom.bin",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board write-mem`")
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			input, err := paths.New(inputFile).Abs()
+			if err != nil {
+				feedback.Fatal(tr("Invalid input file: %v", err), feedback.ErrBadArgument)
+			}
+			if !input.Exist() {
+				feedback.Fatal(tr("File not found: %s", input), feedback.ErrBadArgument)
+			}
+
+			stdOut, stdErr, res := feedback.OutputStreams()
+			if _, err := upload.WriteMemory(context.Background(), &rpc.WriteMemoryRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.GetProgrammer(),
+				MemoryType: memoryType,
+				Address:    parseMemoryValue("address", address),
+				InputFile:  input.String(),
+				Verbose:    verbose,
+				Verify:     verify,
+				DryRun:     dryRun,
+			}, stdOut, stdErr); err != nil {
+				feedback.Fatal(tr("Error writing memory: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(res())
+		},
+	}
+	fqbn.AddToCommand(writeMemCommand)
+	port.AddToCommand(writeMemCommand)
+	programmer.AddToCommand(writeMemCommand)
+	writeMemCommand.Flags().StringVar(&memoryType, "memory", "flash", tr("The memory to write, e.g. flash or eeprom."))
+	writeMemCommand.Flags().StringVar(&address, "address", "0", tr("Start address of the range, decimal or hexadecimal (0x...)."))
+	writeMemCommand.Flags().StringVarP(&inputFile, "input-file", "i", "", tr("The file containing the data to write."))
+	writeMemCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	writeMemCommand.Flags().BoolVarP(&verify, "verify", "t", false, tr("Verify the memory content after writing."))
+	writeMemCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual write, just log out actions"))
+	writeMemCommand.Flags().MarkHidden("dry-run")
+	writeMemCommand.MarkFlagRequired("input-file")
+	return writeMemCommand
+}
+
+// parseMemoryValue parses an address or a size given in decimal or
+// hexadecimal (0x prefixed) notation.
+func parseMemoryValue(flag, value string) uint64 {
+	res, err := strconv.ParseUint(value, 0, 64)
+	if err != nil {
+		feedback.Fatal(tr("Invalid value for --%[1]s: %[2]s", flag, value), feedback.ErrBadArgument)
+	}
+	return res
+}
@@ -0,0 +1,174 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initPowerCommand() *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		watch      bool
+		interval   time.Duration
+		duration   time.Duration
+		csvFile    string
+		verbose    bool
+		dryRun     bool
+	)
+	powerCommand := &cobra.Command{
+		Use:   "power",
+		Short: tr("Measures the power drawn by a board."),
+		Long: tr("Measures the current drawn by a board, and the target voltage, using a debug probe that supports power measurements, " +
+			"if supported by the board platform. With --watch the samples are printed until CTRL-C is pressed or the given duration elapses, " +
+			"and may be saved in a CSV file."),
+		Example: "" +
+			"  " + os.Args[0] + " board power -b arduino:mbed_nano:nano33ble -P jlink\n" +
+			"  " + os.Args[0] + " board power -b arduino:mbed_nano:nano33ble -P jlink --watch --interval 10ms --csv power.csv",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board power`")
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			if (duration != 0 || csvFile != "") && !watch {
+				feedback.Fatal(tr("The --duration and --csv flags can be used only with --watch"), feedback.ErrBadArgument)
+			}
+
+			var csvWriter *csv.Writer
+			if csvFile != "" {
+				f, err := os.Create(csvFile)
+				if err != nil {
+					feedback.Fatal(tr("Error creating the CSV file: %v", err), feedback.ErrGeneric)
+				}
+				defer f.Close()
+				csvWriter = csv.NewWriter(f)
+				csvWriter.Write([]string{"time", "current", "voltage", "power"})
+				csvWriter.Flush()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			if watch && feedback.GetFormat() == feedback.Text {
+				feedback.Print(fmt.Sprintf("%10s %12s %10s %12s", tr("Time"), tr("Current"), tr("Voltage"), tr("Power")))
+			}
+			var last *powerSampleResult
+			stdOut, stdErr, _ := feedback.OutputStreams()
+			err = upload.BoardPower(ctx, &rpc.BoardPowerRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.GetProgrammer(),
+				Watch:      watch,
+				Interval:   interval.Milliseconds(),
+				Duration:   duration.Milliseconds(),
+				Verbose:    verbose,
+				DryRun:     dryRun,
+			}, stdOut, stdErr, func(sample *rpc.PowerSample) {
+				last = newPowerSampleResult(sample, watch)
+				if csvWriter != nil {
+					csvWriter.Write([]string{formatFloat(sample.GetTime()), formatFloat(sample.GetCurrent()), formatFloat(sample.GetVoltage()), formatFloat(sample.GetPower())})
+					csvWriter.Flush()
+				}
+				if watch {
+					feedback.PrintResult(last)
+				}
+			})
+			if err != nil {
+				feedback.Fatal(tr("Error measuring board power: %v", err), feedback.ErrGeneric)
+			}
+			if csvWriter != nil {
+				if err := csvWriter.Error(); err != nil {
+					feedback.Fatal(tr("Error writing the CSV file: %v", err), feedback.ErrGeneric)
+				}
+			}
+			if !watch && last != nil {
+				feedback.PrintResult(last)
+			}
+		},
+	}
+	fqbn.AddToCommand(powerCommand)
+	port.AddToCommand(powerCommand)
+	programmer.AddToCommand(powerCommand)
+	powerCommand.Flags().BoolVarP(&watch, "watch", "w", false, tr("Print the samples until CTRL-C is pressed."))
+	powerCommand.Flags().DurationVar(&interval, "interval", 0, tr("The interval between two samples, e.g. 10ms. Defaults to the interval of the probe."))
+	powerCommand.Flags().DurationVar(&duration, "duration", 0, tr("Stop the measurement after the given time, e.g. 30s."))
+	powerCommand.Flags().StringVar(&csvFile, "csv", "", tr("Save the samples in the given CSV file."))
+	powerCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	powerCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual measurement, just log out actions"))
+	powerCommand.Flags().MarkHidden("dry-run")
+	return powerCommand
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type powerSampleResult struct {
+	Time    float64 `json:"time"`
+	Current float64 `json:"current"`
+	Voltage float64 `json:"voltage,omitempty"`
+	Power   float64 `json:"power,omitempty"`
+	row     bool
+}
+
+func newPowerSampleResult(sample *rpc.PowerSample, row bool) *powerSampleResult {
+	return &powerSampleResult{
+		Time:    sample.GetTime(),
+		Current: sample.GetCurrent(),
+		Voltage: sample.GetVoltage(),
+		Power:   sample.GetPower(),
+		row:     row,
+	}
+}
+
+func (r *powerSampleResult) Data() interface{} {
+	return r
+}
+
+func (r *powerSampleResult) String() string {
+	current := fmt.Sprintf("%.3f mA", r.Current*1000)
+	voltage, power := "-", "-"
+	if r.Voltage != 0 {
+		voltage = fmt.Sprintf("%.3f V", r.Voltage)
+		power = fmt.Sprintf("%.3f mW", r.Power*1000)
+	}
+	if r.row {
+		return fmt.Sprintf("%9.3fs %12s %10s %12s", r.Time, current, voltage, power)
+	}
+	return tr("Current: %[1]s, voltage: %[2]s, power: %[3]s", current, voltage, power)
+}
@@ -0,0 +1,142 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initProtectionCommand() *cobra.Command {
+	protectionCommand := &cobra.Command{
+		Use:   "protection",
+		Short: tr("Flash readout protection commands."),
+		Long:  tr("Queries and changes the flash readout protection of the boards that support it."),
+		Example: "" +
+			"  " + os.Args[0] + " board protection status -b arduino:mbed_portenta:envie_m7 -P stlink\n" +
+			"  " + os.Args[0] + " board protection enable -b arduino:mbed_portenta:envie_m7 -P stlink --force",
+	}
+	protectionCommand.AddCommand(initProtectionActionCommand(
+		"status",
+		tr("Shows if the flash readout protection is enabled."),
+		rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_STATUS))
+	protectionCommand.AddCommand(initProtectionActionCommand(
+		"enable",
+		tr("Enables the flash readout protection, the firmware can't be read back anymore."),
+		rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_ENABLE))
+	protectionCommand.AddCommand(initProtectionActionCommand(
+		"disable",
+		tr("Disables the flash readout protection, on most boards this erases the whole memory."),
+		rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_DISABLE))
+	return protectionCommand
+}
+
+func initProtectionActionCommand(use, short string, action rpc.FlashProtectionAction) *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		verbose    bool
+		force      bool
+		dryRun     bool
+	)
+	actionCommand := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  short,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Infof("Executing `arduino-cli board protection %s`", use)
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+
+			if force && !dryRun {
+				switch action {
+				case rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_ENABLE:
+					warnIrreversible(tr("Enabling the flash readout protection."))
+				case rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_DISABLE:
+					warnIrreversible(tr("Disabling the flash readout protection: the sketch, the bootloader and all the data stored in the board may be lost."))
+				}
+			}
+			stdOut, stdErr, _ := feedback.OutputStreams()
+			res, err := upload.FlashProtection(context.Background(), &rpc.FlashProtectionRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.GetProgrammer(),
+				Action:     action,
+				Verbose:    verbose,
+				Force:      force,
+				DryRun:     dryRun,
+			}, stdOut, stdErr)
+			if err != nil {
+				fatalIfNotForced(err)
+				feedback.Fatal(tr("Error accessing the flash protection: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(&flashProtectionResult{
+				Enabled:   res.GetEnabled(),
+				Level:     res.GetLevel(),
+				Permanent: res.GetPermanent(),
+			})
+		},
+	}
+	fqbn.AddToCommand(actionCommand)
+	port.AddToCommand(actionCommand)
+	programmer.AddToCommand(actionCommand)
+	actionCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	if action != rpc.FlashProtectionAction_FLASH_PROTECTION_ACTION_STATUS {
+		actionCommand.Flags().BoolVar(&force, "force", false, tr("Confirm the change of the flash protection."))
+	}
+	actionCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual operation, just log out actions"))
+	actionCommand.Flags().MarkHidden("dry-run")
+	return actionCommand
+}
+
+type flashProtectionResult struct {
+	Enabled   bool   `json:"enabled"`
+	Level     string `json:"level,omitempty"`
+	Permanent bool   `json:"permanent"`
+}
+
+func (r *flashProtectionResult) Data() interface{} {
+	return r
+}
+
+func (r *flashProtectionResult) String() string {
+	if !r.Enabled {
+		if r.Permanent {
+			return tr("Flash readout protection: disabled") + "\n" + tr("Enabling the protection on this board is permanent.")
+		}
+		return tr("Flash readout protection: disabled")
+	}
+	if r.Level != "" {
+		return tr("Flash readout protection: enabled (level %s)", r.Level)
+	}
+	return tr("Flash readout protection: enabled")
+}
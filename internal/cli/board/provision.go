@@ -0,0 +1,145 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initProvisionCommand() *cobra.Command {
+	var (
+		fqbn        arguments.Fqbn
+		port        arguments.Port
+		slot        uint32
+		commonName  string
+		reuseKey    bool
+		csrOutput   string
+		certificate string
+		lock        bool
+		verbose     bool
+		dryRun      bool
+	)
+	provisionCommand := &cobra.Command{
+		Use:   "provision",
+		Short: tr("Provisions the secure element of a board."),
+		Long: tr("Provisions the crypto chip of a board using the provisioning sketch provided by the board platform. " +
+			"Without --certificate a new private key is generated in the secure element and the certificate signing request (CSR) is printed or saved with --csr-output. " +
+			"Once the CSR is signed by the CA, the certificate is written back to the secure element with --certificate."),
+		Example: "" +
+			"  " + os.Args[0] + " board provision -b arduino:samd:mkrwifi1010 -p /dev/ttyACM0 --common-name device-1 --csr-output device-1.csr\n" +
+			"  " + os.Args[0] + " board provision -b arduino:samd:mkrwifi1010 -p /dev/ttyACM0 --certificate device-1.pem --lock",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board provision`")
+
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			certificateData := ""
+			if certificate != "" {
+				data, err := paths.New(certificate).ReadFile()
+				if err != nil {
+					feedback.Fatal(tr("Error reading certificate: %v", err), feedback.ErrBadArgument)
+				}
+				certificateData = string(data)
+			}
+
+			stdOut, stdErr, _ := feedback.OutputStreams()
+			res, err := upload.BoardProvision(context.Background(), &rpc.BoardProvisionRequest{
+				Instance:    inst,
+				Fqbn:        fqbn.String(),
+				Port:        discoveryPort,
+				Slot:        slot,
+				CommonName:  commonName,
+				ReuseKey:    reuseKey,
+				Certificate: certificateData,
+				Lock:        lock,
+				Verbose:     verbose,
+				DryRun:      dryRun,
+			}, stdOut, stdErr)
+			if err != nil {
+				feedback.Fatal(tr("Error provisioning board: %v", err), feedback.ErrGeneric)
+			}
+
+			out := &boardProvisionResult{
+				Csr:                res.GetCsr(),
+				CertificateWritten: res.GetCertificateWritten(),
+				Locked:             res.GetLocked(),
+			}
+			if csrOutput != "" && out.Csr != "" {
+				if err := paths.New(csrOutput).WriteFile([]byte(out.Csr)); err != nil {
+					feedback.Fatal(tr("Error saving the certificate signing request: %v", err), feedback.ErrGeneric)
+				}
+				out.CsrFile = csrOutput
+			}
+			feedback.PrintResult(out)
+		},
+	}
+	fqbn.AddToCommand(provisionCommand)
+	port.AddToCommand(provisionCommand)
+	provisionCommand.Flags().Uint32Var(&slot, "slot", 0, tr("The slot of the secure element holding the private key."))
+	provisionCommand.Flags().StringVar(&commonName, "common-name", "", tr("The common name of the certificate signing request, usually the device ID."))
+	provisionCommand.Flags().BoolVar(&reuseKey, "reuse-key", false, tr("Use the private key already stored in the slot instead of generating a new one."))
+	provisionCommand.Flags().StringVar(&csrOutput, "csr-output", "", tr("The file where the certificate signing request is saved."))
+	provisionCommand.Flags().StringVar(&certificate, "certificate", "", tr("The PEM certificate, signed by the CA, to write to the secure element."))
+	provisionCommand.Flags().BoolVar(&lock, "lock", false, tr("Lock the configuration of the secure element. This operation cannot be undone."))
+	provisionCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	provisionCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual provisioning, just log out actions"))
+	provisionCommand.Flags().MarkHidden("dry-run")
+	provisionCommand.MarkFlagsMutuallyExclusive("certificate", "common-name")
+	provisionCommand.MarkFlagsMutuallyExclusive("certificate", "reuse-key")
+	return provisionCommand
+}
+
+type boardProvisionResult struct {
+	Csr                string `json:"csr,omitempty"`
+	CsrFile            string `json:"csr_file,omitempty"`
+	CertificateWritten bool   `json:"certificate_written"`
+	Locked             bool   `json:"locked"`
+}
+
+func (r *boardProvisionResult) Data() interface{} {
+	return r
+}
+
+func (r *boardProvisionResult) String() string {
+	var res []string
+	if r.CsrFile != "" {
+		res = append(res, tr("Certificate signing request saved to %s", r.CsrFile))
+	} else if r.Csr != "" {
+		res = append(res, strings.TrimSuffix(r.Csr, "\n"))
+	}
+	if r.CertificateWritten {
+		res = append(res, tr("Certificate written to the secure element."))
+	}
+	if r.Locked {
+		res = append(res, tr("Secure element locked."))
+	}
+	return strings.Join(res, "\n")
+}
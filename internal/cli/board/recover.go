@@ -0,0 +1,141 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initRecoverCommand() *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		verbose    bool
+		dryRun     bool
+	)
+	recoverCommand := &cobra.Command{
+		Use:   "recover",
+		Short: tr("Tries to recover a board that does not respond anymore."),
+		Long: tr("Tries to recover a board that does not respond anymore. " +
+			"Without a programmer the board is forced in bootloader mode using a 1200-bps touch or a DTR reset, " +
+			"with a programmer the bootloader is burned again."),
+		Example: "" +
+			"  " + os.Args[0] + " board recover -b arduino:samd:mkr1000 -p /dev/ttyACM0\n" +
+			"  " + os.Args[0] + " board recover -b arduino:avr:uno -P atmel_ice",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board recover`")
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+
+			stdOut, stdErr, _ := feedback.OutputStreams()
+			res, err := upload.BoardRecover(context.Background(), &rpc.BoardRecoverRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.GetProgrammer(),
+				Verbose:    verbose,
+				DryRun:     dryRun,
+			}, stdOut, stdErr)
+			if err != nil {
+				feedback.Fatal(tr("Error recovering board: %v", err), feedback.ErrGeneric)
+			}
+			out := newBoardRecoverResult(res)
+			if !out.Recovered {
+				feedback.FatalResult(out, feedback.ErrGeneric)
+			}
+			feedback.PrintResult(out)
+		},
+	}
+	fqbn.AddToCommand(recoverCommand)
+	port.AddToCommand(recoverCommand)
+	programmer.AddToCommand(recoverCommand)
+	recoverCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	recoverCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual recovery, just log out actions"))
+	recoverCommand.Flags().MarkHidden("dry-run")
+	return recoverCommand
+}
+
+type boardRecoverStep struct {
+	Description string `json:"description"`
+	Success     bool   `json:"success"`
+	Message     string `json:"message,omitempty"`
+}
+
+type boardRecoverResult struct {
+	Steps                 []*boardRecoverStep `json:"steps"`
+	Recovered             bool                `json:"recovered"`
+	BootloaderPortAddress string              `json:"bootloader_port_address,omitempty"`
+	Suggestions           []string            `json:"suggestions,omitempty"`
+}
+
+func newBoardRecoverResult(res *rpc.BoardRecoverResult) *boardRecoverResult {
+	out := &boardRecoverResult{
+		Recovered:             res.GetRecovered(),
+		BootloaderPortAddress: res.GetBootloaderPortAddress(),
+		Suggestions:           res.GetSuggestions(),
+	}
+	for _, step := range res.GetSteps() {
+		out.Steps = append(out.Steps, &boardRecoverStep{
+			Description: step.GetDescription(),
+			Success:     step.GetSuccess(),
+			Message:     step.GetMessage(),
+		})
+	}
+	return out
+}
+
+func (r *boardRecoverResult) Data() interface{} {
+	return r
+}
+
+func (r *boardRecoverResult) String() string {
+	res := ""
+	for _, step := range r.Steps {
+		status := feedback.GetTheme().Success.Sprint("OK")
+		if !step.Success {
+			status = feedback.GetTheme().Error.Sprint(tr("FAILED"))
+		}
+		res += fmt.Sprintf("[%s] %s: %s\n", status, step.Description, step.Message)
+	}
+	if r.Recovered {
+		res += "\n" + tr("Board recovered.") + "\n"
+	}
+	for _, suggestion := range r.Suggestions {
+		res += "- " + suggestion + "\n"
+	}
+	return res
+}
+
+func (r *boardRecoverResult) ErrorString() string {
+	return tr("The board could not be recovered.")
+}
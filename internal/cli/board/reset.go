@@ -0,0 +1,127 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/board"
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initResetCommand() *cobra.Command {
+	var (
+		fqbn    arguments.Fqbn
+		port    arguments.Port
+		method  string
+		verbose bool
+		dryRun  bool
+	)
+	resetCommand := &cobra.Command{
+		Use:   "reset",
+		Short: tr("Resets a board."),
+		Long: tr("Resets the board connected to the given port using the reset method of its platform: the reset tool of the platform, if any, " +
+			"a 1200-bps touch for the boards using it to start the bootloader, or a DTR pulse. " +
+			"If the FQBN is not given, it is detected from the port. Use --method to force a reset method."),
+		Example: "" +
+			"  " + os.Args[0] + " board reset -p /dev/ttyACM0\n" +
+			"  " + os.Args[0] + " board reset -p /dev/ttyUSB0 --method rts",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli board reset`")
+
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			if fqbn.String() == "" && method == "" {
+				fqbn.Set(detectResetFQBN(inst, discoveryPort, port.GetSearchTimeout()))
+			}
+
+			stdOut, stdErr, _ := feedback.OutputStreams()
+			res, err := upload.BoardReset(context.Background(), &rpc.BoardResetRequest{
+				Instance: inst,
+				Fqbn:     fqbn.String(),
+				Port:     discoveryPort,
+				Method:   method,
+				Verbose:  verbose,
+				DryRun:   dryRun,
+			}, stdOut, stdErr)
+			if err != nil {
+				feedback.Fatal(tr("Error resetting board: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(&boardResetResult{
+				Method:      res.GetMethod(),
+				PortAddress: res.GetPortAddress(),
+			})
+		},
+	}
+	fqbn.AddToCommand(resetCommand)
+	port.AddToCommand(resetCommand)
+	resetCommand.Flags().StringVar(&method, "method", "", tr("The reset method: tool, 1200bps, dtr or rts."))
+	resetCommand.RegisterFlagCompletionFunc("method", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"tool", "1200bps", "dtr", "rts"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	resetCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	resetCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual reset, just log out actions"))
+	resetCommand.Flags().MarkHidden("dry-run")
+	return resetCommand
+}
+
+// detectResetFQBN returns the FQBN of the board connected to the port, if it
+// is identified unambiguously, an unknown board is reset with a DTR pulse.
+func detectResetFQBN(inst *rpc.Instance, port *rpc.Port, timeout time.Duration) string {
+	detectedPorts, _, err := board.List(&rpc.BoardListRequest{
+		Instance: inst,
+		Timeout:  timeout.Milliseconds(),
+	})
+	if err != nil {
+		logrus.Warnf("Error detecting the board: %v", err)
+		return ""
+	}
+	for _, detectedPort := range detectedPorts {
+		p := detectedPort.GetPort()
+		if p.GetAddress() != port.GetAddress() || p.GetProtocol() != port.GetProtocol() {
+			continue
+		}
+		if boards := detectedPort.GetMatchingBoards(); len(boards) == 1 {
+			return boards[0].GetFqbn()
+		}
+	}
+	return ""
+}
+
+type boardResetResult struct {
+	Method      string `json:"method"`
+	PortAddress string `json:"port_address"`
+}
+
+func (r *boardResetResult) Data() interface{} {
+	return r
+}
+
+func (r *boardResetResult) String() string {
+	return tr("Board on port %[1]s reset using %[2]s", r.PortAddress, r.Method)
+}
@@ -33,21 +33,27 @@ import (
 )
 
 func initSearchCommand() *cobra.Command {
+	var filter boardFilterFlags
 	var searchCommand = &cobra.Command{
 		Use:   fmt.Sprintf("search [%s]", tr("boardname")),
 		Short: tr("Search for a board in the Boards Manager."),
 		Long:  tr(`Search for a board in the Boards Manager using the specified keywords.`),
 		Example: "" +
 			"  " + os.Args[0] + " board search\n" +
-			"  " + os.Args[0] + " board search zero",
+			"  " + os.Args[0] + " board search zero\n" +
+			"  " + os.Args[0] + " board search --arch samd --has-debug\n" +
+			"  " + os.Args[0] + " board search --connected-only",
 		Args: cobra.ArbitraryArgs,
-		Run:  runSearchCommand,
+		Run: func(cmd *cobra.Command, args []string) {
+			runSearchCommand(args, filter.ToRPC())
+		},
 	}
 	searchCommand.Flags().BoolVarP(&showHiddenBoard, "show-hidden", "a", false, tr("Show also boards marked as 'hidden' in the platform"))
+	filter.AddToCommand(searchCommand)
 	return searchCommand
 }
 
-func runSearchCommand(cmd *cobra.Command, args []string) {
+func runSearchCommand(args []string, filter *rpc.BoardFilter) {
 	inst := instance.CreateAndInit()
 
 	logrus.Info("Executing `arduino-cli board search`")
@@ -56,6 +62,7 @@ func runSearchCommand(cmd *cobra.Command, args []string) {
 		Instance:            inst,
 		SearchArgs:          strings.Join(args, " "),
 		IncludeHiddenBoards: showHiddenBoard,
+		Filter:              filter,
 	})
 	if err != nil {
 		feedback.Fatal(tr("Error searching boards: %v", err), feedback.ErrGeneric)
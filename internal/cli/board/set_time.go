@@ -0,0 +1,132 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/monitor"
+	"github.com/arduino/arduino-cli/internal/arduino/timesync"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initSetTimeCommand() *cobra.Command {
+	var (
+		fqbn     arguments.Fqbn
+		port     arguments.Port
+		protocol string
+		config   map[string]string
+		timeout  time.Duration
+		local    bool
+	)
+	setTimeCommand := &cobra.Command{
+		Use:   "set-time",
+		Short: tr("Sends the time of the computer to a board."),
+		Long: tr("Opens the monitor port of the board and sends the current time using a time synchronization protocol, "+
+			"so that the sketch can set its clock or RTC. The sketch must implement the protocol, the available protocols are: %s.",
+			strings.Join(timesync.Names(), ", ")),
+		Example: "" +
+			"  " + os.Args[0] + " board set-time -p /dev/ttyACM0 -b arduino:samd:mkrzero --config baudrate=115200\n" +
+			"  " + os.Args[0] + " board set-time -p /dev/ttyUSB0 --sync-protocol timelib --local",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runSetTimeCommand(&fqbn, &port, protocol, config, timeout, local)
+		},
+	}
+	fqbn.AddToCommand(setTimeCommand)
+	port.AddToCommand(setTimeCommand)
+	setTimeCommand.Flags().StringVar(&protocol, "sync-protocol", timesync.DefaultProtocol, tr("The time synchronization protocol implemented by the sketch: %s.", strings.Join(timesync.Names(), ", ")))
+	setTimeCommand.RegisterFlagCompletionFunc("sync-protocol", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return timesync.Names(), cobra.ShellCompDirectiveNoFileComp
+	})
+	arguments.AddKeyValuePFlag(setTimeCommand, &config, "config", "c", nil, tr("Configure the monitor port, for example baudrate=115200."))
+	setTimeCommand.Flags().DurationVar(&timeout, "timeout", 10*time.Second, tr("Max time to wait for the board."))
+	setTimeCommand.Flags().BoolVar(&local, "local", false, tr("Send the local time instead of UTC."))
+	return setTimeCommand
+}
+
+func runSetTimeCommand(fqbn *arguments.Fqbn, port *arguments.Port, protocolName string, config map[string]string, timeout time.Duration, local bool) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli board set-time`")
+
+	protocol, err := timesync.Find(protocolName)
+	if err != nil {
+		feedback.Fatal(err.Error(), feedback.ErrBadArgument)
+	}
+	discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+	if err != nil {
+		feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+	}
+
+	conf := &rpc.MonitorPortConfiguration{}
+	settings := make([]string, 0, len(config))
+	for setting := range config {
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+	for _, setting := range settings {
+		conf.Settings = append(conf.Settings, &rpc.MonitorPortSetting{SettingId: setting, Value: config[setting]})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	portProxy, _, err := monitor.Monitor(ctx, &rpc.MonitorPortOpenRequest{
+		Instance:          inst,
+		Port:              discoveryPort,
+		Fqbn:              fqbn.String(),
+		PortConfiguration: conf,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error opening the monitor port: %v", err), feedback.ErrGeneric)
+	}
+	defer portProxy.Close()
+
+	now := time.Now()
+	if !local {
+		now = now.UTC()
+	}
+	if err := protocol.SetTime(ctx, portProxy, now); err != nil {
+		feedback.Fatal(tr("Error setting the time of the board: %v", err), feedback.ErrGeneric)
+	}
+	feedback.PrintResult(&setTimeResult{
+		Port:     discoveryPort.GetAddress(),
+		Protocol: protocolName,
+		Time:     now.Format(time.RFC3339),
+	})
+}
+
+type setTimeResult struct {
+	Port     string `json:"port"`
+	Protocol string `json:"protocol"`
+	Time     string `json:"time"`
+}
+
+func (r *setTimeResult) Data() interface{} {
+	return r
+}
+
+func (r *setTimeResult) String() string {
+	return tr("Time of the board on %[1]s set to %[2]s", r.Port, r.Time)
+}
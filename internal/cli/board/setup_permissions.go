@@ -0,0 +1,123 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package board
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/board"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initSetupPermissionsCommand() *cobra.Command {
+	var port arguments.Port
+	var fix bool
+	setupPermissionsCommand := &cobra.Command{
+		Use:   fmt.Sprintf("setup-permissions -p <%s> [--fix]", tr("port")),
+		Short: tr("Checks the permissions needed to access a board port."),
+		Long:  tr("Checks if the current user can access the port of a connected board and prints the commands needed to fix the detected issues (missing udev rules, group membership, drivers). With --fix the commands are run: the ones that need administrative privileges are run only if sudo doesn't ask for a password (for example after running `sudo -v`), otherwise they must be run in a terminal."),
+		Example: "  " + os.Args[0] + " board setup-permissions -p /dev/ttyACM0\n" +
+			"  " + os.Args[0] + " board setup-permissions -p /dev/ttyACM0 --fix",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runSetupPermissionsCommand(&port, fix)
+		},
+	}
+	port.AddToCommand(setupPermissionsCommand)
+	setupPermissionsCommand.Flags().BoolVar(&fix, "fix", false, tr("Run the commands needed to fix the detected issues."))
+	return setupPermissionsCommand
+}
+
+func runSetupPermissionsCommand(portArgs *arguments.Port, fix bool) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli board setup-permissions`")
+
+	if !portArgs.IsPortFlagSet() {
+		feedback.Fatal(tr("No port specified, use the --port flag."), feedback.ErrBadArgument)
+	}
+	port, err := portArgs.GetPort(inst, "", "")
+	if err != nil {
+		feedback.Fatal(tr("Error discovering port: %v", err), feedback.ErrGeneric)
+	}
+
+	res, err := board.SetupPermissions(context.Background(), &rpc.BoardSetupPermissionsRequest{
+		Port:       port,
+		ApplyFixes: fix,
+	})
+	if err != nil {
+		feedback.FatalError(err, feedback.ErrGeneric)
+	}
+
+	out := &setupPermissionsResult{Port: port.GetAddress(), Issues: []*portPermissionIssueResult{}}
+	for _, issue := range res.GetIssues() {
+		out.Issues = append(out.Issues, &portPermissionIssueResult{
+			ID:          issue.GetId(),
+			Description: issue.GetDescription(),
+			FixCommands: issue.GetFixCommands(),
+			Fixed:       issue.GetFixed(),
+			FixError:    issue.GetFixError(),
+		})
+	}
+	feedback.PrintResult(out)
+}
+
+type portPermissionIssueResult struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	FixCommands []string `json:"fix_commands,omitempty"`
+	Fixed       bool     `json:"fixed,omitempty"`
+	FixError    string   `json:"fix_error,omitempty"`
+}
+
+type setupPermissionsResult struct {
+	Port   string                       `json:"port"`
+	Issues []*portPermissionIssueResult `json:"issues"`
+}
+
+func (r *setupPermissionsResult) Data() interface{} {
+	return r
+}
+
+func (r *setupPermissionsResult) String() string {
+	if len(r.Issues) == 0 {
+		return tr("Port %s is accessible, no issues found.", r.Port)
+	}
+	res := ""
+	for _, issue := range r.Issues {
+		res += fmt.Sprintln("- " + issue.Description)
+		switch {
+		case issue.Fixed:
+			res += fmt.Sprintln("  " + tr("Fixed."))
+		case issue.FixError != "":
+			res += fmt.Sprintln("  " + tr("Error applying fix: %v", issue.FixError))
+			fallthrough
+		case len(issue.FixCommands) > 0:
+			res += fmt.Sprintln("  " + tr("Run the following commands to fix it:"))
+			for _, command := range issue.FixCommands {
+				res += fmt.Sprintln("    " + command)
+			}
+		}
+	}
+	return strings.TrimRight(res, "\n")
+}
@@ -69,7 +69,8 @@ func runBootloaderCommand(command *cobra.Command, args []string) {
 	logrus.Info("Executing `arduino-cli burn-bootloader`")
 
 	// We don't need a Sketch to upload a board's bootloader
-	discoveryPort, err := port.GetPort(instance, "", "")
+	programmer.SetDefaultFromBoardAlias(&fqbn)
+	discoveryPort, err := port.GetPort(instance, fqbn.DefaultPort(), "")
 	if err != nil {
 		feedback.Fatal(tr("Error during Upload: %v", err), feedback.ErrGeneric)
 	}
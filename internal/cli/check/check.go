@@ -0,0 +1,296 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands"
+	"github.com/arduino/arduino-cli/commands/compile"
+	"github.com/arduino/arduino-cli/commands/core"
+	"github.com/arduino/arduino-cli/commands/lib"
+	"github.com/arduino/arduino-cli/internal/arduino/cores"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `check` command
+func NewCommand() *cobra.Command {
+	var noCompile bool
+	checkCommand := &cobra.Command{
+		Use:   "check [" + tr("SKETCH_PATH") + "]",
+		Short: tr("Runs a set of checks on a sketch and its project file."),
+		Long: tr("Runs a set of checks on a sketch: validates the sketch and the sketch.yaml project file, " +
+			"verifies that the platforms and libraries pinned in the profiles are available in the indexes " +
+			"and compiles the sketch with each profile whose platforms and libraries are installed. " +
+			"The command fails if any of the checks fails."),
+		Example: "" +
+			"  " + os.Args[0] + " check\n" +
+			"  " + os.Args[0] + " check --no-compile /home/user/Arduino/MySketch\n",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			runCheckCommand(path, noCompile)
+		},
+	}
+	checkCommand.Flags().BoolVar(&noCompile, "no-compile", false, tr("Do not compile the sketch with the profiles."))
+	return checkCommand
+}
+
+func runCheckCommand(path string, noCompile bool) {
+	logrus.Info("Executing `arduino-cli check`")
+
+	sketchPath := arguments.InitSketchPath(path)
+	res := &checkResult{Success: true}
+
+	sk, err := sketch.New(sketchPath)
+	if err != nil {
+		res.add("sketch", checkFail, err.Error())
+		feedback.FatalResult(res, feedback.ErrGeneric)
+	}
+	res.add("sketch", checkPass, tr("Sketch %s is valid", sk.Name))
+
+	if !sk.GetProjectPath().Exist() {
+		res.add("project-file", checkWarning, tr("The sketch has no project file (sketch.yaml)"))
+	} else {
+		res.add("project-file", checkPass, tr("Project file %s is valid", sk.GetProjectPath().Base()))
+	}
+
+	lintProjectMetadata(res, sk.Project)
+
+	if len(sk.Project.Profiles) > 0 {
+		inst := instance.CreateAndInit()
+		for _, profile := range sk.Project.Profiles {
+			checkProfilePins(res, inst, profile)
+		}
+		destroyInstance(inst)
+	}
+
+	if !noCompile {
+		for _, profile := range sk.Project.Profiles {
+			checkName := "compile/" + profile.Name
+			// The check must not install anything, the profiles with missing
+			// dependencies are not compiled
+			if missing := profileMissingDependencies(profile); len(missing) > 0 {
+				res.add(checkName, checkWarning, tr("Compilation skipped, the dependencies of the profile are not installed: %s", strings.Join(missing, ", ")))
+				continue
+			}
+			inst, _ := instance.CreateAndInitWithProfile(profile.Name, sketchPath)
+			stdOut, stdErr, _ := feedback.NewBufferedStreams()
+			_, err := compile.Compile(context.Background(), &rpc.CompileRequest{
+				Instance:   inst,
+				Fqbn:       profile.FQBN,
+				SketchPath: sketchPath.String(),
+			}, stdOut, stdErr, nil)
+			if err != nil {
+				res.add(checkName, checkFail, tr("Compilation failed: %v", err))
+			} else {
+				res.add(checkName, checkPass, tr("Compiled successfully for %s", profile.FQBN))
+			}
+			destroyInstance(inst)
+		}
+	}
+
+	if !res.Success {
+		feedback.FatalResult(res, feedback.ErrGeneric)
+	}
+	feedback.PrintResult(res)
+}
+
+// profileMissingDependencies returns the platforms and the libraries of the
+// profile that are not installed in the profiles cache.
+func profileMissingDependencies(profile *sketch.Profile) []string {
+	cacheDir := configuration.ProfilesCacheDir(configuration.Settings)
+	missing := []string{}
+	for _, platform := range profile.Platforms {
+		if !cacheDir.Join(platform.InternalUniqueIdentifier()).IsDir() {
+			missing = append(missing, platform.String())
+		}
+	}
+	for _, library := range profile.Libraries {
+		if !cacheDir.Join(library.InternalUniqueIdentifier(), library.Library).IsDir() {
+			missing = append(missing, library.String())
+		}
+	}
+	return missing
+}
+
+// destroyInstance releases the instance, the errors are only logged since
+// the check results are not affected.
+func destroyInstance(inst *rpc.Instance) {
+	if _, err := commands.Destroy(context.Background(), &rpc.DestroyRequest{Instance: inst}); err != nil {
+		logrus.WithError(err).Warn("Error destroying instance")
+	}
+}
+
+// lintProjectMetadata checks the consistency of the fields in the project file.
+func lintProjectMetadata(res *checkResult, project *sketch.Project) {
+	profileNames := map[string]bool{}
+	for _, profile := range project.Profiles {
+		profileNames[profile.Name] = true
+		name := "profile/" + profile.Name
+		if profile.FQBN == "" {
+			res.add(name, checkFail, tr("Profile %s has no FQBN", profile.Name))
+		} else if _, err := cores.ParseFQBN(profile.FQBN); err != nil {
+			res.add(name, checkFail, tr("Profile %[1]s has an invalid FQBN: %[2]v", profile.Name, err))
+		} else if len(profile.Platforms) == 0 {
+			res.add(name, checkFail, tr("Profile %s does not declare any platform", profile.Name))
+		} else {
+			res.add(name, checkPass, tr("Profile %s is valid", profile.Name))
+		}
+	}
+
+	if project.DefaultProfile != "" && !profileNames[project.DefaultProfile] {
+		res.add("default-profile", checkFail, tr("The default profile %s is not defined", project.DefaultProfile))
+	}
+	if project.DefaultFqbn != "" {
+		if _, err := cores.ParseFQBN(project.DefaultFqbn); err != nil {
+			res.add("default-fqbn", checkFail, tr("The default FQBN is invalid: %v", err))
+		}
+	}
+	if project.DefaultProtocol != "" && project.DefaultPort == "" {
+		res.add("default-port", checkWarning, tr("A default protocol is set without a default port"))
+	}
+}
+
+// checkProfilePins verifies that the platforms and libraries pinned in the
+// profile are available in the indexes.
+func checkProfilePins(res *checkResult, inst *rpc.Instance, profile *sketch.Profile) {
+	for _, platform := range profile.Platforms {
+		name := "platform/" + profile.Name + "/" + platform.Packager + ":" + platform.Architecture
+		if platform.PlatformIndexURL != nil {
+			res.add(name, checkWarning, tr("Platform %s uses a third-party index and can not be verified", platform))
+			continue
+		}
+		platformID := platform.Packager + ":" + platform.Architecture
+		searchRes, err := core.PlatformSearch(&rpc.PlatformSearchRequest{Instance: inst, SearchArgs: platformID})
+		if err != nil {
+			res.add(name, checkFail, tr("Error searching platform %[1]s: %[2]v", platformID, err))
+			continue
+		}
+		found := false
+		for _, summary := range searchRes.GetSearchOutput() {
+			if summary.GetMetadata().GetId() != platformID {
+				continue
+			}
+			if _, ok := summary.GetReleases()[platform.Version.String()]; ok {
+				found = true
+			}
+		}
+		if found {
+			res.add(name, checkPass, tr("Platform %s is available", platform))
+		} else {
+			res.add(name, checkFail, tr("Platform %s not found in the index", platform))
+		}
+	}
+
+	for _, library := range profile.Libraries {
+		name := "library/" + profile.Name + "/" + library.Library
+		if library.URL != nil {
+			// The libraries provided by an URL are not in the index
+			res.add(name, checkPass, tr("Library %s is provided by its URL", library))
+			continue
+		}
+		searchRes, err := lib.LibrarySearch(context.Background(), &rpc.LibrarySearchRequest{Instance: inst, SearchArgs: library.Library})
+		if err != nil {
+			res.add(name, checkFail, tr("Error searching library %[1]s: %[2]v", library.Library, err))
+			continue
+		}
+		found := false
+		for _, searched := range searchRes.GetLibraries() {
+			if searched.GetName() != library.Library {
+				continue
+			}
+			if _, ok := searched.GetReleases()[library.Version.String()]; ok {
+				found = true
+			}
+		}
+		if found {
+			res.add(name, checkPass, tr("Library %s is available", library))
+		} else {
+			res.add(name, checkFail, tr("Library %s not found in the index", library))
+		}
+	}
+}
+
+type checkStatus string
+
+const (
+	checkPass    checkStatus = "pass"
+	checkWarning checkStatus = "warning"
+	checkFail    checkStatus = "fail"
+)
+
+type checkItem struct {
+	Name    string      `json:"name"`
+	Status  checkStatus `json:"status"`
+	Message string      `json:"message"`
+}
+
+type checkResult struct {
+	Checks  []*checkItem `json:"checks"`
+	Success bool         `json:"success"`
+}
+
+func (r *checkResult) add(name string, status checkStatus, msg string) {
+	r.Checks = append(r.Checks, &checkItem{Name: name, Status: status, Message: msg})
+	if status == checkFail {
+		r.Success = false
+	}
+}
+
+func (r *checkResult) Data() interface{} {
+	return r
+}
+
+func (r *checkResult) String() string {
+	statusColors := map[checkStatus]*color.Color{
+		checkPass:    feedback.GetTheme().Success,
+		checkWarning: feedback.GetTheme().Warning,
+		checkFail:    feedback.GetTheme().Error,
+	}
+	t := table.New()
+	t.SetHeader(tr("Check"), tr("Status"), tr("Message"))
+	for _, check := range r.Checks {
+		t.AddRow(check.Name, table.NewCell(strings.ToUpper(string(check.Status)), statusColors[check.Status]), check.Message)
+	}
+	res := t.Render()
+	if r.Success {
+		res += fmt.Sprintln() + tr("All checks passed.")
+	}
+	return res
+}
+
+func (r *checkResult) ErrorString() string {
+	return tr("Some checks failed.")
+}
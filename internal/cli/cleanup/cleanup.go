@@ -0,0 +1,141 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `cleanup` command
+func NewCommand() *cobra.Command {
+	var dryRun bool
+	cleanupCommand := &cobra.Command{
+		Use:   "cleanup",
+		Short: tr("Removes the unused files from the data directory."),
+		Long: tr("Removes the platform versions superseded by another installed version, the tools not required by any installed platform, " +
+			"the archives in the downloads directory not needed by the installed platforms and tools, and the build caches not used for longer than `build_cache.ttl`."),
+		Example: "  " + os.Args[0] + " cleanup --dry-run\n" +
+			"  " + os.Args[0] + " cleanup",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runCleanupCommand(dryRun)
+		},
+	}
+	cleanupCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Show the files that would be removed without removing them."))
+	return cleanupCommand
+}
+
+func runCleanupCommand(dryRun bool) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli cleanup`")
+
+	resp, err := commands.Cleanup(context.Background(), &rpc.CleanupRequest{Instance: inst, DryRun: dryRun})
+	if err != nil {
+		feedback.Fatal(tr("Error cleaning up the data directory: %v", err), feedback.ErrGeneric)
+	}
+
+	res := &cleanupResult{DryRun: dryRun, Categories: []*cleanupCategory{}}
+	for _, category := range resp.GetCategories() {
+		res.Categories = append(res.Categories, &cleanupCategory{
+			Name:  cleanupCategoryName(category.GetType()),
+			Items: category.GetItems(),
+			Size:  category.GetSize(),
+		})
+		res.TotalSize += category.GetSize()
+	}
+	feedback.PrintResult(res)
+}
+
+func cleanupCategoryName(categoryType rpc.CleanupCategoryType) string {
+	switch categoryType {
+	case rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_PLATFORMS:
+		return "platforms"
+	case rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_TOOLS:
+		return "tools"
+	case rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_DOWNLOADS:
+		return "downloads"
+	case rpc.CleanupCategoryType_CLEANUP_CATEGORY_TYPE_BUILD_CACHE:
+		return "build_cache"
+	}
+	return "unknown"
+}
+
+func cleanupCategoryDescription(name string) string {
+	switch name {
+	case "platforms":
+		return tr("Unused platform versions")
+	case "tools":
+		return tr("Orphaned tools")
+	case "downloads":
+		return tr("Stale downloaded archives")
+	case "build_cache":
+		return tr("Expired build caches")
+	}
+	return name
+}
+
+type cleanupCategory struct {
+	Name  string   `json:"name"`
+	Items []string `json:"items"`
+	Size  int64    `json:"size"`
+}
+
+type cleanupResult struct {
+	DryRun     bool               `json:"dry_run"`
+	Categories []*cleanupCategory `json:"categories"`
+	TotalSize  int64              `json:"total_size"`
+}
+
+func (r *cleanupResult) Data() interface{} {
+	return r
+}
+
+func (r *cleanupResult) String() string {
+	t := table.New()
+	t.SetHeader(tr("Category"), tr("Items"), tr("Size"))
+	details := ""
+	for _, category := range r.Categories {
+		t.AddRow(cleanupCategoryDescription(category.Name), fmt.Sprint(len(category.Items)), feedback.FormatSize(category.Size))
+		if len(category.Items) == 0 {
+			continue
+		}
+		details += "\n" + cleanupCategoryDescription(category.Name) + ":\n"
+		for _, item := range category.Items {
+			details += "  " + item + "\n"
+		}
+	}
+	res := t.Render() + details + "\n"
+	if r.DryRun {
+		res += tr("%s would be freed.", feedback.FormatSize(r.TotalSize))
+	} else {
+		res += tr("%s freed.", feedback.FormatSize(r.TotalSize))
+	}
+	return strings.TrimSpace(res)
+}
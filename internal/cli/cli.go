@@ -23,22 +23,40 @@ import (
 	"strings"
 
 	"github.com/arduino/arduino-cli/commands/updatecheck"
+	"github.com/arduino/arduino-cli/internal/cli/ble"
 	"github.com/arduino/arduino-cli/internal/cli/board"
 	"github.com/arduino/arduino-cli/internal/cli/burnbootloader"
 	"github.com/arduino/arduino-cli/internal/cli/cache"
+	"github.com/arduino/arduino-cli/internal/cli/check"
+	"github.com/arduino/arduino-cli/internal/cli/cleanup"
+	"github.com/arduino/arduino-cli/internal/cli/cloud"
+	"github.com/arduino/arduino-cli/internal/cli/compat"
 	"github.com/arduino/arduino-cli/internal/cli/compile"
 	"github.com/arduino/arduino-cli/internal/cli/completion"
 	"github.com/arduino/arduino-cli/internal/cli/config"
 	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/cli/core"
 	"github.com/arduino/arduino-cli/internal/cli/daemon"
+	"github.com/arduino/arduino-cli/internal/cli/datalog"
 	"github.com/arduino/arduino-cli/internal/cli/debug"
+	"github.com/arduino/arduino-cli/internal/cli/deployments"
+	"github.com/arduino/arduino-cli/internal/cli/du"
+	"github.com/arduino/arduino-cli/internal/cli/eeprom"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
 	"github.com/arduino/arduino-cli/internal/cli/generatedocs"
 	"github.com/arduino/arduino-cli/internal/cli/lib"
+	"github.com/arduino/arduino-cli/internal/cli/loopback"
 	"github.com/arduino/arduino-cli/internal/cli/monitor"
 	"github.com/arduino/arduino-cli/internal/cli/outdated"
+	"github.com/arduino/arduino-cli/internal/cli/pipeline"
+	"github.com/arduino/arduino-cli/internal/cli/portability"
+	"github.com/arduino/arduino-cli/internal/cli/programmer"
 	"github.com/arduino/arduino-cli/internal/cli/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/sketchbook"
+	"github.com/arduino/arduino-cli/internal/cli/symbolize"
+	"github.com/arduino/arduino-cli/internal/cli/tcpmonitor"
+	"github.com/arduino/arduino-cli/internal/cli/tui"
 	"github.com/arduino/arduino-cli/internal/cli/update"
 	"github.com/arduino/arduino-cli/internal/cli/updater"
 	"github.com/arduino/arduino-cli/internal/cli/upgrade"
@@ -47,7 +65,9 @@ import (
 	"github.com/arduino/arduino-cli/internal/i18n"
 	"github.com/arduino/arduino-cli/internal/inventory"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/arduino-cli/sandbox"
 	versioninfo "github.com/arduino/arduino-cli/version"
+	"github.com/arduino/go-paths-helper"
 	"github.com/fatih/color"
 	"github.com/mattn/go-colorable"
 	"github.com/rifflock/lfshook"
@@ -57,10 +77,14 @@ import (
 )
 
 var (
-	verbose      bool
-	jsonOutput   bool
-	outputFormat string
-	configFile   string
+	verbose       bool
+	jsonOutput    bool
+	outputFormat  string
+	tableFormat   string
+	tableColumns  []string
+	tableNoHeader bool
+	configFile    string
+	sandboxDir    string
 )
 
 // NewCommand creates a new ArduinoCli command root
@@ -121,22 +145,39 @@ func NewCommand() *cobra.Command {
 
 // this is here only for testing
 func createCliCommandTree(cmd *cobra.Command) {
+	cmd.AddCommand(ble.NewCommand())
 	cmd.AddCommand(board.NewCommand())
 	cmd.AddCommand(cache.NewCommand())
+	cmd.AddCommand(check.NewCommand())
+	cmd.AddCommand(cleanup.NewCommand())
+	cmd.AddCommand(cloud.NewCommand())
+	cmd.AddCommand(compat.NewCommand())
+	cmd.AddCommand(portability.NewCommand())
 	cmd.AddCommand(compile.NewCommand())
 	cmd.AddCommand(completion.NewCommand())
 	cmd.AddCommand(config.NewCommand())
 	cmd.AddCommand(core.NewCommand())
+	cmd.AddCommand(eeprom.NewCommand())
 	cmd.AddCommand(daemon.NewCommand())
+	cmd.AddCommand(du.NewCommand())
 	cmd.AddCommand(generatedocs.NewCommand())
 	cmd.AddCommand(lib.NewCommand())
+	cmd.AddCommand(datalog.NewCommand())
+	cmd.AddCommand(loopback.NewCommand())
 	cmd.AddCommand(monitor.NewCommand())
 	cmd.AddCommand(outdated.NewCommand())
+	cmd.AddCommand(pipeline.NewCommand())
+	cmd.AddCommand(programmer.NewCommand())
 	cmd.AddCommand(sketch.NewCommand())
+	cmd.AddCommand(sketchbook.NewCommand())
+	cmd.AddCommand(symbolize.NewCommand())
+	cmd.AddCommand(tcpmonitor.NewCommand())
+	cmd.AddCommand(tui.NewCommand())
 	cmd.AddCommand(update.NewCommand())
 	cmd.AddCommand(upgrade.NewCommand())
 	cmd.AddCommand(upload.NewCommand())
 	cmd.AddCommand(debug.NewCommand())
+	cmd.AddCommand(deployments.NewCommand())
 	cmd.AddCommand(burnbootloader.NewCommand())
 	cmd.AddCommand(version.NewCommand())
 	cmd.AddCommand(feedback.NewCommand())
@@ -162,12 +203,36 @@ func createCliCommandTree(cmd *cobra.Command) {
 	})
 	cmd.Flag("format").Hidden = true
 	cmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, tr("Print the output in JSON format."))
+	validTableFormats := []string{"text", "tsv", "csv"}
+	cmd.PersistentFlags().StringVar(&tableFormat, "table-format", "text", tr("The format of the tables in the command output, can be: %s", strings.Join(validTableFormats, ", ")))
+	cmd.RegisterFlagCompletionFunc("table-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validTableFormats, cobra.ShellCompDirectiveDefault
+	})
+	cmd.PersistentFlags().StringSliceVar(&tableColumns, "columns", nil, tr("Comma-separated list of the columns to print in the tables of the command output, by header or by position."))
+	cmd.PersistentFlags().BoolVar(&tableNoHeader, "no-header", false, tr("Do not print the header of the tables in the command output."))
 	cmd.PersistentFlags().StringVar(&configFile, "config-file", "", tr("The custom config file (if not specified the default will be used)."))
+	cmd.PersistentFlags().StringVar(&sandboxDir, "sandbox", "", tr("Run in a self-contained directory populated with a fake platform and library, useful for testing."))
 	cmd.PersistentFlags().StringSlice("additional-urls", []string{}, tr("Comma-separated list of additional URLs for the Boards Manager."))
 	cmd.PersistentFlags().Bool("no-color", false, "Disable colored output.")
 	configuration.BindFlags(cmd, configuration.Settings)
 }
 
+// setupSandbox creates (or reuses) a sandbox in the given directory and
+// points the data, user and downloads directories to it.
+func setupSandbox(dir string) {
+	sb, err := sandbox.New(paths.New(dir))
+	if err == nil {
+		err = sb.Populate()
+	}
+	if err != nil {
+		feedback.Fatal(tr("Error creating sandbox: %v", err), feedback.ErrGeneric)
+	}
+	configuration.Settings.Set("directories.data", sb.DataDir.String())
+	configuration.Settings.Set("directories.user", sb.UserDir.String())
+	configuration.Settings.Set("directories.downloads", sb.DownloadsDir.String())
+	configuration.Settings.Set("updater.enable_notification", false)
+}
+
 // convert the string passed to the `--log-level` option to the corresponding
 // logrus formal level.
 func toLogLevel(s string) (t logrus.Level, found bool) {
@@ -187,6 +252,10 @@ func toLogLevel(s string) (t logrus.Level, found bool) {
 func preRun(cmd *cobra.Command, args []string) {
 	configFile := configuration.Settings.ConfigFileUsed()
 
+	if sandboxDir != "" {
+		setupSandbox(sandboxDir)
+	}
+
 	// initialize inventory
 	err := inventory.Init(configuration.DataDir(configuration.Settings).String())
 	if err != nil {
@@ -194,7 +263,12 @@ func preRun(cmd *cobra.Command, args []string) {
 	}
 
 	// https://no-color.org/
-	color.NoColor = configuration.Settings.GetBool("output.no_color") || os.Getenv("NO_COLOR") != ""
+	color.NoColor = !feedback.ColorsEnabled(configuration.Settings.GetBool("output.no_color"))
+	theme, err := feedback.ParseTheme(configuration.Settings.GetStringMapString("output.theme"))
+	if err != nil {
+		feedback.Fatal(tr("Invalid output theme: %v", err), feedback.ErrBadArgument)
+	}
+	feedback.SetTheme(theme)
 
 	// Set default feedback output to colorable
 	feedback.SetOut(colorable.NewColorableStdout())
@@ -258,6 +332,21 @@ func preRun(cmd *cobra.Command, args []string) {
 	// use the output format to configure the Feedback
 	feedback.SetFormat(format)
 
+	// configure the rendering of the tables
+	tblFormat, found := table.ParseFormat(tableFormat)
+	if !found {
+		feedback.Fatal(tr("Invalid table format: %s", tableFormat), feedback.ErrBadArgument)
+	}
+	table.SetFormat(tblFormat)
+	table.SetColumns(tableColumns)
+	table.SetNoHeader(tableNoHeader)
+
+	// check the command is allowed by the restrictions in the configuration
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())
+	if err := configuration.CheckCommandAllowed(configuration.Settings, command); err != nil {
+		feedback.FatalError(err, feedback.ErrGeneric)
+	}
+
 	//
 	// Print some status info and check command is consistent
 	//
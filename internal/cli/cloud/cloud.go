@@ -0,0 +1,135 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cloud
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cloud"
+	"github.com/arduino/arduino-cli/internal/arduino/httpclient"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `cloud` command
+func NewCommand() *cobra.Command {
+	cloudCommand := &cobra.Command{
+		Use:   "cloud",
+		Short: tr("Arduino Cloud commands."),
+		Long:  tr("Synchronizes sketches between the local sketchbook and Arduino Cloud. The token used to authenticate is read from the `cloud.token` setting or from the ARDUINO_CLOUD_TOKEN environment variable."),
+		Example: "  " + os.Args[0] + " cloud push /home/user/Arduino/MySketch\n" +
+			"  " + os.Args[0] + " cloud pull MySketch --dry-run",
+	}
+
+	cloudCommand.AddCommand(initPushCommand())
+	cloudCommand.AddCommand(initPullCommand())
+
+	return cloudCommand
+}
+
+func newClient() *cloud.Client {
+	httpClient, err := httpclient.New()
+	if err != nil {
+		feedback.Fatal(tr("Error connecting to Arduino Cloud: %v", err), feedback.ErrNetwork)
+	}
+	client, err := cloud.NewClient(
+		configuration.Settings.GetString("cloud.api_url"),
+		configuration.Settings.GetString("cloud.token"),
+		httpClient)
+	if err != nil {
+		feedback.Fatal(tr("Error connecting to Arduino Cloud: %v", err), feedback.ErrBadArgument)
+	}
+	return client
+}
+
+// findRemoteSketch returns the cloud sketch tracked by the given sync state
+// or, if the sketch was never synchronized, the one with the given name.
+func findRemoteSketch(client *cloud.Client, state *cloud.SyncState, name string) *cloud.Sketch {
+	var remote *cloud.Sketch
+	var err error
+	if state != nil && state.SketchID != "" {
+		remote, err = client.GetSketch(context.Background(), state.SketchID)
+	} else {
+		remote, err = client.FindSketch(context.Background(), name)
+	}
+	if err != nil {
+		feedback.Fatal(tr("Error reading sketch %[1]s from Arduino Cloud: %[2]v", name, err), feedback.ErrNetwork)
+	}
+	return remote
+}
+
+type syncChange struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Diff string `json:"diff,omitempty"`
+}
+
+type syncResult struct {
+	Sketch    string        `json:"sketch"`
+	Path      string        `json:"path"`
+	DryRun    bool          `json:"dry_run"`
+	Changes   []*syncChange `json:"changes"`
+	Conflicts []string      `json:"conflicts,omitempty"`
+	done      string
+}
+
+func newSyncResult(name, path string, dryRun bool, changes []*cloud.Change, conflicts []string) *syncResult {
+	res := &syncResult{
+		Sketch:    name,
+		Path:      path,
+		DryRun:    dryRun,
+		Changes:   []*syncChange{},
+		Conflicts: conflicts,
+	}
+	for _, c := range changes {
+		change := &syncChange{Path: c.Path, Type: string(c.Type)}
+		if dryRun {
+			change.Diff = c.UnifiedDiff()
+		}
+		res.Changes = append(res.Changes, change)
+	}
+	return res
+}
+
+func (r *syncResult) Data() interface{} {
+	return r
+}
+
+func (r *syncResult) String() string {
+	if len(r.Changes) == 0 {
+		return tr("Sketch %s is in sync with Arduino Cloud.", r.Sketch)
+	}
+	if !r.DryRun {
+		return r.done
+	}
+	var res strings.Builder
+	for _, c := range r.Changes {
+		res.WriteString(tr("%[1]s: %[2]s", c.Type, c.Path) + "\n")
+	}
+	for _, c := range r.Changes {
+		res.WriteString("\n" + c.Diff)
+	}
+	if len(r.Conflicts) > 0 {
+		res.WriteString("\n" + tr("The following files have been modified on both sides: %s", strings.Join(r.Conflicts, ", ")) + "\n")
+	}
+	return strings.TrimSuffix(res.String(), "\n")
+}
@@ -0,0 +1,104 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cloud
+
+import (
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cloud"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initPullCommand() *cobra.Command {
+	var dest string
+	var dryRun, force bool
+	pullCommand := &cobra.Command{
+		Use:   "pull NAME",
+		Short: tr("Downloads a sketch from Arduino Cloud."),
+		Long:  tr("Downloads a sketch from Arduino Cloud into the sketchbook, or into the folder given with --dest. If the local copy has been modified since the last synchronization the pull is refused, unless --force is given."),
+		Example: "  " + os.Args[0] + " cloud pull MySketch\n" +
+			"  " + os.Args[0] + " cloud pull MySketch --dest /tmp/MySketch --dry-run",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runPullCommand(args[0], dest, dryRun, force)
+		},
+	}
+	pullCommand.Flags().StringVar(&dest, "dest", "", tr("The folder where the sketch is downloaded, defaults to the sketch folder in the sketchbook."))
+	pullCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Show the changes that would be downloaded without applying them."))
+	pullCommand.Flags().BoolVar(&force, "force", false, tr("Overwrite the local files modified since the last synchronization."))
+	return pullCommand
+}
+
+func runPullCommand(name, dest string, dryRun, force bool) {
+	logrus.Info("Executing `arduino-cli cloud pull`")
+	var sketchDir *paths.Path
+	if dest != "" {
+		sketchDir = paths.New(dest)
+	} else {
+		sketchDir = paths.New(configuration.Settings.GetString("directories.User")).Join(name)
+	}
+
+	state, err := cloud.LoadSyncState(sketchDir)
+	if err != nil {
+		feedback.Fatal(err.Error(), feedback.ErrGeneric)
+	}
+	localFiles := map[string][]byte{}
+	if sketchDir.Exist() {
+		localFiles, err = cloud.LocalFiles(sketchDir)
+		if err != nil {
+			feedback.Fatal(tr("Error opening sketch: %v", err), feedback.ErrGeneric)
+		}
+	}
+
+	client := newClient()
+	remote := findRemoteSketch(client, state, name)
+	if remote == nil {
+		feedback.Fatal(tr("Sketch %s not found in Arduino Cloud", name), feedback.ErrBadArgument)
+	}
+	remoteFiles, err := cloud.RemoteFiles(remote)
+	if err != nil {
+		feedback.Fatal(tr("Error reading sketch %[1]s from Arduino Cloud: %[2]v", name, err), feedback.ErrGeneric)
+	}
+
+	changes := cloud.Diff(remoteFiles, localFiles)
+	conflicts := cloud.FindConflicts(state, remoteFiles, localFiles)
+	res := newSyncResult(name, sketchDir.String(), dryRun, changes, conflicts)
+	if dryRun {
+		feedback.PrintResult(res)
+		return
+	}
+	if len(conflicts) > 0 && !force {
+		feedback.Fatal(tr("The following files have been modified locally since the last synchronization: %s", strings.Join(conflicts, ", "))+"\n"+
+			tr("Use `push` to upload the changes or --force to overwrite them."), feedback.ErrGeneric)
+	}
+
+	if err := sketchDir.MkdirAll(); err != nil {
+		feedback.Fatal(tr("Error creating sketch folder: %v", err), feedback.ErrGeneric)
+	}
+	if err := cloud.ApplyChanges(sketchDir, changes); err != nil {
+		feedback.Fatal(tr("Error writing sketch files: %v", err), feedback.ErrGeneric)
+	}
+	if err := cloud.SaveSyncState(sketchDir, remote, remoteFiles); err != nil {
+		feedback.Fatal(tr("Error saving cloud sync state: %v", err), feedback.ErrGeneric)
+	}
+	res.done = tr("Sketch %[1]s pulled from Arduino Cloud into %[2]s (%[3]d files changed).", name, sketchDir, len(changes))
+	feedback.PrintResult(res)
+}
@@ -0,0 +1,103 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package cloud
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cloud"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initPushCommand() *cobra.Command {
+	var dryRun, force bool
+	pushCommand := &cobra.Command{
+		Use:   "push [SKETCH_PATH]",
+		Short: tr("Uploads a local sketch to Arduino Cloud."),
+		Long:  tr("Uploads a local sketch to Arduino Cloud, creating it if it doesn't exist. If the cloud copy has been modified since the last synchronization the push is refused, unless --force is given."),
+		Example: "  " + os.Args[0] + " cloud push\n" +
+			"  " + os.Args[0] + " cloud push /home/user/Arduino/MySketch --dry-run",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			sketchPath := ""
+			if len(args) > 0 {
+				sketchPath = args[0]
+			}
+			runPushCommand(sketchPath, dryRun, force)
+		},
+	}
+	pushCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Show the changes that would be uploaded without uploading them."))
+	pushCommand.Flags().BoolVar(&force, "force", false, tr("Overwrite the files modified in the cloud since the last synchronization."))
+	return pushCommand
+}
+
+func runPushCommand(sketchArg string, dryRun, force bool) {
+	logrus.Info("Executing `arduino-cli cloud push`")
+	sketchDir := arguments.InitSketchPath(sketchArg)
+	if !sketchDir.IsDir() {
+		sketchDir = sketchDir.Parent()
+	}
+	name := sketchDir.Base()
+
+	localFiles, err := cloud.LocalFiles(sketchDir)
+	if err != nil {
+		feedback.Fatal(tr("Error opening sketch: %v", err), feedback.ErrGeneric)
+	}
+	state, err := cloud.LoadSyncState(sketchDir)
+	if err != nil {
+		feedback.Fatal(err.Error(), feedback.ErrGeneric)
+	}
+
+	client := newClient()
+	remote := findRemoteSketch(client, state, name)
+	remoteFiles, err := cloud.RemoteFiles(remote)
+	if err != nil {
+		feedback.Fatal(tr("Error reading sketch %[1]s from Arduino Cloud: %[2]v", name, err), feedback.ErrGeneric)
+	}
+
+	changes := cloud.Diff(localFiles, remoteFiles)
+	conflicts := cloud.FindConflicts(state, localFiles, remoteFiles)
+	res := newSyncResult(name, sketchDir.String(), dryRun, changes, conflicts)
+	if dryRun {
+		feedback.PrintResult(res)
+		return
+	}
+	if len(conflicts) > 0 && !force {
+		feedback.Fatal(tr("The following files have been modified in Arduino Cloud since the last synchronization: %s", strings.Join(conflicts, ", "))+"\n"+
+			tr("Use `pull` to get the changes or --force to overwrite them."), feedback.ErrGeneric)
+	}
+
+	if remote == nil || len(changes) > 0 {
+		upload := &cloud.Sketch{Name: name, Files: cloud.EncodeFiles(localFiles)}
+		if remote != nil {
+			upload.ID = remote.ID
+		}
+		remote, err = client.SaveSketch(context.Background(), upload)
+		if err != nil {
+			feedback.Fatal(tr("Error uploading sketch %[1]s to Arduino Cloud: %[2]v", name, err), feedback.ErrNetwork)
+		}
+	}
+	if err := cloud.SaveSyncState(sketchDir, remote, localFiles); err != nil {
+		feedback.Fatal(tr("Error saving cloud sync state: %v", err), feedback.ErrGeneric)
+	}
+	res.done = tr("Sketch %[1]s pushed to Arduino Cloud (%[2]d files changed).", name, len(changes))
+	feedback.PrintResult(res)
+}
@@ -0,0 +1,351 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compat
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/lib"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/core"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tr             = i18n.Tr
+	includeRegexp  = regexp.MustCompile(`(?m)^\s*#\s*include\s*[<"]([^>"]+)[>"]`)
+	sourceExtRegex = regexp.MustCompile(`(?i)\.(ino|pde|h|hh|hpp|c|cc|cpp|cxx|s)$`)
+)
+
+// NewCommand created a new `compat` command
+func NewCommand() *cobra.Command {
+	compatCommand := &cobra.Command{
+		Use:   "compat [" + tr("SKETCH_PATH") + "]",
+		Short: tr("Shows on which installed platforms the libraries used by a sketch are available."),
+		Long: tr("Detects the libraries included by the sketch, and by the libraries themselves, and checks the " +
+			"architectures they declare against each installed platform, without compiling the sketch. " +
+			"The headers not provided by any installed library are assumed to be provided by the platforms."),
+		Example: "" +
+			"  " + os.Args[0] + " compat\n" +
+			"  " + os.Args[0] + " compat /home/user/Arduino/MySketch\n",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			runCompatCommand(path)
+		},
+	}
+	return compatCommand
+}
+
+func runCompatCommand(path string) {
+	logrus.Info("Executing `arduino-cli compat`")
+
+	sketchPath := arguments.InitSketchPath(path)
+	sk, err := sketch.New(sketchPath)
+	if err != nil {
+		feedback.FatalError(err, feedback.ErrGeneric)
+	}
+
+	inst := instance.CreateAndInit()
+	platforms := []string{}
+	for _, platform := range core.GetList(inst, false, false) {
+		platforms = append(platforms, platform.GetMetadata().GetId())
+	}
+	libs, err := lib.LibraryList(context.Background(), &rpc.LibraryListRequest{Instance: inst, All: true})
+	if err != nil {
+		feedback.Fatal(tr("Error listing libraries: %v", err), feedback.ErrGeneric)
+	}
+	installed := []*rpc.Library{}
+	for _, l := range libs.GetInstalledLibraries() {
+		installed = append(installed, l.GetLibrary())
+	}
+
+	feedback.PrintResult(checkCompatibility(sk, platforms, installed))
+}
+
+// checkCompatibility resolves the includes of the sketch, and recursively
+// of the libraries, with the libraries available on each platform.
+func checkCompatibility(sk *sketch.Sketch, platforms []string, libs []*rpc.Library) *compatResult {
+	sketchHeaders := scanIncludes(sketchSources(sk))
+	ownHeaders := map[string]bool{}
+	for _, file := range sketchSources(sk) {
+		if rel, err := file.RelTo(sk.FullPath); err == nil {
+			ownHeaders[rel.String()] = true
+			ownHeaders[file.Base()] = true
+		}
+	}
+
+	sketchLevel := map[string]bool{}
+	for _, header := range sketchHeaders {
+		sketchLevel[header] = !ownHeaders[header]
+	}
+
+	res := &compatResult{Sketch: sk.Name, Platforms: []*compatPlatform{}, Includes: []*compatInclude{}}
+	includes := map[string]*compatInclude{}
+	for _, platform := range platforms {
+		arch := platform[strings.Index(platform, ":")+1:]
+		resolved := map[string]*rpc.Library{}
+		queue := []string{}
+		for _, header := range sketchHeaders {
+			if sketchLevel[header] {
+				queue = append(queue, header)
+			}
+		}
+		scanned := map[string]bool{}
+		for len(queue) > 0 {
+			header := queue[0]
+			queue = queue[1:]
+			if _, ok := resolved[header]; ok {
+				continue
+			}
+			l := resolveHeader(header, platform, arch, libs)
+			resolved[header] = l
+			if l == nil || scanned[l.GetInstallDir()] {
+				continue
+			}
+			scanned[l.GetInstallDir()] = true
+			queue = append(queue, scanIncludes(librarySources(l))...)
+		}
+		for header, l := range resolved {
+			include, ok := includes[header]
+			if !ok {
+				include = &compatInclude{Header: header, Libraries: map[string]string{}}
+				includes[header] = include
+			}
+			if l != nil {
+				include.Libraries[platform] = l.GetName()
+			}
+		}
+		res.Platforms = append(res.Platforms, &compatPlatform{ID: platform, Compatible: true})
+	}
+
+	// The headers not provided by any library are provided by the platforms.
+	// Only the ones of the sketch are reported, the others are usually
+	// internal to the libraries.
+	for header, include := range includes {
+		if !providedByAny(header, libs) {
+			if sketchLevel[header] {
+				res.Unresolved = append(res.Unresolved, header)
+			}
+			continue
+		}
+		res.Includes = append(res.Includes, include)
+		for _, platform := range res.Platforms {
+			if _, ok := include.Libraries[platform.ID]; !ok {
+				platform.Compatible = false
+				platform.MissingIncludes = append(platform.MissingIncludes, header)
+			}
+		}
+	}
+	sort.Strings(res.Unresolved)
+	sort.Slice(res.Includes, func(i, j int) bool { return res.Includes[i].Header < res.Includes[j].Header })
+	for _, platform := range res.Platforms {
+		sort.Strings(platform.MissingIncludes)
+	}
+	return res
+}
+
+// resolveHeader returns the library providing the header on the platform,
+// preferring the libraries bundled with the platform, or nil if none is
+// available.
+func resolveHeader(header, platform, arch string, libs []*rpc.Library) *rpc.Library {
+	var res *rpc.Library
+	for _, l := range libs {
+		if !providesHeader(l, header) {
+			continue
+		}
+		switch l.GetLocation() {
+		case rpc.LibraryLocation_LIBRARY_LOCATION_PLATFORM_BUILTIN, rpc.LibraryLocation_LIBRARY_LOCATION_REFERENCED_PLATFORM_BUILTIN:
+			if strings.Split(l.GetContainerPlatform(), "@")[0] == platform {
+				return l
+			}
+		default:
+			if res == nil && supportsArchitecture(l, arch) {
+				res = l
+			}
+		}
+	}
+	return res
+}
+
+func providedByAny(header string, libs []*rpc.Library) bool {
+	for _, l := range libs {
+		if providesHeader(l, header) {
+			return true
+		}
+	}
+	return false
+}
+
+func providesHeader(l *rpc.Library, header string) bool {
+	for _, provided := range l.GetProvidesIncludes() {
+		if provided == header {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsArchitecture returns true if the library declares to support the
+// architecture, or any architecture.
+func supportsArchitecture(l *rpc.Library, arch string) bool {
+	if len(l.GetArchitectures()) == 0 {
+		return true
+	}
+	for _, a := range l.GetArchitectures() {
+		if a == "*" || a == arch {
+			return true
+		}
+	}
+	return false
+}
+
+func sketchSources(sk *sketch.Sketch) paths.PathList {
+	files := paths.PathList{sk.MainFile}
+	files = append(files, sk.OtherSketchFiles...)
+	files = append(files, sk.AdditionalFiles...)
+	return files
+}
+
+func librarySources(l *rpc.Library) paths.PathList {
+	files := paths.PathList{}
+	sourceDir := paths.New(l.GetSourceDir())
+	if sourceDir == nil {
+		return files
+	}
+	var list paths.PathList
+	var err error
+	if l.GetLayout() == rpc.LibraryLayout_LIBRARY_LAYOUT_RECURSIVE {
+		list, err = sourceDir.ReadDirRecursive()
+	} else {
+		list, err = sourceDir.ReadDir()
+		if utilityDir := paths.New(l.GetUtilityDir()); err == nil && utilityDir != nil {
+			if utilityFiles, err := utilityDir.ReadDir(); err == nil {
+				list = append(list, utilityFiles...)
+			}
+		}
+	}
+	if err != nil {
+		logrus.WithError(err).Warnf("Reading sources of library %s", l.GetName())
+		return files
+	}
+	for _, file := range list {
+		if !file.IsDir() && sourceExtRegex.MatchString(file.Base()) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// scanIncludes returns the headers included by the files, in order of
+// appearance and without duplicates.
+func scanIncludes(files paths.PathList) []string {
+	res := []string{}
+	seen := map[string]bool{}
+	for _, file := range files {
+		data, err := file.ReadFile()
+		if err != nil {
+			logrus.WithError(err).Warnf("Reading %s", file)
+			continue
+		}
+		for _, match := range includeRegexp.FindAllStringSubmatch(string(data), -1) {
+			header := strings.TrimSpace(match[1])
+			if !seen[header] {
+				seen[header] = true
+				res = append(res, header)
+			}
+		}
+	}
+	return res
+}
+
+type compatInclude struct {
+	Header string `json:"header"`
+	// Libraries maps the platforms to the library providing the header
+	Libraries map[string]string `json:"libraries"`
+}
+
+type compatPlatform struct {
+	ID              string   `json:"id"`
+	Compatible      bool     `json:"compatible"`
+	MissingIncludes []string `json:"missing_includes,omitempty"`
+}
+
+type compatResult struct {
+	Sketch     string            `json:"sketch"`
+	Platforms  []*compatPlatform `json:"platforms"`
+	Includes   []*compatInclude  `json:"includes"`
+	Unresolved []string          `json:"unresolved_includes,omitempty"`
+}
+
+func (r *compatResult) Data() interface{} {
+	return r
+}
+
+func (r *compatResult) String() string {
+	if len(r.Platforms) == 0 {
+		return tr("No platforms installed.")
+	}
+	theme := feedback.GetTheme()
+	t := table.New()
+	header := []interface{}{tr("Include")}
+	for _, platform := range r.Platforms {
+		header = append(header, platform.ID)
+	}
+	t.SetHeader(header...)
+	for _, include := range r.Includes {
+		row := []interface{}{include.Header}
+		for _, platform := range r.Platforms {
+			if name, ok := include.Libraries[platform.ID]; ok {
+				row = append(row, table.NewCell(name, theme.Success))
+			} else {
+				row = append(row, table.NewCell("-", theme.Error))
+			}
+		}
+		t.AddRow(row...)
+	}
+	row := []interface{}{table.NewCell(tr("Compatible"), theme.Title)}
+	for _, platform := range r.Platforms {
+		if platform.Compatible {
+			row = append(row, table.NewCell(tr("yes"), theme.Success))
+		} else {
+			row = append(row, table.NewCell(tr("no"), theme.Error))
+		}
+	}
+	t.AddRow(row...)
+
+	res := t.Render()
+	if len(r.Unresolved) > 0 {
+		res += fmt.Sprintln() + tr("Headers not provided by any library, expected from the platforms: %s", strings.Join(r.Unresolved, ", "))
+	}
+	return res
+}
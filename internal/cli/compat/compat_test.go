@@ -0,0 +1,63 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compat
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	sketchDir := tmp.Join("MySketch")
+	require.NoError(t, sketchDir.MkdirAll())
+	require.NoError(t, sketchDir.Join("MySketch.ino").WriteFile([]byte(
+		"#include <Arduino.h>\n#include <Servo.h>\n  #  include \"config.h\"\n#include <Display.h>\n")))
+	require.NoError(t, sketchDir.Join("config.h").WriteFile([]byte("#define PIN 3\n")))
+	sk, err := sketch.New(sketchDir)
+	require.NoError(t, err)
+
+	// Display depends on SPI, that is bundled with the platforms
+	displayDir := tmp.Join("Display")
+	require.NoError(t, displayDir.MkdirAll())
+	require.NoError(t, displayDir.Join("Display.cpp").WriteFile([]byte("#include <SPI.h>\n#include \"utility/font.h\"\n")))
+
+	libs := []*rpc.Library{
+		{Name: "Servo", Architectures: []string{"avr", "samd"}, ProvidesIncludes: []string{"Servo.h"}, Location: rpc.LibraryLocation_LIBRARY_LOCATION_USER},
+		{Name: "Display", ProvidesIncludes: []string{"Display.h"}, SourceDir: displayDir.String(), InstallDir: displayDir.String(),
+			Layout: rpc.LibraryLayout_LIBRARY_LAYOUT_RECURSIVE, Location: rpc.LibraryLocation_LIBRARY_LOCATION_USER},
+		{Name: "SPI", ProvidesIncludes: []string{"SPI.h"}, ContainerPlatform: "arduino:avr@1.8.6", Location: rpc.LibraryLocation_LIBRARY_LOCATION_PLATFORM_BUILTIN},
+		{Name: "SPI", ProvidesIncludes: []string{"SPI.h"}, ContainerPlatform: "esp32:esp32@2.0.0", Location: rpc.LibraryLocation_LIBRARY_LOCATION_PLATFORM_BUILTIN},
+	}
+	res := checkCompatibility(sk, []string{"arduino:avr", "arduino:samd", "esp32:esp32"}, libs)
+
+	require.Equal(t, "MySketch", res.Sketch)
+	require.Equal(t, []string{"Arduino.h"}, res.Unresolved)
+	require.Equal(t, []*compatInclude{
+		{Header: "Display.h", Libraries: map[string]string{"arduino:avr": "Display", "arduino:samd": "Display", "esp32:esp32": "Display"}},
+		{Header: "SPI.h", Libraries: map[string]string{"arduino:avr": "SPI", "esp32:esp32": "SPI"}},
+		{Header: "Servo.h", Libraries: map[string]string{"arduino:avr": "Servo", "arduino:samd": "Servo"}},
+	}, res.Includes)
+	require.Equal(t, []*compatPlatform{
+		{ID: "arduino:avr", Compatible: true},
+		{ID: "arduino:samd", Compatible: false, MissingIncludes: []string{"SPI.h"}},
+		{ID: "esp32:esp32", Compatible: false, MissingIncludes: []string{"Servo.h"}},
+	}, res.Platforms)
+}
@@ -26,7 +26,6 @@ import (
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
 	"github.com/arduino/arduino-cli/commands/compile"
-	"github.com/arduino/arduino-cli/commands/core"
 	"github.com/arduino/arduino-cli/commands/sketch"
 	"github.com/arduino/arduino-cli/commands/upload"
 	"github.com/arduino/arduino-cli/internal/cli/arguments"
@@ -39,7 +38,6 @@ import (
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/arduino/arduino-cli/version"
 	"github.com/arduino/go-paths-helper"
-	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -49,6 +47,22 @@ var (
 	profileArg              arguments.Profile        // Profile to use
 	showPropertiesArg       arguments.ShowProperties // Show all build preferences used instead of compiling.
 	preprocess              bool                     // Print preprocessed code to stdout.
+	showConditionals        bool                     // Report the conditional compilation branches of the sketch.
+	emitCELink              bool                     // Print a Compiler Explorer link of the sketch.
+	analyzeIncludes         bool                     // Report the unused and the missing #include directives of the sketch.
+	ceCompiler              string                   // The Compiler Explorer compiler to use in the link.
+	lastLog                 bool                     // Print the log of the last build of the sketch.
+	saveAsm                 bool                     // Save the assembly listings in the export directory.
+	sizeReport              string                   // The kind of detailed size report to print after the build.
+	warningsAsErrors        bool                     // Fail the build on warnings of the sketch and of the libraries.
+	failFast                bool                     // Stop the build at the first file failing to compile.
+	maxErrors               int32                    // Number of files failing to compile after which the build stops.
+	applyFixIts             bool                     // Apply the fix-it hints of the compiler to the sketch and the local libraries.
+	suggestBoards           bool                     // Suggest the installed boards where the sketch fits.
+	suggestBoardsHeadroom   int32                    // The percentage of the memory of the suggested boards left free.
+	strip                   []string                 // The information stripped from the build artifacts.
+	exportSymbols           bool                     // Save the executable with the debug information in the symbols archive.
+	werrorExemptLibraries   []string                 // Libraries whose warnings don't make the build fail.
 	buildCachePath          string                   // Builds of 'core.a' are saved into this path to be cached and reused.
 	buildPath               string                   // Path where to save compiled files.
 	buildProperties         []string                 // List of custom build properties separated by commas. Or can be used multiple times for multiple properties.
@@ -68,8 +82,17 @@ var (
 	clean                   bool                     // Cleanup the build folder and do not use any cached build
 	compilationDatabaseOnly bool                     // Only create compilation database without actually compiling
 	sourceOverrides         string                   // Path to a .json file that contains a set of replacements of the sketch source code.
+	secrets                 []string                 // Secrets injected at build time as NAME=VALUE pairs.
 	dumpProfile             bool                     // Create and print a profile configuration from the build
 	jobs                    int32                    // Max number of parallel jobs
+	summaryFile             string                   // Path of the file where the build summary is written
+	fqbnList                []string                 // List of FQBNs to compile the sketches for
+	onlyCore                bool                     // Build only the core
+	onlyLibrary             string                   // Build only the given library
+	onlySketch              bool                     // Build only the sketch objects
+	onlyFile                string                   // Build only the given source file
+	autoInstallLibs         bool                     // Install the missing libraries found during the build
+	assumeYes               bool                     // Install the missing platform without asking for confirmation
 	// library and libraries sound similar but they're actually different.
 	// library expects a path to the root folder of one single library.
 	// libraries expects a path to a directory containing multiple libraries, similarly to the <directories.user>/libraries path.
@@ -89,9 +112,17 @@ func NewCommand() *cobra.Command {
 			"  " + os.Args[0] + " compile -b arduino:avr:uno /home/user/Arduino/MySketch\n" +
 			"  " + os.Args[0] + ` compile -b arduino:avr:uno --build-property "build.extra_flags=\"-DMY_DEFINE=\"hello world\"\"" /home/user/Arduino/MySketch` + "\n" +
 			"  " + os.Args[0] + ` compile -b arduino:avr:uno --build-property "build.extra_flags=-DPIN=2 \"-DMY_DEFINE=\"hello world\"\"" /home/user/Arduino/MySketch` + "\n" +
-			"  " + os.Args[0] + ` compile -b arduino:avr:uno --build-property build.extra_flags=-DPIN=2 --build-property "compiler.cpp.extra_flags=\"-DSSID=\"hello world\"\"" /home/user/Arduino/MySketch` + "\n",
-		Args: cobra.MaximumNArgs(1),
-		Run:  runCompileCommand,
+			"  " + os.Args[0] + ` compile -b arduino:avr:uno --build-property build.extra_flags=-DPIN=2 --build-property "compiler.cpp.extra_flags=\"-DSSID=\"hello world\"\"" /home/user/Arduino/MySketch` + "\n" +
+			"  " + os.Args[0] + " compile --fqbn-list arduino:avr:uno,arduino:samd:mkr1000 /home/user/Arduino/MySketch /home/user/Arduino/OtherSketch\n" +
+			"  " + os.Args[0] + " compile -b arduino:avr:uno --only-library Servo /home/user/Arduino/MySketch\n" +
+			"  " + os.Args[0] + " compile -b arduino:avr:uno --only-file /home/user/Arduino/MySketch/MySketch.ino /home/user/Arduino/MySketch\n",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(fqbnList) > 0 {
+				return nil
+			}
+			return cobra.MaximumNArgs(1)(cmd, args)
+		},
+		Run: runCompileCommand,
 	}
 
 	fqbnArg.AddToCommand(compileCommand)
@@ -99,6 +130,13 @@ func NewCommand() *cobra.Command {
 	compileCommand.Flags().BoolVar(&dumpProfile, "dump-profile", false, tr("Create and print a profile configuration from the build."))
 	showPropertiesArg.AddToCommand(compileCommand)
 	compileCommand.Flags().BoolVar(&preprocess, "preprocess", false, tr("Print preprocessed code to stdout instead of compiling."))
+	compileCommand.Flags().BoolVar(&showConditionals, "show-conditionals", false, tr("Report which branches of the conditional compilation directives (#if, #ifdef...) of the sketch are compiled for the board, instead of compiling."))
+	compileCommand.Flags().BoolVar(&emitCELink, "emit-ce-link", false, tr("Upload the preprocessed sketch and the compiler flags of the board to Compiler Explorer and print the link to the session, instead of compiling."))
+	compileCommand.Flags().BoolVar(&analyzeIncludes, "analyze-includes", false, tr("Report the headers included by the sketch but never used, and the headers used by the sketch but included only through another library or the core, instead of compiling."))
+	compileCommand.Flags().BoolVar(&lastLog, "last-log", false, tr("Print the full verbose log of the last build of the sketch, saved in the build path, instead of compiling. With %s the log is saved in that directory.", "--output-dir"))
+	compileCommand.Flags().StringVar(&ceCompiler, "ce-compiler", "", tr("The Compiler Explorer compiler used by %s, if omitted it's guessed from the platform.", "--emit-ce-link"))
+	compileCommand.Flags().StringSliceVar(&strip, "strip", []string{}, tr("Strip information from the executable and the map file for distribution, one or more of: %s. Can be used multiple times or entries can be comma separated.", "paths, debug-info, symbols, all"))
+	compileCommand.Flags().BoolVar(&exportSymbols, "export-symbols", false, tr("Save the executable, with the full debug information, in the symbols archive. The build UUID embedded in the firmware can be used to resolve the addresses of a crash with the %s command.", "symbolize"))
 	compileCommand.Flags().StringVar(&buildCachePath, "build-cache-path", "", tr("Builds of 'core.a' are saved into this path to be cached and reused."))
 	compileCommand.Flags().StringVarP(&exportDir, "output-dir", "", "", tr("Save build artifacts in this directory."))
 	compileCommand.Flags().StringVar(&buildPath, "build-path", "",
@@ -115,6 +153,20 @@ func NewCommand() *cobra.Command {
 		tr("The name of the custom encryption key to use to encrypt a binary during the compile process. Used only by the platforms that support it."))
 	compileCommand.Flags().StringVar(&warnings, "warnings", "none",
 		tr(`Optional, can be: %s. Used to tell gcc which warning level to use (-W flag).`, "none, default, more, all"))
+	compileCommand.Flags().BoolVar(&warningsAsErrors, "werror", false,
+		tr("Treat the compiler warnings of the sketch and of the libraries as errors. The warnings of the core are ignored."))
+	compileCommand.Flags().BoolVar(&failFast, "fail-fast", true,
+		tr("Stop the build at the first file failing to compile. If false the other files are compiled anyway, to report all the errors at once grouped by message, and the build stops before linking."))
+	compileCommand.Flags().Int32Var(&maxErrors, "max-errors", 0,
+		tr("Number of files failing to compile after which the build stops, when used with %s. If 0 there is no limit.", "--fail-fast=false"))
+	compileCommand.Flags().BoolVar(&applyFixIts, "apply-fixits", false,
+		tr("Apply in place the fixes suggested by the compiler to the files of the sketch and of the libraries given with %s.", "--library"))
+	compileCommand.Flags().BoolVar(&suggestBoards, "suggest-boards", false,
+		tr("After the build, suggest the installed boards of the same architecture where the sketch fits, starting from the ones with the smallest memory."))
+	compileCommand.Flags().Int32Var(&suggestBoardsHeadroom, "suggest-boards-headroom", 20,
+		tr("The percentage of the flash and of the RAM of the boards suggested by %s that must be left free.", "--suggest-boards"))
+	compileCommand.Flags().StringSliceVar(&werrorExemptLibraries, "werror-exempt", []string{},
+		tr("Name of a library whose warnings are not treated as errors. Can be used multiple times or entries can be comma separated."))
 	compileCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Optional, turns on verbose mode."))
 	compileCommand.Flags().BoolVar(&quiet, "quiet", false, tr("Optional, suppresses almost every output."))
 	compileCommand.Flags().BoolVarP(&uploadAfterCompile, "upload", "u", false, tr("Upload the binary after the compilation."))
@@ -128,12 +180,34 @@ func NewCommand() *cobra.Command {
 	programmer.AddToCommand(compileCommand)
 	compileCommand.Flags().BoolVar(&compilationDatabaseOnly, "only-compilation-database", false, tr("Just produce the compilation database, without actually compiling. All build commands are skipped except pre* hooks."))
 	compileCommand.Flags().BoolVar(&clean, "clean", false, tr("Optional, cleanup the build folder and do not use any cached build."))
-	compileCommand.Flags().BoolVarP(&exportBinaries, "export-binaries", "e", false, tr("If set built binaries will be exported to the sketch folder."))
+	compileCommand.Flags().BoolVarP(&exportBinaries, "export-binaries", "e", false, tr("If set built binaries will be exported to the sketch folder, together with a script to flash them."))
+	compileCommand.Flags().BoolVar(&saveAsm, "save-asm", false, tr("Save the assembly listings, interleaved with the source code, of the compiled files and of the final executable together with the exported binaries."))
+	compileCommand.Flags().StringVar(&sizeReport, "size-report", "",
+		tr(`Optional, can be: %s. Print a detailed report of the memory usage after the build (the platform must produce the linker map file).`, "map"))
+	compileCommand.RegisterFlagCompletionFunc("size-report", cobra.FixedCompletions([]string{"map"}, cobra.ShellCompDirectiveDefault))
 	compileCommand.Flags().StringVar(&sourceOverrides, "source-override", "", tr("Optional. Path to a .json file that contains a set of replacements of the sketch source code."))
 	compileCommand.Flag("source-override").Hidden = true
+	compileCommand.Flags().StringArrayVar(&secrets, "secret", []string{},
+		tr("Inject a secret, as NAME=VALUE, at build time without saving it in the sketch. If the value is omitted it is read from the NAME environment variable. Can be used multiple times for multiple secrets."))
 	compileCommand.Flags().BoolVar(&skipLibrariesDiscovery, "skip-libraries-discovery", false, "Skip libraries discovery. This flag is provided only for use in language server and other, very specific, use cases. Do not use for normal compiles")
 	compileCommand.Flag("skip-libraries-discovery").Hidden = true
 	compileCommand.Flags().Int32VarP(&jobs, "jobs", "j", 0, tr("Max number of parallel compiles. If set to 0 the number of available CPUs cores will be used."))
+	compileCommand.Flags().StringVar(&summaryFile, "summary-file", "", tr("Write a summary of the build (status, sizes, warnings and used libraries) to this file. The format is JSON (an array with an entry for each build) if the file extension is .json, markdown otherwise."))
+	compileCommand.Flags().StringSliceVar(&fqbnList, "fqbn-list", []string{}, tr("Compile the sketches for each of the given FQBNs and report the aggregated results. Can be used multiple times or entries can be comma separated."))
+	compileCommand.Flags().BoolVar(&onlyCore, "only-core", false, tr("Build only the core and the variant of the board, without linking."))
+	compileCommand.Flags().StringVar(&onlyLibrary, "only-library", "", tr("Build only the library with the given name, without linking. The library must be used by the sketch."))
+	compileCommand.Flags().BoolVar(&onlySketch, "only-sketch", false, tr("Build only the sketch objects, without linking."))
+	compileCommand.Flags().StringVar(&onlyFile, "only-file", "", tr("Build only the given source file of the sketch, of a library used by the sketch or of the core, without linking."))
+	compileCommand.Flags().BoolVar(&autoInstallLibs, "auto-install-libs", false, tr("Install a missing library if the missing header is provided by exactly one library of the index, then continue the build. If a profile is used the library is added to the profile in the sketch project file."))
+	compileCommand.Flags().BoolVar(&assumeYes, "yes", false, tr("Install the platform of the board, if missing, without asking for confirmation."))
+	compileCommand.MarkFlagsMutuallyExclusive("only-core", "only-library", "only-sketch", "only-file")
+	for _, flag := range []string{"only-core", "only-library", "only-sketch", "only-file"} {
+		compileCommand.MarkFlagsMutuallyExclusive(flag, "upload")
+	}
+	// The flags that replace the compilation with a report can't be combined
+	compileCommand.MarkFlagsMutuallyExclusive("preprocess", "show-conditionals", "emit-ce-link", "analyze-includes", "last-log", "upload")
+	// The secrets must never be printed or uploaded
+	compileCommand.MarkFlagsMutuallyExclusive("secret", "preprocess", "emit-ce-link")
 	configuration.Settings.BindPFlag("sketch.always_export_binaries", compileCommand.Flags().Lookup("export-binaries"))
 
 	compileCommand.Flags().MarkDeprecated("build-properties", tr("please use --build-property instead."))
@@ -142,6 +216,11 @@ func NewCommand() *cobra.Command {
 }
 
 func runCompileCommand(cmd *cobra.Command, args []string) {
+	if len(fqbnList) > 0 {
+		runCompileMatrixCommand(cmd, args)
+		return
+	}
+
 	logrus.Info("Executing `arduino-cli compile`")
 
 	if profileArg.Get() != "" {
@@ -153,6 +232,29 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if sizeReport != "" && sizeReport != "map" {
+		feedback.Fatal(tr("Invalid value for %[1]s flag: %[2]s", "--size-report", sizeReport), feedback.ErrBadArgument)
+	}
+
+	var stripPaths, stripDebugInfo, stripSymbols bool
+	for _, s := range strip {
+		switch s {
+		case "paths":
+			stripPaths = true
+		case "debug-info":
+			stripDebugInfo = true
+		case "symbols":
+			stripSymbols = true
+		case "all":
+			stripPaths, stripDebugInfo, stripSymbols = true, true, true
+		default:
+			feedback.Fatal(tr("Invalid value for %[1]s flag: %[2]s", "--strip", s), feedback.ErrBadArgument)
+		}
+	}
+	if stripSymbols && sizeReport == "map" {
+		feedback.Fatal(tr("The linker map file is deleted when the symbols are stripped, %s can't be used.", "--size-report map"), feedback.ErrBadArgument)
+	}
+
 	path := ""
 	if len(args) > 0 {
 		path = args[0]
@@ -179,6 +281,9 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 	}
 
 	fqbn, port := arguments.CalculateFQBNAndPort(&portArgs, &fqbnArg, inst, sk.GetDefaultFqbn(), sk.GetDefaultPort(), sk.GetDefaultProtocol())
+	if profile == nil {
+		arguments.InstallMissingPlatform(inst, fqbn, assumeYes)
+	}
 
 	if keysKeychain != "" || signKey != "" || encryptKey != "" {
 		arguments.CheckFlagsMandatory(cmd, "keys-keychain", "sign-key", "encrypt-key")
@@ -210,6 +315,9 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 		stdOut, stdErr, stdIORes = feedback.NewBufferedStreams()
 	} else {
 		stdOut, stdErr, stdIORes = feedback.OutputStreams()
+		if verbose && feedback.GetFormat() == feedback.Text {
+			stdOut, stdErr = feedback.NewToolOutputWriter(stdOut), feedback.NewToolOutputWriter(stdErr)
+		}
 	}
 
 	var libraryAbs []string
@@ -221,30 +329,51 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 	}
 
 	compileRequest := &rpc.CompileRequest{
-		Instance:                      inst,
-		Fqbn:                          fqbn,
-		SketchPath:                    sketchPath.String(),
-		ShowProperties:                showProperties != arguments.ShowPropertiesDisabled,
-		Preprocess:                    preprocess,
-		BuildCachePath:                buildCachePath,
-		BuildPath:                     buildPath,
-		BuildProperties:               buildProperties,
-		Warnings:                      warnings,
-		Verbose:                       verbose,
-		Quiet:                         quiet,
-		ExportDir:                     exportDir,
-		Libraries:                     libraries,
-		OptimizeForDebug:              optimizeForDebug,
-		Clean:                         clean,
-		CreateCompilationDatabaseOnly: compilationDatabaseOnly,
-		SourceOverride:                overrides,
-		Library:                       libraryAbs,
-		KeysKeychain:                  keysKeychain,
-		SignKey:                       signKey,
-		EncryptKey:                    encryptKey,
-		SkipLibrariesDiscovery:        skipLibrariesDiscovery,
-		DoNotExpandBuildProperties:    showProperties == arguments.ShowPropertiesUnexpanded,
-		Jobs:                          jobs,
+		Instance:                        inst,
+		Fqbn:                            fqbn,
+		SketchPath:                      sketchPath.String(),
+		ShowProperties:                  showProperties != arguments.ShowPropertiesDisabled,
+		Preprocess:                      preprocess,
+		ReportConditionalBranches:       showConditionals,
+		CompilerExplorerSession:         emitCELink,
+		AnalyzeIncludes:                 analyzeIncludes,
+		LastLog:                         lastLog,
+		KeepGoing:                       !failFast,
+		MaxErrors:                       maxErrors,
+		ApplyFixits:                     applyFixIts,
+		SuggestBoards:                   suggestBoards,
+		SuggestBoardsHeadroom:           suggestBoardsHeadroom,
+		StripPaths:                      stripPaths,
+		StripDebugInfo:                  stripDebugInfo,
+		StripSymbols:                    stripSymbols,
+		ExportSymbols:                   exportSymbols,
+		CompilerExplorerCompiler:        ceCompiler,
+		SaveAssemblyListings:            saveAsm,
+		MemoryMapReport:                 sizeReport == "map",
+		WarningsAsErrors:                warningsAsErrors,
+		WarningsAsErrorsExemptLibraries: werrorExemptLibraries,
+		BuildCachePath:                  buildCachePath,
+		BuildPath:                       buildPath,
+		BuildProperties:                 buildProperties,
+		Warnings:                        warnings,
+		Verbose:                         verbose,
+		Quiet:                           quiet,
+		ExportDir:                       exportDir,
+		Libraries:                       libraries,
+		OptimizeForDebug:                optimizeForDebug,
+		Clean:                           clean,
+		CreateCompilationDatabaseOnly:   compilationDatabaseOnly,
+		SourceOverride:                  overrides,
+		Secrets:                         parseSecrets(secrets),
+		Library:                         libraryAbs,
+		KeysKeychain:                    keysKeychain,
+		SignKey:                         signKey,
+		EncryptKey:                      encryptKey,
+		SkipLibrariesDiscovery:          skipLibrariesDiscovery,
+		AutoInstallLibraries:            autoInstallLibs,
+		DoNotExpandBuildProperties:      showProperties == arguments.ShowPropertiesUnexpanded,
+		Jobs:                            jobs,
+		Target:                          compileTarget(),
 	}
 	builderRes, compileError := compile.Compile(context.Background(), compileRequest, stdOut, stdErr, nil)
 
@@ -269,6 +398,7 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 			}
 		}
 
+		programmer.SetDefaultFromBoardAlias(&fqbnArg)
 		prog := profile.GetProgrammer()
 		if prog == "" || programmer.GetProgrammer() != "" {
 			prog = programmer.String(inst, fqbn)
@@ -289,7 +419,7 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 			UserFields: fields,
 		}
 
-		if res, err := upload.Upload(context.Background(), uploadRequest, stdOut, stdErr); err != nil {
+		if res, err := upload.Upload(context.Background(), uploadRequest, stdOut, stdErr, nil); err != nil {
 			errcode := feedback.ErrGeneric
 			if errors.Is(err, &cmderrors.ProgrammerRequiredForUploadError{}) {
 				errcode = feedback.ErrMissingProgrammer
@@ -367,10 +497,30 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 		Success:            compileError == nil,
 		showPropertiesMode: showProperties,
 		hideStats:          preprocess,
+		showConditionals:   showConditionals,
+		showCELink:         emitCELink,
+		showIncludes:       analyzeIncludes,
+		showLastLog:        lastLog,
+		showMemoryMap:      sizeReport == "map",
+		sketchPath:         sketchPath,
+	}
+
+	if summaryFile != "" {
+		summary := newCompileSummary(sketchPath.Base(), fqbn, builderRes, compileError)
+		if err := writeCompileSummary(paths.New(summaryFile), summary); err != nil {
+			feedback.Warning(tr("Error writing build summary: %v", err))
+		}
 	}
 
 	if compileError != nil {
 		res.Error = tr("Error during build: %v", compileError)
+		if !failFast && res.BuilderResult != nil {
+			sketchDirs := paths.PathList{paths.New(res.BuilderResult.BuildPath, "sketch")}
+			if absSketchPath, err := sketchPath.Abs(); err == nil {
+				sketchDirs.Add(absSketchPath)
+			}
+			res.ErrorsSummary = groupCompileErrors(res.BuilderResult.Diagnostics, sketchDirs...)
+		}
 
 		// Check the error type to give the user better feedback on how
 		// to resolve it
@@ -383,18 +533,21 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 
 			if profileArg.String() == "" {
 				res.Error += fmt.Sprintln()
+				res.Error += arguments.PlatformNotFoundHint(inst, platformErr.Platform)
+			}
+		}
 
-				if platform, err := core.PlatformSearch(&rpc.PlatformSearchRequest{
-					Instance:   inst,
-					SearchArgs: platformErr.Platform,
-				}); err != nil {
-					res.Error += err.Error()
-				} else if len(platform.GetSearchOutput()) > 0 {
-					suggestion := fmt.Sprintf("`%s core install %s`", version.VersionInfo.Application, platformErr.Platform)
-					res.Error += tr("Try running %s", suggestion)
-				} else {
-					res.Error += tr("Platform %s is not found in any known index\nMaybe you need to add a 3rd party URL?", platformErr.Platform)
-				}
+		var missingIncludeErr *cmderrors.MissingIncludeError
+		if errors.As(compileError, &missingIncludeErr) {
+			res.MissingInclude = &missingIncludeResult{
+				Header:             missingIncludeErr.Header,
+				SourceFile:         missingIncludeErr.SourceFile,
+				Line:               missingIncludeErr.Line,
+				CandidateLibraries: missingIncludeErr.Candidates,
+			}
+			if profileArg.String() == "" && len(missingIncludeErr.Candidates) > 0 {
+				suggestion := fmt.Sprintf("`%s lib install \"%s\"`", version.VersionInfo.Application, missingIncludeErr.Candidates[0])
+				res.Error += fmt.Sprintln() + tr("Try running %s", suggestion)
 			}
 		}
 		feedback.FatalResult(res, feedback.ErrGeneric)
@@ -402,6 +555,13 @@ func runCompileCommand(cmd *cobra.Command, args []string) {
 	feedback.PrintResult(res)
 }
 
+type missingIncludeResult struct {
+	Header             string   `json:"header"`
+	SourceFile         string   `json:"source_file"`
+	Line               int      `json:"line,omitempty"`
+	CandidateLibraries []string `json:"candidate_libraries,omitempty"`
+}
+
 type updatedUploadPortResult struct {
 	UpdatedUploadPort *result.Port `json:"updated_upload_port,omitempty"`
 }
@@ -414,8 +574,16 @@ type compileResult struct {
 	Success            bool                    `json:"success"`
 	ProfileOut         string                  `json:"profile_out,omitempty"`
 	Error              string                  `json:"error,omitempty"`
+	MissingInclude     *missingIncludeResult   `json:"missing_include,omitempty"`
+	ErrorsSummary      []*compileErrorGroup    `json:"errors_summary,omitempty"`
 	showPropertiesMode arguments.ShowPropertiesMode
 	hideStats          bool
+	showConditionals   bool
+	showCELink         bool
+	showIncludes       bool
+	showLastLog        bool
+	showMemoryMap      bool
+	sketchPath         *paths.Path
 }
 
 func (r *compileResult) Data() interface{} {
@@ -431,9 +599,32 @@ func (r *compileResult) String() string {
 		return ""
 	}
 
-	titleColor := color.New(color.FgHiGreen)
-	nameColor := color.New(color.FgHiYellow)
-	pathColor := color.New(color.FgHiBlack)
+	if r.BuilderResult != nil && r.showConditionals {
+		return r.conditionalsString()
+	}
+
+	if r.BuilderResult != nil && r.showIncludes {
+		return r.includeIssuesString()
+	}
+
+	if r.BuilderResult != nil && r.showLastLog {
+		if lastLog := r.BuilderResult.LastLog; lastLog != nil {
+			return strings.TrimRight(lastLog.Content, fmt.Sprintln())
+		}
+		return ""
+	}
+
+	if r.BuilderResult != nil && r.showCELink {
+		if session := r.BuilderResult.CompilerExplorerSession; session != nil {
+			return session.Link
+		}
+		return ""
+	}
+
+	theme := feedback.GetTheme()
+	titleColor := theme.Title
+	nameColor := theme.Accent
+	pathColor := theme.Muted
 	build := r.BuilderResult
 
 	res := ""
@@ -475,12 +666,231 @@ func (r *compileResult) String() string {
 		}
 		res += fmt.Sprintln(platforms.Render())
 	}
+	if build != nil && build.MemoryMapReport != nil && r.showMemoryMap {
+		res += fmt.Sprintln(memoryMapString(build.MemoryMapReport))
+	}
+	if build != nil && len(build.BoardSuggestions) > 0 {
+		res += fmt.Sprintln(boardSuggestionsString(build.BoardSuggestions, build.ExecutableSectionsSize))
+	}
+	if build != nil && len(build.AppliedFixIts) > 0 {
+		res += fmt.Sprintln(r.appliedFixItsString())
+	}
+	if build != nil && build.BuildUUID != "" {
+		res += fmt.Sprintln(tr("Symbols exported for build %s", build.BuildUUID))
+	}
 	if r.ProfileOut != "" {
 		res += fmt.Sprintln(r.ProfileOut)
 	}
 	return strings.TrimRight(res, fmt.Sprintln())
 }
 
+// memoryMapString renders the memory usage of each region as an ASCII chart,
+// followed by the sections and the largest contributors of the region.
+func memoryMapString(report *result.MemoryMapReport) string {
+	const barWidth = 40
+	titleColor := feedback.GetTheme().Title
+	nameColor := feedback.GetTheme().Accent
+	res := ""
+	for _, region := range report.Regions {
+		if region.Used == 0 {
+			continue
+		}
+		header := fmt.Sprintf("%s (0x%08x)", region.Name, region.Origin)
+		if region.Length > 0 {
+			filled := int(min(region.Used, region.Length) * barWidth / region.Length)
+			if filled == 0 {
+				filled = 1
+			}
+			bar := "[" + strings.Repeat("#", filled) + strings.Repeat(".", barWidth-filled) + "]"
+			percent := fmt.Sprintf("%.1f%%", float64(region.Used)*100/float64(region.Length))
+			header += fmt.Sprintf(" %s %s", bar, tr("%[1]d of %[2]d bytes (%[3]s)", region.Used, region.Length, percent))
+		} else {
+			header += " " + tr("%d bytes", region.Used)
+		}
+		res += titleColor.Sprintln(header)
+
+		t := table.New()
+		t.SetHeader(tr("Section"), tr("Address"), tr("Size"))
+		for _, section := range region.Sections {
+			t.AddRow(table.NewCell(section.Name, nameColor), fmt.Sprintf("0x%08x", section.Address), fmt.Sprint(section.Size))
+		}
+		res += fmt.Sprintln(t.Render())
+
+		if len(region.LargestContributors) > 0 {
+			t := table.New()
+			t.SetHeader(tr("Largest contributors"), tr("Size"), "")
+			for _, contributor := range region.LargestContributors {
+				t.AddRow(contributor.Name, fmt.Sprint(contributor.Size), fmt.Sprintf("%.1f%%", float64(contributor.Size)*100/float64(region.Used)))
+			}
+			res += fmt.Sprintln(t.Render())
+		}
+	}
+	if res == "" {
+		return tr("The linker map file doesn't report any memory usage.")
+	}
+	return res
+}
+
+// boardSuggestionsString renders the boards where the sketch fits, with the
+// share of their memory used by the sketch.
+func boardSuggestionsString(suggestions []*result.BoardSuggestion, sections []*result.ExecutableSectionSize) string {
+	const maxSuggestions = 10
+	var flash, ram int64 = -1, -1
+	for _, section := range sections {
+		switch section.Name {
+		case "text":
+			flash = section.Size
+		case "data":
+			ram = section.Size
+		}
+	}
+	usage := func(size, maxSize int64) string {
+		if maxSize <= 0 {
+			return "-"
+		}
+		if size < 0 {
+			return fmt.Sprint(maxSize)
+		}
+		return fmt.Sprintf("%d (%d%%)", maxSize, size*100/maxSize)
+	}
+
+	titleColor := feedback.GetTheme().Title
+	t := table.New()
+	t.SetHeader(table.NewCell(tr("Suggested board"), titleColor), tr("FQBN"), tr("Flash"), tr("RAM"))
+	for i, suggestion := range suggestions {
+		if i == maxSuggestions {
+			break
+		}
+		t.AddRow(suggestion.Name, suggestion.Fqbn, usage(flash, suggestion.MaxFlash), usage(ram, suggestion.MaxRam))
+	}
+	res := t.Render()
+	if len(suggestions) > maxSuggestions {
+		res += fmt.Sprintln() + tr("The sketch fits in %d more boards, use %s to list them all.", len(suggestions)-maxSuggestions, "--json")
+	}
+	return res
+}
+
+// conditionalsString returns the report of the conditional compilation branches.
+func (r *compileResult) conditionalsString() string {
+	if len(r.BuilderResult.ConditionalBranches) == 0 {
+		return tr("No conditional compilation directives found in the sketch.")
+	}
+	activeColor := feedback.GetTheme().Success
+	inactiveColor := feedback.GetTheme().Muted
+	t := table.New()
+	t.SetHeader(tr("Location"), tr("Directive"), tr("Status"))
+	for _, branch := range r.BuilderResult.ConditionalBranches {
+		file := paths.New(branch.File)
+		if rel, err := file.RelFrom(r.sketchPath); err == nil && !strings.HasPrefix(rel.String(), "..") {
+			file = rel
+		}
+		location := fmt.Sprintf("%s:%d", file, branch.Line)
+		if branch.EndLine > 0 {
+			location += fmt.Sprintf("-%d", branch.EndLine)
+		}
+		directive := strings.TrimSpace("#" + branch.Directive + " " + branch.Condition)
+		if branch.Active {
+			t.AddRow(location, directive, table.NewCell(tr("compiled"), activeColor))
+		} else {
+			t.AddRow(location, table.NewCell(directive, inactiveColor), table.NewCell(tr("not compiled"), inactiveColor))
+		}
+	}
+	return t.Render()
+}
+
+// includeIssuesString returns the report of the issues of the #include
+// directives of the sketch.
+func (r *compileResult) includeIssuesString() string {
+	if len(r.BuilderResult.IncludeIssues) == 0 {
+		return tr("No issues found in the #include directives of the sketch.")
+	}
+	sketchDir := r.sketchPath
+	if absSketchDir, err := sketchDir.Abs(); err == nil {
+		sketchDir = absSketchDir
+	}
+	t := table.New()
+	t.SetHeader(tr("Location"), tr("Issue"))
+	for _, issue := range r.BuilderResult.IncludeIssues {
+		location := paths.New(issue.File)
+		if rel, err := location.RelFrom(sketchDir); err == nil && !strings.HasPrefix(rel.String(), "..") {
+			location = rel
+		}
+		switch issue.Kind {
+		case result.IncludeIssueKindUnused:
+			t.AddRow(fmt.Sprintf("%s:%d", location, issue.Line), tr("unused #include %s", issue.Header))
+		case result.IncludeIssueKindMissing:
+			t.AddRow(location.String(), tr("missing #include %[1]s for %[2]s, included by %[3]s", issue.Header, strings.Join(issue.Symbols, ", "), issue.IncludedBy))
+		}
+	}
+	return t.Render()
+}
+
+// appliedFixItsString renders the fix-it hints applied to the source files.
+func (r *compileResult) appliedFixItsString() string {
+	sketchDir := r.sketchPath
+	if absSketchDir, err := sketchDir.Abs(); err == nil {
+		sketchDir = absSketchDir
+	}
+	t := table.New()
+	t.SetHeader(table.NewCell(tr("Applied fix"), feedback.GetTheme().Title), tr("Change"))
+	for _, fixit := range r.BuilderResult.AppliedFixIts {
+		file := paths.New(fixit.File)
+		if rel, err := file.RelFrom(sketchDir); err == nil && !strings.HasPrefix(rel.String(), "..") {
+			file = rel
+		}
+		location := fmt.Sprintf("%s:%d:%d", file, fixit.StartLine, fixit.StartColumn)
+		switch {
+		case fixit.StartLine == fixit.EndLine && fixit.StartColumn == fixit.EndColumn:
+			t.AddRow(location, tr("inserted %q", fixit.Replacement))
+		case fixit.Replacement == "":
+			t.AddRow(location, tr("removed"))
+		default:
+			t.AddRow(location, tr("replaced with %q", fixit.Replacement))
+		}
+	}
+	return t.Render()
+}
+
 func (r *compileResult) ErrorString() string {
+	if len(r.ErrorsSummary) > 0 {
+		return compileErrorsSummary(r.ErrorsSummary) + "\n" + r.Error
+	}
 	return r.Error
 }
+
+// parseSecrets parses the secrets given as NAME=VALUE pairs. If the value is
+// missing it is read from the environment variable with the same name.
+func parseSecrets(args []string) map[string]string {
+	res := map[string]string{}
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			value, ok = os.LookupEnv(name)
+			if !ok {
+				feedback.Fatal(tr("Secret %[1]s not set: use --secret %[1]s=VALUE or set the %[1]s environment variable", name), feedback.ErrBadArgument)
+			}
+		}
+		res[name] = value
+	}
+	return res
+}
+
+// compileTarget returns the part of the sketch selected by the --only-* flags,
+// or nil to build the whole sketch.
+func compileTarget() *rpc.CompileTarget {
+	switch {
+	case onlyCore:
+		return &rpc.CompileTarget{Target: &rpc.CompileTarget_Core{Core: true}}
+	case onlyLibrary != "":
+		return &rpc.CompileTarget{Target: &rpc.CompileTarget_Library{Library: onlyLibrary}}
+	case onlySketch:
+		return &rpc.CompileTarget{Target: &rpc.CompileTarget_Sketch{Sketch: true}}
+	case onlyFile != "":
+		file, err := paths.New(onlyFile).Abs()
+		if err != nil {
+			feedback.Fatal(tr("Error converting path to absolute: %v", err), feedback.ErrGeneric)
+		}
+		return &rpc.CompileTarget{Target: &rpc.CompileTarget_File{File: file.String()}}
+	}
+	return nil
+}
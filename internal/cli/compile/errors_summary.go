@@ -0,0 +1,98 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/result"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/go-paths-helper"
+)
+
+// maxErrorLocations is the number of locations of an error shown in the
+// summary, the others are only counted.
+const maxErrorLocations = 3
+
+// compileErrorGroup is a compiler error reported, with the same message, in
+// one or more locations.
+type compileErrorGroup struct {
+	Message   string   `json:"message"`
+	Locations []string `json:"locations"`
+}
+
+// groupCompileErrors groups the compiler errors having the same message, in
+// the order of their first occurrence. The locations inside the sketch, or
+// inside its copy in the build path, are relative to the sketch directory.
+func groupCompileErrors(diags []*result.CompileDiagnostic, sketchDirs ...*paths.Path) []*compileErrorGroup {
+	groups := []*compileErrorGroup{}
+	byMessage := map[string]*compileErrorGroup{}
+	for _, diag := range diags {
+		if diag.Severity != "ERROR" && diag.Severity != "FATAL" {
+			continue
+		}
+		file := paths.New(diag.File)
+		for _, sketchDir := range sketchDirs {
+			if rel, err := file.RelFrom(sketchDir); err == nil && !strings.HasPrefix(rel.String(), "..") {
+				file = rel
+				break
+			}
+		}
+		location := fmt.Sprintf("%s:%d:%d", file, diag.Line, diag.Column)
+
+		group, ok := byMessage[diag.Message]
+		if !ok {
+			group = &compileErrorGroup{Message: diag.Message}
+			byMessage[diag.Message] = group
+			groups = append(groups, group)
+		}
+		// The same error is reported by each file including a broken header
+		if !slices.Contains(group.Locations, location) {
+			group.Locations = append(group.Locations, location)
+		}
+	}
+	return groups
+}
+
+// compileErrorsSummary renders the first error, that often causes the
+// others, followed by the table of the errors grouped by message.
+func compileErrorsSummary(groups []*compileErrorGroup) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	theme := feedback.GetTheme()
+	first := groups[0]
+	res := theme.Title.Sprint(tr("First error:")) + " " + first.Locations[0] + ": " + theme.Error.Sprint(first.Message) + "\n\n"
+
+	t := table.New()
+	t.SetHeader(tr("Error"), tr("Count"), tr("Locations"))
+	for _, group := range groups {
+		locations := group.Locations
+		more := ""
+		if len(locations) > maxErrorLocations {
+			more = " " + tr("(and %d more)", len(locations)-maxErrorLocations)
+			locations = locations[:maxErrorLocations]
+		}
+		t.AddRow(
+			table.NewCell(group.Message, theme.Error),
+			fmt.Sprint(len(group.Locations)),
+			table.NewCell(strings.Join(locations, ", ")+more, theme.Muted))
+	}
+	return res + t.Render()
+}
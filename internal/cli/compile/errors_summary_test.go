@@ -0,0 +1,47 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/cli/feedback/result"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupCompileErrors(t *testing.T) {
+	sketchPath := paths.New("/home/user/Arduino/Blink")
+	diags := []*result.CompileDiagnostic{
+		{Severity: "ERROR", Message: "'led' was not declared in this scope", File: sketchPath.Join("Blink.ino").String(), Line: 10, Column: 5},
+		{Severity: "WARNING", Message: "unused variable 'x'", File: sketchPath.Join("Blink.ino").String(), Line: 3, Column: 7},
+		{Severity: "ERROR", Message: "expected ';' before '}' token", File: "/libs/Servo/Servo.h", Line: 20, Column: 1},
+		{Severity: "ERROR", Message: "'led' was not declared in this scope", File: sketchPath.Join("src", "util.cpp").String(), Line: 4, Column: 2},
+		{Severity: "ERROR", Message: "expected ';' before '}' token", File: "/libs/Servo/Servo.h", Line: 20, Column: 1},
+	}
+	groups := groupCompileErrors(diags, sketchPath)
+	require.Equal(t, []*compileErrorGroup{
+		{
+			Message:   "'led' was not declared in this scope",
+			Locations: []string{"Blink.ino:10:5", paths.New("src", "util.cpp").String() + ":4:2"},
+		},
+		{
+			Message:   "expected ';' before '}' token",
+			Locations: []string{paths.New("/libs/Servo/Servo.h").String() + ":20:1"},
+		},
+	}, groups)
+	require.Empty(t, groupCompileErrors(diags[1:2], sketchPath))
+}
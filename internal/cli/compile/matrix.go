@@ -0,0 +1,258 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/compile"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/result"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// runCompileMatrixCommand compiles each of the given sketches for every
+// FQBN in the --fqbn-list flag, using a single instance so that the
+// indexes, the installed platforms and the core cache are shared between
+// all the builds.
+func runCompileMatrixCommand(cmd *cobra.Command, args []string) {
+	logrus.Info("Executing `arduino-cli compile --fqbn-list`")
+
+	for _, flag := range []string{"fqbn", "profile", "port", "protocol", "upload", "preprocess", "show-properties", "dump-profile", "source-override"} {
+		if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
+			feedback.Fatal(tr("You cannot use the %[1]s flag together with %[2]s.", "--"+flag, "--fqbn-list"), feedback.ErrBadArgument)
+		}
+	}
+
+	sketchPaths := paths.PathList{}
+	if len(args) == 0 {
+		sketchPaths.Add(arguments.InitSketchPath(""))
+	}
+	for _, arg := range args {
+		sketchPaths.Add(arguments.InitSketchPath(arg))
+	}
+
+	var libraryAbs []string
+	for _, libPath := range paths.NewPathList(library...) {
+		libPath, err := libPath.Abs()
+		if err != nil {
+			feedback.Fatal(tr("Error converting path to absolute: %v", err), feedback.ErrGeneric)
+		}
+		libraryAbs = append(libraryAbs, libPath.String())
+	}
+
+	inst := instance.CreateAndInit()
+	textOutput := feedback.GetFormat() == feedback.Text
+
+	sketchDirs := matrixSketchDirs(sketchPaths)
+	res := &compileMatrixResult{Success: true}
+	summaries := []*compileSummary{}
+	for _, sketchPath := range sketchPaths {
+		for _, fqbn := range fqbnList {
+			entry := &compileMatrixEntry{
+				Sketch: sketchPath.String(),
+				Fqbn:   fqbn,
+			}
+
+			var stdOut, stdErr io.Writer
+			var stdIORes func() *feedback.OutputStreamsResult
+			if textOutput {
+				feedback.Print(tr("Compiling %[1]s for %[2]s...", sketchPath.Base(), fqbn))
+				stdOut, stdErr, stdIORes = feedback.OutputStreams()
+			} else {
+				stdOut, stdErr, stdIORes = feedback.NewBufferedStreams()
+			}
+
+			entryBuildPath := matrixEntryPath(buildPath, sketchDirs[sketchPath], fqbn)
+			if entryBuildPath == "" {
+				entryBuildPath = matrixDefaultBuildPath(sketchPath, fqbn)
+			}
+			compileRequest := &rpc.CompileRequest{
+				Instance:         inst,
+				Fqbn:             fqbn,
+				SketchPath:       sketchPath.String(),
+				BuildCachePath:   buildCachePath,
+				BuildPath:        entryBuildPath,
+				BuildProperties:  buildProperties,
+				Warnings:         warnings,
+				Verbose:          verbose,
+				Quiet:            quiet,
+				ExportDir:        matrixEntryPath(exportDir, sketchDirs[sketchPath], fqbn),
+				Libraries:        libraries,
+				Library:          libraryAbs,
+				OptimizeForDebug: optimizeForDebug,
+				Clean:            clean,
+				KeysKeychain:     keysKeychain,
+				SignKey:          signKey,
+				EncryptKey:       encryptKey,
+				Jobs:             jobs,
+				Secrets:          parseSecrets(secrets),
+
+				CreateCompilationDatabaseOnly: compilationDatabaseOnly,
+			}
+			builderRes, err := compile.Compile(context.Background(), compileRequest, stdOut, stdErr, nil)
+			entry.Success = err == nil
+			if err != nil {
+				entry.Error = err.Error()
+				res.Success = false
+			}
+			entry.BuilderResult = result.NewBuilderResult(builderRes)
+			if !textOutput {
+				stdIO := stdIORes()
+				entry.CompilerOut = stdIO.Stdout
+				entry.CompilerErr = stdIO.Stderr
+			}
+			res.Results = append(res.Results, entry)
+			summaries = append(summaries, newCompileSummary(sketchPath.Base(), fqbn, builderRes, err))
+		}
+	}
+
+	if summaryFile != "" {
+		if err := writeCompileSummary(paths.New(summaryFile), summaries...); err != nil {
+			feedback.Warning(tr("Error writing build summary: %v", err))
+		}
+	}
+
+	if !res.Success {
+		feedback.FatalResult(res, feedback.ErrGeneric)
+	}
+	feedback.PrintResult(res)
+}
+
+// matrixSketchDirs returns the name of the subfolder used for each sketch in
+// the build and export directories: the name of the sketch, followed by a
+// hash of its path when other sketches in the list have the same name.
+func matrixSketchDirs(sketchPaths paths.PathList) map[*paths.Path]string {
+	count := map[string]int{}
+	for _, sketchPath := range sketchPaths {
+		count[sketchPath.Base()]++
+	}
+	dirs := map[*paths.Path]string{}
+	for _, sketchPath := range sketchPaths {
+		dir := sketchPath.Base()
+		if count[dir] > 1 {
+			hash := md5.Sum([]byte(sketchPath.String()))
+			dir += "-" + strings.ToUpper(hex.EncodeToString(hash[:4]))
+		}
+		dirs[sketchPath] = dir
+	}
+	return dirs
+}
+
+// matrixEntryPath returns a subfolder of the given base path that is unique
+// for the given sketch and FQBN, or an empty string if base is empty.
+func matrixEntryPath(base string, sketchDir string, fqbn string) string {
+	if base == "" {
+		return ""
+	}
+	return paths.New(base, sketchDir, strings.ReplaceAll(fqbn, ":", ".")).String()
+}
+
+// matrixDefaultBuildPath returns the build directory of the sketch for the
+// board, so the boards don't share the default build directory of the
+// sketch and each build stays incremental. An empty string is returned (and
+// the default build directory is used) if the sketch can't be loaded.
+func matrixDefaultBuildPath(sketchPath *paths.Path, fqbn string) string {
+	sk, err := sketch.New(sketchPath)
+	if err != nil {
+		return ""
+	}
+	template, err := configuration.SketchBuildPathTemplate(configuration.Settings)
+	if err != nil {
+		return ""
+	}
+	buildPath, err := sk.VariantBuildPath(template, fqbn, buildProperties)
+	if err != nil {
+		return ""
+	}
+	return buildPath.String()
+}
+
+type compileMatrixEntry struct {
+	Sketch        string                `json:"sketch"`
+	Fqbn          string                `json:"fqbn"`
+	Success       bool                  `json:"success"`
+	Error         string                `json:"error,omitempty"`
+	CompilerOut   string                `json:"compiler_out,omitempty"`
+	CompilerErr   string                `json:"compiler_err,omitempty"`
+	BuilderResult *result.BuilderResult `json:"builder_result,omitempty"`
+}
+
+type compileMatrixResult struct {
+	Results []*compileMatrixEntry `json:"results"`
+	Success bool                  `json:"success"`
+}
+
+func (r *compileMatrixResult) Data() interface{} {
+	return r
+}
+
+func (r *compileMatrixResult) String() string {
+	titleColor := feedback.GetTheme().Title
+	okColor := feedback.GetTheme().Success
+	failColor := feedback.GetTheme().Error
+
+	t := table.New()
+	t.SetHeader(
+		table.NewCell(tr("Sketch"), titleColor),
+		table.NewCell(tr("FQBN"), titleColor),
+		table.NewCell(tr("Status"), titleColor),
+		table.NewCell(tr("Sizes"), titleColor))
+	for _, entry := range r.Results {
+		status := table.NewCell(tr("OK"), okColor)
+		if !entry.Success {
+			status = table.NewCell(tr("FAILED"), failColor)
+		}
+		sizes := []string{}
+		if entry.BuilderResult != nil {
+			for _, section := range entry.BuilderResult.ExecutableSectionsSize {
+				if section.MaxSize > 0 {
+					sizes = append(sizes, fmt.Sprintf("%s: %d/%d (%d%%)", section.Name, section.Size, section.MaxSize, section.Size*100/section.MaxSize))
+				} else {
+					sizes = append(sizes, fmt.Sprintf("%s: %d", section.Name, section.Size))
+				}
+			}
+		}
+		t.AddRow(paths.New(entry.Sketch).Base(), entry.Fqbn, status, strings.Join(sizes, ", "))
+	}
+	return "\n" + t.Render()
+}
+
+func (r *compileMatrixResult) ErrorString() string {
+	failed := []string{}
+	for _, entry := range r.Results {
+		if !entry.Success {
+			failed = append(failed, fmt.Sprintf("%s (%s): %s", paths.New(entry.Sketch).Base(), entry.Fqbn, entry.Error))
+		}
+	}
+	if len(failed) == 0 {
+		return ""
+	}
+	return tr("Some builds failed:") + "\n" + strings.Join(failed, "\n")
+}
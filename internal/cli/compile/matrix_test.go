@@ -0,0 +1,37 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"testing"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixEntryPath(t *testing.T) {
+	blink := paths.New("/sketches/Blink")
+	other := paths.New("/other/Blink")
+	fade := paths.New("/sketches/Fade")
+	dirs := matrixSketchDirs(paths.PathList{blink, other, fade})
+	require.Equal(t, "Fade", dirs[fade])
+	require.NotEqual(t, dirs[blink], dirs[other])
+	require.Regexp(t, "^Blink-[0-9A-F]{8}$", dirs[blink])
+	require.Regexp(t, "^Blink-[0-9A-F]{8}$", dirs[other])
+
+	require.Equal(t, "", matrixEntryPath("", dirs[fade], "arduino:avr:uno"))
+	require.Equal(t, paths.New("/build", "Fade", "arduino.avr.uno").String(), matrixEntryPath("/build", dirs[fade], "arduino:avr:uno"))
+}
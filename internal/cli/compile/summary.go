@@ -0,0 +1,142 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+)
+
+// compileSummary is a concise report of the result of a build, suitable
+// to be posted as a comment on a pull request.
+type compileSummary struct {
+	Sketch        string                   `json:"sketch"`
+	Fqbn          string                   `json:"fqbn"`
+	Success       bool                     `json:"success"`
+	Error         string                   `json:"error,omitempty"`
+	Sizes         []*compileSummarySize    `json:"sizes,omitempty"`
+	Warnings      int                      `json:"warnings"`
+	Errors        int                      `json:"errors"`
+	Platform      string                   `json:"platform,omitempty"`
+	UsedLibraries []*compileSummaryLibrary `json:"used_libraries,omitempty"`
+}
+
+type compileSummarySize struct {
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	MaxSize int64  `json:"max_size,omitempty"`
+}
+
+type compileSummaryLibrary struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+func newCompileSummary(sketch, fqbn string, builderRes *rpc.BuilderResult, compileErr error) *compileSummary {
+	summary := &compileSummary{
+		Sketch:  sketch,
+		Fqbn:    fqbn,
+		Success: compileErr == nil,
+	}
+	if compileErr != nil {
+		summary.Error = compileErr.Error()
+	}
+	for _, section := range builderRes.GetExecutableSectionsSize() {
+		summary.Sizes = append(summary.Sizes, &compileSummarySize{
+			Name:    section.GetName(),
+			Size:    section.GetSize(),
+			MaxSize: section.GetMaxSize(),
+		})
+	}
+	for _, diag := range builderRes.GetDiagnostics() {
+		switch diag.GetSeverity() {
+		case "WARNING":
+			summary.Warnings++
+		case "ERROR", "FATAL":
+			summary.Errors++
+		}
+	}
+	if platform := builderRes.GetBoardPlatform(); platform != nil {
+		summary.Platform = platform.GetId() + "@" + platform.GetVersion()
+	}
+	for _, lib := range builderRes.GetUsedLibraries() {
+		summary.UsedLibraries = append(summary.UsedLibraries, &compileSummaryLibrary{
+			Name:    lib.GetName(),
+			Version: lib.GetVersion(),
+		})
+	}
+	return summary
+}
+
+// Markdown renders the summary in markdown format.
+func (s *compileSummary) Markdown() string {
+	status := "success"
+	if !s.Success {
+		status = "failure"
+	}
+	res := fmt.Sprintf("### %s (`%s`): %s\n\n", s.Sketch, s.Fqbn, status)
+	if s.Platform != "" {
+		res += fmt.Sprintf("Platform: `%s`\n\n", s.Platform)
+	}
+	if s.Error != "" {
+		res += "```\n" + strings.TrimSpace(s.Error) + "\n```\n\n"
+	}
+	if len(s.Sizes) > 0 {
+		res += "| Section | Size | Max size | Usage |\n"
+		res += "|---|---:|---:|---:|\n"
+		for _, size := range s.Sizes {
+			maxSize, usage := "-", "-"
+			if size.MaxSize > 0 {
+				maxSize = fmt.Sprint(size.MaxSize)
+				usage = fmt.Sprintf("%d%%", size.Size*100/size.MaxSize)
+			}
+			res += fmt.Sprintf("| %s | %d | %s | %s |\n", size.Name, size.Size, maxSize, usage)
+		}
+		res += "\n"
+	}
+	res += fmt.Sprintf("Warnings: %d, Errors: %d\n", s.Warnings, s.Errors)
+	if len(s.UsedLibraries) > 0 {
+		res += "\n| Library | Version |\n"
+		res += "|---|---|\n"
+		for _, lib := range s.UsedLibraries {
+			res += fmt.Sprintf("| %s | %s |\n", lib.Name, lib.Version)
+		}
+	}
+	return res
+}
+
+// writeCompileSummary writes the given summaries in a file, the format is
+// JSON if the file has the .json extension, otherwise markdown is used.
+// The JSON is always an array, even for a single summary, so the consumers
+// get the same schema for a single build and for a build matrix.
+func writeCompileSummary(file *paths.Path, summaries ...*compileSummary) error {
+	if strings.EqualFold(file.Ext(), ".json") {
+		data, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return err
+		}
+		return file.WriteFile(append(data, '\n'))
+	}
+	md := []string{}
+	for _, summary := range summaries {
+		md = append(md, summary.Markdown())
+	}
+	return file.WriteFile([]byte(strings.Join(md, "\n")))
+}
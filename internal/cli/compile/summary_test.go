@@ -0,0 +1,82 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileSummary(t *testing.T) {
+	builderRes := &rpc.BuilderResult{
+		ExecutableSectionsSize: []*rpc.ExecutableSectionSize{
+			{Name: "text", Size: 924, MaxSize: 32256},
+			{Name: "data", Size: 9, MaxSize: 2048},
+		},
+		Diagnostics: []*rpc.CompileDiagnostic{
+			{Severity: "WARNING", Message: "unused variable"},
+			{Severity: "WARNING", Message: "unused function"},
+			{Severity: "ERROR", Message: "missing semicolon"},
+		},
+		BoardPlatform: &rpc.InstalledPlatformReference{Id: "arduino:avr", Version: "1.8.6"},
+		UsedLibraries: []*rpc.Library{{Name: "Servo", Version: "1.2.1"}},
+	}
+	summary := newCompileSummary("Blink", "arduino:avr:uno", builderRes, errors.New("build failed"))
+	require.False(t, summary.Success)
+	require.Equal(t, 2, summary.Warnings)
+	require.Equal(t, 1, summary.Errors)
+	require.Equal(t, "arduino:avr@1.8.6", summary.Platform)
+
+	md := summary.Markdown()
+	require.Contains(t, md, "### Blink (`arduino:avr:uno`): failure")
+	require.Contains(t, md, "| text | 924 | 32256 | 2% |")
+	require.Contains(t, md, "Warnings: 2, Errors: 1")
+	require.Contains(t, md, "| Servo | 1.2.1 |")
+
+	// A nil result (for example when the build fails early) must not panic
+	summary = newCompileSummary("Blink", "arduino:avr:uno", nil, nil)
+	require.True(t, summary.Success)
+	require.Contains(t, summary.Markdown(), "Warnings: 0, Errors: 0")
+}
+
+func TestWriteCompileSummary(t *testing.T) {
+	tmp := paths.New(t.TempDir())
+	blink := newCompileSummary("Blink", "arduino:avr:uno", nil, nil)
+	fade := newCompileSummary("Fade", "arduino:avr:uno", nil, errors.New("build failed"))
+
+	// The JSON is an array also for a single summary
+	for _, summaries := range [][]*compileSummary{{blink}, {blink, fade}} {
+		file := tmp.Join("summary.json")
+		require.NoError(t, writeCompileSummary(file, summaries...))
+		data, err := file.ReadFile()
+		require.NoError(t, err)
+		var read []*compileSummary
+		require.NoError(t, json.Unmarshal(data, &read))
+		require.Equal(t, summaries, read)
+	}
+
+	file := tmp.Join("summary.md")
+	require.NoError(t, writeCompileSummary(file, blink, fade))
+	data, err := file.ReadFile()
+	require.NoError(t, err)
+	require.Contains(t, string(data), "### Blink (`arduino:avr:uno`): success")
+	require.Contains(t, string(data), "### Fade (`arduino:avr:uno`): failure")
+}
@@ -19,29 +19,63 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
 )
 
 var validMap = map[string]reflect.Kind{
-	"board_manager.additional_urls": reflect.Slice,
-	"daemon.port":                   reflect.String,
-	"directories.data":              reflect.String,
-	"directories.downloads":         reflect.String,
-	"directories.user":              reflect.String,
-	"directories.builtin.tools":     reflect.String,
-	"directories.builtin.libraries": reflect.String,
-	"library.enable_unsafe_install": reflect.Bool,
-	"locale":                        reflect.String,
-	"logging.file":                  reflect.String,
-	"logging.format":                reflect.String,
-	"logging.level":                 reflect.String,
-	"sketch.always_export_binaries": reflect.Bool,
-	"metrics.addr":                  reflect.String,
-	"metrics.enabled":               reflect.Bool,
-	"network.proxy":                 reflect.String,
-	"network.user_agent_ext":        reflect.String,
-	"output.no_color":               reflect.Bool,
-	"updater.enable_notification":   reflect.Bool,
+	"ble.enabled":                                 reflect.Bool,
+	"board_manager.additional_urls":               reflect.Slice,
+	"board_manager.require_secure_tool_downloads": reflect.Bool,
+	"cloud.api_url":                               reflect.String,
+	"cloud.token":                                 reflect.String,
+	"daemon.port":                                 reflect.String,
+	"daemon.audit_log":                            reflect.String,
+	"daemon.watch_directories":                    reflect.Bool,
+	"directories.data":                            reflect.String,
+	"directories.downloads":                       reflect.String,
+	"directories.user":                            reflect.String,
+	"directories.builtin.tools":                   reflect.String,
+	"directories.builtin.libraries":               reflect.String,
+	"library.enable_unsafe_install":               reflect.Bool,
+	"locale":                                      reflect.String,
+	"locking.wait_timeout":                        reflect.String,
+	"logging.file":                                reflect.String,
+	"logging.format":                              reflect.String,
+	"logging.level":                               reflect.String,
+	"loopback.enabled":                            reflect.Bool,
+	"sketch.always_export_binaries":               reflect.Bool,
+	"sketch.warnings_as_errors_exempt_libraries":  reflect.Slice,
+	"sketch.build_path.strategy":                  reflect.String,
+	"sketch.build_path.template":                  reflect.String,
+	"sketch.build_logs_to_keep":                   reflect.String,
+	"metrics.addr":                                reflect.String,
+	"metrics.enabled":                             reflect.Bool,
+	"network.download_rate_limit":                 reflect.String,
+	"network.proxy":                               reflect.String,
+	"network.retry.attempts":                      reflect.String,
+	"network.retry.backoff":                       reflect.String,
+	"network.user_agent":                          reflect.String,
+	"network.user_agent_ext":                      reflect.String,
+	"notifications.webhooks":                      reflect.Slice,
+	"output.no_color":                             reflect.Bool,
+	"output.theme.title":                          reflect.String,
+	"output.theme.accent":                         reflect.String,
+	"output.theme.muted":                          reflect.String,
+	"output.theme.success":                        reflect.String,
+	"output.theme.warning":                        reflect.String,
+	"output.theme.error":                          reflect.String,
+	"output.theme.header":                         reflect.String,
+	"output.theme.progress":                       reflect.String,
+	"restrictions.allow_commands":                 reflect.Slice,
+	"restrictions.deny_commands":                  reflect.Slice,
+	"restrictions.allowed_additional_urls":        reflect.Slice,
+	"tools.env.clean":                             reflect.Bool,
+	"tools.env.no_network":                        reflect.Bool,
+	"tools.env.path":                              reflect.Slice,
+	"tools.env.properties":                        reflect.Slice,
+	"tools.env.vars":                              reflect.Slice,
+	"updater.enable_notification":                 reflect.Bool,
 }
 
 func typeOf(key string) (reflect.Kind, error) {
@@ -57,5 +91,8 @@ func validateKey(key string) reflect.Kind {
 	if err != nil {
 		feedback.FatalError(err, feedback.ErrGeneric)
 	}
+	if err := configuration.CheckSettingChangeAllowed(configuration.Settings, key); err != nil {
+		feedback.FatalError(err, feedback.ErrGeneric)
+	}
 	return kind
 }
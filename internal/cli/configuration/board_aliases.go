@@ -0,0 +1,116 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package configuration
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// BoardAlias is a name given by the user to a board, that can be used in
+// place of the port address and of the FQBN. The board is identified by its
+// USB serial number, so the alias keeps working when the board is connected
+// to a different port. The other settings are used as defaults by the
+// commands using the alias.
+type BoardAlias struct {
+	FQBN          string            `mapstructure:"fqbn" json:"fqbn,omitempty"`
+	SerialNumber  string            `mapstructure:"serial_number" json:"serial_number,omitempty"`
+	Programmer    string            `mapstructure:"programmer" json:"programmer,omitempty"`
+	MonitorConfig map[string]string `mapstructure:"monitor_config" json:"monitor_config,omitempty"`
+}
+
+// MonitorSettings returns the monitor settings of the alias in the form
+// `setting=value`, sorted by setting.
+func (a *BoardAlias) MonitorSettings() []string {
+	res := []string{}
+	for setting, value := range a.MonitorConfig {
+		res = append(res, setting+"="+value)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (a *BoardAlias) toMap() map[string]interface{} {
+	res := map[string]interface{}{}
+	if a.FQBN != "" {
+		res["fqbn"] = a.FQBN
+	}
+	if a.SerialNumber != "" {
+		res["serial_number"] = a.SerialNumber
+	}
+	if a.Programmer != "" {
+		res["programmer"] = a.Programmer
+	}
+	if len(a.MonitorConfig) > 0 {
+		config := map[string]interface{}{}
+		for setting, value := range a.MonitorConfig {
+			config[setting] = value
+		}
+		res["monitor_config"] = config
+	}
+	return res
+}
+
+// BoardAliases returns the board aliases defined in the configuration, the
+// names of the aliases are lowercase.
+func BoardAliases(settings *viper.Viper) map[string]*BoardAlias {
+	aliases := map[string]*BoardAlias{}
+	if settings == nil {
+		return aliases
+	}
+	if err := settings.UnmarshalKey("board_aliases", &aliases); err != nil {
+		return map[string]*BoardAlias{}
+	}
+	return aliases
+}
+
+// GetBoardAlias returns the board alias with the given name, the names are
+// case insensitive. It returns nil if the alias is not defined.
+func GetBoardAlias(settings *viper.Viper, name string) *BoardAlias {
+	if name == "" {
+		return nil
+	}
+	return BoardAliases(settings)[strings.ToLower(name)]
+}
+
+// WriteBoardAliases replaces the board aliases in the settings and saves the
+// settings in the configuration file.
+func WriteBoardAliases(settings *viper.Viper, aliases map[string]*BoardAlias) error {
+	aliasesMap := map[string]interface{}{}
+	for name, alias := range aliases {
+		aliasesMap[strings.ToLower(name)] = alias.toMap()
+	}
+	settings.Set("board_aliases", aliasesMap)
+
+	configFile := settings.ConfigFileUsed()
+	if configFile == "" {
+		return errors.New(tr("no configuration file found, create one with `config init`"))
+	}
+	// The keys of a map read from the configuration file can't be removed
+	// from the settings, they would be written again, so the file is written
+	// from a copy of the settings without the old aliases.
+	out := viper.New()
+	for _, key := range settings.AllKeys() {
+		if !strings.HasPrefix(key, "board_aliases.") {
+			out.Set(key, settings.Get(key))
+		}
+	}
+	out.Set("board_aliases", aliasesMap)
+	return out.WriteConfigAs(configFile)
+}
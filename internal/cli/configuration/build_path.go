@@ -0,0 +1,75 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/spf13/viper"
+)
+
+// SketchBuildPathTemplate returns the template of the build directories of
+// the sketches, set with the `sketch.build_path.strategy` setting:
+//   - `temp`: a directory unique for each sketch in the temporary directory
+//     of the system, the default
+//   - `cache`: a directory unique for each sketch in the cache directory of
+//     the user, that is not cleared at reboot
+//   - `sketch`: the `build/<FQBN>` directory inside the sketch
+//   - `template`: the template set with the `sketch.build_path.template`
+//     setting
+func SketchBuildPathTemplate(settings *viper.Viper) (string, error) {
+	strategy := ""
+	if settings != nil {
+		strategy = settings.GetString("sketch.build_path.strategy")
+	}
+	switch strategy {
+	case "", "temp":
+		return "{temp}/arduino/sketches/{hash}", nil
+	case "cache":
+		return "{cache}/arduino/sketches/{hash}", nil
+	case "sketch":
+		return "{sketch_path}/build/{fqbn}", nil
+	case "template":
+		template := settings.GetString("sketch.build_path.template")
+		if template == "" {
+			return "", errors.New(tr("Invalid sketch.build_path.template: the template is empty"))
+		}
+		return template, nil
+	}
+	return "", fmt.Errorf(tr("Invalid sketch.build_path.strategy: %s"), strategy)
+}
+
+// SketchesBuildCacheDir returns the directory containing the build
+// directories of all the sketches, or nil if the build directories are not
+// kept in a common directory.
+func SketchesBuildCacheDir(settings *viper.Viper) *paths.Path {
+	strategy := ""
+	if settings != nil {
+		strategy = settings.GetString("sketch.build_path.strategy")
+	}
+	switch strategy {
+	case "", "temp":
+		return paths.TempDir().Join("arduino", "sketches")
+	case "cache":
+		if cacheDir, err := os.UserCacheDir(); err == nil {
+			return paths.New(cacheDir, "arduino", "sketches")
+		}
+	}
+	return nil
+}
@@ -20,7 +20,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
 
@@ -80,3 +82,116 @@ func TestFindConfigFile(t *testing.T) {
 	configFile = FindConfigFileInArgsFallbackOnEnv([]string{"--config-file", "flag/path"})
 	require.Equal(t, "flag/path", configFile)
 }
+
+func TestWriteBoardAliases(t *testing.T) {
+	tmp := tmpDirOrDie()
+	defer os.RemoveAll(tmp)
+	configFile := filepath.Join(tmp, "arduino-cli.yaml")
+	config := "board_aliases:\n  old:\n    serial_number: \"1111\"\n  kept:\n    fqbn: arduino:avr:nano\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0644))
+
+	settings := Init(configFile)
+	aliases := BoardAliases(settings)
+	require.Len(t, aliases, 2)
+	require.Equal(t, "arduino:avr:nano", aliases["kept"].FQBN)
+
+	delete(aliases, "old")
+	aliases["NEW"] = &BoardAlias{SerialNumber: "2222", MonitorConfig: map[string]string{"baudrate": "9600"}}
+	require.NoError(t, WriteBoardAliases(settings, aliases))
+	require.Nil(t, GetBoardAlias(settings, "old"))
+	require.Equal(t, "2222", GetBoardAlias(settings, "new").SerialNumber)
+
+	// The removed alias is not in the configuration file anymore
+	settings = Init(configFile)
+	aliases = BoardAliases(settings)
+	require.Len(t, aliases, 2)
+	require.Equal(t, &BoardAlias{SerialNumber: "2222", MonitorConfig: map[string]string{"baudrate": "9600"}}, aliases["new"])
+	require.Equal(t, "arduino:avr:nano", aliases["kept"].FQBN)
+
+	require.Error(t, WriteBoardAliases(Init(filepath.Join(tmp, "missing.yaml")), aliases))
+}
+
+func TestNetworkSettings(t *testing.T) {
+	tmp := tmpDirOrDie()
+	defer os.RemoveAll(tmp)
+	configFile := filepath.Join(tmp, "arduino-cli.yaml")
+	config := `network:
+  user_agent: corporate-agent/1.0
+  download_rate_limit: 1.5MiB
+  retry:
+    attempts: 3
+    backoff: 2s
+  extra_headers:
+    - host: Mirror.example.com
+      headers:
+        authorization: Bearer secret
+        X-Api-Key: key
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0644))
+	settings := Init(configFile)
+
+	require.Equal(t, "corporate-agent/1.0", UserAgent(settings))
+
+	limit, err := NetworkDownloadRateLimit(settings)
+	require.NoError(t, err)
+	require.Equal(t, int64(1572864), limit)
+
+	attempts, backoff, err := NetworkRetryPolicy(settings)
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, 2*time.Second, backoff)
+
+	headers, err := NetworkExtraHeaders(settings)
+	require.NoError(t, err)
+	require.Len(t, headers, 1)
+	require.Equal(t, "Bearer secret", headers["mirror.example.com"].Get("Authorization"))
+	require.Equal(t, "key", headers["mirror.example.com"].Get("X-Api-Key"))
+
+	for value, expected := range map[string]int64{"": 0, "2048": 2048, "500K": 512000, "500 KB/s": 512000, "1g": 1 << 30} {
+		settings.Set("network.download_rate_limit", value)
+		limit, err := NetworkDownloadRateLimit(settings)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, limit, value)
+	}
+	settings.Set("network.download_rate_limit", "fast")
+	_, err = NetworkDownloadRateLimit(settings)
+	require.Error(t, err)
+
+	// The defaults don't change the behavior of the previous versions
+	settings = Init(filepath.Join(tmp, "missing.yaml"))
+	attempts, backoff, err = NetworkRetryPolicy(settings)
+	require.NoError(t, err)
+	require.Zero(t, attempts)
+	require.Equal(t, time.Second, backoff)
+	headers, err = NetworkExtraHeaders(settings)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+}
+
+func TestSketchBuildPathTemplate(t *testing.T) {
+	settings := viper.New()
+	SetDefaults(settings)
+
+	template, err := SketchBuildPathTemplate(settings)
+	require.NoError(t, err)
+	require.Equal(t, "{temp}/arduino/sketches/{hash}", template)
+	require.NotNil(t, SketchesBuildCacheDir(settings))
+
+	settings.Set("sketch.build_path.strategy", "sketch")
+	template, err = SketchBuildPathTemplate(settings)
+	require.NoError(t, err)
+	require.Equal(t, "{sketch_path}/build/{fqbn}", template)
+	require.Nil(t, SketchesBuildCacheDir(settings))
+
+	settings.Set("sketch.build_path.strategy", "template")
+	_, err = SketchBuildPathTemplate(settings)
+	require.Error(t, err)
+	settings.Set("sketch.build_path.template", "{cache}/builds/{sketch}")
+	template, err = SketchBuildPathTemplate(settings)
+	require.NoError(t, err)
+	require.Equal(t, "{cache}/builds/{sketch}", template)
+
+	settings.Set("sketch.build_path.strategy", "nowhere")
+	_, err = SketchBuildPathTemplate(settings)
+	require.Error(t, err)
+}
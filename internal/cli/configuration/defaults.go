@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
 	"github.com/spf13/viper"
 )
 
@@ -34,6 +35,7 @@ func SetDefaults(settings *viper.Viper) {
 
 	// Boards Manager
 	settings.SetDefault("board_manager.additional_urls", []string{})
+	settings.SetDefault("board_manager.require_secure_tool_downloads", false)
 
 	// arduino directories
 	settings.SetDefault("directories.Data", getDefaultArduinoDataDir())
@@ -42,11 +44,27 @@ func SetDefaults(settings *viper.Viper) {
 
 	// Sketch compilation
 	settings.SetDefault("sketch.always_export_binaries", false)
+	settings.SetDefault("sketch.warnings_as_errors_exempt_libraries", []string{})
+	settings.SetDefault("sketch.build_path.strategy", "temp")
+	settings.SetDefault("sketch.build_path.template", "")
+	settings.SetDefault("sketch.build_logs_to_keep", 5)
 	settings.SetDefault("build_cache.ttl", time.Hour*24*30)
 	settings.SetDefault("build_cache.compilations_before_purge", 10)
+	settings.SetDefault("build_cache.libraries", true)
+
+	// Arduino Cloud settings
+	settings.SetDefault("cloud.api_url", "https://api2.arduino.cc/create/v2")
+	settings.SetDefault("cloud.token", "")
 
 	// daemon settings
 	settings.SetDefault("daemon.port", "50051")
+	settings.SetDefault("daemon.watch_directories", false)
+	settings.SetDefault("daemon.audit_log", "")
+
+	// Restrictions, restrictions.allowed_additional_urls has no default
+	// because being set, even to an empty list, enables it
+	settings.SetDefault("restrictions.allow_commands", []string{})
+	settings.SetDefault("restrictions.deny_commands", []string{})
 
 	// metrics settings
 	settings.SetDefault("metrics.enabled", true)
@@ -54,10 +72,32 @@ func SetDefaults(settings *viper.Viper) {
 
 	// output settings
 	settings.SetDefault("output.no_color", false)
+	for role, color := range feedback.DefaultThemeColors {
+		settings.SetDefault("output.theme."+role, color)
+	}
 
 	// updater settings
 	settings.SetDefault("updater.enable_notification", true)
 
+	// Bluetooth LE ports settings
+	settings.SetDefault("ble.enabled", false)
+
+	// loopback port settings
+	settings.SetDefault("loopback.enabled", false)
+
+	// data directory locking settings
+	settings.SetDefault("locking.wait_timeout", time.Minute*5)
+
+	// notifications settings
+	settings.SetDefault("notifications.webhooks", []string{})
+
+	// external tools settings
+	settings.SetDefault("tools.env.clean", false)
+	settings.SetDefault("tools.env.path", []string{})
+	settings.SetDefault("tools.env.vars", []string{})
+	settings.SetDefault("tools.env.properties", []string{})
+	settings.SetDefault("tools.env.no_network", false)
+
 	// Bind env vars
 	settings.SetEnvPrefix("ARDUINO")
 	settings.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
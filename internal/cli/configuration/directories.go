@@ -66,6 +66,19 @@ func ProfilesCacheDir(settings *viper.Viper) *paths.Path {
 	return DataDir(settings).Join("internal")
 }
 
+// SymbolsDir returns the full path to the symbols archive directory
+// (it contains the executables saved to resolve the addresses reported by
+// the firmware)
+func SymbolsDir(settings *viper.Viper) *paths.Path {
+	return DataDir(settings).Join("symbols")
+}
+
+// DeploymentsFile returns the full path to the registry of the sketches
+// uploaded to the boards
+func DeploymentsFile(settings *viper.Viper) *paths.Path {
+	return DataDir(settings).Join("deployments.json")
+}
+
 // DataDir returns the full path to the data directory
 func DataDir(settings *viper.Viper) *paths.Path {
 	return paths.New(settings.GetString("directories.Data"))
@@ -16,19 +16,29 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/arduino/arduino-cli/version"
 	"github.com/spf13/viper"
 )
 
-// UserAgent returns the user agent (mainly used by HTTP clients)
+// UserAgent returns the user agent (mainly used by HTTP clients). The
+// `network.user_agent` setting replaces the default one.
 func UserAgent(settings *viper.Viper) string {
 	subComponent := ""
 	if settings != nil {
+		if userAgent := settings.GetString("network.user_agent"); userAgent != "" {
+			return userAgent
+		}
 		subComponent = settings.GetString("network.user_agent_ext")
 	}
 	if subComponent != "" {
@@ -65,3 +75,91 @@ func NetworkProxy(settings *viper.Viper) (*url.URL, error) {
 		return proxy, nil
 	}
 }
+
+// NetworkRetryPolicy returns the number of times a failed HTTP request is
+// retried, by default none, and the delay before the first retry, by default
+// one second. The delay is doubled at each following retry.
+func NetworkRetryPolicy(settings *viper.Viper) (int, time.Duration, error) {
+	if settings == nil {
+		return 0, 0, nil
+	}
+	attempts := settings.GetInt("network.retry.attempts")
+	if attempts < 0 {
+		return 0, 0, errors.New(tr("Invalid network.retry.attempts '%d': must not be negative", attempts))
+	}
+	backoff := time.Second
+	if settings.IsSet("network.retry.backoff") {
+		backoff = settings.GetDuration("network.retry.backoff")
+	}
+	if backoff < 0 {
+		return 0, 0, errors.New(tr("Invalid network.retry.backoff '%s': must not be negative", backoff))
+	}
+	return attempts, backoff, nil
+}
+
+var rateLimitRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([kKmMgG]?)(?:i?B)?(?:/s)?$`)
+
+// NetworkDownloadRateLimit returns the maximum download speed in bytes per
+// second, or 0 if the speed is not limited. The limit is set with the
+// `network.download_rate_limit` setting as a number of bytes followed by an
+// optional unit (for example `500K` or `2MiB`), the units are powers of 1024.
+func NetworkDownloadRateLimit(settings *viper.Viper) (int64, error) {
+	if settings == nil {
+		return 0, nil
+	}
+	limit := strings.TrimSpace(settings.GetString("network.download_rate_limit"))
+	if limit == "" {
+		return 0, nil
+	}
+	match := rateLimitRegexp.FindStringSubmatch(limit)
+	if match == nil {
+		return 0, errors.New(tr("Invalid network.download_rate_limit '%s'", limit))
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf(tr("Invalid network.download_rate_limit '%[1]s': %[2]s"), limit, err)
+	}
+	switch strings.ToUpper(match[2]) {
+	case "K":
+		value *= 1 << 10
+	case "M":
+		value *= 1 << 20
+	case "G":
+		value *= 1 << 30
+	}
+	return int64(value), nil
+}
+
+// NetworkHostHeaders are the extra headers sent to a host
+type NetworkHostHeaders struct {
+	Host    string            `mapstructure:"host"`
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// NetworkExtraHeaders returns the extra headers to send with the HTTP requests,
+// indexed by host name. The headers are set with the `network.extra_headers`
+// setting, a list of hosts each with its own headers, for example to
+// authenticate to a private mirror.
+func NetworkExtraHeaders(settings *viper.Viper) (map[string]http.Header, error) {
+	res := map[string]http.Header{}
+	if settings == nil || !settings.IsSet("network.extra_headers") {
+		return res, nil
+	}
+	var hosts []*NetworkHostHeaders
+	if err := settings.UnmarshalKey("network.extra_headers", &hosts); err != nil {
+		return nil, fmt.Errorf(tr("Invalid network.extra_headers: %s"), err)
+	}
+	for _, host := range hosts {
+		if host == nil || host.Host == "" {
+			return nil, errors.New(tr("Invalid network.extra_headers: missing host"))
+		}
+		name := strings.ToLower(host.Host)
+		if res[name] == nil {
+			res[name] = http.Header{}
+		}
+		for header, value := range host.Headers {
+			res[name].Set(header, value)
+		}
+	}
+	return res, nil
+}
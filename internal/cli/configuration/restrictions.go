@@ -0,0 +1,93 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package configuration
+
+import (
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/spf13/viper"
+)
+
+// alwaysAllowedCommands are the commands that can't be restricted.
+var alwaysAllowedCommands = []string{"", "help", "version", "completion"}
+
+// RestrictionsEnabled returns true if any restriction is set in the
+// configuration.
+func RestrictionsEnabled(settings *viper.Viper) bool {
+	return len(settings.GetStringSlice("restrictions.allow_commands")) > 0 ||
+		len(settings.GetStringSlice("restrictions.deny_commands")) > 0 ||
+		settings.IsSet("restrictions.allowed_additional_urls")
+}
+
+// CheckCommandAllowed returns an error if the command, identified by its
+// path without the executable name (for example "core uninstall"), is listed
+// in restrictions.deny_commands or, when restrictions.allow_commands is set,
+// is not listed there. A command is also matched by the rules on the
+// commands containing it: "core" matches "core uninstall".
+func CheckCommandAllowed(settings *viper.Viper, command string) error {
+	command = strings.Join(strings.Fields(command), " ")
+	for _, c := range alwaysAllowedCommands {
+		if command == c {
+			return nil
+		}
+	}
+	denied := &cmderrors.PermissionDeniedError{Message: tr("The command %s is not allowed by the restrictions in the configuration", command)}
+	for _, rule := range settings.GetStringSlice("restrictions.deny_commands") {
+		if commandMatches(rule, command) {
+			return denied
+		}
+	}
+	allowed := settings.GetStringSlice("restrictions.allow_commands")
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, rule := range allowed {
+		if commandMatches(rule, command) {
+			return nil
+		}
+	}
+	return denied
+}
+
+func commandMatches(rule, command string) bool {
+	rule = strings.Join(strings.Fields(rule), " ")
+	return rule != "" && (command == rule || strings.HasPrefix(command, rule+" "))
+}
+
+// CheckAdditionalURLAllowed returns an error if restrictions.allowed_additional_urls
+// is set and doesn't contain the given package index URL.
+func CheckAdditionalURLAllowed(settings *viper.Viper, url string) error {
+	if !settings.IsSet("restrictions.allowed_additional_urls") {
+		return nil
+	}
+	for _, allowed := range settings.GetStringSlice("restrictions.allowed_additional_urls") {
+		if url == allowed {
+			return nil
+		}
+	}
+	return &cmderrors.PermissionDeniedError{Message: tr("The additional URL %s is not allowed by the restrictions in the configuration", url)}
+}
+
+// CheckSettingChangeAllowed returns an error if the setting can't be
+// changed because it is part of the restrictions and they are enabled.
+func CheckSettingChangeAllowed(settings *viper.Viper, key string) error {
+	key = strings.ToLower(key)
+	if !RestrictionsEnabled(settings) || (key != "restrictions" && !strings.HasPrefix(key, "restrictions.")) {
+		return nil
+	}
+	return &cmderrors.PermissionDeniedError{Message: tr("The setting %s can't be changed while the restrictions are enabled", key)}
+}
@@ -0,0 +1,71 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCommandAllowed(t *testing.T) {
+	settings := viper.New()
+	SetDefaults(settings)
+	require.False(t, RestrictionsEnabled(settings))
+	require.NoError(t, CheckCommandAllowed(settings, "core uninstall"))
+
+	settings.Set("restrictions.deny_commands", []string{"core uninstall", "lib"})
+	require.True(t, RestrictionsEnabled(settings))
+	require.EqualError(t, CheckCommandAllowed(settings, " core  uninstall"), "The command core uninstall is not allowed by the restrictions in the configuration")
+	require.Error(t, CheckCommandAllowed(settings, "lib install"))
+	require.NoError(t, CheckCommandAllowed(settings, "core install"))
+	require.NoError(t, CheckCommandAllowed(settings, "library"))
+
+	// The denied commands take precedence over the allowed ones
+	settings.Set("restrictions.allow_commands", []string{"compile", "upload", "core"})
+	require.NoError(t, CheckCommandAllowed(settings, "compile"))
+	require.NoError(t, CheckCommandAllowed(settings, "core list"))
+	require.Error(t, CheckCommandAllowed(settings, "core uninstall"))
+	require.Error(t, CheckCommandAllowed(settings, "board list"))
+	require.NoError(t, CheckCommandAllowed(settings, "version"))
+	require.NoError(t, CheckCommandAllowed(settings, ""))
+}
+
+func TestCheckAdditionalURLAllowed(t *testing.T) {
+	settings := viper.New()
+	SetDefaults(settings)
+	require.NoError(t, CheckAdditionalURLAllowed(settings, "https://example.com/package_index.json"))
+
+	settings.Set("restrictions.allowed_additional_urls", []string{"https://school.example.com/package_index.json"})
+	require.NoError(t, CheckAdditionalURLAllowed(settings, "https://school.example.com/package_index.json"))
+	require.Error(t, CheckAdditionalURLAllowed(settings, "https://example.com/package_index.json"))
+
+	// An empty list forbids all the additional URLs
+	settings.Set("restrictions.allowed_additional_urls", []string{})
+	require.Error(t, CheckAdditionalURLAllowed(settings, "https://school.example.com/package_index.json"))
+}
+
+func TestCheckSettingChangeAllowed(t *testing.T) {
+	settings := viper.New()
+	SetDefaults(settings)
+	require.NoError(t, CheckSettingChangeAllowed(settings, "restrictions.deny_commands"))
+
+	settings.Set("restrictions.deny_commands", []string{"core uninstall"})
+	require.NoError(t, CheckSettingChangeAllowed(settings, "board_manager.additional_urls"))
+	require.Error(t, CheckSettingChangeAllowed(settings, "restrictions.deny_commands"))
+	require.Error(t, CheckSettingChangeAllowed(settings, "Restrictions"))
+}
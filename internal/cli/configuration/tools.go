@@ -0,0 +1,52 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package configuration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ToolsEnvVars returns the environment variables, in the `KEY=value` format,
+// set for all the external tools by the `tools.env.vars` setting.
+func ToolsEnvVars(settings *viper.Viper) ([]string, error) {
+	return parseEnvVars("tools.env.vars", settings.GetStringSlice("tools.env.vars"))
+}
+
+// ToolsEnvOverrides returns the environment variables set for specific tools
+// by the `tools.env.overrides` setting, indexed by the name of the executable.
+func ToolsEnvOverrides(settings *viper.Viper) (map[string][]string, error) {
+	overrides := map[string][]string{}
+	for tool, vars := range settings.GetStringMapStringSlice("tools.env.overrides") {
+		env, err := parseEnvVars("tools.env.overrides."+tool, vars)
+		if err != nil {
+			return nil, err
+		}
+		overrides[tool] = env
+	}
+	return overrides, nil
+}
+
+func parseEnvVars(key string, vars []string) ([]string, error) {
+	for _, v := range vars {
+		if name, _, ok := strings.Cut(v, "="); !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf(tr("Invalid %[1]s '%[2]s': must be in the format KEY=value"), key, v)
+		}
+	}
+	return vars, nil
+}
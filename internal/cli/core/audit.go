@@ -0,0 +1,175 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/core"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initAuditCommand() *cobra.Command {
+	auditCommand := &cobra.Command{
+		Use:   "audit",
+		Short: tr("Audits the installed tools."),
+		Long: tr("Reports the installed tools whose checksum in the package index changed since they have been installed, " +
+			"and the tools downloaded over insecure transports (plain HTTP). Exits with an error if any of them is found."),
+		Example: "  " + os.Args[0] + " core audit",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runAuditCommand()
+		},
+	}
+	return auditCommand
+}
+
+func runAuditCommand() {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli core audit`")
+
+	resp, err := core.PlatformAudit(context.Background(), &rpc.PlatformAuditRequest{Instance: inst})
+	if err != nil {
+		feedback.Fatal(tr("Error auditing the installed tools: %v", err), feedback.ErrGeneric)
+	}
+
+	res := &auditResult{Tools: []*toolAuditResult{}}
+	for _, tool := range resp.GetTools() {
+		issues := []string{}
+		for _, issue := range tool.GetIssues() {
+			issues = append(issues, toolAuditIssueName(issue))
+		}
+		res.Tools = append(res.Tools, &toolAuditResult{
+			Packager:          tool.GetPackager(),
+			Name:              tool.GetName(),
+			Version:           tool.GetVersion(),
+			InstalledURL:      tool.GetInstalledUrl(),
+			InstalledChecksum: tool.GetInstalledChecksum(),
+			IndexURL:          tool.GetIndexUrl(),
+			IndexChecksum:     tool.GetIndexChecksum(),
+			Issues:            issues,
+		})
+	}
+	if res.ErrorString() != "" {
+		feedback.FatalResult(res, feedback.ErrGeneric)
+	}
+	feedback.PrintResult(res)
+}
+
+func toolAuditIssueName(issue rpc.ToolAuditIssue) string {
+	switch issue {
+	case rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_CHECKSUM_CHANGED:
+		return "checksum_changed"
+	case rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_INSECURE_URL:
+		return "insecure_url"
+	case rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_UNKNOWN_ORIGIN:
+		return "unknown_origin"
+	case rpc.ToolAuditIssue_TOOL_AUDIT_ISSUE_NOT_IN_INDEX:
+		return "not_in_index"
+	}
+	return "unknown"
+}
+
+func toolAuditIssueDescription(issue string) string {
+	switch issue {
+	case "checksum_changed":
+		return tr("checksum changed upstream")
+	case "insecure_url":
+		return tr("insecure URL")
+	case "unknown_origin":
+		return tr("origin not recorded")
+	case "not_in_index":
+		return tr("not in the package index")
+	}
+	return issue
+}
+
+type toolAuditResult struct {
+	Packager          string   `json:"packager"`
+	Name              string   `json:"name"`
+	Version           string   `json:"version"`
+	InstalledURL      string   `json:"installed_url,omitempty"`
+	InstalledChecksum string   `json:"installed_checksum,omitempty"`
+	IndexURL          string   `json:"index_url,omitempty"`
+	IndexChecksum     string   `json:"index_checksum,omitempty"`
+	Issues            []string `json:"issues"`
+}
+
+type auditResult struct {
+	Tools []*toolAuditResult `json:"tools"`
+}
+
+func (r *auditResult) Data() interface{} {
+	return r
+}
+
+func (r *auditResult) String() string {
+	if len(r.Tools) == 0 {
+		return tr("No tools installed.")
+	}
+	t := table.New()
+	t.SetHeader(tr("Tool"), tr("Version"), tr("Status"))
+	details := ""
+	for _, tool := range r.Tools {
+		status := []string{}
+		for _, issue := range tool.Issues {
+			status = append(status, toolAuditIssueDescription(issue))
+		}
+		if len(status) == 0 {
+			status = append(status, tr("ok"))
+		}
+		t.AddRow(tool.Packager+":"+tool.Name, tool.Version, strings.Join(status, ", "))
+		for _, issue := range tool.Issues {
+			if issue != "checksum_changed" && issue != "insecure_url" {
+				continue
+			}
+			details += fmt.Sprintln()
+			details += fmt.Sprintln(tool.Packager + ":" + tool.Name + "@" + tool.Version + ":")
+			details += fmt.Sprintln("  " + tr("Installed from:") + " " + tool.InstalledURL + " " + tool.InstalledChecksum)
+			details += fmt.Sprintln("  " + tr("Package index:") + "  " + tool.IndexURL + " " + tool.IndexChecksum)
+			break
+		}
+	}
+	return strings.TrimRight(t.Render()+details, "\n")
+}
+
+// ErrorString returns an error if any tool has a checksum changed upstream or
+// has been downloaded over an insecure transport. The tools without a record
+// of their origin are only reported, since they may have been installed by a
+// previous version of the CLI.
+func (r *auditResult) ErrorString() string {
+	flagged := []string{}
+	for _, tool := range r.Tools {
+		for _, issue := range tool.Issues {
+			if issue == "checksum_changed" || issue == "insecure_url" {
+				flagged = append(flagged, tool.Packager+":"+tool.Name+"@"+tool.Version)
+				break
+			}
+		}
+	}
+	if len(flagged) == 0 {
+		return ""
+	}
+	return tr("Tools that need attention: %s", strings.Join(flagged, ", "))
+}
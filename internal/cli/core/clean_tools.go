@@ -0,0 +1,93 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/core"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initCleanToolsCommand() *cobra.Command {
+	var dryRun bool
+	cleanToolsCommand := &cobra.Command{
+		Use:   "clean-tools",
+		Short: tr("Removes the tools not used by any installed core."),
+		Long: tr("Removes the installed tools that are not required by any installed core. " +
+			"The tools shared by several cores are stored once and removed when no longer used."),
+		Example: "  " + os.Args[0] + " core clean-tools\n" +
+			"  " + os.Args[0] + " core clean-tools --dry-run",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runCleanToolsCommand(dryRun)
+		},
+	}
+	cleanToolsCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Show the tools that would be removed without removing them."))
+	return cleanToolsCommand
+}
+
+func runCleanToolsCommand(dryRun bool) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli core clean-tools`")
+
+	resp, err := core.PlatformCleanTools(context.Background(), &rpc.PlatformCleanToolsRequest{
+		Instance: inst,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error removing unused tools: %v", err), feedback.ErrGeneric)
+	}
+
+	res := &cleanToolsResult{DryRun: dryRun, RemovedTools: []string{}, FreedBytes: resp.GetFreedBytes()}
+	for _, tool := range resp.GetRemovedTools() {
+		res.RemovedTools = append(res.RemovedTools, tool.GetPackager()+":"+tool.GetName()+"@"+tool.GetVersion())
+	}
+	feedback.PrintResult(res)
+}
+
+type cleanToolsResult struct {
+	DryRun       bool     `json:"dry_run"`
+	RemovedTools []string `json:"removed_tools"`
+	FreedBytes   int64    `json:"freed_bytes"`
+}
+
+func (r *cleanToolsResult) Data() interface{} {
+	return r
+}
+
+func (r *cleanToolsResult) String() string {
+	if len(r.RemovedTools) == 0 {
+		return tr("No unused tools found.")
+	}
+	res := ""
+	for _, tool := range r.RemovedTools {
+		res += fmt.Sprintln(tool)
+	}
+	if r.DryRun {
+		res += tr("%s would be freed.", feedback.FormatSize(r.FreedBytes))
+	} else {
+		res += tr("%s freed.", feedback.FormatSize(r.FreedBytes))
+	}
+	return strings.TrimSpace(res)
+}
@@ -40,6 +40,9 @@ func NewCommand() *cobra.Command {
 	coreCommand.AddCommand(initUpgradeCommand())
 	coreCommand.AddCommand(initUninstallCommand())
 	coreCommand.AddCommand(initSearchCommand())
+	coreCommand.AddCommand(initPostInstallCommand())
+	coreCommand.AddCommand(initAuditCommand())
+	coreCommand.AddCommand(initCleanToolsCommand())
 
 	return coreCommand
 }
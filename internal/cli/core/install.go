@@ -31,6 +31,7 @@ import (
 
 func initInstallCommand() *cobra.Command {
 	var noOverwrite bool
+	var keepPrevious bool
 	var scriptFlags arguments.PrePostScriptsFlags
 	installCommand := &cobra.Command{
 		Use:   fmt.Sprintf("install %s:%s[@%s]...", tr("PACKAGER"), tr("ARCH"), tr("VERSION")),
@@ -45,7 +46,7 @@ func initInstallCommand() *cobra.Command {
 			arguments.CheckFlagsConflicts(cmd, "run-post-install", "skip-post-install")
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			runInstallCommand(args, scriptFlags, noOverwrite)
+			runInstallCommand(args, scriptFlags, noOverwrite, keepPrevious)
 		},
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			return arguments.GetInstallableCores(), cobra.ShellCompDirectiveDefault
@@ -53,10 +54,11 @@ func initInstallCommand() *cobra.Command {
 	}
 	scriptFlags.AddToCommand(installCommand)
 	installCommand.Flags().BoolVar(&noOverwrite, "no-overwrite", false, tr("Do not overwrite already installed platforms."))
+	installCommand.Flags().BoolVar(&keepPrevious, "keep-previous", false, tr("Keep the previously installed version of the platforms, instead of removing it."))
 	return installCommand
 }
 
-func runInstallCommand(args []string, scriptFlags arguments.PrePostScriptsFlags, noOverwrite bool) {
+func runInstallCommand(args []string, scriptFlags arguments.PrePostScriptsFlags, noOverwrite bool, keepPrevious bool) {
 	inst := instance.CreateAndInit()
 	logrus.Info("Executing `arduino-cli core install`")
 
@@ -74,6 +76,7 @@ func runInstallCommand(args []string, scriptFlags arguments.PrePostScriptsFlags,
 			SkipPostInstall:  scriptFlags.DetectSkipPostInstallValue(),
 			NoOverwrite:      noOverwrite,
 			SkipPreUninstall: scriptFlags.DetectSkipPreUninstallValue(),
+			KeepPrevious:     keepPrevious,
 		}
 		_, err := core.PlatformInstall(context.Background(), platformInstallRequest, feedback.ProgressBar(), feedback.TaskProgress())
 		if err != nil {
@@ -0,0 +1,197 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/core"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initPostInstallCommand() *cobra.Command {
+	var listOnly bool
+	var assumeYes bool
+	var stepIDs []string
+	postInstallCommand := &cobra.Command{
+		Use:   fmt.Sprintf("post-install %s:%s", tr("PACKAGER"), tr("ARCH")),
+		Short: tr("Runs the post-install setup steps of an installed core."),
+		Long:  tr("Shows and runs, after confirmation, the post-install setup steps (for example drivers or udev rules installation) declared by an installed core."),
+		Example: "  " + os.Args[0] + " core post-install arduino:avr --list\n" +
+			"  " + os.Args[0] + " core post-install arduino:avr\n" +
+			"  " + os.Args[0] + " core post-install arduino:avr --step udev_rules --yes",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runPostInstallCommand(args[0], listOnly, assumeYes, stepIDs)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return arguments.GetUninstallableCores(), cobra.ShellCompDirectiveDefault
+		},
+	}
+	postInstallCommand.Flags().BoolVar(&listOnly, "list", false, tr("List the post-install steps without running them."))
+	postInstallCommand.Flags().BoolVar(&assumeYes, "yes", false, tr("Run the post-install steps without asking for confirmation."))
+	postInstallCommand.Flags().StringSliceVar(&stepIDs, "step", []string{}, tr("Run only the post-install steps with the given IDs. Can be used multiple times."))
+	return postInstallCommand
+}
+
+func runPostInstallCommand(arg string, listOnly, assumeYes bool, stepIDs []string) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli core post-install`")
+
+	platformRef, err := arguments.ParseReference(arg)
+	if err != nil {
+		feedback.Fatal(tr("Invalid argument passed: %v", err), feedback.ErrBadArgument)
+	}
+	if platformRef.Version != "" {
+		feedback.Fatal(tr("Invalid parameter %s: version not allowed", platformRef), feedback.ErrBadArgument)
+	}
+
+	steps, err := core.PlatformPostInstallSteps(context.Background(), &rpc.PlatformPostInstallStepsRequest{
+		Instance:        inst,
+		PlatformPackage: platformRef.PackageName,
+		Architecture:    platformRef.Architecture,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error getting post-install steps: %v", err), feedback.ErrGeneric)
+	}
+
+	res := &postInstallResult{Steps: []*postInstallStepResult{}}
+	for _, step := range steps.GetSteps() {
+		if len(stepIDs) > 0 && !slices.Contains(stepIDs, step.GetId()) {
+			continue
+		}
+		res.Steps = append(res.Steps, &postInstallStepResult{
+			ID:            step.GetId(),
+			Description:   step.GetDescription(),
+			Command:       step.GetCommand(),
+			RequiresAdmin: step.GetRequiresAdmin(),
+		})
+	}
+	for _, stepID := range stepIDs {
+		if !slices.ContainsFunc(steps.GetSteps(), func(step *rpc.PlatformPostInstallStep) bool { return step.GetId() == stepID }) {
+			feedback.Fatal(tr("Post-install step %[1]s not found in platform %[2]s", stepID, platformRef), feedback.ErrBadArgument)
+		}
+	}
+	if listOnly {
+		feedback.PrintResult(res)
+		return
+	}
+
+	for _, step := range res.Steps {
+		if !assumeYes {
+			feedback.Print(step.Description)
+			feedback.Print("  " + step.Command)
+			if step.RequiresAdmin {
+				feedback.Print("  " + tr("This step requires administrative privileges."))
+			}
+			answer, err := feedback.InputUserField(tr("Run this step? [y/N]"), false)
+			if err != nil {
+				feedback.Fatal(tr("Cannot ask for confirmation: %v. Use --yes to run the steps without confirmation.", err), feedback.ErrBadArgument)
+			}
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				step.Status = "skipped"
+				continue
+			}
+		}
+
+		stepRes, err := core.PlatformRunPostInstallStep(context.Background(), &rpc.PlatformRunPostInstallStepRequest{
+			Instance:        inst,
+			PlatformPackage: platformRef.PackageName,
+			Architecture:    platformRef.Architecture,
+			StepId:          step.ID,
+			Confirmed:       true,
+		})
+		step.Output = string(stepRes.GetOutStream()) + string(stepRes.GetErrStream())
+		if err != nil {
+			step.Status = "failed"
+			step.Error = err.Error()
+		} else {
+			step.Status = "done"
+		}
+	}
+	if res.ErrorString() != "" {
+		feedback.FatalResult(res, feedback.ErrGeneric)
+	}
+	feedback.PrintResult(res)
+}
+
+type postInstallStepResult struct {
+	ID            string `json:"id"`
+	Description   string `json:"description"`
+	Command       string `json:"command"`
+	RequiresAdmin bool   `json:"requires_admin,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Output        string `json:"output,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type postInstallResult struct {
+	Steps []*postInstallStepResult `json:"steps"`
+}
+
+func (r *postInstallResult) Data() interface{} {
+	return r
+}
+
+func (r *postInstallResult) String() string {
+	if len(r.Steps) == 0 {
+		return tr("No post-install steps declared by the platform.")
+	}
+	t := table.New()
+	t.SetHeader(tr("ID"), tr("Description"), tr("Admin"), tr("Status"))
+	for _, step := range r.Steps {
+		admin := ""
+		if step.RequiresAdmin {
+			admin = tr("yes")
+		}
+		t.AddRow(step.ID, step.Description, admin, step.Status)
+	}
+	res := t.Render()
+	for _, step := range r.Steps {
+		if step.Output != "" || step.Error != "" {
+			res += fmt.Sprintln()
+			res += fmt.Sprintln(step.ID + ":")
+			res += fmt.Sprint(step.Output)
+			if step.Error != "" {
+				res += fmt.Sprintln(step.Error)
+			}
+		}
+	}
+	return strings.TrimRight(res, "\n")
+}
+
+func (r *postInstallResult) ErrorString() string {
+	failed := []string{}
+	for _, step := range r.Steps {
+		if step.Status == "failed" {
+			failed = append(failed, step.ID)
+		}
+	}
+	if len(failed) == 0 {
+		return ""
+	}
+	return tr("Post-install steps failed: %s", strings.Join(failed, ", "))
+}
@@ -33,6 +33,8 @@ import (
 
 func initUpgradeCommand() *cobra.Command {
 	var postInstallFlags arguments.PrePostScriptsFlags
+	var keepPrevious bool
+	var dryRun bool
 	upgradeCommand := &cobra.Command{
 		Use:   fmt.Sprintf("upgrade [%s:%s] ...", tr("PACKAGER"), tr("ARCH")),
 		Short: tr("Upgrades one or all installed platforms to the latest version."),
@@ -41,23 +43,32 @@ func initUpgradeCommand() *cobra.Command {
 			"  # " + tr("upgrade everything to the latest version") + "\n" +
 			"  " + os.Args[0] + " core upgrade\n\n" +
 			"  # " + tr("upgrade arduino:samd to the latest version") + "\n" +
-			"  " + os.Args[0] + " core upgrade arduino:samd",
+			"  " + os.Args[0] + " core upgrade arduino:samd\n\n" +
+			"  # " + tr("show what would be upgraded, without changing anything") + "\n" +
+			"  " + os.Args[0] + " core upgrade --dry-run",
 		Run: func(cmd *cobra.Command, args []string) {
-			runUpgradeCommand(args, postInstallFlags.DetectSkipPostInstallValue(), postInstallFlags.DetectSkipPreUninstallValue())
+			if dryRun {
+				runUpgradePreviewCommand(args, keepPrevious)
+				return
+			}
+			runUpgradeCommand(args, postInstallFlags.DetectSkipPostInstallValue(), postInstallFlags.DetectSkipPreUninstallValue(), keepPrevious)
 		},
 	}
 	postInstallFlags.AddToCommand(upgradeCommand)
+	upgradeCommand.Flags().BoolVar(&keepPrevious, "keep-previous", false, tr("Keep the previously installed version of the platforms, instead of removing it."))
+	upgradeCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Show the versions that would be installed, the tools that would be downloaded and removed, without changing anything."))
 	return upgradeCommand
 }
 
-func runUpgradeCommand(args []string, skipPostInstall bool, skipPreUninstall bool) {
+func runUpgradeCommand(args []string, skipPostInstall bool, skipPreUninstall bool, keepPrevious bool) {
 	inst := instance.CreateAndInit()
 	logrus.Info("Executing `arduino-cli core upgrade`")
-	Upgrade(inst, args, skipPostInstall, skipPreUninstall)
+	Upgrade(inst, args, skipPostInstall, skipPreUninstall, keepPrevious)
 }
 
 // Upgrade upgrades one or all installed platforms to the latest version.
-func Upgrade(inst *rpc.Instance, args []string, skipPostInstall bool, skipPreUninstall bool) {
+// If keepPrevious is true the previously installed versions are not removed.
+func Upgrade(inst *rpc.Instance, args []string, skipPostInstall bool, skipPreUninstall bool, keepPrevious bool) {
 	// if no platform was passed, upgrade allthethings
 	if len(args) == 0 {
 		platforms, err := core.PlatformSearch(&rpc.PlatformSearchRequest{
@@ -121,6 +132,7 @@ func Upgrade(inst *rpc.Instance, args []string, skipPostInstall bool, skipPreUni
 			Architecture:     platformRef.Architecture,
 			SkipPostInstall:  skipPostInstall,
 			SkipPreUninstall: skipPreUninstall,
+			KeepPrevious:     keepPrevious,
 		}
 		response, err := core.PlatformUpgrade(context.Background(), r, feedback.ProgressBar(), feedback.TaskProgress())
 		warningMissingIndex(response)
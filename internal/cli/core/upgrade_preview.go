@@ -0,0 +1,149 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/arduino/arduino-cli/commands/core"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+)
+
+func runUpgradePreviewCommand(args []string, keepPrevious bool) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli core upgrade --dry-run`")
+
+	platformsRefs, err := arguments.ParseReferences(args)
+	if err != nil {
+		feedback.Fatal(tr("Invalid argument passed: %v", err), feedback.ErrBadArgument)
+	}
+	requests := []*rpc.PlatformUpgradePreviewRequest{}
+	for i, platformRef := range platformsRefs {
+		if platformRef.Version != "" {
+			feedback.Fatal(tr("Invalid item %s", args[i]), feedback.ErrBadArgument)
+		}
+		requests = append(requests, &rpc.PlatformUpgradePreviewRequest{
+			Instance:        inst,
+			PlatformPackage: platformRef.PackageName,
+			Architecture:    platformRef.Architecture,
+			KeepPrevious:    keepPrevious,
+		})
+	}
+	if len(requests) == 0 {
+		// Preview the upgrade of all the installed platforms
+		requests = append(requests, &rpc.PlatformUpgradePreviewRequest{Instance: inst, KeepPrevious: keepPrevious})
+	}
+
+	res := &platformUpgradePreviewResult{Platforms: []*platformUpgradePreview{}}
+	for _, req := range requests {
+		resp, err := core.PlatformUpgradePreview(context.Background(), req)
+		if err != nil {
+			feedback.Fatal(tr("Error computing the upgrade preview: %v", err), feedback.ErrGeneric)
+		}
+		for _, p := range resp.GetPlatforms() {
+			preview := &platformUpgradePreview{
+				ID:               p.GetId(),
+				InstalledVersion: p.GetInstalledVersion(),
+				NewVersion:       p.GetNewVersion(),
+				DownloadSize:     p.GetDownloadSize(),
+				Cached:           p.GetCached(),
+				NewTools:         []*toolInstallPreview{},
+				RemovedTools:     p.GetRemovedTools(),
+			}
+			for _, tool := range p.GetNewTools() {
+				preview.NewTools = append(preview.NewTools, &toolInstallPreview{
+					ID:           tool.GetId(),
+					DownloadSize: tool.GetDownloadSize(),
+					Cached:       tool.GetCached(),
+				})
+			}
+			res.Platforms = append(res.Platforms, preview)
+		}
+		res.DownloadSize += resp.GetDownloadSize()
+	}
+	sort.SliceStable(res.Platforms, func(i, j int) bool {
+		return res.Platforms[i].ID < res.Platforms[j].ID
+	})
+	feedback.PrintResult(res)
+}
+
+type toolInstallPreview struct {
+	ID           string `json:"id"`
+	DownloadSize int64  `json:"download_size"`
+	Cached       bool   `json:"cached,omitempty"`
+}
+
+type platformUpgradePreview struct {
+	ID               string                `json:"id"`
+	InstalledVersion string                `json:"installed_version"`
+	NewVersion       string                `json:"new_version"`
+	DownloadSize     int64                 `json:"download_size"`
+	Cached           bool                  `json:"cached,omitempty"`
+	NewTools         []*toolInstallPreview `json:"new_tools"`
+	RemovedTools     []string              `json:"removed_tools"`
+}
+
+type platformUpgradePreviewResult struct {
+	Platforms    []*platformUpgradePreview `json:"platforms"`
+	DownloadSize int64                     `json:"download_size"`
+}
+
+// Data implements feedback.Result.
+func (r *platformUpgradePreviewResult) Data() interface{} {
+	return r
+}
+
+// String implements feedback.Result.
+func (r *platformUpgradePreviewResult) String() string {
+	if len(r.Platforms) == 0 {
+		return tr("All the cores are already at the latest version")
+	}
+	downloadSize := func(size int64, cached bool) string {
+		if cached {
+			return tr("cached")
+		}
+		return feedback.FormatSize(size)
+	}
+
+	t := table.New()
+	t.SetHeader(tr("ID"), tr("Installed"), tr("New version"), tr("Download"))
+	tools := table.New()
+	tools.SetHeader(tr("Tool"), tr("Platform"), tr("Change"), tr("Download"))
+	hasTools := false
+	for _, p := range r.Platforms {
+		t.AddRow(p.ID, p.InstalledVersion, p.NewVersion, downloadSize(p.DownloadSize, p.Cached))
+		for _, tool := range p.NewTools {
+			tools.AddRow(tool.ID, p.ID, tr("install"), downloadSize(tool.DownloadSize, tool.Cached))
+			hasTools = true
+		}
+		for _, tool := range p.RemovedTools {
+			tools.AddRow(tool, p.ID, tr("remove"), "")
+			hasTools = true
+		}
+	}
+	res := t.Render()
+	if hasTools {
+		res += "\n" + tools.Render()
+	}
+	res += "\n" + feedback.GetTheme().Title.Sprint(tr("Total download size:")) + " " + feedback.FormatSize(r.DownloadSize)
+	return res
+}
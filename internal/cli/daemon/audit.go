@@ -0,0 +1,227 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// auditSyslog is the value of daemon.audit_log sending the audit log to
+// the system logger instead of a file.
+const auditSyslog = "syslog"
+
+// auditMaxValueLength is the maximum length of the values of the
+// parameters reported in the audit log.
+const auditMaxValueLength = 100
+
+// auditEntry is a line of the audit log, describing a completed RPC.
+type auditEntry struct {
+	Time     string            `json:"time"`
+	Method   string            `json:"method"`
+	Peer     string            `json:"peer,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
+	Duration float64           `json:"duration_ms"`
+	Code     string            `json:"code"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// auditLogger writes an entry for each RPC served by the daemon.
+type auditLogger struct {
+	mux sync.Mutex
+	out io.Writer
+}
+
+// openAuditLog opens the audit log, that is the system logger if target is
+// "syslog" or a file otherwise. The returned closer must be called when the
+// daemon exits.
+func openAuditLog(target string) (*auditLogger, io.Closer, error) {
+	if target == auditSyslog {
+		w, err := openSyslog()
+		if err != nil {
+			return nil, nil, err
+		}
+		return &auditLogger{out: w}, w, nil
+	}
+	f, err := paths.New(target).Append()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &auditLogger{out: f}, f, nil
+}
+
+func (a *auditLogger) log(ctx context.Context, method string, req interface{}, start time.Time, err error) {
+	entry := &auditEntry{
+		Time:     start.UTC().Format(time.RFC3339Nano),
+		Method:   method,
+		Params:   summarizeParams(req),
+		Duration: float64(time.Since(start).Microseconds()) / 1000,
+		Code:     status.Code(err).String(),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		entry.Peer = p.Addr.String()
+	}
+	if err != nil {
+		entry.Error = status.Convert(err).Message()
+	}
+	line, _ := json.Marshal(entry)
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	fmt.Fprintln(a.out, string(line))
+}
+
+func (a *auditLogger) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	a.log(ctx, info.FullMethod, req, start, err)
+	return resp, err
+}
+
+func (a *auditLogger) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	auditStream := &auditServerStream{ServerStream: stream}
+	err := handler(srv, auditStream)
+	a.log(stream.Context(), info.FullMethod, auditStream.firstReq, start, err)
+	return err
+}
+
+// auditServerStream keeps the first message received on a stream, that is
+// the request of a server streaming call or the opening message of a
+// bidirectional one.
+type auditServerStream struct {
+	grpc.ServerStream
+	firstReq interface{}
+}
+
+func (s *auditServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.firstReq == nil {
+		s.firstReq = m
+	}
+	return err
+}
+
+// summarizeParams returns the fields set in the request, with the nested
+// messages in compact JSON and the values shortened to keep the log lines
+// readable. The content of the byte fields is omitted, and the values that
+// may contain credentials are redacted (see isSensitiveField).
+func summarizeParams(req interface{}) map[string]string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	return summarizeMessage(msg.ProtoReflect())
+}
+
+func summarizeMessage(msg protoreflect.Message) map[string]string {
+	res := map[string]string{}
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if isSensitiveField(field) {
+			res[string(field.Name())] = auditRedacted
+		} else {
+			res[string(field.Name())] = summarizeValue(field, value)
+		}
+		return true
+	})
+	return res
+}
+
+// auditRedacted replaces the values that may contain credentials.
+const auditRedacted = "<redacted>"
+
+// isSensitiveField returns true if the field may contain credentials: the
+// values of the settings (like the proxy credentials and the authorization
+// headers of network.extra_headers) and the fields named after passwords,
+// tokens and secrets. Only the key of the settings is logged.
+func isSensitiveField(field protoreflect.FieldDescriptor) bool {
+	if field.Name() == "json_data" {
+		return true
+	}
+	name := strings.ToLower(string(field.Name()))
+	for _, sensitive := range []string{"password", "token", "secret", "credential"} {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	var res string
+	switch {
+	case field.IsList():
+		items := []string{}
+		for i := 0; i < value.List().Len(); i++ {
+			items = append(items, summarizeScalar(field, value.List().Get(i)))
+		}
+		res = "[" + strings.Join(items, ", ") + "]"
+	case field.IsMap():
+		res = fmt.Sprintf("<%d entries>", value.Map().Len())
+	default:
+		res = summarizeScalar(field, value)
+	}
+	if runes := []rune(res); len(runes) > auditMaxValueLength {
+		res = string(runes[:auditMaxValueLength-1]) + "…"
+	}
+	return res
+}
+
+func summarizeScalar(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// The nested messages are summarized too, to redact their fields
+		j, _ := json.Marshal(summarizeMessage(value.Message()))
+		return string(j)
+	case protoreflect.StringKind:
+		return redactURLCredentials(value.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("<%d bytes>", len(value.Bytes()))
+	case protoreflect.EnumKind:
+		if v := field.Enum().Values().ByNumber(value.Enum()); v != nil {
+			return string(v.Name())
+		}
+		return fmt.Sprint(value.Enum())
+	default:
+		return value.String()
+	}
+}
+
+// redactURLCredentials redacts the user information (like the credentials
+// of a git repository) of the value, if it's an URL.
+func redactURLCredentials(value string) string {
+	if !strings.Contains(value, "@") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil || u.Host == "" {
+		return value
+	}
+	u.User = url.User("redacted")
+	return u.String()
+}
@@ -16,6 +16,7 @@
 package daemon
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/arduino/arduino-cli/commands"
 	"github.com/arduino/arduino-cli/commands/daemon"
 	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
@@ -34,6 +36,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	grpc_reflection "google.golang.org/grpc/reflection"
 )
 
 var (
@@ -42,6 +45,7 @@ var (
 	debug        bool
 	debugFile    string
 	debugFilters []string
+	reflection   bool
 )
 
 // NewCommand created a new `daemon` command
@@ -56,10 +60,14 @@ func NewCommand() *cobra.Command {
 	}
 	daemonCommand.PersistentFlags().String("port", "", tr("The TCP port the daemon will listen to"))
 	configuration.Settings.BindPFlag("daemon.port", daemonCommand.PersistentFlags().Lookup("port"))
+	daemonCommand.Flags().String("audit-log", "", tr("Log every gRPC call to the specified file, or to the system logger if set to \"syslog\""))
+	configuration.Settings.BindPFlag("daemon.audit_log", daemonCommand.Flags().Lookup("audit-log"))
 	daemonCommand.Flags().BoolVar(&daemonize, "daemonize", false, tr("Do not terminate daemon process if the parent process dies"))
 	daemonCommand.Flags().BoolVar(&debug, "debug", false, tr("Enable debug logging of gRPC calls"))
 	daemonCommand.Flags().StringVar(&debugFile, "debug-file", "", tr("Append debug logging to the specified file"))
 	daemonCommand.Flags().StringSliceVar(&debugFilters, "debug-filter", []string{}, tr("Display only the provided gRPC calls"))
+	daemonCommand.Flags().BoolVar(&reflection, "enable-reflection", false, tr("Enable the gRPC server reflection, to let the clients discover the API"))
+	daemonCommand.AddCommand(initDescribeAPICommand())
 	return daemonCommand
 }
 
@@ -93,10 +101,26 @@ func runDaemonCommand(cmd *cobra.Command, args []string) {
 			}
 		}
 		gRPCOptions = append(gRPCOptions,
-			grpc.UnaryInterceptor(unaryLoggerInterceptor),
-			grpc.StreamInterceptor(streamLoggerInterceptor),
+			grpc.ChainUnaryInterceptor(unaryLoggerInterceptor),
+			grpc.ChainStreamInterceptor(streamLoggerInterceptor),
 		)
 	}
+	if auditLog := configuration.Settings.GetString("daemon.audit_log"); auditLog != "" {
+		audit, closer, err := openAuditLog(auditLog)
+		if err != nil {
+			feedback.Fatal(tr("Error opening audit log: %s", err), feedback.ErrGeneric)
+		}
+		defer closer.Close()
+		gRPCOptions = append(gRPCOptions,
+			grpc.ChainUnaryInterceptor(audit.unaryInterceptor),
+			grpc.ChainStreamInterceptor(audit.streamInterceptor),
+		)
+	}
+	// The restrictions are checked after the audit, to log the denied calls too
+	gRPCOptions = append(gRPCOptions,
+		grpc.ChainUnaryInterceptor(unaryRestrictionsInterceptor),
+		grpc.ChainStreamInterceptor(streamRestrictionsInterceptor),
+	)
 	s := grpc.NewServer(gRPCOptions...)
 	// Set specific user-agent for the daemon
 	configuration.Settings.Set("network.user_agent_ext", "daemon")
@@ -105,6 +129,17 @@ func runDaemonCommand(cmd *cobra.Command, args []string) {
 	srv_commands.RegisterArduinoCoreServiceServer(s, &daemon.ArduinoCoreServerImpl{
 		VersionString: version.VersionInfo.VersionString,
 	})
+	if reflection {
+		grpc_reflection.Register(s)
+	}
+
+	if configuration.Settings.GetBool("daemon.watch_directories") {
+		go func() {
+			if err := commands.WatchDirectories(context.Background()); err != nil {
+				logrus.WithError(err).Error("Watching sketchbook and libraries directories")
+			}
+		}()
+	}
 
 	if !daemonize {
 		// When parent process ends terminate also the daemon
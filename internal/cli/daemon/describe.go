@@ -0,0 +1,290 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	srv_commands "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func initDescribeAPICommand() *cobra.Command {
+	describeAPICommand := &cobra.Command{
+		Use:   fmt.Sprintf("describe-api [%s]...", tr("method")),
+		Short: tr("Describe the gRPC API of the daemon."),
+		Long: tr("Describe the services and the methods of the gRPC API of the daemon, with the schemas of their requests and responses. " +
+			"Use the --json flag to get the catalog in a format suitable for the generation of clients."),
+		Example: "" +
+			"  " + os.Args[0] + " daemon describe-api\n" +
+			"  " + os.Args[0] + " daemon describe-api Compile --json",
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runDescribeAPICommand(args)
+		},
+	}
+	return describeAPICommand
+}
+
+func runDescribeAPICommand(methods []string) {
+	services := srv_commands.File_cc_arduino_cli_commands_v1_commands_proto.Services()
+	res, err := describeAPI(services, methods, configuration.Settings.GetString("daemon.port"))
+	if err != nil {
+		feedback.Fatal(err.Error(), feedback.ErrBadArgument)
+	}
+	feedback.PrintResult(res)
+}
+
+// describeAPI returns the description of the given services, restricted to
+// the given methods if any.
+func describeAPI(services protoreflect.ServiceDescriptors, methods []string, port string) (*apiDescription, error) {
+	selected := map[string]bool{}
+	for _, method := range methods {
+		selected[strings.ToLower(method)] = false
+	}
+
+	res := &apiDescription{
+		Services: []*serviceDescription{},
+		Messages: map[string]*messageSchema{},
+		Enums:    map[string]*enumSchema{},
+		detailed: len(methods) > 0,
+	}
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		serviceRes := &serviceDescription{Name: string(service.FullName())}
+		for j := 0; j < service.Methods().Len(); j++ {
+			method := service.Methods().Get(j)
+			if len(selected) > 0 {
+				if _, ok := selected[strings.ToLower(string(method.Name()))]; !ok {
+					continue
+				}
+				selected[strings.ToLower(string(method.Name()))] = true
+			}
+			serviceRes.Methods = append(serviceRes.Methods, &methodDescription{
+				Name:            string(method.Name()),
+				FullName:        fmt.Sprintf("%s/%s", service.FullName(), method.Name()),
+				Request:         string(method.Input().FullName()),
+				Response:        string(method.Output().FullName()),
+				ClientStreaming: method.IsStreamingClient(),
+				ServerStreaming: method.IsStreamingServer(),
+				Example:         exampleCall(method, port),
+			})
+			res.addMessage(method.Input())
+			res.addMessage(method.Output())
+		}
+		if len(serviceRes.Methods) > 0 {
+			res.Services = append(res.Services, serviceRes)
+		}
+	}
+	for _, method := range methods {
+		if !selected[strings.ToLower(method)] {
+			return nil, fmt.Errorf(tr("Unknown gRPC method: %s", method))
+		}
+	}
+	return res, nil
+}
+
+// addMessage adds the schema of the message and of all the messages and
+// enums used by its fields.
+func (res *apiDescription) addMessage(msg protoreflect.MessageDescriptor) {
+	name := string(msg.FullName())
+	if _, ok := res.Messages[name]; ok {
+		return
+	}
+	schema := &messageSchema{Fields: []*fieldSchema{}}
+	res.Messages[name] = schema
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldRes := &fieldSchema{
+			Name:     string(field.Name()),
+			JSONName: field.JSONName(),
+			Number:   int(field.Number()),
+			Type:     res.fieldType(field),
+			Repeated: field.IsList(),
+		}
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			fieldRes.Oneof = string(oneof.Name())
+		}
+		schema.Fields = append(schema.Fields, fieldRes)
+	}
+}
+
+// fieldType returns the type of the field and adds the schema of the message
+// or enum it refers to.
+func (res *apiDescription) fieldType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", res.fieldType(field.MapKey()), res.fieldType(field.MapValue()))
+	}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		res.addMessage(field.Message())
+		return string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		res.addEnum(field.Enum())
+		return string(field.Enum().FullName())
+	default:
+		return field.Kind().String()
+	}
+}
+
+func (res *apiDescription) addEnum(enum protoreflect.EnumDescriptor) {
+	name := string(enum.FullName())
+	if _, ok := res.Enums[name]; ok {
+		return
+	}
+	schema := &enumSchema{Values: []string{}}
+	for i := 0; i < enum.Values().Len(); i++ {
+		schema.Values = append(schema.Values, string(enum.Values().Get(i).Name()))
+	}
+	res.Enums[name] = schema
+}
+
+// exampleCall returns a grpcurl command line calling the method, with a
+// skeleton of the request. The daemon must be started with --enable-reflection.
+func exampleCall(method protoreflect.MethodDescriptor, port string) string {
+	fields := []string{}
+	for i := 0; i < method.Input().Fields().Len(); i++ {
+		field := method.Input().Fields().Get(i)
+		fields = append(fields, fmt.Sprintf("%q: %s", field.JSONName(), exampleValue(field)))
+	}
+	return fmt.Sprintf("grpcurl -plaintext -d '{%s}' 127.0.0.1:%s %s/%s",
+		strings.Join(fields, ", "), port, method.Parent().FullName(), method.Name())
+}
+
+// exampleValue returns the zero value of the field in JSON format.
+func exampleValue(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return "{}"
+	case field.IsList():
+		return "[]"
+	}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "{}"
+	case protoreflect.EnumKind:
+		return fmt.Sprintf("%q", field.Enum().Values().Get(0).Name())
+	case protoreflect.BoolKind:
+		return "false"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return `""`
+	case protoreflect.Int64Kind, protoreflect.Uint64Kind, protoreflect.Sint64Kind,
+		protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+		// 64 bit integers are represented as strings in JSON
+		return `"0"`
+	default:
+		return "0"
+	}
+}
+
+type apiDescription struct {
+	Services []*serviceDescription     `json:"services"`
+	Messages map[string]*messageSchema `json:"messages"`
+	Enums    map[string]*enumSchema    `json:"enums"`
+	detailed bool
+}
+
+type serviceDescription struct {
+	Name    string               `json:"name"`
+	Methods []*methodDescription `json:"methods"`
+}
+
+type methodDescription struct {
+	Name            string `json:"name"`
+	FullName        string `json:"full_name"`
+	Request         string `json:"request"`
+	Response        string `json:"response"`
+	ClientStreaming bool   `json:"client_streaming"`
+	ServerStreaming bool   `json:"server_streaming"`
+	Example         string `json:"example"`
+}
+
+type messageSchema struct {
+	Fields []*fieldSchema `json:"fields"`
+}
+
+type fieldSchema struct {
+	Name     string `json:"name"`
+	JSONName string `json:"json_name"`
+	Number   int    `json:"number"`
+	Type     string `json:"type"`
+	Repeated bool   `json:"repeated,omitempty"`
+	Oneof    string `json:"oneof,omitempty"`
+}
+
+type enumSchema struct {
+	Values []string `json:"values"`
+}
+
+func (r *apiDescription) Data() interface{} {
+	return r
+}
+
+func (r *apiDescription) String() string {
+	res := ""
+	for _, service := range r.Services {
+		res += fmt.Sprintf("service %s\n", service.Name)
+		for _, method := range service.Methods {
+			request, response := shortName(method.Request), shortName(method.Response)
+			if method.ClientStreaming {
+				request = "stream " + request
+			}
+			if method.ServerStreaming {
+				response = "stream " + response
+			}
+			res += fmt.Sprintf("  rpc %s(%s) returns (%s)\n", method.Name, request, response)
+		}
+	}
+
+	// The schemas are shown only when the methods are selected
+	if !r.detailed {
+		return res
+	}
+	names := []string{}
+	for name := range r.Messages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		res += fmt.Sprintf("\nmessage %s\n", shortName(name))
+		for _, field := range r.Messages[name].Fields {
+			fieldType := shortName(field.Type)
+			if field.Repeated {
+				fieldType = "repeated " + fieldType
+			}
+			res += fmt.Sprintf("  %s %s = %d\n", fieldType, field.Name, field.Number)
+		}
+	}
+	for _, service := range r.Services {
+		for _, method := range service.Methods {
+			res += "\n" + tr("Example call of %s:", method.Name) + "\n  " + method.Example + "\n"
+		}
+	}
+	return res
+}
+
+// shortName removes the package of the commands service from the name of a
+// message or enum.
+func shortName(name string) string {
+	return strings.ReplaceAll(name, "cc.arduino.cli.commands.v1.", "")
+}
@@ -0,0 +1,56 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package daemon
+
+import (
+	"testing"
+
+	srv_commands "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeAPI(t *testing.T) {
+	services := srv_commands.File_cc_arduino_cli_commands_v1_commands_proto.Services()
+
+	res, err := describeAPI(services, nil, "50051")
+	require.NoError(t, err)
+	require.Len(t, res.Services, 1)
+	require.Greater(t, len(res.Services[0].Methods), 50)
+
+	res, err = describeAPI(services, []string{"boardlistall"}, "50051")
+	require.NoError(t, err)
+	require.Len(t, res.Services[0].Methods, 1)
+	method := res.Services[0].Methods[0]
+	require.Equal(t, "cc.arduino.cli.commands.v1.ArduinoCoreService/BoardListAll", method.FullName)
+	require.Equal(t, "cc.arduino.cli.commands.v1.BoardListAllRequest", method.Request)
+	require.False(t, method.ServerStreaming)
+	require.Equal(t, `grpcurl -plaintext -d '{"instance": {}, "searchArgs": [], "includeHiddenBoards": false, "filter": {}}' `+
+		`127.0.0.1:50051 cc.arduino.cli.commands.v1.ArduinoCoreService/BoardListAll`, method.Example)
+
+	// The schemas of the messages used by the method are included
+	request := res.Messages["cc.arduino.cli.commands.v1.BoardListAllRequest"]
+	require.NotNil(t, request)
+	require.Equal(t, &fieldSchema{Name: "search_args", JSONName: "searchArgs", Number: 2, Type: "string", Repeated: true}, request.Fields[1])
+	require.Contains(t, res.Messages, "cc.arduino.cli.commands.v1.BoardFilter")
+	require.Contains(t, res.Messages, "cc.arduino.cli.commands.v1.PlatformRelease")
+
+	res, err = describeAPI(services, []string{"BoardList"}, "50051")
+	require.NoError(t, err)
+	require.Contains(t, res.Messages["cc.arduino.cli.commands.v1.Port"].Fields, &fieldSchema{Name: "properties", JSONName: "properties", Number: 5, Type: "map<string, string>"})
+
+	_, err = describeAPI(services, []string{"BoardListAll", "Unknown"}, "50051")
+	require.EqualError(t, err, "Unknown gRPC method: Unknown")
+}
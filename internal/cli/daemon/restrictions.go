@@ -0,0 +1,130 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package daemon
+
+import (
+	"context"
+	"path"
+
+	"github.com/arduino/arduino-cli/commands/cmderrors"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"google.golang.org/grpc"
+)
+
+// restrictedMethods maps the gRPC methods to the equivalent CLI commands,
+// so that the restrictions on the commands apply to the daemon too. The
+// methods not listed here, like Create or Version, are always allowed.
+var restrictedMethods = map[string]string{
+	"UpdateIndex":                       "core update-index",
+	"UpdateLibrariesIndex":              "lib update-index",
+	"NewSketch":                         "sketch new",
+	"ArchiveSketch":                     "sketch archive",
+	"FlattenSketch":                     "sketch flatten",
+	"SetSketchDefaults":                 "board attach",
+	"SketchbookList":                    "sketchbook list",
+	"BoardDetails":                      "board details",
+	"BoardList":                         "board list",
+	"BoardListWatch":                    "board list",
+	"BoardListAll":                      "board listall",
+	"BoardSearch":                       "board search",
+	"BoardSetupPermissions":             "board setup-permissions",
+	"ReadMemory":                        "board read-mem",
+	"WriteMemory":                       "board write-mem",
+	"EraseChip":                         "board erase",
+	"FlashProtection":                   "board protection",
+	"BoardPower":                        "board power",
+	"BoardRecover":                      "board recover",
+	"BoardReset":                        "board reset",
+	"BoardCertificates":                 "board certificates",
+	"BoardProvision":                    "board provision",
+	"Compile":                           "compile",
+	"CompileWarmUp":                     "compile",
+	"CompileDropWarmState":              "compile",
+	"Symbolize":                         "symbolize",
+	"PlatformInstall":                   "core install",
+	"PlatformDownload":                  "core download",
+	"PlatformUninstall":                 "core uninstall",
+	"PlatformUpgrade":                   "core upgrade",
+	"PlatformUpgradePreview":            "core upgrade",
+	"PlatformPostInstallSteps":          "core post-install",
+	"PlatformRunPostInstallStep":        "core post-install",
+	"PlatformAudit":                     "core audit",
+	"PlatformCleanTools":                "core clean-tools",
+	"PlatformSearch":                    "core search",
+	"Upload":                            "upload",
+	"UploadUsingProgrammer":             "upload",
+	"SupportedUserFields":               "upload",
+	"ListProgrammersAvailableForUpload": "upload",
+	"ProgrammerDetails":                 "programmer details",
+	"BurnBootloader":                    "burn-bootloader",
+	"DeploymentsList":                   "deployments list",
+	"DeploymentsShow":                   "deployments show",
+	"LibraryDownload":                   "lib download",
+	"LibraryDiff":                       "lib diff",
+	"LibraryNew":                        "lib new",
+	"LibraryInstall":                    "lib install",
+	"ZipLibraryInstall":                 "lib install",
+	"GitLibraryInstall":                 "lib install",
+	"LibraryUpgrade":                    "lib upgrade",
+	"LibraryUpgradeAll":                 "lib upgrade",
+	"LibraryUpgradePreview":             "lib upgrade",
+	"LibraryUninstall":                  "lib uninstall",
+	"LibraryResolveDependencies":        "lib deps",
+	"LibrarySearch":                     "lib search",
+	"LibraryList":                       "lib list",
+	"Monitor":                           "monitor",
+	"EnumerateMonitorPortSettings":      "monitor",
+	"Debug":                             "debug",
+	"IsDebugSupported":                  "debug",
+	"GetDebugConfig":                    "debug",
+	"CleanDownloadCacheDirectory":       "cache clean",
+	"Cleanup":                           "cleanup",
+	"DiskUsage":                         "du",
+	"SettingsGetAll":                    "config dump",
+	"SettingsGetValue":                  "config get",
+	"SettingsSetValue":                  "config set",
+	"SettingsMerge":                     "config set",
+	"SettingsWrite":                     "config init",
+	"SettingsDelete":                    "config delete",
+}
+
+// checkMethodAllowed returns an error if the gRPC method is restricted by
+// the configuration.
+func checkMethodAllowed(fullMethod string) error {
+	command, ok := restrictedMethods[path.Base(fullMethod)]
+	if !ok {
+		return nil
+	}
+	err := configuration.CheckCommandAllowed(configuration.Settings, command)
+	if cmdErr, ok := err.(cmderrors.CommandError); ok {
+		return cmdErr.ToRPCStatus().Err()
+	}
+	return err
+}
+
+func unaryRestrictionsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := checkMethodAllowed(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamRestrictionsInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := checkMethodAllowed(info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
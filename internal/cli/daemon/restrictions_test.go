@@ -0,0 +1,49 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package daemon
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	srv_commands "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestCheckMethodAllowed(t *testing.T) {
+	settings := configuration.Settings
+	defer func() { configuration.Settings = settings }()
+	configuration.Settings = viper.New()
+	configuration.SetDefaults(configuration.Settings)
+	configuration.Settings.Set("restrictions.deny_commands", []string{"core uninstall", "lib"})
+
+	err := checkMethodAllowed("/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformUninstall")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = checkMethodAllowed("/cc.arduino.cli.commands.v1.ArduinoCoreService/GitLibraryInstall")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, checkMethodAllowed("/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformInstall"))
+	require.NoError(t, checkMethodAllowed("/cc.arduino.cli.commands.v1.ArduinoCoreService/Create"))
+
+	// All the mapped methods must exist
+	service := srv_commands.File_cc_arduino_cli_commands_v1_commands_proto.Services().Get(0)
+	for method := range restrictedMethods {
+		require.NotNil(t, service.Methods().ByName(protoreflect.Name(method)), method)
+	}
+}
@@ -0,0 +1,177 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package datalog
+
+import (
+	"bufio"
+	"context"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/monitor"
+	"github.com/arduino/arduino-cli/internal/arduino/datalog"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.bug.st/cleanup"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `log` command
+func NewCommand() *cobra.Command {
+	var (
+		fqbn   arguments.Fqbn
+		port   arguments.Port
+		config map[string]string
+		sinks  []string
+		fields []string
+		csv    bool
+	)
+	logCommand := &cobra.Command{
+		Use:   "log",
+		Short: tr("Logs the data printed by a board to MQTT, InfluxDB or SQLite."),
+		Long: tr("Reads the lines printed by the sketch on the monitor port and parses them into records, that are forwarded to the given sinks until CTRL-C is pressed. " +
+			"The values on each line are separated by commas, spaces or tabs and can be labeled as label:value, like for the Serial Plotter. " +
+			"The values without a label are named after the --fields flag or the first line, if it's made only of names. " +
+			"The sinks are given as URLs:\n" +
+			"  mqtt://[user:password@]host[:port]/topic (mqtts:// for TLS) publishes each record as JSON\n" +
+			"  influx://[user:password@]host[:port]/database[?measurement=name&token=token] (influxs:// for HTTPS) writes to InfluxDB\n" +
+			"  sqlite:file.db[?table=name] inserts in a table of a SQLite database, adding a column for each new field"),
+		Example: "" +
+			"  " + os.Args[0] + " log -p /dev/ttyACM0 -c baudrate=115200 --sink mqtt://localhost/sensors/room1\n" +
+			"  " + os.Args[0] + " log -p /dev/ttyACM0 --sink sqlite:data.db --sink influx://localhost:8086/sensors\n" +
+			"  " + os.Args[0] + " log -p /dev/ttyUSB0 --csv --fields time,temperature,humidity --sink sqlite:data.db?table=weather",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runLogCommand(&fqbn, &port, config, sinks, fields, csv)
+		},
+	}
+	fqbn.AddToCommand(logCommand)
+	port.AddToCommand(logCommand)
+	arguments.AddKeyValuePFlag(logCommand, &config, "config", "c", nil, tr("Configure the monitor port, for example baudrate=115200."))
+	logCommand.Flags().StringArrayVar(&sinks, "sink", nil, tr("The URL of a sink receiving the records, can be used multiple times."))
+	logCommand.Flags().StringSliceVar(&fields, "fields", nil, tr("The names of the values without a label, in order."))
+	logCommand.Flags().BoolVar(&csv, "csv", false, tr("Parse the lines as CSV: the values are separated only by commas and can be quoted."))
+	return logCommand
+}
+
+func runLogCommand(fqbn *arguments.Fqbn, port *arguments.Port, config map[string]string, sinkURLs, fields []string, csv bool) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli log`")
+
+	discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+	if err != nil {
+		feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+	}
+
+	type namedSink struct {
+		name string
+		sink datalog.Sink
+	}
+	sinks := []*namedSink{}
+	for _, sinkURL := range sinkURLs {
+		sink, err := datalog.OpenSink(sinkURL, discoveryPort.GetAddress())
+		if err != nil {
+			feedback.Fatal(tr("Error opening the sink: %v", err), feedback.ErrGeneric)
+		}
+		defer sink.Close()
+		sinks = append(sinks, &namedSink{name: redactSink(sinkURL), sink: sink})
+	}
+
+	conf := &rpc.MonitorPortConfiguration{}
+	settings := make([]string, 0, len(config))
+	for setting := range config {
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+	for _, setting := range settings {
+		conf.Settings = append(conf.Settings, &rpc.MonitorPortSetting{SettingId: setting, Value: config[setting]})
+	}
+	portProxy, _, err := monitor.Monitor(context.Background(), &rpc.MonitorPortOpenRequest{
+		Instance:          inst,
+		Port:              discoveryPort,
+		Fqbn:              fqbn.String(),
+		PortConfiguration: conf,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error opening the monitor port: %v", err), feedback.ErrGeneric)
+	}
+
+	ctx, cancel := cleanup.InterruptableContext(context.Background())
+	defer cancel()
+	go func() {
+		// Closing the port stops the reading of the lines
+		<-ctx.Done()
+		portProxy.Close()
+	}()
+
+	res := &logResult{Port: discoveryPort.GetAddress()}
+	parser := datalog.NewParser(fields, csv)
+	scanner := bufio.NewScanner(portProxy)
+	for scanner.Scan() {
+		record := parser.Parse(scanner.Text(), time.Now())
+		if record == nil {
+			continue
+		}
+		res.Records++
+		feedback.Print(record.String())
+		for _, s := range sinks {
+			if err := s.sink.Write(record); err != nil {
+				res.Errors++
+				feedback.Warning(tr("Error writing to %[1]s: %[2]v", s.name, err))
+			}
+		}
+	}
+	feedback.PrintResult(res)
+}
+
+// redactSink returns the URL of the sink without the password and the token
+func redactSink(sinkURL string) string {
+	u, err := url.Parse(sinkURL)
+	if err != nil {
+		return sinkURL
+	}
+	if query := u.Query(); query.Has("token") {
+		query.Set("token", "xxxxx")
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
+
+type logResult struct {
+	Port    string `json:"port"`
+	Records int    `json:"records"`
+	Errors  int    `json:"errors"`
+}
+
+func (r *logResult) Data() interface{} {
+	return r
+}
+
+func (r *logResult) String() string {
+	res := tr("%[1]d records logged from %[2]s", r.Records, r.Port)
+	if r.Errors > 0 {
+		res += "\n" + tr("%d errors writing to the sinks", r.Errors)
+	}
+	return res
+}
@@ -31,7 +31,6 @@ import (
 	"github.com/arduino/arduino-cli/internal/cli/instance"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
-	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -104,6 +103,7 @@ func runDebugCommand(args []string, portArgs *arguments.Port, fqbnArg *arguments
 
 	fqbn, port := arguments.CalculateFQBNAndPort(portArgs, fqbnArg, inst, sk.GetDefaultFqbn(), sk.GetDefaultPort(), sk.GetDefaultProtocol())
 
+	programmer.SetDefaultFromBoardAlias(fqbnArg)
 	prog := profile.GetProgrammer()
 	if prog == "" || programmer.GetProgrammer() != "" {
 		prog = programmer.String(inst, fqbn)
@@ -218,8 +218,8 @@ func (r *debugInfoResult) Data() interface{} {
 
 func (r *debugInfoResult) String() string {
 	t := table.New()
-	green := color.New(color.FgHiGreen)
-	dimGreen := color.New(color.FgGreen)
+	green := feedback.GetTheme().Accent
+	dimGreen := feedback.GetTheme().Muted
 	t.AddRow(tr("Executable to debug"), table.NewCell(r.Executable, green))
 	t.AddRow(tr("Toolchain type"), table.NewCell(r.Toolchain, green))
 	t.AddRow(tr("Toolchain path"), table.NewCell(r.ToolchainPath, dimGreen))
@@ -55,7 +55,8 @@ func runDebugCheckCommand(portArgs *arguments.Port, fqbnArg *arguments.Fqbn, int
 	instance := instance.CreateAndInit()
 	logrus.Info("Executing `arduino-cli debug`")
 
-	port, err := portArgs.GetPort(instance, "", "")
+	programmerArg.SetDefaultFromBoardAlias(fqbnArg)
+	port, err := portArgs.GetPort(instance, fqbnArg.DefaultPort(), "")
 	if err != nil {
 		feedback.FatalError(err, feedback.ErrBadArgument)
 	}
@@ -0,0 +1,87 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package deployments
+
+import (
+	"os"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `deployments` command
+func NewCommand() *cobra.Command {
+	deploymentsCommand := &cobra.Command{
+		Use:   "deployments",
+		Short: tr("Shows the sketches uploaded to the boards."),
+		Long: tr("Shows the sketches uploaded to the boards. Every successful upload is recorded, " +
+			"together with the serial number of the board and the UUID of the build, in a registry in the data directory."),
+		Example: "  " + os.Args[0] + " deployments list\n" +
+			"  " + os.Args[0] + " deployments show 95037323535351F0E1C1",
+	}
+
+	deploymentsCommand.AddCommand(initListCommand())
+	deploymentsCommand.AddCommand(initShowCommand())
+
+	return deploymentsCommand
+}
+
+type deployment struct {
+	SerialNumber string `json:"serial_number,omitempty"`
+	PortAddress  string `json:"port_address,omitempty"`
+	PortProtocol string `json:"port_protocol,omitempty"`
+	FQBN         string `json:"fqbn"`
+	Sketch       string `json:"sketch"`
+	BuildUUID    string `json:"build_uuid,omitempty"`
+	Timestamp    string `json:"timestamp"`
+}
+
+func newDeployments(list []*rpc.Deployment) []*deployment {
+	res := []*deployment{}
+	for _, d := range list {
+		res = append(res, &deployment{
+			SerialNumber: d.GetSerialNumber(),
+			PortAddress:  d.GetPortAddress(),
+			PortProtocol: d.GetPortProtocol(),
+			FQBN:         d.GetFqbn(),
+			Sketch:       d.GetSketch(),
+			BuildUUID:    d.GetBuildUuid(),
+			Timestamp:    d.GetTimestamp(),
+		})
+	}
+	return res
+}
+
+// board returns the serial number of the board or, if missing, the port
+func (d *deployment) board() string {
+	if d.SerialNumber != "" {
+		return d.SerialNumber
+	}
+	return d.PortAddress
+}
+
+// localTime returns the timestamp in the local time zone
+func (d *deployment) localTime() string {
+	t, err := time.Parse(time.RFC3339, d.Timestamp)
+	if err != nil {
+		return d.Timestamp
+	}
+	return t.Local().Format(time.DateTime)
+}
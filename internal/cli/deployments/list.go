@@ -0,0 +1,72 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package deployments
+
+import (
+	"context"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initListCommand() *cobra.Command {
+	listCommand := &cobra.Command{
+		Use:     "list",
+		Short:   tr("Lists the last sketch uploaded to each board."),
+		Long:    tr("Lists the last sketch uploaded to each board, from the most recent upload."),
+		Example: "  " + os.Args[0] + " deployments list",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runListCommand()
+		},
+	}
+	return listCommand
+}
+
+func runListCommand() {
+	logrus.Info("Executing `arduino-cli deployments list`")
+
+	resp, err := upload.DeploymentsList(context.Background(), &rpc.DeploymentsListRequest{})
+	if err != nil {
+		feedback.Fatal(tr("Error listing the deployments: %v", err), feedback.ErrGeneric)
+	}
+	feedback.PrintResult(&listResult{Deployments: newDeployments(resp.GetDeployments())})
+}
+
+type listResult struct {
+	Deployments []*deployment `json:"deployments"`
+}
+
+func (r *listResult) Data() interface{} {
+	return r
+}
+
+func (r *listResult) String() string {
+	if len(r.Deployments) == 0 {
+		return tr("No sketch has been uploaded yet.")
+	}
+	t := table.New()
+	t.SetHeader(tr("Board"), tr("FQBN"), tr("Sketch"), tr("Build UUID"), tr("Uploaded"))
+	for _, d := range r.Deployments {
+		t.AddRow(d.board(), d.FQBN, d.Sketch, d.BuildUUID, d.localTime())
+	}
+	return t.Render()
+}
@@ -0,0 +1,90 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package deployments
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initShowCommand() *cobra.Command {
+	showCommand := &cobra.Command{
+		Use:   fmt.Sprintf("show <%s>", tr("serial-number")),
+		Short: tr("Shows the sketches uploaded to a board."),
+		Long: tr("Shows the sketch running on a board and the history of the previous uploads. " +
+			"The boards without a serial number are identified by the address of the upload port."),
+		Example: "  " + os.Args[0] + " deployments show 95037323535351F0E1C1\n" +
+			"  " + os.Args[0] + " deployments show /dev/ttyUSB0",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runShowCommand(args[0])
+		},
+	}
+	return showCommand
+}
+
+func runShowCommand(board string) {
+	logrus.Info("Executing `arduino-cli deployments show`")
+
+	resp, err := upload.DeploymentsShow(context.Background(), &rpc.DeploymentsShowRequest{Board: board})
+	if err != nil {
+		feedback.Fatal(tr("Error showing the deployments: %v", err), feedback.ErrGeneric)
+	}
+	feedback.PrintResult(&showResult{Deployments: newDeployments(resp.GetDeployments())})
+}
+
+type showResult struct {
+	Deployments []*deployment `json:"deployments"`
+}
+
+func (r *showResult) Data() interface{} {
+	return r
+}
+
+func (r *showResult) String() string {
+	current := r.Deployments[0]
+	t := table.New()
+	t.SetColumnWidthMode(1, table.Average)
+	t.AddRow(tr("Board:"), current.board())
+	if current.SerialNumber != "" && current.PortAddress != "" {
+		t.AddRow(tr("Last port:"), current.PortAddress)
+	}
+	t.AddRow(tr("FQBN:"), current.FQBN)
+	t.AddRow(tr("Sketch:"), current.Sketch)
+	if current.BuildUUID != "" {
+		t.AddRow(tr("Build UUID:"), current.BuildUUID)
+	}
+	t.AddRow(tr("Uploaded:"), current.localTime())
+	res := t.Render()
+
+	if len(r.Deployments) > 1 {
+		history := table.New()
+		history.SetHeader(tr("Sketch"), tr("FQBN"), tr("Build UUID"), tr("Uploaded"))
+		for _, d := range r.Deployments[1:] {
+			history.AddRow(d.Sketch, d.FQBN, d.BuildUUID, d.localTime())
+		}
+		res += "\n" + feedback.GetTheme().Title.Sprint(tr("Previous uploads:")) + "\n" + history.Render()
+	}
+	return res
+}
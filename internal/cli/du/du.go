@@ -0,0 +1,155 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package du
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"github.com/arduino/arduino-cli/commands"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `du` command
+func NewCommand() *cobra.Command {
+	duCommand := &cobra.Command{
+		Use:   "du",
+		Short: tr("Shows the disk usage of the data directory."),
+		Long: tr("Shows the disk usage of the installed platforms and tools, of the archives in the downloads directory and of the build caches. " +
+			"The tools shared by several platforms are counted once in the total."),
+		Example: "  " + os.Args[0] + " du\n" +
+			"  " + os.Args[0] + " du --format json",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runDiskUsageCommand()
+		},
+	}
+	return duCommand
+}
+
+func runDiskUsageCommand() {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli du`")
+
+	resp, err := commands.DiskUsage(context.Background(), &rpc.DiskUsageRequest{Instance: inst})
+	if err != nil {
+		feedback.Fatal(tr("Error computing the disk usage: %v", err), feedback.ErrGeneric)
+	}
+
+	res := &diskUsageResult{Entries: []*diskUsageEntry{}, TotalSize: resp.GetTotalSize()}
+	for _, entry := range resp.GetEntries() {
+		res.Entries = append(res.Entries, &diskUsageEntry{
+			Category: diskUsageCategoryName(entry.GetCategory()),
+			Name:     entry.GetName(),
+			Path:     entry.GetPath(),
+			Size:     entry.GetSize(),
+			Shared:   entry.GetShared(),
+		})
+	}
+	feedback.PrintResult(res)
+}
+
+func diskUsageCategoryName(category rpc.DiskUsageCategory) string {
+	switch category {
+	case rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_PLATFORM:
+		return "platform"
+	case rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_TOOL:
+		return "tool"
+	case rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_DOWNLOAD:
+		return "download"
+	case rpc.DiskUsageCategory_DISK_USAGE_CATEGORY_BUILD_CACHE:
+		return "build_cache"
+	}
+	return "unknown"
+}
+
+func diskUsageCategoryDescription(category string) string {
+	switch category {
+	case "platform":
+		return tr("Platforms")
+	case "tool":
+		return tr("Tools")
+	case "download":
+		return tr("Downloaded archives")
+	case "build_cache":
+		return tr("Build caches")
+	}
+	return category
+}
+
+type diskUsageEntry struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Shared   bool   `json:"shared,omitempty"`
+}
+
+type diskUsageResult struct {
+	Entries   []*diskUsageEntry `json:"entries"`
+	TotalSize int64             `json:"total_size"`
+}
+
+func (r *diskUsageResult) Data() interface{} {
+	return r
+}
+
+func (r *diskUsageResult) String() string {
+	if len(r.Entries) == 0 {
+		return tr("The data directory is empty.")
+	}
+
+	// Group the entries by category, keeping the order of the categories, and
+	// show the largest entries first
+	categories := []string{}
+	byCategory := map[string][]*diskUsageEntry{}
+	for _, entry := range r.Entries {
+		if _, ok := byCategory[entry.Category]; !ok {
+			categories = append(categories, entry.Category)
+		}
+		byCategory[entry.Category] = append(byCategory[entry.Category], entry)
+	}
+
+	t := table.New()
+	t.SetHeader(tr("Category"), tr("Name"), tr("Size"))
+	for _, category := range categories {
+		entries := byCategory[category]
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Size > entries[j].Size })
+		var subtotal int64
+		for _, entry := range entries {
+			size := feedback.FormatSize(entry.Size)
+			if entry.Shared {
+				size += " " + tr("(shared)")
+			}
+			t.AddRow(diskUsageCategoryDescription(category), entry.Name, size)
+			subtotal += entry.Size
+		}
+		if category != "tool" {
+			t.AddRow("", tr("Subtotal"), feedback.FormatSize(subtotal))
+		}
+	}
+	t.AddRow("", tr("Total"), feedback.FormatSize(r.TotalSize))
+	return t.Render()
+}
@@ -0,0 +1,82 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package eeprom
+
+import (
+	"context"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initDumpCommand() *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		outputFile string
+		verbose    bool
+	)
+	dumpCommand := &cobra.Command{
+		Use:     "dump",
+		Short:   tr("Saves the EEPROM content of a board to a file."),
+		Long:    tr("Saves the EEPROM content of a board to a file. Boards that emulate the EEPROM in flash are supported if the platform declares the emulated region."),
+		Example: "  " + os.Args[0] + " eeprom dump -b arduino:avr:uno -P atmel_ice -o eeprom.bin",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli eeprom dump`")
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			output, err := paths.New(outputFile).Abs()
+			if err != nil {
+				feedback.Fatal(tr("Invalid output file: %v", err), feedback.ErrBadArgument)
+			}
+
+			stdOut, stdErr, res := feedback.OutputStreams()
+			if _, err := upload.ReadMemory(context.Background(), &rpc.ReadMemoryRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.String(inst, fqbn.String()),
+				MemoryType: "eeprom",
+				OutputFile: output.String(),
+				Verbose:    verbose,
+			}, stdOut, stdErr); err != nil {
+				feedback.Fatal(tr("Error saving EEPROM: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(res())
+		},
+	}
+	fqbn.AddToCommand(dumpCommand)
+	port.AddToCommand(dumpCommand)
+	programmer.AddToCommand(dumpCommand)
+	dumpCommand.Flags().StringVarP(&outputFile, "output-file", "o", "", tr("The file where the EEPROM content is saved."))
+	dumpCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	dumpCommand.MarkFlagRequired("output-file")
+	return dumpCommand
+}
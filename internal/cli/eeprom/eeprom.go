@@ -0,0 +1,41 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package eeprom
+
+import (
+	"os"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `eeprom` command
+func NewCommand() *cobra.Command {
+	eepromCommand := &cobra.Command{
+		Use:   "eeprom",
+		Short: tr("Arduino EEPROM commands."),
+		Long:  tr("Saves and restores the EEPROM content of a board, for example to preserve calibration data before reflashing it."),
+		Example: "  " + os.Args[0] + " eeprom dump -b arduino:avr:uno -P atmel_ice -o eeprom.bin\n" +
+			"  " + os.Args[0] + " eeprom restore -b arduino:avr:uno -P atmel_ice -i eeprom.bin",
+	}
+
+	eepromCommand.AddCommand(initDumpCommand())
+	eepromCommand.AddCommand(initRestoreCommand())
+
+	return eepromCommand
+}
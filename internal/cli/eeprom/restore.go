@@ -0,0 +1,88 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package eeprom
+
+import (
+	"context"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initRestoreCommand() *cobra.Command {
+	var (
+		fqbn       arguments.Fqbn
+		port       arguments.Port
+		programmer arguments.Programmer
+		inputFile  string
+		verbose    bool
+		verify     bool
+	)
+	restoreCommand := &cobra.Command{
+		Use:     "restore",
+		Short:   tr("Restores the EEPROM content of a board from a file."),
+		Long:    tr("Restores the EEPROM content of a board from a file previously saved with the dump command."),
+		Example: "  " + os.Args[0] + " eeprom restore -b arduino:avr:uno -P atmel_ice -i eeprom.bin",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			inst := instance.CreateAndInit()
+			logrus.Info("Executing `arduino-cli eeprom restore`")
+
+			programmer.SetDefaultFromBoardAlias(&fqbn)
+			discoveryPort, err := port.GetPort(inst, fqbn.DefaultPort(), "")
+			if err != nil {
+				feedback.Fatal(tr("Error getting port: %v", err), feedback.ErrGeneric)
+			}
+			input, err := paths.New(inputFile).Abs()
+			if err != nil {
+				feedback.Fatal(tr("Invalid input file: %v", err), feedback.ErrBadArgument)
+			}
+			if !input.Exist() {
+				feedback.Fatal(tr("File not found: %s", input), feedback.ErrBadArgument)
+			}
+
+			stdOut, stdErr, res := feedback.OutputStreams()
+			if _, err := upload.WriteMemory(context.Background(), &rpc.WriteMemoryRequest{
+				Instance:   inst,
+				Fqbn:       fqbn.String(),
+				Port:       discoveryPort,
+				Programmer: programmer.String(inst, fqbn.String()),
+				MemoryType: "eeprom",
+				InputFile:  input.String(),
+				Verbose:    verbose,
+				Verify:     verify,
+			}, stdOut, stdErr); err != nil {
+				feedback.Fatal(tr("Error restoring EEPROM: %v", err), feedback.ErrGeneric)
+			}
+			feedback.PrintResult(res())
+		},
+	}
+	fqbn.AddToCommand(restoreCommand)
+	port.AddToCommand(restoreCommand)
+	programmer.AddToCommand(restoreCommand)
+	restoreCommand.Flags().StringVarP(&inputFile, "input-file", "i", "", tr("The file containing the EEPROM content to restore."))
+	restoreCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Turns on verbose mode."))
+	restoreCommand.Flags().BoolVarP(&verify, "verify", "t", false, tr("Verify the EEPROM content after writing."))
+	restoreCommand.MarkFlagRequired("input-file")
+	return restoreCommand
+}
@@ -153,13 +153,13 @@ func Printf(format string, v ...interface{}) {
 
 // Print behaves like fmt.Print but writes on the out writer and adds a newline.
 func Print(v string) {
-	fmt.Fprintln(feedbackOut, v)
+	progress.print(feedbackOut, v)
 }
 
 // Warning outputs a warning message.
 func Warning(msg string) {
 	if format == Text {
-		fmt.Fprintln(feedbackErr, msg)
+		progress.print(feedbackErr, msg)
 	} else {
 		bufferWarnings = append(bufferWarnings, msg)
 	}
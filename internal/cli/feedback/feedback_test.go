@@ -121,3 +121,11 @@ func (r *testResult) String() string {
 	}
 	return "Failure"
 }
+
+func TestFormatSize(t *testing.T) {
+	require.Equal(t, "0 B", FormatSize(0))
+	require.Equal(t, "1023 B", FormatSize(1023))
+	require.Equal(t, "1.0 KiB", FormatSize(1024))
+	require.Equal(t, "1.5 MiB", FormatSize(1024*1024*3/2))
+	require.Equal(t, "2.0 GiB", FormatSize(2*1024*1024*1024))
+}
@@ -0,0 +1,206 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package feedback
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// progressRedrawInterval is the minimum interval between two redraws of the
+// progress bars caused by an update.
+const progressRedrawInterval = 100 * time.Millisecond
+
+// progressBar is the progress of an operation, shown on its own line.
+type progressBar struct {
+	label   string
+	bytes   bool
+	current int64
+	total   int64
+	start   time.Time
+}
+
+// progressRenderer draws the progress bars of the operations running
+// concurrently, one line for each operation, below the regular output. The
+// bars are drawn directly on stdOut to keep them out of the output buffer.
+type progressRenderer struct {
+	mux      sync.Mutex
+	bars     []*progressBar
+	drawn    int
+	lastDraw time.Time
+}
+
+var progress = &progressRenderer{}
+
+// progressBarsEnabled returns true if the progress bars can be drawn, that is
+// if the text output goes to a terminal. Otherwise the progress is reported
+// with plain sequential messages.
+func progressBarsEnabled() bool {
+	return format == Text && HasConsole()
+}
+
+// add starts drawing a new progress bar. The bytes flag selects whether the
+// progress is an amount of bytes or a percentage.
+func (r *progressRenderer) add(label string, bytes bool) *progressBar {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	bar := &progressBar{label: label, bytes: bytes, start: time.Now()}
+	if !bytes {
+		bar.total = 100
+	}
+	r.bars = append(r.bars, bar)
+	r.draw()
+	return bar
+}
+
+// update sets the progress of the bar, a total of 0 means unknown.
+func (r *progressRenderer) update(bar *progressBar, current, total int64) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	bar.current, bar.total = current, total
+	if time.Since(r.lastDraw) >= progressRedrawInterval {
+		r.draw()
+	}
+}
+
+// finish removes the bar and prints the final message, if any, in its place.
+func (r *progressRenderer) finish(bar *progressBar, msg string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for i, b := range r.bars {
+		if b == bar {
+			r.bars = append(r.bars[:i], r.bars[i+1:]...)
+			break
+		}
+	}
+	if msg == "" {
+		r.draw()
+		return
+	}
+	r.printAbove(feedbackOut, msg)
+}
+
+// print writes the message above the progress bars, or simply writes it if
+// there are no progress bars.
+func (r *progressRenderer) print(out io.Writer, msg string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.drawn == 0 && len(r.bars) == 0 {
+		fmt.Fprintln(out, msg)
+		return
+	}
+	r.printAbove(out, msg)
+}
+
+func (r *progressRenderer) printAbove(out io.Writer, msg string) {
+	r.clear()
+	fmt.Fprintln(out, msg)
+	r.draw()
+}
+
+// clear erases the progress bars drawn on the terminal.
+func (r *progressRenderer) clear() {
+	if r.drawn > 0 {
+		fmt.Fprintf(stdOut, "\x1b[%dA\r\x1b[J", r.drawn)
+		r.drawn = 0
+	}
+}
+
+// draw redraws all the progress bars.
+func (r *progressRenderer) draw() {
+	width := 80
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		width = w
+	}
+	res := ""
+	if r.drawn > 0 {
+		res += fmt.Sprintf("\x1b[%dA\r", r.drawn)
+	}
+	for _, bar := range r.bars {
+		res += "\x1b[2K" + bar.render(width-1) + "\n"
+	}
+	res += "\x1b[J"
+	fmt.Fprint(stdOut, res)
+	r.drawn = len(r.bars)
+	r.lastDraw = time.Now()
+}
+
+// render returns the line of the progress bar, fitting the given width.
+func (bar *progressBar) render(width int) string {
+	status := ""
+	percent := -1
+	if bar.total > 0 {
+		percent = int(min(bar.current, bar.total) * 100 / bar.total)
+		status = fmt.Sprintf(" %3d%%", percent)
+	}
+	if bar.bytes {
+		if bar.total > 0 {
+			status += fmt.Sprintf(" %s / %s", FormatSize(bar.current), FormatSize(bar.total))
+		} else {
+			status += " " + FormatSize(bar.current)
+		}
+	}
+	if eta := bar.eta(); eta > 0 {
+		status += " " + tr("ETA %s", eta.String())
+	}
+
+	label := bar.label
+	barWidth := width - utf8.RuneCountInString(label) - utf8.RuneCountInString(status) - 3
+	if barWidth < 10 {
+		// Shorten the label to leave room for the bar
+		barWidth = 10
+		maxLabel := max(width-utf8.RuneCountInString(status)-barWidth-3, 1)
+		if runes := []rune(label); len(runes) > maxLabel {
+			label = string(runes[:maxLabel-1]) + "…"
+		}
+	}
+	barWidth = min(barWidth, 40)
+	graph := ""
+	if percent >= 0 {
+		filled := percent * barWidth / 100
+		graph = strings.Repeat("=", filled)
+		if filled < barWidth {
+			graph += ">" + strings.Repeat(" ", barWidth-filled-1)
+		}
+	} else {
+		// Unknown total, bounce an indicator back and forth
+		span := barWidth - 3
+		pos := int(time.Since(bar.start)/progressRedrawInterval) % (2 * span)
+		if pos > span {
+			pos = 2*span - pos
+		}
+		graph = strings.Repeat(" ", pos) + "<=>" + strings.Repeat(" ", span-pos)
+	}
+	return theme.Progress.Sprint(label) + " [" + graph + "]" + status
+}
+
+// eta returns the estimated time left to complete the operation, or 0 if
+// it can't be estimated yet.
+func (bar *progressBar) eta() time.Duration {
+	elapsed := time.Since(bar.start)
+	if bar.total <= 0 || bar.current <= 0 || bar.current >= bar.total || elapsed < time.Second {
+		return 0
+	}
+	left := time.Duration(float64(elapsed) * float64(bar.total-bar.current) / float64(bar.current))
+	return left.Round(time.Second)
+}
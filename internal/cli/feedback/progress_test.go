@@ -0,0 +1,74 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package feedback
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressBarRender(t *testing.T) {
+	bar := &progressBar{label: "core.tar.bz2", bytes: true, current: 512, total: 2048, start: time.Now()}
+	require.Equal(t, "core.tar.bz2 [==========>                             ]  25% 512 B / 2.0 KiB", bar.render(80))
+
+	// The label is shortened to leave room for the bar
+	bar = &progressBar{label: "a-very-long-label-for-the-download", total: 100, current: 50, start: time.Now()}
+	line := bar.render(30)
+	require.Equal(t, "a-very-long… [=====>    ]  50%", line)
+
+	// The ETA is estimated from the elapsed time
+	bar = &progressBar{label: "sketch", total: 100, current: 25, start: time.Now().Add(-10 * time.Second)}
+	require.Equal(t, 30*time.Second, bar.eta())
+	require.Contains(t, bar.render(80), "ETA 30s")
+
+	// An unknown total shows a bouncing indicator
+	bar = &progressBar{label: "index", bytes: true, current: 100, start: time.Now()}
+	require.Equal(t, "index [<=>                                     ] 100 B", bar.render(80))
+}
+
+func TestProgressRenderer(t *testing.T) {
+	reset()
+	out := &bytes.Buffer{}
+	stdOut = out
+	feedbackOut = out
+	defer reset()
+
+	r := &progressRenderer{}
+	first := r.add("first", false)
+	second := r.add("second", false)
+	require.Equal(t, 2, r.drawn)
+	out.Reset()
+
+	// Messages are printed above the bars, which are redrawn below
+	r.print(out, "hello")
+	require.Equal(t, "\x1b[2A\r\x1b[Jhello\n\x1b[2Kfirst [>                                       ]   0%\n\x1b[2Ksecond [>                                       ]   0%\n\x1b[J", out.String())
+	out.Reset()
+
+	r.finish(first, "first done")
+	require.Equal(t, "\x1b[2A\r\x1b[Jfirst done\n\x1b[2Ksecond [>                                       ]   0%\n\x1b[J", out.String())
+	out.Reset()
+
+	r.finish(second, "")
+	require.Equal(t, "\x1b[1A\r\x1b[J", out.String())
+	require.Equal(t, 0, r.drawn)
+	out.Reset()
+
+	r.print(out, "plain")
+	require.Equal(t, "plain\n", out.String())
+}
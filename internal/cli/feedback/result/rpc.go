@@ -655,6 +655,58 @@ func NewProgrammer(c *rpc.Programmer) *Programmer {
 	}
 }
 
+type ProgrammerDetailsResponse struct {
+	Programmer    *Programmer           `json:"programmer,omitempty"`
+	Tool          string                `json:"tool,omitempty"`
+	Protocol      string                `json:"protocol,omitempty"`
+	Communication string                `json:"communication,omitempty"`
+	Wiring        string                `json:"wiring,omitempty"`
+	RequiresPort  bool                  `json:"requires_port"`
+	Operations    *ProgrammerOperations `json:"operations,omitempty"`
+}
+
+func NewProgrammerDetailsResponse(r *rpc.ProgrammerDetailsResponse) *ProgrammerDetailsResponse {
+	if r == nil {
+		return nil
+	}
+	return &ProgrammerDetailsResponse{
+		Programmer:    NewProgrammer(r.GetProgrammer()),
+		Tool:          r.GetTool(),
+		Protocol:      r.GetProtocol(),
+		Communication: r.GetCommunication(),
+		Wiring:        r.GetWiring(),
+		RequiresPort:  r.GetRequiresPort(),
+		Operations:    NewProgrammerOperations(r.GetOperations()),
+	}
+}
+
+type ProgrammerOperations struct {
+	Upload           bool `json:"upload"`
+	Erase            bool `json:"erase"`
+	BurnBootloader   bool `json:"burn_bootloader"`
+	MemoryRead       bool `json:"memory_read"`
+	MemoryWrite      bool `json:"memory_write"`
+	Fuses            bool `json:"fuses"`
+	FlashProtection  bool `json:"flash_protection"`
+	PowerMeasurement bool `json:"power_measurement"`
+}
+
+func NewProgrammerOperations(o *rpc.ProgrammerOperations) *ProgrammerOperations {
+	if o == nil {
+		return nil
+	}
+	return &ProgrammerOperations{
+		Upload:           o.GetUpload(),
+		Erase:            o.GetErase(),
+		BurnBootloader:   o.GetBurnBootloader(),
+		MemoryRead:       o.GetMemoryRead(),
+		MemoryWrite:      o.GetMemoryWrite(),
+		Fuses:            o.GetFuses(),
+		FlashProtection:  o.GetFlashProtection(),
+		PowerMeasurement: o.GetPowerMeasurement(),
+	}
+}
+
 type BoardIdentificationProperties struct {
 	Properties orderedmap.Map[string, string] `json:"properties,omitempty"`
 }
@@ -699,10 +751,11 @@ func NewBoardListAllResponse(p *rpc.BoardListAllResponse) *BoardListAllResponse
 }
 
 type BoardListItem struct {
-	Name     string    `json:"name,omitempty"`
-	Fqbn     string    `json:"fqbn,omitempty"`
-	IsHidden bool      `json:"is_hidden,omitempty"`
-	Platform *Platform `json:"platform,omitempty"`
+	Name         string             `json:"name,omitempty"`
+	Fqbn         string             `json:"fqbn,omitempty"`
+	IsHidden     bool               `json:"is_hidden,omitempty"`
+	Platform     *Platform          `json:"platform,omitempty"`
+	Capabilities *BoardCapabilities `json:"capabilities,omitempty"`
 }
 
 func NewBoardListItems(b []*rpc.BoardListItem) []*BoardListItem {
@@ -721,10 +774,28 @@ func NewBoardListItem(b *rpc.BoardListItem) *BoardListItem {
 		return nil
 	}
 	return &BoardListItem{
-		Name:     b.GetName(),
-		Fqbn:     b.GetFqbn(),
-		IsHidden: b.GetIsHidden(),
-		Platform: NewPlatform(b.GetPlatform()),
+		Name:         b.GetName(),
+		Fqbn:         b.GetFqbn(),
+		IsHidden:     b.GetIsHidden(),
+		Platform:     NewPlatform(b.GetPlatform()),
+		Capabilities: NewBoardCapabilities(b.GetCapabilities()),
+	}
+}
+
+type BoardCapabilities struct {
+	Debug         bool `json:"debug"`
+	NetworkUpload bool `json:"network_upload"`
+	CryptoChip    bool `json:"crypto_chip"`
+}
+
+func NewBoardCapabilities(c *rpc.BoardCapabilities) *BoardCapabilities {
+	if c == nil {
+		return nil
+	}
+	return &BoardCapabilities{
+		Debug:         c.GetDebug(),
+		NetworkUpload: c.GetNetworkUpload(),
+		CryptoChip:    c.GetCryptoChip(),
 	}
 }
 
@@ -910,13 +981,22 @@ func NewMonitorPortSettingDescriptor(m *rpc.MonitorPortSettingDescriptor) *Monit
 }
 
 type BuilderResult struct {
-	BuildPath              string                      `json:"build_path,omitempty"`
-	UsedLibraries          []*Library                  `json:"used_libraries,omitempty"`
-	ExecutableSectionsSize []*ExecutableSectionSize    `json:"executable_sections_size,omitempty"`
-	BoardPlatform          *InstalledPlatformReference `json:"board_platform,omitempty"`
-	BuildPlatform          *InstalledPlatformReference `json:"build_platform,omitempty"`
-	BuildProperties        []string                    `json:"build_properties,omitempty"`
-	Diagnostics            []*CompileDiagnostic        `json:"diagnostics,omitempty"`
+	BuildPath               string                      `json:"build_path,omitempty"`
+	UsedLibraries           []*Library                  `json:"used_libraries,omitempty"`
+	ExecutableSectionsSize  []*ExecutableSectionSize    `json:"executable_sections_size,omitempty"`
+	BoardPlatform           *InstalledPlatformReference `json:"board_platform,omitempty"`
+	BuildPlatform           *InstalledPlatformReference `json:"build_platform,omitempty"`
+	BuildProperties         []string                    `json:"build_properties,omitempty"`
+	Diagnostics             []*CompileDiagnostic        `json:"diagnostics,omitempty"`
+	ConditionalBranches     []*ConditionalBranch        `json:"conditional_branches,omitempty"`
+	MemoryMapReport         *MemoryMapReport            `json:"memory_map_report,omitempty"`
+	CompilerExplorerSession *CompilerExplorerSession    `json:"compiler_explorer_session,omitempty"`
+	BuildUUID               string                      `json:"build_uuid,omitempty"`
+	LastLog                 *BuildLog                   `json:"last_log,omitempty"`
+	AppliedFixIts           []*CompileDiagnosticFixIt   `json:"applied_fixits,omitempty"`
+	IncludeIssues           []*IncludeIssue             `json:"include_issues,omitempty"`
+	HeaderDeclarations      *HeaderDeclarations         `json:"header_declarations,omitempty"`
+	BoardSuggestions        []*BoardSuggestion          `json:"board_suggestions,omitempty"`
 }
 
 func NewBuilderResult(c *rpc.BuilderResult) *BuilderResult {
@@ -933,13 +1013,221 @@ func NewBuilderResult(c *rpc.BuilderResult) *BuilderResult {
 	}
 
 	return &BuilderResult{
-		BuildPath:              c.GetBuildPath(),
-		UsedLibraries:          usedLibs,
-		ExecutableSectionsSize: executableSectionsSizes,
-		BoardPlatform:          NewInstalledPlatformReference(c.GetBoardPlatform()),
-		BuildPlatform:          NewInstalledPlatformReference(c.GetBuildPlatform()),
-		BuildProperties:        c.GetBuildProperties(),
-		Diagnostics:            NewCompileDiagnostics(c.GetDiagnostics()),
+		BuildPath:               c.GetBuildPath(),
+		UsedLibraries:           usedLibs,
+		ExecutableSectionsSize:  executableSectionsSizes,
+		BoardPlatform:           NewInstalledPlatformReference(c.GetBoardPlatform()),
+		BuildPlatform:           NewInstalledPlatformReference(c.GetBuildPlatform()),
+		BuildProperties:         c.GetBuildProperties(),
+		Diagnostics:             NewCompileDiagnostics(c.GetDiagnostics()),
+		ConditionalBranches:     f.Map(c.GetConditionalBranches(), NewConditionalBranch),
+		MemoryMapReport:         NewMemoryMapReport(c.GetMemoryMapReport()),
+		CompilerExplorerSession: NewCompilerExplorerSession(c.GetCompilerExplorerSession()),
+		BuildUUID:               c.GetBuildUuid(),
+		LastLog:                 NewBuildLog(c.GetLastLog()),
+		AppliedFixIts:           f.Map(c.GetAppliedFixits(), NewCompileDiagnosticFixIt),
+		IncludeIssues:           f.Map(c.GetIncludeIssues(), NewIncludeIssue),
+		HeaderDeclarations:      NewHeaderDeclarations(c.GetHeaderDeclarations()),
+		BoardSuggestions:        f.Map(c.GetBoardSuggestions(), NewBoardSuggestion),
+	}
+}
+
+type BuildLog struct {
+	Path    string `json:"path,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+func NewBuildLog(l *rpc.BuildLog) *BuildLog {
+	if l == nil {
+		return nil
+	}
+	return &BuildLog{
+		Path:    l.GetPath(),
+		Content: l.GetContent(),
+	}
+}
+
+type CompilerExplorerSession struct {
+	Compiler string   `json:"compiler,omitempty"`
+	Flags    []string `json:"flags,omitempty"`
+	Source   string   `json:"source,omitempty"`
+	Link     string   `json:"link,omitempty"`
+}
+
+func NewCompilerExplorerSession(s *rpc.CompilerExplorerSession) *CompilerExplorerSession {
+	if s == nil {
+		return nil
+	}
+	return &CompilerExplorerSession{
+		Compiler: s.GetCompiler(),
+		Flags:    s.GetFlags(),
+		Source:   s.GetSource(),
+		Link:     s.GetLink(),
+	}
+}
+
+type ConditionalBranch struct {
+	File      string `json:"file,omitempty"`
+	Line      int64  `json:"line,omitempty"`
+	EndLine   int64  `json:"end_line,omitempty"`
+	Directive string `json:"directive,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Active    bool   `json:"active"`
+}
+
+func NewConditionalBranch(b *rpc.ConditionalBranch) *ConditionalBranch {
+	if b == nil {
+		return nil
+	}
+	return &ConditionalBranch{
+		File:      b.GetFile(),
+		Line:      b.GetLine(),
+		EndLine:   b.GetEndLine(),
+		Directive: b.GetDirective(),
+		Condition: b.GetCondition(),
+		Active:    b.GetActive(),
+	}
+}
+
+type BoardSuggestion struct {
+	Fqbn     string `json:"fqbn,omitempty"`
+	Name     string `json:"name,omitempty"`
+	MaxFlash int64  `json:"max_flash,omitempty"`
+	MaxRam   int64  `json:"max_ram,omitempty"`
+}
+
+func NewBoardSuggestion(s *rpc.BoardSuggestion) *BoardSuggestion {
+	if s == nil {
+		return nil
+	}
+	return &BoardSuggestion{
+		Fqbn:     s.GetFqbn(),
+		Name:     s.GetName(),
+		MaxFlash: s.GetMaxFlash(),
+		MaxRam:   s.GetMaxRam(),
+	}
+}
+
+type HeaderDeclarations struct {
+	Names          []string `json:"names,omitempty"`
+	MissingHeaders []string `json:"missing_headers,omitempty"`
+}
+
+func NewHeaderDeclarations(d *rpc.HeaderDeclarations) *HeaderDeclarations {
+	if d == nil {
+		return nil
+	}
+	return &HeaderDeclarations{
+		Names:          d.GetNames(),
+		MissingHeaders: d.GetMissingHeaders(),
+	}
+}
+
+type IncludeIssueKind string
+
+const (
+	IncludeIssueKindUnused  IncludeIssueKind = "unused"
+	IncludeIssueKindMissing IncludeIssueKind = "missing"
+)
+
+func NewIncludeIssueKind(k rpc.IncludeIssue_Kind) IncludeIssueKind {
+	switch k {
+	case rpc.IncludeIssue_KIND_UNUSED:
+		return IncludeIssueKindUnused
+	case rpc.IncludeIssue_KIND_MISSING:
+		return IncludeIssueKindMissing
+	}
+	return ""
+}
+
+type IncludeIssue struct {
+	Kind       IncludeIssueKind `json:"kind,omitempty"`
+	File       string           `json:"file,omitempty"`
+	Line       int64            `json:"line,omitempty"`
+	Header     string           `json:"header,omitempty"`
+	Symbols    []string         `json:"symbols,omitempty"`
+	IncludedBy string           `json:"included_by,omitempty"`
+}
+
+func NewIncludeIssue(i *rpc.IncludeIssue) *IncludeIssue {
+	if i == nil {
+		return nil
+	}
+	return &IncludeIssue{
+		Kind:       NewIncludeIssueKind(i.GetKind()),
+		File:       i.GetFile(),
+		Line:       i.GetLine(),
+		Header:     i.GetHeader(),
+		Symbols:    i.GetSymbols(),
+		IncludedBy: i.GetIncludedBy(),
+	}
+}
+
+type MemoryMapReport struct {
+	Regions []*MemoryRegionUsage `json:"regions,omitempty"`
+}
+
+func NewMemoryMapReport(r *rpc.MemoryMapReport) *MemoryMapReport {
+	if r == nil {
+		return nil
+	}
+	return &MemoryMapReport{
+		Regions: f.Map(r.GetRegions(), NewMemoryRegionUsage),
+	}
+}
+
+type MemoryRegionUsage struct {
+	Name                string                `json:"name,omitempty"`
+	Origin              uint64                `json:"origin"`
+	Length              uint64                `json:"length,omitempty"`
+	Used                uint64                `json:"used"`
+	Sections            []*MemorySectionUsage `json:"sections,omitempty"`
+	LargestContributors []*MemoryContributor  `json:"largest_contributors,omitempty"`
+}
+
+func NewMemoryRegionUsage(r *rpc.MemoryRegionUsage) *MemoryRegionUsage {
+	if r == nil {
+		return nil
+	}
+	return &MemoryRegionUsage{
+		Name:                r.GetName(),
+		Origin:              r.GetOrigin(),
+		Length:              r.GetLength(),
+		Used:                r.GetUsed(),
+		Sections:            f.Map(r.GetSections(), NewMemorySectionUsage),
+		LargestContributors: f.Map(r.GetLargestContributors(), NewMemoryContributor),
+	}
+}
+
+type MemorySectionUsage struct {
+	Name    string `json:"name,omitempty"`
+	Address uint64 `json:"address"`
+	Size    uint64 `json:"size"`
+}
+
+func NewMemorySectionUsage(s *rpc.MemorySectionUsage) *MemorySectionUsage {
+	if s == nil {
+		return nil
+	}
+	return &MemorySectionUsage{
+		Name:    s.GetName(),
+		Address: s.GetAddress(),
+		Size:    s.GetSize(),
+	}
+}
+
+type MemoryContributor struct {
+	Name string `json:"name,omitempty"`
+	Size uint64 `json:"size"`
+}
+
+func NewMemoryContributor(c *rpc.MemoryContributor) *MemoryContributor {
+	if c == nil {
+		return nil
+	}
+	return &MemoryContributor{
+		Name: c.GetName(),
+		Size: c.GetSize(),
 	}
 }
 
@@ -1004,6 +1292,7 @@ type CompileDiagnostic struct {
 	Column   int64                       `json:"column,omitempty"`
 	Context  []*CompileDiagnosticContext `json:"context,omitempty"`
 	Notes    []*CompileDiagnosticNote    `json:"notes,omitempty"`
+	FixIts   []*CompileDiagnosticFixIt   `json:"fixits,omitempty"`
 }
 
 func NewCompileDiagnostics(cd []*rpc.CompileDiagnostic) []*CompileDiagnostic {
@@ -1019,6 +1308,7 @@ func NewCompileDiagnostic(cd *rpc.CompileDiagnostic) *CompileDiagnostic {
 		Column:   cd.GetColumn(),
 		Context:  f.Map(cd.GetContext(), NewCompileDiagnosticContext),
 		Notes:    f.Map(cd.GetNotes(), NewCompileDiagnosticNote),
+		FixIts:   f.Map(cd.GetFixits(), NewCompileDiagnosticFixIt),
 	}
 }
 
@@ -1054,6 +1344,26 @@ func NewCompileDiagnosticNote(cdn *rpc.CompileDiagnosticNote) *CompileDiagnostic
 	}
 }
 
+type CompileDiagnosticFixIt struct {
+	File        string `json:"file,omitempty"`
+	StartLine   int64  `json:"start_line,omitempty"`
+	StartColumn int64  `json:"start_column,omitempty"`
+	EndLine     int64  `json:"end_line,omitempty"`
+	EndColumn   int64  `json:"end_column,omitempty"`
+	Replacement string `json:"replacement"`
+}
+
+func NewCompileDiagnosticFixIt(cdf *rpc.CompileDiagnosticFixIt) *CompileDiagnosticFixIt {
+	return &CompileDiagnosticFixIt{
+		File:        cdf.GetFile(),
+		StartLine:   cdf.GetStartLine(),
+		StartColumn: cdf.GetStartColumn(),
+		EndLine:     cdf.GetEndLine(),
+		EndColumn:   cdf.GetEndColumn(),
+		Replacement: cdf.GetReplacement(),
+	}
+}
+
 type IsDebugSupportedResponse struct {
 	DebuggingSupported bool   `json:"debugging_supported"`
 	DebugFQBN          string `json:"debug_fqbn,omitempty"`
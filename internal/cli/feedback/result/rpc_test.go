@@ -146,6 +146,14 @@ func TestAllFieldAreMapped(t *testing.T) {
 	programmerResult := result.NewProgrammer(programmerRpc)
 	mustContainsAllPropertyOfRpcStruct(t, programmerRpc, programmerResult)
 
+	programmerDetailsResponseRpc := &rpc.ProgrammerDetailsResponse{}
+	programmerDetailsResponseResult := result.NewProgrammerDetailsResponse(programmerDetailsResponseRpc)
+	mustContainsAllPropertyOfRpcStruct(t, programmerDetailsResponseRpc, programmerDetailsResponseResult)
+
+	programmerOperationsRpc := &rpc.ProgrammerOperations{}
+	programmerOperationsResult := result.NewProgrammerOperations(programmerOperationsRpc)
+	mustContainsAllPropertyOfRpcStruct(t, programmerOperationsRpc, programmerOperationsResult)
+
 	boardIdentificationPropertiesRpc := &rpc.BoardIdentificationProperties{}
 	boardIdentificationPropertiesResult := result.NewBoardIndentificationProperty(boardIdentificationPropertiesRpc)
 	mustContainsAllPropertyOfRpcStruct(t, boardIdentificationPropertiesRpc, boardIdentificationPropertiesResult)
@@ -158,6 +166,10 @@ func TestAllFieldAreMapped(t *testing.T) {
 	boardListItemResult := result.NewBoardListItem(boardListItemRpc)
 	mustContainsAllPropertyOfRpcStruct(t, boardListItemRpc, boardListItemResult)
 
+	boardCapabilitiesRpc := &rpc.BoardCapabilities{}
+	boardCapabilitiesResult := result.NewBoardCapabilities(boardCapabilitiesRpc)
+	mustContainsAllPropertyOfRpcStruct(t, boardCapabilitiesRpc, boardCapabilitiesResult)
+
 	platformRpc := &rpc.Platform{}
 	platformResult := result.NewPlatform(platformRpc)
 	mustContainsAllPropertyOfRpcStruct(t, platformRpc, platformResult)
@@ -206,6 +218,46 @@ func TestAllFieldAreMapped(t *testing.T) {
 	boardListWatchResponseResult := result.NewBoardListWatchResponse(boardListWatchResponseRpc)
 	mustContainsAllPropertyOfRpcStruct(t, boardListWatchResponseRpc, boardListWatchResponseResult)
 
+	conditionalBranchRpc := &rpc.ConditionalBranch{}
+	conditionalBranchResult := result.NewConditionalBranch(conditionalBranchRpc)
+	mustContainsAllPropertyOfRpcStruct(t, conditionalBranchRpc, conditionalBranchResult)
+
+	boardSuggestionRpc := &rpc.BoardSuggestion{}
+	boardSuggestionResult := result.NewBoardSuggestion(boardSuggestionRpc)
+	mustContainsAllPropertyOfRpcStruct(t, boardSuggestionRpc, boardSuggestionResult)
+
+	headerDeclarationsRpc := &rpc.HeaderDeclarations{}
+	headerDeclarationsResult := result.NewHeaderDeclarations(headerDeclarationsRpc)
+	mustContainsAllPropertyOfRpcStruct(t, headerDeclarationsRpc, headerDeclarationsResult)
+
+	includeIssueRpc := &rpc.IncludeIssue{}
+	includeIssueResult := result.NewIncludeIssue(includeIssueRpc)
+	mustContainsAllPropertyOfRpcStruct(t, includeIssueRpc, includeIssueResult)
+
+	memoryMapReportRpc := &rpc.MemoryMapReport{}
+	memoryMapReportResult := result.NewMemoryMapReport(memoryMapReportRpc)
+	mustContainsAllPropertyOfRpcStruct(t, memoryMapReportRpc, memoryMapReportResult)
+
+	compilerExplorerSessionRpc := &rpc.CompilerExplorerSession{}
+	compilerExplorerSessionResult := result.NewCompilerExplorerSession(compilerExplorerSessionRpc)
+	mustContainsAllPropertyOfRpcStruct(t, compilerExplorerSessionRpc, compilerExplorerSessionResult)
+
+	buildLogRpc := &rpc.BuildLog{}
+	buildLogResult := result.NewBuildLog(buildLogRpc)
+	mustContainsAllPropertyOfRpcStruct(t, buildLogRpc, buildLogResult)
+
+	memoryRegionUsageRpc := &rpc.MemoryRegionUsage{}
+	memoryRegionUsageResult := result.NewMemoryRegionUsage(memoryRegionUsageRpc)
+	mustContainsAllPropertyOfRpcStruct(t, memoryRegionUsageRpc, memoryRegionUsageResult)
+
+	memorySectionUsageRpc := &rpc.MemorySectionUsage{}
+	memorySectionUsageResult := result.NewMemorySectionUsage(memorySectionUsageRpc)
+	mustContainsAllPropertyOfRpcStruct(t, memorySectionUsageRpc, memorySectionUsageResult)
+
+	memoryContributorRpc := &rpc.MemoryContributor{}
+	memoryContributorResult := result.NewMemoryContributor(memoryContributorRpc)
+	mustContainsAllPropertyOfRpcStruct(t, memoryContributorRpc, memoryContributorResult)
+
 	compileDiagnosticRpc := &rpc.CompileDiagnostic{}
 	compileDiagnosticResult := result.NewCompileDiagnostic(compileDiagnosticRpc)
 	mustContainsAllPropertyOfRpcStruct(t, compileDiagnosticRpc, compileDiagnosticResult)
@@ -218,6 +270,10 @@ func TestAllFieldAreMapped(t *testing.T) {
 	compileDiagnosticNoteResult := result.NewCompileDiagnosticNote(compileDiagnosticNoteRpc)
 	mustContainsAllPropertyOfRpcStruct(t, compileDiagnosticNoteRpc, compileDiagnosticNoteResult)
 
+	compileDiagnosticFixItRpc := &rpc.CompileDiagnosticFixIt{}
+	compileDiagnosticFixItResult := result.NewCompileDiagnosticFixIt(compileDiagnosticFixItRpc)
+	mustContainsAllPropertyOfRpcStruct(t, compileDiagnosticFixItRpc, compileDiagnosticFixItResult)
+
 	isDebugSupportedResponseRpc := &rpc.IsDebugSupportedResponse{}
 	isDebugSupportedResponseResult := result.NewIsDebugSupportedResponse(isDebugSupportedResponseRpc)
 	mustContainsAllPropertyOfRpcStruct(t, isDebugSupportedResponseRpc, isDebugSupportedResponseResult)
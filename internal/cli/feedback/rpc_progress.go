@@ -19,7 +19,6 @@ import (
 	"sync"
 
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
-	"github.com/cmaglie/pb"
 )
 
 // ProgressBar returns a DownloadProgressCB that prints a progress bar.
@@ -43,41 +42,68 @@ func TaskProgress() rpc.TaskProgressCB {
 }
 
 // NewDownloadProgressBarCB creates a progress bar callback that outputs a progress
-// bar on the terminal
+// bar on the terminal. Each concurrent download must use its own callback, the
+// bars of the concurrent downloads are drawn on separate lines. When the output
+// is not a terminal only the final message of each download is printed.
 func NewDownloadProgressBarCB() func(*rpc.DownloadProgress) {
 	var mux sync.Mutex
-	var bar *pb.ProgressBar
+	var bar *progressBar
 	var label string
-	started := false
 	return func(curr *rpc.DownloadProgress) {
 		mux.Lock()
 		defer mux.Unlock()
 
 		if start := curr.GetStart(); start != nil {
 			label = start.GetLabel()
-			bar = pb.New(0)
-			bar.Prefix(label)
-			bar.SetUnits(pb.U_BYTES)
 		}
 		if update := curr.GetUpdate(); update != nil {
-			bar.SetTotal64(update.GetTotalSize())
-			if !started {
-				bar.Start()
-				started = true
+			if bar == nil && progressBarsEnabled() {
+				bar = progress.add(label, true)
+			}
+			if bar != nil {
+				progress.update(bar, update.GetDownloaded(), update.GetTotalSize())
 			}
-			bar.Set64(update.GetDownloaded())
 		}
 		if end := curr.GetEnd(); end != nil {
 			msg := end.GetMessage()
 			if end.GetSuccess() && msg == "" {
 				msg = tr("downloaded")
+			} else if !end.GetSuccess() {
+				msg = theme.Error.Sprint(msg)
 			}
-			if started {
-				bar.FinishPrintOver(label + " " + msg)
+			msg = theme.Progress.Sprint(label) + " " + msg
+			if bar != nil {
+				progress.finish(bar, msg)
+				bar = nil
 			} else {
-				Print(label + " " + msg)
+				Print(msg)
+			}
+		}
+	}
+}
+
+// NewTaskProgressBarCB returns a TaskProgressCB that draws a progress bar with
+// the given label for the completion percent of a task, like a compilation.
+// Each concurrent task must use its own callback, a TaskProgress with the
+// Completed flag removes the bar also if the task failed. Nothing is printed
+// when the output is not a terminal.
+func NewTaskProgressBarCB(label string) rpc.TaskProgressCB {
+	var mux sync.Mutex
+	var bar *progressBar
+	return func(curr *rpc.TaskProgress) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		if bar == nil {
+			if curr.GetCompleted() || !progressBarsEnabled() {
+				return
 			}
-			started = false
+			bar = progress.add(label, false)
+		}
+		progress.update(bar, int64(curr.GetPercent()), 100)
+		if curr.GetCompleted() {
+			progress.finish(bar, "")
+			bar = nil
 		}
 	}
 }
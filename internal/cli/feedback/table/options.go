@@ -0,0 +1,200 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/i18n"
+)
+
+var tr = i18n.Tr
+
+// Format is the format used to render the tables
+type Format int
+
+const (
+	// Text renders the tables with aligned columns, suitable for humans
+	Text Format = iota
+	// TSV renders the tables as tab separated values
+	TSV
+	// CSV renders the tables as comma separated values
+	CSV
+)
+
+var formats = map[string]Format{
+	"text": Text,
+	"tsv":  TSV,
+	"csv":  CSV,
+}
+
+// ParseFormat parses a string and returns the corresponding Format.
+// The boolean returned is true if the string was a valid Format.
+func ParseFormat(in string) (Format, bool) {
+	format, found := formats[strings.ToLower(in)]
+	return format, found
+}
+
+var (
+	format   Format
+	columns  []string
+	noHeader bool
+)
+
+// SetFormat sets the format used to render all the tables
+func SetFormat(f Format) {
+	format = f
+}
+
+// SetColumns selects the columns rendered in all the tables, in the given
+// order. A column is identified by its header, case insensitive, or by its
+// position starting from 1. All the columns are rendered if none is given.
+func SetColumns(c []string) {
+	columns = c
+}
+
+// SetNoHeader suppresses the header of all the tables
+func SetNoHeader(b bool) {
+	noHeader = b
+}
+
+// project returns a copy of the table with the selected columns and header.
+func (t *Table) project() (*Table, error) {
+	res := &Table{
+		hasHeader:        t.hasHeader && !noHeader,
+		columnsCount:     t.columnsCount,
+		columnsWidthMode: t.columnsWidthMode,
+		rows:             t.rows,
+	}
+	if noHeader && t.hasHeader {
+		res.rows = t.rows[1:]
+	}
+	if len(columns) > 0 {
+		if err := res.selectColumns(t); err != nil {
+			return nil, err
+		}
+	}
+	if res.hasHeader {
+		res.rows = append([]*tableRow{styleHeader(res.rows[0])}, res.rows[1:]...)
+	}
+	return res, nil
+}
+
+// styleHeader returns a copy of the header row with the theme color applied
+// to the cells that have no specific color.
+func styleHeader(header *tableRow) *tableRow {
+	headerColor := feedback.GetTheme().Header
+	cells := make([]Cell, len(header.cells))
+	for i, cell := range header.cells {
+		if cell.raw == cell.clean && cell.clean != "" {
+			cell.raw = headerColor.Sprint(cell.clean)
+		}
+		cells[i] = cell
+	}
+	return &tableRow{cells: cells}
+}
+
+// selectColumns replaces the columns of the table with the selected columns
+// of the source table.
+func (t *Table) selectColumns(source *Table) error {
+	indexes := []int{}
+	for _, column := range columns {
+		index, err := source.columnIndex(column)
+		if err != nil {
+			return err
+		}
+		indexes = append(indexes, index)
+	}
+	t.columnsCount = len(indexes)
+	t.columnsWidthMode = make([]ColumnWidthMode, len(indexes))
+	for i, index := range indexes {
+		if index < len(source.columnsWidthMode) {
+			t.columnsWidthMode[i] = source.columnsWidthMode[index]
+		}
+	}
+	rows := make([]*tableRow, len(t.rows))
+	for r, row := range t.rows {
+		cells := make([]Cell, len(indexes))
+		for i, index := range indexes {
+			if index < len(row.cells) {
+				cells[i] = row.cells[index]
+			}
+		}
+		rows[r] = &tableRow{cells: cells}
+	}
+	t.rows = rows
+	return nil
+}
+
+// columnIndex returns the index of the column identified by its header or
+// by its position.
+func (t *Table) columnIndex(column string) (int, error) {
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 || n > t.columnsCount {
+			return 0, fmt.Errorf(tr("invalid column %[1]d, the table has %[2]d columns", n, t.columnsCount))
+		}
+		return n - 1, nil
+	}
+	if !t.hasHeader {
+		return 0, fmt.Errorf(tr("unknown column %s, the table has no header: select the columns by position", column))
+	}
+	available := []string{}
+	for i, cell := range t.rows[0].cells {
+		if strings.EqualFold(strings.TrimSpace(cell.clean), strings.TrimSpace(column)) {
+			return i, nil
+		}
+		if cell.clean != "" {
+			available = append(available, cell.clean)
+		}
+	}
+	return 0, fmt.Errorf(tr("unknown column %[1]s, available columns are: %[2]s", column, strings.Join(available, ", ")))
+}
+
+// renderSeparated renders the table as separated values, without colors.
+func (t *Table) renderSeparated() string {
+	records := make([][]string, len(t.rows))
+	for r, row := range t.rows {
+		record := make([]string, t.columnsCount)
+		for i, cell := range row.cells {
+			record[i] = cell.clean
+		}
+		records[r] = record
+	}
+
+	if format == CSV {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		// Errors are not possible while writing to a bytes.Buffer
+		_ = w.WriteAll(records)
+		return buf.String()
+	}
+
+	// Tabs and newlines can not be escaped in TSV, replace them with spaces
+	sanitize := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	res := strings.Builder{}
+	for _, record := range records {
+		for i, value := range record {
+			record[i] = sanitize.Replace(value)
+		}
+		res.WriteString(strings.Join(record, "\t") + "\n")
+	}
+	return res.String()
+}
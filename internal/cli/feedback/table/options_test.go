@@ -0,0 +1,63 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderOptions(t *testing.T) {
+	defer func() {
+		SetFormat(Text)
+		SetColumns(nil)
+		SetNoHeader(false)
+	}()
+	newTable := func() *Table {
+		t := New()
+		t.SetHeader("Name", "Version", "Description")
+		t.AddRow("Servo", "1.2.1", "Controls servo motors")
+		t.AddRow("WiFi", "1.2.7", "Connects to\ta network, \"quickly\"")
+		return t
+	}
+
+	SetColumns([]string{"version", "1"})
+	require.Equal(t, "Version Name\n1.2.1   Servo\n1.2.7   WiFi\n", newTable().Render())
+
+	SetNoHeader(true)
+	require.Equal(t, "1.2.1 Servo\n1.2.7 WiFi\n", newTable().Render())
+
+	SetColumns(nil)
+	SetFormat(TSV)
+	require.Equal(t, "Servo\t1.2.1\tControls servo motors\nWiFi\t1.2.7\tConnects to a network, \"quickly\"\n", newTable().Render())
+
+	SetNoHeader(false)
+	SetFormat(CSV)
+	require.Equal(t, "Name,Version,Description\nServo,1.2.1,Controls servo motors\nWiFi,1.2.7,\"Connects to\ta network, \"\"quickly\"\"\"\n", newTable().Render())
+
+	tbl := newTable()
+	_, err := tbl.columnIndex("License")
+	require.EqualError(t, err, "unknown column License, available columns are: Name, Version, Description")
+	_, err = tbl.columnIndex("4")
+	require.EqualError(t, err, "invalid column 4, the table has 3 columns")
+
+	format, found := ParseFormat("CSV")
+	require.True(t, found)
+	require.Equal(t, CSV, format)
+	_, found = ParseFormat("xml")
+	require.False(t, found)
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"math"
 	"strings"
+
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
 )
 
 // ColumnWidthMode is used to configure columns type
@@ -77,6 +79,14 @@ func (t *Table) AddRow(columns ...interface{}) {
 
 // Render FIXMEDOC
 func (t *Table) Render() string {
+	t, err := t.project()
+	if err != nil {
+		feedback.Fatal(err.Error(), feedback.ErrBadArgument)
+	}
+	if format != Text {
+		return t.renderSeparated()
+	}
+
 	// find max width for each row
 	average := make([]int, t.columnsCount)
 	widths := make([]int, t.columnsCount)
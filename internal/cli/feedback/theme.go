@@ -0,0 +1,193 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package feedback
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Theme contains the colors used to highlight the text output. A color
+// without attributes leaves the text unchanged.
+type Theme struct {
+	// Title is used for the titles and the headers of the sections
+	Title *color.Color
+	// Accent is used for the names of the relevant items, like libraries and platforms
+	Accent *color.Color
+	// Muted is used for the secondary information, like paths
+	Muted *color.Color
+	// Success is used for the successful results
+	Success *color.Color
+	// Warning is used for the warnings
+	Warning *color.Color
+	// Error is used for the errors and the failed results
+	Error *color.Color
+	// Header is used for the headers of the tables that have no specific color
+	Header *color.Color
+	// Progress is used for the labels of the progress bars
+	Progress *color.Color
+}
+
+// DefaultThemeColors are the colors of the default theme, for each theme role.
+var DefaultThemeColors = map[string]string{
+	"title":    "hi-green",
+	"accent":   "hi-yellow",
+	"muted":    "hi-black",
+	"success":  "hi-green",
+	"warning":  "hi-yellow",
+	"error":    "hi-red",
+	"header":   "none",
+	"progress": "none",
+}
+
+var theme = mustParseTheme(nil)
+
+// GetTheme returns the theme used to highlight the text output
+func GetTheme() *Theme {
+	return theme
+}
+
+// SetTheme sets the theme used to highlight the text output
+func SetTheme(t *Theme) {
+	theme = t
+}
+
+func mustParseTheme(colors map[string]string) *Theme {
+	t, err := ParseTheme(colors)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ParseTheme returns a Theme with the given colors for each theme role, the
+// roles not given use the colors of the default theme. See ParseColor for the
+// syntax of the colors.
+func ParseTheme(colors map[string]string) (*Theme, error) {
+	for role := range colors {
+		if _, ok := DefaultThemeColors[role]; !ok {
+			roles := []string{}
+			for r := range DefaultThemeColors {
+				roles = append(roles, r)
+			}
+			sort.Strings(roles)
+			return nil, fmt.Errorf(tr("unknown theme color %[1]s, valid colors are: %[2]s", role, strings.Join(roles, ", ")))
+		}
+	}
+	get := func(role string) (*color.Color, error) {
+		spec, ok := colors[role]
+		if !ok {
+			spec = DefaultThemeColors[role]
+		}
+		c, err := ParseColor(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", role, err)
+		}
+		return c, nil
+	}
+	t := &Theme{}
+	for role, field := range map[string]**color.Color{
+		"title":    &t.Title,
+		"accent":   &t.Accent,
+		"muted":    &t.Muted,
+		"success":  &t.Success,
+		"warning":  &t.Warning,
+		"error":    &t.Error,
+		"header":   &t.Header,
+		"progress": &t.Progress,
+	} {
+		c, err := get(role)
+		if err != nil {
+			return nil, err
+		}
+		*field = c
+	}
+	return t, nil
+}
+
+var colorNames = map[string]color.Attribute{
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+}
+
+var styleNames = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+}
+
+// ParseColor parses a color made of a list of attributes separated by spaces
+// or commas, e.g. "bold hi-yellow". The attributes can be a color (black, red,
+// green, yellow, blue, magenta, cyan, white) optionally prefixed by "hi-" for
+// the high intensity variant and by "bg-" for the background, or a style
+// (bold, faint, italic, underline). The color "none" leaves the text unchanged.
+func ParseColor(spec string) (*color.Color, error) {
+	attrs := []color.Attribute{}
+	for _, attr := range strings.FieldsFunc(strings.ToLower(spec), func(r rune) bool { return r == ' ' || r == ',' }) {
+		if attr == "none" {
+			continue
+		}
+		if style, ok := styleNames[attr]; ok {
+			attrs = append(attrs, style)
+			continue
+		}
+		name, background := strings.CutPrefix(attr, "bg-")
+		name, hiIntensity := strings.CutPrefix(name, "hi-")
+		fg, ok := colorNames[name]
+		if !ok {
+			return nil, fmt.Errorf(tr("invalid color attribute: %s", attr))
+		}
+		if hiIntensity {
+			fg += color.FgHiBlack - color.FgBlack
+		}
+		if background {
+			fg += color.BgBlack - color.FgBlack
+		}
+		attrs = append(attrs, fg)
+	}
+	c := color.New(attrs...)
+	if len(attrs) == 0 {
+		// Avoid the empty escape sequences around the text
+		c.DisableColor()
+	}
+	return c, nil
+}
+
+// ColorsEnabled returns true if the text output should be colored, following
+// the NO_COLOR (https://no-color.org/) and the CLICOLOR/CLICOLOR_FORCE
+// (https://bixense.com/clicolors/) conventions. NO_COLOR and the noColor
+// setting have the precedence, then CLICOLOR_FORCE enables the colors and
+// CLICOLOR=0 disables them. Colors are enabled by default.
+func ColorsEnabled(noColor bool) bool {
+	if os.Getenv("NO_COLOR") != "" || noColor {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	return os.Getenv("CLICOLOR") != "0"
+}
@@ -0,0 +1,86 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package feedback
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColor(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	c, err := ParseColor("bold, hi-yellow")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(c.Sprint("text"), "\x1b[1;93mtext"))
+
+	c, err = ParseColor("bg-red white")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(c.Sprint("text"), "\x1b[41;37mtext"))
+
+	c, err = ParseColor("bg-hi-blue")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(c.Sprint("text"), "\x1b[104mtext"))
+
+	for _, spec := range []string{"", "none"} {
+		c, err = ParseColor(spec)
+		require.NoError(t, err)
+		require.Equal(t, "text", c.Sprint("text"))
+	}
+
+	_, err = ParseColor("bold purple")
+	require.EqualError(t, err, "invalid color attribute: purple")
+}
+
+func TestParseTheme(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	theme, err := ParseTheme(map[string]string{"accent": "cyan"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(theme.Accent.Sprint("text"), "\x1b[36mtext"))
+	require.True(t, strings.HasPrefix(theme.Success.Sprint("text"), "\x1b[92mtext"))
+	require.Equal(t, "text", theme.Header.Sprint("text"))
+
+	_, err = ParseTheme(map[string]string{"error": "blinking"})
+	require.EqualError(t, err, "error: invalid color attribute: blinking")
+
+	_, err = ParseTheme(map[string]string{"info": "blue"})
+	require.EqualError(t, err, "unknown theme color info, valid colors are: accent, error, header, muted, progress, success, title, warning")
+}
+
+func TestColorsEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	require.True(t, ColorsEnabled(false))
+	require.False(t, ColorsEnabled(true))
+
+	t.Setenv("CLICOLOR", "0")
+	require.False(t, ColorsEnabled(false))
+	t.Setenv("CLICOLOR_FORCE", "1")
+	require.True(t, ColorsEnabled(false))
+	require.False(t, ColorsEnabled(true))
+
+	t.Setenv("NO_COLOR", "1")
+	require.False(t, ColorsEnabled(false))
+}
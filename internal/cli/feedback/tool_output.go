@@ -0,0 +1,47 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package feedback
+
+import (
+	"io"
+	"regexp"
+)
+
+// toolPrefix matches the "[tool phase] " prefix of the lines printed by the
+// external tools in verbose mode.
+var toolPrefix = regexp.MustCompile(`(?m)^\[[^\]\n]+\] `)
+
+// NewToolOutputWriter returns an io.Writer that highlights, with the muted
+// color of the theme, the prefix attributing each line of the given output
+// to the external tool that printed it. The writes must contain whole lines.
+func NewToolOutputWriter(w io.Writer) io.Writer {
+	return &toolOutputWriter{w: w}
+}
+
+type toolOutputWriter struct {
+	w io.Writer
+}
+
+func (t *toolOutputWriter) Write(data []byte) (int, error) {
+	muted := GetTheme().Muted
+	colored := toolPrefix.ReplaceAllFunc(data, func(prefix []byte) []byte {
+		return []byte(muted.Sprint(string(prefix)))
+	})
+	if _, err := t.w.Write(colored); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
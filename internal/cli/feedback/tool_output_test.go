@@ -0,0 +1,38 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package feedback
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolOutputWriter(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	out := &bytes.Buffer{}
+	w := NewToolOutputWriter(out)
+	data := []byte("[avr-gcc compile Blink.ino.cpp] warning: unused\n[avr-ar archive core.a] done\nplain [line]\n")
+	n, err := w.Write(data)
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	require.Equal(t, "\x1b[90m[avr-gcc compile Blink.ino.cpp] \x1b[0mwarning: unused\n\x1b[90m[avr-ar archive core.a] \x1b[0mdone\nplain [line]\n", out.String())
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/arduino/arduino-cli/internal/cli/feedback/result"
 	"github.com/arduino/arduino-cli/internal/cli/instance"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
-	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -110,9 +109,9 @@ func (dr checkDepResult) String() string {
 
 func outputDep(dep *result.LibraryDependencyStatus) string {
 	res := ""
-	green := color.New(color.FgGreen)
-	red := color.New(color.FgRed)
-	yellow := color.New(color.FgYellow)
+	green := feedback.GetTheme().Success
+	red := feedback.GetTheme().Error
+	yellow := feedback.GetTheme().Warning
 	if dep.VersionInstalled == "" {
 		res += tr("%s must be installed.",
 			red.Sprintf("✕ %s %s", dep.Name, dep.VersionRequired))
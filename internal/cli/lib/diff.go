@@ -0,0 +1,147 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/lib"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initDiffCommand() *cobra.Command {
+	diffCommand := &cobra.Command{
+		Use:   fmt.Sprintf("diff %s %s %s", tr("LIBRARY_NAME"), tr("FROM_VERSION"), tr("TO_VERSION")),
+		Short: tr("Shows the API changes between two versions of a library."),
+		Long: tr("Downloads two versions of a library and compares the functions, classes and enums declared in their headers, " +
+			"to assess the changes that may break the sketches before upgrading the library."),
+		Example: "  " + os.Args[0] + " lib diff Servo 1.1.8 1.2.1",
+		Args:    cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			runDiffCommand(args[0], args[1], args[2])
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return arguments.GetInstallableLibs(), cobra.ShellCompDirectiveDefault
+		},
+	}
+	return diffCommand
+}
+
+func runDiffCommand(name, fromVersion, toVersion string) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli lib diff`")
+
+	ref, err := ParseLibraryReferenceArgAndAdjustCase(inst, name)
+	if err != nil {
+		feedback.Fatal(tr("Invalid argument passed: %v", err), feedback.ErrBadArgument)
+	}
+
+	res, err := lib.LibraryDiff(context.Background(), &rpc.LibraryDiffRequest{
+		Instance:    inst,
+		Name:        ref.Name,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+	}, feedback.ProgressBar())
+	if err != nil {
+		feedback.Fatal(tr("Error comparing the versions of %[1]s: %[2]v", ref.Name, err), feedback.ErrGeneric)
+	}
+
+	diff := &libraryDiffResult{
+		Library:     ref.Name,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     []*libraryAPIChange{},
+	}
+	for _, c := range res.GetChanges() {
+		diff.Changes = append(diff.Changes, &libraryAPIChange{
+			Kind:           libraryAPIChangeKinds[c.GetKind()],
+			Header:         c.GetHeader(),
+			Symbol:         c.GetSymbol(),
+			SymbolKind:     c.GetSymbolKind(),
+			OldDeclaration: c.GetOldDeclaration(),
+			NewDeclaration: c.GetNewDeclaration(),
+		})
+	}
+	feedback.PrintResult(diff)
+}
+
+var libraryAPIChangeKinds = map[rpc.LibraryAPIChange_Kind]string{
+	rpc.LibraryAPIChange_KIND_ADDED:   "added",
+	rpc.LibraryAPIChange_KIND_REMOVED: "removed",
+	rpc.LibraryAPIChange_KIND_CHANGED: "changed",
+}
+
+type libraryAPIChange struct {
+	Kind           string `json:"kind"`
+	Header         string `json:"header"`
+	Symbol         string `json:"symbol"`
+	SymbolKind     string `json:"symbol_kind"`
+	OldDeclaration string `json:"old_declaration,omitempty"`
+	NewDeclaration string `json:"new_declaration,omitempty"`
+}
+
+type libraryDiffResult struct {
+	Library     string              `json:"library"`
+	FromVersion string              `json:"from_version"`
+	ToVersion   string              `json:"to_version"`
+	Changes     []*libraryAPIChange `json:"changes"`
+}
+
+// Data implements feedback.Result.
+func (r *libraryDiffResult) Data() interface{} {
+	return r
+}
+
+// String implements feedback.Result.
+func (r *libraryDiffResult) String() string {
+	if len(r.Changes) == 0 {
+		return tr("No API changes between %[1]s %[2]s and %[3]s", r.Library, r.FromVersion, r.ToVersion)
+	}
+
+	theme := feedback.GetTheme()
+	t := table.New()
+	t.SetHeader(tr("Change"), tr("Header"), tr("Declaration"))
+	removed := 0
+	for _, c := range r.Changes {
+		switch c.Kind {
+		case "added":
+			t.AddRow(table.NewCell("+", theme.Success), c.Header, c.NewDeclaration)
+		case "removed":
+			removed++
+			t.AddRow(table.NewCell("-", theme.Error), c.Header, c.OldDeclaration)
+		case "changed":
+			removed++
+			t.AddRow(table.NewCell("-", theme.Warning), c.Header, c.OldDeclaration)
+			t.AddRow(table.NewCell("+", theme.Warning), "", c.NewDeclaration)
+		}
+	}
+	res := t.Render()
+	if removed > 0 {
+		res += "\n" + theme.Warning.Sprint(tr("%d declarations have been removed or changed, the sketches using them may need to be updated.", removed))
+	}
+	return res
+}
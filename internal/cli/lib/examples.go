@@ -29,7 +29,6 @@ import (
 	"github.com/arduino/arduino-cli/internal/cli/instance"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
 	"github.com/arduino/go-paths-helper"
-	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -118,14 +117,14 @@ func (ir libraryExamplesResult) String() string {
 		} else if lib.Library.Location != result.LibraryLocationUser {
 			name += " (" + string(lib.Library.Location) + ")"
 		}
-		r := tr("Examples for library %s", color.GreenString("%s", name)) + "\n"
+		r := tr("Examples for library %s", feedback.GetTheme().Accent.Sprint(name)) + "\n"
 		sort.Slice(lib.Examples, func(i, j int) bool {
 			return strings.ToLower(lib.Examples[i]) < strings.ToLower(lib.Examples[j])
 		})
 		for _, example := range lib.Examples {
 			examplePath := paths.New(example)
 			r += fmt.Sprintf("  - %s%s\n",
-				color.New(color.Faint).Sprintf("%s%c", examplePath.Parent(), os.PathSeparator),
+				feedback.GetTheme().Muted.Sprintf("%s%c", examplePath.Parent(), os.PathSeparator),
 				examplePath.Base())
 		}
 		res = append(res, r)
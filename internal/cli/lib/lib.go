@@ -35,14 +35,17 @@ func NewCommand() *cobra.Command {
 			"  " + os.Args[0] + " lib update-index",
 	}
 
+	libCommand.AddCommand(initDiffCommand())
 	libCommand.AddCommand(initDownloadCommand())
 	libCommand.AddCommand(initInstallCommand())
 	libCommand.AddCommand(initListCommand())
+	libCommand.AddCommand(initNewCommand())
 	libCommand.AddCommand(initExamplesCommand())
 	libCommand.AddCommand(initSearchCommand())
 	libCommand.AddCommand(initUninstallCommand())
 	libCommand.AddCommand(initUpgradeCommand())
 	libCommand.AddCommand(initUpdateIndexCommand())
 	libCommand.AddCommand(initDepsCommand())
+	libCommand.AddCommand(initTestExamplesCommand())
 	return libCommand
 }
@@ -0,0 +1,110 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/lib"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initNewCommand() *cobra.Command {
+	var (
+		layout     string
+		headerOnly bool
+		author     string
+		overwrite  bool
+	)
+	newCommand := &cobra.Command{
+		Use:   fmt.Sprintf("new %s", tr("LIBRARY_NAME")),
+		Short: tr("Creates a new library."),
+		Long: tr("Creates the skeleton of a new library in the given directory: the library.properties and keywords.txt files, " +
+			"the sources with a sample class, an example, a host test and a CI workflow that lints the library, " +
+			"compiles the examples and runs the test."),
+		Example: "" +
+			"  " + os.Args[0] + " lib new MyLib\n" +
+			"  " + os.Args[0] + " lib new ~/Arduino/libraries/MyLib --header-only --author \"Jane Doe <jane@example.com>\"",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runNewCommand(args[0], layout, headerOnly, author, overwrite)
+		},
+	}
+	newCommand.Flags().StringVar(&layout, "layout", "recursive", tr("Layout of the library, can be %s: recursive puts the sources in the src folder, flat in the library root.", "recursive, flat"))
+	newCommand.Flags().BoolVar(&headerOnly, "header-only", false, tr("Creates a header-only library, without .cpp files."))
+	newCommand.Flags().StringVar(&author, "author", "", tr("The author and maintainer of the library."))
+	newCommand.Flags().BoolVarP(&overwrite, "overwrite", "f", false, tr("Overwrites an existing library."))
+	return newCommand
+}
+
+func runNewCommand(name, layout string, headerOnly bool, author string, overwrite bool) {
+	logrus.Info("Executing `arduino-cli lib new`")
+
+	if layout != "recursive" && layout != "flat" {
+		feedback.Fatal(tr("Invalid layout: %s", layout), feedback.ErrBadArgument)
+	}
+
+	libraryDir := ""
+	if name = strings.TrimRight(name, "/\\"); name != "" {
+		// `paths.New` returns nil with an empty string, the empty name is
+		// reported by the "new" command with the same logic in grpc and cli flows
+		libraryPath, err := paths.New(name).Abs()
+		if err != nil {
+			feedback.Fatal(tr("Error creating library: %v", err), feedback.ErrGeneric)
+		}
+		libraryDir = libraryPath.Parent().String()
+		name = libraryPath.Base()
+	}
+
+	res, err := lib.LibraryNew(context.Background(), &rpc.LibraryNewRequest{
+		Name:       name,
+		Dir:        libraryDir,
+		FlatLayout: layout == "flat",
+		HeaderOnly: headerOnly,
+		Author:     author,
+		Overwrite:  overwrite,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error creating library: %v", err), feedback.ErrGeneric)
+	}
+	feedback.PrintResult(&libraryNewResult{LibraryPath: res.GetLibraryDir(), Files: res.GetFiles()})
+}
+
+type libraryNewResult struct {
+	LibraryPath string   `json:"library_path"`
+	Files       []string `json:"files"`
+}
+
+// Data implements feedback.Result.
+func (r *libraryNewResult) Data() interface{} {
+	return r
+}
+
+// String implements feedback.Result.
+func (r *libraryNewResult) String() string {
+	res := tr("Library created in: %s", r.LibraryPath)
+	for _, file := range r.Files {
+		res += "\n  " + file
+	}
+	return res
+}
@@ -0,0 +1,294 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/compile"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initTestExamplesCommand() *cobra.Command {
+	var (
+		fqbns           []string
+		jobs            int
+		buildProperties []string
+		junitFile       string
+	)
+	testExamplesCommand := &cobra.Command{
+		Use:   fmt.Sprintf("test-examples [%s]", tr("LIBRARY_PATH")),
+		Short: tr("Compiles all the examples of a library for the given boards."),
+		Long: tr("Compiles each example of the library in the given directory, or in the current directory, for each of the given boards, " +
+			"using the library from that directory instead of the installed one. The compilations run in parallel and keep their " +
+			"build directories, so that running the command again only rebuilds what changed. " +
+			"The command fails if any example fails to compile."),
+		Example: "" +
+			"  " + os.Args[0] + " lib test-examples --fqbn arduino:avr:uno --fqbn arduino:samd:mkr1000\n" +
+			"  " + os.Args[0] + " lib test-examples ~/Arduino/libraries/MyLib -b arduino:avr:uno --junit report.xml",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			libPath := "."
+			if len(args) > 0 {
+				libPath = args[0]
+			}
+			opts := &testExamplesOptions{
+				fqbns:           fqbns,
+				jobs:            jobs,
+				buildProperties: buildProperties,
+			}
+			if junitFile != "" {
+				opts.junitFile = paths.New(junitFile)
+			}
+			runTestExamplesCommand(paths.New(libPath), opts)
+		},
+	}
+	testExamplesCommand.Flags().StringSliceVarP(&fqbns, "fqbn", "b", nil, tr("Fully Qualified Board Name of a board to compile the examples for, can be repeated."))
+	testExamplesCommand.Flags().IntVarP(&jobs, "jobs", "j", 0, tr("Max number of compilations running in parallel, defaults to the number of CPUs."))
+	testExamplesCommand.Flags().StringArrayVar(&buildProperties, "build-property", nil, tr("Override a build property with a custom value. Can be used multiple times for multiple properties."))
+	testExamplesCommand.Flags().StringVar(&junitFile, "junit", "", tr("Save a JUnit XML report of the compilations in the given file."))
+	testExamplesCommand.MarkFlagRequired("fqbn")
+	return testExamplesCommand
+}
+
+type testExamplesOptions struct {
+	fqbns           []string
+	jobs            int
+	buildProperties []string
+	junitFile       *paths.Path
+}
+
+func runTestExamplesCommand(libPath *paths.Path, opts *testExamplesOptions) {
+	logrus.Info("Executing `arduino-cli lib test-examples`")
+
+	libPath, err := libPath.Abs()
+	if err != nil {
+		feedback.Fatal(tr("Invalid library path: %v", err), feedback.ErrBadArgument)
+	}
+	library, err := libraries.Load(libPath, libraries.User)
+	if err != nil {
+		feedback.Fatal(tr("Error loading library: %v", err), feedback.ErrBadArgument)
+	}
+	if len(library.Examples) == 0 {
+		feedback.Fatal(tr("The library %s has no examples.", library.Name), feedback.ErrBadArgument)
+	}
+
+	inst := instance.CreateAndInit()
+	res := newTestExamplesResult(library.Name, libPath, library.Examples, opts.fqbns)
+	jobs := opts.jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+
+	type compileJob struct {
+		example *exampleResult
+		fqbn    string
+	}
+	queue := make(chan *compileJob)
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	total := len(res.Examples) * len(res.Boards)
+	done := 0
+	progressCB := feedback.NewTaskProgressBarCB(tr("Compiling %d examples for %d boards", len(res.Examples), len(res.Boards)))
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				outcome := compileExample(context.Background(), inst, libPath, job.example.path, job.fqbn, opts.buildProperties)
+				mux.Lock()
+				job.example.Results[job.fqbn] = outcome
+				done++
+				progressCB(&rpc.TaskProgress{Percent: float32(done) * 100 / float32(total)})
+				mux.Unlock()
+			}
+		}()
+	}
+	start := time.Now()
+	for _, example := range res.Examples {
+		for _, fqbn := range res.Boards {
+			queue <- &compileJob{example: example, fqbn: fqbn}
+		}
+	}
+	close(queue)
+	wg.Wait()
+	progressCB(&rpc.TaskProgress{Completed: true})
+	res.duration = time.Since(start)
+	res.Success = res.failures() == 0
+
+	if opts.junitFile != nil {
+		if err := writeTestExamplesJUnitReport(opts.junitFile, res); err != nil {
+			feedback.Fatal(tr("Error writing JUnit report: %v", err), feedback.ErrGeneric)
+		}
+	}
+	if !res.Success {
+		feedback.FatalResult(res, feedback.ErrGeneric)
+	}
+	feedback.PrintResult(res)
+}
+
+// compileExample compiles the example for the board, using the library in
+// libPath.
+func compileExample(ctx context.Context, inst *rpc.Instance, libPath, example *paths.Path, fqbn string, buildProperties []string) *compileOutcome {
+	output := &bytes.Buffer{}
+	start := time.Now()
+	buildPath, err := exampleBuildPath(example, fqbn, buildProperties)
+	if err == nil {
+		_, err = compile.Compile(ctx, &rpc.CompileRequest{
+			Instance:        inst,
+			Fqbn:            fqbn,
+			SketchPath:      example.String(),
+			BuildPath:       buildPath.String(),
+			BuildProperties: buildProperties,
+			Library:         []string{libPath.String()},
+		}, output, output, nil)
+	}
+	outcome := &compileOutcome{Success: err == nil, duration: time.Since(start)}
+	outcome.Duration = outcome.duration.Round(time.Millisecond).String()
+	if err != nil {
+		outcome.Error = err.Error()
+		outcome.Output = output.String()
+	}
+	return outcome
+}
+
+// exampleBuildPath returns the build directory of the example for the board,
+// it doesn't change between runs so the compilations are incremental.
+func exampleBuildPath(example *paths.Path, fqbn string, buildProperties []string) (*paths.Path, error) {
+	sk, err := sketch.New(example)
+	if err != nil {
+		return nil, err
+	}
+	template, err := configuration.SketchBuildPathTemplate(configuration.Settings)
+	if err != nil {
+		return nil, err
+	}
+	return sk.VariantBuildPath(template, fqbn, buildProperties)
+}
+
+type compileOutcome struct {
+	Success  bool   `json:"success"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+	Output   string `json:"output,omitempty"`
+	duration time.Duration
+}
+
+type exampleResult struct {
+	Name string `json:"name"`
+	// Results maps the FQBNs to the result of the compilation
+	Results map[string]*compileOutcome `json:"results"`
+	path    *paths.Path
+}
+
+type testExamplesResult struct {
+	Library  string           `json:"library"`
+	Boards   []string         `json:"boards"`
+	Examples []*exampleResult `json:"examples"`
+	Success  bool             `json:"success"`
+	duration time.Duration
+}
+
+func newTestExamplesResult(name string, libPath *paths.Path, examples paths.PathList, fqbns []string) *testExamplesResult {
+	res := &testExamplesResult{Library: name, Boards: fqbns, Examples: []*exampleResult{}}
+	examplesDir := libPath.Join("examples")
+	for _, example := range examples {
+		exampleName := example.Base()
+		if rel, err := example.RelFrom(examplesDir); err == nil {
+			exampleName = rel.String()
+		}
+		res.Examples = append(res.Examples, &exampleResult{
+			Name:    exampleName,
+			Results: map[string]*compileOutcome{},
+			path:    example,
+		})
+	}
+	return res
+}
+
+// failures returns the number of failed compilations
+func (r *testExamplesResult) failures() int {
+	failures := 0
+	for _, example := range r.Examples {
+		for _, outcome := range example.Results {
+			if !outcome.Success {
+				failures++
+			}
+		}
+	}
+	return failures
+}
+
+func (r *testExamplesResult) Data() interface{} {
+	return r
+}
+
+func (r *testExamplesResult) String() string {
+	theme := feedback.GetTheme()
+	t := table.New()
+	header := []interface{}{tr("Example")}
+	for _, fqbn := range r.Boards {
+		header = append(header, fqbn)
+	}
+	t.SetHeader(header...)
+	for _, example := range r.Examples {
+		row := []interface{}{example.Name}
+		for _, fqbn := range r.Boards {
+			if outcome := example.Results[fqbn]; outcome != nil && outcome.Success {
+				row = append(row, table.NewCell(tr("PASS"), theme.Success))
+			} else {
+				row = append(row, table.NewCell(tr("FAIL"), theme.Error))
+			}
+		}
+		t.AddRow(row...)
+	}
+	res := t.Render()
+	for _, example := range r.Examples {
+		for _, fqbn := range r.Boards {
+			if outcome := example.Results[fqbn]; outcome != nil && !outcome.Success {
+				details := outcome.Output
+				if details == "" {
+					details = outcome.Error
+				}
+				res += "\n" + theme.Error.Sprint(tr("%[1]s failed for %[2]s:", example.Name, fqbn)) + "\n" + strings.TrimRight(details, "\n") + "\n"
+			}
+		}
+	}
+	return res
+}
+
+func (r *testExamplesResult) ErrorString() string {
+	if r.Success {
+		return ""
+	}
+	return tr("%d compilations failed.", r.failures())
+}
@@ -0,0 +1,96 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+)
+
+// The JUnit XML report has a test suite for each board and a test case for
+// the compilation of each example.
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Cases    []*junitTestCase `xml:"testcase"`
+	duration time.Duration
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Output  string `xml:",chardata"`
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// newTestExamplesJUnitReport converts the compilation matrix to a JUnit report
+func newTestExamplesJUnitReport(res *testExamplesResult) *junitTestSuites {
+	report := &junitTestSuites{Name: res.Library, Time: junitTime(res.duration)}
+	for _, fqbn := range res.Boards {
+		suite := &junitTestSuite{Name: fqbn}
+		for _, example := range res.Examples {
+			outcome := example.Results[fqbn]
+			if outcome == nil {
+				continue
+			}
+			testCase := &junitTestCase{Name: example.Name, Classname: fqbn, Time: junitTime(outcome.duration)}
+			if !outcome.Success {
+				testCase.Failure = &junitFailure{Message: outcome.Error, Output: outcome.Output}
+				suite.Failures++
+			}
+			suite.duration += outcome.duration
+			suite.Cases = append(suite.Cases, testCase)
+			suite.Tests++
+		}
+		suite.Time = junitTime(suite.duration)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+// writeTestExamplesJUnitReport saves the compilation matrix in a JUnit XML file.
+func writeTestExamplesJUnitReport(file *paths.Path, res *testExamplesResult) error {
+	data, err := xml.MarshalIndent(newTestExamplesJUnitReport(res), "", "  ")
+	if err != nil {
+		return err
+	}
+	return file.WriteFile(append([]byte(xml.Header), append(data, '\n')...))
+}
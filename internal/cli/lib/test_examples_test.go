@@ -0,0 +1,59 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestExamplesResult(t *testing.T) {
+	libPath := paths.New("/home/user/MyLib")
+	res := newTestExamplesResult("MyLib", libPath, paths.PathList{
+		libPath.Join("examples", "Blink"),
+		libPath.Join("examples", "Advanced", "Sweep"),
+	}, []string{"arduino:avr:uno", "arduino:samd:mkr1000"})
+	require.Equal(t, "Blink", res.Examples[0].Name)
+	require.Equal(t, paths.New("Advanced", "Sweep").String(), res.Examples[1].Name)
+
+	res.Examples[0].Results["arduino:avr:uno"] = &compileOutcome{Success: true, duration: time.Second}
+	res.Examples[0].Results["arduino:samd:mkr1000"] = &compileOutcome{Success: true, duration: 2 * time.Second}
+	res.Examples[1].Results["arduino:avr:uno"] = &compileOutcome{Error: "Compilation failed", Output: "Sweep.ino:1: error", duration: 500 * time.Millisecond}
+	res.Examples[1].Results["arduino:samd:mkr1000"] = &compileOutcome{Success: true, duration: time.Second}
+	res.duration = 3 * time.Second
+	require.Equal(t, 1, res.failures())
+
+	report := newTestExamplesJUnitReport(res)
+	require.Equal(t, "MyLib", report.Name)
+	require.Equal(t, 4, report.Tests)
+	require.Equal(t, 1, report.Failures)
+	require.Equal(t, "3.000", report.Time)
+	require.Len(t, report.Suites, 2)
+
+	uno := report.Suites[0]
+	require.Equal(t, "arduino:avr:uno", uno.Name)
+	require.Equal(t, 1, uno.Failures)
+	require.Equal(t, "1.500", uno.Time)
+	require.Nil(t, uno.Cases[0].Failure)
+	require.Equal(t, &junitFailure{Message: "Compilation failed", Output: "Sweep.ino:1: error"}, uno.Cases[1].Failure)
+
+	mkr := report.Suites[1]
+	require.Equal(t, 0, mkr.Failures)
+	require.Equal(t, "3.000", mkr.Time)
+}
@@ -29,16 +29,25 @@ import (
 )
 
 func initUpgradeCommand() *cobra.Command {
+	var dryRun bool
 	upgradeCommand := &cobra.Command{
 		Use:   "upgrade",
 		Short: tr("Upgrades installed libraries."),
 		Long:  tr("This command upgrades an installed library to the latest available version. Multiple libraries can be passed separated by a space. If no arguments are provided, the command will upgrade all the installed libraries where an update is available."),
 		Example: "  " + os.Args[0] + " lib upgrade \n" +
 			"  " + os.Args[0] + " lib upgrade Audio\n" +
-			"  " + os.Args[0] + " lib upgrade Audio ArduinoJson",
+			"  " + os.Args[0] + " lib upgrade Audio ArduinoJson\n" +
+			"  " + os.Args[0] + " lib upgrade --dry-run",
 		Args: cobra.ArbitraryArgs,
-		Run:  runUpgradeCommand,
+		Run: func(cmd *cobra.Command, args []string) {
+			if dryRun {
+				runUpgradePreviewCommand(args)
+				return
+			}
+			runUpgradeCommand(cmd, args)
+		},
 	}
+	upgradeCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Show the versions that would be installed and the version constraints of the installed libraries that would break, without changing anything."))
 	return upgradeCommand
 }
 
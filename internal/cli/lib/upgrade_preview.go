@@ -0,0 +1,126 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package lib
+
+import (
+	"context"
+
+	"github.com/arduino/arduino-cli/commands/lib"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+)
+
+func runUpgradePreviewCommand(libraries []string) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli lib upgrade --dry-run`")
+
+	resp, err := lib.LibraryUpgradePreview(context.Background(), &rpc.LibraryUpgradePreviewRequest{
+		Instance: inst,
+		Names:    libraries,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error computing the upgrade preview: %v", err), feedback.ErrGeneric)
+	}
+
+	res := &libraryUpgradePreviewResult{
+		Libraries:         []*libraryUpgradePreview{},
+		BrokenConstraints: []*libraryBrokenConstraint{},
+		DownloadSize:      resp.GetDownloadSize(),
+	}
+	for _, l := range resp.GetLibraries() {
+		res.Libraries = append(res.Libraries, &libraryUpgradePreview{
+			Name:             l.GetName(),
+			InstalledVersion: l.GetInstalledVersion(),
+			NewVersion:       l.GetNewVersion(),
+			DownloadSize:     l.GetDownloadSize(),
+			Cached:           l.GetCached(),
+			Dependency:       l.GetDependency(),
+		})
+	}
+	for _, c := range resp.GetBrokenConstraints() {
+		res.BrokenConstraints = append(res.BrokenConstraints, &libraryBrokenConstraint{
+			Library:    c.GetLibrary(),
+			Dependency: c.GetDependency(),
+			Constraint: c.GetConstraint(),
+			NewVersion: c.GetNewVersion(),
+		})
+	}
+	feedback.PrintResult(res)
+}
+
+type libraryUpgradePreview struct {
+	Name             string `json:"name"`
+	InstalledVersion string `json:"installed_version,omitempty"`
+	NewVersion       string `json:"new_version"`
+	DownloadSize     int64  `json:"download_size"`
+	Cached           bool   `json:"cached,omitempty"`
+	Dependency       bool   `json:"dependency,omitempty"`
+}
+
+type libraryBrokenConstraint struct {
+	Library    string `json:"library"`
+	Dependency string `json:"dependency"`
+	Constraint string `json:"constraint"`
+	NewVersion string `json:"new_version"`
+}
+
+type libraryUpgradePreviewResult struct {
+	Libraries         []*libraryUpgradePreview   `json:"libraries"`
+	BrokenConstraints []*libraryBrokenConstraint `json:"broken_constraints"`
+	DownloadSize      int64                      `json:"download_size"`
+}
+
+// Data implements feedback.Result.
+func (r *libraryUpgradePreviewResult) Data() interface{} {
+	return r
+}
+
+// String implements feedback.Result.
+func (r *libraryUpgradePreviewResult) String() string {
+	if len(r.Libraries) == 0 {
+		return tr("All the libraries are already at the latest version")
+	}
+
+	t := table.New()
+	t.SetHeader(tr("Name"), tr("Installed"), tr("New version"), tr("Download"), "")
+	for _, l := range r.Libraries {
+		download := feedback.FormatSize(l.DownloadSize)
+		if l.Cached {
+			download = tr("cached")
+		}
+		note := ""
+		if l.Dependency {
+			note = tr("dependency")
+		}
+		t.AddRow(l.Name, l.InstalledVersion, l.NewVersion, download, note)
+	}
+	res := t.Render()
+
+	if len(r.BrokenConstraints) > 0 {
+		constraints := table.New()
+		constraints.SetHeader(tr("Library"), tr("Requires"), tr("New version"))
+		for _, c := range r.BrokenConstraints {
+			constraints.AddRow(c.Library, c.Dependency+" ("+c.Constraint+")", c.NewVersion)
+		}
+		res += "\n" + feedback.GetTheme().Warning.Sprint(tr("The upgrade would break the dependencies of the following libraries:")) +
+			"\n" + constraints.Render()
+	}
+	res += "\n" + feedback.GetTheme().Title.Sprint(tr("Total download size:")) + " " + feedback.FormatSize(r.DownloadSize)
+	return res
+}
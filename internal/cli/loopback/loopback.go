@@ -0,0 +1,66 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package loopback
+
+import (
+	"os"
+
+	"github.com/arduino/arduino-cli/internal/arduino/loopback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	discovery "github.com/arduino/pluggable-discovery-protocol-handler/v2"
+	monitor "github.com/arduino/pluggable-monitor-protocol-handler"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `loopback` command. The command is hidden, it runs
+// the loopback pluggable discovery and monitor that are started by the CLI
+// itself when the `loopback.enabled` setting is true.
+func NewCommand() *cobra.Command {
+	loopbackCommand := &cobra.Command{
+		Use:    "loopback",
+		Short:  tr("Runs the loopback pluggable discovery or monitor."),
+		Long:   tr("Runs the loopback pluggable discovery or monitor."),
+		Hidden: true,
+	}
+
+	loopbackCommand.AddCommand(&cobra.Command{
+		Use:   "discovery",
+		Short: tr("Runs the loopback pluggable discovery."),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			server := discovery.NewServer(&loopback.Discovery{})
+			if err := server.Run(os.Stdin, os.Stdout); err != nil {
+				feedback.FatalError(err, feedback.ErrGeneric)
+			}
+		},
+	})
+	loopbackCommand.AddCommand(&cobra.Command{
+		Use:   "monitor",
+		Short: tr("Runs the loopback pluggable monitor."),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			server := monitor.NewServer(loopback.NewMonitor())
+			if err := server.Run(os.Stdin, os.Stdout); err != nil {
+				feedback.FatalError(err, feedback.ErrGeneric)
+			}
+		},
+	})
+
+	return loopbackCommand
+}
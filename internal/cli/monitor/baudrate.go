@@ -0,0 +1,185 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"io"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// commonBaudrates are the baud rates tried first by the detection, in order
+// of popularity.
+var commonBaudrates = []string{"115200", "9600", "57600", "38400", "19200", "74880", "230400", "250000", "500000", "1000000", "4800", "2400", "1200", "300"}
+
+// baudrateSampleTime is how long the data is sampled at each baud rate
+var baudrateSampleTime = time.Second
+
+const (
+	// minBaudrateSample is the minimum number of bytes needed to score a baud rate
+	minBaudrateSample = 8
+	// lockBaudrateScore is the score that stops the detection at once, when
+	// the sample is long enough
+	lockBaudrateScore = 0.98
+	// minBaudrateScore is the minimum score of the detected baud rate
+	minBaudrateScore = 0.85
+)
+
+// baudrateCandidates returns the baud rates supported by the port, the common
+// ones first.
+func baudrateCandidates(supported []string) []string {
+	res := []string{}
+	for _, rate := range commonBaudrates {
+		if slices.Contains(supported, rate) {
+			res = append(res, rate)
+		}
+	}
+	for _, rate := range supported {
+		if !slices.Contains(res, rate) {
+			res = append(res, rate)
+		}
+	}
+	return res
+}
+
+// textScore returns the fraction of the data that is valid text: printable
+// characters, whitespace and valid UTF-8 sequences. The data received with a
+// wrong baud rate is mostly made of framing errors and random bytes.
+func textScore(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	valid := 0
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		switch {
+		case r == utf8.RuneError:
+		case r == '\r' || r == '\n' || r == '\t':
+			valid += size
+		case r >= 0x20 && r != 0x7f && !(r >= 0x80 && r < 0xa0):
+			valid += size
+		}
+		i += size
+	}
+	return float64(valid) / float64(len(data))
+}
+
+// chanReader reads the port in the background, so that the data received
+// can be sampled for a given time.
+type chanReader struct {
+	data    chan []byte
+	err     error
+	pending []byte
+}
+
+func newChanReader(r io.Reader) *chanReader {
+	c := &chanReader{data: make(chan []byte, 64)}
+	go func() {
+		for {
+			buf := make([]byte, 1024)
+			n, err := r.Read(buf)
+			if n > 0 {
+				c.data <- buf[:n]
+			}
+			if err != nil {
+				c.err = err
+				close(c.data)
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func (c *chanReader) Read(buf []byte) (int, error) {
+	if len(c.pending) == 0 {
+		data, ok := <-c.data
+		if !ok {
+			return 0, c.err
+		}
+		c.pending = data
+	}
+	n := copy(buf, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// drain discards the data already received
+func (c *chanReader) drain() {
+	c.pending = nil
+	for {
+		select {
+		case _, ok := <-c.data:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// sample returns the data received in the given time
+func (c *chanReader) sample(d time.Duration) []byte {
+	res := []byte{}
+	timeout := time.After(d)
+	for {
+		select {
+		case data, ok := <-c.data:
+			if !ok {
+				return res
+			}
+			res = append(res, data...)
+		case <-timeout:
+			return res
+		}
+	}
+}
+
+// detectBaudrate sets the port to each of the baud rates and samples the data
+// received, it returns the baud rate that gives the most text-like data, or
+// an empty string if no data is valid text. The port is left at the detected
+// baud rate.
+func detectBaudrate(port *chanReader, setBaudrate func(rate string) error, rates []string, progress func(rate string)) (string, error) {
+	best, bestScore := "", 0.0
+	for _, rate := range rates {
+		progress(rate)
+		if err := setBaudrate(rate); err != nil {
+			return "", err
+		}
+		port.drain()
+		data := port.sample(baudrateSampleTime)
+		if len(data) < minBaudrateSample {
+			continue
+		}
+		score := textScore(data)
+		if score > bestScore {
+			best, bestScore = rate, score
+		}
+		if score >= lockBaudrateScore {
+			return rate, nil
+		}
+	}
+	if bestScore < minBaudrateScore {
+		return "", nil
+	}
+	if err := setBaudrate(best); err != nil {
+		return "", err
+	}
+	port.drain()
+	return best, nil
+}
@@ -0,0 +1,69 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextScore(t *testing.T) {
+	require.Equal(t, 0.0, textScore(nil))
+	require.Equal(t, 1.0, textScore([]byte("Temperature: 21.5°C\r\n")))
+	require.Equal(t, 0.5, textScore([]byte{'o', 'k', 0x00, 0xff}))
+}
+
+func TestBaudrateCandidates(t *testing.T) {
+	require.Equal(t, []string{"115200", "9600", "300", "14400"}, baudrateCandidates([]string{"300", "9600", "14400", "115200"}))
+}
+
+// fakeSerial sends text at the right baud rate and garbage at the others
+type fakeSerial struct {
+	rate atomic.Value
+}
+
+func (s *fakeSerial) Read(buf []byte) (int, error) {
+	time.Sleep(5 * time.Millisecond)
+	if s.rate.Load() == "57600" {
+		return copy(buf, "hello world\n"), nil
+	}
+	return copy(buf, []byte{0x00, 0xf8, 'x', 0x80, 0xfe, 0x00, 0x1c, 0xe0}), nil
+}
+
+func TestDetectBaudrate(t *testing.T) {
+	baudrateSampleTime = 50 * time.Millisecond
+	serial := &fakeSerial{}
+	serial.rate.Store("")
+	tried := []string{}
+	setBaudrate := func(rate string) error {
+		serial.rate.Store(rate)
+		return nil
+	}
+	progress := func(rate string) { tried = append(tried, rate) }
+
+	port := newChanReader(serial)
+	rate, err := detectBaudrate(port, setBaudrate, []string{"115200", "9600", "57600", "38400"}, progress)
+	require.NoError(t, err)
+	require.Equal(t, "57600", rate)
+	require.Equal(t, []string{"115200", "9600", "57600"}, tried)
+
+	rate, err = detectBaudrate(port, setBaudrate, []string{"115200", "9600"}, progress)
+	require.NoError(t, err)
+	require.Equal(t, "", rate)
+}
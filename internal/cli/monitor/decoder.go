@@ -0,0 +1,51 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"io"
+
+	"github.com/arduino/arduino-cli/internal/arduino/decoders"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+)
+
+// registerExternalDecoders registers the decoders defined in the
+// configuration, that run an external command.
+func registerExternalDecoders() {
+	for name, commandLine := range configuration.Settings.GetStringMapString("monitor.decoders") {
+		decoders.Register(name, tr("External decoder running %s", commandLine), decoders.External(name, commandLine))
+	}
+}
+
+// newDecoderReader returns a reader of the frames decoded from r with the
+// decoder registered with the given name, one for each line.
+func newDecoderReader(r io.Reader, name string) io.Reader {
+	registerExternalDecoders()
+	decoder, err := decoders.New(name)
+	if err != nil {
+		feedback.Fatal(tr("Invalid decoder: %v", err), feedback.ErrBadArgument)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		err := decoder.Decode(r, func(frame *decoders.Frame) {
+			// CRLF moves to a new line also when the terminal is in raw mode
+			pw.Write([]byte(frame.String() + "\r\n"))
+		})
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
@@ -0,0 +1,244 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// macroKeys maps the name of the keys that can be bound to a macro to the
+// sequences sent by the terminals when they are pressed.
+var macroKeys = map[string][]string{
+	"f1":  {"\x1bOP", "\x1b[11~"},
+	"f2":  {"\x1bOQ", "\x1b[12~"},
+	"f3":  {"\x1bOR", "\x1b[13~"},
+	"f4":  {"\x1bOS", "\x1b[14~"},
+	"f5":  {"\x1b[15~"},
+	"f6":  {"\x1b[17~"},
+	"f7":  {"\x1b[18~"},
+	"f8":  {"\x1b[19~"},
+	"f9":  {"\x1b[20~"},
+	"f10": {"\x1b[21~"},
+	"f11": {"\x1b[23~"},
+	"f12": {"\x1b[24~"},
+}
+
+func init() {
+	for c := 'a'; c <= 'z'; c++ {
+		switch c {
+		case 'c', 'h', 'i', 'j', 'm', 't':
+			// CTRL-C exits the monitor, CTRL-T starts the monitor commands,
+			// the others are backspace, tab and newlines
+			continue
+		}
+		macroKeys["ctrl-"+string(c)] = []string{string(rune(c - 'a' + 1))}
+	}
+}
+
+// defaultBreakDuration is the duration of the break condition if not specified
+const defaultBreakDuration = 250 * time.Millisecond
+
+// macro is the action performed when the key bound to it is pressed: it
+// sends some data, the content of a file or a break condition.
+type macro struct {
+	Key    string
+	Action string
+	data   []byte
+	file   string
+	brk    time.Duration
+}
+
+// parseMacro parses the action of a macro, that can be:
+//   - "hex:<bytes>" to send a sequence of bytes in hex, for example "hex:01 02 ff"
+//   - "file:<path>" to send the content of a file
+//   - "break" or "break:<duration>" to send a break condition
+//   - any other text, with the escape sequences like \n or \x1b, to send it
+func parseMacro(key, action string) (*macro, error) {
+	key = strings.ToLower(key)
+	if _, ok := macroKeys[key]; !ok {
+		return nil, errors.New(tr("invalid macro key %[1]s, the valid keys are: %[2]s", key, strings.Join(macroKeyNames(), ", ")))
+	}
+	m := &macro{Key: key, Action: action}
+	switch {
+	case strings.HasPrefix(action, "hex:"):
+		data, err := hex.DecodeString(strings.NewReplacer(" ", "", ":", "").Replace(action[4:]))
+		if err != nil {
+			return nil, fmt.Errorf(tr("invalid hex data for macro %[1]s: %[2]v"), key, err)
+		}
+		m.data = data
+	case strings.HasPrefix(action, "file:"):
+		m.file = action[5:]
+	case action == "break":
+		m.brk = defaultBreakDuration
+	case strings.HasPrefix(action, "break:"):
+		d, err := time.ParseDuration(action[6:])
+		if err != nil {
+			return nil, fmt.Errorf(tr("invalid break duration for macro %[1]s: %[2]v"), key, err)
+		}
+		m.brk = d
+	default:
+		data, err := unescapeMacroText(action)
+		if err != nil {
+			return nil, fmt.Errorf(tr("invalid text for macro %[1]s: %[2]v"), key, err)
+		}
+		m.data = data
+	}
+	return m, nil
+}
+
+// parseMacros parses the macros defined in each of the given maps, the
+// macros of the later maps replace the ones bound to the same key.
+func parseMacros(definitions ...map[string]string) (map[string]*macro, error) {
+	macros := map[string]*macro{}
+	for _, defs := range definitions {
+		for key, action := range defs {
+			m, err := parseMacro(key, action)
+			if err != nil {
+				return nil, err
+			}
+			macros[m.Key] = m
+		}
+	}
+	return macros, nil
+}
+
+func macroKeyNames() []string {
+	names := make([]string, 0, len(macroKeys))
+	for key := range macroKeys {
+		names = append(names, key)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) < len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// unescapeMacroText replaces the Go escape sequences in the text
+func unescapeMacroText(text string) ([]byte, error) {
+	res := []byte{}
+	for len(text) > 0 {
+		value, multibyte, tail, err := strconv.UnquoteChar(text, 0)
+		if err != nil {
+			return nil, err
+		}
+		if value < utf8.RuneSelf || !multibyte {
+			res = append(res, byte(value))
+		} else {
+			res = utf8.AppendRune(res, value)
+		}
+		text = tail
+	}
+	return res, nil
+}
+
+// macroWriter forwards the data typed by the user to the port, replacing
+// the keys bound to a macro with the action of the macro.
+type macroWriter struct {
+	out     io.Writer
+	macros  map[string]*macro
+	config  func(setting, value string) error
+	warn    func(msg string)
+	pending []byte
+}
+
+func (w *macroWriter) Write(buf []byte) (int, error) {
+	w.pending = append(w.pending, buf...)
+	// The beginning of a key sequence is kept until the rest is received
+	start, end := 0, len(w.pending)
+	for i := 0; i < len(w.pending); {
+		m, seqLen, partial := w.match(w.pending[i:])
+		if partial {
+			end = i
+			break
+		}
+		if m == nil {
+			i++
+			continue
+		}
+		if err := w.forward(w.pending[start:i]); err != nil {
+			return 0, err
+		}
+		if err := w.run(m); err != nil {
+			w.warn(tr("Error running the macro %[1]s: %[2]v", m.Key, err))
+		}
+		i += seqLen
+		start = i
+	}
+	if err := w.forward(w.pending[start:end]); err != nil {
+		return 0, err
+	}
+	w.pending = append([]byte(nil), w.pending[end:]...)
+	return len(buf), nil
+}
+
+func (w *macroWriter) forward(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	_, err := w.out.Write(data)
+	return err
+}
+
+// match returns the macro bound to the key sequence at the beginning of
+// data and the length of the sequence. It returns partial=true if data is
+// the incomplete beginning of a sequence.
+func (w *macroWriter) match(data []byte) (m *macro, seqLen int, partial bool) {
+	for key, macro := range w.macros {
+		for _, seq := range macroKeys[key] {
+			if bytes.HasPrefix(data, []byte(seq)) {
+				return macro, len(seq), false
+			}
+			if len(data) < len(seq) && strings.HasPrefix(seq, string(data)) {
+				partial = true
+			}
+		}
+	}
+	return nil, 0, partial
+}
+
+func (w *macroWriter) run(m *macro) error {
+	switch {
+	case m.file != "":
+		data, err := os.ReadFile(m.file)
+		if err != nil {
+			return err
+		}
+		_, err = w.out.Write(data)
+		return err
+	case m.brk > 0:
+		if err := w.config("break", "on"); err != nil {
+			return fmt.Errorf("%s: %w", tr("the monitor doesn't support break conditions"), err)
+		}
+		time.Sleep(m.brk)
+		return w.config("break", "off")
+	default:
+		_, err := w.out.Write(m.data)
+		return err
+	}
+}
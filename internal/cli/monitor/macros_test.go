@@ -0,0 +1,96 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMacros(t *testing.T) {
+	macros, err := parseMacros(
+		map[string]string{"f2": "STATUS\\n", "f3": "RESET\n"},
+		map[string]string{"F3": "hex:01 02 ff", "ctrl-b": "break", "f12": "break:1s", "f5": "file:fw.bin"},
+	)
+	require.NoError(t, err)
+	require.Len(t, macros, 5)
+	require.Equal(t, []byte("STATUS\n"), macros["f2"].data)
+	require.Equal(t, []byte{0x01, 0x02, 0xff}, macros["f3"].data)
+	require.Equal(t, defaultBreakDuration, macros["ctrl-b"].brk)
+	require.Equal(t, time.Second, macros["f12"].brk)
+	require.Equal(t, "fw.bin", macros["f5"].file)
+
+	data, err := unescapeMacroText(`\x1b[0m\t"é"`)
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x1b[0m\t\"é\""), data)
+
+	_, err = parseMacros(map[string]string{"f13": "X"})
+	require.ErrorContains(t, err, "invalid macro key f13")
+	_, err = parseMacros(map[string]string{"ctrl-c": "X"})
+	require.Error(t, err)
+	_, err = parseMacros(map[string]string{"f1": "hex:0g"})
+	require.Error(t, err)
+	_, err = parseMacros(map[string]string{"f1": "break:soon"})
+	require.Error(t, err)
+}
+
+func TestMacroWriter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "data.txt")
+	require.NoError(t, os.WriteFile(file, []byte("FILE"), 0644))
+	macros, err := parseMacros(map[string]string{
+		"f2":     "STATUS\\n",
+		"f5":     "file:" + file,
+		"ctrl-b": "break:1ms",
+	})
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	configs := []string{}
+	w := &macroWriter{
+		out:    out,
+		macros: macros,
+		config: func(setting, value string) error {
+			configs = append(configs, setting+"="+value)
+			return nil
+		},
+		warn: func(msg string) { t.Fatal(msg) },
+	}
+	write := func(data string) {
+		n, err := w.Write([]byte(data))
+		require.NoError(t, err)
+		require.Equal(t, len(data), n)
+	}
+
+	write("abc\x1bOQdef")
+	require.Equal(t, "abcSTATUS\ndef", out.String())
+
+	// A key sequence split between two writes
+	out.Reset()
+	write("x\x1b[1")
+	require.Equal(t, "x", out.String())
+	write("5~y\x1b[A")
+	require.Equal(t, "xFILEy\x1b[A", out.String())
+
+	out.Reset()
+	write("\x02\x1b[12~")
+	require.Equal(t, "STATUS\n", out.String())
+	require.Equal(t, []string{"break=on", "break=off"}, configs)
+}
@@ -28,14 +28,17 @@ import (
 
 	"github.com/arduino/arduino-cli/commands/monitor"
 	sk "github.com/arduino/arduino-cli/commands/sketch"
+	"github.com/arduino/arduino-cli/internal/arduino/decoders"
+	"github.com/arduino/arduino-cli/internal/arduino/pcapng"
 	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
 	"github.com/arduino/arduino-cli/internal/cli/feedback/result"
 	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
 	"github.com/arduino/arduino-cli/internal/cli/instance"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
-	"github.com/fatih/color"
+	"github.com/arduino/arduino-cli/version"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"go.bug.st/cleanup"
@@ -54,6 +57,16 @@ func NewCommand() *cobra.Command {
 		configs    []string
 		quiet      bool
 		timestamp  bool
+		capture    string
+		replay     string
+		macros     map[string]string
+		lineEnding string
+		echo       bool
+		txLog      string
+		dtr        string
+		rts        string
+		detectBaud bool
+		decoder    string
 	)
 	monitorCommand := &cobra.Command{
 		Use:   "monitor",
@@ -61,13 +74,34 @@ func NewCommand() *cobra.Command {
 		Long:  tr("Open a communication port with a board."),
 		Example: "" +
 			"  " + os.Args[0] + " monitor -p /dev/ttyACM0\n" +
-			"  " + os.Args[0] + " monitor -p /dev/ttyACM0 --describe",
+			"  " + os.Args[0] + " monitor -p /dev/ttyACM0 --describe\n" +
+			"  " + os.Args[0] + " monitor -p /dev/ttyACM0 --capture session.pcapng\n" +
+			"  " + os.Args[0] + " monitor -p /dev/ttyACM0 --raw --macro f2=\"STATUS\\n\" --macro f3=hex:01ff\n" +
+			"  " + os.Args[0] + " monitor -p /dev/ttyUSB0 --dtr off --rts off\n" +
+			"  " + os.Args[0] + " monitor -p /dev/ttyUSB0 --detect-baud\n" +
+			"  " + os.Args[0] + " monitor -p /dev/ttyUSB0 --config baudrate=9600 --decoder nmea\n" +
+			"  " + os.Args[0] + " monitor --replay session.pcapng",
 		Run: func(cmd *cobra.Command, args []string) {
 			sketchPath := ""
 			if len(args) > 0 {
 				sketchPath = args[0]
 			}
-			runMonitorCmd(&portArgs, &fqbnArg, &profileArg, sketchPath, configs, describe, timestamp, quiet, raw)
+			if replay != "" {
+				runReplayCmd(replay, timestamp, quiet, decoder)
+				return
+			}
+			var echoFlag *bool
+			if cmd.Flags().Changed("echo") {
+				echoFlag = &echo
+			}
+			// The control lines given with the flags take precedence
+			// over the other port settings
+			for _, line := range []struct{ setting, value string }{{"dtr", dtr}, {"rts", rts}} {
+				if line.value != "" {
+					configs = append(configs, line.setting+"="+line.value)
+				}
+			}
+			runMonitorCmd(&portArgs, &fqbnArg, &profileArg, sketchPath, configs, describe, timestamp, quiet, raw, capture, macros, lineEnding, echoFlag, txLog, detectBaud, decoder)
 		},
 	}
 	portArgs.AddToCommand(monitorCommand)
@@ -77,13 +111,42 @@ func NewCommand() *cobra.Command {
 	monitorCommand.Flags().StringSliceVarP(&configs, "config", "c", []string{}, tr("Configure communication port settings. The format is <ID>=<value>[,<ID>=<value>]..."))
 	monitorCommand.Flags().BoolVarP(&quiet, "quiet", "q", false, tr("Run in silent mode, show only monitor input and output."))
 	monitorCommand.Flags().BoolVar(&timestamp, "timestamp", false, tr("Timestamp each incoming line."))
+	monitorCommand.Flags().StringVar(&capture, "capture", "", tr("Record the data sent and received in the given pcapng file, that can be analyzed with Wireshark."))
+	monitorCommand.Flags().StringVar(&replay, "replay", "", tr("Replay the data received in a session recorded with --capture, with the original timing."))
+	arguments.AddKeyValuePFlag(monitorCommand, &macros, "macro", "", nil, tr("Bind a key (f1-f12, ctrl-a...) to the data sent when it's pressed: a text, hex:<bytes>, file:<path> or break[:<duration>]. Can be used multiple times."))
+	monitorCommand.Flags().StringVar(&lineEnding, "line-ending", "", tr("Line ending sent in place of the one typed: %s. Can be changed while the monitor is running with CTRL-T L.", strings.Join(monitor.LineEndingNames, ", ")))
+	monitorCommand.RegisterFlagCompletionFunc("line-ending", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return monitor.LineEndingNames, cobra.ShellCompDirectiveNoFileComp
+	})
+	monitorCommand.Flags().BoolVar(&echo, "echo", false, tr("Print the data sent, useful in raw mode. Can be toggled while the monitor is running with CTRL-T E."))
+	monitorCommand.Flags().StringVar(&dtr, "dtr", "", tr("Set the DTR line on or off when the port is opened. Can be toggled while the monitor is running with CTRL-T D."))
+	monitorCommand.Flags().StringVar(&rts, "rts", "", tr("Set the RTS line on or off when the port is opened. Can be toggled while the monitor is running with CTRL-T R."))
+	for _, line := range []string{"dtr", "rts"} {
+		monitorCommand.RegisterFlagCompletionFunc(line, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"on", "off"}, cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+	monitorCommand.Flags().BoolVar(&detectBaud, "detect-baud", false, tr("Detect the baud rate of the port from the data received."))
+	monitorCommand.Flags().StringVar(&decoder, "decoder", "", tr("Decode the data received as the frames of a protocol: %s, or a decoder defined in the configuration.", strings.Join(decoders.Names(), ", ")))
+	monitorCommand.RegisterFlagCompletionFunc("decoder", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		registerExternalDecoders()
+		res := []string{}
+		for _, name := range decoders.Names() {
+			res = append(res, name+"\t"+decoders.Description(name))
+		}
+		return res, cobra.ShellCompDirectiveNoFileComp
+	})
+	monitorCommand.Flags().StringVar(&txLog, "tx-log", "", tr("Append the data sent, with a timestamp on each line, to the given file."))
+	monitorCommand.MarkFlagsMutuallyExclusive("capture", "replay")
+	monitorCommand.MarkFlagsMutuallyExclusive("describe", "replay")
 	fqbnArg.AddToCommand(monitorCommand)
 	return monitorCommand
 }
 
 func runMonitorCmd(
 	portArgs *arguments.Port, fqbnArg *arguments.Fqbn, profileArg *arguments.Profile, sketchPathArg string,
-	configs []string, describe, timestamp, quiet, raw bool,
+	configs []string, describe, timestamp, quiet, raw bool, capture string, macroFlags map[string]string,
+	lineEnding string, echo *bool, txLog string, detectBaud bool, decoder string,
 ) {
 	logrus.Info("Executing `arduino-cli monitor`")
 
@@ -103,9 +166,13 @@ func runMonitorCmd(
 	// If both {--port --profile} are set we read the fqbn in the following order: profile -> default_fqbn -> discovery
 	// If only --port is set we read the fqbn in the following order: default_fqbn -> discovery
 	// If only --fqbn is set we read the port in the following order: default_port
+	// If --fqbn is a board alias, the port and the monitor settings of the
+	// alias are used unless given with the flags.
+	// The monitor settings not given with the flags or the alias are read from
+	// default_monitor_config of the sketch.yaml
 	sketchPath := arguments.InitSketchPath(sketchPathArg)
 	sketch, err := sk.LoadSketch(context.Background(), &rpc.LoadSketchRequest{SketchPath: sketchPath.String()})
-	if err != nil && !portArgs.IsPortFlagSet() {
+	if err != nil && !portArgs.IsPortFlagSet() && fqbnArg.DefaultPort() == "" {
 		feedback.Fatal(
 			tr("Error getting default port from `sketch.yaml`. Check if you're in the correct sketch folder or provide the --port flag: %s", err),
 			feedback.ErrGeneric,
@@ -114,6 +181,15 @@ func runMonitorCmd(
 	if sketch != nil {
 		defaultPort, defaultProtocol = sketch.GetDefaultPort(), sketch.GetDefaultProtocol()
 	}
+	if aliasPort := fqbnArg.DefaultPort(); aliasPort != "" {
+		defaultPort, defaultProtocol = aliasPort, ""
+	}
+	if alias := fqbnArg.Alias(); alias != nil {
+		configs = withDefaultSettings(alias.MonitorSettings(), configs)
+	}
+	if sketch != nil {
+		configs = withDefaultSettings(settingsList(sketch.GetDefaultMonitorConfig()), configs)
+	}
 	if fqbnArg.String() == "" {
 		if profileArg.Get() == "" {
 			inst, profile = instance.CreateAndInitWithProfile(sketch.GetDefaultProfile().GetName(), sketchPath)
@@ -162,6 +238,28 @@ func runMonitorCmd(
 		return
 	}
 
+	// The macros of the sketch.yaml replace the ones of the configuration
+	// and are replaced by the ones given with the flags
+	macros, err := parseMacros(configuration.Settings.GetStringMapString("monitor.macros"), sketch.GetMonitorMacros(), macroFlags)
+	if err != nil {
+		feedback.Fatal(tr("Invalid monitor macro: %v", err), feedback.ErrBadArgument)
+	}
+
+	// The line ending and the local echo given with the flags take
+	// precedence over the defaults of the sketch.yaml
+	txOpts := &txOptions{lineEnding: lineEnding}
+	if txOpts.lineEnding == "" {
+		txOpts.lineEnding = sketch.GetDefaultMonitorLineEnding()
+	}
+	if _, ok := monitor.LineEndings[txOpts.lineEnding]; txOpts.lineEnding != "" && !ok {
+		feedback.Fatal(tr("Invalid line ending %[1]s, must be one of: %[2]s", txOpts.lineEnding, strings.Join(monitor.LineEndingNames, ", ")), feedback.ErrBadArgument)
+	}
+	if echo != nil {
+		txOpts.echo = *echo
+	} else {
+		txOpts.echo = sketch.GetDefaultMonitorEcho()
+	}
+
 	configuration := &rpc.MonitorPortConfiguration{}
 	if len(configs) > 0 {
 		for _, config := range configs {
@@ -203,6 +301,20 @@ func runMonitorCmd(
 			}
 		}
 	}
+	// The initial state of the control lines supported by the port, that
+	// can be changed while the monitor is running
+	txOpts.lines = map[string]bool{}
+	for _, s := range enumerateResp.GetSettings() {
+		if line := strings.ToLower(s.GetSettingId()); line == "dtr" || line == "rts" {
+			txOpts.lines[line] = s.GetValue() == "on"
+		}
+	}
+	for _, s := range configuration.GetSettings() {
+		if line := strings.ToLower(s.GetSettingId()); line == "dtr" || line == "rts" {
+			txOpts.lines[line] = s.GetValue() == "on"
+		}
+	}
+
 	portProxy, _, err := monitor.Monitor(context.Background(), &rpc.MonitorPortOpenRequest{
 		Instance:          inst,
 		Port:              &rpc.Port{Address: portAddress, Protocol: portProtocol},
@@ -214,8 +326,19 @@ func runMonitorCmd(
 	}
 	defer portProxy.Close()
 
+	var portIn io.Reader = portProxy
+	if detectBaud {
+		portIn = runBaudrateDetection(portProxy, enumerateResp.GetSettings(), quiet)
+	}
+
 	if !quiet {
 		feedback.Print(tr("Connected to %s! Press CTRL-C to exit.", portAddress))
+		feedback.Print(tr("Press CTRL-T followed by H to list the monitor commands."))
+		for _, key := range macroKeyNames() {
+			if m, ok := macros[key]; ok {
+				feedback.Print(tr("Press %[1]s to send %[2]s", strings.ToUpper(m.Key), m.Action))
+			}
+		}
 	}
 
 	ttyIn, ttyOut, err := feedback.InteractiveStreams()
@@ -223,9 +346,43 @@ func runMonitorCmd(
 		feedback.FatalError(err, feedback.ErrGeneric)
 	}
 
+	var portOut io.Writer = portProxy
+	if capture != "" {
+		captureFile, err := os.Create(capture)
+		if err != nil {
+			feedback.Fatal(tr("Error creating capture file: %v", err), feedback.ErrGeneric)
+		}
+		defer captureFile.Close()
+		captureWriter, err := pcapng.NewWriter(captureFile, portAddress, version.VersionInfo.Application+" "+version.VersionInfo.VersionString)
+		if err != nil {
+			feedback.Fatal(tr("Error creating capture file: %v", err), feedback.ErrGeneric)
+		}
+		portIn = io.TeeReader(portIn, &packetWriter{writer: captureWriter, direction: pcapng.Inbound})
+		portOut = io.MultiWriter(portProxy, &packetWriter{writer: captureWriter, direction: pcapng.Outbound})
+		if !quiet {
+			feedback.Print(tr("Recording the traffic in %s", capture))
+		}
+	}
+
+	if decoder != "" {
+		portIn = newDecoderReader(portIn, decoder)
+	}
+
 	if timestamp {
 		ttyOut = newTimeStampWriter(ttyOut)
 	}
+	// The terminal is written by the port and by the local echo
+	ttyOut = &syncWriter{writer: ttyOut}
+
+	var txLogWriter io.Writer
+	if txLog != "" {
+		txLogFile, err := os.OpenFile(txLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			feedback.Fatal(tr("Error opening TX log file: %v", err), feedback.ErrGeneric)
+		}
+		defer txLogFile.Close()
+		txLogWriter = newTimeStampWriter(txLogFile)
+	}
 
 	ctx, cancel := cleanup.InterruptableContext(context.Background())
 	if raw {
@@ -246,7 +403,7 @@ func runMonitorCmd(
 	}
 
 	go func() {
-		_, err := io.Copy(ttyOut, portProxy)
+		_, err := io.Copy(ttyOut, portIn)
 		if err != nil && !errors.Is(err, io.EOF) {
 			if !quiet {
 				feedback.Print(tr("Port closed: %v", err))
@@ -254,8 +411,19 @@ func runMonitorCmd(
 		}
 		cancel()
 	}()
+	portOut = &txTeeWriter{out: portOut, opts: txOpts, tty: ttyOut, log: txLogWriter}
+	if len(macros) > 0 {
+		portOut = &macroWriter{
+			out:    portOut,
+			macros: macros,
+			config: portProxy.Config,
+			warn:   feedback.Warning,
+		}
+	}
+	portOut = &lineEndingWriter{out: portOut, opts: txOpts}
+	portOut = &commandWriter{out: portOut, opts: txOpts, print: feedback.Print, config: portProxy.Config}
 	go func() {
-		_, err := io.Copy(portProxy, ttyIn)
+		_, err := io.Copy(portOut, ttyIn)
 		if err != nil && !errors.Is(err, io.EOF) {
 			if !quiet {
 				feedback.Print(tr("Port closed: %v", err))
@@ -268,6 +436,94 @@ func runMonitorCmd(
 	<-ctx.Done()
 }
 
+// runReplayCmd prints the data received in a recorded session, waiting
+// between each chunk the same time elapsed during the recording.
+func runReplayCmd(replay string, timestamp, quiet bool, decoder string) {
+	logrus.Info("Executing `arduino-cli monitor --replay`")
+
+	captureFile, err := os.Open(replay)
+	if err != nil {
+		feedback.Fatal(tr("Error opening capture file: %v", err), feedback.ErrGeneric)
+	}
+	defer captureFile.Close()
+	reader, err := pcapng.NewReader(captureFile)
+	if err != nil {
+		feedback.Fatal(tr("Error reading capture file: %v", err), feedback.ErrGeneric)
+	}
+
+	_, ttyOut, err := feedback.InteractiveStreams()
+	if err != nil {
+		feedback.FatalError(err, feedback.ErrGeneric)
+	}
+	if timestamp {
+		ttyOut = newTimeStampWriter(ttyOut)
+	}
+	if decoder != "" {
+		// The data replayed is written to the decoder, that writes the
+		// frames to the terminal
+		pr, pw := io.Pipe()
+		done := make(chan struct{})
+		go func(out io.Writer) {
+			io.Copy(out, newDecoderReader(pr, decoder))
+			close(done)
+		}(ttyOut)
+		defer func() {
+			pw.Close()
+			<-done
+		}()
+		ttyOut = pw
+	}
+	if !quiet {
+		feedback.Print(tr("Replaying %s! Press CTRL-C to exit.", replay))
+	}
+
+	ctx, cancel := cleanup.InterruptableContext(context.Background())
+	defer cancel()
+	var first time.Time
+	start := time.Now()
+	for {
+		packet, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			feedback.Fatal(tr("Error reading capture file: %v", err), feedback.ErrGeneric)
+		}
+		if packet.Direction != pcapng.Inbound {
+			continue
+		}
+		if first.IsZero() {
+			first = packet.Timestamp
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(start.Add(packet.Timestamp.Sub(first)))):
+		}
+		if _, err := ttyOut.Write(packet.Data); err != nil {
+			feedback.FatalError(err, feedback.ErrGeneric)
+		}
+	}
+}
+
+// packetWriter records each chunk of data written as a packet of a capture
+type packetWriter struct {
+	writer    *pcapng.Writer
+	direction pcapng.Direction
+}
+
+func (w *packetWriter) Write(buf []byte) (int, error) {
+	err := w.writer.WritePacket(&pcapng.Packet{
+		Timestamp: time.Now(),
+		Direction: w.direction,
+		Data:      buf,
+	})
+	if err != nil {
+		logrus.WithError(err).Error("Error recording traffic")
+	}
+	return len(buf), nil
+}
+
 type charDetectorWriter struct {
 	callback     func()
 	detectedChar byte
@@ -295,7 +551,7 @@ func (r *detailsResult) String() string {
 	t := table.New()
 	t.SetHeader(tr("ID"), tr("Setting"), tr("Default"), tr("Values"))
 
-	green := color.New(color.FgGreen)
+	green := feedback.GetTheme().Accent
 	sort.Slice(r.Settings, func(i, j int) bool {
 		return r.Settings[i].Label < r.Settings[j].Label
 	})
@@ -306,6 +562,71 @@ func (r *detailsResult) String() string {
 	return t.Render()
 }
 
+// runBaudrateDetection detects the baud rate of the port and returns the
+// reader of the data received.
+func runBaudrateDetection(portProxy *monitor.PortProxy, settings []*rpc.MonitorPortSettingDescriptor, quiet bool) io.Reader {
+	var baudrate *rpc.MonitorPortSettingDescriptor
+	for _, s := range settings {
+		if strings.EqualFold(s.GetSettingId(), "baudrate") {
+			baudrate = s
+		}
+	}
+	if baudrate == nil {
+		feedback.Fatal(tr("The port doesn't support setting the baud rate"), feedback.ErrBadArgument)
+	}
+
+	portIn := newChanReader(portProxy)
+	setBaudrate := func(rate string) error {
+		return portProxy.Config(baudrate.GetSettingId(), rate)
+	}
+	progress := func(rate string) {
+		if !quiet {
+			feedback.Print(tr("Trying %s baud...", rate))
+		}
+	}
+	rate, err := detectBaudrate(portIn, setBaudrate, baudrateCandidates(baudrate.GetEnumValues()), progress)
+	if err != nil {
+		feedback.Fatal(tr("Error detecting the baud rate: %v", err), feedback.ErrGeneric)
+	}
+	if rate == "" {
+		feedback.Fatal(tr("Could not detect the baud rate: no readable data has been received at any rate"), feedback.ErrGeneric)
+	}
+	if !quiet {
+		feedback.Print(tr("Detected baud rate: %s", rate))
+	}
+	return portIn
+}
+
+// withDefaultSettings returns the monitor settings in configs, preceded by the
+// default settings not given in configs. Both are in the form `setting=value`.
+func withDefaultSettings(defaults, configs []string) []string {
+	res := []string{}
+	for _, def := range defaults {
+		setting, _, _ := strings.Cut(def, "=")
+		overridden := false
+		for _, config := range configs {
+			if k, _, ok := strings.Cut(config, "="); ok && strings.EqualFold(k, setting) {
+				overridden = true
+			}
+		}
+		if !overridden {
+			res = append(res, def)
+		}
+	}
+	return append(res, configs...)
+}
+
+// settingsList returns the given monitor settings in the form `setting=value`,
+// sorted by setting.
+func settingsList(config map[string]string) []string {
+	res := []string{}
+	for setting, value := range config {
+		res = append(res, setting+"="+value)
+	}
+	sort.Strings(res)
+	return res
+}
+
 func contains(s []string, searchterm string) bool {
 	for _, item := range s {
 		if strings.EqualFold(item, searchterm) {
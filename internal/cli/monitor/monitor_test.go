@@ -35,3 +35,13 @@ func TestTimeStampWriter(t *testing.T) {
 	// A timestamp should be inserted before the first char of the next line
 	require.Regexp(t, "^\n"+`\[\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\] bar`+"\n$", buf)
 }
+
+func TestWithDefaultSettings(t *testing.T) {
+	defaults := []string{"baudrate=115200", "parity=none"}
+	require.Equal(t, []string{"baudrate=115200", "parity=none"}, withDefaultSettings(defaults, nil))
+	require.Equal(t, []string{"parity=none", "BaudRate=9600"}, withDefaultSettings(defaults, []string{"BaudRate=9600"}))
+	// Settings given only by value don't override the defaults
+	require.Equal(t, []string{"baudrate=115200", "parity=none", "odd"}, withDefaultSettings(defaults, []string{"odd"}))
+	require.Equal(t, []string{"dtr=on"}, withDefaultSettings(nil, []string{"dtr=on"}))
+	require.Equal(t, defaults, settingsList(map[string]string{"parity": "none", "baudrate": "115200"}))
+}
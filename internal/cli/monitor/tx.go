@@ -0,0 +1,251 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/monitor"
+)
+
+// commandKey is the key (CTRL-T) that, followed by another key, changes
+// the options of the data sent while the monitor is running.
+const commandKey = 0x14
+
+// txOptions are the options of the data sent to the port
+type txOptions struct {
+	// lineEnding is the name of the line ending sent in place of the one
+	// typed by the user, if empty the line endings are sent as typed.
+	lineEnding string
+	echo       bool
+	// lines is the state of the control lines (dtr and rts) supported by
+	// the port, true if the line is asserted.
+	lines map[string]bool
+}
+
+// resetPulse is how long DTR is deasserted to reset the board
+var resetPulse = 250 * time.Millisecond
+
+// commandWriter runs the commands given with CTRL-T followed by a key and
+// forwards the other data.
+type commandWriter struct {
+	out     io.Writer
+	opts    *txOptions
+	print   func(msg string)
+	config  func(setting, value string) error
+	command bool
+}
+
+func (w *commandWriter) Write(buf []byte) (int, error) {
+	start := 0
+	for i, c := range buf {
+		if w.command {
+			w.command = false
+			w.run(c)
+			start = i + 1
+			continue
+		}
+		if c == commandKey {
+			if err := w.forward(buf[start:i]); err != nil {
+				return 0, err
+			}
+			w.command = true
+			start = i + 1
+		}
+	}
+	if err := w.forward(buf[start:]); err != nil {
+		return 0, err
+	}
+	return len(buf), nil
+}
+
+func (w *commandWriter) forward(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	_, err := w.out.Write(data)
+	return err
+}
+
+func (w *commandWriter) run(key byte) {
+	switch key {
+	case 'e', 'E':
+		w.opts.echo = !w.opts.echo
+		if w.opts.echo {
+			w.print(tr("Local echo enabled"))
+		} else {
+			w.print(tr("Local echo disabled"))
+		}
+	case 'l', 'L':
+		next := monitor.LineEndingNames[0]
+		for i, name := range monitor.LineEndingNames {
+			if name == w.opts.lineEnding && i+1 < len(monitor.LineEndingNames) {
+				next = monitor.LineEndingNames[i+1]
+			}
+		}
+		w.opts.lineEnding = next
+		w.print(tr("Line ending set to %s", next))
+	case 'd', 'D':
+		w.toggleLine("dtr")
+	case 'r', 'R':
+		w.toggleLine("rts")
+	case 'p', 'P':
+		w.resetBoard()
+	case commandKey:
+		// CTRL-T twice sends CTRL-T
+		w.out.Write([]byte{commandKey})
+	default:
+		w.print(tr("Monitor commands: CTRL-T E toggles the local echo, CTRL-T L changes the line ending, " +
+			"CTRL-T D toggles DTR, CTRL-T R toggles RTS, CTRL-T P resets the board with a DTR pulse, CTRL-T CTRL-T sends CTRL-T"))
+	}
+}
+
+// setLine asserts or deasserts a control line of the port
+func (w *commandWriter) setLine(line string, on bool) error {
+	value := "off"
+	if on {
+		value = "on"
+	}
+	if err := w.config(line, value); err != nil {
+		return err
+	}
+	w.opts.lines[line] = on
+	return nil
+}
+
+func (w *commandWriter) toggleLine(line string) {
+	on, ok := w.opts.lines[line]
+	if !ok {
+		w.print(tr("The port doesn't support changing %s", strings.ToUpper(line)))
+		return
+	}
+	if err := w.setLine(line, !on); err != nil {
+		w.print(tr("Error changing %[1]s: %[2]v", strings.ToUpper(line), err))
+	} else if !on {
+		w.print(tr("%s asserted", strings.ToUpper(line)))
+	} else {
+		w.print(tr("%s deasserted", strings.ToUpper(line)))
+	}
+}
+
+// resetBoard deasserts and asserts DTR, that resets the boards with the
+// auto-reset circuit.
+func (w *commandWriter) resetBoard() {
+	if _, ok := w.opts.lines["dtr"]; !ok {
+		w.print(tr("The port doesn't support changing %s", "DTR"))
+		return
+	}
+	if err := w.setLine("dtr", false); err != nil {
+		w.print(tr("Error resetting the board: %v", err))
+		return
+	}
+	time.Sleep(resetPulse)
+	if err := w.setLine("dtr", true); err != nil {
+		w.print(tr("Error resetting the board: %v", err))
+		return
+	}
+	w.print(tr("Board reset"))
+}
+
+// lineEndingWriter replaces the line endings typed by the user, that are
+// CR in raw mode and LF or CRLF otherwise, with the selected line ending.
+type lineEndingWriter struct {
+	out    io.Writer
+	opts   *txOptions
+	lastCR bool
+}
+
+func (w *lineEndingWriter) Write(buf []byte) (int, error) {
+	if w.opts.lineEnding == "" {
+		_, err := w.out.Write(buf)
+		return len(buf), err
+	}
+	ending := []byte(monitor.LineEndings[w.opts.lineEnding])
+	res := make([]byte, 0, len(buf))
+	for _, c := range buf {
+		switch {
+		case c == '\r':
+			res = append(res, ending...)
+		case c == '\n' && w.lastCR:
+			// The LF of a CRLF, the line ending has been already sent
+		case c == '\n':
+			res = append(res, ending...)
+		default:
+			res = append(res, c)
+		}
+		w.lastCR = c == '\r'
+	}
+	_, err := w.out.Write(res)
+	return len(buf), err
+}
+
+// txTeeWriter sends the data to the port and copies it to the terminal, if
+// the local echo is enabled, and to the TX log, if any.
+type txTeeWriter struct {
+	out    io.Writer
+	opts   *txOptions
+	tty    io.Writer
+	log    io.Writer
+	lastCR bool
+}
+
+func (w *txTeeWriter) Write(buf []byte) (int, error) {
+	n, err := w.out.Write(buf)
+	if w.log == nil && !w.opts.echo {
+		return n, err
+	}
+	lines := w.normalize(buf[:n])
+	if w.log != nil {
+		w.log.Write(lines)
+	}
+	if w.opts.echo {
+		// CRLF moves to a new line also when the terminal is in raw mode
+		w.tty.Write(bytes.ReplaceAll(lines, []byte("\n"), []byte("\r\n")))
+	}
+	return n, err
+}
+
+// normalize replaces the line endings (CR, LF or CRLF) with LF
+func (w *txTeeWriter) normalize(buf []byte) []byte {
+	res := make([]byte, 0, len(buf))
+	for _, c := range buf {
+		switch {
+		case c == '\r':
+			res = append(res, '\n')
+		case c == '\n' && w.lastCR:
+		default:
+			res = append(res, c)
+		}
+		w.lastCR = c == '\r'
+	}
+	return res
+}
+
+// syncWriter serializes the writes to the underlying writer
+type syncWriter struct {
+	mux    sync.Mutex
+	writer io.Writer
+}
+
+func (w *syncWriter) Write(buf []byte) (int, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.writer.Write(buf)
+}
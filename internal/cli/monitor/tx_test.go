@@ -0,0 +1,103 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package monitor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxWriters(t *testing.T) {
+	port := &bytes.Buffer{}
+	tty := &bytes.Buffer{}
+	log := &bytes.Buffer{}
+	messages := []string{}
+	opts := &txOptions{}
+	out := &commandWriter{
+		out: &lineEndingWriter{
+			out:  &txTeeWriter{out: port, opts: opts, tty: tty, log: log},
+			opts: opts,
+		},
+		opts:  opts,
+		print: func(msg string) { messages = append(messages, msg) },
+	}
+	write := func(data string) {
+		n, err := out.Write([]byte(data))
+		require.NoError(t, err)
+		require.Equal(t, len(data), n)
+	}
+
+	// Without a line ending the data is sent as typed
+	write("a\r")
+	require.Equal(t, "a\r", port.String())
+	require.Equal(t, "", tty.String())
+	require.Equal(t, "a\n", log.String())
+
+	// CTRL-T L cycles through the line endings, CTRL-T E toggles the echo
+	port.Reset()
+	log.Reset()
+	write("\x14l\x14e")
+	require.Equal(t, "none", opts.lineEnding)
+	require.True(t, opts.echo)
+	write("\x14")
+	write("lb\r")
+	require.Equal(t, "nl", opts.lineEnding)
+	require.Equal(t, "b\n", port.String())
+	require.Equal(t, "b\r\n", tty.String())
+	require.Equal(t, []string{"Line ending set to none", "Local echo enabled", "Line ending set to nl"}, messages)
+
+	port.Reset()
+	write("\x14l\x14l")
+	require.Equal(t, "nlcr", opts.lineEnding)
+	write("c\r\nd\ne\r")
+	require.Equal(t, "c\r\nd\r\ne\r\n", port.String())
+	require.Equal(t, "b\nc\nd\ne\n", log.String())
+
+	// CTRL-T twice sends CTRL-T
+	port.Reset()
+	write("\x14\x14")
+	require.Equal(t, "\x14", port.String())
+}
+
+func TestControlLines(t *testing.T) {
+	resetPulse = 0
+	messages := []string{}
+	settings := []string{}
+	opts := &txOptions{lines: map[string]bool{"dtr": true}}
+	out := &commandWriter{
+		out:   &bytes.Buffer{},
+		opts:  opts,
+		print: func(msg string) { messages = append(messages, msg) },
+		config: func(setting, value string) error {
+			settings = append(settings, setting+"="+value)
+			return nil
+		},
+	}
+
+	// CTRL-T D toggles DTR, RTS is not supported by the port
+	out.Write([]byte("\x14d\x14r"))
+	require.False(t, opts.lines["dtr"])
+	require.Equal(t, []string{"dtr=off"}, settings)
+	require.Equal(t, []string{"DTR deasserted", "The port doesn't support changing RTS"}, messages)
+
+	// CTRL-T P pulses DTR and leaves it asserted
+	settings = nil
+	out.Write([]byte("\x14p"))
+	require.True(t, opts.lines["dtr"])
+	require.Equal(t, []string{"dtr=off", "dtr=on"}, settings)
+}
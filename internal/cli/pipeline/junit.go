@@ -0,0 +1,121 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package pipeline
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/arduino/pipeline"
+	"github.com/arduino/go-paths-helper"
+)
+
+// The JUnit XML report has a test suite for each board and a test case for
+// each step of the pipeline. The steps skipped after a failure are reported
+// as skipped test cases.
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties []*junitProperty `xml:"properties>property,omitempty"`
+	Cases      []*junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Output  string `xml:",chardata"`
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// newJUnitReport converts the result of the pipeline to a JUnit report
+func newJUnitReport(name string, p *pipeline.Pipeline, res *pipelineResult) *junitTestSuites {
+	report := &junitTestSuites{Name: name, Time: junitTime(res.duration)}
+	for _, b := range res.Boards {
+		suiteName := fmt.Sprintf("%s (%s)", b.FQBN, b.Port)
+		suite := &junitTestSuite{
+			Name: suiteName,
+			Time: junitTime(b.duration),
+			Properties: []*junitProperty{
+				{Name: "port", Value: b.Port},
+				{Name: "protocol", Value: b.Protocol},
+				{Name: "fqbn", Value: b.FQBN},
+			},
+		}
+		if b.SerialNumber != "" {
+			suite.Properties = append(suite.Properties, &junitProperty{Name: "serial_number", Value: b.SerialNumber})
+		}
+		for i, step := range p.Steps {
+			testCase := &junitTestCase{Name: step.String(), Classname: suiteName, Time: junitTime(0)}
+			if i < len(b.Steps) {
+				stepRes := b.Steps[i]
+				testCase.Time = junitTime(stepRes.duration)
+				if !stepRes.Success {
+					testCase.Failure = &junitFailure{Message: stepRes.Error, Output: stepRes.Output}
+					suite.Failures++
+				}
+			} else {
+				testCase.Skipped = &struct{}{}
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+			suite.Tests++
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+// writeJUnitReport saves the result of the pipeline in a JUnit XML file, name
+// is the name of the whole report.
+func writeJUnitReport(file *paths.Path, name string, p *pipeline.Pipeline, res *pipelineResult) error {
+	data, err := xml.MarshalIndent(newJUnitReport(name, p, res), "", "  ")
+	if err != nil {
+		return err
+	}
+	return file.WriteFile(append([]byte(xml.Header), append(data, '\n')...))
+}
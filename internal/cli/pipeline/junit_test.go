@@ -0,0 +1,93 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package pipeline
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/arduino/arduino-cli/internal/arduino/pipeline"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitReport(t *testing.T) {
+	p := &pipeline.Pipeline{Steps: []*pipeline.Step{
+		{Upload: &pipeline.UploadStep{}},
+		{Name: "Self test", Expect: &pipeline.ExpectStep{}},
+		{EEPROM: &pipeline.EEPROMStep{}},
+	}}
+	res := &pipelineResult{
+		duration: 3 * time.Second,
+		Boards: []*boardResult{
+			{
+				Port: "/dev/ttyACM0", Protocol: "serial", FQBN: "arduino:avr:uno", SerialNumber: "1234", Success: true,
+				Steps: []*stepResult{
+					{Name: "upload", Success: true, duration: 1500 * time.Millisecond},
+					{Name: "Self test", Success: true, duration: 500 * time.Millisecond},
+					{Name: "eeprom", Success: true, duration: 250 * time.Millisecond},
+				},
+			},
+			{
+				Port: "/dev/ttyACM1", Protocol: "serial", FQBN: "arduino:avr:uno",
+				Steps: []*stepResult{
+					{Name: "upload", Success: true, duration: time.Second},
+					{Name: "Self test", Error: "timeout", Output: "FAIL", duration: 2 * time.Second},
+				},
+			},
+		},
+	}
+	report := newJUnitReport("station.yaml", p, res)
+	require.Equal(t, 6, report.Tests)
+	require.Equal(t, 1, report.Failures)
+	require.Equal(t, 1, report.Skipped)
+	require.Equal(t, "3.000", report.Time)
+	require.Len(t, report.Suites, 2)
+
+	require.Equal(t, "arduino:avr:uno (/dev/ttyACM0)", report.Suites[0].Name)
+	require.Contains(t, report.Suites[0].Properties, &junitProperty{Name: "serial_number", Value: "1234"})
+	require.Equal(t, "1.500", report.Suites[0].Cases[0].Time)
+
+	failed := report.Suites[1]
+	require.Len(t, failed.Properties, 3)
+	require.Equal(t, 1, failed.Failures)
+	require.Equal(t, 1, failed.Skipped)
+	require.Equal(t, "Self test", failed.Cases[1].Name)
+	require.Equal(t, &junitFailure{Message: "timeout", Output: "FAIL"}, failed.Cases[1].Failure)
+	require.NotNil(t, failed.Cases[2].Skipped)
+
+	data, err := xml.Marshal(failed.Cases[2])
+	require.NoError(t, err)
+	require.Equal(t, `<junitTestCase name="eeprom" classname="arduino:avr:uno (/dev/ttyACM1)" time="0.000"><skipped></skipped></junitTestCase>`, string(data))
+}
+
+func TestSelectTargetsBySerialNumber(t *testing.T) {
+	detected := []*rpc.DetectedPort{
+		{Port: &rpc.Port{Address: "/dev/ttyACM0", Properties: map[string]string{"serialNumber": "AAAA"}}},
+		{Port: &rpc.Port{Address: "/dev/ttyACM1", Properties: map[string]string{"serialNumber": "BBBB"}}},
+	}
+	p := &pipeline.Pipeline{Boards: []*pipeline.Board{
+		{FQBN: "arduino:avr:uno", SerialNumber: "bbbb", Sketch: "blink"},
+		{FQBN: "arduino:avr:nano", SerialNumber: "CCCC"},
+	}}
+	targets := selectTargets(p, detected, nil)
+	require.Len(t, targets, 1)
+	require.Equal(t, "/dev/ttyACM1", targets[0].port.GetAddress())
+	require.Equal(t, "arduino:avr:uno", targets[0].fqbn)
+	require.Equal(t, "blink", targets[0].sketch(""))
+	require.Equal(t, "other", targets[0].sketch("other"))
+}
@@ -0,0 +1,614 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arduino/arduino-cli/commands/board"
+	"github.com/arduino/arduino-cli/commands/compile"
+	"github.com/arduino/arduino-cli/commands/monitor"
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/arduino/pipeline"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initRunCommand() *cobra.Command {
+	var (
+		ports            []string
+		discoveryTimeout time.Duration
+		verbose          bool
+		parallel         bool
+		logDir           string
+		junitFile        string
+	)
+	runCommand := &cobra.Command{
+		Use:   fmt.Sprintf("run <%s>", tr("PIPELINE_FILE")),
+		Short: tr("Runs a provisioning pipeline on the connected boards."),
+		Long: tr("Runs the steps defined in a pipeline file (compile, burn-bootloader, upload, expect, eeprom) on each connected board. " +
+			"The boards are processed one at a time, or concurrently with --parallel. If a step fails the remaining steps are skipped for that board. " +
+			"The command fails if the pipeline fails on any board."),
+		Example: "" +
+			"  " + os.Args[0] + " pipeline run station.yaml\n" +
+			"  " + os.Args[0] + " pipeline run station.yaml --port /dev/ttyACM0 --port /dev/ttyACM1\n" +
+			"  " + os.Args[0] + " pipeline run lab.yaml --parallel --log-dir logs --junit report.xml",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := &runOptions{
+				ports:            ports,
+				discoveryTimeout: discoveryTimeout,
+				verbose:          verbose,
+				parallel:         parallel,
+			}
+			if logDir != "" {
+				opts.logDir = paths.New(logDir)
+			}
+			if junitFile != "" {
+				opts.junitFile = paths.New(junitFile)
+			}
+			runPipelineCommand(args[0], opts)
+		},
+	}
+	runCommand.Flags().StringSliceVarP(&ports, "port", "p", nil, tr("Run the pipeline only on the boards connected to the given ports."))
+	runCommand.Flags().DurationVar(&discoveryTimeout, "discovery-timeout", time.Second, tr("Max time to wait for port discovery, e.g.: 30s, 1m"))
+	runCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Print the output of each step."))
+	runCommand.Flags().BoolVar(&parallel, "parallel", false, tr("Run the pipeline on all the boards concurrently."))
+	runCommand.Flags().StringVar(&logDir, "log-dir", "", tr("Save the output of the pipeline of each board in a log file in the given directory."))
+	runCommand.Flags().StringVar(&junitFile, "junit", "", tr("Save a JUnit XML report of the pipeline in the given file."))
+	return runCommand
+}
+
+type runOptions struct {
+	ports            []string
+	discoveryTimeout time.Duration
+	verbose          bool
+	parallel         bool
+	logDir           *paths.Path
+	junitFile        *paths.Path
+}
+
+func runPipelineCommand(pipelineFile string, opts *runOptions) {
+	logrus.Info("Executing `arduino-cli pipeline run`")
+
+	p, err := pipeline.Load(paths.New(pipelineFile))
+	if err != nil {
+		feedback.Fatal(tr("Error loading pipeline: %v", err), feedback.ErrBadArgument)
+	}
+
+	inst := instance.CreateAndInit()
+	// The boards using a profile need a dedicated instance, with the
+	// platforms and libraries of the profile.
+	instances := map[*pipeline.Board]*rpc.Instance{}
+	profileInstances := map[string]*rpc.Instance{}
+	for _, b := range p.Boards {
+		if b.Profile == "" {
+			continue
+		}
+		key := b.Profile + "|" + b.Sketch
+		profileInst, ok := profileInstances[key]
+		if !ok {
+			var profile *rpc.SketchProfile
+			profileInst, profile = instance.CreateAndInitWithProfile(b.Profile, paths.New(b.Sketch))
+			profileInstances[key] = profileInst
+			if b.FQBN == "" {
+				b.FQBN = profile.GetFqbn()
+			}
+		}
+		if b.FQBN == "" {
+			feedback.Fatal(tr("The profile %s doesn't specify a board, set the fqbn in the pipeline.", b.Profile), feedback.ErrBadArgument)
+		}
+		instances[b] = profileInst
+	}
+
+	detected, _, err := board.List(&rpc.BoardListRequest{
+		Instance: inst,
+		Timeout:  opts.discoveryTimeout.Milliseconds(),
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error detecting boards: %v", err), feedback.ErrNetwork)
+	}
+	targets := selectTargets(p, detected, opts.ports)
+	if len(targets) == 0 {
+		feedback.Fatal(tr("No boards found for the pipeline."), feedback.ErrGeneric)
+	}
+	for _, t := range targets {
+		t.inst = inst
+		if profileInst, ok := instances[t.board]; ok {
+			t.inst = profileInst
+		}
+	}
+	if opts.logDir != nil {
+		if err := opts.logDir.MkdirAll(); err != nil {
+			feedback.Fatal(tr("Error creating the logs directory: %v", err), feedback.ErrGeneric)
+		}
+	}
+
+	r := &runner{
+		verbose:  opts.verbose,
+		parallel: opts.parallel,
+		logDir:   opts.logDir,
+		compiled: map[string]*compileJob{},
+	}
+	res := &pipelineResult{Success: true}
+	res.Boards = make([]*boardResult, len(targets))
+	start := time.Now()
+	if opts.parallel {
+		var wg sync.WaitGroup
+		for i, t := range targets {
+			wg.Add(1)
+			go func(i int, t *target) {
+				defer wg.Done()
+				res.Boards[i] = r.run(context.Background(), p, t)
+			}(i, t)
+		}
+		wg.Wait()
+	} else {
+		for i, t := range targets {
+			res.Boards[i] = r.run(context.Background(), p, t)
+		}
+	}
+	res.duration = time.Since(start)
+	for _, b := range res.Boards {
+		if !b.Success {
+			res.Success = false
+		}
+	}
+	if opts.junitFile != nil {
+		if err := writeJUnitReport(opts.junitFile, paths.New(pipelineFile).Base(), p, res); err != nil {
+			feedback.Fatal(tr("Error writing JUnit report: %v", err), feedback.ErrGeneric)
+		}
+	}
+	if !res.Success {
+		feedback.FatalResult(res, feedback.ErrGeneric)
+	}
+	feedback.PrintResult(res)
+}
+
+// target is a board the pipeline runs on
+type target struct {
+	port *rpc.Port
+	fqbn string
+	// board is the pipeline board matching the target, nil if the pipeline
+	// runs on all the detected boards.
+	board *pipeline.Board
+	inst  *rpc.Instance
+	// builds are the build directories of the sketches compiled for the
+	// target, used to upload them.
+	builds map[string]string
+	log    io.Writer
+}
+
+// sketch returns the sketch of a compile or upload step, or the sketch of
+// the board if the step doesn't specify one.
+func (t *target) sketch(sketch string) string {
+	if sketch == "" && t.board != nil {
+		return t.board.Sketch
+	}
+	return sketch
+}
+
+// selectTargets returns the boards, among the detected ones, selected by the
+// pipeline. If ports is not empty only the boards connected to those ports
+// are returned.
+func selectTargets(p *pipeline.Pipeline, detected []*rpc.DetectedPort, ports []string) []*target {
+	var res []*target
+	added := map[string]bool{}
+	add := func(port *rpc.Port, fqbn string, b *pipeline.Board) {
+		if added[port.GetAddress()] {
+			return
+		}
+		if len(ports) > 0 && !slices.Contains(ports, port.GetAddress()) {
+			return
+		}
+		added[port.GetAddress()] = true
+		res = append(res, &target{port: port, fqbn: fqbn, board: b, builds: map[string]string{}})
+	}
+	detectedFQBN := func(d *rpc.DetectedPort) string {
+		if boards := d.GetMatchingBoards(); len(boards) > 0 {
+			return boards[0].GetFqbn()
+		}
+		return ""
+	}
+
+	if len(p.Boards) == 0 {
+		for _, d := range detected {
+			if fqbn := detectedFQBN(d); fqbn != "" {
+				add(d.GetPort(), fqbn, nil)
+			}
+		}
+		return res
+	}
+	for _, b := range p.Boards {
+		switch {
+		case b.Port != "":
+			port := &rpc.Port{Address: b.Port, Protocol: "serial"}
+			for _, d := range detected {
+				if d.GetPort().GetAddress() == b.Port {
+					port = d.GetPort()
+				}
+			}
+			add(port, b.FQBN, b)
+		case b.SerialNumber != "":
+			for _, d := range detected {
+				if strings.EqualFold(portSerialNumber(d.GetPort()), b.SerialNumber) {
+					add(d.GetPort(), b.FQBN, b)
+				}
+			}
+		default:
+			for _, d := range detected {
+				if detectedFQBN(d) == b.FQBN {
+					add(d.GetPort(), b.FQBN, b)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// portSerialNumber returns the USB serial number of the board connected to
+// the port, if reported by the discovery.
+func portSerialNumber(port *rpc.Port) string {
+	return port.GetProperties()["serialNumber"]
+}
+
+type runner struct {
+	verbose  bool
+	parallel bool
+	logDir   *paths.Path
+
+	printMux sync.Mutex
+
+	// compiled keeps track of the sketches already compiled for each FQBN,
+	// to compile them only once when there are many boards of the same kind.
+	compiledMux sync.Mutex
+	compiled    map[string]*compileJob
+}
+
+// compileJob is the compilation of a sketch shared by the boards of the
+// same kind, the first board needing it runs it and the others wait.
+type compileJob struct {
+	once      sync.Once
+	buildPath *paths.Path
+	err       error
+}
+
+// print prints a message about the target and saves it in the log of the
+// target.
+func (r *runner) print(t *target, msg string) {
+	if t.log != nil {
+		fmt.Fprintln(t.log, msg)
+	}
+	r.display(t, msg)
+}
+
+// display prints a message about the target, when running in parallel each
+// line is prefixed with the port of the target.
+func (r *runner) display(t *target, msg string) {
+	r.printMux.Lock()
+	defer r.printMux.Unlock()
+	if !r.parallel {
+		feedback.Print(msg)
+		return
+	}
+	for _, line := range strings.Split(msg, "\n") {
+		feedback.Print(fmt.Sprintf("[%s] %s", t.port.GetAddress(), line))
+	}
+}
+
+// run executes the pipeline steps on the given board, stopping at the first
+// failed step.
+func (r *runner) run(ctx context.Context, p *pipeline.Pipeline, t *target) *boardResult {
+	res := &boardResult{
+		Port:         t.port.GetAddress(),
+		Protocol:     t.port.GetProtocol(),
+		FQBN:         t.fqbn,
+		SerialNumber: portSerialNumber(t.port),
+		Success:      true,
+	}
+	if r.logDir != nil {
+		logFile := r.logDir.Join(logFileName(t.port) + ".log")
+		if f, err := logFile.Create(); err != nil {
+			r.print(t, tr("Error creating log file: %v", err))
+		} else {
+			defer f.Close()
+			t.log = f
+			res.Log = logFile.String()
+		}
+	}
+
+	start := time.Now()
+	defer func() { res.duration = time.Since(start) }()
+	r.print(t, tr("Running pipeline on %[1]s (%[2]s)", res.Port, res.FQBN))
+	for i, step := range p.Steps {
+		r.print(t, fmt.Sprintf("  [%d/%d] %s", i+1, len(p.Steps), step))
+
+		output := &bytes.Buffer{}
+		stdOut, stdErr := io.Writer(output), io.Writer(output)
+		if t.log != nil {
+			stdOut = io.MultiWriter(output, t.log)
+			stdErr = stdOut
+		}
+		var lines *lineWriter
+		if r.verbose && r.parallel {
+			lines = &lineWriter{print: func(line string) { r.display(t, line) }}
+			stdOut = io.MultiWriter(stdOut, lines)
+			stdErr = stdOut
+		} else if r.verbose {
+			feedbackOut, feedbackErr, _ := feedback.OutputStreams()
+			stdOut = io.MultiWriter(stdOut, feedbackOut)
+			stdErr = io.MultiWriter(stdErr, feedbackErr)
+		}
+		stepStart := time.Now()
+		err := r.runStep(ctx, step, t, stdOut, stdErr)
+		if lines != nil {
+			lines.Flush()
+		}
+		stepRes := &stepResult{
+			Name:     step.String(),
+			Success:  err == nil,
+			duration: time.Since(stepStart),
+		}
+		stepRes.Duration = stepRes.duration.Round(time.Millisecond).String()
+		res.Steps = append(res.Steps, stepRes)
+		if err != nil {
+			stepRes.Error = err.Error()
+			stepRes.Output = output.String()
+			res.Success = false
+			if !r.verbose && output.Len() > 0 {
+				r.display(t, strings.TrimRight(output.String(), "\n"))
+			}
+			r.print(t, "  "+tr("Failed: %v", err))
+			break
+		}
+	}
+	return res
+}
+
+// logFileName returns the name of the log file of the board connected to the
+// port, the characters not allowed in file names are replaced.
+func logFileName(port *rpc.Port) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, port.GetAddress())
+	return strings.Trim(name, "_")
+}
+
+// compileBuildPath returns the build directory used to compile the sketch
+// for the board, the sketches compiled for different boards or with
+// different build properties must not share the same directory.
+func compileBuildPath(sketchPath, fqbn string, buildProperties []string) (*paths.Path, error) {
+	sk, err := sketch.New(paths.New(sketchPath))
+	if err != nil {
+		return nil, err
+	}
+	template, err := configuration.SketchBuildPathTemplate(configuration.Settings)
+	if err != nil {
+		return nil, err
+	}
+	return sk.VariantBuildPath(template, fqbn, buildProperties)
+}
+
+func (r *runner) runStep(ctx context.Context, step *pipeline.Step, t *target, stdOut, stdErr io.Writer) error {
+	switch {
+	case step.Compile != nil:
+		s := step.Compile
+		sketch := t.sketch(s.Sketch)
+		buildPath, err := compileBuildPath(sketch, t.fqbn, s.BuildProperties)
+		if err != nil {
+			return err
+		}
+		r.compiledMux.Lock()
+		job, ok := r.compiled[buildPath.String()]
+		if !ok {
+			job = &compileJob{buildPath: buildPath}
+			r.compiled[buildPath.String()] = job
+		}
+		r.compiledMux.Unlock()
+		job.once.Do(func() {
+			// When running in parallel the compilations of the boards are
+			// shown with a progress bar each
+			var progressCB rpc.TaskProgressCB
+			if r.parallel {
+				progressCB = feedback.NewTaskProgressBarCB(fmt.Sprintf("[%s] %s", t.port.GetAddress(), tr("Compiling %s", paths.New(sketch).Base())))
+				defer progressCB(&rpc.TaskProgress{Completed: true})
+			}
+			_, job.err = compile.Compile(ctx, &rpc.CompileRequest{
+				Instance:        t.inst,
+				Fqbn:            t.fqbn,
+				SketchPath:      sketch,
+				BuildPath:       buildPath.String(),
+				BuildProperties: s.BuildProperties,
+				Verbose:         r.verbose,
+			}, stdOut, stdErr, progressCB)
+		})
+		if job.err == nil {
+			t.builds[sketch] = job.buildPath.String()
+		}
+		return job.err
+	case step.BurnBootloader != nil:
+		s := step.BurnBootloader
+		_, err := upload.BurnBootloader(ctx, &rpc.BurnBootloaderRequest{
+			Instance:   t.inst,
+			Fqbn:       t.fqbn,
+			Port:       t.port,
+			Programmer: s.Programmer,
+			Verify:     s.Verify,
+			Verbose:    r.verbose,
+		}, stdOut, stdErr)
+		return err
+	case step.Upload != nil:
+		s := step.Upload
+		sketch := t.sketch(s.Sketch)
+		res, err := upload.Upload(ctx, &rpc.UploadRequest{
+			Instance:   t.inst,
+			Fqbn:       t.fqbn,
+			SketchPath: sketch,
+			ImportDir:  t.builds[sketch],
+			Port:       t.port,
+			Programmer: s.Programmer,
+			Verify:     s.Verify,
+			Verbose:    r.verbose,
+		}, stdOut, stdErr, nil)
+		if err != nil {
+			return err
+		}
+		// The board may reconnect on a different port after the upload
+		if updatedPort := res.GetUpdatedUploadPort(); updatedPort != nil {
+			t.port = updatedPort
+		}
+		return nil
+	case step.EEPROM != nil:
+		s := step.EEPROM
+		_, err := upload.WriteMemory(ctx, &rpc.WriteMemoryRequest{
+			Instance:   t.inst,
+			Fqbn:       t.fqbn,
+			Port:       t.port,
+			Programmer: s.Programmer,
+			MemoryType: "eeprom",
+			InputFile:  s.File,
+			Verify:     s.Verify,
+			Verbose:    r.verbose,
+		}, stdOut, stdErr)
+		return err
+	case step.Expect != nil:
+		return r.expect(ctx, step.Expect, t, stdOut)
+	}
+	return nil
+}
+
+// lineWriter calls print for each line written, the last line is buffered
+// until it's complete or Flush is called.
+type lineWriter struct {
+	mux     sync.Mutex
+	print   func(line string)
+	partial []byte
+}
+
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.partial = append(w.partial, data...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i == -1 {
+			break
+		}
+		w.print(strings.TrimRight(string(w.partial[:i]), "\r"))
+		w.partial = w.partial[i+1:]
+	}
+	return len(data), nil
+}
+
+// Flush prints the incomplete last line, if any
+func (w *lineWriter) Flush() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if len(w.partial) > 0 {
+		w.print(string(w.partial))
+		w.partial = nil
+	}
+}
+
+// expect opens the monitor port of the board, sends the given data and waits
+// for an answer matching the expected regular expression.
+func (r *runner) expect(ctx context.Context, s *pipeline.ExpectStep, t *target, stdOut io.Writer) error {
+	conf := &rpc.MonitorPortConfiguration{}
+	settings := make([]string, 0, len(s.Config))
+	for setting := range s.Config {
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+	for _, setting := range settings {
+		conf.Settings = append(conf.Settings, &rpc.MonitorPortSetting{SettingId: setting, Value: s.Config[setting]})
+	}
+	return monitor.Expect(ctx, &rpc.MonitorPortOpenRequest{
+		Instance:          t.inst,
+		Port:              t.port,
+		Fqbn:              t.fqbn,
+		PortConfiguration: conf,
+	}, []byte(s.Send), s.Regexp(), s.Timeout, stdOut)
+}
+
+type pipelineResult struct {
+	Boards   []*boardResult `json:"boards"`
+	Success  bool           `json:"success"`
+	duration time.Duration
+}
+
+type boardResult struct {
+	Port         string        `json:"port"`
+	Protocol     string        `json:"protocol"`
+	FQBN         string        `json:"fqbn"`
+	SerialNumber string        `json:"serial_number,omitempty"`
+	Success      bool          `json:"success"`
+	Steps        []*stepResult `json:"steps"`
+	Log          string        `json:"log,omitempty"`
+	duration     time.Duration
+}
+
+type stepResult struct {
+	Name     string `json:"name"`
+	Success  bool   `json:"success"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+	Output   string `json:"output,omitempty"`
+	duration time.Duration
+}
+
+func (r *pipelineResult) Data() interface{} {
+	return r
+}
+
+func (r *pipelineResult) String() string {
+	t := table.New()
+	t.SetHeader(tr("Port"), tr("FQBN"), tr("Result"), tr("Details"))
+	for _, b := range r.Boards {
+		if b.Success {
+			t.AddRow(b.Port, b.FQBN, table.NewCell(tr("PASS"), feedback.GetTheme().Success), "")
+			continue
+		}
+		failed := b.Steps[len(b.Steps)-1]
+		t.AddRow(b.Port, b.FQBN, table.NewCell(tr("FAIL"), feedback.GetTheme().Error), failed.Name+": "+failed.Error)
+	}
+	return t.Render()
+}
+
+func (r *pipelineResult) ErrorString() string {
+	if r.Success {
+		return ""
+	}
+	return tr("The pipeline failed on some boards.")
+}
@@ -0,0 +1,325 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package portability
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/compile"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries/librariesapi"
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/configuration"
+	"github.com/arduino/arduino-cli/internal/cli/core"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tr = i18n.Tr
+	// declarationRegexp matches the declarations of the variables, of the
+	// functions and of the parameters, like "int level =" or "void blink("
+	declarationRegexp = regexp.MustCompile(`\b([A-Za-z_][\w:]*(?:<[^;{}()]*>)?)[\s*&]+([A-Za-z_]\w*)\s*[=;,\[()]`)
+	// notTypes are the keywords that may precede a name without declaring it
+	notTypes = map[string]bool{"return": true, "else": true, "case": true, "goto": true, "new": true, "delete": true, "throw": true, "sizeof": true}
+)
+
+// NewCommand created a new `portability` command
+func NewCommand() *cobra.Command {
+	var (
+		targets         []string
+		buildProperties []string
+	)
+	portabilityCommand := &cobra.Command{
+		Use:   "portability [" + tr("SKETCH_PATH") + "]",
+		Short: tr("Shows the non-portable APIs used by a sketch."),
+		Long: tr("Preprocesses the headers included by the sketch, without its code, for a board of each target, and " +
+			"reports the headers that can't be found and the functions, variables, types and macros used by the sketch " +
+			"that are declared only on some of the targets, like the architecture-specific APIs and registers. " +
+			"A target is an architecture, using the first board of the installed platforms for it, or an FQBN. " +
+			"The names are found with a lexical scan of the sources, so the report is a hint to review rather than a definitive list."),
+		Example: "" +
+			"  " + os.Args[0] + " portability --targets avr,esp32,rp2040\n" +
+			"  " + os.Args[0] + " portability /home/user/Arduino/MySketch --targets arduino:avr:uno,arduino:samd:mkr1000\n",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			runPortabilityCommand(path, targets, buildProperties)
+		},
+	}
+	portabilityCommand.Flags().StringSliceVar(&targets, "targets", nil, tr("The architectures or the FQBNs to compare, at least two. Can be used multiple times or entries can be comma separated."))
+	portabilityCommand.Flags().StringArrayVar(&buildProperties, "build-property", nil, tr("Override a build property with a custom value. Can be used multiple times for multiple properties."))
+	portabilityCommand.MarkFlagRequired("targets")
+	return portabilityCommand
+}
+
+func runPortabilityCommand(path string, targets, buildProperties []string) {
+	logrus.Info("Executing `arduino-cli portability`")
+
+	if len(targets) < 2 {
+		feedback.Fatal(tr("At least two targets must be given to compare them."), feedback.ErrBadArgument)
+	}
+	sketchPath := arguments.InitSketchPath(path)
+	sk, err := sketch.New(sketchPath)
+	if err != nil {
+		feedback.FatalError(err, feedback.ErrGeneric)
+	}
+
+	inst := instance.CreateAndInit()
+	platforms := core.GetList(inst, false, false)
+	res := []*portabilityTarget{}
+	for _, target := range targets {
+		fqbn := target
+		if !strings.Contains(target, ":") {
+			fqbn = architectureFQBN(platforms, target)
+			if fqbn == "" {
+				feedback.Fatal(tr("No installed platform for the %[1]s architecture, install one with `%[2]s`.", target, "core install"), feedback.ErrBadArgument)
+			}
+		}
+		res = append(res, headerDeclarations(inst, sk, target, fqbn, buildProperties))
+	}
+
+	portability := checkPortability(sk, res)
+	if !portability.Portable {
+		feedback.FatalResult(portability, feedback.ErrGeneric)
+	}
+	feedback.PrintResult(portability)
+}
+
+// architectureFQBN returns the FQBN of the first board of the first installed
+// platform for the architecture, or an empty string if there is none.
+func architectureFQBN(platforms []*rpc.PlatformSummary, arch string) string {
+	for _, platform := range platforms {
+		id := platform.GetMetadata().GetId()
+		if id[strings.Index(id, ":")+1:] != arch {
+			continue
+		}
+		if boards := platform.GetInstalledRelease().GetBoards(); len(boards) > 0 {
+			return boards[0].GetFqbn()
+		}
+	}
+	return ""
+}
+
+// headerDeclarations preprocesses the headers included by the sketch for the
+// board and returns the names they declare.
+func headerDeclarations(inst *rpc.Instance, sk *sketch.Sketch, target, fqbn string, buildProperties []string) *portabilityTarget {
+	output := &bytes.Buffer{}
+	t := &portabilityTarget{Name: target, FQBN: fqbn, names: map[string]bool{}}
+	buildPath, err := targetBuildPath(sk, fqbn, buildProperties)
+	if err != nil {
+		t.Error = err.Error()
+		return t
+	}
+	res, err := compile.Compile(context.Background(), &rpc.CompileRequest{
+		Instance:                 inst,
+		Fqbn:                     fqbn,
+		SketchPath:               sk.FullPath.String(),
+		BuildPath:                buildPath.String(),
+		BuildProperties:          buildProperties,
+		ReportHeaderDeclarations: true,
+	}, output, output, nil)
+	if err != nil {
+		t.Error = err.Error()
+		t.Output = output.String()
+		return t
+	}
+	t.MissingHeaders = res.GetHeaderDeclarations().GetMissingHeaders()
+	for _, name := range res.GetHeaderDeclarations().GetNames() {
+		t.names[name] = true
+	}
+	return t
+}
+
+// targetBuildPath returns the build directory of the sketch for the board, it
+// doesn't change between runs so the libraries detection is cached.
+func targetBuildPath(sk *sketch.Sketch, fqbn string, buildProperties []string) (*paths.Path, error) {
+	template, err := configuration.SketchBuildPathTemplate(configuration.Settings)
+	if err != nil {
+		return nil, err
+	}
+	return sk.VariantBuildPath(template, fqbn, buildProperties)
+}
+
+// checkPortability compares the names used by the sketch with the ones
+// declared for each target: the names declared only for some of the targets
+// and the headers missing for some of the targets are reported.
+func checkPortability(sk *sketch.Sketch, targets []*portabilityTarget) *portabilityResult {
+	res := &portabilityResult{Sketch: sk.Name, Targets: targets, Issues: []*portabilityIssue{}}
+
+	missingHeaders := map[string][]string{}
+	available := []*portabilityTarget{}
+	for _, target := range targets {
+		if target.Error != "" {
+			continue
+		}
+		available = append(available, target)
+		for _, header := range target.MissingHeaders {
+			missingHeaders[header] = append(missingHeaders[header], target.Name)
+		}
+	}
+	for header, missingOn := range missingHeaders {
+		res.Issues = append(res.Issues, &portabilityIssue{Kind: "header", Name: header, MissingOn: missingOn})
+	}
+
+	used := map[string]bool{}
+	declared := map[string]bool{}
+	for _, file := range sketchSources(sk) {
+		data, err := file.ReadFile()
+		if err != nil {
+			logrus.WithError(err).Warnf("Reading %s", file)
+			continue
+		}
+		for _, name := range librariesapi.UsedNames(string(data)) {
+			used[name] = true
+		}
+		for _, name := range librariesapi.DeclaredNames(string(data)) {
+			declared[name] = true
+		}
+		for _, m := range declarationRegexp.FindAllStringSubmatch(string(data), -1) {
+			if !notTypes[m[1]] {
+				declared[m[2]] = true
+			}
+		}
+	}
+	for name := range used {
+		if declared[name] {
+			continue
+		}
+		missingOn := []string{}
+		for _, target := range available {
+			if !target.names[name] {
+				missingOn = append(missingOn, target.Name)
+			}
+		}
+		if len(missingOn) > 0 && len(missingOn) < len(available) {
+			res.Issues = append(res.Issues, &portabilityIssue{Kind: "symbol", Name: name, MissingOn: missingOn})
+		}
+	}
+
+	sort.Slice(res.Issues, func(i, j int) bool {
+		if res.Issues[i].Kind != res.Issues[j].Kind {
+			return res.Issues[i].Kind == "header"
+		}
+		return res.Issues[i].Name < res.Issues[j].Name
+	})
+	res.Portable = len(res.Issues) == 0 && len(available) == len(targets)
+	return res
+}
+
+func sketchSources(sk *sketch.Sketch) paths.PathList {
+	files := paths.PathList{sk.MainFile}
+	files = append(files, sk.OtherSketchFiles...)
+	files = append(files, sk.AdditionalFiles...)
+	return files
+}
+
+type portabilityTarget struct {
+	Name           string   `json:"name"`
+	FQBN           string   `json:"fqbn"`
+	MissingHeaders []string `json:"missing_headers,omitempty"`
+	Error          string   `json:"error,omitempty"`
+	Output         string   `json:"output,omitempty"`
+	names          map[string]bool
+}
+
+type portabilityIssue struct {
+	// Kind is "header" for a missing header or "symbol" for a name that is
+	// not declared
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// MissingOn are the targets where the header or the name are not
+	// available
+	MissingOn []string `json:"missing_on"`
+}
+
+type portabilityResult struct {
+	Sketch   string               `json:"sketch"`
+	Targets  []*portabilityTarget `json:"targets"`
+	Issues   []*portabilityIssue  `json:"issues"`
+	Portable bool                 `json:"portable"`
+}
+
+func (r *portabilityResult) Data() interface{} {
+	return r
+}
+
+func (r *portabilityResult) String() string {
+	theme := feedback.GetTheme()
+	res := ""
+	if len(r.Issues) == 0 {
+		res = tr("No non-portable APIs used by the sketch.")
+	} else {
+		t := table.New()
+		header := []interface{}{tr("Not portable")}
+		for _, target := range r.Targets {
+			if target.Error == "" {
+				header = append(header, target.Name)
+			}
+		}
+		t.SetHeader(header...)
+		for _, issue := range r.Issues {
+			name := issue.Name
+			if issue.Kind == "header" {
+				name = "#include <" + name + ">"
+			}
+			row := []interface{}{name}
+			for _, target := range r.Targets {
+				if target.Error != "" {
+					continue
+				}
+				if slices.Contains(issue.MissingOn, target.Name) {
+					row = append(row, table.NewCell("-", theme.Error))
+				} else {
+					row = append(row, table.NewCell(tr("yes"), theme.Success))
+				}
+			}
+			t.AddRow(row...)
+		}
+		res = t.Render()
+	}
+	for _, target := range r.Targets {
+		if target.Error != "" {
+			res += "\n" + theme.Error.Sprint(tr("%[1]s (%[2]s) failed: %[3]s", target.Name, target.FQBN, target.Error)) + "\n" + strings.TrimRight(target.Output, "\n")
+		}
+	}
+	return strings.TrimRight(res, "\n")
+}
+
+func (r *portabilityResult) ErrorString() string {
+	if r.Portable {
+		return ""
+	}
+	if len(r.Issues) == 0 {
+		return tr("The sketch could not be checked for all the targets.")
+	}
+	return tr("The sketch uses APIs not available on all the targets.")
+}
@@ -0,0 +1,60 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package portability
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/sketch"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPortability(t *testing.T) {
+	sketchDir := paths.New(t.TempDir(), "MySketch")
+	require.NoError(t, sketchDir.MkdirAll())
+	require.NoError(t, sketchDir.Join("MySketch.ino").WriteFile([]byte(
+		"#include <avr/io.h>\n"+
+			"int level = 0;\n"+
+			"void blink(int pin) { digitalWrite(pin, level); }\n"+
+			"void setup() { analogWriteResolution(12); PORTB = 1; blink(3); }\n"+
+			"void loop() { Serial.print(\"PORTB\"); }\n")))
+	sk, err := sketch.New(sketchDir)
+	require.NoError(t, err)
+
+	targets := []*portabilityTarget{
+		{Name: "avr", FQBN: "arduino:avr:uno", names: map[string]bool{
+			"setup": true, "loop": true, "digitalWrite": true, "Serial": true, "PORTB": true, "level": true}},
+		{Name: "samd", FQBN: "arduino:samd:mkr1000", MissingHeaders: []string{"avr/io.h"}, names: map[string]bool{
+			"setup": true, "loop": true, "digitalWrite": true, "Serial": true, "analogWriteResolution": true}},
+		{Name: "esp32", FQBN: "esp32:esp32:esp32", MissingHeaders: []string{"avr/io.h"}, names: map[string]bool{
+			"setup": true, "loop": true, "digitalWrite": true, "Serial": true, "analogWriteResolution": true}},
+	}
+	res := checkPortability(sk, targets)
+	require.False(t, res.Portable)
+	require.Equal(t, []*portabilityIssue{
+		{Kind: "header", Name: "avr/io.h", MissingOn: []string{"samd", "esp32"}},
+		{Kind: "symbol", Name: "PORTB", MissingOn: []string{"samd", "esp32"}},
+		{Kind: "symbol", Name: "analogWriteResolution", MissingOn: []string{"avr"}},
+	}, res.Issues)
+
+	// The targets failing to compile are not compared
+	targets[1].Error = "Compilation failed"
+	targets[2].Error = "Compilation failed"
+	res = checkPortability(sk, targets)
+	require.False(t, res.Portable)
+	require.Empty(t, res.Issues)
+}
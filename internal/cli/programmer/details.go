@@ -0,0 +1,118 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package programmer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/result"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initDetailsCommand() *cobra.Command {
+	var fqbn arguments.Fqbn
+	detailsCommand := &cobra.Command{
+		Use:   fmt.Sprintf("details <%s> -b <%s>", tr("programmer"), tr("FQBN")),
+		Short: tr("Print the operations supported by a programmer."),
+		Long:  tr("Show the operations that a programmer supports with a board (upload, chip erase, bootloader burning, fuses and memory access), the tool used and how the programmer is connected."),
+		Example: "  " + os.Args[0] + " programmer details usbasp -b arduino:avr:uno\n" +
+			"  " + os.Args[0] + " programmer details atmel_ice -b arduino:samd:mkrwifi1010",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runDetailsCommand(args[0], fqbn.String())
+		},
+	}
+	fqbn.AddToCommand(detailsCommand)
+	detailsCommand.MarkFlagRequired("fqbn")
+	return detailsCommand
+}
+
+func runDetailsCommand(programmer, fqbn string) {
+	inst := instance.CreateAndInit()
+	logrus.Info("Executing `arduino-cli programmer details`")
+
+	res, err := upload.ProgrammerDetails(context.Background(), &rpc.ProgrammerDetailsRequest{
+		Instance:   inst,
+		Fqbn:       fqbn,
+		Programmer: programmer,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error getting programmer details: %v", err), feedback.ErrGeneric)
+	}
+	feedback.PrintResult(&detailsResult{details: result.NewProgrammerDetailsResponse(res)})
+}
+
+type detailsResult struct {
+	details *result.ProgrammerDetailsResponse
+}
+
+func (dr *detailsResult) Data() interface{} {
+	return dr.details
+}
+
+func (dr *detailsResult) String() string {
+	details := dr.details
+	t := table.New()
+	addIfNotEmpty := func(label, content string) {
+		if content != "" {
+			t.AddRow(label, content)
+		}
+	}
+	t.AddRow(tr("Programmer name:"), details.Programmer.Name)
+	t.AddRow(tr("Id:"), details.Programmer.Id)
+	t.AddRow(tr("Platform:"), details.Programmer.Platform)
+	addIfNotEmpty(tr("Tool:"), details.Tool)
+	addIfNotEmpty(tr("Protocol:"), details.Protocol)
+	addIfNotEmpty(tr("Communication:"), details.Communication)
+	addIfNotEmpty(tr("Wiring:"), details.Wiring)
+	if details.RequiresPort {
+		t.AddRow(tr("Port:"), tr("required"))
+	} else {
+		t.AddRow(tr("Port:"), tr("not required"))
+	}
+
+	t.AddRow() // get some space from above
+	green := feedback.GetTheme().Success
+	red := feedback.GetTheme().Error
+	header := tr("Operations:")
+	addOperation := func(label string, supported bool) {
+		if supported {
+			t.AddRow(header, table.NewCell("✔", green), label)
+		} else {
+			t.AddRow(header, table.NewCell("✘", red), label)
+		}
+		header = ""
+	}
+	operations := details.Operations
+	addOperation(tr("Upload"), operations.Upload)
+	addOperation(tr("Chip erase"), operations.Erase)
+	addOperation(tr("Burn bootloader"), operations.BurnBootloader)
+	addOperation(tr("Write fuses"), operations.Fuses)
+	addOperation(tr("Read memory"), operations.MemoryRead)
+	addOperation(tr("Write memory"), operations.MemoryWrite)
+	addOperation(tr("Flash readout protection"), operations.FlashProtection)
+	addOperation(tr("Power measurement"), operations.PowerMeasurement)
+	return t.Render()
+}
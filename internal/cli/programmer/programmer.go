@@ -0,0 +1,40 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package programmer
+
+import (
+	"os"
+
+	"github.com/arduino/arduino-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `programmer` command
+func NewCommand() *cobra.Command {
+	programmerCommand := &cobra.Command{
+		Use:   "programmer",
+		Short: tr("Arduino programmer commands."),
+		Long:  tr("Arduino programmer commands."),
+		Example: "  # " + tr("Show the operations supported by a programmer with a board.") + "\n" +
+			"  " + os.Args[0] + " programmer details usbasp -b arduino:avr:uno",
+	}
+
+	programmerCommand.AddCommand(initDetailsCommand())
+
+	return programmerCommand
+}
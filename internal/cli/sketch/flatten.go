@@ -0,0 +1,126 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketch
+
+import (
+	"context"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// initFlattenCommand creates a new `flatten` command
+func initFlattenCommand() *cobra.Command {
+	var (
+		fqbnArg            arguments.Fqbn
+		profileArg         arguments.Profile
+		inlineHeaders      bool
+		keepLineDirectives bool
+		output             string
+	)
+
+	flattenCommand := &cobra.Command{
+		Use:   "flatten",
+		Short: tr("Merges the sketch files in a single source file."),
+		Long: tr("Merges the sketch files, together with the generated function prototypes, in a single preprocessed source file. " +
+			"The result can be shared to reproduce an issue without the whole sketch."),
+		Example: "" +
+			"  " + os.Args[0] + " sketch flatten -b arduino:avr:uno\n" +
+			"  " + os.Args[0] + " sketch flatten -b arduino:avr:uno --inline-headers /home/user/Arduino/MySketch\n" +
+			"  " + os.Args[0] + " sketch flatten -b arduino:avr:uno -o MySketch.cpp /home/user/Arduino/MySketch",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runFlattenCommand(args, &fqbnArg, &profileArg, inlineHeaders, keepLineDirectives, output)
+		},
+	}
+
+	fqbnArg.AddToCommand(flattenCommand)
+	profileArg.AddToCommand(flattenCommand)
+	flattenCommand.Flags().BoolVar(&inlineHeaders, "inline-headers", false, tr("Replaces the inclusions of the sketch headers with their content."))
+	flattenCommand.Flags().BoolVar(&keepLineDirectives, "keep-line-directives", false, tr("Keeps the %s directives pointing to the original sketch files.", "#line"))
+	flattenCommand.Flags().StringVarP(&output, "output", "o", "", tr("Saves the flattened sketch to the given file instead of printing it."))
+
+	return flattenCommand
+}
+
+func runFlattenCommand(args []string, fqbnArg *arguments.Fqbn, profileArg *arguments.Profile, inlineHeaders, keepLineDirectives bool, output string) {
+	logrus.Info("Executing `arduino-cli sketch flatten`")
+
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	sketchPath := arguments.InitSketchPath(path)
+	sk, err := sketch.LoadSketch(context.Background(), &rpc.LoadSketchRequest{SketchPath: sketchPath.String()})
+	if err != nil {
+		feedback.FatalError(err, feedback.ErrGeneric)
+	}
+	feedback.WarnAboutDeprecatedFiles(sk)
+
+	profileName := profileArg.Get()
+	if profileName == "" {
+		profileName = sk.GetDefaultProfile().GetName()
+	}
+	inst, profile := instance.CreateAndInitWithProfile(profileName, sketchPath)
+	if fqbnArg.String() == "" {
+		fqbnArg.Set(profile.GetFqbn())
+	}
+
+	res, err := sketch.FlattenSketch(context.Background(), &rpc.FlattenSketchRequest{
+		Instance:           inst,
+		SketchPath:         sketchPath.String(),
+		Fqbn:               fqbnArg.String(),
+		InlineHeaders:      inlineHeaders,
+		KeepLineDirectives: keepLineDirectives,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error flattening sketch: %v", err), feedback.ErrGeneric)
+	}
+
+	if output == "" {
+		feedback.PrintResult(&flattenResult{Source: res.GetSource()})
+		return
+	}
+	outputPath := paths.New(output)
+	if err := outputPath.WriteFile([]byte(res.GetSource())); err != nil {
+		feedback.Fatal(tr("Error saving flattened sketch: %v", err), feedback.ErrGeneric)
+	}
+	feedback.PrintResult(&flattenResult{OutputPath: outputPath})
+}
+
+type flattenResult struct {
+	Source     string      `json:"source,omitempty"`
+	OutputPath *paths.Path `json:"output_path,omitempty"`
+}
+
+func (r *flattenResult) Data() interface{} {
+	return r
+}
+
+func (r *flattenResult) String() string {
+	if r.OutputPath != nil {
+		return tr("Flattened sketch saved in: %s", r.OutputPath)
+	}
+	return r.Source
+}
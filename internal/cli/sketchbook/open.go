@@ -0,0 +1,74 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketchbook
+
+import (
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initOpenCommand() *cobra.Command {
+	openCommand := &cobra.Command{
+		Use:   "open NAME",
+		Short: tr("Prints the location of a sketch of the sketchbook."),
+		Long:  tr("Looks for the sketch with the given name in the sketchbook and prints its location, so it can be opened in an editor or used in scripts."),
+		Example: "  " + os.Args[0] + " sketchbook open Blink\n" +
+			"  cd $(" + os.Args[0] + " sketchbook open Blink)",
+		Args: cobra.ExactArgs(1),
+		Run:  runOpenCommand,
+	}
+	return openCommand
+}
+
+func runOpenCommand(cmd *cobra.Command, args []string) {
+	logrus.Info("Executing `arduino-cli sketchbook open`")
+	name := args[0]
+
+	var found []*sketchbookSketch
+	for _, sk := range listSketches([]string{name}, "").GetSketches() {
+		if s := newSketchbookSketch(sk); strings.EqualFold(s.Name, name) {
+			found = append(found, s)
+		}
+	}
+	switch len(found) {
+	case 0:
+		feedback.Fatal(tr("Sketch %s not found in the sketchbook", name), feedback.ErrBadArgument)
+	case 1:
+		feedback.PrintResult(&openResult{Sketch: found[0]})
+	default:
+		paths := []string{}
+		for _, s := range found {
+			paths = append(paths, s.Path)
+		}
+		feedback.Fatal(tr("Multiple sketches named %[1]s found in the sketchbook: %[2]s", name, strings.Join(paths, ", ")), feedback.ErrBadArgument)
+	}
+}
+
+type openResult struct {
+	Sketch *sketchbookSketch `json:"sketch"`
+}
+
+func (r *openResult) Data() interface{} {
+	return r
+}
+
+func (r *openResult) String() string {
+	return r.Sketch.Path
+}
@@ -0,0 +1,41 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketchbook
+
+import (
+	"os"
+
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func initSearchCommand() *cobra.Command {
+	var fqbn string
+	searchCommand := &cobra.Command{
+		Use:   "search [KEYWORDS...]",
+		Short: tr("Searches for sketches in the sketchbook."),
+		Long:  tr("Searches for sketches in the sketchbook whose name contains all the given keywords."),
+		Example: "  " + os.Args[0] + " sketchbook search blink\n" +
+			"  " + os.Args[0] + " sketchbook search weather -b esp32:esp32",
+		Run: func(cmd *cobra.Command, args []string) {
+			logrus.Info("Executing `arduino-cli sketchbook search`")
+			feedback.PrintResult(newSketchbookListResult(listSketches(args, fqbn)))
+		},
+	}
+	searchCommand.Flags().StringVarP(&fqbn, "fqbn", "b", "", tr("Show only the sketches using this board, a partial FQBN like arduino:avr selects all the boards of the platform."))
+	return searchCommand
+}
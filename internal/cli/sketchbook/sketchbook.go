@@ -0,0 +1,136 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sketchbook
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/arduino/arduino-cli/commands/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/arduino/go-paths-helper"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tr             = i18n.Tr
+	sketchbookPath string
+)
+
+// NewCommand created a new `sketchbook` command
+func NewCommand() *cobra.Command {
+	sketchbookCommand := &cobra.Command{
+		Use:   "sketchbook",
+		Short: tr("Arduino CLI sketchbook commands."),
+		Long:  tr("Arduino CLI sketchbook commands."),
+		Example: "  " + os.Args[0] + " sketchbook list\n" +
+			"  " + os.Args[0] + " sketchbook search blink -b arduino:avr",
+	}
+
+	sketchbookCommand.PersistentFlags().StringVar(&sketchbookPath, "sketchbook", "", tr("The sketchbook folder, if not set the user directory from the configuration is used."))
+	sketchbookCommand.AddCommand(initListCommand())
+	sketchbookCommand.AddCommand(initSearchCommand())
+	sketchbookCommand.AddCommand(initOpenCommand())
+
+	return sketchbookCommand
+}
+
+// listSketches returns the sketches of the sketchbook matching the given
+// search arguments and FQBN.
+func listSketches(searchArgs []string, fqbn string) *rpc.SketchbookListResponse {
+	res, err := sketch.SketchbookList(context.Background(), &rpc.SketchbookListRequest{
+		SketchbookPath: sketchbookPath,
+		SearchArgs:     strings.Join(searchArgs, " "),
+		Fqbn:           fqbn,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error listing sketches: %v", err), feedback.ErrGeneric)
+	}
+	return res
+}
+
+type sketchbookSketch struct {
+	Name           string   `json:"name"`
+	Path           string   `json:"path"`
+	MainFile       string   `json:"main_file"`
+	DefaultFqbn    string   `json:"default_fqbn,omitempty"`
+	DefaultPort    string   `json:"default_port,omitempty"`
+	DefaultProfile string   `json:"default_profile,omitempty"`
+	Profiles       []string `json:"profiles,omitempty"`
+}
+
+func newSketchbookSketch(sk *rpc.Sketch) *sketchbookSketch {
+	res := &sketchbookSketch{
+		Name:           paths.New(sk.GetLocationPath()).Base(),
+		Path:           sk.GetLocationPath(),
+		MainFile:       sk.GetMainFile(),
+		DefaultFqbn:    sk.GetDefaultFqbn(),
+		DefaultPort:    sk.GetDefaultPort(),
+		DefaultProfile: sk.GetDefaultProfile().GetName(),
+	}
+	for _, profile := range sk.GetProfiles() {
+		res.Profiles = append(res.Profiles, profile.GetName())
+	}
+	return res
+}
+
+type sketchbookInvalidSketch struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+type sketchbookListResult struct {
+	Sketches        []*sketchbookSketch        `json:"sketches"`
+	InvalidSketches []*sketchbookInvalidSketch `json:"invalid_sketches,omitempty"`
+}
+
+func newSketchbookListResult(res *rpc.SketchbookListResponse) *sketchbookListResult {
+	out := &sketchbookListResult{Sketches: []*sketchbookSketch{}}
+	for _, sk := range res.GetSketches() {
+		out.Sketches = append(out.Sketches, newSketchbookSketch(sk))
+	}
+	for _, invalid := range res.GetInvalidSketches() {
+		out.InvalidSketches = append(out.InvalidSketches, &sketchbookInvalidSketch{
+			Path:  invalid.GetPath(),
+			Error: invalid.GetError(),
+		})
+	}
+	return out
+}
+
+func (r *sketchbookListResult) Data() interface{} {
+	return r
+}
+
+func (r *sketchbookListResult) String() string {
+	if len(r.Sketches) == 0 {
+		return tr("No sketches found.")
+	}
+	t := table.New()
+	t.SetHeader(tr("Name"), tr("FQBN"), tr("Profiles"), tr("Path"))
+	for _, sk := range r.Sketches {
+		t.AddRow(sk.Name, sk.DefaultFqbn, strings.Join(sk.Profiles, ", "), sk.Path)
+	}
+	res := t.Render()
+	for _, invalid := range r.InvalidSketches {
+		res += "\n" + tr("Invalid sketch %[1]s: %[2]s", invalid.Path, invalid.Error)
+	}
+	return res
+}
@@ -0,0 +1,122 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package symbolize
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/feedback/table"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `symbolize` command
+func NewCommand() *cobra.Command {
+	symbolizeCommand := &cobra.Command{
+		Use:   fmt.Sprintf("symbolize <%s> <%s>...", tr("build-uuid"), tr("address")),
+		Short: tr("Resolves the addresses reported by a firmware."),
+		Long: tr("Resolves the addresses reported by a firmware, for example in a crash report, to functions and source lines. "+
+			"The firmware must have been compiled with the %s flag, that saves the executable in the symbols archive.", "--export-symbols"),
+		Example: "  " + os.Args[0] + " symbolize 7d1c3b5e-6a4f-4c7e-9f3b-2a1d8e6c5b4a 0x1f4 0x3a8",
+		Args:    cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			runSymbolizeCommand(args[0], args[1:])
+		},
+	}
+	return symbolizeCommand
+}
+
+func runSymbolizeCommand(buildUUID string, addresses []string) {
+	logrus.Info("Executing `arduino-cli symbolize`")
+
+	resp, err := commands.Symbolize(context.Background(), &rpc.SymbolizeRequest{
+		BuildUuid: buildUUID,
+		Addresses: addresses,
+	})
+	if err != nil {
+		feedback.Fatal(tr("Error resolving the addresses: %v", err), feedback.ErrGeneric)
+	}
+
+	res := &symbolizeResult{Addresses: []*symbolizedAddress{}}
+	for _, address := range resp.GetAddresses() {
+		entry := &symbolizedAddress{Address: address.GetAddress(), Locations: []*sourceLocation{}}
+		for _, location := range address.GetLocations() {
+			entry.Locations = append(entry.Locations, &sourceLocation{
+				Function: location.GetFunction(),
+				File:     location.GetFile(),
+				Line:     location.GetLine(),
+			})
+		}
+		res.Addresses = append(res.Addresses, entry)
+	}
+	feedback.PrintResult(res)
+}
+
+type sourceLocation struct {
+	Function string `json:"function,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int64  `json:"line,omitempty"`
+}
+
+type symbolizedAddress struct {
+	Address   string            `json:"address"`
+	Locations []*sourceLocation `json:"locations"`
+}
+
+type symbolizeResult struct {
+	Addresses []*symbolizedAddress `json:"addresses"`
+}
+
+func (r *symbolizeResult) Data() interface{} {
+	return r
+}
+
+func (r *symbolizeResult) String() string {
+	t := table.New()
+	t.SetHeader(tr("Address"), tr("Function"), tr("Location"))
+	for _, address := range r.Addresses {
+		if len(address.Locations) == 0 {
+			t.AddRow(address.Address, "??", "??")
+			continue
+		}
+		for i, location := range address.Locations {
+			addr := address.Address
+			if i > 0 {
+				// Inlined functions are listed below the address
+				addr = ""
+			}
+			function, file := location.Function, location.File
+			if function == "" {
+				function = "??"
+			}
+			if file == "" {
+				file = "??"
+			} else if location.Line > 0 {
+				file = fmt.Sprintf("%s:%d", file, location.Line)
+			}
+			t.AddRow(addr, function, file)
+		}
+	}
+	return t.Render()
+}
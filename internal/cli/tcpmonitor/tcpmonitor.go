@@ -0,0 +1,47 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tcpmonitor
+
+import (
+	"os"
+
+	"github.com/arduino/arduino-cli/internal/arduino/tcpmonitor"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	monitor "github.com/arduino/pluggable-monitor-protocol-handler"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `tcp-monitor` command. The command is hidden, it
+// runs the built-in TCP pluggable monitor that is started by the CLI itself
+// when no platform provides a monitor for the `tcp` protocol.
+func NewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:    "tcp-monitor",
+		Short:  tr("Runs the TCP pluggable monitor."),
+		Long:   tr("Runs the TCP pluggable monitor."),
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			server := monitor.NewServer(tcpmonitor.NewMonitor())
+			if err := server.Run(os.Stdin, os.Stdout); err != nil {
+				feedback.FatalError(err, feedback.ErrGeneric)
+			}
+		},
+	}
+}
@@ -0,0 +1,264 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+	"golang.org/x/term"
+)
+
+// screenLine is a line of the content of a pane
+type screenLine struct {
+	text     string
+	selected bool
+}
+
+// pane is one of the screens of the TUI, selected with the function keys
+type pane interface {
+	// title is the name of the pane in the tab bar
+	title() string
+	// help describes the keys handled by the pane
+	help() string
+	// render returns the content of the pane, at most height lines
+	render(width, height int) []screenLine
+	// handleKey processes a key pressed while the pane is active
+	handleKey(k key)
+}
+
+// app is the state of the TUI. The state is changed only by the main loop:
+// the goroutines running the commands post their updates with app.post.
+type app struct {
+	inst       *rpc.Instance
+	sketchPath *paths.Path
+	fqbn       string
+	port       *rpc.Port
+
+	panes  []pane
+	active int
+	status string
+	quit   bool
+	events chan func()
+	out    io.Writer
+}
+
+func newApp(inst *rpc.Instance, sketchPath *paths.Path, fqbn string, port *rpc.Port, out io.Writer) *app {
+	a := &app{
+		inst:       inst,
+		sketchPath: sketchPath,
+		fqbn:       fqbn,
+		port:       port,
+		events:     make(chan func(), 256),
+		out:        out,
+	}
+	a.panes = []pane{
+		newBoardsPane(a),
+		newLibrariesPane(a),
+		newCompilePane(a),
+		newMonitorPane(a),
+	}
+	return a
+}
+
+// post schedules a change of the state in the main loop
+func (a *app) post(f func()) {
+	a.events <- f
+}
+
+// setStatus shows a message in the status bar, until the next key press
+func (a *app) setStatus(msg string) {
+	a.status = msg
+}
+
+// run processes the keys read from in until the user quits
+func (a *app) run(in io.Reader) {
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				a.post(func() { a.quit = true })
+				return
+			}
+			keys := parseKeys(buf[:n])
+			a.post(func() {
+				for _, k := range keys {
+					a.handleKey(k)
+				}
+			})
+		}
+	}()
+	go func() {
+		// Redraw when the terminal is resized
+		width, height := a.size()
+		for range time.Tick(250 * time.Millisecond) {
+			if w, h := a.size(); w != width || h != height {
+				width, height = w, h
+				a.post(func() {})
+			}
+		}
+	}()
+
+	a.panes[0].(*boardsPane).refresh()
+	a.draw()
+	for !a.quit {
+		f := <-a.events
+		f()
+		a.draw()
+	}
+}
+
+// close stops the running commands and releases the port
+func (a *app) close() {
+	for _, p := range a.panes {
+		switch p := p.(type) {
+		case *compilePane:
+			if p.cancel != nil {
+				p.cancel()
+			}
+		case *monitorPane:
+			p.close()
+		}
+	}
+}
+
+func (a *app) handleKey(k key) {
+	a.status = ""
+	switch k.code {
+	case keyCtrlC, keyCtrlQ:
+		a.quit = true
+	case keyTab:
+		a.active = (a.active + 1) % len(a.panes)
+	case keyShiftTab:
+		a.active = (a.active + len(a.panes) - 1) % len(a.panes)
+	case keyF1, keyF2, keyF3, keyF4:
+		a.active = int(k.code - keyF1)
+	default:
+		a.panes[a.active].handleKey(k)
+	}
+}
+
+// size returns the size of the terminal
+func (a *app) size() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+func (a *app) draw() {
+	width, height := a.size()
+	var b strings.Builder
+	b.WriteString(escCursorHome)
+
+	// Tab bar
+	used := 0
+	for i, p := range a.panes {
+		label := fmt.Sprintf(" F%d %s ", i+1, p.title())
+		if used+len(label) > width {
+			break
+		}
+		used += len(label)
+		if i == a.active {
+			b.WriteString(highlight(label))
+		} else {
+			b.WriteString(label)
+		}
+	}
+	b.WriteString(strings.Repeat(" ", width-used) + escNewLine)
+
+	// Current sketch, board and port
+	sketch, board, port := "-", "-", "-"
+	if a.sketchPath != nil {
+		sketch = a.sketchPath.Base()
+	}
+	if a.fqbn != "" {
+		board = a.fqbn
+	}
+	if a.port != nil && a.port.GetAddress() != "" {
+		port = a.port.GetAddress()
+	}
+	info := fmt.Sprintf(" %s: %s   %s: %s   %s: %s", tr("Sketch"), sketch, tr("Board"), board, tr("Port"), port)
+	b.WriteString(escBold + fitLine(info, width) + escReset + escNewLine)
+	b.WriteString(strings.Repeat("─", width) + escNewLine)
+
+	// Content of the active pane
+	contentHeight := max(height-5, 1)
+	lines := a.panes[a.active].render(width, contentHeight)
+	for i := 0; i < contentHeight; i++ {
+		if i < len(lines) {
+			line := fitLine(lines[i].text, width)
+			if lines[i].selected {
+				line = highlight(line)
+			}
+			b.WriteString(line)
+		} else {
+			b.WriteString(strings.Repeat(" ", width))
+		}
+		b.WriteString(escNewLine)
+	}
+
+	// Status bar
+	b.WriteString(strings.Repeat("─", width) + escNewLine)
+	footer := a.status
+	if footer == "" {
+		footer = a.panes[a.active].help() + "  " + tr("Tab: next pane  Ctrl-Q: quit")
+	}
+	b.WriteString(fitLine(" "+footer, width))
+	io.WriteString(a.out, b.String())
+}
+
+// listWindow returns the range of the items of a list to show in a pane of
+// the given height, keeping the selected item visible
+func listWindow(selected, count, height int) (int, int) {
+	if height <= 0 || count == 0 {
+		return 0, 0
+	}
+	start := 0
+	if selected >= height {
+		start = selected - height + 1
+	}
+	return start, min(start+height, count)
+}
+
+// moveSelection returns the selection moved by the navigation key, and true
+// if the key has been handled
+func moveSelection(k key, selected, count int) (int, bool) {
+	switch k.code {
+	case keyUp:
+		selected--
+	case keyDown:
+		selected++
+	case keyPageUp:
+		selected -= 10
+	case keyPageDown:
+		selected += 10
+	case keyHome:
+		selected = 0
+	case keyEnd:
+		selected = count - 1
+	default:
+		return selected, false
+	}
+	return max(0, min(selected, count-1)), true
+}
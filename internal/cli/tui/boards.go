@@ -0,0 +1,110 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"fmt"
+
+	"github.com/arduino/arduino-cli/commands/board"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// boardsPane lists the detected ports to pick the board to work with
+type boardsPane struct {
+	app      *app
+	ports    []*rpc.DetectedPort
+	selected int
+	loading  bool
+	err      error
+}
+
+func newBoardsPane(a *app) *boardsPane {
+	return &boardsPane{app: a}
+}
+
+func (p *boardsPane) title() string {
+	return tr("Boards")
+}
+
+func (p *boardsPane) help() string {
+	return tr("Enter: select board  r: refresh")
+}
+
+func (p *boardsPane) refresh() {
+	if p.loading {
+		return
+	}
+	p.loading = true
+	go func() {
+		ports, _, err := board.List(&rpc.BoardListRequest{Instance: p.app.inst, Timeout: 1000})
+		p.app.post(func() {
+			p.loading = false
+			p.ports, p.err = ports, err
+			p.selected = max(0, min(p.selected, len(p.ports)-1))
+		})
+	}()
+}
+
+func (p *boardsPane) render(width, height int) []screenLine {
+	if p.loading && len(p.ports) == 0 {
+		return []screenLine{{text: tr("Searching for boards...")}}
+	}
+	if p.err != nil {
+		return []screenLine{{text: tr("Error detecting boards: %v", p.err)}}
+	}
+	if len(p.ports) == 0 {
+		return []screenLine{{text: tr("No boards found.")}}
+	}
+	lines := []screenLine{{text: fmt.Sprintf("   %-24s %-10s %-30s %s", tr("Port"), tr("Protocol"), tr("Board Name"), tr("FQBN"))}}
+	start, end := listWindow(p.selected, len(p.ports), height-1)
+	for i := start; i < end; i++ {
+		detected := p.ports[i]
+		name, fqbn := tr("Unknown"), ""
+		if boards := detected.GetMatchingBoards(); len(boards) > 0 {
+			name, fqbn = boards[0].GetName(), boards[0].GetFqbn()
+		}
+		mark := " "
+		if p.app.port != nil && p.app.port.GetAddress() == detected.GetPort().GetAddress() && p.app.port.GetProtocol() == detected.GetPort().GetProtocol() {
+			mark = "*"
+		}
+		lines = append(lines, screenLine{
+			text:     fmt.Sprintf(" %s %-24s %-10s %-30s %s", mark, detected.GetPort().GetAddress(), detected.GetPort().GetProtocol(), name, fqbn),
+			selected: i == p.selected,
+		})
+	}
+	return lines
+}
+
+func (p *boardsPane) handleKey(k key) {
+	if selected, ok := moveSelection(k, p.selected, len(p.ports)); ok {
+		p.selected = selected
+		return
+	}
+	switch {
+	case k.code == keyRune && k.r == 'r':
+		p.refresh()
+	case k.code == keyEnter:
+		if p.selected >= len(p.ports) {
+			return
+		}
+		detected := p.ports[p.selected]
+		p.app.port = detected.GetPort()
+		if boards := detected.GetMatchingBoards(); len(boards) > 0 {
+			p.app.fqbn = boards[0].GetFqbn()
+		}
+		p.app.setStatus(tr("Selected port %s", p.app.port.GetAddress()))
+	}
+}
@@ -0,0 +1,123 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"context"
+
+	"github.com/arduino/arduino-cli/commands/compile"
+	"github.com/arduino/arduino-cli/commands/upload"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// compilePane compiles and uploads the sketch showing the live output
+type compilePane struct {
+	app     *app
+	output  outputView
+	running bool
+	cancel  context.CancelFunc
+}
+
+func newCompilePane(a *app) *compilePane {
+	return &compilePane{app: a}
+}
+
+func (p *compilePane) title() string {
+	return tr("Compile")
+}
+
+func (p *compilePane) help() string {
+	if p.running {
+		return tr("Esc: stop")
+	}
+	return tr("c: compile  u: compile and upload  x: clear")
+}
+
+func (p *compilePane) start(doUpload bool) {
+	if p.running {
+		return
+	}
+	if p.app.sketchPath == nil {
+		p.app.setStatus(tr("No sketch selected"))
+		return
+	}
+	if p.app.fqbn == "" {
+		p.app.setStatus(tr("No board selected"))
+		return
+	}
+	if doUpload && p.app.port == nil {
+		p.app.setStatus(tr("No port selected"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.running, p.cancel = true, cancel
+	p.output.clear()
+	out := &outputWriter{app: p.app, view: &p.output}
+	inst, fqbn, port, sketchPath := p.app.inst, p.app.fqbn, p.app.port, p.app.sketchPath.String()
+	go func() {
+		defer cancel()
+		res, err := compile.Compile(ctx, &rpc.CompileRequest{
+			Instance:   inst,
+			Fqbn:       fqbn,
+			SketchPath: sketchPath,
+		}, out, out, func(*rpc.TaskProgress) {})
+		if err == nil && doUpload {
+			_, err = upload.Upload(ctx, &rpc.UploadRequest{
+				Instance:   inst,
+				Fqbn:       fqbn,
+				SketchPath: sketchPath,
+				Port:       port,
+			}, out, out, func(*rpc.TaskProgress) {})
+		}
+		p.app.post(func() {
+			p.running, p.cancel = false, nil
+			p.output.println("")
+			for _, section := range res.GetExecutableSectionsSize() {
+				if section.GetMaxSize() > 0 {
+					p.output.println(tr("%[1]s: %[2]d of %[3]d bytes used", section.GetName(), section.GetSize(), section.GetMaxSize()))
+				}
+			}
+			switch {
+			case err != nil:
+				p.output.println(tr("Error: %v", err))
+				p.app.setStatus(tr("Failed"))
+			case doUpload:
+				p.app.setStatus(tr("Upload completed"))
+			default:
+				p.app.setStatus(tr("Compilation completed"))
+			}
+		})
+	}()
+}
+
+func (p *compilePane) render(width, height int) []screenLine {
+	return p.output.render(height)
+}
+
+func (p *compilePane) handleKey(k key) {
+	if p.output.handleScroll(k) {
+		return
+	}
+	switch {
+	case k.code == keyEscape && p.cancel != nil:
+		p.cancel()
+	case k.code == keyRune && (k.r == 'c' || k.r == 'u'):
+		p.start(k.r == 'u')
+	case k.code == keyRune && k.r == 'x' && !p.running:
+		p.output.clear()
+	}
+}
@@ -0,0 +1,193 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arduino/arduino-cli/commands/lib"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// librariesPane searches the libraries in the index and installs them
+type librariesPane struct {
+	app       *app
+	query     string
+	editing   bool
+	results   []*rpc.SearchedLibrary
+	installed map[string]string
+	selected  int
+	busy      bool
+}
+
+func newLibrariesPane(a *app) *librariesPane {
+	return &librariesPane{app: a, editing: true, installed: map[string]string{}}
+}
+
+func (p *librariesPane) title() string {
+	return tr("Libraries")
+}
+
+func (p *librariesPane) help() string {
+	if p.editing {
+		return tr("Enter: search  Down: results")
+	}
+	return tr("Enter/i: install  /: search")
+}
+
+func (p *librariesPane) search() {
+	if p.busy {
+		return
+	}
+	p.busy = true
+	query := p.query
+	go func() {
+		resp, err := lib.LibrarySearch(context.Background(), &rpc.LibrarySearchRequest{
+			Instance:            p.app.inst,
+			SearchArgs:          query,
+			OmitReleasesDetails: true,
+		})
+		installed := p.listInstalled()
+		p.app.post(func() {
+			p.busy = false
+			if err != nil {
+				p.app.setStatus(tr("Error searching libraries: %v", err))
+				return
+			}
+			p.results, p.installed, p.selected = resp.GetLibraries(), installed, 0
+			p.editing = len(p.results) == 0
+			p.app.setStatus(tr("%d libraries found", len(p.results)))
+		})
+	}()
+}
+
+// listInstalled returns the versions of the installed libraries, it's
+// called outside of the main loop
+func (p *librariesPane) listInstalled() map[string]string {
+	installed := map[string]string{}
+	resp, err := lib.LibraryList(context.Background(), &rpc.LibraryListRequest{Instance: p.app.inst})
+	if err != nil {
+		return installed
+	}
+	for _, l := range resp.GetInstalledLibraries() {
+		installed[l.GetLibrary().GetName()] = l.GetLibrary().GetVersion()
+	}
+	return installed
+}
+
+func (p *librariesPane) install(name string) {
+	if p.busy {
+		return
+	}
+	p.busy = true
+	p.app.setStatus(tr("Installing %s...", name))
+	go func() {
+		downloadCB := func(progress *rpc.DownloadProgress) {
+			if update := progress.GetUpdate(); update != nil && update.GetTotalSize() > 0 {
+				p.app.post(func() {
+					p.app.setStatus(tr("Downloading %[1]s: %[2]s of %[3]s", name, feedback.FormatSize(update.GetDownloaded()), feedback.FormatSize(update.GetTotalSize())))
+				})
+			}
+		}
+		taskCB := func(progress *rpc.TaskProgress) {
+			if msg := progress.GetName(); msg != "" {
+				p.app.post(func() { p.app.setStatus(msg) })
+			}
+		}
+		err := lib.LibraryInstall(context.Background(), &rpc.LibraryInstallRequest{Instance: p.app.inst, Name: name}, downloadCB, taskCB)
+		installed := p.listInstalled()
+		p.app.post(func() {
+			p.busy = false
+			p.installed = installed
+			if err != nil {
+				p.app.setStatus(tr("Error installing %[1]s: %[2]v", name, err))
+			} else {
+				p.app.setStatus(tr("Installed %s", name))
+			}
+		})
+	}()
+}
+
+func (p *librariesPane) render(width, height int) []screenLine {
+	cursor := ""
+	if p.editing {
+		cursor = "_"
+	}
+	lines := []screenLine{{text: fmt.Sprintf(" %s: %s%s", tr("Search"), p.query, cursor), selected: p.editing}, {}}
+	if p.busy && len(p.results) == 0 {
+		return append(lines, screenLine{text: tr("Searching...")})
+	}
+
+	// The last lines describe the selected library
+	start, end := listWindow(p.selected, len(p.results), height-5)
+	for i := start; i < end; i++ {
+		library := p.results[i]
+		status := ""
+		if version, ok := p.installed[library.GetName()]; ok {
+			status = tr("installed %s", version)
+		} else if i == p.selected && !p.editing {
+			status = "[ " + tr("Install") + " ]"
+		}
+		lines = append(lines, screenLine{
+			text:     fmt.Sprintf(" %-40s %-12s %s", library.GetName(), library.GetLatest().GetVersion(), status),
+			selected: i == p.selected && !p.editing,
+		})
+	}
+	if p.selected < len(p.results) {
+		latest := p.results[p.selected].GetLatest()
+		lines = append(lines, screenLine{}, screenLine{text: " " + latest.GetSentence()}, screenLine{text: " " + latest.GetAuthor()})
+	}
+	return lines
+}
+
+func (p *librariesPane) handleKey(k key) {
+	if p.editing {
+		switch k.code {
+		case keyRune:
+			p.query += string(k.r)
+		case keyBackspace:
+			if runes := []rune(p.query); len(runes) > 0 {
+				p.query = string(runes[:len(runes)-1])
+			}
+		case keyEnter:
+			p.search()
+		case keyDown, keyEscape:
+			if len(p.results) > 0 {
+				p.editing = false
+			}
+		}
+		return
+	}
+
+	if k.code == keyUp && p.selected == 0 {
+		p.editing = true
+		return
+	}
+	if selected, ok := moveSelection(k, p.selected, len(p.results)); ok {
+		p.selected = selected
+		return
+	}
+	switch {
+	case k.code == keyRune && k.r == '/':
+		p.editing = true
+	case k.code == keyEnter || (k.code == keyRune && k.r == 'i'):
+		if p.selected < len(p.results) {
+			p.install(p.results[p.selected].GetName())
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"context"
+
+	"github.com/arduino/arduino-cli/commands/monitor"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+)
+
+// monitorPane shows the data received from the selected port and sends the
+// lines typed by the user
+type monitorPane struct {
+	app     *app
+	output  outputView
+	proxy   *monitor.PortProxy
+	address string
+	input   string
+}
+
+func newMonitorPane(a *app) *monitorPane {
+	return &monitorPane{app: a}
+}
+
+func (p *monitorPane) title() string {
+	return tr("Monitor")
+}
+
+func (p *monitorPane) help() string {
+	if p.proxy != nil {
+		return tr("Enter: send line  Esc: disconnect")
+	}
+	return tr("o: connect")
+}
+
+func (p *monitorPane) open() {
+	if p.app.port == nil || p.app.port.GetAddress() == "" {
+		p.app.setStatus(tr("No port selected"))
+		return
+	}
+	proxy, _, err := monitor.Monitor(context.Background(), &rpc.MonitorPortOpenRequest{
+		Instance: p.app.inst,
+		Port:     p.app.port,
+		Fqbn:     p.app.fqbn,
+	})
+	if err != nil {
+		p.app.setStatus(tr("Error opening the monitor: %v", err))
+		return
+	}
+	p.proxy, p.address = proxy, p.app.port.GetAddress()
+	p.output.println(tr("Connected to %s", p.address))
+	out := &outputWriter{app: p.app, view: &p.output}
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := proxy.Read(buf)
+			if n > 0 {
+				out.Write(buf[:n])
+			}
+			if err != nil {
+				p.app.post(func() {
+					if p.proxy == proxy {
+						p.close()
+					}
+				})
+				return
+			}
+		}
+	}()
+}
+
+func (p *monitorPane) close() {
+	if p.proxy == nil {
+		return
+	}
+	p.proxy.Close()
+	p.proxy = nil
+	p.output.println(tr("Disconnected from %s", p.address))
+}
+
+func (p *monitorPane) render(width, height int) []screenLine {
+	lines := p.output.render(height - 2)
+	for len(lines) < height-2 {
+		lines = append(lines, screenLine{})
+	}
+	if p.proxy != nil {
+		lines = append(lines, screenLine{}, screenLine{text: "> " + p.input + "_", selected: true})
+	}
+	return lines
+}
+
+func (p *monitorPane) handleKey(k key) {
+	if p.output.handleScroll(k) {
+		return
+	}
+	if p.proxy == nil {
+		if k.code == keyRune && k.r == 'o' {
+			p.open()
+		}
+		return
+	}
+	switch k.code {
+	case keyRune:
+		p.input += string(k.r)
+	case keyBackspace:
+		if runes := []rune(p.input); len(runes) > 0 {
+			p.input = string(runes[:len(runes)-1])
+		}
+	case keyEnter:
+		if _, err := p.proxy.Write([]byte(p.input + "\n")); err != nil {
+			p.app.setStatus(tr("Error writing to the port: %v", err))
+		}
+		p.input = ""
+	case keyEscape:
+		p.close()
+	}
+}
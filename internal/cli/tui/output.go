@@ -0,0 +1,109 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+// maxOutputLines is the number of lines kept by an output view
+const maxOutputLines = 5000
+
+// outputView is a scrollable view of the output of a tool or of a board
+type outputView struct {
+	lines []string
+	rest  string
+	// scroll is the number of lines hidden at the bottom, 0 to follow the
+	// new output
+	scroll int
+}
+
+func (o *outputView) append(data []byte) {
+	before := o.count()
+	lines, rest := splitLines(o.rest, data)
+	o.rest = rest
+	o.lines = append(o.lines, lines...)
+	if len(o.lines) > maxOutputLines {
+		o.lines = o.lines[len(o.lines)-maxOutputLines:]
+	}
+	if o.scroll > 0 {
+		// Keep the scrolled view still
+		o.scroll = max(0, min(o.scroll+o.count()-before, len(o.lines)))
+	}
+}
+
+// count returns the number of lines, including the incomplete last line
+func (o *outputView) count() int {
+	if o.rest != "" {
+		return len(o.lines) + 1
+	}
+	return len(o.lines)
+}
+
+func (o *outputView) println(line string) {
+	o.append([]byte(line + "\n"))
+}
+
+func (o *outputView) clear() {
+	o.lines, o.rest, o.scroll = nil, "", 0
+}
+
+func (o *outputView) render(height int) []screenLine {
+	all := o.lines
+	if o.rest != "" {
+		all = append(all[:len(all):len(all)], o.rest)
+	}
+	end := len(all) - o.scroll
+	start := max(0, end-height)
+	res := []screenLine{}
+	for _, line := range all[start:end] {
+		res = append(res, screenLine{text: line})
+	}
+	return res
+}
+
+// handleScroll processes the navigation keys, it returns true if the key has
+// been handled
+func (o *outputView) handleScroll(k key) bool {
+	switch k.code {
+	case keyUp:
+		o.scroll++
+	case keyDown:
+		o.scroll--
+	case keyPageUp:
+		o.scroll += 10
+	case keyPageDown:
+		o.scroll -= 10
+	case keyHome:
+		o.scroll = len(o.lines)
+	case keyEnd:
+		o.scroll = 0
+	default:
+		return false
+	}
+	o.scroll = max(0, min(o.scroll, len(o.lines)))
+	return true
+}
+
+// outputWriter forwards the data written by the commands to an output view,
+// through the main loop of the app
+type outputWriter struct {
+	app  *app
+	view *outputView
+}
+
+func (w *outputWriter) Write(data []byte) (int, error) {
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	w.app.post(func() { w.view.append(buf) })
+	return len(data), nil
+}
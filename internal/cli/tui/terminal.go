@@ -0,0 +1,200 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// keyCode identifies the special keys, the printable characters are reported
+// as keyRune
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyTab
+	keyShiftTab
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyF1
+	keyF2
+	keyF3
+	keyF4
+	keyCtrlC
+	keyCtrlQ
+)
+
+// key is a key press read from the terminal
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// escapeSequences maps the escape sequences sent by the terminals to the
+// special keys, the longest sequences must be matched first
+var escapeSequences = []struct {
+	seq  string
+	code keyCode
+}{
+	{"\x1b[5~", keyPageUp},
+	{"\x1b[6~", keyPageDown},
+	{"\x1b[1~", keyHome},
+	{"\x1b[4~", keyEnd},
+	{"\x1b[11~", keyF1},
+	{"\x1b[12~", keyF2},
+	{"\x1b[13~", keyF3},
+	{"\x1b[14~", keyF4},
+	{"\x1b[A", keyUp},
+	{"\x1b[B", keyDown},
+	{"\x1b[C", keyRight},
+	{"\x1b[D", keyLeft},
+	{"\x1b[H", keyHome},
+	{"\x1b[F", keyEnd},
+	{"\x1b[Z", keyShiftTab},
+	{"\x1bOA", keyUp},
+	{"\x1bOB", keyDown},
+	{"\x1bOC", keyRight},
+	{"\x1bOD", keyLeft},
+	{"\x1bOP", keyF1},
+	{"\x1bOQ", keyF2},
+	{"\x1bOR", keyF3},
+	{"\x1bOS", keyF4},
+}
+
+// parseKeys decodes the keys contained in a chunk of data read from a
+// terminal in raw mode. The unknown escape sequences are ignored.
+func parseKeys(data []byte) []key {
+	keys := []key{}
+	s := string(data)
+	for len(s) > 0 {
+		if s[0] == 0x1b {
+			matched := false
+			for _, e := range escapeSequences {
+				if strings.HasPrefix(s, e.seq) {
+					keys = append(keys, key{code: e.code})
+					s = s[len(e.seq):]
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			if len(s) > 1 && (s[1] == '[' || s[1] == 'O') {
+				// Skip an unknown CSI or SS3 sequence up to its final byte
+				end := 2
+				for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+					end++
+				}
+				s = s[min(end+1, len(s)):]
+				continue
+			}
+			keys = append(keys, key{code: keyEscape})
+			s = s[1:]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		switch r {
+		case '\r', '\n':
+			keys = append(keys, key{code: keyEnter})
+		case '\t':
+			keys = append(keys, key{code: keyTab})
+		case 0x7f, 0x08:
+			keys = append(keys, key{code: keyBackspace})
+		case 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+		case 0x11:
+			keys = append(keys, key{code: keyCtrlQ})
+		default:
+			if unicode.IsPrint(r) {
+				keys = append(keys, key{code: keyRune, r: r})
+			}
+		}
+	}
+	return keys
+}
+
+// fitLine returns the line cut or padded to exactly width characters. The
+// tabs are expanded and the other control characters are removed, so that
+// the output of the tools and of the boards can't move the cursor.
+func fitLine(line string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range line {
+		if n >= width {
+			break
+		}
+		if r == '\t' {
+			for spaces := 4 - n%4; spaces > 0 && n < width; spaces-- {
+				b.WriteRune(' ')
+				n++
+			}
+			continue
+		}
+		if !unicode.IsPrint(r) {
+			continue
+		}
+		b.WriteRune(r)
+		n++
+	}
+	if n < width {
+		b.WriteString(strings.Repeat(" ", width-n))
+	}
+	return b.String()
+}
+
+// splitLines splits the data received from a tool or a board in lines, the
+// last incomplete line is returned as rest to be completed by the next data.
+func splitLines(rest string, data []byte) ([]string, string) {
+	text := rest + strings.ReplaceAll(string(data), "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	return lines[:len(lines)-1], lines[len(lines)-1]
+}
+
+const (
+	escClearScreen  = "\x1b[2J"
+	escCursorHome   = "\x1b[H"
+	escHideCursor   = "\x1b[?25l"
+	escShowCursor   = "\x1b[?25h"
+	escAltScreenOn  = "\x1b[?1049h"
+	escAltScreenOff = "\x1b[?1049l"
+	escReverse      = "\x1b[7m"
+	escBold         = "\x1b[1m"
+	escReset        = "\x1b[0m"
+	escNewLine      = "\r\n"
+)
+
+// highlight returns the line, already fit to the screen width, in reverse
+// video
+func highlight(line string) string {
+	return escReverse + line + escReset
+}
@@ -0,0 +1,80 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeys(t *testing.T) {
+	keys := parseKeys([]byte("a\x1b[A\x1b[B\x1bOP\x1b[5~\r\x7fè\x1b[Z\x1b\x11\x03\x1b[1;5C\t"))
+	require.Equal(t, []key{
+		{code: keyRune, r: 'a'},
+		{code: keyUp},
+		{code: keyDown},
+		{code: keyF1},
+		{code: keyPageUp},
+		{code: keyEnter},
+		{code: keyBackspace},
+		{code: keyRune, r: 'è'},
+		{code: keyShiftTab},
+		{code: keyEscape},
+		{code: keyCtrlQ},
+		{code: keyCtrlC},
+		{code: keyTab},
+	}, keys)
+	require.Empty(t, parseKeys([]byte{0x01, 0x02}))
+}
+
+func TestFitLine(t *testing.T) {
+	require.Equal(t, "abc  ", fitLine("abc", 5))
+	require.Equal(t, "abcde", fitLine("abcdefgh", 5))
+	require.Equal(t, "a   b", fitLine("a\tb", 5))
+	require.Equal(t, "ab[1mc", fitLine("a\x1bb[1mc\r", 6))
+	require.Equal(t, "èé ", fitLine("èé", 3))
+	require.Equal(t, "", fitLine("abc", 0))
+}
+
+func TestOutputView(t *testing.T) {
+	o := &outputView{}
+	o.append([]byte("line 1\r\nline"))
+	o.append([]byte(" 2\nline 3\n"))
+	o.append([]byte("partial"))
+	require.Equal(t, []string{"line 1", "line 2", "line 3"}, o.lines)
+	require.Equal(t, []screenLine{{text: "line 3"}, {text: "partial"}}, o.render(2))
+
+	require.True(t, o.handleScroll(key{code: keyUp}))
+	require.Equal(t, []screenLine{{text: "line 2"}, {text: "line 3"}}, o.render(2))
+	// New output keeps the scrolled view still
+	o.append([]byte("\n"))
+	require.Equal(t, []screenLine{{text: "line 2"}, {text: "line 3"}}, o.render(2))
+	require.True(t, o.handleScroll(key{code: keyEnd}))
+	require.Equal(t, []screenLine{{text: "line 3"}, {text: "partial"}}, o.render(2))
+	require.False(t, o.handleScroll(key{code: keyRune, r: 'x'}))
+}
+
+func TestListWindow(t *testing.T) {
+	start, end := listWindow(0, 20, 5)
+	require.Equal(t, []int{0, 5}, []int{start, end})
+	start, end = listWindow(7, 20, 5)
+	require.Equal(t, []int{3, 8}, []int{start, end})
+	start, end = listWindow(2, 3, 5)
+	require.Equal(t, []int{0, 3}, []int{start, end})
+	start, end = listWindow(0, 0, 5)
+	require.Equal(t, []int{0, 0}, []int{start, end})
+}
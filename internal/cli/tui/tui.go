@@ -0,0 +1,109 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package tui
+
+import (
+	"context"
+	"os"
+
+	"github.com/arduino/arduino-cli/commands/sketch"
+	"github.com/arduino/arduino-cli/internal/cli/arguments"
+	"github.com/arduino/arduino-cli/internal/cli/feedback"
+	"github.com/arduino/arduino-cli/internal/cli/instance"
+	"github.com/arduino/arduino-cli/internal/i18n"
+	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tr = i18n.Tr
+
+// NewCommand created a new `tui` command
+func NewCommand() *cobra.Command {
+	var (
+		fqbnArg arguments.Fqbn
+		portArg arguments.Port
+	)
+	tuiCommand := &cobra.Command{
+		Use:   "tui [" + tr("sketchPath") + "]",
+		Short: tr("Starts the interactive terminal user interface."),
+		Long: tr("Starts an interactive terminal user interface to pick the board, browse and install the libraries, " +
+			"compile and upload the sketch and monitor the board, without leaving the terminal."),
+		Example: "  " + os.Args[0] + " tui\n" +
+			"  " + os.Args[0] + " tui /home/user/Arduino/MySketch -b arduino:avr:uno",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			runTUICommand(path, &fqbnArg, &portArg)
+		},
+	}
+	fqbnArg.AddToCommand(tuiCommand)
+	portArg.AddToCommand(tuiCommand)
+	return tuiCommand
+}
+
+func runTUICommand(path string, fqbnArg *arguments.Fqbn, portArg *arguments.Port) {
+	logrus.Info("Executing `arduino-cli tui`")
+	if _, _, err := feedback.InteractiveStreams(); err != nil {
+		feedback.FatalError(err, feedback.ErrBadArgument)
+	}
+	if !feedback.IsInteractive() || !feedback.HasConsole() {
+		feedback.Fatal(tr("The terminal user interface requires an interactive terminal"), feedback.ErrBadArgument)
+	}
+
+	inst := instance.CreateAndInit()
+
+	// The sketch is optional, the board and the port default to the ones
+	// saved in the sketch project
+	var sketchPath *paths.Path
+	fqbn := fqbnArg.String()
+	var port *rpc.Port
+	defaultAddress, defaultProtocol := "", ""
+	if sk, err := sketch.LoadSketch(context.Background(), &rpc.LoadSketchRequest{SketchPath: arguments.InitSketchPath(path).String()}); err == nil {
+		sketchPath = paths.New(sk.GetLocationPath())
+		if fqbn == "" {
+			fqbn = sk.GetDefaultFqbn()
+		}
+		defaultAddress, defaultProtocol = sk.GetDefaultPort(), sk.GetDefaultProtocol()
+	} else if path != "" {
+		feedback.Fatal(tr("Error opening sketch: %v", err), feedback.ErrGeneric)
+	}
+	if portArg.IsPortFlagSet() || defaultAddress != "" {
+		p, err := portArg.GetPort(inst, defaultAddress, defaultProtocol)
+		if err != nil {
+			feedback.Fatal(tr("Error getting port metadata: %v", err), feedback.ErrGeneric)
+		}
+		port = p
+	}
+
+	ttyIn, ttyOut, _ := feedback.InteractiveStreams()
+	if err := feedback.SetRawModeStdin(); err != nil {
+		feedback.Fatal(tr("Error setting raw mode: %v", err), feedback.ErrGeneric)
+	}
+	ttyOut.Write([]byte(escAltScreenOn + escHideCursor + escClearScreen))
+	defer func() {
+		ttyOut.Write([]byte(escReset + escShowCursor + escAltScreenOff))
+		feedback.RestoreModeStdin()
+	}()
+
+	a := newApp(inst, sketchPath, fqbn, port, ttyOut)
+	a.run(ttyIn)
+	a.close()
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	"github.com/arduino/arduino-cli/version"
-	"github.com/fatih/color"
 )
 
 var tr = i18n.Tr
@@ -29,9 +28,9 @@ var tr = i18n.Tr
 // NotifyNewVersionIsAvailable prints information about the new latestVersion
 func NotifyNewVersionIsAvailable(latestVersion string) {
 	msg := fmt.Sprintf("\n\n%s %s → %s\n%s",
-		color.YellowString(tr("A new release of Arduino CLI is available:")),
-		color.CyanString(version.VersionInfo.VersionString),
-		color.CyanString(latestVersion),
-		color.YellowString("https://arduino.github.io/arduino-cli/latest/installation/#latest-packages"))
+		feedback.GetTheme().Warning.Sprint(tr("A new release of Arduino CLI is available:")),
+		feedback.GetTheme().Accent.Sprint(version.VersionInfo.VersionString),
+		feedback.GetTheme().Accent.Sprint(latestVersion),
+		feedback.GetTheme().Warning.Sprint("https://arduino.github.io/arduino-cli/latest/installation/#latest-packages"))
 	feedback.Warning(msg)
 }
@@ -32,6 +32,7 @@ var tr = i18n.Tr
 // NewCommand creates a new `upgrade` command
 func NewCommand() *cobra.Command {
 	var postInstallFlags arguments.PrePostScriptsFlags
+	var keepPrevious bool
 	upgradeCommand := &cobra.Command{
 		Use:     "upgrade",
 		Short:   tr("Upgrades installed cores and libraries."),
@@ -39,16 +40,17 @@ func NewCommand() *cobra.Command {
 		Example: "  " + os.Args[0] + " upgrade",
 		Args:    cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			runUpgradeCommand(postInstallFlags.DetectSkipPostInstallValue(), postInstallFlags.DetectSkipPreUninstallValue())
+			runUpgradeCommand(postInstallFlags.DetectSkipPostInstallValue(), postInstallFlags.DetectSkipPreUninstallValue(), keepPrevious)
 		},
 	}
 	postInstallFlags.AddToCommand(upgradeCommand)
+	upgradeCommand.Flags().BoolVar(&keepPrevious, "keep-previous", false, tr("Keep the previously installed version of the cores, instead of removing it."))
 	return upgradeCommand
 }
 
-func runUpgradeCommand(skipPostInstall bool, skipPreUninstall bool) {
+func runUpgradeCommand(skipPostInstall bool, skipPreUninstall bool, keepPrevious bool) {
 	inst := instance.CreateAndInit()
 	logrus.Info("Executing `arduino-cli upgrade`")
 	lib.Upgrade(inst, []string{})
-	core.Upgrade(inst, []string{}, skipPostInstall, skipPreUninstall)
+	core.Upgrade(inst, []string{}, skipPostInstall, skipPreUninstall, keepPrevious)
 }
@@ -20,19 +20,23 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/arduino/arduino-cli/commands/cmderrors"
-	"github.com/arduino/arduino-cli/commands/core"
+	"github.com/arduino/arduino-cli/commands/monitor"
 	sk "github.com/arduino/arduino-cli/commands/sketch"
 	"github.com/arduino/arduino-cli/commands/upload"
+	"github.com/arduino/arduino-cli/internal/arduino/buildmanifest"
 	"github.com/arduino/arduino-cli/internal/cli/arguments"
 	"github.com/arduino/arduino-cli/internal/cli/feedback"
 	"github.com/arduino/arduino-cli/internal/cli/feedback/result"
 	"github.com/arduino/arduino-cli/internal/cli/instance"
 	"github.com/arduino/arduino-cli/internal/i18n"
 	rpc "github.com/arduino/arduino-cli/rpc/cc/arduino/cli/commands/v1"
-	"github.com/arduino/arduino-cli/version"
+	"github.com/arduino/go-paths-helper"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -43,29 +47,36 @@ var (
 	profileArg arguments.Profile
 	verbose    bool
 	verify     bool
+	preflight  bool
 	importDir  string
 	importFile string
 	programmer arguments.Programmer
 	dryRun     bool
+	assumeYes  bool
+	expect     string
+	expectSend string
+	expectTime time.Duration
 	tr         = i18n.Tr
 )
 
 // NewCommand created a new `upload` command
 func NewCommand() *cobra.Command {
 	uploadFields := map[string]string{}
+	expectConfig := map[string]string{}
 	uploadCommand := &cobra.Command{
 		Use:   "upload",
 		Short: tr("Upload Arduino sketches."),
 		Long:  tr("Upload Arduino sketches. This does NOT compile the sketch prior to upload."),
 		Example: "" +
 			"  " + os.Args[0] + " upload /home/user/Arduino/MySketch -p /dev/ttyACM0 -b arduino:avr:uno\n" +
-			"  " + os.Args[0] + " upload -p 192.168.10.1 -b arduino:avr:uno --upload-field password=abc",
+			"  " + os.Args[0] + " upload -p 192.168.10.1 -b arduino:avr:uno --upload-field password=abc\n" +
+			"  " + os.Args[0] + " upload -p /dev/ttyACM0 -b arduino:avr:uno --expect \"READY\" --expect-config baudrate=115200",
 		Args: cobra.MaximumNArgs(1),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			arguments.CheckFlagsConflicts(cmd, "input-file", "input-dir")
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			runUploadCommand(args, uploadFields)
+			runUploadCommand(args, uploadFields, expectConfig)
 		},
 	}
 
@@ -75,17 +86,32 @@ func NewCommand() *cobra.Command {
 	uploadCommand.Flags().StringVarP(&importDir, "input-dir", "", "", tr("Directory containing binaries to upload."))
 	uploadCommand.Flags().StringVarP(&importFile, "input-file", "i", "", tr("Binary file to upload."))
 	uploadCommand.Flags().BoolVarP(&verify, "verify", "t", false, tr("Verify uploaded binary after the upload."))
+	uploadCommand.Flags().BoolVar(&preflight, "preflight", false, tr("Verify that the target is connected, and its signature and voltage, before writing, if supported by the upload tool."))
 	uploadCommand.Flags().BoolVarP(&verbose, "verbose", "v", false, tr("Optional, turns on verbose mode."))
 	programmer.AddToCommand(uploadCommand)
 	uploadCommand.Flags().BoolVar(&dryRun, "dry-run", false, tr("Do not perform the actual upload, just log out actions"))
 	uploadCommand.Flags().MarkHidden("dry-run")
+	uploadCommand.Flags().BoolVar(&assumeYes, "yes", false, tr("Install the platform of the board, if missing, without asking for confirmation."))
 	arguments.AddKeyValuePFlag(uploadCommand, &uploadFields, "upload-field", "F", nil, tr("Set a value for a field required to upload."))
+	uploadCommand.Flags().StringVar(&expect, "expect", "", tr("After the upload, open the port and wait for an answer from the board matching this regular expression, the command fails if it's not received."))
+	uploadCommand.Flags().DurationVar(&expectTime, "expect-timeout", 10*time.Second, tr("Max time to wait for the answer expected with --expect."))
+	uploadCommand.Flags().StringVar(&expectSend, "expect-send", "", tr("Data to send to the board before waiting for the answer expected with --expect."))
+	arguments.AddKeyValuePFlag(uploadCommand, &expectConfig, "expect-config", "", nil, tr("Configure the monitor port used for --expect, for example baudrate=115200."))
 	return uploadCommand
 }
 
-func runUploadCommand(args []string, uploadFieldsArgs map[string]string) {
+func runUploadCommand(args []string, uploadFieldsArgs map[string]string, expectConfig map[string]string) {
 	logrus.Info("Executing `arduino-cli upload`")
 
+	var expectRegexp *regexp.Regexp
+	if expect != "" {
+		re, err := regexp.Compile(expect)
+		if err != nil {
+			feedback.Fatal(tr("Invalid expected answer %[1]q: %[2]v", expect, err), feedback.ErrBadArgument)
+		}
+		expectRegexp = re
+	}
+
 	path := ""
 	if len(args) > 0 {
 		path = args[0]
@@ -113,9 +139,18 @@ func runUploadCommand(args []string, uploadFieldsArgs map[string]string) {
 	}
 
 	defaultFQBN := sketch.GetDefaultFqbn()
+	if importDir != "" {
+		// The build manifest tells the board the sketch has been compiled for
+		if manifest, err := buildmanifest.Load(paths.New(importDir)); err == nil && manifest != nil {
+			defaultFQBN = manifest.FQBN
+		}
+	}
 	defaultAddress := sketch.GetDefaultPort()
 	defaultProtocol := sketch.GetDefaultProtocol()
 	fqbn, port := arguments.CalculateFQBNAndPort(&portArgs, &fqbnArg, inst, defaultFQBN, defaultAddress, defaultProtocol)
+	if profile == nil {
+		arguments.InstallMissingPlatform(inst, fqbn, assumeYes)
+	}
 
 	userFieldRes, err := upload.SupportedUserFields(context.Background(), &rpc.SupportedUserFieldsRequest{
 		Instance: inst,
@@ -135,16 +170,7 @@ func runUploadCommand(args []string, uploadFieldsArgs map[string]string) {
 			}
 
 			msg += "\n"
-			if platform, err := core.PlatformSearch(&rpc.PlatformSearchRequest{
-				Instance:   inst,
-				SearchArgs: platformErr.Platform,
-			}); err != nil {
-				msg += err.Error()
-			} else if len(platform.GetSearchOutput()) > 0 {
-				msg += tr("Try running %s", fmt.Sprintf("`%s core install %s`", version.VersionInfo.Application, platformErr.Platform))
-			} else {
-				msg += tr("Platform %s is not found in any known index\nMaybe you need to add a 3rd party URL?", platformErr.Platform)
-			}
+			msg += arguments.PlatformNotFoundHint(inst, platformErr.Platform)
 		}
 		feedback.Fatal(msg, feedback.ErrGeneric)
 	}
@@ -176,6 +202,7 @@ func runUploadCommand(args []string, uploadFieldsArgs map[string]string) {
 		path = sketchPath.String()
 	}
 
+	programmer.SetDefaultFromBoardAlias(&fqbnArg)
 	prog := profile.GetProgrammer()
 	if prog == "" || programmer.GetProgrammer() != "" {
 		prog = programmer.String(inst, fqbn)
@@ -192,13 +219,15 @@ func runUploadCommand(args []string, uploadFieldsArgs map[string]string) {
 		Port:       port,
 		Verbose:    verbose,
 		Verify:     verify,
+		Preflight:  preflight,
 		ImportFile: importFile,
 		ImportDir:  importDir,
 		Programmer: prog,
 		DryRun:     dryRun,
 		UserFields: fields,
 	}
-	if res, err := upload.Upload(context.Background(), req, stdOut, stdErr); err != nil {
+	res, err := upload.Upload(context.Background(), req, stdOut, stdErr, nil)
+	if err != nil {
 		errcode := feedback.ErrGeneric
 		if errors.Is(err, &cmderrors.ProgrammerRequiredForUploadError{}) {
 			errcode = feedback.ErrMissingProgrammer
@@ -207,14 +236,43 @@ func runUploadCommand(args []string, uploadFieldsArgs map[string]string) {
 			errcode = feedback.ErrMissingProgrammer
 		}
 		feedback.FatalError(err, errcode)
-	} else {
-		io := stdIOResult()
-		feedback.PrintResult(&uploadResult{
-			Stdout:            io.Stdout,
-			Stderr:            io.Stderr,
-			UpdatedUploadPort: result.NewPort(res.GetUpdatedUploadPort()),
-		})
 	}
+
+	if expectRegexp != nil && !dryRun {
+		// The board may answer on a different port after the upload
+		expectPort := port
+		if res.GetUpdatedUploadPort() != nil {
+			expectPort = res.GetUpdatedUploadPort()
+		}
+		if expectPort.GetAddress() == "" {
+			feedback.Fatal(tr("A port is required to wait for the answer of the board."), feedback.ErrBadArgument)
+		}
+		conf := &rpc.MonitorPortConfiguration{}
+		settings := make([]string, 0, len(expectConfig))
+		for setting := range expectConfig {
+			settings = append(settings, setting)
+		}
+		sort.Strings(settings)
+		for _, setting := range settings {
+			conf.Settings = append(conf.Settings, &rpc.MonitorPortSetting{SettingId: setting, Value: expectConfig[setting]})
+		}
+		err := monitor.Expect(context.Background(), &rpc.MonitorPortOpenRequest{
+			Instance:          inst,
+			Port:              expectPort,
+			Fqbn:              fqbn,
+			PortConfiguration: conf,
+		}, []byte(expectSend), expectRegexp, expectTime, stdOut)
+		if err != nil {
+			feedback.Fatal(tr("The board did not send the expected answer: %v", err), feedback.ErrGeneric)
+		}
+	}
+
+	io := stdIOResult()
+	feedback.PrintResult(&uploadResult{
+		Stdout:            io.Stdout,
+		Stderr:            io.Stderr,
+		UpdatedUploadPort: result.NewPort(res.GetUpdatedUploadPort()),
+	})
 }
 
 type uploadResult struct {
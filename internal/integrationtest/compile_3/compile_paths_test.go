@@ -0,0 +1,81 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/integrationtest"
+	"github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWithUnusualPaths(t *testing.T) {
+	env, cli := integrationtest.CreateArduinoCLIWithEnvironment(t)
+	defer env.CleanUp()
+
+	_, _, err := cli.Run("core", "install", "arduino:avr@1.8.6")
+	require.NoError(t, err)
+
+	// A path longer than MAX_PATH (260 characters) on Windows
+	longDir := cli.SketchbookDir()
+	for i := 0; len(longDir.String()) <= 260; i++ {
+		longDir = longDir.Join(strings.Repeat(string(rune('a'+i%26)), 40))
+	}
+
+	for _, dir := range []struct {
+		name string
+		path *paths.Path
+	}{
+		{"Spaces", cli.SketchbookDir().Join("My Sketches", "with spaces")},
+		{"UTF8", cli.SketchbookDir().Join("Schizzi àèìòù", "Скетчи 草图")},
+		{"LongPath", longDir},
+	} {
+		t.Run(dir.name, func(t *testing.T) {
+			testCompileInDir(t, cli, dir.path)
+		})
+	}
+}
+
+func testCompileInDir(t *testing.T, cli *integrationtest.ArduinoCLI, dir *paths.Path) {
+	// The sketch, its build path and a library are all in the directory
+	sketchPath := dir.Join("Sketch")
+	_, _, err := cli.Run("sketch", "new", sketchPath.String())
+	require.NoError(t, err)
+	require.NoError(t, sketchPath.Join("Sketch.ino").WriteFile([]byte("#include <MyLib.h>\nvoid setup() { myLib(); }\nvoid loop() {}\n")))
+
+	libPath := dir.Join("libraries", "MyLib")
+	require.NoError(t, libPath.Join("src").MkdirAll())
+	require.NoError(t, libPath.Join("library.properties").WriteFile([]byte("name=MyLib\nversion=1.0.0\narchitectures=*\n")))
+	require.NoError(t, libPath.Join("src", "MyLib.h").WriteFile([]byte("void myLib();\n")))
+	require.NoError(t, libPath.Join("src", "MyLib.cpp").WriteFile([]byte("#include \"MyLib.h\"\nvoid myLib() {}\n")))
+
+	buildPath := dir.Join("build")
+	_, _, err = cli.Run("compile", "-b", "arduino:avr:uno", "--library", libPath.String(), "--build-path", buildPath.String(), sketchPath.String())
+	require.NoError(t, err)
+	require.FileExists(t, buildPath.Join("Sketch.ino.hex").String())
+
+	// The libraries are installed in a sketchbook in the directory
+	envVar := cli.GetDefaultEnv()
+	envVar["ARDUINO_DIRECTORIES_USER"] = dir.Join("sketchbook").String()
+	_, _, err = cli.RunWithCustomEnv(envVar, "lib", "install", "ArduinoJson@6.17.2")
+	require.NoError(t, err)
+	require.DirExists(t, dir.Join("sketchbook", "libraries", "ArduinoJson").String())
+	require.NoError(t, sketchPath.Join("Sketch.ino").WriteFile([]byte("#include <ArduinoJson.h>\nvoid setup() {}\nvoid loop() {}\n")))
+	_, _, err = cli.RunWithCustomEnv(envVar, "compile", "-b", "arduino:avr:uno", "--build-path", buildPath.String(), sketchPath.String())
+	require.NoError(t, err)
+}
@@ -1117,7 +1117,8 @@ func TestCoreInstallRunsToolPostInstallScript(t *testing.T) {
 	stdout, _, err := cli.Run("core", "install", "Test:x86", "--additional-urls", url.String())
 	require.NoError(t, err)
 	require.Contains(t, string(stdout), "Skipping tool configuration.")
-	require.Contains(t, string(stdout), "Skipping platform configuration.")
+	// The post_install script of the platform is never run automatically
+	require.Contains(t, string(stdout), "requires additional setup, run `arduino-cli core post-install Test:x86`")
 }
 
 func TestCoreBrokenDependency(t *testing.T) {
@@ -39,26 +39,51 @@ const (
 	ArduinoCoreService_Destroy_FullMethodName                           = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Destroy"
 	ArduinoCoreService_UpdateIndex_FullMethodName                       = "/cc.arduino.cli.commands.v1.ArduinoCoreService/UpdateIndex"
 	ArduinoCoreService_UpdateLibrariesIndex_FullMethodName              = "/cc.arduino.cli.commands.v1.ArduinoCoreService/UpdateLibrariesIndex"
+	ArduinoCoreService_InstanceEvents_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/InstanceEvents"
 	ArduinoCoreService_Version_FullMethodName                           = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Version"
+	ArduinoCoreService_GetCapabilities_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/GetCapabilities"
 	ArduinoCoreService_NewSketch_FullMethodName                         = "/cc.arduino.cli.commands.v1.ArduinoCoreService/NewSketch"
 	ArduinoCoreService_LoadSketch_FullMethodName                        = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LoadSketch"
 	ArduinoCoreService_ArchiveSketch_FullMethodName                     = "/cc.arduino.cli.commands.v1.ArduinoCoreService/ArchiveSketch"
+	ArduinoCoreService_FlattenSketch_FullMethodName                     = "/cc.arduino.cli.commands.v1.ArduinoCoreService/FlattenSketch"
 	ArduinoCoreService_SetSketchDefaults_FullMethodName                 = "/cc.arduino.cli.commands.v1.ArduinoCoreService/SetSketchDefaults"
+	ArduinoCoreService_SketchbookList_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/SketchbookList"
 	ArduinoCoreService_BoardDetails_FullMethodName                      = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardDetails"
 	ArduinoCoreService_BoardList_FullMethodName                         = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardList"
 	ArduinoCoreService_BoardListAll_FullMethodName                      = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardListAll"
 	ArduinoCoreService_BoardSearch_FullMethodName                       = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardSearch"
 	ArduinoCoreService_BoardListWatch_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardListWatch"
+	ArduinoCoreService_BoardSetupPermissions_FullMethodName             = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardSetupPermissions"
 	ArduinoCoreService_Compile_FullMethodName                           = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Compile"
+	ArduinoCoreService_CompileWarmUp_FullMethodName                     = "/cc.arduino.cli.commands.v1.ArduinoCoreService/CompileWarmUp"
+	ArduinoCoreService_CompileDropWarmState_FullMethodName              = "/cc.arduino.cli.commands.v1.ArduinoCoreService/CompileDropWarmState"
+	ArduinoCoreService_Symbolize_FullMethodName                         = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Symbolize"
 	ArduinoCoreService_PlatformInstall_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformInstall"
 	ArduinoCoreService_PlatformDownload_FullMethodName                  = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformDownload"
 	ArduinoCoreService_PlatformUninstall_FullMethodName                 = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformUninstall"
 	ArduinoCoreService_PlatformUpgrade_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformUpgrade"
+	ArduinoCoreService_PlatformUpgradePreview_FullMethodName            = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformUpgradePreview"
+	ArduinoCoreService_PlatformPostInstallSteps_FullMethodName          = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformPostInstallSteps"
+	ArduinoCoreService_PlatformRunPostInstallStep_FullMethodName        = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformRunPostInstallStep"
+	ArduinoCoreService_PlatformAudit_FullMethodName                     = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformAudit"
+	ArduinoCoreService_PlatformCleanTools_FullMethodName                = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformCleanTools"
 	ArduinoCoreService_Upload_FullMethodName                            = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Upload"
 	ArduinoCoreService_UploadUsingProgrammer_FullMethodName             = "/cc.arduino.cli.commands.v1.ArduinoCoreService/UploadUsingProgrammer"
 	ArduinoCoreService_SupportedUserFields_FullMethodName               = "/cc.arduino.cli.commands.v1.ArduinoCoreService/SupportedUserFields"
 	ArduinoCoreService_ListProgrammersAvailableForUpload_FullMethodName = "/cc.arduino.cli.commands.v1.ArduinoCoreService/ListProgrammersAvailableForUpload"
+	ArduinoCoreService_ProgrammerDetails_FullMethodName                 = "/cc.arduino.cli.commands.v1.ArduinoCoreService/ProgrammerDetails"
 	ArduinoCoreService_BurnBootloader_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BurnBootloader"
+	ArduinoCoreService_ReadMemory_FullMethodName                        = "/cc.arduino.cli.commands.v1.ArduinoCoreService/ReadMemory"
+	ArduinoCoreService_WriteMemory_FullMethodName                       = "/cc.arduino.cli.commands.v1.ArduinoCoreService/WriteMemory"
+	ArduinoCoreService_EraseChip_FullMethodName                         = "/cc.arduino.cli.commands.v1.ArduinoCoreService/EraseChip"
+	ArduinoCoreService_FlashProtection_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/FlashProtection"
+	ArduinoCoreService_BoardRecover_FullMethodName                      = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardRecover"
+	ArduinoCoreService_BoardReset_FullMethodName                        = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardReset"
+	ArduinoCoreService_BoardCertificates_FullMethodName                 = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardCertificates"
+	ArduinoCoreService_BoardProvision_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardProvision"
+	ArduinoCoreService_BoardPower_FullMethodName                        = "/cc.arduino.cli.commands.v1.ArduinoCoreService/BoardPower"
+	ArduinoCoreService_DeploymentsList_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/DeploymentsList"
+	ArduinoCoreService_DeploymentsShow_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/DeploymentsShow"
 	ArduinoCoreService_PlatformSearch_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/PlatformSearch"
 	ArduinoCoreService_LibraryDownload_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryDownload"
 	ArduinoCoreService_LibraryInstall_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryInstall"
@@ -67,9 +92,13 @@ const (
 	ArduinoCoreService_GitLibraryInstall_FullMethodName                 = "/cc.arduino.cli.commands.v1.ArduinoCoreService/GitLibraryInstall"
 	ArduinoCoreService_LibraryUninstall_FullMethodName                  = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryUninstall"
 	ArduinoCoreService_LibraryUpgradeAll_FullMethodName                 = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryUpgradeAll"
+	ArduinoCoreService_LibraryUpgradePreview_FullMethodName             = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryUpgradePreview"
 	ArduinoCoreService_LibraryResolveDependencies_FullMethodName        = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryResolveDependencies"
+	ArduinoCoreService_LibraryDiff_FullMethodName                       = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryDiff"
+	ArduinoCoreService_LibraryNew_FullMethodName                        = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryNew"
 	ArduinoCoreService_LibrarySearch_FullMethodName                     = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibrarySearch"
 	ArduinoCoreService_LibraryList_FullMethodName                       = "/cc.arduino.cli.commands.v1.ArduinoCoreService/LibraryList"
+	ArduinoCoreService_RescanLibraries_FullMethodName                   = "/cc.arduino.cli.commands.v1.ArduinoCoreService/RescanLibraries"
 	ArduinoCoreService_Monitor_FullMethodName                           = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Monitor"
 	ArduinoCoreService_EnumerateMonitorPortSettings_FullMethodName      = "/cc.arduino.cli.commands.v1.ArduinoCoreService/EnumerateMonitorPortSettings"
 	ArduinoCoreService_Debug_FullMethodName                             = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Debug"
@@ -77,6 +106,8 @@ const (
 	ArduinoCoreService_GetDebugConfig_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/GetDebugConfig"
 	ArduinoCoreService_CheckForArduinoCLIUpdates_FullMethodName         = "/cc.arduino.cli.commands.v1.ArduinoCoreService/CheckForArduinoCLIUpdates"
 	ArduinoCoreService_CleanDownloadCacheDirectory_FullMethodName       = "/cc.arduino.cli.commands.v1.ArduinoCoreService/CleanDownloadCacheDirectory"
+	ArduinoCoreService_Cleanup_FullMethodName                           = "/cc.arduino.cli.commands.v1.ArduinoCoreService/Cleanup"
+	ArduinoCoreService_DiskUsage_FullMethodName                         = "/cc.arduino.cli.commands.v1.ArduinoCoreService/DiskUsage"
 	ArduinoCoreService_SettingsGetAll_FullMethodName                    = "/cc.arduino.cli.commands.v1.ArduinoCoreService/SettingsGetAll"
 	ArduinoCoreService_SettingsMerge_FullMethodName                     = "/cc.arduino.cli.commands.v1.ArduinoCoreService/SettingsMerge"
 	ArduinoCoreService_SettingsGetValue_FullMethodName                  = "/cc.arduino.cli.commands.v1.ArduinoCoreService/SettingsGetValue"
@@ -100,18 +131,30 @@ type ArduinoCoreServiceClient interface {
 	UpdateIndex(ctx context.Context, in *UpdateIndexRequest, opts ...grpc.CallOption) (ArduinoCoreService_UpdateIndexClient, error)
 	// Update libraries index
 	UpdateLibrariesIndex(ctx context.Context, in *UpdateLibrariesIndexRequest, opts ...grpc.CallOption) (ArduinoCoreService_UpdateLibrariesIndexClient, error)
+	// Start watching for changes to the state shared by the instances (indexes
+	// updated, platforms or libraries installed or removed, sketchbook changed).
+	// Clients can use these events to refresh their data without polling.
+	InstanceEvents(ctx context.Context, in *InstanceEventsRequest, opts ...grpc.CallOption) (ArduinoCoreService_InstanceEventsClient, error)
 	// Get the version of Arduino CLI in use.
 	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+	// Get the API version and the list of features supported by the daemon.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
 	// Create a new Sketch
 	NewSketch(ctx context.Context, in *NewSketchRequest, opts ...grpc.CallOption) (*NewSketchResponse, error)
 	// Returns all files composing a Sketch
 	LoadSketch(ctx context.Context, in *LoadSketchRequest, opts ...grpc.CallOption) (*LoadSketchResponse, error)
 	// Creates a zip file containing all files of specified Sketch
 	ArchiveSketch(ctx context.Context, in *ArchiveSketchRequest, opts ...grpc.CallOption) (*ArchiveSketchResponse, error)
+	// Merges the files of a sketch in a single, preprocessed, source file that
+	// can be shared to reproduce an issue.
+	FlattenSketch(ctx context.Context, in *FlattenSketchRequest, opts ...grpc.CallOption) (*FlattenSketchResponse, error)
 	// Sets the sketch default FQBN and Port Address/Protocol in
 	// the sketch project file (sketch.yaml). These metadata can be retrieved
 	// using LoadSketch.
 	SetSketchDefaults(ctx context.Context, in *SetSketchDefaultsRequest, opts ...grpc.CallOption) (*SetSketchDefaultsResponse, error)
+	// List the sketches contained in the sketchbook, optionally filtered by
+	// name or board.
+	SketchbookList(ctx context.Context, in *SketchbookListRequest, opts ...grpc.CallOption) (*SketchbookListResponse, error)
 	// Requests details about a board
 	BoardDetails(ctx context.Context, in *BoardDetailsRequest, opts ...grpc.CallOption) (*BoardDetailsResponse, error)
 	// List the boards currently connected to the computer.
@@ -122,8 +165,19 @@ type ArduinoCoreServiceClient interface {
 	BoardSearch(ctx context.Context, in *BoardSearchRequest, opts ...grpc.CallOption) (*BoardSearchResponse, error)
 	// List boards connection and disconnected events.
 	BoardListWatch(ctx context.Context, in *BoardListWatchRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardListWatchClient, error)
+	// Check if the current user has the permissions needed to access a port
+	// and, optionally, try to fix the detected issues.
+	BoardSetupPermissions(ctx context.Context, in *BoardSetupPermissionsRequest, opts ...grpc.CallOption) (*BoardSetupPermissionsResponse, error)
 	// Compile an Arduino sketch.
 	Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (ArduinoCoreService_CompileClient, error)
+	// Prepare the state of the builds for a board, so that the following
+	// compilations for the same board skip the loading of the libraries.
+	CompileWarmUp(ctx context.Context, in *CompileWarmUpRequest, opts ...grpc.CallOption) (*CompileWarmUpResponse, error)
+	// Drop the state of the builds kept between the compilations.
+	CompileDropWarmState(ctx context.Context, in *CompileDropWarmStateRequest, opts ...grpc.CallOption) (*CompileDropWarmStateResponse, error)
+	// Resolve the addresses reported by a firmware, using the executable saved
+	// in the symbols archive when the firmware was compiled.
+	Symbolize(ctx context.Context, in *SymbolizeRequest, opts ...grpc.CallOption) (*SymbolizeResponse, error)
 	// Download and install a platform and its tool dependencies.
 	PlatformInstall(ctx context.Context, in *PlatformInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_PlatformInstallClient, error)
 	// Download a platform and its tool dependencies to the `staging/packages`
@@ -134,6 +188,26 @@ type ArduinoCoreServiceClient interface {
 	PlatformUninstall(ctx context.Context, in *PlatformUninstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_PlatformUninstallClient, error)
 	// Upgrade an installed platform to the latest version.
 	PlatformUpgrade(ctx context.Context, in *PlatformUpgradeRequest, opts ...grpc.CallOption) (ArduinoCoreService_PlatformUpgradeClient, error)
+	// Preview the changes of a platform upgrade: versions, tools to download and
+	// tools to remove.
+	PlatformUpgradePreview(ctx context.Context, in *PlatformUpgradePreviewRequest, opts ...grpc.CallOption) (*PlatformUpgradePreviewResponse, error)
+	// List the post-install setup steps declared by an installed platform.
+	PlatformPostInstallSteps(ctx context.Context, in *PlatformPostInstallStepsRequest, opts ...grpc.CallOption) (*PlatformPostInstallStepsResponse, error)
+	// Run a post-install setup step of an installed platform. The step executes
+	// the command declared by the platform in its platform.txt (or its
+	// post-install script) with the privileges of the daemon: clients must show
+	// the command to the user and set `confirmed` only after the user agreed to
+	// run it. A step that requires administrative privileges is not run, and a
+	// PostInstallStepRequiresAdminError detail is returned, if the daemon is not
+	// running with them.
+	PlatformRunPostInstallStep(ctx context.Context, in *PlatformRunPostInstallStepRequest, opts ...grpc.CallOption) (*PlatformRunPostInstallStepResponse, error)
+	// Audit the tools installed by the package manager: report the tools whose
+	// checksum in the package index changed since the installation and the
+	// tools downloaded over insecure transports.
+	PlatformAudit(ctx context.Context, in *PlatformAuditRequest, opts ...grpc.CallOption) (*PlatformAuditResponse, error)
+	// Remove the installed tools that are not required by any installed
+	// platform and the shared copies of the tools that are not used anymore.
+	PlatformCleanTools(ctx context.Context, in *PlatformCleanToolsRequest, opts ...grpc.CallOption) (*PlatformCleanToolsResponse, error)
 	// Upload a compiled sketch to a board.
 	Upload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (ArduinoCoreService_UploadClient, error)
 	// Upload a compiled sketch to a board using a programmer.
@@ -143,8 +217,35 @@ type ArduinoCoreServiceClient interface {
 	SupportedUserFields(ctx context.Context, in *SupportedUserFieldsRequest, opts ...grpc.CallOption) (*SupportedUserFieldsResponse, error)
 	// List programmers available for a board.
 	ListProgrammersAvailableForUpload(ctx context.Context, in *ListProgrammersAvailableForUploadRequest, opts ...grpc.CallOption) (*ListProgrammersAvailableForUploadResponse, error)
+	// Returns the operations supported by a programmer with a board.
+	ProgrammerDetails(ctx context.Context, in *ProgrammerDetailsRequest, opts ...grpc.CallOption) (*ProgrammerDetailsResponse, error)
 	// Burn bootloader to a board.
 	BurnBootloader(ctx context.Context, in *BurnBootloaderRequest, opts ...grpc.CallOption) (ArduinoCoreService_BurnBootloaderClient, error)
+	// Read a memory range of a board and save it to a file.
+	ReadMemory(ctx context.Context, in *ReadMemoryRequest, opts ...grpc.CallOption) (ArduinoCoreService_ReadMemoryClient, error)
+	// Write the content of a file to a memory range of a board.
+	WriteMemory(ctx context.Context, in *WriteMemoryRequest, opts ...grpc.CallOption) (ArduinoCoreService_WriteMemoryClient, error)
+	// Erase the whole memory of the microcontroller of a board.
+	EraseChip(ctx context.Context, in *EraseChipRequest, opts ...grpc.CallOption) (ArduinoCoreService_EraseChipClient, error)
+	// Query or change the flash readout protection of a board.
+	FlashProtection(ctx context.Context, in *FlashProtectionRequest, opts ...grpc.CallOption) (ArduinoCoreService_FlashProtectionClient, error)
+	// Try to recover a board that is not responding anymore.
+	BoardRecover(ctx context.Context, in *BoardRecoverRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardRecoverClient, error)
+	// Reset a board using the reset method of its platform.
+	BoardReset(ctx context.Context, in *BoardResetRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardResetClient, error)
+	// Flash root certificates, and optionally a new firmware, to the network
+	// module of a board.
+	BoardCertificates(ctx context.Context, in *BoardCertificatesRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardCertificatesClient, error)
+	// Provision the secure element of a board: generate a private key and a
+	// certificate signing request, or write back the signed certificate.
+	BoardProvision(ctx context.Context, in *BoardProvisionRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardProvisionClient, error)
+	// Measure the current drawn by a board, and the target voltage, using a
+	// debug probe that supports power measurements.
+	BoardPower(ctx context.Context, in *BoardPowerRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardPowerClient, error)
+	// List the last sketch uploaded to each board.
+	DeploymentsList(ctx context.Context, in *DeploymentsListRequest, opts ...grpc.CallOption) (*DeploymentsListResponse, error)
+	// Show the history of the sketches uploaded to a board.
+	DeploymentsShow(ctx context.Context, in *DeploymentsShowRequest, opts ...grpc.CallOption) (*DeploymentsShowResponse, error)
 	// Search for a platform in the platforms indexes.
 	PlatformSearch(ctx context.Context, in *PlatformSearchRequest, opts ...grpc.CallOption) (*PlatformSearchResponse, error)
 	// Download the archive file of an Arduino library in the libraries index to
@@ -162,13 +263,26 @@ type ArduinoCoreServiceClient interface {
 	LibraryUninstall(ctx context.Context, in *LibraryUninstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUninstallClient, error)
 	// Upgrade all installed Arduino libraries to the newest version available.
 	LibraryUpgradeAll(ctx context.Context, in *LibraryUpgradeAllRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUpgradeAllClient, error)
+	// Preview the changes of a library upgrade: versions, dependencies to
+	// install and version constraints of the installed libraries that would
+	// break.
+	LibraryUpgradePreview(ctx context.Context, in *LibraryUpgradePreviewRequest, opts ...grpc.CallOption) (*LibraryUpgradePreviewResponse, error)
 	// List the recursive dependencies of a library, as defined by the `depends`
 	// field of the library.properties files.
 	LibraryResolveDependencies(ctx context.Context, in *LibraryResolveDependenciesRequest, opts ...grpc.CallOption) (*LibraryResolveDependenciesResponse, error)
+	// Download two versions of a library from the libraries index and compare
+	// the public API declared in their headers.
+	LibraryDiff(ctx context.Context, in *LibraryDiffRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryDiffClient, error)
+	// Create the skeleton of a new library, with its examples, a CI workflow
+	// and a test harness.
+	LibraryNew(ctx context.Context, in *LibraryNewRequest, opts ...grpc.CallOption) (*LibraryNewResponse, error)
 	// Search the Arduino libraries index for libraries.
 	LibrarySearch(ctx context.Context, in *LibrarySearchRequest, opts ...grpc.CallOption) (*LibrarySearchResponse, error)
 	// List the installed libraries.
 	LibraryList(ctx context.Context, in *LibraryListRequest, opts ...grpc.CallOption) (*LibraryListResponse, error)
+	// Reload only the libraries contained in the given directories, without a
+	// full rescan of the installed libraries.
+	RescanLibraries(ctx context.Context, in *RescanLibrariesRequest, opts ...grpc.CallOption) (*RescanLibrariesResponse, error)
 	// Open a monitor connection to a board port
 	Monitor(ctx context.Context, opts ...grpc.CallOption) (ArduinoCoreService_MonitorClient, error)
 	// Returns the parameters that can be set in the MonitorRequest calls
@@ -183,6 +297,14 @@ type ArduinoCoreServiceClient interface {
 	CheckForArduinoCLIUpdates(ctx context.Context, in *CheckForArduinoCLIUpdatesRequest, opts ...grpc.CallOption) (*CheckForArduinoCLIUpdatesResponse, error)
 	// Clean the download cache directory (where archives are downloaded).
 	CleanDownloadCacheDirectory(ctx context.Context, in *CleanDownloadCacheDirectoryRequest, opts ...grpc.CallOption) (*CleanDownloadCacheDirectoryResponse, error)
+	// Remove the unused files from the data directory: the platform releases
+	// superseded by another installed release, the tools not required by any
+	// platform, the stale archives in the downloads directory and the expired
+	// build caches.
+	Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error)
+	// Report the disk usage of the data directory: the installed platforms and
+	// tools, the downloaded archives and the build caches.
+	DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error)
 	// List all the settings.
 	SettingsGetAll(ctx context.Context, in *SettingsGetAllRequest, opts ...grpc.CallOption) (*SettingsGetAllResponse, error)
 	// Set multiple settings values at once.
@@ -319,6 +441,38 @@ func (x *arduinoCoreServiceUpdateLibrariesIndexClient) Recv() (*UpdateLibrariesI
 	return m, nil
 }
 
+func (c *arduinoCoreServiceClient) InstanceEvents(ctx context.Context, in *InstanceEventsRequest, opts ...grpc.CallOption) (ArduinoCoreService_InstanceEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[3], ArduinoCoreService_InstanceEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceInstanceEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArduinoCoreService_InstanceEventsClient interface {
+	Recv() (*InstanceEventsResponse, error)
+	grpc.ClientStream
+}
+
+type arduinoCoreServiceInstanceEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *arduinoCoreServiceInstanceEventsClient) Recv() (*InstanceEventsResponse, error) {
+	m := new(InstanceEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *arduinoCoreServiceClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
 	out := new(VersionResponse)
 	err := c.cc.Invoke(ctx, ArduinoCoreService_Version_FullMethodName, in, out, opts...)
@@ -328,6 +482,15 @@ func (c *arduinoCoreServiceClient) Version(ctx context.Context, in *VersionReque
 	return out, nil
 }
 
+func (c *arduinoCoreServiceClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_GetCapabilities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arduinoCoreServiceClient) NewSketch(ctx context.Context, in *NewSketchRequest, opts ...grpc.CallOption) (*NewSketchResponse, error) {
 	out := new(NewSketchResponse)
 	err := c.cc.Invoke(ctx, ArduinoCoreService_NewSketch_FullMethodName, in, out, opts...)
@@ -355,6 +518,15 @@ func (c *arduinoCoreServiceClient) ArchiveSketch(ctx context.Context, in *Archiv
 	return out, nil
 }
 
+func (c *arduinoCoreServiceClient) FlattenSketch(ctx context.Context, in *FlattenSketchRequest, opts ...grpc.CallOption) (*FlattenSketchResponse, error) {
+	out := new(FlattenSketchResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_FlattenSketch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arduinoCoreServiceClient) SetSketchDefaults(ctx context.Context, in *SetSketchDefaultsRequest, opts ...grpc.CallOption) (*SetSketchDefaultsResponse, error) {
 	out := new(SetSketchDefaultsResponse)
 	err := c.cc.Invoke(ctx, ArduinoCoreService_SetSketchDefaults_FullMethodName, in, out, opts...)
@@ -364,6 +536,15 @@ func (c *arduinoCoreServiceClient) SetSketchDefaults(ctx context.Context, in *Se
 	return out, nil
 }
 
+func (c *arduinoCoreServiceClient) SketchbookList(ctx context.Context, in *SketchbookListRequest, opts ...grpc.CallOption) (*SketchbookListResponse, error) {
+	out := new(SketchbookListResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_SketchbookList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arduinoCoreServiceClient) BoardDetails(ctx context.Context, in *BoardDetailsRequest, opts ...grpc.CallOption) (*BoardDetailsResponse, error) {
 	out := new(BoardDetailsResponse)
 	err := c.cc.Invoke(ctx, ArduinoCoreService_BoardDetails_FullMethodName, in, out, opts...)
@@ -401,7 +582,7 @@ func (c *arduinoCoreServiceClient) BoardSearch(ctx context.Context, in *BoardSea
 }
 
 func (c *arduinoCoreServiceClient) BoardListWatch(ctx context.Context, in *BoardListWatchRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardListWatchClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[3], ArduinoCoreService_BoardListWatch_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[4], ArduinoCoreService_BoardListWatch_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -432,8 +613,17 @@ func (x *arduinoCoreServiceBoardListWatchClient) Recv() (*BoardListWatchResponse
 	return m, nil
 }
 
+func (c *arduinoCoreServiceClient) BoardSetupPermissions(ctx context.Context, in *BoardSetupPermissionsRequest, opts ...grpc.CallOption) (*BoardSetupPermissionsResponse, error) {
+	out := new(BoardSetupPermissionsResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_BoardSetupPermissions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arduinoCoreServiceClient) Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (ArduinoCoreService_CompileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[4], ArduinoCoreService_Compile_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[5], ArduinoCoreService_Compile_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -464,8 +654,35 @@ func (x *arduinoCoreServiceCompileClient) Recv() (*CompileResponse, error) {
 	return m, nil
 }
 
+func (c *arduinoCoreServiceClient) CompileWarmUp(ctx context.Context, in *CompileWarmUpRequest, opts ...grpc.CallOption) (*CompileWarmUpResponse, error) {
+	out := new(CompileWarmUpResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_CompileWarmUp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) CompileDropWarmState(ctx context.Context, in *CompileDropWarmStateRequest, opts ...grpc.CallOption) (*CompileDropWarmStateResponse, error) {
+	out := new(CompileDropWarmStateResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_CompileDropWarmState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) Symbolize(ctx context.Context, in *SymbolizeRequest, opts ...grpc.CallOption) (*SymbolizeResponse, error) {
+	out := new(SymbolizeResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_Symbolize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arduinoCoreServiceClient) PlatformInstall(ctx context.Context, in *PlatformInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_PlatformInstallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[5], ArduinoCoreService_PlatformInstall_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[6], ArduinoCoreService_PlatformInstall_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -497,7 +714,7 @@ func (x *arduinoCoreServicePlatformInstallClient) Recv() (*PlatformInstallRespon
 }
 
 func (c *arduinoCoreServiceClient) PlatformDownload(ctx context.Context, in *PlatformDownloadRequest, opts ...grpc.CallOption) (ArduinoCoreService_PlatformDownloadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[6], ArduinoCoreService_PlatformDownload_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[7], ArduinoCoreService_PlatformDownload_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -529,7 +746,7 @@ func (x *arduinoCoreServicePlatformDownloadClient) Recv() (*PlatformDownloadResp
 }
 
 func (c *arduinoCoreServiceClient) PlatformUninstall(ctx context.Context, in *PlatformUninstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_PlatformUninstallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[7], ArduinoCoreService_PlatformUninstall_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[8], ArduinoCoreService_PlatformUninstall_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -561,7 +778,7 @@ func (x *arduinoCoreServicePlatformUninstallClient) Recv() (*PlatformUninstallRe
 }
 
 func (c *arduinoCoreServiceClient) PlatformUpgrade(ctx context.Context, in *PlatformUpgradeRequest, opts ...grpc.CallOption) (ArduinoCoreService_PlatformUpgradeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[8], ArduinoCoreService_PlatformUpgrade_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[9], ArduinoCoreService_PlatformUpgrade_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -592,8 +809,53 @@ func (x *arduinoCoreServicePlatformUpgradeClient) Recv() (*PlatformUpgradeRespon
 	return m, nil
 }
 
+func (c *arduinoCoreServiceClient) PlatformUpgradePreview(ctx context.Context, in *PlatformUpgradePreviewRequest, opts ...grpc.CallOption) (*PlatformUpgradePreviewResponse, error) {
+	out := new(PlatformUpgradePreviewResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_PlatformUpgradePreview_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) PlatformPostInstallSteps(ctx context.Context, in *PlatformPostInstallStepsRequest, opts ...grpc.CallOption) (*PlatformPostInstallStepsResponse, error) {
+	out := new(PlatformPostInstallStepsResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_PlatformPostInstallSteps_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) PlatformRunPostInstallStep(ctx context.Context, in *PlatformRunPostInstallStepRequest, opts ...grpc.CallOption) (*PlatformRunPostInstallStepResponse, error) {
+	out := new(PlatformRunPostInstallStepResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_PlatformRunPostInstallStep_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) PlatformAudit(ctx context.Context, in *PlatformAuditRequest, opts ...grpc.CallOption) (*PlatformAuditResponse, error) {
+	out := new(PlatformAuditResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_PlatformAudit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) PlatformCleanTools(ctx context.Context, in *PlatformCleanToolsRequest, opts ...grpc.CallOption) (*PlatformCleanToolsResponse, error) {
+	out := new(PlatformCleanToolsResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_PlatformCleanTools_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arduinoCoreServiceClient) Upload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (ArduinoCoreService_UploadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[9], ArduinoCoreService_Upload_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[10], ArduinoCoreService_Upload_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -625,7 +887,7 @@ func (x *arduinoCoreServiceUploadClient) Recv() (*UploadResponse, error) {
 }
 
 func (c *arduinoCoreServiceClient) UploadUsingProgrammer(ctx context.Context, in *UploadUsingProgrammerRequest, opts ...grpc.CallOption) (ArduinoCoreService_UploadUsingProgrammerClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[10], ArduinoCoreService_UploadUsingProgrammer_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[11], ArduinoCoreService_UploadUsingProgrammer_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -674,8 +936,17 @@ func (c *arduinoCoreServiceClient) ListProgrammersAvailableForUpload(ctx context
 	return out, nil
 }
 
+func (c *arduinoCoreServiceClient) ProgrammerDetails(ctx context.Context, in *ProgrammerDetailsRequest, opts ...grpc.CallOption) (*ProgrammerDetailsResponse, error) {
+	out := new(ProgrammerDetailsResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_ProgrammerDetails_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arduinoCoreServiceClient) BurnBootloader(ctx context.Context, in *BurnBootloaderRequest, opts ...grpc.CallOption) (ArduinoCoreService_BurnBootloaderClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[11], ArduinoCoreService_BurnBootloader_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[12], ArduinoCoreService_BurnBootloader_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -706,21 +977,12 @@ func (x *arduinoCoreServiceBurnBootloaderClient) Recv() (*BurnBootloaderResponse
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) PlatformSearch(ctx context.Context, in *PlatformSearchRequest, opts ...grpc.CallOption) (*PlatformSearchResponse, error) {
-	out := new(PlatformSearchResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_PlatformSearch_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *arduinoCoreServiceClient) LibraryDownload(ctx context.Context, in *LibraryDownloadRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryDownloadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[12], ArduinoCoreService_LibraryDownload_FullMethodName, opts...)
+func (c *arduinoCoreServiceClient) ReadMemory(ctx context.Context, in *ReadMemoryRequest, opts ...grpc.CallOption) (ArduinoCoreService_ReadMemoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[13], ArduinoCoreService_ReadMemory_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceLibraryDownloadClient{stream}
+	x := &arduinoCoreServiceReadMemoryClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -730,29 +992,29 @@ func (c *arduinoCoreServiceClient) LibraryDownload(ctx context.Context, in *Libr
 	return x, nil
 }
 
-type ArduinoCoreService_LibraryDownloadClient interface {
-	Recv() (*LibraryDownloadResponse, error)
+type ArduinoCoreService_ReadMemoryClient interface {
+	Recv() (*ReadMemoryResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceLibraryDownloadClient struct {
+type arduinoCoreServiceReadMemoryClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceLibraryDownloadClient) Recv() (*LibraryDownloadResponse, error) {
-	m := new(LibraryDownloadResponse)
+func (x *arduinoCoreServiceReadMemoryClient) Recv() (*ReadMemoryResponse, error) {
+	m := new(ReadMemoryResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) LibraryInstall(ctx context.Context, in *LibraryInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryInstallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[13], ArduinoCoreService_LibraryInstall_FullMethodName, opts...)
+func (c *arduinoCoreServiceClient) WriteMemory(ctx context.Context, in *WriteMemoryRequest, opts ...grpc.CallOption) (ArduinoCoreService_WriteMemoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[14], ArduinoCoreService_WriteMemory_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceLibraryInstallClient{stream}
+	x := &arduinoCoreServiceWriteMemoryClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -762,29 +1024,29 @@ func (c *arduinoCoreServiceClient) LibraryInstall(ctx context.Context, in *Libra
 	return x, nil
 }
 
-type ArduinoCoreService_LibraryInstallClient interface {
-	Recv() (*LibraryInstallResponse, error)
+type ArduinoCoreService_WriteMemoryClient interface {
+	Recv() (*WriteMemoryResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceLibraryInstallClient struct {
+type arduinoCoreServiceWriteMemoryClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceLibraryInstallClient) Recv() (*LibraryInstallResponse, error) {
-	m := new(LibraryInstallResponse)
+func (x *arduinoCoreServiceWriteMemoryClient) Recv() (*WriteMemoryResponse, error) {
+	m := new(WriteMemoryResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) LibraryUpgrade(ctx context.Context, in *LibraryUpgradeRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUpgradeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[14], ArduinoCoreService_LibraryUpgrade_FullMethodName, opts...)
+func (c *arduinoCoreServiceClient) EraseChip(ctx context.Context, in *EraseChipRequest, opts ...grpc.CallOption) (ArduinoCoreService_EraseChipClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[15], ArduinoCoreService_EraseChip_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceLibraryUpgradeClient{stream}
+	x := &arduinoCoreServiceEraseChipClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -794,29 +1056,29 @@ func (c *arduinoCoreServiceClient) LibraryUpgrade(ctx context.Context, in *Libra
 	return x, nil
 }
 
-type ArduinoCoreService_LibraryUpgradeClient interface {
-	Recv() (*LibraryUpgradeResponse, error)
+type ArduinoCoreService_EraseChipClient interface {
+	Recv() (*EraseChipResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceLibraryUpgradeClient struct {
+type arduinoCoreServiceEraseChipClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceLibraryUpgradeClient) Recv() (*LibraryUpgradeResponse, error) {
-	m := new(LibraryUpgradeResponse)
+func (x *arduinoCoreServiceEraseChipClient) Recv() (*EraseChipResponse, error) {
+	m := new(EraseChipResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) ZipLibraryInstall(ctx context.Context, in *ZipLibraryInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_ZipLibraryInstallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[15], ArduinoCoreService_ZipLibraryInstall_FullMethodName, opts...)
+func (c *arduinoCoreServiceClient) FlashProtection(ctx context.Context, in *FlashProtectionRequest, opts ...grpc.CallOption) (ArduinoCoreService_FlashProtectionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[16], ArduinoCoreService_FlashProtection_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceZipLibraryInstallClient{stream}
+	x := &arduinoCoreServiceFlashProtectionClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -826,29 +1088,29 @@ func (c *arduinoCoreServiceClient) ZipLibraryInstall(ctx context.Context, in *Zi
 	return x, nil
 }
 
-type ArduinoCoreService_ZipLibraryInstallClient interface {
-	Recv() (*ZipLibraryInstallResponse, error)
+type ArduinoCoreService_FlashProtectionClient interface {
+	Recv() (*FlashProtectionResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceZipLibraryInstallClient struct {
+type arduinoCoreServiceFlashProtectionClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceZipLibraryInstallClient) Recv() (*ZipLibraryInstallResponse, error) {
-	m := new(ZipLibraryInstallResponse)
+func (x *arduinoCoreServiceFlashProtectionClient) Recv() (*FlashProtectionResponse, error) {
+	m := new(FlashProtectionResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) GitLibraryInstall(ctx context.Context, in *GitLibraryInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_GitLibraryInstallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[16], ArduinoCoreService_GitLibraryInstall_FullMethodName, opts...)
+func (c *arduinoCoreServiceClient) BoardRecover(ctx context.Context, in *BoardRecoverRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardRecoverClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[17], ArduinoCoreService_BoardRecover_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceGitLibraryInstallClient{stream}
+	x := &arduinoCoreServiceBoardRecoverClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -858,29 +1120,29 @@ func (c *arduinoCoreServiceClient) GitLibraryInstall(ctx context.Context, in *Gi
 	return x, nil
 }
 
-type ArduinoCoreService_GitLibraryInstallClient interface {
-	Recv() (*GitLibraryInstallResponse, error)
+type ArduinoCoreService_BoardRecoverClient interface {
+	Recv() (*BoardRecoverResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceGitLibraryInstallClient struct {
+type arduinoCoreServiceBoardRecoverClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceGitLibraryInstallClient) Recv() (*GitLibraryInstallResponse, error) {
-	m := new(GitLibraryInstallResponse)
+func (x *arduinoCoreServiceBoardRecoverClient) Recv() (*BoardRecoverResponse, error) {
+	m := new(BoardRecoverResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) LibraryUninstall(ctx context.Context, in *LibraryUninstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUninstallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[17], ArduinoCoreService_LibraryUninstall_FullMethodName, opts...)
+func (c *arduinoCoreServiceClient) BoardReset(ctx context.Context, in *BoardResetRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardResetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[18], ArduinoCoreService_BoardReset_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceLibraryUninstallClient{stream}
+	x := &arduinoCoreServiceBoardResetClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -890,29 +1152,29 @@ func (c *arduinoCoreServiceClient) LibraryUninstall(ctx context.Context, in *Lib
 	return x, nil
 }
 
-type ArduinoCoreService_LibraryUninstallClient interface {
-	Recv() (*LibraryUninstallResponse, error)
+type ArduinoCoreService_BoardResetClient interface {
+	Recv() (*BoardResetResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceLibraryUninstallClient struct {
+type arduinoCoreServiceBoardResetClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceLibraryUninstallClient) Recv() (*LibraryUninstallResponse, error) {
-	m := new(LibraryUninstallResponse)
+func (x *arduinoCoreServiceBoardResetClient) Recv() (*BoardResetResponse, error) {
+	m := new(BoardResetResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) LibraryUpgradeAll(ctx context.Context, in *LibraryUpgradeAllRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUpgradeAllClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[18], ArduinoCoreService_LibraryUpgradeAll_FullMethodName, opts...)
+func (c *arduinoCoreServiceClient) BoardCertificates(ctx context.Context, in *BoardCertificatesRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardCertificatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[19], ArduinoCoreService_BoardCertificates_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceLibraryUpgradeAllClient{stream}
+	x := &arduinoCoreServiceBoardCertificatesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -922,974 +1184,2030 @@ func (c *arduinoCoreServiceClient) LibraryUpgradeAll(ctx context.Context, in *Li
 	return x, nil
 }
 
-type ArduinoCoreService_LibraryUpgradeAllClient interface {
-	Recv() (*LibraryUpgradeAllResponse, error)
+type ArduinoCoreService_BoardCertificatesClient interface {
+	Recv() (*BoardCertificatesResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceLibraryUpgradeAllClient struct {
+type arduinoCoreServiceBoardCertificatesClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceLibraryUpgradeAllClient) Recv() (*LibraryUpgradeAllResponse, error) {
-	m := new(LibraryUpgradeAllResponse)
+func (x *arduinoCoreServiceBoardCertificatesClient) Recv() (*BoardCertificatesResponse, error) {
+	m := new(BoardCertificatesResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) LibraryResolveDependencies(ctx context.Context, in *LibraryResolveDependenciesRequest, opts ...grpc.CallOption) (*LibraryResolveDependenciesResponse, error) {
-	out := new(LibraryResolveDependenciesResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_LibraryResolveDependencies_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *arduinoCoreServiceClient) LibrarySearch(ctx context.Context, in *LibrarySearchRequest, opts ...grpc.CallOption) (*LibrarySearchResponse, error) {
-	out := new(LibrarySearchResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_LibrarySearch_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) BoardProvision(ctx context.Context, in *BoardProvisionRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardProvisionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[20], ArduinoCoreService_BoardProvision_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *arduinoCoreServiceClient) LibraryList(ctx context.Context, in *LibraryListRequest, opts ...grpc.CallOption) (*LibraryListResponse, error) {
-	out := new(LibraryListResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_LibraryList_FullMethodName, in, out, opts...)
-	if err != nil {
+	x := &arduinoCoreServiceBoardProvisionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *arduinoCoreServiceClient) Monitor(ctx context.Context, opts ...grpc.CallOption) (ArduinoCoreService_MonitorClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[19], ArduinoCoreService_Monitor_FullMethodName, opts...)
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceMonitorClient{stream}
 	return x, nil
 }
 
-type ArduinoCoreService_MonitorClient interface {
-	Send(*MonitorRequest) error
-	Recv() (*MonitorResponse, error)
+type ArduinoCoreService_BoardProvisionClient interface {
+	Recv() (*BoardProvisionResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceMonitorClient struct {
+type arduinoCoreServiceBoardProvisionClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceMonitorClient) Send(m *MonitorRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *arduinoCoreServiceMonitorClient) Recv() (*MonitorResponse, error) {
-	m := new(MonitorResponse)
+func (x *arduinoCoreServiceBoardProvisionClient) Recv() (*BoardProvisionResponse, error) {
+	m := new(BoardProvisionResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) EnumerateMonitorPortSettings(ctx context.Context, in *EnumerateMonitorPortSettingsRequest, opts ...grpc.CallOption) (*EnumerateMonitorPortSettingsResponse, error) {
-	out := new(EnumerateMonitorPortSettingsResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_EnumerateMonitorPortSettings_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) BoardPower(ctx context.Context, in *BoardPowerRequest, opts ...grpc.CallOption) (ArduinoCoreService_BoardPowerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[21], ArduinoCoreService_BoardPower_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *arduinoCoreServiceClient) Debug(ctx context.Context, opts ...grpc.CallOption) (ArduinoCoreService_DebugClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[20], ArduinoCoreService_Debug_FullMethodName, opts...)
-	if err != nil {
+	x := &arduinoCoreServiceBoardPowerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	x := &arduinoCoreServiceDebugClient{stream}
 	return x, nil
 }
 
-type ArduinoCoreService_DebugClient interface {
-	Send(*DebugRequest) error
-	Recv() (*DebugResponse, error)
+type ArduinoCoreService_BoardPowerClient interface {
+	Recv() (*BoardPowerResponse, error)
 	grpc.ClientStream
 }
 
-type arduinoCoreServiceDebugClient struct {
+type arduinoCoreServiceBoardPowerClient struct {
 	grpc.ClientStream
 }
 
-func (x *arduinoCoreServiceDebugClient) Send(m *DebugRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *arduinoCoreServiceDebugClient) Recv() (*DebugResponse, error) {
-	m := new(DebugResponse)
+func (x *arduinoCoreServiceBoardPowerClient) Recv() (*BoardPowerResponse, error) {
+	m := new(BoardPowerResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) IsDebugSupported(ctx context.Context, in *IsDebugSupportedRequest, opts ...grpc.CallOption) (*IsDebugSupportedResponse, error) {
-	out := new(IsDebugSupportedResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_IsDebugSupported_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) DeploymentsList(ctx context.Context, in *DeploymentsListRequest, opts ...grpc.CallOption) (*DeploymentsListResponse, error) {
+	out := new(DeploymentsListResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_DeploymentsList_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *arduinoCoreServiceClient) GetDebugConfig(ctx context.Context, in *GetDebugConfigRequest, opts ...grpc.CallOption) (*GetDebugConfigResponse, error) {
-	out := new(GetDebugConfigResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_GetDebugConfig_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) DeploymentsShow(ctx context.Context, in *DeploymentsShowRequest, opts ...grpc.CallOption) (*DeploymentsShowResponse, error) {
+	out := new(DeploymentsShowResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_DeploymentsShow_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *arduinoCoreServiceClient) CheckForArduinoCLIUpdates(ctx context.Context, in *CheckForArduinoCLIUpdatesRequest, opts ...grpc.CallOption) (*CheckForArduinoCLIUpdatesResponse, error) {
-	out := new(CheckForArduinoCLIUpdatesResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_CheckForArduinoCLIUpdates_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) PlatformSearch(ctx context.Context, in *PlatformSearchRequest, opts ...grpc.CallOption) (*PlatformSearchResponse, error) {
+	out := new(PlatformSearchResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_PlatformSearch_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *arduinoCoreServiceClient) CleanDownloadCacheDirectory(ctx context.Context, in *CleanDownloadCacheDirectoryRequest, opts ...grpc.CallOption) (*CleanDownloadCacheDirectoryResponse, error) {
-	out := new(CleanDownloadCacheDirectoryResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_CleanDownloadCacheDirectory_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) LibraryDownload(ctx context.Context, in *LibraryDownloadRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryDownloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[22], ArduinoCoreService_LibraryDownload_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *arduinoCoreServiceClient) SettingsGetAll(ctx context.Context, in *SettingsGetAllRequest, opts ...grpc.CallOption) (*SettingsGetAllResponse, error) {
-	out := new(SettingsGetAllResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsGetAll_FullMethodName, in, out, opts...)
-	if err != nil {
+	x := &arduinoCoreServiceLibraryDownloadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *arduinoCoreServiceClient) SettingsMerge(ctx context.Context, in *SettingsMergeRequest, opts ...grpc.CallOption) (*SettingsMergeResponse, error) {
-	out := new(SettingsMergeResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsMerge_FullMethodName, in, out, opts...)
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return x, nil
 }
 
-func (c *arduinoCoreServiceClient) SettingsGetValue(ctx context.Context, in *SettingsGetValueRequest, opts ...grpc.CallOption) (*SettingsGetValueResponse, error) {
-	out := new(SettingsGetValueResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsGetValue_FullMethodName, in, out, opts...)
-	if err != nil {
+type ArduinoCoreService_LibraryDownloadClient interface {
+	Recv() (*LibraryDownloadResponse, error)
+	grpc.ClientStream
+}
+
+type arduinoCoreServiceLibraryDownloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *arduinoCoreServiceLibraryDownloadClient) Recv() (*LibraryDownloadResponse, error) {
+	m := new(LibraryDownloadResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) SettingsSetValue(ctx context.Context, in *SettingsSetValueRequest, opts ...grpc.CallOption) (*SettingsSetValueResponse, error) {
-	out := new(SettingsSetValueResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsSetValue_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) LibraryInstall(ctx context.Context, in *LibraryInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryInstallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[23], ArduinoCoreService_LibraryInstall_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &arduinoCoreServiceLibraryInstallClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *arduinoCoreServiceClient) SettingsWrite(ctx context.Context, in *SettingsWriteRequest, opts ...grpc.CallOption) (*SettingsWriteResponse, error) {
-	out := new(SettingsWriteResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsWrite_FullMethodName, in, out, opts...)
-	if err != nil {
+type ArduinoCoreService_LibraryInstallClient interface {
+	Recv() (*LibraryInstallResponse, error)
+	grpc.ClientStream
+}
+
+type arduinoCoreServiceLibraryInstallClient struct {
+	grpc.ClientStream
+}
+
+func (x *arduinoCoreServiceLibraryInstallClient) Recv() (*LibraryInstallResponse, error) {
+	m := new(LibraryInstallResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *arduinoCoreServiceClient) SettingsDelete(ctx context.Context, in *SettingsDeleteRequest, opts ...grpc.CallOption) (*SettingsDeleteResponse, error) {
-	out := new(SettingsDeleteResponse)
-	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsDelete_FullMethodName, in, out, opts...)
+func (c *arduinoCoreServiceClient) LibraryUpgrade(ctx context.Context, in *LibraryUpgradeRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUpgradeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[24], ArduinoCoreService_LibraryUpgrade_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &arduinoCoreServiceLibraryUpgradeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// ArduinoCoreServiceServer is the server API for ArduinoCoreService service.
-// All implementations must embed UnimplementedArduinoCoreServiceServer
-// for forward compatibility
-type ArduinoCoreServiceServer interface {
-	// Create a new Arduino Core instance
-	Create(context.Context, *CreateRequest) (*CreateResponse, error)
-	// Initializes an existing Arduino Core instance by loading platforms and
-	// libraries
-	Init(*InitRequest, ArduinoCoreService_InitServer) error
-	// Destroy an instance of the Arduino Core Service
-	Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error)
-	// Update package index of the Arduino Core Service
-	UpdateIndex(*UpdateIndexRequest, ArduinoCoreService_UpdateIndexServer) error
-	// Update libraries index
-	UpdateLibrariesIndex(*UpdateLibrariesIndexRequest, ArduinoCoreService_UpdateLibrariesIndexServer) error
-	// Get the version of Arduino CLI in use.
-	Version(context.Context, *VersionRequest) (*VersionResponse, error)
-	// Create a new Sketch
-	NewSketch(context.Context, *NewSketchRequest) (*NewSketchResponse, error)
-	// Returns all files composing a Sketch
-	LoadSketch(context.Context, *LoadSketchRequest) (*LoadSketchResponse, error)
-	// Creates a zip file containing all files of specified Sketch
-	ArchiveSketch(context.Context, *ArchiveSketchRequest) (*ArchiveSketchResponse, error)
-	// Sets the sketch default FQBN and Port Address/Protocol in
-	// the sketch project file (sketch.yaml). These metadata can be retrieved
-	// using LoadSketch.
-	SetSketchDefaults(context.Context, *SetSketchDefaultsRequest) (*SetSketchDefaultsResponse, error)
-	// Requests details about a board
-	BoardDetails(context.Context, *BoardDetailsRequest) (*BoardDetailsResponse, error)
-	// List the boards currently connected to the computer.
-	BoardList(context.Context, *BoardListRequest) (*BoardListResponse, error)
-	// List all the boards provided by installed platforms.
-	BoardListAll(context.Context, *BoardListAllRequest) (*BoardListAllResponse, error)
-	// Search boards in installed and not installed Platforms.
-	BoardSearch(context.Context, *BoardSearchRequest) (*BoardSearchResponse, error)
-	// List boards connection and disconnected events.
-	BoardListWatch(*BoardListWatchRequest, ArduinoCoreService_BoardListWatchServer) error
-	// Compile an Arduino sketch.
-	Compile(*CompileRequest, ArduinoCoreService_CompileServer) error
-	// Download and install a platform and its tool dependencies.
-	PlatformInstall(*PlatformInstallRequest, ArduinoCoreService_PlatformInstallServer) error
-	// Download a platform and its tool dependencies to the `staging/packages`
-	// subdirectory of the data directory.
-	PlatformDownload(*PlatformDownloadRequest, ArduinoCoreService_PlatformDownloadServer) error
-	// Uninstall a platform as well as its tool dependencies that are not used by
-	// other installed platforms.
-	PlatformUninstall(*PlatformUninstallRequest, ArduinoCoreService_PlatformUninstallServer) error
-	// Upgrade an installed platform to the latest version.
-	PlatformUpgrade(*PlatformUpgradeRequest, ArduinoCoreService_PlatformUpgradeServer) error
-	// Upload a compiled sketch to a board.
-	Upload(*UploadRequest, ArduinoCoreService_UploadServer) error
-	// Upload a compiled sketch to a board using a programmer.
-	UploadUsingProgrammer(*UploadUsingProgrammerRequest, ArduinoCoreService_UploadUsingProgrammerServer) error
-	// Returns the list of users fields necessary to upload to that board
-	// using the specified protocol.
-	SupportedUserFields(context.Context, *SupportedUserFieldsRequest) (*SupportedUserFieldsResponse, error)
-	// List programmers available for a board.
-	ListProgrammersAvailableForUpload(context.Context, *ListProgrammersAvailableForUploadRequest) (*ListProgrammersAvailableForUploadResponse, error)
-	// Burn bootloader to a board.
-	BurnBootloader(*BurnBootloaderRequest, ArduinoCoreService_BurnBootloaderServer) error
-	// Search for a platform in the platforms indexes.
-	PlatformSearch(context.Context, *PlatformSearchRequest) (*PlatformSearchResponse, error)
-	// Download the archive file of an Arduino library in the libraries index to
-	// the staging directory.
-	LibraryDownload(*LibraryDownloadRequest, ArduinoCoreService_LibraryDownloadServer) error
-	// Download and install an Arduino library from the libraries index.
-	LibraryInstall(*LibraryInstallRequest, ArduinoCoreService_LibraryInstallServer) error
-	// Upgrade a library to the newest version available.
-	LibraryUpgrade(*LibraryUpgradeRequest, ArduinoCoreService_LibraryUpgradeServer) error
-	// Install a library from a Zip File
-	ZipLibraryInstall(*ZipLibraryInstallRequest, ArduinoCoreService_ZipLibraryInstallServer) error
-	// Download and install a library from a git url
-	GitLibraryInstall(*GitLibraryInstallRequest, ArduinoCoreService_GitLibraryInstallServer) error
-	// Uninstall an Arduino library.
-	LibraryUninstall(*LibraryUninstallRequest, ArduinoCoreService_LibraryUninstallServer) error
-	// Upgrade all installed Arduino libraries to the newest version available.
-	LibraryUpgradeAll(*LibraryUpgradeAllRequest, ArduinoCoreService_LibraryUpgradeAllServer) error
-	// List the recursive dependencies of a library, as defined by the `depends`
-	// field of the library.properties files.
-	LibraryResolveDependencies(context.Context, *LibraryResolveDependenciesRequest) (*LibraryResolveDependenciesResponse, error)
-	// Search the Arduino libraries index for libraries.
-	LibrarySearch(context.Context, *LibrarySearchRequest) (*LibrarySearchResponse, error)
-	// List the installed libraries.
-	LibraryList(context.Context, *LibraryListRequest) (*LibraryListResponse, error)
-	// Open a monitor connection to a board port
-	Monitor(ArduinoCoreService_MonitorServer) error
-	// Returns the parameters that can be set in the MonitorRequest calls
-	EnumerateMonitorPortSettings(context.Context, *EnumerateMonitorPortSettingsRequest) (*EnumerateMonitorPortSettingsResponse, error)
-	// Start a debug session and communicate with the debugger tool.
-	Debug(ArduinoCoreService_DebugServer) error
-	// Determine if debugging is suported given a specific configuration.
-	IsDebugSupported(context.Context, *IsDebugSupportedRequest) (*IsDebugSupportedResponse, error)
-	// Query the debugger information given a specific configuration.
-	GetDebugConfig(context.Context, *GetDebugConfigRequest) (*GetDebugConfigResponse, error)
-	// Check for updates to the Arduino CLI.
-	CheckForArduinoCLIUpdates(context.Context, *CheckForArduinoCLIUpdatesRequest) (*CheckForArduinoCLIUpdatesResponse, error)
-	// Clean the download cache directory (where archives are downloaded).
-	CleanDownloadCacheDirectory(context.Context, *CleanDownloadCacheDirectoryRequest) (*CleanDownloadCacheDirectoryResponse, error)
-	// List all the settings.
-	SettingsGetAll(context.Context, *SettingsGetAllRequest) (*SettingsGetAllResponse, error)
-	// Set multiple settings values at once.
-	SettingsMerge(context.Context, *SettingsMergeRequest) (*SettingsMergeResponse, error)
-	// Get the value of a specific setting.
-	SettingsGetValue(context.Context, *SettingsGetValueRequest) (*SettingsGetValueResponse, error)
-	// Set the value of a specific setting.
-	SettingsSetValue(context.Context, *SettingsSetValueRequest) (*SettingsSetValueResponse, error)
-	// Writes to file settings currently stored in memory
-	SettingsWrite(context.Context, *SettingsWriteRequest) (*SettingsWriteResponse, error)
-	// Deletes an entry and rewrites the file settings
-	SettingsDelete(context.Context, *SettingsDeleteRequest) (*SettingsDeleteResponse, error)
-	mustEmbedUnimplementedArduinoCoreServiceServer()
+type ArduinoCoreService_LibraryUpgradeClient interface {
+	Recv() (*LibraryUpgradeResponse, error)
+	grpc.ClientStream
 }
 
-// UnimplementedArduinoCoreServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedArduinoCoreServiceServer struct {
+type arduinoCoreServiceLibraryUpgradeClient struct {
+	grpc.ClientStream
 }
 
-func (UnimplementedArduinoCoreServiceServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
-}
-func (UnimplementedArduinoCoreServiceServer) Init(*InitRequest, ArduinoCoreService_InitServer) error {
-	return status.Errorf(codes.Unimplemented, "method Init not implemented")
-}
-func (UnimplementedArduinoCoreServiceServer) Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+func (x *arduinoCoreServiceLibraryUpgradeClient) Recv() (*LibraryUpgradeResponse, error) {
+	m := new(LibraryUpgradeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) UpdateIndex(*UpdateIndexRequest, ArduinoCoreService_UpdateIndexServer) error {
-	return status.Errorf(codes.Unimplemented, "method UpdateIndex not implemented")
+
+func (c *arduinoCoreServiceClient) ZipLibraryInstall(ctx context.Context, in *ZipLibraryInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_ZipLibraryInstallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[25], ArduinoCoreService_ZipLibraryInstall_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceZipLibraryInstallClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedArduinoCoreServiceServer) UpdateLibrariesIndex(*UpdateLibrariesIndexRequest, ArduinoCoreService_UpdateLibrariesIndexServer) error {
-	return status.Errorf(codes.Unimplemented, "method UpdateLibrariesIndex not implemented")
+
+type ArduinoCoreService_ZipLibraryInstallClient interface {
+	Recv() (*ZipLibraryInstallResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) Version(context.Context, *VersionRequest) (*VersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+
+type arduinoCoreServiceZipLibraryInstallClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) NewSketch(context.Context, *NewSketchRequest) (*NewSketchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NewSketch not implemented")
+
+func (x *arduinoCoreServiceZipLibraryInstallClient) Recv() (*ZipLibraryInstallResponse, error) {
+	m := new(ZipLibraryInstallResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) LoadSketch(context.Context, *LoadSketchRequest) (*LoadSketchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoadSketch not implemented")
+
+func (c *arduinoCoreServiceClient) GitLibraryInstall(ctx context.Context, in *GitLibraryInstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_GitLibraryInstallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[26], ArduinoCoreService_GitLibraryInstall_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceGitLibraryInstallClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedArduinoCoreServiceServer) ArchiveSketch(context.Context, *ArchiveSketchRequest) (*ArchiveSketchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ArchiveSketch not implemented")
+
+type ArduinoCoreService_GitLibraryInstallClient interface {
+	Recv() (*GitLibraryInstallResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) SetSketchDefaults(context.Context, *SetSketchDefaultsRequest) (*SetSketchDefaultsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetSketchDefaults not implemented")
+
+type arduinoCoreServiceGitLibraryInstallClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) BoardDetails(context.Context, *BoardDetailsRequest) (*BoardDetailsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BoardDetails not implemented")
+
+func (x *arduinoCoreServiceGitLibraryInstallClient) Recv() (*GitLibraryInstallResponse, error) {
+	m := new(GitLibraryInstallResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) BoardList(context.Context, *BoardListRequest) (*BoardListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BoardList not implemented")
+
+func (c *arduinoCoreServiceClient) LibraryUninstall(ctx context.Context, in *LibraryUninstallRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUninstallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[27], ArduinoCoreService_LibraryUninstall_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceLibraryUninstallClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedArduinoCoreServiceServer) BoardListAll(context.Context, *BoardListAllRequest) (*BoardListAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BoardListAll not implemented")
+
+type ArduinoCoreService_LibraryUninstallClient interface {
+	Recv() (*LibraryUninstallResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) BoardSearch(context.Context, *BoardSearchRequest) (*BoardSearchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BoardSearch not implemented")
+
+type arduinoCoreServiceLibraryUninstallClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) BoardListWatch(*BoardListWatchRequest, ArduinoCoreService_BoardListWatchServer) error {
-	return status.Errorf(codes.Unimplemented, "method BoardListWatch not implemented")
+
+func (x *arduinoCoreServiceLibraryUninstallClient) Recv() (*LibraryUninstallResponse, error) {
+	m := new(LibraryUninstallResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) Compile(*CompileRequest, ArduinoCoreService_CompileServer) error {
-	return status.Errorf(codes.Unimplemented, "method Compile not implemented")
+
+func (c *arduinoCoreServiceClient) LibraryUpgradeAll(ctx context.Context, in *LibraryUpgradeAllRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryUpgradeAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[28], ArduinoCoreService_LibraryUpgradeAll_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceLibraryUpgradeAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedArduinoCoreServiceServer) PlatformInstall(*PlatformInstallRequest, ArduinoCoreService_PlatformInstallServer) error {
-	return status.Errorf(codes.Unimplemented, "method PlatformInstall not implemented")
+
+type ArduinoCoreService_LibraryUpgradeAllClient interface {
+	Recv() (*LibraryUpgradeAllResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) PlatformDownload(*PlatformDownloadRequest, ArduinoCoreService_PlatformDownloadServer) error {
-	return status.Errorf(codes.Unimplemented, "method PlatformDownload not implemented")
+
+type arduinoCoreServiceLibraryUpgradeAllClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) PlatformUninstall(*PlatformUninstallRequest, ArduinoCoreService_PlatformUninstallServer) error {
-	return status.Errorf(codes.Unimplemented, "method PlatformUninstall not implemented")
+
+func (x *arduinoCoreServiceLibraryUpgradeAllClient) Recv() (*LibraryUpgradeAllResponse, error) {
+	m := new(LibraryUpgradeAllResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) PlatformUpgrade(*PlatformUpgradeRequest, ArduinoCoreService_PlatformUpgradeServer) error {
-	return status.Errorf(codes.Unimplemented, "method PlatformUpgrade not implemented")
+
+func (c *arduinoCoreServiceClient) LibraryUpgradePreview(ctx context.Context, in *LibraryUpgradePreviewRequest, opts ...grpc.CallOption) (*LibraryUpgradePreviewResponse, error) {
+	out := new(LibraryUpgradePreviewResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_LibraryUpgradePreview_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) Upload(*UploadRequest, ArduinoCoreService_UploadServer) error {
-	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
+
+func (c *arduinoCoreServiceClient) LibraryResolveDependencies(ctx context.Context, in *LibraryResolveDependenciesRequest, opts ...grpc.CallOption) (*LibraryResolveDependenciesResponse, error) {
+	out := new(LibraryResolveDependenciesResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_LibraryResolveDependencies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) UploadUsingProgrammer(*UploadUsingProgrammerRequest, ArduinoCoreService_UploadUsingProgrammerServer) error {
-	return status.Errorf(codes.Unimplemented, "method UploadUsingProgrammer not implemented")
+
+func (c *arduinoCoreServiceClient) LibraryDiff(ctx context.Context, in *LibraryDiffRequest, opts ...grpc.CallOption) (ArduinoCoreService_LibraryDiffClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[29], ArduinoCoreService_LibraryDiff_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceLibraryDiffClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedArduinoCoreServiceServer) SupportedUserFields(context.Context, *SupportedUserFieldsRequest) (*SupportedUserFieldsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SupportedUserFields not implemented")
+
+type ArduinoCoreService_LibraryDiffClient interface {
+	Recv() (*LibraryDiffResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) ListProgrammersAvailableForUpload(context.Context, *ListProgrammersAvailableForUploadRequest) (*ListProgrammersAvailableForUploadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListProgrammersAvailableForUpload not implemented")
+
+type arduinoCoreServiceLibraryDiffClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) BurnBootloader(*BurnBootloaderRequest, ArduinoCoreService_BurnBootloaderServer) error {
-	return status.Errorf(codes.Unimplemented, "method BurnBootloader not implemented")
+
+func (x *arduinoCoreServiceLibraryDiffClient) Recv() (*LibraryDiffResponse, error) {
+	m := new(LibraryDiffResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) PlatformSearch(context.Context, *PlatformSearchRequest) (*PlatformSearchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PlatformSearch not implemented")
+
+func (c *arduinoCoreServiceClient) LibraryNew(ctx context.Context, in *LibraryNewRequest, opts ...grpc.CallOption) (*LibraryNewResponse, error) {
+	out := new(LibraryNewResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_LibraryNew_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) LibraryDownload(*LibraryDownloadRequest, ArduinoCoreService_LibraryDownloadServer) error {
-	return status.Errorf(codes.Unimplemented, "method LibraryDownload not implemented")
+
+func (c *arduinoCoreServiceClient) LibrarySearch(ctx context.Context, in *LibrarySearchRequest, opts ...grpc.CallOption) (*LibrarySearchResponse, error) {
+	out := new(LibrarySearchResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_LibrarySearch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) LibraryInstall(*LibraryInstallRequest, ArduinoCoreService_LibraryInstallServer) error {
-	return status.Errorf(codes.Unimplemented, "method LibraryInstall not implemented")
+
+func (c *arduinoCoreServiceClient) LibraryList(ctx context.Context, in *LibraryListRequest, opts ...grpc.CallOption) (*LibraryListResponse, error) {
+	out := new(LibraryListResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_LibraryList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) LibraryUpgrade(*LibraryUpgradeRequest, ArduinoCoreService_LibraryUpgradeServer) error {
-	return status.Errorf(codes.Unimplemented, "method LibraryUpgrade not implemented")
+
+func (c *arduinoCoreServiceClient) RescanLibraries(ctx context.Context, in *RescanLibrariesRequest, opts ...grpc.CallOption) (*RescanLibrariesResponse, error) {
+	out := new(RescanLibrariesResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_RescanLibraries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) ZipLibraryInstall(*ZipLibraryInstallRequest, ArduinoCoreService_ZipLibraryInstallServer) error {
-	return status.Errorf(codes.Unimplemented, "method ZipLibraryInstall not implemented")
+
+func (c *arduinoCoreServiceClient) Monitor(ctx context.Context, opts ...grpc.CallOption) (ArduinoCoreService_MonitorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[30], ArduinoCoreService_Monitor_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceMonitorClient{stream}
+	return x, nil
 }
-func (UnimplementedArduinoCoreServiceServer) GitLibraryInstall(*GitLibraryInstallRequest, ArduinoCoreService_GitLibraryInstallServer) error {
-	return status.Errorf(codes.Unimplemented, "method GitLibraryInstall not implemented")
+
+type ArduinoCoreService_MonitorClient interface {
+	Send(*MonitorRequest) error
+	Recv() (*MonitorResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) LibraryUninstall(*LibraryUninstallRequest, ArduinoCoreService_LibraryUninstallServer) error {
-	return status.Errorf(codes.Unimplemented, "method LibraryUninstall not implemented")
+
+type arduinoCoreServiceMonitorClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) LibraryUpgradeAll(*LibraryUpgradeAllRequest, ArduinoCoreService_LibraryUpgradeAllServer) error {
-	return status.Errorf(codes.Unimplemented, "method LibraryUpgradeAll not implemented")
+
+func (x *arduinoCoreServiceMonitorClient) Send(m *MonitorRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
-func (UnimplementedArduinoCoreServiceServer) LibraryResolveDependencies(context.Context, *LibraryResolveDependenciesRequest) (*LibraryResolveDependenciesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LibraryResolveDependencies not implemented")
+
+func (x *arduinoCoreServiceMonitorClient) Recv() (*MonitorResponse, error) {
+	m := new(MonitorResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) LibrarySearch(context.Context, *LibrarySearchRequest) (*LibrarySearchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LibrarySearch not implemented")
+
+func (c *arduinoCoreServiceClient) EnumerateMonitorPortSettings(ctx context.Context, in *EnumerateMonitorPortSettingsRequest, opts ...grpc.CallOption) (*EnumerateMonitorPortSettingsResponse, error) {
+	out := new(EnumerateMonitorPortSettingsResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_EnumerateMonitorPortSettings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) LibraryList(context.Context, *LibraryListRequest) (*LibraryListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LibraryList not implemented")
+
+func (c *arduinoCoreServiceClient) Debug(ctx context.Context, opts ...grpc.CallOption) (ArduinoCoreService_DebugClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArduinoCoreService_ServiceDesc.Streams[31], ArduinoCoreService_Debug_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arduinoCoreServiceDebugClient{stream}
+	return x, nil
 }
-func (UnimplementedArduinoCoreServiceServer) Monitor(ArduinoCoreService_MonitorServer) error {
-	return status.Errorf(codes.Unimplemented, "method Monitor not implemented")
+
+type ArduinoCoreService_DebugClient interface {
+	Send(*DebugRequest) error
+	Recv() (*DebugResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) EnumerateMonitorPortSettings(context.Context, *EnumerateMonitorPortSettingsRequest) (*EnumerateMonitorPortSettingsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EnumerateMonitorPortSettings not implemented")
+
+type arduinoCoreServiceDebugClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedArduinoCoreServiceServer) Debug(ArduinoCoreService_DebugServer) error {
-	return status.Errorf(codes.Unimplemented, "method Debug not implemented")
+
+func (x *arduinoCoreServiceDebugClient) Send(m *DebugRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
-func (UnimplementedArduinoCoreServiceServer) IsDebugSupported(context.Context, *IsDebugSupportedRequest) (*IsDebugSupportedResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsDebugSupported not implemented")
+
+func (x *arduinoCoreServiceDebugClient) Recv() (*DebugResponse, error) {
+	m := new(DebugResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedArduinoCoreServiceServer) GetDebugConfig(context.Context, *GetDebugConfigRequest) (*GetDebugConfigResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDebugConfig not implemented")
+
+func (c *arduinoCoreServiceClient) IsDebugSupported(ctx context.Context, in *IsDebugSupportedRequest, opts ...grpc.CallOption) (*IsDebugSupportedResponse, error) {
+	out := new(IsDebugSupportedResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_IsDebugSupported_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedArduinoCoreServiceServer) CheckForArduinoCLIUpdates(context.Context, *CheckForArduinoCLIUpdatesRequest) (*CheckForArduinoCLIUpdatesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CheckForArduinoCLIUpdates not implemented")
+
+func (c *arduinoCoreServiceClient) GetDebugConfig(ctx context.Context, in *GetDebugConfigRequest, opts ...grpc.CallOption) (*GetDebugConfigResponse, error) {
+	out := new(GetDebugConfigResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_GetDebugConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) CheckForArduinoCLIUpdates(ctx context.Context, in *CheckForArduinoCLIUpdatesRequest, opts ...grpc.CallOption) (*CheckForArduinoCLIUpdatesResponse, error) {
+	out := new(CheckForArduinoCLIUpdatesResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_CheckForArduinoCLIUpdates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) CleanDownloadCacheDirectory(ctx context.Context, in *CleanDownloadCacheDirectoryRequest, opts ...grpc.CallOption) (*CleanDownloadCacheDirectoryResponse, error) {
+	out := new(CleanDownloadCacheDirectoryResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_CleanDownloadCacheDirectory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error) {
+	out := new(CleanupResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_Cleanup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error) {
+	out := new(DiskUsageResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_DiskUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) SettingsGetAll(ctx context.Context, in *SettingsGetAllRequest, opts ...grpc.CallOption) (*SettingsGetAllResponse, error) {
+	out := new(SettingsGetAllResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsGetAll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) SettingsMerge(ctx context.Context, in *SettingsMergeRequest, opts ...grpc.CallOption) (*SettingsMergeResponse, error) {
+	out := new(SettingsMergeResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsMerge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) SettingsGetValue(ctx context.Context, in *SettingsGetValueRequest, opts ...grpc.CallOption) (*SettingsGetValueResponse, error) {
+	out := new(SettingsGetValueResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsGetValue_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) SettingsSetValue(ctx context.Context, in *SettingsSetValueRequest, opts ...grpc.CallOption) (*SettingsSetValueResponse, error) {
+	out := new(SettingsSetValueResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsSetValue_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) SettingsWrite(ctx context.Context, in *SettingsWriteRequest, opts ...grpc.CallOption) (*SettingsWriteResponse, error) {
+	out := new(SettingsWriteResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsWrite_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arduinoCoreServiceClient) SettingsDelete(ctx context.Context, in *SettingsDeleteRequest, opts ...grpc.CallOption) (*SettingsDeleteResponse, error) {
+	out := new(SettingsDeleteResponse)
+	err := c.cc.Invoke(ctx, ArduinoCoreService_SettingsDelete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArduinoCoreServiceServer is the server API for ArduinoCoreService service.
+// All implementations must embed UnimplementedArduinoCoreServiceServer
+// for forward compatibility
+type ArduinoCoreServiceServer interface {
+	// Create a new Arduino Core instance
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	// Initializes an existing Arduino Core instance by loading platforms and
+	// libraries
+	Init(*InitRequest, ArduinoCoreService_InitServer) error
+	// Destroy an instance of the Arduino Core Service
+	Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error)
+	// Update package index of the Arduino Core Service
+	UpdateIndex(*UpdateIndexRequest, ArduinoCoreService_UpdateIndexServer) error
+	// Update libraries index
+	UpdateLibrariesIndex(*UpdateLibrariesIndexRequest, ArduinoCoreService_UpdateLibrariesIndexServer) error
+	// Start watching for changes to the state shared by the instances (indexes
+	// updated, platforms or libraries installed or removed, sketchbook changed).
+	// Clients can use these events to refresh their data without polling.
+	InstanceEvents(*InstanceEventsRequest, ArduinoCoreService_InstanceEventsServer) error
+	// Get the version of Arduino CLI in use.
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+	// Get the API version and the list of features supported by the daemon.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
+	// Create a new Sketch
+	NewSketch(context.Context, *NewSketchRequest) (*NewSketchResponse, error)
+	// Returns all files composing a Sketch
+	LoadSketch(context.Context, *LoadSketchRequest) (*LoadSketchResponse, error)
+	// Creates a zip file containing all files of specified Sketch
+	ArchiveSketch(context.Context, *ArchiveSketchRequest) (*ArchiveSketchResponse, error)
+	// Merges the files of a sketch in a single, preprocessed, source file that
+	// can be shared to reproduce an issue.
+	FlattenSketch(context.Context, *FlattenSketchRequest) (*FlattenSketchResponse, error)
+	// Sets the sketch default FQBN and Port Address/Protocol in
+	// the sketch project file (sketch.yaml). These metadata can be retrieved
+	// using LoadSketch.
+	SetSketchDefaults(context.Context, *SetSketchDefaultsRequest) (*SetSketchDefaultsResponse, error)
+	// List the sketches contained in the sketchbook, optionally filtered by
+	// name or board.
+	SketchbookList(context.Context, *SketchbookListRequest) (*SketchbookListResponse, error)
+	// Requests details about a board
+	BoardDetails(context.Context, *BoardDetailsRequest) (*BoardDetailsResponse, error)
+	// List the boards currently connected to the computer.
+	BoardList(context.Context, *BoardListRequest) (*BoardListResponse, error)
+	// List all the boards provided by installed platforms.
+	BoardListAll(context.Context, *BoardListAllRequest) (*BoardListAllResponse, error)
+	// Search boards in installed and not installed Platforms.
+	BoardSearch(context.Context, *BoardSearchRequest) (*BoardSearchResponse, error)
+	// List boards connection and disconnected events.
+	BoardListWatch(*BoardListWatchRequest, ArduinoCoreService_BoardListWatchServer) error
+	// Check if the current user has the permissions needed to access a port
+	// and, optionally, try to fix the detected issues.
+	BoardSetupPermissions(context.Context, *BoardSetupPermissionsRequest) (*BoardSetupPermissionsResponse, error)
+	// Compile an Arduino sketch.
+	Compile(*CompileRequest, ArduinoCoreService_CompileServer) error
+	// Prepare the state of the builds for a board, so that the following
+	// compilations for the same board skip the loading of the libraries.
+	CompileWarmUp(context.Context, *CompileWarmUpRequest) (*CompileWarmUpResponse, error)
+	// Drop the state of the builds kept between the compilations.
+	CompileDropWarmState(context.Context, *CompileDropWarmStateRequest) (*CompileDropWarmStateResponse, error)
+	// Resolve the addresses reported by a firmware, using the executable saved
+	// in the symbols archive when the firmware was compiled.
+	Symbolize(context.Context, *SymbolizeRequest) (*SymbolizeResponse, error)
+	// Download and install a platform and its tool dependencies.
+	PlatformInstall(*PlatformInstallRequest, ArduinoCoreService_PlatformInstallServer) error
+	// Download a platform and its tool dependencies to the `staging/packages`
+	// subdirectory of the data directory.
+	PlatformDownload(*PlatformDownloadRequest, ArduinoCoreService_PlatformDownloadServer) error
+	// Uninstall a platform as well as its tool dependencies that are not used by
+	// other installed platforms.
+	PlatformUninstall(*PlatformUninstallRequest, ArduinoCoreService_PlatformUninstallServer) error
+	// Upgrade an installed platform to the latest version.
+	PlatformUpgrade(*PlatformUpgradeRequest, ArduinoCoreService_PlatformUpgradeServer) error
+	// Preview the changes of a platform upgrade: versions, tools to download and
+	// tools to remove.
+	PlatformUpgradePreview(context.Context, *PlatformUpgradePreviewRequest) (*PlatformUpgradePreviewResponse, error)
+	// List the post-install setup steps declared by an installed platform.
+	PlatformPostInstallSteps(context.Context, *PlatformPostInstallStepsRequest) (*PlatformPostInstallStepsResponse, error)
+	// Run a post-install setup step of an installed platform. The step executes
+	// the command declared by the platform in its platform.txt (or its
+	// post-install script) with the privileges of the daemon: clients must show
+	// the command to the user and set `confirmed` only after the user agreed to
+	// run it. A step that requires administrative privileges is not run, and a
+	// PostInstallStepRequiresAdminError detail is returned, if the daemon is not
+	// running with them.
+	PlatformRunPostInstallStep(context.Context, *PlatformRunPostInstallStepRequest) (*PlatformRunPostInstallStepResponse, error)
+	// Audit the tools installed by the package manager: report the tools whose
+	// checksum in the package index changed since the installation and the
+	// tools downloaded over insecure transports.
+	PlatformAudit(context.Context, *PlatformAuditRequest) (*PlatformAuditResponse, error)
+	// Remove the installed tools that are not required by any installed
+	// platform and the shared copies of the tools that are not used anymore.
+	PlatformCleanTools(context.Context, *PlatformCleanToolsRequest) (*PlatformCleanToolsResponse, error)
+	// Upload a compiled sketch to a board.
+	Upload(*UploadRequest, ArduinoCoreService_UploadServer) error
+	// Upload a compiled sketch to a board using a programmer.
+	UploadUsingProgrammer(*UploadUsingProgrammerRequest, ArduinoCoreService_UploadUsingProgrammerServer) error
+	// Returns the list of users fields necessary to upload to that board
+	// using the specified protocol.
+	SupportedUserFields(context.Context, *SupportedUserFieldsRequest) (*SupportedUserFieldsResponse, error)
+	// List programmers available for a board.
+	ListProgrammersAvailableForUpload(context.Context, *ListProgrammersAvailableForUploadRequest) (*ListProgrammersAvailableForUploadResponse, error)
+	// Returns the operations supported by a programmer with a board.
+	ProgrammerDetails(context.Context, *ProgrammerDetailsRequest) (*ProgrammerDetailsResponse, error)
+	// Burn bootloader to a board.
+	BurnBootloader(*BurnBootloaderRequest, ArduinoCoreService_BurnBootloaderServer) error
+	// Read a memory range of a board and save it to a file.
+	ReadMemory(*ReadMemoryRequest, ArduinoCoreService_ReadMemoryServer) error
+	// Write the content of a file to a memory range of a board.
+	WriteMemory(*WriteMemoryRequest, ArduinoCoreService_WriteMemoryServer) error
+	// Erase the whole memory of the microcontroller of a board.
+	EraseChip(*EraseChipRequest, ArduinoCoreService_EraseChipServer) error
+	// Query or change the flash readout protection of a board.
+	FlashProtection(*FlashProtectionRequest, ArduinoCoreService_FlashProtectionServer) error
+	// Try to recover a board that is not responding anymore.
+	BoardRecover(*BoardRecoverRequest, ArduinoCoreService_BoardRecoverServer) error
+	// Reset a board using the reset method of its platform.
+	BoardReset(*BoardResetRequest, ArduinoCoreService_BoardResetServer) error
+	// Flash root certificates, and optionally a new firmware, to the network
+	// module of a board.
+	BoardCertificates(*BoardCertificatesRequest, ArduinoCoreService_BoardCertificatesServer) error
+	// Provision the secure element of a board: generate a private key and a
+	// certificate signing request, or write back the signed certificate.
+	BoardProvision(*BoardProvisionRequest, ArduinoCoreService_BoardProvisionServer) error
+	// Measure the current drawn by a board, and the target voltage, using a
+	// debug probe that supports power measurements.
+	BoardPower(*BoardPowerRequest, ArduinoCoreService_BoardPowerServer) error
+	// List the last sketch uploaded to each board.
+	DeploymentsList(context.Context, *DeploymentsListRequest) (*DeploymentsListResponse, error)
+	// Show the history of the sketches uploaded to a board.
+	DeploymentsShow(context.Context, *DeploymentsShowRequest) (*DeploymentsShowResponse, error)
+	// Search for a platform in the platforms indexes.
+	PlatformSearch(context.Context, *PlatformSearchRequest) (*PlatformSearchResponse, error)
+	// Download the archive file of an Arduino library in the libraries index to
+	// the staging directory.
+	LibraryDownload(*LibraryDownloadRequest, ArduinoCoreService_LibraryDownloadServer) error
+	// Download and install an Arduino library from the libraries index.
+	LibraryInstall(*LibraryInstallRequest, ArduinoCoreService_LibraryInstallServer) error
+	// Upgrade a library to the newest version available.
+	LibraryUpgrade(*LibraryUpgradeRequest, ArduinoCoreService_LibraryUpgradeServer) error
+	// Install a library from a Zip File
+	ZipLibraryInstall(*ZipLibraryInstallRequest, ArduinoCoreService_ZipLibraryInstallServer) error
+	// Download and install a library from a git url
+	GitLibraryInstall(*GitLibraryInstallRequest, ArduinoCoreService_GitLibraryInstallServer) error
+	// Uninstall an Arduino library.
+	LibraryUninstall(*LibraryUninstallRequest, ArduinoCoreService_LibraryUninstallServer) error
+	// Upgrade all installed Arduino libraries to the newest version available.
+	LibraryUpgradeAll(*LibraryUpgradeAllRequest, ArduinoCoreService_LibraryUpgradeAllServer) error
+	// Preview the changes of a library upgrade: versions, dependencies to
+	// install and version constraints of the installed libraries that would
+	// break.
+	LibraryUpgradePreview(context.Context, *LibraryUpgradePreviewRequest) (*LibraryUpgradePreviewResponse, error)
+	// List the recursive dependencies of a library, as defined by the `depends`
+	// field of the library.properties files.
+	LibraryResolveDependencies(context.Context, *LibraryResolveDependenciesRequest) (*LibraryResolveDependenciesResponse, error)
+	// Download two versions of a library from the libraries index and compare
+	// the public API declared in their headers.
+	LibraryDiff(*LibraryDiffRequest, ArduinoCoreService_LibraryDiffServer) error
+	// Create the skeleton of a new library, with its examples, a CI workflow
+	// and a test harness.
+	LibraryNew(context.Context, *LibraryNewRequest) (*LibraryNewResponse, error)
+	// Search the Arduino libraries index for libraries.
+	LibrarySearch(context.Context, *LibrarySearchRequest) (*LibrarySearchResponse, error)
+	// List the installed libraries.
+	LibraryList(context.Context, *LibraryListRequest) (*LibraryListResponse, error)
+	// Reload only the libraries contained in the given directories, without a
+	// full rescan of the installed libraries.
+	RescanLibraries(context.Context, *RescanLibrariesRequest) (*RescanLibrariesResponse, error)
+	// Open a monitor connection to a board port
+	Monitor(ArduinoCoreService_MonitorServer) error
+	// Returns the parameters that can be set in the MonitorRequest calls
+	EnumerateMonitorPortSettings(context.Context, *EnumerateMonitorPortSettingsRequest) (*EnumerateMonitorPortSettingsResponse, error)
+	// Start a debug session and communicate with the debugger tool.
+	Debug(ArduinoCoreService_DebugServer) error
+	// Determine if debugging is suported given a specific configuration.
+	IsDebugSupported(context.Context, *IsDebugSupportedRequest) (*IsDebugSupportedResponse, error)
+	// Query the debugger information given a specific configuration.
+	GetDebugConfig(context.Context, *GetDebugConfigRequest) (*GetDebugConfigResponse, error)
+	// Check for updates to the Arduino CLI.
+	CheckForArduinoCLIUpdates(context.Context, *CheckForArduinoCLIUpdatesRequest) (*CheckForArduinoCLIUpdatesResponse, error)
+	// Clean the download cache directory (where archives are downloaded).
+	CleanDownloadCacheDirectory(context.Context, *CleanDownloadCacheDirectoryRequest) (*CleanDownloadCacheDirectoryResponse, error)
+	// Remove the unused files from the data directory: the platform releases
+	// superseded by another installed release, the tools not required by any
+	// platform, the stale archives in the downloads directory and the expired
+	// build caches.
+	Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error)
+	// Report the disk usage of the data directory: the installed platforms and
+	// tools, the downloaded archives and the build caches.
+	DiskUsage(context.Context, *DiskUsageRequest) (*DiskUsageResponse, error)
+	// List all the settings.
+	SettingsGetAll(context.Context, *SettingsGetAllRequest) (*SettingsGetAllResponse, error)
+	// Set multiple settings values at once.
+	SettingsMerge(context.Context, *SettingsMergeRequest) (*SettingsMergeResponse, error)
+	// Get the value of a specific setting.
+	SettingsGetValue(context.Context, *SettingsGetValueRequest) (*SettingsGetValueResponse, error)
+	// Set the value of a specific setting.
+	SettingsSetValue(context.Context, *SettingsSetValueRequest) (*SettingsSetValueResponse, error)
+	// Writes to file settings currently stored in memory
+	SettingsWrite(context.Context, *SettingsWriteRequest) (*SettingsWriteResponse, error)
+	// Deletes an entry and rewrites the file settings
+	SettingsDelete(context.Context, *SettingsDeleteRequest) (*SettingsDeleteResponse, error)
+	mustEmbedUnimplementedArduinoCoreServiceServer()
+}
+
+// UnimplementedArduinoCoreServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedArduinoCoreServiceServer struct {
+}
+
+func (UnimplementedArduinoCoreServiceServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Init(*InitRequest, ArduinoCoreService_InitServer) error {
+	return status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) UpdateIndex(*UpdateIndexRequest, ArduinoCoreService_UpdateIndexServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpdateIndex not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) UpdateLibrariesIndex(*UpdateLibrariesIndexRequest, ArduinoCoreService_UpdateLibrariesIndexServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpdateLibrariesIndex not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) InstanceEvents(*InstanceEventsRequest, ArduinoCoreService_InstanceEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method InstanceEvents not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Version(context.Context, *VersionRequest) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) NewSketch(context.Context, *NewSketchRequest) (*NewSketchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewSketch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LoadSketch(context.Context, *LoadSketchRequest) (*LoadSketchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadSketch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) ArchiveSketch(context.Context, *ArchiveSketchRequest) (*ArchiveSketchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveSketch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) FlattenSketch(context.Context, *FlattenSketchRequest) (*FlattenSketchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlattenSketch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SetSketchDefaults(context.Context, *SetSketchDefaultsRequest) (*SetSketchDefaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSketchDefaults not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SketchbookList(context.Context, *SketchbookListRequest) (*SketchbookListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SketchbookList not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardDetails(context.Context, *BoardDetailsRequest) (*BoardDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BoardDetails not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardList(context.Context, *BoardListRequest) (*BoardListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BoardList not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardListAll(context.Context, *BoardListAllRequest) (*BoardListAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BoardListAll not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardSearch(context.Context, *BoardSearchRequest) (*BoardSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BoardSearch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardListWatch(*BoardListWatchRequest, ArduinoCoreService_BoardListWatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method BoardListWatch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardSetupPermissions(context.Context, *BoardSetupPermissionsRequest) (*BoardSetupPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BoardSetupPermissions not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Compile(*CompileRequest, ArduinoCoreService_CompileServer) error {
+	return status.Errorf(codes.Unimplemented, "method Compile not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) CompileWarmUp(context.Context, *CompileWarmUpRequest) (*CompileWarmUpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompileWarmUp not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) CompileDropWarmState(context.Context, *CompileDropWarmStateRequest) (*CompileDropWarmStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompileDropWarmState not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Symbolize(context.Context, *SymbolizeRequest) (*SymbolizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Symbolize not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformInstall(*PlatformInstallRequest, ArduinoCoreService_PlatformInstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method PlatformInstall not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformDownload(*PlatformDownloadRequest, ArduinoCoreService_PlatformDownloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method PlatformDownload not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformUninstall(*PlatformUninstallRequest, ArduinoCoreService_PlatformUninstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method PlatformUninstall not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformUpgrade(*PlatformUpgradeRequest, ArduinoCoreService_PlatformUpgradeServer) error {
+	return status.Errorf(codes.Unimplemented, "method PlatformUpgrade not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformUpgradePreview(context.Context, *PlatformUpgradePreviewRequest) (*PlatformUpgradePreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlatformUpgradePreview not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformPostInstallSteps(context.Context, *PlatformPostInstallStepsRequest) (*PlatformPostInstallStepsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlatformPostInstallSteps not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformRunPostInstallStep(context.Context, *PlatformRunPostInstallStepRequest) (*PlatformRunPostInstallStepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlatformRunPostInstallStep not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformAudit(context.Context, *PlatformAuditRequest) (*PlatformAuditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlatformAudit not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformCleanTools(context.Context, *PlatformCleanToolsRequest) (*PlatformCleanToolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlatformCleanTools not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Upload(*UploadRequest, ArduinoCoreService_UploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) UploadUsingProgrammer(*UploadUsingProgrammerRequest, ArduinoCoreService_UploadUsingProgrammerServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadUsingProgrammer not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SupportedUserFields(context.Context, *SupportedUserFieldsRequest) (*SupportedUserFieldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SupportedUserFields not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) ListProgrammersAvailableForUpload(context.Context, *ListProgrammersAvailableForUploadRequest) (*ListProgrammersAvailableForUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProgrammersAvailableForUpload not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) ProgrammerDetails(context.Context, *ProgrammerDetailsRequest) (*ProgrammerDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProgrammerDetails not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BurnBootloader(*BurnBootloaderRequest, ArduinoCoreService_BurnBootloaderServer) error {
+	return status.Errorf(codes.Unimplemented, "method BurnBootloader not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) ReadMemory(*ReadMemoryRequest, ArduinoCoreService_ReadMemoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadMemory not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) WriteMemory(*WriteMemoryRequest, ArduinoCoreService_WriteMemoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteMemory not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) EraseChip(*EraseChipRequest, ArduinoCoreService_EraseChipServer) error {
+	return status.Errorf(codes.Unimplemented, "method EraseChip not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) FlashProtection(*FlashProtectionRequest, ArduinoCoreService_FlashProtectionServer) error {
+	return status.Errorf(codes.Unimplemented, "method FlashProtection not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardRecover(*BoardRecoverRequest, ArduinoCoreService_BoardRecoverServer) error {
+	return status.Errorf(codes.Unimplemented, "method BoardRecover not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardReset(*BoardResetRequest, ArduinoCoreService_BoardResetServer) error {
+	return status.Errorf(codes.Unimplemented, "method BoardReset not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardCertificates(*BoardCertificatesRequest, ArduinoCoreService_BoardCertificatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method BoardCertificates not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardProvision(*BoardProvisionRequest, ArduinoCoreService_BoardProvisionServer) error {
+	return status.Errorf(codes.Unimplemented, "method BoardProvision not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) BoardPower(*BoardPowerRequest, ArduinoCoreService_BoardPowerServer) error {
+	return status.Errorf(codes.Unimplemented, "method BoardPower not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) DeploymentsList(context.Context, *DeploymentsListRequest) (*DeploymentsListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeploymentsList not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) DeploymentsShow(context.Context, *DeploymentsShowRequest) (*DeploymentsShowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeploymentsShow not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) PlatformSearch(context.Context, *PlatformSearchRequest) (*PlatformSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlatformSearch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryDownload(*LibraryDownloadRequest, ArduinoCoreService_LibraryDownloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method LibraryDownload not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryInstall(*LibraryInstallRequest, ArduinoCoreService_LibraryInstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method LibraryInstall not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryUpgrade(*LibraryUpgradeRequest, ArduinoCoreService_LibraryUpgradeServer) error {
+	return status.Errorf(codes.Unimplemented, "method LibraryUpgrade not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) ZipLibraryInstall(*ZipLibraryInstallRequest, ArduinoCoreService_ZipLibraryInstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method ZipLibraryInstall not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) GitLibraryInstall(*GitLibraryInstallRequest, ArduinoCoreService_GitLibraryInstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method GitLibraryInstall not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryUninstall(*LibraryUninstallRequest, ArduinoCoreService_LibraryUninstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method LibraryUninstall not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryUpgradeAll(*LibraryUpgradeAllRequest, ArduinoCoreService_LibraryUpgradeAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method LibraryUpgradeAll not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryUpgradePreview(context.Context, *LibraryUpgradePreviewRequest) (*LibraryUpgradePreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LibraryUpgradePreview not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryResolveDependencies(context.Context, *LibraryResolveDependenciesRequest) (*LibraryResolveDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LibraryResolveDependencies not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryDiff(*LibraryDiffRequest, ArduinoCoreService_LibraryDiffServer) error {
+	return status.Errorf(codes.Unimplemented, "method LibraryDiff not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryNew(context.Context, *LibraryNewRequest) (*LibraryNewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LibraryNew not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibrarySearch(context.Context, *LibrarySearchRequest) (*LibrarySearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LibrarySearch not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) LibraryList(context.Context, *LibraryListRequest) (*LibraryListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LibraryList not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) RescanLibraries(context.Context, *RescanLibrariesRequest) (*RescanLibrariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescanLibraries not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Monitor(ArduinoCoreService_MonitorServer) error {
+	return status.Errorf(codes.Unimplemented, "method Monitor not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) EnumerateMonitorPortSettings(context.Context, *EnumerateMonitorPortSettingsRequest) (*EnumerateMonitorPortSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnumerateMonitorPortSettings not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) Debug(ArduinoCoreService_DebugServer) error {
+	return status.Errorf(codes.Unimplemented, "method Debug not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) IsDebugSupported(context.Context, *IsDebugSupportedRequest) (*IsDebugSupportedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsDebugSupported not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) GetDebugConfig(context.Context, *GetDebugConfigRequest) (*GetDebugConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDebugConfig not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) CheckForArduinoCLIUpdates(context.Context, *CheckForArduinoCLIUpdatesRequest) (*CheckForArduinoCLIUpdatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckForArduinoCLIUpdates not implemented")
 }
 func (UnimplementedArduinoCoreServiceServer) CleanDownloadCacheDirectory(context.Context, *CleanDownloadCacheDirectoryRequest) (*CleanDownloadCacheDirectoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CleanDownloadCacheDirectory not implemented")
 }
-func (UnimplementedArduinoCoreServiceServer) SettingsGetAll(context.Context, *SettingsGetAllRequest) (*SettingsGetAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SettingsGetAll not implemented")
+func (UnimplementedArduinoCoreServiceServer) Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cleanup not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) DiskUsage(context.Context, *DiskUsageRequest) (*DiskUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiskUsage not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SettingsGetAll(context.Context, *SettingsGetAllRequest) (*SettingsGetAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsGetAll not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SettingsMerge(context.Context, *SettingsMergeRequest) (*SettingsMergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsMerge not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SettingsGetValue(context.Context, *SettingsGetValueRequest) (*SettingsGetValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsGetValue not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SettingsSetValue(context.Context, *SettingsSetValueRequest) (*SettingsSetValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsSetValue not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SettingsWrite(context.Context, *SettingsWriteRequest) (*SettingsWriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsWrite not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) SettingsDelete(context.Context, *SettingsDeleteRequest) (*SettingsDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsDelete not implemented")
+}
+func (UnimplementedArduinoCoreServiceServer) mustEmbedUnimplementedArduinoCoreServiceServer() {}
+
+// UnsafeArduinoCoreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArduinoCoreServiceServer will
+// result in compilation errors.
+type UnsafeArduinoCoreServiceServer interface {
+	mustEmbedUnimplementedArduinoCoreServiceServer()
+}
+
+func RegisterArduinoCoreServiceServer(s grpc.ServiceRegistrar, srv ArduinoCoreServiceServer) {
+	s.RegisterService(&ArduinoCoreService_ServiceDesc, srv)
+}
+
+func _ArduinoCoreService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_Init_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InitRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).Init(m, &arduinoCoreServiceInitServer{stream})
+}
+
+type ArduinoCoreService_InitServer interface {
+	Send(*InitResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceInitServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceInitServer) Send(m *InitResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DestroyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).Destroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_Destroy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).Destroy(ctx, req.(*DestroyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_UpdateIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateIndexRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).UpdateIndex(m, &arduinoCoreServiceUpdateIndexServer{stream})
+}
+
+type ArduinoCoreService_UpdateIndexServer interface {
+	Send(*UpdateIndexResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceUpdateIndexServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceUpdateIndexServer) Send(m *UpdateIndexResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_UpdateLibrariesIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateLibrariesIndexRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).UpdateLibrariesIndex(m, &arduinoCoreServiceUpdateLibrariesIndexServer{stream})
 }
-func (UnimplementedArduinoCoreServiceServer) SettingsMerge(context.Context, *SettingsMergeRequest) (*SettingsMergeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SettingsMerge not implemented")
+
+type ArduinoCoreService_UpdateLibrariesIndexServer interface {
+	Send(*UpdateLibrariesIndexResponse) error
+	grpc.ServerStream
 }
-func (UnimplementedArduinoCoreServiceServer) SettingsGetValue(context.Context, *SettingsGetValueRequest) (*SettingsGetValueResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SettingsGetValue not implemented")
+
+type arduinoCoreServiceUpdateLibrariesIndexServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedArduinoCoreServiceServer) SettingsSetValue(context.Context, *SettingsSetValueRequest) (*SettingsSetValueResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SettingsSetValue not implemented")
+
+func (x *arduinoCoreServiceUpdateLibrariesIndexServer) Send(m *UpdateLibrariesIndexResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedArduinoCoreServiceServer) SettingsWrite(context.Context, *SettingsWriteRequest) (*SettingsWriteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SettingsWrite not implemented")
+
+func _ArduinoCoreService_InstanceEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InstanceEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).InstanceEvents(m, &arduinoCoreServiceInstanceEventsServer{stream})
 }
-func (UnimplementedArduinoCoreServiceServer) SettingsDelete(context.Context, *SettingsDeleteRequest) (*SettingsDeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SettingsDelete not implemented")
+
+type ArduinoCoreService_InstanceEventsServer interface {
+	Send(*InstanceEventsResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceInstanceEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceInstanceEventsServer) Send(m *InstanceEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_Version_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_NewSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSketchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).NewSketch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_NewSketch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).NewSketch(ctx, req.(*NewSketchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_LoadSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadSketchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).LoadSketch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_LoadSketch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).LoadSketch(ctx, req.(*LoadSketchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_ArchiveSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveSketchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).ArchiveSketch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_ArchiveSketch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).ArchiveSketch(ctx, req.(*ArchiveSketchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_FlattenSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlattenSketchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).FlattenSketch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_FlattenSketch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).FlattenSketch(ctx, req.(*FlattenSketchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_SetSketchDefaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSketchDefaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).SetSketchDefaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_SetSketchDefaults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).SetSketchDefaults(ctx, req.(*SetSketchDefaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_SketchbookList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SketchbookListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).SketchbookList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_SketchbookList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).SketchbookList(ctx, req.(*SketchbookListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_BoardDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).BoardDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_BoardDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).BoardDetails(ctx, req.(*BoardDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_BoardList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).BoardList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_BoardList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).BoardList(ctx, req.(*BoardListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_BoardListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardListAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).BoardListAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_BoardListAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).BoardListAll(ctx, req.(*BoardListAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_BoardSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).BoardSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_BoardSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).BoardSearch(ctx, req.(*BoardSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_BoardListWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BoardListWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).BoardListWatch(m, &arduinoCoreServiceBoardListWatchServer{stream})
+}
+
+type ArduinoCoreService_BoardListWatchServer interface {
+	Send(*BoardListWatchResponse) error
+	grpc.ServerStream
 }
-func (UnimplementedArduinoCoreServiceServer) mustEmbedUnimplementedArduinoCoreServiceServer() {}
 
-// UnsafeArduinoCoreServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ArduinoCoreServiceServer will
-// result in compilation errors.
-type UnsafeArduinoCoreServiceServer interface {
-	mustEmbedUnimplementedArduinoCoreServiceServer()
+type arduinoCoreServiceBoardListWatchServer struct {
+	grpc.ServerStream
 }
 
-func RegisterArduinoCoreServiceServer(s grpc.ServiceRegistrar, srv ArduinoCoreServiceServer) {
-	s.RegisterService(&ArduinoCoreService_ServiceDesc, srv)
+func (x *arduinoCoreServiceBoardListWatchServer) Send(m *BoardListWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _ArduinoCoreService_BoardSetupPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardSetupPermissionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).Create(ctx, in)
+		return srv.(ArduinoCoreServiceServer).BoardSetupPermissions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_Create_FullMethodName,
+		FullMethod: ArduinoCoreService_BoardSetupPermissions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).Create(ctx, req.(*CreateRequest))
+		return srv.(ArduinoCoreServiceServer).BoardSetupPermissions(ctx, req.(*BoardSetupPermissionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_Init_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(InitRequest)
+func _ArduinoCoreService_Compile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CompileRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).Init(m, &arduinoCoreServiceInitServer{stream})
+	return srv.(ArduinoCoreServiceServer).Compile(m, &arduinoCoreServiceCompileServer{stream})
 }
 
-type ArduinoCoreService_InitServer interface {
-	Send(*InitResponse) error
+type ArduinoCoreService_CompileServer interface {
+	Send(*CompileResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServiceInitServer struct {
+type arduinoCoreServiceCompileServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServiceInitServer) Send(m *InitResponse) error {
+func (x *arduinoCoreServiceCompileServer) Send(m *CompileResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DestroyRequest)
+func _ArduinoCoreService_CompileWarmUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompileWarmUpRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).Destroy(ctx, in)
+		return srv.(ArduinoCoreServiceServer).CompileWarmUp(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_Destroy_FullMethodName,
+		FullMethod: ArduinoCoreService_CompileWarmUp_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).Destroy(ctx, req.(*DestroyRequest))
+		return srv.(ArduinoCoreServiceServer).CompileWarmUp(ctx, req.(*CompileWarmUpRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_UpdateIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UpdateIndexRequest)
+func _ArduinoCoreService_CompileDropWarmState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompileDropWarmStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).CompileDropWarmState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_CompileDropWarmState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).CompileDropWarmState(ctx, req.(*CompileDropWarmStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_Symbolize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SymbolizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).Symbolize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_Symbolize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).Symbolize(ctx, req.(*SymbolizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_PlatformInstall_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PlatformInstallRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).UpdateIndex(m, &arduinoCoreServiceUpdateIndexServer{stream})
+	return srv.(ArduinoCoreServiceServer).PlatformInstall(m, &arduinoCoreServicePlatformInstallServer{stream})
 }
 
-type ArduinoCoreService_UpdateIndexServer interface {
-	Send(*UpdateIndexResponse) error
+type ArduinoCoreService_PlatformInstallServer interface {
+	Send(*PlatformInstallResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServiceUpdateIndexServer struct {
+type arduinoCoreServicePlatformInstallServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServiceUpdateIndexServer) Send(m *UpdateIndexResponse) error {
+func (x *arduinoCoreServicePlatformInstallServer) Send(m *PlatformInstallResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_UpdateLibrariesIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UpdateLibrariesIndexRequest)
+func _ArduinoCoreService_PlatformDownload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PlatformDownloadRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).UpdateLibrariesIndex(m, &arduinoCoreServiceUpdateLibrariesIndexServer{stream})
+	return srv.(ArduinoCoreServiceServer).PlatformDownload(m, &arduinoCoreServicePlatformDownloadServer{stream})
 }
 
-type ArduinoCoreService_UpdateLibrariesIndexServer interface {
-	Send(*UpdateLibrariesIndexResponse) error
+type ArduinoCoreService_PlatformDownloadServer interface {
+	Send(*PlatformDownloadResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServiceUpdateLibrariesIndexServer struct {
+type arduinoCoreServicePlatformDownloadServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServiceUpdateLibrariesIndexServer) Send(m *UpdateLibrariesIndexResponse) error {
+func (x *arduinoCoreServicePlatformDownloadServer) Send(m *PlatformDownloadResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VersionRequest)
+func _ArduinoCoreService_PlatformUninstall_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PlatformUninstallRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).PlatformUninstall(m, &arduinoCoreServicePlatformUninstallServer{stream})
+}
+
+type ArduinoCoreService_PlatformUninstallServer interface {
+	Send(*PlatformUninstallResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServicePlatformUninstallServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServicePlatformUninstallServer) Send(m *PlatformUninstallResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_PlatformUpgrade_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PlatformUpgradeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).PlatformUpgrade(m, &arduinoCoreServicePlatformUpgradeServer{stream})
+}
+
+type ArduinoCoreService_PlatformUpgradeServer interface {
+	Send(*PlatformUpgradeResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServicePlatformUpgradeServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServicePlatformUpgradeServer) Send(m *PlatformUpgradeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_PlatformUpgradePreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlatformUpgradePreviewRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).Version(ctx, in)
+		return srv.(ArduinoCoreServiceServer).PlatformUpgradePreview(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_Version_FullMethodName,
+		FullMethod: ArduinoCoreService_PlatformUpgradePreview_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).Version(ctx, req.(*VersionRequest))
+		return srv.(ArduinoCoreServiceServer).PlatformUpgradePreview(ctx, req.(*PlatformUpgradePreviewRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_NewSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewSketchRequest)
+func _ArduinoCoreService_PlatformPostInstallSteps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlatformPostInstallStepsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).NewSketch(ctx, in)
+		return srv.(ArduinoCoreServiceServer).PlatformPostInstallSteps(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_NewSketch_FullMethodName,
+		FullMethod: ArduinoCoreService_PlatformPostInstallSteps_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).NewSketch(ctx, req.(*NewSketchRequest))
+		return srv.(ArduinoCoreServiceServer).PlatformPostInstallSteps(ctx, req.(*PlatformPostInstallStepsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_LoadSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoadSketchRequest)
+func _ArduinoCoreService_PlatformRunPostInstallStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlatformRunPostInstallStepRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).LoadSketch(ctx, in)
+		return srv.(ArduinoCoreServiceServer).PlatformRunPostInstallStep(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_LoadSketch_FullMethodName,
+		FullMethod: ArduinoCoreService_PlatformRunPostInstallStep_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).LoadSketch(ctx, req.(*LoadSketchRequest))
+		return srv.(ArduinoCoreServiceServer).PlatformRunPostInstallStep(ctx, req.(*PlatformRunPostInstallStepRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_ArchiveSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ArchiveSketchRequest)
+func _ArduinoCoreService_PlatformAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlatformAuditRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).ArchiveSketch(ctx, in)
+		return srv.(ArduinoCoreServiceServer).PlatformAudit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_ArchiveSketch_FullMethodName,
+		FullMethod: ArduinoCoreService_PlatformAudit_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).ArchiveSketch(ctx, req.(*ArchiveSketchRequest))
+		return srv.(ArduinoCoreServiceServer).PlatformAudit(ctx, req.(*PlatformAuditRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_SetSketchDefaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetSketchDefaultsRequest)
+func _ArduinoCoreService_PlatformCleanTools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlatformCleanToolsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).SetSketchDefaults(ctx, in)
+		return srv.(ArduinoCoreServiceServer).PlatformCleanTools(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_SetSketchDefaults_FullMethodName,
+		FullMethod: ArduinoCoreService_PlatformCleanTools_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).SetSketchDefaults(ctx, req.(*SetSketchDefaultsRequest))
+		return srv.(ArduinoCoreServiceServer).PlatformCleanTools(ctx, req.(*PlatformCleanToolsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_BoardDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BoardDetailsRequest)
+func _ArduinoCoreService_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UploadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).Upload(m, &arduinoCoreServiceUploadServer{stream})
+}
+
+type ArduinoCoreService_UploadServer interface {
+	Send(*UploadResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceUploadServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceUploadServer) Send(m *UploadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_UploadUsingProgrammer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UploadUsingProgrammerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).UploadUsingProgrammer(m, &arduinoCoreServiceUploadUsingProgrammerServer{stream})
+}
+
+type ArduinoCoreService_UploadUsingProgrammerServer interface {
+	Send(*UploadUsingProgrammerResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceUploadUsingProgrammerServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceUploadUsingProgrammerServer) Send(m *UploadUsingProgrammerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_SupportedUserFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupportedUserFieldsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).BoardDetails(ctx, in)
+		return srv.(ArduinoCoreServiceServer).SupportedUserFields(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_BoardDetails_FullMethodName,
+		FullMethod: ArduinoCoreService_SupportedUserFields_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).BoardDetails(ctx, req.(*BoardDetailsRequest))
+		return srv.(ArduinoCoreServiceServer).SupportedUserFields(ctx, req.(*SupportedUserFieldsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_BoardList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BoardListRequest)
+func _ArduinoCoreService_ListProgrammersAvailableForUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProgrammersAvailableForUploadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).BoardList(ctx, in)
+		return srv.(ArduinoCoreServiceServer).ListProgrammersAvailableForUpload(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_BoardList_FullMethodName,
+		FullMethod: ArduinoCoreService_ListProgrammersAvailableForUpload_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).BoardList(ctx, req.(*BoardListRequest))
+		return srv.(ArduinoCoreServiceServer).ListProgrammersAvailableForUpload(ctx, req.(*ListProgrammersAvailableForUploadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_BoardListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BoardListAllRequest)
+func _ArduinoCoreService_ProgrammerDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProgrammerDetailsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).BoardListAll(ctx, in)
+		return srv.(ArduinoCoreServiceServer).ProgrammerDetails(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_BoardListAll_FullMethodName,
+		FullMethod: ArduinoCoreService_ProgrammerDetails_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).BoardListAll(ctx, req.(*BoardListAllRequest))
+		return srv.(ArduinoCoreServiceServer).ProgrammerDetails(ctx, req.(*ProgrammerDetailsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_BoardSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BoardSearchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).BoardSearch(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: ArduinoCoreService_BoardSearch_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).BoardSearch(ctx, req.(*BoardSearchRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func _ArduinoCoreService_BurnBootloader_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BurnBootloaderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).BurnBootloader(m, &arduinoCoreServiceBurnBootloaderServer{stream})
+}
+
+type ArduinoCoreService_BurnBootloaderServer interface {
+	Send(*BurnBootloaderResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceBurnBootloaderServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceBurnBootloaderServer) Send(m *BurnBootloaderResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_ReadMemory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadMemoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).ReadMemory(m, &arduinoCoreServiceReadMemoryServer{stream})
+}
+
+type ArduinoCoreService_ReadMemoryServer interface {
+	Send(*ReadMemoryResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceReadMemoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceReadMemoryServer) Send(m *ReadMemoryResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_BoardListWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(BoardListWatchRequest)
+func _ArduinoCoreService_WriteMemory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WriteMemoryRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).BoardListWatch(m, &arduinoCoreServiceBoardListWatchServer{stream})
+	return srv.(ArduinoCoreServiceServer).WriteMemory(m, &arduinoCoreServiceWriteMemoryServer{stream})
 }
 
-type ArduinoCoreService_BoardListWatchServer interface {
-	Send(*BoardListWatchResponse) error
+type ArduinoCoreService_WriteMemoryServer interface {
+	Send(*WriteMemoryResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServiceBoardListWatchServer struct {
+type arduinoCoreServiceWriteMemoryServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServiceBoardListWatchServer) Send(m *BoardListWatchResponse) error {
+func (x *arduinoCoreServiceWriteMemoryServer) Send(m *WriteMemoryResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_Compile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CompileRequest)
+func _ArduinoCoreService_EraseChip_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EraseChipRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).Compile(m, &arduinoCoreServiceCompileServer{stream})
+	return srv.(ArduinoCoreServiceServer).EraseChip(m, &arduinoCoreServiceEraseChipServer{stream})
 }
 
-type ArduinoCoreService_CompileServer interface {
-	Send(*CompileResponse) error
+type ArduinoCoreService_EraseChipServer interface {
+	Send(*EraseChipResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServiceCompileServer struct {
+type arduinoCoreServiceEraseChipServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServiceCompileServer) Send(m *CompileResponse) error {
+func (x *arduinoCoreServiceEraseChipServer) Send(m *EraseChipResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_PlatformInstall_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PlatformInstallRequest)
+func _ArduinoCoreService_FlashProtection_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FlashProtectionRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).PlatformInstall(m, &arduinoCoreServicePlatformInstallServer{stream})
+	return srv.(ArduinoCoreServiceServer).FlashProtection(m, &arduinoCoreServiceFlashProtectionServer{stream})
 }
 
-type ArduinoCoreService_PlatformInstallServer interface {
-	Send(*PlatformInstallResponse) error
+type ArduinoCoreService_FlashProtectionServer interface {
+	Send(*FlashProtectionResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServicePlatformInstallServer struct {
+type arduinoCoreServiceFlashProtectionServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServicePlatformInstallServer) Send(m *PlatformInstallResponse) error {
+func (x *arduinoCoreServiceFlashProtectionServer) Send(m *FlashProtectionResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_PlatformDownload_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PlatformDownloadRequest)
+func _ArduinoCoreService_BoardRecover_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BoardRecoverRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).PlatformDownload(m, &arduinoCoreServicePlatformDownloadServer{stream})
+	return srv.(ArduinoCoreServiceServer).BoardRecover(m, &arduinoCoreServiceBoardRecoverServer{stream})
 }
 
-type ArduinoCoreService_PlatformDownloadServer interface {
-	Send(*PlatformDownloadResponse) error
+type ArduinoCoreService_BoardRecoverServer interface {
+	Send(*BoardRecoverResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServicePlatformDownloadServer struct {
+type arduinoCoreServiceBoardRecoverServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServicePlatformDownloadServer) Send(m *PlatformDownloadResponse) error {
+func (x *arduinoCoreServiceBoardRecoverServer) Send(m *BoardRecoverResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_PlatformUninstall_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PlatformUninstallRequest)
+func _ArduinoCoreService_BoardReset_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BoardResetRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).PlatformUninstall(m, &arduinoCoreServicePlatformUninstallServer{stream})
+	return srv.(ArduinoCoreServiceServer).BoardReset(m, &arduinoCoreServiceBoardResetServer{stream})
 }
 
-type ArduinoCoreService_PlatformUninstallServer interface {
-	Send(*PlatformUninstallResponse) error
+type ArduinoCoreService_BoardResetServer interface {
+	Send(*BoardResetResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServicePlatformUninstallServer struct {
+type arduinoCoreServiceBoardResetServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServicePlatformUninstallServer) Send(m *PlatformUninstallResponse) error {
+func (x *arduinoCoreServiceBoardResetServer) Send(m *BoardResetResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_PlatformUpgrade_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PlatformUpgradeRequest)
+func _ArduinoCoreService_BoardCertificates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BoardCertificatesRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).PlatformUpgrade(m, &arduinoCoreServicePlatformUpgradeServer{stream})
+	return srv.(ArduinoCoreServiceServer).BoardCertificates(m, &arduinoCoreServiceBoardCertificatesServer{stream})
 }
 
-type ArduinoCoreService_PlatformUpgradeServer interface {
-	Send(*PlatformUpgradeResponse) error
+type ArduinoCoreService_BoardCertificatesServer interface {
+	Send(*BoardCertificatesResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServicePlatformUpgradeServer struct {
+type arduinoCoreServiceBoardCertificatesServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServicePlatformUpgradeServer) Send(m *PlatformUpgradeResponse) error {
+func (x *arduinoCoreServiceBoardCertificatesServer) Send(m *BoardCertificatesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UploadRequest)
+func _ArduinoCoreService_BoardProvision_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BoardProvisionRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).Upload(m, &arduinoCoreServiceUploadServer{stream})
+	return srv.(ArduinoCoreServiceServer).BoardProvision(m, &arduinoCoreServiceBoardProvisionServer{stream})
 }
 
-type ArduinoCoreService_UploadServer interface {
-	Send(*UploadResponse) error
+type ArduinoCoreService_BoardProvisionServer interface {
+	Send(*BoardProvisionResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServiceUploadServer struct {
+type arduinoCoreServiceBoardProvisionServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServiceUploadServer) Send(m *UploadResponse) error {
+func (x *arduinoCoreServiceBoardProvisionServer) Send(m *BoardProvisionResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_UploadUsingProgrammer_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UploadUsingProgrammerRequest)
+func _ArduinoCoreService_BoardPower_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BoardPowerRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArduinoCoreServiceServer).UploadUsingProgrammer(m, &arduinoCoreServiceUploadUsingProgrammerServer{stream})
+	return srv.(ArduinoCoreServiceServer).BoardPower(m, &arduinoCoreServiceBoardPowerServer{stream})
 }
 
-type ArduinoCoreService_UploadUsingProgrammerServer interface {
-	Send(*UploadUsingProgrammerResponse) error
+type ArduinoCoreService_BoardPowerServer interface {
+	Send(*BoardPowerResponse) error
 	grpc.ServerStream
 }
 
-type arduinoCoreServiceUploadUsingProgrammerServer struct {
+type arduinoCoreServiceBoardPowerServer struct {
 	grpc.ServerStream
 }
 
-func (x *arduinoCoreServiceUploadUsingProgrammerServer) Send(m *UploadUsingProgrammerResponse) error {
+func (x *arduinoCoreServiceBoardPowerServer) Send(m *BoardPowerResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArduinoCoreService_SupportedUserFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SupportedUserFieldsRequest)
+func _ArduinoCoreService_DeploymentsList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeploymentsListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).SupportedUserFields(ctx, in)
+		return srv.(ArduinoCoreServiceServer).DeploymentsList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_SupportedUserFields_FullMethodName,
+		FullMethod: ArduinoCoreService_DeploymentsList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).SupportedUserFields(ctx, req.(*SupportedUserFieldsRequest))
+		return srv.(ArduinoCoreServiceServer).DeploymentsList(ctx, req.(*DeploymentsListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_ListProgrammersAvailableForUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListProgrammersAvailableForUploadRequest)
+func _ArduinoCoreService_DeploymentsShow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeploymentsShowRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArduinoCoreServiceServer).ListProgrammersAvailableForUpload(ctx, in)
+		return srv.(ArduinoCoreServiceServer).DeploymentsShow(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArduinoCoreService_ListProgrammersAvailableForUpload_FullMethodName,
+		FullMethod: ArduinoCoreService_DeploymentsShow_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArduinoCoreServiceServer).ListProgrammersAvailableForUpload(ctx, req.(*ListProgrammersAvailableForUploadRequest))
+		return srv.(ArduinoCoreServiceServer).DeploymentsShow(ctx, req.(*DeploymentsShowRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArduinoCoreService_BurnBootloader_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(BurnBootloaderRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(ArduinoCoreServiceServer).BurnBootloader(m, &arduinoCoreServiceBurnBootloaderServer{stream})
-}
-
-type ArduinoCoreService_BurnBootloaderServer interface {
-	Send(*BurnBootloaderResponse) error
-	grpc.ServerStream
-}
-
-type arduinoCoreServiceBurnBootloaderServer struct {
-	grpc.ServerStream
-}
-
-func (x *arduinoCoreServiceBurnBootloaderServer) Send(m *BurnBootloaderResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
 func _ArduinoCoreService_PlatformSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PlatformSearchRequest)
 	if err := dec(in); err != nil {
@@ -2055,6 +3373,24 @@ func (x *arduinoCoreServiceLibraryUpgradeAllServer) Send(m *LibraryUpgradeAllRes
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ArduinoCoreService_LibraryUpgradePreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LibraryUpgradePreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).LibraryUpgradePreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_LibraryUpgradePreview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).LibraryUpgradePreview(ctx, req.(*LibraryUpgradePreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArduinoCoreService_LibraryResolveDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LibraryResolveDependenciesRequest)
 	if err := dec(in); err != nil {
@@ -2073,6 +3409,45 @@ func _ArduinoCoreService_LibraryResolveDependencies_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArduinoCoreService_LibraryDiff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LibraryDiffRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArduinoCoreServiceServer).LibraryDiff(m, &arduinoCoreServiceLibraryDiffServer{stream})
+}
+
+type ArduinoCoreService_LibraryDiffServer interface {
+	Send(*LibraryDiffResponse) error
+	grpc.ServerStream
+}
+
+type arduinoCoreServiceLibraryDiffServer struct {
+	grpc.ServerStream
+}
+
+func (x *arduinoCoreServiceLibraryDiffServer) Send(m *LibraryDiffResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArduinoCoreService_LibraryNew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LibraryNewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).LibraryNew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_LibraryNew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).LibraryNew(ctx, req.(*LibraryNewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArduinoCoreService_LibrarySearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LibrarySearchRequest)
 	if err := dec(in); err != nil {
@@ -2109,6 +3484,24 @@ func _ArduinoCoreService_LibraryList_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArduinoCoreService_RescanLibraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescanLibrariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).RescanLibraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_RescanLibraries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).RescanLibraries(ctx, req.(*RescanLibrariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArduinoCoreService_Monitor_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ArduinoCoreServiceServer).Monitor(&arduinoCoreServiceMonitorServer{stream})
 }
@@ -2251,6 +3644,42 @@ func _ArduinoCoreService_CleanDownloadCacheDirectory_Handler(srv interface{}, ct
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArduinoCoreService_Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_Cleanup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).Cleanup(ctx, req.(*CleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArduinoCoreService_DiskUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiskUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArduinoCoreServiceServer).DiskUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArduinoCoreService_DiskUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArduinoCoreServiceServer).DiskUsage(ctx, req.(*DiskUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArduinoCoreService_SettingsGetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SettingsGetAllRequest)
 	if err := dec(in); err != nil {
@@ -2378,6 +3807,10 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Version",
 			Handler:    _ArduinoCoreService_Version_Handler,
 		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _ArduinoCoreService_GetCapabilities_Handler,
+		},
 		{
 			MethodName: "NewSketch",
 			Handler:    _ArduinoCoreService_NewSketch_Handler,
@@ -2390,10 +3823,18 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ArchiveSketch",
 			Handler:    _ArduinoCoreService_ArchiveSketch_Handler,
 		},
+		{
+			MethodName: "FlattenSketch",
+			Handler:    _ArduinoCoreService_FlattenSketch_Handler,
+		},
 		{
 			MethodName: "SetSketchDefaults",
 			Handler:    _ArduinoCoreService_SetSketchDefaults_Handler,
 		},
+		{
+			MethodName: "SketchbookList",
+			Handler:    _ArduinoCoreService_SketchbookList_Handler,
+		},
 		{
 			MethodName: "BoardDetails",
 			Handler:    _ArduinoCoreService_BoardDetails_Handler,
@@ -2410,6 +3851,42 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BoardSearch",
 			Handler:    _ArduinoCoreService_BoardSearch_Handler,
 		},
+		{
+			MethodName: "BoardSetupPermissions",
+			Handler:    _ArduinoCoreService_BoardSetupPermissions_Handler,
+		},
+		{
+			MethodName: "CompileWarmUp",
+			Handler:    _ArduinoCoreService_CompileWarmUp_Handler,
+		},
+		{
+			MethodName: "CompileDropWarmState",
+			Handler:    _ArduinoCoreService_CompileDropWarmState_Handler,
+		},
+		{
+			MethodName: "Symbolize",
+			Handler:    _ArduinoCoreService_Symbolize_Handler,
+		},
+		{
+			MethodName: "PlatformUpgradePreview",
+			Handler:    _ArduinoCoreService_PlatformUpgradePreview_Handler,
+		},
+		{
+			MethodName: "PlatformPostInstallSteps",
+			Handler:    _ArduinoCoreService_PlatformPostInstallSteps_Handler,
+		},
+		{
+			MethodName: "PlatformRunPostInstallStep",
+			Handler:    _ArduinoCoreService_PlatformRunPostInstallStep_Handler,
+		},
+		{
+			MethodName: "PlatformAudit",
+			Handler:    _ArduinoCoreService_PlatformAudit_Handler,
+		},
+		{
+			MethodName: "PlatformCleanTools",
+			Handler:    _ArduinoCoreService_PlatformCleanTools_Handler,
+		},
 		{
 			MethodName: "SupportedUserFields",
 			Handler:    _ArduinoCoreService_SupportedUserFields_Handler,
@@ -2418,14 +3895,34 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListProgrammersAvailableForUpload",
 			Handler:    _ArduinoCoreService_ListProgrammersAvailableForUpload_Handler,
 		},
+		{
+			MethodName: "ProgrammerDetails",
+			Handler:    _ArduinoCoreService_ProgrammerDetails_Handler,
+		},
+		{
+			MethodName: "DeploymentsList",
+			Handler:    _ArduinoCoreService_DeploymentsList_Handler,
+		},
+		{
+			MethodName: "DeploymentsShow",
+			Handler:    _ArduinoCoreService_DeploymentsShow_Handler,
+		},
 		{
 			MethodName: "PlatformSearch",
 			Handler:    _ArduinoCoreService_PlatformSearch_Handler,
 		},
+		{
+			MethodName: "LibraryUpgradePreview",
+			Handler:    _ArduinoCoreService_LibraryUpgradePreview_Handler,
+		},
 		{
 			MethodName: "LibraryResolveDependencies",
 			Handler:    _ArduinoCoreService_LibraryResolveDependencies_Handler,
 		},
+		{
+			MethodName: "LibraryNew",
+			Handler:    _ArduinoCoreService_LibraryNew_Handler,
+		},
 		{
 			MethodName: "LibrarySearch",
 			Handler:    _ArduinoCoreService_LibrarySearch_Handler,
@@ -2434,6 +3931,10 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LibraryList",
 			Handler:    _ArduinoCoreService_LibraryList_Handler,
 		},
+		{
+			MethodName: "RescanLibraries",
+			Handler:    _ArduinoCoreService_RescanLibraries_Handler,
+		},
 		{
 			MethodName: "EnumerateMonitorPortSettings",
 			Handler:    _ArduinoCoreService_EnumerateMonitorPortSettings_Handler,
@@ -2454,6 +3955,14 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CleanDownloadCacheDirectory",
 			Handler:    _ArduinoCoreService_CleanDownloadCacheDirectory_Handler,
 		},
+		{
+			MethodName: "Cleanup",
+			Handler:    _ArduinoCoreService_Cleanup_Handler,
+		},
+		{
+			MethodName: "DiskUsage",
+			Handler:    _ArduinoCoreService_DiskUsage_Handler,
+		},
 		{
 			MethodName: "SettingsGetAll",
 			Handler:    _ArduinoCoreService_SettingsGetAll_Handler,
@@ -2495,6 +4004,11 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ArduinoCoreService_UpdateLibrariesIndex_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "InstanceEvents",
+			Handler:       _ArduinoCoreService_InstanceEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "BoardListWatch",
 			Handler:       _ArduinoCoreService_BoardListWatch_Handler,
@@ -2540,6 +4054,51 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ArduinoCoreService_BurnBootloader_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReadMemory",
+			Handler:       _ArduinoCoreService_ReadMemory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteMemory",
+			Handler:       _ArduinoCoreService_WriteMemory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "EraseChip",
+			Handler:       _ArduinoCoreService_EraseChip_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FlashProtection",
+			Handler:       _ArduinoCoreService_FlashProtection_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BoardRecover",
+			Handler:       _ArduinoCoreService_BoardRecover_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BoardReset",
+			Handler:       _ArduinoCoreService_BoardReset_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BoardCertificates",
+			Handler:       _ArduinoCoreService_BoardCertificates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BoardProvision",
+			Handler:       _ArduinoCoreService_BoardProvision_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BoardPower",
+			Handler:       _ArduinoCoreService_BoardPower_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "LibraryDownload",
 			Handler:       _ArduinoCoreService_LibraryDownload_Handler,
@@ -2575,6 +4134,11 @@ var ArduinoCoreService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ArduinoCoreService_LibraryUpgradeAll_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "LibraryDiff",
+			Handler:       _ArduinoCoreService_LibraryDiff_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Monitor",
 			Handler:       _ArduinoCoreService_Monitor_Handler,
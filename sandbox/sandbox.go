@@ -0,0 +1,337 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+// Package sandbox generates self-contained Arduino CLI data directories
+// populated with fake platforms and libraries. The generated platforms
+// use harmless recipes (mostly based on "echo") in place of a real
+// toolchain, so compile and upload flows can be exercised hermetically,
+// without network access and without installing any core.
+package sandbox
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arduino/go-paths-helper"
+)
+
+// Sandbox is a set of directories that can be used as data, user and
+// downloads directories for the Arduino CLI.
+type Sandbox struct {
+	Root         *paths.Path
+	DataDir      *paths.Path
+	UserDir      *paths.Path
+	DownloadsDir *paths.Path
+}
+
+// New creates (or reuses) a sandbox in the given root directory. Empty
+// package and library indexes are created in the data directory, if not
+// already present, so the CLI will not try to download them.
+func New(root *paths.Path) (*Sandbox, error) {
+	root, err := root.Abs()
+	if err != nil {
+		return nil, err
+	}
+	s := &Sandbox{
+		Root:         root,
+		DataDir:      root.Join("data"),
+		UserDir:      root.Join("user"),
+		DownloadsDir: root.Join("data", "staging"),
+	}
+	for _, dir := range []*paths.Path{s.DataDir, s.UserDir, s.DownloadsDir} {
+		if err := dir.MkdirAll(); err != nil {
+			return nil, err
+		}
+	}
+	indexes := map[string]string{
+		"package_index.json": `{"packages":[]}`,
+		"library_index.json": `{"libraries":[]}`,
+	}
+	for name, content := range indexes {
+		index := s.DataDir.Join(name)
+		if index.Exist() {
+			continue
+		}
+		if err := index.WriteFile([]byte(content + "\n")); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// ConfigFile returns the path of the configuration file of the sandbox.
+func (s *Sandbox) ConfigFile() *paths.Path {
+	return s.Root.Join("arduino-cli.yaml")
+}
+
+// WriteConfig writes a configuration file pointing the Arduino CLI to the
+// sandbox directories. The path of the configuration file is returned.
+func (s *Sandbox) WriteConfig() (*paths.Path, error) {
+	config := fmt.Sprintf("directories:\n"+
+		"  data: %q\n"+
+		"  user: %q\n"+
+		"  downloads: %q\n"+
+		"updater:\n"+
+		"  enable_notification: false\n",
+		s.DataDir, s.UserDir, s.DownloadsDir)
+	configFile := s.ConfigFile()
+	if err := configFile.WriteFile([]byte(config)); err != nil {
+		return nil, err
+	}
+	return configFile, nil
+}
+
+// DefaultPlatform is the platform generated by Populate.
+var DefaultPlatform = FakePlatform{
+	Vendor:       "sandbox",
+	Architecture: "fake",
+	Name:         "Sandbox fake platform",
+	Version:      "1.0.0",
+	Boards: []Board{
+		{ID: "fake", Name: "Fake board"},
+		{ID: "fakeusb", Name: "Fake USB board", VID: "0x2341", PID: "0xfa4e"},
+	},
+}
+
+// DefaultLibrary is the library generated by Populate.
+var DefaultLibrary = FakeLibrary{
+	Name:    "FakeLibrary",
+	Version: "1.0.0",
+}
+
+// Populate adds DefaultPlatform and DefaultLibrary to the sandbox, unless
+// they are already present.
+func (s *Sandbox) Populate() error {
+	if !s.UserDir.Join("hardware", DefaultPlatform.Vendor, DefaultPlatform.Architecture).Exist() {
+		if _, err := s.AddPlatform(DefaultPlatform); err != nil {
+			return err
+		}
+	}
+	if !s.UserDir.Join("libraries", libraryDirName(DefaultLibrary.Name)).Exist() {
+		if _, err := s.AddLibrary(DefaultLibrary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Board is a board of a FakePlatform.
+type Board struct {
+	// ID is the board identifier used in the FQBN.
+	ID string
+	// Name is the human readable name of the board.
+	Name string
+	// VID and PID, if set, are used to identify the board on serial ports.
+	VID, PID string
+}
+
+// FakePlatform describes a platform to be generated in a Sandbox.
+type FakePlatform struct {
+	Vendor       string
+	Architecture string
+	Name         string
+	Version      string
+	Boards       []Board
+}
+
+// FQBN returns the FQBN of the given board of the platform.
+func (p *FakePlatform) FQBN(boardID string) string {
+	return p.Vendor + ":" + p.Architecture + ":" + boardID
+}
+
+// AddPlatform generates the given platform in the hardware folder of the
+// sandbox user directory. The platform directory is returned.
+func (s *Sandbox) AddPlatform(p FakePlatform) (*paths.Path, error) {
+	if p.Vendor == "" || p.Architecture == "" {
+		return nil, fmt.Errorf("vendor and architecture are required")
+	}
+	if p.Name == "" {
+		p.Name = "Fake " + p.Architecture + " platform"
+	}
+	if p.Version == "" {
+		p.Version = "1.0.0"
+	}
+	if len(p.Boards) == 0 {
+		p.Boards = []Board{{ID: "fake", Name: "Fake board"}}
+	}
+
+	platformDir := s.UserDir.Join("hardware", p.Vendor, p.Architecture)
+	if err := platformDir.Join("cores", "fake").MkdirAll(); err != nil {
+		return nil, err
+	}
+	if err := platformDir.Join("cores", "fake", "Arduino.h").WriteFile([]byte(fakeArduinoH)); err != nil {
+		return nil, err
+	}
+
+	var boards strings.Builder
+	for _, b := range p.Boards {
+		name := b.Name
+		if name == "" {
+			name = b.ID
+		}
+		fmt.Fprintf(&boards, "%s.name=%s\n", b.ID, name)
+		if b.VID != "" && b.PID != "" {
+			fmt.Fprintf(&boards, "%s.vid.0=%s\n", b.ID, b.VID)
+			fmt.Fprintf(&boards, "%s.pid.0=%s\n", b.ID, b.PID)
+			fmt.Fprintf(&boards, "%s.upload_port.0.vid=%s\n", b.ID, b.VID)
+			fmt.Fprintf(&boards, "%s.upload_port.0.pid=%s\n", b.ID, b.PID)
+		}
+		fmt.Fprintf(&boards, "%s.build.core=fake\n", b.ID)
+		fmt.Fprintf(&boards, "%s.build.board=%s\n", b.ID, strings.ToUpper(p.Architecture+"_"+b.ID))
+		fmt.Fprintf(&boards, "%s.upload.protocol=serial\n", b.ID)
+		fmt.Fprintf(&boards, "%s.upload.tool=fake\n", b.ID)
+		fmt.Fprintf(&boards, "%s.upload.tool.default=fake\n", b.ID)
+		fmt.Fprintf(&boards, "%s.bootloader.tool=fake\n", b.ID)
+		fmt.Fprintf(&boards, "%s.bootloader.tool.default=fake\n", b.ID)
+		fmt.Fprintf(&boards, "%s.upload.maximum_size=32768\n", b.ID)
+		fmt.Fprintf(&boards, "%s.upload.maximum_data_size=2048\n", b.ID)
+		fmt.Fprintf(&boards, "\n")
+	}
+	if err := platformDir.Join("boards.txt").WriteFile([]byte(boards.String())); err != nil {
+		return nil, err
+	}
+
+	programmers := "fake.name=Fake programmer\nfake.program.tool=fake\nfake.program.tool.default=fake\n"
+	if err := platformDir.Join("programmers.txt").WriteFile([]byte(programmers)); err != nil {
+		return nil, err
+	}
+
+	platform := fmt.Sprintf(fakePlatformTxt, p.Name, p.Version)
+	if err := platformDir.Join("platform.txt").WriteFile([]byte(platform)); err != nil {
+		return nil, err
+	}
+	return platformDir, nil
+}
+
+// FakeLibrary describes a library to be generated in a Sandbox.
+type FakeLibrary struct {
+	Name          string
+	Version       string
+	Architectures []string
+	// Depends is a list of library names this library depends on.
+	Depends []string
+}
+
+// AddLibrary generates the given library in the libraries folder of the
+// sandbox user directory. The library directory is returned.
+func (s *Sandbox) AddLibrary(l FakeLibrary) (*paths.Path, error) {
+	if l.Name == "" {
+		return nil, fmt.Errorf("library name is required")
+	}
+	if l.Version == "" {
+		l.Version = "1.0.0"
+	}
+	if len(l.Architectures) == 0 {
+		l.Architectures = []string{"*"}
+	}
+	dirName := libraryDirName(l.Name)
+	libDir := s.UserDir.Join("libraries", dirName)
+	if err := libDir.Join("src").MkdirAll(); err != nil {
+		return nil, err
+	}
+
+	depends := append([]string{}, l.Depends...)
+	sort.Strings(depends)
+	props := fmt.Sprintf("name=%s\n"+
+		"version=%s\n"+
+		"author=Arduino CLI sandbox\n"+
+		"maintainer=Arduino CLI sandbox\n"+
+		"sentence=A fake library.\n"+
+		"paragraph=\n"+
+		"category=Other\n"+
+		"url=https://github.com/arduino/arduino-cli\n"+
+		"architectures=%s\n"+
+		"depends=%s\n",
+		l.Name, l.Version, strings.Join(l.Architectures, ","), strings.Join(depends, ","))
+	if err := libDir.Join("library.properties").WriteFile([]byte(props)); err != nil {
+		return nil, err
+	}
+
+	guard := strings.ToUpper(dirName) + "_H"
+	header := fmt.Sprintf("#ifndef %[1]s\n#define %[1]s\n\nvoid %[2]s_init();\n\n#endif\n", guard, dirName)
+	if err := libDir.Join("src", dirName+".h").WriteFile([]byte(header)); err != nil {
+		return nil, err
+	}
+	source := fmt.Sprintf("#include \"%[1]s.h\"\n\nvoid %[1]s_init() {}\n", dirName)
+	if err := libDir.Join("src", dirName+".cpp").WriteFile([]byte(source)); err != nil {
+		return nil, err
+	}
+	return libDir, nil
+}
+
+func libraryDirName(name string) string {
+	return strings.NewReplacer(" ", "_", ".", "_").Replace(name)
+}
+
+// AddSketch generates a minimal sketch with the given name in the sandbox
+// user directory. The sketch directory is returned.
+func (s *Sandbox) AddSketch(name string) (*paths.Path, error) {
+	sketchDir := s.UserDir.Join(name)
+	if err := sketchDir.MkdirAll(); err != nil {
+		return nil, err
+	}
+	source := "void setup() {\n}\n\nvoid loop() {\n}\n"
+	if err := sketchDir.Join(name + ".ino").WriteFile([]byte(source)); err != nil {
+		return nil, err
+	}
+	return sketchDir, nil
+}
+
+const fakeArduinoH = `#ifndef Arduino_h
+#define Arduino_h
+
+void setup();
+void loop();
+
+#endif
+`
+
+// fakePlatformTxt is the platform.txt of a fake platform: the toolchain is
+// replaced by "echo" commands, except for the preprocessor that must
+// produce an output file to let the sketch preprocessing succeed.
+const fakePlatformTxt = `name=%s
+version=%s
+
+compiler.path=
+compiler.cpp.flags=
+
+recipe.preproc.macros=cp "{source_file}" "{preprocessed_file_path}"
+recipe.preproc.macros.windows=cmd /c copy /y "{source_file}" "{preprocessed_file_path}"
+tools.ctags.pattern=echo
+
+recipe.c.o.pattern=echo compile "{source_file}" "{object_file}"
+recipe.cpp.o.pattern=echo compile "{source_file}" "{object_file}"
+recipe.S.o.pattern=echo compile "{source_file}" "{object_file}"
+recipe.ar.pattern=echo archive "{archive_file_path}" "{object_file}"
+recipe.c.combine.pattern=echo link "{build.path}/{build.project_name}.elf" {object_files}
+recipe.objcopy.bin.pattern=echo objcopy "{build.path}/{build.project_name}.bin"
+recipe.size.pattern=echo "text 1024 data 128"
+recipe.size.regex=text\s+([0-9]+)
+recipe.size.regex.data=data\s+([0-9]+)
+
+tools.fake.upload.params.verbose=-v
+tools.fake.upload.params.quiet=-q
+tools.fake.upload.pattern=echo upload {upload.verbose} "{build.path}/{build.project_name}.bin" "{upload.port.address}"
+tools.fake.program.params.verbose=-v
+tools.fake.program.params.quiet=-q
+tools.fake.program.pattern=echo program {program.verbose} "{build.path}/{build.project_name}.bin"
+tools.fake.erase.params.verbose=-v
+tools.fake.erase.params.quiet=-q
+tools.fake.erase.pattern=echo erase {erase.verbose}
+tools.fake.bootloader.params.verbose=-v
+tools.fake.bootloader.params.quiet=-q
+tools.fake.bootloader.pattern=echo bootloader {bootloader.verbose}
+`
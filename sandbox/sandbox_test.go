@@ -0,0 +1,75 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2024 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/arduino/arduino-cli/internal/arduino/cores/packagemanager"
+	"github.com/arduino/arduino-cli/internal/arduino/libraries"
+	"github.com/arduino/go-paths-helper"
+	properties "github.com/arduino/go-properties-orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandbox(t *testing.T) {
+	sb, err := New(paths.New(t.TempDir()))
+	require.NoError(t, err)
+	require.True(t, sb.DataDir.Join("package_index.json").Exist())
+	require.True(t, sb.DataDir.Join("library_index.json").Exist())
+	require.NoError(t, sb.Populate())
+	// Populating twice must not fail
+	require.NoError(t, sb.Populate())
+
+	_, err = sb.AddPlatform(FakePlatform{})
+	require.Error(t, err)
+
+	hardwareDir := sb.UserDir.Join("hardware")
+	pmb := packagemanager.NewBuilder(hardwareDir, hardwareDir, sb.DataDir, sb.DownloadsDir, "test")
+	require.Empty(t, pmb.LoadHardwareFromDirectory(hardwareDir))
+	pm := pmb.Build()
+	pme, release := pm.NewExplorer()
+	defer release()
+
+	board, err := pme.FindBoardWithFQBN(DefaultPlatform.FQBN("fakeusb"))
+	require.NoError(t, err)
+	require.Equal(t, "Fake USB board", board.Name())
+	idProps := properties.NewFromHashmap(map[string]string{"vid": "0x2341", "pid": "0xfa4e"})
+	require.True(t, board.IsBoardMatchingIDProperties(idProps))
+	require.NotEmpty(t, board.PlatformRelease.Properties.Get("recipe.cpp.o.pattern"))
+	require.NotEmpty(t, board.PlatformRelease.Properties.Get("tools.fake.upload.pattern"))
+	require.Contains(t, board.PlatformRelease.Programmers, "fake")
+
+	libDir, err := sb.AddLibrary(FakeLibrary{Name: "My Lib", Version: "0.1.0", Architectures: []string{"fake"}, Depends: []string{"FakeLibrary"}})
+	require.NoError(t, err)
+	lib, err := libraries.Load(libDir, libraries.User)
+	require.NoError(t, err)
+	require.Equal(t, "My Lib", lib.Name)
+	require.Equal(t, "0.1.0", lib.Version.String())
+	require.Equal(t, []string{"fake"}, lib.Architectures)
+	require.Equal(t, "FakeLibrary", lib.Properties.Get("depends"))
+	require.True(t, libDir.Join("src", "My_Lib.h").Exist())
+
+	sketchDir, err := sb.AddSketch("Blink")
+	require.NoError(t, err)
+	require.True(t, sketchDir.Join("Blink.ino").Exist())
+
+	configFile, err := sb.WriteConfig()
+	require.NoError(t, err)
+	config, err := configFile.ReadFile()
+	require.NoError(t, err)
+	require.Contains(t, string(config), sb.DataDir.String())
+}